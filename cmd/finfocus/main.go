@@ -50,9 +50,15 @@ func run() error {
 	startupLogger = logging.ComponentLogger(startupLogger, "main")
 
 	root := cli.NewRootCmd(version.GetVersion())
-	if err := root.Execute(); err != nil {
-		// Print user-friendly error to stderr for immediate visibility
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+	if cmd, err := root.ExecuteC(); err != nil {
+		// Print the error to stderr for immediate visibility, as a JSON error
+		// report when the command was asked for JSON output
+		exitCode := extractBudgetExitCode(err)
+		if !cli.WriteErrorReport(os.Stderr, cmd, err, exitCode) {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		// Keep the failure for 'finfocus debug bundle'
+		cli.RecordFailure(cmd, os.Args[1:], err, exitCode)
 		// Also log for debugging purposes
 		startupLogger.Error().Err(err).Msg("command execution failed")
 		return err
@@ -60,8 +66,8 @@ func run() error {
 	return nil
 }
 
-// extractBudgetExitCode returns the custom exit code from a BudgetExitError,
-// or 1 for non-budget errors, or 0 for nil errors.
+// extractBudgetExitCode returns the custom exit code from a BudgetExitError
+// or PolicyViolationError, or 1 for other errors, or 0 for nil errors.
 // Invariant: when err is non-nil the returned code is always >= 1.
 func extractBudgetExitCode(err error) int {
 	if err == nil {
@@ -71,6 +77,10 @@ func extractBudgetExitCode(err error) int {
 	if errors.As(err, &budgetErr) && budgetErr.ExitCode != 0 {
 		return budgetErr.ExitCode
 	}
+	var policyErr *cli.PolicyViolationError
+	if errors.As(err, &policyErr) && policyErr.ExitCode > 0 {
+		return policyErr.ExitCode
+	}
 	return 1
 }
 
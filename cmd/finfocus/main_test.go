@@ -74,6 +74,12 @@ func TestExtractBudgetExitCode(t *testing.T) {
 			wantExitCode: 3,
 			wantIsBudget: true,
 		},
+		{
+			name:         "PolicyViolationError uses its exit code",
+			err:          &cli.PolicyViolationError{ExitCode: 2, Violations: 3},
+			wantExitCode: 2,
+			wantIsBudget: false,
+		},
 		{
 			name:         "non-BudgetExitError falls through",
 			err:          errors.New("generic error"),
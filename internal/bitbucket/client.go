@@ -0,0 +1,235 @@
+// Package bitbucket posts finfocus reports to pull requests through the
+// Bitbucket Cloud REST API.
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/restapi"
+)
+
+// Bitbucket API settings.
+const (
+	// DefaultAPIURL is the Bitbucket Cloud REST API endpoint.
+	DefaultAPIURL = "https://api.bitbucket.org/2.0"
+	// DefaultTimeout bounds a single Bitbucket API request.
+	DefaultTimeout = 30 * time.Second
+
+	// EnvToken is the environment variable holding the Bitbucket access token.
+	EnvToken = "BITBUCKET_TOKEN" //nolint:gosec // Environment variable name, not a credential.
+	// EnvWorkspace names the workspace to post to (set by Bitbucket Pipelines).
+	EnvWorkspace = "BITBUCKET_WORKSPACE"
+	// EnvRepoSlug names the repository to post to (set by Bitbucket Pipelines).
+	EnvRepoSlug = "BITBUCKET_REPO_SLUG"
+	// EnvAPIURL overrides DefaultAPIURL.
+	EnvAPIURL = "BITBUCKET_API_URL"
+
+	// commentsPerPage is the page size used when searching for an existing comment.
+	commentsPerPage = 100
+)
+
+var (
+	// ErrNoToken indicates no Bitbucket token is configured.
+	ErrNoToken = errors.New("no Bitbucket token")
+
+	// ErrNoRepository indicates no Bitbucket workspace or repository is configured.
+	ErrNoRepository = errors.New("no Bitbucket repository")
+
+	// ErrRequestFailed indicates a Bitbucket API request failed.
+	ErrRequestFailed = errors.New("bitbucket request failed")
+)
+
+// Repository identifies a Bitbucket repository.
+type Repository struct {
+	Workspace string
+	Slug      string
+}
+
+// String returns the repository in workspace/slug form.
+func (r Repository) String() string {
+	return r.Workspace + "/" + r.Slug
+}
+
+// RepositoryFromEnv returns the repository named by BITBUCKET_WORKSPACE and
+// BITBUCKET_REPO_SLUG.
+func RepositoryFromEnv() (Repository, error) {
+	repo := Repository{
+		Workspace: strings.TrimSpace(os.Getenv(EnvWorkspace)),
+		Slug:      strings.TrimSpace(os.Getenv(EnvRepoSlug)),
+	}
+	if repo.Workspace == "" || repo.Slug == "" {
+		return Repository{}, fmt.Errorf("%w; set %s and %s", ErrNoRepository, EnvWorkspace, EnvRepoSlug)
+	}
+	return repo, nil
+}
+
+// ResolveToken returns the Bitbucket token from BITBUCKET_TOKEN, or "" when unset.
+func ResolveToken() string {
+	return strings.TrimSpace(os.Getenv(EnvToken))
+}
+
+// Comment is a comment on a pull request.
+type Comment struct {
+	ID      int64 `json:"id"`
+	Content struct {
+		Raw string `json:"raw"`
+	} `json:"content"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	User account `json:"user"`
+}
+
+// account identifies a Bitbucket user.
+type account struct {
+	UUID string `json:"uuid"`
+}
+
+// commentPage is one page of a paginated comment listing.
+type commentPage struct {
+	Values []Comment `json:"values"`
+	Next   string    `json:"next"`
+}
+
+// Client calls the Bitbucket Cloud REST API.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	token      string
+	user       restapi.User
+}
+
+// NewClient returns a Client that authenticates with token. An empty baseURL
+// selects DefaultAPIURL.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// UpsertPullRequestComment keeps a single "sticky" comment on pull request id:
+// the first comment of the token's user whose body contains marker is replaced
+// with body, and a new comment is created when there is none. body should contain marker so
+// the next call finds it. It reports whether a comment was created. Errors
+// wrap ErrNoToken or ErrRequestFailed.
+func (c *Client) UpsertPullRequestComment(
+	ctx context.Context,
+	repo Repository,
+	id int,
+	marker, body string,
+) (*Comment, bool, error) {
+	if c.token == "" {
+		return nil, false, fmt.Errorf("%w; set %s", ErrNoToken, EnvToken)
+	}
+
+	thread := commentThread{
+		c:    c,
+		repo: repo,
+		id:   id,
+		commentsURL: fmt.Sprintf("%s/repositories/%s/%s/pullrequests/%d/comments", c.BaseURL,
+			url.PathEscape(repo.Workspace), url.PathEscape(repo.Slug), id),
+	}
+	return restapi.UpsertComment[Comment](ctx, thread, marker, body)
+}
+
+// commentThread is the comment thread of a pull request.
+type commentThread struct {
+	c           *Client
+	repo        Repository
+	id          int
+	commentsURL string
+}
+
+func (t commentThread) FirstPage() string {
+	return fmt.Sprintf("%s?pagelen=%d", t.commentsURL, commentsPerPage)
+}
+
+// Page follows the "next" links of the paginated listing.
+func (t commentThread) Page(ctx context.Context, endpoint string) ([]Comment, string, error) {
+	var page commentPage
+	if err := t.c.api().Do(ctx, http.MethodGet, endpoint, nil, &page); err != nil {
+		return nil, "", err
+	}
+	return page.Values, page.Next, nil
+}
+
+func (commentThread) Body(comment *Comment) string {
+	return comment.Content.Raw
+}
+
+func (commentThread) Author(comment *Comment) string {
+	return comment.User.UUID
+}
+
+func (t commentThread) CurrentUser(ctx context.Context) (string, error) {
+	return t.c.user.Get(ctx, t.c.currentUser)
+}
+
+func (t commentThread) Create(ctx context.Context, body string) (*Comment, error) {
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "bitbucket").Str("operation", "create_comment").
+		Str("repository", t.repo.String()).Int("id", t.id).
+		Msg("creating pull request comment")
+	var comment Comment
+	if err := t.c.api().Do(ctx, http.MethodPost, t.commentsURL, commentPayload(body), &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (t commentThread) Update(ctx context.Context, existing *Comment, body string) (*Comment, error) {
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "bitbucket").Str("operation", "update_comment").
+		Str("repository", t.repo.String()).Int("id", t.id).Int64("comment_id", existing.ID).
+		Msg("updating pull request comment")
+	endpoint := fmt.Sprintf("%s/%d", t.commentsURL, existing.ID)
+	var comment Comment
+	if err := t.c.api().Do(ctx, http.MethodPut, endpoint, commentPayload(body), &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// commentPayload returns the request body setting the text of a comment.
+func commentPayload(body string) map[string]any {
+	return map[string]any{"content": map[string]string{"raw": body}}
+}
+
+// currentUser returns the UUID of the token's user.
+func (c *Client) currentUser(ctx context.Context) (string, error) {
+	var user account
+	if err := c.api().Do(ctx, http.MethodGet, c.BaseURL+"/user", nil, &user); err != nil {
+		return "", err
+	}
+	if user.UUID == "" {
+		return "", fmt.Errorf("%w: GET /user: no uuid in response", ErrRequestFailed)
+	}
+	return user.UUID, nil
+}
+
+// api returns the JSON client of the Bitbucket API.
+func (c *Client) api() *restapi.Client {
+	return &restapi.Client{
+		HTTPClient: c.HTTPClient,
+		API:        "Bitbucket",
+		Header: http.Header{
+			"Accept":        {"application/json"},
+			"Authorization": {"Bearer " + c.token},
+		},
+		ErrFailed: ErrRequestFailed,
+		AuthHint:  "check that " + EnvToken + " can write pull request comments",
+	}
+}
@@ -0,0 +1,115 @@
+package bitbucket
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMarker = "[//]: # (finfocus)"
+	testUser   = "{bot}"
+)
+
+func newComment(id int64, raw string) Comment {
+	var c Comment
+	c.ID = id
+	c.Content.Raw = raw
+	c.User.UUID = testUser
+	return c
+}
+
+// serveUser answers the current user lookup as testUser.
+func serveUser(w http.ResponseWriter, r *http.Request) bool {
+	if r.URL.Path != "/user" {
+		return false
+	}
+	_ = json.NewEncoder(w).Encode(account{UUID: testUser})
+	return true
+}
+
+func TestRepositoryFromEnv(t *testing.T) {
+	t.Setenv(EnvWorkspace, "acme")
+	t.Setenv(EnvRepoSlug, "")
+	_, err := RepositoryFromEnv()
+	require.ErrorIs(t, err, ErrNoRepository)
+
+	t.Setenv(EnvRepoSlug, "infra")
+	repo, err := RepositoryFromEnv()
+	require.NoError(t, err)
+	assert.Equal(t, "acme/infra", repo.String())
+}
+
+func TestClient_UpsertPullRequestComment(t *testing.T) {
+	// The listing is split over two pages; the marked comment of the token's
+	// user is on the second, after one of another user quoting the marker.
+	var server *httptest.Server
+	var updated string
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		foreign := newComment(1, "quoting "+testMarker)
+		foreign.User.UUID = "{mallory}"
+		switch {
+		case serveUser(w, r):
+		case r.Method == http.MethodGet && r.URL.Query().Get("page") == "":
+			_ = json.NewEncoder(w).Encode(commentPage{
+				Values: []Comment{foreign},
+				Next:   server.URL + r.URL.Path + "?page=2",
+			})
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(commentPage{Values: []Comment{newComment(5, testMarker+"\nold")}})
+		case r.Method == http.MethodPut:
+			assert.Equal(t, "/repositories/acme/infra/pullrequests/7/comments/5", r.URL.Path)
+			var payload struct {
+				Content struct {
+					Raw string `json:"raw"`
+				} `json:"content"`
+			}
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			updated = payload.Content.Raw
+			_ = json.NewEncoder(w).Encode(newComment(5, updated))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	repo := Repository{Workspace: "acme", Slug: "infra"}
+	comment, created, err := NewClient(server.URL, "secret").
+		UpsertPullRequestComment(context.Background(), repo, 7, testMarker, testMarker+"\nnew")
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, int64(5), comment.ID)
+	assert.Equal(t, testMarker+"\nnew", updated)
+}
+
+func TestClient_UpsertPullRequestComment_Create(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if serveUser(w, r) {
+			return
+		}
+		if r.Method == http.MethodGet {
+			_ = json.NewEncoder(w).Encode(commentPage{})
+			return
+		}
+		assert.Equal(t, http.MethodPost, r.Method)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(newComment(9, "created"))
+	}))
+	t.Cleanup(server.Close)
+
+	repo := Repository{Workspace: "acme", Slug: "infra"}
+	comment, created, err := NewClient(server.URL, "secret").
+		UpsertPullRequestComment(context.Background(), repo, 7, testMarker, "body")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(9), comment.ID)
+
+	_, _, err = NewClient(server.URL, "").UpsertPullRequestComment(context.Background(), repo, 7, testMarker, "body")
+	require.ErrorIs(t, err, ErrNoToken)
+}
@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// baselineSummary is one saved baseline in 'baseline list'.
+type baselineSummary struct {
+	Name      string    `json:"name"`
+	SavedAt   time.Time `json:"saved_at"`
+	Resources int       `json:"resources"`
+	Monthly   float64   `json:"monthly"`
+	Currency  string    `json:"currency"`
+	// Unreadable is true when the saved results can no longer be parsed.
+	Unreadable bool `json:"unreadable,omitempty"`
+}
+
+// newBaselineCmd creates the baseline command group.
+func newBaselineCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "baseline", Short: "Saved cost result baselines"}
+	cmd.AddCommand(NewBaselineSaveCmd(), NewBaselineListCmd(), NewBaselineDeleteCmd())
+	return cmd
+}
+
+// NewBaselineSaveCmd creates the "save" subcommand, which saves cost results
+// under a name for 'cost projected --baseline'.
+func NewBaselineSaveCmd() *cobra.Command {
+	var file string
+
+	cmd := &cobra.Command{
+		Use:   "save <name>",
+		Short: "Save cost results as a named baseline",
+		Long: `Save the results of 'cost projected --output json' (or ndjson) under a
+name, so later runs can be compared with them by name with
+'cost projected --baseline <name>'. The results are read from --file, or from
+stdin when --file is omitted or "-". Saving under an existing name replaces
+that baseline.
+
+Baselines are kept in the baselines directory of the finfocus home
+(normally ~/.finfocus/baselines).`,
+		Example: `  # Save the costs of the main branch
+  finfocus cost projected --pulumi-json plan.json --output json | finfocus baseline save main
+
+  # Save a result written earlier
+  finfocus baseline save release-1.4 --file costs.json
+
+  # Gate a pull request on the saved baseline
+  finfocus cost projected --pulumi-json plan.json --baseline main --max-increase 10%`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeBaselineSave(cmd, config.NewBaselineStore(""), args[0], file)
+		},
+	}
+
+	cmd.Flags().StringVar(&file, "file", "", `Path to the cost results to save ("-" or omitted for stdin)`)
+
+	return cmd
+}
+
+// executeBaselineSave reads and validates the cost results and saves them
+// under name as a JSON array.
+func executeBaselineSave(cmd *cobra.Command, store *config.BaselineStore, name, file string) error {
+	if err := config.ValidateBaselineName(name); err != nil {
+		return err
+	}
+
+	var (
+		data []byte
+		err  error
+	)
+	if file == "" || file == "-" {
+		data, err = io.ReadAll(cmd.InOrStdin())
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return fmt.Errorf("reading cost results: %w", err)
+	}
+	results, err := engine.ParseCostResults(data)
+	if err != nil {
+		return fmt.Errorf("reading cost results: %w", err)
+	}
+
+	normalized, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshaling cost results: %w", err)
+	}
+	if err = store.Save(name, normalized); err != nil {
+		return err
+	}
+
+	summary := summarizeBaseline(name, results)
+	cmd.Printf("Saved baseline %s: %d resource(s), %.2f %s/mo\n",
+		name, summary.Resources, summary.Monthly, summary.Currency)
+	return nil
+}
+
+// NewBaselineListCmd creates the "list" subcommand, which lists the saved
+// baselines.
+func NewBaselineListCmd() *cobra.Command {
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "list",
+		Short: "List saved baselines",
+		Long: `List the baselines saved with 'baseline save', with when they were saved,
+how many resources they hold, and their total monthly cost.`,
+		Example: `  # List saved baselines
+  finfocus baseline list
+
+  # List saved baselines as JSON
+  finfocus baseline list --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeBaselineList(cmd, config.NewBaselineStore(""), output)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", outputFormatTable, "Output format: table or json")
+
+	return cmd
+}
+
+// executeBaselineList renders the saved baselines. Baselines that can no
+// longer be read are listed without resources or cost.
+func executeBaselineList(cmd *cobra.Command, store *config.BaselineStore, output string) error {
+	if output != outputFormatTable && output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", output)
+	}
+	baselines, err := store.List()
+	if err != nil {
+		return err
+	}
+
+	summaries := make([]baselineSummary, 0, len(baselines))
+	for _, baseline := range baselines {
+		summary := baselineSummary{Name: baseline.Name, Unreadable: true}
+		if data, loadErr := store.Load(baseline.Name); loadErr == nil {
+			if results, parseErr := engine.ParseCostResults(data); parseErr == nil {
+				summary = summarizeBaseline(baseline.Name, results)
+			}
+		}
+		summary.SavedAt = baseline.SavedAt
+		summaries = append(summaries, summary)
+	}
+
+	if output == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(summaries)
+	}
+	if len(summaries) == 0 {
+		cmd.Println("No baselines saved. Save one with 'finfocus baseline save <name>'.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "NAME\tSAVED\tRESOURCES\tMONTHLY")
+	for _, summary := range summaries {
+		if summary.Unreadable {
+			fmt.Fprintf(tw, "%s\t%s\t-\t-\n", summary.Name, summary.SavedAt.Format(time.DateTime))
+			continue
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%d\t%.2f %s\n", summary.Name, summary.SavedAt.Format(time.DateTime),
+			summary.Resources, summary.Monthly, summary.Currency)
+	}
+	return tw.Flush()
+}
+
+// NewBaselineDeleteCmd creates the "delete" subcommand, which deletes a saved
+// baseline.
+func NewBaselineDeleteCmd() *cobra.Command {
+	var force bool
+
+	cmd := &cobra.Command{
+		Use:   "delete <name>",
+		Short: "Delete a saved baseline",
+		Long:  `Delete a baseline saved with 'baseline save'.`,
+		Example: `  # Delete a saved baseline
+  finfocus baseline delete release-1.4
+
+  # Skip confirmation prompt
+  finfocus baseline delete release-1.4 --force`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeBaselineDelete(cmd, config.NewBaselineStore(""), args[0], force)
+		},
+	}
+
+	cmd.Flags().BoolVarP(&force, "force", "f", false, "Skip confirmation prompt")
+
+	return cmd
+}
+
+// executeBaselineDelete deletes the baseline saved under name.
+func executeBaselineDelete(cmd *cobra.Command, store *config.BaselineStore, name string, force bool) error {
+	if err := config.ValidateBaselineName(name); err != nil {
+		return err
+	}
+	if !store.Exists(name) {
+		return fmt.Errorf("%w: %s", config.ErrBaselineNotFound, name)
+	}
+	if !force && !confirmPrompt(cmd, fmt.Sprintf("Delete baseline %s? [y/N]: ", name)) {
+		cmd.PrintErrln("Delete cancelled.")
+		return nil
+	}
+	if err := store.Delete(name); err != nil {
+		return err
+	}
+	cmd.Printf("Deleted baseline %s.\n", name)
+	return nil
+}
+
+// summarizeBaseline counts the resources of a baseline and totals the monthly
+// cost of those without errors, as --baseline compares them.
+func summarizeBaseline(name string, results []engine.CostResult) baselineSummary {
+	summary := baselineSummary{Name: name, Resources: len(results)}
+	summary.Currency, _ = extractCurrencyFromResults(results)
+	for _, r := range results {
+		if r.Error == nil {
+			summary.Monthly += r.Monthly
+		}
+	}
+	return summary
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestBaselineSaveListDelete(t *testing.T) {
+	store := config.NewBaselineStore(filepath.Join(t.TempDir(), "baselines"))
+	var results bytes.Buffer
+	require.NoError(t, engine.RenderResults(&results, engine.OutputNDJSON, []engine.CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 100},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 130.5},
+	}))
+
+	cmd := NewBaselineSaveCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetIn(&results)
+	require.NoError(t, executeBaselineSave(cmd, store, "main", ""))
+	assert.Equal(t, "Saved baseline main: 2 resource(s), 230.50 USD/mo\n", out.String())
+
+	data, err := store.Load("main")
+	require.NoError(t, err)
+	saved, err := engine.ParseCostResults(data)
+	require.NoError(t, err)
+	assert.Len(t, saved, 2)
+
+	out.Reset()
+	require.NoError(t, executeBaselineList(cmd, store, outputFormatTable))
+	assert.Regexp(t, `NAME\s+SAVED\s+RESOURCES\s+MONTHLY\nmain\s+\S+ \S+\s+2\s+230\.50 USD\n`, out.String())
+
+	out.Reset()
+	require.NoError(t, executeBaselineList(cmd, store, outputFormatJSON))
+	var listed []baselineSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &listed))
+	require.Len(t, listed, 1)
+	assert.Equal(t, "main", listed[0].Name)
+	assert.InDelta(t, 230.5, listed[0].Monthly, 1e-9)
+
+	out.Reset()
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetErr(&bytes.Buffer{})
+	require.NoError(t, executeBaselineDelete(cmd, store, "main", false))
+	assert.True(t, store.Exists("main"), "declined")
+	require.NoError(t, executeBaselineDelete(cmd, store, "main", true))
+	assert.Equal(t, "Deleted baseline main.\n", out.String())
+	require.ErrorIs(t, executeBaselineDelete(cmd, store, "main", true), config.ErrBaselineNotFound)
+
+	out.Reset()
+	require.NoError(t, executeBaselineList(cmd, store, outputFormatTable))
+	assert.Contains(t, out.String(), "No baselines saved.")
+}
+
+func TestBaselineSave_Invalid(t *testing.T) {
+	store := config.NewBaselineStore(filepath.Join(t.TempDir(), "baselines"))
+	cmd := NewBaselineSaveCmd()
+
+	cmd.SetIn(strings.NewReader("RESOURCE  MONTHLY\n"))
+	require.ErrorContains(t, executeBaselineSave(cmd, store, "main", ""), "reading cost results")
+	require.ErrorContains(t, executeBaselineSave(cmd, store, "../main", ""), "invalid baseline name")
+	assert.False(t, store.Exists("main"))
+}
+
+func TestLoadBaselineResults_SavedName(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	require.NoError(t, config.NewBaselineStore("").Save("main",
+		[]byte(`[{"resourceId": "web", "currency": "USD", "monthly": 42}]`)))
+
+	results, err := loadBaselineResults("main")
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.InDelta(t, 42, results[0].Monthly, 1e-9)
+
+	_, err = loadBaselineResults("other")
+	require.ErrorContains(t, err, "reading baseline")
+}
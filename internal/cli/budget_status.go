@@ -0,0 +1,263 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// defaultBudgetRefresh is the default refresh interval of 'budget status --interactive'.
+const defaultBudgetRefresh = 5 * time.Minute
+
+// budgetStatusParams holds the parameters for the budget status command execution.
+type budgetStatusParams struct {
+	planPath    string
+	statePath   string
+	adapter     string
+	output      string
+	filter      []string
+	interactive bool
+	refresh     time.Duration
+	watch       time.Duration
+}
+
+// budgetStatusJSON is one budget scope in 'budget status --output json'.
+type budgetStatusJSON struct {
+	Scope              string  `json:"scope"`
+	Currency           string  `json:"currency"`
+	Amount             float64 `json:"amount"`
+	Spend              float64 `json:"spend"`
+	Percentage         float64 `json:"percentage"`
+	Forecast           float64 `json:"forecast,omitempty"`
+	ForecastPercentage float64 `json:"forecast_percentage,omitempty"`
+	Health             string  `json:"health"`
+}
+
+// newBudgetCmd creates the budget command group.
+func newBudgetCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "budget", Short: "Budget commands"}
+	cmd.AddCommand(NewBudgetStatusCmd())
+	return cmd
+}
+
+// NewBudgetStatusCmd creates the "status" subcommand, which evaluates the
+// configured budgets against projected monthly costs and shows a gauge per
+// budget scope, optionally as a live-refreshing interactive view.
+func NewBudgetStatusCmd() *cobra.Command {
+	var params budgetStatusParams
+
+	cmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show projected spend against each configured budget",
+		Long: `Evaluate the budgets configured under cost.budgets against the projected
+monthly costs of the stack and show a gauge per budget scope: global, provider,
+tag, type, and stack budgets.
+
+Gauges change color as spend crosses the warning (80%), critical (90%), and
+exceeded (100%) levels. Alert thresholds not yet reached are marked with ┊, and
+the forecast end-of-period spend with ◆ (» when beyond the budget).
+
+With --interactive the gauges stay on screen and are re-evaluated every
+--refresh; scopes whose level rose at the last refresh are marked with ▲.
+Scroll with up and down, refresh with r, and quit with q. --watch instead
+re-renders the output every interval with the values that changed since the
+previous refresh highlighted.
+
+Resources come from --pulumi-json or --pulumi-state. When both are omitted, the
+deployed state of the current Pulumi stack is used. Use --stack to pick a
+different stack.`,
+		Example: `  # Budget gauges for the current Pulumi stack
+  finfocus budget status
+
+  # Live budget view of a plan, refreshing every minute
+  finfocus budget status --pulumi-json plan.json --interactive --refresh 1m
+
+  # Re-check the budgets every 30 seconds during a load test
+  finfocus budget status --watch 30s
+
+  # Budget status as JSON
+  finfocus budget status --pulumi-state state.json --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeBudgetStatus(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().StringVar(&params.statePath, "pulumi-state", "", "Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().String("stack", "", "Pulumi stack for auto-detection (ignored with --pulumi-json/--pulumi-state)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
+		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	cmd.Flags().BoolVar(&params.interactive, "interactive", false, "Show live-refreshing budget gauges")
+	cmd.Flags().DurationVar(&params.refresh, "refresh", defaultBudgetRefresh,
+		"Refresh interval with --interactive (0 disables live refresh)")
+	cmd.Flags().DurationVar(&params.watch, "watch", 0,
+		"Re-evaluate at this interval and re-render, highlighting changed values (e.g. 30s)")
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "pulumi-state")
+	cmd.MarkFlagsMutuallyExclusive("interactive", "watch")
+
+	return cmd
+}
+
+// executeBudgetStatus evaluates the budgets and renders them, runs the
+// interactive budget view with --interactive, or re-renders them every
+// interval with --watch.
+func executeBudgetStatus(cmd *cobra.Command, params budgetStatusParams) error {
+	if params.output != outputFormatTable && params.output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", params.output)
+	}
+	if err := validateWatchInterval(params.watch); err != nil {
+		return err
+	}
+	if params.interactive {
+		if params.refresh != 0 && params.refresh < minDashboardRefresh {
+			return fmt.Errorf("--refresh must be 0 or at least %s, got %s", minDashboardRefresh, params.refresh)
+		}
+		if !shouldUseInteractiveTUI(cmd.OutOrStdout(), params.output, false) {
+			return errors.New("--interactive requires an interactive terminal and table output")
+		}
+	}
+
+	ctx := cmd.Context()
+	audit := newAuditContext(ctx, "budget status", map[string]string{
+		"pulumi_json": params.planPath, "pulumi_state": params.statePath,
+	})
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	switch {
+	case params.interactive:
+		err = runInteractiveBudgetStatus(cmd, eng, params)
+	case params.watch > 0:
+		err = runWatch(cmd, "FinFocus Budgets", params.output, params.watch, func(frameCtx context.Context) error {
+			return renderBudgetStatusOnce(frameCtx, cmd, eng, params)
+		})
+	default:
+		err = renderBudgetStatusOnce(ctx, cmd, eng, params)
+	}
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	audit.logSuccess(ctx, 0, 0)
+	return nil
+}
+
+// renderBudgetStatusOnce evaluates the budgets once and writes them as
+// gauges or JSON.
+func renderBudgetStatusOnce(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng projectedCostEngine,
+	params budgetStatusParams,
+) error {
+	scopes, err := collectBudgetScopes(ctx, cmd, eng, params)
+	if err != nil {
+		return err
+	}
+	if params.output == outputFormatJSON {
+		return renderBudgetStatusJSON(cmd, scopes)
+	}
+	cmd.Println(tui.NewBudgetPanel(scopes).View(0, len(scopes)))
+	return nil
+}
+
+// runInteractiveBudgetStatus runs the live budget view until the user quits.
+func runInteractiveBudgetStatus(cmd *cobra.Command, eng projectedCostEngine, params budgetStatusParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+	fetch := func(fetchCtx context.Context) ([]*engine.ScopedBudgetStatus, error) {
+		scopes, err := collectBudgetScopes(fetchCtx, cmd, eng, params)
+		if err != nil {
+			log.Warn().Ctx(fetchCtx).Err(err).Str("component", "cli").Str("operation", "budget status").
+				Msg("budget refresh failed")
+		}
+		return scopes, err
+	}
+
+	model := tui.NewBudgetStatusModel(ctx, fetch, params.refresh)
+	if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil {
+		return fmt.Errorf("running budget status: %w", err)
+	}
+	return nil
+}
+
+// collectBudgetScopes loads and prices the resources and evaluates the
+// budgets against their projected monthly cost, global scope first.
+func collectBudgetScopes(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng projectedCostEngine,
+	params budgetStatusParams,
+) ([]*engine.ScopedBudgetStatus, error) {
+	resources, err := loadStackResources(ctx, cmd, "budget status refresh", params.planPath, params.statePath)
+	if err != nil {
+		return nil, err
+	}
+	resources, err = ApplyFilters(ctx, resources, params.filter)
+	if err != nil {
+		return nil, fmt.Errorf("applying filters: %w", err)
+	}
+
+	projected, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	currency, mixed := extractCurrencyFromResults(projected.Results)
+	if mixed {
+		return nil, errors.New("budgets cannot be evaluated against costs in mixed currencies")
+	}
+	total := 0.0
+	for _, r := range projected.Results {
+		total += r.Monthly
+	}
+	budgets, err := evaluateBudgetsQuietly(cmd, projected.Results, resourceTagIndex(resources), total, currency)
+	if err != nil {
+		return nil, fmt.Errorf("evaluating budgets: %w", err)
+	}
+	return budgetScopes(budgets), nil
+}
+
+// renderBudgetStatusJSON writes the budget scopes as a JSON array.
+func renderBudgetStatusJSON(cmd *cobra.Command, scopes []*engine.ScopedBudgetStatus) error {
+	out := make([]budgetStatusJSON, 0, len(scopes))
+	for _, s := range scopes {
+		out = append(out, budgetStatusJSON{
+			Scope:              s.ScopeIdentifier(),
+			Currency:           s.Currency,
+			Amount:             s.Budget.Amount,
+			Spend:              s.CurrentSpend,
+			Percentage:         s.Percentage,
+			Forecast:           s.ForecastedSpend,
+			ForecastPercentage: s.ForecastPercentage,
+			Health:             strings.ToLower(healthStatusLabel(s.Health)),
+		})
+	}
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(out); err != nil {
+		return fmt.Errorf("encoding budget status JSON: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestBudgetStatus_RejectsInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"output", []string{"--output", "yaml"}, `unsupported output format "yaml"`},
+		{"refresh", []string{"--interactive", "--refresh", "5s"}, "--refresh must be 0 or at least 30s"},
+		{"no terminal", []string{"--interactive"}, "requires an interactive terminal"},
+		{"interactive json", []string{"--interactive", "--output", "json"}, "requires an interactive terminal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewBudgetStatusCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestRenderBudgetStatusJSON(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderBudgetStatusJSON(cmd, []*engine.ScopedBudgetStatus{
+		{
+			ScopeType: engine.ScopeTypeGlobal, Budget: config.ScopedBudget{Amount: 1000},
+			CurrentSpend: 850, Percentage: 85, ForecastedSpend: 1100, ForecastPercentage: 110,
+			Health: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING, Currency: "USD",
+		},
+		{
+			ScopeType: engine.ScopeTypeProvider, ScopeKey: "aws", Budget: config.ScopedBudget{Amount: 100},
+			CurrentSpend: 20, Percentage: 20, Health: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK, Currency: "USD",
+		},
+	}))
+
+	var got []budgetStatusJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	require.Len(t, got, 2)
+	assert.Equal(t, budgetStatusJSON{
+		Scope: "global", Currency: "USD", Amount: 1000, Spend: 850, Percentage: 85,
+		Forecast: 1100, ForecastPercentage: 110, Health: "warning",
+	}, got[0])
+	assert.Equal(t, "provider:aws", got[1].Scope)
+
+	var raw []map[string]any
+	require.NoError(t, json.Unmarshal(out.Bytes(), &raw))
+	assert.NotContains(t, raw[1], "forecast", "an unset forecast is omitted")
+}
+
+func TestBudgetScopes_LegacyKeepsForecastAndAlerts(t *testing.T) {
+	alerts := []engine.ThresholdStatus{{Threshold: 80, Status: engine.ThresholdStatusExceeded}}
+	scopes := budgetScopes(&BudgetRenderResult{LegacyStatus: &engine.BudgetStatus{
+		Budget:       config.BudgetConfig{Amount: 500, Alerts: []config.AlertConfig{{Threshold: 80}}},
+		CurrentSpend: 450, Percentage: 90, ForecastedSpend: 600, ForecastPercentage: 120,
+		Alerts: alerts, Currency: "USD",
+	}})
+
+	require.Len(t, scopes, 1)
+	assert.InDelta(t, 120.0, scopes[0].ForecastPercentage, 0.001)
+	assert.InDelta(t, 600.0, scopes[0].ForecastedSpend, 0.001)
+	assert.Equal(t, alerts, scopes[0].Alerts)
+	assert.Len(t, scopes[0].Budget.Alerts, 1)
+}
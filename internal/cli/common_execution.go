@@ -5,11 +5,14 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/spf13/cobra"
+
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/ingest"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
 	pulumidetect "github.com/rshade/finfocus/internal/pulumi"
 	"github.com/rshade/finfocus/internal/registry"
 	"github.com/rshade/finfocus/internal/router"
@@ -91,6 +94,33 @@ func loadAndMapResources(
 	return resources, nil
 }
 
+// loadK8sResources reads Deployments, StatefulSets, and PersistentVolumeClaims from a manifest file or
+// directory and maps them to resource descriptors for projected cost.
+func loadK8sResources(
+	ctx context.Context,
+	manifestPath string,
+	audit *auditContext,
+) ([]engine.ResourceDescriptor, error) {
+	log := logging.FromContext(ctx)
+
+	workloads, err := ingest.LoadK8sManifestsWithContext(ctx, manifestPath)
+	if err != nil {
+		log.Error().Ctx(ctx).Err(err).Str("manifest_path", manifestPath).Msg("failed to load Kubernetes manifests")
+		if audit != nil {
+			audit.logFailure(ctx, err)
+		}
+		return nil, fmt.Errorf("loading Kubernetes manifests: %w", err)
+	}
+	if len(workloads) == 0 {
+		log.Warn().Ctx(ctx).Str("manifest_path", manifestPath).
+			Msg("no Deployments, StatefulSets, or PersistentVolumeClaims found")
+	}
+
+	resources := ingest.MapK8sWorkloads(workloads)
+	log.Debug().Ctx(ctx).Int("resource_count", len(resources)).Msg("resources loaded from Kubernetes manifests")
+	return resources, nil
+}
+
 // openPlugins opens the requested adapter plugins and returns the plugin clients,
 // a cleanup function to release plugin resources, and an error if opening fails.
 // The ctx is used for plugin initialization and cancellation. The adapter string
@@ -101,6 +131,8 @@ func loadAndMapResources(
 // openPlugins opens plugins for the specified adapter using the default registry.
 // It returns the loaded plugin clients, a cleanup function that is guaranteed to be non-nil, and an error.
 // If plugin opening fails the error is logged and, if audit is non-nil, recorded via audit.logFailure.
+// When --replay-traffic is set, clients are rebuilt from the recording instead of launching plugins;
+// when --record-traffic is set, every client's traffic is recorded.
 //
 // Parameters:
 //   - ctx: context for plugin operations and logging.
@@ -113,8 +145,27 @@ func loadAndMapResources(
 //   - error: non-nil if opening plugins failed.
 func openPlugins(ctx context.Context, adapter string, audit *auditContext) ([]*pluginhost.Client, func(), error) {
 	log := logging.FromContext(ctx)
+	hostCfg := config.GetGlobalConfig().PluginHostConfig
 
-	clients, cleanup, err := registry.NewDefault().Open(ctx, adapter)
+	var (
+		clients []*pluginhost.Client
+		cleanup func()
+		err     error
+	)
+	if hostCfg.ReplayDir != "" {
+		clients, err = openReplayPlugins(hostCfg.ReplayDir, adapter)
+	} else {
+		clients, cleanup, err = registry.NewDefault().WithLauncher(pluginLauncher(ctx)).Open(ctx, adapter)
+	}
+	if cleanup == nil {
+		cleanup = func() {}
+	}
+	if err == nil && hostCfg.RecordDir != "" {
+		err = recordPluginTraffic(clients, hostCfg.RecordDir)
+		if err != nil {
+			cleanup()
+		}
+	}
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Str("adapter", adapter).Msg("failed to open plugins")
 		if audit != nil {
@@ -122,14 +173,109 @@ func openPlugins(ctx context.Context, adapter string, audit *auditContext) ([]*p
 		}
 		return nil, nil, fmt.Errorf("opening plugins: %w", err)
 	}
-	if cleanup == nil {
-		cleanup = func() {}
+	// The response cache wraps clients later, outside these spans, so cache
+	// hits show up as events on the caller's span rather than as RPC spans.
+	for _, client := range clients {
+		client.API = proto.NewTracingClient(client.Name, client.API)
 	}
 	log.Debug().Ctx(ctx).Int("plugin_count", len(clients)).Msg("plugins opened")
 
 	return clients, cleanup, nil
 }
 
+// openReplayPlugins builds clients for the plugins recorded in dir, limited to
+// adapter when one is given. No plugin processes are started.
+func openReplayPlugins(dir, adapter string) ([]*pluginhost.Client, error) {
+	recorded, err := proto.LoadRecordedPlugins(dir)
+	if err != nil {
+		return nil, err
+	}
+	var clients []*pluginhost.Client
+	for _, plugin := range recorded {
+		if adapter != "" && plugin.Name != adapter {
+			continue
+		}
+		clients = append(clients, &pluginhost.Client{
+			Name:     plugin.Name,
+			Metadata: plugin.Metadata,
+			API:      plugin.API,
+			Close:    func() error { return nil },
+		})
+	}
+	if len(clients) == 0 {
+		return nil, fmt.Errorf("plugin %q was not recorded in %s", adapter, dir)
+	}
+	return clients, nil
+}
+
+// recordPluginTraffic routes every client through a recorder that writes its
+// requests and responses under dir.
+func recordPluginTraffic(clients []*pluginhost.Client, dir string) error {
+	for _, client := range clients {
+		api, err := proto.NewRecordingClient(client.Name, client.Metadata, client.API, dir)
+		if err != nil {
+			return fmt.Errorf("recording traffic for plugin %s: %w", client.Name, err)
+		}
+		client.API = api
+	}
+	return nil
+}
+
+// enablePluginResponseCache routes the cost RPCs of every client through the
+// adapter-level response cache, so repeated plugin calls within their TTL are
+// served from disk. Per-operation TTLs from config apply unless --cache-ttl is
+// set, which overrides them all. Clients are left unchanged when caching is
+// disabled, the cache cannot be initialized, or plugin traffic is being
+// recorded or replayed.
+func enablePluginResponseCache(
+	ctx context.Context,
+	cmd *cobra.Command,
+	cfg *config.Config,
+	clients []*pluginhost.Client,
+) {
+	// Recording must see every plugin call and replay must not mix in cached
+	// responses, so the cache stays out of the way of both.
+	if hostCfg := config.GetGlobalConfig().PluginHostConfig; hostCfg.RecordDir != "" || hostCfg.ReplayDir != "" {
+		return
+	}
+
+	store := setupPluginCache(ctx, cmd, cfg)
+	if store == nil || !store.IsEnabled() {
+		return
+	}
+
+	opts := proto.CacheOptions{Store: store, TTLSeconds: cfg.Cost.Cache.OperationTTLSeconds}
+	if flagTTL, err := cmd.Flags().GetInt("cache-ttl"); err == nil && flagTTL > 0 {
+		opts.TTLSeconds = nil
+	}
+
+	for _, client := range clients {
+		identity := client.Name
+		if client.Metadata != nil && client.Metadata.Version != "" {
+			identity += "@" + client.Metadata.Version
+		}
+		client.API = proto.NewCachingClient(identity, client.API, opts)
+	}
+}
+
+// enableIncrementalResults makes eng reuse the projected cost results of
+// resources that are unchanged since an earlier run, so that only new and
+// changed resources reach the plugins. Like the plugin response cache, it is
+// off while recording or replaying plugin traffic and when the cache is
+// disabled. Results are kept for the projected cost TTL of
+// cost.cache.operation_ttl_seconds unless --cache-ttl is given.
+func enableIncrementalResults(ctx context.Context, cmd *cobra.Command, cfg *config.Config, eng *engine.Engine) {
+	if hostCfg := config.GetGlobalConfig().PluginHostConfig; hostCfg.RecordDir != "" || hostCfg.ReplayDir != "" {
+		return
+	}
+
+	ttl := cfg.Cost.Cache.OperationTTLSeconds[proto.CacheOpProjectedCost]
+	if flagTTL, err := cmd.Flags().GetInt("cache-ttl"); err == nil && flagTTL > 0 {
+		ttl = 0
+	}
+	eng.WithResultCache(setupPluginCache(ctx, cmd, cfg), ttl)
+}
+
 // recommendationFetcher abstracts recommendation retrieval for testability.
 type recommendationFetcher interface {
 	GetRecommendationsForResources(
@@ -144,17 +290,34 @@ type recommendationFetcher interface {
 // Errors are logged at WARN level but never propagated (FR-006).
 func fetchAndMergeRecommendations(ctx context.Context, fetcher recommendationFetcher,
 	resources []engine.ResourceDescriptor, results []engine.CostResult) {
-	log := logging.FromContext(ctx)
+	recsResult := fetchRecommendationsForMerge(ctx, fetcher, resources)
+	mergeRecommendations(ctx, recsResult, resources, results)
+}
+
+// fetchRecommendationsForMerge fetches recommendations to merge into cost
+// results. Errors are logged at WARN level and yield nil (FR-006).
+func fetchRecommendationsForMerge(ctx context.Context, fetcher recommendationFetcher,
+	resources []engine.ResourceDescriptor) *engine.RecommendationsResult {
 	recsResult, err := fetcher.GetRecommendationsForResources(ctx, resources)
 	if err != nil {
-		log.Warn().Ctx(ctx).Err(err).
+		logging.FromContext(ctx).Warn().Ctx(ctx).Err(err).
 			Str("operation", "fetch_and_merge_recommendations").
 			Msg("failed to fetch recommendations for detail view")
-		return
+		return nil
 	}
+	return recsResult
+}
+
+// mergeRecommendations attaches recsResult to the matching entries of results
+// by ResourceID, falling back to cloud ID and ARN lookups. results may be any
+// subset of the results for resources, which lets streamed output merge each
+// batch as it arrives.
+func mergeRecommendations(ctx context.Context, recsResult *engine.RecommendationsResult,
+	resources []engine.ResourceDescriptor, results []engine.CostResult) {
 	if recsResult == nil || len(recsResult.Recommendations) == 0 {
 		return
 	}
+	log := logging.FromContext(ctx)
 
 	recMap := make(map[string][]engine.Recommendation)
 	for _, rec := range recsResult.Recommendations {
@@ -287,6 +450,8 @@ const (
 // execution of the appropriate Pulumi CLI command to produce resource descriptors.
 //
 // If `stack` is empty the current Pulumi stack for the detected project directory is used.
+// A fully qualified `org/project/stack` name is fetched from the Pulumi Cloud API instead,
+// without requiring the Pulumi CLI or a local project.
 // `mode` must be either modePulumiPreview or modePulumiExport.
 // The function returns an error if the Pulumi binary or project cannot be found, if the stack cannot be resolved,
 // if the Pulumi command fails, if parsing the Pulumi output fails, or if an unsupported mode is provided.
@@ -301,6 +466,10 @@ func resolveResourcesFromPulumi(
 ) ([]engine.ResourceDescriptor, error) {
 	log := logging.FromContext(ctx)
 
+	if ref, ok := pulumidetect.ParseStackRef(stack); ok {
+		return resolveResourcesFromPulumiCloud(ctx, ref, mode)
+	}
+
 	projectDir, resolvedStack, err := detectPulumiProject(ctx, stack)
 	if err != nil {
 		return nil, err
@@ -308,20 +477,9 @@ func resolveResourcesFromPulumi(
 
 	switch mode {
 	case modePulumiPreview:
-		log.Info().Ctx(ctx).Str("component", "pulumi").
-			Msg("Running pulumi preview --json (this may take a moment)...")
-
-		data, previewErr := pulumidetect.Preview(ctx, pulumidetect.PreviewOptions{
-			ProjectDir: projectDir,
-			Stack:      resolvedStack,
-		})
+		plan, previewErr := runPulumiPreview(ctx, projectDir, resolvedStack)
 		if previewErr != nil {
-			return nil, fmt.Errorf("running pulumi preview: %w", previewErr)
-		}
-
-		plan, parseErr := ingest.ParsePulumiPlanWithContext(ctx, data)
-		if parseErr != nil {
-			return nil, fmt.Errorf("parsing Pulumi preview output: %w", parseErr)
+			return nil, previewErr
 		}
 
 		resources, mapErr := ingest.MapResources(plan.GetResourcesWithContext(ctx))
@@ -359,6 +517,65 @@ func resolveResourcesFromPulumi(
 	}
 }
 
+// runPulumiPreview runs pulumi preview --json for the stack of the Pulumi
+// project in projectDir and parses the plan it prints.
+func runPulumiPreview(ctx context.Context, projectDir, stack string) (*ingest.PulumiPlan, error) {
+	logging.FromContext(ctx).Info().Ctx(ctx).Str("component", "pulumi").
+		Msg("Running pulumi preview --json (this may take a moment)...")
+
+	data, err := pulumidetect.Preview(ctx, pulumidetect.PreviewOptions{
+		ProjectDir: projectDir,
+		Stack:      stack,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("running pulumi preview: %w", err)
+	}
+
+	plan, err := ingest.ParsePulumiPlanWithContext(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Pulumi preview output: %w", err)
+	}
+	return plan, nil
+}
+
+// resolveResourcesFromPulumiCloud fetches the latest checkpoint of ref from the
+// Pulumi Cloud API and maps its custom resources. The access token comes from
+// PULUMI_ACCESS_TOKEN or the pulumi.access_token config value. The Cloud API
+// cannot run a preview, so modePulumiPreview prices the deployed state.
+func resolveResourcesFromPulumiCloud(
+	ctx context.Context,
+	ref pulumidetect.StackRef,
+	mode pulumiMode,
+) ([]engine.ResourceDescriptor, error) {
+	log := logging.FromContext(ctx)
+
+	cfg := config.New()
+	client := pulumidetect.NewCloudClient(cfg.PulumiAPIURL(), pulumidetect.ResolveAccessToken(cfg.PulumiAccessToken()))
+
+	if mode == modePulumiPreview {
+		log.Info().Ctx(ctx).Str("component", "pulumi").Str("stack", ref.String()).
+			Msg("Pulumi Cloud stacks have no preview; using the latest deployed state")
+	}
+	log.Info().Ctx(ctx).Str("component", "pulumi").Str("stack", ref.String()).
+		Msg("Fetching stack state from Pulumi Cloud...")
+
+	data, err := client.ExportStack(ctx, ref)
+	if err != nil {
+		return nil, fmt.Errorf("fetching stack %s from Pulumi Cloud: %w", ref, err)
+	}
+
+	state, err := ingest.ParseStackExportWithContext(ctx, data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing Pulumi Cloud stack state: %w", err)
+	}
+
+	resources, err := ingest.MapStateResources(state.GetCustomResourcesWithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("mapping state resources: %w", err)
+	}
+	return resources, nil
+}
+
 // extractCurrencyFromResults scans results to find a single canonical currency.
 // It returns the currency code and a boolean indicating if mixed currencies were detected.
 // extractCurrencyFromResults determines a canonical currency from the provided cost
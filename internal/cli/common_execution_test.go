@@ -3,7 +3,11 @@ package cli
 import (
 	"context"
 	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -12,6 +16,7 @@ import (
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
 	pulumidetect "github.com/rshade/finfocus/internal/pulumi"
 )
 
@@ -497,3 +502,79 @@ func TestOpenPlugins_NilAudit_NoPanic(t *testing.T) {
 		cleanup()
 	}
 }
+
+func TestOpenPlugins_ReplayTraffic(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"aws-public", "kubecost"} {
+		_, err := proto.NewRecordingClient(name, &proto.PluginMetadata{Name: name}, nil, dir)
+		require.NoError(t, err)
+	}
+
+	hostCfg := &config.GetGlobalConfig().PluginHostConfig
+	hostCfg.ReplayDir = dir
+	t.Cleanup(func() { hostCfg.ReplayDir = "" })
+
+	clients, cleanup, err := openPlugins(context.Background(), "", nil)
+	require.NoError(t, err)
+	defer cleanup()
+	assert.Len(t, clients, 2)
+
+	clients, _, err = openPlugins(context.Background(), "kubecost", nil)
+	require.NoError(t, err)
+	require.Len(t, clients, 1)
+	assert.Equal(t, "kubecost", clients[0].Name)
+	assert.Equal(t, "kubecost", clients[0].Metadata.Name)
+
+	_, _, err = openPlugins(context.Background(), "vantage", nil)
+	require.Error(t, err)
+}
+
+func TestCostCmd_RecordAndReplayTrafficAreExclusive(t *testing.T) {
+	cmd := NewRootCmd("test")
+	cmd.SetArgs([]string{"cost", "projected", "--pulumi-json", "plan.json",
+		"--record-traffic", t.TempDir(), "--replay-traffic", t.TempDir()})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "cannot be used together")
+}
+
+func TestResolveResourcesFromPulumi_CloudStack(t *testing.T) {
+	const state = `{"version": 3, "deployment": {"resources": [
+		{"urn": "urn:pulumi:prod::webapp::aws:ec2/instance:Instance::web", "type": "aws:ec2/instance:Instance",
+		 "id": "i-0abc123", "custom": true, "outputs": {"arn": "arn:aws:ec2:us-east-1:1:instance/i-0abc123"}},
+		{"urn": "urn:pulumi:prod::webapp::pulumi:pulumi:Stack::webapp-prod", "type": "pulumi:pulumi:Stack"}
+	]}}`
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/stacks/acme/webapp/prod/export" || r.Header.Get("Authorization") != "token secret" {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(state))
+	}))
+	t.Cleanup(server.Close)
+
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	t.Setenv(pulumidetect.EnvAccessToken, "secret")
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"),
+		[]byte("pulumi:\n  api_url: "+server.URL+"\n"), 0o600))
+	// No Pulumi CLI is needed for Pulumi Cloud stacks.
+	t.Setenv("PATH", t.TempDir())
+
+	resources, err := resolveResourcesFromPulumi(context.Background(), "acme/webapp/prod", modePulumiExport)
+	require.NoError(t, err)
+	require.Len(t, resources, 1)
+	assert.Equal(t, "aws:ec2/instance:Instance", resources[0].Type)
+	assert.Equal(t, "i-0abc123", resources[0].Properties["pulumi:cloudId"])
+	assert.Equal(t, "arn:aws:ec2:us-east-1:1:instance/i-0abc123", resources[0].Properties["pulumi:arn"])
+
+	_, err = resolveResourcesFromPulumi(context.Background(), "acme/webapp/missing", modePulumiPreview)
+	require.ErrorIs(t, err, pulumidetect.ErrCloudStackNotFound)
+
+	t.Setenv(pulumidetect.EnvAccessToken, "")
+	_, err = resolveResourcesFromPulumi(context.Background(), "acme/webapp/prod", modePulumiExport)
+	require.ErrorIs(t, err, pulumidetect.ErrNoAccessToken)
+}
@@ -0,0 +1,175 @@
+package cli
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// resourceFilterKeys are the keys of key=value resource filters offered when
+// completing --filter.
+//
+//nolint:gochecknoglobals // Static list of completion candidates.
+var resourceFilterKeys = []string{"type=", "provider=", "service=", "id=", "tag:"}
+
+// actionFilterKey is the key of the recommendation action type filter.
+const actionFilterKey = "action="
+
+// budgetScopeSections are the --budget-scope values that select a whole
+// section of the budget status.
+//
+//nolint:gochecknoglobals // Static list of completion candidates.
+var budgetScopeSections = []string{"global", "provider", "tag", "type", "stack"}
+
+// registerCompletions registers dynamic value completion for the flags of cmd
+// and its subcommands that take values known to finfocus: --adapter completes
+// installed plugins, --stack stacks with recorded projections or budgets,
+// --filter filter keys and action types, and --budget-scope configured budget
+// scopes, and --profile configured profiles. The completions are served by the completion scripts of the
+// 'completion' command for bash, zsh, fish, and powershell.
+func registerCompletions(cmd *cobra.Command) {
+	completions := map[string]cobra.CompletionFunc{
+		"adapter":      completeAdapters,
+		"stack":        completeStacks,
+		"budget-scope": completeBudgetScopes,
+		"profile":      completeProfiles,
+	}
+	cmd.LocalFlags().VisitAll(func(flag *pflag.Flag) {
+		complete, ok := completions[flag.Name]
+		if flag.Name == "filter" {
+			// Resource filters are repeatable; single-valued filters, such as
+			// the test name regex of 'plugin conformance', are not completed.
+			complete, ok = completeFilters, flag.Value.Type() != "string"
+		}
+		if ok {
+			// Registration fails only for flags already registered.
+			_ = cmd.RegisterFlagCompletionFunc(flag.Name, complete)
+		}
+	})
+	for _, sub := range cmd.Commands() {
+		registerCompletions(sub)
+	}
+}
+
+// completeAdapters completes --adapter with the names of installed plugins.
+func completeAdapters(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	plugins, err := registry.NewDefault().ListPlugins()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	names := make([]string, 0, len(plugins))
+	for _, plugin := range plugins {
+		names = append(names, plugin.Name)
+	}
+	return uniqueSorted(names), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeStacks completes --stack with the stacks that have recorded
+// projections or a configured stack budget.
+func completeStacks(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	var stacks []string
+	history := config.NewProjectionHistoryStore("")
+	if history.Load() == nil {
+		stacks = append(stacks, history.Stacks()...)
+	}
+	if budgets := configuredBudgets(); budgets != nil {
+		for stack := range budgets.Stacks {
+			stacks = append(stacks, stack)
+		}
+	}
+	return uniqueSorted(stacks), cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeFilters completes --filter with the keys of key=value filters and,
+// for 'cost recommendations', the action types after "action=". Several
+// action types are separated by commas.
+func completeFilters(cmd *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	recommendations := cmd.Name() == "recommendations"
+	if recommendations && strings.HasPrefix(strings.ToLower(toComplete), actionFilterKey) {
+		prefix := toComplete[:strings.LastIndex(toComplete, "=")+1]
+		if i := strings.LastIndex(toComplete, ","); i >= 0 {
+			prefix = toComplete[:i+1]
+		}
+		actionTypes := proto.ValidActionTypes()
+		sort.Strings(actionTypes)
+		candidates := make([]string, 0, len(actionTypes))
+		for _, actionType := range actionTypes {
+			candidates = append(candidates, prefix+actionType)
+		}
+		return candidates, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+	}
+
+	keys := resourceFilterKeys
+	if recommendations {
+		keys = []string{actionFilterKey}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp | cobra.ShellCompDirectiveNoSpace
+}
+
+// completeBudgetScopes completes --budget-scope with the budget status
+// sections and the scopes of the configured budgets. Several scopes are
+// separated by commas.
+func completeBudgetScopes(_ *cobra.Command, _ []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	scopes := append([]string{}, budgetScopeSections...)
+	if budgets := configuredBudgets(); budgets != nil {
+		for provider := range budgets.Providers {
+			scopes = append(scopes, "provider="+provider)
+		}
+		for _, tag := range budgets.Tags {
+			scopes = append(scopes, "tag="+tag.Selector)
+		}
+		for resourceType := range budgets.Types {
+			scopes = append(scopes, "type="+resourceType)
+		}
+		for stack := range budgets.Stacks {
+			scopes = append(scopes, "stack="+stack)
+		}
+	}
+
+	prefix := ""
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix = toComplete[:i+1]
+	}
+	scopes = uniqueSorted(scopes)
+	for i, scope := range scopes {
+		scopes[i] = prefix + scope
+	}
+	return scopes, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProfiles completes --profile with the profiles of the config file.
+func completeProfiles(_ *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return cfg.ProfileNames(), cobra.ShellCompDirectiveNoFileComp
+}
+
+// configuredBudgets returns the budgets of the global configuration, or nil
+// when none are configured.
+func configuredBudgets() *config.BudgetsConfig {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil {
+		return nil
+	}
+	return cfg.Cost.Budgets
+}
+
+// uniqueSorted returns values sorted, without duplicates and empty values.
+func uniqueSorted(values []string) []string {
+	sort.Strings(values)
+	unique := values[:0]
+	for i, value := range values {
+		if value != "" && (i == 0 || value != values[i-1]) {
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// runCompletion runs the hidden completion command of the root command for
+// args and returns the candidates it prints.
+func runCompletion(t *testing.T, args ...string) []string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_SKIP_MIGRATION_CHECK", "1")
+
+	root := NewRootCmd("test")
+	var out bytes.Buffer
+	root.SetOut(&out)
+	root.SetErr(&bytes.Buffer{})
+	root.SetArgs(append([]string{cobra.ShellCompRequestCmd}, args...))
+	require.NoError(t, root.Execute())
+
+	var candidates []string
+	for _, line := range bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n")) {
+		if len(line) > 0 && line[0] != ':' {
+			candidates = append(candidates, string(line))
+		}
+	}
+	return candidates
+}
+
+func TestCompletionRegistered(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	assert.Equal(t, []string{"action="}, runCompletion(t, "cost", "recommendations", "--filter", ""))
+	assert.Equal(t, resourceFilterKeys, runCompletion(t, "cost", "actual", "--filter", ""))
+	assert.Contains(t, runCompletion(t, "cost", "projected", "--budget-scope", ""), "global")
+	assert.Empty(t, runCompletion(t, "plugin", "conformance", "--filter", ""),
+		"the test name regex is not completed")
+}
+
+func TestCompleteStacks(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	config.SetGlobalConfig(&config.Config{Cost: config.CostConfig{Budgets: &config.BudgetsConfig{
+		Stacks: map[string]*config.ScopedBudget{"prod": {Amount: 100}, "qa": {Amount: 50}},
+	}}})
+
+	history := config.NewProjectionHistoryStore("")
+	require.NoError(t, history.RecordSnapshot("dev", config.ProjectionSnapshot{RecordedAt: time.Now()}))
+	require.NoError(t, history.RecordSnapshot("prod", config.ProjectionSnapshot{RecordedAt: time.Now()}))
+	require.NoError(t, history.Save())
+
+	stacks, directive := completeStacks(&cobra.Command{}, nil, "")
+	assert.Equal(t, []string{"dev", "prod", "qa"}, stacks)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestCompleteFilters(t *testing.T) {
+	recommendations := &cobra.Command{Use: "recommendations"}
+
+	values, directive := completeFilters(recommendations, nil, "action=MIGRATE,")
+	assert.Contains(t, values, "action=MIGRATE,RIGHTSIZE")
+	assert.NotContains(t, values, "action=MIGRATE,UNSPECIFIED")
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp|cobra.ShellCompDirectiveNoSpace, directive)
+
+	values, _ = completeFilters(&cobra.Command{Use: "projected"}, nil, "action=")
+	assert.Equal(t, resourceFilterKeys, values, "action types only filter recommendations")
+}
+
+func TestCompleteBudgetScopes(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	config.SetGlobalConfig(&config.Config{Cost: config.CostConfig{Budgets: &config.BudgetsConfig{
+		Providers: map[string]*config.ScopedBudget{"aws": {Amount: 100}},
+		Tags:      []config.TagBudget{{Selector: "team:platform"}},
+		Types:     map[string]*config.ScopedBudget{"aws:ec2/instance": {Amount: 10}},
+		Stacks:    map[string]*config.ScopedBudget{"prod": {Amount: 100}},
+	}}})
+
+	scopes, _ := completeBudgetScopes(&cobra.Command{}, nil, "")
+	assert.Equal(t, []string{
+		"global", "provider", "provider=aws", "stack", "stack=prod",
+		"tag", "tag=team:platform", "type", "type=aws:ec2/instance",
+	}, scopes)
+
+	scopes, _ = completeBudgetScopes(&cobra.Command{}, nil, "global,st")
+	assert.Contains(t, scopes, "global,stack=prod")
+}
@@ -22,7 +22,7 @@ func NewConfigInitCmd() *cobra.Command {
   # Create default configuration, overwriting existing
   finfocus config init --force`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
-			cfg := config.New()
+			cfg := config.NewUserConfig()
 
 			// Check if config already exists and force isn't set
 			if !force {
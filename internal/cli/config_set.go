@@ -32,13 +32,17 @@ For sensitive values like API keys or credentials, use environment variables ins
   finfocus config set logging.level debug
 
   # For sensitive values, use environment variables instead
-  export FINFOCUS_PLUGIN_AWS_SECRET_KEY="mysecret"`,
+  export FINFOCUS_PLUGIN_AWS_SECRET_KEY="mysecret"
+
+  # Or store a reference to the secret rather than the secret itself
+  finfocus config set notify.slack.bot_token keychain://finfocus/slack
+  finfocus config set plugins.kubecost.api_key file://~/.secrets/kubecost`,
 		Args: cobra.ExactArgs(2), //nolint:mnd // Exactly 2 args: key and value
 		RunE: func(cmd *cobra.Command, args []string) error {
 			key := args[0]
 			value := args[1]
 
-			cfg := config.New()
+			cfg := config.NewUserConfig()
 
 			// Set the value
 			if err := cfg.Set(key, value); err != nil {
@@ -303,6 +303,81 @@ func TestConfigValidateCmdErrors(t *testing.T) {
 	assert.Contains(t, err.Error(), "invalid output format")
 }
 
+func TestConfigValidateCmdLint(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	path := filepath.Join(home, "config.yaml")
+
+	require.NoError(t, os.WriteFile(path, []byte(`output:
+  default_fromat: json
+cost:
+  budgets:
+    global:
+      amount: 1000
+      currency: USD
+    tags:
+      - selector: "env:prod"
+        amount: 300
+      - selector: "env:prod"
+        amount: 400
+`), 0600))
+
+	cmd := cli.NewConfigValidateCmd()
+	var output bytes.Buffer
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, output.String(), "Configuration warnings:")
+	assert.Contains(t, output.String(), path+":2: field default_fromat not found")
+	assert.Contains(t, output.String(), path+":11: cost.budgets.tags[1]: ties with \"env:prod\"")
+	assert.Contains(t, output.String(), "✅ Configuration is valid")
+
+	require.NoError(t, os.WriteFile(path, []byte(`cost:
+  cache:
+    enabled: true
+    ttl_seconds: -5
+  budgets:
+    global:
+      amount: 1000
+      currency: DOLLARS
+`), 0600))
+
+	cmd = cli.NewConfigValidateCmd()
+	output.Reset()
+	cmd.SetOut(&output)
+	cmd.SetErr(&output)
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), path+":4: cost.cache.ttl_seconds: TTL must not be negative")
+	assert.Contains(t, err.Error(), "(and 1 more error(s))")
+	assert.Contains(t, output.String(), "Configuration errors:")
+}
+
+func TestCheckConfigFlag(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_SKIP_MIGRATION_CHECK", "1")
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_HOME", home)
+	path := filepath.Join(home, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("output:\n  default_fromat: json\n"), 0600))
+
+	run := func(args ...string) string {
+		root := cli.NewRootCmd("test")
+		var output bytes.Buffer
+		root.SetOut(&output)
+		root.SetErr(&output)
+		root.SetArgs(args)
+		require.NoError(t, root.Execute())
+		return output.String()
+	}
+
+	assert.NotContains(t, run("config", "get", "output.default_format"), "Config warning")
+	assert.Contains(t, run("--check-config", "config", "get", "output.default_format"),
+		"Config warning: "+path+":2: field default_fromat not found")
+}
+
 func TestConfigCommandsIntegration(t *testing.T) {
 	// Set log level to error to avoid cluttering test output with debug logs
 	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
@@ -1,7 +1,9 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
+	"io/fs"
 	"sort"
 
 	"github.com/spf13/cobra"
@@ -24,13 +26,31 @@ func NewConfigValidateCmd() *cobra.Command {
 		Long: `Validates the configuration file at ~/.finfocus/config.yaml for syntax and semantic correctness.
 
 This includes:
-- General configuration syntax validation
-- Routing configuration validation (if present):
+- YAML syntax and unknown (misspelled) keys
+- General configuration validation
+- Budget scopes: currencies, tag selector syntax, provider budgets that
+  overlap, and tag budgets that tie at the same priority or never apply
+- Currency codes of budgets and currency conversion
+- Cache TTLs outside 60 seconds to 7 days
+- Analyzer plugin paths and settings of plugins that are not installed
+- Secret references that do not resolve, and secrets stored in plain text
+- Policy files that do not exist
+- Allocation selectors and routing configuration (if present):
   - Plugin existence verification
   - Pattern syntax validation (glob and regex)
   - Feature name validation
   - Priority value validation
-  - Duplicate plugin detection`,
+  - Duplicate plugin detection
+
+The .finfocus.yaml project config file found in the working directory or
+its closest ancestor is checked too, including settings it cannot set.
+
+Problems are reported with the line of the setting in the file. Errors make
+the command fail; warnings point at settings that are valid but likely
+mistakes.
+
+A lighter check without plugin discovery runs before any command with the
+global --check-config flag.`,
 		Example: `  # Validate current configuration
   finfocus config validate
 
@@ -47,10 +67,39 @@ This includes:
 }
 
 // runConfigValidate validates the application's configuration and reports results to cmd.
-// It validates both general and routing configuration; when routing yields warnings it emits a separating blank line before the success message.
+// It lints the config file, reporting each problem with its line, then validates the loaded
+// configuration, including any environment overrides, and the routing configuration; when
+// there are warnings it emits a separating blank line before the success message.
 // cmd is used for CLI output. If verbose is true, detailed configuration information is printed.
 // It returns an error when validation fails.
 func runConfigValidate(cmd *cobra.Command, verbose bool) error {
+	path := config.FilePath()
+	var installed []string
+	if plugins, _, err := registry.NewDefault().ListLatestPlugins(); err == nil {
+		installed = make([]string, 0, len(plugins))
+		for _, plugin := range plugins {
+			installed = append(installed, plugin.Name)
+		}
+	}
+
+	issues, err := config.LintFile(path, config.LintOptions{InstalledPlugins: installed})
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("reading configuration: %w", err)
+	}
+	if err := reportLintIssues(cmd, path, issues); err != nil {
+		return err
+	}
+	if projectPath := config.ProjectFilePath(); projectPath != "" {
+		projectIssues, lintErr := config.LintProjectFile(projectPath)
+		if lintErr != nil {
+			return fmt.Errorf("reading project configuration: %w", lintErr)
+		}
+		if err := reportLintIssues(cmd, projectPath, projectIssues); err != nil {
+			return err
+		}
+		issues = append(issues, projectIssues...)
+	}
+
 	cfg := config.New()
 
 	// Validate general configuration
@@ -64,7 +113,7 @@ func runConfigValidate(cmd *cobra.Command, verbose bool) error {
 		return err
 	}
 
-	if hasRoutingWarnings {
+	if hasRoutingWarnings || len(issues) > 0 {
 		cmd.Println()
 	}
 	cmd.Printf("✅ Configuration is valid\n")
@@ -76,6 +125,83 @@ func runConfigValidate(cmd *cobra.Command, verbose bool) error {
 	return nil
 }
 
+// reportLintIssues prints the problems found in the config file at path:
+// errors to the command's error output and warnings to its output. It
+// returns an error naming the first error when there are any.
+func reportLintIssues(cmd *cobra.Command, path string, issues []config.LintIssue) error {
+	var errs, warnings []string
+	for _, issue := range issues {
+		if issue.Severity == config.LintError {
+			errs = append(errs, formatLintIssue(path, issue))
+		} else {
+			warnings = append(warnings, formatLintIssue(path, issue))
+		}
+	}
+
+	if len(warnings) > 0 {
+		cmd.Println("Configuration warnings:")
+		for _, w := range warnings {
+			cmd.Printf("  - %s\n", w)
+		}
+	}
+	if len(errs) == 0 {
+		return nil
+	}
+	cmd.PrintErrln("Configuration errors:")
+	for _, e := range errs {
+		cmd.PrintErrf("  - %s\n", e)
+	}
+	if len(errs) == 1 {
+		return fmt.Errorf("configuration validation failed: %s", errs[0])
+	}
+	return fmt.Errorf("configuration validation failed: %s (and %d more error(s))", errs[0], len(errs)-1)
+}
+
+// formatLintIssue formats a config file problem as "path:line: key: message",
+// the form editors jump to.
+func formatLintIssue(path string, issue config.LintIssue) string {
+	location := path
+	if issue.Line > 0 {
+		location = fmt.Sprintf("%s:%d", path, issue.Line)
+	}
+	issue.Line = 0
+	return location + ": " + issue.String()
+}
+
+// checkConfigFile runs the checks of 'config validate' that need no plugin
+// discovery for --check-config, printing any problems as warnings without
+// failing the command.
+func checkConfigFile(cmd *cobra.Command) {
+	if flag := cmd.Flag("check-config"); flag == nil || flag.Value.String() != "true" {
+		return
+	}
+	printConfigIssues(cmd, config.FilePath(), func(path string) ([]config.LintIssue, error) {
+		return config.LintFile(path, config.LintOptions{})
+	})
+	if projectPath := config.ProjectFilePath(); projectPath != "" {
+		printConfigIssues(cmd, projectPath, config.LintProjectFile)
+	}
+}
+
+// printConfigIssues prints the problems lint finds in the config file at
+// path for --check-config.
+func printConfigIssues(cmd *cobra.Command, path string, lint func(string) ([]config.LintIssue, error)) {
+	issues, err := lint(path)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			cmd.PrintErrf("Warning: could not check %s: %v\n", path, err)
+		}
+		return
+	}
+	for _, issue := range issues {
+		label := "Config warning"
+		if issue.Severity == config.LintError {
+			label = "Config error"
+		}
+		cmd.PrintErrf("%s: %s\n", label, formatLintIssue(path, issue))
+	}
+}
+
 // validateRoutingConfig validates the routing configuration against available plugins.
 // validateRoutingConfig validates routing-related configuration and reports any issues to the provided command output.
 // It checks routing rules against available plugin clients and prints errors or warnings to the command's output streams.
@@ -135,6 +261,12 @@ func validateRoutingConfig(cmd *cobra.Command, cfg *config.Config) (bool, error)
 func printVerboseDetails(cmd *cobra.Command, cfg *config.Config) {
 	cmd.Println()
 	cmd.Println("Configuration details:")
+	if cfg.Profile() != "" {
+		cmd.Printf("  Profile: %s\n", cfg.Profile())
+	}
+	if cfg.ProjectFile() != "" {
+		cmd.Printf("  Project config: %s\n", cfg.ProjectFile())
+	}
 	cmd.Printf("  Output format: %s\n", cfg.Output.DefaultFormat)
 	cmd.Printf("  Output precision: %d\n", cfg.Output.Precision)
 	cmd.Printf("  Logging level: %s\n", cfg.Logging.Level)
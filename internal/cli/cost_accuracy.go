@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// addMinConfidenceFlag registers the --min-confidence flag of the commands
+// that calculate costs.
+func addMinConfidenceFlag(cmd *cobra.Command, minConfidence *string) {
+	cmd.Flags().StringVar(minConfidence, "min-confidence", "",
+		"Leave results less accurate than this out of totals: exact, estimated, heuristic, or unknown")
+}
+
+// parseMinConfidence parses the --min-confidence flag; empty keeps every
+// result.
+func parseMinConfidence(minConfidence string) (engine.Accuracy, error) {
+	if minConfidence == "" {
+		return "", nil
+	}
+	accuracy, err := engine.ParseAccuracy(minConfidence)
+	if err != nil {
+		return "", &usageError{err: fmt.Errorf("invalid --min-confidence: %w", err)}
+	}
+	return accuracy, nil
+}
+
+// applyMinConfidence leaves the results less accurate than minimum out of
+// results, and so out of their totals, and notes how many were left out on
+// the error output of cmd. An empty minimum keeps every result.
+func applyMinConfidence(cmd *cobra.Command, results *costResults, minimum engine.Accuracy) error {
+	if minimum == "" {
+		return nil
+	}
+	excluded, err := results.Filter(func(result engine.CostResult) bool {
+		return result.Accuracy.AtLeast(minimum)
+	})
+	if err != nil {
+		return err
+	}
+	if excluded > 0 {
+		cmd.PrintErrf("Left %d result(s) less accurate than %s out of totals (--min-confidence)\n",
+			excluded, minimum)
+	}
+	return nil
+}
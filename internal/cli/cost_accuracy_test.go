@@ -0,0 +1,34 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestApplyMinConfidence(t *testing.T) {
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	minimum, err := parseMinConfidence("estimated")
+	require.NoError(t, err)
+	results := storedResults(t,
+		engine.CostResult{ResourceID: "web", Monthly: 10, Accuracy: engine.AccuracyExact},
+		engine.CostResult{ResourceID: "spec", Monthly: 5, Accuracy: engine.AccuracyHeuristic},
+	)
+	require.NoError(t, applyMinConfidence(cmd, results, minimum))
+	kept := collected(t, results.CostResultStore)
+	require.Len(t, kept, 1)
+	assert.Equal(t, "web", kept[0].ResourceID)
+	assert.Contains(t, stderr.String(), "Left 1 result(s) less accurate than estimated out of totals")
+
+	_, err = parseMinConfidence("precise")
+	require.Error(t, err)
+	assert.Equal(t, engine.ErrCodeUsageError, errorReportCode(err))
+}
@@ -36,6 +36,14 @@ type costActualParams struct {
 	toStr              string
 	groupBy            string
 	filter             []string
+	export             string        // parquet://<path> or csv://<path> for per-resource daily rows
+	watch              time.Duration // Re-run the query at this interval (0 = once)
+	breakdown          bool          // Split each resource's cost by pricing dimension
+	onError            string        // --on-error policy for resources that failed to be costed
+	minConfidence      string        // Least accuracy of the results counted in totals
+	rollup             string        // Aggregate costs under their Pulumi component resources
+	sort               string        // Sort results by cost, name, or type
+	page               costPage      // Return one page of the results
 }
 
 // defaultToNow returns s if non-empty, otherwise returns the current time in RFC3339 format.
@@ -67,17 +75,46 @@ or estimate costs from Pulumi state file timestamps.
 When --pulumi-json and --pulumi-state are both omitted, finfocus automatically
 detects the Pulumi project in the current directory and runs 'pulumi stack export'
 to generate the input. The --from date is auto-detected from the earliest Created
-timestamp, and --stack can be used to target a specific stack.
+timestamp, and --stack can be used to target a specific stack. A fully qualified
+--stack org/project/stack fetches the stack state from the Pulumi Cloud API instead
+(token from PULUMI_ACCESS_TOKEN or pulumi.access_token).
 
 When using --pulumi-state, costs are estimated based on resource runtime calculated
 from the Created timestamp. The --from date is auto-detected from the earliest
-timestamp if not provided.`,
+timestamp if not provided.
+
+--on-error sets how resources whose plugin calls failed count: zero (default)
+keeps them, as $0 placeholders with --fallback-estimate; omit leaves them out
+of the results and totals and reports how many were left out; fail fails the
+command.
+
+--output ndjson writes each resource's costs as soon as its plugin calls
+complete, in input order rather than URN order unless --sort is given (which
+writes them once every resource is queried), and ends with a summary record of type "summary"
+with the totals and the number of failed plugin calls. Streamed results are
+written before --on-error and --min-confidence apply. Results grouped with
+--group-by are written once every resource is queried.
+
+--min-confidence leaves results less accurate than exact (billing data),
+estimated, heuristic, or unknown out of the output and totals.
+
+--rollup components aggregates the costs of resources under the Pulumi
+component resources that enclose them, with a subtotal per component. It
+cannot be combined with --group-by.
+
+--limit and --offset, or --page and --page-size, return one page of the
+results, ordered as by --sort. JSON output becomes an object with the page
+under "results" and a "pagination" object with the page and the total number
+of results.`,
 		Example: `  # Auto-detect from Pulumi project (dates auto-detected from state)
   finfocus cost actual
 
   # Auto-detect with specific stack
   finfocus cost actual --stack production
 
+  # Fetch state directly from Pulumi Cloud
+  finfocus cost actual --stack acme/webapp/production
+
   # Get costs for the last 7 days (to defaults to now)
   finfocus cost actual --pulumi-json plan.json --from 2025-01-07
 
@@ -103,7 +140,22 @@ timestamp if not provided.`,
   finfocus cost actual --pulumi-json plan.json --from 2025-01-01 --output json --group-by provider
 
   # Show confidence levels for cost estimates (useful for imported resources)
-  finfocus cost actual --pulumi-state state.json --estimate-confidence`,
+  finfocus cost actual --pulumi-state state.json --estimate-confidence
+
+  # Export one row per resource per day for warehouse ingestion
+  finfocus cost actual --from 2025-01-01 --to 2025-03-31 --export parquet://costs.parquet
+
+  # Re-run every 30 seconds, highlighting costs that changed
+  finfocus cost actual --from 2025-01-01 --watch 30s
+
+  # Actual cost by pricing dimension (compute, storage, data transfer, license)
+  finfocus cost actual --from 2025-01-01 --breakdown
+
+  # Fail instead of under-reporting when a plugin cannot cost some resources
+  finfocus cost actual --from 2025-01-01 --on-error fail
+
+  # Actual cost of each Pulumi component, as a tree
+  finfocus cost actual --pulumi-state state.json --rollup components`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return executeCostActual(cmd, params)
 		},
@@ -138,6 +190,19 @@ timestamp if not provided.`,
 	)
 	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
 		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+	cmd.Flags().StringVar(&params.export, "export", "",
+		"Also write per-resource daily cost rows to parquet://<path> or csv://<path>")
+
+	cmd.Flags().DurationVar(&params.watch, "watch", 0,
+		"Re-run the query at this interval and re-render it, highlighting changed values (e.g. 30s)")
+	cmd.Flags().BoolVar(&params.breakdown, "breakdown", false,
+		"Break each resource's cost into compute, storage, data transfer, and license")
+	addOnErrorFlag(cmd, &params.onError)
+	addMinConfidenceFlag(cmd, &params.minConfidence)
+	addRollupFlag(cmd, &params.rollup)
+	addSortFlag(cmd, &params.sort)
+	addPaginationFlags(cmd, &params.page)
+	cmd.MarkFlagsMutuallyExclusive("breakdown", "rollup")
 
 	// Note: --pulumi-json and --from are no longer required - validation is done in executeCostActual
 
@@ -183,6 +248,66 @@ func executeCostActual(cmd *cobra.Command, params costActualParams) error {
 		Msg("starting actual cost calculation")
 
 	audit := newAuditContext(ctx, "cost actual", buildActualAuditParams(params))
+	plugins := &actualCostPlugins{}
+	defer plugins.close()
+
+	if params.watch > 0 {
+		return runWatch(cmd, "FinFocus Actual Costs", params.output, params.watch, func(frameCtx context.Context) error {
+			return queryActualCosts(frameCtx, cmd, params, audit, plugins)
+		})
+	}
+	return queryActualCosts(ctx, cmd, params, audit, plugins)
+}
+
+// actualCostPlugins opens the adapter plugins on first use and keeps them
+// open, so every refresh of --watch reuses them.
+type actualCostPlugins struct {
+	eng     *engine.Engine
+	cleanup func()
+}
+
+// engine returns the cost engine, opening the plugins on the first call.
+func (p *actualCostPlugins) engine(
+	ctx context.Context,
+	cmd *cobra.Command,
+	adapter string,
+	audit *auditContext,
+) (*engine.Engine, error) {
+	if p.eng != nil {
+		return p.eng, nil
+	}
+	clients, cleanup, err := openPlugins(ctx, adapter, audit)
+	if err != nil {
+		return nil, err
+	}
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	p.eng = engine.New(clients, nil).
+		WithRouter(createRouterForEngine(ctx, cfg, clients)).
+		WithSpillThreshold(cfg.Cost.SpillThreshold)
+	p.cleanup = cleanup
+	return p.eng, nil
+}
+
+// close closes the plugins if they were opened.
+func (p *actualCostPlugins) close() {
+	if p.cleanup != nil {
+		p.cleanup()
+	}
+}
+
+// queryActualCosts runs one actual cost query: it loads and filters the
+// resources, resolves the time range, fetches the costs, renders them with
+// any export and budget status, and records the audit events. Budget exit
+// policies apply only outside --watch.
+func queryActualCosts(
+	ctx context.Context,
+	cmd *cobra.Command,
+	params costActualParams,
+	audit *auditContext,
+	plugins *actualCostPlugins,
+) error {
+	log := logging.FromContext(ctx)
 
 	resources, err := loadActualResources(ctx, cmd, params, audit)
 	if err != nil {
@@ -208,11 +333,10 @@ func executeCostActual(cmd *cobra.Command, params costActualParams) error {
 		return fmt.Errorf("parsing time range: %w", err)
 	}
 
-	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	eng, err := plugins.engine(ctx, cmd, params.adapter, audit)
 	if err != nil {
 		return err
 	}
-	defer cleanup()
 
 	tags, actualGroupBy := parseTagFilter(params.groupBy)
 	request := engine.ActualCostRequest{
@@ -222,46 +346,138 @@ func executeCostActual(cmd *cobra.Command, params costActualParams) error {
 		FallbackEstimate:   params.fallbackEstimate,
 	}
 
-	cfg := config.New()
-	eng := engine.New(clients, nil).
-		WithRouter(createRouterForEngine(ctx, cfg, clients))
-	resultWithErrors, err := eng.GetActualCostWithOptionsAndErrors(ctx, request)
+	// Breakdowns, rollups, --sort, and pagination need the complete results,
+	// so only the plain output may be streamed.
+	calculateFormat := params.output
+	if params.breakdown || params.rollup != "" || params.sort != "" || params.page.enabled() {
+		calculateFormat = outputFormatJSON
+	}
+	store, rendered, err := calculateActualCosts(
+		ctx, cmd, eng, request, calculateFormat, params.estimateConfidence)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to fetch actual costs")
 		audit.logFailure(ctx, err)
 		return fmt.Errorf("fetching actual costs: %w", err)
 	}
+	results := &costResults{CostResultStore: store}
+	defer func() { _ = results.Close() }()
 
-	fetchAndMergeRecommendations(ctx, eng, resources, resultWithErrors.Results)
+	// The flags are validated by validateActualInputFlags.
+	errorPolicy, _ := parseOnError(params.onError)
+	minAccuracy, _ := parseMinConfidence(params.minConfidence)
+	resultSort, _ := parseCostSort(params.sort)
+	if policyErr := results.ApplyErrorPolicy(errorPolicy); policyErr != nil {
+		audit.logFailure(ctx, policyErr)
+		return policyErr
+	}
+	if filterErr := applyMinConfidence(cmd, results, minAccuracy); filterErr != nil {
+		return filterErr
+	}
+	// Time-based groups stay in date order.
+	if !engine.GroupBy(actualGroupBy).IsTimeBasedGrouping() {
+		if sortErr := resultSort.apply(results); sortErr != nil {
+			return sortErr
+		}
+	}
 
-	if renderErr := RenderActualCostOutput(
-		ctx, cmd, params.output, resultWithErrors, actualGroupBy, params.estimateConfidence,
-	); renderErr != nil {
-		return renderErr
+	switch {
+	case params.breakdown:
+		all, collectErr := results.collect()
+		if collectErr != nil {
+			return collectErr
+		}
+		if renderErr := renderCostBreakdown(
+			cmd, params.output, all.Results, engine.DimensionBasisTotal,
+		); renderErr != nil {
+			return renderErr
+		}
+	case params.rollup != "":
+		all, collectErr := results.collect()
+		if collectErr != nil {
+			return collectErr
+		}
+		if renderErr := renderComponentRollup(
+			ctx, cmd, params.output, all.Results, resources, true,
+		); renderErr != nil {
+			return renderErr
+		}
+	case !rendered:
+		if renderErr := renderActualCostResults(ctx, cmd, params, actualGroupBy, results); renderErr != nil {
+			return renderErr
+		}
 	}
 
-	log.Info().Ctx(ctx).Str("operation", "cost_actual").Int("result_count", len(resultWithErrors.Results)).
-		Dur("duration_ms", time.Since(audit.start)).Msg("actual cost calculation complete")
+	totalCost, currency, mixedCurrencies, err := results.totals(
+		func(r engine.CostResult) float64 { return r.TotalCost })
+	if err != nil {
+		return err
+	}
 
-	totalCost := 0.0
-	for _, r := range resultWithErrors.Results {
-		totalCost += r.TotalCost
+	// Exports and budgets by scope need every result.
+	var all []engine.CostResult
+	if params.export != "" || budgetsNeedResults(false) {
+		collected, collectErr := results.collect()
+		if collectErr != nil {
+			return collectErr
+		}
+		all = collected.Results
 	}
 
-	currency, mixedCurrencies := extractCurrencyFromResults(resultWithErrors.Results)
+	if params.export != "" {
+		if exportErr := exportActualCosts(cmd, params.export, all, from); exportErr != nil {
+			audit.logFailure(ctx, exportErr)
+			return exportErr
+		}
+	}
+
+	log.Info().Ctx(ctx).Str("operation", "cost_actual").Int("result_count", results.Results.Len()).
+		Dur("duration_ms", time.Since(audit.start)).Msg("actual cost calculation complete")
 
 	// Evaluate and render budget status when currencies are consistent
 	if !mixedCurrencies {
 		scopeFilter := getBudgetScopeFilter(cmd)
 
-		budgetResult, budgetErr := renderBudgetWithScope(
-			cmd, resultWithErrors.Results, totalCost, currency, scopeFilter)
-		if exitErr := checkBudgetExitFromResult(cmd, budgetResult, budgetErr); exitErr != nil {
+		budgetResult, budgetErr := renderBudgetWithScope(cmd, all, resourceTagIndex(resources),
+			totalCost, currency, scopeFilter, &spendWindow{from: from, to: to})
+		if params.watch > 0 {
+			// Exit policies would end the watch; only evaluation errors fail a refresh.
+			if budgetErr != nil {
+				return budgetErr
+			}
+		} else if exitErr := checkBudgetExitFromResult(cmd, budgetResult, budgetErr); exitErr != nil {
 			return exitErr
 		}
 	}
 
-	audit.logSuccess(ctx, len(resultWithErrors.Results), totalCost)
+	audit.logSuccess(ctx, results.Results.Len(), totalCost)
+	return nil
+}
+
+// renderActualCostResults renders the actual cost results of a query that
+// did not stream them. Ungrouped NDJSON is written from the store a chunk at
+// a time; other outputs aggregate every result, or the page selected by
+// --limit, --offset, or --page.
+func renderActualCostResults(
+	ctx context.Context,
+	cmd *cobra.Command,
+	params costActualParams,
+	groupBy string,
+	results *costResults,
+) error {
+	ndjson := config.GetOutputFormat(params.output) == outputFormatNDJSON
+	if ndjson && groupBy == "" && !params.page.enabled() {
+		return writeStoreNDJSON(cmd, results.CostResultStore, true, params.estimateConfidence)
+	}
+	shown, meta, err := params.page.apply(results)
+	if err != nil {
+		return err
+	}
+	if err = RenderActualCostOutput(
+		ctx, cmd, params.output, shown, groupBy, params.estimateConfidence, meta,
+	); err != nil {
+		return err
+	}
+	writePageFooter(cmd, params.output, meta, len(shown.Results))
 	return nil
 }
 
@@ -433,6 +649,64 @@ func validateActualInputFlags(params costActualParams) error {
 
 	// When using --pulumi-state or auto-detection, --from is optional (auto-detected from timestamps)
 
+	if err := validateWatchInterval(params.watch); err != nil {
+		return err
+	}
+
+	if _, err := parseOnError(params.onError); err != nil {
+		return err
+	}
+
+	if _, err := parseMinConfidence(params.minConfidence); err != nil {
+		return err
+	}
+
+	if params.export != "" {
+		if _, err := parseExportTarget(params.export); err != nil {
+			return err
+		}
+		if _, groupBy := parseTagFilter(params.groupBy); groupBy != "" {
+			return errExportGroupBy
+		}
+	}
+
+	if params.breakdown {
+		if err := validateBreakdownOutput(params.output); err != nil {
+			return err
+		}
+		if _, groupBy := parseTagFilter(params.groupBy); engine.GroupBy(groupBy).IsTimeBasedGrouping() {
+			return fmt.Errorf("--breakdown cannot be combined with --group-by %s", groupBy)
+		}
+	}
+
+	if _, err := parseRollup(params.rollup, params.output); err != nil {
+		return err
+	}
+	if _, groupBy := parseTagFilter(params.groupBy); params.rollup != "" && groupBy != "" {
+		return &usageError{err: fmt.Errorf("--rollup cannot be combined with --group-by %s", groupBy)}
+	}
+
+	if _, err := parseCostSort(params.sort); err != nil {
+		return err
+	}
+	_, groupBy := parseTagFilter(params.groupBy)
+	if params.sort != "" && engine.GroupBy(groupBy).IsTimeBasedGrouping() {
+		return &usageError{err: fmt.Errorf("--sort cannot be combined with --group-by %s", groupBy)}
+	}
+
+	var paginateWithout string
+	switch {
+	case params.breakdown:
+		paginateWithout = "--breakdown"
+	case params.rollup != "":
+		paginateWithout = "--rollup"
+	case engine.GroupBy(groupBy).IsTimeBasedGrouping():
+		paginateWithout = "--group-by " + groupBy
+	}
+	if err := params.page.validate(paginateWithout); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -495,6 +769,9 @@ func buildActualAuditParams(params costActualParams) map[string]string {
 	if params.statePath != "" {
 		auditParams["state_path"] = params.statePath
 	}
+	if params.export != "" {
+		auditParams["export"] = params.export
+	}
 	return auditParams
 }
 
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/pkg/version"
+)
+
+// Export formats accepted by cost actual --export, used as URI schemes.
+const (
+	exportFormatParquet = "parquet"
+	exportFormatCSV     = "csv"
+)
+
+// parquetRowsPerGroup bounds how many rows the Parquet writer buffers before
+// flushing a row group, which keeps memory flat on long date ranges.
+const parquetRowsPerGroup = 64 * 1024
+
+// errExportGroupBy is returned when --export is combined with a --group-by
+// that aggregates results, which would lose the per-resource rows.
+var errExportGroupBy = errors.New("--export writes per-resource rows and cannot be combined with --group-by")
+
+// costExportTarget is a parsed --export value.
+type costExportTarget struct {
+	format string
+	path   string
+}
+
+// parseExportTarget parses "parquet://path" or "csv://path".
+func parseExportTarget(raw string) (costExportTarget, error) {
+	scheme, path, ok := strings.Cut(raw, "://")
+	scheme = strings.ToLower(scheme)
+	if !ok || path == "" || (scheme != exportFormatParquet && scheme != exportFormatCSV) {
+		return costExportTarget{}, fmt.Errorf(
+			"invalid --export %q: use parquet://<path> or csv://<path>", raw)
+	}
+	return costExportTarget{format: scheme, path: path}, nil
+}
+
+// exportActualCosts writes results to the --export target and reports the
+// number of rows on stderr, keeping stdout for the regular output.
+func exportActualCosts(cmd *cobra.Command, raw string, results []engine.CostResult, from time.Time) error {
+	target, err := parseExportTarget(raw)
+	if err != nil {
+		return err
+	}
+	rows, err := writeCostExport(target, results, from)
+	if err != nil {
+		return err
+	}
+	cmd.PrintErrf("Exported %d cost rows to %s\n", rows, target.path)
+	return nil
+}
+
+// secondsPerDay converts export dates to and from days since the Unix epoch.
+const secondsPerDay = 24 * 60 * 60
+
+// costExportRow is one resource's cost on one day. Field tags name the
+// Parquet columns; the CSV header uses the same names. Date is in days since
+// the Unix epoch, the physical encoding of the Parquet DATE type.
+type costExportRow struct {
+	Date         int32   `parquet:"date,date"`
+	ResourceID   string  `parquet:"resource_id"`
+	ResourceType string  `parquet:"resource_type"`
+	Provider     string  `parquet:"provider"`
+	Adapter      string  `parquet:"adapter"`
+	Cost         float64 `parquet:"cost"`
+	Currency     string  `parquet:"currency"`
+	Confidence   string  `parquet:"confidence"`
+}
+
+// costExportColumns is the CSV header, in costExportRow field order.
+//
+//nolint:gochecknoglobals // Static column list shared by the CSV writer and tests.
+var costExportColumns = []string{
+	"date", "resource_id", "resource_type", "provider", "adapter", "cost", "currency", "confidence",
+}
+
+// costRowWriter streams export rows to a file.
+type costRowWriter interface {
+	Write(row costExportRow) error
+	Close() error
+}
+
+// writeCostExport writes one row per resource per day of results to target
+// and returns the number of rows written. Results without a daily series are
+// written as a single row dated at their start, or at from when unset.
+func writeCostExport(target costExportTarget, results []engine.CostResult, from time.Time) (int, error) {
+	f, err := os.Create(target.path)
+	if err != nil {
+		return 0, fmt.Errorf("creating export file: %w", err)
+	}
+
+	var w costRowWriter
+	if target.format == exportFormatParquet {
+		w = newParquetRowWriter(f)
+	} else {
+		w = &csvRowWriter{w: csv.NewWriter(f)}
+	}
+
+	rows, err := streamCostRows(w, results, from)
+	if closeErr := w.Close(); err == nil {
+		err = closeErr
+	}
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("closing export file: %w", closeErr)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("writing %s export: %w", target.format, err)
+	}
+	return rows, nil
+}
+
+// streamCostRows expands results into daily rows and writes them one at a time.
+func streamCostRows(w costRowWriter, results []engine.CostResult, from time.Time) (int, error) {
+	rows := 0
+	for _, r := range results {
+		start := r.StartDate
+		if start.IsZero() {
+			start = from
+		}
+		base := costExportRow{
+			ResourceID:   r.ResourceID,
+			ResourceType: r.ResourceType,
+			Provider:     engine.ExtractProvider(r.ResourceType),
+			Adapter:      r.Adapter,
+			Currency:     r.Currency,
+			Confidence:   string(r.Confidence),
+		}
+
+		if len(r.DailyCosts) == 0 {
+			base.Date, base.Cost = exportDate(start), r.TotalCost
+			if err := w.Write(base); err != nil {
+				return rows, err
+			}
+			rows++
+			continue
+		}
+		for i, cost := range r.DailyCosts {
+			row := base
+			row.Date, row.Cost = exportDate(start.AddDate(0, 0, i)), cost
+			if err := w.Write(row); err != nil {
+				return rows, err
+			}
+			rows++
+		}
+	}
+	return rows, nil
+}
+
+// exportDate returns the UTC calendar day of t in days since the Unix epoch.
+func exportDate(t time.Time) int32 {
+	y, m, d := t.UTC().Date()
+	return int32(time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Unix() / secondsPerDay) //nolint:gosec // Dates fit in int32.
+}
+
+// parquetRowWriter writes rows to a Parquet file, flushing a row group every
+// parquetRowsPerGroup rows.
+type parquetRowWriter struct {
+	w   *parquet.GenericWriter[costExportRow]
+	row [1]costExportRow
+}
+
+func newParquetRowWriter(out io.Writer) *parquetRowWriter {
+	return &parquetRowWriter{
+		w: parquet.NewGenericWriter[costExportRow](out,
+			parquet.MaxRowsPerRowGroup(parquetRowsPerGroup),
+			parquet.CreatedBy("finfocus", version.GetVersion(), "")),
+	}
+}
+
+func (p *parquetRowWriter) Write(row costExportRow) error {
+	p.row[0] = row
+	_, err := p.w.Write(p.row[:])
+	return err
+}
+
+func (p *parquetRowWriter) Close() error {
+	return p.w.Close()
+}
+
+// csvRowWriter writes rows as RFC 4180 CSV with a header row.
+type csvRowWriter struct {
+	w           *csv.Writer
+	wroteHeader bool
+}
+
+func (c *csvRowWriter) Write(row costExportRow) error {
+	if !c.wroteHeader {
+		if err := c.w.Write(costExportColumns); err != nil {
+			return err
+		}
+		c.wroteHeader = true
+	}
+	return c.w.Write([]string{
+		time.Unix(int64(row.Date)*secondsPerDay, 0).UTC().Format("2006-01-02"),
+		row.ResourceID,
+		row.ResourceType,
+		row.Provider,
+		row.Adapter,
+		strconv.FormatFloat(row.Cost, 'f', -1, 64),
+		row.Currency,
+		row.Confidence,
+	})
+}
+
+func (c *csvRowWriter) Close() error {
+	if !c.wroteHeader {
+		if err := c.w.Write(costExportColumns); err != nil {
+			return err
+		}
+	}
+	c.w.Flush()
+	return c.w.Error()
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/parquet-go/parquet-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func actualExportResults() []engine.CostResult {
+	start := time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)
+	return []engine.CostResult{
+		{
+			ResourceID: "i-123", ResourceType: "aws:ec2/instance:Instance", Adapter: "aws-ce",
+			Currency: "USD", TotalCost: 6, DailyCosts: []float64{1, 2, 3}, StartDate: start,
+			Confidence: engine.ConfidenceHigh,
+		},
+		{
+			ResourceID: "bucket", ResourceType: "aws:s3/bucket:Bucket", Adapter: "aws-ce",
+			Currency: "USD", TotalCost: 0.5,
+		},
+	}
+}
+
+func TestParseExportTarget(t *testing.T) {
+	target, err := parseExportTarget("parquet://out/costs.parquet")
+	require.NoError(t, err)
+	assert.Equal(t, costExportTarget{format: exportFormatParquet, path: "out/costs.parquet"}, target)
+
+	target, err = parseExportTarget("CSV:///tmp/costs.csv")
+	require.NoError(t, err)
+	assert.Equal(t, costExportTarget{format: exportFormatCSV, path: "/tmp/costs.csv"}, target)
+
+	for _, raw := range []string{"costs.parquet", "json://costs.json", "csv://"} {
+		_, err = parseExportTarget(raw)
+		assert.Error(t, err, raw)
+	}
+}
+
+func TestValidateActualInputFlags_Export(t *testing.T) {
+	params := costActualParams{export: "csv://costs.csv", groupBy: "type"}
+	require.ErrorIs(t, validateActualInputFlags(params), errExportGroupBy)
+
+	params.groupBy = "tag:env=prod"
+	require.NoError(t, validateActualInputFlags(params))
+
+	params.export = "costs.csv"
+	require.Error(t, validateActualInputFlags(params))
+}
+
+func TestWriteCostExport_CSV(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "costs.csv")
+	from := time.Date(2025, 1, 30, 12, 0, 0, 0, time.UTC)
+
+	rows, err := writeCostExport(costExportTarget{format: exportFormatCSV, path: path}, actualExportResults(), from)
+	require.NoError(t, err)
+	assert.Equal(t, 4, rows)
+
+	f, err := os.Open(path)
+	require.NoError(t, err)
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{
+		costExportColumns,
+		{"2025-01-30", "i-123", "aws:ec2/instance:Instance", "aws", "aws-ce", "1", "USD", "high"},
+		{"2025-01-31", "i-123", "aws:ec2/instance:Instance", "aws", "aws-ce", "2", "USD", "high"},
+		{"2025-02-01", "i-123", "aws:ec2/instance:Instance", "aws", "aws-ce", "3", "USD", "high"},
+		{"2025-01-30", "bucket", "aws:s3/bucket:Bucket", "aws", "aws-ce", "0.5", "USD", ""},
+	}, records)
+}
+
+func TestWriteCostExport_Parquet(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "costs.parquet")
+	from := time.Date(2025, 1, 30, 0, 0, 0, 0, time.UTC)
+
+	rows, err := writeCostExport(costExportTarget{format: exportFormatParquet, path: path}, actualExportResults(), from)
+	require.NoError(t, err)
+	assert.Equal(t, 4, rows)
+
+	got, err := parquet.ReadFile[costExportRow](path)
+	require.NoError(t, err)
+	require.Len(t, got, 4)
+	assert.Equal(t, "i-123", got[2].ResourceID)
+	assert.Equal(t, exportDate(time.Date(2025, 2, 1, 12, 0, 0, 0, time.UTC)), got[2].Date)
+	assert.Equal(t, got[2].Date-2, got[0].Date)
+	assert.InDelta(t, 3.0, got[2].Cost, 0.0001)
+	assert.Equal(t, "bucket", got[3].ResourceID)
+	assert.InDelta(t, 0.5, got[3].Cost, 0.0001)
+}
+
+func TestWriteCostExport_EmptyCSVHasHeader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "costs.csv")
+
+	rows, err := writeCostExport(costExportTarget{format: exportFormatCSV, path: path}, nil, time.Now())
+	require.NoError(t, err)
+	assert.Zero(t, rows)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "date,resource_id,resource_type,provider,adapter,cost,currency,confidence\n", string(data))
+}
@@ -0,0 +1,424 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// costAllocateParams holds the parameters for the allocate command execution.
+type costAllocateParams struct {
+	planPath    string
+	fromStr     string
+	toStr       string
+	adapter     string
+	teamTag     string
+	attribution string
+	output      string
+	outputFile  string
+}
+
+// allocationCostSource prices resources for allocation: projected monthly
+// costs, or actual costs over a period.
+type allocationCostSource interface {
+	actualCostFetcher
+	GetProjectedCostWithErrors(
+		ctx context.Context, resources []engine.ResourceDescriptor,
+	) (*engine.CostResultWithErrors, error)
+}
+
+// NewCostAllocateCmd creates the "allocate" subcommand, which attributes the
+// costs of a stack to teams using the tag rules and shared-cost pools
+// configured under cost.allocation.
+func NewCostAllocateCmd() *cobra.Command {
+	var params costAllocateParams
+
+	cmd := &cobra.Command{
+		Use:   "allocate",
+		Short: "Attribute costs to teams using tag rules and shared-cost distribution",
+		Long: `Attribute costs to teams using tag rules and shared-cost distribution.
+
+Each resource is owned by the team of the first cost.allocation rule whose
+selector matches its tags, or else by the value of its team tag (--team-tag,
+cost.allocation.tag_key, default "team"). Resources without an owner are
+reported as "(unallocated)".
+
+Resources matched by a cost.allocation.shared pool are distributed between
+teams instead: evenly, in proportion to each team's direct spend, or by fixed
+percentages.
+
+With --attribution dependencies, the cost of a shared resource that other
+resources depend on in the Pulumi dependency graph, such as a NAT gateway, is
+attributed to the workloads that depend on it in proportion to their own cost,
+and goes to the teams owning them. The effective cost of each workload, its own
+cost plus its share of the shared resources, is listed after the teams.
+
+Without --from the projected monthly costs are allocated. With --from the
+actual costs for the period are allocated.`,
+		Example: `  # Allocate projected monthly costs to teams
+  finfocus cost allocate --pulumi-json plan.json
+
+  # Allocate actual spend for March 2025
+  finfocus cost allocate --pulumi-json plan.json --from 2025-03-01 --to 2025-04-01
+
+  # Attribute shared resources to the workloads that depend on them
+  finfocus cost allocate --pulumi-json plan.json --attribution dependencies
+
+  # Use the "owner" tag and export CSV
+  finfocus cost allocate --pulumi-json plan.json --team-tag owner --output csv --output-file teams.csv`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostAllocate(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "",
+		"Path to Pulumi preview JSON output (auto-detected from the Pulumi project when omitted)")
+	cmd.Flags().StringVar(&params.fromStr, "from", "",
+		"Allocate actual costs from this date (YYYY-MM-DD or RFC3339) instead of projected costs")
+	cmd.Flags().StringVar(&params.toStr, "to", "", "End date for actual costs (YYYY-MM-DD or RFC3339, defaults to now)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.teamTag, "team-tag", "",
+		"Resource tag that names the owning team (default from cost.allocation.tag_key, else \"team\")")
+	cmd.Flags().StringVar(&params.attribution, "attribution", "",
+		"Attribute shared resources to the workloads that depend on them: dependencies")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table, json, or csv")
+	cmd.Flags().StringVar(&params.outputFile, "output-file", "", "Write output to file (default: stdout)")
+
+	return cmd
+}
+
+// executeCostAllocate prices the resources, allocates their costs to teams,
+// and renders the per-team totals.
+func executeCostAllocate(cmd *cobra.Command, params costAllocateParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	switch params.output {
+	case outputFormatTable, outputFormatJSON, outputFormatCSV:
+	default:
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+	if params.toStr != "" && params.fromStr == "" {
+		return errors.New("--to requires --from")
+	}
+
+	attribution, err := engine.ParseAttribution(params.attribution)
+	if err != nil {
+		return &usageError{err: fmt.Errorf("invalid --attribution: %w", err)}
+	}
+
+	req := allocationRequest{adapter: params.adapter, attribution: attribution}
+	if params.fromStr != "" {
+		from, to, err := ParseTimeRange(params.fromStr, defaultToNow(params.toStr))
+		if err != nil {
+			return fmt.Errorf("parsing time range: %w", err)
+		}
+		req.actual, req.from, req.to = true, from, to
+	}
+
+	allocCfg := allocationConfig(params.teamTag)
+	if err := allocCfg.Validate(); err != nil {
+		return err
+	}
+
+	audit := newAuditContext(ctx, "cost allocate", map[string]string{
+		"pulumi_json": params.planPath, "from": params.fromStr, "to": params.toStr, "output": params.output,
+		"attribution": attribution,
+	})
+
+	mode := modePulumiPreview
+	if req.actual {
+		mode = modePulumiExport
+	}
+	resources, err := loadAllocationResources(ctx, cmd, params.planPath, mode, audit)
+	if err != nil {
+		return err
+	}
+	req.resources = resources
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, nil).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	items, err := fetchAllocationItems(ctx, eng, req)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	var report *engine.AllocationReport
+	if req.attribution == engine.AttributionDependencies {
+		report = engine.AllocateCostsByDependency(items, allocCfg, engine.ResourceDependencies(req.resources))
+	} else {
+		report = engine.AllocateCosts(items, allocCfg)
+	}
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "cost_allocate").
+		Int("team_count", len(report.Teams)).Float64("total", report.Total).
+		Dur("duration_ms", time.Since(audit.start)).Msg("cost allocation complete")
+	audit.logSuccess(ctx, len(items), report.Total)
+
+	writer, closeOutput, err := getOutputWriter(cmd, params.outputFile)
+	if err != nil {
+		return err
+	}
+	if closeOutput != nil {
+		defer closeOutput()
+	}
+	return renderAllocationReport(writer, params.output, report, req)
+}
+
+// allocationConfig returns the configured cost.allocation settings with the
+// --team-tag override applied.
+func allocationConfig(teamTag string) *config.AllocationConfig {
+	allocCfg := &config.AllocationConfig{}
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Cost.Allocation != nil {
+		copied := *cfg.Cost.Allocation
+		allocCfg = &copied
+	}
+	if teamTag != "" {
+		allocCfg.TagKey = teamTag
+	}
+	return allocCfg
+}
+
+// loadAllocationResources loads resources from the plan, or from the Pulumi
+// project in the current directory when planPath is empty.
+func loadAllocationResources(
+	ctx context.Context,
+	cmd *cobra.Command,
+	planPath string,
+	mode pulumiMode,
+	audit *auditContext,
+) ([]engine.ResourceDescriptor, error) {
+	if planPath != "" {
+		return loadAndMapResources(ctx, planPath, audit)
+	}
+	resources, err := resolveResourcesFromPulumi(ctx, getStackFlag(cmd), mode)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return nil, err
+	}
+	return resources, nil
+}
+
+// allocationRequest describes the costs to allocate.
+type allocationRequest struct {
+	resources []engine.ResourceDescriptor
+	// actual selects actual costs over [from, to) instead of projected monthly costs.
+	actual   bool
+	from, to time.Time
+	adapter  string
+	// attribution is engine.AttributionDependencies to attribute shared
+	// resources to the workloads that depend on them.
+	attribution string
+}
+
+// fetchAllocationItems prices the request's resources and pairs each cost
+// with the resource's tags. Results that carry errors are skipped.
+func fetchAllocationItems(
+	ctx context.Context,
+	src allocationCostSource,
+	req allocationRequest,
+) ([]engine.AllocationItem, error) {
+	var (
+		result *engine.CostResultWithErrors
+		err    error
+	)
+	if req.actual {
+		result, err = src.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+			Resources: req.resources, From: req.from, To: req.to, Adapter: req.adapter,
+		})
+	} else {
+		result, err = src.GetProjectedCostWithErrors(ctx, req.resources)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fetching costs: %w", err)
+	}
+	if result == nil {
+		return nil, nil
+	}
+
+	tags := resourceTagIndex(req.resources)
+
+	items := make([]engine.AllocationItem, 0, len(result.Results))
+	for _, r := range result.Results {
+		if r.Error != nil {
+			continue
+		}
+		cost := r.Monthly
+		if req.actual {
+			cost = r.TotalCost
+		}
+		items = append(items, engine.AllocationItem{
+			ResourceID:   r.ResourceID,
+			ResourceType: r.ResourceType,
+			Tags:         tags[r.ResourceID],
+			Cost:         cost,
+			Currency:     r.Currency,
+		})
+	}
+	return items, nil
+}
+
+// renderAllocationReport renders the allocation report in the requested format.
+func renderAllocationReport(w io.Writer, format string, report *engine.AllocationReport, req allocationRequest) error {
+	switch format {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encoding allocation JSON: %w", err)
+		}
+		return nil
+	case outputFormatCSV:
+		return renderAllocationCSV(w, report, req)
+	default:
+		return renderAllocationTable(w, report, req)
+	}
+}
+
+// renderAllocationTable renders per-team totals, the shared pools, and, with
+// dependency attribution, the effective cost of the workloads that carry a
+// share of shared resources.
+func renderAllocationTable(w io.Writer, report *engine.AllocationReport, req allocationRequest) error {
+	if req.actual {
+		fmt.Fprintf(w, "Cost allocation by %s tag, %s to %s\n\n",
+			report.TagKey, req.from.Format("2006-01-02"), req.to.Format("2006-01-02"))
+	} else {
+		fmt.Fprintf(w, "Projected monthly cost allocation by %s tag\n\n", report.TagKey)
+	}
+
+	if len(report.Teams) == 0 {
+		fmt.Fprintln(w, "No costs to allocate.")
+		return nil
+	}
+
+	byDependency := req.attribution == engine.AttributionDependencies
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	if byDependency {
+		fmt.Fprintln(tw, "TEAM\tDIRECT\tSHARED\tDEPENDENCIES\tTOTAL\tRESOURCES")
+		fmt.Fprintln(tw, "----\t------\t------\t------------\t-----\t---------")
+		for _, t := range report.Teams {
+			fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%.2f\t%.2f\t%d\n",
+				t.Team, t.Direct, t.Shared, t.Dependencies, t.Total, t.Resources)
+		}
+	} else {
+		fmt.Fprintln(tw, "TEAM\tDIRECT\tSHARED\tTOTAL\tRESOURCES")
+		fmt.Fprintln(tw, "----\t------\t------\t-----\t---------")
+		for _, t := range report.Teams {
+			fmt.Fprintf(tw, "%s\t%.2f\t%.2f\t%.2f\t%d\n", t.Team, t.Direct, t.Shared, t.Total, t.Resources)
+		}
+	}
+
+	if len(report.Pools) > 0 {
+		fmt.Fprintln(tw)
+		fmt.Fprintln(tw, "SHARED POOL\tSTRATEGY\tTOTAL\tRESOURCES\tSPLIT")
+		fmt.Fprintln(tw, "-----------\t--------\t-----\t---------\t-----")
+		for _, p := range report.Pools {
+			fmt.Fprintf(tw, "%s\t%s\t%.2f\t%d\t%s\n", p.Name, p.Strategy, p.Total, p.Resources, formatPoolShares(p))
+		}
+	}
+	if byDependency {
+		writeWorkloadRows(tw, report.Workloads)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTotal: %.2f %s across %d teams\n", report.Total, report.Currency, len(report.Teams))
+	if report.Unallocated > 0 && report.Total > 0 {
+		fmt.Fprintf(w, "Unallocated: %.2f %s (%.1f%% of total, %d resources); tag them with %q or add a rule\n",
+			report.Unallocated, report.Currency, report.Unallocated/report.Total*100, //nolint:mnd // Percentage calculation.
+			report.UnallocatedResources, report.TagKey)
+	}
+	if report.MixedCurrencies {
+		fmt.Fprintln(w, "Warning: costs use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// writeWorkloadRows writes the effective cost of the workloads that carry a
+// share of shared resources.
+func writeWorkloadRows(w io.Writer, workloads []engine.WorkloadCost) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "WORKLOAD\tTEAM\tDIRECT\tATTRIBUTED\tEFFECTIVE\tSHARED RESOURCES")
+	fmt.Fprintln(w, "--------\t----\t------\t----------\t---------\t----------------")
+	rows := 0
+	for _, wl := range workloads {
+		if wl.Attributed == 0 {
+			continue
+		}
+		_, name := engine.SplitURN(wl.ResourceID)
+		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.2f\t%.2f\t%d\n",
+			name, wl.Team, wl.Direct, wl.Attributed, wl.Effective, wl.SharedResources)
+		rows++
+	}
+	if rows == 0 {
+		fmt.Fprintln(w, "(no shared resources with dependent workloads)")
+	}
+}
+
+// formatPoolShares formats a pool's split as "team=amount" pairs.
+func formatPoolShares(p engine.SharedPoolAllocation) string {
+	teams := make([]string, 0, len(p.Shares))
+	for team := range p.Shares {
+		teams = append(teams, team)
+	}
+	sort.Strings(teams)
+
+	pairs := make([]string, 0, len(teams))
+	for _, team := range teams {
+		pairs = append(pairs, fmt.Sprintf("%s=%.2f", team, p.Shares[team]))
+	}
+	return strings.Join(pairs, ", ")
+}
+
+// renderAllocationCSV renders one row per team. With dependency attribution a
+// dependencies column follows shared.
+func renderAllocationCSV(w io.Writer, report *engine.AllocationReport, req allocationRequest) error {
+	byDependency := req.attribution == engine.AttributionDependencies
+	cw := csv.NewWriter(w)
+	header := []string{"team", "direct", "shared", "total", "currency", "resources"}
+	if byDependency {
+		header = []string{"team", "direct", "shared", "dependencies", "total", "currency", "resources"}
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+	formatAmount := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, t := range report.Teams {
+		row := []string{t.Team, formatAmount(t.Direct), formatAmount(t.Shared)}
+		if byDependency {
+			row = append(row, formatAmount(t.Dependencies))
+		}
+		row = append(row, formatAmount(t.Total), report.Currency, strconv.Itoa(t.Resources))
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("writing CSV row: %w", err)
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flushing CSV: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,143 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// fakeAllocationSource returns fixed projected and actual results.
+type fakeAllocationSource struct {
+	projected, actual []engine.CostResult
+	actualReq         engine.ActualCostRequest
+}
+
+func (f *fakeAllocationSource) GetProjectedCostWithErrors(
+	context.Context, []engine.ResourceDescriptor,
+) (*engine.CostResultWithErrors, error) {
+	return &engine.CostResultWithErrors{Results: f.projected}, nil
+}
+
+func (f *fakeAllocationSource) GetActualCostWithOptionsAndErrors(
+	_ context.Context, req engine.ActualCostRequest,
+) (*engine.CostResultWithErrors, error) {
+	f.actualReq = req
+	return &engine.CostResultWithErrors{Results: f.actual}, nil
+}
+
+func allocationTestResources() []engine.ResourceDescriptor {
+	return []engine.ResourceDescriptor{
+		{ID: "api", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"team": "payments"},
+		}},
+		{ID: "nat", Type: "aws:ec2/natGateway:NatGateway", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"shared": "network"},
+		}},
+	}
+}
+
+func TestFetchAllocationItems(t *testing.T) {
+	src := &fakeAllocationSource{
+		projected: []engine.CostResult{
+			{ResourceID: "api", ResourceType: "aws:ec2/instance:Instance", Monthly: 300, Currency: "USD"},
+			{ResourceID: "nat", Monthly: 40, Currency: "USD"},
+			{ResourceID: "broken", Error: &engine.StructuredError{Message: "boom"}},
+		},
+		actual: []engine.CostResult{{ResourceID: "api", TotalCost: 120, Currency: "USD"}},
+	}
+	req := allocationRequest{resources: allocationTestResources()}
+
+	items, err := fetchAllocationItems(context.Background(), src, req)
+	require.NoError(t, err)
+	require.Len(t, items, 2, "results with errors are skipped")
+	assert.InDelta(t, 300, items[0].Cost, 1e-9)
+	assert.Equal(t, map[string]string{"team": "payments"}, items[0].Tags)
+
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	req.actual, req.from, req.to = true, from, from.AddDate(0, 1, 0)
+	items, err = fetchAllocationItems(context.Background(), src, req)
+	require.NoError(t, err)
+	require.Len(t, items, 1)
+	assert.InDelta(t, 120, items[0].Cost, 1e-9)
+	assert.Equal(t, from, src.actualReq.From)
+}
+
+func TestRenderAllocationReport(t *testing.T) {
+	items := []engine.AllocationItem{
+		{ResourceID: "api", Cost: 300, Currency: "USD", Tags: map[string]string{"team": "payments"}},
+		{ResourceID: "web", Cost: 100, Currency: "USD", Tags: map[string]string{"team": "frontend"}},
+		{ResourceID: "nat", Cost: 40, Currency: "USD", Tags: map[string]string{"shared": "network"}},
+	}
+	report := engine.AllocateCosts(items, &config.AllocationConfig{
+		Shared: []config.SharedCostPool{
+			{Name: "network", Selector: "shared:*", Strategy: config.AllocationStrategyProportional},
+		},
+	})
+
+	var table bytes.Buffer
+	require.NoError(t, renderAllocationReport(&table, outputFormatTable, report, allocationRequest{}))
+	assert.Contains(t, table.String(), "Projected monthly cost allocation by team tag")
+	assert.Contains(t, table.String(), "payments")
+	assert.Contains(t, table.String(), "frontend=10.00, payments=30.00")
+	assert.Contains(t, table.String(), "Total: 440.00 USD across 2 teams")
+
+	var csvOut bytes.Buffer
+	require.NoError(t, renderAllocationReport(&csvOut, outputFormatCSV, report, allocationRequest{}))
+	assert.Equal(t, "team,direct,shared,total,currency,resources\n"+
+		"payments,300.00,30.00,330.00,USD,1\n"+
+		"frontend,100.00,10.00,110.00,USD,1\n", csvOut.String())
+}
+
+func TestExecuteCostAllocate_ValidatesFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	err := executeCostAllocate(cmd, costAllocateParams{output: "xml"})
+	require.ErrorContains(t, err, "unsupported output format")
+
+	err = executeCostAllocate(cmd, costAllocateParams{output: outputFormatTable, toStr: "2025-04-01"})
+	require.ErrorContains(t, err, "--to requires --from")
+
+	err = executeCostAllocate(cmd, costAllocateParams{output: outputFormatTable, attribution: "tags"})
+	require.ErrorContains(t, err, `invalid --attribution: invalid attribution "tags"`)
+}
+
+func TestRenderAllocationReport_Dependencies(t *testing.T) {
+	const (
+		api = "urn:pulumi:dev::app::aws:ec2/instance:Instance::api"
+		web = "urn:pulumi:dev::app::aws:ec2/instance:Instance::web"
+		nat = "urn:pulumi:dev::app::aws:ec2/natGateway:NatGateway::nat"
+	)
+	items := []engine.AllocationItem{
+		{ResourceID: api, Cost: 300, Currency: "USD", Tags: map[string]string{"team": "payments"}},
+		{ResourceID: web, Cost: 100, Currency: "USD", Tags: map[string]string{"team": "frontend"}},
+		{ResourceID: nat, Cost: 40, Currency: "USD"},
+	}
+	resources := []engine.ResourceDescriptor{
+		{ID: api, Dependencies: []string{nat}},
+		{ID: web, Dependencies: []string{nat}},
+	}
+	report := engine.AllocateCostsByDependency(items, nil, engine.ResourceDependencies(resources))
+	req := allocationRequest{attribution: engine.AttributionDependencies}
+
+	var table bytes.Buffer
+	require.NoError(t, renderAllocationReport(&table, outputFormatTable, report, req))
+	assert.Regexp(t, `payments\s+300\.00\s+0\.00\s+30\.00\s+330\.00\s+1`, table.String())
+	assert.Contains(t, table.String(), "WORKLOAD")
+	assert.Regexp(t, `api\s+payments\s+300\.00\s+30\.00\s+330\.00\s+1`, table.String())
+	assert.NotContains(t, table.String(), "Unallocated:")
+
+	var csvOut bytes.Buffer
+	require.NoError(t, renderAllocationReport(&csvOut, outputFormatCSV, report, req))
+	assert.Equal(t, "team,direct,shared,dependencies,total,currency,resources\n"+
+		"payments,300.00,0.00,30.00,330.00,USD,1\n"+
+		"frontend,100.00,0.00,10.00,110.00,USD,1\n", csvOut.String())
+}
@@ -0,0 +1,379 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/anomaly"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Anomaly grouping keys for the --by flag.
+const (
+	anomalyByResource = "resource"
+	anomalyByType     = "type"
+	anomalyByProvider = "provider"
+)
+
+// defaultAnomalyDays is the default lookback window for anomaly detection.
+const defaultAnomalyDays = 30
+
+// costAnomaliesParams holds the parameters for the anomalies command execution.
+type costAnomaliesParams struct {
+	planPath    string
+	statePath   string
+	adapter     string
+	output      string
+	toStr       string
+	by          string
+	method      string
+	sensitivity string
+	threshold   float64
+	days        int
+	filter      []string
+}
+
+// actualCostFetcher abstracts actual cost retrieval for testability.
+type actualCostFetcher interface {
+	GetActualCostWithOptionsAndErrors(
+		ctx context.Context, request engine.ActualCostRequest,
+	) (*engine.CostResultWithErrors, error)
+}
+
+// anomaliesJSONOutput represents the JSON output for the anomalies command.
+type anomaliesJSONOutput struct {
+	From         time.Time         `json:"from"`
+	To           time.Time         `json:"to"`
+	By           string            `json:"by"`
+	Method       string            `json:"method"`
+	Threshold    float64           `json:"threshold"`
+	SeriesCount  int               `json:"series_count"`
+	Anomalies    []anomaly.Anomaly `json:"anomalies"`
+	AnomalyCount int               `json:"anomaly_count"`
+}
+
+// NewCostAnomaliesCmd creates the "anomalies" subcommand, which fetches daily
+// actual costs over a lookback window and reports resources, resource types, or
+// providers whose spend on a given day deviates sharply from the rest of the
+// window. Detection uses z-score or IQR statistics with configurable sensitivity.
+func NewCostAnomaliesCmd() *cobra.Command {
+	var params costAnomaliesParams
+
+	cmd := &cobra.Command{
+		Use:   "anomalies",
+		Short: "Detect unusual daily spend in actual costs",
+		Long: `Detect unusual daily spend by fetching actual costs one day at a time over a
+lookback window and applying statistical outlier detection to each series.
+
+Series are built per resource, resource type, or provider (--by). Two methods
+are available:
+  zscore  flags days more than N standard deviations from the mean
+  iqr     flags days outside the interquartile range fences (robust to skew)
+
+--sensitivity selects a preset threshold (low reports only extreme deviations,
+high reports moderate ones); --threshold overrides the preset. Series with fewer
+than 7 days of data are skipped.`,
+		Example: `  # Detect anomalies over the last 30 days (auto-detect Pulumi project)
+  finfocus cost anomalies
+
+  # Use IQR detection per resource type with high sensitivity
+  finfocus cost anomalies --pulumi-state state.json --by type --method iqr --sensitivity high
+
+  # Look back 14 days from a specific date with an explicit z-score threshold
+  finfocus cost anomalies --pulumi-json plan.json --days 14 --to 2025-01-31 --threshold 3
+
+  # Output as JSON
+  finfocus cost anomalies --pulumi-state state.json --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostAnomalies(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().
+		StringVar(&params.statePath, "pulumi-state", "", "Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().StringVar(&params.toStr, "to", "", "End date (YYYY-MM-DD or RFC3339) (defaults to today)")
+	cmd.Flags().IntVar(&params.days, "days", defaultAnomalyDays, "Number of days to look back")
+	cmd.Flags().StringVar(&params.by, "by", anomalyByResource, "Build series by: resource, type, or provider")
+	cmd.Flags().StringVar(&params.method, "method", string(anomaly.MethodZScore), "Detection method: zscore or iqr")
+	cmd.Flags().StringVar(&params.sensitivity, "sensitivity", string(anomaly.SensitivityMedium),
+		"Detection sensitivity: low, medium, or high")
+	cmd.Flags().Float64Var(&params.threshold, "threshold", 0,
+		"Explicit detection threshold (overrides --sensitivity; 0 = use preset)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table, json, or ndjson")
+	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
+		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+
+	return cmd
+}
+
+// executeCostAnomalies validates flags, loads resources, collects daily actual
+// cost series, runs anomaly detection, and renders the results.
+func executeCostAnomalies(cmd *cobra.Command, params costAnomaliesParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	detector, err := newAnomalyDetector(params)
+	if err != nil {
+		return err
+	}
+	if validateErr := validateAnomalyParams(params); validateErr != nil {
+		return validateErr
+	}
+
+	from, to, err := resolveAnomalyWindow(params.toStr, params.days)
+	if err != nil {
+		return err
+	}
+
+	audit := newAuditContext(ctx, "cost anomalies", map[string]string{
+		"pulumi_json":  params.planPath,
+		"pulumi_state": params.statePath,
+		"by":           params.by,
+		"method":       string(detector.Method()),
+		"days":         strconv.Itoa(params.days),
+	})
+
+	resources, err := loadActualResources(ctx, cmd, costActualParams{
+		planPath:  params.planPath,
+		statePath: params.statePath,
+	}, audit)
+	if err != nil {
+		return err
+	}
+
+	resources, err = ApplyFilters(ctx, resources, params.filter)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("applying filters: %w", err)
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	eng := engine.New(clients, nil)
+	series, err := collectDailySeries(ctx, eng, resources, from, params.days, params.adapter, params.by)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	anomalies := detector.DetectAll(series)
+
+	log.Info().Ctx(ctx).Str("operation", "cost_anomalies").
+		Int("series_count", len(series)).Int("anomaly_count", len(anomalies)).
+		Dur("duration_ms", time.Since(audit.start)).Msg("anomaly detection complete")
+
+	output := anomaliesJSONOutput{
+		From:         from,
+		To:           to,
+		By:           params.by,
+		Method:       string(detector.Method()),
+		Threshold:    detector.Threshold(),
+		SeriesCount:  len(series),
+		Anomalies:    anomalies,
+		AnomalyCount: len(anomalies),
+	}
+
+	audit.logSuccess(ctx, len(anomalies), 0)
+	return renderAnomalies(cmd, params.output, output)
+}
+
+// newAnomalyDetector builds a detector from the method, sensitivity, and
+// threshold flags.
+func newAnomalyDetector(params costAnomaliesParams) (*anomaly.Detector, error) {
+	detector, err := anomaly.NewDetector(anomaly.Method(params.method), anomaly.Sensitivity(params.sensitivity))
+	if err != nil {
+		return nil, err
+	}
+	if params.threshold != 0 {
+		return detector.WithThreshold(params.threshold)
+	}
+	return detector, nil
+}
+
+// validateAnomalyParams validates input exclusivity and the --by, --days, and
+// --output flags.
+func validateAnomalyParams(params costAnomaliesParams) error {
+	if params.planPath != "" && params.statePath != "" {
+		return errors.New("--pulumi-json and --pulumi-state are mutually exclusive; use only one")
+	}
+
+	switch params.by {
+	case anomalyByResource, anomalyByType, anomalyByProvider:
+	default:
+		return fmt.Errorf("invalid --by value %q (valid: resource, type, provider)", params.by)
+	}
+
+	if params.days < anomaly.DefaultMinPoints || params.days > maxDateRangeDays {
+		return fmt.Errorf("--days must be between %d and %d, got %d",
+			anomaly.DefaultMinPoints, maxDateRangeDays, params.days)
+	}
+
+	switch params.output {
+	case outputFormatTable, outputFormatJSON, outputFormatNDJSON:
+	default:
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+
+	return nil
+}
+
+// resolveAnomalyWindow returns the [from, to) window of whole UTC days ending
+// at the start of the day containing toStr (or today when empty). The current
+// partial day is excluded so it is not reported as a drop.
+func resolveAnomalyWindow(toStr string, days int) (time.Time, time.Time, error) {
+	end := time.Now()
+	if toStr != "" {
+		parsed, err := ParseTime(toStr)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("parsing 'to' date: %w", err)
+		}
+		end = parsed
+	}
+
+	year, month, day := end.UTC().Date()
+	to := time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+	return to.AddDate(0, 0, -days), to, nil
+}
+
+// collectDailySeries fetches actual costs one day at a time and accumulates
+// them into series keyed by resource, resource type, or provider. Results that
+// carry errors are skipped so failed lookups are not counted as zero spend.
+func collectDailySeries(
+	ctx context.Context,
+	fetcher actualCostFetcher,
+	resources []engine.ResourceDescriptor,
+	from time.Time,
+	days int,
+	adapter string,
+	by string,
+) ([]anomaly.Series, error) {
+	log := logging.FromContext(ctx)
+	builder := anomaly.NewSeriesBuilder()
+
+	for i := range days {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		dayStart := from.AddDate(0, 0, i)
+		result, err := fetcher.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+			Resources: resources,
+			From:      dayStart,
+			To:        dayStart.AddDate(0, 0, 1),
+			Adapter:   adapter,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fetching actual costs for %s: %w", dayStart.Format("2006-01-02"), err)
+		}
+		if result == nil {
+			continue
+		}
+
+		if result.HasErrors() {
+			log.Debug().Ctx(ctx).Str("component", "cli").Str("operation", "cost_anomalies").
+				Str("day", dayStart.Format("2006-01-02")).Int("error_count", len(result.Errors)).
+				Msg("some resources failed actual cost lookup")
+		}
+
+		for _, r := range result.Results {
+			if r.Error != nil {
+				continue
+			}
+			builder.Add(anomalySeriesKey(r, by), dayStart, r.TotalCost, r.Currency)
+		}
+	}
+
+	return builder.Series(), nil
+}
+
+// anomalySeriesKey returns the series key for a cost result under the given grouping.
+func anomalySeriesKey(r engine.CostResult, by string) string {
+	switch by {
+	case anomalyByType:
+		return r.ResourceType
+	case anomalyByProvider:
+		return engine.ExtractProvider(r.ResourceType)
+	default:
+		return r.ResourceID
+	}
+}
+
+// renderAnomalies renders detection results in the requested format.
+func renderAnomalies(cmd *cobra.Command, format string, output anomaliesJSONOutput) error {
+	switch format {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(output); err != nil {
+			return fmt.Errorf("encoding anomalies JSON: %w", err)
+		}
+		return nil
+	case outputFormatNDJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		for _, a := range output.Anomalies {
+			if err := encoder.Encode(a); err != nil {
+				return fmt.Errorf("encoding anomalies NDJSON: %w", err)
+			}
+		}
+		return nil
+	case outputFormatTable:
+		return renderAnomaliesTable(cmd, output)
+	default:
+		return fmt.Errorf("unsupported output format: %s", format)
+	}
+}
+
+// renderAnomaliesTable renders anomalies as a table with a summary header.
+func renderAnomaliesTable(cmd *cobra.Command, output anomaliesJSONOutput) error {
+	cmd.Printf("Anomalies from %s to %s (by %s, %s threshold %.2f)\n\n",
+		output.From.Format("2006-01-02"),
+		output.To.AddDate(0, 0, -1).Format("2006-01-02"),
+		output.By, output.Method, output.Threshold)
+
+	if len(output.Anomalies) == 0 {
+		cmd.Printf("No anomalies detected across %d series.\n", output.SeriesCount)
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+
+	fmt.Fprintln(tw, "DATE\tKEY\tDIRECTION\tSPEND\tEXPECTED\tSCORE")
+	fmt.Fprintln(tw, "----\t---\t---------\t-----\t--------\t-----")
+
+	for _, a := range output.Anomalies {
+		currency := a.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f %s\t%.2f %s\t%.2f\n",
+			a.Date.Format("2006-01-02"),
+			a.Key,
+			strings.ToUpper(string(a.Direction)),
+			a.Value, currency,
+			a.Expected, currency,
+			a.Score,
+		)
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	cmd.Printf("\n%d anomalies across %d series.\n", len(output.Anomalies), output.SeriesCount)
+	return nil
+}
@@ -0,0 +1,195 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/anomaly"
+)
+
+// fakeActualCostFetcher returns per-day results from a callback.
+type fakeActualCostFetcher struct {
+	fn    func(day time.Time) (*engine.CostResultWithErrors, error)
+	calls int
+}
+
+func (f *fakeActualCostFetcher) GetActualCostWithOptionsAndErrors(
+	_ context.Context, request engine.ActualCostRequest,
+) (*engine.CostResultWithErrors, error) {
+	f.calls++
+	return f.fn(request.From)
+}
+
+func TestCollectDailySeries(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fetcher := &fakeActualCostFetcher{fn: func(day time.Time) (*engine.CostResultWithErrors, error) {
+		return &engine.CostResultWithErrors{Results: []engine.CostResult{
+			{ResourceID: "i-1", ResourceType: "aws:ec2/instance", TotalCost: float64(day.Day()), Currency: "USD"},
+			{ResourceID: "i-2", ResourceType: "aws:ec2/instance", TotalCost: 1, Currency: "USD"},
+			{ResourceID: "b-1", ResourceType: "gcp:storage/bucket", TotalCost: 2, Currency: "USD",
+				Error: &engine.StructuredError{Code: "PLUGIN_ERROR"}},
+		}}, nil
+	}}
+
+	t.Run("by resource", func(t *testing.T) {
+		series, err := collectDailySeries(t.Context(), fetcher, nil, from, 3, "", anomalyByResource)
+		require.NoError(t, err)
+		require.Len(t, series, 2, "errored results are skipped")
+		assert.Equal(t, "i-1", series[0].Key)
+		require.Len(t, series[0].Points, 3)
+		assert.InDelta(t, 3.0, series[0].Points[2].Value, 0.001)
+	})
+
+	t.Run("by type sums resources", func(t *testing.T) {
+		series, err := collectDailySeries(t.Context(), fetcher, nil, from, 2, "", anomalyByType)
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		assert.Equal(t, "aws:ec2/instance", series[0].Key)
+		assert.InDelta(t, 2.0, series[0].Points[0].Value, 0.001)
+	})
+
+	t.Run("by provider", func(t *testing.T) {
+		series, err := collectDailySeries(t.Context(), fetcher, nil, from, 1, "", anomalyByProvider)
+		require.NoError(t, err)
+		require.Len(t, series, 1)
+		assert.Equal(t, "aws", series[0].Key)
+	})
+
+	t.Run("fetch error propagates", func(t *testing.T) {
+		failing := &fakeActualCostFetcher{fn: func(time.Time) (*engine.CostResultWithErrors, error) {
+			return nil, errors.New("boom")
+		}}
+		_, err := collectDailySeries(t.Context(), failing, nil, from, 3, "", anomalyByResource)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "2026-01-01")
+		assert.Equal(t, 1, failing.calls)
+	})
+}
+
+func TestValidateAnomalyParams(t *testing.T) {
+	valid := costAnomaliesParams{by: anomalyByResource, days: 30, output: outputFormatTable}
+	require.NoError(t, validateAnomalyParams(valid))
+
+	tests := []struct {
+		name   string
+		mutate func(p *costAnomaliesParams)
+		errMsg string
+	}{
+		{name: "both inputs", mutate: func(p *costAnomaliesParams) {
+			p.planPath, p.statePath = "plan.json", "state.json"
+		}, errMsg: "mutually exclusive"},
+		{name: "bad by", mutate: func(p *costAnomaliesParams) { p.by = "tag" }, errMsg: "invalid --by"},
+		{name: "too few days", mutate: func(p *costAnomaliesParams) { p.days = 3 }, errMsg: "--days"},
+		{name: "too many days", mutate: func(p *costAnomaliesParams) { p.days = 400 }, errMsg: "--days"},
+		{name: "bad output", mutate: func(p *costAnomaliesParams) { p.output = "xml" }, errMsg: "unsupported output"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := valid
+			tt.mutate(&p)
+			err := validateAnomalyParams(p)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errMsg)
+		})
+	}
+}
+
+func TestNewAnomalyDetector(t *testing.T) {
+	d, err := newAnomalyDetector(costAnomaliesParams{method: "iqr", sensitivity: "low", threshold: 4})
+	require.NoError(t, err)
+	assert.Equal(t, anomaly.MethodIQR, d.Method())
+	assert.InDelta(t, 4.0, d.Threshold(), 0.001)
+
+	_, err = newAnomalyDetector(costAnomaliesParams{method: "bogus"})
+	require.ErrorIs(t, err, anomaly.ErrInvalidMethod)
+
+	_, err = newAnomalyDetector(costAnomaliesParams{threshold: -1})
+	require.ErrorIs(t, err, anomaly.ErrInvalidThreshold)
+}
+
+func TestResolveAnomalyWindow(t *testing.T) {
+	to := time.Now().AddDate(0, 0, -2).UTC()
+	from, end, err := resolveAnomalyWindow(to.Format("2006-01-02"), 7)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC), end)
+	assert.Equal(t, end.AddDate(0, 0, -7), from)
+
+	_, _, err = resolveAnomalyWindow("not-a-date", 7)
+	require.Error(t, err)
+}
+
+func TestRenderAnomalies(t *testing.T) {
+	output := anomaliesJSONOutput{
+		From:        time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		To:          time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC),
+		By:          anomalyByResource,
+		Method:      string(anomaly.MethodZScore),
+		Threshold:   2.5,
+		SeriesCount: 3,
+		Anomalies: []anomaly.Anomaly{{
+			Key: "i-123", Date: time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC),
+			Value: 50, Expected: 14, Score: 3, Direction: anomaly.DirectionSpike, Currency: "USD",
+		}},
+		AnomalyCount: 1,
+	}
+
+	t.Run("table", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&buf)
+		require.NoError(t, renderAnomalies(cmd, outputFormatTable, output))
+		out := buf.String()
+		assert.Contains(t, out, "Anomalies from 2026-01-01 to 2026-01-30")
+		assert.Contains(t, out, "i-123")
+		assert.Contains(t, out, "SPIKE")
+		assert.Contains(t, out, "1 anomalies across 3 series")
+	})
+
+	t.Run("table empty", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&buf)
+		empty := output
+		empty.Anomalies = nil
+		require.NoError(t, renderAnomalies(cmd, outputFormatTable, empty))
+		assert.Contains(t, buf.String(), "No anomalies detected across 3 series")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&buf)
+		require.NoError(t, renderAnomalies(cmd, outputFormatJSON, output))
+		var decoded anomaliesJSONOutput
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+		assert.Equal(t, 1, decoded.AnomalyCount)
+		assert.Equal(t, "i-123", decoded.Anomalies[0].Key)
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		var buf bytes.Buffer
+		cmd := &cobra.Command{}
+		cmd.SetOut(&buf)
+		require.NoError(t, renderAnomalies(cmd, outputFormatNDJSON, output))
+		assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("\n")))
+	})
+}
+
+func TestCostAnomaliesCmd_Flags(t *testing.T) {
+	cmd := NewCostAnomaliesCmd()
+	for _, name := range []string{
+		"pulumi-json", "pulumi-state", "to", "days", "by", "method", "sensitivity", "threshold", "adapter", "output", "filter",
+	} {
+		assert.NotNil(t, cmd.Flags().Lookup(name), "missing flag %s", name)
+	}
+	assert.Equal(t, "30", cmd.Flags().Lookup("days").DefValue)
+}
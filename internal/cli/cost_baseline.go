@@ -0,0 +1,129 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// parseMaxIncrease parses --max-increase, which takes a monthly amount with
+// --delta and an amount or a percentage with --baseline. It returns nil when
+// the flag is not set.
+func parseMaxIncrease(cmd *cobra.Command, params costProjectedParams) (*engine.IncreaseLimit, error) {
+	if !cmd.Flags().Changed("max-increase") {
+		return nil, nil //nolint:nilnil // no limit to apply
+	}
+	if !params.delta && params.baseline == "" {
+		return nil, &usageError{err: errors.New("--max-increase requires --delta or --baseline")}
+	}
+	limit, err := engine.ParseIncreaseLimit(params.maxIncrease)
+	if err != nil {
+		return nil, &usageError{err: fmt.Errorf("invalid --max-increase: %w", err)}
+	}
+	if params.delta && limit.Percent {
+		return nil, &usageError{
+			err: fmt.Errorf("--delta takes a monthly amount for --max-increase, got %q", params.maxIncrease),
+		}
+	}
+	return &limit, nil
+}
+
+// loadBaselineResults reads the results of --baseline: the file at ref, or
+// when no such file exists, the baseline saved under the name ref with
+// 'baseline save'.
+func loadBaselineResults(ref string) ([]engine.CostResult, error) {
+	data, err := os.ReadFile(ref)
+	if errors.Is(err, fs.ErrNotExist) {
+		if store := config.NewBaselineStore(""); store.Exists(ref) {
+			data, err = store.Load(ref)
+		}
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline: %w", err)
+	}
+	results, err := engine.ParseCostResults(data)
+	if err != nil {
+		return nil, fmt.Errorf("reading baseline %s: %w", ref, err)
+	}
+	return results, nil
+}
+
+// checkBaseline compares results with the baseline results saved at path and
+// renders the change in total, after the results in table output and to
+// stderr otherwise. When the total or any resource increases by more than
+// maxIncrease, it renders those increases and returns the BudgetExitError of
+// gateExitCode, or nil with a warning when that exit code is zero.
+func checkBaseline(
+	cmd *cobra.Command,
+	output, path string,
+	baseline, results []engine.CostResult,
+	maxIncrease *engine.IncreaseLimit,
+) error {
+	delta := engine.CompareWithBaseline(baseline, results)
+	w := cmd.OutOrStdout()
+	if config.GetOutputFormat(output) != outputFormatTable {
+		w = cmd.ErrOrStderr()
+	}
+	currency, _ := extractCurrencyFromResults(results)
+	fmt.Fprintf(w, "\nCompared with %s: %.2f -> %.2f %s/mo (%+.2f, %+.1f%%)\n",
+		path, delta.Baseline, delta.Projected, currency, delta.Change, delta.ChangePercent())
+	if maxIncrease == nil {
+		return nil
+	}
+
+	total, exceeding := engine.ExceedingChanges(delta, *maxIncrease)
+	if !total && len(exceeding) == 0 {
+		return nil
+	}
+	if err := renderBaselineIncreases(w, delta, total, exceeding, *maxIncrease); err != nil {
+		return err
+	}
+
+	var parts []string
+	if total {
+		parts = append(parts, "total")
+	}
+	if len(exceeding) > 0 {
+		parts = append(parts, fmt.Sprintf("%d resource(s)", len(exceeding)))
+	}
+	reason := fmt.Sprintf("cost increased by more than --max-increase %s since the baseline: %s",
+		maxIncrease, strings.Join(parts, ", "))
+	exitCode := gateExitCode(cmd)
+	if exitCode == 0 {
+		cmd.PrintErrf("WARNING: %s\n", reason)
+		return nil
+	}
+	return &BudgetExitError{ExitCode: exitCode, Reason: reason}
+}
+
+// renderBaselineIncreases renders the total, when it exceeds the limit, and
+// the resources whose increase exceeds it.
+func renderBaselineIncreases(
+	w io.Writer,
+	delta *engine.ProjectionDelta,
+	total bool,
+	exceeding []engine.ResourceCostChange,
+	limit engine.IncreaseLimit,
+) error {
+	fmt.Fprintf(w, "Increases above --max-increase %s:\n", limit)
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tTYPE\tBASELINE\tNOW\tCHANGE")
+	if total {
+		fmt.Fprintf(tw, "TOTAL\t\t%.2f\t%.2f\t%+.2f\n", delta.Baseline, delta.Projected, delta.Change)
+	}
+	for _, change := range exceeding {
+		_, name := engine.SplitURN(change.ResourceID)
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%.2f\t%+.2f\n",
+			name, dashIfEmpty(change.ResourceType), change.Before, change.After, change.Change)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func writeBaselineFile(t *testing.T, results []engine.CostResult) string {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, engine.RenderResults(&buf, engine.OutputJSON, results))
+	path := filepath.Join(t.TempDir(), "baseline.json")
+	require.NoError(t, os.WriteFile(path, buf.Bytes(), 0o600))
+	return path
+}
+
+func TestCheckBaseline(t *testing.T) {
+	path := writeBaselineFile(t, []engine.CostResult{
+		{ResourceID: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 100},
+		{ResourceID: "urn:pulumi:dev::app::aws:rds/instance:Instance::db",
+			ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 100},
+	})
+	baseline, err := loadBaselineResults(path)
+	require.NoError(t, err)
+	results := []engine.CostResult{
+		{ResourceID: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 130},
+		{ResourceID: "urn:pulumi:dev::app::aws:rds/instance:Instance::db",
+			ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 100},
+	}
+
+	t.Run("report only", func(t *testing.T) {
+		cmd := NewCostProjectedCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		require.NoError(t, checkBaseline(cmd, outputFormatTable, path, baseline, results, nil))
+		assert.Contains(t, out.String(), "Compared with "+path+": 200.00 -> 230.00 USD/mo (+30.00, +15.0%)")
+	})
+
+	t.Run("over the limit", func(t *testing.T) {
+		cmd := NewCostProjectedCmd()
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		err := checkBaseline(cmd, outputFormatTable, path, baseline, results,
+			&engine.IncreaseLimit{Value: 20, Percent: true})
+
+		var exitErr *BudgetExitError
+		require.ErrorAs(t, err, &exitErr)
+		assert.Equal(t, 1, exitErr.ExitCode)
+		assert.Equal(t, "cost increased by more than --max-increase 20% since the baseline: 1 resource(s)",
+			exitErr.Reason)
+		text := out.String()
+		assert.Contains(t, text, "Increases above --max-increase 20%:")
+		assert.Regexp(t, `web\s+aws:ec2/instance:Instance\s+100\.00\s+130\.00\s+\+30\.00`, text)
+		assert.NotContains(t, text, "TOTAL", "the total rose by 15%")
+	})
+
+	t.Run("warning only", func(t *testing.T) {
+		cmd := newCostCmd()
+		require.NoError(t, cmd.ParseFlags([]string{"--exit-code=0"}))
+		var out, errOut bytes.Buffer
+		cmd.SetOut(&out)
+		cmd.SetErr(&errOut)
+		require.NoError(t, checkBaseline(cmd, outputFormatJSON, path, baseline, results,
+			&engine.IncreaseLimit{Value: 10}))
+		assert.Empty(t, out.String(), "JSON output is left intact")
+		assert.Contains(t, errOut.String(), "TOTAL")
+		assert.Contains(t, errOut.String(),
+			"WARNING: cost increased by more than --max-increase 10.00 since the baseline: total, 1 resource(s)")
+	})
+}
+
+func TestLoadBaselineResults_Errors(t *testing.T) {
+	_, err := loadBaselineResults(filepath.Join(t.TempDir(), "missing.json"))
+	require.ErrorContains(t, err, "reading baseline")
+
+	path := filepath.Join(t.TempDir(), "table.txt")
+	require.NoError(t, os.WriteFile(path, []byte("RESOURCE  MONTHLY\n"), 0o600))
+	_, err = loadBaselineResults(path)
+	require.ErrorContains(t, err, "reading baseline "+path)
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// validateBreakdownOutput checks that --breakdown is combined with an output
+// format it can render.
+func validateBreakdownOutput(output string) error {
+	switch format := config.GetOutputFormat(output); format {
+	case outputFormatTable, outputFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("--breakdown supports --output table or json, got %q", format)
+	}
+}
+
+// renderCostBreakdown renders the results split by pricing dimension as a
+// table or, with --output json, as an engine.DimensionBreakdown.
+func renderCostBreakdown(cmd *cobra.Command, output string, results []engine.CostResult, basis string) error {
+	breakdown := engine.BuildDimensionBreakdown(results, basis)
+	if config.GetOutputFormat(output) == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(breakdown); err != nil {
+			return fmt.Errorf("encoding cost breakdown JSON: %w", err)
+		}
+		return nil
+	}
+	return renderCostBreakdownTable(cmd.OutOrStdout(), breakdown)
+}
+
+// renderCostBreakdownTable renders one row per resource with a column per
+// pricing dimension that has a cost, followed by the totals and the share of
+// each dimension.
+func renderCostBreakdownTable(w io.Writer, breakdown *engine.DimensionBreakdown) error {
+	fmt.Fprintf(w, "Cost by pricing dimension (%s, %s)\n\n", breakdown.Basis, breakdown.Currency)
+	if len(breakdown.Resources) == 0 {
+		fmt.Fprintln(w, "No costs to break down.")
+		return nil
+	}
+
+	headers := make([]string, 0, len(breakdown.Dimensions))
+	for _, dimension := range breakdown.Dimensions {
+		headers = append(headers, strings.ToUpper(strings.ReplaceAll(dimension, "_", " ")))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintf(tw, "RESOURCE\tTYPE\t%s\tTOTAL\n", strings.Join(headers, "\t"))
+	for _, resource := range breakdown.Resources {
+		fmt.Fprintf(tw, "%s\t%s\t", resource.ResourceID, resource.ResourceType)
+		for _, dimension := range breakdown.Dimensions {
+			fmt.Fprintf(tw, "%.2f\t", resource.Dimensions[dimension])
+		}
+		fmt.Fprintf(tw, "%.2f\n", resource.Total)
+	}
+
+	fmt.Fprint(tw, "TOTAL\t\t")
+	for _, dimension := range breakdown.Dimensions {
+		fmt.Fprintf(tw, "%.2f\t", breakdown.Totals[dimension])
+	}
+	fmt.Fprintf(tw, "%.2f\n", breakdown.Total)
+	if breakdown.Total > 0 {
+		fmt.Fprint(tw, "SHARE\t\t")
+		for _, dimension := range breakdown.Dimensions {
+			share := breakdown.Totals[dimension] / breakdown.Total * 100 //nolint:mnd // Percentage calculation.
+			fmt.Fprintf(tw, "%.1f%%\t", share)
+		}
+		fmt.Fprintln(tw, "100.0%")
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if breakdown.MixedCurrencies {
+		fmt.Fprintln(w, "\nWarning: results use more than one currency; totals are not converted.")
+	}
+	return nil
+}
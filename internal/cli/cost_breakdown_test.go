@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func breakdownFixtures() []engine.CostResult {
+	return []engine.CostResult{
+		{
+			ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 80,
+			Breakdown: map[string]float64{"ebs_storage": 20},
+		},
+		{ResourceID: "assets", ResourceType: "aws:s3/bucket:Bucket", Currency: "USD", Monthly: 20},
+	}
+}
+
+func TestRenderCostBreakdown_Table(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderCostBreakdown(cmd, outputFormatTable, breakdownFixtures(), engine.DimensionBasisMonthly))
+
+	text := out.String()
+	assert.Contains(t, text, "Cost by pricing dimension (monthly, USD)")
+	assert.Contains(t, text, "COMPUTE")
+	assert.Contains(t, text, "STORAGE")
+	assert.NotContains(t, text, "LICENSE", "dimensions without a cost are not shown")
+	assert.Contains(t, text, "60.00")
+	assert.Contains(t, text, "100.00")
+	assert.Contains(t, text, "60.0%")
+	assert.Contains(t, text, "40.0%")
+}
+
+func TestRenderCostBreakdown_JSON(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderCostBreakdown(cmd, outputFormatJSON, breakdownFixtures(), engine.DimensionBasisMonthly))
+
+	var got engine.DimensionBreakdown
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, engine.DimensionBasisMonthly, got.Basis)
+	assert.InDelta(t, 100.0, got.Total, 0.001)
+	assert.InDelta(t, 40.0, got.Totals[engine.DimensionStorage], 0.001)
+	require.Len(t, got.Resources, 2)
+	assert.InDelta(t, 60.0, got.Resources[0].Dimensions[engine.DimensionCompute], 0.001)
+}
+
+func TestRenderCostBreakdown_Empty(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderCostBreakdown(cmd, outputFormatTable, nil, engine.DimensionBasisTotal))
+	assert.Contains(t, out.String(), "No costs to break down.")
+}
+
+func TestBreakdown_RejectsUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  func() *cobra.Command
+		args []string
+		want string
+	}{
+		{"projected ndjson", NewCostProjectedCmd, []string{"--breakdown", "--output", "ndjson"},
+			`--breakdown supports --output table or json, got "ndjson"`},
+		{"projected comment", NewCostProjectedCmd, []string{"--breakdown", "--output", "github-comment"},
+			"--breakdown supports --output table or json"},
+		{"actual ndjson", NewCostActualCmd, []string{"--breakdown", "--output", "ndjson"},
+			"--breakdown supports --output table or json"},
+		{"actual daily", NewCostActualCmd, []string{"--breakdown", "--group-by", "daily"},
+			"--breakdown cannot be combined with --group-by daily"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -5,7 +5,9 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
 	"github.com/spf13/cobra"
@@ -17,6 +19,7 @@ import (
 
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui"
 )
 
 // Budget rendering constants.
@@ -78,12 +81,19 @@ func RenderBudgetStatus(w io.Writer, status *engine.BudgetStatus) error {
 	}
 
 	// Detect if we're writing to a TTY
-	if isWriterTerminal(w) {
+	if useStyledBudget(w) {
 		return renderStyledBudget(w, status)
 	}
 	return renderPlainBudget(w, status)
 }
 
+// useStyledBudget reports whether budgets are drawn as styled boxes on w: w is
+// a terminal and --plain is not set. Without colors the boxes keep their
+// borders.
+func useStyledBudget(w io.Writer) bool {
+	return isWriterTerminal(w) && !tui.CurrentOutputControls().Plain
+}
+
 // isWriterTerminal checks if the writer is a terminal (TTY).
 // isWriterTerminal reports whether the provided io.Writer refers to a terminal.
 // It returns true when w is an *os.File whose file descriptor is a terminal, and false for any other writer.
@@ -404,7 +414,12 @@ func calculateProgressBarWidth(boxWidth int) int {
 // command output immediately before the rendered status when a budget is shown.
 //
 // The returned BudgetStatus can be used for exit code evaluation.
-func renderBudgetIfConfigured(cmd *cobra.Command, totalCost float64, currency string) (*engine.BudgetStatus, error) {
+func renderBudgetIfConfigured(
+	cmd *cobra.Command,
+	totalCost float64,
+	currency string,
+	window *spendWindow,
+) (*engine.BudgetStatus, error) {
 	// Get the global configuration
 	cfg := config.GetGlobalConfig()
 	if cfg == nil || !cfg.Cost.HasBudget() {
@@ -437,6 +452,13 @@ func renderBudgetIfConfigured(cmd *cobra.Command, totalCost float64, currency st
 		budgetConfig.ExitCode = *budgetsCfg.ExitCode
 	}
 
+	// Carry unspent budget from the previous period forward when rollover is enabled
+	now := time.Now()
+	history := loadBudgetHistory(cmd, budgetsCfg)
+	if history != nil {
+		budgetConfig.Amount += engine.CalculateRolloverAmount(globalBudget, history, "global", now)
+	}
+
 	// Create budget engine and evaluate
 	budgetEngine := engine.NewBudgetEngine()
 	status, err := budgetEngine.Evaluate(budgetConfig, totalCost, currency)
@@ -445,6 +467,15 @@ func renderBudgetIfConfigured(cmd *cobra.Command, totalCost float64, currency st
 		return nil, fmt.Errorf("evaluating budget: %w", err)
 	}
 
+	effectiveGlobal := *globalBudget
+	effectiveGlobal.Amount = budgetConfig.Amount
+	recordBudgetHistory(cmd, history, []*engine.ScopedBudgetStatus{{
+		ScopeType:    engine.ScopeTypeGlobal,
+		Budget:       effectiveGlobal,
+		CurrentSpend: totalCost,
+		Currency:     currency,
+	}}, window, now)
+
 	// Add a blank line before budget status
 	cmd.Println()
 
@@ -463,19 +494,26 @@ type BudgetRenderResult struct {
 	LegacyStatus *engine.BudgetStatus
 	// ScopedResult is set when using scoped budget configuration.
 	ScopedResult *engine.ScopedBudgetResult
+	// CarbonResult is set when carbon budgets are configured, alongside
+	// either cost budget result.
+	CarbonResult *engine.ScopedBudgetResult
 }
 
 // renderBudgetWithScope renders budget status using either scoped or legacy budgets.
 // It automatically detects which configuration style is in use and renders appropriately.
 // The scopeFilter parameter is only used when scoped budgets are configured.
+// tags maps resource IDs to their tags for tag budgets (see resourceTagIndex).
+// window is the range of the actual costs, or nil for projected costs.
 //
 // This is the main entry point for budget rendering in cost commands.
 func renderBudgetWithScope(
 	cmd *cobra.Command,
 	costs []engine.CostResult,
+	tags map[string]map[string]string,
 	totalCost float64,
 	currency string,
 	scopeFilter string,
+	window *spendWindow,
 ) (*BudgetRenderResult, error) {
 	cfg := config.GetGlobalConfig()
 	if cfg == nil {
@@ -483,26 +521,48 @@ func renderBudgetWithScope(
 	}
 
 	// Check if scoped budgets are configured (provider/tag/type)
+	var result *BudgetRenderResult
 	budgetsCfg := cfg.Cost.Budgets
 	if budgetsCfg != nil && budgetsCfg.HasScopedBudgets() {
 		// Use scoped budget rendering
-		result, err := renderScopedBudgetIfConfigured(cmd, costs, scopeFilter)
+		scoped, err := renderScopedBudgetIfConfigured(cmd, costs, tags, scopeFilter, window)
 		if err != nil {
 			return nil, err
 		}
-		return &BudgetRenderResult{ScopedResult: result}, nil
+		result = &BudgetRenderResult{ScopedResult: scoped}
+	} else {
+		// Fall back to legacy budget rendering
+		status, err := renderBudgetIfConfigured(cmd, totalCost, currency, window)
+		if err != nil {
+			return nil, err
+		}
+		result = &BudgetRenderResult{LegacyStatus: status}
 	}
 
-	// Fall back to legacy budget rendering
-	status, err := renderBudgetIfConfigured(cmd, totalCost, currency)
+	carbon, err := renderCarbonBudgetIfConfigured(cmd, costs, tags, scopeFilter)
 	if err != nil {
 		return nil, err
 	}
-	return &BudgetRenderResult{LegacyStatus: status}, nil
+	result.CarbonResult = carbon
+	return result, nil
+}
+
+// budgetsNeedResults reports whether the configured budgets evaluate the
+// individual results, by scope or carbon footprint, rather than only their
+// total. impact adds the budget impact that table output projects from them.
+func budgetsNeedResults(impact bool) bool {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || cfg.Cost.Budgets == nil {
+		return false
+	}
+	budgets := cfg.Cost.Budgets
+	return budgets.HasScopedBudgets() || budgets.HasCarbonBudgets() || (impact && budgets.IsEnabled())
 }
 
 // checkBudgetExitFromResult evaluates whether the CLI should exit based on budget result.
-// It handles both legacy and scoped budget results.
+// It handles both legacy and scoped budget results. Threshold-based exits
+// (--exit-on-threshold) are checked first; the --fail-on health policy is
+// applied afterwards so either mechanism can fail the command.
 func checkBudgetExitFromResult(cmd *cobra.Command, result *BudgetRenderResult, evalErr error) error {
 	// Handle evaluation errors first - propagate them consistently
 	if evalErr != nil {
@@ -514,21 +574,125 @@ func checkBudgetExitFromResult(cmd *cobra.Command, result *BudgetRenderResult, e
 	}
 
 	if result.LegacyStatus != nil {
-		return checkBudgetExit(cmd, result.LegacyStatus, nil)
+		if err := checkBudgetExit(cmd, result.LegacyStatus, nil); err != nil {
+			return err
+		}
 	}
 
-	// For scoped budgets, check if any scope is critical/exceeded
-	if result.ScopedResult != nil && result.ScopedResult.HasCriticalBudgets() {
-		return checkScopedBudgetExit(cmd, result.ScopedResult)
+	// For scoped and carbon budgets, check if any scope is critical/exceeded
+	for _, scoped := range []*engine.ScopedBudgetResult{result.ScopedResult, result.CarbonResult} {
+		if scoped != nil && scoped.HasCriticalBudgets() {
+			if err := checkScopedBudgetExit(cmd, scoped); err != nil {
+				return err
+			}
+		}
 	}
 
-	return nil
+	return checkFailOnPolicy(cmd, result)
+}
+
+// getFailOnLevel returns the --fail-on flag value or empty string if not set.
+func getFailOnLevel(cmd *cobra.Command) string {
+	if flag := cmd.Flag("fail-on"); flag != nil {
+		return flag.Value.String()
+	}
+	return ""
+}
+
+// Health returns the aggregated budget health for the rendered result.
+// Legacy budgets derive health from utilization; scoped budgets use the
+// worst-wins OverallHealth, and carbon budgets count toward it the same way.
+// Returns UNSPECIFIED when no budget was evaluated.
+func (r *BudgetRenderResult) Health() pbc.BudgetHealthStatus {
+	if r == nil {
+		return pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED
+	}
+
+	var statuses []pbc.BudgetHealthStatus
+	switch {
+	case r.LegacyStatus != nil:
+		statuses = append(statuses, engine.CalculateBudgetHealthFromPercentage(r.LegacyStatus.Percentage))
+	case r.ScopedResult != nil:
+		statuses = append(statuses, r.ScopedResult.OverallHealth)
+	}
+	if r.CarbonResult != nil {
+		statuses = append(statuses, r.CarbonResult.OverallHealth)
+	}
+	return engine.AggregateHealthStatuses(statuses)
+}
+
+// checkFailOnPolicy applies the --fail-on exit policy to the aggregated budget health.
+// It returns a BudgetExitError when the health is at or above the requested level,
+// using the exit code of gateExitCode. An exit code of
+// zero keeps the warning-only semantics used by --exit-on-threshold.
+func checkFailOnPolicy(cmd *cobra.Command, result *BudgetRenderResult) error {
+	level := getFailOnLevel(cmd)
+	if level == "" {
+		return nil
+	}
+
+	threshold, err := engine.ParseHealthLevel(level)
+	if err != nil {
+		return fmt.Errorf("invalid --fail-on value: %w", err)
+	}
+
+	health := result.Health()
+	if !engine.HealthAtOrAbove(health, threshold) {
+		return nil
+	}
+
+	exitCode := gateExitCode(cmd)
+	reason := fmt.Sprintf("budget health %s meets --fail-on level %s",
+		healthLevelName(health), strings.ToLower(level))
+
+	if cmd.Flag("debug") != nil && cmd.Flag("debug").Changed {
+		cmd.PrintErrf("DEBUG: %s\n", reason)
+	}
+
+	if exitCode == 0 {
+		cmd.PrintErrf("WARNING: %s\n", reason)
+		return nil
+	}
+
+	return &BudgetExitError{
+		ExitCode: exitCode,
+		Reason:   reason,
+	}
+}
+
+// gateExitCode returns the exit code of a failed cost gate such as --fail-on:
+// --exit-code, then the configured global budget exit code, then 1.
+func gateExitCode(cmd *cobra.Command) int {
+	var budgetsCfg *config.BudgetsConfig
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		budgetsCfg = cfg.Cost.Budgets
+	}
+	var scopeExitCode *int
+	if budgetsCfg != nil {
+		scopeExitCode = budgetsCfg.Global.GetExitCode()
+	}
+	exitCode := budgetsCfg.GetEffectiveExitCode(scopeExitCode)
+	if flag := cmd.Flag("exit-code"); flag != nil && flag.Changed {
+		if code, parseErr := strconv.Atoi(flag.Value.String()); parseErr == nil {
+			exitCode = code
+		}
+	}
+	return exitCode
+}
+
+// healthLevelName returns the lowercase --fail-on level name for a health status.
+func healthLevelName(health pbc.BudgetHealthStatus) string {
+	return strings.ToLower(strings.TrimPrefix(health.String(), "BUDGET_HEALTH_STATUS_"))
 }
 
 // checkScopedBudgetExit checks whether any critical/exceeded scoped budget should trigger a non-zero exit.
 func checkScopedBudgetExit(cmd *cobra.Command, scopedResult *engine.ScopedBudgetResult) error {
 	isDebug := cmd.Flag("debug") != nil && cmd.Flag("debug").Changed
-	reason := fmt.Sprintf("budget exceeded: %d critical scope(s)", len(scopedResult.CriticalScopes))
+	kind := "budget"
+	if scopedResult.Metric == engine.BudgetMetricCarbon {
+		kind = "carbon budget"
+	}
+	reason := fmt.Sprintf("%s exceeded: %d critical scope(s)", kind, len(scopedResult.CriticalScopes))
 
 	if isDebug {
 		cmd.PrintErrf("DEBUG: %s: %v\n", reason, scopedResult.CriticalScopes)
@@ -575,7 +739,9 @@ func checkScopedBudgetExit(cmd *cobra.Command, scopedResult *engine.ScopedBudget
 func renderScopedBudgetIfConfigured(
 	cmd *cobra.Command,
 	costs []engine.CostResult,
+	tags map[string]map[string]string,
 	scopeFilter string,
+	window *spendWindow,
 ) (*engine.ScopedBudgetResult, error) {
 	cfg := config.GetGlobalConfig()
 	if cfg == nil {
@@ -597,8 +763,19 @@ func renderScopedBudgetIfConfigured(
 	// Create scoped budget evaluator
 	eval := engine.NewScopedBudgetEvaluator(budgetsCfg)
 
+	// Attach budget history so rollover-enabled scopes carry unspent amounts forward
+	now := time.Now()
+	history := loadBudgetHistory(cmd, budgetsCfg)
+	if history != nil {
+		eval.WithSpendHistory(history, func() time.Time { return now })
+	}
+
+	// Resolve the active Pulumi stack for stack-scoped budgets
+	activeStack := engine.ResolveActiveStack(costs, getStackFlag(cmd))
+
 	// Allocate costs and evaluate all scopes
-	result := evaluateScopedBudgets(cmd.Context(), eval, budgetsCfg, costs)
+	result := evaluateScopedBudgets(cmd.Context(), eval, budgetsCfg, costs, tags, activeStack)
+	recordBudgetHistory(cmd, history, result.AllScopes(), window, now)
 
 	// Add a blank line before budget status
 	cmd.Println()
@@ -612,57 +789,240 @@ func renderScopedBudgetIfConfigured(
 	return result, nil
 }
 
-// evaluateScopedBudgets allocates costs to scopes and calculates budget statuses.
-func evaluateScopedBudgets(
+// renderCarbonBudgetIfConfigured evaluates the carbon budgets against the
+// carbon footprints that plugins reported for costs and renders their status
+// after the cost budgets. Carbon budgets have no stack scope, so no active
+// stack is resolved.
+//
+// Returns the carbon ScopedBudgetResult for exit code evaluation, or nil if no
+// carbon budgets are configured.
+func renderCarbonBudgetIfConfigured(
+	cmd *cobra.Command,
+	costs []engine.CostResult,
+	tags map[string]map[string]string,
+	scopeFilter string,
+) (*engine.ScopedBudgetResult, error) {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || !cfg.Cost.Budgets.HasCarbonBudgets() {
+		return nil, nil //nolint:nilnil // intentionally returns nil,nil when no carbon budget configured
+	}
+
+	ctx := cmd.Context()
+	carbonCfg := cfg.Cost.Budgets.Carbon.Scopes(cfg.Cost.Budgets)
+	footprints, missing := engine.CarbonBudgetCosts(ctx, costs)
+	result := evaluateScopedBudgets(ctx, engine.NewScopedBudgetEvaluator(carbonCfg), carbonCfg, footprints, tags, "")
+	engine.MarkCarbonBudgetResult(result)
+	if missing > 0 {
+		result.Warnings = append(result.Warnings, fmt.Sprintf(
+			"%d resource(s) report no carbon footprint and count as 0 %s", missing, config.CarbonBudgetUnit))
+	}
+
+	cmd.Println()
+
+	filter := NewBudgetScopeFilter(scopeFilter)
+	if renderErr := RenderScopedBudgetStatus(cmd.OutOrStdout(), result, filter); renderErr != nil {
+		return result, renderErr
+	}
+
+	return result, nil
+}
+
+// loadBudgetHistory opens the budget history store when any budget has rollover
+// enabled. Returns nil when rollover is not configured. Load failures are
+// reported as warnings and disable rollover for this run rather than failing
+// the command.
+func loadBudgetHistory(cmd *cobra.Command, budgetsCfg *config.BudgetsConfig) *config.BudgetHistoryStore {
+	if !budgetsCfg.HasRollover() {
+		return nil
+	}
+
+	store := config.NewBudgetHistoryStore("")
+	if err := store.Load(); err != nil {
+		cmd.PrintErrf("Warning: budget rollover disabled: %v\n", err)
+		return nil
+	}
+	return store
+}
+
+// spendWindow is the --from/--to range of the costs of a "cost actual" run.
+type spendWindow struct {
+	from, to time.Time
+}
+
+// coversPeriod reports whether the window holds the spend of the budget period
+// containing now and nothing else: it starts when the period starts and reaches
+// the current day.
+func (w *spendWindow) coversPeriod(now time.Time) bool {
+	if w == nil {
+		return false
+	}
+	start, _ := engine.BudgetPeriodBounds(now)
+	today := now.UTC().Truncate(24 * time.Hour)
+	return w.from.Equal(start) && !w.to.Before(today)
+}
+
+// recordBudgetHistory stores the current period's effective amount and spend for
+// every rollover-enabled scope so the next period can carry the unspent amount
+// forward. Only "cost actual" runs whose window covers the current period are
+// recorded: projected costs are estimates rather than spend, and a window that
+// starts elsewhere or ends early measures the spend of some other range. Save
+// failures are reported as warnings.
+func recordBudgetHistory(
+	cmd *cobra.Command,
+	store *config.BudgetHistoryStore,
+	statuses []*engine.ScopedBudgetStatus,
+	window *spendWindow,
+	now time.Time,
+) {
+	if store == nil || !window.coversPeriod(now) {
+		return
+	}
+
+	period := engine.BudgetPeriodKey(now)
+	recorded := 0
+	for _, status := range statuses {
+		if status == nil || !status.Budget.Rollover {
+			continue
+		}
+		if err := store.RecordPeriod(status.ScopeIdentifier(), period, config.BudgetPeriodRecord{
+			Amount:     status.Budget.Amount,
+			Spend:      status.CurrentSpend,
+			Currency:   status.Currency,
+			RecordedAt: now,
+		}); err != nil {
+			cmd.PrintErrf("Warning: failed to record budget history: %v\n", err)
+			continue
+		}
+		recorded++
+	}
+
+	if recorded == 0 {
+		return
+	}
+	if err := store.Save(); err != nil {
+		cmd.PrintErrf("Warning: failed to save budget history: %v\n", err)
+	}
+}
+
+// getStackFlag returns the --stack flag value, or the configured default
+// stack (defaults.stack) when the flag is not set.
+func getStackFlag(cmd *cobra.Command) string {
+	if flag := cmd.Flag("stack"); flag != nil && flag.Value.String() != "" {
+		return flag.Value.String()
+	}
+	return config.GetGlobalConfig().DefaultStack()
+}
+
+// scopeSpend accumulates the spend allocated to each budget scope.
+type scopeSpend struct {
+	global               float64
+	provider             map[string]float64
+	tag                  map[string]float64
+	typ                  map[string]float64
+	stack                map[string]float64
+	unallocated          float64
+	unallocatedResources int
+}
+
+// allocateScopeSpend allocates each cost to its budget scopes. tags maps
+// resource IDs to their tags for tag budgets; costs of resources matching no
+// tag budget are counted as unallocated. Allocation stops early if ctx is
+// cancelled.
+func allocateScopeSpend(
 	ctx context.Context,
 	eval *engine.ScopedBudgetEvaluator,
-	cfg *config.BudgetsConfig,
 	costs []engine.CostResult,
-) *engine.ScopedBudgetResult {
-	result := &engine.ScopedBudgetResult{
-		ByProvider: make(map[string]*engine.ScopedBudgetStatus),
-		ByType:     make(map[string]*engine.ScopedBudgetStatus),
+	tags map[string]map[string]string,
+	activeStack string,
+) *scopeSpend {
+	spend := &scopeSpend{
+		provider: make(map[string]float64),
+		tag:      make(map[string]float64),
+		typ:      make(map[string]float64),
+		stack:    make(map[string]float64),
 	}
 
-	// Track spend per scope
-	globalSpend := 0.0
-	providerSpend := make(map[string]float64)
-	tagSpend := make(map[string]float64)
-	typeSpend := make(map[string]float64)
-
-	// Allocate each cost result to appropriate scopes
 	for _, cost := range costs {
 		// Check for context cancellation to support graceful shutdown
-		if err := ctx.Err(); err != nil {
-			return result // Return partial result on cancellation
+		if ctx.Err() != nil {
+			return spend
 		}
 
 		// All costs count toward global
-		globalSpend += cost.Monthly
+		spend.global += cost.Monthly
 
 		// Allocate to provider
 		allocation := eval.AllocateCostToProvider(ctx, cost.ResourceType, cost.Monthly)
 		if allocation.Provider != "" {
-			providerSpend[allocation.Provider] += cost.Monthly
+			spend.provider[allocation.Provider] += cost.Monthly
 		}
 
-		// NOTE: Tag budget allocation is not implemented here because CostResult
-		// does not carry tag information. Tag-based budgets require tags to be
-		// passed through from the original ResourceDescriptor.
-		// Future enhancement: Add Tags field to CostResult or pass resources alongside costs.
-		_ = tagSpend // silence unused warning until tag budgets are fully implemented
+		// Allocate to the highest-priority matching tag budget
+		if eval.HasTagBudgets() {
+			tagAlloc := eval.AllocateCostToTag(ctx, cost.ResourceType, tags[cost.ResourceID], cost.Monthly)
+			if tagAlloc.SelectedTagBudget != "" {
+				spend.tag[tagAlloc.SelectedTagBudget] += cost.Monthly
+			} else {
+				spend.unallocated += cost.Monthly
+				spend.unallocatedResources++
+			}
+		}
 
 		// Allocate to type (if type budget exists)
 		if eval.GetTypeBudget(cost.ResourceType) != nil {
-			typeSpend[cost.ResourceType] += cost.Monthly
+			spend.typ[cost.ResourceType] += cost.Monthly
 		}
+
+		// Allocate to stack (if stack budget exists for the resource's stack)
+		stackAlloc := eval.AllocateCostToStack(ctx, cost.ResourceID, cost.ResourceType, activeStack, cost.Monthly)
+		for _, scope := range stackAlloc.AllocatedScopes {
+			spend.stack[strings.TrimPrefix(scope, "stack:")] += cost.Monthly
+		}
+	}
+	return spend
+}
+
+// resourceTagIndex maps resource IDs to their tags for tag budget allocation.
+func resourceTagIndex(resources []engine.ResourceDescriptor) map[string]map[string]string {
+	tags := make(map[string]map[string]string, len(resources))
+	for _, r := range resources {
+		tags[r.ID] = engine.ResourceTags(r.Properties)
+	}
+	return tags
+}
+
+// evaluateScopedBudgets allocates costs to scopes and calculates budget statuses.
+// tags maps resource IDs to their tags for tag budgets and may be nil, in which
+// case every cost is unallocated when tag budgets are configured. activeStack is
+// used for stack budgets when a resource ID does not carry a stack URN.
+func evaluateScopedBudgets(
+	ctx context.Context,
+	eval *engine.ScopedBudgetEvaluator,
+	cfg *config.BudgetsConfig,
+	costs []engine.CostResult,
+	tags map[string]map[string]string,
+	activeStack string,
+) *engine.ScopedBudgetResult {
+	result := &engine.ScopedBudgetResult{
+		ByProvider: make(map[string]*engine.ScopedBudgetStatus),
+		ByType:     make(map[string]*engine.ScopedBudgetStatus),
+		ByStack:    make(map[string]*engine.ScopedBudgetStatus),
 	}
 
+	spend := allocateScopeSpend(ctx, eval, costs, tags, activeStack)
+	if ctx.Err() != nil {
+		return result // Return partial result on cancellation
+	}
+	globalSpend, providerSpend, tagSpend := spend.global, spend.provider, spend.tag
+	typeSpend, stackSpend := spend.typ, spend.stack
+
 	// Calculate global status
 	if cfg.Global != nil {
-		result.Global = engine.CalculateProviderBudgetStatus("", cfg.Global, globalSpend)
+		budget, carried := eval.EffectiveBudget("global", cfg.Global)
+		result.Global = engine.CalculateProviderBudgetStatus("", budget, globalSpend)
 		result.Global.ScopeType = engine.ScopeTypeGlobal
 		result.Global.ScopeKey = ""
+		result.Global.RolloverAmount = carried
 	}
 
 	// Calculate provider statuses (skip nil budgets)
@@ -671,7 +1031,9 @@ func evaluateScopedBudgets(
 			continue
 		}
 		spend := providerSpend[provider]
-		status := engine.CalculateProviderBudgetStatus(provider, budget, spend)
+		effective, carried := eval.EffectiveBudget("provider:"+provider, budget)
+		status := engine.CalculateProviderBudgetStatus(provider, effective, spend)
+		status.RolloverAmount = carried
 		result.ByProvider[provider] = status
 	}
 
@@ -681,9 +1043,15 @@ func evaluateScopedBudgets(
 			continue
 		}
 		spend := tagSpend[tagBudget.Selector]
+		effective, carried := eval.EffectiveBudget("tag:"+tagBudget.Selector, &tagBudget.ScopedBudget)
+		tagBudget.ScopedBudget = *effective
 		status := engine.CalculateTagBudgetStatus(&tagBudget, spend)
+		status.RolloverAmount = carried
 		result.ByTag = append(result.ByTag, status)
 	}
+	if eval.HasTagBudgets() {
+		result.Unallocated = unallocatedSpend(spend, result.ByTag)
+	}
 
 	// Calculate type statuses (skip nil budgets)
 	for resourceType, budget := range cfg.Types {
@@ -691,12 +1059,25 @@ func evaluateScopedBudgets(
 			continue
 		}
 		spend := typeSpend[resourceType]
-		status := engine.CalculateProviderBudgetStatus(resourceType, budget, spend)
+		effective, carried := eval.EffectiveBudget("type:"+resourceType, budget)
+		status := engine.CalculateProviderBudgetStatus(resourceType, effective, spend)
 		status.ScopeType = engine.ScopeTypeType
 		status.ScopeKey = resourceType
+		status.RolloverAmount = carried
 		result.ByType[resourceType] = status
 	}
 
+	// Calculate stack statuses (skip nil budgets)
+	for stack, budget := range cfg.Stacks {
+		if budget == nil {
+			continue
+		}
+		effective, carried := eval.EffectiveBudget("stack:"+stack, budget)
+		status := engine.CalculateStackBudgetStatus(stack, effective, stackSpend[stack])
+		status.RolloverAmount = carried
+		result.ByStack[stack] = status
+	}
+
 	// Calculate overall health (worst wins)
 	healthStatuses := collectHealthStatuses(result)
 	result.OverallHealth = engine.AggregateHealthStatuses(healthStatuses)
@@ -707,6 +1088,22 @@ func evaluateScopedBudgets(
 	return result
 }
 
+// unallocatedSpend summarizes the spend matching no tag budget, in the
+// currency of the tag budgets.
+func unallocatedSpend(spend *scopeSpend, tagStatuses []*engine.ScopedBudgetStatus) *engine.UnallocatedSpend {
+	unallocated := &engine.UnallocatedSpend{
+		CurrentSpend: spend.unallocated,
+		Resources:    spend.unallocatedResources,
+	}
+	if spend.global > 0 {
+		unallocated.Percentage = spend.unallocated / spend.global * 100 //nolint:mnd // Percentage calculation.
+	}
+	if len(tagStatuses) > 0 {
+		unallocated.Currency = tagStatuses[0].Currency
+	}
+	return unallocated
+}
+
 // collectHealthStatuses gathers all health statuses from a scoped budget result.
 func collectHealthStatuses(result *engine.ScopedBudgetResult) []pbc.BudgetHealthStatus {
 	var statuses []pbc.BudgetHealthStatus
@@ -727,6 +1124,10 @@ func collectHealthStatuses(result *engine.ScopedBudgetResult) []pbc.BudgetHealth
 		statuses = append(statuses, status.Health)
 	}
 
+	for _, status := range result.ByStack {
+		statuses = append(statuses, status.Health)
+	}
+
 	return statuses
 }
 
@@ -761,6 +1162,12 @@ func identifyCriticalScopes(result *engine.ScopedBudgetResult) []string {
 		}
 	}
 
+	for key, status := range result.ByStack {
+		if isCritical(status.Health) {
+			critical = append(critical, "stack:"+key)
+		}
+	}
+
 	return critical
 }
 
@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"io"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/text/language"
+	"golang.org/x/text/message"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// renderBudgetImpactIfConfigured renders the budget impact section of cost
+// projected after the budget status: for each budget scope that projected
+// costs are allocated to, the share of its budget they would use after the
+// deploy. tags maps resource IDs to their tags for tag budgets. Nothing is
+// rendered when no cost budget is configured.
+func renderBudgetImpactIfConfigured(
+	cmd *cobra.Command,
+	costs []engine.CostResult,
+	tags map[string]map[string]string,
+) error {
+	cfg := config.GetGlobalConfig()
+	if cfg == nil || cfg.Cost.Budgets == nil || !cfg.Cost.Budgets.IsEnabled() {
+		return nil
+	}
+
+	eval := engine.NewScopedBudgetEvaluator(cfg.Cost.Budgets)
+	if history := loadBudgetHistory(cmd, cfg.Cost.Budgets); history != nil {
+		now := time.Now()
+		eval.WithSpendHistory(history, func() time.Time { return now })
+	}
+	impacts := eval.ProjectBudgetImpact(cmd.Context(), costs, tags)
+	if len(impacts) == 0 {
+		return nil
+	}
+	cmd.Println()
+	return renderBudgetImpact(cmd.OutOrStdout(), impacts)
+}
+
+// renderBudgetImpact renders one line per scope, such as "provider:aws would
+// reach 92% of budget after this deploy".
+func renderBudgetImpact(w io.Writer, impacts []engine.BudgetImpact) error {
+	p := message.NewPrinter(language.English)
+	if _, err := p.Fprintln(w, "Budget impact:"); err != nil {
+		return err
+	}
+	for _, impact := range impacts {
+		symbol := currencySymbol(impact.Currency)
+		if _, err := p.Fprintf(w, "  %s would reach %.0f%% of budget after this deploy (%s%.2f of %s%.2f) | %s\n",
+			impact.Scope, impact.Percentage, symbol, impact.Projected, symbol, impact.Budget,
+			healthStatusLabel(impact.Health)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
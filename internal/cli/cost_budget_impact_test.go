@@ -0,0 +1,43 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestRenderBudgetImpactIfConfigured(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	costs := []engine.CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 1150},
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	config.SetGlobalConfig(&config.Config{})
+	require.NoError(t, renderBudgetImpactIfConfigured(cmd, costs, nil))
+	assert.Empty(t, out.String(), "no budgets, no impact section")
+
+	config.SetGlobalConfig(&config.Config{Cost: config.CostConfig{Budgets: &config.BudgetsConfig{
+		Global:    &config.ScopedBudget{Amount: 5000, Currency: "USD"},
+		Providers: map[string]*config.ScopedBudget{"aws": {Amount: 1250, Currency: "USD"}},
+	}}})
+	require.NoError(t, renderBudgetImpactIfConfigured(cmd, costs, nil))
+
+	text := out.String()
+	assert.Contains(t, text, "Budget impact:")
+	assert.Contains(t, text,
+		"  provider:aws would reach 92% of budget after this deploy ($1,150.00 of $1,250.00) | CRITICAL\n")
+	assert.Contains(t, text, "  global would reach 23% of budget after this deploy ($1,150.00 of $5,000.00) | OK\n")
+}
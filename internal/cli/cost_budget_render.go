@@ -39,12 +39,16 @@ type BudgetScopeFilter struct {
 	ShowTag bool
 	// ShowType displays the BY TYPE section.
 	ShowType bool
+	// ShowStack displays the BY STACK section.
+	ShowStack bool
 	// ProviderFilter limits provider display to specific providers.
 	ProviderFilter []string
 	// TagFilter limits tag display to specific tag selectors.
 	TagFilter []string
 	// TypeFilter limits type display to specific resource types.
 	TypeFilter []string
+	// StackFilter limits stack display to specific Pulumi stacks.
+	StackFilter []string
 }
 
 // NewBudgetScopeFilter creates a filter from a --budget-scope flag value.
@@ -55,7 +59,9 @@ type BudgetScopeFilter struct {
 // - "tag" - show BY TAG section
 // - "tag=team:platform" - show only the specific tag budget
 // - "type" - show BY TYPE section
-// - "type=aws:ec2/instance" - show only the specific resource type budget.
+// - "type=aws:ec2/instance" - show only the specific resource type budget
+// - "stack" - show BY STACK section
+// - "stack=prod" - show only the specific Pulumi stack budget.
 func NewBudgetScopeFilter(scopeFlag string) *BudgetScopeFilter {
 	filter := &BudgetScopeFilter{}
 
@@ -65,6 +71,7 @@ func NewBudgetScopeFilter(scopeFlag string) *BudgetScopeFilter {
 		filter.ShowProvider = true
 		filter.ShowTag = true
 		filter.ShowType = true
+		filter.ShowStack = true
 		return filter
 	}
 
@@ -108,15 +115,25 @@ func NewBudgetScopeFilter(scopeFlag string) *BudgetScopeFilter {
 			}
 		case partLower == "type":
 			filter.ShowType = true
+		case strings.HasPrefix(partLower, "stack="):
+			filter.ShowStack = true
+			// Preserve original case for stack names
+			stack := part[len("stack="):]
+			if stack != "" {
+				filter.StackFilter = append(filter.StackFilter, stack)
+			}
+		case partLower == "stack":
+			filter.ShowStack = true
 		}
 	}
 
 	// If no valid scope was specified, default to all
-	if !filter.ShowGlobal && !filter.ShowProvider && !filter.ShowTag && !filter.ShowType {
+	if !filter.ShowGlobal && !filter.ShowProvider && !filter.ShowTag && !filter.ShowType && !filter.ShowStack {
 		filter.ShowGlobal = true
 		filter.ShowProvider = true
 		filter.ShowTag = true
 		filter.ShowType = true
+		filter.ShowStack = true
 	}
 
 	return filter
@@ -133,7 +150,7 @@ func RenderScopedBudgetStatus(w io.Writer, result *engine.ScopedBudgetResult, fi
 		filter = NewBudgetScopeFilter("")
 	}
 
-	if isWriterTerminal(w) {
+	if useStyledBudget(w) {
 		return renderStyledScopedBudget(w, result, filter)
 	}
 	return renderPlainScopedBudget(w, result, filter)
@@ -161,7 +178,7 @@ func renderStyledScopedBudget(w io.Writer, result *engine.ScopedBudgetResult, fi
 	var content strings.Builder
 
 	// Main title
-	content.WriteString(titleStyle.Render("BUDGET STATUS"))
+	content.WriteString(titleStyle.Render(scopedBudgetTitle(result)))
 	content.WriteString("\n")
 	content.WriteString(strings.Repeat("═", scopedBoxWidth-scopedBoxTitlePadding))
 	content.WriteString("\n\n")
@@ -203,6 +220,18 @@ func renderStyledScopedBudget(w io.Writer, result *engine.ScopedBudgetResult, fi
 		sectionsRendered++
 	}
 
+	// UNALLOCATED section (spend matching no tag budget)
+	if filter.ShowTag && result.Unallocated != nil {
+		if sectionsRendered > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(sectionStyle.Render("UNALLOCATED"))
+		content.WriteString("\n")
+		content.WriteString(renderUnallocatedLine(result.Unallocated))
+		content.WriteString("\n")
+		sectionsRendered++
+	}
+
 	// BY TYPE section
 	if filter.ShowType && len(result.ByType) > 0 {
 		if sectionsRendered > 0 {
@@ -211,6 +240,17 @@ func renderStyledScopedBudget(w io.Writer, result *engine.ScopedBudgetResult, fi
 		content.WriteString(sectionStyle.Render("BY TYPE"))
 		content.WriteString("\n")
 		content.WriteString(renderTypeSection(result.ByType, filter.TypeFilter))
+		sectionsRendered++
+	}
+
+	// BY STACK section
+	if filter.ShowStack && len(result.ByStack) > 0 {
+		if sectionsRendered > 0 {
+			content.WriteString("\n")
+		}
+		content.WriteString(sectionStyle.Render("BY STACK"))
+		content.WriteString("\n")
+		content.WriteString(renderTypeSection(result.ByStack, filter.StackFilter))
 	}
 
 	// Critical scopes warning
@@ -238,7 +278,7 @@ func renderPlainScopedBudget(
 ) error {
 	p := message.NewPrinter(language.English)
 
-	if err := writePlainHeader(w, p, result.OverallHealth); err != nil {
+	if err := writePlainHeader(w, p, scopedBudgetTitle(result), result.OverallHealth); err != nil {
 		return err
 	}
 
@@ -254,10 +294,18 @@ func renderPlainScopedBudget(
 		return err
 	}
 
+	if err := writePlainUnallocatedSection(w, filter, result.Unallocated); err != nil {
+		return err
+	}
+
 	if err := writePlainTypeSectionWrapper(w, filter, result.ByType); err != nil {
 		return err
 	}
 
+	if err := writePlainStackSectionWrapper(w, filter, result.ByStack); err != nil {
+		return err
+	}
+
 	if err := writePlainCriticalScopes(w, result.CriticalScopes); err != nil {
 		return err
 	}
@@ -265,12 +313,20 @@ func renderPlainScopedBudget(
 	return writePlainWarnings(w, result.Warnings)
 }
 
+// scopedBudgetTitle returns the title of a scoped budget status.
+func scopedBudgetTitle(result *engine.ScopedBudgetResult) string {
+	if result.Metric == engine.BudgetMetricCarbon {
+		return "CARBON BUDGET STATUS"
+	}
+	return "BUDGET STATUS"
+}
+
 // writePlainHeader writes the budget status header with overall health.
-func writePlainHeader(w io.Writer, p *message.Printer, health pbc.BudgetHealthStatus) error {
-	if _, err := fmt.Fprintln(w, "BUDGET STATUS"); err != nil {
+func writePlainHeader(w io.Writer, p *message.Printer, title string, health pbc.BudgetHealthStatus) error {
+	if _, err := fmt.Fprintln(w, title); err != nil {
 		return err
 	}
-	if _, err := fmt.Fprintln(w, "============="); err != nil {
+	if _, err := fmt.Fprintln(w, strings.Repeat("=", len(title))); err != nil {
 		return err
 	}
 	_, err := p.Fprintf(w, "Overall Health: %s\n\n", healthStatusLabel(health))
@@ -319,6 +375,22 @@ func writePlainTagSectionWrapper(
 	})
 }
 
+// writePlainUnallocatedSection writes the spend matching no tag budget when
+// the tag section is enabled and tag budgets are configured.
+func writePlainUnallocatedSection(
+	w io.Writer,
+	filter *BudgetScopeFilter,
+	unallocated *engine.UnallocatedSpend,
+) error {
+	if !filter.ShowTag || unallocated == nil {
+		return nil
+	}
+	return writePlainSection(w, "UNALLOCATED", "-----------", func() error {
+		_, err := fmt.Fprintln(w, renderUnallocatedLine(unallocated))
+		return err
+	})
+}
+
 // writePlainTypeSectionWrapper writes the type section if enabled and has data.
 func writePlainTypeSectionWrapper(
 	w io.Writer,
@@ -333,6 +405,20 @@ func writePlainTypeSectionWrapper(
 	})
 }
 
+// writePlainStackSectionWrapper writes the stack section if enabled and has data.
+func writePlainStackSectionWrapper(
+	w io.Writer,
+	filter *BudgetScopeFilter,
+	stacks map[string]*engine.ScopedBudgetStatus,
+) error {
+	if !filter.ShowStack || len(stacks) == 0 {
+		return nil
+	}
+	return writePlainSection(w, "BY STACK", "--------", func() error {
+		return renderPlainTypeSection(w, stacks, filter.StackFilter)
+	})
+}
+
 // writePlainSection writes a section with header, underline, content, and trailing newline.
 func writePlainSection(w io.Writer, header, underline string, renderContent func() error) error {
 	if _, err := fmt.Fprintln(w, header); err != nil {
@@ -409,6 +495,12 @@ func renderScopedStatusLine(status *engine.ScopedBudgetStatus) string {
 	content.WriteString(budgetLine)
 	content.WriteString("\n")
 
+	// Rollover carried from the previous period (already included in Budget)
+	if status.RolloverAmount > 0 {
+		content.WriteString(p.Sprintf("  Rollover: %s%.2f carried from previous period\n",
+			currencySymbol(status.Currency), status.RolloverAmount))
+	}
+
 	// Progress bar
 	bar := renderScopedProgressBar(status.Percentage, scopedMinProgressBar)
 	content.WriteString("  ")
@@ -437,6 +529,12 @@ func renderPlainScopedStatusLine(w io.Writer, status *engine.ScopedBudgetStatus)
 		healthStatusLabel(status.Health)); err != nil {
 		return err
 	}
+	if status.RolloverAmount > 0 {
+		if _, err := p.Fprintf(w, "  Rollover: %s%.2f carried from previous period\n",
+			currencySymbol(status.Currency), status.RolloverAmount); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -534,6 +632,14 @@ func renderPlainTagSection(w io.Writer, tags []*engine.ScopedBudgetStatus, filte
 	return nil
 }
 
+// renderUnallocatedLine describes the spend matching no tag budget.
+func renderUnallocatedLine(unallocated *engine.UnallocatedSpend) string {
+	p := message.NewPrinter(language.English)
+	return p.Sprintf("  Spend: %s%.2f (%.1f%% of total) from %d resources matching no tag budget",
+		currencySymbol(unallocated.Currency), unallocated.CurrentSpend, unallocated.Percentage,
+		unallocated.Resources)
+}
+
 // renderTypeSection renders the BY TYPE section content.
 func renderTypeSection(types map[string]*engine.ScopedBudgetStatus, filterTypes []string) string {
 	var content strings.Builder
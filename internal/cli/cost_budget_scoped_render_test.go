@@ -2,9 +2,12 @@ package cli
 
 import (
 	"bytes"
+	"path/filepath"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -312,6 +315,204 @@ func TestRenderPlainScopedBudget_ByType(t *testing.T) {
 	assert.Contains(t, output, "110.0%")
 }
 
+func TestRenderPlainScopedBudget_ByStack(t *testing.T) {
+	result := &engine.ScopedBudgetResult{
+		ByStack: map[string]*engine.ScopedBudgetStatus{
+			"prod": {
+				ScopeType:    engine.ScopeTypeStack,
+				ScopeKey:     "prod",
+				Budget:       config.ScopedBudget{Amount: 2000, Currency: "USD"},
+				CurrentSpend: 500,
+				Percentage:   25,
+				Health:       pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+				Currency:     "USD",
+			},
+			"dev": {
+				ScopeType:    engine.ScopeTypeStack,
+				ScopeKey:     "dev",
+				Budget:       config.ScopedBudget{Amount: 100, Currency: "USD"},
+				CurrentSpend: 50,
+				Percentage:   50,
+				Health:       pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+				Currency:     "USD",
+			},
+		},
+		OverallHealth: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+	}
+
+	filter := NewBudgetScopeFilter("stack=prod")
+	assert.True(t, filter.ShowStack)
+	assert.False(t, filter.ShowGlobal)
+
+	var buf bytes.Buffer
+	err := renderPlainScopedBudget(&buf, result, filter)
+	require.NoError(t, err)
+
+	output := buf.String()
+	assert.Contains(t, output, "BY STACK")
+	assert.Contains(t, output, "prod:")
+	assert.NotContains(t, output, "dev:")
+	assert.Contains(t, output, "2,000.00")
+}
+
+func TestEvaluateScopedBudgets_ByStack(t *testing.T) {
+	cfg := &config.BudgetsConfig{
+		Global: &config.ScopedBudget{Amount: 1000, Currency: "USD"},
+		Stacks: map[string]*config.ScopedBudget{
+			"prod": {Amount: 100, Currency: "USD"},
+			"dev":  {Amount: 100, Currency: "USD"},
+		},
+	}
+	costs := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance", ResourceID: "urn:pulumi:prod::app::aws:ec2/instance:Instance::a", Monthly: 95},
+		{ResourceType: "aws:s3/bucket", ResourceID: "bucket-id", Monthly: 10},
+	}
+
+	result := evaluateScopedBudgets(
+		t.Context(), engine.NewScopedBudgetEvaluator(cfg), cfg, costs, nil, "dev")
+
+	require.Contains(t, result.ByStack, "prod")
+	require.Contains(t, result.ByStack, "dev")
+	assert.InDelta(t, 95.0, result.ByStack["prod"].CurrentSpend, 0.001)
+	assert.InDelta(t, 10.0, result.ByStack["dev"].CurrentSpend, 0.001)
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL, result.OverallHealth)
+	assert.Contains(t, result.CriticalScopes, "stack:prod")
+}
+
+func TestEvaluateScopedBudgets_Unallocated(t *testing.T) {
+	cfg := &config.BudgetsConfig{
+		Global: &config.ScopedBudget{Amount: 1000, Currency: "USD"},
+		Tags: []config.TagBudget{
+			{Selector: "team:platform", ScopedBudget: config.ScopedBudget{Amount: 200, Currency: "USD"}},
+		},
+	}
+	costs := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance", ResourceID: "web", Monthly: 150},
+		{ResourceType: "aws:s3/bucket", ResourceID: "logs", Monthly: 40},
+		{ResourceType: "aws:rds/instance", ResourceID: "db", Monthly: 10},
+	}
+	tags := map[string]map[string]string{
+		"web":  {"team": "platform"},
+		"logs": {"team": "data"},
+	}
+
+	result := evaluateScopedBudgets(t.Context(), engine.NewScopedBudgetEvaluator(cfg), cfg, costs, tags, "")
+
+	require.Len(t, result.ByTag, 1)
+	assert.InDelta(t, 150.0, result.ByTag[0].CurrentSpend, 0.001)
+	require.NotNil(t, result.Unallocated)
+	assert.InDelta(t, 50.0, result.Unallocated.CurrentSpend, 0.001)
+	assert.InDelta(t, 25.0, result.Unallocated.Percentage, 0.001)
+	assert.Equal(t, 2, result.Unallocated.Resources)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderPlainScopedBudget(&buf, result, NewBudgetScopeFilter("")))
+	assert.Contains(t, buf.String(), "UNALLOCATED")
+	assert.Contains(t, buf.String(), "Spend: $50.00 (25.0% of total) from 2 resources matching no tag budget")
+
+	buf.Reset()
+	require.NoError(t, renderPlainScopedBudget(&buf, result, NewBudgetScopeFilter("global")))
+	assert.NotContains(t, buf.String(), "UNALLOCATED", "shown with the tag section only")
+
+	noTags := &config.BudgetsConfig{Global: cfg.Global}
+	result = evaluateScopedBudgets(t.Context(), engine.NewScopedBudgetEvaluator(noTags), noTags, costs, tags, "")
+	assert.Nil(t, result.Unallocated, "no bucket without tag budgets")
+}
+
+func TestEvaluateScopedBudgets_Rollover(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	cfg := &config.BudgetsConfig{
+		Global: &config.ScopedBudget{Amount: 1000, Currency: "USD", Rollover: true},
+		Providers: map[string]*config.ScopedBudget{
+			"aws": {Amount: 100, Currency: "USD", Rollover: true},
+		},
+	}
+
+	history := config.NewBudgetHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+	require.NoError(t, history.RecordPeriod("provider:aws", "2026-02", config.BudgetPeriodRecord{
+		Amount: 100, Spend: 40, Currency: "USD",
+	}))
+
+	eval := engine.NewScopedBudgetEvaluator(cfg).WithSpendHistory(history, func() time.Time { return now })
+	costs := []engine.CostResult{{ResourceType: "aws:ec2/instance", Monthly: 96}}
+
+	result := evaluateScopedBudgets(t.Context(), eval, cfg, costs, nil, "")
+
+	require.Contains(t, result.ByProvider, "aws")
+	aws := result.ByProvider["aws"]
+	assert.InDelta(t, 60.0, aws.RolloverAmount, 0.001)
+	assert.InDelta(t, 160.0, aws.Budget.Amount, 0.001)
+	assert.InDelta(t, 60.0, aws.Percentage, 0.001)
+	assert.Zero(t, result.Global.RolloverAmount, "no history recorded for global")
+	assert.InDelta(t, 100.0, cfg.Providers["aws"].Amount, 0.001, "config must not be mutated")
+
+	var buf bytes.Buffer
+	require.NoError(t, renderPlainScopedBudget(&buf, result, NewBudgetScopeFilter("provider")))
+	assert.Contains(t, buf.String(), "Rollover: $60.00 carried from previous period")
+}
+
+func TestRecordBudgetHistory(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	period := &spendWindow{from: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), to: now}
+	statuses := []*engine.ScopedBudgetStatus{
+		{
+			ScopeType:    engine.ScopeTypeGlobal,
+			Budget:       config.ScopedBudget{Amount: 1000, Rollover: true},
+			CurrentSpend: 250,
+			Currency:     "USD",
+		},
+		{
+			ScopeType:    engine.ScopeTypeProvider,
+			ScopeKey:     "aws",
+			Budget:       config.ScopedBudget{Amount: 100},
+			CurrentSpend: 50,
+		},
+	}
+
+	t.Run("actual command records rollover scopes", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "history.json")
+		store := config.NewBudgetHistoryStore(path)
+		recordBudgetHistory(&cobra.Command{Use: "actual"}, store, statuses, period, now)
+
+		reloaded := config.NewBudgetHistoryStore(path)
+		require.NoError(t, reloaded.Load())
+		record, ok := reloaded.GetPeriod("global", "2026-03")
+		require.True(t, ok)
+		assert.InDelta(t, 1000.0, record.Amount, 0.001)
+		assert.InDelta(t, 250.0, record.Spend, 0.001)
+		_, ok = reloaded.GetPeriod("provider:aws", "2026-03")
+		assert.False(t, ok, "scopes without rollover are not recorded")
+	})
+
+	t.Run("projected command does not record", func(t *testing.T) {
+		store := config.NewBudgetHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+		recordBudgetHistory(&cobra.Command{Use: "projected"}, store, statuses, nil, now)
+		_, ok := store.GetPeriod("global", "2026-03")
+		assert.False(t, ok)
+	})
+
+	t.Run("windows other than the current period do not record", func(t *testing.T) {
+		for name, window := range map[string]*spendWindow{
+			"starts before the period": {from: time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), to: now},
+			"starts inside the period": {from: time.Date(2026, 3, 5, 0, 0, 0, 0, time.UTC), to: now},
+			"ends before today":        {from: period.from, to: time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC)},
+		} {
+			store := config.NewBudgetHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+			recordBudgetHistory(&cobra.Command{Use: "actual"}, store, statuses, window, now)
+			_, ok := store.GetPeriod("global", "2026-03")
+			assert.False(t, ok, name)
+		}
+	})
+
+	t.Run("a window ending at the start of today records", func(t *testing.T) {
+		store := config.NewBudgetHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+		window := &spendWindow{from: period.from, to: time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)}
+		recordBudgetHistory(&cobra.Command{Use: "actual"}, store, statuses, window, now)
+		_, ok := store.GetPeriod("global", "2026-03")
+		assert.True(t, ok)
+	})
+}
+
 func TestRenderPlainScopedBudget_TagFilter(t *testing.T) {
 	result := &engine.ScopedBudgetResult{
 		ByTag: []*engine.ScopedBudgetStatus{
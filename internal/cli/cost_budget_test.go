@@ -2,12 +2,15 @@ package cli
 
 import (
 	"bytes"
+	"context"
 	"testing"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 )
@@ -493,3 +496,168 @@ func TestCostCmd_NilGlobalConfig(t *testing.T) {
 	err = cmd.PersistentPreRunE(cmd, []string{})
 	assert.NoError(t, err, "should handle nil global config gracefully")
 }
+
+// TestCostCmd_FailOnFlagValidation verifies that unknown --fail-on levels are rejected.
+func TestCostCmd_FailOnFlagValidation(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	config.SetGlobalConfig(nil)
+
+	cmd := newCostCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--fail-on=severe"}))
+	err := cmd.PersistentPreRunE(cmd, []string{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, engine.ErrInvalidHealthLevel)
+
+	cmd = newCostCmd()
+	require.NoError(t, cmd.ParseFlags([]string{"--fail-on=critical"}))
+	assert.NoError(t, cmd.PersistentPreRunE(cmd, []string{}))
+}
+
+// TestCheckBudgetExitFromResult_FailOn verifies the --fail-on health exit policy.
+func TestCheckBudgetExitFromResult_FailOn(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	config.SetGlobalConfig(nil)
+
+	legacy := func(percentage float64) *BudgetRenderResult {
+		return &BudgetRenderResult{
+			LegacyStatus: &engine.BudgetStatus{
+				Budget:     config.BudgetConfig{Amount: 100.0, Currency: "USD"},
+				Percentage: percentage,
+			},
+		}
+	}
+
+	tests := []struct {
+		name         string
+		args         []string
+		result       *BudgetRenderResult
+		wantErr      bool
+		wantExitCode int
+	}{
+		{
+			name:   "flag not set never fails",
+			result: legacy(150),
+		},
+		{
+			name:         "legacy exceeded fails on critical",
+			args:         []string{"--fail-on=critical"},
+			result:       legacy(120),
+			wantErr:      true,
+			wantExitCode: 1,
+		},
+		{
+			name:   "legacy warning passes on critical",
+			args:   []string{"--fail-on=critical"},
+			result: legacy(85),
+		},
+		{
+			name:         "custom exit code is used",
+			args:         []string{"--fail-on=warning", "--exit-code=3"},
+			result:       legacy(85),
+			wantErr:      true,
+			wantExitCode: 3,
+		},
+		{
+			name:   "exit code zero is warning only",
+			args:   []string{"--fail-on=warning", "--exit-code=0"},
+			result: legacy(95),
+		},
+		{
+			name: "scoped overall health is used",
+			args: []string{"--fail-on=exceeded"},
+			result: &BudgetRenderResult{
+				ScopedResult: &engine.ScopedBudgetResult{
+					OverallHealth: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED,
+				},
+			},
+			wantErr:      true,
+			wantExitCode: 1,
+		},
+		{
+			name:   "no budget evaluated never fails",
+			args:   []string{"--fail-on=ok"},
+			result: &BudgetRenderResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := newCostCmd()
+			require.NoError(t, cmd.ParseFlags(tt.args))
+			var errBuf bytes.Buffer
+			cmd.SetErr(&errBuf)
+
+			err := checkBudgetExitFromResult(cmd, tt.result, nil)
+			if !tt.wantErr {
+				assert.NoError(t, err)
+				return
+			}
+			var budgetErr *BudgetExitError
+			require.ErrorAs(t, err, &budgetErr)
+			assert.Equal(t, tt.wantExitCode, budgetErr.ExitCode)
+			assert.Contains(t, budgetErr.Reason, "--fail-on")
+		})
+	}
+}
+
+func TestRenderBudgetWithScope_CarbonBudgets(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+
+	exitCode := 4
+	config.SetGlobalConfig(&config.Config{
+		Cost: config.CostConfig{
+			Budgets: &config.BudgetsConfig{
+				ExitOnThreshold: true,
+				ExitCode:        &exitCode,
+				Carbon: &config.CarbonBudgetsConfig{
+					Global:    &config.ScopedBudget{Amount: 100},
+					Providers: map[string]*config.ScopedBudget{"aws": {Amount: 50}},
+				},
+			},
+		},
+	})
+
+	costs := []engine.CostResult{
+		{
+			ResourceID: "web", ResourceType: "aws:ec2/instance", Monthly: 70, Currency: "USD",
+			Sustainability: map[string]engine.SustainabilityMetric{
+				"carbon_footprint": {Value: 60, Unit: "kgCO2e"},
+			},
+		},
+		{ResourceID: "bucket", ResourceType: "aws:s3/bucket", Monthly: 5, Currency: "USD"},
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	var out, errBuf bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errBuf)
+
+	result, err := renderBudgetWithScope(cmd, costs, nil, 75, "USD", "", nil)
+	require.NoError(t, err)
+	require.NotNil(t, result)
+	assert.Nil(t, result.LegacyStatus, "no cost budget is configured")
+
+	carbon := result.CarbonResult
+	require.NotNil(t, carbon)
+	assert.Equal(t, engine.BudgetMetricCarbon, carbon.Metric)
+	assert.InDelta(t, 60.0, carbon.Global.CurrentSpend, 0.001)
+	assert.Equal(t, config.CarbonBudgetUnit, carbon.Global.Currency)
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED, carbon.ByProvider["aws"].Health)
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED, result.Health())
+	assert.Contains(t, carbon.CriticalScopes, "provider:aws")
+	assert.Contains(t, carbon.Warnings, "1 resource(s) report no carbon footprint and count as 0 kgCO2e")
+
+	output := out.String()
+	assert.Contains(t, output, "CARBON BUDGET STATUS")
+	assert.Contains(t, output, "Spend: kgCO2e 60.00")
+
+	err = checkBudgetExitFromResult(cmd, result, nil)
+	var budgetErr *BudgetExitError
+	require.ErrorAs(t, err, &budgetErr)
+	assert.Equal(t, exitCode, budgetErr.ExitCode)
+	assert.Contains(t, budgetErr.Reason, "carbon budget exceeded")
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// costCommitmentsParams holds the parameters for the cost commitments command execution.
+type costCommitmentsParams struct {
+	planPath  string
+	statePath string
+	adapter   string
+	output    string
+	discount  float64
+}
+
+// commitmentEngine prices resources and lists the commitment inventory of
+// the plugins.
+type commitmentEngine interface {
+	StreamProjectedCostWithErrors(
+		ctx context.Context,
+		resources []engine.ResourceDescriptor,
+		fn engine.ProjectedResultFunc,
+	) (*engine.CostResultWithErrors, error)
+	GetCommitments(ctx context.Context) ([]engine.Commitment, []error)
+}
+
+// NewCostCommitmentsCmd creates the "commitments" subcommand, which reports
+// how well reserved instances and savings plans cover the stack.
+func NewCostCommitmentsCmd() *cobra.Command {
+	var params costCommitmentsParams
+
+	cmd := &cobra.Command{
+		Use:   "commitments",
+		Short: "Report reserved instance and savings plan coverage and utilization",
+		Long: `Report how well reserved instances and savings plans cover the compute
+resources of the stack, how much of each commitment is used, and where buying
+one would save money.
+
+Plugins report their commitment inventory as budgets with commitment_type
+metadata (reserved_instance or savings_plan); such budgets are left out of
+budget health. The resources are priced on demand. Reservations cover running
+instances of their instance type, and savings plans then cover the remaining
+compute spend up to their hourly commitment.
+
+Findings list the instance types that run on demand, with the savings of
+reserving them at --discount, commitments utilized below 80%, and commitments
+that expire within 30 days.
+
+Resources come from --pulumi-json or --pulumi-state. When both are omitted, the
+deployed state of the current Pulumi stack is used.`,
+		Example: `  # Coverage of the current Pulumi stack
+  finfocus cost commitments
+
+  # Coverage of an exported state, as JSON
+  finfocus cost commitments --pulumi-state state.json --output json
+
+  # Estimate purchase savings at a 40% reservation discount
+  finfocus cost commitments --discount 0.4`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostCommitments(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().StringVar(&params.statePath, "pulumi-state", "", "Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	cmd.Flags().Float64Var(&params.discount, "discount", engine.DefaultReservationDiscount,
+		"Expected reservation discount over on-demand (0 to 1) for purchase savings")
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "pulumi-state")
+
+	return cmd
+}
+
+// executeCostCommitments loads and prices the resources, collects the
+// commitments of the plugins, and renders the coverage report.
+func executeCostCommitments(cmd *cobra.Command, params costCommitmentsParams) error {
+	if params.output != outputFormatTable && params.output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", params.output)
+	}
+	if params.discount <= 0 || params.discount >= 1 {
+		return fmt.Errorf("--discount must be between 0 and 1, got %g", params.discount)
+	}
+
+	ctx := cmd.Context()
+	audit := newAuditContext(ctx, "cost commitments", map[string]string{
+		"pulumi_json": params.planPath, "pulumi_state": params.statePath,
+	})
+
+	resources, err := loadStackResources(ctx, cmd, "cost commitments", params.planPath, params.statePath)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	report, err := analyzeCommitments(ctx, cmd, eng, resources, params, time.Now())
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	if params.output == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil {
+			return fmt.Errorf("encoding commitments JSON: %w", encodeErr)
+		}
+	} else if renderErr := renderCommitmentsTable(cmd.OutOrStdout(), report); renderErr != nil {
+		return renderErr
+	}
+	audit.logSuccess(ctx, len(report.Commitments), report.PotentialMonthlySavings)
+	return nil
+}
+
+// analyzeCommitments prices the resources and compares them with the
+// commitments of the plugins. Failing plugins and invalid commitments are
+// warned about on stderr.
+func analyzeCommitments(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng commitmentEngine,
+	resources []engine.ResourceDescriptor,
+	params costCommitmentsParams,
+	now time.Time,
+) (*engine.CommitmentReport, error) {
+	projected, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	commitments, errs := eng.GetCommitments(ctx)
+	for _, commitmentErr := range errs {
+		cmd.PrintErrf("Warning: %v\n", commitmentErr)
+	}
+	return engine.AnalyzeCommitments(commitments, resources, projected.Results, engine.CommitmentOptions{
+		Now: now, ReservationDiscount: params.discount,
+	}), nil
+}
+
+// renderCommitmentsTable renders the overall coverage, the utilization of
+// each commitment, the coverage of each instance type, and the findings.
+func renderCommitmentsTable(w io.Writer, report *engine.CommitmentReport) error {
+	fmt.Fprintf(w, "Commitment coverage: %.1f%% of %.2f %s/month of compute\n",
+		report.Coverage, report.EligibleMonthly, report.Currency)
+
+	fmt.Fprintln(w, "\nCOMMITMENTS")
+	if len(report.Commitments) == 0 {
+		fmt.Fprintln(w, "No reserved instances or savings plans were reported by the plugins.")
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+		fmt.Fprintln(tw, "NAME\tTYPE\tCOVERS\tUSED\tUTILIZATION\tEXPIRES")
+		for _, c := range report.Commitments {
+			covers, used := fmt.Sprintf("%.2f %s/h", c.Committed, c.Currency), fmt.Sprintf("%.2f", c.Used)
+			if c.Type == engine.CommitmentReservedInstance {
+				covers = fmt.Sprintf("%d x %s", c.Count, c.InstanceType)
+				if c.Region != "" {
+					covers += " (" + c.Region + ")"
+				}
+				used = fmt.Sprintf("%.0f", c.Used)
+			}
+			expires := "-"
+			if c.Expires != nil {
+				expires = c.Expires.Format("2006-01-02")
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%.1f%%\t%s\n",
+				c.Name, strings.ReplaceAll(c.Type, "_", " "), covers, used, c.Utilization, expires)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	if len(report.InstanceTypes) > 0 {
+		fmt.Fprintln(w, "\nINSTANCE TYPES")
+		tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+		fmt.Fprintln(tw, "INSTANCE TYPE\tREGION\tRUNNING\tRESERVED\tON-DEMAND/MONTH\tCOVERAGE")
+		for _, c := range report.InstanceTypes {
+			region := c.Region
+			if region == "" {
+				region = "-"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%d\t%d\t%.2f\t%.1f%%\n",
+				c.InstanceType, region, c.Running, c.Reserved, c.UncoveredMonthly, c.Coverage)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintln(w, "\nFINDINGS")
+	if len(report.Findings) == 0 {
+		fmt.Fprintln(w, "None.")
+		return nil
+	}
+	for _, f := range report.Findings {
+		line := fmt.Sprintf("  [%s] %s", f.Kind, f.Message)
+		if f.MonthlySavings > 0 {
+			line += fmt.Sprintf("; saves ~%.2f %s/month", f.MonthlySavings, report.Currency)
+		}
+		fmt.Fprintln(w, line)
+	}
+	if report.PotentialMonthlySavings > 0 {
+		fmt.Fprintf(w, "\nPotential savings: %.2f %s/month\n", report.PotentialMonthlySavings, report.Currency)
+	}
+	return nil
+}
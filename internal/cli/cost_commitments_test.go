@@ -0,0 +1,125 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// commitmentTestEngine prices every resource at 10 USD a month and reports
+// a fixed commitment inventory.
+type commitmentTestEngine struct {
+	apiTestEngine
+
+	commitments []engine.Commitment
+	errs        []error
+}
+
+func (e *commitmentTestEngine) GetCommitments(context.Context) ([]engine.Commitment, []error) {
+	return e.commitments, e.errs
+}
+
+func TestAnalyzeCommitments_CLI(t *testing.T) {
+	props := map[string]interface{}{"instanceType": "t3.micro"}
+	resources := []engine.ResourceDescriptor{
+		{ID: "web-1", Type: "aws:ec2/instance:Instance", Properties: props},
+		{ID: "web-2", Type: "aws:ec2/instance:Instance", Properties: props},
+	}
+	eng := &commitmentTestEngine{
+		commitments: []engine.Commitment{
+			{ID: "ri", Name: "web-ri", Type: engine.CommitmentReservedInstance, InstanceType: "t3.micro", Count: 1,
+				Currency: "USD"},
+		},
+		errs: []error{errors.New("plugin gcp: unavailable")},
+	}
+	cmd := &cobra.Command{}
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+
+	report, err := analyzeCommitments(context.Background(), cmd, eng, resources,
+		costCommitmentsParams{discount: 0.5}, time.Now())
+	require.NoError(t, err)
+
+	assert.Len(t, eng.priced, 2)
+	assert.InDelta(t, 50.0, report.Coverage, 0.001)
+	require.Len(t, report.Findings, 1)
+	assert.Equal(t, engine.CommitmentFindingPurchase, report.Findings[0].Kind)
+	assert.InDelta(t, 5.0, report.Findings[0].MonthlySavings, 0.001, "half of one 10 USD instance")
+	assert.Contains(t, stderr.String(), "Warning: plugin gcp: unavailable")
+}
+
+func TestRenderCommitmentsTable(t *testing.T) {
+	expires := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	report := &engine.CommitmentReport{
+		Currency: "USD", EligibleMonthly: 200, Coverage: 75,
+		Commitments: []engine.CommitmentUtilization{
+			{
+				Commitment: engine.Commitment{Name: "web-ri", Type: engine.CommitmentReservedInstance,
+					InstanceType: "m5.large", Region: "us-east-1", Count: 2, Currency: "USD", Expires: &expires},
+				Used: 1, Committed: 2, Utilization: 50,
+			},
+			{
+				Commitment: engine.Commitment{Name: "compute-sp", Type: engine.CommitmentSavingsPlan, Hourly: 0.25,
+					Currency: "USD"},
+				Used: 0.25, Committed: 0.25, Utilization: 100,
+			},
+		},
+		InstanceTypes: []engine.CommitmentCoverage{
+			{InstanceType: "m5.large", Running: 1, Reserved: 2, Monthly: 70, Coverage: 100},
+		},
+		Findings: []engine.CommitmentFinding{
+			{Kind: engine.CommitmentFindingPurchase, Message: "Reserve 1 x c5.xlarge", MonthlySavings: 12.5},
+		},
+		PotentialMonthlySavings: 12.5,
+	}
+	var out bytes.Buffer
+	require.NoError(t, renderCommitmentsTable(&out, report))
+
+	text := out.String()
+	assert.Contains(t, text, "Commitment coverage: 75.0% of 200.00 USD/month of compute")
+	assert.Contains(t, text, "2 x m5.large (us-east-1)")
+	assert.Contains(t, text, "2026-04-01")
+	assert.Contains(t, text, "0.25 USD/h")
+	assert.Contains(t, text, "INSTANCE TYPES")
+	assert.Contains(t, text, "[purchase] Reserve 1 x c5.xlarge; saves ~12.50 USD/month")
+	assert.Contains(t, text, "Potential savings: 12.50 USD/month")
+}
+
+func TestRenderCommitmentsTable_Empty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderCommitmentsTable(&out, &engine.CommitmentReport{Currency: "USD"}))
+	assert.Contains(t, out.String(), "No reserved instances or savings plans were reported by the plugins.")
+	assert.Contains(t, out.String(), "None.")
+}
+
+func TestCostCommitments_RejectsInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"output", []string{"--output", "csv"}, `unsupported output format "csv"`},
+		{"discount", []string{"--discount", "1.5"}, "--discount must be between 0 and 1"},
+		{"inputs", []string{"--pulumi-json", "a.json", "--pulumi-state", "b.json"}, "none of the others can be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCostCommitmentsCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
@@ -0,0 +1,243 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/ingest"
+	pulumidetect "github.com/rshade/finfocus/internal/pulumi"
+)
+
+// previewChanges is the resources a Pulumi preview changes, as deployed and
+// as the deployment leaves them.
+type previewChanges struct {
+	before, after []engine.ResourceDescriptor
+	// operations maps the ID of each changed resource to its operation.
+	operations map[string]string
+}
+
+// validateDeltaOutput checks that --delta is combined with an output format
+// it can render.
+func validateDeltaOutput(output string) error {
+	switch format := config.GetOutputFormat(output); format {
+	case outputFormatTable, outputFormatJSON:
+		return nil
+	default:
+		return &usageError{err: fmt.Errorf("--delta supports --output table or json, got %q", format)}
+	}
+}
+
+// loadPreviewChanges loads the changes of the Pulumi preview at planPath, or
+// of a preview run for stack in the Pulumi project of the current directory
+// when planPath is empty, and applies filters to both sides of the change.
+func loadPreviewChanges(
+	ctx context.Context,
+	planPath, stack string,
+	filters []string,
+) (*previewChanges, error) {
+	var plan *ingest.PulumiPlan
+	if planPath != "" {
+		loaded, err := ingest.LoadPulumiPlanWithContext(ctx, planPath)
+		if err != nil {
+			return nil, fmt.Errorf("loading Pulumi plan: %w", err)
+		}
+		plan = loaded
+	} else {
+		if _, ok := pulumidetect.ParseStackRef(stack); ok {
+			return nil, errors.New("--delta needs a preview, which Pulumi Cloud stacks do not have; use --pulumi-json")
+		}
+		projectDir, resolvedStack, err := detectPulumiProject(ctx, stack)
+		if err != nil {
+			return nil, err
+		}
+		if plan, err = runPulumiPreview(ctx, projectDir, resolvedStack); err != nil {
+			return nil, err
+		}
+	}
+
+	planChanges := plan.GetResourceChanges()
+	changes := &previewChanges{operations: make(map[string]string, len(planChanges))}
+	for _, change := range planChanges {
+		if change.Before != nil {
+			desc, err := ingest.MapResource(*change.Before)
+			if err != nil {
+				return nil, fmt.Errorf("mapping resources: %w", err)
+			}
+			changes.before = append(changes.before, desc)
+		}
+		if change.After != nil {
+			desc, err := ingest.MapResource(*change.After)
+			if err != nil {
+				return nil, fmt.Errorf("mapping resources: %w", err)
+			}
+			changes.after = append(changes.after, desc)
+		}
+	}
+
+	var err error
+	if changes.before, err = ApplyFilters(ctx, changes.before, filters); err != nil {
+		return nil, fmt.Errorf("applying filters: %w", err)
+	}
+	if changes.after, err = ApplyFilters(ctx, changes.after, filters); err != nil {
+		return nil, fmt.Errorf("applying filters: %w", err)
+	}
+	kept := make(map[string]bool, len(changes.before)+len(changes.after))
+	for _, resources := range [][]engine.ResourceDescriptor{changes.before, changes.after} {
+		for _, r := range resources {
+			kept[r.ID] = true
+		}
+	}
+	for _, change := range planChanges {
+		if kept[change.URN] {
+			changes.operations[change.URN] = change.Op
+		}
+	}
+	return changes, nil
+}
+
+// executePreviewDelta prices both sides of the changes of a preview, estimates
+// the one-time costs of its replacements, renders the change in monthly cost,
+// and fails with the gate exit code when it exceeds maxIncrease, which is nil
+// without --max-increase.
+func executePreviewDelta(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng projectedCostEngine,
+	changes *previewChanges,
+	output string,
+	maxIncrease *engine.IncreaseLimit,
+	errorPolicy engine.ErrorPolicy,
+	audit *auditContext,
+) error {
+	var results [2][]engine.CostResult
+	for i, resources := range [][]engine.ResourceDescriptor{changes.before, changes.after} {
+		result, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+		if err != nil {
+			audit.logFailure(ctx, err)
+			return fmt.Errorf("calculating projected costs: %w", err)
+		}
+		if policyErr := result.ApplyErrorPolicy(errorPolicy); policyErr != nil {
+			audit.logFailure(ctx, policyErr)
+			return policyErr
+		}
+		results[i] = result.Results
+	}
+	var replaced []engine.ResourceDescriptor
+	for _, r := range changes.after {
+		if changes.operations[r.ID] == ingest.ChangeReplace {
+			replaced = append(replaced, r)
+		}
+	}
+	oneTime := engine.EstimateReplacementChurn(replaced, results[1])
+	delta := engine.CalculatePreviewDelta(changes.operations, results[0], results[1], oneTime)
+	audit.logSuccess(ctx, len(changes.operations), delta.Change)
+
+	if err := renderPreviewDelta(cmd, output, delta); err != nil {
+		return err
+	}
+	if maxIncrease != nil && maxIncrease.Exceeded(0, delta.Change) {
+		return checkDeltaGate(cmd, delta, maxIncrease.Value)
+	}
+	return nil
+}
+
+// checkDeltaGate returns the BudgetExitError of a deploy whose cost increase
+// exceeds maxIncrease, or nil with a warning when the gate exit code is zero.
+func checkDeltaGate(cmd *cobra.Command, delta *engine.PreviewDelta, maxIncrease float64) error {
+	symbol := currencySymbol(delta.Currency)
+	reason := fmt.Sprintf("this deploy adds %s%.2f/mo, more than --max-increase %s%.2f",
+		symbol, delta.Change, symbol, maxIncrease)
+	exitCode := gateExitCode(cmd)
+	if exitCode == 0 {
+		cmd.PrintErrf("WARNING: %s\n", reason)
+		return nil
+	}
+	return &BudgetExitError{ExitCode: exitCode, Reason: reason}
+}
+
+// renderPreviewDelta renders the delta as a summary line and a table of the
+// resources whose cost changes or, with --output json, as an
+// engine.PreviewDelta.
+func renderPreviewDelta(cmd *cobra.Command, output string, delta *engine.PreviewDelta) error {
+	if config.GetOutputFormat(output) == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(delta); err != nil {
+			return fmt.Errorf("encoding preview delta JSON: %w", err)
+		}
+		return nil
+	}
+	return renderPreviewDeltaTable(cmd.OutOrStdout(), delta)
+}
+
+// renderPreviewDeltaTable renders the summary line, one row per resource whose
+// cost changes, and the added, removed, and modified totals. A ONE-TIME column
+// and total are shown when replacements have one-time costs.
+func renderPreviewDeltaTable(w io.Writer, delta *engine.PreviewDelta) error {
+	fmt.Fprintln(w, previewDeltaSummary(delta))
+	oneTime := delta.OneTime > 0
+	if len(delta.Changes) > 0 {
+		fmt.Fprintln(w)
+		tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+		header := "OPERATION\tRESOURCE\tTYPE\tBEFORE\tAFTER\tCHANGE"
+		if oneTime {
+			header += "\tONE-TIME"
+		}
+		fmt.Fprintln(tw, header)
+		for _, change := range delta.Changes {
+			_, name := engine.SplitURN(change.ResourceID)
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%.2f\t%+.2f",
+				change.Operation, name, dashIfEmpty(change.ResourceType), change.Before, change.After, change.Change)
+			if oneTime {
+				fmt.Fprintf(tw, "\t%.2f", change.OneTime)
+			}
+			fmt.Fprintln(tw)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+	}
+
+	fmt.Fprintf(w, "\nAdded: +%.2f  Removed: -%.2f  Modified: %+.2f  Net: %+.2f %s/mo\n",
+		delta.Added, delta.Removed, delta.Modified, delta.Change, delta.Currency)
+	if oneTime {
+		fmt.Fprintf(w, "One-time: %.2f %s (estimated cost of replacing resources, not recurring)\n",
+			delta.OneTime, delta.Currency)
+	}
+	if delta.Failed > 0 {
+		fmt.Fprintf(w, "Warning: %d changed resource(s) could not be priced and count as 0.\n", delta.Failed)
+	}
+	if delta.MixedCurrencies {
+		fmt.Fprintln(w, "Warning: results use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// previewDeltaSummary describes the change in monthly cost of a deploy and
+// its one-time cost, such as "This deploy adds $123.45/mo (plus $12.00
+// one-time)".
+func previewDeltaSummary(delta *engine.PreviewDelta) string {
+	symbol := currencySymbol(delta.Currency)
+	var summary string
+	switch {
+	case delta.Change > 0:
+		summary = fmt.Sprintf("This deploy adds %s%.2f/mo", symbol, delta.Change)
+	case delta.Change < 0:
+		summary = fmt.Sprintf("This deploy saves %s%.2f/mo", symbol, math.Abs(delta.Change))
+	default:
+		summary = "This deploy does not change the monthly cost"
+	}
+	if delta.OneTime > 0 {
+		summary += fmt.Sprintf(" (plus %s%.2f one-time)", symbol, delta.OneTime)
+	}
+	return summary
+}
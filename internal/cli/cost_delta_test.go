@@ -0,0 +1,173 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// deltaTestEngine prices instances by their instanceType input.
+type deltaTestEngine struct {
+	mockRecommendationFetcher
+}
+
+func (e *deltaTestEngine) StreamProjectedCostWithErrors(
+	_ context.Context,
+	resources []engine.ResourceDescriptor,
+	_ engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	prices := map[string]float64{"t3.micro": 8, "t3.small": 15, "t3.large": 60, "cache.t3.small": 25}
+	result := &engine.CostResultWithErrors{}
+	for _, r := range resources {
+		size, _ := r.Properties["instanceType"].(string)
+		result.Results = append(result.Results, engine.CostResult{
+			ResourceID: r.ID, ResourceType: r.Type, Currency: "USD", Monthly: prices[size],
+		})
+	}
+	return result, nil
+}
+
+const deltaTestPlan = `{"steps": [
+	{"op": "create", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+		"newState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}},
+	{"op": "update", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::api",
+		"oldState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.small"}},
+		"newState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.large"}}},
+	{"op": "delete", "urn": "urn:pulumi:dev::app::aws:elasticache/cluster:Cluster::cache",
+		"oldState": {"type": "aws:elasticache/cluster:Cluster", "inputs": {"instanceType": "cache.t3.small"}}},
+	{"op": "same", "urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::batch",
+		"oldState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.large"}}}
+]}`
+
+func loadDeltaTestChanges(t *testing.T, filters []string) *previewChanges {
+	t.Helper()
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(planPath, []byte(deltaTestPlan), 0o600))
+	changes, err := loadPreviewChanges(context.Background(), planPath, "", filters)
+	require.NoError(t, err)
+	return changes
+}
+
+func TestExecutePreviewDelta_Table(t *testing.T) {
+	changes := loadDeltaTestChanges(t, nil)
+	assert.Len(t, changes.operations, 3)
+
+	cmd := NewCostProjectedCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	audit := newAuditContext(context.Background(), "cost projected", nil)
+	require.NoError(t, executePreviewDelta(context.Background(), cmd, &deltaTestEngine{}, changes,
+		outputFormatTable, nil, engine.ErrorPolicyZero, audit))
+
+	text := out.String()
+	assert.Contains(t, text, "This deploy adds $28.00/mo")
+	assert.Regexp(t, `update\s+api\s+aws:ec2/instance:Instance\s+15\.00\s+60\.00\s+\+45\.00`, text)
+	assert.Regexp(t, `delete\s+cache\s+aws:elasticache/cluster:Cluster\s+25\.00\s+0\.00\s+-25\.00`, text)
+	assert.NotContains(t, text, "batch")
+	assert.Contains(t, text, "Added: +8.00  Removed: -25.00  Modified: +45.00  Net: +28.00 USD/mo")
+	assert.NotContains(t, text, "ONE-TIME", "no replacement, no one-time column")
+}
+
+func TestExecutePreviewDelta_JSONAndFilters(t *testing.T) {
+	changes := loadDeltaTestChanges(t, []string{"type=aws:ec2/instance"})
+
+	cmd := NewCostProjectedCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	audit := newAuditContext(context.Background(), "cost projected", nil)
+	require.NoError(t, executePreviewDelta(context.Background(), cmd, &deltaTestEngine{}, changes,
+		outputFormatJSON, nil, engine.ErrorPolicyZero, audit))
+
+	var delta engine.PreviewDelta
+	require.NoError(t, json.Unmarshal(out.Bytes(), &delta))
+	assert.InDelta(t, 53.0, delta.Change, 0.001, "the filter leaves the cache out")
+	assert.InDelta(t, 0.0, delta.Removed, 0.001)
+	require.Len(t, delta.Changes, 2)
+}
+
+func TestExecutePreviewDelta_MaxIncrease(t *testing.T) {
+	changes := loadDeltaTestChanges(t, nil)
+
+	cmd := NewCostProjectedCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	audit := newAuditContext(context.Background(), "cost projected", nil)
+	err := executePreviewDelta(context.Background(), cmd, &deltaTestEngine{}, changes,
+		outputFormatTable, &engine.IncreaseLimit{Value: 20}, engine.ErrorPolicyZero, audit)
+
+	var exitErr *BudgetExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Equal(t, 1, exitErr.ExitCode)
+	assert.Equal(t, "this deploy adds $28.00/mo, more than --max-increase $20.00", exitErr.Reason)
+
+	require.NoError(t, executePreviewDelta(context.Background(), cmd, &deltaTestEngine{}, changes,
+		outputFormatTable, &engine.IncreaseLimit{Value: 30}, engine.ErrorPolicyZero, audit))
+}
+
+func TestExecutePreviewDelta_OneTimeColumn(t *testing.T) {
+	const plan = `{"steps": [
+	{"op": "replace", "urn": "urn:pulumi:dev::app::aws:ebs/volume:Volume::data",
+		"oldState": {"type": "aws:ebs/volume:Volume", "inputs": {"size": 100, "type": "gp3"}},
+		"newState": {"type": "aws:ebs/volume:Volume", "inputs": {"size": 100, "type": "gp2"}}}
+]}`
+	planPath := filepath.Join(t.TempDir(), "plan.json")
+	require.NoError(t, os.WriteFile(planPath, []byte(plan), 0o600))
+	changes, err := loadPreviewChanges(context.Background(), planPath, "", nil)
+	require.NoError(t, err)
+
+	cmd := NewCostProjectedCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	audit := newAuditContext(context.Background(), "cost projected", nil)
+	require.NoError(t, executePreviewDelta(context.Background(), cmd, &deltaTestEngine{}, changes,
+		outputFormatTable, nil, engine.ErrorPolicyZero, audit))
+
+	text := out.String()
+	assert.Contains(t, text, "This deploy does not change the monthly cost (plus $5.00 one-time)")
+	assert.Regexp(t, `CHANGE\s+ONE-TIME`, text)
+	assert.Regexp(t, `replace\s+data\s+aws:ebs/volume:Volume\s+0\.00\s+0\.00\s+\+0\.00\s+5\.00`, text)
+	assert.Contains(t, text, "One-time: 5.00 USD")
+}
+
+func TestPreviewDeltaSummary(t *testing.T) {
+	assert.Equal(t, "This deploy saves €12.50/mo",
+		previewDeltaSummary(&engine.PreviewDelta{Currency: "EUR", Change: -12.5}))
+	assert.Equal(t, "This deploy does not change the monthly cost",
+		previewDeltaSummary(&engine.PreviewDelta{Currency: "USD"}))
+}
+
+func TestDelta_RejectsUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"ndjson", []string{"--delta", "--output", "ndjson"}, `--delta supports --output table or json, got "ndjson"`},
+		{"max increase alone", []string{"--max-increase", "10"}, "--max-increase requires --delta or --baseline"},
+		{"percent increase", []string{"--delta", "--max-increase", "10%"},
+			`--delta takes a monthly amount for --max-increase, got "10%"`},
+		{"baseline", []string{"--delta", "--baseline", "baseline.json"}, "[baseline delta] are set none of the others"},
+		{"breakdown", []string{"--delta", "--breakdown"}, "[delta breakdown] are set none of the others can be"},
+		{"several plans", []string{"--delta", "--pulumi-json", "a.json", "--pulumi-json", "b.json"},
+			"--delta supports a single --pulumi-json plan"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewCostProjectedCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
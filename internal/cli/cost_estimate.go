@@ -1,12 +1,15 @@
 package cli
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"slices"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
@@ -26,7 +29,9 @@ type CostEstimateParams struct {
 	Provider     string
 	ResourceType string
 	Properties   []string // key=value format
+	Set          []string // key=value format, same as Properties
 	Region       string
+	Scenario     string // path to a scenario file to compare
 
 	// Plan-based mode flags
 	PlanPath string
@@ -43,8 +48,9 @@ type CostEstimateParams struct {
 // The command supports two mutually exclusive modes:
 //
 // 1. Single-resource mode:
-//   - --provider (required), --resource-type (required), --property (optional, repeatable)
+//   - --provider (required), --resource-type (required), --set/--property (optional, repeatable)
 //   - Estimates cost impact of property changes on a single resource
+//   - --scenario compares several named override sets from a YAML file instead
 //
 // 2. Plan-based mode:
 //   - --pulumi-json (required), --modify (optional, repeatable)
@@ -73,7 +79,15 @@ Supports two modes:
 Examples:
   # Single resource estimation
   finfocus cost estimate --provider aws --resource-type ec2:Instance \
-    --property instanceType=m5.large
+    --set instanceType=m5.large
+
+  # Several overrides, each with its own cost delta
+  finfocus cost estimate --provider aws --resource-type aws:ec2/instance:Instance \
+    --set instanceType=m5.large --set volumeSize=100
+
+  # Compare named scenarios side by side
+  finfocus cost estimate --provider aws --resource-type aws:ec2/instance:Instance \
+    --scenario scenarios.yaml
 
   # Plan-based estimation
   finfocus cost estimate --pulumi-json plan.json \
@@ -90,7 +104,9 @@ Examples:
 	cmd.Flags().StringVar(&params.Provider, "provider", "", "Cloud provider (aws, gcp, azure)")
 	cmd.Flags().StringVar(&params.ResourceType, "resource-type", "", "Resource type (e.g., ec2:Instance)")
 	cmd.Flags().StringArrayVar(&params.Properties, "property", nil, "Property override key=value (repeatable)")
+	cmd.Flags().StringArrayVar(&params.Set, "set", nil, "Property override key=value (repeatable, alias of --property)")
 	cmd.Flags().StringVar(&params.Region, "region", "", "Region for cost calculation")
+	cmd.Flags().StringVar(&params.Scenario, "scenario", "", "YAML file of named override sets to compare")
 
 	// Plan-based mode flags
 	cmd.Flags().StringVar(&params.PlanPath, "pulumi-json", "", "Path to Pulumi preview JSON file")
@@ -111,19 +127,21 @@ Examples:
 // Rules:
 //   - Single-resource mode requires both --provider and --resource-type
 //   - Plan-based mode requires --pulumi-json
-//   - --property is only valid in single-resource mode
+//   - --property and --set are only valid in single-resource mode
+//   - --scenario is a single-resource flag and replaces --property/--set and --interactive
 //   - --modify is only valid in plan-based mode
 //   - Modes are mutually exclusive
 //
 // Returns an error describing the validation failure, or nil if valid.
 func ValidateEstimateFlags(params *CostEstimateParams) error {
-	hasSingleResource := params.Provider != "" || params.ResourceType != "" || len(params.Properties) > 0
+	hasSingleResource := params.Provider != "" || params.ResourceType != "" ||
+		len(params.Properties) > 0 || len(params.Set) > 0 || params.Scenario != ""
 	hasPlanBased := params.PlanPath != "" || len(params.Modify) > 0
 
 	// Check for mutually exclusive modes
 	if hasSingleResource && hasPlanBased {
 		return errors.New(
-			"cannot mix single-resource flags (--provider, --resource-type, --property) " +
+			"cannot mix single-resource flags (--provider, --resource-type, --property, --set, --scenario) " +
 				"with plan-based flags (--pulumi-json, --modify)")
 	}
 
@@ -137,6 +155,15 @@ func ValidateEstimateFlags(params *CostEstimateParams) error {
 		}
 	}
 
+	if params.Scenario != "" {
+		if len(params.Properties) > 0 || len(params.Set) > 0 {
+			return errors.New("--scenario cannot be combined with --property or --set; put overrides in the scenario file")
+		}
+		if params.Interactive {
+			return errors.New("--scenario cannot be combined with --interactive")
+		}
+	}
+
 	// Validate plan-based mode requirements
 	if hasPlanBased && params.PlanPath == "" {
 		return errors.New("--pulumi-json is required for plan-based estimation")
@@ -296,6 +323,8 @@ func executeCostEstimate(cmd *cobra.Command, params CostEstimateParams) error {
 	switch {
 	case params.Interactive:
 		err = executeInteractiveEstimate(cmd, params, cfg)
+	case params.Scenario != "":
+		err = executeScenarioEstimate(cmd, params, cfg)
 	case params.PlanPath != "":
 		err = executePlanBasedEstimate(cmd, params, cfg)
 	default:
@@ -321,8 +350,8 @@ func executeSingleResourceEstimate(cmd *cobra.Command, params CostEstimateParams
 	ctx := cmd.Context()
 	log := logging.FromContext(ctx)
 
-	// Parse property overrides
-	overrides, err := ParsePropertyOverrides(params.Properties)
+	// Parse property overrides; --set and --property are interchangeable
+	overrides, err := ParsePropertyOverrides(slices.Concat(params.Properties, params.Set))
 	if err != nil {
 		return fmt.Errorf("parsing properties: %w", err)
 	}
@@ -548,20 +577,7 @@ func renderEstimateResultTable(w io.Writer, result *engine.EstimateResult) error
 	// Property deltas
 	if len(result.Deltas) > 0 {
 		fmt.Fprintln(w, "Property Changes:")
-		fmt.Fprintln(w, "-----------------")
-		for _, delta := range result.Deltas {
-			sign := ""
-			if delta.CostChange > 0 {
-				sign = "+"
-			}
-			fmt.Fprintf(w, "  %s: %s -> %s (%s$%.2f/mo)\n",
-				delta.Property,
-				delta.OriginalValue,
-				delta.NewValue,
-				sign,
-				delta.CostChange,
-			)
-		}
+		renderEstimateDeltaTable(w, baselineMonthly, result.Deltas)
 		fmt.Fprintln(w)
 	}
 
@@ -573,6 +589,37 @@ func renderEstimateResultTable(w io.Writer, result *engine.EstimateResult) error
 	return nil
 }
 
+// renderEstimateDeltaTable writes one row per changed property with the
+// baseline monthly cost and the monthly cost with only that change applied.
+func renderEstimateDeltaTable(w io.Writer, baselineMonthly float64, deltas []engine.CostDelta) {
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "PROPERTY\tORIGINAL\tNEW\tBASELINE\tMODIFIED\tCHANGE")
+	fmt.Fprintln(tw, "--------\t--------\t---\t--------\t--------\t------")
+	for _, delta := range deltas {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t$%.2f\t$%.2f\t%s\n",
+			delta.Property,
+			cmp.Or(delta.OriginalValue, "-"),
+			cmp.Or(delta.NewValue, "-"),
+			baselineMonthly,
+			baselineMonthly+delta.CostChange,
+			formatCostChange(delta.CostChange),
+		)
+	}
+	_ = tw.Flush()
+}
+
+// formatCostChange formats a monthly cost change as "+$1.00", "-$1.00", or "$0.00".
+func formatCostChange(amount float64) string {
+	switch {
+	case amount > 0:
+		return fmt.Sprintf("+$%.2f", amount)
+	case amount < 0:
+		return fmt.Sprintf("-$%.2f", -amount)
+	default:
+		return "$0.00"
+	}
+}
+
 // renderEstimateResultJSON renders a single estimate result as JSON.
 func renderEstimateResultJSON(w io.Writer, result *engine.EstimateResult) error {
 	enc := json.NewEncoder(w)
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestRenderEstimateResultTable_DeltaTable(t *testing.T) {
+	result := &engine.EstimateResult{
+		Resource:    &engine.ResourceDescriptor{Provider: "aws", Type: "aws:ec2/instance:Instance", ID: "web"},
+		Baseline:    &engine.CostResult{Monthly: 8, Currency: "USD"},
+		Modified:    &engine.CostResult{Monthly: 75, Currency: "USD"},
+		TotalChange: 67,
+		Deltas: []engine.CostDelta{
+			{Property: "instanceType", OriginalValue: "t3.micro", NewValue: "m5.large", CostChange: 70},
+			{Property: "volumeType", OriginalValue: "io1", NewValue: "gp3", CostChange: -3},
+			{Property: "combined", CostChange: 0},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, renderEstimateResultTable(&out, result))
+
+	lines := bytes.Split(out.Bytes(), []byte("\n"))
+	var rows []string
+	for _, line := range lines {
+		fields := bytes.Fields(line)
+		if len(fields) == 6 {
+			rows = append(rows, string(bytes.Join(fields, []byte(" "))))
+		}
+	}
+	assert.Equal(t, []string{
+		"PROPERTY ORIGINAL NEW BASELINE MODIFIED CHANGE",
+		"-------- -------- --- -------- -------- ------",
+		"instanceType t3.micro m5.large $8.00 $78.00 +$70.00",
+		"volumeType io1 gp3 $8.00 $5.00 -$3.00",
+		"combined - - $8.00 $8.00 $0.00",
+	}, rows)
+}
+
+func TestRenderScenarioComparisonTable(t *testing.T) {
+	carbon := func(v float64) map[string]engine.SustainabilityMetric {
+		return map[string]engine.SustainabilityMetric{"carbon_footprint": {Value: v, Unit: "kgCO2e"}}
+	}
+	comparison := &engine.ScenarioComparison{
+		Resource: &engine.ResourceDescriptor{Provider: "aws", Type: "aws:ec2/instance:Instance"},
+		Baseline: &engine.CostResult{Monthly: 50, Currency: "USD", Sustainability: carbon(10)},
+		Scenarios: []engine.ScenarioResult{
+			{
+				Scenario: engine.Scenario{Name: "m5.large"},
+				Estimate: &engine.EstimateResult{
+					Modified:    &engine.CostResult{Monthly: 70, Sustainability: carbon(14)},
+					TotalChange: 20,
+				},
+				DeltaPercent: 40,
+			},
+			{
+				Scenario:     engine.Scenario{Name: "spot"},
+				Estimate:     &engine.EstimateResult{Modified: &engine.CostResult{Monthly: 20}, TotalChange: -30},
+				DeltaPercent: -60,
+			},
+			{Scenario: engine.Scenario{Name: "broken"}, Error: "plugin unavailable"},
+		},
+	}
+
+	var out bytes.Buffer
+	require.NoError(t, renderScenarioComparison(&out, "table", comparison))
+
+	var rows []string
+	for _, line := range bytes.Split(out.Bytes(), []byte("\n")) {
+		if fields := bytes.Fields(line); len(fields) >= 5 {
+			rows = append(rows, string(bytes.Join(fields, []byte(" "))))
+		}
+	}
+	assert.Equal(t, []string{
+		"SCENARIO MONTHLY DELTA DELTA % CARBON_FOOTPRINT",
+		"baseline $50.00 - - 10.00 kgCO2e",
+		"m5.large $70.00 +$20.00 +40.0% 14.00 kgCO2e",
+		"spot $20.00 -$30.00 -60.0% -",
+		"broken error - - -",
+	}, rows)
+	assert.Contains(t, out.String(), "broken: plugin unavailable")
+}
@@ -0,0 +1,280 @@
+package cli
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// maxScenarios limits the number of scenarios in a scenario file.
+const maxScenarios = 50
+
+// ScenarioFile is the --scenario file format. Baseline holds properties of
+// the resource before any scenario is applied; each scenario's overrides are
+// layered on top of it.
+//
+//	baseline:
+//	  instanceType: t3.medium
+//	scenarios:
+//	  - name: t3.large
+//	    set:
+//	      instanceType: t3.large
+//	  - name: spot
+//	    description: m5.large on spot capacity
+//	    set:
+//	      instanceType: m5.large
+//	      lifecycle: spot
+type ScenarioFile struct {
+	Baseline  map[string]string `yaml:"baseline"`
+	Scenarios []engine.Scenario `yaml:"scenarios"`
+}
+
+// LoadScenarioFile reads and validates a scenario file. Unknown fields are
+// rejected so that a misspelled key does not silently drop an override.
+// Exported for testing.
+func LoadScenarioFile(path string) (*ScenarioFile, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading scenario file: %w", err)
+	}
+
+	var file ScenarioFile
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if decodeErr := dec.Decode(&file); decodeErr != nil && !errors.Is(decodeErr, io.EOF) {
+		return nil, fmt.Errorf("parsing scenario file %s: %w", path, decodeErr)
+	}
+
+	if len(file.Scenarios) == 0 {
+		return nil, fmt.Errorf("scenario file %s defines no scenarios", path)
+	}
+	if len(file.Scenarios) > maxScenarios {
+		return nil, fmt.Errorf("too many scenarios: %d (max %d)", len(file.Scenarios), maxScenarios)
+	}
+	if validateErr := validateScenarioProperties("baseline", file.Baseline); validateErr != nil {
+		return nil, validateErr
+	}
+
+	seen := make(map[string]bool, len(file.Scenarios))
+	for i, scenario := range file.Scenarios {
+		name := strings.TrimSpace(scenario.Name)
+		if name == "" {
+			return nil, fmt.Errorf("scenario %d has no name", i+1)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate scenario name %q", name)
+		}
+		seen[name] = true
+		if len(scenario.Set) == 0 {
+			return nil, fmt.Errorf("scenario %q sets no properties", name)
+		}
+		if validateErr := validateScenarioProperties("scenario "+name, scenario.Set); validateErr != nil {
+			return nil, validateErr
+		}
+		file.Scenarios[i].Name = name
+	}
+	return &file, nil
+}
+
+// validateScenarioProperties applies the --property limits to a scenario file map.
+func validateScenarioProperties(owner string, props map[string]string) error {
+	if len(props) > maxPropertyOverrides {
+		return fmt.Errorf("%s: too many properties: %d (max %d)", owner, len(props), maxPropertyOverrides)
+	}
+	for key, value := range props {
+		if strings.TrimSpace(key) == "" {
+			return fmt.Errorf("%s: property key cannot be empty", owner)
+		}
+		if len(key) > maxPropertyKeyLen {
+			return fmt.Errorf("%s: property key too long: %d bytes (max %d)", owner, len(key), maxPropertyKeyLen)
+		}
+		if len(value) > maxPropertyValueLen {
+			return fmt.Errorf("%s: property value too large for key %q: %d bytes (max %d)",
+				owner, key, len(value), maxPropertyValueLen)
+		}
+	}
+	return nil
+}
+
+// executeScenarioEstimate estimates every scenario in params.Scenario against
+// the single resource described by the command flags and renders a comparison.
+func executeScenarioEstimate(cmd *cobra.Command, params CostEstimateParams, cfg *config.Config) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	file, err := LoadScenarioFile(params.Scenario)
+	if err != nil {
+		return err
+	}
+
+	log.Debug().Ctx(ctx).
+		Str("scenario_file", params.Scenario).
+		Int("scenario_count", len(file.Scenarios)).
+		Msg("executing scenario comparison")
+
+	resource := buildResourceFromParams(params.Provider, params.ResourceType, params.Region)
+	resource.ID = "estimate-resource"
+	for key, value := range file.Baseline {
+		resource.Properties[key] = value
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.Adapter, nil)
+	// IMPORTANT: Register cleanup immediately before any error handling to prevent resource leaks
+	if cleanup != nil {
+		defer cleanup()
+	}
+	if err != nil {
+		log.Warn().Ctx(ctx).Err(err).Msg("failed to open plugins, using spec fallback")
+		clients = nil
+	}
+
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).
+		WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	comparison, err := eng.CompareScenarios(ctx, resource, file.Scenarios)
+	if err != nil {
+		return fmt.Errorf("comparing scenarios: %w", err)
+	}
+
+	return renderScenarioComparison(cmd.OutOrStdout(), params.Output, comparison)
+}
+
+// renderScenarioComparison renders a scenario comparison in the requested format.
+func renderScenarioComparison(w io.Writer, format string, comparison *engine.ScenarioComparison) error {
+	switch format {
+	case outputFormatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(comparison)
+	case outputFormatNDJSON:
+		enc := json.NewEncoder(w)
+		for _, result := range comparison.Scenarios {
+			if err := enc.Encode(result); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return renderScenarioComparisonTable(w, comparison)
+	}
+}
+
+// renderScenarioComparisonTable renders the comparison matrix: one row for the
+// baseline and one per scenario, with monthly cost, delta vs baseline, and a
+// column per sustainability metric reported for any row.
+func renderScenarioComparisonTable(w io.Writer, comparison *engine.ScenarioComparison) error {
+	fmt.Fprintln(w, "Scenario Comparison")
+	fmt.Fprintln(w, "===================")
+	fmt.Fprintln(w)
+	if comparison.Resource != nil {
+		fmt.Fprintf(w, "Resource: %s (%s)\n", comparison.Resource.Type, comparison.Resource.Provider)
+	}
+	currency := defaultCurrency
+	if comparison.Baseline != nil {
+		currency = cmp.Or(comparison.Baseline.Currency, defaultCurrency)
+	}
+	fmt.Fprintf(w, "Currency: %s\n", currency)
+	fmt.Fprintln(w)
+
+	metrics := scenarioMetricKeys(comparison)
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	header := []string{"SCENARIO", "MONTHLY", "DELTA", "DELTA %"}
+	for _, key := range metrics {
+		header = append(header, strings.ToUpper(key))
+	}
+	fmt.Fprintln(tw, strings.Join(header, "\t"))
+
+	baselineRow := []string{"baseline", "-", "-", "-"}
+	if comparison.Baseline != nil {
+		baselineRow[1] = fmt.Sprintf("$%.2f", comparison.Baseline.Monthly)
+	}
+	fmt.Fprintln(tw, strings.Join(append(baselineRow, scenarioMetricCells(comparison.Baseline, metrics)...), "\t"))
+
+	var failures []engine.ScenarioResult
+	for _, result := range comparison.Scenarios {
+		if result.Estimate == nil {
+			failures = append(failures, result)
+			row := []string{result.Scenario.Name, "error", "-", "-"}
+			fmt.Fprintln(tw, strings.Join(append(row, scenarioMetricCells(nil, metrics)...), "\t"))
+			continue
+		}
+		row := []string{
+			result.Scenario.Name,
+			fmt.Sprintf("$%.2f", scenarioMonthly(result.Estimate)),
+			formatCostChange(result.Estimate.TotalChange),
+			fmt.Sprintf("%+.1f%%", result.DeltaPercent),
+		}
+		fmt.Fprintln(tw, strings.Join(append(row, scenarioMetricCells(result.Estimate.Modified, metrics)...), "\t"))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if len(failures) > 0 {
+		fmt.Fprintln(w)
+		for _, result := range failures {
+			fmt.Fprintf(w, "%s: %s\n", result.Scenario.Name, result.Error)
+		}
+	}
+	return nil
+}
+
+// scenarioMonthly returns the modified monthly cost of an estimate.
+func scenarioMonthly(estimate *engine.EstimateResult) float64 {
+	if estimate.Modified == nil {
+		return 0
+	}
+	return estimate.Modified.Monthly
+}
+
+// scenarioMetricKeys returns the sorted sustainability metric keys reported
+// by the baseline or any scenario.
+func scenarioMetricKeys(comparison *engine.ScenarioComparison) []string {
+	keys := make(map[string]bool)
+	if comparison.Baseline != nil {
+		for key := range comparison.Baseline.Sustainability {
+			keys[key] = true
+		}
+	}
+	for _, result := range comparison.Scenarios {
+		if result.Estimate != nil && result.Estimate.Modified != nil {
+			for key := range result.Estimate.Modified.Sustainability {
+				keys[key] = true
+			}
+		}
+	}
+	return slices.Sorted(maps.Keys(keys))
+}
+
+// scenarioMetricCells formats the sustainability metrics of result in keys
+// order, using "-" for metrics it does not report.
+func scenarioMetricCells(result *engine.CostResult, keys []string) []string {
+	cells := make([]string, len(keys))
+	for i, key := range keys {
+		cells[i] = "-"
+		if result == nil {
+			continue
+		}
+		if metric, ok := result.Sustainability[key]; ok {
+			cells[i] = strings.TrimSpace(fmt.Sprintf("%.2f %s", metric.Value, metric.Unit))
+		}
+	}
+	return cells
+}
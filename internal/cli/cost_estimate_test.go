@@ -3,6 +3,8 @@ package cli_test
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
@@ -29,6 +31,8 @@ func TestNewCostEstimateCmd_FlagParsing(t *testing.T) {
 		{"provider", "provider", strPtr("")},
 		{"resource-type", "resource-type", strPtr("")},
 		{"property", "property", nil},
+		{"set", "set", nil},
+		{"scenario", "scenario", strPtr("")},
 		{"pulumi-json", "pulumi-json", strPtr("")},
 		{"modify", "modify", nil},
 		{"interactive", "interactive", strPtr("false")},
@@ -297,6 +301,35 @@ func TestValidateEstimateFlags(t *testing.T) {
 			},
 			expectError: false,
 		},
+		{
+			name: "set is a single-resource flag",
+			params: cli.CostEstimateParams{
+				Set:      []string{"instanceType=m5.large"},
+				PlanPath: "plan.json",
+			},
+			expectError: true,
+			errContains: "cannot mix",
+		},
+		{
+			name: "scenario cannot be combined with set",
+			params: cli.CostEstimateParams{
+				Provider:     "aws",
+				ResourceType: "ec2:Instance",
+				Scenario:     "scenarios.yaml",
+				Set:          []string{"instanceType=m5.large"},
+			},
+			expectError: true,
+			errContains: "--scenario cannot be combined",
+		},
+		{
+			name: "scenario requires a resource type",
+			params: cli.CostEstimateParams{
+				Provider: "aws",
+				Scenario: "scenarios.yaml",
+			},
+			expectError: true,
+			errContains: "--resource-type is required",
+		},
 		{
 			name: "missing provider in single-resource mode",
 			params: cli.CostEstimateParams{
@@ -571,3 +604,59 @@ func TestCostEstimateCmd_ResourceNotFound(t *testing.T) {
 		}
 	})
 }
+
+// TestLoadScenarioFile tests scenario file parsing and validation.
+func TestLoadScenarioFile(t *testing.T) {
+	write := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "scenarios.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("valid file", func(t *testing.T) {
+		file, err := cli.LoadScenarioFile(write(t, `
+baseline:
+  instanceType: t3.medium
+scenarios:
+  - name: " t3.large "
+    set:
+      instanceType: t3.large
+  - name: spot
+    description: m5.large on spot capacity
+    set:
+      instanceType: m5.large
+      lifecycle: spot
+`))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"instanceType": "t3.medium"}, file.Baseline)
+		require.Len(t, file.Scenarios, 2)
+		assert.Equal(t, "t3.large", file.Scenarios[0].Name)
+		assert.Equal(t, "spot", file.Scenarios[1].Set["lifecycle"])
+	})
+
+	tests := []struct {
+		name        string
+		content     string
+		errContains string
+	}{
+		{"empty", "", "defines no scenarios"},
+		{"unknown field", "scenarios:\n  - name: a\n    overrides:\n      x: y\n", "field overrides not found"},
+		{"missing name", "scenarios:\n  - set:\n      x: y\n", "scenario 1 has no name"},
+		{"duplicate name", "scenarios:\n  - name: a\n    set: {x: y}\n  - name: a\n    set: {x: z}\n", "duplicate"},
+		{"no overrides", "scenarios:\n  - name: a\n", "sets no properties"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := cli.LoadScenarioFile(write(t, tt.content))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errContains)
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := cli.LoadScenarioFile(filepath.Join(t.TempDir(), "missing.yaml"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "reading scenario file")
+	})
+}
@@ -0,0 +1,266 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/router"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// errNoCandidateRegions is returned when neither --regions nor the
+// configuration names candidate regions.
+var errNoCandidateRegions = errors.New(
+	"no candidate regions: pass --regions or configure cost.regions.candidates")
+
+// costOptimizeRegionParams holds the parameters for the cost optimize-region
+// command execution.
+type costOptimizeRegionParams struct {
+	planPath  string
+	statePath string
+	adapter   string
+	output    string
+	regions   []string
+	types     []string
+}
+
+// NewCostOptimizeRegionCmd creates the "optimize-region" subcommand, which
+// compares the cost and carbon footprint of resources in candidate regions.
+func NewCostOptimizeRegionCmd() *cobra.Command {
+	var params costOptimizeRegionParams
+
+	cmd := &cobra.Command{
+		Use:   "optimize-region",
+		Short: "Compare the cost and carbon footprint of resources in other regions",
+		Long: `Compare what resources cost and emit where they are with what they would
+cost and emit if they were relocated to each candidate region.
+
+The plugins price every resource once where it is and once in each candidate
+region. Candidate regions come from --regions, or from cost.regions.candidates
+in the configuration; cost.regions.latency annotates regions with the latency
+constraints of moving workloads there. --type limits the comparison to
+resource types starting with one of its values (e.g. aws:ec2/instance).
+
+Carbon deltas use the carbon_footprint metric that plugins report; they are
+left blank when a plugin reports no footprint. Resources that no plugin prices
+where they are cannot be compared and are left out.
+
+Resources come from --pulumi-json or --pulumi-state. When both are omitted, the
+deployed state of the current Pulumi stack is used.`,
+		Example: `  # Compare the instances of the current stack in the configured regions
+  finfocus cost optimize-region --type aws:ec2/instance
+
+  # Compare a plan in two regions, as JSON
+  finfocus cost optimize-region --pulumi-json plan.json --regions us-west-2,eu-north-1 --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostOptimizeRegion(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().StringVar(&params.statePath, "pulumi-state", "", "Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	cmd.Flags().StringSliceVar(&params.regions, "regions", nil,
+		"Candidate regions (default: cost.regions.candidates from the configuration)")
+	cmd.Flags().StringSliceVar(&params.types, "type", nil,
+		"Compare only resource types starting with this value (repeatable)")
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "pulumi-state")
+
+	return cmd
+}
+
+// executeCostOptimizeRegion loads the resources, prices them in their
+// current and the candidate regions, and renders the comparison.
+func executeCostOptimizeRegion(cmd *cobra.Command, params costOptimizeRegionParams) error {
+	if params.output != outputFormatTable && params.output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", params.output)
+	}
+	regionsCfg := configuredRegions()
+	candidates := params.regions
+	if len(candidates) == 0 {
+		candidates = regionsCfg.Candidates
+	}
+	if len(candidates) == 0 {
+		return errNoCandidateRegions
+	}
+
+	ctx := cmd.Context()
+	audit := newAuditContext(ctx, "cost optimize-region", map[string]string{
+		"pulumi_json": params.planPath, "pulumi_state": params.statePath,
+	})
+
+	resources, err := loadStackResources(ctx, cmd, "cost optimize-region", params.planPath, params.statePath)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	selected := make([]engine.ResourceDescriptor, 0, len(resources))
+	for _, resource := range resources {
+		if engine.MatchesResourceTypes(resource.Type, params.types) {
+			selected = append(selected, resource)
+		}
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	report, err := compareRegions(ctx, eng, selected, candidates, regionsCfg.Latency)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	if params.output == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil {
+			return fmt.Errorf("encoding region comparison JSON: %w", encodeErr)
+		}
+	} else if renderErr := renderRegionOptimizationTable(cmd.OutOrStdout(), report); renderErr != nil {
+		return renderErr
+	}
+	audit.logSuccess(ctx, len(report.Resources), report.Savings)
+	return nil
+}
+
+// configuredRegions returns the cost.regions configuration, which may be
+// empty.
+func configuredRegions() config.RegionsConfig {
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Cost.Regions != nil {
+		return *cfg.Cost.Regions
+	}
+	return config.RegionsConfig{}
+}
+
+// compareRegions prices the resources where they are and relocated to each
+// candidate region, and compares the costs.
+func compareRegions(
+	ctx context.Context,
+	eng projectedCostEngine,
+	resources []engine.ResourceDescriptor,
+	candidates []string,
+	latency map[string]string,
+) (*engine.RegionOptimizationReport, error) {
+	input := engine.RegionOptimizationInput{
+		Resources:  resources,
+		Regions:    make(map[string]string, len(resources)),
+		Candidates: make(map[string][]engine.CostResult, len(candidates)),
+		Latency:    latency,
+	}
+	for _, resource := range resources {
+		input.Regions[resource.ID] = router.ExtractResourceRegion(resource)
+	}
+
+	current, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	input.Current = current.Results
+
+	for _, region := range candidates {
+		relocated := make([]engine.ResourceDescriptor, len(resources))
+		for i, resource := range resources {
+			relocated[i] = engine.RelocateResource(resource, region)
+		}
+		priced, pricedErr := eng.StreamProjectedCostWithErrors(ctx, relocated, nil)
+		if pricedErr != nil {
+			return nil, fmt.Errorf("calculating projected costs in %s: %w", region, pricedErr)
+		}
+		input.Candidates[region] = priced.Results
+	}
+	return engine.BuildRegionOptimization(input), nil
+}
+
+// renderRegionOptimizationTable renders the totals of each candidate region
+// and the cheapest and greenest region of each resource.
+func renderRegionOptimizationTable(w io.Writer, report *engine.RegionOptimizationReport) error {
+	if len(report.Resources) == 0 {
+		fmt.Fprintln(w, "No priced resources to compare.")
+		return nil
+	}
+
+	current := fmt.Sprintf("%.2f %s/month", report.Monthly, report.Currency)
+	if report.HasCarbon {
+		current += fmt.Sprintf(", %.1f %s/month", report.CarbonKg, config.CarbonBudgetUnit)
+	}
+	fmt.Fprintf(w, "Current: %d resources, %s\n\n", len(report.Resources), current)
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintf(tw, "REGION\tMONTHLY\tCOST DELTA\tCARBON (%s)\tCARBON DELTA\tLATENCY\n", config.CarbonBudgetUnit)
+	for _, option := range report.Regions {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\n", option.Region,
+			regionMonthly(option), regionCostDelta(option), regionCarbon(option, option.CarbonKg, "%.1f"),
+			regionCarbon(option, option.CarbonDeltaKg, "%+.1f"), dashIfEmpty(option.Latency))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nRESOURCES")
+	tw = tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tTYPE\tREGION\tMONTHLY\tCHEAPEST\tGREENEST")
+	for _, r := range report.Resources {
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%.2f\t%s\t%s\n", r.ResourceID, r.ResourceType, dashIfEmpty(r.Region),
+			r.Monthly, dashIfEmpty(r.Cheapest), dashIfEmpty(r.Greenest))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nMoving each resource to its cheapest region saves %.2f %s/month", report.Savings, report.Currency)
+	if report.HasCarbon {
+		fmt.Fprintf(w, "; moving it to its greenest region saves %.1f %s/month",
+			report.CarbonSavingsKg, config.CarbonBudgetUnit)
+	}
+	fmt.Fprintln(w, ".")
+	return nil
+}
+
+// regionMonthly formats the total of a region, which is incomplete when
+// resources were left unpriced there.
+func regionMonthly(option engine.RegionOption) string {
+	if option.Unpriced > 0 {
+		return fmt.Sprintf("%.2f (%d unpriced)", option.Monthly, option.Unpriced)
+	}
+	return fmt.Sprintf("%.2f", option.Monthly)
+}
+
+// regionCostDelta formats the cost delta of a region.
+func regionCostDelta(option engine.RegionOption) string {
+	if option.Unpriced > 0 {
+		return "-"
+	}
+	return fmt.Sprintf("%+.2f", option.CostDelta)
+}
+
+// regionCarbon formats a carbon amount of a region, or "-" when the plugins
+// reported no footprint.
+func regionCarbon(option engine.RegionOption, kg float64, format string) string {
+	if !option.HasCarbon || option.Unpriced > 0 {
+		return "-"
+	}
+	return fmt.Sprintf(format, kg)
+}
+
+// dashIfEmpty returns s, or "-" when s is empty.
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
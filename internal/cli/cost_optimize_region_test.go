@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// regionTestEngine prices every resource by the region property of its
+// descriptor.
+type regionTestEngine struct {
+	mockRecommendationFetcher
+}
+
+func (e *regionTestEngine) StreamProjectedCostWithErrors(
+	_ context.Context,
+	resources []engine.ResourceDescriptor,
+	_ engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	prices := map[string][2]float64{
+		"us-east-1":  {100, 60},
+		"us-west-2":  {90, 40},
+		"eu-north-1": {110, 5},
+	}
+	result := &engine.CostResultWithErrors{}
+	for _, r := range resources {
+		region, _ := r.Properties["region"].(string)
+		price := prices[region]
+		result.Results = append(result.Results, engine.CostResult{
+			ResourceID: r.ID, ResourceType: r.Type, Currency: "USD", Monthly: price[0],
+			Sustainability: map[string]engine.SustainabilityMetric{
+				"carbon_footprint": {Value: price[1], Unit: "kgCO2e"},
+			},
+		})
+	}
+	return result, nil
+}
+
+func TestCompareRegions(t *testing.T) {
+	resources := []engine.ResourceDescriptor{{
+		ID:         "web",
+		Type:       "aws:ec2/instance:Instance",
+		Properties: map[string]interface{}{"region": "us-east-1"},
+	}}
+
+	report, err := compareRegions(context.Background(), &regionTestEngine{}, resources,
+		[]string{"us-west-2", "eu-north-1"}, map[string]string{"eu-north-1": "+90 ms to US users"})
+	require.NoError(t, err)
+
+	require.Len(t, report.Resources, 1)
+	assert.Equal(t, "us-east-1", report.Resources[0].Region)
+	assert.Equal(t, "us-west-2", report.Resources[0].Cheapest)
+	assert.Equal(t, "eu-north-1", report.Resources[0].Greenest)
+	assert.InDelta(t, 10.0, report.Savings, 0.001)
+	assert.InDelta(t, 55.0, report.CarbonSavingsKg, 0.001)
+
+	var out bytes.Buffer
+	require.NoError(t, renderRegionOptimizationTable(&out, report))
+	output := out.String()
+	assert.Contains(t, output, "Current: 1 resources, 100.00 USD/month, 60.0 kgCO2e/month")
+	assert.Contains(t, output, "+90 ms to US users")
+	assert.Contains(t, output, "-10.00")
+	assert.Contains(t, output, "saves 10.00 USD/month; moving it to its greenest region saves 55.0 kgCO2e/month.")
+}
+
+func TestCostOptimizeRegion_RequiresCandidates(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	config.SetGlobalConfig(&config.Config{})
+
+	cmd := NewCostOptimizeRegionCmd()
+	cmd.SetArgs([]string{"--pulumi-json", "plan.json"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, errNoCandidateRegions)
+}
+
+func TestCostOptimizeRegion_InvalidOutput(t *testing.T) {
+	cmd := NewCostOptimizeRegionCmd()
+	cmd.SetArgs([]string{"--regions", "us-west-2", "--output", "yaml"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported output format")
+}
@@ -0,0 +1,121 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/cli/pagination"
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// costPage holds the --limit, --offset, --page, and --page-size flags shared
+// by the cost commands.
+type costPage struct {
+	limit    int
+	offset   int
+	page     int
+	pageSize int
+}
+
+// addPaginationFlags adds the pagination flags shared by the cost commands.
+func addPaginationFlags(cmd *cobra.Command, p *costPage) {
+	cmd.Flags().IntVar(&p.limit, "limit", 0,
+		"Maximum number of results to return (0 = unlimited)")
+	cmd.Flags().IntVar(&p.offset, "offset", 0,
+		"Number of results to skip for offset-based pagination")
+	cmd.Flags().IntVar(&p.page, "page", 0,
+		"Page number for page-based pagination (1-indexed, 0 = disabled)")
+	cmd.Flags().IntVar(&p.pageSize, "page-size", 0,
+		"Number of results per page (requires --page)")
+}
+
+// params returns the flags as pagination parameters.
+func (p costPage) params() pagination.PaginationParams {
+	return pagination.PaginationParams{Limit: p.limit, Offset: p.offset, Page: p.page, PageSize: p.pageSize}
+}
+
+// enabled reports whether any pagination flag is set.
+func (p costPage) enabled() bool {
+	return p.params().IsEnabled()
+}
+
+// validate checks the flags. Pagination applies to the plain results, so
+// flags that render something else reject it; without is the first such flag
+// given, or empty.
+func (p costPage) validate(without string) error {
+	if err := p.params().Validate(); err != nil {
+		return fmt.Errorf("invalid pagination parameters: %w", err)
+	}
+	if p.enabled() && without != "" {
+		return &usageError{err: fmt.Errorf("--limit, --offset, and --page cannot be combined with %s", without)}
+	}
+	return nil
+}
+
+// apply returns the page of results to render and its metadata, reading
+// only that page from the store, or every result and nil when no pagination
+// flag is set. Failed plugin calls are reported with every page.
+func (p costPage) apply(
+	results *costResults,
+) (*engine.CostResultWithErrors, *pagination.PaginationMeta, error) {
+	if !p.enabled() {
+		all, err := results.collect()
+		return all, nil, err
+	}
+	pp := p.params()
+	window, err := pagination.StoreWindow(pp, results.Results)
+	if err != nil {
+		return nil, nil, fmt.Errorf("reading cost results: %w", err)
+	}
+	shown := &engine.CostResultWithErrors{Results: window, Errors: results.Errors, Omitted: results.Omitted}
+	meta := pagination.NewPaginationMeta(pp, results.Results.Len())
+	return shown, &meta, nil
+}
+
+// writePageFooter ends table output of a page with a line naming how many
+// results it shows, when there are more.
+func writePageFooter(cmd *cobra.Command, outputFormat string, meta *pagination.PaginationMeta, shown int) {
+	if meta == nil || shown >= meta.TotalItems || config.GetOutputFormat(outputFormat) != outputFormatTable {
+		return
+	}
+	cmd.Printf("Showing %d of %d results; use --limit, --offset, or --page for more.\n", shown, meta.TotalItems)
+}
+
+// renderProjectedCostPageJSON renders a page of projected cost results as the
+// JSON of RenderResults with the pagination metadata added.
+func renderProjectedCostPageJSON(w io.Writer, results []engine.CostResult, meta *pagination.PaginationMeta) error {
+	output := map[string]interface{}{
+		"finfocus":   engine.AggregateResults(results),
+		"pagination": meta,
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
+
+// renderActualCostPageJSON renders a page of actual cost results as an
+// object holding the JSON array of RenderActualCostJSON under "results" and
+// the pagination metadata.
+func renderActualCostPageJSON(
+	w io.Writer,
+	results []engine.CostResult,
+	showConfidence bool,
+	meta *pagination.PaginationMeta,
+) error {
+	var buf bytes.Buffer
+	if err := engine.RenderActualCostJSON(&buf, results, showConfidence); err != nil {
+		return err
+	}
+	output := struct {
+		Results    json.RawMessage            `json:"results"`
+		Pagination *pagination.PaginationMeta `json:"pagination"`
+	}{Results: buf.Bytes(), Pagination: meta}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(output)
+}
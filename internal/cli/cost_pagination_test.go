@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/cli/pagination"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// pagedResults returns n stored results with the URNs urn:0 to urn:n-1.
+func pagedResults(t *testing.T, n int) *costResults {
+	t.Helper()
+	results := make([]engine.CostResult, 0, n)
+	for i := range n {
+		results = append(results, engine.CostResult{ResourceID: fmt.Sprintf("urn:%d", i), Monthly: 1})
+	}
+	return storedResults(t, results...)
+}
+
+func TestCostPageValidate(t *testing.T) {
+	require.NoError(t, costPage{}.validate("--breakdown"), "no pagination flag is set")
+	require.NoError(t, costPage{limit: 5}.validate(""))
+
+	require.ErrorContains(t, costPage{page: 2}.validate(""), "invalid pagination parameters")
+	require.ErrorContains(t, costPage{page: 2, pageSize: 5, offset: 3}.validate(""), "mutually exclusive")
+
+	err := costPage{limit: 5}.validate("--rollup")
+	var usageErr *usageError
+	require.ErrorAs(t, err, &usageErr)
+	assert.Contains(t, err.Error(), "--rollup")
+}
+
+func TestCostPageApply(t *testing.T) {
+	results := pagedResults(t, 5)
+
+	shown, meta, err := costPage{}.apply(results)
+	require.NoError(t, err)
+	assert.Len(t, shown.Results, 5)
+	assert.Nil(t, meta)
+
+	shown, meta, err = costPage{page: 2, pageSize: 2}.apply(results)
+	require.NoError(t, err)
+	require.NotNil(t, meta)
+	require.Len(t, shown.Results, 2)
+	assert.Equal(t, "urn:2", shown.Results[0].ResourceID)
+	assert.Equal(t, pagination.PaginationMeta{
+		CurrentPage: 2, PageSize: 2, TotalPages: 3, TotalItems: 5, HasPrevious: true, HasNext: true,
+	}, *meta)
+	assert.Equal(t, 5, results.Results.Len(), "the full results are kept")
+
+	shown, _, err = costPage{limit: 2, offset: 4}.apply(results)
+	require.NoError(t, err)
+	require.Len(t, shown.Results, 1)
+	assert.Equal(t, "urn:4", shown.Results[0].ResourceID)
+
+	shown, _, err = costPage{page: 9, pageSize: 2}.apply(results)
+	require.NoError(t, err)
+	require.Len(t, shown.Results, 1, "a page past the end is capped to the last page")
+	assert.Equal(t, "urn:4", shown.Results[0].ResourceID)
+}
+
+func TestRenderCostOutput_Page(t *testing.T) {
+	shown, meta, err := costPage{limit: 2}.apply(pagedResults(t, 3))
+	require.NoError(t, err)
+
+	cmd := newOutputCmd(t, "json")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, RenderCostOutput(context.Background(), cmd, "json", shown, meta))
+	var projected struct {
+		FinFocus   engine.AggregatedResults  `json:"finfocus"`
+		Pagination pagination.PaginationMeta `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &projected))
+	assert.Len(t, projected.FinFocus.Resources, 2)
+	assert.Equal(t, 3, projected.Pagination.TotalItems)
+	assert.True(t, projected.Pagination.HasNext)
+
+	out.Reset()
+	require.NoError(t, RenderActualCostOutput(context.Background(), cmd, "json", shown, "", false, meta))
+	var actual struct {
+		Results    []engine.CostResult       `json:"results"`
+		Pagination pagination.PaginationMeta `json:"pagination"`
+	}
+	require.NoError(t, json.Unmarshal(out.Bytes(), &actual))
+	require.Len(t, actual.Results, 2)
+	assert.Equal(t, "urn:1", actual.Results[1].ResourceID)
+	assert.Equal(t, 2, actual.Pagination.TotalPages)
+
+	out.Reset()
+	writePageFooter(cmd, "table", meta, len(shown.Results))
+	assert.Equal(t, "Showing 2 of 3 results; use --limit, --offset, or --page for more.\n", out.String())
+}
@@ -0,0 +1,133 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// comparePricingModels prices the resources once under each pricing model and
+// sets the costs side by side. lineItems, such as modeled transfer costs, do
+// not depend on the pricing model and are added under every model.
+func comparePricingModels(
+	ctx context.Context,
+	eng projectedCostEngine,
+	resources []engine.ResourceDescriptor,
+	lineItems []engine.CostResult,
+) (*engine.PricingModelComparison, error) {
+	results := make(map[string][]engine.CostResult, len(engine.PricingModels()))
+	for _, model := range engine.PricingModels() {
+		modelCtx := context.WithValue(ctx, engine.ContextKeyPricingModel, model)
+		priced, err := eng.StreamProjectedCostWithErrors(modelCtx, resources, nil)
+		if err != nil {
+			return nil, fmt.Errorf("pricing %s: %w", model, err)
+		}
+		results[model] = append(priced.Results, lineItems...)
+	}
+	return engine.ComparePricingModels(results), nil
+}
+
+// executePricingModelComparison renders the --compare-pricing-models
+// comparison of the resources in place of the projected cost output.
+func executePricingModelComparison(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng projectedCostEngine,
+	resources []engine.ResourceDescriptor,
+	params costProjectedParams,
+	audit *auditContext,
+) error {
+	var lineItems []engine.CostResult
+	if params.transfer {
+		estimates := engine.EstimateDataTransfer(resources, engine.TransferOptions{GBPerMonth: params.transferGB})
+		lineItems = engine.TransferLineItems(estimates)
+	}
+	comparison, err := comparePricingModels(ctx, eng, resources, lineItems)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("calculating projected costs: %w", err)
+	}
+	if renderErr := renderPricingModelComparison(cmd, params.output, comparison); renderErr != nil {
+		return renderErr
+	}
+	audit.logSuccess(ctx, len(comparison.Resources), comparison.Totals[engine.PricingModelOnDemand])
+	return nil
+}
+
+// renderPricingModelComparison renders the comparison as a table or, with
+// --output json, as an engine.PricingModelComparison.
+func renderPricingModelComparison(
+	cmd *cobra.Command,
+	output string,
+	comparison *engine.PricingModelComparison,
+) error {
+	if config.GetOutputFormat(output) == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(comparison); err != nil {
+			return fmt.Errorf("encoding pricing model comparison JSON: %w", err)
+		}
+		return nil
+	}
+	return renderPricingModelTable(cmd.OutOrStdout(), comparison)
+}
+
+// renderPricingModelTable renders one row per resource with a monthly cost
+// column per pricing model, followed by the totals and the savings of each
+// model over on-demand.
+func renderPricingModelTable(w io.Writer, comparison *engine.PricingModelComparison) error {
+	fmt.Fprintf(w, "Monthly cost by pricing model (%s)\n\n", comparison.Currency)
+	if len(comparison.Resources) == 0 {
+		fmt.Fprintln(w, "No costs to compare.")
+		return nil
+	}
+
+	headers := make([]string, 0, len(comparison.Models))
+	for _, model := range comparison.Models {
+		headers = append(headers, strings.ToUpper(model))
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintf(tw, "RESOURCE\tTYPE\t%s\n", strings.Join(headers, "\t"))
+	for _, resource := range comparison.Resources {
+		cells := make([]string, 0, len(comparison.Models))
+		for _, model := range comparison.Models {
+			if monthly, ok := resource.Monthly[model]; ok {
+				cells = append(cells, fmt.Sprintf("%.2f", monthly))
+			} else {
+				cells = append(cells, "-")
+			}
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\n", resource.ResourceID, resource.ResourceType, strings.Join(cells, "\t"))
+	}
+
+	totals := make([]string, 0, len(comparison.Models))
+	savings := make([]string, 0, len(comparison.Models))
+	onDemand := comparison.Totals[engine.PricingModelOnDemand]
+	for _, model := range comparison.Models {
+		totals = append(totals, fmt.Sprintf("%.2f", comparison.Totals[model]))
+		saving := comparison.Savings[model]
+		if onDemand > 0 {
+			percent := saving / onDemand * 100 //nolint:mnd // Percentage calculation.
+			savings = append(savings, fmt.Sprintf("%.2f (%.1f%%)", saving, percent))
+		} else {
+			savings = append(savings, fmt.Sprintf("%.2f", saving))
+		}
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%s\n", strings.Join(totals, "\t"))
+	fmt.Fprintf(tw, "SAVINGS\t\t%s\n", strings.Join(savings, "\t"))
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintln(w, "\nPlugins that do not support a pricing model return on-demand prices for it.")
+	return nil
+}
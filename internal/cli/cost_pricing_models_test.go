@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// pricingModelTestEngine prices every resource at a monthly cost that
+// depends on the pricing model in the context.
+type pricingModelTestEngine struct {
+	mockRecommendationFetcher
+}
+
+func (e *pricingModelTestEngine) StreamProjectedCostWithErrors(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	_ engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	monthly := map[string]float64{
+		engine.PricingModelOnDemand:   100,
+		engine.PricingModelSpot:       30,
+		engine.PricingModelReserved1Y: 60,
+		engine.PricingModelReserved3Y: 40,
+	}[engine.PricingModelFromContext(ctx)]
+	result := &engine.CostResultWithErrors{}
+	for _, r := range resources {
+		result.Results = append(result.Results,
+			engine.CostResult{ResourceID: r.ID, ResourceType: r.Type, Currency: "USD", Monthly: monthly})
+	}
+	return result, nil
+}
+
+func TestComparePricingModels(t *testing.T) {
+	resources := []engine.ResourceDescriptor{{ID: "web", Type: "aws:ec2/instance:Instance"}}
+	lineItems := []engine.CostResult{{ResourceID: "web#transfer:egress", Currency: "USD", Monthly: 9}}
+
+	comparison, err := comparePricingModels(context.Background(), &pricingModelTestEngine{}, resources, lineItems)
+	require.NoError(t, err)
+
+	assert.Equal(t, engine.PricingModels(), comparison.Models)
+	require.Len(t, comparison.Resources, 2)
+	assert.InDelta(t, 109.0, comparison.Totals[engine.PricingModelOnDemand], 0.001)
+	assert.InDelta(t, 39.0, comparison.Totals[engine.PricingModelSpot], 0.001)
+	assert.InDelta(t, 60.0, comparison.Savings[engine.PricingModelReserved3Y], 0.001)
+}
+
+func TestRenderPricingModelComparison_Table(t *testing.T) {
+	resources := []engine.ResourceDescriptor{{ID: "web", Type: "aws:ec2/instance:Instance"}}
+	comparison, err := comparePricingModels(context.Background(), &pricingModelTestEngine{}, resources, nil)
+	require.NoError(t, err)
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, renderPricingModelComparison(cmd, outputFormatTable, comparison))
+
+	text := out.String()
+	assert.Contains(t, text, "Monthly cost by pricing model (USD)")
+	assert.Contains(t, text, "ON-DEMAND")
+	assert.Contains(t, text, "RESERVED-3Y")
+	assert.Contains(t, text, "70.00 (70.0%)")
+	assert.Contains(t, text, "return on-demand prices")
+}
+
+func TestRenderPricingModelComparison_JSON(t *testing.T) {
+	comparison := engine.ComparePricingModels(map[string][]engine.CostResult{
+		engine.PricingModelOnDemand: {{ResourceID: "web", Currency: "USD", Monthly: 100}},
+		engine.PricingModelSpot:     {{ResourceID: "web", Currency: "USD", Monthly: 30}},
+	})
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, renderPricingModelComparison(cmd, outputFormatJSON, comparison))
+
+	var got engine.PricingModelComparison
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, []string{engine.PricingModelOnDemand, engine.PricingModelSpot}, got.Models)
+	assert.InDelta(t, 70.0, got.Savings[engine.PricingModelSpot], 0.001)
+}
+
+func TestRenderPricingModelComparison_Empty(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderPricingModelTable(&out, engine.ComparePricingModels(nil)))
+	assert.Contains(t, out.String(), "No costs to compare.")
+}
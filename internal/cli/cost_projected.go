@@ -2,6 +2,7 @@ package cli
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -39,12 +40,45 @@ func displayErrorSummary(
 
 // costProjectedParams holds the parameters for the projected cost command execution.
 type costProjectedParams struct {
-	planPath    string
+	planPaths   []string
+	k8sManifest string
 	specDir     string
 	adapter     string
 	output      string
 	filter      []string
 	utilization float64
+	record      bool
+	updatePR    int
+	breakdown   bool
+	transfer    bool
+	transferGB  float64
+	// pricingModel is the pricing model requested from plugins.
+	pricingModel string
+	// comparePricing prices the resources under every pricing model.
+	comparePricing bool
+	// usageProfile names the cost.profiles entry that scales hourly costs.
+	usageProfile string
+	// onError is the --on-error policy for resources that failed to be priced.
+	onError string
+	// minConfidence is the least accuracy of the results counted in totals.
+	minConfidence string
+	// rollup aggregates costs under their Pulumi component resources.
+	rollup string
+	// delta prices the changes of the preview instead of its resources.
+	delta bool
+	// baseline is a saved JSON result, or the name of a baseline saved with
+	// 'baseline save', to compare the results with.
+	baseline string
+	// maxIncrease is the largest increase in monthly cost that --delta or
+	// --baseline accepts: an amount, or with --baseline a percentage.
+	maxIncrease string
+	// noIncremental queries plugins for every resource instead of reusing
+	// the cached results of unchanged ones.
+	noIncremental bool
+	// sort orders the results: cost, name, or type with :asc or :desc.
+	sort string
+	// page selects one page of the results.
+	page costPage
 }
 
 // NewCostProjectedCmd creates the "projected" subcommand for calculating projected costs.
@@ -86,7 +120,69 @@ func NewCostProjectedCmd() *cobra.Command {
 
 When --pulumi-json is omitted, finfocus automatically detects the Pulumi project
 in the current directory and runs 'pulumi preview --json' to generate the input.
-Use --stack to target a specific stack during auto-detection.`,
+Use --stack to target a specific stack during auto-detection. A fully qualified
+--stack org/project/stack fetches the latest deployed state from the Pulumi Cloud
+API instead (token from PULUMI_ACCESS_TOKEN or pulumi.access_token).
+
+Repeat --pulumi-json (or pass a glob) to cost several stacks together: results
+gain a stack column, totals are subtotaled per stack, and budgets are evaluated
+against the combined cost.
+
+--output github-comment (or gitlab-comment, bitbucket-comment) renders a
+Markdown pull request comment with the cost change since the projection last
+recorded for --stack, budget impact, and the top recommendations. --update-pr
+posts it as a sticky comment on that pull or merge request, replacing the
+previous one. Without --output the platform is detected from the CI
+environment, defaulting to GitHub.
+
+--output gh-summary writes a Markdown cost table, budget gauges, and a savings
+summary to the GitHub Actions job summary ($GITHUB_STEP_SUMMARY) when running
+in Actions, and to stdout otherwise.
+
+Resources whose plugin calls failed are kept as $0 placeholders by default,
+which understates totals. --on-error fail fails the command instead, and
+--on-error omit leaves them out of the results and totals and reports how many
+were left out. Streamed output (ndjson) is written before the policy applies;
+it ends with a summary record of type "summary" with the totals and the number
+of failed plugin calls.
+
+Every result has an accuracy: exact (billing data or a fixed price), estimated
+(priced from published rates), heuristic (a local spec, a modeled transfer, or
+a fallback price), or unknown (not priced). --min-confidence leaves less
+accurate results out of the output and totals.
+
+--rollup components aggregates the costs of resources under the Pulumi
+component resources that enclose them, with a subtotal per component. Table
+output draws the components as a tree, which is collapsible in an interactive
+terminal; JSON output nests them.
+
+--delta prices what the preview changes rather than every resource: the cost
+of the resources it creates, less the cost of those it deletes, plus the change
+in cost of those it updates or replaces, as in "This deploy adds $123.45/mo".
+--max-increase fails the command with --exit-code when the deploy adds more
+than the given monthly amount, for gating pull requests.
+
+--baseline compares the results with a result saved from an earlier run with
+--output json or ndjson, without the projection history of --record, or with
+a baseline saved under a name with 'finfocus baseline save'. With
+--max-increase, an amount such as 100 or a percentage such as 10%, the command
+fails with --exit-code when the total or any resource increases by more.
+
+Results are cached per resource by a hash of its type, provider, and
+properties, so a run against a slightly changed plan queries plugins only for
+the resources that changed; renaming a resource keeps its cached result. The
+cache follows the plugin response cache settings and --cache-ttl.
+--no-incremental queries plugins for every resource.
+
+Results are listed by resource URN. --sort orders them by cost, name, or type,
+ascending unless followed by :desc (for example --sort cost:desc). Without
+--sort, --output ndjson streams results as they are priced, in plan order
+rather than URN order, and the interactive table shows them as they arrive,
+ordered by its own sort column.
+
+--limit and --offset, or --page and --page-size, return one page of the
+sorted results. JSON output adds a "pagination" object with the page and the
+total number of results; totals, budgets, and --baseline use every result.`,
 		Example: costProjectedExample,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return executeCostProjected(cmd, params)
@@ -95,16 +191,62 @@ Use --stack to target a specific stack during auto-detection.`,
 
 	// --pulumi-json is intentionally optional (not MarkFlagRequired) to support
 	// automatic Pulumi project detection via FindProject + preview.
-	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "",
-		"Path to Pulumi preview JSON output (optional; auto-detected from Pulumi project if omitted)")
+	cmd.Flags().StringArrayVar(&params.planPaths, "pulumi-json", nil,
+		"Path or glob of Pulumi preview JSON (optional; auto-detected if omitted); repeat to aggregate stacks")
+	cmd.Flags().StringVar(&params.k8sManifest, "k8s-manifest", "",
+		"Kubernetes manifest file or directory; costs its Deployments, StatefulSets, and PersistentVolumeClaims")
 	cmd.Flags().StringVar(&params.specDir, "spec-dir", "", "Directory containing pricing spec files")
 	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
 	cmd.Flags().StringVar(
-		&params.output, "output", config.GetDefaultOutputFormat(), "Output format: table, json, or ndjson")
+		&params.output, "output", config.GetDefaultOutputFormat(),
+		"Output format: table, json, ndjson, gh-summary, github-comment, gitlab-comment, or bitbucket-comment")
 	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
-		"Resource filter expressions (e.g., 'type=aws:ec2/instance')")
+		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag.env =~ \"prod*\"')")
 	cmd.Flags().Float64Var(
 		&params.utilization, "utilization", 1.0, "Utilization rate for sustainability calculations (0.0 to 1.0)")
+	cmd.Flags().BoolVar(&params.record, "record", false,
+		"Record the projected costs for --stack so 'cost variance' can compare them with actual spend")
+	cmd.Flags().IntVar(&params.updatePR, "update-pr", 0,
+		"Post the comment output as a sticky comment on this pull or merge request number")
+	cmd.Flags().BoolVar(&params.breakdown, "breakdown", false,
+		"Break each resource's monthly cost into compute, storage, data transfer, and license")
+	cmd.Flags().BoolVar(&params.transfer, "estimate-transfer", false,
+		"Add estimated inter-AZ, inter-region, and egress transfer costs as line items")
+	cmd.Flags().Float64Var(&params.transferGB, "transfer-gb", engine.DefaultTransferGBPerMonth,
+		"Monthly GB assumed per transfer path with --estimate-transfer")
+	cmd.Flags().StringVar(&params.pricingModel, "pricing-model", engine.PricingModelOnDemand,
+		"Pricing model requested from plugins: on-demand, spot, reserved-1y, or reserved-3y")
+	cmd.Flags().BoolVar(&params.comparePricing, "compare-pricing-models", false,
+		"Show each resource's monthly cost under every pricing model side by side")
+	cmd.Flags().StringVar(&params.usageProfile, "usage-profile", "",
+		"Scale hourly-billed costs to the hours of this usage profile from cost.profiles in the config")
+	addOnErrorFlag(cmd, &params.onError)
+	addMinConfidenceFlag(cmd, &params.minConfidence)
+	addRollupFlag(cmd, &params.rollup)
+	cmd.Flags().BoolVar(&params.delta, "delta", false,
+		"Show the monthly cost change the preview implies instead of the cost of every resource")
+	cmd.Flags().StringVar(&params.baseline, "baseline", "",
+		"Compare the results with a result saved with --output json or ndjson, or a saved baseline name")
+	cmd.Flags().StringVar(&params.maxIncrease, "max-increase", "",
+		"With --delta or --baseline, exit with --exit-code when the monthly cost increases by more (100 or 10%)")
+	cmd.Flags().BoolVar(&params.noIncremental, "no-incremental", false,
+		"Query plugins for every resource instead of reusing the cached results of unchanged resources")
+	addSortFlag(cmd, &params.sort)
+	addPaginationFlags(cmd, &params.page)
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "k8s-manifest")
+	cmd.MarkFlagsMutuallyExclusive("pricing-model", "compare-pricing-models")
+	cmd.MarkFlagsMutuallyExclusive("breakdown", "compare-pricing-models")
+	cmd.MarkFlagsMutuallyExclusive("breakdown", "rollup")
+	cmd.MarkFlagsMutuallyExclusive("rollup", "compare-pricing-models")
+	cmd.MarkFlagsMutuallyExclusive("sort", "compare-pricing-models")
+	for _, flag := range []string{
+		"k8s-manifest", "breakdown", "rollup", "compare-pricing-models", "estimate-transfer", "record", "update-pr",
+		"sort",
+	} {
+		cmd.MarkFlagsMutuallyExclusive("delta", flag)
+	}
+	cmd.MarkFlagsMutuallyExclusive("baseline", "delta")
+	cmd.MarkFlagsMutuallyExclusive("baseline", "compare-pricing-models")
 
 	return cmd
 }
@@ -115,12 +257,25 @@ const costProjectedExample = `  # Auto-detect from Pulumi project
   # Specific stack
   finfocus cost projected --stack production
 
+  # Deployed state of a Pulumi Cloud stack (no local project needed)
+  finfocus cost projected --stack acme/webapp/production
+
   # Explicit file (existing behavior)
   finfocus cost projected --pulumi-json plan.json
 
   # Filter resources by type
   finfocus cost projected --pulumi-json plan.json --filter "type=aws:ec2/instance"
 
+  # Filter resources with an expression
+  finfocus cost projected --pulumi-json plan.json --filter 'provider == "aws" && tag.env =~ "prod*"'
+
+  # Aggregate several stacks (repeat the flag or use a glob)
+  finfocus cost projected --pulumi-json dev.json --pulumi-json prod.json
+  finfocus cost projected --pulumi-json 'plans/*.json'
+
+  # Kubernetes workloads and volume claims from manifests
+  finfocus cost projected --k8s-manifest ./k8s --adapter kubecost
+
   # Output as JSON
   finfocus cost projected --pulumi-json plan.json --output json
 
@@ -128,7 +283,55 @@ const costProjectedExample = `  # Auto-detect from Pulumi project
   finfocus cost projected --pulumi-json plan.json --adapter aws-plugin
 
   # Use custom spec directory
-  finfocus cost projected --pulumi-json plan.json --spec-dir ./custom-specs`
+  finfocus cost projected --pulumi-json plan.json --spec-dir ./custom-specs
+
+  # Record the projection at deploy time for 'cost variance'
+  finfocus cost projected --stack production --record
+
+  # Markdown pull request comment with the cost change since the last --record
+  finfocus cost projected --stack production --output github-comment
+
+  # Post or update the sticky comment on pull request 42 (in GitHub Actions)
+  finfocus cost projected --stack production --update-pr 42
+
+  # Same for a GitLab merge request
+  finfocus cost projected --stack production --output gitlab-comment --update-pr 17
+
+  # Cost table, budget gauges, and savings on the GitHub Actions run page
+  finfocus cost projected --pulumi-json plan.json --output gh-summary
+
+  # Monthly cost by pricing dimension (compute, storage, data transfer, license)
+  finfocus cost projected --pulumi-json plan.json --breakdown
+
+  # Include modeled data transfer costs, assuming 500 GB per path
+  finfocus cost projected --pulumi-json plan.json --estimate-transfer --transfer-gb 500
+
+  # Price with spot instances, or compare every pricing model side by side
+  finfocus cost projected --pulumi-json plan.json --pricing-model spot
+  finfocus cost projected --pulumi-json plan.json --compare-pricing-models
+
+  # Cost of a dev environment that runs only during working hours
+  finfocus cost projected --pulumi-json plan.json --usage-profile dev
+
+  # Fail instead of counting resources that failed to be priced as $0
+  finfocus cost projected --pulumi-json plan.json --on-error fail
+
+  # Count only costs priced by plugins or from billing data
+  finfocus cost projected --pulumi-json plan.json --min-confidence estimated
+
+  # Monthly cost of each Pulumi component, as a tree
+  finfocus cost projected --pulumi-json plan.json --rollup components
+
+  # Monthly cost change of the preview; fail when it adds more than $100/mo
+  finfocus cost projected --pulumi-json plan.json --delta --max-increase 100
+
+  # Fail when the total or any resource costs 10% more than in a saved result
+  finfocus cost projected --pulumi-json plan.json --output json > baseline.json
+  finfocus cost projected --pulumi-json plan.json --baseline baseline.json --max-increase 10%
+
+  # Compare with a baseline saved by name
+  finfocus cost projected --pulumi-json plan.json --output json | finfocus baseline save main
+  finfocus cost projected --pulumi-json plan.json --baseline main`
 
 // executeCostProjected runs the projected cost calculation for the "projected" command.
 // It validates the utilization value, obtains resource descriptors either from an explicit
@@ -171,28 +374,145 @@ func executeCostProjected(cmd *cobra.Command, params costProjectedParams) error
 		return fmt.Errorf("utilization must be between 0.0 and 1.0, got %f", params.utilization)
 	}
 	ctx = context.WithValue(ctx, engine.ContextKeyUtilization, params.utilization)
+	if params.pricingModel != "" {
+		if err := engine.ValidatePricingModel(params.pricingModel); err != nil {
+			return err
+		}
+	}
+	ctx = context.WithValue(ctx, engine.ContextKeyPricingModel, params.pricingModel)
+	errorPolicy, err := parseOnError(params.onError)
+	if err != nil {
+		return err
+	}
+	minAccuracy, err := parseMinConfidence(params.minConfidence)
+	if err != nil {
+		return err
+	}
+	rollup, err := parseRollup(params.rollup, params.output)
+	if err != nil {
+		return err
+	}
+	resultSort, err := parseCostSort(params.sort)
+	if err != nil {
+		return err
+	}
 
 	log := logging.FromContext(ctx)
-	log.Debug().Ctx(ctx).Str("operation", "cost_projected").Str("plan_path", params.planPath).
+	log.Debug().Ctx(ctx).Str("operation", "cost_projected").Strs("plan_paths", params.planPaths).
 		Msg("starting projected cost calculation")
 
-	auditParams := map[string]string{"pulumi_json": params.planPath, "output": params.output}
+	auditParams := map[string]string{
+		"pulumi_json":   strings.Join(params.planPaths, ","),
+		"output":        params.output,
+		"pricing_model": params.pricingModel,
+	}
 	if len(params.filter) > 0 {
 		auditParams["filter"] = strings.Join(params.filter, ",")
 	}
 	audit := newAuditContext(ctx, "cost projected", auditParams)
 
 	var resources []engine.ResourceDescriptor
-	var err error
+	// stacks maps resource IDs to their plan's stack when several plans are aggregated.
+	var stacks map[string]string
 
-	if params.planPath != "" {
-		resources, err = loadAndMapResources(ctx, params.planPath, audit)
+	if params.updatePR < 0 {
+		return fmt.Errorf("--update-pr must be a pull request number, got %d", params.updatePR)
+	}
+	var platform *commentPlatform
+	if params.updatePR > 0 && !cmd.Flags().Changed("output") {
+		platform = detectCommentPlatform()
+		params.output = platform.format
 	} else {
-		auditParams["pulumi_json"] = "auto-detect"
-		stackFlag, flagErr := cmd.Flags().GetString("stack")
-		if flagErr != nil {
-			return fmt.Errorf("reading --stack flag: %w", flagErr)
+		platform = commentPlatformForFormat(config.GetOutputFormat(params.output))
+	}
+	if params.updatePR > 0 && platform == nil {
+		return fmt.Errorf("--update-pr requires --output %s, %s, or %s",
+			outputFormatGitHubComment, outputFormatGitLabComment, outputFormatBitbucketComment)
+	}
+	commentMode := platform != nil
+	summaryMode := config.GetOutputFormat(params.output) == outputFormatGHSummary
+	// Markdown outputs evaluate budgets quietly and report them in the document.
+	markdownMode := commentMode || summaryMode
+	if params.breakdown {
+		if err := validateBreakdownOutput(params.output); err != nil {
+			return err
+		}
+	}
+	if params.comparePricing {
+		if format := config.GetOutputFormat(params.output); format != outputFormatTable && format != outputFormatJSON {
+			return fmt.Errorf("--compare-pricing-models supports --output table or json, got %q", format)
+		}
+		if params.record {
+			return errors.New("--compare-pricing-models cannot be combined with --record")
+		}
+	}
+	if err = params.page.validate(projectedPaginateWithout(params, markdownMode)); err != nil {
+		return err
+	}
+	var usageProfile config.UsageProfile
+	if params.usageProfile != "" {
+		profile, err := lookupUsageProfile(params.usageProfile)
+		if err != nil {
+			return err
+		}
+		usageProfile = profile
+		auditParams["usage_profile"] = params.usageProfile
+	}
+	if params.transferGB < 0 {
+		return fmt.Errorf("--transfer-gb must not be negative, got %g", params.transferGB)
+	}
+	maxIncrease, err := parseMaxIncrease(cmd, params)
+	if err != nil {
+		return err
+	}
+	if params.delta {
+		if err = validateDeltaOutput(params.output); err != nil {
+			return err
+		}
+	}
+	var baseline []engine.CostResult
+	if params.baseline != "" {
+		if baseline, err = loadBaselineResults(params.baseline); err != nil {
+			return err
+		}
+		auditParams["baseline"] = params.baseline
+	}
+
+	stackFlag := getStackFlag(cmd)
+	if params.record && stackFlag == "" {
+		return errors.New("--record requires --stack to name the stack the projection belongs to")
+	}
+
+	planPaths, err := expandPlanPaths(params.planPaths)
+	if err != nil {
+		return err
+	}
+	if params.record && len(planPaths) > 1 {
+		return errors.New("--record supports a single --pulumi-json plan")
+	}
+	if params.delta && len(planPaths) > 1 {
+		return &usageError{err: errors.New("--delta supports a single --pulumi-json plan")}
+	}
+
+	var changes *previewChanges
+	switch {
+	case params.delta:
+		planPath := ""
+		if len(planPaths) == 1 {
+			planPath = planPaths[0]
+		} else {
+			auditParams["pulumi_json"] = "auto-detect"
 		}
+		changes, err = loadPreviewChanges(ctx, planPath, stackFlag, params.filter)
+	case params.k8sManifest != "":
+		auditParams["k8s_manifest"] = params.k8sManifest
+		resources, err = loadK8sResources(ctx, params.k8sManifest, audit)
+	case len(planPaths) > 1:
+		resources, stacks, err = loadMultiplePlans(ctx, planPaths, audit)
+	case len(planPaths) == 1:
+		resources, err = loadAndMapResources(ctx, planPaths[0], audit)
+	default:
+		auditParams["pulumi_json"] = "auto-detect"
 		resources, err = resolveResourcesFromPulumi(ctx, stackFlag, modePulumiPreview)
 	}
 	if err != nil {
@@ -218,43 +538,203 @@ func executeCostProjected(cmd *cobra.Command, params costProjectedParams) error
 	}
 	defer cleanup()
 
-	eng := engine.New(clients, spec.NewLoader(specDir)).
-		WithRouter(createRouterForEngine(ctx, cfg, clients))
-	resultWithErrors, err := eng.GetProjectedCostWithErrors(ctx, resources)
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	base := engine.New(clients, spec.NewLoader(specDir)).
+		WithRouter(createRouterForEngine(ctx, cfg, clients)).
+		WithSpillThreshold(cfg.Cost.SpillThreshold)
+	if !params.noIncremental {
+		enableIncrementalResults(ctx, cmd, cfg, base)
+	}
+	var eng projectedCostStoreEngine = base
+	if stacks != nil {
+		eng = stackLabelingEngine{projectedCostStoreEngine: eng, stacks: stacks}
+	}
+	if params.usageProfile != "" {
+		eng = usageProfileEngine{projectedCostStoreEngine: eng, name: params.usageProfile, profile: usageProfile}
+	}
+	if params.comparePricing {
+		return executePricingModelComparison(ctx, cmd, eng, resources, params, audit)
+	}
+	if params.delta {
+		return executePreviewDelta(ctx, cmd, eng, changes, params.output, maxIncrease, errorPolicy, audit)
+	}
+	// The breakdown and roll-up render their own tables, transfer line items
+	// are added after pricing, and --sort and pagination need every result,
+	// so results are stored rather than streamed.
+	calculateFormat := params.output
+	if params.breakdown || params.transfer || rollup != "" || params.sort != "" || params.page.enabled() {
+		calculateFormat = outputFormatJSON
+	}
+	store, rendered, err := calculateProjectedCosts(ctx, cmd, eng, resources, calculateFormat)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to calculate projected costs")
 		audit.logFailure(ctx, err)
 		return fmt.Errorf("calculating projected costs: %w", err)
 	}
+	results := &costResults{CostResultStore: store}
+	defer func() { _ = results.Close() }()
+	if params.transfer {
+		estimates := engine.EstimateDataTransfer(resources, engine.TransferOptions{GBPerMonth: params.transferGB})
+		if appendErr := results.Results.Append(engine.TransferLineItems(estimates)...); appendErr != nil {
+			return fmt.Errorf("adding data transfer line items: %w", appendErr)
+		}
+	}
+	if policyErr := results.ApplyErrorPolicy(errorPolicy); policyErr != nil {
+		audit.logFailure(ctx, policyErr)
+		return policyErr
+	}
+	if filterErr := applyMinConfidence(cmd, results, minAccuracy); filterErr != nil {
+		return filterErr
+	}
+	if sortErr := resultSort.apply(results); sortErr != nil {
+		return sortErr
+	}
 
-	fetchAndMergeRecommendations(ctx, eng, resources, resultWithErrors.Results)
-
-	if renderErr := RenderCostOutput(ctx, cmd, params.output, resultWithErrors); renderErr != nil {
-		return renderErr
+	switch {
+	case params.breakdown:
+		all, collectErr := results.collect()
+		if collectErr != nil {
+			return collectErr
+		}
+		if renderErr := renderCostBreakdown(
+			cmd, params.output, all.Results, engine.DimensionBasisMonthly,
+		); renderErr != nil {
+			return renderErr
+		}
+	case rollup != "":
+		all, collectErr := results.collect()
+		if collectErr != nil {
+			return collectErr
+		}
+		if renderErr := renderComponentRollup(
+			ctx, cmd, params.output, all.Results, resources, false,
+		); renderErr != nil {
+			return renderErr
+		}
+	case !rendered && !markdownMode:
+		if renderErr := renderProjectedCostResults(ctx, cmd, params.output, params.page, results); renderErr != nil {
+			return renderErr
+		}
 	}
 
-	log.Info().Ctx(ctx).Str("operation", "cost_projected").Int("result_count", len(resultWithErrors.Results)).
+	totalCost, currency, mixedCurrencies, err := results.totals(
+		func(r engine.CostResult) float64 { return r.Monthly })
+	if err != nil {
+		return err
+	}
+	log.Info().Ctx(ctx).Str("operation", "cost_projected").Int("result_count", results.Results.Len()).
 		Dur("duration_ms", time.Since(audit.start)).Msg("projected cost calculation complete")
+	audit.logSuccess(ctx, results.Results.Len(), totalCost)
+
+	// Baselines, comments, records, and budgets by scope need every result.
+	var all []engine.CostResult
+	impact := config.GetOutputFormat(params.output) == outputFormatTable
+	if params.baseline != "" || markdownMode || params.record || budgetsNeedResults(impact) {
+		collected, collectErr := results.collect()
+		if collectErr != nil {
+			return collectErr
+		}
+		all = collected.Results
+	}
 
-	totalCost := 0.0
-	for _, r := range resultWithErrors.Results {
-		totalCost += r.Monthly
+	// A failed baseline gate is returned after the budgets are rendered.
+	var baselineErr error
+	if params.baseline != "" {
+		baselineErr = checkBaseline(cmd, params.output, params.baseline, baseline, all, maxIncrease)
+	}
+
+	var budgetResult *BudgetRenderResult
+	var budgetErr error
+	if markdownMode && !mixedCurrencies {
+		budgetResult, budgetErr = evaluateBudgetsQuietly(cmd, all, resourceTagIndex(resources), totalCost, currency)
+	}
+	if summaryMode {
+		var summary strings.Builder
+		if summaryErr := renderCostSummary(&summary, costCommentData{
+			Stack: stackFlag, Currency: currency, Results: all, Budgets: budgetResult,
+		}); summaryErr != nil {
+			return summaryErr
+		}
+		if summaryErr := writeJobSummary(cmd, summary.String()); summaryErr != nil {
+			return summaryErr
+		}
+	}
+	if commentMode {
+		if commentErr := publishProjectedComment(
+			ctx, cmd, platform, params.updatePR, stackFlag, currency, all, budgetResult,
+		); commentErr != nil {
+			return commentErr
+		}
 	}
 
-	currency, mixedCurrencies := extractCurrencyFromResults(resultWithErrors.Results)
-	audit.logSuccess(ctx, len(resultWithErrors.Results), totalCost)
+	if params.record {
+		store := config.NewProjectionHistoryStore("")
+		if recordErr := recordProjectionSnapshot(ctx, store, stackFlag, resources, all, time.Now()); recordErr != nil {
+			return fmt.Errorf("recording projection: %w", recordErr)
+		}
+		cmd.PrintErrf("Recorded projection for stack %s to %s\n", stackFlag, store.FilePath())
+	}
 
 	// Evaluate and render budget status (T025: Call checkBudgetExit after renderBudgetIfConfigured)
 	// Render budget status only when currencies are consistent
 	if !mixedCurrencies {
-		scopeFilter := getBudgetScopeFilter(cmd)
-
-		budgetResult, budgetErr := renderBudgetWithScope(
-			cmd, resultWithErrors.Results, totalCost, currency, scopeFilter)
+		if !markdownMode {
+			budgetResult, budgetErr = renderBudgetWithScope(cmd, all, resourceTagIndex(resources),
+				totalCost, currency, getBudgetScopeFilter(cmd), nil)
+		}
+		if budgetErr == nil && impact {
+			if impactErr := renderBudgetImpactIfConfigured(cmd, all, resourceTagIndex(resources)); impactErr != nil {
+				return impactErr
+			}
+		}
 		if exitErr := checkBudgetExitFromResult(cmd, budgetResult, budgetErr); exitErr != nil {
 			return exitErr
 		}
 	}
 
+	return baselineErr
+}
+
+// renderProjectedCostResults renders the projected cost results of a command
+// that did not stream them. NDJSON is written from the store a chunk at a
+// time; other outputs aggregate every result, or the page selected by page.
+func renderProjectedCostResults(
+	ctx context.Context,
+	cmd *cobra.Command,
+	outputFormat string,
+	page costPage,
+	results *costResults,
+) error {
+	if config.GetOutputFormat(outputFormat) == outputFormatNDJSON && !page.enabled() {
+		return writeStoreNDJSON(cmd, results.CostResultStore, false, false)
+	}
+	shown, meta, err := page.apply(results)
+	if err != nil {
+		return err
+	}
+	if err = RenderCostOutput(ctx, cmd, outputFormat, shown, meta); err != nil {
+		return err
+	}
+	writePageFooter(cmd, outputFormat, meta, len(shown.Results))
 	return nil
 }
+
+// projectedPaginateWithout names the first flag given that renders something
+// other than the plain results, which pagination applies to, or returns an
+// empty string.
+func projectedPaginateWithout(params costProjectedParams, markdownMode bool) string {
+	switch {
+	case params.breakdown:
+		return "--breakdown"
+	case params.rollup != "":
+		return "--rollup"
+	case params.comparePricing:
+		return "--compare-pricing-models"
+	case params.delta:
+		return "--delta"
+	case markdownMode:
+		return "--output " + params.output
+	default:
+		return ""
+	}
+}
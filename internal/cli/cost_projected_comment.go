@@ -0,0 +1,326 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+)
+
+// Cost comment limits.
+const (
+	// costCommentMaxChanges caps the per-resource cost changes listed.
+	costCommentMaxChanges = 10
+	// costCommentMaxRecommendations caps the recommendations listed.
+	costCommentMaxRecommendations = 5
+)
+
+// costCommentData is everything shown in a projected cost PR comment.
+type costCommentData struct {
+	// Stack names the stack the projection belongs to; empty when unknown.
+	Stack    string
+	Currency string
+	Results  []engine.CostResult
+	// Delta compares the projection with the last recorded one; nil when
+	// there is no baseline.
+	Delta *engine.ProjectionDelta
+	// Budgets is the budget evaluation; nil when no budget is configured.
+	Budgets *BudgetRenderResult
+}
+
+// costCommentMarker returns the hidden marker that identifies the sticky
+// comment. It includes the stack so several stacks can comment on one PR. The
+// marker is a Markdown link reference definition rather than an HTML comment
+// because Bitbucket escapes raw HTML; all three platforms render it as nothing.
+func costCommentMarker(stack string) string {
+	if stack == "" {
+		return "[//]: # (finfocus:cost-comment)"
+	}
+	return fmt.Sprintf("[//]: # (finfocus:cost-comment stack=%s)", stack)
+}
+
+// renderCostComment writes the projected cost PR comment as Markdown: the cost
+// delta against the recorded baseline, budget impact, and the top
+// recommendations by estimated savings. The output uses plain Markdown only,
+// so the same comment renders on GitHub, GitLab, and Bitbucket.
+func renderCostComment(w io.Writer, data costCommentData) error {
+	var b strings.Builder
+	cur := data.Currency
+
+	b.WriteString(costCommentMarker(data.Stack) + "\n\n")
+	if data.Stack != "" {
+		fmt.Fprintf(&b, "### FinFocus cost estimate for `%s`\n\n", data.Stack)
+	} else {
+		b.WriteString("### FinFocus cost estimate\n\n")
+	}
+
+	projected, failed := 0.0, 0
+	for _, r := range data.Results {
+		if r.Error != nil {
+			failed++
+			continue
+		}
+		projected += r.Monthly
+	}
+
+	b.WriteString("| | Monthly cost |\n| --- | ---: |\n")
+	if data.Delta != nil {
+		fmt.Fprintf(&b, "| Current (recorded %s) | %s |\n",
+			data.Delta.BaselineAt.Format(time.DateOnly), commentMoney(data.Delta.Baseline, cur))
+		fmt.Fprintf(&b, "| Projected | %s |\n", commentMoney(data.Delta.Projected, cur))
+		change := commentSignedMoney(data.Delta.Change, cur)
+		if data.Delta.Baseline != 0 {
+			change += fmt.Sprintf(" (%+.1f%%)", data.Delta.ChangePercent())
+		}
+		fmt.Fprintf(&b, "| **Change** | **%s** |\n", change)
+		writeCommentChanges(&b, data.Delta.Changes, cur)
+	} else {
+		fmt.Fprintf(&b, "| Projected | %s |\n", commentMoney(projected, cur))
+		if data.Stack != "" {
+			fmt.Fprintf(&b, "\n_No recorded projection for `%s`. Run `finfocus cost projected --stack %s --record` "+
+				"after deploying to show the cost change here._\n", data.Stack, data.Stack)
+		}
+	}
+
+	writeCommentBudgets(&b, data.Budgets)
+	writeCommentRecommendations(&b, data.Results)
+
+	if failed > 0 {
+		fmt.Fprintf(&b, "\n_%d resource(s) could not be priced and are not included._\n", failed)
+	}
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing cost comment: %w", err)
+	}
+	return nil
+}
+
+// writeCommentChanges lists the largest per-resource changes.
+func writeCommentChanges(b *strings.Builder, changes []engine.ResourceCostChange, cur string) {
+	if len(changes) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n#### Resource changes (%d)\n\n", len(changes))
+	b.WriteString("| Resource | Type | Before | After | Change |\n| --- | --- | ---: | ---: | ---: |\n")
+	for i, c := range changes {
+		if i == costCommentMaxChanges {
+			fmt.Fprintf(b, "\n_%d more not shown._\n", len(changes)-costCommentMaxChanges)
+			break
+		}
+		name := commentResourceName(c.ResourceID)
+		switch {
+		case c.Added:
+			name += " (new)"
+		case c.Removed:
+			name += " (removed)"
+		}
+		fmt.Fprintf(b, "| %s | `%s` | %s | %s | %s |\n", commentEscape(name), c.ResourceType,
+			commentMoney(c.Before, cur), commentMoney(c.After, cur), commentSignedMoney(c.Change, cur))
+	}
+}
+
+// writeCommentBudgets summarises how the projection lands against configured budgets.
+func writeCommentBudgets(b *strings.Builder, budgets *BudgetRenderResult) {
+	scopes := budgetScopes(budgets)
+	if len(scopes) == 0 {
+		return
+	}
+
+	b.WriteString("\n#### Budget impact\n\n")
+	b.WriteString("| Budget | Limit | Projected | Used | Status |\n| --- | ---: | ---: | ---: | --- |\n")
+	for _, s := range scopes {
+		fmt.Fprintf(b, "| %s | %s | %s | %.1f%% | %s |\n", commentEscape(s.ScopeIdentifier()),
+			commentMoney(s.Budget.Amount, s.Currency), commentMoney(s.CurrentSpend, s.Currency),
+			s.Percentage, healthStatusLabel(s.Health))
+	}
+}
+
+// budgetScopes flattens a budget evaluation into one status per budget, with
+// a legacy global budget reported as the global scope.
+func budgetScopes(budgets *BudgetRenderResult) []*engine.ScopedBudgetStatus {
+	switch {
+	case budgets == nil:
+		return nil
+	case budgets.LegacyStatus != nil:
+		status := budgets.LegacyStatus
+		return []*engine.ScopedBudgetStatus{{
+			ScopeType: engine.ScopeTypeGlobal,
+			Budget: config.ScopedBudget{
+				Amount: status.Budget.Amount, Period: status.Budget.Period, Alerts: status.Budget.Alerts,
+			},
+			CurrentSpend:       status.CurrentSpend,
+			Percentage:         status.Percentage,
+			ForecastedSpend:    status.ForecastedSpend,
+			ForecastPercentage: status.ForecastPercentage,
+			Health:             engine.CalculateHealthFromPercentage(status.Percentage),
+			Alerts:             status.Alerts,
+			Currency:           status.Currency,
+		}}
+	case budgets.ScopedResult != nil:
+		return budgets.ScopedResult.AllScopes()
+	default:
+		return nil
+	}
+}
+
+// writeCommentRecommendations lists the recommendations with the largest savings.
+func writeCommentRecommendations(b *strings.Builder, results []engine.CostResult) {
+	recs := recommendationsBySavings(results)
+	if len(recs) == 0 {
+		return
+	}
+
+	total, cur := 0.0, ""
+	for _, rec := range recs {
+		total += rec.EstimatedSavings
+		if cur == "" {
+			cur = rec.Currency
+		}
+	}
+
+	b.WriteString("\n#### Top recommendations\n\n")
+	b.WriteString("| Resource | Action | Description | Savings |\n| --- | --- | --- | ---: |\n")
+	for i, rec := range recs {
+		if i == costCommentMaxRecommendations {
+			break
+		}
+		savings := "-"
+		if rec.EstimatedSavings > 0 {
+			savings = commentMoney(rec.EstimatedSavings, rec.Currency)
+		}
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n", commentEscape(commentResourceName(rec.ResourceID)),
+			formatActionTypeLabel(rec.Type), commentEscape(rec.Description), savings)
+	}
+	if total > 0 {
+		fmt.Fprintf(b, "\n_Potential savings across %d recommendation(s): %s/month_\n",
+			len(recs), commentMoney(total, cur))
+	}
+}
+
+// recommendationsBySavings collects the recommendations merged into results,
+// largest estimated savings first.
+func recommendationsBySavings(results []engine.CostResult) []engine.Recommendation {
+	var recs []engine.Recommendation
+	for _, r := range results {
+		for _, rec := range r.Recommendations {
+			if rec.ResourceID == "" {
+				rec.ResourceID = r.ResourceID
+			}
+			recs = append(recs, rec)
+		}
+	}
+	return pipeline.Sort(pipeline.Descending(func(a, b engine.Recommendation) bool {
+		return a.EstimatedSavings < b.EstimatedSavings
+	}))(recs)
+}
+
+// commentResourceName shortens a Pulumi URN to its resource name.
+func commentResourceName(id string) string {
+	if i := strings.LastIndex(id, "::"); i >= 0 {
+		return id[i+2:]
+	}
+	return id
+}
+
+// commentEscape keeps text from breaking a Markdown table row.
+func commentEscape(s string) string {
+	return strings.NewReplacer("|", `\|`, "\r\n", " ", "\n", " ").Replace(s)
+}
+
+func commentMoney(amount float64, currency string) string {
+	return strings.TrimSpace(fmt.Sprintf("%.2f %s", amount, currency))
+}
+
+func commentSignedMoney(amount float64, currency string) string {
+	return strings.TrimSpace(fmt.Sprintf("%+.2f %s", amount, currency))
+}
+
+// projectionBaseline returns the delta against the projection recorded for
+// stack, or nil when there is no stack or nothing has been recorded.
+func projectionBaseline(
+	store *config.ProjectionHistoryStore,
+	stack string,
+	results []engine.CostResult,
+	now time.Time,
+) (*engine.ProjectionDelta, error) {
+	if stack == "" {
+		return nil, nil //nolint:nilnil // no stack to compare against
+	}
+	if err := store.Load(); err != nil {
+		return nil, fmt.Errorf("loading projection history: %w", err)
+	}
+	snapshot, ok := store.SnapshotAt(stack, now)
+	if !ok {
+		return nil, nil //nolint:nilnil // nothing recorded yet
+	}
+	return engine.CalculateProjectionDelta(*snapshot, results), nil
+}
+
+// evaluateBudgetsQuietly evaluates budgets like renderBudgetWithScope without
+// printing the budget table, so it does not end up in the comment output.
+func evaluateBudgetsQuietly(
+	cmd *cobra.Command,
+	costs []engine.CostResult,
+	tags map[string]map[string]string,
+	totalCost float64,
+	currency string,
+) (*BudgetRenderResult, error) {
+	out := cmd.OutOrStdout()
+	cmd.SetOut(io.Discard)
+	defer cmd.SetOut(out)
+	return renderBudgetWithScope(cmd, costs, tags, totalCost, currency, getBudgetScopeFilter(cmd), nil)
+}
+
+// publishProjectedComment renders the projected cost PR comment to the command
+// output and, when number is set, posts it as the sticky comment on that pull
+// (or merge) request of platform. The cost change is measured against the
+// projection recorded for stack.
+func publishProjectedComment(
+	ctx context.Context,
+	cmd *cobra.Command,
+	platform *commentPlatform,
+	number int,
+	stack, currency string,
+	results []engine.CostResult,
+	budgets *BudgetRenderResult,
+) error {
+	delta, err := projectionBaseline(config.NewProjectionHistoryStore(""), stack, results, time.Now())
+	if err != nil {
+		return err
+	}
+
+	var body strings.Builder
+	if err = renderCostComment(&body, costCommentData{
+		Stack:    stack,
+		Currency: currency,
+		Results:  results,
+		Delta:    delta,
+		Budgets:  budgets,
+	}); err != nil {
+		return err
+	}
+	if _, err = io.WriteString(cmd.OutOrStdout(), body.String()); err != nil {
+		return fmt.Errorf("writing cost comment: %w", err)
+	}
+
+	if number == 0 {
+		return nil
+	}
+	location, created, err := platform.post(ctx, number, costCommentMarker(stack), body.String())
+	if err != nil {
+		return err
+	}
+	action := "Updated"
+	if created {
+		action = "Posted"
+	}
+	cmd.PrintErrf("%s cost comment on %s\n", action, location)
+	return nil
+}
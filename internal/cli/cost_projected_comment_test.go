@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+const (
+	commentWebURN   = "urn:pulumi:prod::app::aws:ec2/instance:Instance::web"
+	commentQueueURN = "urn:pulumi:prod::app::aws:sqs/queue:Queue::queue"
+)
+
+func commentResults() []engine.CostResult {
+	return []engine.CostResult{
+		{
+			ResourceID: commentWebURN, ResourceType: "aws:ec2/instance:Instance", Monthly: 150, Currency: "USD",
+			Recommendations: []engine.Recommendation{
+				{Type: "RIGHTSIZE", Description: "Use t3.small | burstable", EstimatedSavings: 40, Currency: "USD"},
+				{Type: "TERMINATE", Description: "Idle", EstimatedSavings: 150, Currency: "USD"},
+			},
+		},
+		{ResourceID: "broken", Error: &engine.StructuredError{Code: engine.ErrCodePluginError}},
+	}
+}
+
+func TestRenderCostComment(t *testing.T) {
+	delta := engine.CalculateProjectionDelta(config.ProjectionSnapshot{
+		RecordedAt: time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC),
+		Resources: map[string]config.ProjectedResourceRecord{
+			commentWebURN:   {ResourceType: "aws:ec2/instance:Instance", Monthly: 100},
+			commentQueueURN: {ResourceType: "aws:sqs/queue:Queue", Monthly: 20},
+		},
+	}, commentResults())
+
+	var out bytes.Buffer
+	require.NoError(t, renderCostComment(&out, costCommentData{
+		Stack:    "prod",
+		Currency: "USD",
+		Results:  commentResults(),
+		Delta:    delta,
+		Budgets: &BudgetRenderResult{LegacyStatus: &engine.BudgetStatus{
+			Budget:       config.BudgetConfig{Amount: 200, Currency: "USD"},
+			CurrentSpend: 150,
+			Percentage:   75,
+			Currency:     "USD",
+		}},
+	}))
+	comment := out.String()
+
+	assert.Contains(t, comment, "[//]: # (finfocus:cost-comment stack=prod)")
+	assert.Contains(t, comment, "### FinFocus cost estimate for `prod`")
+	assert.Contains(t, comment, "| Current (recorded 2026-01-05) | 120.00 USD |")
+	assert.Contains(t, comment, "| **Change** | **+30.00 USD (+25.0%)** |")
+	assert.Contains(t, comment, "| web | `aws:ec2/instance:Instance` | 100.00 USD | 150.00 USD | +50.00 USD |")
+	assert.Contains(t, comment, "| queue (removed) |")
+	assert.Contains(t, comment, "| global | 200.00 USD | 150.00 USD | 75.0% |")
+	assert.Contains(t, comment, `Use t3.small \| burstable`, "pipes are escaped")
+	assert.Less(t, bytes.Index(out.Bytes(), []byte("Idle")), bytes.Index(out.Bytes(), []byte("t3.small")),
+		"recommendations are ordered by savings")
+	assert.Contains(t, comment, "Potential savings across 2 recommendation(s): 190.00 USD/month")
+	assert.Contains(t, comment, "1 resource(s) could not be priced")
+}
+
+func TestRenderCostComment_NoBaseline(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, renderCostComment(&out, costCommentData{
+		Stack:    "dev",
+		Currency: "USD",
+		Results:  []engine.CostResult{{ResourceID: commentWebURN, Monthly: 12.5, Currency: "USD"}},
+	}))
+	comment := out.String()
+
+	assert.Contains(t, comment, "| Projected | 12.50 USD |")
+	assert.Contains(t, comment, "finfocus cost projected --stack dev --record")
+	assert.NotContains(t, comment, "Budget impact")
+	assert.NotContains(t, comment, "Top recommendations")
+}
+
+func TestProjectionBaseline(t *testing.T) {
+	store := config.NewProjectionHistoryStore(t.TempDir() + "/history.json")
+	results := []engine.CostResult{{ResourceID: "web", Monthly: 30}}
+	now := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	delta, err := projectionBaseline(store, "", results, now)
+	require.NoError(t, err)
+	assert.Nil(t, delta, "no stack")
+
+	delta, err = projectionBaseline(store, "prod", results, now)
+	require.NoError(t, err)
+	assert.Nil(t, delta, "nothing recorded")
+
+	require.NoError(t, store.RecordSnapshot("prod", config.ProjectionSnapshot{
+		RecordedAt: now.AddDate(0, 0, -7),
+		Resources:  map[string]config.ProjectedResourceRecord{"web": {Monthly: 20}},
+	}))
+	require.NoError(t, store.Save())
+	delta, err = projectionBaseline(store, "prod", results, now)
+	require.NoError(t, err)
+	require.NotNil(t, delta)
+	assert.InDelta(t, 10.0, delta.Change, 0.001)
+}
+
+func TestCostProjected_UpdatePRRequiresCommentOutput(t *testing.T) {
+	cmd := NewCostProjectedCmd()
+	cmd.SetArgs([]string{"--pulumi-json", "plan.json", "--output", "json", "--update-pr", "42"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	err := cmd.Execute()
+	require.ErrorContains(t, err, "--update-pr requires --output github-comment, gitlab-comment, or bitbucket-comment")
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// expandPlanPaths expands glob patterns in --pulumi-json values and removes
+// duplicates while keeping the order the plans were given in. A pattern that
+// matches nothing is an error; plain paths are passed through unchanged so a
+// missing file is reported when it is loaded.
+func expandPlanPaths(patterns []string) ([]string, error) {
+	var paths []string
+	seen := make(map[string]bool)
+	for _, pattern := range patterns {
+		matches := []string{pattern}
+		if strings.ContainsAny(pattern, "*?[") {
+			var err error
+			matches, err = filepath.Glob(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid --pulumi-json pattern %q: %w", pattern, err)
+			}
+			if len(matches) == 0 {
+				return nil, fmt.Errorf("--pulumi-json pattern %q matched no files", pattern)
+			}
+		}
+		for _, path := range matches {
+			if !seen[path] {
+				seen[path] = true
+				paths = append(paths, path)
+			}
+		}
+	}
+	return paths, nil
+}
+
+// loadMultiplePlans loads every plan and returns their combined resources with
+// a map from resource ID to the stack label of the plan it came from.
+func loadMultiplePlans(
+	ctx context.Context,
+	paths []string,
+	audit *auditContext,
+) ([]engine.ResourceDescriptor, map[string]string, error) {
+	var resources []engine.ResourceDescriptor
+	stacks := make(map[string]string)
+	for _, path := range paths {
+		planResources, err := loadAndMapResources(ctx, path, audit)
+		if err != nil {
+			return nil, nil, err
+		}
+		label := planStackLabel(path, planResources)
+		for _, r := range planResources {
+			stacks[r.ID] = label
+		}
+		resources = append(resources, planResources...)
+	}
+	return resources, stacks, nil
+}
+
+// planStackLabel names a plan by the stack in its resource URNs, falling back
+// to the file name without its extension when no URN carries a stack.
+func planStackLabel(path string, resources []engine.ResourceDescriptor) string {
+	for _, r := range resources {
+		if stack := engine.ExtractStackFromURN(r.ID); stack != "" {
+			return stack
+		}
+	}
+	base := filepath.Base(path)
+	return strings.TrimSuffix(base, filepath.Ext(base))
+}
+
+// stackLabelingEngine sets CostResult.Stack on every result the wrapped engine
+// streams or returns, so streamed NDJSON and TUI output carry the stack too.
+type stackLabelingEngine struct {
+	projectedCostStoreEngine
+
+	stacks map[string]string
+}
+
+// StreamProjectedCostWithErrors labels each batch before handing it to fn.
+func (e stackLabelingEngine) StreamProjectedCostWithErrors(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	var labeled engine.ProjectedResultFunc
+	if fn != nil {
+		labeled = func(batch []engine.CostResult) error {
+			e.label(batch)
+			return fn(batch)
+		}
+	}
+	result, err := e.projectedCostStoreEngine.StreamProjectedCostWithErrors(ctx, resources, labeled)
+	if result != nil {
+		e.label(result.Results)
+	}
+	return result, err
+}
+
+// StreamProjectedCostToStore labels each batch before it is handed to fn and
+// stored.
+func (e stackLabelingEngine) StreamProjectedCostToStore(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ResultBatchFunc,
+) (*engine.CostResultStore, error) {
+	return e.projectedCostStoreEngine.StreamProjectedCostToStore(ctx, resources,
+		func(batch []engine.CostResult) error {
+			e.label(batch)
+			if fn == nil {
+				return nil
+			}
+			return fn(batch)
+		})
+}
+
+// label sets the stack of each result from its resource ID.
+func (e stackLabelingEngine) label(results []engine.CostResult) {
+	for i := range results {
+		if stack, ok := e.stacks[results[i].ResourceID]; ok {
+			results[i].Stack = stack
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestExpandPlanPaths(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"dev.json", "prod.json", "notes.txt"} {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte("{}"), 0o600))
+	}
+	dev, prod := filepath.Join(dir, "dev.json"), filepath.Join(dir, "prod.json")
+
+	paths, err := expandPlanPaths([]string{prod, filepath.Join(dir, "*.json")})
+	require.NoError(t, err)
+	assert.Equal(t, []string{prod, dev}, paths, "explicit paths keep their position and globs are deduplicated")
+
+	_, err = expandPlanPaths([]string{filepath.Join(dir, "*.yaml")})
+	require.ErrorContains(t, err, "matched no files")
+
+	paths, err = expandPlanPaths(nil)
+	require.NoError(t, err)
+	assert.Empty(t, paths)
+}
+
+func TestPlanStackLabel(t *testing.T) {
+	withURN := []engine.ResourceDescriptor{
+		{ID: "urn:pulumi:prod::webapp::aws:s3/bucket:Bucket::assets"},
+	}
+	assert.Equal(t, "prod", planStackLabel("plans/anything.json", withURN))
+	assert.Equal(t, "staging", planStackLabel("plans/staging.json", []engine.ResourceDescriptor{{ID: "bucket"}}))
+}
+
+func TestStackLabelingEngine_LabelsStreamedResults(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	eng := stackLabelingEngine{
+		projectedCostStoreEngine: &streamingEngine{
+			mockRecommendationFetcher: mockRecommendationFetcher{result: &engine.RecommendationsResult{}},
+			results:                   []engine.CostResult{{ResourceID: "web", Monthly: 10}, {ResourceID: "db", Monthly: 20}},
+			out:                       &out,
+		},
+		stacks: map[string]string{"web": "dev", "db": "prod"},
+	}
+
+	store, rendered, err := calculateProjectedCosts(context.Background(), cmd, eng,
+		[]engine.ResourceDescriptor{{ID: "web"}, {ID: "db"}}, "ndjson")
+	require.NoError(t, err)
+	defer store.Close()
+	assert.True(t, rendered)
+	assert.Contains(t, out.String(), `"stack":"dev"`)
+	assert.Contains(t, out.String(), `"stack":"prod"`)
+	assert.Equal(t, "prod", collected(t, store)[1].Stack)
+}
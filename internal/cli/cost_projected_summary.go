@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+	"github.com/rshade/finfocus/internal/github"
+)
+
+// outputFormatGHSummary renders projected costs as a GitHub Actions job summary.
+const outputFormatGHSummary = "gh-summary"
+
+// Job summary layout.
+const (
+	// summaryMaxResources caps the resources listed in the cost table.
+	summaryMaxResources = 25
+	// summaryGaugeWidth is the number of cells in a budget gauge.
+	summaryGaugeWidth = 10
+)
+
+// renderCostSummary writes a GitHub Actions job summary: a cost table of the
+// most expensive resources, a gauge per budget, and the potential savings from
+// recommendations grouped by action.
+func renderCostSummary(w io.Writer, data costCommentData) error {
+	var b strings.Builder
+	cur := data.Currency
+
+	if data.Stack != "" {
+		fmt.Fprintf(&b, "## FinFocus projected costs for `%s`\n\n", data.Stack)
+	} else {
+		b.WriteString("## FinFocus projected costs\n\n")
+	}
+
+	priced := make([]engine.CostResult, 0, len(data.Results))
+	total := 0.0
+	for _, r := range data.Results {
+		if r.Error == nil {
+			priced = append(priced, r)
+			total += r.Monthly
+		}
+	}
+	priced = pipeline.Sort(pipeline.Descending(func(a, b engine.CostResult) bool {
+		return a.Monthly < b.Monthly
+	}))(priced)
+
+	fmt.Fprintf(&b, "**Total:** %s/month across %d resource(s)", commentMoney(total, cur), len(priced))
+	if failed := len(data.Results) - len(priced); failed > 0 {
+		fmt.Fprintf(&b, " (%d could not be priced)", failed)
+	}
+	b.WriteString("\n")
+
+	if len(priced) > 0 {
+		b.WriteString("\n| Resource | Type | Monthly |\n| --- | --- | ---: |\n")
+		for i, r := range priced {
+			if i == summaryMaxResources {
+				fmt.Fprintf(&b, "\n_%d cheaper resource(s) not shown._\n", len(priced)-summaryMaxResources)
+				break
+			}
+			fmt.Fprintf(&b, "| %s | `%s` | %s |\n", commentEscape(commentResourceName(r.ResourceID)),
+				r.ResourceType, commentMoney(r.Monthly, r.Currency))
+		}
+	}
+
+	if scopes := budgetScopes(data.Budgets); len(scopes) > 0 {
+		b.WriteString("\n### Budgets\n\n")
+		b.WriteString("| Budget | Usage | Projected | Limit | Status |\n| --- | --- | ---: | ---: | --- |\n")
+		for _, s := range scopes {
+			fmt.Fprintf(&b, "| %s | `%s` %.1f%% | %s | %s | %s |\n", commentEscape(s.ScopeIdentifier()),
+				summaryGauge(s.Percentage), s.Percentage, commentMoney(s.CurrentSpend, s.Currency),
+				commentMoney(s.Budget.Amount, s.Currency), healthStatusLabel(s.Health))
+		}
+	}
+
+	writeSummarySavings(&b, recommendationsBySavings(data.Results))
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	return nil
+}
+
+// writeSummarySavings totals recommendation savings by action type.
+func writeSummarySavings(b *strings.Builder, recs []engine.Recommendation) {
+	if len(recs) == 0 {
+		return
+	}
+
+	type actionSavings struct {
+		action  string
+		count   int
+		savings float64
+	}
+	byAction := make(map[string]*actionSavings)
+	var actions []*actionSavings
+	total, cur := 0.0, ""
+	for _, rec := range recs {
+		label := formatActionTypeLabel(rec.Type)
+		a, ok := byAction[label]
+		if !ok {
+			a = &actionSavings{action: label}
+			byAction[label] = a
+			actions = append(actions, a)
+		}
+		a.count++
+		a.savings += rec.EstimatedSavings
+		total += rec.EstimatedSavings
+		if cur == "" {
+			cur = rec.Currency
+		}
+	}
+	actions = pipeline.Sort(pipeline.Descending(func(a, b *actionSavings) bool {
+		return a.savings < b.savings
+	}))(actions)
+
+	b.WriteString("\n### Savings\n\n")
+	fmt.Fprintf(b, "**Potential savings:** %s/month from %d recommendation(s)\n\n",
+		commentMoney(total, cur), len(recs))
+	b.WriteString("| Action | Recommendations | Savings |\n| --- | ---: | ---: |\n")
+	for _, a := range actions {
+		fmt.Fprintf(b, "| %s | %d | %s |\n", a.action, a.count, commentMoney(a.savings, cur))
+	}
+}
+
+// summaryGauge draws a budget gauge, full at 100% or more.
+func summaryGauge(percentage float64) string {
+	filled := int(min(max(percentage, 0), thresholdPercent100) / thresholdPercent100 * summaryGaugeWidth)
+	return strings.Repeat(progressFilledChar, filled) + strings.Repeat(progressEmptyChar, summaryGaugeWidth-filled)
+}
+
+// writeJobSummary appends summary to the file named by GITHUB_STEP_SUMMARY
+// when running inside GitHub Actions, and writes it to the command output
+// otherwise.
+func writeJobSummary(cmd *cobra.Command, summary string) error {
+	path := os.Getenv(github.EnvStepSummary)
+	if path == "" {
+		if _, err := io.WriteString(cmd.OutOrStdout(), summary); err != nil {
+			return fmt.Errorf("writing job summary: %w", err)
+		}
+		return nil
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", github.EnvStepSummary, err)
+	}
+	if _, err = io.WriteString(f, summary+"\n"); err != nil {
+		_ = f.Close()
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	if err = f.Close(); err != nil {
+		return fmt.Errorf("writing job summary: %w", err)
+	}
+	cmd.PrintErrf("Wrote job summary to $%s\n", github.EnvStepSummary)
+	return nil
+}
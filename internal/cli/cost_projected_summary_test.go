@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/github"
+)
+
+func TestRenderCostSummary(t *testing.T) {
+	results := append(commentResults(), engine.CostResult{
+		ResourceID: commentQueueURN, ResourceType: "aws:sqs/queue:Queue", Monthly: 2.5, Currency: "USD",
+		Recommendations: []engine.Recommendation{
+			{Type: "TERMINATE", Description: "Unused", EstimatedSavings: 2.5, Currency: "USD"},
+		},
+	})
+
+	var out bytes.Buffer
+	require.NoError(t, renderCostSummary(&out, costCommentData{
+		Stack:    "prod",
+		Currency: "USD",
+		Results:  results,
+		Budgets: &BudgetRenderResult{LegacyStatus: &engine.BudgetStatus{
+			Budget:       config.BudgetConfig{Amount: 200, Currency: "USD"},
+			CurrentSpend: 152.5,
+			Percentage:   76.25,
+			Currency:     "USD",
+		}},
+	}))
+	summary := out.String()
+
+	assert.Contains(t, summary, "## FinFocus projected costs for `prod`")
+	assert.Contains(t, summary, "**Total:** 152.50 USD/month across 2 resource(s) (1 could not be priced)")
+	assert.Less(t, bytes.Index(out.Bytes(), []byte("| web |")), bytes.Index(out.Bytes(), []byte("| queue |")),
+		"resources are ordered by cost")
+	assert.Contains(t, summary, "| global | `███████░░░` 76.2% | 152.50 USD | 200.00 USD |")
+	assert.Contains(t, summary, "**Potential savings:** 192.50 USD/month from 3 recommendation(s)")
+	assert.Contains(t, summary, "| Terminate | 2 | 152.50 USD |")
+}
+
+func TestSummaryGauge(t *testing.T) {
+	assert.Equal(t, "░░░░░░░░░░", summaryGauge(-5))
+	assert.Equal(t, "█████░░░░░", summaryGauge(50))
+	assert.Equal(t, "██████████", summaryGauge(140), "over budget fills the gauge")
+}
+
+func TestWriteJobSummary(t *testing.T) {
+	cmd := &cobra.Command{}
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+
+	t.Setenv(github.EnvStepSummary, "")
+	require.NoError(t, writeJobSummary(cmd, "# local\n"))
+	assert.Equal(t, "# local\n", stdout.String(), "outside Actions the summary goes to stdout")
+
+	path := filepath.Join(t.TempDir(), "step_summary.md")
+	require.NoError(t, os.WriteFile(path, []byte("# earlier step\n"), 0o600))
+	t.Setenv(github.EnvStepSummary, path)
+	stdout.Reset()
+	require.NoError(t, writeJobSummary(cmd, "# costs"))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "# earlier step\n# costs\n", string(data), "the summary is appended")
+	assert.Empty(t, stdout.String())
+	assert.Contains(t, stderr.String(), "Wrote job summary")
+}
@@ -121,8 +121,8 @@ func TestCostProjectedCmdFlags(t *testing.T) {
 	// Check required flags
 	pulumiJSONFlag := cmd.Flags().Lookup("pulumi-json")
 	assert.NotNil(t, pulumiJSONFlag)
-	assert.Equal(t, "string", pulumiJSONFlag.Value.Type())
-	assert.Empty(t, pulumiJSONFlag.DefValue)
+	assert.Equal(t, "stringArray", pulumiJSONFlag.Value.Type())
+	assert.Equal(t, "[]", pulumiJSONFlag.DefValue)
 
 	// Check optional flags
 	specDirFlag := cmd.Flags().Lookup("spec-dir")
@@ -270,3 +270,55 @@ func TestCostProjectedCmdErrorSummaryDisplay(t *testing.T) {
 	outputFlag := cmd.Flags().Lookup("output")
 	assert.NotNil(t, outputFlag, "Should have output flag for format selection")
 }
+
+func TestCostProjectedTransferFlags(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	cmd := cli.NewCostProjectedCmd()
+
+	transferFlag := cmd.Flags().Lookup("estimate-transfer")
+	require.NotNil(t, transferFlag)
+	assert.Equal(t, "false", transferFlag.DefValue)
+	gbFlag := cmd.Flags().Lookup("transfer-gb")
+	require.NotNil(t, gbFlag)
+	assert.Equal(t, "100", gbFlag.DefValue)
+
+	var buf bytes.Buffer
+	cmd.SetOut(&buf)
+	cmd.SetErr(&buf)
+	cmd.SetArgs([]string{"--estimate-transfer", "--transfer-gb", "-5"})
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "--transfer-gb must not be negative")
+}
+
+func TestCostProjectedPricingModelFlags(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+
+	modelFlag := cli.NewCostProjectedCmd().Flags().Lookup("pricing-model")
+	require.NotNil(t, modelFlag)
+	assert.Equal(t, "on-demand", modelFlag.DefValue)
+
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"unknown model", []string{"--pricing-model", "preemptible"}, `unsupported pricing model "preemptible"`},
+		{"compare with ndjson", []string{"--compare-pricing-models", "--output", "ndjson"},
+			"--compare-pricing-models supports --output table or json"},
+		{"compare with model", []string{"--compare-pricing-models", "--pricing-model", "spot"},
+			"none of the others can be"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := cli.NewCostProjectedCmd()
+			var buf bytes.Buffer
+			cmd.SetOut(&buf)
+			cmd.SetErr(&buf)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
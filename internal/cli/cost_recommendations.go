@@ -10,22 +10,23 @@ import (
 	"path/filepath"
 	"slices"
 	"strings"
-	"sync"
 	"syscall"
 	"text/tabwriter"
 	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
 
 	"github.com/rshade/finfocus/internal/cli/pagination"
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/engine/cache"
+	"github.com/rshade/finfocus/internal/engine/filterexpr"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/proto"
 	"github.com/rshade/finfocus/internal/tui"
+	listview "github.com/rshade/finfocus/internal/tui/list"
 )
 
 // Note: The engine.Recommendation struct has these fields:
@@ -40,12 +41,8 @@ const (
 	defaultCacheTTLSeconds = 3600
 	// defaultCacheMaxSizeMB is the default maximum cache size (100MB).
 	defaultCacheMaxSizeMB = 100
-	// progressDelayMS is the delay before showing progress indicator (500ms).
+	// progressDelayMS is the delay before showing the progress display (500ms).
 	progressDelayMS = 500
-	// progressTickerMS is the spinner update interval (100ms).
-	progressTickerMS = 100
-	// batchSize is the number of resources per batch for progress calculation.
-	progressBatchSize = 100
 	// statusActive is the default status label for active recommendations.
 	statusActive engine.RecommendationStatus = engine.RecommendationStatusActive
 )
@@ -63,6 +60,12 @@ type costRecommendationsParams struct {
 	offset           int
 	sort             string
 	includeDismissed bool
+	columns          []string
+	groupBy          string
+	snoozeWarnDays   int
+	minSavings       float64
+	noDedupe         bool
+	progress         string
 }
 
 // NewCostRecommendationsCmd creates the "recommendations" subcommand that fetches cost optimization
@@ -71,8 +74,12 @@ type costRecommendationsParams struct {
 // The command is configured with flags:
 //   - --pulumi-json (required): path to Pulumi preview JSON output
 //   - --adapter: restrict to a specific adapter plugin
-//   - --output: output format (table, json, ndjson; defaults from configuration)
-//   - --filter: filter expressions for recommendations (e.g., 'action=MIGRATE')
+//   - --output: output format (table, json, ndjson, csv, markdown, sarif; defaults from configuration)
+//   - --columns: column selection for csv and markdown output
+//   - --group-by: aggregate savings and counts by resource-type, action, provider, or tag:KEY
+//   - --min-savings: hide recommendations below a savings threshold (defaults from configuration)
+//   - --snooze-warning-days: warn about snoozes expiring within N days (0 disables)
+//   - --filter: filter expressions for recommendations (e.g., 'savings > 100', 'action=MIGRATE')
 //
 // The returned *cobra.Command is ready to be added to the CLI command tree.
 func NewCostRecommendationsCmd() *cobra.Command {
@@ -86,13 +93,27 @@ func NewCostRecommendationsCmd() *cobra.Command {
 By default, shows a summary with the top 5 recommendations sorted by savings.
 Use --verbose to see all recommendations with full details.
 
+When several plugins recommend the same action for the same resource, the
+duplicates are merged into one recommendation listing every source and the
+highest savings estimate. Use --no-dedupe to show each plugin's result.
+
 In interactive terminals, launches a TUI with:
   - Keyboard navigation (up/down arrows)
   - Filter by typing '/' and entering search text
   - Sort cycling by pressing 's'
-  - Detail view by pressing Enter
+  - Split-pane detail view by pressing Enter (impact, reasoning,
+    metadata, and the plan resource; 'r' retries a failed load)
+  - Multi-select with space (or 'a' for all), then 'b' for bulk
+    dismiss, snooze, or CSV export of the selection
   - Quit by pressing 'q' or Ctrl+C
 
+Filter expressions compare fields with ==, !=, >, >=, <, <=, =~ (glob match),
+and !~, and combine comparisons with &&, ||, !, and parentheses. String values
+are double-quoted and compared ignoring case. Fields:
+  savings, cost, projected (numbers: monthly savings and cost before/after)
+  action, resource, type, provider, source, status, currency, tag.<key>
+The "action=TYPE1,TYPE2" form remains supported.
+
 Valid action types for filtering:
   RIGHTSIZE, TERMINATE, PURCHASE_COMMITMENT, ADJUST_REQUESTS, MODIFY,
   DELETE_UNUSED, MIGRATE, CONSOLIDATE, SCHEDULE, REFACTOR, OTHER`,
@@ -108,14 +129,40 @@ Valid action types for filtering:
   # Output as newline-delimited JSON (first line is summary)
   finfocus cost recommendations --pulumi-json plan.json --output ndjson
 
+  # Export selected columns as CSV for spreadsheets
+  finfocus cost recommendations --pulumi-json plan.json --output csv --columns id,resource,action,savings
+
+  # Render a Markdown table for a PR comment
+  finfocus cost recommendations --pulumi-json plan.json --output markdown
+
+  # Write SARIF for upload to GitHub code scanning
+  finfocus cost recommendations --pulumi-json plan.json --output sarif > finfocus.sarif
+
+  # Aggregate savings by resource type or by a tag
+  finfocus cost recommendations --pulumi-json plan.json --group-by resource-type
+  finfocus cost recommendations --pulumi-json plan.json --group-by tag:team --output json
+
   # Filter recommendations by action type
   finfocus cost recommendations --pulumi-json plan.json --filter "action=MIGRATE"
 
+  # Hide recommendations saving less than $10/month
+  finfocus cost recommendations --pulumi-json plan.json --min-savings 10
+
   # Filter by multiple action types (comma-separated)
   finfocus cost recommendations --pulumi-json plan.json --filter "action=RIGHTSIZE,TERMINATE"
 
+  # Filter with an expression
+  finfocus cost recommendations --pulumi-json plan.json \
+    --filter 'savings > 100 && provider == "aws" && tag.env =~ "prod*"'
+
   # Use a specific adapter plugin
-  finfocus cost recommendations --pulumi-json plan.json --adapter kubecost`,
+  finfocus cost recommendations --pulumi-json plan.json --adapter kubecost
+
+  # Show overlapping recommendations from each plugin separately
+  finfocus cost recommendations --pulumi-json plan.json --no-dedupe
+
+  # Report progress as JSON events on stderr, e.g. in CI logs
+  finfocus cost recommendations --pulumi-json plan.json --progress json --output json`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			return executeCostRecommendations(cmd, params)
 		},
@@ -128,9 +175,9 @@ Valid action types for filtering:
 	// Use configuration default if no output format specified
 	defaultFormat := config.GetDefaultOutputFormat()
 	cmd.Flags().
-		StringVar(&params.output, "output", defaultFormat, "Output format: table, json, or ndjson")
+		StringVar(&params.output, "output", defaultFormat, "Output format: table, json, ndjson, csv, markdown, or sarif")
 	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
-		"Filter expressions (e.g., 'action=MIGRATE,RIGHTSIZE')")
+		"Filter expressions (e.g., 'savings > 100 && action == \"RIGHTSIZE\"' or 'action=MIGRATE,RIGHTSIZE')")
 	cmd.Flags().BoolVar(&params.verbose, "verbose", false,
 		"Show all recommendations with full details (default shows top 5 by savings)")
 	cmd.Flags().IntVar(&params.limit, "limit", 0,
@@ -145,6 +192,19 @@ Valid action types for filtering:
 		"Sort expression (e.g., 'savings:desc', 'name:asc')")
 	cmd.Flags().BoolVar(&params.includeDismissed, "include-dismissed", false,
 		"Show dismissed and snoozed recommendations alongside active ones")
+	cmd.Flags().StringSliceVar(&params.columns, "columns", nil,
+		"Columns for csv and markdown output (e.g., 'id,resource,action,savings')")
+	cmd.Flags().StringVar(&params.groupBy, "group-by", "",
+		"Aggregate savings and counts by: resource-type, action, provider, or tag:KEY")
+	cmd.Flags().Float64Var(&params.minSavings, "min-savings", 0,
+		"Hide recommendations with estimated monthly savings below this amount "+
+			"(default from recommendations.min_savings)")
+	cmd.Flags().IntVar(&params.snoozeWarnDays, "snooze-warning-days", defaultSnoozeWarningDays,
+		"Warn about snoozes expiring within this many days (0 = disabled)")
+	cmd.Flags().BoolVar(&params.noDedupe, "no-dedupe", false,
+		"Show duplicate recommendations from multiple plugins separately instead of merging them")
+	cmd.Flags().StringVar(&params.progress, "progress", progressModeAuto,
+		"Progress reporting on stderr: auto (display in terminals), json (NDJSON events), or none")
 
 	_ = cmd.MarkFlagRequired("pulumi-json")
 
@@ -154,6 +214,9 @@ Valid action types for filtering:
 		newRecommendationsSnoozeCmd(),
 		newRecommendationsUndismissCmd(),
 		newRecommendationsHistoryCmd(),
+		newRecommendationsApplyCmd(),
+		newRecommendationsSyncCmd(),
+		newRecommendationsExpiringCmd(),
 	)
 
 	return cmd
@@ -199,8 +262,27 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 	if len(params.filter) > 0 {
 		auditParams["filter"] = strings.Join(params.filter, ",")
 	}
+	if params.groupBy != "" {
+		auditParams["group_by"] = params.groupBy
+	}
 	audit := newAuditContext(ctx, "cost recommendations", auditParams)
 
+	groupSpec, err := validateGroupByParams(params)
+	if err != nil {
+		return err
+	}
+	if err = validateProgressMode(params.progress); err != nil {
+		return err
+	}
+
+	minSavings, err := resolveMinSavings(cmd, params.minSavings)
+	if err != nil {
+		return err
+	}
+
+	// Reactivate expired snoozes and warn about ones expiring soon
+	checkSnoozeExpiry(ctx, cmd.ErrOrStderr(), params.snoozeWarnDays)
+
 	// Load and map resources from Pulumi plan
 	resources, err := loadAndMapResources(ctx, params.planPath, audit)
 	if err != nil {
@@ -216,16 +298,14 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 
 	// Setup cache and create engine
 	cfg := config.New()
-	cacheStore := setupRecommendationsCache(ctx, cmd, cfg)
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
 
 	eng := engine.New(clients, nil).
-		WithRouter(createRouterForEngine(ctx, cfg, clients))
-	if cacheStore != nil && cacheStore.IsEnabled() {
-		eng = eng.WithCache(cacheStore)
-	}
+		WithRouter(createRouterForEngine(ctx, cfg, clients)).
+		WithRecommendationDedupe(!params.noDedupe)
 
-	// Fetch recommendations with progress indicator
-	result, err := fetchRecommendationsWithProgress(ctx, cmd, eng, resources)
+	// Fetch recommendations, reporting progress as --progress selects
+	result, err := fetchRecommendationsWithProgress(ctx, cmd, eng, pluginNames(clients), resources, params.progress)
 	if err != nil {
 		log.Error().Ctx(ctx).Err(err).Msg("failed to fetch recommendations")
 		audit.logFailure(ctx, err)
@@ -249,16 +329,23 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 	}
 
 	// Apply filters, sorting, and pagination
-	filteredRecommendations, err := applyActionTypeFilters(ctx, result.Recommendations, params.filter)
+	filteredRecommendations, err := applyRecommendationFilters(ctx, result.Recommendations, resources, params.filter)
 	if err != nil {
 		return err
 	}
 
+	filteredRecommendations = applyMinSavingsFilter(ctx, filteredRecommendations, minSavings)
+
 	filteredRecommendations, err = applySortExpression(ctx, filteredRecommendations, params.sort)
 	if err != nil {
 		return err
 	}
 
+	if groupSpec != nil {
+		return renderGroupedRecommendationsOutput(ctx, cmd, params, *groupSpec,
+			filteredRecommendations, resources, result, audit)
+	}
+
 	paginatedRecommendations, paginationMeta, err := paginateRecommendations(
 		ctx, filteredRecommendations, params,
 	)
@@ -276,7 +363,12 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 
 	// Render output
 	if renderErr := RenderRecommendationsOutput(
-		ctx, cmd, params.output, filteredResult, params.verbose, paginationMeta,
+		ctx, cmd, params.output, filteredResult, params.verbose, paginationMeta, params.columns,
+		InteractiveRecommendationsOptions{
+			Actions:        newRecommendationBulkActions(eng),
+			ResourceLookup: newPlanResourceLookup(resources),
+			ResourceTags:   resourceTagIndex(resources),
+		},
 	); renderErr != nil {
 		return renderErr
 	}
@@ -290,9 +382,9 @@ func executeCostRecommendations(cmd *cobra.Command, params costRecommendationsPa
 	return nil
 }
 
-// setupRecommendationsCache initializes the file-based cache for recommendations.
+// setupPluginCache initializes the file-based cache for plugin responses.
 // Returns nil if cache initialization fails (the caller should proceed without cache).
-func setupRecommendationsCache(
+func setupPluginCache(
 	ctx context.Context,
 	cmd *cobra.Command,
 	cfg *config.Config,
@@ -343,38 +435,6 @@ func resolveCacheTTL(ctx context.Context, cmd *cobra.Command, configTTL int) int
 	return defaultCacheTTLSeconds
 }
 
-// fetchRecommendationsWithProgress fetches recommendations while showing a progress
-// indicator for operations that exceed 500ms.
-func fetchRecommendationsWithProgress(
-	ctx context.Context,
-	cmd *cobra.Command,
-	eng *engine.Engine,
-	resources []engine.ResourceDescriptor,
-) (*engine.RecommendationsResult, error) {
-	progressCtx, cancelProgress := context.WithCancel(ctx)
-	defer cancelProgress()
-
-	var spinnerWg sync.WaitGroup
-	spinnerWg.Add(1)
-
-	go func() {
-		defer spinnerWg.Done()
-		showProgressIndicator(progressCtx, cmd, resources)
-	}()
-
-	result, err := eng.GetRecommendationsForResources(ctx, resources)
-
-	cancelProgress()
-	spinnerWg.Wait()
-
-	// Clear progress line if it was shown
-	if term.IsTerminal(int(os.Stderr.Fd())) {
-		fmt.Fprint(cmd.ErrOrStderr(), "\r\033[K")
-	}
-
-	return result, err
-}
-
 // annotateActiveStatus sets the status to "Active" for any recommendation without a status.
 func annotateActiveStatus(result *engine.RecommendationsResult) {
 	for i := range result.Recommendations {
@@ -384,26 +444,88 @@ func annotateActiveStatus(result *engine.RecommendationsResult) {
 	}
 }
 
-// applyActionTypeFilters applies all action type filter expressions to recommendations.
-func applyActionTypeFilters(
+// applyRecommendationFilters applies all filter expressions to recommendations.
+// "action=TYPE1,TYPE2" filters select action types; other filters are filter
+// expressions over engine.RecommendationFilterSchema fields, whose resource
+// fields are looked up from resources by ResourceID.
+func applyRecommendationFilters(
 	ctx context.Context,
 	recommendations []engine.Recommendation,
+	resources []engine.ResourceDescriptor,
 	filters []string,
 ) ([]engine.Recommendation, error) {
+	byID := make(map[string]*engine.ResourceDescriptor, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+
 	filtered := recommendations
 	for _, f := range filters {
 		if f == "" {
 			continue
 		}
-		var err error
-		filtered, err = applyActionTypeFilter(ctx, filtered, f)
+		if isKeyValueFilter(f) {
+			var err error
+			filtered, err = applyActionTypeFilter(ctx, filtered, f)
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+
+		expr, err := filterexpr.Parse(f, engine.RecommendationFilterSchema())
 		if err != nil {
 			return nil, err
 		}
+		before := len(filtered)
+		filtered = pipeline.Filter(func(rec engine.Recommendation) bool {
+			return expr.Match(engine.RecommendationRecord(rec, byID[rec.ResourceID]))
+		})(filtered)
+		logging.FromContext(ctx).Debug().Ctx(ctx).
+			Int("original_count", before).
+			Int("filtered_count", len(filtered)).
+			Str("filter", f).
+			Msg("applied filter expression")
 	}
 	return filtered, nil
 }
 
+// resolveMinSavings returns the --min-savings flag when set, otherwise the
+// recommendations.min_savings configuration default.
+func resolveMinSavings(cmd *cobra.Command, flagValue float64) (float64, error) {
+	if cmd.Flags().Changed("min-savings") {
+		if flagValue < 0 {
+			return 0, fmt.Errorf("--min-savings must be zero or greater, got %g", flagValue)
+		}
+		return flagValue, nil
+	}
+	return config.New().MinRecommendationSavings(), nil
+}
+
+// applyMinSavingsFilter drops recommendations whose estimated savings are below
+// minSavings. A zero threshold returns the input unchanged.
+func applyMinSavingsFilter(
+	ctx context.Context,
+	recommendations []engine.Recommendation,
+	minSavings float64,
+) []engine.Recommendation {
+	if minSavings <= 0 {
+		return recommendations
+	}
+
+	filtered := pipeline.Filter(func(rec engine.Recommendation) bool {
+		return rec.EstimatedSavings >= minSavings
+	})(recommendations)
+
+	logging.FromContext(ctx).Debug().Ctx(ctx).
+		Int("original_count", len(recommendations)).
+		Int("filtered_count", len(filtered)).
+		Float64("min_savings", minSavings).
+		Msg("applied minimum savings filter")
+
+	return filtered
+}
+
 // applySortExpression applies a sort expression to recommendations.
 // Returns the original slice unchanged if sortExpr is empty.
 func applySortExpression(
@@ -479,34 +601,13 @@ func paginateRecommendations(
 	return paginated, &meta, nil
 }
 
-// applyPaginationWindow slices recommendations based on pagination offset and limit,
+// applyPaginationWindow slices items based on pagination offset and limit,
 // handling out-of-bounds page capping for page-based pagination.
-func applyPaginationWindow(
+func applyPaginationWindow[T any](
 	pp pagination.PaginationParams,
-	recommendations []engine.Recommendation,
-) []engine.Recommendation {
-	offset, limit := pp.CalculateOffsetLimit()
-
-	// Handle out-of-bounds page edge case (T036):
-	// cap offset to the last available page for page-based pagination.
-	if pp.IsPageBased() && offset >= len(recommendations) && len(recommendations) > 0 {
-		pageSize := pp.PageSize
-		if pageSize <= 0 {
-			pageSize = len(recommendations)
-		}
-		offset = ((len(recommendations) - 1) / pageSize) * pageSize
-	}
-
-	if offset >= len(recommendations) {
-		return []engine.Recommendation{}
-	}
-
-	end := offset + limit
-	if limit == 0 || end > len(recommendations) {
-		end = len(recommendations)
-	}
-
-	return recommendations[offset:end]
+	items []T,
+) []T {
+	return pagination.Stage[T](pp)(items)
 }
 
 // applyActionTypeFilter filters recommendations by action type based on a filter expression.
@@ -566,14 +667,19 @@ func calculateTotalSavings(recommendations []engine.Recommendation) float64 {
 // RenderRecommendationsOutput routes the recommendations results to the appropriate
 // rendering function based on the output format and terminal mode.
 // In interactive terminals, it launches the TUI; otherwise, it renders table output.
+// columns selects the columns for CSV and Markdown output and is ignored by other formats.
+// SARIF output uses interactive.ResourceLookup, when set, to resolve source locations.
+// interactive configures the optional TUI features (bulk actions, resource details).
 // Returns an error if result is nil.
 func RenderRecommendationsOutput(
-	_ context.Context,
+	ctx context.Context,
 	cmd *cobra.Command,
 	outputFormat string,
 	result *engine.RecommendationsResult,
 	verbose bool,
 	paginationMeta *pagination.PaginationMeta,
+	columns []string,
+	interactive InteractiveRecommendationsOptions,
 ) error {
 	if result == nil {
 		return errors.New("render recommendations: result cannot be nil")
@@ -581,6 +687,31 @@ func RenderRecommendationsOutput(
 
 	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
 
+	// CSV/Markdown are export formats specific to recommendations
+	if fmtType == engine.OutputCSV || fmtType == engine.OutputMarkdown {
+		selected, err := resolveRecommendationColumns(columns, result.Recommendations)
+		if err != nil {
+			return err
+		}
+		if fmtType == engine.OutputCSV {
+			err = renderRecommendationsCSV(cmd.OutOrStdout(), result, selected)
+		} else {
+			err = renderRecommendationsMarkdown(cmd.OutOrStdout(), result, selected)
+		}
+		if isBrokenPipe(err) {
+			return nil
+		}
+		return err
+	}
+
+	if fmtType == engine.OutputSARIF {
+		err := renderRecommendationsSARIF(ctx, cmd.OutOrStdout(), result, interactive.ResourceLookup)
+		if isBrokenPipe(err) {
+			return nil
+		}
+		return err
+	}
+
 	// Validate format is supported
 	if !isValidOutputFormat(fmtType) {
 		return fmt.Errorf("unsupported output format: %s", fmtType)
@@ -589,7 +720,11 @@ func RenderRecommendationsOutput(
 	// JSON/NDJSON bypass TUI entirely
 	switch fmtType {
 	case engine.OutputJSON:
-		return renderRecommendationsJSON(cmd.OutOrStdout(), result, paginationMeta)
+		if err := renderRecommendationsJSON(cmd.OutOrStdout(), result, paginationMeta); err != nil {
+			return err
+		}
+		writePartialErrorReport(cmd, result.ErrorReport())
+		return nil
 	case engine.OutputNDJSON:
 		// T050: Disable pagination metadata in NDJSON streaming mode
 		// NDJSON is designed for line-by-line streaming without pagination
@@ -599,17 +734,20 @@ func RenderRecommendationsOutput(
 		if isBrokenPipe(err) {
 			return nil
 		}
+		if err == nil {
+			writePartialErrorReport(cmd, result.ErrorReport())
+		}
 		return err
 	case engine.OutputTable:
 		// Fall through to terminal mode detection below
 	}
 
 	// For table output, detect terminal mode
-	mode := tui.DetectOutputMode(false, false, false)
+	mode := tui.ResolveOutputMode()
 
 	switch mode {
 	case tui.OutputModeInteractive:
-		return runInteractiveRecommendations(result.Recommendations)
+		return runInteractiveRecommendations(ctx, result.Recommendations, interactive)
 
 	case tui.OutputModeStyled:
 		// Styled mode renders the summary with lipgloss styling
@@ -622,10 +760,59 @@ func RenderRecommendationsOutput(
 	}
 }
 
+// InteractiveRecommendationsOptions configures optional features of the
+// interactive recommendations TUI. Zero values disable the feature.
+type InteractiveRecommendationsOptions struct {
+	// Actions enables multi-select bulk dismiss, snooze, and export.
+	Actions tui.RecommendationActions
+	// ResourceLookup loads the resource descriptor shown in the detail pane.
+	// SARIF output also uses it to map results to source locations.
+	ResourceLookup tui.ResourceFetcher
+	// ResourceTags makes resource tags, keyed by resource ID, searchable.
+	ResourceTags map[string]map[string]string
+}
+
+// newPlanResourceLookup returns a resource lookup over the resources loaded
+// from the Pulumi plan. Unknown IDs resolve to a nil descriptor.
+func newPlanResourceLookup(resources []engine.ResourceDescriptor) tui.ResourceFetcher {
+	byID := make(map[string]*engine.ResourceDescriptor, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+	return func(ctx context.Context, id string) (*engine.ResourceDescriptor, error) {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		return byID[id], nil
+	}
+}
+
 // runInteractiveRecommendations launches the interactive TUI for recommendations.
 // Uses NewRecommendationsViewModel which starts with data already loaded.
-func runInteractiveRecommendations(recommendations []engine.Recommendation) error {
-	model := tui.NewRecommendationsViewModel(recommendations)
+// Optional features are enabled by the non-nil fields of opts.
+func runInteractiveRecommendations(
+	ctx context.Context,
+	recommendations []engine.Recommendation,
+	opts InteractiveRecommendationsOptions,
+) error {
+	cfg := config.NewUserConfig()
+	model := tui.NewRecommendationsViewModel(recommendations).WithSpillThreshold(spillThreshold()).WithLayout(
+		listview.Layout(cfg.ListLayout(tui.RecommendationsLayoutView)),
+		func(layout listview.Layout) error {
+			cfg.SetListLayout(tui.RecommendationsLayoutView, config.ListLayout(layout))
+			return cfg.Save()
+		},
+	).WithExporter(ctx, exportRecommendationsView)
+	if opts.Actions != nil {
+		model.WithActions(ctx, opts.Actions)
+	}
+	if opts.ResourceLookup != nil {
+		model.WithResourceLookup(ctx, opts.ResourceLookup)
+	}
+	if opts.ResourceTags != nil {
+		model.WithResourceTags(opts.ResourceTags)
+	}
+	defer model.Close()
 	p := tea.NewProgram(model)
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run interactive recommendations TUI: %w", err)
@@ -798,12 +985,7 @@ func writeRecommendationRow(tw *tabwriter.Writer, rec engine.Recommendation, has
 		savings = fmt.Sprintf("%.2f %s", rec.EstimatedSavings, rec.Currency)
 	}
 
-	// Truncate long descriptions
-	description := rec.Description
-	const maxDescLen = 50
-	if len(description) > maxDescLen {
-		description = description[:maxDescLen-3] + "..."
-	}
+	description := truncateDescription(rec.Description)
 
 	if hasStatus {
 		status := rec.Status
@@ -818,6 +1000,15 @@ func writeRecommendationRow(tw *tabwriter.Writer, rec engine.Recommendation, has
 	}
 }
 
+// truncateDescription shortens long descriptions for table output.
+func truncateDescription(description string) string {
+	const maxDescLen = 50
+	if len(description) > maxDescLen {
+		return description[:maxDescLen-3] + "..."
+	}
+	return description
+}
+
 // renderRecommendationsJSON renders recommendations in JSON format.
 func renderRecommendationsJSON(
 	w io.Writer,
@@ -837,14 +1028,7 @@ func renderRecommendationsJSON(
 	}
 
 	for _, rec := range result.Recommendations {
-		jsonRec := recommendationJSON{
-			ResourceID:       rec.ResourceID,
-			ActionType:       rec.Type,
-			Description:      rec.Description,
-			EstimatedSavings: rec.EstimatedSavings,
-			Currency:         rec.Currency,
-			Status:           string(rec.Status),
-		}
+		jsonRec := toRecommendationJSON(rec)
 		output.Recommendations = append(output.Recommendations, jsonRec)
 	}
 
@@ -883,14 +1067,7 @@ func renderRecommendationsNDJSON(
 
 	// Emit individual recommendations
 	for _, rec := range result.Recommendations {
-		jsonRec := recommendationJSON{
-			ResourceID:       rec.ResourceID,
-			ActionType:       rec.Type,
-			Description:      rec.Description,
-			EstimatedSavings: rec.EstimatedSavings,
-			Currency:         rec.Currency,
-			Status:           string(rec.Status),
-		}
+		jsonRec := toRecommendationJSON(rec)
 		if err := encoder.Encode(jsonRec); err != nil {
 			return fmt.Errorf("encoding NDJSON: %w", err)
 		}
@@ -1045,6 +1222,7 @@ type ndjsonSummary struct {
 }
 
 type recommendationJSON struct {
+	ID               string  `json:"id,omitempty"`
 	ResourceID       string  `json:"resource_id"`
 	ActionType       string  `json:"action_type"`
 	Description      string  `json:"description"`
@@ -1053,6 +1231,19 @@ type recommendationJSON struct {
 	Status           string  `json:"status,omitempty"`
 }
 
+// toRecommendationJSON converts an engine recommendation to its JSON output form.
+func toRecommendationJSON(rec engine.Recommendation) recommendationJSON {
+	return recommendationJSON{
+		ID:               rec.ID,
+		ResourceID:       rec.ResourceID,
+		ActionType:       rec.Type,
+		Description:      rec.Description,
+		EstimatedSavings: rec.EstimatedSavings,
+		Currency:         rec.Currency,
+		Status:           string(rec.Status),
+	}
+}
+
 // buildJSONSummary constructs the summary structure for JSON/NDJSON output.
 func buildJSONSummary(recommendations []engine.Recommendation) jsonSummary {
 	countByAction := make(map[string]int)
@@ -1077,58 +1268,3 @@ func buildJSONSummary(recommendations []engine.Recommendation) jsonSummary {
 		SavingsByAction:   savingsByAction,
 	}
 }
-
-// showProgressIndicator displays a spinner with batch progress for queries >500ms.
-// This function is designed to run in a goroutine and will only show progress if
-// the operation takes longer than 500ms.
-func showProgressIndicator(
-	ctx context.Context,
-	cmd *cobra.Command,
-	resources []engine.ResourceDescriptor,
-) {
-	// Wait before showing progress
-	timer := time.NewTimer(progressDelayMS * time.Millisecond)
-	defer timer.Stop()
-
-	select {
-	case <-ctx.Done():
-		// Operation completed before threshold
-		return
-	case <-timer.C:
-		// Operation exceeded threshold, show progress
-	}
-
-	// Only show progress if stderr is a terminal
-	if !term.IsTerminal(int(os.Stderr.Fd())) {
-		return
-	}
-
-	// Spinner frames
-	spinnerFrames := []string{"⠋", "⠙", "⠹", "⠸", "⠼", "⠴", "⠦", "⠧", "⠇", "⠏"}
-	frameIndex := 0
-
-	// Calculate total batches
-	totalBatches := len(resources) / progressBatchSize
-	if len(resources)%progressBatchSize > 0 {
-		totalBatches++
-	}
-
-	ticker := time.NewTicker(progressTickerMS * time.Millisecond)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case <-ticker.C:
-			// Update spinner frame
-			frame := spinnerFrames[frameIndex%len(spinnerFrames)]
-			frameIndex++
-
-			// Show spinner with resource count and estimated batches
-			msg := fmt.Sprintf("\r%s Fetching recommendations for %d resources (%d batches)...",
-				frame, len(resources), totalBatches)
-			fmt.Fprint(cmd.ErrOrStderr(), msg)
-		}
-	}
-}
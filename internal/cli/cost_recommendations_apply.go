@@ -0,0 +1,210 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// errApplyRequiresDryRun is returned when apply is invoked without --dry-run.
+var errApplyRequiresDryRun = errors.New(
+	"apply currently only generates plans for review; re-run with --dry-run")
+
+// unsafeFileNameChars matches characters replaced when deriving a plan file name
+// from a recommendation ID.
+var unsafeFileNameChars = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// applyParams holds the parameters for the apply subcommand.
+type applyParams struct {
+	dryRun     bool
+	format     string
+	outputFile string
+	planPath   string
+	adapter    string
+}
+
+// newRecommendationsApplyCmd creates the "apply" subcommand that turns a
+// recommendation into a remediation plan written to a file for review.
+func newRecommendationsApplyCmd() *cobra.Command {
+	var params applyParams
+
+	cmd := &cobra.Command{
+		Use:   "apply <recommendation-id>",
+		Short: "Generate a remediation plan for a recommendation",
+		Long: `Translate a recommendation into a remediation plan and write it to a file for review.
+
+Supported action types: RIGHTSIZE, TERMINATE, DELETE_UNUSED.
+
+Plan formats:
+  script   Shell script of provider CLI commands (aws, gcloud, az, or pulumi destroy)
+  pulumi   YAML patch describing the property change or resource removal in your Pulumi program
+
+When the plugin supplies remediation metadata (remediation.script,
+remediation.property, remediation.value), it is used instead of the generated defaults.
+
+finfocus never executes the plan; --dry-run is currently required.`,
+		Example: `  # Write a shell script for review
+  finfocus cost recommendations apply rec-123abc --dry-run --pulumi-json plan.json
+
+  # Write a Pulumi property patch to a specific file
+  finfocus cost recommendations apply rec-123abc --dry-run --format pulumi \
+    --output-file rightsize.yaml --pulumi-json plan.json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeApply(cmd, args[0], params)
+		},
+	}
+
+	cmd.Flags().BoolVar(&params.dryRun, "dry-run", false, "Write the remediation plan without executing it (required)")
+	cmd.Flags().StringVar(&params.format, "format", string(engine.RemediationFormatScript),
+		"Plan format: script or pulumi")
+	cmd.Flags().StringVar(&params.outputFile, "output-file", "",
+		"Path to write the plan (default: remediation-<id>.sh or .yaml)")
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output (required)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+
+	_ = cmd.MarkFlagRequired("pulumi-json")
+
+	return cmd
+}
+
+// executeApply handles the apply subcommand logic.
+func executeApply(cmd *cobra.Command, recommendationID string, params applyParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	if !params.dryRun {
+		return errApplyRequiresDryRun
+	}
+
+	format := engine.RemediationFormat(params.format)
+	if format != engine.RemediationFormatScript && format != engine.RemediationFormatPulumi {
+		return fmt.Errorf("%w: %q", engine.ErrInvalidRemediationFormat, params.format)
+	}
+
+	audit := newAuditContext(ctx, "cost recommendations apply", map[string]string{
+		"pulumi_json":       params.planPath,
+		"recommendation_id": recommendationID,
+		"format":            params.format,
+	})
+
+	resources, err := loadAndMapResources(ctx, params.planPath, audit)
+	if err != nil {
+		return err
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	eng := engine.New(clients, nil).
+		WithRouter(createRouterForEngine(ctx, config.New(), clients))
+
+	result, err := eng.GetRecommendationsForResources(ctx, resources)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("fetching recommendations: %w", err)
+	}
+
+	rec, found := findRecommendationByID(result, recommendationID)
+	if !found {
+		return fmt.Errorf("recommendation %q not found for resources in %s", recommendationID, params.planPath)
+	}
+
+	plan, err := engine.BuildRemediationPlan(rec, findResourceDescriptor(resources, rec.ResourceID), format)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("building remediation plan: %w", err)
+	}
+
+	outputFile := params.outputFile
+	if outputFile == "" {
+		outputFile = defaultRemediationFileName(recommendationID, format)
+	}
+	if writeErr := writeRemediationPlan(outputFile, plan); writeErr != nil {
+		return writeErr
+	}
+
+	renderApplyResult(cmd, plan, outputFile)
+
+	log.Info().
+		Ctx(ctx).
+		Str("component", "cli").
+		Str("operation", "apply").
+		Str("recommendation_id", recommendationID).
+		Str("action_type", plan.ActionType).
+		Str("output_file", outputFile).
+		Bool("from_plugin", plan.FromPlugin).
+		Msg("remediation plan written")
+
+	audit.logSuccess(ctx, 1, rec.EstimatedSavings)
+	return nil
+}
+
+// findRecommendationByID returns the recommendation with the given plugin ID.
+func findRecommendationByID(result *engine.RecommendationsResult, id string) (engine.Recommendation, bool) {
+	if result == nil {
+		return engine.Recommendation{}, false
+	}
+	for _, rec := range result.Recommendations {
+		if rec.ID == id {
+			return rec, true
+		}
+	}
+	return engine.Recommendation{}, false
+}
+
+// findResourceDescriptor returns the descriptor whose ID matches resourceID, or nil.
+func findResourceDescriptor(resources []engine.ResourceDescriptor, resourceID string) *engine.ResourceDescriptor {
+	for i := range resources {
+		if resources[i].ID == resourceID {
+			return &resources[i]
+		}
+	}
+	return nil
+}
+
+// defaultRemediationFileName derives a plan file name from the recommendation ID.
+func defaultRemediationFileName(recommendationID string, format engine.RemediationFormat) string {
+	ext := ".sh"
+	if format == engine.RemediationFormatPulumi {
+		ext = ".yaml"
+	}
+	return "remediation-" + unsafeFileNameChars.ReplaceAllString(recommendationID, "_") + ext
+}
+
+// writeRemediationPlan writes the plan content to path. Plans are written
+// without execute permission so they must be reviewed before running.
+func writeRemediationPlan(path string, plan *engine.RemediationPlan) error {
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0o750); err != nil {
+			return fmt.Errorf("creating plan directory: %w", err)
+		}
+	}
+	if err := os.WriteFile(path, []byte(plan.Content), 0o600); err != nil {
+		return fmt.Errorf("writing remediation plan: %w", err)
+	}
+	return nil
+}
+
+// renderApplyResult prints a summary of the written plan.
+func renderApplyResult(cmd *cobra.Command, plan *engine.RemediationPlan, path string) {
+	cmd.Printf("Remediation plan written to %s\n", path)
+	cmd.Printf("  Action: %s\n", formatActionTypeLabel(plan.ActionType))
+	cmd.Printf("  Resource: %s\n", plan.ResourceID)
+	cmd.Printf("  Format: %s\n", plan.Format)
+	if plan.FromPlugin {
+		cmd.Println("  Source: plugin-supplied remediation")
+	}
+	cmd.Println("Review the plan before running it; no changes were made.")
+}
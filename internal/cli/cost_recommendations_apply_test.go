@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestNewRecommendationsApplyCmd(t *testing.T) {
+	cmd := NewCostRecommendationsCmd()
+	applySub := findSubcommandLocal(cmd, "apply")
+	require.NotNil(t, applySub, "apply subcommand should exist")
+
+	for _, name := range []string{"dry-run", "format", "output-file", "pulumi-json", "adapter"} {
+		assert.NotNil(t, applySub.Flags().Lookup(name), "%s flag should exist", name)
+	}
+	assert.Equal(t, "script", applySub.Flags().Lookup("format").DefValue)
+}
+
+func TestApplyCmd_Validation(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  applyParams
+		wantErr string
+	}{
+		{
+			name:    "requires dry-run",
+			params:  applyParams{format: "script", planPath: "plan.json"},
+			wantErr: "--dry-run",
+		},
+		{
+			name:    "invalid format",
+			params:  applyParams{dryRun: true, format: "terraform", planPath: "plan.json"},
+			wantErr: "invalid remediation format",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := &cobra.Command{}
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetContext(context.Background())
+			err := executeApply(cmd, "rec-1", tt.params)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestFindRecommendationByID(t *testing.T) {
+	result := &engine.RecommendationsResult{
+		Recommendations: []engine.Recommendation{
+			{ID: "rec-1", ResourceID: "a"},
+			{ID: "rec-2", ResourceID: "b"},
+		},
+	}
+
+	rec, found := findRecommendationByID(result, "rec-2")
+	require.True(t, found)
+	assert.Equal(t, "b", rec.ResourceID)
+
+	_, found = findRecommendationByID(result, "missing")
+	assert.False(t, found)
+
+	_, found = findRecommendationByID(nil, "rec-1")
+	assert.False(t, found)
+}
+
+func TestDefaultRemediationFileName(t *testing.T) {
+	assert.Equal(t, "remediation-rec-1.sh", defaultRemediationFileName("rec-1", engine.RemediationFormatScript))
+	assert.Equal(t, "remediation-a_b_c.yaml",
+		defaultRemediationFileName("a/b:c", engine.RemediationFormatPulumi))
+}
+
+func TestWriteRemediationPlan(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "plans", "remediation.sh")
+	plan := &engine.RemediationPlan{
+		ResourceID: "i-0abc",
+		ActionType: "TERMINATE",
+		Format:     engine.RemediationFormatScript,
+		Content:    "#!/usr/bin/env bash\n",
+	}
+
+	require.NoError(t, writeRemediationPlan(path, plan))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, plan.Content, string(data))
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm(), "plan must not be executable")
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	renderApplyResult(cmd, plan, path)
+	assert.Contains(t, out.String(), "Remediation plan written to "+path)
+	assert.Contains(t, out.String(), "Action: Terminate")
+}
@@ -0,0 +1,128 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// errRecommendationWithoutID is returned when a selected recommendation has no ID
+// and therefore cannot be dismissed or snoozed.
+var errRecommendationWithoutID = errors.New("recommendation has no ID")
+
+// recommendationBulkActions implements tui.RecommendationActions using the
+// engine's DismissRecommendation (plugin RPC plus local dismissal store).
+type recommendationBulkActions struct {
+	eng *engine.Engine
+	// exportDir is the directory selection exports are written to.
+	exportDir string
+	// now returns the current time; overridden in tests.
+	now func() time.Time
+}
+
+// newRecommendationBulkActions creates the TUI bulk action handler for eng.
+func newRecommendationBulkActions(eng *engine.Engine) *recommendationBulkActions {
+	return &recommendationBulkActions{eng: eng, exportDir: ".", now: time.Now}
+}
+
+// Dismiss permanently dismisses each recommendation with reason.
+func (a *recommendationBulkActions) Dismiss(
+	ctx context.Context,
+	recs []engine.Recommendation,
+	reason string,
+) (int, error) {
+	return a.dismissAll(ctx, recs, reason, nil)
+}
+
+// Snooze dismisses each recommendation until the given time.
+func (a *recommendationBulkActions) Snooze(
+	ctx context.Context,
+	recs []engine.Recommendation,
+	until time.Time,
+) (int, error) {
+	return a.dismissAll(ctx, recs, "deferred", &until)
+}
+
+// dismissAll dismisses each recommendation, continuing past failures and
+// returning the number that succeeded with the joined errors.
+func (a *recommendationBulkActions) dismissAll(
+	ctx context.Context,
+	recs []engine.Recommendation,
+	reason string,
+	expiresAt *time.Time,
+) (int, error) {
+	log := logging.FromContext(ctx)
+
+	store, err := loadDismissalStore()
+	if err != nil {
+		return 0, err
+	}
+
+	var errs []error
+	count := 0
+	for _, rec := range recs {
+		if rec.ID == "" {
+			errs = append(errs, fmt.Errorf("%s: %w", rec.ResourceID, errRecommendationWithoutID))
+			continue
+		}
+		req := engine.DismissRequest{
+			RecommendationID: rec.ID,
+			Reason:           reason,
+			ExpiresAt:        expiresAt,
+			Recommendation:   &rec,
+		}
+		if _, dismissErr := a.eng.DismissRecommendation(ctx, store, req); dismissErr != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", rec.ID, dismissErr))
+			continue
+		}
+		count++
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Str("component", "cli").
+		Str("operation", "bulk_dismiss").
+		Str("reason", reason).
+		Bool("snooze", expiresAt != nil).
+		Int("selected_count", len(recs)).
+		Int("dismissed_count", count).
+		Msg("bulk dismissal complete")
+
+	return count, errors.Join(errs...)
+}
+
+// Export writes the recommendations as CSV to a timestamped file in exportDir.
+func (a *recommendationBulkActions) Export(_ context.Context, recs []engine.Recommendation) (string, error) {
+	columns, err := resolveRecommendationColumns(
+		[]string{"id", "resource", "action", "description", "savings", "currency"}, recs)
+	if err != nil {
+		return "", err
+	}
+
+	path := filepath.Join(a.exportDir,
+		fmt.Sprintf("recommendations-selection-%s.csv", a.now().Format("20060102-150405")))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return "", fmt.Errorf("creating export file: %w", err)
+	}
+
+	result := &engine.RecommendationsResult{Recommendations: recs}
+	if renderErr := renderRecommendationsCSV(f, result, columns); renderErr != nil {
+		_ = f.Close()
+		return "", renderErr
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		return "", fmt.Errorf("closing export file: %w", closeErr)
+	}
+	return path, nil
+}
+
+// Compile-time check that recommendationBulkActions implements tui.RecommendationActions.
+var _ tui.RecommendationActions = (*recommendationBulkActions)(nil)
@@ -0,0 +1,81 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestRecommendationBulkActions_DismissAndSnooze(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	actions := newRecommendationBulkActions(engine.New(nil, nil))
+	recs := []engine.Recommendation{
+		{ID: "rec-1", ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 20},
+		{ResourceID: "no-id", Type: "TERMINATE"},
+	}
+
+	count, err := actions.Dismiss(context.Background(), recs, "not-applicable")
+	assert.Equal(t, 1, count)
+	require.ErrorIs(t, err, errRecommendationWithoutID)
+
+	until := time.Now().Add(30 * 24 * time.Hour)
+	count, err = actions.Snooze(context.Background(), recs[:1], until)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+
+	store, err := config.NewDismissalStore(filepath.Join(home, ".finfocus", "dismissed.json"))
+	require.NoError(t, err)
+	require.NoError(t, store.Load())
+	record, ok := store.Get("rec-1")
+	require.True(t, ok)
+	assert.Equal(t, config.StatusSnoozed, record.Status)
+	require.NotNil(t, record.LastKnown)
+	assert.Equal(t, "web", record.LastKnown.ResourceID)
+}
+
+func TestRecommendationBulkActions_Export(t *testing.T) {
+	actions := newRecommendationBulkActions(engine.New(nil, nil))
+	actions.exportDir = t.TempDir()
+	actions.now = func() time.Time { return time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC) }
+
+	path, err := actions.Export(context.Background(), []engine.Recommendation{
+		{ID: "rec-1", ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 20, Currency: "USD"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(actions.exportDir, "recommendations-selection-20260301-120000.csv"), path)
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "id,resource,action,description,savings,currency")
+	assert.Contains(t, string(data), "rec-1,web")
+}
+
+func TestNewPlanResourceLookup(t *testing.T) {
+	lookup := newPlanResourceLookup([]engine.ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Provider: "aws"},
+	})
+
+	res, err := lookup(context.Background(), "web")
+	require.NoError(t, err)
+	require.NotNil(t, res)
+	assert.Equal(t, "aws:ec2/instance:Instance", res.Type)
+
+	res, err = lookup(context.Background(), "missing")
+	require.NoError(t, err)
+	assert.Nil(t, res)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = lookup(ctx, "web")
+	require.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,240 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// defaultSnoozeWarningDays is how far ahead snooze expiry is reported by default.
+const defaultSnoozeWarningDays = 7
+
+// expiringSnoozeJSON is the JSON representation of an expiring snooze.
+type expiringSnoozeJSON struct {
+	RecommendationID string    `json:"recommendation_id"`
+	Reason           string    `json:"reason"`
+	CustomReason     string    `json:"custom_reason,omitempty"`
+	ExpiresAt        time.Time `json:"expires_at"`
+	ResourceID       string    `json:"resource_id,omitempty"`
+	Description      string    `json:"description,omitempty"`
+}
+
+// newRecommendationsExpiringCmd creates the "expiring" subcommand for listing
+// snoozes that will expire soon.
+func newRecommendationsExpiringCmd() *cobra.Command {
+	var within int
+	var output string
+
+	cmd := &cobra.Command{
+		Use:   "expiring",
+		Short: "List snoozed recommendations expiring soon",
+		Long: `List snoozed recommendations whose snooze expires within the given number
+of days, soonest first. Snoozes that have already expired are reactivated in
+the dismissal store so they reappear in the default recommendation listing.
+
+This operates on local state only and does not require plugin connections.`,
+		Example: `  # List snoozes expiring within the next 7 days
+  finfocus cost recommendations expiring
+
+  # Look ahead 30 days and output JSON
+  finfocus cost recommendations expiring --within 30 --output json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeExpiring(cmd, within, output)
+		},
+	}
+
+	cmd.Flags().IntVar(&within, "within", defaultSnoozeWarningDays, "Number of days ahead to look for expiring snoozes")
+	cmd.Flags().StringVar(&output, "output", "table", "Output format: table, json, ndjson")
+
+	return cmd
+}
+
+// executeExpiring handles the expiring subcommand logic.
+func executeExpiring(cmd *cobra.Command, within int, output string) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	if within < 0 {
+		return errors.New("--within must be zero or greater")
+	}
+
+	store, err := loadDismissalStore()
+	if err != nil {
+		return fmt.Errorf("load dismissal store: %w", err)
+	}
+
+	reactivated, err := reactivateExpiredSnoozes(store)
+	if err != nil {
+		return err
+	}
+	if reactivated > 0 {
+		cmd.PrintErrf("Reactivated %d expired snooze(s).\n", reactivated)
+	}
+
+	expiring := store.GetExpiringSnoozes(time.Duration(within) * hoursPerDay * time.Hour)
+
+	log.Debug().
+		Ctx(ctx).
+		Str("component", "cli").
+		Str("operation", "snooze_expiring").
+		Int("within_days", within).
+		Int("expiring_count", len(expiring)).
+		Int("reactivated_count", reactivated).
+		Msg("expiring snoozes retrieved")
+
+	switch output {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(toExpiringSnoozesJSON(expiring))
+	case outputFormatNDJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		for _, snooze := range toExpiringSnoozesJSON(expiring) {
+			if encErr := encoder.Encode(snooze); encErr != nil {
+				if isBrokenPipe(encErr) {
+					return nil
+				}
+				return fmt.Errorf("encoding NDJSON: %w", encErr)
+			}
+		}
+		return nil
+	case outputFormatTable:
+		return renderExpiringTable(cmd.OutOrStdout(), expiring, within)
+	default:
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+}
+
+// renderExpiringTable renders expiring snoozes as a table.
+func renderExpiringTable(w io.Writer, expiring []*config.DismissalRecord, within int) error {
+	if len(expiring) == 0 {
+		fmt.Fprintf(w, "No snoozes expiring within %d day(s).\n", within)
+		return nil
+	}
+
+	fmt.Fprintf(w, "%s expiring within %d day(s):\n\n", pluralizeSnoozes(len(expiring)), within)
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "RECOMMENDATION\tEXPIRES\tIN\tREASON\tRESOURCE")
+	fmt.Fprintln(tw, "--------------\t-------\t--\t------\t--------")
+
+	now := time.Now()
+	for _, record := range expiring {
+		resource := ""
+		if record.LastKnown != nil {
+			resource = record.LastKnown.ResourceID
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\n",
+			record.RecommendationID,
+			record.ExpiresAt.Format("2006-01-02 15:04"),
+			formatTimeUntil(record.ExpiresAt.Sub(now)),
+			record.Reason,
+			resource,
+		)
+	}
+
+	return tw.Flush()
+}
+
+// toExpiringSnoozesJSON converts records to their JSON form.
+func toExpiringSnoozesJSON(expiring []*config.DismissalRecord) []expiringSnoozeJSON {
+	out := make([]expiringSnoozeJSON, 0, len(expiring))
+	for _, record := range expiring {
+		item := expiringSnoozeJSON{
+			RecommendationID: record.RecommendationID,
+			Reason:           record.Reason,
+			CustomReason:     record.CustomReason,
+			ExpiresAt:        *record.ExpiresAt,
+		}
+		if record.LastKnown != nil {
+			item.ResourceID = record.LastKnown.ResourceID
+			item.Description = record.LastKnown.Description
+		}
+		out = append(out, item)
+	}
+	return out
+}
+
+// checkSnoozeExpiry is the best-effort snooze check run by the recommendations
+// command: it reactivates expired snoozes and prints a banner when snoozes
+// expire within warningDays. Failures are logged and never fail the command.
+func checkSnoozeExpiry(ctx context.Context, w io.Writer, warningDays int) {
+	log := logging.FromContext(ctx)
+
+	store, err := loadDismissalStore()
+	if err != nil {
+		log.Debug().Ctx(ctx).Err(err).Msg("skipping snooze expiry check")
+		return
+	}
+
+	if reportErr := reportSnoozeExpiry(w, store, warningDays); reportErr != nil {
+		log.Warn().Ctx(ctx).Err(reportErr).Msg("snooze expiry check failed")
+	}
+}
+
+// reportSnoozeExpiry reactivates expired snoozes in store and writes the
+// reactivation notice and expiring-soon banner to w.
+func reportSnoozeExpiry(w io.Writer, store *config.DismissalStore, warningDays int) error {
+	reactivated, err := reactivateExpiredSnoozes(store)
+	if err != nil {
+		return err
+	}
+	if reactivated > 0 {
+		fmt.Fprintf(w, "Reactivated %d expired snooze(s); the recommendations are active again.\n", reactivated)
+	}
+
+	if warningDays <= 0 {
+		return nil
+	}
+	expiring := store.GetExpiringSnoozes(time.Duration(warningDays) * hoursPerDay * time.Hour)
+	if len(expiring) > 0 {
+		fmt.Fprintf(w, "%s expiring soon (within %d day(s)). "+
+			"Run 'finfocus cost recommendations expiring' for details.\n",
+			pluralizeSnoozes(len(expiring)), warningDays)
+	}
+	return nil
+}
+
+// reactivateExpiredSnoozes marks expired snoozes active and persists the store
+// when anything changed.
+func reactivateExpiredSnoozes(store *config.DismissalStore) (int, error) {
+	reactivated, err := store.CleanExpiredSnoozes()
+	if err != nil {
+		return 0, fmt.Errorf("reactivating expired snoozes: %w", err)
+	}
+	if reactivated > 0 {
+		if saveErr := store.Save(); saveErr != nil {
+			return 0, fmt.Errorf("saving reactivated snoozes: %w", saveErr)
+		}
+	}
+	return reactivated, nil
+}
+
+// pluralizeSnoozes formats a snooze count, e.g. "1 snooze" or "3 snoozes".
+func pluralizeSnoozes(n int) string {
+	if n == 1 {
+		return "1 snooze"
+	}
+	return fmt.Sprintf("%d snoozes", n)
+}
+
+// formatTimeUntil renders a remaining duration in days or hours.
+func formatTimeUntil(d time.Duration) string {
+	if d >= hoursPerDay*time.Hour {
+		return fmt.Sprintf("%dd", int(d.Hours())/hoursPerDay)
+	}
+	if d >= time.Hour {
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return "<1h"
+}
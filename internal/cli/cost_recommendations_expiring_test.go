@@ -0,0 +1,109 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// seedSnoozes writes snoozed records with the given expiry offsets into a store
+// under a temporary HOME and returns the store path.
+func seedSnoozes(t *testing.T, offsets map[string]time.Duration) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	storePath := filepath.Join(home, ".finfocus", "dismissed.json")
+	store, err := config.NewDismissalStore(storePath)
+	require.NoError(t, err)
+
+	now := time.Now()
+	for id, offset := range offsets {
+		expiresAt := now.Add(offset)
+		require.NoError(t, store.Set(&config.DismissalRecord{
+			RecommendationID: id,
+			Status:           config.StatusSnoozed,
+			Reason:           "DEFERRED",
+			DismissedAt:      now.Add(-time.Hour),
+			ExpiresAt:        &expiresAt,
+			LastKnown:        &config.LastKnownRecommendation{ResourceID: "res-" + id},
+		}))
+	}
+	require.NoError(t, store.Save())
+	return storePath
+}
+
+func TestReportSnoozeExpiry(t *testing.T) {
+	storePath := seedSnoozes(t, map[string]time.Duration{
+		"rec-expired": -time.Hour,
+		"rec-soon":    48 * time.Hour,
+		"rec-later":   60 * 24 * time.Hour,
+	})
+
+	store, err := config.NewDismissalStore(storePath)
+	require.NoError(t, err)
+	require.NoError(t, store.Load())
+
+	var buf bytes.Buffer
+	require.NoError(t, reportSnoozeExpiry(&buf, store, defaultSnoozeWarningDays))
+	assert.Contains(t, buf.String(), "Reactivated 1 expired snooze(s)")
+	assert.Contains(t, buf.String(), "1 snooze expiring soon (within 7 day(s))")
+
+	reloaded, err := config.NewDismissalStore(storePath)
+	require.NoError(t, err)
+	require.NoError(t, reloaded.Load())
+	record, ok := reloaded.Get("rec-expired")
+	require.True(t, ok)
+	assert.Equal(t, config.StatusActive, record.Status, "expired snooze persisted as active")
+
+	buf.Reset()
+	require.NoError(t, reportSnoozeExpiry(&buf, reloaded, 0))
+	assert.Empty(t, buf.String(), "warning disabled and nothing left to reactivate")
+}
+
+func TestExpiringCmd_JSON(t *testing.T) {
+	seedSnoozes(t, map[string]time.Duration{
+		"rec-b":       72 * time.Hour,
+		"rec-a":       24 * time.Hour,
+		"rec-distant": 90 * 24 * time.Hour,
+	})
+
+	cmd := NewCostRecommendationsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"expiring", "--within", "7", "--output", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var snoozes []expiringSnoozeJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &snoozes))
+	require.Len(t, snoozes, 2)
+	assert.Equal(t, "rec-a", snoozes[0].RecommendationID)
+	assert.Equal(t, "res-rec-a", snoozes[0].ResourceID)
+	assert.Equal(t, "rec-b", snoozes[1].RecommendationID)
+}
+
+func TestExpiringCmd_TableEmpty(t *testing.T) {
+	seedSnoozes(t, nil)
+
+	cmd := NewCostRecommendationsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"expiring"})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "No snoozes expiring within 7 day(s).")
+}
+
+func TestFormatTimeUntil(t *testing.T) {
+	assert.Equal(t, "3d", formatTimeUntil(3*24*time.Hour+time.Hour))
+	assert.Equal(t, "5h", formatTimeUntil(5*time.Hour+time.Minute))
+	assert.Equal(t, "<1h", formatTimeUntil(10*time.Minute))
+}
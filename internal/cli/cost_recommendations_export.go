@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// recommendationColumn describes a selectable column for CSV and Markdown export.
+type recommendationColumn struct {
+	name   string
+	header string
+	value  func(rec engine.Recommendation) string
+}
+
+// recommendationColumns lists the columns available to --columns, in display order.
+//
+//nolint:gochecknoglobals // Static column registry for export renderers.
+var recommendationColumns = []recommendationColumn{
+	{name: "id", header: "ID", value: func(r engine.Recommendation) string { return r.ID }},
+	{name: "resource", header: "Resource", value: func(r engine.Recommendation) string { return r.ResourceID }},
+	{name: "action", header: "Action Type", value: func(r engine.Recommendation) string {
+		return formatActionTypeLabel(r.Type)
+	}},
+	{name: "description", header: "Description", value: func(r engine.Recommendation) string { return r.Description }},
+	{name: "savings", header: "Savings", value: func(r engine.Recommendation) string {
+		return strconv.FormatFloat(r.EstimatedSavings, 'f', 2, 64)
+	}},
+	{name: "currency", header: "Currency", value: func(r engine.Recommendation) string { return r.Currency }},
+	{name: "status", header: "Status", value: func(r engine.Recommendation) string {
+		if r.Status == "" {
+			return string(statusActive)
+		}
+		return string(r.Status)
+	}},
+	{name: "source", header: "Source", value: func(r engine.Recommendation) string {
+		return strings.Join(r.AllSources(), "; ")
+	}},
+	{name: "reasoning", header: "Reasoning", value: func(r engine.Recommendation) string {
+		return strings.Join(r.Reasoning, "; ")
+	}},
+}
+
+// defaultRecommendationColumns are exported when --columns is not set.
+// The status column is appended when any recommendation is dismissed or snoozed.
+//
+//nolint:gochecknoglobals // Static default column selection.
+var defaultRecommendationColumns = []string{"resource", "action", "description", "savings", "currency"}
+
+// validRecommendationColumns returns the names accepted by --columns.
+func validRecommendationColumns() []string {
+	names := make([]string, 0, len(recommendationColumns))
+	for _, col := range recommendationColumns {
+		names = append(names, col.name)
+	}
+	return names
+}
+
+// resolveRecommendationColumns maps requested column names to column definitions.
+// Names are case-insensitive and may be comma-separated within a single entry.
+// An empty request selects the default columns.
+func resolveRecommendationColumns(
+	requested []string,
+	recs []engine.Recommendation,
+) ([]recommendationColumn, error) {
+	var names []string
+	for _, entry := range requested {
+		for _, name := range strings.Split(entry, ",") {
+			if name = strings.ToLower(strings.TrimSpace(name)); name != "" {
+				names = append(names, name)
+			}
+		}
+	}
+
+	if len(names) == 0 {
+		names = append(names, defaultRecommendationColumns...)
+		if hasStatusAnnotations(recs) {
+			names = append(names, "status")
+		}
+	}
+
+	columns := make([]recommendationColumn, 0, len(names))
+	for _, name := range names {
+		col, ok := lookupRecommendationColumn(name)
+		if !ok {
+			return nil, fmt.Errorf("unknown column %q (valid: %s)",
+				name, strings.Join(validRecommendationColumns(), ", "))
+		}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+// lookupRecommendationColumn finds a column by name.
+func lookupRecommendationColumn(name string) (recommendationColumn, bool) {
+	for _, col := range recommendationColumns {
+		if col.name == name {
+			return col, true
+		}
+	}
+	return recommendationColumn{}, false
+}
+
+// renderRecommendationsCSV renders recommendations as RFC 4180 CSV with a header row.
+func renderRecommendationsCSV(
+	w io.Writer,
+	result *engine.RecommendationsResult,
+	columns []recommendationColumn,
+) error {
+	cw := csv.NewWriter(w)
+
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.name
+	}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	for _, rec := range result.Recommendations {
+		row := make([]string, len(columns))
+		for i, col := range columns {
+			row[i] = col.value(rec)
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("writing CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flushing CSV: %w", err)
+	}
+	return nil
+}
+
+// renderRecommendationsMarkdown renders recommendations as a GitHub-flavored
+// Markdown table followed by a total savings line, suitable for PR comments.
+func renderRecommendationsMarkdown(
+	w io.Writer,
+	result *engine.RecommendationsResult,
+	columns []recommendationColumn,
+) error {
+	var b strings.Builder
+
+	b.WriteString("|")
+	for _, col := range columns {
+		b.WriteString(" " + col.header + " |")
+	}
+	b.WriteString("\n|")
+	for _, col := range columns {
+		if col.name == "savings" {
+			b.WriteString(" ---: |")
+		} else {
+			b.WriteString(" --- |")
+		}
+	}
+	b.WriteString("\n")
+
+	for _, rec := range result.Recommendations {
+		b.WriteString("|")
+		for _, col := range columns {
+			b.WriteString(" " + escapeMarkdownCell(col.value(rec)) + " |")
+		}
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "\n**%d recommendation(s), total estimated savings: %.2f %s/month**\n",
+		len(result.Recommendations), result.TotalSavings, result.Currency)
+
+	if _, err := io.WriteString(w, b.String()); err != nil {
+		return fmt.Errorf("writing markdown: %w", err)
+	}
+	return nil
+}
+
+// escapeMarkdownCell escapes pipes and flattens newlines so a value fits in one table cell.
+func escapeMarkdownCell(s string) string {
+	s = strings.ReplaceAll(s, "|", `\|`)
+	s = strings.ReplaceAll(s, "\r\n", " ")
+	return strings.ReplaceAll(s, "\n", " ")
+}
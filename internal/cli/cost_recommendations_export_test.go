@@ -0,0 +1,155 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func exportTestResult() *engine.RecommendationsResult {
+	return &engine.RecommendationsResult{
+		Recommendations: []engine.Recommendation{
+			{
+				ID: "rec-1", ResourceID: "i-0abc", Type: "RIGHTSIZE",
+				Description: "Downsize, saves 30%", EstimatedSavings: 70, Currency: "USD",
+			},
+			{
+				ID: "rec-2", ResourceID: "vol-1", Type: "DELETE_UNUSED",
+				Description: "Unattached | idle\nvolume", EstimatedSavings: 5.5, Currency: "USD",
+			},
+		},
+		TotalSavings: 75.5,
+		Currency:     "USD",
+	}
+}
+
+func TestResolveRecommendationColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		requested []string
+		recs      []engine.Recommendation
+		want      []string
+		wantErr   string
+	}{
+		{
+			name: "defaults",
+			want: []string{"resource", "action", "description", "savings", "currency"},
+		},
+		{
+			name: "defaults add status when dismissed present",
+			recs: []engine.Recommendation{{Status: engine.RecommendationStatusDismissed}},
+			want: []string{"resource", "action", "description", "savings", "currency", "status"},
+		},
+		{
+			name:      "comma separated and case-insensitive",
+			requested: []string{"ID, Resource", "savings"},
+			want:      []string{"id", "resource", "savings"},
+		},
+		{
+			name:      "unknown column",
+			requested: []string{"resource,cost"},
+			wantErr:   `unknown column "cost"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cols, err := resolveRecommendationColumns(tt.requested, tt.recs)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			names := make([]string, len(cols))
+			for i, c := range cols {
+				names[i] = c.name
+			}
+			assert.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestRenderRecommendationsCSV(t *testing.T) {
+	cols, err := resolveRecommendationColumns([]string{"id,action,description,savings"}, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderRecommendationsCSV(&buf, exportTestResult(), cols))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"id", "action", "description", "savings"}, records[0])
+	assert.Equal(t, []string{"rec-1", "Rightsize", "Downsize, saves 30%", "70.00"}, records[1])
+	assert.Equal(t, "Unattached | idle\nvolume", records[2][2], "CSV preserves embedded newlines via quoting")
+}
+
+func TestRenderRecommendationsCSV_MergedSources(t *testing.T) {
+	cols, err := resolveRecommendationColumns([]string{"id,source"}, nil)
+	require.NoError(t, err)
+
+	result := &engine.RecommendationsResult{Recommendations: []engine.Recommendation{
+		{ID: "rec-1", Source: "kubecost", Sources: []string{"aws", "kubecost"}},
+		{ID: "rec-2", Source: "aws"},
+	}}
+	var buf bytes.Buffer
+	require.NoError(t, renderRecommendationsCSV(&buf, result, cols))
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"rec-1", "aws; kubecost"}, records[1])
+	assert.Equal(t, []string{"rec-2", "aws"}, records[2])
+}
+
+func TestRenderRecommendationsMarkdown(t *testing.T) {
+	cols, err := resolveRecommendationColumns([]string{"resource,description,savings"}, nil)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, renderRecommendationsMarkdown(&buf, exportTestResult(), cols))
+
+	lines := strings.Split(buf.String(), "\n")
+	assert.Equal(t, "| Resource | Description | Savings |", lines[0])
+	assert.Equal(t, "| --- | --- | ---: |", lines[1])
+	assert.Equal(t, "| i-0abc | Downsize, saves 30% | 70.00 |", lines[2])
+	assert.Equal(t, `| vol-1 | Unattached \| idle volume | 5.50 |`, lines[3])
+	assert.Contains(t, buf.String(), "**2 recommendation(s), total estimated savings: 75.50 USD/month**")
+}
+
+func TestRenderRecommendationsOutput_ExportFormats(t *testing.T) {
+	for _, format := range []string{"csv", "markdown"} {
+		t.Run(format, func(t *testing.T) {
+			var out bytes.Buffer
+			cmd := &cobra.Command{}
+			cmd.SetOut(&out)
+
+			err := RenderRecommendationsOutput(
+				context.Background(), cmd, format, exportTestResult(), false, nil, []string{"id"},
+				InteractiveRecommendationsOptions{},
+			)
+			require.NoError(t, err)
+			assert.Contains(t, out.String(), "rec-2")
+			assert.NotContains(t, out.String(), "i-0abc")
+		})
+	}
+
+	t.Run("invalid column", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		cmd.SetOut(&bytes.Buffer{})
+		err := RenderRecommendationsOutput(
+			context.Background(), cmd, "csv", exportTestResult(), false, nil, []string{"bogus"},
+			InteractiveRecommendationsOptions{},
+		)
+		require.Error(t, err)
+	})
+}
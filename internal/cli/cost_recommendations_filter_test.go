@@ -0,0 +1,100 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestApplyMinSavingsFilter(t *testing.T) {
+	recs := []engine.Recommendation{
+		{ResourceID: "big", EstimatedSavings: 120},
+		{ResourceID: "edge", EstimatedSavings: 10},
+		{ResourceID: "small", EstimatedSavings: 2.5},
+		{ResourceID: "none"},
+	}
+
+	ctx := context.Background()
+	assert.Len(t, applyMinSavingsFilter(ctx, recs, 0), 4, "zero threshold keeps everything")
+
+	filtered := applyMinSavingsFilter(ctx, recs, 10)
+	require.Len(t, filtered, 2)
+	assert.Equal(t, "big", filtered[0].ResourceID)
+	assert.Equal(t, "edge", filtered[1].ResourceID, "threshold is inclusive")
+	assert.InDelta(t, 130.0, calculateTotalSavings(filtered), 0.001)
+}
+
+func TestResolveMinSavings(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_HOME", filepath.Join(home, ".finfocus"))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, ".finfocus"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(home, ".finfocus", "config.yaml"),
+		[]byte("recommendations:\n  min_savings: 15\n"), 0o600))
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{}
+		cmd.Flags().Float64("min-savings", 0, "")
+		return cmd
+	}
+
+	got, err := resolveMinSavings(newCmd(), 0)
+	require.NoError(t, err)
+	assert.InDelta(t, 15.0, got, 0.001, "config default applies when flag unset")
+
+	cmd := newCmd()
+	require.NoError(t, cmd.Flags().Set("min-savings", "0"))
+	got, err = resolveMinSavings(cmd, 0)
+	require.NoError(t, err)
+	assert.Zero(t, got, "explicit flag overrides config")
+
+	cmd = newCmd()
+	require.NoError(t, cmd.Flags().Set("min-savings", "-1"))
+	_, err = resolveMinSavings(cmd, -1)
+	require.Error(t, err)
+}
+
+func TestApplyRecommendationFilters(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"env": "prod-eu"}}},
+		{ID: "db", Type: "aws:rds/instance:Instance",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"env": "dev"}}},
+		{ID: "vm", Type: "gcp:compute/instance:Instance"},
+	}
+	recs := []engine.Recommendation{
+		{ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 150},
+		{ResourceID: "db", Type: "TERMINATE", EstimatedSavings: 300},
+		{ResourceID: "vm", Type: "RIGHTSIZE", EstimatedSavings: 200},
+		{ResourceID: "gone", Type: "MIGRATE", EstimatedSavings: 50},
+	}
+	ctx := context.Background()
+	ids := func(filtered []engine.Recommendation) []string {
+		out := make([]string, 0, len(filtered))
+		for _, rec := range filtered {
+			out = append(out, rec.ResourceID)
+		}
+		return out
+	}
+
+	filtered, err := applyRecommendationFilters(ctx, recs, resources,
+		[]string{`savings > 100 && provider == "aws" && tag.env =~ "prod*"`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, ids(filtered))
+
+	filtered, err = applyRecommendationFilters(ctx, recs, resources,
+		[]string{"action=RIGHTSIZE,MIGRATE", `savings < 180 || provider == "gcp"`})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web", "vm", "gone"}, ids(filtered), "key=value and expressions combine")
+
+	_, err = applyRecommendationFilters(ctx, recs, resources, []string{"savngs > 100"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `did you mean "savings"?`)
+}
@@ -0,0 +1,228 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// errGroupByWithPagination is returned when --group-by is combined with pagination flags.
+var errGroupByWithPagination = errors.New("--group-by cannot be combined with --limit, --page, or --offset")
+
+// groupedRecommendationsJSON is the nested JSON output for --group-by.
+type groupedRecommendationsJSON struct {
+	GroupBy      string                       `json:"group_by"`
+	Groups       []recommendationGroupJSON    `json:"groups"`
+	TotalCount   int                          `json:"total_count"`
+	TotalSavings float64                      `json:"total_savings"`
+	Currency     string                       `json:"currency"`
+	Errors       []engine.RecommendationError `json:"errors,omitempty"`
+}
+
+// recommendationGroupJSON is a single group in JSON/NDJSON output.
+type recommendationGroupJSON struct {
+	Type            string               `json:"type,omitempty"`
+	Key             string               `json:"key"`
+	Count           int                  `json:"count"`
+	TotalSavings    float64              `json:"total_savings"`
+	Currency        string               `json:"currency"`
+	Recommendations []recommendationJSON `json:"recommendations"`
+}
+
+// validateGroupByParams parses --group-by and rejects incompatible flags.
+// It returns a nil spec when grouping is not requested.
+func validateGroupByParams(params costRecommendationsParams) (*engine.RecommendationGroupSpec, error) {
+	if params.groupBy == "" {
+		return nil, nil //nolint:nilnil // nil spec means grouping is disabled
+	}
+
+	spec, err := engine.ParseRecommendationGroupBy(params.groupBy)
+	if err != nil {
+		return nil, err
+	}
+
+	if params.limit > 0 || params.page > 0 || params.offset > 0 {
+		return nil, errGroupByWithPagination
+	}
+
+	switch engine.OutputFormat(config.GetOutputFormat(params.output)) {
+	case engine.OutputCSV, engine.OutputMarkdown, engine.OutputSARIF:
+		return nil, fmt.Errorf("--group-by does not support %s output (use table, json, or ndjson)", params.output)
+	case engine.OutputTable, engine.OutputJSON, engine.OutputNDJSON:
+	}
+
+	return &spec, nil
+}
+
+// renderGroupedRecommendationsOutput groups the filtered recommendations,
+// renders them, and records the audit entry.
+func renderGroupedRecommendationsOutput(
+	ctx context.Context,
+	cmd *cobra.Command,
+	params costRecommendationsParams,
+	spec engine.RecommendationGroupSpec,
+	recommendations []engine.Recommendation,
+	resources []engine.ResourceDescriptor,
+	result *engine.RecommendationsResult,
+	audit *auditContext,
+) error {
+	groups := engine.GroupRecommendations(recommendations, resources, spec)
+	groupedResult := &engine.RecommendationsResult{
+		Recommendations: recommendations,
+		Errors:          result.Errors,
+		TotalSavings:    calculateTotalSavings(recommendations),
+		Currency:        result.Currency,
+	}
+
+	if err := renderGroupedRecommendations(cmd.OutOrStdout(), params.output, spec, groups, groupedResult); err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Info().Ctx(ctx).Str("operation", "cost_recommendations").
+		Str("group_by", spec.String()).
+		Int("group_count", len(groups)).
+		Int("recommendation_count", len(recommendations)).
+		Msg("grouped recommendations rendered")
+
+	audit.logSuccess(ctx, len(recommendations), groupedResult.TotalSavings)
+	return nil
+}
+
+// renderGroupedRecommendations renders grouped recommendations as a hierarchical
+// table, nested JSON, or NDJSON with one line per group.
+func renderGroupedRecommendations(
+	w io.Writer,
+	outputFormat string,
+	spec engine.RecommendationGroupSpec,
+	groups []engine.RecommendationGroup,
+	result *engine.RecommendationsResult,
+) error {
+	switch engine.OutputFormat(config.GetOutputFormat(outputFormat)) {
+	case engine.OutputJSON:
+		return renderGroupedRecommendationsJSON(w, spec, groups, result)
+	case engine.OutputNDJSON:
+		err := renderGroupedRecommendationsNDJSON(w, groups)
+		if isBrokenPipe(err) {
+			return nil
+		}
+		return err
+	case engine.OutputTable, engine.OutputCSV, engine.OutputMarkdown, engine.OutputSARIF:
+	}
+	return renderGroupedRecommendationsTable(w, spec, groups, result)
+}
+
+// renderGroupedRecommendationsTable renders a group summary row followed by the
+// group's recommendations, indented beneath it.
+func renderGroupedRecommendationsTable(
+	w io.Writer,
+	spec engine.RecommendationGroupSpec,
+	groups []engine.RecommendationGroup,
+	result *engine.RecommendationsResult,
+) error {
+	fmt.Fprintf(w, "RECOMMENDATIONS BY %s\n", strings.ToUpper(spec.String()))
+	fmt.Fprintln(w, strings.Repeat("-", headerSeparatorLen))
+
+	if len(groups) == 0 {
+		fmt.Fprintln(w, "No recommendations available.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "GROUP\tCOUNT\tACTION TYPE\tDESCRIPTION\tSAVINGS")
+	fmt.Fprintln(tw, "-----\t-----\t-----------\t-----------\t-------")
+
+	var totalCount int
+	var totalSavings float64
+	for _, group := range groups {
+		totalCount += group.Count
+		totalSavings += group.TotalSavings
+		fmt.Fprintf(tw, "%s\t%d\t\t\t%.2f %s\n", group.Key, group.Count, group.TotalSavings, group.Currency)
+		for _, rec := range group.Recommendations {
+			savings := ""
+			if rec.EstimatedSavings > 0 {
+				savings = fmt.Sprintf("%.2f %s", rec.EstimatedSavings, rec.Currency)
+			}
+			fmt.Fprintf(tw, "  %s\t\t%s\t%s\t%s\n",
+				rec.ResourceID, formatActionTypeLabel(rec.Type), truncateDescription(rec.Description), savings)
+		}
+	}
+
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("flushing table writer: %w", err)
+	}
+
+	fmt.Fprintf(w, "\nTotal: %d recommendation(s) in %d group(s), %.2f %s potential monthly savings\n",
+		totalCount, len(groups), totalSavings, result.Currency)
+
+	if result.HasErrors() {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "ERRORS")
+		fmt.Fprintln(w, "======")
+		fmt.Fprintln(w, result.ErrorSummary())
+	}
+	return nil
+}
+
+// renderGroupedRecommendationsJSON renders groups as a single nested JSON document.
+func renderGroupedRecommendationsJSON(
+	w io.Writer,
+	spec engine.RecommendationGroupSpec,
+	groups []engine.RecommendationGroup,
+	result *engine.RecommendationsResult,
+) error {
+	output := groupedRecommendationsJSON{
+		GroupBy:  spec.String(),
+		Groups:   make([]recommendationGroupJSON, 0, len(groups)),
+		Currency: result.Currency,
+		Errors:   result.Errors,
+	}
+	for _, group := range groups {
+		output.Groups = append(output.Groups, toRecommendationGroupJSON(group, ""))
+		output.TotalCount += group.Count
+		output.TotalSavings += group.TotalSavings
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("encoding JSON: %w", err)
+	}
+	return nil
+}
+
+// renderGroupedRecommendationsNDJSON renders one JSON object per group.
+func renderGroupedRecommendationsNDJSON(w io.Writer, groups []engine.RecommendationGroup) error {
+	encoder := json.NewEncoder(w)
+	for _, group := range groups {
+		if err := encoder.Encode(toRecommendationGroupJSON(group, "group")); err != nil {
+			return fmt.Errorf("encoding NDJSON: %w", err)
+		}
+	}
+	return nil
+}
+
+// toRecommendationGroupJSON converts an engine group to its JSON form.
+func toRecommendationGroupJSON(group engine.RecommendationGroup, lineType string) recommendationGroupJSON {
+	out := recommendationGroupJSON{
+		Type:            lineType,
+		Key:             group.Key,
+		Count:           group.Count,
+		TotalSavings:    group.TotalSavings,
+		Currency:        group.Currency,
+		Recommendations: make([]recommendationJSON, 0, len(group.Recommendations)),
+	}
+	for _, rec := range group.Recommendations {
+		out.Recommendations = append(out.Recommendations, toRecommendationJSON(rec))
+	}
+	return out
+}
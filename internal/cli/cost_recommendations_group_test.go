@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestValidateGroupByParams(t *testing.T) {
+	tests := []struct {
+		name    string
+		params  costRecommendationsParams
+		wantNil bool
+		wantErr string
+	}{
+		{name: "disabled", params: costRecommendationsParams{}, wantNil: true},
+		{name: "valid", params: costRecommendationsParams{groupBy: "provider", output: "json"}},
+		{name: "invalid value", params: costRecommendationsParams{groupBy: "zone"}, wantErr: "invalid recommendation group-by"},
+		{
+			name:    "pagination rejected",
+			params:  costRecommendationsParams{groupBy: "action", limit: 5},
+			wantErr: "cannot be combined",
+		},
+		{
+			name:    "csv rejected",
+			params:  costRecommendationsParams{groupBy: "action", output: "csv"},
+			wantErr: "does not support csv",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			spec, err := validateGroupByParams(tt.params)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			if tt.wantNil {
+				assert.Nil(t, spec)
+			} else {
+				assert.NotNil(t, spec)
+			}
+		})
+	}
+}
+
+func groupTestData() (engine.RecommendationGroupSpec, []engine.RecommendationGroup, *engine.RecommendationsResult) {
+	recs := []engine.Recommendation{
+		{ID: "rec-1", ResourceID: "web", Type: "RIGHTSIZE", Description: "Downsize", EstimatedSavings: 50, Currency: "USD"},
+		{ID: "rec-2", ResourceID: "vol", Type: "DELETE_UNUSED", Description: "Delete", EstimatedSavings: 10, Currency: "USD"},
+	}
+	spec := engine.RecommendationGroupSpec{Kind: engine.RecommendationGroupByAction}
+	groups := engine.GroupRecommendations(recs, nil, spec)
+	result := &engine.RecommendationsResult{Recommendations: recs, TotalSavings: 60, Currency: "USD"}
+	return spec, groups, result
+}
+
+func TestRenderGroupedRecommendations_Table(t *testing.T) {
+	spec, groups, result := groupTestData()
+
+	var buf bytes.Buffer
+	require.NoError(t, renderGroupedRecommendations(&buf, "table", spec, groups, result))
+
+	out := buf.String()
+	assert.Contains(t, out, "RECOMMENDATIONS BY ACTION")
+	assert.Contains(t, out, "Total: 2 recommendation(s) in 2 group(s), 60.00 USD potential monthly savings")
+
+	rightsizeIdx := strings.Index(out, "RIGHTSIZE")
+	deleteIdx := strings.Index(out, "DELETE_UNUSED")
+	require.NotEqual(t, -1, rightsizeIdx)
+	require.NotEqual(t, -1, deleteIdx)
+	assert.Less(t, rightsizeIdx, deleteIdx, "groups ordered by savings")
+	assert.Contains(t, out, "  web")
+}
+
+func TestRenderGroupedRecommendations_JSON(t *testing.T) {
+	spec, groups, result := groupTestData()
+
+	var buf bytes.Buffer
+	require.NoError(t, renderGroupedRecommendations(&buf, "json", spec, groups, result))
+
+	var output groupedRecommendationsJSON
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &output))
+	assert.Equal(t, "action", output.GroupBy)
+	assert.Equal(t, 2, output.TotalCount)
+	assert.InDelta(t, 60.0, output.TotalSavings, 0.001)
+	require.Len(t, output.Groups, 2)
+	assert.Equal(t, "RIGHTSIZE", output.Groups[0].Key)
+	require.Len(t, output.Groups[0].Recommendations, 1)
+	assert.Equal(t, "rec-1", output.Groups[0].Recommendations[0].ID)
+}
+
+func TestRenderGroupedRecommendations_NDJSON(t *testing.T) {
+	spec, groups, result := groupTestData()
+
+	var buf bytes.Buffer
+	require.NoError(t, renderGroupedRecommendations(&buf, "ndjson", spec, groups, result))
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+	var first recommendationGroupJSON
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "group", first.Type)
+	assert.Equal(t, "RIGHTSIZE", first.Key)
+}
@@ -0,0 +1,336 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sync"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// Values of the --progress flag of cost recommendations.
+const (
+	progressModeAuto = "auto"
+	progressModeJSON = "json"
+	progressModeNone = "none"
+)
+
+// progressWindow is the period over which throughput is measured, so the
+// ETA follows changes in plugin speed.
+const progressWindow = 10 * time.Second
+
+// progressPercentScale converts the share of completed requests to the
+// percentage of --progress json events.
+const progressPercentScale = 100
+
+// Events of --progress json.
+const (
+	progressEventStart = "start"
+	progressEventBatch = "batch"
+	progressEventDone  = "done"
+)
+
+// progressEvent is a line of --progress json output.
+type progressEvent struct {
+	Event              string    `json:"event"`
+	Time               time.Time `json:"time"`
+	Resources          int       `json:"resources,omitempty"`
+	Plugins            []string  `json:"plugins,omitempty"`
+	Plugin             string    `json:"plugin,omitempty"`
+	Batch              int       `json:"batch,omitempty"` // 1-based
+	Batches            int       `json:"batches,omitempty"`
+	Recommendations    int       `json:"recommendations,omitempty"`
+	Error              string    `json:"error,omitempty"`
+	CompletedBatches   int       `json:"completedBatches"`
+	TotalBatches       int       `json:"totalBatches"`
+	Percent            float64   `json:"percent"`
+	ResourcesPerSecond float64   `json:"resourcesPerSecond"`
+	ETASeconds         float64   `json:"etaSeconds"`
+	ElapsedSeconds     float64   `json:"elapsedSeconds"`
+}
+
+// progressSample records the resources of the requests completed at a time.
+type progressSample struct {
+	at        time.Time
+	resources int
+}
+
+// recommendationProgressTracker turns the progress the engine reports into
+// snapshots with the throughput and ETA of the whole fetch. It is safe for
+// concurrent use.
+type recommendationProgressTracker struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	start     time.Time
+	resources int
+	plugins   []tui.PluginFetchProgress
+	done      map[string]int // Resources completed by plugin
+	samples   []progressSample
+}
+
+// newRecommendationProgressTracker tracks fetching recommendations for
+// resources from the named plugins.
+func newRecommendationProgressTracker(
+	resources int, plugins []string, now func() time.Time,
+) *recommendationProgressTracker {
+	t := &recommendationProgressTracker{
+		now:       now,
+		start:     now(),
+		resources: resources,
+		done:      make(map[string]int, len(plugins)),
+	}
+	for _, name := range plugins {
+		t.plugins = append(t.plugins, tui.PluginFetchProgress{
+			Name:    name,
+			Batches: engine.RecommendationBatches(resources),
+		})
+	}
+	return t
+}
+
+// record applies a completed request and returns the new snapshot.
+func (t *recommendationProgressTracker) record(p engine.RecommendationProgress) tui.FetchProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range t.plugins {
+		plugin := &t.plugins[i]
+		if plugin.Name != p.Plugin {
+			continue
+		}
+		plugin.Completed++
+		plugin.Recommendations += p.Recommendations
+		plugin.Failed = plugin.Failed || p.Err != nil
+	}
+	t.done[p.Plugin] += p.Resources
+	t.samples = append(t.samples, progressSample{at: t.now(), resources: p.Resources})
+	return t.snapshotLocked()
+}
+
+// snapshot returns the current progress.
+func (t *recommendationProgressTracker) snapshot() tui.FetchProgress {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.snapshotLocked()
+}
+
+// snapshotLocked returns the current progress. Throughput is measured over
+// the requests completed within progressWindow, or since the start when it
+// is shorter, and the ETA divides the resources left to send by it.
+func (t *recommendationProgressTracker) snapshotLocked() tui.FetchProgress {
+	now := t.now()
+	snapshot := tui.FetchProgress{
+		Resources: t.resources,
+		Plugins:   append([]tui.PluginFetchProgress(nil), t.plugins...),
+		Elapsed:   now.Sub(t.start),
+	}
+
+	windowStart := now.Add(-progressWindow)
+	if windowStart.Before(t.start) {
+		windowStart = t.start
+	}
+	recent := 0
+	for _, sample := range t.samples {
+		if !sample.at.Before(windowStart) {
+			recent += sample.resources
+		}
+	}
+	if window := now.Sub(windowStart).Seconds(); window > 0 && recent > 0 {
+		snapshot.Throughput = float64(recent) / window
+	}
+
+	remaining := 0
+	for _, plugin := range t.plugins {
+		if !plugin.Failed {
+			remaining += t.resources - t.done[plugin.Name]
+		}
+	}
+	if snapshot.Throughput > 0 && remaining > 0 {
+		snapshot.ETA = time.Duration(float64(remaining) / snapshot.Throughput * float64(time.Second))
+	}
+	return snapshot
+}
+
+// validateProgressMode checks the value of --progress.
+func validateProgressMode(mode string) error {
+	switch mode {
+	case progressModeAuto, progressModeJSON, progressModeNone:
+		return nil
+	default:
+		return &usageError{err: fmt.Errorf("invalid --progress %q: use %s, %s, or %s",
+			mode, progressModeAuto, progressModeJSON, progressModeNone)}
+	}
+}
+
+// pluginNames returns the names of clients.
+func pluginNames(clients []*pluginhost.Client) []string {
+	names := make([]string, 0, len(clients))
+	for _, client := range clients {
+		names = append(names, client.Name)
+	}
+	return names
+}
+
+// newProgressEvent returns a --progress json event for snapshot.
+func newProgressEvent(event string, at time.Time, snapshot tui.FetchProgress) progressEvent {
+	completed, total := snapshot.Batches()
+	return progressEvent{
+		Event:              event,
+		Time:               at.UTC(),
+		CompletedBatches:   completed,
+		TotalBatches:       total,
+		Percent:            snapshot.Percent() * progressPercentScale,
+		ResourcesPerSecond: snapshot.Throughput,
+		ETASeconds:         snapshot.ETA.Seconds(),
+		ElapsedSeconds:     snapshot.Elapsed.Seconds(),
+	}
+}
+
+// fetchRecommendationsWithProgress fetches recommendations while reporting
+// progress on stderr as --progress selects: a display of each plugin's
+// completed requests, the throughput, and the ETA in terminals unless --quiet
+// is set (auto), JSON events (json), or nothing (none).
+func fetchRecommendationsWithProgress(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng *engine.Engine,
+	plugins []string,
+	resources []engine.ResourceDescriptor,
+	mode string,
+) (*engine.RecommendationsResult, error) {
+	tracker := newRecommendationProgressTracker(len(resources), plugins, time.Now)
+
+	switch mode {
+	case progressModeJSON:
+		return fetchRecommendationsWithProgressEvents(ctx, cmd.ErrOrStderr(), eng, tracker, plugins, resources)
+	case progressModeAuto:
+		if term.IsTerminal(int(os.Stderr.Fd())) && !tui.Quiet() {
+			return fetchRecommendationsWithProgressDisplay(ctx, cmd.ErrOrStderr(), eng, tracker, resources)
+		}
+	}
+	return eng.GetRecommendationsForResources(ctx, resources)
+}
+
+// fetchRecommendationsWithProgressEvents fetches recommendations, writing a
+// start event, an event per completed request, and a done event to w as
+// NDJSON.
+func fetchRecommendationsWithProgressEvents(
+	ctx context.Context,
+	w io.Writer,
+	eng *engine.Engine,
+	tracker *recommendationProgressTracker,
+	plugins []string,
+	resources []engine.ResourceDescriptor,
+) (*engine.RecommendationsResult, error) {
+	enc := json.NewEncoder(w)
+	start := newProgressEvent(progressEventStart, time.Now(), tracker.snapshot())
+	start.Resources, start.Plugins = len(resources), plugins
+	_ = enc.Encode(start)
+
+	eng.WithRecommendationProgress(func(p engine.RecommendationProgress) {
+		event := newProgressEvent(progressEventBatch, time.Now(), tracker.record(p))
+		event.Plugin, event.Batch, event.Batches = p.Plugin, p.Batch+1, p.Batches
+		event.Recommendations = p.Recommendations
+		if p.Err != nil {
+			event.Error = p.Err.Error()
+		}
+		_ = enc.Encode(event)
+	})
+	defer eng.WithRecommendationProgress(nil)
+
+	result, err := eng.GetRecommendationsForResources(ctx, resources)
+	done := newProgressEvent(progressEventDone, time.Now(), tracker.snapshot())
+	if err != nil {
+		done.Error = err.Error()
+	}
+	_ = enc.Encode(done)
+	return result, err
+}
+
+// fetchRecommendationsWithProgressDisplay fetches recommendations, showing
+// a tui.FetchProgressModel on w once the fetch takes longer than
+// progressDelayMS.
+func fetchRecommendationsWithProgressDisplay(
+	ctx context.Context,
+	w io.Writer,
+	eng *engine.Engine,
+	tracker *recommendationProgressTracker,
+	resources []engine.ResourceDescriptor,
+) (*engine.RecommendationsResult, error) {
+	updates := make(chan struct{}, 1)
+	eng.WithRecommendationProgress(func(p engine.RecommendationProgress) {
+		tracker.record(p)
+		select {
+		case updates <- struct{}{}:
+		default:
+		}
+	})
+	defer eng.WithRecommendationProgress(nil)
+
+	fetched := make(chan struct{})
+	var display sync.WaitGroup
+	display.Add(1)
+	go func() {
+		defer display.Done()
+		showRecommendationProgress(ctx, w, tracker, updates, fetched)
+	}()
+
+	result, err := eng.GetRecommendationsForResources(ctx, resources)
+	close(fetched)
+	display.Wait()
+	return result, err
+}
+
+// showRecommendationProgress runs the progress display from progressDelayMS
+// after it is called until fetched is closed, sending it a snapshot on each
+// update. Nothing is shown when the fetch ends sooner.
+func showRecommendationProgress(
+	ctx context.Context,
+	w io.Writer,
+	tracker *recommendationProgressTracker,
+	updates <-chan struct{},
+	fetched <-chan struct{},
+) {
+	timer := time.NewTimer(progressDelayMS * time.Millisecond)
+	defer timer.Stop()
+	select {
+	case <-fetched:
+		return
+	case <-timer.C:
+	}
+
+	// Without input and signal handling, Ctrl+C interrupts the command as
+	// it does without the display.
+	program := tea.NewProgram(tui.NewFetchProgressModel(tracker.snapshot()),
+		tea.WithOutput(w), tea.WithInput(nil), tea.WithoutSignalHandler(), tea.WithContext(ctx))
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		// A display that fails to start leaves the fetch running unseen.
+		_, _ = program.Run()
+	}()
+
+	for {
+		select {
+		case <-updates:
+			program.Send(tui.FetchProgressMsg(tracker.snapshot()))
+		case <-fetched:
+			program.Send(tui.FetchDoneMsg{})
+			<-stopped
+			return
+		case <-stopped:
+			<-fetched
+			return
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// progressTestClient returns a recommendation for the first resource of
+// every request, or err.
+type progressTestClient struct {
+	proto.CostSourceClient
+
+	err error
+}
+
+func (c progressTestClient) GetRecommendations(
+	_ context.Context, in *proto.GetRecommendationsRequest, _ ...grpc.CallOption,
+) (*proto.GetRecommendationsResponse, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &proto.GetRecommendationsResponse{Recommendations: []*proto.Recommendation{{
+		ID:         "rec-" + in.TargetResources[0].ID,
+		ResourceID: in.TargetResources[0].ID,
+		Impact:     &proto.RecommendationImpact{EstimatedSavings: 10, Currency: "USD"},
+	}}}, nil
+}
+
+func TestRecommendationProgressTracker(t *testing.T) {
+	clock := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	tracker := newRecommendationProgressTracker(300, []string{"aws", "kubecost"}, func() time.Time { return clock })
+
+	snapshot := tracker.snapshot()
+	assert.Len(t, snapshot.Plugins, 2)
+	assert.Equal(t, 3, snapshot.Plugins[0].Batches)
+	assert.Zero(t, snapshot.Throughput)
+	assert.Zero(t, snapshot.ETA, "no ETA before a request completes")
+
+	clock = clock.Add(2 * time.Second)
+	snapshot = tracker.record(engine.RecommendationProgress{
+		Plugin: "aws", Batches: 3, Resources: 100, Recommendations: 4,
+	})
+	assert.Equal(t, 1, snapshot.Plugins[0].Completed)
+	assert.Equal(t, 4, snapshot.Plugins[0].Recommendations)
+	assert.InDelta(t, 50, snapshot.Throughput, 1e-9)
+	assert.Equal(t, 10*time.Second, snapshot.ETA, "500 resources left at 50 resources/s")
+
+	clock = clock.Add(30 * time.Second)
+	snapshot = tracker.record(engine.RecommendationProgress{
+		Plugin: "kubecost", Batches: 3, Resources: 100, Err: errors.New("unavailable"),
+	})
+	assert.True(t, snapshot.Plugins[1].Failed)
+	assert.InDelta(t, 10, snapshot.Throughput, 1e-9, "only the last 10 seconds count")
+	assert.Equal(t, 20*time.Second, snapshot.ETA, "a failed plugin is not waited for")
+}
+
+func TestFetchRecommendationsWithProgress_JSON(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	resources := make([]engine.ResourceDescriptor, 150)
+	for i := range resources {
+		resources[i] = engine.ResourceDescriptor{ID: "r" + strconv.Itoa(i), Type: "aws:ec2/instance:Instance"}
+	}
+	clients := []*pluginhost.Client{
+		{Name: "aws", API: progressTestClient{}},
+		{Name: "kubecost", API: progressTestClient{err: errors.New("unavailable")}},
+	}
+	eng := engine.New(clients, nil)
+
+	cmd := NewCostRecommendationsCmd()
+	var stderr bytes.Buffer
+	cmd.SetErr(&stderr)
+	result, err := fetchRecommendationsWithProgress(context.Background(), cmd, eng, pluginNames(clients),
+		resources, progressModeJSON)
+	require.NoError(t, err)
+	assert.Len(t, result.Recommendations, 2)
+
+	var events []progressEvent
+	dec := json.NewDecoder(&stderr)
+	for dec.More() {
+		var event progressEvent
+		require.NoError(t, dec.Decode(&event))
+		events = append(events, event)
+	}
+	require.Len(t, events, 5, "start, two aws batches, the failed kubecost batch, and done")
+
+	assert.Equal(t, progressEventStart, events[0].Event)
+	assert.Equal(t, 150, events[0].Resources)
+	assert.Equal(t, []string{"aws", "kubecost"}, events[0].Plugins)
+	assert.Equal(t, 4, events[0].TotalBatches)
+
+	assert.Equal(t, progressEventBatch, events[1].Event)
+	assert.Equal(t, "aws", events[1].Plugin)
+	assert.Equal(t, 1, events[1].Batch)
+	assert.Equal(t, 2, events[1].Batches)
+	assert.Equal(t, 1, events[1].Recommendations)
+	assert.Equal(t, 1, events[1].CompletedBatches)
+
+	assert.Equal(t, "kubecost", events[3].Plugin)
+	assert.Contains(t, events[3].Error, "unavailable")
+
+	assert.Equal(t, progressEventDone, events[4].Event)
+	assert.Equal(t, 4, events[4].CompletedBatches)
+	assert.InDelta(t, 100, events[4].Percent, 1e-9)
+}
+
+func TestValidateProgressMode(t *testing.T) {
+	for _, mode := range []string{progressModeAuto, progressModeJSON, progressModeNone} {
+		require.NoError(t, validateProgressMode(mode))
+	}
+	err := validateProgressMode("bar")
+	var usageErr *usageError
+	require.ErrorAs(t, err, &usageErr)
+	assert.Contains(t, err.Error(), `invalid --progress "bar"`)
+}
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/ingest"
+	"github.com/rshade/finfocus/internal/tui"
+	"github.com/rshade/finfocus/pkg/version"
+)
+
+// SARIF log constants.
+const (
+	sarifVersion    = "2.1.0"
+	sarifSchemaURI  = "https://json.schemastore.org/sarif-2.1.0.json"
+	sarifToolURI    = "https://github.com/rshade/finfocus"
+	sarifRulePrefix = "finfocus/"
+	// sarifFallbackFile anchors results whose resource has no recorded source
+	// position. GitHub code scanning rejects results without a file location.
+	sarifFallbackFile = "Pulumi.yaml"
+	// sarifFingerprintKey identifies the partial fingerprint used to track a
+	// recommendation across runs.
+	sarifFingerprintKey = "finfocusRecommendation/v1"
+)
+
+// sarifLog is the top-level SARIF 2.1.0 document.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	Version        string      `json:"version,omitempty"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name"`
+	ShortDescription sarifMessage `json:"shortDescription"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID              string            `json:"ruleId"`
+	RuleIndex           int               `json:"ruleIndex"`
+	Level               string            `json:"level"`
+	Message             sarifMessage      `json:"message"`
+	Locations           []sarifLocation   `json:"locations"`
+	PartialFingerprints map[string]string `json:"partialFingerprints"`
+	Properties          map[string]any    `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation  `json:"physicalLocation"`
+	LogicalLocations []sarifLogicalLocation `json:"logicalLocations,omitempty"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           *sarifRegion          `json:"region,omitempty"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn,omitempty"`
+}
+
+type sarifLogicalLocation struct {
+	FullyQualifiedName string `json:"fullyQualifiedName"`
+	Kind               string `json:"kind"`
+}
+
+// renderRecommendationsSARIF renders recommendations as a SARIF 2.1.0 log for
+// upload to GitHub code scanning. Each recommendation type becomes a rule and
+// each recommendation a result. Results point at the line that declares the
+// resource when lookup returns a descriptor with a Pulumi source position, and
+// at Pulumi.yaml otherwise; the resource URN is always kept as a logical location.
+func renderRecommendationsSARIF(
+	ctx context.Context,
+	w io.Writer,
+	result *engine.RecommendationsResult,
+	lookup tui.ResourceFetcher,
+) error {
+	run := sarifRun{
+		Tool: sarifTool{Driver: sarifDriver{
+			Name:           "finfocus",
+			Version:        version.GetVersion(),
+			InformationURI: sarifToolURI,
+			Rules:          []sarifRule{},
+		}},
+		Results: []sarifResult{},
+	}
+
+	ruleIndex := make(map[string]int)
+	for _, rec := range result.Recommendations {
+		ruleID := sarifRuleID(rec.Type)
+		index, ok := ruleIndex[ruleID]
+		if !ok {
+			label := formatActionTypeLabel(rec.Type)
+			index = len(run.Tool.Driver.Rules)
+			ruleIndex[ruleID] = index
+			run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, sarifRule{
+				ID:               ruleID,
+				Name:             label,
+				ShortDescription: sarifMessage{Text: label + " cost recommendation"},
+			})
+		}
+
+		location, err := sarifResourceLocation(ctx, rec.ResourceID, lookup)
+		if err != nil {
+			return err
+		}
+
+		properties := map[string]any{"resourceId": rec.ResourceID}
+		if rec.EstimatedSavings > 0 {
+			properties["estimatedSavings"] = rec.EstimatedSavings
+			properties["currency"] = rec.Currency
+		}
+
+		run.Results = append(run.Results, sarifResult{
+			RuleID:              ruleID,
+			RuleIndex:           index,
+			Level:               "note",
+			Message:             sarifMessage{Text: sarifResultMessage(rec)},
+			Locations:           []sarifLocation{location},
+			PartialFingerprints: map[string]string{sarifFingerprintKey: recommendationFingerprint(rec)},
+			Properties:          properties,
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(sarifLog{Schema: sarifSchemaURI, Version: sarifVersion, Runs: []sarifRun{run}}); err != nil {
+		return fmt.Errorf("encoding SARIF: %w", err)
+	}
+	return nil
+}
+
+// sarifResourceLocation resolves the source location of a resource.
+func sarifResourceLocation(ctx context.Context, resourceID string, lookup tui.ResourceFetcher) (sarifLocation, error) {
+	location := sarifLocation{
+		PhysicalLocation: sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: sarifFallbackFile},
+		},
+	}
+	if resourceID != "" {
+		location.LogicalLocations = []sarifLogicalLocation{{FullyQualifiedName: resourceID, Kind: "resource"}}
+	}
+	if lookup == nil || resourceID == "" {
+		return location, nil
+	}
+
+	resource, err := lookup(ctx, resourceID)
+	if err != nil {
+		return sarifLocation{}, fmt.Errorf("looking up resource %s: %w", resourceID, err)
+	}
+	if resource == nil {
+		return location, nil
+	}
+	if src, ok := ingest.ParseSourcePosition(resource.SourcePosition); ok {
+		location.PhysicalLocation = sarifPhysicalLocation{
+			ArtifactLocation: sarifArtifactLocation{URI: src.Path},
+			Region:           &sarifRegion{StartLine: src.Line, StartColumn: src.Column},
+		}
+	}
+	return location, nil
+}
+
+// sarifRuleID derives a stable rule ID from a recommendation type.
+func sarifRuleID(recType string) string {
+	slug := strings.ToLower(strings.TrimSpace(recType))
+	slug = strings.NewReplacer(" ", "-", "_", "-").Replace(slug)
+	if slug == "" {
+		slug = "other"
+	}
+	return sarifRulePrefix + slug
+}
+
+// sarifResultMessage describes the recommendation and its savings.
+func sarifResultMessage(rec engine.Recommendation) string {
+	msg := rec.Description
+	if msg == "" {
+		msg = formatActionTypeLabel(rec.Type)
+	}
+	if rec.EstimatedSavings > 0 {
+		msg += fmt.Sprintf(" (estimated savings: %.2f %s/month)", rec.EstimatedSavings, rec.Currency)
+	}
+	return msg
+}
+
+// recommendationFingerprint identifies a recommendation across runs: the plugin
+// ID when present, otherwise a hash of the resource, type, and description.
+func recommendationFingerprint(rec engine.Recommendation) string {
+	if rec.ID != "" {
+		return rec.ID
+	}
+	sum := sha256.Sum256([]byte(rec.ResourceID + "\x00" + rec.Type + "\x00" + rec.Description))
+	return hex.EncodeToString(sum[:])
+}
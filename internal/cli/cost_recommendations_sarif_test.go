@@ -0,0 +1,72 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestRenderRecommendationsSARIF(t *testing.T) {
+	lookup := newPlanResourceLookup([]engine.ResourceDescriptor{
+		{ID: "i-0abc", Type: "aws:ec2/instance:Instance", SourcePosition: "project:///index.ts#12,5"},
+		{ID: "vol-1", Type: "aws:ebs/volume:Volume"},
+	})
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	err := RenderRecommendationsOutput(
+		context.Background(), cmd, "sarif", exportTestResult(), false, nil, nil,
+		InteractiveRecommendationsOptions{ResourceLookup: lookup},
+	)
+	require.NoError(t, err)
+
+	var log sarifLog
+	require.NoError(t, json.Unmarshal(out.Bytes(), &log))
+	assert.Equal(t, "2.1.0", log.Version)
+	require.Len(t, log.Runs, 1)
+	run := log.Runs[0]
+	assert.Equal(t, "finfocus", run.Tool.Driver.Name)
+	require.Len(t, run.Tool.Driver.Rules, 2)
+	assert.Equal(t, "finfocus/rightsize", run.Tool.Driver.Rules[0].ID)
+	assert.Equal(t, "finfocus/delete-unused", run.Tool.Driver.Rules[1].ID)
+
+	require.Len(t, run.Results, 2)
+	first := run.Results[0]
+	assert.Equal(t, "finfocus/rightsize", first.RuleID)
+	assert.Equal(t, 0, first.RuleIndex)
+	assert.Equal(t, "Downsize, saves 30% (estimated savings: 70.00 USD/month)", first.Message.Text)
+	assert.Equal(t, "rec-1", first.PartialFingerprints[sarifFingerprintKey])
+	require.Len(t, first.Locations, 1)
+	assert.Equal(t, "index.ts", first.Locations[0].PhysicalLocation.ArtifactLocation.URI)
+	assert.Equal(t, &sarifRegion{StartLine: 12, StartColumn: 5}, first.Locations[0].PhysicalLocation.Region)
+	assert.Equal(t, "i-0abc", first.Locations[0].LogicalLocations[0].FullyQualifiedName)
+
+	second := run.Results[1]
+	assert.Equal(t, 1, second.RuleIndex)
+	assert.Equal(t, sarifFallbackFile, second.Locations[0].PhysicalLocation.ArtifactLocation.URI,
+		"resources without a source position point at the project file")
+	assert.Nil(t, second.Locations[0].PhysicalLocation.Region)
+}
+
+func TestRenderRecommendationsSARIF_Empty(t *testing.T) {
+	var out bytes.Buffer
+	err := renderRecommendationsSARIF(context.Background(), &out, &engine.RecommendationsResult{}, nil)
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), `"results": []`, "an empty run still has a results array")
+}
+
+func TestRecommendationFingerprint_WithoutID(t *testing.T) {
+	rec := engine.Recommendation{ResourceID: "vol-1", Type: "DELETE_UNUSED", Description: "idle"}
+	assert.Equal(t, recommendationFingerprint(rec), recommendationFingerprint(rec))
+	rec2 := rec
+	rec2.ResourceID = "vol-2"
+	assert.NotEqual(t, recommendationFingerprint(rec), recommendationFingerprint(rec2))
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// errNoDismissalRemote is returned when sync runs without a configured remote.
+var errNoDismissalRemote = errors.New(
+	"no dismissal remote configured; set recommendations.dismissals.remote or pass --remote")
+
+// newRecommendationsSyncCmd creates the "sync" subcommand for sharing
+// dismissal state with a team remote.
+func newRecommendationsSyncCmd() *cobra.Command {
+	var remoteSpec, output string
+
+	cmd := &cobra.Command{
+		Use:   "sync [pull|push]",
+		Short: "Sync dismissals with a shared team remote",
+		Long: `Synchronize the local dismissal store with a shared remote so that
+dismissals and snoozes made by one team member apply to everyone.
+
+The remote is read from recommendations.dismissals.remote in config.yaml,
+or from --remote. Supported remotes:
+  s3://bucket/path/dismissed.json   (requires the aws CLI)
+  gs://bucket/path/dismissed.json   (requires the gcloud CLI)
+  /path/to/repo                     (directory or git working copy)
+
+Git working copies are pulled before reading and the state file is
+committed and pushed when it changes.
+
+Directions:
+  pull   Merge remote dismissals into the local store
+  push   Merge local dismissals into the remote
+  (none) Pull, then push the merged result
+
+When both sides have a record for the same recommendation, the record
+with the most recent lifecycle change wins.`,
+		Example: `  # Pull and push using the configured remote
+  finfocus cost recommendations sync
+
+  # Only fetch teammates' dismissals
+  finfocus cost recommendations sync pull
+
+  # Sync with an explicit git working copy
+  finfocus cost recommendations sync --remote ~/src/finops-state`,
+		Args:      cobra.MatchAll(cobra.MaximumNArgs(1), cobra.OnlyValidArgs),
+		ValidArgs: []string{string(config.SyncPull), string(config.SyncPush)},
+		RunE: func(cmd *cobra.Command, args []string) error {
+			direction := config.SyncBoth
+			if len(args) == 1 {
+				direction = config.DismissalSyncDirection(args[0])
+			}
+			return executeSync(cmd, remoteSpec, direction, output)
+		},
+	}
+
+	cmd.Flags().StringVar(&remoteSpec, "remote", "",
+		"Remote to sync with (overrides recommendations.dismissals.remote)")
+	cmd.Flags().StringVar(&output, "output", "table", "Output format: table, json")
+
+	return cmd
+}
+
+// executeSync handles the sync subcommand logic.
+func executeSync(cmd *cobra.Command, remoteSpec string, direction config.DismissalSyncDirection, output string) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	if output != outputFormatTable && output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format: %s", output)
+	}
+
+	if remoteSpec == "" {
+		remoteSpec = config.New().DismissalRemoteURL()
+	}
+	if remoteSpec == "" {
+		return errNoDismissalRemote
+	}
+
+	remote, err := config.ParseDismissalRemote(remoteSpec)
+	if err != nil {
+		return err
+	}
+
+	store, err := loadDismissalStore()
+	if err != nil {
+		return fmt.Errorf("load dismissal store: %w", err)
+	}
+
+	result, err := config.SyncDismissals(ctx, store, remote, direction)
+	if err != nil {
+		return fmt.Errorf("syncing dismissals: %w", err)
+	}
+
+	log.Info().
+		Ctx(ctx).
+		Str("component", "cli").
+		Str("operation", "dismissal_sync").
+		Str("remote", result.Remote).
+		Str("direction", string(direction)).
+		Int("added", result.Pulled.Added).
+		Int("updated", result.Pulled.Updated).
+		Bool("pushed", result.Pushed).
+		Msg("dismissals synced")
+
+	if output == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(result)
+	}
+
+	cmd.Printf("Synced dismissals with %s\n", result.Remote)
+	if direction != config.SyncPush {
+		cmd.Printf("  Pulled: %d added, %d updated, %d unchanged\n",
+			result.Pulled.Added, result.Pulled.Updated, result.Pulled.Unchanged)
+	}
+	if direction != config.SyncPull {
+		if result.Pushed {
+			cmd.Println("  Pushed: remote updated")
+		} else {
+			cmd.Println("  Pushed: remote already up to date")
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestNewRecommendationsSyncCmd(t *testing.T) {
+	cmd := NewCostRecommendationsCmd()
+	syncSub := findSubcommandLocal(cmd, "sync")
+	require.NotNil(t, syncSub, "sync subcommand should exist")
+	assert.NotNil(t, syncSub.Flags().Lookup("remote"))
+	assert.Nil(t, syncSub.Flags().Lookup("pulumi-json"), "sync operates on local state only")
+}
+
+func TestSyncCmd_RejectsUnknownDirection(t *testing.T) {
+	cmd := NewCostRecommendationsCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"sync", "sideways", "--remote", t.TempDir()})
+
+	err := cmd.Execute()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid argument")
+}
+
+func TestSyncCmd_PullsFromDirectoryRemote(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	remoteDir := t.TempDir()
+	remoteStore, err := config.NewDismissalStore(filepath.Join(remoteDir, "dismissed.json"))
+	require.NoError(t, err)
+	require.NoError(t, remoteStore.Set(&config.DismissalRecord{
+		RecommendationID: "rec-team",
+		Status:           config.StatusDismissed,
+		Reason:           "NOT_APPLICABLE",
+		DismissedAt:      time.Now(),
+	}))
+	require.NoError(t, remoteStore.Save())
+
+	cmd := NewCostRecommendationsCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"sync", "pull", "--remote", remoteDir, "--output", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var result config.DismissalSyncResult
+	require.NoError(t, json.Unmarshal(out.Bytes(), &result))
+	assert.Equal(t, 1, result.Pulled.Added)
+	assert.False(t, result.Pushed)
+
+	_, err = os.Stat(filepath.Join(home, ".finfocus", "dismissed.json"))
+	require.NoError(t, err, "local store should be saved after pull")
+}
@@ -0,0 +1,132 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// Branches that draw the component roll-up tree in table output.
+const (
+	rollupBranch     = "├─ "
+	rollupLastBranch = "└─ "
+	rollupTrunk      = "│  "
+	rollupGap        = "   "
+)
+
+// addRollupFlag registers the --rollup flag of the commands that calculate
+// costs.
+func addRollupFlag(cmd *cobra.Command, rollup *string) {
+	cmd.Flags().StringVar(rollup, "rollup", "",
+		"Aggregate costs under their Pulumi component resources as a tree: components")
+}
+
+// parseRollup parses the --rollup flag and checks that it is combined with an
+// output format it can render.
+func parseRollup(rollup, output string) (string, error) {
+	mode, err := engine.ParseRollup(rollup)
+	if err != nil {
+		return "", &usageError{err: fmt.Errorf("invalid --rollup: %w", err)}
+	}
+	if mode == "" {
+		return "", nil
+	}
+	switch format := config.GetOutputFormat(output); format {
+	case outputFormatTable, outputFormatJSON:
+		return mode, nil
+	default:
+		return "", &usageError{err: fmt.Errorf("--rollup supports --output table or json, got %q", format)}
+	}
+}
+
+// renderComponentRollup renders the results under the components that enclose
+// their resources: as an engine.ComponentRollup with --output json, as the
+// collapsible component tree of the cost view in an interactive terminal, and
+// as a tree table otherwise. actual marks the results as actual costs, whose
+// total cost is rolled up rather than the monthly cost.
+func renderComponentRollup(
+	ctx context.Context,
+	cmd *cobra.Command,
+	output string,
+	results []engine.CostResult,
+	resources []engine.ResourceDescriptor,
+	actual bool,
+) error {
+	components := engine.ResourceComponents(resources)
+	rollup := engine.BuildComponentRollup(results, components)
+	if config.GetOutputFormat(output) == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(rollup); err != nil {
+			return fmt.Errorf("encoding component rollup JSON: %w", err)
+		}
+		return nil
+	}
+
+	if tui.ResolveOutputMode() == tui.OutputModeInteractive {
+		view := tui.NewCostViewModel(ctx, results)
+		if actual {
+			view = tui.NewCostViewModelFromActual(ctx, results, engine.GroupByNone)
+		}
+		view = view.WithExporter(costViewExporter(actual, time.Now())).WithComponentTree(components)
+		if _, err := tea.NewProgram(view).Run(); err != nil {
+			return fmt.Errorf("failed to run interactive TUI: %w", err)
+		}
+		return nil
+	}
+	costHeader := "MONTHLY"
+	if actual {
+		costHeader = "COST"
+	}
+	return renderComponentRollupTable(cmd.OutOrStdout(), rollup, costHeader)
+}
+
+// renderComponentRollupTable renders the roll-up as a table whose first column
+// draws the component tree, followed by the total.
+func renderComponentRollupTable(w io.Writer, rollup *engine.ComponentRollup, costHeader string) error {
+	fmt.Fprintf(w, "Cost by component (%s)\n\n", rollup.Currency)
+	if len(rollup.Roots) == 0 {
+		fmt.Fprintln(w, "No costs to roll up.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintf(tw, "COMPONENT / RESOURCE\tTYPE\tRESOURCES\t%s\n", costHeader)
+	resources := 0
+	for _, root := range rollup.Roots {
+		writeComponentRollupRows(tw, root, "", "")
+		resources += root.Resources
+	}
+	fmt.Fprintf(tw, "TOTAL\t\t%d\t%.2f\n", resources, rollup.Total)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if rollup.MixedCurrencies {
+		fmt.Fprintln(w, "\nWarning: results use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// writeComponentRollupRows writes the row of node, drawn after branch, and
+// the rows of its descendants, drawn after indent.
+func writeComponentRollupRows(w io.Writer, node *engine.ComponentNode, branch, indent string) {
+	fmt.Fprintf(w, "%s%s\t%s\t%d\t%.2f\n", branch, node.Name, dashIfEmpty(node.Type), node.Resources, node.Cost)
+	for i, child := range node.Children {
+		if i == len(node.Children)-1 {
+			writeComponentRollupRows(w, child, indent+rollupLastBranch, indent+rollupGap)
+		} else {
+			writeComponentRollupRows(w, child, indent+rollupBranch, indent+rollupTrunk)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+const (
+	rollupSiteURN = "urn:pulumi:dev::app::my:web:Site::site"
+	rollupCDNURN  = "urn:pulumi:dev::app::my:web:Site$my:web:CDN::cdn"
+)
+
+func rollupFixtures() ([]engine.CostResult, []engine.ResourceDescriptor) {
+	results := []engine.CostResult{
+		{
+			ResourceID:   "urn:pulumi:dev::app::my:web:Site$aws:s3/bucket:Bucket::assets",
+			ResourceType: "aws:s3/bucket:Bucket", Currency: "USD", Monthly: 5,
+		},
+		{
+			ResourceID:   "urn:pulumi:dev::app::my:web:Site$my:web:CDN$aws:cloudfront/distribution:Distribution::edge",
+			ResourceType: "aws:cloudfront/distribution:Distribution", Currency: "USD", Monthly: 40,
+		},
+		{
+			ResourceID:   "urn:pulumi:dev::app::aws:dynamodb/table:Table::sessions",
+			ResourceType: "aws:dynamodb/table:Table", Currency: "USD", Monthly: 20,
+		},
+	}
+	resources := []engine.ResourceDescriptor{
+		{ID: results[0].ResourceID, Components: []string{rollupSiteURN}},
+		{ID: results[1].ResourceID, Components: []string{rollupSiteURN, rollupCDNURN}},
+		{ID: results[2].ResourceID},
+	}
+	return results, resources
+}
+
+func TestRenderComponentRollup_Table(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	results, resources := rollupFixtures()
+
+	require.NoError(t, renderComponentRollup(context.Background(), cmd, outputFormatTable, results, resources, false))
+
+	text := out.String()
+	assert.Contains(t, text, "Cost by component (USD)")
+	assert.Contains(t, text, "MONTHLY")
+	assert.Regexp(t, `site\s+my:web:Site\s+2\s+45\.00`, text)
+	assert.Regexp(t, `├─ cdn\s+my:web:CDN\s+1\s+40\.00`, text)
+	assert.Regexp(t, `│  └─ edge\s+aws:cloudfront/distribution:Distribution\s+1\s+40\.00`, text)
+	assert.Regexp(t, `└─ assets\s+aws:s3/bucket:Bucket\s+1\s+5\.00`, text)
+	assert.Regexp(t, `\nsessions\s+aws:dynamodb/table:Table\s+1\s+20\.00`, text)
+	assert.Regexp(t, `TOTAL\s+3\s+65\.00`, text)
+}
+
+func TestRenderComponentRollup_JSON(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	results, resources := rollupFixtures()
+
+	require.NoError(t, renderComponentRollup(context.Background(), cmd, outputFormatJSON, results, resources, false))
+
+	var got engine.ComponentRollup
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.InDelta(t, 65.0, got.Total, 0.001)
+	require.Len(t, got.Roots, 2)
+	assert.Equal(t, rollupSiteURN, got.Roots[0].URN)
+	assert.True(t, got.Roots[0].Component)
+	assert.Equal(t, 2, got.Roots[0].Resources)
+}
+
+func TestRenderComponentRollup_Empty(t *testing.T) {
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderComponentRollup(context.Background(), cmd, outputFormatTable, nil, nil, true))
+	assert.Contains(t, out.String(), "No costs to roll up.")
+}
+
+func TestRollup_RejectsUnsupportedOptions(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  func() *cobra.Command
+		args []string
+		want string
+	}{
+		{"projected invalid", NewCostProjectedCmd, []string{"--rollup", "stacks"},
+			`invalid --rollup: invalid rollup "stacks": use components`},
+		{"projected ndjson", NewCostProjectedCmd, []string{"--rollup", "components", "--output", "ndjson"},
+			`--rollup supports --output table or json, got "ndjson"`},
+		{"projected breakdown", NewCostProjectedCmd, []string{"--rollup", "components", "--breakdown"},
+			"if any flags in the group [breakdown rollup] are set none of the others can be"},
+		{"actual ndjson", NewCostActualCmd, []string{"--rollup", "components", "--output", "ndjson"},
+			"--rollup supports --output table or json"},
+		{"actual daily", NewCostActualCmd, []string{"--rollup", "components", "--group-by", "daily"},
+			"--rollup cannot be combined with --group-by daily"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
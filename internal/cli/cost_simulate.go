@@ -0,0 +1,198 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// defaultScheduleStart starts scheduled resources at 07:00 on weekdays.
+const defaultScheduleStart = "0 7 * * 1-5"
+
+// costSimulateScheduleParams holds the parameters for the cost simulate
+// schedule command execution.
+type costSimulateScheduleParams struct {
+	stop    string
+	start   string
+	groupBy string
+	envTag  string
+	output  string
+}
+
+// NewCostSimulateCmd creates the "simulate" command group, which estimates
+// the savings of changes to how resources run.
+func NewCostSimulateCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "simulate", Short: "Simulate the savings of changes to how resources run"}
+	cmd.AddCommand(NewCostSimulateScheduleCmd())
+	return cmd
+}
+
+// NewCostSimulateScheduleCmd creates the "schedule" subcommand, which ranks
+// the savings of stopping non-production resources on a schedule.
+func NewCostSimulateScheduleCmd() *cobra.Command {
+	var params costSimulateScheduleParams
+
+	cmd := &cobra.Command{
+		Use:   "schedule",
+		Short: "Estimate the savings of stopping non-production resources on a schedule",
+		Long: `Estimate how much stopping non-production compute resources on a schedule
+would save, ranked by group, to justify scheduling automation.
+
+Resources are stopped whenever the --cron expression fires and started again
+whenever --start-cron fires (default: 07:00 on weekdays). Both are five-field
+cron expressions ("minute hour day-of-month month day-of-week") in the time
+zone the resources are scheduled in.
+
+Costs come from the latest projection recorded for each stack with 'finfocus
+cost projected --stack <name> --record'; --stack limits the report to one
+stack. Only compute resources are billed by the hour, so storage and other
+resources are left out. Resources in production are left out as well: the
+environment of a resource is the value of its --env-tag tag, or its stack
+name, and names production when it is or contains prod, production, prd, or
+live (e.g. prod-eu).
+
+Savings are grouped by stack, or by the value of a tag with tag:<key>;
+resources without the tag are reported as "(untagged)".`,
+		Example: `  # Stop at 19:00 and start at 07:00 on weekdays, off all weekend
+  finfocus cost simulate schedule --cron "0 19 * * 1-5"
+
+  # Savings per team of running only 09:00-17:00 on weekdays
+  finfocus cost simulate schedule --cron "0 17 * * 1-5" --start-cron "0 9 * * 1-5" --group-by tag:team
+
+  # One stack, as JSON
+  finfocus cost simulate schedule --cron "0 19 * * 1-5" --stack dev --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostSimulateSchedule(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.stop, "cron", "", "Cron expression of when resources are stopped (required)")
+	cmd.Flags().StringVar(&params.start, "start-cron", defaultScheduleStart,
+		"Cron expression of when resources are started again")
+	cmd.Flags().StringVar(&params.groupBy, "group-by", engine.ScheduleGroupByStack,
+		"Group savings by: stack or tag:<key>")
+	cmd.Flags().StringVar(&params.envTag, "env-tag", engine.DefaultOrgEnvironmentTag,
+		"Resource tag that names the environment (falls back to the stack name)")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	_ = cmd.MarkFlagRequired("cron")
+
+	return cmd
+}
+
+// executeCostSimulateSchedule parses the schedules, simulates them against
+// the latest recorded projections, and renders the ranked savings.
+func executeCostSimulateSchedule(cmd *cobra.Command, params costSimulateScheduleParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	if params.output != outputFormatTable && params.output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", params.output)
+	}
+	if err := engine.ValidateScheduleGroupBy(params.groupBy); err != nil {
+		return fmt.Errorf("invalid --group-by: %w", err)
+	}
+	stop, err := engine.ParseCronSchedule(params.stop)
+	if err != nil {
+		return fmt.Errorf("invalid --cron: %w", err)
+	}
+	start, err := engine.ParseCronSchedule(params.start)
+	if err != nil {
+		return fmt.Errorf("invalid --start-cron: %w", err)
+	}
+
+	store := config.NewProjectionHistoryStore("")
+	if loadErr := store.Load(); loadErr != nil {
+		return fmt.Errorf("loading projection history: %w", loadErr)
+	}
+
+	report := engine.BuildScheduleSavings(engine.ScheduleSavingsInput{
+		Stop:      stop,
+		Start:     start,
+		GroupBy:   params.groupBy,
+		EnvTag:    params.envTag,
+		Snapshots: latestSnapshots(store, getStackFlag(cmd), time.Now()),
+	})
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "cost_simulate_schedule").
+		Str("stop", report.Stop).Str("start", report.Start).Int("group_count", len(report.Groups)).
+		Float64("savings", report.Savings).Msg("schedule simulation complete")
+
+	if params.output == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(report); encodeErr != nil {
+			return fmt.Errorf("encoding schedule savings JSON: %w", encodeErr)
+		}
+		return nil
+	}
+	return renderScheduleSavingsTable(cmd.OutOrStdout(), report)
+}
+
+// latestSnapshots returns the projection in effect at now for stack, or for
+// every stack in the store when stack is empty.
+func latestSnapshots(
+	store *config.ProjectionHistoryStore,
+	stack string,
+	now time.Time,
+) map[string]config.ProjectionSnapshot {
+	stacks := store.Stacks()
+	if stack != "" {
+		stacks = []string{stack}
+	}
+	snapshots := make(map[string]config.ProjectionSnapshot, len(stacks))
+	for _, name := range stacks {
+		if snapshot, ok := store.SnapshotAt(name, now); ok {
+			snapshots[name] = *snapshot
+		}
+	}
+	return snapshots
+}
+
+// renderScheduleSavingsTable renders the schedule, the ranked groups, and
+// the total savings.
+func renderScheduleSavingsTable(w io.Writer, report *engine.ScheduleSavingsReport) error {
+	fmt.Fprintf(w, "Stopping at %q and starting at %q runs resources %.0f hours/month (%.1f%% of the time)\n\n",
+		report.Stop, report.Start, report.RunningHoursPerMonth,
+		report.RunningFraction*100) //nolint:mnd // Percentage calculation.
+
+	if len(report.Groups) == 0 {
+		fmt.Fprintln(w, "No non-production compute resources were found in the recorded projections; "+
+			"record them with 'finfocus cost projected --stack <name> --record'.")
+	} else {
+		tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+		fmt.Fprintf(tw, "RANK\t%s\tRESOURCES\tMONTHLY\tSCHEDULED\tSAVINGS\n", scheduleGroupHeader(report.GroupBy))
+		for i, g := range report.Groups {
+			fmt.Fprintf(tw, "%d\t%s\t%d\t%.2f\t%.2f\t%.2f\n",
+				i+1, g.Key, g.Resources, g.Monthly, g.Monthly-g.Savings, g.Savings)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintf(w, "\nPotential savings: %.2f of %.2f %s/month\n", report.Savings, report.Monthly, report.Currency)
+	}
+
+	if report.SkippedProduction > 0 {
+		fmt.Fprintf(w, "Left out %d production compute resources.\n", report.SkippedProduction)
+	}
+	if report.MixedCurrencies {
+		fmt.Fprintln(w, "Warning: projections use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// scheduleGroupHeader returns the column header of a grouping.
+func scheduleGroupHeader(groupBy string) string {
+	if groupBy == engine.ScheduleGroupByStack {
+		return "STACK"
+	}
+	return strings.ToUpper(strings.TrimPrefix(groupBy, engine.TopSpendersTagPrefix))
+}
@@ -0,0 +1,126 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// seedScheduleHistory records projections of a dev, a staging, and a prod
+// stack under a temporary FINFOCUS_HOME.
+func seedScheduleHistory(t *testing.T) {
+	t.Helper()
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	recordedAt := time.Now().UTC().AddDate(0, 0, -1)
+	store := config.NewProjectionHistoryStore("")
+	for stack, resources := range map[string]map[string]config.ProjectedResourceRecord{
+		"dev": {
+			"web":    {ResourceType: "aws:ec2/instance:Instance", Monthly: 100, Currency: "USD"},
+			"bucket": {ResourceType: "aws:s3/bucket:Bucket", Monthly: 30, Currency: "USD"},
+		},
+		"staging": {
+			"web": {ResourceType: "aws:ec2/instance:Instance", Monthly: 300, Currency: "USD",
+				Tags: map[string]string{"team": "web"}},
+		},
+		"prod": {
+			"web": {ResourceType: "aws:ec2/instance:Instance", Monthly: 900, Currency: "USD"},
+		},
+	} {
+		require.NoError(t, store.RecordSnapshot(stack, config.ProjectionSnapshot{
+			RecordedAt: recordedAt, Resources: resources,
+		}))
+	}
+	require.NoError(t, store.Save())
+}
+
+func runCostSimulate(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newCostCmd()
+	cmd.SetContext(context.Background())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(append([]string{"simulate", "schedule"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestCostSimulateSchedule_Table(t *testing.T) {
+	seedScheduleHistory(t)
+
+	out, err := runCostSimulate(t, "--cron", "0 19 * * 1-5")
+	require.NoError(t, err)
+
+	assert.Contains(t, out, `Stopping at "0 19 * * 1-5" and starting at "0 7 * * 1-5"`)
+	assert.Contains(t, out, "RANK")
+	assert.Contains(t, out, "STACK")
+	assert.Less(t, strings.Index(out, "staging"), strings.Index(out, "dev"),
+		"groups are ranked by savings")
+	assert.NotContains(t, out, "prod ")
+	assert.Contains(t, out, "Left out 1 production compute resources.")
+}
+
+func TestCostSimulateSchedule_JSON(t *testing.T) {
+	seedScheduleHistory(t)
+
+	out, err := runCostSimulate(t, "--cron", "0 19 * * 1-5", "--group-by", "tag:team", "--output", "json")
+	require.NoError(t, err)
+
+	var report engine.ScheduleSavingsReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.InDelta(t, 400.0, report.Monthly, 0.001)
+	assert.InDelta(t, 400*(1-60.0/168), report.Savings, 3)
+	require.Len(t, report.Groups, 2)
+	assert.Equal(t, "web", report.Groups[0].Key)
+	assert.Equal(t, engine.UntaggedGroupKey, report.Groups[1].Key)
+}
+
+func TestCostSimulateSchedule_Stack(t *testing.T) {
+	seedScheduleHistory(t)
+
+	out, err := runCostSimulate(t, "--cron", "0 19 * * 1-5", "--stack", "dev", "--output", "json")
+	require.NoError(t, err)
+
+	var report engine.ScheduleSavingsReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	require.Len(t, report.Groups, 1)
+	assert.Equal(t, "dev", report.Groups[0].Key)
+}
+
+func TestCostSimulateSchedule_Errors(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	tests := []struct {
+		args []string
+		want string
+	}{
+		{nil, `required flag(s) "cron" not set`},
+		{[]string{"--cron", "0 19 * *"}, "invalid --cron"},
+		{[]string{"--cron", "0 19 * * 1-5", "--start-cron", "0 7 * * mon"}, "invalid --start-cron"},
+		{[]string{"--cron", "0 19 * * 1-5", "--group-by", "service"}, "invalid --group-by"},
+		{[]string{"--cron", "0 19 * * 1-5", "--output", "csv"}, "unsupported output format"},
+	}
+	for _, tt := range tests {
+		_, err := runCostSimulate(t, tt.args...)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), tt.want)
+	}
+}
+
+func TestCostSimulateSchedule_NoHistory(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	out, err := runCostSimulate(t, "--cron", "0 19 * * 1-5")
+	require.NoError(t, err)
+	assert.Contains(t, out, "No non-production compute resources")
+}
@@ -0,0 +1,83 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/cli/pagination"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// defaultCostSortField orders cost results by resource URN unless --sort
+// says otherwise, so output does not depend on plugin response order.
+const defaultCostSortField = "name"
+
+// costSort is a parsed --sort flag.
+type costSort struct {
+	field string
+	order string
+}
+
+// addSortFlag adds the --sort flag shared by the cost commands.
+func addSortFlag(cmd *cobra.Command, sortExpr *string) {
+	cmd.Flags().StringVar(sortExpr, "sort", "",
+		"Sort results by cost, name, or type, optionally with :asc or :desc (default name:asc, the resource URN; "+
+			"ndjson without --sort streams in input order)")
+}
+
+// parseCostSort parses the --sort flag. An empty flag sorts by resource URN.
+func parseCostSort(sortExpr string) (costSort, error) {
+	if sortExpr == "" {
+		return costSort{field: defaultCostSortField, order: pagination.SortOrderAsc}, nil
+	}
+	field, order, err := pagination.ParseSort(sortExpr)
+	if err != nil {
+		return costSort{}, &usageError{err: fmt.Errorf("invalid --sort: %w", err)}
+	}
+	sorter := pagination.NewCostResultSorter()
+	if !sorter.IsValidField(field) {
+		return costSort{}, &usageError{err: fmt.Errorf("invalid --sort field %q (valid fields: %s)",
+			field, strings.Join(sorter.GetValidFields(), ", "))}
+	}
+	return costSort{field: field, order: order}, nil
+}
+
+// apply orders the results in the order of s. Results that compare equal
+// are ordered by resource URN, and the results of one resource keep their
+// order.
+func (s costSort) apply(results *costResults) error {
+	return results.Sort(costSortKey, s.less())
+}
+
+// less returns the order of s, with ties ordered by resource URN.
+func (s costSort) less() func(a, b engine.CostResult) bool {
+	sorter := pagination.NewCostResultSorter()
+	byURN, _ := sorter.Less(defaultCostSortField, pagination.SortOrderAsc)
+	byField, ok := sorter.Less(s.field, s.order)
+	if !ok {
+		return byURN
+	}
+	return func(a, b engine.CostResult) bool {
+		switch {
+		case byField(a, b):
+			return true
+		case byField(b, a):
+			return false
+		default:
+			return byURN(a, b)
+		}
+	}
+}
+
+// costSortKey copies the fields of result that costSort compares, so that
+// sorting holds only those in memory.
+func costSortKey(result engine.CostResult) engine.CostResult {
+	return engine.CostResult{
+		ResourceID:   result.ResourceID,
+		ResourceType: result.ResourceType,
+		Monthly:      result.Monthly,
+		TotalCost:    result.TotalCost,
+	}
+}
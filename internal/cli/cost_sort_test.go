@@ -0,0 +1,52 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestParseCostSort(t *testing.T) {
+	parsed, err := parseCostSort("")
+	require.NoError(t, err)
+	assert.Equal(t, costSort{field: "name", order: "asc"}, parsed)
+
+	parsed, err = parseCostSort("cost:desc")
+	require.NoError(t, err)
+	assert.Equal(t, costSort{field: "cost", order: "desc"}, parsed)
+
+	for _, expr := range []string{"savings", "cost:down", "cost:desc:x"} {
+		_, err = parseCostSort(expr)
+		var usageErr *usageError
+		require.ErrorAs(t, err, &usageErr, expr)
+	}
+}
+
+func TestCostSortApply(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "urn:c", Monthly: 10, Adapter: "first"},
+		{ResourceID: "urn:a", Monthly: 10},
+		{ResourceID: "urn:c", Monthly: 10, Adapter: "second"},
+		{ResourceID: "urn:b", Monthly: 30},
+	}
+	order := func(costSort costSort) []string {
+		stored := storedResults(t, results...)
+		require.NoError(t, costSort.apply(stored))
+		out := make([]string, 0, len(results))
+		for _, r := range collected(t, stored.CostResultStore) {
+			out = append(out, r.ResourceID+"/"+r.Adapter)
+		}
+		return out
+	}
+
+	byURN, _ := parseCostSort("")
+	assert.Equal(t, []string{"urn:a/", "urn:b/", "urn:c/first", "urn:c/second"}, order(byURN),
+		"results of one resource keep their order")
+
+	byCost, _ := parseCostSort("cost:desc")
+	assert.Equal(t, []string{"urn:b/", "urn:a/", "urn:c/first", "urn:c/second"}, order(byCost),
+		"ties are ordered by URN")
+}
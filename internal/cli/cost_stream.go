@@ -0,0 +1,314 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// projectedCostEngine is the engine surface used to price projected costs.
+type projectedCostEngine interface {
+	recommendationFetcher
+	StreamProjectedCostWithErrors(
+		ctx context.Context,
+		resources []engine.ResourceDescriptor,
+		fn engine.ProjectedResultFunc,
+	) (*engine.CostResultWithErrors, error)
+}
+
+// projectedCostStoreEngine is the engine surface cost projected prices with,
+// which keeps the results in a spill store from pricing to rendering.
+type projectedCostStoreEngine interface {
+	projectedCostEngine
+	StreamProjectedCostToStore(
+		ctx context.Context,
+		resources []engine.ResourceDescriptor,
+		fn engine.ResultBatchFunc,
+	) (*engine.CostResultStore, error)
+}
+
+// actualCostEngine is the engine surface used to query actual costs.
+type actualCostEngine interface {
+	recommendationFetcher
+	StreamActualCostToStore(
+		ctx context.Context,
+		request engine.ActualCostRequest,
+		fn engine.ResultBatchFunc,
+	) (*engine.CostResultStore, error)
+}
+
+// costResults holds the results of a cost command in their spill store.
+// Filtering, sorting, pagination, NDJSON output, and totals read the store a
+// chunk at a time; outputs that need every result at once, such as tables,
+// breakdowns, and budgets, call collect.
+type costResults struct {
+	*engine.CostResultStore
+
+	collected *engine.CostResultWithErrors
+}
+
+// collect reads every result into memory the first time it is called. The
+// results must not be filtered or sorted afterwards.
+func (r *costResults) collect() (*engine.CostResultWithErrors, error) {
+	if r.collected == nil {
+		collected, err := r.Collect()
+		if err != nil {
+			return nil, err
+		}
+		r.collected = collected
+	}
+	return r.collected, nil
+}
+
+// totals sums cost over the results and finds their currency as
+// extractCurrencyFromResults does.
+func (r *costResults) totals(cost func(engine.CostResult) float64) (float64, string, bool, error) {
+	total, currency, mixed := 0.0, "", false
+	err := r.Results.Each(func(result engine.CostResult) error {
+		total += cost(result)
+		switch {
+		case result.Currency == "":
+		case currency == "":
+			currency = result.Currency
+		case result.Currency != currency:
+			mixed = true
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, "", false, fmt.Errorf("reading cost results: %w", err)
+	}
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	return total, currency, mixed, nil
+}
+
+// calculateProjectedCosts prices resources and merges their recommendations
+// into a spill store, which the caller must close. NDJSON output and the
+// interactive TUI render each resource as soon as it is priced, NDJSON
+// ending with a summary record, in which case rendered is true; for every
+// other output the caller renders the returned results.
+func calculateProjectedCosts(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng projectedCostStoreEngine,
+	resources []engine.ResourceDescriptor,
+	outputFormat string,
+) (*engine.CostResultStore, bool, error) {
+	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
+	switch {
+	case fmtType == engine.OutputNDJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		store, err := streamProjectedCosts(ctx, eng, resources, func(batch []engine.CostResult) error {
+			for _, r := range batch {
+				if encodeErr := encoder.Encode(r); encodeErr != nil {
+					return encodeErr
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, true, err
+		}
+		if err = writeStoreNDJSONSummary(cmd, store); err != nil {
+			_ = store.Close()
+			return nil, true, err
+		}
+		return store, true, nil
+
+	case fmtType == engine.OutputTable && tui.ResolveOutputMode() == tui.OutputModeInteractive:
+		store, err := runStreamingCostTUI(ctx, eng, resources)
+		return store, true, err
+
+	default:
+		store, err := streamProjectedCosts(ctx, eng, resources, nil)
+		return store, false, err
+	}
+}
+
+// calculateActualCosts queries actual costs and merges their recommendations
+// into a spill store, which the caller must close. NDJSON output of
+// ungrouped results renders each resource as soon as it is queried, ending
+// with a summary record, in which case rendered is true; for every other
+// output the caller renders the returned results.
+func calculateActualCosts(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng actualCostEngine,
+	request engine.ActualCostRequest,
+	outputFormat string,
+	estimateConfidence bool,
+) (*engine.CostResultStore, bool, error) {
+	recs := fetchRecommendationsForMerge(ctx, eng, request.Resources)
+	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
+	if fmtType != engine.OutputNDJSON || request.GroupBy != "" {
+		store, err := eng.StreamActualCostToStore(ctx, request, func(batch []engine.CostResult) error {
+			mergeRecommendations(ctx, recs, request.Resources, batch)
+			return nil
+		})
+		return store, false, err
+	}
+
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	store, err := eng.StreamActualCostToStore(ctx, request, func(batch []engine.CostResult) error {
+		mergeRecommendations(ctx, recs, request.Resources, batch)
+		return encodeActualCostNDJSON(encoder, batch[0], estimateConfidence)
+	})
+	if err != nil {
+		return nil, true, err
+	}
+	if err = writeStoreNDJSONSummary(cmd, store); err != nil {
+		_ = store.Close()
+		return nil, true, err
+	}
+	return store, true, nil
+}
+
+// encodeActualCostNDJSON writes result as a line of NDJSON actual cost
+// output, which shows confidence only with --estimate-confidence.
+func encodeActualCostNDJSON(encoder *json.Encoder, result engine.CostResult, estimateConfidence bool) error {
+	if !estimateConfidence {
+		result.Confidence = engine.ConfidenceUnknown
+	}
+	return encoder.Encode(result)
+}
+
+// writeNDJSONSummary ends NDJSON cost output with the summary record of
+// result, followed on stderr by any report of the failed plugin calls.
+func writeNDJSONSummary(cmd *cobra.Command, result *engine.CostResultWithErrors) error {
+	return encodeNDJSONSummary(cmd, engine.NewNDJSONSummary(result.Results, len(result.Errors)), result.ErrorReport())
+}
+
+// writeStoreNDJSONSummary is writeNDJSONSummary for results in a store.
+func writeStoreNDJSONSummary(cmd *cobra.Command, store *engine.CostResultStore) error {
+	summary, err := store.NDJSONSummary()
+	if err != nil {
+		return err
+	}
+	return encodeNDJSONSummary(cmd, summary, store.ErrorReport())
+}
+
+// encodeNDJSONSummary writes summary and then report on stderr.
+func encodeNDJSONSummary(cmd *cobra.Command, summary engine.NDJSONSummary, report *engine.ErrorReport) error {
+	if err := json.NewEncoder(cmd.OutOrStdout()).Encode(summary); err != nil {
+		return fmt.Errorf("encoding NDJSON summary: %w", err)
+	}
+	writePartialErrorReport(cmd, report)
+	return nil
+}
+
+// writeStoreNDJSON renders the results of store as NDJSON, one result per
+// line read a chunk at a time, ending with the summary record. actual
+// renders actual cost results, which show confidence only with
+// estimateConfidence.
+func writeStoreNDJSON(cmd *cobra.Command, store *engine.CostResultStore, actual, estimateConfidence bool) error {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	err := store.Results.Each(func(result engine.CostResult) error {
+		if actual {
+			return encodeActualCostNDJSON(encoder, result, estimateConfidence)
+		}
+		return encoder.Encode(result)
+	})
+	if err != nil {
+		return err
+	}
+	return writeStoreNDJSONSummary(cmd, store)
+}
+
+// streamProjectedCosts fetches recommendations up front and then prices
+// resources into a store, merging each resource's recommendations before it
+// is stored and handed to fn, which may be nil.
+func streamProjectedCosts(
+	ctx context.Context,
+	eng projectedCostStoreEngine,
+	resources []engine.ResourceDescriptor,
+	fn engine.ResultBatchFunc,
+) (*engine.CostResultStore, error) {
+	recs := fetchRecommendationsForMerge(ctx, eng, resources)
+	return eng.StreamProjectedCostToStore(ctx, resources, func(batch []engine.CostResult) error {
+		mergeRecommendations(ctx, recs, resources, batch)
+		if fn == nil {
+			return nil
+		}
+		return fn(batch)
+	})
+}
+
+// runStreamingCostTUI shows the interactive cost table while resources are
+// still being priced. If the user quits before pricing finishes, the
+// remaining work is cancelled and the results streamed so far are returned.
+func runStreamingCostTUI(
+	ctx context.Context,
+	eng projectedCostStoreEngine,
+	resources []engine.ResourceDescriptor,
+) (*engine.CostResultStore, error) {
+	streamCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	// The table shows every result, so they are held in memory as well.
+	var (
+		store    *engine.CostResultStore
+		streamed []engine.CostResult
+		fetchErr error
+		done     = make(chan struct{})
+	)
+	model := tui.NewCostViewModelWithLoading(ctx, func() ([]engine.CostResult, error) {
+		<-done
+		if fetchErr != nil {
+			return nil, fetchErr
+		}
+		return streamed, nil
+	}).WithExporter(costViewExporter(false, time.Now()))
+	p := tea.NewProgram(model)
+
+	go func() {
+		defer close(done)
+		store, fetchErr = streamProjectedCosts(streamCtx, eng, resources, func(batch []engine.CostResult) error {
+			streamed = append(streamed, batch...)
+			p.Send(tui.CostResultsMsg{Results: batch})
+			return nil
+		})
+	}()
+
+	_, runErr := p.Run()
+	cancel()
+	<-done
+	if runErr != nil {
+		if store != nil {
+			_ = store.Close()
+		}
+		return nil, fmt.Errorf("failed to run interactive TUI: %w", runErr)
+	}
+
+	if fetchErr != nil {
+		if errors.Is(fetchErr, context.Canceled) && ctx.Err() == nil {
+			partial := engine.NewCostResultStore(spillThreshold())
+			if err := partial.Results.Append(streamed...); err != nil {
+				_ = partial.Close()
+				return nil, err
+			}
+			return partial, nil
+		}
+		return nil, fetchErr
+	}
+	return store, nil
+}
+
+// spillThreshold returns the cost.spill_threshold of the global
+// configuration, or zero for the default.
+func spillThreshold() int {
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		return cfg.Cost.SpillThreshold
+	}
+	return 0
+}
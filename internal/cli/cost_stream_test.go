@@ -0,0 +1,245 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// streamingEngine emits each configured result as its own batch and checks
+// that nothing was rendered before the batch was emitted.
+type streamingEngine struct {
+	mockRecommendationFetcher
+
+	results []engine.CostResult
+	out     *bytes.Buffer
+	lines   []int
+}
+
+func (e *streamingEngine) StreamProjectedCostWithErrors(
+	_ context.Context,
+	_ []engine.ResourceDescriptor,
+	fn engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	for _, r := range e.results {
+		if fn != nil {
+			e.lines = append(e.lines, strings.Count(e.out.String(), "\n"))
+			if err := fn([]engine.CostResult{r}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return &engine.CostResultWithErrors{Results: e.results}, nil
+}
+
+func (e *streamingEngine) StreamProjectedCostToStore(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ResultBatchFunc,
+) (*engine.CostResultStore, error) {
+	store := engine.NewCostResultStore(1)
+	_, err := e.StreamProjectedCostWithErrors(ctx, resources, func(batch []engine.CostResult) error {
+		if fn != nil {
+			if err := fn(batch); err != nil {
+				return err
+			}
+		}
+		return store.Results.Append(batch...)
+	})
+	if err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// storedResults returns results in a store that spills past one result,
+// closed when the test ends.
+func storedResults(t *testing.T, results ...engine.CostResult) *costResults {
+	t.Helper()
+	store := engine.NewCostResultStore(1)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.Results.Append(results...))
+	return &costResults{CostResultStore: store}
+}
+
+// collected reads every result of store.
+func collected(t *testing.T, store *engine.CostResultStore) []engine.CostResult {
+	t.Helper()
+	results, err := store.Results.Slice(0, store.Results.Len())
+	require.NoError(t, err)
+	return results
+}
+
+func TestCalculateProjectedCosts_StreamsNDJSON(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	eng := &streamingEngine{
+		mockRecommendationFetcher: mockRecommendationFetcher{result: &engine.RecommendationsResult{
+			Recommendations: []engine.Recommendation{{ResourceID: "db", Type: "RIGHTSIZE"}},
+		}},
+		results: []engine.CostResult{{ResourceID: "web", Monthly: 10}, {ResourceID: "db", Monthly: 20}},
+		out:     &out,
+	}
+
+	store, rendered, err := calculateProjectedCosts(context.Background(), cmd, eng,
+		[]engine.ResourceDescriptor{{ID: "web"}, {ID: "db"}}, "ndjson")
+	require.NoError(t, err)
+	defer store.Close()
+	assert.True(t, rendered)
+	assert.Len(t, collected(t, store), 2)
+	assert.Equal(t, []int{0, 1}, eng.lines, "each line is written as its resource is emitted")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	var db engine.CostResult
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &db))
+	assert.Equal(t, "db", db.ResourceID)
+	require.Len(t, db.Recommendations, 1, "recommendations are merged before streaming")
+
+	var summary engine.NDJSONSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &summary))
+	assert.Equal(t, engine.NDJSONSummary{
+		Type: "summary", Resources: 2, TotalMonthly: 30, Currency: "USD",
+	}, summary)
+}
+
+func TestCalculateProjectedCosts_TableIsRenderedByCaller(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	eng := &streamingEngine{results: []engine.CostResult{{ResourceID: "web"}}, out: &out}
+
+	store, rendered, err := calculateProjectedCosts(context.Background(), cmd, eng,
+		[]engine.ResourceDescriptor{{ID: "web"}}, "table")
+	require.NoError(t, err)
+	defer store.Close()
+	assert.False(t, rendered)
+	assert.Len(t, collected(t, store), 1)
+	assert.Empty(t, out.String())
+}
+
+// streamingActualEngine emits each configured result as it would be queried
+// and checks that nothing was rendered before the result was emitted.
+type streamingActualEngine struct {
+	mockRecommendationFetcher
+
+	results []engine.CostResult
+	errors  []engine.ErrorDetail
+	out     *bytes.Buffer
+	lines   []int
+}
+
+func (e *streamingActualEngine) StreamActualCostToStore(
+	_ context.Context,
+	_ engine.ActualCostRequest,
+	fn engine.ResultBatchFunc,
+) (*engine.CostResultStore, error) {
+	store := engine.NewCostResultStore(1)
+	store.Errors = e.errors
+	for _, r := range e.results {
+		batch := []engine.CostResult{r}
+		if fn != nil {
+			e.lines = append(e.lines, strings.Count(e.out.String(), "\n"))
+			if err := fn(batch); err != nil {
+				_ = store.Close()
+				return nil, err
+			}
+		}
+		if err := store.Results.Append(batch...); err != nil {
+			_ = store.Close()
+			return nil, err
+		}
+	}
+	return store, nil
+}
+
+func TestCalculateActualCosts_StreamsNDJSON(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+
+	eng := &streamingActualEngine{
+		mockRecommendationFetcher: mockRecommendationFetcher{result: &engine.RecommendationsResult{
+			Recommendations: []engine.Recommendation{{ResourceID: "db", Type: "RIGHTSIZE"}},
+		}},
+		results: []engine.CostResult{
+			{ResourceID: "web", TotalCost: 10, Currency: "EUR", Confidence: engine.ConfidenceHigh},
+			{ResourceID: "db", TotalCost: 20, Currency: "EUR"},
+		},
+		errors: []engine.ErrorDetail{{ResourceID: "cache", PluginName: "aws", Error: errors.New("timeout")}},
+		out:    &out,
+	}
+	request := engine.ActualCostRequest{Resources: []engine.ResourceDescriptor{{ID: "web"}, {ID: "db"}}}
+
+	store, rendered, err := calculateActualCosts(context.Background(), cmd, eng, request, "ndjson", false)
+	require.NoError(t, err)
+	defer store.Close()
+	assert.True(t, rendered)
+	assert.Equal(t, []int{0, 1}, eng.lines, "each line is written as its resource is emitted")
+	stored := collected(t, store)
+	require.Len(t, stored[1].Recommendations, 1, "the stored results get recommendations too")
+	assert.Equal(t, engine.ConfidenceHigh, stored[0].Confidence, "only the output hides confidence")
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	var web, db engine.CostResult
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &web))
+	assert.Empty(t, web.Confidence, "confidence is shown only with --estimate-confidence")
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &db))
+	require.Len(t, db.Recommendations, 1, "recommendations are merged before streaming")
+
+	var summary engine.NDJSONSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &summary))
+	assert.Equal(t, engine.NDJSONSummary{
+		Type: "summary", Resources: 2, TotalCost: 30, Currency: "EUR", Errors: 1,
+	}, summary)
+}
+
+func TestCalculateActualCosts_GroupedIsRenderedByCaller(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	eng := &streamingActualEngine{results: []engine.CostResult{{ResourceID: "web"}}, out: &out}
+
+	request := engine.ActualCostRequest{Resources: []engine.ResourceDescriptor{{ID: "web"}}, GroupBy: "daily"}
+	store, rendered, err := calculateActualCosts(context.Background(), cmd, eng, request, "ndjson", false)
+	require.NoError(t, err)
+	defer store.Close()
+	assert.False(t, rendered)
+	assert.Len(t, collected(t, store), 1)
+	assert.Empty(t, out.String())
+}
+
+func TestWriteStoreNDJSON(t *testing.T) {
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	results := storedResults(t,
+		engine.CostResult{ResourceID: "web", TotalCost: 10, Currency: "EUR", Confidence: engine.ConfidenceHigh},
+		engine.CostResult{ResourceID: "db", TotalCost: 20, Currency: "EUR"},
+	)
+	require.True(t, results.Results.Spilled())
+
+	require.NoError(t, writeStoreNDJSON(cmd, results.CostResultStore, true, false))
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 3)
+	var web engine.CostResult
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &web))
+	assert.Equal(t, "web", web.ResourceID)
+	assert.Empty(t, web.Confidence, "confidence is shown only with --estimate-confidence")
+	var summary engine.NDJSONSummary
+	require.NoError(t, json.Unmarshal([]byte(lines[2]), &summary))
+	assert.Equal(t, engine.NDJSONSummary{Type: "summary", Resources: 2, TotalCost: 30, Currency: "EUR"}, summary)
+}
@@ -0,0 +1,299 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/cli/pagination"
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Defaults of 'cost top'.
+const (
+	defaultTopPeriod = "30d"
+	defaultTopLimit  = 10
+)
+
+// costTopParams holds the parameters for the cost top command execution.
+type costTopParams struct {
+	by       string
+	period   string
+	output   string
+	sort     string
+	limit    int
+	page     int
+	pageSize int
+	offset   int
+}
+
+// costTopJSON is the output of 'cost top --output json'.
+type costTopJSON struct {
+	*engine.TopSpendersReport
+	Pagination *pagination.PaginationMeta `json:"pagination,omitempty"`
+}
+
+// NewCostTopCmd creates the "top" subcommand, which ranks the biggest cost
+// contributors of the recorded projections over a period and compares each
+// with the previous period of equal length.
+func NewCostTopCmd() *cobra.Command {
+	var params costTopParams
+
+	cmd := &cobra.Command{
+		Use:   "top",
+		Short: "List the biggest cost contributors and their change from the previous period",
+		Long: `List the biggest cost contributors over the last --period, with the change
+of each versus the previous period of equal length.
+
+Costs come from the projections recorded with 'finfocus cost projected --stack
+<name> --record'. As in 'report org', each projection is weighted by how long
+it was in effect during a period, so amounts are projected monthly costs
+averaged over the period. All recorded stacks are included unless --stack
+names one.
+
+Contributors are grouped by resource, by service ("provider:service", e.g.
+aws:ec2), or by the value of a tag with tag:<key>; resources without the tag
+are reported as "(untagged)".
+
+Results are sorted by cost, largest first, and limited to the top 10. Sort
+with --sort field[:asc|desc] on cost, change, changePercent, or key, and page
+through the rest with --limit/--offset or --page/--page-size.`,
+		Example: `  # Top 10 resources over the last 30 days
+  finfocus cost top
+
+  # Services with the largest increase over the last week
+  finfocus cost top --by service --period 7d --sort change
+
+  # Spend per team for one stack, as JSON
+  finfocus cost top --by tag:team --stack prod --output json
+
+  # Second page of 20 resources
+  finfocus cost top --page 2 --page-size 20`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostTop(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.by, "by", engine.TopSpendersByResource,
+		"Group contributors by: resource, service, or tag:<key>")
+	cmd.Flags().StringVar(&params.period, "period", defaultTopPeriod,
+		"Length of the period ending now (e.g. 7d, 2w, 12h)")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	cmd.Flags().StringVar(&params.sort, "sort", "",
+		"Sort by field[:asc|desc]: cost, change, changePercent, or key (default: cost:desc)")
+	cmd.Flags().IntVar(&params.limit, "limit", defaultTopLimit,
+		"Maximum number of contributors to return (0 = unlimited)")
+	cmd.Flags().IntVar(&params.page, "page", 0,
+		"Page number for page-based pagination (1-indexed, 0 = disabled)")
+	cmd.Flags().IntVar(&params.pageSize, "page-size", 0,
+		"Number of items per page (requires --page)")
+	cmd.Flags().IntVar(&params.offset, "offset", 0,
+		"Number of items to skip for offset-based pagination")
+
+	return cmd
+}
+
+// executeCostTop loads the projection history, ranks the contributors of
+// the period, and renders the requested page.
+func executeCostTop(cmd *cobra.Command, params costTopParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	if params.output != outputFormatTable && params.output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", params.output)
+	}
+	if err := engine.ValidateTopSpendersBy(params.by); err != nil {
+		return fmt.Errorf("invalid --by: %w", err)
+	}
+	period, err := parseLookbackPeriod(params.period)
+	if err != nil {
+		return fmt.Errorf("invalid --period: %w", err)
+	}
+	// The default --limit gives the top N; page-based pagination sets its
+	// own page size unless --limit is given explicitly.
+	if params.page > 0 && !cmd.Flags().Changed("limit") {
+		params.limit = 0
+	}
+	paginationParams := pagination.PaginationParams{
+		Limit: params.limit, Offset: params.offset, Page: params.page, PageSize: params.pageSize,
+	}
+	if validationErr := paginationParams.Validate(); validationErr != nil {
+		return fmt.Errorf("invalid pagination parameters: %w", validationErr)
+	}
+
+	store := config.NewProjectionHistoryStore("")
+	if loadErr := store.Load(); loadErr != nil {
+		return fmt.Errorf("loading projection history: %w", loadErr)
+	}
+
+	to := time.Now().UTC()
+	report := buildTopSpenders(store, getStackFlag(cmd), params.by, to.Add(-period), to)
+	report.Spenders, err = sortTopSpenders(report.Spenders, params.sort)
+	if err != nil {
+		return err
+	}
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "cost_top").
+		Str("by", params.by).Int("spender_count", len(report.Spenders)).
+		Float64("total_monthly", report.TotalMonthly).Msg("top spenders complete")
+
+	out := costTopJSON{TopSpendersReport: report}
+	total := len(report.Spenders)
+	if paginationParams.IsEnabled() {
+		out.Spenders = applyPaginationWindow(paginationParams, report.Spenders)
+		meta := pagination.NewPaginationMeta(paginationParams, total)
+		out.Pagination = &meta
+	}
+
+	if params.output == outputFormatJSON {
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if encodeErr := encoder.Encode(out); encodeErr != nil {
+			return fmt.Errorf("encoding top spenders JSON: %w", encodeErr)
+		}
+		return nil
+	}
+	return renderTopSpendersTable(cmd.OutOrStdout(), out, total)
+}
+
+// parseLookbackPeriod parses a period length given in days ("30d"), weeks
+// ("2w"), or as a Go duration ("12h").
+func parseLookbackPeriod(period string) (time.Duration, error) {
+	const day = 24 * time.Hour
+	var unit time.Duration
+	switch {
+	case strings.HasSuffix(period, "d"):
+		unit = day
+	case strings.HasSuffix(period, "w"):
+		unit = 7 * day //nolint:mnd // Days in a week.
+	}
+
+	length, err := time.ParseDuration(period)
+	if unit != 0 {
+		var n int
+		n, err = strconv.Atoi(period[:len(period)-1])
+		length = time.Duration(n) * unit
+	}
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a period such as 30d, 2w, or 12h", period)
+	}
+	if length <= 0 {
+		return 0, fmt.Errorf("period must be positive, got %q", period)
+	}
+	return length, nil
+}
+
+// buildTopSpenders collects the snapshots in effect during the period and
+// the previous period of equal length for stack, or for every stack in the
+// store when stack is empty, and ranks them.
+func buildTopSpenders(
+	store *config.ProjectionHistoryStore,
+	stack, by string,
+	from, to time.Time,
+) *engine.TopSpendersReport {
+	stacks := store.Stacks()
+	if stack != "" {
+		stacks = []string{stack}
+	}
+	previousFrom := engine.PreviousPeriodStart(from, to)
+	snapshots := make(map[string][]config.ProjectionSnapshot)
+	for _, name := range stacks {
+		if inEffect := store.SnapshotsBetween(name, previousFrom, to); len(inEffect) > 0 {
+			snapshots[name] = inEffect
+		}
+	}
+	return engine.BuildTopSpenders(engine.TopSpendersInput{By: by, From: from, To: to, Snapshots: snapshots})
+}
+
+// sortTopSpenders sorts spenders by a field[:asc|desc] expression; an empty
+// expression keeps the report order, largest cost first.
+func sortTopSpenders(spenders []engine.TopSpender, expr string) ([]engine.TopSpender, error) {
+	if expr == "" {
+		return spenders, nil
+	}
+	field, order, err := pagination.ParseSortExpression(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sort expression: %w", err)
+	}
+
+	var less func(a, b engine.TopSpender) bool
+	switch field {
+	case "cost":
+		less = func(a, b engine.TopSpender) bool { return a.Monthly < b.Monthly }
+	case "change":
+		less = func(a, b engine.TopSpender) bool { return a.Change < b.Change }
+	case "changePercent":
+		less = func(a, b engine.TopSpender) bool { return a.ChangePercent() < b.ChangePercent() }
+	case "key":
+		less = func(a, b engine.TopSpender) bool { return a.Key < b.Key }
+	default:
+		return nil, fmt.Errorf("invalid sort field: %q (valid fields: change, changePercent, cost, key)", field)
+	}
+
+	if order == pagination.SortOrderDesc {
+		less = pipeline.Descending(less)
+	}
+	return pipeline.Sort(less)(spenders), nil
+}
+
+// renderTopSpendersTable renders the ranked contributors with their change,
+// followed by the totals and, when paginated, the position in the results.
+func renderTopSpendersTable(w io.Writer, out costTopJSON, total int) error {
+	report := out.TopSpendersReport
+	fmt.Fprintf(w, "Top spenders by %s, %s to %s (vs %s to %s)\n\n", report.By,
+		report.From.Format("2006-01-02"), report.To.Format("2006-01-02"),
+		report.PreviousFrom.Format("2006-01-02"), report.From.Format("2006-01-02"))
+
+	if total == 0 {
+		fmt.Fprintln(w, "No projections were recorded for this period; "+
+			"record them with 'finfocus cost projected --stack <name> --record'.")
+		return nil
+	}
+
+	first := 1
+	if out.Pagination != nil {
+		first = (out.Pagination.CurrentPage-1)*out.Pagination.PageSize + 1
+	}
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "RANK\tKEY\tMONTHLY\tPREVIOUS\tCHANGE\tCHANGE %")
+	for i, s := range out.Spenders {
+		fmt.Fprintf(tw, "%d\t%s\t%.2f\t%.2f\t%+.2f\t%s\n",
+			first+i, s.Key, s.Monthly, s.PreviousMonthly, s.Change, formatTopChangePercent(s))
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTotal: %.2f %s/month (%+.2f vs previous period)\n",
+		report.TotalMonthly, report.Currency, report.TotalMonthly-report.PreviousTotalMonthly)
+	if len(out.Spenders) < total {
+		fmt.Fprintf(w, "Showing %d of %d contributors; use --limit, --offset, or --page for more.\n",
+			len(out.Spenders), total)
+	}
+	if report.MixedCurrencies {
+		fmt.Fprintln(w, "Warning: projections use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// formatTopChangePercent formats the change of a contributor as a
+// percentage, or "new" when it had no cost in the previous period.
+func formatTopChangePercent(s engine.TopSpender) string {
+	switch {
+	case s.New:
+		return "new"
+	case s.PreviousMonthly == 0:
+		return "-"
+	default:
+		return fmt.Sprintf("%+.1f%%", s.ChangePercent())
+	}
+}
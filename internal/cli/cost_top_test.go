@@ -0,0 +1,148 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// seedTopHistory records a projection 60 days ago and a larger one 10 days
+// ago under a temporary FINFOCUS_HOME.
+func seedTopHistory(t *testing.T) {
+	t.Helper()
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	now := time.Now().UTC()
+	store := config.NewProjectionHistoryStore("")
+	require.NoError(t, store.RecordSnapshot("prod", config.ProjectionSnapshot{
+		RecordedAt: now.AddDate(0, 0, -60),
+		Resources: map[string]config.ProjectedResourceRecord{
+			"web":    {ResourceType: "aws:ec2/instance:Instance", Monthly: 90, Currency: "USD"},
+			"bucket": {ResourceType: "aws:s3/bucket:Bucket", Monthly: 30, Currency: "USD"},
+		},
+	}))
+	require.NoError(t, store.RecordSnapshot("prod", config.ProjectionSnapshot{
+		RecordedAt: now.AddDate(0, 0, -10),
+		Resources: map[string]config.ProjectedResourceRecord{
+			"web":    {ResourceType: "aws:ec2/instance:Instance", Monthly: 180, Currency: "USD"},
+			"bucket": {ResourceType: "aws:s3/bucket:Bucket", Monthly: 30, Currency: "USD"},
+			"db":     {ResourceType: "aws:rds/instance:Instance", Monthly: 60, Currency: "USD"},
+		},
+	}))
+	require.NoError(t, store.RecordSnapshot("dev", config.ProjectionSnapshot{
+		RecordedAt: now.AddDate(0, 0, -60),
+		Resources: map[string]config.ProjectedResourceRecord{
+			"cache": {ResourceType: "aws:elasticache/cluster:Cluster", Monthly: 15, Currency: "USD"},
+		},
+	}))
+	require.NoError(t, store.Save())
+}
+
+func runCostTop(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := newCostCmd()
+	cmd.SetContext(context.Background())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(append([]string{"top"}, args...))
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestCostTop_Table(t *testing.T) {
+	seedTopHistory(t)
+
+	out, err := runCostTop(t, "--by", "service")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Top spenders by service")
+	assert.Contains(t, out, "RANK")
+	assert.Contains(t, out, "aws:ec2")
+	assert.Contains(t, out, "+33.3%", "web costs 120 averaged over the period, up from 90")
+	assert.Contains(t, out, "new")
+	assert.Contains(t, out, "vs previous period")
+}
+
+func TestCostTop_JSONSortAndPaginate(t *testing.T) {
+	seedTopHistory(t)
+
+	out, err := runCostTop(t, "--output", "json", "--sort", "change:asc", "--limit", "2", "--offset", "1")
+	require.NoError(t, err)
+
+	var got costTopJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	require.NotNil(t, got.Pagination)
+	assert.Equal(t, 4, got.Pagination.TotalItems)
+	require.Len(t, got.Spenders, 2)
+	assert.LessOrEqual(t, got.Spenders[0].Change, got.Spenders[1].Change)
+	assert.Equal(t, engine.TopSpendersByResource, got.By)
+}
+
+func TestCostTop_StackFilterAndPages(t *testing.T) {
+	seedTopHistory(t)
+
+	out, err := runCostTop(t, "--stack", "dev", "--output", "json", "--page", "1", "--page-size", "5")
+	require.NoError(t, err)
+	var got costTopJSON
+	require.NoError(t, json.Unmarshal([]byte(out), &got))
+	require.Len(t, got.Spenders, 1, "the default --limit does not apply to pages")
+	assert.Equal(t, "cache", got.Spenders[0].Key)
+}
+
+func TestCostTop_NoHistory(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	out, err := runCostTop(t)
+	require.NoError(t, err)
+	assert.Contains(t, out, "No projections were recorded for this period")
+}
+
+func TestCostTop_InvalidInput(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"output", []string{"--output", "csv"}, `unsupported output format "csv"`},
+		{"by", []string{"--by", "provider"}, "invalid --by"},
+		{"period", []string{"--period", "monthly"}, "invalid --period"},
+		{"negative period", []string{"--period", "-3d"}, "period must be positive"},
+		{"sort", []string{"--sort", "savings"}, `invalid sort field: "savings"`},
+		{"pagination", []string{"--page", "1"}, "invalid pagination parameters"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := runCostTop(t, tt.args...)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestParseLookbackPeriod(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+	}{
+		{"30d", 30 * 24 * time.Hour},
+		{"2w", 14 * 24 * time.Hour},
+		{"12h", 12 * time.Hour},
+	}
+	for _, tt := range tests {
+		got, err := parseLookbackPeriod(tt.in)
+		require.NoError(t, err, tt.in)
+		assert.Equal(t, tt.want, got, tt.in)
+	}
+	for _, in := range []string{"", "d", "0d", "1.5w"} {
+		_, err := parseLookbackPeriod(in)
+		assert.Error(t, err, in)
+	}
+}
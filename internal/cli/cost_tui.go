@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"time"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
 
+	"github.com/rshade/finfocus/internal/cli/pagination"
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/tui"
@@ -16,11 +18,13 @@ import (
 // RenderCostOutput routes the cost results to the appropriate rendering function
 // based on the detected output mode (Plain, Styled, or Interactive).
 // The context parameter enables trace ID propagation for contextual logging.
+// A non-nil meta describes the page the results are, and is included in JSON output.
 func RenderCostOutput(
 	ctx context.Context,
 	cmd *cobra.Command,
 	outputFormat string,
 	resultWithErrors *engine.CostResultWithErrors,
+	meta *pagination.PaginationMeta,
 ) error {
 	// 1. Determine and validate output format.
 	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
@@ -33,13 +37,19 @@ func RenderCostOutput(
 	// 2. If output format is explicitly structured (JSON/NDJSON), bypass TUI completely.
 	// This satisfies FR-004: Maintain output for --output json/ndjson.
 	if fmtType == engine.OutputJSON || fmtType == engine.OutputNDJSON {
-		return engine.RenderResults(cmd.OutOrStdout(), fmtType, resultWithErrors.Results)
+		if err := renderStructuredCostOutput(cmd.OutOrStdout(), fmtType, resultWithErrors.Results, meta); err != nil {
+			return err
+		}
+		if fmtType == engine.OutputNDJSON {
+			return writeNDJSONSummary(cmd, resultWithErrors)
+		}
+		writePartialErrorReport(cmd, resultWithErrors.ErrorReport())
+		return nil
 	}
 
-	// 2. Detect the appropriate output mode for the terminal.
-	// We rely on standard detection (flags passed as false for now, as they aren't global yet).
-	// Future improvement: plumb --no-color / --plain flags if added to CLI.
-	mode := tui.DetectOutputMode(false, false, false)
+	// 2. Detect the appropriate output mode for the terminal, under the
+	// global --no-color and --plain controls.
+	mode := tui.ResolveOutputMode()
 
 	// 3. Route to specific renderer
 	switch mode {
@@ -59,6 +69,7 @@ func RenderCostOutput(
 
 // RenderActualCostOutput routes actual cost results to the appropriate rendering function.
 // The context parameter enables trace ID propagation for contextual logging.
+// A non-nil meta describes the page the results are, and is included in JSON output.
 func RenderActualCostOutput(
 	ctx context.Context,
 	cmd *cobra.Command,
@@ -66,6 +77,7 @@ func RenderActualCostOutput(
 	resultWithErrors *engine.CostResultWithErrors,
 	groupBy string,
 	estimateConfidence bool,
+	meta *pagination.PaginationMeta,
 ) error {
 	fmtType := engine.OutputFormat(config.GetOutputFormat(outputFormat))
 
@@ -76,10 +88,26 @@ func RenderActualCostOutput(
 
 	if fmtType == engine.OutputJSON || fmtType == engine.OutputNDJSON {
 		// Use existing logic for JSON/NDJSON (handling aggregation inside)
-		return renderActualCostOutput(cmd.OutOrStdout(), fmtType, resultWithErrors.Results, groupBy, estimateConfidence)
+		var err error
+		if meta != nil && fmtType == engine.OutputJSON {
+			err = renderActualCostPageJSON(cmd.OutOrStdout(), resultWithErrors.Results, estimateConfidence, meta)
+		} else {
+			err = renderActualCostOutput(
+				cmd.OutOrStdout(), fmtType, resultWithErrors.Results, groupBy, estimateConfidence,
+			)
+		}
+		if err != nil {
+			return err
+		}
+		// Time-based groupings render aggregations, not results to summarize.
+		if fmtType == engine.OutputNDJSON && !engine.GroupBy(groupBy).IsTimeBasedGrouping() {
+			return writeNDJSONSummary(cmd, resultWithErrors)
+		}
+		writePartialErrorReport(cmd, resultWithErrors.ErrorReport())
+		return nil
 	}
 
-	mode := tui.DetectOutputMode(false, false, false)
+	mode := tui.ResolveOutputMode()
 	switch mode {
 	case tui.OutputModeInteractive:
 		return runInteractiveActualCostTUI(ctx, resultWithErrors, engine.GroupBy(groupBy))
@@ -97,8 +125,25 @@ func RenderActualCostOutput(
 	}
 }
 
+// renderStructuredCostOutput renders projected cost results as JSON or
+// NDJSON. JSON output of a page carries its pagination metadata; NDJSON stays
+// one record per line.
+func renderStructuredCostOutput(
+	w io.Writer,
+	fmtType engine.OutputFormat,
+	results []engine.CostResult,
+	meta *pagination.PaginationMeta,
+) error {
+	if meta != nil && fmtType == engine.OutputJSON {
+		return renderProjectedCostPageJSON(w, results, meta)
+	}
+	return engine.RenderResults(w, fmtType, results)
+}
+
 func runInteractiveTUI(ctx context.Context, resultWithErrors *engine.CostResultWithErrors) error {
-	p := tea.NewProgram(tui.NewCostViewModel(ctx, resultWithErrors.Results))
+	p := tea.NewProgram(
+		tui.NewCostViewModel(ctx, resultWithErrors.Results).WithExporter(costViewExporter(false, time.Now())),
+	)
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run interactive TUI: %w", err)
 	}
@@ -110,7 +155,10 @@ func runInteractiveActualCostTUI(
 	resultWithErrors *engine.CostResultWithErrors,
 	groupBy engine.GroupBy,
 ) error {
-	p := tea.NewProgram(tui.NewCostViewModelFromActual(ctx, resultWithErrors.Results, groupBy))
+	p := tea.NewProgram(
+		tui.NewCostViewModelFromActual(ctx, resultWithErrors.Results, groupBy).
+			WithExporter(costViewExporter(true, time.Now())),
+	)
 	if _, err := p.Run(); err != nil {
 		return fmt.Errorf("failed to run interactive TUI: %w", err)
 	}
@@ -0,0 +1,69 @@
+package cli
+
+import (
+	"context"
+	"slices"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// usageProfileEngine scales the projected costs of the wrapped engine to a
+// usage profile from the cost.profiles configuration.
+type usageProfileEngine struct {
+	projectedCostStoreEngine
+
+	name    string
+	profile config.UsageProfile
+}
+
+// lookupUsageProfile returns the usage profile called name from the global
+// configuration.
+func lookupUsageProfile(name string) (config.UsageProfile, error) {
+	var profiles config.UsageProfiles
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		profiles = cfg.Cost.Profiles
+	}
+	return profiles.Lookup(name)
+}
+
+// StreamProjectedCostWithErrors scales each batch before handing it to fn,
+// and the returned results.
+func (e usageProfileEngine) StreamProjectedCostWithErrors(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	var scaled engine.ProjectedResultFunc
+	if fn != nil {
+		scaled = func(batch []engine.CostResult) error {
+			// The batch may share results with the returned ones, which are
+			// scaled separately, so a copy is scaled.
+			batch = slices.Clone(batch)
+			engine.ApplyUsageProfile(batch, e.name, e.profile)
+			return fn(batch)
+		}
+	}
+	result, err := e.projectedCostStoreEngine.StreamProjectedCostWithErrors(ctx, resources, scaled)
+	if result != nil {
+		engine.ApplyUsageProfile(result.Results, e.name, e.profile)
+	}
+	return result, err
+}
+
+// StreamProjectedCostToStore scales each batch before it is handed to fn and
+// stored.
+func (e usageProfileEngine) StreamProjectedCostToStore(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ResultBatchFunc,
+) (*engine.CostResultStore, error) {
+	return e.projectedCostStoreEngine.StreamProjectedCostToStore(ctx, resources,
+		func(batch []engine.CostResult) error {
+			engine.ApplyUsageProfile(batch, e.name, e.profile)
+			if fn == nil {
+				return nil
+			}
+			return fn(batch)
+		})
+}
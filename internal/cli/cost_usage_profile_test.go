@@ -0,0 +1,90 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// hourlyTestEngine prices every resource at 0.1 an hour and streams the
+// results it returns.
+type hourlyTestEngine struct {
+	mockRecommendationFetcher
+}
+
+func (e *hourlyTestEngine) StreamProjectedCostWithErrors(
+	_ context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	result := &engine.CostResultWithErrors{}
+	for _, r := range resources {
+		result.Results = append(result.Results,
+			engine.CostResult{ResourceID: r.ID, ResourceType: r.Type, Currency: "USD", Monthly: 73, Hourly: 0.1})
+	}
+	if fn != nil {
+		if err := fn(result.Results); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+func (e *hourlyTestEngine) StreamProjectedCostToStore(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	fn engine.ResultBatchFunc,
+) (*engine.CostResultStore, error) {
+	store := engine.NewCostResultStore(0)
+	_, err := e.StreamProjectedCostWithErrors(ctx, resources, func(batch []engine.CostResult) error {
+		if err := fn(batch); err != nil {
+			return err
+		}
+		return store.Results.Append(batch...)
+	})
+	if err != nil {
+		_ = store.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+func TestUsageProfileEngine(t *testing.T) {
+	eng := usageProfileEngine{
+		projectedCostStoreEngine: &hourlyTestEngine{},
+		name:                     "dev",
+		profile:                  config.UsageProfile{HoursPerDay: 12},
+	}
+	resources := []engine.ResourceDescriptor{{ID: "web", Type: "aws:ec2/instance:Instance"}}
+
+	var streamed []engine.CostResult
+	result, err := eng.StreamProjectedCostWithErrors(context.Background(), resources,
+		func(batch []engine.CostResult) error {
+			streamed = append(streamed, batch...)
+			return nil
+		})
+	require.NoError(t, err)
+
+	require.Len(t, streamed, 1)
+	assert.InDelta(t, 36.5, streamed[0].Monthly, 0.001)
+	require.Len(t, result.Results, 1)
+	assert.InDelta(t, 36.5, result.Results[0].Monthly, 0.001, "streamed and returned results are scaled once")
+	assert.Contains(t, result.Results[0].Notes, "Usage profile dev")
+
+	store, err := eng.StreamProjectedCostToStore(context.Background(), resources, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	stored := collected(t, store)
+	require.Len(t, stored, 1)
+	assert.InDelta(t, 36.5, stored[0].Monthly, 0.001, "stored results are scaled")
+}
+
+func TestLookupUsageProfile(t *testing.T) {
+	_, err := lookupUsageProfile("no-such-profile")
+	require.ErrorIs(t, err, config.ErrUnknownUsageProfile)
+}
@@ -0,0 +1,366 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// variancePeriodLayout is the --period format (calendar month).
+const variancePeriodLayout = "2006-01"
+
+// errNoProjectionRecorded is returned when no projection snapshot exists for the stack and period.
+var errNoProjectionRecorded = errors.New("no projection recorded")
+
+// costVarianceParams holds the parameters for the variance command execution.
+type costVarianceParams struct {
+	period    string
+	threshold float64
+	adapter   string
+	output    string
+}
+
+// NewCostVarianceCmd creates the "variance" subcommand, which compares the
+// projected costs recorded for a stack (see 'cost projected --record') with
+// actual spend over a calendar month and flags resources whose actual spend
+// exceeds the projection by more than a threshold.
+func NewCostVarianceCmd() *cobra.Command {
+	var params costVarianceParams
+
+	cmd := &cobra.Command{
+		Use:   "variance",
+		Short: "Compare projected costs with actual spend for a stack",
+		Long: `Compare the projected costs recorded for a stack with actual spend over a month.
+
+Projections are recorded with 'finfocus cost projected --stack <name> --record',
+typically at deploy time. The variance report uses the most recent projection
+recorded before the end of the period, fetches actual costs for the same
+resources, and reports the variance per resource. For the current month the
+projection is prorated to the days elapsed so far.
+
+Resources whose actual spend exceeds the projection by more than --threshold
+percent, or that incurred spend with no projected cost, are flagged.`,
+		Example: `  # Variance for the current month
+  finfocus cost variance --stack production
+
+  # Variance for January 2026, flagging overruns above 20%
+  finfocus cost variance --stack production --period 2026-01 --threshold 20
+
+  # Output as JSON
+  finfocus cost variance --stack production --period 2026-01 --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeCostVariance(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.period, "period", "",
+		"Month to report on (YYYY-MM, defaults to the current month)")
+	cmd.Flags().Float64Var(&params.threshold, "threshold", engine.DefaultVarianceThreshold,
+		"Flag resources whose actual spend exceeds the projection by more than this percentage")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table, json, or ndjson")
+
+	return cmd
+}
+
+// executeCostVariance loads the recorded projection for the stack, fetches
+// actual costs for the period, and renders the variance report.
+func executeCostVariance(cmd *cobra.Command, params costVarianceParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	stack := getStackFlag(cmd)
+	if stack == "" {
+		return errors.New("--stack is required")
+	}
+	if params.threshold < 0 {
+		return fmt.Errorf("--threshold must be non-negative, got %g", params.threshold)
+	}
+	switch params.output {
+	case outputFormatTable, outputFormatJSON, outputFormatNDJSON:
+	default:
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+
+	from, to, monthHours, err := resolveVariancePeriod(params.period, time.Now())
+	if err != nil {
+		return err
+	}
+
+	store := config.NewProjectionHistoryStore("")
+	if loadErr := store.Load(); loadErr != nil {
+		return fmt.Errorf("loading projection history: %w", loadErr)
+	}
+	snapshot, ok := store.SnapshotAt(stack, to)
+	if !ok {
+		return fmt.Errorf("%w for stack %q before %s; run 'finfocus cost projected --stack %s --record' first",
+			errNoProjectionRecorded, stack, to.Format("2006-01-02"), stack)
+	}
+
+	audit := newAuditContext(ctx, "cost variance", map[string]string{
+		"stack":  stack,
+		"period": from.Format(variancePeriodLayout),
+	})
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	report, err := buildVarianceReport(ctx, engine.New(clients, nil), varianceRequest{
+		stack:      stack,
+		snapshot:   snapshot,
+		from:       from,
+		to:         to,
+		monthHours: monthHours,
+		threshold:  params.threshold,
+		adapter:    params.adapter,
+	})
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "cost_variance").
+		Str("stack", stack).Int("resource_count", len(report.Resources)).
+		Int("exceeded_count", report.ExceededCount).
+		Dur("duration_ms", time.Since(audit.start)).Msg("variance report complete")
+
+	audit.logSuccess(ctx, len(report.Resources), report.TotalActual)
+	return renderVarianceReport(cmd, params.output, report)
+}
+
+// varianceRequest carries the inputs for buildVarianceReport.
+type varianceRequest struct {
+	stack      string
+	snapshot   *config.ProjectionSnapshot
+	from, to   time.Time
+	monthHours float64
+	threshold  float64
+	adapter    string
+}
+
+// buildVarianceReport fetches actual costs for the snapshot's resources and
+// computes the variance against the recorded projection.
+func buildVarianceReport(
+	ctx context.Context,
+	fetcher actualCostFetcher,
+	req varianceRequest,
+) (*engine.VarianceReport, error) {
+	ids := make([]string, 0, len(req.snapshot.Resources))
+	for id := range req.snapshot.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	resources := make([]engine.ResourceDescriptor, 0, len(ids))
+	projected := make([]engine.CostResult, 0, len(ids))
+	for _, id := range ids {
+		record := req.snapshot.Resources[id]
+		resources = append(resources, engine.ResourceDescriptor{
+			ID: id, Type: record.ResourceType, Provider: record.Provider,
+		})
+		projected = append(projected, engine.CostResult{
+			ResourceID: id, ResourceType: record.ResourceType,
+			Monthly: record.Monthly, Currency: record.Currency,
+		})
+	}
+
+	actual, err := fetcher.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+		Resources: resources,
+		From:      req.from,
+		To:        req.to,
+		Adapter:   req.adapter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching actual costs: %w", err)
+	}
+
+	var actualResults []engine.CostResult
+	if actual != nil {
+		actualResults = actual.Results
+	}
+
+	return engine.CalculateVariance(engine.VarianceInput{
+		Stack:       req.stack,
+		Period:      req.from.Format(variancePeriodLayout),
+		From:        req.from,
+		To:          req.to,
+		ProjectedAt: req.snapshot.RecordedAt,
+		Threshold:   req.threshold,
+		MonthHours:  req.monthHours,
+		Projected:   projected,
+		Actual:      actualResults,
+	}), nil
+}
+
+// resolveVariancePeriod returns the [from, to) window for a YYYY-MM period
+// (the current month when empty) and the number of hours in the full month.
+// For the current month, to is capped at now.
+func resolveVariancePeriod(period string, now time.Time) (time.Time, time.Time, float64, error) {
+	now = now.UTC()
+	start := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC)
+	if period != "" {
+		parsed, err := time.Parse(variancePeriodLayout, period)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("invalid --period %q (use YYYY-MM): %w", period, err)
+		}
+		start = parsed
+	}
+	if start.After(now) {
+		return time.Time{}, time.Time{}, 0, fmt.Errorf("--period cannot be in the future: %s", period)
+	}
+
+	end := start.AddDate(0, 1, 0)
+	monthHours := end.Sub(start).Hours()
+	if end.After(now) {
+		end = now
+	}
+	return start, end, monthHours, nil
+}
+
+// recordProjectionSnapshot stores the projected monthly costs for the stack so
+// 'cost variance' can later compare them against actual spend.
+func recordProjectionSnapshot(
+	ctx context.Context,
+	store *config.ProjectionHistoryStore,
+	stack string,
+	resources []engine.ResourceDescriptor,
+	results []engine.CostResult,
+	recordedAt time.Time,
+) error {
+	if err := store.Load(); err != nil {
+		return fmt.Errorf("loading projection history: %w", err)
+	}
+
+	providers := make(map[string]string, len(resources))
+	tags := make(map[string]map[string]string, len(resources))
+	for _, r := range resources {
+		providers[r.ID] = r.Provider
+		tags[r.ID] = engine.ResourceTags(r.Properties)
+	}
+
+	snapshot := config.ProjectionSnapshot{
+		RecordedAt: recordedAt,
+		Resources:  make(map[string]config.ProjectedResourceRecord, len(results)),
+	}
+	for _, r := range results {
+		if r.ResourceID == "" || r.Error != nil {
+			continue
+		}
+		snapshot.Resources[r.ResourceID] = config.ProjectedResourceRecord{
+			ResourceType: r.ResourceType,
+			Provider:     providers[r.ResourceID],
+			Monthly:      r.Monthly,
+			Currency:     r.Currency,
+			Tags:         tags[r.ResourceID],
+		}
+	}
+
+	if err := store.RecordSnapshot(stack, snapshot); err != nil {
+		return err
+	}
+	if err := store.Save(); err != nil {
+		return fmt.Errorf("saving projection history: %w", err)
+	}
+
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "cli").
+		Str("operation", "record_projection").Str("stack", stack).
+		Int("resource_count", len(snapshot.Resources)).Msg("recorded projection snapshot")
+	return nil
+}
+
+// renderVarianceReport renders the variance report in the requested format.
+func renderVarianceReport(cmd *cobra.Command, format string, report *engine.VarianceReport) error {
+	switch format {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encoding variance JSON: %w", err)
+		}
+		return nil
+	case outputFormatNDJSON:
+		encoder := json.NewEncoder(cmd.OutOrStdout())
+		for _, r := range report.Resources {
+			if err := encoder.Encode(r); err != nil {
+				return fmt.Errorf("encoding variance NDJSON: %w", err)
+			}
+		}
+		return nil
+	default:
+		return renderVarianceTable(cmd, report)
+	}
+}
+
+// renderVarianceTable renders the variance report as a table, marking
+// resources that exceeded the threshold.
+func renderVarianceTable(cmd *cobra.Command, report *engine.VarianceReport) error {
+	cmd.Printf("Variance for stack %s, %s (projection recorded %s, threshold %.1f%%)\n\n",
+		report.Stack, report.Period, report.ProjectedAt.Format("2006-01-02 15:04"), report.Threshold)
+
+	if len(report.Resources) == 0 {
+		cmd.Println("The recorded projection contains no resources.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "RESOURCE\tTYPE\tPROJECTED\tACTUAL\tVARIANCE\tVARIANCE %\tSTATUS")
+	fmt.Fprintln(tw, "--------\t----\t---------\t------\t--------\t----------\t------")
+
+	for _, r := range report.Resources {
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%s\t%+.2f\t%s\t%s\n",
+			r.ResourceID, r.ResourceType, r.Projected,
+			formatVarianceActual(r), r.Variance, formatVariancePercent(r), varianceStatus(r))
+	}
+
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	cmd.Printf("\nTotal: projected %.2f %s, actual %.2f %s (%+.1f%%)\n",
+		report.TotalProjected, report.Currency, report.TotalActual, report.Currency,
+		report.TotalVariancePercent)
+	cmd.Printf("%d of %d resources exceeded the projection by more than %.1f%%.\n",
+		report.ExceededCount, len(report.Resources), report.Threshold)
+	return nil
+}
+
+// formatVarianceActual formats the actual cost, or "-" when none was returned.
+func formatVarianceActual(r engine.ResourceVariance) string {
+	if r.NoActual {
+		return "-"
+	}
+	return fmt.Sprintf("%.2f", r.Actual)
+}
+
+// formatVariancePercent formats the variance percentage, or "n/a" without a projection.
+func formatVariancePercent(r engine.ResourceVariance) string {
+	if r.Projected == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%+.1f%%", r.VariancePercent)
+}
+
+// varianceStatus returns the status label for a resource variance.
+func varianceStatus(r engine.ResourceVariance) string {
+	switch {
+	case r.Exceeded:
+		return "OVER"
+	case r.NoActual:
+		return "NO DATA"
+	default:
+		return "OK"
+	}
+}
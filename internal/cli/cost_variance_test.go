@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestResolveVariancePeriod(t *testing.T) {
+	now := time.Date(2026, 3, 16, 12, 0, 0, 0, time.UTC)
+
+	from, to, hours, err := resolveVariancePeriod("2026-02", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), to)
+	assert.InDelta(t, 28*24.0, hours, 0.001)
+
+	from, to, hours, err = resolveVariancePeriod("", now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), from)
+	assert.Equal(t, now, to, "current month ends now")
+	assert.InDelta(t, 31*24.0, hours, 0.001)
+
+	_, _, _, err = resolveVariancePeriod("2026-04", now)
+	require.ErrorContains(t, err, "future")
+	_, _, _, err = resolveVariancePeriod("March", now)
+	require.ErrorContains(t, err, "YYYY-MM")
+}
+
+func TestRecordProjectionSnapshot(t *testing.T) {
+	store := config.NewProjectionHistoryStore(filepath.Join(t.TempDir(), "projections.json"))
+	recordedAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	err := recordProjectionSnapshot(context.Background(), store, "prod",
+		[]engine.ResourceDescriptor{{
+			ID: "web", Provider: "aws",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"team": "platform"}},
+		}},
+		[]engine.CostResult{
+			{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 70, Currency: "USD"},
+			{ResourceID: "broken", Monthly: 0, Error: &engine.StructuredError{Code: "PLUGIN_ERROR"}},
+		}, recordedAt)
+	require.NoError(t, err)
+
+	reloaded := config.NewProjectionHistoryStore(store.FilePath())
+	require.NoError(t, reloaded.Load())
+	snapshot, ok := reloaded.SnapshotAt("prod", recordedAt)
+	require.True(t, ok)
+	require.Len(t, snapshot.Resources, 1, "errored results are not recorded")
+	assert.Equal(t, "aws", snapshot.Resources["web"].Provider)
+	assert.Equal(t, map[string]string{"team": "platform"}, snapshot.Resources["web"].Tags)
+	assert.InDelta(t, 70.0, snapshot.Resources["web"].Monthly, 0.001)
+}
+
+func TestBuildVarianceReport(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	var request engine.ActualCostRequest
+	fetcher := actualCostFetcherFunc(func(req engine.ActualCostRequest) (*engine.CostResultWithErrors, error) {
+		request = req
+		return &engine.CostResultWithErrors{Results: []engine.CostResult{
+			{ResourceID: "web", TotalCost: 130, Currency: "USD"},
+			{ResourceID: "db", TotalCost: 95, Currency: "USD"},
+		}}, nil
+	})
+
+	report, err := buildVarianceReport(context.Background(), fetcher, varianceRequest{
+		stack: "prod",
+		snapshot: &config.ProjectionSnapshot{
+			RecordedAt: from.AddDate(0, 0, -2),
+			Resources: map[string]config.ProjectedResourceRecord{
+				"web": {ResourceType: "aws:ec2/instance:Instance", Provider: "aws", Monthly: 100, Currency: "USD"},
+				"db":  {ResourceType: "aws:rds/instance:Instance", Provider: "aws", Monthly: 100, Currency: "USD"},
+			},
+		},
+		from: from, to: to, monthHours: to.Sub(from).Hours(),
+		threshold: 20,
+	})
+	require.NoError(t, err)
+
+	require.Len(t, request.Resources, 2)
+	assert.Equal(t, "db", request.Resources[0].ID)
+	assert.Equal(t, "aws", request.Resources[0].Provider)
+	assert.Equal(t, from, request.From)
+
+	assert.Equal(t, "2026-01", report.Period)
+	assert.Equal(t, 1, report.ExceededCount)
+	assert.Equal(t, "web", report.Resources[0].ResourceID)
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, renderVarianceReport(cmd, outputFormatTable, report))
+	assert.Contains(t, out.String(), "Variance for stack prod, 2026-01")
+	assert.Contains(t, out.String(), "+30.0%")
+	assert.Contains(t, out.String(), "OVER")
+	assert.Contains(t, out.String(), "1 of 2 resources exceeded the projection by more than 20.0%")
+
+	out.Reset()
+	require.NoError(t, renderVarianceReport(cmd, outputFormatJSON, report))
+	var decoded engine.VarianceReport
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	assert.Equal(t, "prod", decoded.Stack)
+	assert.Len(t, decoded.Resources, 2)
+}
+
+func TestExecuteCostVariance_Errors(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	newCmd := func(stack string) *cobra.Command {
+		cmd := NewCostVarianceCmd()
+		cmd.Flags().String("stack", stack, "")
+		cmd.SetContext(context.Background())
+		cmd.SetOut(&bytes.Buffer{})
+		return cmd
+	}
+
+	err := executeCostVariance(newCmd(""), costVarianceParams{output: outputFormatTable})
+	require.ErrorContains(t, err, "--stack is required")
+
+	err = executeCostVariance(newCmd("prod"), costVarianceParams{output: outputFormatTable, threshold: -1})
+	require.ErrorContains(t, err, "--threshold")
+
+	err = executeCostVariance(newCmd("prod"), costVarianceParams{output: outputFormatTable, period: "2026-01"})
+	require.ErrorIs(t, err, errNoProjectionRecorded)
+	assert.Contains(t, err.Error(), "--record")
+}
+
+// actualCostFetcherFunc adapts a function to actualCostFetcher.
+type actualCostFetcherFunc func(req engine.ActualCostRequest) (*engine.CostResultWithErrors, error)
+
+func (f actualCostFetcherFunc) GetActualCostWithOptionsAndErrors(
+	_ context.Context, req engine.ActualCostRequest,
+) (*engine.CostResultWithErrors, error) {
+	return f(req)
+}
+
+func TestExecuteCostProjected_RecordRequiresStack(t *testing.T) {
+	cmd := NewCostProjectedCmd()
+	cmd.Flags().String("stack", "", "")
+	cmd.SetContext(context.Background())
+
+	err := executeCostProjected(cmd, costProjectedParams{planPaths: []string{"plan.json"}, record: true, utilization: 1})
+	require.ErrorContains(t, err, "--record requires --stack")
+}
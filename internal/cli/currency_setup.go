@@ -0,0 +1,136 @@
+package cli
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/currency"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// setupCurrency installs a currency converter on the command context when a
+// target currency is requested by --currency or the currency.target config
+// key. Costs, savings, and the configured budgets are then reported in that
+// currency.
+func setupCurrency(cmd *cobra.Command) error {
+	target, _ := cmd.Flags().GetString("currency")
+	cfg := config.GetGlobalConfig()
+	if target == "" && cfg != nil {
+		target = cfg.CurrencyTarget()
+	}
+	if target == "" {
+		return nil
+	}
+	target = strings.ToUpper(target)
+	if err := currency.ValidateCode(target); err != nil {
+		return err
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	var settings *config.CurrencyConfig
+	if cfg != nil {
+		settings = cfg.Currency
+	}
+	rates, err := currencySource(cfg, settings).Rates(ctx)
+	if err != nil {
+		return err
+	}
+	conv, err := currency.NewConverter(target, rates)
+	if err != nil {
+		return err
+	}
+
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "cli").
+		Str("target_currency", target).Str("rate_source", rates.Source).
+		Msg("converting costs to target currency")
+
+	if cfg != nil && cfg.Cost.Budgets != nil {
+		convertBudgets(ctx, conv, cfg.Cost.Budgets)
+	}
+	cmd.SetContext(currency.NewContext(ctx, conv))
+	return nil
+}
+
+// currencySource builds the exchange rate source from the currency config.
+// Fetched rates are cached next to the plugin response cache.
+func currencySource(cfg *config.Config, settings *config.CurrencyConfig) currency.Source {
+	source := settings.RateSource()
+	var fetcher currency.Source
+	switch source {
+	case config.CurrencySourceStatic:
+		return currency.NewStaticSource(settings.RateBase(), settings.Rates)
+	case config.CurrencySourceOpenExchangeRates:
+		appID := os.Getenv(currency.EnvOpenExchangeRatesAppID)
+		if appID == "" {
+			appID = settings.AppID
+		}
+		fetcher = currency.NewOpenExchangeRatesSource(appID)
+	default:
+		fetcher = currency.NewECBSource()
+	}
+
+	ttl := currency.DefaultCacheTTL
+	if settings != nil && settings.CacheTTLSeconds > 0 {
+		ttl = time.Duration(settings.CacheTTLSeconds) * time.Second
+	}
+	cacheDir := ""
+	if cfg != nil {
+		cacheDir = cfg.Cost.Cache.Directory
+	}
+	if cacheDir == "" {
+		homeDir, _ := os.UserHomeDir()
+		cacheDir = filepath.Join(homeDir, ".finfocus", "cache")
+	}
+	return currency.NewCachedSource(fetcher, filepath.Join(cacheDir, "exchange-rates-"+source+".json"), ttl)
+}
+
+// convertBudgets converts every configured budget amount into the converter's
+// target so budget checks compare like with like. Scoped budgets without a
+// currency inherit the global budget's currency.
+func convertBudgets(ctx context.Context, conv *currency.Converter, budgets *config.BudgetsConfig) {
+	base := defaultCurrency
+	if budgets.Global != nil && budgets.Global.Currency != "" {
+		base = budgets.Global.Currency
+	}
+
+	convert := func(b *config.ScopedBudget) {
+		if b == nil {
+			return
+		}
+		from := b.Currency
+		if from == "" {
+			from = base
+		}
+		amount, err := conv.Convert(b.Amount, from)
+		if err != nil {
+			logging.FromContext(ctx).Warn().Ctx(ctx).Str("component", "cli").Err(err).
+				Msg("leaving budget in native currency")
+			return
+		}
+		b.Amount, b.Currency = amount, conv.Target()
+	}
+
+	convert(budgets.Global)
+	for _, b := range budgets.Providers {
+		convert(b)
+	}
+	for i := range budgets.Tags {
+		convert(&budgets.Tags[i].ScopedBudget)
+	}
+	for _, b := range budgets.Types {
+		convert(b)
+	}
+	for _, b := range budgets.Stacks {
+		convert(b)
+	}
+}
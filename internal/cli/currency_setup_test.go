@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/currency"
+)
+
+func newCurrencyTestCmd(target string) *cobra.Command {
+	cmd := &cobra.Command{Use: "finfocus"}
+	cmd.Flags().String("currency", target, "")
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestSetupCurrency_NoTargetLeavesContext(t *testing.T) {
+	cmd := newCurrencyTestCmd("")
+	require.NoError(t, setupCurrency(cmd))
+	assert.Nil(t, currency.FromContext(cmd.Context()))
+}
+
+func TestSetupCurrency_RejectsInvalidCode(t *testing.T) {
+	require.Error(t, setupCurrency(newCurrencyTestCmd("euro")))
+}
+
+func TestCurrencySource_Static(t *testing.T) {
+	settings := &config.CurrencyConfig{Base: "USD", Rates: map[string]float64{"EUR": 0.9}}
+	rates, err := currencySource(nil, settings).Rates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "USD", rates.Base)
+	assert.InDelta(t, 0.9, rates.Rates["EUR"], 1e-9)
+}
+
+func TestConvertBudgets(t *testing.T) {
+	conv, err := currency.NewConverter("EUR", &currency.Rates{
+		Base: "USD", Rates: map[string]float64{"EUR": 0.5},
+	})
+	require.NoError(t, err)
+
+	budgets := &config.BudgetsConfig{
+		Global:    &config.ScopedBudget{Amount: 1000, Currency: "USD"},
+		Providers: map[string]*config.ScopedBudget{"aws": {Amount: 400}},
+		Tags: []config.TagBudget{
+			{Selector: "team:platform", ScopedBudget: config.ScopedBudget{Amount: 200, Currency: "USD"}},
+		},
+	}
+	convertBudgets(context.Background(), conv, budgets)
+
+	assert.InDelta(t, 500, budgets.Global.Amount, 1e-9)
+	assert.Equal(t, "EUR", budgets.Global.Currency)
+	assert.InDelta(t, 200, budgets.Providers["aws"].Amount, 1e-9)
+	assert.Equal(t, "EUR", budgets.Providers["aws"].Currency)
+	assert.InDelta(t, 100, budgets.Tags[0].Amount, 1e-9)
+}
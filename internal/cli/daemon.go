@@ -0,0 +1,277 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/daemon"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/pkg/version"
+)
+
+// Daemon client settings.
+const (
+	// daemonStatusTimeout bounds how long a command waits for the daemon to
+	// answer before starting plugins itself.
+	daemonStatusTimeout = 500 * time.Millisecond
+	// daemonStartTimeout bounds how long 'daemon start' waits for the
+	// background daemon to listen.
+	daemonStartTimeout = 10 * time.Second
+	// daemonStartPoll is the interval at which 'daemon start' checks it.
+	daemonStartPoll = 100 * time.Millisecond
+)
+
+// daemonLogFileName is the file in the finfocus log directory that a
+// background daemon writes its output to.
+const daemonLogFileName = "daemon.log"
+
+// daemonStartParams holds the parameters for the daemon start command execution.
+type daemonStartParams struct {
+	foreground  bool
+	idleTimeout time.Duration
+}
+
+// newDaemonCmd creates the daemon command group for the plugin daemon.
+func newDaemonCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "daemon",
+		Short: "Keep plugin processes running between commands",
+		Long: `The plugin daemon keeps plugin processes running between commands, so
+commands skip the startup of every plugin. While it runs, commands that call
+plugins reach them through its Unix socket in the finfocus home
+(~/.finfocus/daemon.sock); otherwise they start plugins themselves.
+
+A plugin is restarted when its binary changes, for example when it is
+reinstalled, and stopped after the idle timeout without calls. The daemon
+exits once it has been idle that long. A daemon of another finfocus version
+is stopped by the next command, which starts its plugins itself.`,
+	}
+	cmd.AddCommand(NewDaemonStartCmd(), NewDaemonStopCmd(), NewDaemonStatusCmd())
+	return cmd
+}
+
+// NewDaemonStartCmd creates the "start" subcommand, which starts the plugin
+// daemon in the background.
+func NewDaemonStartCmd() *cobra.Command {
+	var params daemonStartParams
+
+	cmd := &cobra.Command{
+		Use:   "start",
+		Short: "Start the plugin daemon",
+		Long: `Start the plugin daemon in the background. Its output goes to daemon.log
+in the finfocus log directory. --foreground runs it in the terminal instead,
+for example under a service manager.`,
+		Example: `  # Start the daemon
+  finfocus daemon start
+
+  # Stop plugins after 5 idle minutes, and the daemon with them
+  finfocus daemon start --idle-timeout 5m`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeDaemonStart(cmd, params)
+		},
+	}
+
+	cmd.Flags().BoolVar(&params.foreground, "foreground", false, "Run the daemon in the foreground")
+	cmd.Flags().DurationVar(&params.idleTimeout, "idle-timeout", daemon.DefaultIdleTimeout,
+		"Stop plugins, and then the daemon, after this long without calls")
+
+	return cmd
+}
+
+// NewDaemonStopCmd creates the "stop" subcommand, which stops the plugin
+// daemon and its plugins.
+func NewDaemonStopCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "stop",
+		Short: "Stop the plugin daemon and its plugins",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			err := daemon.Stop(cmd.Context(), daemon.SocketPath())
+			if errors.Is(err, daemon.ErrNotRunning) {
+				cmd.Println("Daemon is not running")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			cmd.Println("Daemon stopped")
+			return nil
+		},
+	}
+}
+
+// NewDaemonStatusCmd creates the "status" subcommand, which describes the
+// plugin daemon and the plugins it keeps running.
+func NewDaemonStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status",
+		Short: "Show the plugin daemon and its running plugins",
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			st, err := daemon.GetStatus(cmd.Context(), daemon.SocketPath())
+			if errors.Is(err, daemon.ErrNotRunning) {
+				cmd.Println("Daemon is not running")
+				return nil
+			}
+			if err != nil {
+				return err
+			}
+			renderDaemonStatus(cmd, st)
+			return nil
+		},
+	}
+}
+
+// executeDaemonStart starts the daemon unless one of this version runs.
+func executeDaemonStart(cmd *cobra.Command, params daemonStartParams) error {
+	ctx := cmd.Context()
+	socket := daemon.SocketPath()
+
+	if st, err := daemon.GetStatus(ctx, socket); err == nil {
+		if st.Version == version.GetVersion() {
+			cmd.Printf("Daemon already running (pid %d)\n", st.PID)
+			return nil
+		}
+		if err = daemon.Stop(ctx, socket); err != nil {
+			return fmt.Errorf("stopping daemon of version %s: %w", st.Version, err)
+		}
+	}
+
+	if params.foreground {
+		return runDaemon(cmd, socket, params.idleTimeout)
+	}
+	return startDaemonProcess(cmd, socket, params.idleTimeout)
+}
+
+// runDaemon serves the daemon on socket until it is interrupted, stopped,
+// or idle.
+func runDaemon(cmd *cobra.Command, socket string, idleTimeout time.Duration) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	// A background daemon outlives the terminal that started it.
+	signal.Ignore(syscall.SIGHUP)
+
+	if err := os.MkdirAll(filepath.Dir(socket), 0o700); err != nil {
+		return fmt.Errorf("creating daemon directory: %w", err)
+	}
+	// No daemon answered, so a socket left behind is stale.
+	_ = os.Remove(socket)
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "unix", socket)
+	if err != nil {
+		return fmt.Errorf("listening on %s: %w", socket, err)
+	}
+	if err = os.Chmod(socket, 0o600); err != nil {
+		_ = listener.Close()
+		return fmt.Errorf("restricting daemon socket: %w", err)
+	}
+
+	srv := daemon.NewServer(pluginhost.NewProcessLauncher(), version.GetVersion(), idleTimeout)
+	cmd.PrintErrf("Plugin daemon listening on %s\n", socket)
+	if err = srv.Serve(ctx, listener); err != nil {
+		return err
+	}
+	cmd.PrintErrln("Plugin daemon stopped")
+	return nil
+}
+
+// startDaemonProcess starts the daemon in a background process and waits
+// until it answers.
+func startDaemonProcess(cmd *cobra.Command, socket string, idleTimeout time.Duration) error {
+	exe, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("locating finfocus executable: %w", err)
+	}
+	logPath := filepath.Join(config.ResolveConfigDir(), "logs", daemonLogFileName)
+	if err = os.MkdirAll(filepath.Dir(logPath), 0o700); err != nil {
+		return fmt.Errorf("creating log directory: %w", err)
+	}
+	logFile, err := os.OpenFile(logPath, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("opening daemon log: %w", err)
+	}
+	defer logFile.Close()
+
+	//nolint:gosec // Re-executes this finfocus binary.
+	proc := exec.Command(exe, "daemon", "start", "--foreground", "--idle-timeout", idleTimeout.String())
+	proc.Stdout = logFile
+	proc.Stderr = logFile
+	if err = proc.Start(); err != nil {
+		return fmt.Errorf("starting daemon: %w", err)
+	}
+	exited := make(chan error, 1)
+	go func() { exited <- proc.Wait() }()
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), daemonStartTimeout)
+	defer cancel()
+	ticker := time.NewTicker(daemonStartPoll)
+	defer ticker.Stop()
+	for {
+		select {
+		case err = <-exited:
+			return fmt.Errorf("daemon exited (see %s): %w", logPath, err)
+		case <-ctx.Done():
+			_ = proc.Process.Kill()
+			return fmt.Errorf("daemon did not start within %s (see %s)", daemonStartTimeout, logPath)
+		case <-ticker.C:
+			if st, statusErr := daemon.GetStatus(ctx, socket); statusErr == nil {
+				cmd.Printf("Daemon started (pid %d), idle timeout %s; logs in %s\n", st.PID, st.IdleTimeout, logPath)
+				return nil
+			}
+		}
+	}
+}
+
+// renderDaemonStatus prints the daemon and its running plugins.
+func renderDaemonStatus(cmd *cobra.Command, st *daemon.Status) {
+	cmd.Printf("Daemon running (pid %d, version %s)\n", st.PID, st.Version)
+	cmd.Printf("Started: %s, idle timeout %s\n", st.Started.Format(time.RFC3339), st.IdleTimeout)
+	if len(st.Plugins) == 0 {
+		cmd.Println("No plugins running")
+		return
+	}
+	cmd.Printf("Plugins running: %d\n", len(st.Plugins))
+	for _, p := range st.Plugins {
+		cmd.Printf("  %s  %d calls, last used %s\n", p.Path, p.Calls, p.LastUsed.Format(time.RFC3339))
+	}
+}
+
+// pluginLauncher returns the launcher commands start plugins with: the
+// daemon when one of this version is running, and otherwise new processes.
+// A daemon of another version is stopped, since its plugins may no longer
+// match the ones installed for this one.
+func pluginLauncher(ctx context.Context) pluginhost.Launcher {
+	log := logging.FromContext(ctx)
+	socket := daemon.SocketPath()
+
+	statusCtx, cancel := context.WithTimeout(ctx, daemonStatusTimeout)
+	defer cancel()
+	st, err := daemon.GetStatus(statusCtx, socket)
+	switch {
+	case err != nil:
+		log.Debug().Ctx(ctx).Err(err).Msg("plugin daemon not available, starting plugins")
+		return pluginhost.NewProcessLauncher()
+	case st.Version != version.GetVersion():
+		log.Info().
+			Ctx(ctx).
+			Str("daemon_version", st.Version).
+			Str("version", version.GetVersion()).
+			Msg("stopping plugin daemon of another finfocus version")
+		if stopErr := daemon.Stop(statusCtx, socket); stopErr != nil {
+			log.Warn().Ctx(ctx).Err(stopErr).Msg("failed to stop plugin daemon")
+		}
+		return pluginhost.NewProcessLauncher()
+	default:
+		log.Debug().Ctx(ctx).Int("daemon_pid", st.PID).Msg("reaching plugins through the plugin daemon")
+		return daemon.NewLauncher(socket)
+	}
+}
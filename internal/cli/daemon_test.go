@@ -0,0 +1,76 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/daemon"
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// shortHome points FINFOCUS_HOME at a new directory with a path short enough
+// for a Unix socket.
+func shortHome(t *testing.T) {
+	t.Helper()
+	home, err := os.MkdirTemp("", "ffh")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(home) })
+	t.Setenv("FINFOCUS_HOME", home)
+}
+
+func TestDaemonCommands_NotRunning(t *testing.T) {
+	shortHome(t)
+	for _, cmd := range []string{"status", "stop"} {
+		var out bytes.Buffer
+		root := newDaemonCmd()
+		root.SetOut(&out)
+		root.SetArgs([]string{cmd})
+		require.NoError(t, root.Execute())
+		assert.Contains(t, out.String(), "Daemon is not running")
+	}
+	assert.IsType(t, &pluginhost.ProcessLauncher{}, pluginLauncher(context.Background()))
+}
+
+func TestDaemonCommands_ForegroundDaemon(t *testing.T) {
+	shortHome(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		start := newDaemonCmd()
+		start.SetArgs([]string{"start", "--foreground", "--idle-timeout", "1m"})
+		start.SetErr(&bytes.Buffer{})
+		done <- start.ExecuteContext(ctx)
+	}()
+	require.Eventually(t, func() bool {
+		_, err := daemon.GetStatus(ctx, daemon.SocketPath())
+		return err == nil
+	}, 5*time.Second, 20*time.Millisecond)
+
+	var out bytes.Buffer
+	status := newDaemonCmd()
+	status.SetOut(&out)
+	status.SetArgs([]string{"status"})
+	require.NoError(t, status.Execute())
+	assert.Contains(t, out.String(), "Daemon running")
+	assert.Contains(t, out.String(), "No plugins running")
+	assert.IsType(t, &daemon.Launcher{}, pluginLauncher(ctx))
+
+	stop := newDaemonCmd()
+	stop.SetOut(&bytes.Buffer{})
+	stop.SetArgs([]string{"stop"})
+	require.NoError(t, stop.Execute())
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+}
@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/spec"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// Dashboard defaults.
+const (
+	defaultDashboardDays    = 30
+	defaultDashboardRefresh = 5 * time.Minute
+	// minDashboardRefresh keeps live refresh from hammering plugins.
+	minDashboardRefresh = 30 * time.Second
+)
+
+// dashboardParams holds the parameters for the dashboard command execution.
+type dashboardParams struct {
+	planPath  string
+	statePath string
+	adapter   string
+	filter    []string
+	days      int
+	refresh   time.Duration
+}
+
+// dashboardEngine prices resources and fetches recommendations for the dashboard.
+type dashboardEngine interface {
+	projectedCostEngine
+	actualCostFetcher
+}
+
+// NewDashboardCmd creates the "dashboard" command, an interactive multi-pane
+// view of projected costs, actual spend, budgets, and recommendations.
+func NewDashboardCmd() *cobra.Command {
+	var params dashboardParams
+
+	cmd := &cobra.Command{
+		Use:   "dashboard",
+		Short: "Interactive cost dashboard with live refresh",
+		Long: `Open an interactive dashboard with a tab for each view of the stack's costs:
+
+  1 Projected        projected monthly cost per resource
+  2 Actual           daily actual spend as a sparkline, and cost per resource
+  3 Budgets          a gauge per configured budget scope
+  4 Recommendations  recommendations with the largest savings first
+
+Switch tabs with tab, shift+tab, the arrow keys, or 1-4, scroll with up and
+down, refresh with r, and quit with q. The data is reloaded every --refresh;
+when a refresh fails, the last data stays on screen with the error below it.
+
+Resources come from --pulumi-json or --pulumi-state, which are re-read on every
+refresh. When both are omitted, the deployed state of the current Pulumi stack
+is used. Use --stack to pick a different stack.`,
+		Example: `  # Dashboard for the current Pulumi stack
+  finfocus dashboard
+
+  # Dashboard from an exported state, refreshing every minute
+  finfocus dashboard --pulumi-state state.json --refresh 1m
+
+  # Show the last 90 days of actual spend without live refresh
+  finfocus dashboard --days 90 --refresh 0`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeDashboard(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().StringVar(&params.statePath, "pulumi-state", "", "Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().String("stack", "", "Pulumi stack for auto-detection (ignored with --pulumi-json/--pulumi-state)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
+		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+	cmd.Flags().IntVar(&params.days, "days", defaultDashboardDays, "Days of actual spend to show")
+	cmd.Flags().DurationVar(&params.refresh, "refresh", defaultDashboardRefresh,
+		"Refresh interval (0 disables live refresh)")
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "pulumi-state")
+
+	return cmd
+}
+
+// executeDashboard runs the dashboard until the user quits.
+func executeDashboard(cmd *cobra.Command, params dashboardParams) error {
+	if params.days < 1 {
+		return fmt.Errorf("--days must be at least 1, got %d", params.days)
+	}
+	if params.refresh != 0 && params.refresh < minDashboardRefresh {
+		return fmt.Errorf("--refresh must be 0 or at least %s, got %s", minDashboardRefresh, params.refresh)
+	}
+	if !shouldUseInteractiveTUI(cmd.OutOrStdout(), outputFormatTable, false) {
+		return errors.New("dashboard requires an interactive terminal; use 'cost projected' in scripts")
+	}
+
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+	audit := newAuditContext(ctx, "dashboard", map[string]string{
+		"pulumi_json": params.planPath, "pulumi_state": params.statePath,
+	})
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	fetch := func(fetchCtx context.Context) (*tui.DashboardData, error) {
+		start := time.Now()
+		data, fetchErr := collectDashboardData(fetchCtx, cmd, eng, params, start)
+		if fetchErr != nil {
+			log.Warn().Ctx(fetchCtx).Err(fetchErr).Str("component", "cli").Str("operation", "dashboard").
+				Msg("dashboard refresh failed")
+			return nil, fetchErr
+		}
+		log.Debug().Ctx(fetchCtx).Str("component", "cli").Str("operation", "dashboard").
+			Int("resource_count", len(data.Projected)).Dur("duration_ms", time.Since(start)).
+			Msg("dashboard refreshed")
+		return data, nil
+	}
+
+	model := tui.NewDashboardModel(ctx, fetch, params.refresh)
+	if _, err = tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("running dashboard: %w", err)
+	}
+	audit.logSuccess(ctx, 0, 0)
+	return nil
+}
+
+// collectDashboardData loads and prices the resources, fetches the actual
+// spend of the last params.days full days before now, evaluates budgets, and
+// fetches recommendations for one refresh.
+func collectDashboardData(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng dashboardEngine,
+	params dashboardParams,
+	now time.Time,
+) (*tui.DashboardData, error) {
+	resources, err := loadStackResources(ctx, cmd, "dashboard refresh", params.planPath, params.statePath)
+	if err != nil {
+		return nil, err
+	}
+	resources, err = ApplyFilters(ctx, resources, params.filter)
+	if err != nil {
+		return nil, fmt.Errorf("applying filters: %w", err)
+	}
+
+	projected, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	fetchAndMergeRecommendations(ctx, eng, resources, projected.Results)
+
+	today := now.UTC().Truncate(24 * time.Hour) //nolint:mnd // One day.
+	from := today.AddDate(0, 0, -params.days)
+	actual, err := eng.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+		Resources: resources, From: from, To: today, Adapter: params.adapter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching actual costs: %w", err)
+	}
+
+	data := &tui.DashboardData{
+		Projected:       sortedCostResults(projected.Results, func(r engine.CostResult) float64 { return r.Monthly }),
+		Actual:          sortedCostResults(actual.Results, func(r engine.CostResult) float64 { return r.TotalCost }),
+		DailySpend:      dashboardDailySpend(actual.Results, from, params.days),
+		From:            from,
+		Recommendations: dashboardRecommendations(projected.Results),
+		UpdatedAt:       now,
+	}
+
+	currency, mixed := extractCurrencyFromResults(projected.Results)
+	data.Currency = currency
+	if !mixed {
+		total := 0.0
+		for _, r := range projected.Results {
+			total += r.Monthly
+		}
+		budgets, budgetErr := evaluateBudgetsQuietly(cmd, projected.Results, resourceTagIndex(resources), total, currency)
+		if budgetErr != nil {
+			return nil, fmt.Errorf("evaluating budgets: %w", budgetErr)
+		}
+		data.Budgets = budgetScopes(budgets)
+	}
+	return data, nil
+}
+
+// loadStackResources loads the resources from planPath (--pulumi-json) or
+// statePath (--pulumi-state), or from the deployed state of the detected
+// Pulumi stack when both are empty. operation names the load in the audit log.
+func loadStackResources(
+	ctx context.Context,
+	cmd *cobra.Command,
+	operation, planPath, statePath string,
+) ([]engine.ResourceDescriptor, error) {
+	audit := newAuditContext(ctx, operation, nil)
+	switch {
+	case planPath != "":
+		return loadAndMapResources(ctx, planPath, audit)
+	case statePath != "":
+		return loadResourcesFromState(ctx, statePath, audit)
+	default:
+		return resolveResourcesFromPulumi(ctx, getStackFlag(cmd), modePulumiExport)
+	}
+}
+
+// sortedCostResults returns the results without errors, largest cost first.
+func sortedCostResults(results []engine.CostResult, cost func(engine.CostResult) float64) []engine.CostResult {
+	out := make([]engine.CostResult, 0, len(results))
+	for _, r := range results {
+		if r.Error == nil {
+			out = append(out, r)
+		}
+	}
+	return pipeline.Sort(pipeline.Descending(func(a, b engine.CostResult) bool { return cost(a) < cost(b) }))(out)
+}
+
+// dashboardDailySpend sums actual costs into days daily totals starting at
+// from. Results with DailyCosts are spread over the days from their start
+// date; others count their total on their start date.
+func dashboardDailySpend(results []engine.CostResult, from time.Time, days int) []float64 {
+	spend := make([]float64, days)
+	add := func(day time.Time, cost float64) {
+		i := int(day.Sub(from).Hours() / 24) //nolint:mnd // Hours per day.
+		if i >= 0 && i < days {
+			spend[i] += cost
+		}
+	}
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		start := r.StartDate
+		if start.IsZero() {
+			start = from
+		}
+		if len(r.DailyCosts) == 0 {
+			add(start, r.TotalCost)
+			continue
+		}
+		for i, cost := range r.DailyCosts {
+			add(start.AddDate(0, 0, i), cost)
+		}
+	}
+	return spend
+}
+
+// dashboardRecommendations collects the recommendations merged into results,
+// largest savings first.
+func dashboardRecommendations(results []engine.CostResult) []engine.Recommendation {
+	var recs []engine.Recommendation
+	for _, r := range results {
+		for _, rec := range r.Recommendations {
+			if rec.ResourceID == "" {
+				rec.ResourceID = r.ResourceID
+			}
+			recs = append(recs, rec)
+		}
+	}
+	return pipeline.Sort(pipeline.Descending(func(a, b engine.Recommendation) bool {
+		return a.EstimatedSavings < b.EstimatedSavings
+	}))(recs)
+}
@@ -0,0 +1,89 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestNewDashboardCmd_Defaults(t *testing.T) {
+	cmd := NewDashboardCmd()
+
+	days, err := cmd.Flags().GetInt("days")
+	require.NoError(t, err)
+	assert.Equal(t, defaultDashboardDays, days)
+
+	refresh, err := cmd.Flags().GetDuration("refresh")
+	require.NoError(t, err)
+	assert.Equal(t, defaultDashboardRefresh, refresh)
+}
+
+func TestDashboard_RejectsInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"days", []string{"--days", "0"}, "--days must be at least 1"},
+		{"refresh", []string{"--refresh", "5s"}, "--refresh must be 0 or at least 30s"},
+		{"no terminal", []string{"--refresh", "0"}, "requires an interactive terminal"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewDashboardCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
+
+func TestDashboardDailySpend(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	results := []engine.CostResult{
+		{StartDate: from.AddDate(0, 0, 1), DailyCosts: []float64{1, 2, 3}},
+		{StartDate: from.AddDate(0, 0, 2), DailyCosts: []float64{10, 10, 10}},
+		{TotalCost: 5},
+		{StartDate: from.AddDate(0, 0, 3), TotalCost: 7},
+		{TotalCost: 100, Error: &engine.StructuredError{Message: "failed"}},
+	}
+
+	spend := dashboardDailySpend(results, from, 4)
+	assert.Equal(t, []float64{5, 1, 12, 20}, spend, "days outside the window are dropped")
+}
+
+func TestSortedCostResults(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "small", Monthly: 1},
+		{ResourceID: "broken", Monthly: 50, Error: &engine.StructuredError{Message: "failed"}},
+		{ResourceID: "large", Monthly: 10},
+	}
+
+	sorted := sortedCostResults(results, func(r engine.CostResult) float64 { return r.Monthly })
+	require.Len(t, sorted, 2)
+	assert.Equal(t, "large", sorted[0].ResourceID)
+	assert.Equal(t, "small", sorted[1].ResourceID)
+}
+
+func TestDashboardRecommendations(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "web", Recommendations: []engine.Recommendation{{Type: "RIGHTSIZE", EstimatedSavings: 5}}},
+		{ResourceID: "db", Recommendations: []engine.Recommendation{
+			{ResourceID: "db-replica", Type: "TERMINATE", EstimatedSavings: 20},
+		}},
+	}
+
+	recs := dashboardRecommendations(results)
+	require.Len(t, recs, 2)
+	assert.Equal(t, "db-replica", recs[0].ResourceID)
+	assert.Equal(t, "web", recs[1].ResourceID, "the resource ID is filled from the cost result")
+}
@@ -0,0 +1,441 @@
+package cli
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/registry"
+	"github.com/rshade/finfocus/pkg/version"
+)
+
+// lastFailureFileName is the file of the finfocus home RecordFailure writes
+// the last failed command to.
+const lastFailureFileName = "last-failure.json"
+
+// Limits on the log lines a debug bundle includes.
+const (
+	bundleAuditEntries = 50
+	bundleLogLines     = 500
+)
+
+// bundleRedacted replaces secrets in a debug bundle, and bundleAccountID
+// the account IDs.
+const (
+	bundleRedacted  = "********"
+	bundleAccountID = "<account-id>"
+)
+
+// bundleSecretKey matches the names of settings and fields holding secrets.
+//
+//nolint:gochecknoglobals // Compiled once; read-only.
+var bundleSecretKey = regexp.MustCompile(
+	`(?i)(token|secret|password|passwd|credential|api_?key|access_?key|private_?key|webhook|auth|app_id)`)
+
+// bundleRedactions replace secrets and account IDs in the text of a debug
+// bundle: key=value and "key":"value" secrets, AWS access key IDs, Azure
+// subscription and GCP project IDs in resource paths, and AWS account IDs.
+//
+//nolint:gochecknoglobals // Compiled once; read-only.
+var bundleRedactions = []struct {
+	pattern     *regexp.Regexp
+	replacement string
+}{
+	{
+		regexp.MustCompile(`(?i)("?[\w.-]*(?:token|secret|password|passwd|credential|api_?key|access_?key|` +
+			`private_?key|webhook|authorization)[\w.-]*"?\s*[:=]\s*"?)(?:bearer\s+)?[^\s",}&]+`),
+		"${1}" + bundleRedacted,
+	},
+	{regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`), bundleRedacted},
+	{regexp.MustCompile(`(?i)(subscriptions/)[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`),
+		"${1}" + bundleAccountID},
+	{regexp.MustCompile(`(projects/)[a-z][a-z0-9-]{4,28}[a-z0-9]`), "${1}" + bundleAccountID},
+	{regexp.MustCompile(`\b\d{12}\b`), bundleAccountID},
+}
+
+// debugBundleParams holds the parameters for the debug bundle command execution.
+type debugBundleParams struct {
+	output string
+}
+
+// bundleSystem is the system.json file of a debug bundle.
+type bundleSystem struct {
+	Version     string    `json:"version"`
+	GoVersion   string    `json:"goVersion"`
+	OS          string    `json:"os"`
+	Arch        string    `json:"arch"`
+	NumCPU      int       `json:"numCPU"`
+	CreatedAt   time.Time `json:"createdAt"`
+	ConfigFile  string    `json:"configFile"`
+	Profile     string    `json:"profile,omitempty"`
+	ProjectFile string    `json:"projectFile,omitempty"`
+}
+
+// bundleCache is the cache.json file of a debug bundle.
+type bundleCache struct {
+	Enabled    bool   `json:"enabled"`
+	Directory  string `json:"directory,omitempty"`
+	TTLSeconds int    `json:"ttlSeconds,omitempty"`
+	Entries    int    `json:"entries"`
+	SizeBytes  int64  `json:"sizeBytes"`
+	Error      string `json:"error,omitempty"`
+}
+
+// lastFailure is the last command that failed, which RecordFailure writes
+// to the finfocus home for 'debug bundle'.
+type lastFailure struct {
+	Time     time.Time `json:"time"`
+	Version  string    `json:"version"`
+	Command  string    `json:"command"`
+	Args     []string  `json:"args"`
+	Error    string    `json:"error"`
+	ExitCode int       `json:"exitCode"`
+}
+
+// bundleFile is a file of a debug bundle.
+type bundleFile struct {
+	name string
+	data []byte
+}
+
+// newDebugCmd creates the debug command group.
+func newDebugCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "debug", Short: "Diagnostics for bug reports"}
+	cmd.AddCommand(NewDebugBundleCmd())
+	return cmd
+}
+
+// NewDebugBundleCmd creates the "bundle" subcommand, which collects
+// diagnostics into a tarball to attach to GitHub issues.
+func NewDebugBundleCmd() *cobra.Command {
+	var params debugBundleParams
+
+	cmd := &cobra.Command{
+		Use:   "bundle",
+		Short: "Collect diagnostics into a tarball for bug reports",
+		Long: `Collect diagnostics into a gzipped tarball to attach to a GitHub issue.
+
+The bundle holds:
+  system.json        finfocus version, Go version, OS, and architecture
+  config.json        the configuration, as 'config list' shows it
+  plugins.json       the installed plugins and their versions
+  cache.json         the number and size of cached plugin responses
+  audit.jsonl        the most recent audit log entries
+  last-failure.json  the last command that failed and its error
+  finfocus.log       the end of the log file
+
+Secrets are redacted: secret settings, plugin settings, analyzer plugin
+environment variables, values of token, password, key, and webhook fields in
+logs, AWS access key IDs, and account IDs (12-digit AWS accounts, Azure subscriptions,
+and GCP projects). Review the bundle before attaching it, since logs may hold
+other details of your infrastructure.`,
+		Example: `  # Write finfocus-debug-<time>.tar.gz to the current directory
+  finfocus debug bundle
+
+  # Write the bundle to a given file
+  finfocus debug bundle --output /tmp/finfocus-debug.tar.gz`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeDebugBundle(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVarP(&params.output, "output", "o", "",
+		"File to write the bundle to (default finfocus-debug-<time>.tar.gz)")
+
+	return cmd
+}
+
+// executeDebugBundle collects the diagnostics and writes the bundle.
+func executeDebugBundle(cmd *cobra.Command, params debugBundleParams) error {
+	now := time.Now().UTC()
+	output := params.output
+	if output == "" {
+		output = "finfocus-debug-" + now.Format("20060102T150405") + ".tar.gz"
+	}
+
+	files, err := collectDebugBundle(cmd, config.GetGlobalConfig(), now)
+	if err != nil {
+		return err
+	}
+	if err = writeDebugBundle(output, files, now); err != nil {
+		return err
+	}
+
+	cmd.Printf("Wrote debug bundle: %s\n", output)
+	for _, file := range files {
+		cmd.Printf("  %s\n", file.name)
+	}
+	cmd.Println("Secrets and account IDs are redacted; review the bundle before attaching it to an issue.")
+	return nil
+}
+
+// collectDebugBundle returns the redacted files of a debug bundle. Files
+// whose source does not exist, such as the log file, are left out.
+func collectDebugBundle(cmd *cobra.Command, cfg *config.Config, now time.Time) ([]bundleFile, error) {
+	var files []bundleFile
+	addJSON := func(name string, v any) error {
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encoding %s: %w", name, err)
+		}
+		files = append(files, bundleFile{name: name, data: redactBundleText(append(data, '\n'))})
+		return nil
+	}
+
+	system := bundleSystem{
+		Version:     version.GetVersion(),
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		NumCPU:      runtime.NumCPU(),
+		CreatedAt:   now,
+		ConfigFile:  cfg.Path(),
+		Profile:     cfg.Profile(),
+		ProjectFile: cfg.ProjectFile(),
+	}
+	// A plugin directory that cannot be read leaves plugins.json empty
+	// rather than failing the bundle.
+	plugins, _ := registry.NewDefault().ListPlugins()
+	var err error
+	for _, step := range []struct {
+		name string
+		v    any
+	}{
+		{"system.json", system},
+		{"config.json", redactBundleConfig(cfg.List())},
+		{"plugins.json", bundlePlugins(plugins)},
+		{"cache.json", bundleCacheStats(cmd, cfg)},
+	} {
+		if err = addJSON(step.name, step.v); err != nil {
+			return nil, err
+		}
+	}
+
+	if audit := recentAuditEntries(cfg); len(audit) > 0 {
+		files = append(files, bundleFile{name: "audit.jsonl", data: redactBundleText(audit)})
+	}
+	if failure, readErr := os.ReadFile(filepath.Join(config.ResolveConfigDir(), lastFailureFileName)); readErr == nil {
+		files = append(files, bundleFile{name: lastFailureFileName, data: redactBundleText(failure)})
+	}
+	if cfg.Logging.File != "" {
+		if tail, tailErr := tailLines(cfg.Logging.File, bundleLogLines, nil); tailErr == nil && len(tail) > 0 {
+			files = append(files, bundleFile{name: "finfocus.log", data: redactBundleText(tail)})
+		}
+	}
+	return files, nil
+}
+
+// bundlePlugins returns the names, versions, and paths of the installed
+// plugins. Plugins are not launched, so a broken plugin does not stop the
+// bundle from being written.
+func bundlePlugins(plugins []registry.PluginInfo) []registry.PluginInfo {
+	out := make([]registry.PluginInfo, 0, len(plugins))
+	for _, plugin := range plugins {
+		out = append(out, registry.PluginInfo{Name: plugin.Name, Version: plugin.Version, Path: plugin.Path})
+	}
+	return out
+}
+
+// bundleCacheStats returns the number and size of the cached plugin
+// responses.
+func bundleCacheStats(cmd *cobra.Command, cfg *config.Config) bundleCache {
+	stats := bundleCache{Enabled: cfg.Cost.Cache.Enabled}
+	if !stats.Enabled {
+		return stats
+	}
+	store := setupPluginCache(cmd.Context(), cmd, cfg)
+	if store == nil {
+		stats.Error = "cache could not be opened"
+		return stats
+	}
+	stats.Directory = store.GetDirectory()
+	stats.TTLSeconds = store.GetTTL()
+	var err error
+	if stats.Entries, err = store.Count(); err == nil {
+		stats.SizeBytes, err = store.Size()
+	}
+	if err != nil {
+		stats.Error = err.Error()
+	}
+	return stats
+}
+
+// recentAuditEntries returns the last audit log entries, from the audit
+// file or, when there is none, the log file.
+func recentAuditEntries(cfg *config.Config) []byte {
+	path := cfg.Logging.Audit.File
+	if path == "" {
+		path = cfg.Logging.File
+	}
+	if path == "" {
+		return nil
+	}
+	entries, err := tailLines(path, bundleAuditEntries, func(line []byte) bool {
+		return bytes.Contains(line, []byte(`"audit":true`))
+	})
+	if err != nil {
+		return nil
+	}
+	return entries
+}
+
+// tailLines returns the last n lines of the file at path for which keep
+// returns true, or the last n lines when keep is nil.
+func tailLines(path string, n int, keep func([]byte) bool) ([]byte, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	lines := make([][]byte, 0, n)
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), 1024*1024)
+	for scanner.Scan() {
+		if keep != nil && !keep(scanner.Bytes()) {
+			continue
+		}
+		if len(lines) == n {
+			lines = lines[1:]
+		}
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	var out bytes.Buffer
+	for _, line := range lines {
+		out.Write(line)
+		out.WriteByte('\n')
+	}
+	return out.Bytes(), nil
+}
+
+// redactBundleConfig returns the configuration listed by config list with
+// its secrets masked: settings whose names suggest secrets, and every plugin
+// setting and analyzer plugin environment variable, since their meaning is
+// up to the plugin.
+func redactBundleConfig(settings map[string]interface{}) any {
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return nil
+	}
+	var tree any
+	if err = json.Unmarshal(data, &tree); err != nil {
+		return nil
+	}
+	return redactBundleValue(tree, nil)
+}
+
+// redactBundleValue masks the secrets of v, the value at path of the
+// configuration.
+func redactBundleValue(v any, path []string) any {
+	switch value := v.(type) {
+	case map[string]any:
+		for key, child := range value {
+			value[key] = redactBundleValue(child, append(path[:len(path):len(path)], key))
+		}
+		return value
+	case []any:
+		for i, child := range value {
+			value[i] = redactBundleValue(child, path)
+		}
+		return value
+	case string:
+		if value != "" && isBundleSecretPath(path) {
+			return bundleRedacted
+		}
+		return value
+	default:
+		return value
+	}
+}
+
+// isBundleSecretPath reports whether the setting at path holds a secret.
+func isBundleSecretPath(path []string) bool {
+	if len(path) == 0 {
+		return false
+	}
+	if path[0] == "plugins" && len(path) > 2 {
+		return true
+	}
+	if path[0] == "analyzer" && len(path) > 4 && path[1] == "plugins" && path[3] == "env" {
+		return true
+	}
+	return bundleSecretKey.MatchString(path[len(path)-1])
+}
+
+// redactBundleText masks secrets and account IDs in data.
+func redactBundleText(data []byte) []byte {
+	for _, redaction := range bundleRedactions {
+		data = redaction.pattern.ReplaceAll(data, []byte(redaction.replacement))
+	}
+	return data
+}
+
+// writeDebugBundle writes files to a gzipped tarball at path, readable only
+// by its owner.
+func writeDebugBundle(path string, files []bundleFile, now time.Time) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("creating debug bundle: %w", err)
+	}
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	for _, file := range files {
+		header := &tar.Header{Name: file.name, Mode: 0600, Size: int64(len(file.data)), ModTime: now}
+		if err = tw.WriteHeader(header); err != nil {
+			break
+		}
+		if _, err = tw.Write(file.data); err != nil {
+			break
+		}
+	}
+	err = errors.Join(err, tw.Close(), gz.Close(), out.Close())
+	if err != nil {
+		_ = os.Remove(path)
+		return fmt.Errorf("writing debug bundle: %w", err)
+	}
+	return nil
+}
+
+// RecordFailure writes the command that failed, its arguments, and its
+// error to the finfocus home, for 'debug bundle' to include. Errors are
+// ignored: recording a failure must not hide it.
+func RecordFailure(cmd *cobra.Command, args []string, err error, exitCode int) {
+	if err == nil {
+		return
+	}
+	failure := lastFailure{
+		Time:     time.Now().UTC(),
+		Version:  version.GetVersion(),
+		Args:     args,
+		Error:    err.Error(),
+		ExitCode: exitCode,
+	}
+	if cmd != nil {
+		failure.Command = cmd.CommandPath()
+	}
+	data, marshalErr := json.MarshalIndent(failure, "", "  ")
+	if marshalErr != nil {
+		return
+	}
+	dir := config.ResolveConfigDir()
+	if os.MkdirAll(dir, 0700) != nil {
+		return
+	}
+	_ = os.WriteFile(filepath.Join(dir, lastFailureFileName), append(data, '\n'), 0600)
+}
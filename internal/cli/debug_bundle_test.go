@@ -0,0 +1,104 @@
+package cli
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// readBundle returns the files of the debug bundle at path, by name.
+func readBundle(t *testing.T, path string) map[string]string {
+	t.Helper()
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+	gz, err := gzip.NewReader(file)
+	require.NoError(t, err)
+	tr := tar.NewReader(gz)
+
+	files := map[string]string{}
+	for {
+		header, nextErr := tr.Next()
+		if errors.Is(nextErr, io.EOF) {
+			return files
+		}
+		require.NoError(t, nextErr)
+		data, readErr := io.ReadAll(tr)
+		require.NoError(t, readErr)
+		files[header.Name] = string(data)
+	}
+}
+
+func TestDebugBundle(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	logFile := filepath.Join(home, "logs", "finfocus.log")
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(`plugins:
+  aws-public:
+    region: us-east-1
+    api_key: plain-secret
+logging:
+  file: `+logFile+`
+cost:
+  cache:
+    enabled: true
+    directory: `+filepath.Join(home, "cache")+`
+`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Dir(logFile), 0o700))
+	require.NoError(t, os.WriteFile(logFile, []byte(
+		`{"level":"debug","message":"calling plugin","account":"123456789012"}
+{"level":"info","audit":true,"command":"cost actual","success":true}
+{"level":"debug","message":"request","authorization":"Bearer abc123"}
+`), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(home, "cache"), 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(home, "cache", "entry.json"), []byte("{}"), 0o600))
+
+	config.SetGlobalConfig(config.New())
+	t.Cleanup(config.ResetGlobalConfigForTest)
+
+	failed := &cobra.Command{Use: "actual"}
+	RecordFailure(failed, []string{"cost", "actual", "--slack-webhook-url=https://hooks.slack.com/x"},
+		errors.New("listing subscriptions/0f0e0d0c-0b0a-0908-0706-050403020100 failed"), 1)
+
+	output := filepath.Join(t.TempDir(), "bundle.tar.gz")
+	cmd := NewDebugBundleCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--output", output})
+	require.NoError(t, cmd.Execute())
+	assert.Contains(t, out.String(), "Wrote debug bundle: "+output)
+
+	files := readBundle(t, output)
+	assert.Contains(t, files["system.json"], `"os"`)
+	assert.Contains(t, files["config.json"], `"region": "********"`, "plugin settings are masked")
+	assert.NotContains(t, files["config.json"], "plain-secret")
+	assert.Contains(t, files["plugins.json"], "[]")
+	assert.Contains(t, files["cache.json"], `"entries": 1`)
+	assert.Equal(t, `{"level":"info","audit":true,"command":"cost actual","success":true}`+"\n", files["audit.jsonl"])
+
+	assert.Contains(t, files[lastFailureFileName], `"command": "actual"`)
+	assert.Contains(t, files[lastFailureFileName], "subscriptions/<account-id>")
+	assert.NotContains(t, files[lastFailureFileName], "hooks.slack.com")
+
+	assert.Contains(t, files["finfocus.log"], `"account":"<account-id>"`)
+	assert.Contains(t, files["finfocus.log"], `"authorization":"********"`)
+	assert.NotContains(t, files["finfocus.log"], "abc123")
+
+	cmd = NewDebugBundleCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs([]string{"--output", output})
+	require.Error(t, cmd.Execute(), "an existing file is not overwritten")
+}
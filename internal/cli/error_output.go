@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// usageError marks an error in the flags or arguments of a command, which is
+// reported with engine.ErrCodeUsageError.
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string { return e.err.Error() }
+func (e *usageError) Unwrap() error { return e.err }
+
+// flagUsageError is the flag error function of the root command: it marks
+// flag errors as usage errors, and silences cobra's own error and usage
+// output when the command reports errors as JSON.
+func flagUsageError(cmd *cobra.Command, err error) error {
+	silenceForStructuredErrors(cmd)
+	return &usageError{err: err}
+}
+
+// structuredErrorOutput reports whether cmd reports its errors as JSON,
+// i.e. its --output flag selects JSON or NDJSON.
+func structuredErrorOutput(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	flag := cmd.Flag("output")
+	if flag == nil {
+		return false
+	}
+	format := engine.OutputFormat(config.GetOutputFormat(flag.Value.String()))
+	return format == engine.OutputJSON || format == engine.OutputNDJSON
+}
+
+// silenceForStructuredErrors stops cobra from printing the error and usage
+// of cmd when cmd reports its errors as JSON, so that the error report is the
+// only error output.
+func silenceForStructuredErrors(cmd *cobra.Command) {
+	if structuredErrorOutput(cmd) {
+		root := cmd.Root()
+		root.SilenceErrors = true
+		root.SilenceUsage = true
+	}
+}
+
+// WriteErrorReport writes err, which made cmd fail with exitCode, to w as a
+// JSON engine.ErrorEnvelope when cmd reports its errors as JSON. It returns
+// false, writing nothing, otherwise.
+func WriteErrorReport(w io.Writer, cmd *cobra.Command, err error, exitCode int) bool {
+	if err == nil || !structuredErrorOutput(cmd) {
+		return false
+	}
+	report := engine.ErrorReport{Code: errorReportCode(err)}
+	var partialErr *engine.PartialFailureError
+	if errors.As(err, &partialErr) {
+		report = *partialErr.Report
+	}
+	report.Message = err.Error()
+	report.ExitCode = exitCode
+	return json.NewEncoder(w).Encode(engine.NewErrorEnvelope(report)) == nil
+}
+
+// writePartialErrorReport writes the partial failures of a command that
+// otherwise succeeded to the error output of cmd, when cmd reports its
+// errors as JSON and there are failures.
+func writePartialErrorReport(cmd *cobra.Command, report *engine.ErrorReport) {
+	if report == nil || !structuredErrorOutput(cmd) {
+		return
+	}
+	_ = json.NewEncoder(cmd.ErrOrStderr()).Encode(engine.NewErrorEnvelope(*report))
+}
+
+// addOnErrorFlag registers the --on-error flag of the commands that calculate
+// costs, which sets the engine.ErrorPolicy for failed resources.
+func addOnErrorFlag(cmd *cobra.Command, onError *string) {
+	cmd.Flags().StringVar(onError, "on-error", string(engine.ErrorPolicyZero),
+		"How failed resources count in totals: fail the command, omit them, or keep them as $0 (zero)")
+}
+
+// parseOnError parses the --on-error flag.
+func parseOnError(onError string) (engine.ErrorPolicy, error) {
+	policy, err := engine.ParseErrorPolicy(onError)
+	if err != nil {
+		return "", &usageError{err: fmt.Errorf("invalid --on-error: %w", err)}
+	}
+	return policy, nil
+}
+
+// errorReportCode classifies the error of a failed command.
+func errorReportCode(err error) string {
+	var (
+		usageErr   *usageError
+		budgetErr  *BudgetExitError
+		policyErr  *PolicyViolationError
+		partialErr *engine.PartialFailureError
+	)
+	switch {
+	case errors.As(err, &usageErr):
+		return engine.ErrCodeUsageError
+	case errors.As(err, &partialErr):
+		return engine.ErrCodePartialFailure
+	case errors.As(err, &budgetErr):
+		return engine.ErrCodeBudgetExceeded
+	case errors.As(err, &policyErr):
+		return engine.ErrCodePolicyViolation
+	case errors.Is(err, context.DeadlineExceeded):
+		return engine.ErrCodeTimeoutError
+	default:
+		return engine.ErrCodeCommandFailed
+	}
+}
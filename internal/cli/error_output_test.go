@@ -0,0 +1,161 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// newOutputCmd returns a command with an --output flag set to format.
+func newOutputCmd(t *testing.T, format string) *cobra.Command {
+	t.Helper()
+	cmd := &cobra.Command{Use: "test"}
+	cmd.Flags().String("output", "table", "")
+	require.NoError(t, cmd.Flags().Set("output", format))
+	return cmd
+}
+
+func TestWriteErrorReport(t *testing.T) {
+	var buf bytes.Buffer
+	assert.False(t, WriteErrorReport(&buf, newOutputCmd(t, "table"), errors.New("boom"), 1))
+	assert.False(t, WriteErrorReport(&buf, &cobra.Command{Use: "test"}, errors.New("boom"), 1))
+	assert.False(t, WriteErrorReport(&buf, nil, errors.New("boom"), 1))
+	assert.Empty(t, buf.String())
+
+	tests := []struct {
+		name string
+		err  error
+		code string
+	}{
+		{"generic", errors.New("boom"), engine.ErrCodeCommandFailed},
+		{"usage", &usageError{err: errors.New("unknown flag: --bogus")}, engine.ErrCodeUsageError},
+		{
+			"budget", fmt.Errorf("check: %w", &BudgetExitError{ExitCode: 2, Reason: "over"}),
+			engine.ErrCodeBudgetExceeded,
+		},
+		{"policy", &PolicyViolationError{ExitCode: 3, Violations: 2}, engine.ErrCodePolicyViolation},
+		{"timeout", fmt.Errorf("plugin: %w", context.DeadlineExceeded), engine.ErrCodeTimeoutError},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			require.True(t, WriteErrorReport(&out, newOutputCmd(t, "ndjson"), tt.err, 4))
+
+			var envelope engine.ErrorEnvelope
+			require.NoError(t, json.Unmarshal(out.Bytes(), &envelope))
+			assert.Equal(t, engine.ErrorReportSchema, envelope.Schema)
+			assert.Equal(t, tt.code, envelope.Error.Code)
+			assert.Equal(t, tt.err.Error(), envelope.Error.Message)
+			assert.Equal(t, 4, envelope.Error.ExitCode)
+		})
+	}
+}
+
+func TestRenderCostOutput_PartialErrorReport(t *testing.T) {
+	result := &engine.CostResultWithErrors{
+		Results: []engine.CostResult{{ResourceType: "aws:ec2:Instance", ResourceID: "web", Monthly: 10}},
+		Errors: []engine.ErrorDetail{{
+			ResourceType: "aws:rds:Instance", ResourceID: "db", PluginName: "aws-public",
+			Error: errors.New("plugin call failed: boom"),
+		}},
+	}
+
+	cmd := newOutputCmd(t, "json")
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+	require.NoError(t, RenderCostOutput(context.Background(), cmd, "json", result, nil))
+
+	var envelope engine.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(errOut.Bytes(), &envelope))
+	assert.Equal(t, engine.ErrCodePartialFailure, envelope.Error.Code)
+	assert.Equal(t, []string{"aws-public"}, envelope.Error.Plugins)
+	require.Len(t, envelope.Error.Failures, 1)
+	assert.Equal(t, "db", envelope.Error.Failures[0].ResourceID)
+	assert.Contains(t, out.String(), `"resourceId": "web"`)
+
+	// Without failures nothing is written to the error output.
+	errOut.Reset()
+	result.Errors = nil
+	require.NoError(t, RenderCostOutput(context.Background(), cmd, "json", result, nil))
+	assert.Empty(t, errOut.String())
+}
+
+func TestRootCmd_StructuredErrorsSilenceCobra(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_HOME", filepath.Join(home, ".finfocus"))
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_SKIP_MIGRATION_CHECK", "1")
+
+	run := func(args ...string) (*cobra.Command, string, error) {
+		root := NewRootCmd("test")
+		var errOut bytes.Buffer
+		root.SetOut(&bytes.Buffer{})
+		root.SetErr(&errOut)
+		root.SetArgs(args)
+		cmd, execErr := root.ExecuteC()
+		return cmd, errOut.String(), execErr
+	}
+
+	cmd, errOut, err := run("cost", "simulate", "schedule", "--cron", "bad", "--output", "json")
+	require.Error(t, err)
+	assert.NotContains(t, errOut, "Error:", "cobra should not print the error")
+	assert.NotContains(t, errOut, "Usage:", "cobra should not print the usage")
+	var buf bytes.Buffer
+	require.True(t, WriteErrorReport(&buf, cmd, err, 1))
+	assert.Contains(t, buf.String(), `"code":"COMMAND_FAILED"`)
+
+	cmd, errOut, err = run("cost", "simulate", "schedule", "--output", "json", "--bogus")
+	require.Error(t, err)
+	assert.NotContains(t, errOut, "Error:")
+	assert.Equal(t, engine.ErrCodeUsageError, errorReportCode(err))
+	assert.True(t, structuredErrorOutput(cmd))
+
+	_, errOut, err = run("cost", "simulate", "schedule", "--cron", "bad")
+	require.Error(t, err)
+	assert.Contains(t, errOut, "Error: invalid --cron")
+}
+
+func TestWriteErrorReport_PartialFailure(t *testing.T) {
+	result := &engine.CostResultWithErrors{
+		Results: []engine.CostResult{{ResourceID: "db", Adapter: "none"}},
+		Errors: []engine.ErrorDetail{{
+			ResourceType: "aws:rds:Instance", ResourceID: "db", PluginName: "aws-public",
+			Error: errors.New("plugin call failed: boom"),
+		}},
+	}
+	err := result.ApplyErrorPolicy(engine.ErrorPolicyFail)
+	require.Error(t, err)
+
+	var out bytes.Buffer
+	require.True(t, WriteErrorReport(&out, newOutputCmd(t, "json"), err, 1))
+	var envelope engine.ErrorEnvelope
+	require.NoError(t, json.Unmarshal(out.Bytes(), &envelope))
+	assert.Equal(t, engine.ErrCodePartialFailure, envelope.Error.Code)
+	assert.Equal(t, "1 resource(s) failed (--on-error fail)", envelope.Error.Message)
+	assert.Equal(t, 1, envelope.Error.ExitCode)
+	assert.Equal(t, []string{"aws-public"}, envelope.Error.Plugins)
+	require.Len(t, envelope.Error.Failures, 1)
+}
+
+func TestParseOnError(t *testing.T) {
+	policy, err := parseOnError("omit")
+	require.NoError(t, err)
+	assert.Equal(t, engine.ErrorPolicyOmit, policy)
+
+	_, err = parseOnError("ignore")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --on-error")
+	assert.Equal(t, engine.ErrCodeUsageError, errorReportCode(err))
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/filterexpr"
 	"github.com/rshade/finfocus/internal/logging"
 )
 
@@ -33,8 +34,9 @@ var ErrInvalidBudgetFilter = errors.New("invalid budget filter syntax")
 // An empty filter slice returns the original resources unchanged.
 // A warning is logged if the filtered result is empty.
 //
-// Filter syntax follows engine.ValidateFilter rules: "key=value" format
-// (e.g., "type=aws:ec2/instance", "tag:env=prod").
+// Each filter is either a "key=value" filter following engine.ValidateFilter
+// rules (e.g., "type=aws:ec2/instance", "tag:env=prod") or a filter expression
+// over engine.ResourceFilterSchema fields (e.g., `provider == "aws" && tag.env =~ "prod*"`).
 func ApplyFilters(
 	ctx context.Context,
 	resources []engine.ResourceDescriptor,
@@ -46,12 +48,19 @@ func ApplyFilters(
 		return resources, nil
 	}
 
-	// Validate all filters upfront
-	for _, f := range filters {
+	// Validate all filters upfront, parsing expressions once
+	exprs := make([]*filterexpr.Expr, len(filters))
+	for i, f := range filters {
 		if f == "" {
 			continue
 		}
-		if err := engine.ValidateFilter(f); err != nil {
+		var err error
+		if isKeyValueFilter(f) {
+			err = engine.ValidateFilter(f)
+		} else {
+			exprs[i], err = filterexpr.Parse(f, engine.ResourceFilterSchema())
+		}
+		if err != nil {
 			log.Warn().Ctx(ctx).
 				Str("component", "cli").
 				Str("operation", "apply_filters").
@@ -64,12 +73,16 @@ func ApplyFilters(
 
 	// Apply filters sequentially
 	result := resources
-	for _, f := range filters {
+	for i, f := range filters {
 		if f == "" {
 			continue
 		}
 		before := len(result)
-		result = engine.FilterResources(result, f)
+		if exprs[i] != nil {
+			result = engine.FilterResourcesByExpr(result, exprs[i])
+		} else {
+			result = engine.FilterResources(result, f)
+		}
 		log.Debug().Ctx(ctx).
 			Str("component", "cli").
 			Str("operation", "apply_filters").
@@ -90,13 +103,34 @@ func ApplyFilters(
 	return result, nil
 }
 
+// isKeyValueFilter reports whether a filter uses the key=value syntax, such as
+// "type=aws:ec2/instance", "tag:env=prod", or "action=MIGRATE", rather than
+// the filter expression syntax. Key=value filters contain a single "=" and no
+// expression operators, or start with the "tag:" prefix.
+func isKeyValueFilter(filter string) bool {
+	if strings.HasPrefix(strings.TrimSpace(filter), "tag:") {
+		return true
+	}
+	if !strings.Contains(filter, "=") {
+		return false
+	}
+	for _, op := range []string{"==", "!=", "=~", ">=", "<=", "&&", "||"} {
+		if strings.Contains(filter, op) {
+			return false
+		}
+	}
+	return true
+}
+
 // ParseBudgetFilters parses a slice of filter strings into BudgetFilterOptions.
 // Filter syntax:
 //   - "provider=<name>": Filter by provider (e.g., "provider=kubecost")
 //   - "tag:<key>=<value>": Filter by metadata tag (e.g., "tag:namespace=production")
+//   - A filter expression over engine.BudgetFilterSchema fields
+//     (e.g., `amount >= 1000 && tag.namespace =~ "prod-*"`)
 //
 // Provider filters use OR logic (any provider matches).
-// Tag filters use AND logic (all tags must match).
+// Tag filters and expressions use AND logic (all must match).
 // Tag values support glob patterns (e.g., "tag:namespace=prod-*").
 //
 // Returns an error if any filter has invalid syntax per ValidateBudgetFilter,
@@ -119,6 +153,15 @@ func ParseBudgetFilters(ctx context.Context, filters []string) (*engine.BudgetFi
 			continue
 		}
 
+		if !isKeyValueFilter(f) {
+			expr, err := filterexpr.Parse(f, engine.BudgetFilterSchema())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %w", ErrInvalidBudgetFilter, err)
+			}
+			opts.Expressions = append(opts.Expressions, expr)
+			continue
+		}
+
 		// Validate filter syntax
 		if err := ValidateBudgetFilter(f); err != nil {
 			log.Debug().Ctx(ctx).
@@ -141,6 +184,7 @@ func ParseBudgetFilters(ctx context.Context, filters []string) (*engine.BudgetFi
 		Str("operation", "parse_budget_filters").
 		Strs("providers", opts.Providers).
 		Int("tag_count", len(opts.Tags)).
+		Int("expression_count", len(opts.Expressions)).
 		Msg("parsed budget filters")
 
 	return opts, nil
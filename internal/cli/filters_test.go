@@ -78,6 +78,27 @@ func TestApplyFilters(t *testing.T) {
 			wantCount: 0,
 			wantErr:   false,
 		},
+		{
+			name:      "filter expression",
+			resources: resources,
+			filters:   []string{`provider == "aws" && type !~ "*rds*"`},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name:      "filter expression combined with key=value filter",
+			resources: resources,
+			filters:   []string{"provider=aws", `id =~ "db-*" || service == "compute"`},
+			wantCount: 1,
+			wantErr:   false,
+		},
+		{
+			name:          "invalid filter expression returns error",
+			resources:     resources,
+			filters:       []string{`provider == aws`},
+			wantErr:       true,
+			wantErrSubstr: `quote the value: provider == "aws"`,
+		},
 		{
 			name:          "invalid filter syntax returns error",
 			resources:     resources,
@@ -244,6 +265,24 @@ func TestParseBudgetFilters_ValidTagKeyValue(t *testing.T) {
 	}
 }
 
+func TestParseBudgetFilters_Expressions(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	opts, err := cli.ParseBudgetFilters(ctx, []string{
+		"provider=kubecost",
+		`amount >= 1000 && tag.namespace =~ "prod-*"`,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"kubecost"}, opts.Providers)
+	require.Len(t, opts.Expressions, 1)
+	assert.Equal(t, `amount >= 1000 && tag.namespace =~ "prod-*"`, opts.Expressions[0].String())
+
+	_, err = cli.ParseBudgetFilters(ctx, []string{`amount > "big"`})
+	require.ErrorIs(t, err, cli.ErrInvalidBudgetFilter)
+	assert.Contains(t, err.Error(), "number field")
+}
+
 func TestValidateBudgetFilter_Errors(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,450 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/policy"
+)
+
+// Git hook types supported by 'hooks install'.
+const (
+	hookTypePrePush   = "pre-push"
+	hookTypePreCommit = "pre-commit"
+)
+
+// hookMarker identifies hooks written by 'hooks install', which may be
+// overwritten without --force.
+const hookMarker = "# finfocus-hook"
+
+// hookFilePerm makes the hook executable, as git requires.
+const hookFilePerm os.FileMode = 0o755
+
+// hooksInstallParams holds the parameters for the hooks install command execution.
+type hooksInstallParams struct {
+	hookType      string
+	policyPath    string
+	policyPackDir string
+	repoDir       string
+	force         bool
+}
+
+// newHooksCmd creates the hooks command group.
+func newHooksCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "hooks", Short: "Git hook commands"}
+	cmd.AddCommand(NewHooksInstallCmd())
+	return cmd
+}
+
+// NewHooksInstallCmd creates the "install" subcommand, which writes a git hook
+// that prices changed Pulumi stacks and checks them against cost policies.
+func NewHooksInstallCmd() *cobra.Command {
+	var params hooksInstallParams
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install a git hook that gates pushes or commits on cost checks",
+		Long: `Install a git pre-push or pre-commit hook that gates changes on cost checks.
+
+The hook finds the Pulumi projects containing the changed files, runs
+'pulumi preview' for each, prices the plan with 'cost projected', and, when
+--policy is given, checks it with 'policy check'. A changed Pulumi.<stack>.yaml
+checks that stack; other changes check the project's selected stack. The push
+or commit is rejected when any check fails.
+
+The hook calls finfocus from PATH, or $FINFOCUS when set. Skip it once with
+'git push --no-verify' or FINFOCUS_SKIP_HOOK=1. An existing hook that was not
+written by finfocus is only replaced with --force.
+
+--policy-pack also writes a Pulumi policy pack stub to the given directory. It
+runs 'policy check' on the resources of every 'pulumi preview' and 'pulumi up'
+run with '--policy-pack <dir>', so deployments are gated as well.`,
+		Example: `  # Check changed stacks before every push
+  finfocus hooks install --policy policies.yaml
+
+  # Check staged changes before every commit
+  finfocus hooks install --type pre-commit --policy policies.yaml
+
+  # Also write a Pulumi policy pack for deployments
+  finfocus hooks install --policy policies.yaml --policy-pack finfocus-policy`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeHooksInstall(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.hookType, "type", hookTypePrePush, "Hook type: pre-push or pre-commit")
+	cmd.Flags().StringVar(&params.policyPath, "policy", "", "Policy file the hook checks plans against")
+	cmd.Flags().StringVar(&params.policyPackDir, "policy-pack", "",
+		"Directory to write a Pulumi policy pack stub to (requires --policy)")
+	cmd.Flags().StringVar(&params.repoDir, "repo", ".", "Path inside the git repository")
+	cmd.Flags().BoolVar(&params.force, "force", false, "Overwrite an existing hook or policy pack")
+
+	return cmd
+}
+
+// executeHooksInstall writes the git hook and, if requested, the policy pack.
+func executeHooksInstall(cmd *cobra.Command, params hooksInstallParams) error {
+	if params.hookType != hookTypePrePush && params.hookType != hookTypePreCommit {
+		return fmt.Errorf("unsupported hook type %q: use %s or %s", params.hookType, hookTypePrePush, hookTypePreCommit)
+	}
+	if params.policyPackDir != "" && params.policyPath == "" {
+		return errors.New("--policy-pack requires --policy")
+	}
+
+	root, hooksDir, err := findGitHooksDir(params.repoDir)
+	if err != nil {
+		return err
+	}
+
+	var policyAbs, policyRel string
+	if params.policyPath != "" {
+		if _, err = policy.Load(params.policyPath); err != nil {
+			return err
+		}
+		if policyAbs, err = filepath.Abs(params.policyPath); err != nil {
+			return fmt.Errorf("resolving policy path: %w", err)
+		}
+		policyRel = repoRelativePath(root, policyAbs)
+	}
+
+	hookPath := filepath.Join(hooksDir, params.hookType)
+	script, err := renderHookScript(params.hookType, policyRel)
+	if err != nil {
+		return err
+	}
+	if err = writeHook(hookPath, script, params.force); err != nil {
+		return err
+	}
+	cmd.Printf("Installed %s hook: %s\n", params.hookType, hookPath)
+
+	if params.policyPackDir != "" {
+		if err = writePolicyPack(params.policyPackDir, policyAbs, params.force); err != nil {
+			return err
+		}
+		cmd.Printf("Wrote Pulumi policy pack: %s\n", params.policyPackDir)
+		cmd.Printf("  Install its dependencies with 'npm install' in that directory, then run\n")
+		cmd.Printf("  'pulumi preview --policy-pack %s'\n", params.policyPackDir)
+	}
+	return nil
+}
+
+// findGitHooksDir returns the root of the git work tree containing dir and
+// the directory its hooks are read from. Linked work trees share the hooks
+// of their main repository.
+func findGitHooksDir(dir string) (string, string, error) {
+	current, err := filepath.Abs(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("resolving repository path: %w", err)
+	}
+	for {
+		gitPath := filepath.Join(current, ".git")
+		info, statErr := os.Stat(gitPath)
+		if statErr == nil {
+			if info.IsDir() {
+				return current, filepath.Join(gitPath, "hooks"), nil
+			}
+			gitDir, linkErr := linkedGitDir(current, gitPath)
+			if linkErr != nil {
+				return "", "", linkErr
+			}
+			return current, filepath.Join(gitDir, "hooks"), nil
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", "", fmt.Errorf("not inside a git repository: %s", dir)
+		}
+		current = parent
+	}
+}
+
+// linkedGitDir resolves the "gitdir:" file of a linked work tree or submodule
+// to the git directory holding its hooks.
+func linkedGitDir(root, gitFile string) (string, error) {
+	data, err := os.ReadFile(gitFile)
+	if err != nil {
+		return "", fmt.Errorf("reading %s: %w", gitFile, err)
+	}
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", fmt.Errorf("unrecognized .git file: %s", gitFile)
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(root, gitDir)
+	}
+	// Work trees keep their hooks in the common directory of the repository.
+	if common, readErr := os.ReadFile(filepath.Join(gitDir, "commondir")); readErr == nil {
+		commonDir := strings.TrimSpace(string(common))
+		if !filepath.IsAbs(commonDir) {
+			commonDir = filepath.Join(gitDir, commonDir)
+		}
+		return filepath.Clean(commonDir), nil
+	}
+	return gitDir, nil
+}
+
+// repoRelativePath returns path relative to the repository root, where the
+// hook runs, or path itself when it lies outside the repository.
+func repoRelativePath(root, path string) string {
+	rel, err := filepath.Rel(root, path)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
+
+// writeHook writes an executable hook script, refusing to replace a hook that
+// was not written by finfocus unless force is set.
+func writeHook(path, script string, force bool) error {
+	existing, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		if !force && !strings.Contains(string(existing), hookMarker) {
+			return fmt.Errorf("hook already exists: %s (use --force to overwrite)", path)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("reading existing hook: %w", err)
+	}
+
+	if err = os.MkdirAll(filepath.Dir(path), pluginDirPerm); err != nil {
+		return fmt.Errorf("creating hooks directory: %w", err)
+	}
+	//nolint:gosec // Git only runs executable hooks.
+	if err = os.WriteFile(path, []byte(script), hookFilePerm); err != nil {
+		return fmt.Errorf("writing hook: %w", err)
+	}
+	// WriteFile keeps the mode of an existing file.
+	if err = os.Chmod(path, hookFilePerm); err != nil { //nolint:gosec // Git only runs executable hooks.
+		return fmt.Errorf("making hook executable: %w", err)
+	}
+	return nil
+}
+
+// writePolicyPack writes the Pulumi policy pack stub to dir.
+func writePolicyPack(dir, policyPath string, force bool) error {
+	if _, err := os.Stat(filepath.Join(dir, "PulumiPolicy.yaml")); err == nil && !force {
+		return fmt.Errorf("policy pack already exists: %s (use --force to overwrite)", dir)
+	}
+	if err := os.MkdirAll(dir, pluginDirPerm); err != nil {
+		return fmt.Errorf("creating policy pack directory: %w", err)
+	}
+
+	var index bytes.Buffer
+	if err := policyPackIndex.Execute(&index, map[string]string{"Policy": policyPath}); err != nil {
+		return fmt.Errorf("rendering policy pack: %w", err)
+	}
+	files := map[string]string{
+		"PulumiPolicy.yaml": policyPackManifest,
+		"package.json":      policyPackPackageJSON,
+		"index.ts":          index.String(),
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), pluginFilePerm); err != nil {
+			return fmt.Errorf("writing policy pack %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// renderHookScript renders the hook script for hookType. policyPath is
+// relative to the repository root, or empty to skip policy checks.
+func renderHookScript(hookType, policyPath string) (string, error) {
+	var buf bytes.Buffer
+	err := hookScript.Execute(&buf, map[string]string{
+		"Type":   hookType,
+		"Action": strings.TrimPrefix(hookType, "pre-"),
+		"Policy": policyPath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("rendering hook: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// shellQuote quotes s as a single POSIX shell word.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// hookScript is the POSIX shell script installed as the git hook.
+//
+//nolint:gochecknoglobals // Parsed once; template text is static.
+var hookScript = template.Must(template.New("hook").Funcs(template.FuncMap{
+	"shell": shellQuote,
+}).Parse(`#!/bin/sh
+# finfocus-hook: {{.Type}}
+# Prices the Pulumi stacks changed by this {{.Action}} and checks them against
+# cost policies. Written by 'finfocus hooks install'; re-run it to update.
+# Skip once with 'git {{.Action}} --no-verify' or FINFOCUS_SKIP_HOOK=1.
+set -u
+
+[ "${FINFOCUS_SKIP_HOOK:-0}" = "1" ] && exit 0
+
+FINFOCUS="${FINFOCUS:-finfocus}"
+POLICY={{shell .Policy}}
+ROOT="$(git rev-parse --show-toplevel)" || exit 1
+cd "$ROOT" || exit 1
+
+changed_files() {
+{{- if eq .Type "pre-push"}}
+	while read -r _local_ref local_sha _remote_ref remote_sha; do
+		# An all-zero local object name deletes the remote ref.
+		case "$local_sha" in *[!0]*) ;; *) continue ;; esac
+		case "$remote_sha" in
+		*[!0]*) git diff --name-only "$remote_sha" "$local_sha" ;;
+		*) git log --name-only --format= "$local_sha" --not --remotes ;;
+		esac
+	done
+{{- else}}
+	git diff --cached --name-only
+{{- end}}
+}
+
+# stack_for prints "<project dir><tab><stack>" for a file inside a Pulumi
+# project. The stack is empty for the project's selected stack.
+stack_for() {
+	dir=$(dirname "$1")
+	while :; do
+		if [ -f "$dir/Pulumi.yaml" ] || [ -f "$dir/Pulumi.yml" ]; then
+			stack=""
+			if [ "$(dirname "$1")" = "$dir" ]; then
+				case "$(basename "$1")" in
+				Pulumi.*.yaml | Pulumi.*.yml)
+					stack=$(basename "$1" | sed -e 's/^Pulumi\.//' -e 's/\.yaml$//' -e 's/\.yml$//')
+					;;
+				esac
+			fi
+			printf '%s\t%s\n' "$dir" "$stack"
+			return
+		fi
+		if [ "$dir" = "." ] || [ "$dir" = "/" ]; then
+			return
+		fi
+		dir=$(dirname "$dir")
+	done
+}
+
+check_stack() {
+	dir=$1
+	stack=$2
+	label=$dir
+	[ -n "$stack" ] && label="$dir ($stack)"
+	echo "finfocus: checking $label"
+
+	plan=$(mktemp) || return 1
+	if [ -n "$stack" ]; then
+		(cd "$dir" && pulumi preview --json --stack "$stack") >"$plan"
+	else
+		(cd "$dir" && pulumi preview --json) >"$plan"
+	fi || {
+		echo "finfocus: pulumi preview failed for $label" >&2
+		rm -f "$plan"
+		return 1
+	}
+
+	status=0
+	"$FINFOCUS" cost projected --pulumi-json "$plan" || status=1
+	if [ -n "$POLICY" ]; then
+		if [ -n "$stack" ]; then
+			"$FINFOCUS" policy check --pulumi-json "$plan" --policy "$POLICY" --stack "$stack" || status=1
+		else
+			"$FINFOCUS" policy check --pulumi-json "$plan" --policy "$POLICY" || status=1
+		fi
+	fi
+	rm -f "$plan"
+	return $status
+}
+
+stacks=$(changed_files | while IFS= read -r file; do stack_for "$file"; done | sort -u)
+[ -z "$stacks" ] && exit 0
+
+failed=0
+tab=$(printf '\t')
+while IFS="$tab" read -r dir stack; do
+	check_stack "$dir" "$stack" </dev/null || failed=1
+done <<EOF
+$stacks
+EOF
+
+if [ "$failed" -ne 0 ]; then
+	echo "finfocus: cost checks failed; fix them or skip with 'git {{.Action}} --no-verify'" >&2
+	exit 1
+fi
+`))
+
+// policyPackManifest is the PulumiPolicy.yaml of the policy pack stub.
+const policyPackManifest = `description: FinFocus cost guardrails
+runtime: nodejs
+`
+
+// policyPackPackageJSON is the package.json of the policy pack stub.
+const policyPackPackageJSON = `{
+  "name": "finfocus-cost-policy",
+  "version": "0.1.0",
+  "main": "index.ts",
+  "dependencies": {
+    "@pulumi/policy": "^1.13.0",
+    "@pulumi/pulumi": "^3.0.0"
+  }
+}
+`
+
+// policyPackIndex is the index.ts of the policy pack stub. Its stack policy
+// writes the stack's resources as a preview plan and runs 'policy check'.
+//
+//nolint:gochecknoglobals // Parsed once; template text is static.
+var policyPackIndex = template.Must(template.New("policy-pack").Funcs(template.FuncMap{
+	"json": func(s string) (string, error) {
+		data, err := json.Marshal(s)
+		return string(data), err
+	},
+}).Parse(`// Written by 'finfocus hooks install'. Runs 'finfocus policy check' on the
+// resources of every preview and update that uses this policy pack.
+import { spawnSync } from "child_process";
+import * as fs from "fs";
+import * as os from "os";
+import * as path from "path";
+import { PolicyPack } from "@pulumi/policy";
+
+const finfocus = process.env.FINFOCUS || "finfocus";
+const policyFile = process.env.FINFOCUS_POLICY || {{json .Policy}};
+
+new PolicyPack("finfocus-cost", {
+    policies: [{
+        name: "finfocus-cost-policy",
+        description: "Checks projected costs against FinFocus cost policies.",
+        enforcementLevel: "mandatory",
+        validateStack: (args, reportViolation) => {
+            const dir = fs.mkdtempSync(path.join(os.tmpdir(), "finfocus-"));
+            const plan = path.join(dir, "plan.json");
+            const steps = args.resources.map(r => ({
+                op: "create",
+                urn: r.urn,
+                type: r.type,
+                newState: { type: r.type, urn: r.urn, inputs: r.props },
+            }));
+            fs.writeFileSync(plan, JSON.stringify({ steps }));
+            try {
+                const result = spawnSync(finfocus,
+                    ["policy", "check", "--pulumi-json", plan, "--policy", policyFile],
+                    { encoding: "utf8" });
+                if (result.error) {
+                    reportViolation("running finfocus: " + result.error.message);
+                } else if (result.status !== 0) {
+                    reportViolation(result.stdout + result.stderr);
+                }
+            } finally {
+                fs.rmSync(dir, { recursive: true, force: true });
+            }
+        },
+    }],
+});
+`))
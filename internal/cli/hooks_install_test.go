@@ -0,0 +1,151 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newHooksTestRepo creates a directory with an empty .git directory and a
+// valid policy file, and returns its path.
+func newHooksTestRepo(t *testing.T) string {
+	t.Helper()
+	repo := t.TempDir()
+	require.NoError(t, os.MkdirAll(filepath.Join(repo, ".git"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(repo, "policies.yaml"),
+		[]byte("policies: [{name: cap, max_monthly_cost: 100}]"), 0o600))
+	return repo
+}
+
+func runHooksInstall(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	cmd := NewHooksInstallCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestHooksInstall_PrePush(t *testing.T) {
+	repo := newHooksTestRepo(t)
+
+	out, err := runHooksInstall(t, "--repo", repo, "--policy", filepath.Join(repo, "policies.yaml"))
+	require.NoError(t, err)
+
+	hookPath := filepath.Join(repo, ".git", "hooks", "pre-push")
+	assert.Contains(t, out, "Installed pre-push hook: "+hookPath)
+
+	info, err := os.Stat(hookPath)
+	require.NoError(t, err)
+	assert.Equal(t, hookFilePerm, info.Mode().Perm())
+
+	script, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "#!/bin/sh\n"+hookMarker+": pre-push")
+	assert.Contains(t, string(script), "POLICY='policies.yaml'", "policy path is relative to the repository root")
+	assert.Contains(t, string(script), "--not --remotes")
+	assert.NotContains(t, string(script), "--cached")
+}
+
+func TestHooksInstall_PreCommitWithoutPolicy(t *testing.T) {
+	repo := newHooksTestRepo(t)
+	sub := filepath.Join(repo, "infra", "app")
+	require.NoError(t, os.MkdirAll(sub, 0o750))
+
+	_, err := runHooksInstall(t, "--repo", sub, "--type", "pre-commit")
+	require.NoError(t, err)
+
+	script, err := os.ReadFile(filepath.Join(repo, ".git", "hooks", "pre-commit"))
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "git diff --cached --name-only")
+	assert.Contains(t, string(script), "POLICY=''")
+	assert.Contains(t, string(script), "git commit --no-verify")
+}
+
+func TestHooksInstall_ExistingHook(t *testing.T) {
+	repo := newHooksTestRepo(t)
+	hookPath := filepath.Join(repo, ".git", "hooks", "pre-push")
+	require.NoError(t, os.MkdirAll(filepath.Dir(hookPath), 0o750))
+	require.NoError(t, os.WriteFile(hookPath, []byte("#!/bin/sh\nmake lint\n"), 0o600))
+
+	_, err := runHooksInstall(t, "--repo", repo)
+	require.ErrorContains(t, err, "use --force to overwrite")
+
+	_, err = runHooksInstall(t, "--repo", repo, "--force")
+	require.NoError(t, err)
+
+	// A hook written by finfocus is replaced without --force.
+	_, err = runHooksInstall(t, "--repo", repo, "--policy", filepath.Join(repo, "policies.yaml"))
+	require.NoError(t, err)
+	script, err := os.ReadFile(hookPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(script), "POLICY='policies.yaml'")
+}
+
+func TestHooksInstall_PolicyPack(t *testing.T) {
+	repo := newHooksTestRepo(t)
+	packDir := filepath.Join(t.TempDir(), "pack")
+	policyPath := filepath.Join(repo, "policies.yaml")
+
+	out, err := runHooksInstall(t, "--repo", repo, "--policy", policyPath, "--policy-pack", packDir)
+	require.NoError(t, err)
+	assert.Contains(t, out, "Wrote Pulumi policy pack: "+packDir)
+
+	for _, name := range []string{"PulumiPolicy.yaml", "package.json"} {
+		assert.FileExists(t, filepath.Join(packDir, name))
+	}
+	index, err := os.ReadFile(filepath.Join(packDir, "index.ts"))
+	require.NoError(t, err)
+	assert.Contains(t, string(index), `process.env.FINFOCUS_POLICY || "`+policyPath+`"`)
+	assert.Contains(t, string(index), `"policy", "check", "--pulumi-json"`)
+
+	_, err = runHooksInstall(t, "--repo", repo, "--policy", policyPath, "--policy-pack", packDir)
+	require.ErrorContains(t, err, "policy pack already exists")
+}
+
+func TestHooksInstall_InvalidInput(t *testing.T) {
+	repo := newHooksTestRepo(t)
+	invalidPolicy := filepath.Join(repo, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalidPolicy, []byte("policies: []"), 0o600))
+
+	tests := map[string][]string{
+		"hook type":           {"--repo", repo, "--type", "post-commit"},
+		"pack without policy": {"--repo", repo, "--policy-pack", t.TempDir()},
+		"invalid policy":      {"--repo", repo, "--policy", invalidPolicy},
+		"not a repository":    {"--repo", t.TempDir()},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := runHooksInstall(t, args...)
+			require.Error(t, err)
+		})
+	}
+	assert.NoFileExists(t, filepath.Join(repo, ".git", "hooks", "pre-push"))
+}
+
+func TestFindGitHooksDir_LinkedWorktree(t *testing.T) {
+	main := t.TempDir()
+	gitDir := filepath.Join(main, ".git", "worktrees", "feature")
+	require.NoError(t, os.MkdirAll(gitDir, 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(gitDir, "commondir"), []byte("../..\n"), 0o600))
+
+	worktree := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(worktree, ".git"), []byte("gitdir: "+gitDir+"\n"), 0o600))
+
+	root, hooksDir, err := findGitHooksDir(worktree)
+	require.NoError(t, err)
+	assert.Equal(t, worktree, root)
+	assert.Equal(t, filepath.Join(main, ".git", "hooks"), hooksDir)
+}
+
+func TestShellQuote(t *testing.T) {
+	assert.Equal(t, `'policies.yaml'`, shellQuote("policies.yaml"))
+	assert.Equal(t, `'it'\''s here'`, shellQuote("it's here"))
+	assert.Equal(t, `''`, shellQuote(""))
+}
@@ -0,0 +1,321 @@
+package cli
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// initParams holds the parameters of the init command.
+type initParams struct {
+	force    bool
+	defaults bool
+}
+
+// NewInitCmd creates the init command, a guided setup that writes a commented
+// configuration file.
+func NewInitCmd() *cobra.Command {
+	var params initParams
+
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up finfocus with a guided configuration wizard",
+		Long: `Guide first-time setup: detect the installed plugins, then ask for the
+default output format, the cache TTL, the currency, and a monthly budget, and
+write the answers to a commented config.yaml in the finfocus home (normally
+~/.finfocus/config.yaml).
+
+Each question shows its default in brackets; press Enter to accept it. When
+a configuration file exists, its values are the defaults and settings the
+wizard does not ask about are kept. With --defaults, no questions are asked.`,
+		Example: `  # Run the setup wizard
+  finfocus init
+
+  # Write the default configuration without asking
+  finfocus init --defaults
+
+  # Rerun the wizard over an existing configuration without confirming
+  finfocus init --force`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeInit(cmd, params)
+		},
+	}
+
+	cmd.Flags().BoolVar(&params.force, "force", false, "Overwrite an existing configuration file without confirming")
+	cmd.Flags().BoolVar(&params.defaults, "defaults", false, "Accept the default answers without asking")
+
+	return cmd
+}
+
+// initWizard asks the questions of the init command, reading answers line by
+// line from in.
+type initWizard struct {
+	cmd      *cobra.Command
+	in       *bufio.Reader
+	defaults bool
+}
+
+// executeInit runs the setup wizard and writes the configuration file.
+func executeInit(cmd *cobra.Command, params initParams) error {
+	cfg := config.NewUserConfig()
+	wizard := &initWizard{cmd: cmd, in: bufio.NewReader(cmd.InOrStdin()), defaults: params.defaults}
+
+	if _, err := os.Stat(cfg.Path()); err == nil && !params.force {
+		if params.defaults {
+			return fmt.Errorf("configuration file %s already exists, use --force to overwrite", cfg.Path())
+		}
+		update, askErr := wizard.confirm(fmt.Sprintf("%s exists. Update it", cfg.Path()), false)
+		if askErr != nil {
+			return askErr
+		}
+		if !update {
+			cmd.PrintErrln("Init cancelled.")
+			return nil
+		}
+	}
+
+	cmd.Println("Welcome to FinFocus! Answer a few questions to create your configuration.")
+	cmd.Println()
+	reportInstalledPlugins(cmd)
+
+	if err := wizard.configure(cfg); err != nil {
+		return err
+	}
+	if err := cfg.Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	if err := cfg.SaveCommented(); err != nil {
+		return fmt.Errorf("failed to save configuration: %w", err)
+	}
+
+	cmd.Println()
+	cmd.Printf("Configuration written to %s\n", cfg.Path())
+	cmd.Println("Next: run 'finfocus cost projected --pulumi-json plan.json' on a Pulumi preview,")
+	cmd.Println("and 'finfocus config set <key> <value>' to change a setting later.")
+	return nil
+}
+
+// configure asks for each setting and applies the answers to cfg.
+func (w *initWizard) configure(cfg *config.Config) error {
+	format, err := w.ask("Default output format (table, json, ndjson)", cfg.Output.DefaultFormat,
+		validateInitOutputFormat)
+	if err != nil {
+		return err
+	}
+	cfg.Output.DefaultFormat = format
+
+	ttlDefault := "0"
+	if cfg.Cost.Cache.Enabled {
+		ttlDefault = formatCacheTTL(time.Duration(cfg.Cost.Cache.TTLSeconds) * time.Second)
+	}
+	ttl, err := w.ask("Cache TTL (e.g. 1h, 30m; 0 disables caching)", ttlDefault, validateCacheTTL)
+	if err != nil {
+		return err
+	}
+	ttlDuration, _ := time.ParseDuration(ttl)
+	cfg.Cost.Cache.Enabled = ttlDuration > 0
+	if ttlDuration > 0 {
+		cfg.Cost.Cache.TTLSeconds = int(ttlDuration.Seconds())
+	}
+
+	currencyDefault := cfg.CurrencyTarget()
+	if currencyDefault == "" {
+		currencyDefault = defaultCurrency
+		if cfg.Cost.Budgets != nil && cfg.Cost.Budgets.Global != nil && cfg.Cost.Budgets.Global.Currency != "" {
+			currencyDefault = cfg.Cost.Budgets.Global.Currency
+		}
+	}
+	currency, err := w.ask("Currency for costs and budgets (ISO 4217)", currencyDefault, validateInitCurrency)
+	if err != nil {
+		return err
+	}
+	currency = strings.ToUpper(currency)
+	if err = w.configureConversion(cfg, currency); err != nil {
+		return err
+	}
+
+	budgetDefault := "0"
+	if cfg.Cost.Budgets != nil && cfg.Cost.Budgets.Global != nil {
+		budgetDefault = strconv.FormatFloat(cfg.Cost.Budgets.Global.Amount, 'f', -1, 64)
+	}
+	budget, err := w.ask(fmt.Sprintf("Monthly budget in %s (0 for none)", currency), budgetDefault,
+		validateInitBudget)
+	if err != nil {
+		return err
+	}
+	applyInitBudget(cfg, budget, currency)
+	return nil
+}
+
+// configureConversion sets currency as the currency all costs are converted
+// into, when it differs from the USD plugins normally report and the user
+// agrees to fetch exchange rates for it.
+func (w *initWizard) configureConversion(cfg *config.Config, currency string) error {
+	convert := cfg.CurrencyTarget() != ""
+	if currency != defaultCurrency && !convert {
+		var err error
+		convert, err = w.confirm(fmt.Sprintf("Convert costs to %s with daily exchange rates (needs network access)",
+			currency), true)
+		if err != nil {
+			return err
+		}
+	}
+	if !convert {
+		return nil
+	}
+	if cfg.Currency == nil {
+		cfg.Currency = &config.CurrencyConfig{}
+	}
+	cfg.Currency.Target = currency
+	return nil
+}
+
+// confirm asks a yes/no question and returns the answer, or def for an empty
+// answer.
+func (w *initWizard) confirm(question string, def bool) (bool, error) {
+	choices := "y/N"
+	if def {
+		choices = "Y/n"
+	}
+	answer, err := w.ask(question, choices, nil)
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "y", "yes":
+		return true, nil
+	case "n", "no":
+		return false, nil
+	default:
+		return def, nil
+	}
+}
+
+// ask prints question with its default and returns the answer, or the
+// default for an empty answer, the end of the input, or --defaults. Invalid
+// answers are reported and the question asked again; validate may be nil.
+func (w *initWizard) ask(question, def string, validate func(string) error) (string, error) {
+	if w.defaults {
+		return def, nil
+	}
+	for {
+		w.cmd.Printf("? %s [%s]: ", question, def)
+		line, readErr := w.in.ReadString('\n')
+		if readErr != nil && !errors.Is(readErr, io.EOF) {
+			return "", fmt.Errorf("reading answer: %w", readErr)
+		}
+		answer := strings.TrimSpace(line)
+		if readErr != nil {
+			w.cmd.Println()
+		}
+		if answer == "" {
+			answer = def
+		}
+		if validate == nil {
+			return answer, nil
+		}
+		err := validate(answer)
+		if err == nil {
+			return answer, nil
+		}
+		if readErr != nil {
+			return "", err
+		}
+		w.cmd.Printf("  %v\n", err)
+	}
+}
+
+// reportInstalledPlugins prints the installed plugins, or how to install one.
+func reportInstalledPlugins(cmd *cobra.Command) {
+	plugins, _, err := registry.NewDefault().ListLatestPlugins()
+	if err != nil || len(plugins) == 0 {
+		cmd.Println("No plugins installed yet. Costs come from plugins; install one with")
+		cmd.Println("'finfocus plugin install <name>' (for example aws-public or kubecost).")
+		cmd.Println()
+		return
+	}
+	cmd.Printf("Detected %d installed plugin(s):\n", len(plugins))
+	for _, plugin := range plugins {
+		cmd.Printf("  %s %s\n", plugin.Name, plugin.Version)
+	}
+	cmd.Println()
+}
+
+// validateInitOutputFormat checks an output format answer.
+func validateInitOutputFormat(format string) error {
+	switch format {
+	case outputFormatTable, outputFormatJSON, outputFormatNDJSON:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q: use table, json, or ndjson", format)
+	}
+}
+
+// validateCacheTTL checks a cache TTL answer: a duration of at least a second,
+// or 0.
+func validateCacheTTL(ttl string) error {
+	d, err := time.ParseDuration(ttl)
+	if err != nil || d < 0 || (d > 0 && d < time.Second) {
+		return fmt.Errorf("invalid cache TTL %q: use a duration such as 1h or 30m, or 0", ttl)
+	}
+	return nil
+}
+
+// validateInitCurrency checks a currency answer, in any letter case.
+func validateInitCurrency(currency string) error {
+	return engine.ValidateCurrency(strings.ToUpper(currency))
+}
+
+// validateInitBudget checks a monthly budget answer: a non-negative amount.
+func validateInitBudget(amount string) error {
+	value, err := strconv.ParseFloat(amount, 64)
+	if err != nil || value < 0 {
+		return fmt.Errorf("invalid budget %q: use a non-negative amount such as 5000", amount)
+	}
+	return nil
+}
+
+// applyInitBudget sets the amount and currency of the global budget, or
+// removes it for an amount of 0. Its other settings, such as alerts, are kept.
+func applyInitBudget(cfg *config.Config, amount, currency string) {
+	value, _ := strconv.ParseFloat(amount, 64)
+	if value == 0 {
+		if cfg.Cost.Budgets != nil {
+			cfg.Cost.Budgets.Global = nil
+		}
+		return
+	}
+	if cfg.Cost.Budgets == nil {
+		cfg.Cost.Budgets = &config.BudgetsConfig{}
+	}
+	if cfg.Cost.Budgets.Global == nil {
+		cfg.Cost.Budgets.Global = &config.ScopedBudget{}
+	}
+	cfg.Cost.Budgets.Global.Amount = value
+	cfg.Cost.Budgets.Global.Currency = currency
+}
+
+// formatCacheTTL formats a TTL without zero minutes and seconds, as 1h
+// rather than 1h0m0s.
+func formatCacheTTL(ttl time.Duration) string {
+	s := ttl.String()
+	if strings.HasSuffix(s, "m0s") {
+		s = strings.TrimSuffix(s, "0s")
+	}
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
@@ -0,0 +1,92 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// runInit runs the init wizard with answers as its input and returns its
+// output.
+func runInit(t *testing.T, params initParams, answers string) (string, error) {
+	t.Helper()
+	cmd := NewInitCmd()
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetIn(strings.NewReader(answers))
+	err := executeInit(cmd, params)
+	return out.String(), err
+}
+
+func TestInitWizard(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	out, err := runInit(t, initParams{}, "yaml\njson\n2h\neur\nn\n-5\n2500\n")
+	require.NoError(t, err)
+	assert.Contains(t, out, "No plugins installed yet.")
+	assert.Contains(t, out, `invalid output format "yaml"`)
+	assert.Contains(t, out, `invalid budget "-5"`)
+	assert.Contains(t, out, "Configuration written to "+filepath.Join(home, "config.yaml"))
+
+	data, err := os.ReadFile(filepath.Join(home, "config.yaml"))
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "# Format of command output when --output is not given")
+
+	cfg := config.New()
+	assert.Equal(t, "json", cfg.Output.DefaultFormat)
+	assert.True(t, cfg.Cost.Cache.Enabled)
+	assert.Equal(t, 7200, cfg.Cost.Cache.TTLSeconds)
+	assert.Empty(t, cfg.CurrencyTarget(), "conversion declined")
+	require.NotNil(t, cfg.Cost.Budgets)
+	require.NotNil(t, cfg.Cost.Budgets.Global)
+	assert.InDelta(t, 2500, cfg.Cost.Budgets.Global.Amount, 1e-9)
+	assert.Equal(t, "EUR", cfg.Cost.Budgets.Global.Currency)
+
+	// Rerunning over the file offers its values as defaults.
+	out, err = runInit(t, initParams{}, "y\n\n0\n\n\n0\n")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Cache TTL (e.g. 1h, 30m; 0 disables caching) [2h]")
+	assert.Contains(t, out, "Monthly budget in EUR (0 for none) [2500]")
+
+	cfg = config.New()
+	assert.Equal(t, "json", cfg.Output.DefaultFormat)
+	assert.False(t, cfg.Cost.Cache.Enabled)
+	assert.Nil(t, cfg.Cost.Budgets.Global)
+}
+
+func TestInitExistingConfig(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	_, err := runInit(t, initParams{defaults: true}, "")
+	require.NoError(t, err)
+	cfg := config.New()
+	assert.Equal(t, "table", cfg.Output.DefaultFormat)
+	assert.Empty(t, cfg.CurrencyTarget())
+	assert.Nil(t, cfg.Cost.Budgets)
+
+	_, err = runInit(t, initParams{defaults: true}, "")
+	require.ErrorContains(t, err, "already exists, use --force")
+
+	out, err := runInit(t, initParams{}, "\n")
+	require.NoError(t, err)
+	assert.Contains(t, out, "Init cancelled.")
+
+	_, err = runInit(t, initParams{defaults: true, force: true}, "")
+	require.NoError(t, err)
+}
+
+func TestFormatCacheTTL(t *testing.T) {
+	assert.Equal(t, "1h", formatCacheTTL(3600e9))
+	assert.Equal(t, "1h30m", formatCacheTTL(5400e9))
+	assert.Equal(t, "45s", formatCacheTTL(45e9))
+}
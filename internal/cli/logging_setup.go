@@ -21,7 +21,6 @@ func setupLogging(cmd *cobra.Command) logging.LogPathResult {
 	if debug {
 		loggingCfg.Level = "debug"
 		loggingCfg.Format = "console"
-		loggingCfg.File = ""
 	}
 
 	if envLevel := os.Getenv(pluginsdk.EnvLogLevel); envLevel != "" && !debug {
@@ -38,7 +37,10 @@ func setupLogging(cmd *cobra.Command) logging.LogPathResult {
 		}
 	}
 
-	result := logging.NewLoggerWithPath(loggingCfg.ToLoggingConfig())
+	// The log file keeps its own level and format; --debug adds the console.
+	logCfg := loggingCfg.ToLoggingConfig()
+	logCfg.Console = debug
+	result := logging.NewLoggerWithPath(logCfg)
 	logger = logging.ComponentLogger(result.Logger, "cli")
 
 	if result.UsingFile {
@@ -55,8 +57,8 @@ func setupLogging(cmd *cobra.Command) logging.LogPathResult {
 
 	// When logging to a file, open a second append-mode handle for plugin I/O.
 	// Plugin stderr/stdout will be redirected here to keep the terminal clean.
-	// In debug mode (no file), plugins continue writing to stderr for visibility.
-	if result.UsingFile {
+	// In debug mode, plugins continue writing to stderr for visibility.
+	if result.UsingFile && !debug {
 		pluginLogFile, err := os.OpenFile(result.FilePath, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
 		if err != nil {
 			logger.Warn().Err(err).Msg("could not open plugin log file, plugin output will go to stderr")
@@ -0,0 +1,402 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/slack"
+	"github.com/rshade/finfocus/pkg/version"
+)
+
+// Slack digest settings.
+const (
+	// digestDaily is the only digest period currently supported.
+	digestDaily = "daily"
+	// slackDailyDigestState names the notify state entry for the daily digest.
+	slackDailyDigestState = "slack-daily"
+	// slackDigestMaxResources caps the resources listed under yesterday's spend.
+	slackDigestMaxResources = 5
+	// slackDigestMaxRecommendations caps the new recommendations listed.
+	slackDigestMaxRecommendations = 5
+)
+
+// notifySlackParams holds the parameters for the notify slack command execution.
+type notifySlackParams struct {
+	digest     string
+	planPath   string
+	statePath  string
+	adapter    string
+	filter     []string
+	webhookURL string
+	channel    string
+	dryRun     bool
+}
+
+// slackDigestData is everything a daily digest reports.
+type slackDigestData struct {
+	// Day is the UTC day the digest covers.
+	Day   time.Time
+	Stack string
+	// Currency is used for totals; empty when the results mix currencies.
+	Currency    string
+	Yesterday   []engine.CostResult
+	MonthToDate []engine.CostResult
+	Budgets     *BudgetRenderResult
+	// NewRecommendations are the recommendations no earlier digest reported,
+	// largest savings first.
+	NewRecommendations []engine.Recommendation
+}
+
+// newNotifyCmd creates the notify command group.
+func newNotifyCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "notify", Short: "Send cost notifications to chat tools"}
+	cmd.AddCommand(NewNotifySlackCmd())
+	return cmd
+}
+
+// NewNotifySlackCmd creates the "slack" subcommand, which posts a cost digest
+// to Slack through an incoming webhook or a bot token and channel.
+func NewNotifySlackCmd() *cobra.Command {
+	var params notifySlackParams
+
+	cmd := &cobra.Command{
+		Use:   "slack",
+		Short: "Post a cost digest to Slack",
+		Long: `Post a cost digest to Slack, designed to run once a day from cron or CI.
+
+The daily digest covers the previous UTC day: its actual spend and the most
+expensive resources, the month-to-date spend against configured budgets, and
+the recommendations that appeared since the last digest. Which recommendations
+were already reported is kept in ~/.finfocus/notify_state.json, which is only
+updated after a message is delivered.
+
+Messages are posted to an incoming webhook, or with a bot token (chat:write
+scope) to a channel. The destination is taken from --webhook-url or --channel,
+then SLACK_WEBHOOK_URL or SLACK_BOT_TOKEN, then the notify.slack settings in
+the config file. A webhook takes precedence over a bot token unless --channel
+is given.`,
+		Example: `  # Post yesterday's digest through an incoming webhook
+  SLACK_WEBHOOK_URL=https://hooks.slack.com/services/... finfocus notify slack --digest daily
+
+  # Post with a bot token to a channel
+  SLACK_BOT_TOKEN=xoxb-... finfocus notify slack --digest daily --channel '#finops'
+
+  # Use a state export instead of auto-detecting the Pulumi stack
+  finfocus notify slack --digest daily --pulumi-state state.json
+
+  # Print the Block Kit payload without posting it
+  finfocus notify slack --digest daily --dry-run`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeNotifySlack(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.digest, "digest", digestDaily, "Digest period (daily)")
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().StringVar(&params.statePath, "pulumi-state", "",
+		"Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().String("stack", "", "Pulumi stack for auto-detection")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
+		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+	cmd.Flags().StringVar(&params.webhookURL, "webhook-url", "", "Slack incoming webhook URL")
+	cmd.Flags().StringVar(&params.channel, "channel", "", "Slack channel for bot token delivery")
+	cmd.Flags().BoolVar(&params.dryRun, "dry-run", false, "Print the message payload instead of posting it")
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "pulumi-state")
+
+	return cmd
+}
+
+// executeNotifySlack gathers yesterday's and month-to-date actual costs, the
+// budget health, and new recommendations, then posts the digest to Slack.
+func executeNotifySlack(cmd *cobra.Command, params notifySlackParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	if params.digest != digestDaily {
+		return fmt.Errorf("unsupported digest %q (supported: %s)", params.digest, digestDaily)
+	}
+
+	cfg := config.New()
+	client := newSlackClient(cfg, params)
+	if !params.dryRun {
+		if err := client.Validate(); err != nil {
+			return err
+		}
+	}
+
+	audit := newAuditContext(ctx, "notify slack", map[string]string{
+		"digest":       params.digest,
+		"pulumi_json":  params.planPath,
+		"pulumi_state": params.statePath,
+	})
+
+	resources, err := loadActualResources(ctx, cmd, costActualParams{
+		planPath: params.planPath, statePath: params.statePath,
+	}, audit)
+	if err != nil {
+		return err
+	}
+	resources, err = ApplyFilters(ctx, resources, params.filter)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("applying filters: %w", err)
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, nil).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	now := time.Now().UTC()
+	dayStart, dayEnd, monthStart := dailyDigestWindow(now)
+	yesterday, err := eng.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+		Resources: resources, From: dayStart, To: dayEnd, Adapter: params.adapter,
+	})
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("fetching yesterday's costs: %w", err)
+	}
+	monthToDate := yesterday
+	if monthStart.Before(dayStart) {
+		monthToDate, err = eng.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+			Resources: resources, From: monthStart, To: dayEnd, Adapter: params.adapter,
+		})
+		if err != nil {
+			audit.logFailure(ctx, err)
+			return fmt.Errorf("fetching month-to-date costs: %w", err)
+		}
+	}
+
+	fetchAndMergeRecommendations(ctx, eng, resources, monthToDate.Results)
+
+	data := slackDigestData{
+		Day:         dayStart,
+		Stack:       getStackFlag(cmd),
+		Yesterday:   yesterday.Results,
+		MonthToDate: monthToDate.Results,
+	}
+	currency, mixedCurrencies := extractCurrencyFromResults(monthToDate.Results)
+	if !mixedCurrencies {
+		data.Currency = currency
+		data.Budgets, err = evaluateBudgetsQuietly(cmd, monthToDate.Results, resourceTagIndex(resources),
+			totalActualCost(monthToDate.Results), currency)
+		if err != nil {
+			log.Warn().Ctx(ctx).Err(err).Msg("budget evaluation failed; digest omits budget health")
+		}
+	}
+
+	store := config.NewNotifyStateStore("")
+	if err = store.Load(); err != nil {
+		return fmt.Errorf("loading notify state: %w", err)
+	}
+	previous, _ := store.Digest(slackDailyDigestState)
+	recs := recommendationsBySavings(monthToDate.Results)
+	data.NewRecommendations = newRecommendations(recs, previous.SeenRecommendations)
+
+	msg := buildSlackDigest(data)
+	if params.dryRun {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if err = enc.Encode(msg); err != nil {
+			return fmt.Errorf("encoding Slack message: %w", err)
+		}
+		return nil
+	}
+
+	if err = client.Post(ctx, msg); err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("posting Slack digest: %w", err)
+	}
+
+	seen := make([]string, 0, len(recs))
+	for _, rec := range recs {
+		seen = append(seen, recommendationFingerprint(rec))
+	}
+	store.SetDigest(slackDailyDigestState, config.DigestState{LastSent: now, SeenRecommendations: seen})
+	if err = store.Save(); err != nil {
+		return fmt.Errorf("saving notify state: %w", err)
+	}
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "notify_slack").
+		Int("new_recommendations", len(data.NewRecommendations)).Msg("daily digest posted")
+	audit.logSuccess(ctx, len(monthToDate.Results), totalActualCost(monthToDate.Results))
+	cmd.PrintErrf("Posted daily digest to Slack %s\n", client.Destination())
+	return nil
+}
+
+// newSlackClient resolves the Slack destination: flags first, then the
+// SLACK_WEBHOOK_URL and SLACK_BOT_TOKEN environment variables, then config.
+func newSlackClient(cfg *config.Config, params notifySlackParams) *slack.Client {
+	settings := cfg.SlackSettings()
+	webhookURL := firstNonEmpty(params.webhookURL, os.Getenv(slack.EnvWebhookURL), settings.WebhookURL)
+	token := firstNonEmpty(os.Getenv(slack.EnvBotToken), settings.BotToken)
+	channel := firstNonEmpty(params.channel, settings.Channel)
+	// An explicit --channel selects bot delivery over a configured webhook.
+	if params.channel != "" && params.webhookURL == "" && token != "" {
+		webhookURL = ""
+	}
+	return slack.NewClient("", webhookURL, token, channel)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// dailyDigestWindow returns the UTC bounds of the day before now and the start
+// of that day's month.
+func dailyDigestWindow(now time.Time) (time.Time, time.Time, time.Time) {
+	now = now.UTC()
+	dayEnd := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	dayStart := dayEnd.AddDate(0, 0, -1)
+	monthStart := time.Date(dayStart.Year(), dayStart.Month(), 1, 0, 0, 0, 0, time.UTC)
+	return dayStart, dayEnd, monthStart
+}
+
+// newRecommendations returns the recommendations whose fingerprints are not in seen.
+func newRecommendations(recs []engine.Recommendation, seen []string) []engine.Recommendation {
+	known := make(map[string]bool, len(seen))
+	for _, fp := range seen {
+		known[fp] = true
+	}
+	var fresh []engine.Recommendation
+	for _, rec := range recs {
+		if !known[recommendationFingerprint(rec)] {
+			fresh = append(fresh, rec)
+		}
+	}
+	return fresh
+}
+
+func totalActualCost(results []engine.CostResult) float64 {
+	total := 0.0
+	for _, r := range results {
+		total += r.TotalCost
+	}
+	return total
+}
+
+// buildSlackDigest composes the daily digest as a Block Kit message.
+func buildSlackDigest(data slackDigestData) slack.Message {
+	day := data.Day.Format(time.DateOnly)
+	cur := data.Currency
+	dayTotal := totalActualCost(data.Yesterday)
+	monthTotal := totalActualCost(data.MonthToDate)
+
+	title := "FinFocus daily digest — " + day
+	if data.Stack != "" {
+		title += " (" + data.Stack + ")"
+	}
+
+	blocks := []slack.Block{
+		slack.Header(title),
+		slack.Section("",
+			"*Yesterday*\n"+commentMoney(dayTotal, cur),
+			"*Month to date*\n"+commentMoney(monthTotal, cur)),
+	}
+
+	if top := topResourcesByCost(data.Yesterday, slackDigestMaxResources); len(top) > 0 {
+		var b strings.Builder
+		b.WriteString("*Top resources yesterday*")
+		for _, r := range top {
+			fmt.Fprintf(&b, "\n• %s `%s` %s", slack.Escape(commentResourceName(r.ResourceID)),
+				slack.Escape(r.ResourceType), commentMoney(r.TotalCost, r.Currency))
+		}
+		blocks = append(blocks, slack.Section(b.String()))
+	}
+
+	if scopes := budgetScopes(data.Budgets); len(scopes) > 0 {
+		var b strings.Builder
+		b.WriteString("*Budget health*")
+		for _, s := range scopes {
+			fmt.Fprintf(&b, "\n%s %s: %s of %s (%.1f%%) — %s", slackHealthEmoji(s.Health),
+				slack.Escape(s.ScopeIdentifier()), commentMoney(s.CurrentSpend, s.Currency),
+				commentMoney(s.Budget.Amount, s.Currency), s.Percentage, healthStatusLabel(s.Health))
+		}
+		blocks = append(blocks, slack.Divider(), slack.Section(b.String()))
+	}
+
+	blocks = append(blocks, slack.Divider(), slack.Section(slackRecommendationsText(data.NewRecommendations)))
+	blocks = append(blocks, slack.Context(fmt.Sprintf("finfocus %s · actual costs for %s UTC",
+		version.GetVersion(), day)))
+
+	return slack.Message{
+		Text: fmt.Sprintf("FinFocus daily digest for %s: %s yesterday, %s month to date",
+			day, commentMoney(dayTotal, cur), commentMoney(monthTotal, cur)),
+		Blocks: blocks,
+	}
+}
+
+// slackRecommendationsText lists the new recommendations with the largest savings.
+func slackRecommendationsText(recs []engine.Recommendation) string {
+	if len(recs) == 0 {
+		return "*New recommendations*\nNo new recommendations."
+	}
+
+	total, cur := 0.0, ""
+	for _, rec := range recs {
+		total += rec.EstimatedSavings
+		if cur == "" {
+			cur = rec.Currency
+		}
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "*New recommendations* (%d, %s/month potential savings)", len(recs), commentMoney(total, cur))
+	for i, rec := range recs {
+		if i == slackDigestMaxRecommendations {
+			fmt.Fprintf(&b, "\n_…and %d more_", len(recs)-slackDigestMaxRecommendations)
+			break
+		}
+		fmt.Fprintf(&b, "\n• %s: %s — %s", slack.Escape(commentResourceName(rec.ResourceID)),
+			formatActionTypeLabel(rec.Type), slack.Escape(rec.Description))
+		if rec.EstimatedSavings > 0 {
+			fmt.Fprintf(&b, " (%s/month)", commentMoney(rec.EstimatedSavings, rec.Currency))
+		}
+	}
+	return b.String()
+}
+
+// topResourcesByCost returns up to limit results with the highest actual cost.
+func topResourcesByCost(results []engine.CostResult, limit int) []engine.CostResult {
+	return pipeline.Apply(results,
+		pipeline.Filter(func(r engine.CostResult) bool { return r.TotalCost > 0 }),
+		pipeline.Sort(pipeline.Descending(func(a, b engine.CostResult) bool { return a.TotalCost < b.TotalCost })),
+		pipeline.Limit[engine.CostResult](limit),
+	)
+}
+
+// slackHealthEmoji maps a budget health status to a Slack emoji.
+func slackHealthEmoji(health pbc.BudgetHealthStatus) string {
+	switch health {
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK:
+		return ":large_green_circle:"
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING:
+		return ":large_yellow_circle:"
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED:
+		return ":red_circle:"
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED:
+		return ":white_circle:"
+	default:
+		return ":white_circle:"
+	}
+}
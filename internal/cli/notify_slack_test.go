@@ -0,0 +1,131 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/slack"
+)
+
+func TestDailyDigestWindow(t *testing.T) {
+	dayStart, dayEnd, monthStart := dailyDigestWindow(time.Date(2026, 3, 15, 7, 30, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), dayStart)
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), dayEnd)
+	assert.Equal(t, time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), monthStart)
+
+	// On the first of the month the digest covers the last day of the previous month.
+	dayStart, _, monthStart = dailyDigestWindow(time.Date(2026, 3, 1, 6, 0, 0, 0, time.UTC))
+	assert.Equal(t, time.Date(2026, 2, 28, 0, 0, 0, 0, time.UTC), dayStart)
+	assert.Equal(t, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC), monthStart)
+}
+
+func TestNewRecommendations(t *testing.T) {
+	seen := engine.Recommendation{ID: "rec-1"}
+	fresh := engine.Recommendation{ResourceID: "web", Type: "RIGHTSIZE", Description: "Downsize"}
+
+	got := newRecommendations([]engine.Recommendation{seen, fresh}, []string{"rec-1"})
+	require.Len(t, got, 1)
+	assert.Equal(t, "web", got[0].ResourceID)
+
+	assert.Len(t, newRecommendations([]engine.Recommendation{seen, fresh}, nil), 2)
+}
+
+func TestBuildSlackDigest(t *testing.T) {
+	yesterday := []engine.CostResult{
+		{ResourceID: commentWebURN, ResourceType: "aws:ec2/instance:Instance", TotalCost: 4.8, Currency: "USD"},
+		{ResourceID: commentQueueURN, ResourceType: "aws:sqs/queue:Queue", TotalCost: 0.2, Currency: "USD"},
+	}
+	monthToDate := []engine.CostResult{
+		{ResourceID: commentWebURN, ResourceType: "aws:ec2/instance:Instance", TotalCost: 67.2, Currency: "USD"},
+		{ResourceID: commentQueueURN, ResourceType: "aws:sqs/queue:Queue", TotalCost: 2.8, Currency: "USD"},
+	}
+
+	msg := buildSlackDigest(slackDigestData{
+		Day:         time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC),
+		Stack:       "prod",
+		Currency:    "USD",
+		Yesterday:   yesterday,
+		MonthToDate: monthToDate,
+		Budgets: &BudgetRenderResult{LegacyStatus: &engine.BudgetStatus{
+			Budget:       config.BudgetConfig{Amount: 100, Currency: "USD"},
+			CurrentSpend: 70,
+			Percentage:   70,
+			Currency:     "USD",
+		}},
+		NewRecommendations: []engine.Recommendation{
+			{ResourceID: commentWebURN, Type: "RIGHTSIZE", Description: "Use <t3.small>",
+				EstimatedSavings: 40, Currency: "USD"},
+		},
+	})
+
+	assert.Equal(t, "FinFocus daily digest for 2026-03-14: 5.00 USD yesterday, 70.00 USD month to date", msg.Text)
+	require.NotEmpty(t, msg.Blocks)
+	assert.Equal(t, "header", msg.Blocks[0].Type)
+	assert.Equal(t, "FinFocus daily digest — 2026-03-14 (prod)", msg.Blocks[0].Text.Text)
+
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	payload := string(data)
+	assert.Contains(t, payload, "*Month to date*\\n70.00 USD")
+	assert.Contains(t, payload, "• web `aws:ec2/instance:Instance` 4.80 USD")
+	assert.Less(t, strings.Index(payload, "• web"), strings.Index(payload, "• queue"), "resources ordered by cost")
+	assert.Contains(t, payload, ":large_green_circle: global: 70.00 USD of 100.00 USD (70.0%)")
+	assert.Contains(t, payload, "*New recommendations* (1, 40.00 USD/month potential savings)")
+	recBlock := msg.Blocks[len(msg.Blocks)-2]
+	assert.Contains(t, recBlock.Text.Text, "• web: Rightsize — Use &lt;t3.small&gt; (40.00 USD/month)")
+}
+
+func TestBuildSlackDigest_NoRecommendations(t *testing.T) {
+	msg := buildSlackDigest(slackDigestData{Day: time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC)})
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "No new recommendations.")
+	assert.NotContains(t, string(data), "Budget health")
+}
+
+func TestNewSlackClient_Precedence(t *testing.T) {
+	cfg := &config.Config{Notify: &config.NotifyConfig{Slack: &config.SlackConfig{
+		WebhookURL: "https://hooks.slack.com/services/config",
+		BotToken:   "xoxb-config",
+		Channel:    "#config",
+	}}}
+	t.Setenv(slack.EnvWebhookURL, "")
+	t.Setenv(slack.EnvBotToken, "")
+
+	assert.Equal(t, "incoming webhook", newSlackClient(cfg, notifySlackParams{}).Destination())
+	assert.Equal(t, "channel #finops",
+		newSlackClient(cfg, notifySlackParams{channel: "#finops"}).Destination(),
+		"--channel selects bot delivery over a configured webhook")
+
+	noWebhook := &config.Config{Notify: &config.NotifyConfig{Slack: &config.SlackConfig{Channel: "#config"}}}
+	assert.ErrorIs(t, newSlackClient(noWebhook, notifySlackParams{}).Validate(), slack.ErrNotConfigured)
+	t.Setenv(slack.EnvBotToken, "xoxb-env")
+	assert.Equal(t, "channel #config", newSlackClient(noWebhook, notifySlackParams{}).Destination())
+	require.NoError(t, newSlackClient(noWebhook, notifySlackParams{}).Validate())
+}
+
+func TestNotifySlack_Errors(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	t.Setenv(slack.EnvWebhookURL, "")
+	t.Setenv(slack.EnvBotToken, "")
+
+	cmd := NewNotifySlackCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--digest", "weekly"})
+	require.ErrorContains(t, cmd.Execute(), `unsupported digest "weekly"`)
+
+	cmd = NewNotifySlackCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--digest", "daily"})
+	require.ErrorIs(t, cmd.Execute(), slack.ErrNotConfigured)
+}
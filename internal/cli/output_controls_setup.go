@@ -0,0 +1,53 @@
+package cli
+
+import (
+	"strconv"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// addOutputControlFlags adds the global --no-color, --plain, and --quiet flags.
+func addOutputControlFlags(cmd *cobra.Command) {
+	cmd.PersistentFlags().
+		Bool("no-color", false, "draw no colors (also NO_COLOR or output.no_color)")
+	cmd.PersistentFlags().
+		Bool("plain", false, "write plain text without styling or interactive views (also output.plain)")
+	cmd.PersistentFlags().
+		BoolP("quiet", "q", false, "leave out tips and progress displays (also output.quiet)")
+}
+
+// setupOutputControls resolves --no-color, --plain, and --quiet, falling back
+// to the output config for flags not given, and applies them to every
+// command and view through the tui output controls.
+func setupOutputControls(cmd *cobra.Command) {
+	var settings config.OutputConfig
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		settings = cfg.Output
+	}
+	resolve := func(name string, configured bool) bool {
+		flag := cmd.Flag(name)
+		if flag == nil || !flag.Changed {
+			return configured
+		}
+		value, err := strconv.ParseBool(flag.Value.String())
+		return err == nil && value
+	}
+	tui.SetOutputControls(tui.OutputControls{
+		NoColor: resolve("no-color", settings.NoColor),
+		Plain:   resolve("plain", settings.Plain),
+		Quiet:   resolve("quiet", settings.Quiet),
+	})
+}
+
+// pluginProgress returns the progress callback of the plugin install, update,
+// and remove commands, which prints each message unless --quiet is set.
+func pluginProgress(cmd *cobra.Command) func(string) {
+	return func(msg string) {
+		if !tui.Quiet() {
+			cmd.Println(msg)
+		}
+	}
+}
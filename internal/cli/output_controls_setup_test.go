@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+func TestSetupOutputControls(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() {
+		config.SetGlobalConfig(prev)
+		tui.SetOutputControls(tui.OutputControls{})
+	})
+	cfg := config.New()
+	cfg.Output.Plain = true
+	cfg.Output.Quiet = true
+	config.SetGlobalConfig(cfg)
+
+	root := &cobra.Command{Use: "finfocus"}
+	addOutputControlFlags(root)
+	sub := &cobra.Command{Use: "sub", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(sub)
+	root.SetArgs([]string{"sub", "--no-color", "--plain=false"})
+	require.NoError(t, root.Execute())
+
+	setupOutputControls(sub)
+	assert.Equal(t, tui.OutputControls{NoColor: true, Quiet: true}, tui.CurrentOutputControls(),
+		"flags override the config, which applies to flags not given")
+	assert.False(t, tui.ColorEnabled())
+}
@@ -11,7 +11,6 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
 
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine"
@@ -102,6 +101,7 @@ func executeOverview(cmd *cobra.Command, params overviewParams) error {
 	if ctx == nil {
 		ctx = context.Background()
 	}
+	params.stack = getStackFlag(cmd)
 	audit := newAuditContext(ctx, "overview", map[string]string{
 		"pulumi_state": params.pulumiState,
 		"pulumi_json":  params.pulumiJSON,
@@ -511,12 +511,8 @@ func shouldUseInteractiveTUI(w io.Writer, outputFormat string, plainFlag bool) b
 		return false
 	}
 
-	// Check if the writer has a file descriptor and is a TTY.
-	type fder interface{ Fd() uintptr }
-	if f, ok := w.(fder); ok {
-		return term.IsTerminal(int(f.Fd()))
-	}
-	return false
+	// The writer must be a TTY, and the global controls must allow views.
+	return tui.CanRunInteractive(w)
 }
 
 // runInteractiveOverview launches the interactive TUI with progressive loading.
@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+	"github.com/rshade/finfocus/internal/engine/spill"
 )
 
 // Pagination modes and validation limits.
@@ -227,34 +230,24 @@ func (p PaginationParams) ApplyToSlice(items []int) []int {
 	if len(items) == 0 {
 		return items
 	}
+	return Stage[int](p)(items)
+}
 
-	offset, limit := p.CalculateOffsetLimit()
-
-	// For page-based pagination, cap offset to last available page
-	if p.IsPageBased() && offset >= len(items) {
-		// Find the starting index of the last page
-		pageSize := p.PageSize
-		if pageSize <= 0 {
-			pageSize = len(items)
-		}
-		// Last page starts at the last multiple of pageSize that's < len(items)
-		lastPageStart := ((len(items) - 1) / pageSize) * pageSize
-		offset = lastPageStart
-	}
-
-	// For offset-based pagination, return empty if beyond length
-	if offset >= len(items) {
-		return []int{}
+// Stage returns the result pipeline stage that keeps the items selected by p.
+// For page-based pagination, a page beyond the results is capped to the last page.
+func Stage[T any](p PaginationParams) pipeline.Stage[T] {
+	if p.IsPageBased() {
+		return pipeline.Page[T](p.Page, p.PageSize, p.Limit)
 	}
+	return pipeline.Paginate[T](p.Offset, p.Limit)
+}
 
-	start := offset
-	end := offset + limit
-	if limit == 0 {
-		end = len(items)
-	}
-	if end > len(items) {
-		end = len(items)
+// StoreWindow returns the items of store selected by p, as Stage does for a
+// slice, reading only those items from disk.
+func StoreWindow[T any](p PaginationParams, store *spill.Store[T]) ([]T, error) {
+	offset, limit := p.Offset, p.Limit
+	if p.IsPageBased() {
+		offset, limit = pipeline.PageWindow(p.Page, p.PageSize, p.Limit, store.Len())
 	}
-
-	return items[start:end]
+	return pipeline.PaginateStore(store, offset, limit)
 }
@@ -7,98 +7,101 @@ import (
 	"strings"
 
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
 )
 
-// Sorter defines the interface for sorting recommendations.
-type Sorter interface {
-	// Sort sorts a slice of recommendations by the specified field and order.
-	Sort(recommendations []engine.Recommendation, field, order string) []engine.Recommendation
+// Sorter defines the interface for sorting items by a named field.
+type Sorter[T any] interface {
+	// Sort sorts a slice of items by the specified field and order.
+	Sort(items []T, field, order string) []T
 	// IsValidField checks if the given field name is valid for sorting.
 	IsValidField(field string) bool
 	// GetValidFields returns a list of valid field names for sorting.
 	GetValidFields() []string
 }
 
-// RecommendationSorter implements Sorter for engine.Recommendation.
-type RecommendationSorter struct {
-	validFields map[string]bool
+// FieldSorter implements Sorter with an ascending order for each valid field.
+type FieldSorter[T any] struct {
+	fields map[string]func(a, b T) bool
+}
+
+// NewFieldSorter creates a sorter for the fields of fields, each mapped to the
+// ascending order of items by that field.
+func NewFieldSorter[T any](fields map[string]func(a, b T) bool) *FieldSorter[T] {
+	return &FieldSorter[T]{fields: fields}
 }
 
+// RecommendationSorter implements Sorter for engine.Recommendation.
+type RecommendationSorter = FieldSorter[engine.Recommendation]
+
 // NewRecommendationSorter creates a new RecommendationSorter with valid sort fields.
 func NewRecommendationSorter() *RecommendationSorter {
-	return &RecommendationSorter{
-		validFields: map[string]bool{
-			"savings":      true,
-			"cost":         true,
-			"name":         true,
-			"resourceType": true,
-			"provider":     true,
-			"actionType":   true,
-		},
+	fields := map[string]func(a, b engine.Recommendation) bool{}
+	for _, field := range []string{"savings", "cost", "name", "resourceType", "provider", "actionType"} {
+		fields[field] = recommendationLess(field)
 	}
+	return NewFieldSorter(fields)
 }
 
 // IsValidField checks if the field is valid for sorting.
-func (s *RecommendationSorter) IsValidField(field string) bool {
-	return s.validFields[field]
+func (s *FieldSorter[T]) IsValidField(field string) bool {
+	_, ok := s.fields[field]
+	return ok
 }
 
 // GetValidFields returns all valid sort fields.
-func (s *RecommendationSorter) GetValidFields() []string {
-	fields := make([]string, 0, len(s.validFields))
-	for field := range s.validFields {
+func (s *FieldSorter[T]) GetValidFields() []string {
+	fields := make([]string, 0, len(s.fields))
+	for field := range s.fields {
 		fields = append(fields, field)
 	}
 	sort.Strings(fields) // Return in consistent order
 	return fields
 }
 
-// Sort sorts recommendations by the specified field and order.
-// Returns a new sorted slice; does not modify the original.
+// Sort sorts items by the specified field and order.
+// Returns a new sorted slice; does not modify the original. Items that
+// compare equal keep their input order.
 // If field is invalid, returns the original slice unchanged.
-func (s *RecommendationSorter) Sort(
-	recommendations []engine.Recommendation,
-	field, order string,
-) []engine.Recommendation {
+func (s *FieldSorter[T]) Sort(items []T, field, order string) []T {
+	less, ok := s.Less(field, order)
 	// Return early if field is invalid
-	if !s.IsValidField(field) {
-		return recommendations
+	if !ok {
+		return items
 	}
+	return pipeline.Sort(less)(items)
+}
 
-	// Make a copy to avoid modifying the original
-	sorted := make([]engine.Recommendation, len(recommendations))
-	copy(sorted, recommendations)
-
-	// Sort using stable sort
-	sort.SliceStable(sorted, func(i, j int) bool {
-		// For descending order, swap i and j in comparisons to maintain stability
-		if order == SortOrderDesc {
-			i, j = j, i
-		}
+// Less returns the order Sort sorts items in by field and order, or false if
+// field is invalid.
+func (s *FieldSorter[T]) Less(field, order string) (func(a, b T) bool, bool) {
+	less, ok := s.fields[field]
+	if !ok {
+		return nil, false
+	}
+	if order == SortOrderDesc {
+		less = pipeline.Descending(less)
+	}
+	return less, true
+}
 
-		switch field {
-		case "savings":
-			return sorted[i].EstimatedSavings < sorted[j].EstimatedSavings
-		case "cost":
-			// "cost" maps to EstimatedSavings since Recommendation has no separate cost field
-			return sorted[i].EstimatedSavings < sorted[j].EstimatedSavings
-		case "name":
-			return sorted[i].ResourceID < sorted[j].ResourceID
-		case "resourceType":
-			return sorted[i].Type < sorted[j].Type
-		case "provider":
-			// Extract provider from ResourceID (format: "provider:service:type/id")
-			providerI := extractProvider(sorted[i].ResourceID)
-			providerJ := extractProvider(sorted[j].ResourceID)
-			return providerI < providerJ
-		case "actionType":
-			return sorted[i].Type < sorted[j].Type
-		default:
-			return false
+// recommendationLess returns the ascending order of recommendations by a
+// valid sort field.
+func recommendationLess(field string) func(a, b engine.Recommendation) bool {
+	switch field {
+	case "savings", "cost":
+		// "cost" maps to EstimatedSavings since Recommendation has no separate cost field
+		return func(a, b engine.Recommendation) bool { return a.EstimatedSavings < b.EstimatedSavings }
+	case "name":
+		return func(a, b engine.Recommendation) bool { return a.ResourceID < b.ResourceID }
+	case "provider":
+		// Extract provider from ResourceID (format: "provider:service:type/id")
+		return func(a, b engine.Recommendation) bool {
+			return extractProvider(a.ResourceID) < extractProvider(b.ResourceID)
 		}
-	})
-
-	return sorted
+	default: // resourceType and actionType
+		return func(a, b engine.Recommendation) bool { return a.Type < b.Type }
+	}
 }
 
 // extractProvider extracts the provider name from a resource ID.
@@ -112,6 +115,28 @@ func extractProvider(resourceID string) string {
 	return resourceID
 }
 
+// CostResultSorter implements Sorter for engine.CostResult.
+type CostResultSorter = FieldSorter[engine.CostResult]
+
+// NewCostResultSorter creates a sorter of cost results by cost, name (the
+// resource URN), or type.
+func NewCostResultSorter() *CostResultSorter {
+	return NewFieldSorter(map[string]func(a, b engine.CostResult) bool{
+		"cost": func(a, b engine.CostResult) bool { return resultCost(a) < resultCost(b) },
+		"name": func(a, b engine.CostResult) bool { return a.ResourceID < b.ResourceID },
+		"type": func(a, b engine.CostResult) bool { return a.ResourceType < b.ResourceType },
+	})
+}
+
+// resultCost returns the cost a result is sorted by: the actual cost of the
+// period for actual costs, and the monthly cost otherwise.
+func resultCost(r engine.CostResult) float64 {
+	if r.TotalCost != 0 {
+		return r.TotalCost
+	}
+	return r.Monthly
+}
+
 // ParseSortExpression parses a sort expression in "field:order" format.
 // Supports:
 //   - "field" - defaults to desc order
@@ -0,0 +1,497 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// doctorStatus is the outcome of a single diagnostic check or of a whole plugin.
+type doctorStatus string
+
+const (
+	doctorStatusOK   doctorStatus = "ok"
+	doctorStatusWarn doctorStatus = "warn"
+	doctorStatusFail doctorStatus = "fail"
+	doctorStatusSkip doctorStatus = "skip"
+)
+
+// Diagnostic check names, in the order they are run.
+const (
+	doctorCheckLaunch   = "launch"
+	doctorCheckName     = "name"
+	doctorCheckInfo     = "info"
+	doctorCheckProtocol = "protocol"
+	doctorCheckDryRun   = "dryrun"
+)
+
+const defaultDoctorTimeout = 10 * time.Second
+
+// doctorSyntheticResources maps a provider to the resource type sent in the
+// DryRun probe. Plugins without a matching provider receive the AWS resource.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var doctorSyntheticResources = map[string]string{
+	"aws":          "aws:ec2/instance:Instance",
+	"azure":        "azure-native:compute:VirtualMachine",
+	"azure-native": "azure-native:compute:VirtualMachine",
+	"gcp":          "gcp:compute/instance:Instance",
+	"kubernetes":   "kubernetes:core/v1:Pod",
+}
+
+// pluginCheck records the result and latency of one diagnostic call.
+type pluginCheck struct {
+	Name      string       `json:"name"`
+	Status    doctorStatus `json:"status"`
+	LatencyMs int64        `json:"latencyMs"`
+	Detail    string       `json:"detail,omitempty"`
+}
+
+// pluginDiagnosis is the health report for a single installed plugin.
+type pluginDiagnosis struct {
+	Plugin      string        `json:"plugin"`
+	Version     string        `json:"version"`
+	Path        string        `json:"path"`
+	SpecVersion string        `json:"specVersion,omitempty"`
+	Providers   []string      `json:"providers,omitempty"`
+	Status      doctorStatus  `json:"status"`
+	Checks      []pluginCheck `json:"checks"`
+	Suggestions []string      `json:"suggestions,omitempty"`
+}
+
+// record appends a check and folds its status into the overall plugin status.
+func (d *pluginDiagnosis) record(check pluginCheck, suggestion string) {
+	d.Checks = append(d.Checks, check)
+	switch check.Status {
+	case doctorStatusFail:
+		d.Status = doctorStatusFail
+	case doctorStatusWarn:
+		if d.Status == doctorStatusOK {
+			d.Status = doctorStatusWarn
+		}
+	case doctorStatusOK, doctorStatusSkip:
+	}
+	if suggestion != "" {
+		d.Suggestions = append(d.Suggestions, suggestion)
+	}
+}
+
+// check returns the named check, or nil if it was not run.
+func (d *pluginDiagnosis) check(name string) *pluginCheck {
+	for i := range d.Checks {
+		if d.Checks[i].Name == name {
+			return &d.Checks[i]
+		}
+	}
+	return nil
+}
+
+// NewPluginDoctorCmd creates the "plugin doctor" command, which launches every
+// installed plugin, probes its RPCs with a synthetic resource, and reports
+// latency, protocol compatibility, and suggested fixes for any failures.
+func NewPluginDoctorCmd() *cobra.Command {
+	var (
+		output  string
+		timeout time.Duration
+	)
+
+	cmd := &cobra.Command{
+		Use:   "doctor [plugin...]",
+		Short: "Run health checks against installed plugins",
+		Long: `Run health checks against installed plugins.
+
+For each plugin, doctor launches the binary and calls Name, GetPluginInfo, and
+DryRun with a synthetic resource for the plugin's provider. It measures the
+latency of each call, verifies that the plugin's spec version is compatible
+with this build of finfocus, and prints suggested fixes for any failures.
+
+The command exits with a non-zero status when any plugin fails a check.
+Warnings (for example, a legacy plugin without GetPluginInfo) do not fail.`,
+		Example: `  # Check all installed plugins
+  finfocus plugin doctor
+
+  # Check specific plugins
+  finfocus plugin doctor aws-public kubecost
+
+  # Machine-readable diagnostics
+  finfocus plugin doctor --output json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runPluginDoctor(cmd, args, output, timeout)
+		},
+	}
+
+	cmd.Flags().StringVar(&output, "output", outputFormatTable, "Output format (table, json)")
+	cmd.Flags().DurationVar(&timeout, "timeout", defaultDoctorTimeout, "Time limit for checking each plugin")
+
+	return cmd
+}
+
+// runPluginDoctor diagnoses the installed plugins (optionally filtered by name)
+// and renders the results. It returns an exitError when any plugin fails.
+func runPluginDoctor(cmd *cobra.Command, names []string, output string, timeout time.Duration) error {
+	if output != outputFormatTable && output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format: %s (supported: table, json)", output)
+	}
+	if timeout <= 0 {
+		return fmt.Errorf("--timeout must be positive, got %s", timeout)
+	}
+
+	cfg := config.New()
+	if _, err := os.Stat(cfg.PluginDir); os.IsNotExist(err) {
+		cmd.Printf("Plugin directory does not exist: %s\n", cfg.PluginDir)
+		cmd.Println("No plugins installed.")
+		return nil
+	}
+
+	plugins, err := registry.NewDefault().ListPlugins()
+	if err != nil {
+		return fmt.Errorf("listing plugins: %w", err)
+	}
+	plugins, err = filterDoctorPlugins(plugins, names)
+	if err != nil {
+		return err
+	}
+	if len(plugins) == 0 {
+		cmd.Println("No plugins found.")
+		return nil
+	}
+
+	diagnoses := diagnosePlugins(cmd.Context(), pluginhost.NewProcessLauncher(), plugins, timeout)
+
+	if output == outputFormatJSON {
+		enc := json.NewEncoder(cmd.OutOrStdout())
+		enc.SetIndent("", "  ")
+		if encErr := enc.Encode(diagnoses); encErr != nil {
+			return fmt.Errorf("encoding diagnostics: %w", encErr)
+		}
+	} else if renderErr := renderPluginDiagnoses(cmd, diagnoses); renderErr != nil {
+		return renderErr
+	}
+
+	failed := 0
+	for _, d := range diagnoses {
+		if d.Status == doctorStatusFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return &exitError{
+			code:    exitCodeFailures,
+			message: fmt.Sprintf("%d of %d plugins failed health checks", failed, len(diagnoses)),
+		}
+	}
+	return nil
+}
+
+// filterDoctorPlugins restricts plugins to the requested names. It returns an
+// error naming any requested plugin that is not installed.
+func filterDoctorPlugins(plugins []registry.PluginInfo, names []string) ([]registry.PluginInfo, error) {
+	if len(names) == 0 {
+		return plugins, nil
+	}
+
+	wanted := make(map[string]bool, len(names))
+	for _, n := range names {
+		wanted[n] = true
+	}
+
+	found := make(map[string]bool, len(names))
+	var filtered []registry.PluginInfo
+	for _, p := range plugins {
+		if wanted[p.Name] {
+			filtered = append(filtered, p)
+			found[p.Name] = true
+		}
+	}
+	var missing []string
+	for n := range wanted {
+		if !found[n] {
+			missing = append(missing, n)
+		}
+	}
+	if len(missing) > 0 {
+		sort.Strings(missing)
+		return nil, fmt.Errorf("plugin not installed: %s", strings.Join(missing, ", "))
+	}
+	return filtered, nil
+}
+
+// diagnosePlugins runs diagnosePlugin for each plugin concurrently, bounded by
+// runtime.NumCPU(), and returns the results sorted by plugin name.
+func diagnosePlugins(
+	ctx context.Context,
+	launcher pluginhost.Launcher,
+	plugins []registry.PluginInfo,
+	timeout time.Duration,
+) []pluginDiagnosis {
+	var mu sync.Mutex
+	diagnoses := make([]pluginDiagnosis, 0, len(plugins))
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.NumCPU())
+	for _, p := range plugins {
+		g.Go(func() error {
+			d := diagnosePlugin(gCtx, launcher, p, timeout)
+			mu.Lock()
+			diagnoses = append(diagnoses, d)
+			mu.Unlock()
+			// Never fail the group: one broken plugin must not cancel the others.
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	sort.Slice(diagnoses, func(i, j int) bool {
+		return diagnoses[i].Plugin < diagnoses[j].Plugin
+	})
+	return diagnoses
+}
+
+// diagnosePlugin launches a single plugin and runs the RPC probes against it.
+// A launch failure is recorded as a failed check; the remaining checks are skipped.
+func diagnosePlugin(
+	ctx context.Context,
+	launcher pluginhost.Launcher,
+	plugin registry.PluginInfo,
+	timeout time.Duration,
+) pluginDiagnosis {
+	log := logging.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	d := pluginDiagnosis{
+		Plugin:  plugin.Name,
+		Version: plugin.Version,
+		Path:    plugin.Path,
+		Status:  doctorStatusOK,
+	}
+
+	start := time.Now()
+	conn, closeFn, err := launcher.Start(ctx, plugin.Path)
+	launch := pluginCheck{Name: doctorCheckLaunch, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		launch.Status = doctorStatusFail
+		launch.Detail = err.Error()
+		d.record(launch, fmt.Sprintf(
+			"Plugin binary failed to start; reinstall it with 'finfocus plugin install %s --force'", plugin.Name))
+		for _, name := range []string{doctorCheckName, doctorCheckInfo, doctorCheckProtocol, doctorCheckDryRun} {
+			d.record(pluginCheck{Name: name, Status: doctorStatusSkip}, "")
+		}
+		return d
+	}
+	defer func() {
+		if closeErr := closeFn(); closeErr != nil {
+			log.Debug().
+				Ctx(ctx).
+				Str("component", "cli").
+				Str("operation", "plugin_doctor").
+				Str("plugin", plugin.Name).
+				Err(closeErr).
+				Msg("failed to close plugin")
+		}
+	}()
+	launch.Status = doctorStatusOK
+	d.record(launch, "")
+
+	probePlugin(ctx, proto.NewCostSourceClient(conn), &d)
+	return d
+}
+
+// probePlugin calls Name, GetPluginInfo, and DryRun on api, recording a check
+// for each plus a protocol compatibility check derived from GetPluginInfo.
+func probePlugin(ctx context.Context, api proto.CostSourceClient, d *pluginDiagnosis) {
+	start := time.Now()
+	_, err := api.Name(ctx, &proto.Empty{})
+	nameCheck := pluginCheck{Name: doctorCheckName, LatencyMs: time.Since(start).Milliseconds()}
+	if err != nil {
+		nameCheck.Status = doctorStatusFail
+		nameCheck.Detail = err.Error()
+		d.record(nameCheck, fmt.Sprintf(
+			"Plugin started but is not serving the CostSource API; run with --debug to see plugin logs, "+
+				"or reinstall with 'finfocus plugin install %s --force'", d.Plugin))
+		for _, name := range []string{doctorCheckInfo, doctorCheckProtocol, doctorCheckDryRun} {
+			d.record(pluginCheck{Name: name, Status: doctorStatusSkip}, "")
+		}
+		return
+	}
+	nameCheck.Status = doctorStatusOK
+	d.record(nameCheck, "")
+
+	start = time.Now()
+	info, err := api.GetPluginInfo(ctx, &proto.Empty{})
+	infoCheck := pluginCheck{Name: doctorCheckInfo, LatencyMs: time.Since(start).Milliseconds()}
+	switch {
+	case pluginhost.IsUnimplementedError(err):
+		infoCheck.Status = doctorStatusWarn
+		infoCheck.Detail = "GetPluginInfo not implemented (legacy plugin)"
+		d.record(infoCheck, fmt.Sprintf(
+			"Plugin predates GetPluginInfo; update it with 'finfocus plugin update %s'", d.Plugin))
+	case err != nil:
+		infoCheck.Status = doctorStatusFail
+		infoCheck.Detail = err.Error()
+		d.record(infoCheck, "GetPluginInfo failed; check the plugin's logs with --debug")
+	default:
+		infoCheck.Status = doctorStatusOK
+		d.SpecVersion = info.GetSpecVersion()
+		d.Providers = info.GetProviders()
+		if v := info.GetVersion(); v != "" {
+			d.Version = v
+		}
+		d.record(infoCheck, "")
+	}
+
+	if err != nil {
+		d.record(pluginCheck{Name: doctorCheckProtocol, Status: doctorStatusSkip}, "")
+	} else {
+		d.record(checkDoctorProtocol(d.Plugin, d.SpecVersion))
+	}
+
+	resourceType := doctorSyntheticResource(d.Providers)
+	provider, _, _ := strings.Cut(resourceType, ":")
+	start = time.Now()
+	resp, err := api.DryRun(ctx, &pbc.DryRunRequest{
+		Resource: &pbc.ResourceDescriptor{
+			Provider:     provider,
+			ResourceType: resourceType,
+		},
+	})
+	dryRunCheck := pluginCheck{Name: doctorCheckDryRun, LatencyMs: time.Since(start).Milliseconds()}
+	switch {
+	case pluginhost.IsUnimplementedError(err):
+		dryRunCheck.Status = doctorStatusWarn
+		dryRunCheck.Detail = "DryRun not implemented; 'plugin inspect' is unavailable"
+		d.record(dryRunCheck, "")
+	case err != nil:
+		dryRunCheck.Status = doctorStatusFail
+		dryRunCheck.Detail = err.Error()
+		d.record(dryRunCheck, fmt.Sprintf(
+			"DryRun for %s failed; the plugin may be missing credentials or configuration, "+
+				"run 'finfocus plugin inspect %s %s' for details", resourceType, d.Plugin, resourceType))
+	case !resp.GetConfigurationValid() && len(resp.GetConfigurationErrors()) > 0:
+		dryRunCheck.Status = doctorStatusWarn
+		dryRunCheck.Detail = strings.Join(resp.GetConfigurationErrors(), "; ")
+		d.record(dryRunCheck, "Plugin reports invalid configuration: "+dryRunCheck.Detail)
+	default:
+		dryRunCheck.Status = doctorStatusOK
+		if !resp.GetResourceTypeSupported() {
+			dryRunCheck.Detail = resourceType + " not supported"
+		}
+		d.record(dryRunCheck, "")
+	}
+}
+
+// checkDoctorProtocol compares the plugin's spec version with the core spec
+// version and returns the resulting check and any suggested fix.
+func checkDoctorProtocol(plugin, specVersion string) (pluginCheck, string) {
+	check := pluginCheck{Name: doctorCheckProtocol, Status: doctorStatusOK}
+	if specVersion == "" {
+		check.Status = doctorStatusWarn
+		check.Detail = "plugin did not report a spec version"
+		return check, ""
+	}
+
+	result, err := pluginhost.CompareSpecVersions(pluginsdk.SpecVersion, specVersion)
+	switch {
+	case err != nil:
+		check.Status = doctorStatusWarn
+		check.Detail = err.Error()
+		return check, ""
+	case result == pluginhost.MajorMismatch:
+		check.Status = doctorStatusFail
+		check.Detail = fmt.Sprintf("plugin spec %s, core spec %s", specVersion, pluginsdk.SpecVersion)
+		return check, fmt.Sprintf(
+			"Spec major version mismatch; update the plugin with 'finfocus plugin update %s' "+
+				"or install a finfocus release built against spec %s", plugin, specVersion)
+	default:
+		check.Detail = "spec " + specVersion
+		return check, ""
+	}
+}
+
+// doctorSyntheticResource picks the DryRun resource type for a plugin's first
+// recognised provider.
+func doctorSyntheticResource(providers []string) string {
+	for _, p := range providers {
+		if rt, ok := doctorSyntheticResources[strings.ToLower(p)]; ok {
+			return rt
+		}
+	}
+	return doctorSyntheticResources["aws"]
+}
+
+// renderPluginDiagnoses writes a per-plugin table of check results followed by
+// the suggested fixes for any plugin with warnings or failures.
+func renderPluginDiagnoses(cmd *cobra.Command, diagnoses []pluginDiagnosis) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(w, "PLUGIN\tVERSION\tSTATUS\tLAUNCH\tNAME\tINFO\tPROTOCOL\tDRYRUN")
+	for _, d := range diagnoses {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			d.Plugin, d.Version, strings.ToUpper(string(d.Status)),
+			formatDoctorCell(d.check(doctorCheckLaunch)),
+			formatDoctorCell(d.check(doctorCheckName)),
+			formatDoctorCell(d.check(doctorCheckInfo)),
+			formatDoctorProtocolCell(d.check(doctorCheckProtocol), d.SpecVersion),
+			formatDoctorCell(d.check(doctorCheckDryRun)),
+		)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	for _, d := range diagnoses {
+		if d.Status == doctorStatusOK {
+			continue
+		}
+		cmd.Printf("\n%s:\n", d.Plugin)
+		for _, c := range d.Checks {
+			if (c.Status == doctorStatusFail || c.Status == doctorStatusWarn) && c.Detail != "" {
+				cmd.Printf("  %s %s: %s\n", strings.ToUpper(string(c.Status)), c.Name, c.Detail)
+			}
+		}
+		for _, s := range d.Suggestions {
+			cmd.Printf("  fix: %s\n", s)
+		}
+	}
+	return nil
+}
+
+// formatDoctorCell renders a check as its latency when it passed, or its status otherwise.
+func formatDoctorCell(c *pluginCheck) string {
+	if c == nil {
+		return "-"
+	}
+	switch c.Status {
+	case doctorStatusOK:
+		return fmt.Sprintf("%dms", c.LatencyMs)
+	case doctorStatusSkip:
+		return "-"
+	case doctorStatusWarn, doctorStatusFail:
+	}
+	return strings.ToUpper(string(c.Status))
+}
+
+// formatDoctorProtocolCell renders the protocol check as the plugin's spec version when compatible.
+func formatDoctorProtocolCell(c *pluginCheck, specVersion string) string {
+	if c != nil && c.Status == doctorStatusOK {
+		return specVersion
+	}
+	return formatDoctorCell(c)
+}
@@ -0,0 +1,218 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// doctorStubClient implements the RPCs probed by plugin doctor. Calls to any
+// other method panic via the nil embedded interface.
+type doctorStubClient struct {
+	proto.CostSourceClient
+
+	nameErr   error
+	info      *pbc.GetPluginInfoResponse
+	infoErr   error
+	dryRun    *pbc.DryRunResponse
+	dryRunErr error
+	dryRunReq *pbc.DryRunRequest
+}
+
+func (c *doctorStubClient) Name(context.Context, *proto.Empty, ...grpc.CallOption) (*proto.NameResponse, error) {
+	if c.nameErr != nil {
+		return nil, c.nameErr
+	}
+	return &proto.NameResponse{Name: "stub"}, nil
+}
+
+func (c *doctorStubClient) GetPluginInfo(
+	context.Context, *proto.Empty, ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return c.info, c.infoErr
+}
+
+func (c *doctorStubClient) DryRun(
+	_ context.Context, in *pbc.DryRunRequest, _ ...grpc.CallOption,
+) (*pbc.DryRunResponse, error) {
+	c.dryRunReq = in
+	return c.dryRun, c.dryRunErr
+}
+
+func TestProbePlugin(t *testing.T) {
+	unimplemented := status.Error(codes.Unimplemented, "not implemented")
+
+	tests := []struct {
+		name       string
+		client     *doctorStubClient
+		wantStatus doctorStatus
+		wantChecks map[string]doctorStatus
+		wantFix    string
+	}{
+		{
+			name: "healthy",
+			client: &doctorStubClient{
+				info: &pbc.GetPluginInfoResponse{
+					Version: "1.2.0", SpecVersion: pluginsdk.SpecVersion, Providers: []string{"gcp"},
+				},
+				dryRun: &pbc.DryRunResponse{ConfigurationValid: true, ResourceTypeSupported: true},
+			},
+			wantStatus: doctorStatusOK,
+			wantChecks: map[string]doctorStatus{
+				doctorCheckName: doctorStatusOK, doctorCheckInfo: doctorStatusOK,
+				doctorCheckProtocol: doctorStatusOK, doctorCheckDryRun: doctorStatusOK,
+			},
+		},
+		{
+			name: "legacy plugin",
+			client: &doctorStubClient{
+				infoErr: unimplemented, dryRunErr: unimplemented,
+			},
+			wantStatus: doctorStatusWarn,
+			wantChecks: map[string]doctorStatus{
+				doctorCheckInfo: doctorStatusWarn, doctorCheckProtocol: doctorStatusSkip,
+				doctorCheckDryRun: doctorStatusWarn,
+			},
+			wantFix: "finfocus plugin update p",
+		},
+		{
+			name: "spec major mismatch",
+			client: &doctorStubClient{
+				info:   &pbc.GetPluginInfoResponse{SpecVersion: "99.0.0"},
+				dryRun: &pbc.DryRunResponse{ConfigurationValid: true},
+			},
+			wantStatus: doctorStatusFail,
+			wantChecks: map[string]doctorStatus{doctorCheckProtocol: doctorStatusFail},
+			wantFix:    "Spec major version mismatch",
+		},
+		{
+			name: "invalid configuration",
+			client: &doctorStubClient{
+				info:   &pbc.GetPluginInfoResponse{SpecVersion: pluginsdk.SpecVersion},
+				dryRun: &pbc.DryRunResponse{ConfigurationErrors: []string{"missing API key"}},
+			},
+			wantStatus: doctorStatusWarn,
+			wantChecks: map[string]doctorStatus{doctorCheckDryRun: doctorStatusWarn},
+			wantFix:    "missing API key",
+		},
+		{
+			name:       "name fails",
+			client:     &doctorStubClient{nameErr: errors.New("connection reset")},
+			wantStatus: doctorStatusFail,
+			wantChecks: map[string]doctorStatus{
+				doctorCheckName: doctorStatusFail, doctorCheckInfo: doctorStatusSkip,
+				doctorCheckDryRun: doctorStatusSkip,
+			},
+			wantFix: "--debug",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := &pluginDiagnosis{Plugin: "p", Status: doctorStatusOK}
+			probePlugin(context.Background(), tt.client, d)
+
+			assert.Equal(t, tt.wantStatus, d.Status)
+			for name, want := range tt.wantChecks {
+				c := d.check(name)
+				require.NotNil(t, c, name)
+				assert.Equal(t, want, c.Status, name)
+			}
+			if tt.wantFix != "" {
+				require.NotEmpty(t, d.Suggestions)
+				assert.Contains(t, d.Suggestions[0], tt.wantFix)
+			}
+		})
+	}
+}
+
+func TestProbePlugin_SyntheticResourceFollowsProvider(t *testing.T) {
+	client := &doctorStubClient{
+		info:   &pbc.GetPluginInfoResponse{SpecVersion: pluginsdk.SpecVersion, Providers: []string{"GCP"}},
+		dryRun: &pbc.DryRunResponse{ConfigurationValid: true},
+	}
+	d := &pluginDiagnosis{Plugin: "gcp-public", Status: doctorStatusOK}
+	probePlugin(context.Background(), client, d)
+
+	require.NotNil(t, client.dryRunReq)
+	assert.Equal(t, "gcp", client.dryRunReq.GetResource().GetProvider())
+	assert.Equal(t, "gcp:compute/instance:Instance", client.dryRunReq.GetResource().GetResourceType())
+	assert.Equal(t, "aws:ec2/instance:Instance", doctorSyntheticResource(nil))
+}
+
+func TestFilterDoctorPlugins(t *testing.T) {
+	plugins := []registry.PluginInfo{
+		{Name: "aws-public", Version: "v1.0.0"},
+		{Name: "aws-public", Version: "v1.1.0"},
+		{Name: "kubecost", Version: "v0.2.0"},
+	}
+
+	all, err := filterDoctorPlugins(plugins, nil)
+	require.NoError(t, err)
+	assert.Len(t, all, 3)
+
+	filtered, err := filterDoctorPlugins(plugins, []string{"aws-public"})
+	require.NoError(t, err)
+	assert.Len(t, filtered, 2, "every installed version is checked")
+
+	_, err = filterDoctorPlugins(plugins, []string{"vantage", "aws-public", "azure"})
+	require.ErrorContains(t, err, "plugin not installed: azure, vantage")
+}
+
+func TestRenderPluginDiagnoses(t *testing.T) {
+	diagnoses := []pluginDiagnosis{
+		{
+			Plugin: "aws-public", Version: "1.0.0", SpecVersion: "0.5.6", Status: doctorStatusOK,
+			Checks: []pluginCheck{
+				{Name: doctorCheckLaunch, Status: doctorStatusOK, LatencyMs: 120},
+				{Name: doctorCheckName, Status: doctorStatusOK, LatencyMs: 2},
+				{Name: doctorCheckInfo, Status: doctorStatusOK, LatencyMs: 3},
+				{Name: doctorCheckProtocol, Status: doctorStatusOK},
+				{Name: doctorCheckDryRun, Status: doctorStatusOK, LatencyMs: 5},
+			},
+		},
+		{
+			Plugin: "broken", Version: "v0.1.0", Status: doctorStatusFail,
+			Checks: []pluginCheck{
+				{Name: doctorCheckLaunch, Status: doctorStatusFail, Detail: "exec format error"},
+				{Name: doctorCheckName, Status: doctorStatusSkip},
+			},
+			Suggestions: []string{"reinstall it"},
+		},
+	}
+
+	var out bytes.Buffer
+	cmd := &cobra.Command{}
+	cmd.SetOut(&out)
+	require.NoError(t, renderPluginDiagnoses(cmd, diagnoses))
+
+	got := out.String()
+	assert.Contains(t, got, "PLUGIN")
+	assert.Contains(t, got, "120ms")
+	assert.Contains(t, got, "0.5.6")
+	assert.Contains(t, got, "FAIL launch: exec format error")
+	assert.Contains(t, got, "fix: reinstall it")
+	assert.NotContains(t, got, "aws-public:\n", "healthy plugins have no detail section")
+}
+
+func TestRunPluginDoctor_Validation(t *testing.T) {
+	cmd := NewPluginDoctorCmd()
+	cmd.SetContext(context.Background())
+	cmd.SetOut(&bytes.Buffer{})
+
+	require.ErrorContains(t, runPluginDoctor(cmd, nil, "yaml", defaultDoctorTimeout), "unsupported output format")
+	require.ErrorContains(t, runPluginDoctor(cmd, nil, outputFormatTable, 0), "--timeout")
+}
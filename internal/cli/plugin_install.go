@@ -18,13 +18,25 @@ const (
 	repoPartCount = 2
 
 	// pluginInstallLong is the long description for the install command.
-	pluginInstallLong = `Install a plugin from the registry or directly from a GitHub URL.
+	pluginInstallLong = `Install a plugin from the registry, a GitHub URL, or an OCI registry.
 
 Plugins can be specified in several formats:
   - Registry name: kubecost
   - Registry name with version: kubecost@v1.0.0
   - GitHub URL: github.com/owner/repo
   - GitHub URL with version: github.com/owner/repo@v1.0.0
+  - OCI artifact with version tag: oci://ghcr.io/owner/repo@v1.0.0
+
+Verification:
+  GitHub release downloads are checked against the SHA-256 checksums published
+  with the release (a checksums.txt, SHA256SUMS, or <asset>.sha256 file). If a
+  release publishes no checksums, a warning is printed; use --require-checksum
+  to fail instead. OCI artifacts are always verified against their content digest.
+  Set FINFOCUS_OCI_TOKEN to pull from private OCI repositories.
+
+Active Version:
+  The latest installed version of a plugin is used by default. Use --activate,
+  or 'finfocus plugin use', to pin a specific installed version.
 
 Fallback Behavior:
   When a requested version exists but lacks compatible assets for your platform,
@@ -63,7 +75,16 @@ Fallback Behavior:
   finfocus plugin install kubecost@v1.0.0 --no-fallback
 
   # Install with region metadata (selects region-specific binary)
-  finfocus plugin install aws-public --metadata="region=us-west-2"`
+  finfocus plugin install aws-public --metadata="region=us-west-2"
+
+  # Install from an OCI registry
+  finfocus plugin install oci://ghcr.io/rshade/finfocus-plugin-kubecost@v1.0.0
+
+  # Install an older version and make it the active one
+  finfocus plugin install kubecost@v0.9.0 --activate
+
+  # Refuse releases that do not publish checksums
+  finfocus plugin install kubecost --require-checksum`
 )
 
 // formatBytes formats a byte count into a human-readable string (KB, MB, GB).
@@ -88,6 +109,12 @@ func formatBytes(bytes int64) string {
 
 // displaySecurityWarning shows security warnings for plugin installations.
 func displaySecurityWarning(cmd *cobra.Command, spec *registry.PluginSpecifier) {
+	if spec.OCI != nil {
+		cmd.Printf("⚠️  Installing from OCI registry: %s\n", spec.OCI)
+		cmd.Printf("   OCI-hosted plugins are not verified by the FinFocus team.\n")
+		cmd.Printf("   Only install from sources you trust.\n\n")
+		return
+	}
 	if spec.IsURL {
 		cmd.Printf("⚠️  Installing from URL: %s/%s\n", spec.Owner, spec.Repo)
 		cmd.Printf("   URL-based plugins are not verified by the FinFocus team.\n")
@@ -143,6 +170,9 @@ func displayInstallResult(cmd *cobra.Command, result *registry.InstallResult) {
 		cmd.Printf("  Version: %s\n", result.Version)
 	}
 	cmd.Printf("  Path:    %s\n", result.Path)
+	if result.Checksum != "" {
+		cmd.Printf("  SHA-256: %s\n", result.Checksum)
+	}
 }
 
 // isNoAssetError checks if an error indicates missing platform assets.
@@ -176,7 +206,7 @@ func handleInstallError(
 	pluginDir string,
 ) error {
 	// Check if we can attempt fallback
-	if !isNoAssetError(installErr) || spec.Version == "" || noFallback {
+	if !isNoAssetError(installErr) || spec.Version == "" || spec.OCI != nil || noFallback {
 		return fmt.Errorf("installing plugin %q: %w", specifier, installErr)
 	}
 
@@ -219,6 +249,8 @@ func handleInstallError(
 //   - --fallback-to-latest: automatically attempt a compatible latest stable release if the requested version lacks assets.
 //   - --no-fallback: disable fallback behavior entirely (mutually exclusive with --fallback-to-latest).
 //   - --metadata: repeatable key=value pairs attached to the plugin install.
+//   - --require-checksum: fail when the release publishes no checksum for the asset.
+//   - --activate: pin the installed version as the plugin's active version.
 //
 // The command prints progress, shows a security warning for URL-based installs, and displays a concise install result.
 // It returns the configured *cobra.Command.
@@ -231,6 +263,8 @@ func NewPluginInstallCmd() *cobra.Command {
 		fallbackToLatest bool
 		noFallback       bool
 		metadata         []string
+		requireChecksum  bool
+		activate         bool
 	)
 
 	cmd := &cobra.Command{
@@ -265,11 +299,11 @@ func NewPluginInstallCmd() *cobra.Command {
 				FallbackToLatest: fallbackToLatest,
 				NoFallback:       noFallback,
 				Metadata:         metadataMap,
+				RequireChecksum:  requireChecksum,
+				Activate:         activate,
 			}
 
-			progress := func(msg string) {
-				cmd.Printf("%s\n", msg)
-			}
+			progress := pluginProgress(cmd)
 
 			// Try the initial installation
 			result, err := installer.Install(specifier, opts, progress)
@@ -315,6 +349,14 @@ func NewPluginInstallCmd() *cobra.Command {
 		"Plugin metadata as key=value pairs (e.g., --metadata=\"region=us-west-2\"); repeatable",
 	)
 
+	cmd.Flags().BoolVar(
+		&requireChecksum,
+		"require-checksum",
+		false,
+		"Fail if the release does not publish a checksum for the downloaded asset",
+	)
+	cmd.Flags().BoolVar(&activate, "activate", false, "Pin the installed version as the plugin's active version")
+
 	// Mark flags as mutually exclusive
 	cmd.MarkFlagsMutuallyExclusive("fallback-to-latest", "no-fallback")
 
@@ -448,6 +490,8 @@ func handleFallback(
 		FallbackToLatest: false, // Don't recurse
 		NoFallback:       true,  // Don't recurse
 		Metadata:         opts.Metadata,
+		RequireChecksum:  opts.RequireChecksum,
+		Activate:         opts.Activate,
 	}
 
 	result, err := installer.Install(fallbackSpecifier, fallbackOpts, progress)
@@ -25,13 +25,15 @@ import (
 
 // NewPluginListCmd creates a Cobra "list" command for displaying plugins.
 // The command lists installed plugins by default and supports an `--verbose`
-// flag for detailed output and an `--available` flag to list plugins from the registry.
+// flag for detailed output, an `--available` flag to list plugins from the registry,
+// and a `--capabilities` flag that renders a per-plugin RPC support matrix.
 // It returns the configured *cobra.Command.
 func NewPluginListCmd() *cobra.Command {
 	var (
-		verbose   bool
-		available bool
-		output    string
+		verbose      bool
+		available    bool
+		capabilities bool
+		output       string
 	)
 
 	cmd := &cobra.Command{
@@ -48,11 +50,20 @@ func NewPluginListCmd() *cobra.Command {
   finfocus plugin list --available
 
   # List plugins as JSON for machine consumption
-  finfocus plugin list --output json`,
+  finfocus plugin list --output json
+
+  # Show which RPCs, providers, and resource types each plugin supports
+  finfocus plugin list --capabilities`,
 		RunE: func(cmd *cobra.Command, _ []string) error {
 			if output != outputFormatTable && output != outputFormatJSON {
 				return fmt.Errorf("unsupported output format: %s (supported: table, json)", output)
 			}
+			if capabilities {
+				if available {
+					return errors.New("--capabilities cannot be combined with --available")
+				}
+				return runPluginListCapabilities(cmd, output)
+			}
 			if available {
 				if output == outputFormatJSON {
 					return errors.New("--output json is not supported with --available")
@@ -65,6 +76,8 @@ func NewPluginListCmd() *cobra.Command {
 
 	cmd.Flags().BoolVar(&verbose, "verbose", false, "Show detailed plugin information")
 	cmd.Flags().BoolVar(&available, "available", false, "List available plugins from registry")
+	cmd.Flags().BoolVar(&capabilities, "capabilities", false,
+		"Show a matrix of supported RPCs, providers, and resource types per plugin")
 	cmd.Flags().StringVar(&output, "output", outputFormatTable, "Output format (table, json)")
 
 	return cmd
@@ -0,0 +1,295 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/sync/errgroup"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// Capability keys used in the capability matrix. They match the names
+// produced by pluginhost.ConvertCapabilities.
+const (
+	capabilityProjected       = "projected_costs"
+	capabilityActual          = "actual_costs"
+	capabilityRecommendations = "recommendations"
+	capabilityBudgets         = "budgets"
+	capabilityDismiss         = "dismiss_recommendations"
+	capabilityDryRun          = "dry_run"
+)
+
+// resourceTypesMetadataKey is the GetPluginInfo metadata key under which a
+// plugin may declare the resource types it prices, as a comma-separated list.
+const resourceTypesMetadataKey = "resource_types"
+
+// maxMatrixResourceTypes caps the resource types shown per row in the table.
+const maxMatrixResourceTypes = 3
+
+// capabilityColumns are the RPC columns of the matrix, in display order.
+//
+//nolint:gochecknoglobals // Read-only column definition.
+var capabilityColumns = []struct {
+	header string
+	key    string
+}{
+	{"PROJECTED", capabilityProjected},
+	{"ACTUAL", capabilityActual},
+	{"RECOMMEND", capabilityRecommendations},
+	{"BUDGETS", capabilityBudgets},
+	{"DISMISS", capabilityDismiss},
+	{"DRYRUN", capabilityDryRun},
+}
+
+// legacyCapabilityNames maps the capability names plugin list assigns to
+// legacy plugins onto the ConvertCapabilities names.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var legacyCapabilityNames = map[string]string{
+	"ProjectedCosts": capabilityProjected,
+	"ActualCosts":    capabilityActual,
+}
+
+// pluginCapabilityRow is one plugin's row in the capability matrix.
+type pluginCapabilityRow struct {
+	Name          string          `json:"name"`
+	Version       string          `json:"version"`
+	Providers     []string        `json:"providers"`
+	RPCs          map[string]bool `json:"rpcs"`
+	ResourceTypes []string        `json:"resourceTypes"`
+	Notes         string          `json:"notes,omitempty"`
+}
+
+// runPluginListCapabilities launches every installed plugin and renders a
+// matrix of the RPCs each supports, its provider coverage, and the resource
+// types it declares.
+func runPluginListCapabilities(cmd *cobra.Command, output string) error {
+	cfg := config.New()
+	var plugins []registry.PluginInfo
+	if _, err := os.Stat(cfg.PluginDir); err == nil {
+		var listErr error
+		plugins, listErr = registry.NewDefault().ListPlugins()
+		if listErr != nil {
+			return fmt.Errorf("listing plugins: %w", listErr)
+		}
+	}
+
+	rows := fetchPluginCapabilitiesParallel(cmd.Context(), pluginhost.NewProcessLauncher(), plugins)
+
+	if output == outputFormatJSON {
+		if rows == nil {
+			rows = []pluginCapabilityRow{}
+		}
+		data, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling capability matrix to JSON: %w", err)
+		}
+		cmd.Printf("%s\n", data)
+		return nil
+	}
+
+	if len(rows) == 0 {
+		cmd.Println("No plugins found.")
+		return nil
+	}
+	return renderCapabilityMatrix(cmd, rows)
+}
+
+// fetchPluginCapabilitiesParallel probes each plugin concurrently, bounded by
+// runtime.NumCPU(), and returns rows sorted by plugin name then version.
+func fetchPluginCapabilitiesParallel(
+	ctx context.Context,
+	launcher pluginhost.Launcher,
+	plugins []registry.PluginInfo,
+) []pluginCapabilityRow {
+	var mu sync.Mutex
+	var rows []pluginCapabilityRow
+
+	g, gCtx := errgroup.WithContext(ctx)
+	g.SetLimit(runtime.NumCPU())
+	for _, p := range plugins {
+		g.Go(func() error {
+			row := fetchPluginCapabilities(gCtx, launcher, p)
+			mu.Lock()
+			rows = append(rows, row)
+			mu.Unlock()
+			return nil
+		})
+	}
+	_ = g.Wait()
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Name != rows[j].Name {
+			return rows[i].Name < rows[j].Name
+		}
+		return rows[i].Version < rows[j].Version
+	})
+	return rows
+}
+
+// fetchPluginCapabilities launches a plugin and builds its capability row.
+// Launch failures are reported in the row's Notes.
+func fetchPluginCapabilities(
+	ctx context.Context,
+	launcher pluginhost.Launcher,
+	plugin registry.PluginInfo,
+) pluginCapabilityRow {
+	const launchTimeout = 10 * time.Second
+	launchCtx, cancel := context.WithTimeout(ctx, launchTimeout)
+	defer cancel()
+
+	client, err := pluginhost.NewClient(launchCtx, launcher, plugin.Path)
+	if err != nil {
+		logging.FromContext(ctx).Debug().
+			Ctx(ctx).
+			Str("component", "cli").
+			Str("operation", "plugin_list_capabilities").
+			Str("plugin_path", plugin.Path).
+			Err(err).
+			Msg("failed to launch plugin")
+		return pluginCapabilityRow{
+			Name:    plugin.Name,
+			Version: plugin.Version,
+			RPCs:    map[string]bool{},
+			Notes:   fmt.Sprintf("Failed: %v", err),
+		}
+	}
+	defer func() { _ = client.Close() }()
+
+	return buildCapabilityRow(launchCtx, plugin, client.Metadata, client.API)
+}
+
+// buildCapabilityRow combines a plugin's declared capabilities with a DryRun
+// probe. DryRun is marked supported when the RPC answers with anything other
+// than Unimplemented, whether or not the plugin declares it. Plugins without
+// GetPluginInfo metadata are assumed to support projected and actual costs.
+func buildCapabilityRow(
+	ctx context.Context,
+	plugin registry.PluginInfo,
+	metadata *proto.PluginMetadata,
+	api proto.CostSourceClient,
+) pluginCapabilityRow {
+	row := pluginCapabilityRow{
+		Name:    plugin.Name,
+		Version: plugin.Version,
+		RPCs:    make(map[string]bool, len(capabilityColumns)),
+	}
+
+	declared := []string{capabilityProjected, capabilityActual}
+	if metadata != nil {
+		if metadata.Version != "" {
+			row.Version = metadata.Version
+		}
+		row.Providers = metadata.SupportedProviders
+		if len(metadata.Capabilities) > 0 {
+			declared = metadata.Capabilities
+		}
+		row.ResourceTypes = parseDeclaredResourceTypes(metadata.Metadata[resourceTypesMetadataKey])
+	} else {
+		row.Notes = "legacy plugin: capabilities assumed"
+	}
+
+	for _, c := range declared {
+		if legacy, ok := legacyCapabilityNames[c]; ok {
+			c = legacy
+		}
+		row.RPCs[c] = true
+	}
+
+	resourceType := doctorSyntheticResource(row.Providers)
+	provider, _, _ := strings.Cut(resourceType, ":")
+	_, err := api.DryRun(ctx, &pbc.DryRunRequest{
+		Resource: &pbc.ResourceDescriptor{Provider: provider, ResourceType: resourceType},
+	})
+	row.RPCs[capabilityDryRun] = !pluginhost.IsUnimplementedError(err)
+
+	if row.Providers == nil {
+		row.Providers = []string{}
+	}
+	if row.ResourceTypes == nil {
+		row.ResourceTypes = []string{}
+	}
+	return row
+}
+
+// parseDeclaredResourceTypes splits a comma-separated resource type list,
+// trimming whitespace and dropping empty entries. The result is sorted.
+func parseDeclaredResourceTypes(s string) []string {
+	var types []string
+	for _, t := range strings.Split(s, ",") {
+		if t = strings.TrimSpace(t); t != "" {
+			types = append(types, t)
+		}
+	}
+	sort.Strings(types)
+	return types
+}
+
+// renderCapabilityMatrix writes the capability matrix as a table, marking
+// supported RPCs with ✓ and unsupported ones with -.
+func renderCapabilityMatrix(cmd *cobra.Command, rows []pluginCapabilityRow) error {
+	w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+
+	headers := []string{"NAME", "VERSION"}
+	for _, col := range capabilityColumns {
+		headers = append(headers, col.header)
+	}
+	headers = append(headers, "PROVIDERS", "RESOURCE TYPES")
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	hasNotes := false
+	for _, row := range rows {
+		cells := []string{row.Name, row.Version}
+		for _, col := range capabilityColumns {
+			mark := "-"
+			if row.RPCs[col.key] {
+				mark = "✓"
+			}
+			cells = append(cells, mark)
+		}
+		cells = append(cells, formatProviders(row.Providers), formatMatrixResourceTypes(row.ResourceTypes))
+		fmt.Fprintln(w, strings.Join(cells, "\t"))
+		hasNotes = hasNotes || row.Notes != ""
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if hasNotes {
+		cmd.Println()
+		for _, row := range rows {
+			if row.Notes != "" {
+				cmd.Printf("%s: %s\n", row.Name, row.Notes)
+			}
+		}
+	}
+	return nil
+}
+
+// formatMatrixResourceTypes shows up to maxMatrixResourceTypes entries and a
+// count of the rest; "-" when none are declared.
+func formatMatrixResourceTypes(types []string) string {
+	if len(types) == 0 {
+		return "-"
+	}
+	if len(types) <= maxMatrixResourceTypes {
+		return strings.Join(types, ", ")
+	}
+	return fmt.Sprintf("%s (+%d more)",
+		strings.Join(types[:maxMatrixResourceTypes], ", "), len(types)-maxMatrixResourceTypes)
+}
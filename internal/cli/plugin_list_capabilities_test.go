@@ -0,0 +1,98 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+func TestBuildCapabilityRow(t *testing.T) {
+	plugin := registry.PluginInfo{Name: "aws-ce", Version: "v1.0.0"}
+
+	t.Run("declared capabilities and resource types", func(t *testing.T) {
+		client := &doctorStubClient{dryRun: &pbc.DryRunResponse{}}
+		row := buildCapabilityRow(context.Background(), plugin, &proto.PluginMetadata{
+			Version:            "1.0.0",
+			SupportedProviders: []string{"aws"},
+			Capabilities:       []string{capabilityActual, capabilityRecommendations, capabilityDismiss},
+			Metadata:           map[string]string{resourceTypesMetadataKey: " aws:s3/bucket:Bucket, ,aws:ec2/instance:Instance"},
+		}, client)
+
+		assert.Equal(t, "1.0.0", row.Version)
+		assert.Equal(t, []string{"aws"}, row.Providers)
+		assert.True(t, row.RPCs[capabilityActual])
+		assert.True(t, row.RPCs[capabilityRecommendations])
+		assert.True(t, row.RPCs[capabilityDismiss])
+		assert.False(t, row.RPCs[capabilityProjected])
+		assert.False(t, row.RPCs[capabilityBudgets])
+		assert.True(t, row.RPCs[capabilityDryRun], "answered DryRun counts as supported")
+		assert.Equal(t, []string{"aws:ec2/instance:Instance", "aws:s3/bucket:Bucket"}, row.ResourceTypes)
+		require.NotNil(t, client.dryRunReq)
+		assert.Equal(t, "aws", client.dryRunReq.GetResource().GetProvider())
+	})
+
+	t.Run("legacy plugin without metadata", func(t *testing.T) {
+		client := &doctorStubClient{dryRunErr: status.Error(codes.Unimplemented, "not implemented")}
+		row := buildCapabilityRow(context.Background(), plugin, nil, client)
+
+		assert.Equal(t, "v1.0.0", row.Version)
+		assert.True(t, row.RPCs[capabilityProjected])
+		assert.True(t, row.RPCs[capabilityActual])
+		assert.False(t, row.RPCs[capabilityDryRun])
+		assert.Empty(t, row.Providers)
+		assert.Empty(t, row.ResourceTypes)
+		assert.Contains(t, row.Notes, "legacy")
+	})
+
+	t.Run("declared dry run that is unimplemented", func(t *testing.T) {
+		client := &doctorStubClient{dryRunErr: status.Error(codes.Unimplemented, "not implemented")}
+		row := buildCapabilityRow(context.Background(), plugin, &proto.PluginMetadata{
+			Capabilities: []string{"ProjectedCosts", capabilityDryRun},
+		}, client)
+
+		assert.True(t, row.RPCs[capabilityProjected], "legacy names are normalised")
+		assert.False(t, row.RPCs[capabilityDryRun], "probe overrides the declaration")
+	})
+}
+
+func TestRenderCapabilityMatrix(t *testing.T) {
+	rows := []pluginCapabilityRow{
+		{
+			Name:          "aws-public",
+			Version:       "1.0.0",
+			Providers:     []string{"aws"},
+			RPCs:          map[string]bool{capabilityProjected: true, capabilityDryRun: true},
+			ResourceTypes: []string{"a", "b", "c", "d", "e"},
+		},
+		{Name: "broken", Version: "v0.1.0", RPCs: map[string]bool{}, Notes: "Failed: timeout"},
+	}
+
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, renderCapabilityMatrix(cmd, rows))
+
+	got := out.String()
+	assert.Contains(t, got, "PROJECTED")
+	assert.Contains(t, got, "RESOURCE TYPES")
+	assert.Contains(t, got, "a, b, c (+2 more)")
+	assert.Contains(t, got, "broken: Failed: timeout")
+}
+
+func TestPluginListCmd_CapabilitiesRejectsAvailable(t *testing.T) {
+	cmd := NewPluginListCmd()
+	cmd.SetArgs([]string{"--capabilities", "--available"})
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	require.ErrorContains(t, cmd.Execute(), "cannot be combined")
+}
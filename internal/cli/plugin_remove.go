@@ -47,10 +47,7 @@ This will delete the plugin files and remove it from the configuration.`,
 				PluginDir:  pluginDir,
 			}
 
-			// Progress callback
-			progress := func(msg string) {
-				cmd.Printf("%s\n", msg)
-			}
+			progress := pluginProgress(cmd)
 
 			// Remove
 			if err := installer.Remove(name, opts, progress); err != nil {
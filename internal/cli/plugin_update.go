@@ -31,11 +31,14 @@ func NewPluginUpdateCmd() *cobra.Command {
 	)
 
 	cmd := &cobra.Command{
-		Use:   "update <plugin>",
-		Short: "Update an installed plugin to the latest version",
+		Use:     "update <plugin>",
+		Aliases: []string{"upgrade"},
+		Short:   "Update an installed plugin to the latest version",
 		Long: `Update an installed plugin to the latest version or a specific version.
 
-The plugin must already be installed. Use 'plugin install' to install new plugins.`,
+The plugin must already be installed. Use 'plugin install' to install new plugins.
+Plugins installed from an OCI registry are updated to the highest version tag.
+If the replaced version was pinned as active, the pin moves to the new version.`,
 		Example: `  # Update to latest version
   finfocus plugin update kubecost
 
@@ -57,10 +60,7 @@ The plugin must already be installed. Use 'plugin install' to install new plugin
 				PluginDir: pluginDir,
 			}
 
-			// Progress callback
-			progress := func(msg string) {
-				cmd.Printf("%s\n", msg)
-			}
+			progress := pluginProgress(cmd)
 
 			// Update
 			result, err := installer.Update(name, opts, progress)
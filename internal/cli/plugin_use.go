@@ -0,0 +1,78 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+// NewPluginUseCmd returns a Cobra command that switches the active version of an
+// installed plugin. With a version argument the plugin is pinned to that version;
+// with --latest the pin is removed so the newest installed version is used.
+func NewPluginUseCmd() *cobra.Command {
+	var (
+		latest    bool
+		pluginDir string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "use <plugin> [version]",
+		Short: "Switch the active version of an installed plugin",
+		Long: `Switch the active version of an installed plugin.
+
+When several versions of a plugin are installed, the latest is used by default.
+Pinning a version makes finfocus use it until the pin is changed or cleared.
+The version must already be installed; use 'plugin install <plugin>@<version>'
+to add it.`,
+		Example: `  # Pin kubecost to an older installed version
+  finfocus plugin use kubecost v0.9.0
+
+  # Go back to using the latest installed version
+  finfocus plugin use kubecost --latest`,
+		Args: cobra.RangeArgs(1, 2), //nolint:mnd // plugin name and optional version
+		RunE: func(cmd *cobra.Command, args []string) error {
+			version := ""
+			if len(args) > 1 {
+				version = args[1]
+			}
+			return runPluginUse(cmd, args[0], version, latest, pluginDir)
+		},
+	}
+
+	cmd.Flags().BoolVar(&latest, "latest", false, "Clear the pin and use the latest installed version")
+	cmd.Flags().StringVar(&pluginDir, "plugin-dir", "", "Custom plugin directory")
+
+	return cmd
+}
+
+// runPluginUse pins or unpins the active version of the named plugin.
+func runPluginUse(cmd *cobra.Command, name, version string, latest bool, pluginDir string) error {
+	switch {
+	case latest && version != "":
+		return errors.New("specify a version or --latest, not both")
+	case !latest && version == "":
+		return errors.New("specify a version to pin, or --latest to use the newest installed version")
+	}
+
+	if pluginDir == "" {
+		pluginDir = config.New().PluginDir
+	}
+
+	if latest {
+		if err := registry.ClearActiveVersion(pluginDir, name); err != nil {
+			return err
+		}
+		cmd.Printf("✓ Plugin %s now uses the latest installed version\n", name)
+		return nil
+	}
+
+	if err := registry.SetActiveVersion(pluginDir, name, version); err != nil {
+		return fmt.Errorf("%w; install it with 'finfocus plugin install %s@%s'", err, name, version)
+	}
+	cmd.Printf("✓ Plugin %s now uses %s\n", name, version)
+	return nil
+}
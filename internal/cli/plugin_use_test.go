@@ -0,0 +1,65 @@
+package cli_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/cli"
+	"github.com/rshade/finfocus/internal/registry"
+)
+
+func TestPluginUseCmd(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	pluginDir := t.TempDir()
+	for _, v := range []string{"v1.0.0", "v2.0.0"} {
+		require.NoError(t, os.MkdirAll(filepath.Join(pluginDir, "kubecost", v), 0o755))
+	}
+
+	run := func(args ...string) (string, error) {
+		rootCmd := cli.NewRootCmd("test")
+		var out bytes.Buffer
+		rootCmd.SetOut(&out)
+		rootCmd.SetErr(&out)
+		rootCmd.SetArgs(append([]string{"plugin", "use", "--plugin-dir", pluginDir}, args...))
+		err := rootCmd.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("kubecost", "v1.0.0")
+	require.NoError(t, err)
+	assert.Contains(t, out, "now uses v1.0.0")
+	active, err := registry.ReadActiveVersion(pluginDir, "kubecost")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", active)
+
+	_, err = run("kubecost", "v3.0.0")
+	require.ErrorContains(t, err, "finfocus plugin install kubecost@v3.0.0")
+
+	_, err = run("kubecost")
+	require.ErrorContains(t, err, "--latest")
+	_, err = run("kubecost", "v1.0.0", "--latest")
+	require.ErrorContains(t, err, "not both")
+
+	out, err = run("kubecost", "--latest")
+	require.NoError(t, err)
+	assert.Contains(t, out, "latest installed version")
+	active, err = registry.ReadActiveVersion(pluginDir, "kubecost")
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func TestPluginUpgradeAlias(t *testing.T) {
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	rootCmd := cli.NewRootCmd("test")
+
+	var stdout bytes.Buffer
+	rootCmd.SetOut(&stdout)
+	rootCmd.SetArgs([]string{"plugin", "upgrade", "--help"})
+	require.NoError(t, rootCmd.Execute())
+	assert.Contains(t, stdout.String(), "update <plugin>")
+}
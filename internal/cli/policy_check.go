@@ -0,0 +1,383 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/policy"
+)
+
+// defaultPolicyExitCode is the exit code of a failed policy check. It differs
+// from the exit code 1 of command errors so CI can tell them apart.
+const defaultPolicyExitCode = 2
+
+// PolicyViolationError is returned by 'policy check' when violations meet the
+// --fail-on severity. It carries the process exit code.
+type PolicyViolationError struct {
+	ExitCode   int
+	Violations int
+}
+
+func (e *PolicyViolationError) Error() string {
+	return fmt.Sprintf("%d policy violations", e.Violations)
+}
+
+// policyCheckParams holds the parameters for the policy check command execution.
+type policyCheckParams struct {
+	planPath    string
+	policyPath  string
+	regoPaths   []string
+	regoPackage string
+	stack       string
+	adapter     string
+	output      string
+	failOn      string
+	exitCode    int
+}
+
+// newPolicyCmd creates the policy command group.
+func newPolicyCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "policy", Short: "Cost guardrail commands"}
+	cmd.AddCommand(NewPolicyCheckCmd())
+	return cmd
+}
+
+// NewPolicyCheckCmd creates the "check" subcommand, which evaluates the cost
+// policies of a policy file, and any Rego policies, against the projected
+// costs of a Pulumi plan.
+func NewPolicyCheckCmd() *cobra.Command {
+	var params policyCheckParams
+
+	cmd := &cobra.Command{
+		Use:   "check",
+		Short: "Check a Pulumi plan against cost policies",
+		Long: `Check a Pulumi plan against the cost policies of a policy file.
+
+Each policy applies one rule to the resources it matches: a largest instance
+size, a largest projected monthly cost, or required tags. A max_monthly_delta
+policy instead caps the increase of the plan's projected monthly cost over the
+projection recorded for --stack with 'cost projected --record'; it is skipped
+when nothing has been recorded.
+
+Policies can also be written in Rego with --rego. The modules define "deny"
+and "warn" rules in the --rego-package package; each is a set of messages,
+either strings or objects with "msg" and optional "resource" and "policy"
+fields. Their input has the plan's "resources" (resource descriptors),
+"costs" (cost results as in 'cost projected --output json'), "currency", and
+"delta" (null without a recorded projection).
+
+Without --policy, the policy files and required tags of the configuration
+(policy.files and policy.require_tags) are checked, usually set by the
+.finfocus.yaml project config file of the repository, and --stack defaults to
+defaults.stack.
+
+Violations are reported per resource. The command exits with --exit-code
+(default 2) when a violation meets the --fail-on severity, and with 1 when the
+check itself fails, so CI can tell the two apart.`,
+		Example: `  # Check a plan
+  finfocus policy check --pulumi-json plan.json --policy policies.yaml
+
+  # Compare against the projection recorded for the prod stack
+  finfocus policy check --pulumi-json plan.json --policy policies.yaml --stack prod
+
+  # Add Rego policies from a directory
+  finfocus policy check --pulumi-json plan.json --policy policies.yaml --rego policies/
+
+  # Check the policies of the repository's .finfocus.yaml
+  finfocus policy check --pulumi-json plan.json
+
+  # Fail on warnings too, with JSON output for tooling
+  finfocus policy check --pulumi-json plan.json --policy policies.yaml --fail-on warning --output json`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executePolicyCheck(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "",
+		"Path to Pulumi preview JSON output (auto-detected from the Pulumi project when omitted)")
+	cmd.Flags().StringVar(&params.policyPath, "policy", "",
+		"Path to the policy file (default: the configured policy.files and policy.require_tags)")
+	cmd.Flags().StringArrayVar(&params.regoPaths, "rego", nil,
+		"Path to a Rego policy module or a directory of modules (repeatable)")
+	cmd.Flags().StringVar(&params.regoPackage, "rego-package", policy.DefaultRegoPackage,
+		"Rego package holding the deny and warn rules")
+	cmd.Flags().StringVar(&params.stack, "stack", "",
+		"Stack whose recorded projection is the baseline for max_monthly_delta")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	cmd.Flags().StringVar(&params.failOn, "fail-on", string(policy.SeverityError),
+		"Lowest violation severity that fails the check: error, warning, or none")
+	cmd.Flags().IntVar(&params.exitCode, "exit-code", defaultPolicyExitCode,
+		"Exit code when the check fails")
+
+	return cmd
+}
+
+// executePolicyCheck prices the plan, evaluates the policies, and reports
+// the violations.
+func executePolicyCheck(cmd *cobra.Command, params policyCheckParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	switch params.output {
+	case outputFormatTable, outputFormatJSON:
+	default:
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+	switch params.failOn {
+	case string(policy.SeverityError), string(policy.SeverityWarning), "none":
+	default:
+		return fmt.Errorf("invalid --fail-on value %q: use error, warning, or none", params.failOn)
+	}
+	params.stack = getStackFlag(cmd)
+
+	cfg := config.New()
+	policies, regoPolicies, err := loadPolicies(ctx, params, cfg)
+	if err != nil {
+		return err
+	}
+
+	audit := newAuditContext(ctx, "policy check", map[string]string{
+		"pulumi_json": params.planPath, "policy": params.policyPath,
+		"rego": strings.Join(params.regoPaths, ","), "stack": params.stack,
+	})
+
+	resources, err := loadAllocationResources(ctx, cmd, params.planPath, modePulumiPreview, audit)
+	if err != nil {
+		return err
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, nil).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	priced, err := eng.GetProjectedCostWithErrors(ctx, resources)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return fmt.Errorf("calculating projected costs: %w", err)
+	}
+
+	input, err := policyInput(ctx, resources, priced.Results, params.stack)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	result, err := evaluatePolicies(ctx, policies, regoPolicies, input, resources, priced.Results)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "policy_check").
+		Int("policy_count", result.Policies).Int("violation_count", len(result.Violations)).
+		Dur("duration_ms", time.Since(audit.start)).Msg("policy check complete")
+	total := 0.0
+	for _, r := range input.Resources {
+		total += r.Monthly
+	}
+	audit.logSuccess(ctx, len(input.Resources), total)
+
+	if err = renderPolicyResult(cmd.OutOrStdout(), params.output, result); err != nil {
+		return err
+	}
+	return policyCheckExit(result, params.failOn, params.exitCode)
+}
+
+// loadPolicies loads the policy file, or the policies configured in cfg
+// without one, and the Rego policies. It fails when there are no policies.
+func loadPolicies(
+	ctx context.Context,
+	params policyCheckParams,
+	cfg *config.Config,
+) (*policy.File, *policy.RegoPolicies, error) {
+	var file *policy.File
+	var err error
+	if params.policyPath != "" {
+		if file, err = policy.Load(params.policyPath); err != nil {
+			return nil, nil, err
+		}
+	} else if file, err = configuredPolicies(cfg); err != nil {
+		return nil, nil, err
+	}
+	if len(params.regoPaths) == 0 {
+		if file == nil {
+			return nil, nil, errors.New("no policies to check: use --policy or --rego, " +
+				"or set policy.files or policy.require_tags in the configuration")
+		}
+		return file, nil, nil
+	}
+	regoPolicies, err := policy.LoadRego(ctx, params.regoPaths, params.regoPackage)
+	if err != nil {
+		return nil, nil, err
+	}
+	return file, regoPolicies, nil
+}
+
+// configuredPolicies combines the policies of the configured policy files
+// with a "required-tags" policy for the configured required tags. It
+// returns nil when neither is configured.
+func configuredPolicies(cfg *config.Config) (*policy.File, error) {
+	files, tags := cfg.PolicyFiles(), cfg.RequiredTags()
+	if len(files) == 0 && len(tags) == 0 {
+		return nil, nil
+	}
+	combined := &policy.File{}
+	for _, path := range files {
+		file, err := policy.Load(path)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		combined.Policies = append(combined.Policies, file.Policies...)
+	}
+	if len(tags) > 0 {
+		combined.Policies = append(combined.Policies, policy.Policy{
+			Name:        "required-tags",
+			Description: "Tags required by policy.require_tags",
+			RequireTags: tags,
+		})
+	}
+	if err := combined.Validate(); err != nil {
+		return nil, err
+	}
+	return combined, nil
+}
+
+// evaluatePolicies evaluates the policy file and then the Rego policies, and
+// combines their violations. Each Rego module counts as one policy.
+func evaluatePolicies(
+	ctx context.Context,
+	file *policy.File,
+	regoPolicies *policy.RegoPolicies,
+	input policy.Input,
+	resources []engine.ResourceDescriptor,
+	results []engine.CostResult,
+) (*policy.Result, error) {
+	result := policy.Evaluate(file, input)
+	if regoPolicies == nil {
+		return result, nil
+	}
+
+	violations, err := regoPolicies.Evaluate(ctx, policy.RegoInput{
+		Currency:  input.Currency,
+		Delta:     input.Delta,
+		Resources: resources,
+		Costs:     results,
+	})
+	if err != nil {
+		return nil, err
+	}
+	result.Policies += regoPolicies.Modules
+	result.Violations = append(result.Violations, violations...)
+	return result, nil
+}
+
+// policyInput pairs each priced resource with its tags and instance type and
+// computes the delta against the projection recorded for stack. Results that
+// carry errors are left out.
+func policyInput(
+	ctx context.Context,
+	resources []engine.ResourceDescriptor,
+	results []engine.CostResult,
+	stack string,
+) (policy.Input, error) {
+	byID := make(map[string]engine.ResourceDescriptor, len(resources))
+	for _, r := range resources {
+		byID[r.ID] = r
+	}
+
+	input := policy.Input{}
+	input.Currency, _ = extractCurrencyFromResults(results)
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		desc := byID[r.ResourceID]
+		input.Resources = append(input.Resources, policy.Resource{
+			ID:           r.ResourceID,
+			Type:         r.ResourceType,
+			Tags:         engine.ResourceTags(desc.Properties),
+			InstanceType: policy.InstanceType(desc.Properties),
+			Monthly:      r.Monthly,
+		})
+	}
+
+	delta, err := projectionBaseline(config.NewProjectionHistoryStore(""), stack, results, time.Now())
+	if err != nil {
+		return input, err
+	}
+	if delta != nil {
+		input.Delta = &delta.Change
+	} else if stack != "" {
+		logging.FromContext(ctx).Debug().Ctx(ctx).Str("stack", stack).
+			Msg("no recorded projection; max_monthly_delta policies are skipped")
+	}
+	return input, nil
+}
+
+// policyCheckExit returns a PolicyViolationError when violations meet the
+// failOn severity. An exit code of zero reports violations without failing.
+func policyCheckExit(result *policy.Result, failOn string, exitCode int) error {
+	failing := 0
+	switch failOn {
+	case string(policy.SeverityWarning):
+		failing = len(result.Violations)
+	case string(policy.SeverityError):
+		failing = result.Count(policy.SeverityError)
+	}
+	if failing == 0 || exitCode == 0 {
+		return nil
+	}
+	return &PolicyViolationError{ExitCode: exitCode, Violations: failing}
+}
+
+// renderPolicyResult renders the policy check result in the requested format.
+func renderPolicyResult(w io.Writer, format string, result *policy.Result) error {
+	if format == outputFormatJSON {
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(result); err != nil {
+			return fmt.Errorf("encoding policy result JSON: %w", err)
+		}
+		return nil
+	}
+
+	if len(result.Violations) > 0 {
+		tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+		fmt.Fprintln(tw, "SEVERITY\tPOLICY\tRESOURCE\tVIOLATION")
+		fmt.Fprintln(tw, "--------\t------\t--------\t---------")
+		for _, v := range result.Violations {
+			resource := v.ResourceID
+			if resource == "" {
+				resource = "(plan)"
+			}
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\n", strings.ToUpper(string(v.Severity)), v.Policy, resource, v.Message)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		fmt.Fprintln(w)
+	}
+
+	for _, s := range result.Skipped {
+		fmt.Fprintf(w, "Skipped %s: %s\n", s.Policy, s.Reason)
+	}
+	fmt.Fprintf(w, "%d policies checked against %d resources: %d errors, %d warnings\n",
+		result.Policies, result.Resources, result.Count(policy.SeverityError), result.Count(policy.SeverityWarning))
+	return nil
+}
@@ -0,0 +1,172 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/policy"
+)
+
+func policyTestResult() *policy.Result {
+	return &policy.Result{
+		Policies:  3,
+		Resources: 4,
+		Violations: []policy.Violation{
+			{Policy: "dev-instance-size", Severity: policy.SeverityError, ResourceID: "web",
+				ResourceType: "aws:ec2/instance:Instance", Message: "instance type m5.8xlarge is larger than 4xlarge"},
+			{Policy: "gpu-approval", Severity: policy.SeverityWarning, ResourceID: "trainer",
+				ResourceType: "aws:ec2/instance:Instance", Message: "missing required tags: approved-by"},
+		},
+		Skipped: []policy.SkippedPolicy{{Policy: "monthly-delta", Reason: "no recorded baseline"}},
+	}
+}
+
+func TestRenderPolicyResult_Table(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderPolicyResult(&buf, outputFormatTable, policyTestResult()))
+
+	out := buf.String()
+	assert.Contains(t, out, "SEVERITY")
+	assert.Contains(t, out, "ERROR")
+	assert.Contains(t, out, "instance type m5.8xlarge is larger than 4xlarge")
+	assert.Contains(t, out, "WARNING")
+	assert.Contains(t, out, "Skipped monthly-delta: no recorded baseline")
+	assert.Contains(t, out, "3 policies checked against 4 resources: 1 errors, 1 warnings")
+}
+
+func TestRenderPolicyResult_PlanWideViolation(t *testing.T) {
+	result := &policy.Result{Policies: 1, Violations: []policy.Violation{
+		{Policy: "monthly-delta", Severity: policy.SeverityError, Message: "too much"},
+	}}
+
+	var buf bytes.Buffer
+	require.NoError(t, renderPolicyResult(&buf, outputFormatTable, result))
+	assert.Contains(t, buf.String(), "(plan)")
+}
+
+func TestRenderPolicyResult_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, renderPolicyResult(&buf, outputFormatJSON, policyTestResult()))
+
+	var decoded policy.Result
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &decoded))
+	assert.Equal(t, *policyTestResult(), decoded)
+}
+
+func TestPolicyCheckExit(t *testing.T) {
+	errorsOnly := &policy.Result{Violations: []policy.Violation{{Severity: policy.SeverityWarning}}}
+
+	tests := []struct {
+		name     string
+		result   *policy.Result
+		failOn   string
+		exitCode int
+		want     *PolicyViolationError
+	}{
+		{"errors fail by default", policyTestResult(), "error", 2, &PolicyViolationError{ExitCode: 2, Violations: 1}},
+		{"warnings fail when requested", policyTestResult(), "warning", 3,
+			&PolicyViolationError{ExitCode: 3, Violations: 2}},
+		{"warnings pass on error", errorsOnly, "error", 2, nil},
+		{"none never fails", policyTestResult(), "none", 2, nil},
+		{"exit code zero never fails", policyTestResult(), "error", 0, nil},
+		{"no violations", &policy.Result{}, "warning", 2, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policyCheckExit(tt.result, tt.failOn, tt.exitCode)
+			if tt.want == nil {
+				require.NoError(t, err)
+				return
+			}
+			var violation *PolicyViolationError
+			require.ErrorAs(t, err, &violation)
+			assert.Equal(t, tt.want, violation)
+		})
+	}
+}
+
+func TestPolicyCheckCmd_InvalidFlags(t *testing.T) {
+	policyPath := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("policies: [{name: a, max_monthly_cost: 10}]"), 0o600))
+
+	tests := map[string][]string{
+		"output":  {"--policy", policyPath, "--output", "csv"},
+		"fail-on": {"--policy", policyPath, "--fail-on", "info"},
+		"policy":  {"--policy", filepath.Join(t.TempDir(), "missing.yaml")},
+		"missing": {},
+	}
+	for name, args := range tests {
+		t.Run(name, func(t *testing.T) {
+			cmd := NewPolicyCheckCmd()
+			cmd.SetArgs(args)
+			cmd.SetOut(&bytes.Buffer{})
+			cmd.SetErr(&bytes.Buffer{})
+			require.Error(t, cmd.Execute())
+		})
+	}
+}
+
+func TestEvaluatePolicies_Rego(t *testing.T) {
+	regoPath := filepath.Join(t.TempDir(), "cost.rego")
+	require.NoError(t, os.WriteFile(regoPath, []byte(`package finfocus
+
+deny contains {"msg": "too expensive", "resource": c.resourceId} if {
+	some c in input.costs
+	c.monthly > 100
+}
+`), 0o600))
+	regoPolicies, err := policy.LoadRego(context.Background(), []string{regoPath}, "")
+	require.NoError(t, err)
+
+	limit := 50.0
+	file := &policy.File{Policies: []policy.Policy{{Name: "cap", MaxMonthlyCost: &limit}}}
+	resources := []engine.ResourceDescriptor{{ID: "web", Type: "aws:ec2/instance:Instance"}}
+	results := []engine.CostResult{{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 200}}
+	input := policy.Input{Resources: []policy.Resource{{ID: "web", Type: "aws:ec2/instance:Instance", Monthly: 200}}}
+
+	result, err := evaluatePolicies(context.Background(), file, regoPolicies, input, resources, results)
+	require.NoError(t, err)
+	assert.Equal(t, 2, result.Policies)
+	require.Len(t, result.Violations, 2)
+	assert.Equal(t, "cap", result.Violations[0].Policy)
+	assert.Equal(t, "finfocus.deny", result.Violations[1].Policy)
+	assert.Equal(t, "aws:ec2/instance:Instance", result.Violations[1].ResourceType)
+
+	result, err = evaluatePolicies(context.Background(), nil, regoPolicies, input, resources, results)
+	require.NoError(t, err)
+	assert.Equal(t, 1, result.Policies, "Rego-only checks need no policy file")
+	assert.Len(t, result.Violations, 1)
+}
+
+func TestLoadPolicies_Configured(t *testing.T) {
+	dir := t.TempDir()
+	policyPath := filepath.Join(dir, "policies.yaml")
+	require.NoError(t, os.WriteFile(policyPath, []byte("policies: [{name: cap, max_monthly_cost: 10}]"), 0o600))
+
+	cfg := &config.Config{}
+	_, _, err := loadPolicies(context.Background(), policyCheckParams{}, cfg)
+	require.ErrorContains(t, err, "no policies to check")
+
+	require.NoError(t, cfg.Set("policy.files", policyPath))
+	require.NoError(t, cfg.Set("policy.require_tags", "team, cost-center"))
+	file, _, err := loadPolicies(context.Background(), policyCheckParams{}, cfg)
+	require.NoError(t, err)
+	require.Len(t, file.Policies, 2)
+	assert.Equal(t, "cap", file.Policies[0].Name)
+	assert.Equal(t, "required-tags", file.Policies[1].Name)
+	assert.Equal(t, []string{"team", "cost-center"}, file.Policies[1].RequireTags)
+
+	// --policy replaces the configured policies.
+	file, _, err = loadPolicies(context.Background(), policyCheckParams{policyPath: policyPath}, cfg)
+	require.NoError(t, err)
+	assert.Len(t, file.Policies, 1)
+}
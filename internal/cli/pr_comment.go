@@ -0,0 +1,116 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+
+	"github.com/rshade/finfocus/internal/bitbucket"
+	"github.com/rshade/finfocus/internal/github"
+	"github.com/rshade/finfocus/internal/gitlab"
+)
+
+// Comment output formats. They all render the same Markdown; the format picks
+// the platform --update-pr posts to.
+const (
+	outputFormatGitHubComment    = "github-comment"
+	outputFormatGitLabComment    = "gitlab-comment"
+	outputFormatBitbucketComment = "bitbucket-comment"
+)
+
+// commentPlatform is a code review system the cost comment can be posted to.
+type commentPlatform struct {
+	// name is the platform's display name.
+	name string
+	// format is the --output value that selects the platform.
+	format string
+	// ciEnv is an environment variable set by the platform's CI service, used
+	// to pick the platform when --output is not given.
+	ciEnv string
+	// post creates or updates the sticky comment on pull request number and
+	// returns where it was posted and whether it was created.
+	post func(ctx context.Context, number int, marker, body string) (string, bool, error)
+}
+
+// commentPlatforms lists the supported platforms; GitHub is the default.
+var commentPlatforms = []*commentPlatform{
+	{name: "GitHub", format: outputFormatGitHubComment, ciEnv: "GITHUB_ACTIONS", post: postGitHubComment},
+	{name: "GitLab", format: outputFormatGitLabComment, ciEnv: "GITLAB_CI", post: postGitLabComment},
+	{name: "Bitbucket", format: outputFormatBitbucketComment, ciEnv: "BITBUCKET_BUILD_NUMBER",
+		post: postBitbucketComment},
+}
+
+// commentPlatformForFormat returns the platform selected by an output format,
+// or nil when the format is not a comment format.
+func commentPlatformForFormat(format string) *commentPlatform {
+	for _, p := range commentPlatforms {
+		if p.format == format {
+			return p
+		}
+	}
+	return nil
+}
+
+// detectCommentPlatform picks the platform whose CI service is running,
+// falling back to GitHub.
+func detectCommentPlatform() *commentPlatform {
+	for _, p := range commentPlatforms {
+		if os.Getenv(p.ciEnv) != "" {
+			return p
+		}
+	}
+	return commentPlatforms[0]
+}
+
+// postGitHubComment creates or updates the sticky comment on pull request
+// number in the repository named by GITHUB_REPOSITORY.
+func postGitHubComment(ctx context.Context, number int, marker, body string) (string, bool, error) {
+	repo, err := github.ParseRepository(os.Getenv(github.EnvRepository))
+	if err != nil {
+		return "", false, fmt.Errorf("--update-pr needs %s=owner/repo: %w", github.EnvRepository, err)
+	}
+
+	client := github.NewClient(os.Getenv(github.EnvAPIURL), github.ResolveToken())
+	comment, created, err := client.UpsertIssueComment(ctx, repo, number, marker, body)
+	if err != nil {
+		return "", false, fmt.Errorf("updating comment on %s#%d: %w", repo, number, err)
+	}
+	return commentLocation(fmt.Sprintf("%s#%d", repo, number), comment.HTMLURL), created, nil
+}
+
+// postGitLabComment creates or updates the sticky note on merge request iid
+// in the project named by CI_PROJECT_ID.
+func postGitLabComment(ctx context.Context, iid int, marker, body string) (string, bool, error) {
+	project := os.Getenv(gitlab.EnvProject)
+	client := gitlab.NewClient(os.Getenv(gitlab.EnvAPIURL), gitlab.ResolveToken())
+	_, created, err := client.UpsertMergeRequestNote(ctx, project, iid, marker, body)
+	if err != nil {
+		return "", false, fmt.Errorf("updating note on %s!%d: %w", project, iid, err)
+	}
+	return project + "!" + strconv.Itoa(iid), created, nil
+}
+
+// postBitbucketComment creates or updates the sticky comment on pull request
+// id in the repository named by BITBUCKET_WORKSPACE and BITBUCKET_REPO_SLUG.
+func postBitbucketComment(ctx context.Context, id int, marker, body string) (string, bool, error) {
+	repo, err := bitbucket.RepositoryFromEnv()
+	if err != nil {
+		return "", false, fmt.Errorf("--update-pr needs a Bitbucket repository: %w", err)
+	}
+
+	client := bitbucket.NewClient(os.Getenv(bitbucket.EnvAPIURL), bitbucket.ResolveToken())
+	comment, created, err := client.UpsertPullRequestComment(ctx, repo, id, marker, body)
+	if err != nil {
+		return "", false, fmt.Errorf("updating comment on %s#%d: %w", repo, id, err)
+	}
+	return commentLocation(fmt.Sprintf("%s#%d", repo, id), comment.Links.HTML.Href), created, nil
+}
+
+// commentLocation describes a posted comment, including its URL when known.
+func commentLocation(target, url string) string {
+	if url == "" {
+		return target
+	}
+	return target + ": " + url
+}
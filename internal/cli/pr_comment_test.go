@@ -0,0 +1,107 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/bitbucket"
+	"github.com/rshade/finfocus/internal/github"
+	"github.com/rshade/finfocus/internal/gitlab"
+)
+
+func TestCommentPlatformSelection(t *testing.T) {
+	assert.Equal(t, "GitLab", commentPlatformForFormat(outputFormatGitLabComment).name)
+	assert.Nil(t, commentPlatformForFormat(outputFormatJSON))
+
+	for _, p := range commentPlatforms {
+		t.Setenv(p.ciEnv, "")
+	}
+	assert.Equal(t, "GitHub", detectCommentPlatform().name, "GitHub is the default")
+
+	t.Setenv("BITBUCKET_BUILD_NUMBER", "12")
+	assert.Equal(t, "Bitbucket", detectCommentPlatform().name)
+}
+
+// commentServer records the body of the comment created on each platform. It
+// answers the current user lookup of every platform as the same bot.
+func commentServer(t *testing.T, empty, created string) (*httptest.Server, *string) {
+	t.Helper()
+	var posted string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user" {
+			_, _ = w.Write([]byte(`{"login":"bot","username":"bot","uuid":"{bot}"}`))
+			return
+		}
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(empty))
+			return
+		}
+		var payload struct {
+			Body    string `json:"body"`
+			Content struct {
+				Raw string `json:"raw"`
+			} `json:"content"`
+		}
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+		posted = payload.Body + payload.Content.Raw
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(created))
+	}))
+	t.Cleanup(server.Close)
+	return server, &posted
+}
+
+func TestPostComment_Platforms(t *testing.T) {
+	marker := costCommentMarker("prod")
+	body := marker + "\n\nbody"
+
+	t.Run("github", func(t *testing.T) {
+		server, posted := commentServer(t, "[]", `{"id":1,"html_url":"https://github.com/acme/infra/pull/42#c1"}`)
+		t.Setenv(github.EnvAPIURL, server.URL)
+		t.Setenv(github.EnvToken, "secret")
+		t.Setenv(github.EnvRepository, "acme/infra")
+
+		location, created, err := postGitHubComment(context.Background(), 42, marker, body)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "acme/infra#42: https://github.com/acme/infra/pull/42#c1", location)
+		assert.Equal(t, body, *posted)
+
+		t.Setenv(github.EnvRepository, "")
+		_, _, err = postGitHubComment(context.Background(), 42, marker, body)
+		require.ErrorIs(t, err, github.ErrInvalidRepository)
+	})
+
+	t.Run("gitlab", func(t *testing.T) {
+		server, posted := commentServer(t, "[]", `{"id":3}`)
+		t.Setenv(gitlab.EnvAPIURL, server.URL)
+		t.Setenv(gitlab.EnvToken, "secret")
+		t.Setenv(gitlab.EnvProject, "99")
+
+		location, created, err := postGitLabComment(context.Background(), 17, marker, body)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "99!17", location)
+		assert.Equal(t, body, *posted)
+	})
+
+	t.Run("bitbucket", func(t *testing.T) {
+		server, posted := commentServer(t, `{"values":[]}`, `{"id":5,"links":{"html":{"href":"https://bb/c5"}}}`)
+		t.Setenv(bitbucket.EnvAPIURL, server.URL)
+		t.Setenv(bitbucket.EnvToken, "secret")
+		t.Setenv(bitbucket.EnvWorkspace, "acme")
+		t.Setenv(bitbucket.EnvRepoSlug, "infra")
+
+		location, created, err := postBitbucketComment(context.Background(), 8, marker, body)
+		require.NoError(t, err)
+		assert.True(t, created)
+		assert.Equal(t, "acme/infra#8: https://bb/c5", location)
+		assert.Equal(t, body, *posted)
+	})
+}
@@ -0,0 +1,25 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// setupProfile selects the config profile of --profile for the run. The flag
+// is passed on as FINFOCUS_PROFILE, so every configuration loaded during the
+// run layers the profile over the config file. An unknown profile, from the
+// flag or the environment, fails the command.
+func setupProfile(cmd *cobra.Command) error {
+	if flag := cmd.Flag("profile"); flag != nil && flag.Changed {
+		if err := os.Setenv(config.EnvProfile, flag.Value.String()); err != nil {
+			return fmt.Errorf("selecting config profile: %w", err)
+		}
+		// Reload the global configuration with the profile.
+		config.SetGlobalConfig(nil)
+	}
+	return config.CheckProfile(config.ActiveProfile())
+}
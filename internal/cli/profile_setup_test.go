@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestProfileFlag(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_HOME", home)
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_SKIP_MIGRATION_CHECK", "1")
+	t.Setenv(config.EnvProfile, "")
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(`output:
+  precision: 2
+profiles:
+  work:
+    output:
+      precision: 4
+`), 0600))
+
+	run := func(args ...string) (string, error) {
+		root := NewRootCmd("test")
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&out)
+		root.SetArgs(args)
+		err := root.Execute()
+		return out.String(), err
+	}
+
+	out, err := run("config", "get", "output.precision")
+	require.NoError(t, err)
+	assert.Contains(t, out, "2")
+
+	out, err = run("--profile", "work", "config", "get", "output.precision")
+	require.NoError(t, err)
+	assert.Contains(t, out, "4")
+	assert.Equal(t, "work", config.GetGlobalConfig().Profile())
+
+	_, err = run("--profile", "work", "config", "set", "output.precision", "3")
+	require.ErrorIs(t, err, config.ErrProfileActive)
+
+	_, err = run("--profile", "home", "config", "get", "output.precision")
+	require.ErrorIs(t, err, config.ErrUnknownProfile)
+
+	t.Setenv(config.EnvProfile, "work")
+	profiles, _ := completeProfiles(nil, nil, "")
+	assert.Equal(t, []string{"work"}, profiles)
+}
@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	"github.com/spf13/cobra"
+)
+
+// errQueryNonJSON is returned when --query is given to a command whose output
+// is not JSON.
+var errQueryNonJSON = errors.New("--query requires JSON output (use --output json or ndjson)")
+
+// queryWriter applies a JMESPath expression to each JSON document written to
+// it and writes the results to out. Documents may span several writes, and
+// several documents may follow each other, as in NDJSON output. Results keep
+// the layout of their document: indented documents give indented results and
+// single-line documents single-line results.
+type queryWriter struct {
+	out     io.Writer
+	query   *jmespath.JMESPath
+	pending []byte
+}
+
+// Write buffers p and writes the query result of every complete document.
+// It fails with errQueryNonJSON when the output is not JSON.
+func (w *queryWriter) Write(p []byte) (int, error) {
+	w.pending = append(w.pending, p...)
+	for {
+		doc := bytes.TrimLeft(w.pending, " \t\r\n")
+		if len(doc) == 0 {
+			w.pending = w.pending[:0]
+			return len(p), nil
+		}
+
+		var data interface{}
+		dec := json.NewDecoder(bytes.NewReader(doc))
+		err := dec.Decode(&data)
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return len(p), nil // The document continues in a later write.
+		}
+		if err != nil {
+			return 0, errQueryNonJSON
+		}
+		indent := bytes.ContainsRune(doc[:dec.InputOffset()], '\n')
+		w.pending = append(w.pending[:0], doc[dec.InputOffset():]...)
+
+		if err = w.writeResult(data, indent); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// writeResult evaluates the query against one document and writes the result.
+func (w *queryWriter) writeResult(data interface{}, indent bool) error {
+	result, err := w.query.Search(data)
+	if err != nil {
+		return fmt.Errorf("evaluating --query: %w", err)
+	}
+	encoder := json.NewEncoder(w.out)
+	if indent {
+		encoder.SetIndent("", "  ")
+	}
+	if err = encoder.Encode(result); err != nil {
+		return fmt.Errorf("writing --query result: %w", err)
+	}
+	return nil
+}
+
+// compileQuery compiles a --query expression, reporting the column of
+// syntax errors.
+func compileQuery(expression string) (*jmespath.JMESPath, error) {
+	query, err := jmespath.Compile(expression)
+	var syntaxErr jmespath.SyntaxError
+	if errors.As(err, &syntaxErr) {
+		return nil, fmt.Errorf("invalid --query expression at column %d: %s",
+			syntaxErr.Offset+1, strings.TrimPrefix(syntaxErr.Error(), "SyntaxError: "))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid --query expression: %w", err)
+	}
+	return query, nil
+}
+
+// setupQueryOutput routes the output of the command tree through a
+// queryWriter when --query is set. It is a no-op when the output is already
+// routed, as command groups run the root setup on their subcommands' behalf.
+func setupQueryOutput(cmd *cobra.Command) error {
+	expression, _ := cmd.Flags().GetString("query")
+	if expression == "" {
+		return nil
+	}
+	root := cmd.Root()
+	if _, routed := root.OutOrStdout().(*queryWriter); routed {
+		return nil
+	}
+	query, err := compileQuery(expression)
+	if err != nil {
+		return err
+	}
+	root.SetOut(&queryWriter{out: root.OutOrStdout(), query: query})
+	return nil
+}
+
+// finishQueryOutput restores the output of the command tree and fails when
+// output was left that is not a complete JSON document.
+func finishQueryOutput(cmd *cobra.Command) error {
+	root := cmd.Root()
+	w, routed := root.OutOrStdout().(*queryWriter)
+	if !routed {
+		return nil
+	}
+	root.SetOut(w.out)
+	if len(bytes.TrimSpace(w.pending)) > 0 {
+		return errQueryNonJSON
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryWriter(t *testing.T) {
+	query, err := compileQuery("items[?cost > `10`].name")
+	require.NoError(t, err)
+
+	t.Run("indented document split across writes", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &queryWriter{out: &out, query: query}
+		doc := "{\n  \"items\": [{\"name\": \"web\", \"cost\": 20}, {\"name\": \"db\", \"cost\": 5}]\n}\n"
+		for _, part := range []string{doc[:10], doc[10:]} {
+			n, writeErr := w.Write([]byte(part))
+			require.NoError(t, writeErr)
+			assert.Len(t, part, n)
+		}
+		assert.Equal(t, "[\n  \"web\"\n]\n", out.String())
+		assert.Empty(t, w.pending)
+	})
+
+	t.Run("one result per NDJSON line", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &queryWriter{out: &out, query: query}
+		_, writeErr := fmt.Fprint(w, "{\"items\":[{\"name\":\"a\",\"cost\":11}]}\n{\"items\":[]}\n")
+		require.NoError(t, writeErr)
+		assert.Equal(t, "[\"a\"]\n[]\n", out.String())
+	})
+
+	t.Run("table output is rejected", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &queryWriter{out: &out, query: query}
+		_, writeErr := fmt.Fprintln(w, "NAME  COST")
+		require.ErrorIs(t, writeErr, errQueryNonJSON)
+		assert.Empty(t, out.String())
+	})
+}
+
+func TestCompileQuery_SyntaxError(t *testing.T) {
+	_, err := compileQuery("items[?cost >")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --query expression at column")
+}
+
+func TestRootCmd_QueryFlag(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_HOME", filepath.Join(home, ".finfocus"))
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_SKIP_MIGRATION_CHECK", "1")
+
+	run := func(args ...string) (string, error) {
+		root := NewRootCmd("test")
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(args)
+		execErr := root.Execute()
+		return out.String(), execErr
+	}
+
+	out, err := run("cost", "simulate", "schedule", "--cron", "0 19 * * 1-5", "--output", "json",
+		"--query", "{stop: stop, groups: length(groups)}")
+	require.NoError(t, err)
+	var result map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &result))
+	assert.Equal(t, map[string]interface{}{"stop": "0 19 * * 1-5", "groups": float64(0)}, result)
+
+	_, err = run("cost", "simulate", "schedule", "--cron", "0 19 * * 1-5", "--query", "stop")
+	require.ErrorIs(t, err, errQueryNonJSON)
+
+	_, err = run("cost", "simulate", "schedule", "--cron", "0 19 * * 1-5", "--output", "json", "--query", "[")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid --query expression")
+}
@@ -0,0 +1,506 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// outputFormatPDF renders chargeback invoices as PDF.
+const outputFormatPDF = "pdf"
+
+// invoiceDirMode is the permission of the --output-dir directory.
+const invoiceDirMode = 0o750
+
+// reportChargebackParams holds the parameters for the report chargeback command execution.
+type reportChargebackParams struct {
+	period     string
+	planPath   string
+	adapter    string
+	teamTag    string
+	output     string
+	outputFile string
+	outputDir  string
+}
+
+// NewReportChargebackCmd creates the "chargeback" subcommand, which allocates
+// a month of actual spend to teams (see 'cost allocate') and produces one
+// invoice per team with a line per service.
+func NewReportChargebackCmd() *cobra.Command {
+	var params reportChargebackParams
+
+	cmd := &cobra.Command{
+		Use:   "chargeback",
+		Short: "Produce per-team chargeback invoices for a month of actual spend",
+		Long: `Produce per-team chargeback invoices for a month of actual spend.
+
+Actual costs for the period are attributed to teams with the cost.allocation
+rules and shared-cost pools, as in 'finfocus cost allocate'. Each team gets an
+invoice with a line per service, split into the team's own resources and its
+share of shared pools. The markup and discount configured under
+cost.chargeback are applied to each invoice subtotal.
+
+By default all invoices are written as one document, preceded by a summary.
+With --output-dir each invoice is written to its own file named after the
+invoice number, e.g. 2025-03-payments.pdf, and the summary is printed.`,
+		Example: `  # Invoices for March 2025 as a table
+  finfocus report chargeback --period 2025-03 --pulumi-json plan.json
+
+  # One PDF per team
+  finfocus report chargeback --period 2025-03 --pulumi-json plan.json --output pdf --output-dir invoices/
+
+  # CSV line items for the billing system
+  finfocus report chargeback --period 2025-03 --pulumi-json plan.json --output csv --output-file march.csv`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeReportChargeback(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.period, "period", "", "Month to bill (YYYY-MM, defaults to the current month)")
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "",
+		"Path to Pulumi preview JSON output (auto-detected from the Pulumi project when omitted)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringVar(&params.teamTag, "team-tag", "",
+		"Resource tag that names the owning team (default from cost.allocation.tag_key, else \"team\")")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable,
+		"Output format: table, json, csv, html, or pdf")
+	cmd.Flags().StringVar(&params.outputFile, "output-file", "", "Write all invoices to this file (default: stdout)")
+	cmd.Flags().StringVar(&params.outputDir, "output-dir", "", "Write each invoice to its own file in this directory")
+	cmd.MarkFlagsMutuallyExclusive("output-file", "output-dir")
+
+	return cmd
+}
+
+// executeReportChargeback allocates the period's actual costs, builds the
+// invoices, and writes them.
+func executeReportChargeback(cmd *cobra.Command, params reportChargebackParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	switch params.output {
+	case outputFormatTable, outputFormatJSON, outputFormatCSV, outputFormatHTML, outputFormatPDF:
+	default:
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+	if params.outputDir != "" && params.output == outputFormatTable {
+		return fmt.Errorf("--output-dir needs --output %s, %s, %s, or %s",
+			outputFormatJSON, outputFormatCSV, outputFormatHTML, outputFormatPDF)
+	}
+
+	from, to, _, err := resolveVariancePeriod(params.period, time.Now())
+	if err != nil {
+		return err
+	}
+	period := from.Format(variancePeriodLayout)
+
+	allocCfg := allocationConfig(params.teamTag)
+	if err = allocCfg.Validate(); err != nil {
+		return err
+	}
+	var chargebackCfg *config.ChargebackConfig
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		chargebackCfg = cfg.Cost.Chargeback
+	}
+	if err = chargebackCfg.Validate(); err != nil {
+		return err
+	}
+
+	audit := newAuditContext(ctx, "report chargeback", map[string]string{
+		"period": period, "pulumi_json": params.planPath, "output": params.output,
+	})
+
+	resources, err := loadAllocationResources(ctx, cmd, params.planPath, modePulumiExport, audit)
+	if err != nil {
+		return err
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, nil).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	items, err := fetchAllocationItems(ctx, eng, allocationRequest{
+		resources: resources, actual: true, from: from, to: to, adapter: params.adapter,
+	})
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	report := engine.BuildChargeback(engine.ChargebackInput{
+		Period:     period,
+		From:       from,
+		To:         to,
+		Allocation: engine.AllocateCosts(items, allocCfg),
+		Config:     chargebackCfg,
+	})
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "report_chargeback").
+		Str("period", period).Int("invoice_count", len(report.Invoices)).Float64("total", report.Total).
+		Dur("duration_ms", time.Since(audit.start)).Msg("chargeback report complete")
+	audit.logSuccess(ctx, len(report.Invoices), report.Total)
+
+	if params.outputDir != "" {
+		return writeInvoiceFiles(cmd, params.outputDir, params.output, report)
+	}
+
+	writer, closeOutput, err := getOutputWriter(cmd, params.outputFile)
+	if err != nil {
+		return err
+	}
+	if closeOutput != nil {
+		defer closeOutput()
+	}
+	return renderChargebackReport(writer, params.output, report)
+}
+
+// writeInvoiceFiles writes each invoice to its own file in dir and prints the
+// summary with the file names.
+func writeInvoiceFiles(cmd *cobra.Command, dir, format string, report *engine.ChargebackReport) error {
+	if err := os.MkdirAll(dir, invoiceDirMode); err != nil {
+		return fmt.Errorf("creating output directory: %w", err)
+	}
+
+	for _, invoice := range report.Invoices {
+		path := filepath.Join(dir, invoiceFileName(invoice.Number, format))
+		if err := writeInvoiceFile(path, format, singleInvoiceReport(report, invoice)); err != nil {
+			return err
+		}
+		cmd.PrintErrf("Wrote invoice %s to %s\n", invoice.Number, path)
+	}
+	return renderChargebackSummary(cmd.OutOrStdout(), report)
+}
+
+// writeInvoiceFile renders report to a new file at path.
+func writeInvoiceFile(path, format string, report *engine.ChargebackReport) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating invoice file: %w", err)
+	}
+	err = renderChargebackReport(f, format, report)
+	if closeErr := f.Close(); err == nil && closeErr != nil {
+		err = fmt.Errorf("closing invoice file: %w", closeErr)
+	}
+	return err
+}
+
+// invoiceFileName returns a file name for an invoice number that is safe on
+// every platform.
+func invoiceFileName(number, format string) string {
+	safe := strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '_', r == '.':
+			return r
+		default:
+			return '_'
+		}
+	}, number)
+	return safe + "." + format
+}
+
+// singleInvoiceReport returns a copy of report holding only invoice, with
+// the summary totals of that invoice.
+func singleInvoiceReport(report *engine.ChargebackReport, invoice engine.ChargebackInvoice) *engine.ChargebackReport {
+	single := *report
+	single.Invoices = []engine.ChargebackInvoice{invoice}
+	single.Subtotal, single.Markup = invoice.Subtotal, invoice.Markup
+	single.Discount, single.Total = invoice.Discount, invoice.Total
+	return &single
+}
+
+// renderChargebackReport renders the chargeback report in the requested format.
+func renderChargebackReport(w io.Writer, format string, report *engine.ChargebackReport) error {
+	switch format {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encoding chargeback JSON: %w", err)
+		}
+		return nil
+	case outputFormatCSV:
+		return renderChargebackCSV(w, report)
+	case outputFormatHTML:
+		if err := chargebackHTML.Execute(w, report); err != nil {
+			return fmt.Errorf("rendering chargeback HTML: %w", err)
+		}
+		return nil
+	case outputFormatPDF:
+		return renderChargebackPDF(w, report)
+	default:
+		return renderChargebackTable(w, report)
+	}
+}
+
+// renderChargebackSummary renders one row per invoice and the grand total.
+func renderChargebackSummary(w io.Writer, report *engine.ChargebackReport) error {
+	fmt.Fprintf(w, "Chargeback for %s (%s to %s)\n\n", report.Period,
+		report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))
+
+	if len(report.Invoices) == 0 {
+		fmt.Fprintln(w, "No costs to charge back for this period.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "INVOICE\tTEAM\tSUBTOTAL\tMARKUP\tDISCOUNT\tTOTAL")
+	fmt.Fprintln(tw, "-------\t----\t--------\t------\t--------\t-----")
+	for _, inv := range report.Invoices {
+		fmt.Fprintf(tw, "%s\t%s\t%.2f\t%.2f\t%.2f\t%.2f\n",
+			inv.Number, inv.Team, inv.Subtotal, inv.Markup, inv.Discount, inv.Total)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTotal: %.2f %s across %d invoices\n", report.Total, report.Currency, len(report.Invoices))
+	if report.MixedCurrencies {
+		fmt.Fprintln(w, "Warning: costs use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// renderChargebackTable renders the summary followed by each invoice's lines.
+func renderChargebackTable(w io.Writer, report *engine.ChargebackReport) error {
+	if err := renderChargebackSummary(w, report); err != nil {
+		return err
+	}
+
+	for _, inv := range report.Invoices {
+		fmt.Fprintf(w, "\nINVOICE %s (%s)\n", inv.Number, inv.Team)
+		tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+		fmt.Fprintln(tw, "SERVICE\tRESOURCES\tDIRECT\tSHARED\tAMOUNT")
+		for _, line := range inv.Lines {
+			fmt.Fprintf(tw, "%s\t%d\t%.2f\t%.2f\t%.2f\n",
+				line.Service, line.Resources, line.Direct, line.Shared, line.Amount)
+		}
+		if err := tw.Flush(); err != nil {
+			return err
+		}
+		for _, adj := range invoiceAdjustments(inv) {
+			fmt.Fprintf(w, "%s: %.2f\n", adj.Label, adj.Amount)
+		}
+	}
+	return nil
+}
+
+// invoiceAdjustment is a subtotal, markup, discount, or total row of an invoice.
+// Fields are exported for the HTML template.
+type invoiceAdjustment struct {
+	Kind   string
+	Label  string
+	Amount float64
+}
+
+// invoiceAdjustments returns the rows below an invoice's lines. Markup and
+// discount rows are omitted when their rate is zero.
+func invoiceAdjustments(inv engine.ChargebackInvoice) []invoiceAdjustment {
+	rows := []invoiceAdjustment{{Kind: "subtotal", Label: "Subtotal", Amount: inv.Subtotal}}
+	if inv.MarkupPercent != 0 {
+		rows = append(rows, invoiceAdjustment{
+			Kind: "markup", Label: fmt.Sprintf("Markup (%g%%)", inv.MarkupPercent), Amount: inv.Markup,
+		})
+	}
+	if inv.DiscountPercent != 0 {
+		rows = append(rows, invoiceAdjustment{
+			Kind: "discount", Label: fmt.Sprintf("Discount (%g%%)", inv.DiscountPercent), Amount: -inv.Discount,
+		})
+	}
+	return append(rows, invoiceAdjustment{Kind: "total", Label: "Total " + inv.Currency, Amount: inv.Total})
+}
+
+// renderChargebackCSV renders one row per invoice line followed by the
+// invoice's adjustment rows, for import into a billing system.
+func renderChargebackCSV(w io.Writer, report *engine.ChargebackReport) error {
+	cw := csv.NewWriter(w)
+	header := []string{"period", "invoice", "team", "row_type", "service", "resources", "direct", "shared", "amount",
+		"currency"}
+	if err := cw.Write(header); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	formatAmount := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, inv := range report.Invoices {
+		for _, line := range inv.Lines {
+			row := []string{
+				report.Period, inv.Number, inv.Team, "line", line.Service, strconv.Itoa(line.Resources),
+				formatAmount(line.Direct), formatAmount(line.Shared), formatAmount(line.Amount), inv.Currency,
+			}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("writing CSV row: %w", err)
+			}
+		}
+		for _, adj := range invoiceAdjustments(inv) {
+			row := []string{
+				report.Period, inv.Number, inv.Team, adj.Kind, "", "", "", "", formatAmount(adj.Amount), inv.Currency,
+			}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("writing CSV row: %w", err)
+			}
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flushing CSV: %w", err)
+	}
+	return nil
+}
+
+// PDF layout, in millimetres on A4 portrait.
+const (
+	pdfMargin      = 15.0
+	pdfLineHeight  = 7.0
+	pdfTitleSize   = 16.0
+	pdfHeadingSize = 12.0
+	pdfBodySize    = 10.0
+	pdfWideColumn  = 60.0
+	pdfColumn      = 28.0
+)
+
+// renderChargebackPDF renders a summary page followed by one page per invoice.
+func renderChargebackPDF(w io.Writer, report *engine.ChargebackReport) error {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetMargins(pdfMargin, pdfMargin, pdfMargin)
+	pdf.SetTitle("Chargeback "+report.Period, true)
+	pdf.SetCreator("finfocus", true)
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	money := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+
+	heading := func(size float64, text string) {
+		pdf.SetFont("Helvetica", "B", size)
+		pdf.CellFormat(0, pdfLineHeight+2, tr(text), "", 1, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", pdfBodySize)
+	}
+	row := func(bold bool, cells ...string) {
+		style := ""
+		if bold {
+			style = "B"
+		}
+		pdf.SetFont("Helvetica", style, pdfBodySize)
+		for i, cell := range cells {
+			width, align := pdfColumn, "R"
+			if i == 0 {
+				width, align = pdfWideColumn, "L"
+			}
+			pdf.CellFormat(width, pdfLineHeight, tr(cell), "1", 0, align, false, 0, "")
+		}
+		pdf.Ln(-1)
+	}
+
+	pdf.AddPage()
+	heading(pdfTitleSize, "Chargeback summary "+report.Period)
+	if report.Issuer != "" {
+		pdf.CellFormat(0, pdfLineHeight, tr("Issued by "+report.Issuer), "", 1, "L", false, 0, "")
+	}
+	pdf.CellFormat(0, pdfLineHeight, fmt.Sprintf("Period %s to %s, amounts in %s",
+		report.From.Format("2006-01-02"), report.To.Format("2006-01-02"), report.Currency), "", 1, "L", false, 0, "")
+	pdf.Ln(pdfLineHeight / 2)
+	row(true, "Team", "Subtotal", "Markup", "Discount", "Total")
+	for _, inv := range report.Invoices {
+		row(false, inv.Team, money(inv.Subtotal), money(inv.Markup), money(inv.Discount), money(inv.Total))
+	}
+	row(true, "Total", money(report.Subtotal), money(report.Markup), money(report.Discount), money(report.Total))
+
+	for _, inv := range report.Invoices {
+		pdf.AddPage()
+		heading(pdfTitleSize, "Invoice "+inv.Number)
+		pdf.CellFormat(0, pdfLineHeight, tr("Bill to: "+inv.Team), "", 1, "L", false, 0, "")
+		pdf.Ln(pdfLineHeight / 2)
+		heading(pdfHeadingSize, "Services")
+		row(true, "Service", "Resources", "Direct", "Shared", "Amount")
+		for _, line := range inv.Lines {
+			row(false, line.Service, strconv.Itoa(line.Resources),
+				money(line.Direct), money(line.Shared), money(line.Amount))
+		}
+		pdf.Ln(pdfLineHeight / 2)
+		for _, adj := range invoiceAdjustments(inv) {
+			row(adj.Kind == "total", adj.Label, money(adj.Amount))
+		}
+	}
+
+	if err := pdf.Output(w); err != nil {
+		return fmt.Errorf("rendering chargeback PDF: %w", err)
+	}
+	return nil
+}
+
+// chargebackHTML is a self-contained, printable HTML page holding the summary
+// and each invoice on its own page.
+//
+//nolint:gochecknoglobals // Parsed once; template text is static.
+var chargebackHTML = template.Must(template.New("chargeback").Funcs(template.FuncMap{
+	"money":       func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) },
+	"date":        func(t time.Time) string { return t.Format("2006-01-02") },
+	"adjustments": invoiceAdjustments,
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>FinFocus chargeback {{.Period}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 1.5em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+td.num { text-align: right; }
+tr.total td { font-weight: bold; }
+section.invoice { page-break-before: always; }
+</style>
+</head>
+<body>
+<h1>Chargeback summary {{.Period}}</h1>
+{{- with .Issuer}}
+<p>Issued by {{.}}</p>
+{{- end}}
+<p>Period {{date .From}} to {{date .To}}, amounts in {{.Currency}}.</p>
+{{- if .MixedCurrencies}}
+<p><strong>Costs use more than one currency; totals are not converted.</strong></p>
+{{- end}}
+<table>
+<tr><th>Invoice</th><th>Team</th><th>Subtotal</th><th>Markup</th><th>Discount</th><th>Total</th></tr>
+{{- range .Invoices}}
+<tr><td>{{.Number}}</td><td>{{.Team}}</td><td class="num">{{money .Subtotal}}</td>
+<td class="num">{{money .Markup}}</td><td class="num">{{money .Discount}}</td><td class="num">{{money .Total}}</td></tr>
+{{- end}}
+<tr class="total"><td colspan="2">Total</td><td class="num">{{money .Subtotal}}</td>
+<td class="num">{{money .Markup}}</td><td class="num">{{money .Discount}}</td><td class="num">{{money .Total}}</td></tr>
+</table>
+{{- range .Invoices}}
+<section class="invoice">
+<h2>Invoice {{.Number}}</h2>
+<p>Bill to: {{.Team}}</p>
+<table>
+<tr><th>Service</th><th>Resources</th><th>Direct</th><th>Shared</th><th>Amount ({{.Currency}})</th></tr>
+{{- range .Lines}}
+<tr><td>{{.Service}}</td><td class="num">{{.Resources}}</td><td class="num">{{money .Direct}}</td>
+<td class="num">{{money .Shared}}</td><td class="num">{{money .Amount}}</td></tr>
+{{- end}}
+{{- range adjustments .}}
+<tr{{if eq .Kind "total"}} class="total"{{end}}><td colspan="4">{{.Label}}</td>
+<td class="num">{{money .Amount}}</td></tr>
+{{- end}}
+</table>
+</section>
+{{- end}}
+</body>
+</html>
+`))
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func chargebackTestReport() *engine.ChargebackReport {
+	items := []engine.AllocationItem{
+		{ResourceID: "api", ResourceType: "aws:ec2/instance:Instance", Cost: 300, Currency: "USD",
+			Tags: map[string]string{"team": "payments"}},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Cost: 100, Currency: "USD",
+			Tags: map[string]string{"team": "payments"}},
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Cost: 100, Currency: "USD",
+			Tags: map[string]string{"team": "frontend"}},
+	}
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	return engine.BuildChargeback(engine.ChargebackInput{
+		Period:     "2025-03",
+		From:       from,
+		To:         from.AddDate(0, 1, 0),
+		Allocation: engine.AllocateCosts(items, nil),
+		Config: &config.ChargebackConfig{
+			Issuer:          "Platform & Ops",
+			ChargebackRates: config.ChargebackRates{MarkupPercent: 10},
+		},
+	})
+}
+
+func TestRenderChargebackReport(t *testing.T) {
+	report := chargebackTestReport()
+
+	var table bytes.Buffer
+	require.NoError(t, renderChargebackReport(&table, outputFormatTable, report))
+	assert.Contains(t, table.String(), "Chargeback for 2025-03 (2025-03-01 to 2025-04-01)")
+	assert.Contains(t, table.String(), "INVOICE 2025-03-payments (payments)")
+	assert.Contains(t, table.String(), "Markup (10%): 40.00")
+	assert.Contains(t, table.String(), "Total: 550.00 USD across 2 invoices")
+
+	var csvOut bytes.Buffer
+	require.NoError(t, renderChargebackReport(&csvOut, outputFormatCSV, report))
+	assert.Equal(t, "period,invoice,team,row_type,service,resources,direct,shared,amount,currency\n"+
+		"2025-03,2025-03-payments,payments,line,aws:ec2,1,300.00,0.00,300.00,USD\n"+
+		"2025-03,2025-03-payments,payments,line,aws:rds,1,100.00,0.00,100.00,USD\n"+
+		"2025-03,2025-03-payments,payments,subtotal,,,,,400.00,USD\n"+
+		"2025-03,2025-03-payments,payments,markup,,,,,40.00,USD\n"+
+		"2025-03,2025-03-payments,payments,total,,,,,440.00,USD\n"+
+		"2025-03,2025-03-frontend,frontend,line,aws:ec2,1,100.00,0.00,100.00,USD\n"+
+		"2025-03,2025-03-frontend,frontend,subtotal,,,,,100.00,USD\n"+
+		"2025-03,2025-03-frontend,frontend,markup,,,,,10.00,USD\n"+
+		"2025-03,2025-03-frontend,frontend,total,,,,,110.00,USD\n", csvOut.String())
+
+	var html bytes.Buffer
+	require.NoError(t, renderChargebackReport(&html, outputFormatHTML, report))
+	assert.Contains(t, html.String(), "<h2>Invoice 2025-03-payments</h2>")
+	assert.Contains(t, html.String(), "Issued by Platform &amp; Ops")
+	assert.Contains(t, html.String(), "Markup (10%)")
+
+	var pdf bytes.Buffer
+	require.NoError(t, renderChargebackReport(&pdf, outputFormatPDF, report))
+	assert.True(t, bytes.HasPrefix(pdf.Bytes(), []byte("%PDF-")))
+}
+
+func TestWriteInvoiceFiles(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "invoices")
+	cmd := &cobra.Command{}
+	var stdout, stderr bytes.Buffer
+	cmd.SetOut(&stdout)
+	cmd.SetErr(&stderr)
+
+	require.NoError(t, writeInvoiceFiles(cmd, dir, outputFormatCSV, chargebackTestReport()))
+
+	data, err := os.ReadFile(filepath.Join(dir, "2025-03-frontend.csv"))
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "payments", "each file holds one invoice")
+	assert.FileExists(t, filepath.Join(dir, "2025-03-payments.csv"))
+	assert.Contains(t, stdout.String(), "Total: 550.00 USD across 2 invoices")
+	assert.Contains(t, stderr.String(), "Wrote invoice 2025-03-payments")
+}
+
+func TestInvoiceFileName(t *testing.T) {
+	assert.Equal(t, "2025-03-payments.pdf", invoiceFileName("2025-03-payments", outputFormatPDF))
+	assert.Equal(t, "2025-03-_unallocated_.csv", invoiceFileName("2025-03-(unallocated)", outputFormatCSV))
+	assert.Equal(t, "2025-03-a_b.html", invoiceFileName("2025-03-a/b", outputFormatHTML))
+}
+
+func TestExecuteReportChargeback_ValidatesFlags(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+
+	err := executeReportChargeback(cmd, reportChargebackParams{output: "xml"})
+	require.ErrorContains(t, err, "unsupported output format")
+
+	err = executeReportChargeback(cmd, reportChargebackParams{output: outputFormatTable, outputDir: "out"})
+	require.ErrorContains(t, err, "--output-dir needs")
+
+	err = executeReportChargeback(cmd, reportChargebackParams{output: outputFormatTable, period: "March"})
+	require.Error(t, err)
+}
@@ -0,0 +1,328 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"html/template"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Export formats supported by 'report org' in addition to table and json.
+const (
+	outputFormatCSV  = "csv"
+	outputFormatHTML = "html"
+)
+
+// reportOrgParams holds the parameters for the report org command execution.
+type reportOrgParams struct {
+	from       string
+	to         string
+	teamTag    string
+	envTag     string
+	output     string
+	outputFile string
+}
+
+// newReportCmd creates the report command group.
+func newReportCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "report", Short: "Reports built from recorded cost history"}
+	cmd.AddCommand(NewReportOrgCmd(), NewReportChargebackCmd())
+	return cmd
+}
+
+// NewReportOrgCmd creates the "org" subcommand, which rolls up the projections
+// recorded for every stack (see 'cost projected --record') over a period into
+// an organization report grouped by provider, team, and environment.
+func NewReportOrgCmd() *cobra.Command {
+	var params reportOrgParams
+
+	cmd := &cobra.Command{
+		Use:   "org",
+		Short: "Roll up recorded projections for all stacks by provider, team, and environment",
+		Long: `Roll up the projected costs recorded for every stack over a period.
+
+Projections are recorded with 'finfocus cost projected --stack <name> --record',
+typically at deploy time. The report walks the recorded history of every known
+stack and weights each projection by how long it was in effect during the
+period, so a stack deployed halfway through contributes half of its projected
+monthly cost.
+
+Costs are grouped by provider, by the team tag (--team-tag), and by the
+environment tag (--env-tag). Resources without a team tag are reported as
+"(untagged)"; resources without an environment tag are grouped under their
+stack name.`,
+		Example: `  # Organization rollup for January 2026
+  finfocus report org --from 2026-01-01 --to 2026-02-01
+
+  # Group teams by the "owner" tag instead of "team"
+  finfocus report org --from 2026-01-01 --to 2026-02-01 --team-tag owner
+
+  # Export as CSV or HTML
+  finfocus report org --from 2026-01-01 --to 2026-02-01 --output csv > org.csv
+  finfocus report org --from 2026-01-01 --to 2026-02-01 --output html --output-file org.html`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeReportOrg(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.from, "from", "", "Start of the period (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&params.to, "to", "", "End of the period, exclusive (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&params.teamTag, "team-tag", engine.DefaultOrgTeamTag, "Resource tag that names the owning team")
+	cmd.Flags().StringVar(&params.envTag, "env-tag", engine.DefaultOrgEnvironmentTag,
+		"Resource tag that names the environment (falls back to the stack name)")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table, json, csv, or html")
+	cmd.Flags().StringVar(&params.outputFile, "output-file", "", "Write output to file (default: stdout)")
+	_ = cmd.MarkFlagRequired("from")
+	_ = cmd.MarkFlagRequired("to")
+
+	return cmd
+}
+
+// executeReportOrg loads the projection history, builds the organization
+// report for the period, and renders it.
+func executeReportOrg(cmd *cobra.Command, params reportOrgParams) error {
+	ctx := cmd.Context()
+	log := logging.FromContext(ctx)
+
+	switch params.output {
+	case outputFormatTable, outputFormatJSON, outputFormatCSV, outputFormatHTML:
+	default:
+		return fmt.Errorf("unsupported output format: %s", params.output)
+	}
+
+	from, err := ParseTime(params.from)
+	if err != nil {
+		return fmt.Errorf("parsing 'from' date: %w", err)
+	}
+	to, err := ParseTime(params.to)
+	if err != nil {
+		return fmt.Errorf("parsing 'to' date: %w", err)
+	}
+	if !to.After(from) {
+		return errors.New("'to' date must be after 'from' date")
+	}
+
+	store := config.NewProjectionHistoryStore("")
+	if loadErr := store.Load(); loadErr != nil {
+		return fmt.Errorf("loading projection history: %w", loadErr)
+	}
+
+	report := buildOrgReport(store, from, to, params.teamTag, params.envTag)
+
+	log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "report_org").
+		Int("stack_count", len(report.Stacks)).Float64("total_monthly", report.TotalMonthly).
+		Msg("organization report complete")
+
+	writer, cleanup, err := getOutputWriter(cmd, params.outputFile)
+	if err != nil {
+		return err
+	}
+	if cleanup != nil {
+		defer cleanup()
+	}
+	return renderOrgReport(writer, params.output, report)
+}
+
+// buildOrgReport collects the snapshots in effect during the period for every
+// stack in the store and rolls them up.
+func buildOrgReport(
+	store *config.ProjectionHistoryStore,
+	from, to time.Time,
+	teamTag, envTag string,
+) *engine.OrgReport {
+	snapshots := make(map[string][]config.ProjectionSnapshot)
+	for _, stack := range store.Stacks() {
+		if inEffect := store.SnapshotsBetween(stack, from, to); len(inEffect) > 0 {
+			snapshots[stack] = inEffect
+		}
+	}
+	return engine.BuildOrgReport(engine.OrgReportInput{
+		From:           from,
+		To:             to,
+		TeamTag:        teamTag,
+		EnvironmentTag: envTag,
+		Snapshots:      snapshots,
+	})
+}
+
+// renderOrgReport renders the organization report in the requested format.
+func renderOrgReport(w io.Writer, format string, report *engine.OrgReport) error {
+	switch format {
+	case outputFormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return fmt.Errorf("encoding organization report JSON: %w", err)
+		}
+		return nil
+	case outputFormatCSV:
+		return renderOrgReportCSV(w, report)
+	case outputFormatHTML:
+		data := struct {
+			*engine.OrgReport
+			Sections []orgReportSection
+		}{report, orgReportSections(report)}
+		if err := orgReportHTML.Execute(w, data); err != nil {
+			return fmt.Errorf("rendering organization report HTML: %w", err)
+		}
+		return nil
+	default:
+		return renderOrgReportTable(w, report)
+	}
+}
+
+// orgReportSection is one grouping of an organization report. Fields are
+// exported for the HTML template.
+type orgReportSection struct {
+	Name  string
+	Title string
+	// Tag is the tag key the grouping is based on, if any.
+	Tag     string
+	Entries []engine.OrgRollupEntry
+}
+
+// orgReportSections returns the report's groupings in display order.
+func orgReportSections(report *engine.OrgReport) []orgReportSection {
+	return []orgReportSection{
+		{Name: "provider", Title: "By provider", Entries: report.ByProvider},
+		{Name: "team", Title: "By team", Tag: report.TeamTag, Entries: report.ByTeam},
+		{Name: "environment", Title: "By environment", Tag: report.EnvironmentTag, Entries: report.ByEnvironment},
+	}
+}
+
+// renderOrgReportTable renders the organization report as one table per grouping.
+func renderOrgReportTable(w io.Writer, report *engine.OrgReport) error {
+	fmt.Fprintf(w, "Organization report, %s to %s\n\n",
+		report.From.Format("2006-01-02"), report.To.Format("2006-01-02"))
+
+	if len(report.Stacks) == 0 {
+		fmt.Fprintln(w, "No projections were recorded for this period; "+
+			"record them with 'finfocus cost projected --stack <name> --record'.")
+		return nil
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 0, tabPadding, ' ', 0)
+	for _, section := range orgReportSections(report) {
+		title := strings.ToUpper(section.Title)
+		if section.Tag != "" {
+			title += fmt.Sprintf(" (tag: %s)", section.Tag)
+		}
+		fmt.Fprintln(tw, title)
+		fmt.Fprintln(tw, "GROUP\tMONTHLY\tRESOURCES\tSTACKS")
+		for _, e := range section.Entries {
+			fmt.Fprintf(tw, "%s\t%.2f\t%d\t%d\n", e.Key, e.Monthly, e.Resources, e.Stacks)
+		}
+		fmt.Fprintln(tw)
+	}
+
+	fmt.Fprintln(tw, "BY STACK")
+	fmt.Fprintln(tw, "STACK\tMONTHLY\tRESOURCES\tSNAPSHOTS")
+	for _, s := range report.Stacks {
+		fmt.Fprintf(tw, "%s\t%.2f\t%d\t%d\n", s.Stack, s.Monthly, s.Resources, s.Snapshots)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTotal: %.2f %s/month across %d stacks\n",
+		report.TotalMonthly, report.Currency, len(report.Stacks))
+	if report.MixedCurrencies {
+		fmt.Fprintln(w, "Warning: projections use more than one currency; totals are not converted.")
+	}
+	return nil
+}
+
+// renderOrgReportCSV renders the organization report as RFC 4180 CSV with one
+// row per group, including a "stack" grouping.
+func renderOrgReportCSV(w io.Writer, report *engine.OrgReport) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"grouping", "group", "monthly", "currency", "resources", "stacks"}); err != nil {
+		return fmt.Errorf("writing CSV header: %w", err)
+	}
+
+	formatMonthly := func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) }
+	for _, section := range orgReportSections(report) {
+		for _, e := range section.Entries {
+			row := []string{
+				section.Name, e.Key, formatMonthly(e.Monthly), report.Currency,
+				strconv.Itoa(e.Resources), strconv.Itoa(e.Stacks),
+			}
+			if err := cw.Write(row); err != nil {
+				return fmt.Errorf("writing CSV row: %w", err)
+			}
+		}
+	}
+	for _, s := range report.Stacks {
+		row := []string{
+			"stack", s.Stack, formatMonthly(s.Monthly), report.Currency, strconv.Itoa(s.Resources), "1",
+		}
+		if err := cw.Write(row); err != nil {
+			return fmt.Errorf("writing CSV row: %w", err)
+		}
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return fmt.Errorf("flushing CSV: %w", err)
+	}
+	return nil
+}
+
+// orgReportHTML is a self-contained HTML page for the organization report.
+//
+//nolint:gochecknoglobals // Parsed once; template text is static.
+var orgReportHTML = template.Must(template.New("org").Funcs(template.FuncMap{
+	"money": func(v float64) string { return strconv.FormatFloat(v, 'f', 2, 64) },
+	"date":  func(t time.Time) string { return t.Format("2006-01-02") },
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<title>FinFocus organization report {{date .From}} to {{date .To}}</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; margin-bottom: 2em; }
+th, td { border: 1px solid #ccc; padding: 4px 10px; text-align: left; }
+td.num { text-align: right; }
+</style>
+</head>
+<body>
+<h1>Organization report</h1>
+<p>{{date .From}} to {{date .To}}: {{money .TotalMonthly}} {{.Currency}}/month across {{len .Stacks}} stacks.</p>
+{{- if .MixedCurrencies}}
+<p><strong>Projections use more than one currency; totals are not converted.</strong></p>
+{{- end}}
+{{- $currency := .Currency}}
+{{- range .Sections}}
+<h2>{{.Title}}{{with .Tag}} (tag: {{.}}){{end}}</h2>
+<table>
+<tr><th>Group</th><th>Monthly ({{$currency}})</th><th>Resources</th><th>Stacks</th></tr>
+{{- range .Entries}}
+<tr><td>{{.Key}}</td><td class="num">{{money .Monthly}}</td>
+<td class="num">{{.Resources}}</td><td class="num">{{.Stacks}}</td></tr>
+{{- end}}
+</table>
+{{- end}}
+<h2>By stack</h2>
+<table>
+<tr><th>Stack</th><th>Monthly ({{.Currency}})</th><th>Resources</th><th>Snapshots</th></tr>
+{{- range .Stacks}}
+<tr><td>{{.Stack}}</td><td class="num">{{money .Monthly}}</td>
+<td class="num">{{.Resources}}</td><td class="num">{{.Snapshots}}</td></tr>
+{{- end}}
+</table>
+</body>
+</html>
+`))
@@ -0,0 +1,117 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// seedOrgHistory records projections for two stacks under a temporary FINFOCUS_HOME.
+func seedOrgHistory(t *testing.T) {
+	t.Helper()
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	store := config.NewProjectionHistoryStore("")
+	require.NoError(t, store.RecordSnapshot("prod", config.ProjectionSnapshot{
+		RecordedAt: time.Date(2025, 12, 1, 0, 0, 0, 0, time.UTC),
+		Resources: map[string]config.ProjectedResourceRecord{
+			"web": {
+				ResourceType: "aws:ec2/instance:Instance", Provider: "aws", Monthly: 100, Currency: "USD",
+				Tags: map[string]string{"team": "web", "environment": "production"},
+			},
+		},
+	}))
+	require.NoError(t, store.RecordSnapshot("dev", config.ProjectionSnapshot{
+		RecordedAt: time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC),
+		Resources: map[string]config.ProjectedResourceRecord{
+			"db": {ResourceType: "gcp:sql/databaseInstance:DatabaseInstance", Monthly: 60, Currency: "USD"},
+		},
+	}))
+	require.NoError(t, store.Save())
+}
+
+func runReportOrg(t *testing.T, params reportOrgParams) (string, error) {
+	t.Helper()
+	cmd := NewReportOrgCmd()
+	cmd.SetContext(context.Background())
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	err := executeReportOrg(cmd, params)
+	return out.String(), err
+}
+
+func TestReportOrg_Formats(t *testing.T) {
+	seedOrgHistory(t)
+	params := reportOrgParams{from: "2026-01-01", to: "2026-01-31", teamTag: "team", envTag: "environment"}
+
+	params.output = outputFormatTable
+	out, err := runReportOrg(t, params)
+	require.NoError(t, err)
+	assert.Contains(t, out, "BY PROVIDER")
+	assert.Contains(t, out, "BY TEAM (tag: team)")
+	assert.Contains(t, out, "BY ENVIRONMENT (tag: environment)")
+	assert.Contains(t, out, "production")
+	assert.Contains(t, out, "Total: 130.00 USD/month across 2 stacks")
+
+	params.output = outputFormatJSON
+	out, err = runReportOrg(t, params)
+	require.NoError(t, err)
+	var report engine.OrgReport
+	require.NoError(t, json.Unmarshal([]byte(out), &report))
+	assert.InDelta(t, 130.0, report.TotalMonthly, 0.001, "dev was in effect for half the period")
+	require.Len(t, report.ByTeam, 2)
+	assert.Equal(t, "web", report.ByTeam[0].Key)
+	assert.Equal(t, engine.UntaggedGroupKey, report.ByTeam[1].Key)
+
+	params.output = outputFormatCSV
+	out, err = runReportOrg(t, params)
+	require.NoError(t, err)
+	rows, err := csv.NewReader(bytes.NewBufferString(out)).ReadAll()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"grouping", "group", "monthly", "currency", "resources", "stacks"}, rows[0])
+	assert.Contains(t, rows, []string{"provider", "gcp", "30.00", "USD", "1", "1"})
+	assert.Contains(t, rows, []string{"environment", "dev", "30.00", "USD", "1", "1"})
+	assert.Contains(t, rows, []string{"stack", "prod", "100.00", "USD", "1", "1"})
+
+	params.output = outputFormatHTML
+	params.outputFile = filepath.Join(t.TempDir(), "org.html")
+	out, err = runReportOrg(t, params)
+	require.NoError(t, err)
+	assert.Empty(t, out, "output goes to the file")
+	html, err := os.ReadFile(params.outputFile)
+	require.NoError(t, err)
+	assert.Contains(t, string(html), "<h2>By team (tag: team)</h2>")
+	assert.Contains(t, string(html), "(untagged)", "untagged resources are listed")
+	assert.Contains(t, string(html), "130.00 USD/month across 2 stacks")
+}
+
+func TestReportOrg_NoHistory(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	out, err := runReportOrg(t, reportOrgParams{from: "2026-01-01", to: "2026-02-01", output: outputFormatTable})
+	require.NoError(t, err)
+	assert.Contains(t, out, "No projections were recorded for this period")
+}
+
+func TestReportOrg_InvalidInput(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	_, err := runReportOrg(t, reportOrgParams{from: "2026-01-01", to: "2026-02-01", output: "xml"})
+	require.ErrorContains(t, err, "unsupported output format")
+
+	_, err = runReportOrg(t, reportOrgParams{from: "2026-02-01", to: "2026-01-01", output: outputFormatTable})
+	require.ErrorContains(t, err, "'to' date must be after 'from' date")
+
+	_, err = runReportOrg(t, reportOrgParams{from: "January", to: "2026-01-01", output: outputFormatTable})
+	require.ErrorContains(t, err, "parsing 'from' date")
+}
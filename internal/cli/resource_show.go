@@ -0,0 +1,515 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// defaultResourceShowDays is the default window of actual cost in 'resource show'.
+const defaultResourceShowDays = 30
+
+// resourceShowParams holds the parameters for the resource show command execution.
+type resourceShowParams struct {
+	planPath  string
+	statePath string
+	adapter   string
+	output    string
+	days      int
+}
+
+// resourceDetail is everything known about one resource.
+type resourceDetail struct {
+	Resource        engine.ResourceDescriptor
+	Projected       *engine.CostResult
+	Actual          *resourceActualJSON
+	Budgets         []*engine.ScopedBudgetStatus
+	Recommendations []engine.Recommendation
+}
+
+// resourceProjectedJSON is the projected cost in 'resource show --output json'.
+type resourceProjectedJSON struct {
+	Adapter   string             `json:"adapter,omitempty"`
+	Currency  string             `json:"currency"`
+	Monthly   float64            `json:"monthly"`
+	Hourly    float64            `json:"hourly"`
+	Breakdown map[string]float64 `json:"breakdown,omitempty"`
+	Notes     string             `json:"notes,omitempty"`
+}
+
+// resourceActualJSON is the actual cost over the last days in 'resource show'.
+type resourceActualJSON struct {
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Currency string    `json:"currency,omitempty"`
+	Total    float64   `json:"total"`
+}
+
+// resourceShowJSON is the output of 'resource show --output json'.
+type resourceShowJSON struct {
+	ID              string                                 `json:"id"`
+	Type            string                                 `json:"type"`
+	Provider        string                                 `json:"provider,omitempty"`
+	SourcePosition  string                                 `json:"source_position,omitempty"`
+	Projected       *resourceProjectedJSON                 `json:"projected,omitempty"`
+	Actual          *resourceActualJSON                    `json:"actual,omitempty"`
+	Budgets         []budgetStatusJSON                     `json:"budgets"`
+	Recommendations []engine.Recommendation                `json:"recommendations"`
+	Sustainability  map[string]engine.SustainabilityMetric `json:"sustainability,omitempty"`
+	Properties      map[string]interface{}                 `json:"properties"`
+}
+
+// newResourceCmd creates the resource command group.
+func newResourceCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "resource", Short: "Resource commands"}
+	cmd.AddCommand(NewResourceShowCmd())
+	return cmd
+}
+
+// NewResourceShowCmd creates the "show" subcommand, which aggregates
+// everything known about one resource of the stack.
+func NewResourceShowCmd() *cobra.Command {
+	var params resourceShowParams
+
+	cmd := &cobra.Command{
+		Use:   "show <urn-or-id>",
+		Short: "Show costs, budgets, and recommendations of one resource",
+		Long: `Show everything known about one resource of the stack: its projected
+monthly cost, its actual cost over the last --days days, the budget scopes its
+cost counts toward, its open recommendations, its sustainability metrics, and
+the raw properties of its descriptor.
+
+The resource is looked up by URN, by cloud ID or ARN, or by the name at the end
+of its URN. When a name matches several resources, their URNs are listed so the
+lookup can be repeated with the full URN.
+
+Resources come from --pulumi-json or --pulumi-state. When both are omitted, the
+deployed state of the current Pulumi stack is used. Use --stack to pick a
+different stack.`,
+		Example: `  # Drill into a resource of the current Pulumi stack by name
+  finfocus resource show web-server
+
+  # Look a resource up by cloud ID in an exported state
+  finfocus resource show i-0abc123 --pulumi-state state.json
+
+  # Everything about a resource as JSON, with 90 days of actual cost
+  finfocus resource show 'urn:pulumi:dev::app::aws:ec2/instance:Instance::web' --days 90 --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeResourceShow(cmd, params, args[0])
+		},
+	}
+
+	cmd.Flags().StringVar(&params.planPath, "pulumi-json", "", "Path to Pulumi preview JSON output")
+	cmd.Flags().StringVar(&params.statePath, "pulumi-state", "", "Path to Pulumi state JSON from 'pulumi stack export'")
+	cmd.Flags().String("stack", "", "Pulumi stack for auto-detection (ignored with --pulumi-json/--pulumi-state)")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().IntVar(&params.days, "days", defaultResourceShowDays, "Days of actual cost to include")
+	cmd.Flags().StringVar(&params.output, "output", outputFormatTable, "Output format: table or json")
+	cmd.MarkFlagsMutuallyExclusive("pulumi-json", "pulumi-state")
+
+	return cmd
+}
+
+// executeResourceShow looks the resource up, collects its details, and
+// renders them.
+func executeResourceShow(cmd *cobra.Command, params resourceShowParams, ref string) error {
+	if params.output != outputFormatTable && params.output != outputFormatJSON {
+		return fmt.Errorf("unsupported output format %q: use table or json", params.output)
+	}
+	if params.days < 1 {
+		return fmt.Errorf("--days must be at least 1, got %d", params.days)
+	}
+
+	ctx := cmd.Context()
+	audit := newAuditContext(ctx, "resource show", map[string]string{
+		"resource": ref, "pulumi_json": params.planPath, "pulumi_state": params.statePath,
+	})
+
+	resources, err := loadStackResources(ctx, cmd, "resource show", params.planPath, params.statePath)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	resource, err := findResource(resources, ref)
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	detail, err := collectResourceDetail(ctx, cmd, eng, params, resources, resource, time.Now())
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	if params.output == outputFormatJSON {
+		err = renderResourceShowJSON(cmd, detail)
+	} else {
+		err = renderResourceShowTable(cmd, detail)
+	}
+	if err != nil {
+		audit.logFailure(ctx, err)
+		return err
+	}
+	audit.logSuccess(ctx, 1, detail.monthly())
+	return nil
+}
+
+// findResource returns the resource that ref identifies. An exact URN match
+// wins; otherwise ref is matched against cloud IDs and ARNs, and then against
+// the names at the end of the URNs. It is an error when nothing matches or
+// when several resources match at the same level.
+func findResource(resources []engine.ResourceDescriptor, ref string) (engine.ResourceDescriptor, error) {
+	for _, r := range resources {
+		if r.ID == ref {
+			return r, nil
+		}
+	}
+
+	levels := []func(engine.ResourceDescriptor) bool{
+		func(r engine.ResourceDescriptor) bool {
+			for _, key := range []string{"pulumi:cloudId", "pulumi:arn", "id", "arn"} {
+				if v, ok := r.Properties[key].(string); ok && v == ref {
+					return true
+				}
+			}
+			return false
+		},
+		func(r engine.ResourceDescriptor) bool {
+			i := strings.LastIndex(r.ID, "::")
+			return i >= 0 && r.ID[i+2:] == ref
+		},
+	}
+	for _, matches := range levels {
+		var found []engine.ResourceDescriptor
+		for _, r := range resources {
+			if matches(r) {
+				found = append(found, r)
+			}
+		}
+		switch len(found) {
+		case 0:
+			continue
+		case 1:
+			return found[0], nil
+		default:
+			ids := make([]string, 0, len(found))
+			for _, r := range found {
+				ids = append(ids, "  "+r.ID)
+			}
+			return engine.ResourceDescriptor{}, fmt.Errorf(
+				"%q matches %d resources; use the full URN:\n%s", ref, len(found), strings.Join(ids, "\n"))
+		}
+	}
+	return engine.ResourceDescriptor{}, fmt.Errorf("resource %q not found among %d resources", ref, len(resources))
+}
+
+// collectResourceDetail prices all resources so the budgets can be
+// evaluated, then gathers the projected cost, recommendations, matching
+// budget scopes, and the actual cost of the last params.days full days
+// before now of resource.
+func collectResourceDetail(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng dashboardEngine,
+	params resourceShowParams,
+	resources []engine.ResourceDescriptor,
+	resource engine.ResourceDescriptor,
+	now time.Time,
+) (*resourceDetail, error) {
+	detail := &resourceDetail{Resource: resource}
+
+	projected, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	fetchAndMergeRecommendations(ctx, eng, []engine.ResourceDescriptor{resource}, projected.Results)
+	for i := range projected.Results {
+		if projected.Results[i].ResourceID == resource.ID {
+			detail.Projected = &projected.Results[i]
+			detail.Recommendations = projected.Results[i].Recommendations
+			break
+		}
+	}
+
+	if detail.Projected != nil {
+		currency, mixed := extractCurrencyFromResults(projected.Results)
+		if !mixed {
+			total := 0.0
+			for _, r := range projected.Results {
+				total += r.Monthly
+			}
+			tags := resourceTagIndex(resources)
+			budgets, budgetErr := evaluateBudgetsQuietly(cmd, projected.Results, tags, total, currency)
+			if budgetErr != nil {
+				return nil, fmt.Errorf("evaluating budgets: %w", budgetErr)
+			}
+			activeStack := engine.ResolveActiveStack(projected.Results, getStackFlag(cmd))
+			detail.Budgets = resourceBudgetScopes(ctx, budgetScopes(budgets), *detail.Projected, tags, activeStack)
+		}
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour) //nolint:mnd // One day.
+	from := today.AddDate(0, 0, -params.days)
+	actual, err := eng.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+		Resources: []engine.ResourceDescriptor{resource}, From: from, To: today, Adapter: params.adapter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching actual costs: %w", err)
+	}
+	detail.Actual = &resourceActualJSON{From: from, To: today}
+	for _, r := range actual.Results {
+		if r.Error != nil {
+			continue
+		}
+		detail.Actual.Total += r.TotalCost
+		if detail.Actual.Currency == "" {
+			detail.Actual.Currency = r.Currency
+		}
+	}
+	return detail, nil
+}
+
+// resourceBudgetScopes returns the scopes that the projected cost of one
+// resource counts toward: the global scope, and the provider, tag, type, and
+// stack scopes it is allocated to.
+func resourceBudgetScopes(
+	ctx context.Context,
+	scopes []*engine.ScopedBudgetStatus,
+	cost engine.CostResult,
+	tags map[string]map[string]string,
+	activeStack string,
+) []*engine.ScopedBudgetStatus {
+	var spend *scopeSpend
+	if cfg := config.GetGlobalConfig(); cfg != nil && cfg.Cost.Budgets != nil {
+		eval := engine.NewScopedBudgetEvaluator(cfg.Cost.Budgets)
+		spend = allocateScopeSpend(ctx, eval, []engine.CostResult{cost}, tags, activeStack)
+	}
+	return matchBudgetScopes(scopes, spend)
+}
+
+// matchBudgetScopes returns the scopes that spend, the allocation of a single
+// resource, was allocated to. With a nil spend only the global scope matches.
+func matchBudgetScopes(scopes []*engine.ScopedBudgetStatus, spend *scopeSpend) []*engine.ScopedBudgetStatus {
+	var matched []*engine.ScopedBudgetStatus
+	for _, s := range scopes {
+		ok := s.ScopeType == engine.ScopeTypeGlobal
+		if spend != nil {
+			switch s.ScopeType {
+			case engine.ScopeTypeGlobal:
+			case engine.ScopeTypeProvider:
+				_, ok = spend.provider[s.ScopeKey]
+			case engine.ScopeTypeTag:
+				_, ok = spend.tag[s.ScopeKey]
+			case engine.ScopeTypeType:
+				_, ok = spend.typ[s.ScopeKey]
+			case engine.ScopeTypeStack:
+				_, ok = spend.stack[s.ScopeKey]
+			}
+		}
+		if ok {
+			matched = append(matched, s)
+		}
+	}
+	return matched
+}
+
+// monthly returns the projected monthly cost of the resource, or zero.
+func (d *resourceDetail) monthly() float64 {
+	if d.Projected == nil {
+		return 0
+	}
+	return d.Projected.Monthly
+}
+
+// toJSON converts the detail to its JSON form.
+func (d *resourceDetail) toJSON() resourceShowJSON {
+	out := resourceShowJSON{
+		ID:              d.Resource.ID,
+		Type:            d.Resource.Type,
+		Provider:        d.Resource.Provider,
+		SourcePosition:  d.Resource.SourcePosition,
+		Actual:          d.Actual,
+		Budgets:         make([]budgetStatusJSON, 0, len(d.Budgets)),
+		Recommendations: d.Recommendations,
+		Properties:      d.Resource.Properties,
+	}
+	if p := d.Projected; p != nil {
+		out.Projected = &resourceProjectedJSON{
+			Adapter: p.Adapter, Currency: p.Currency, Monthly: p.Monthly, Hourly: p.Hourly,
+			Breakdown: p.Breakdown, Notes: p.Notes,
+		}
+		out.Sustainability = p.Sustainability
+	}
+	for _, s := range d.Budgets {
+		out.Budgets = append(out.Budgets, budgetStatusJSON{
+			Scope:              s.ScopeIdentifier(),
+			Currency:           s.Currency,
+			Amount:             s.Budget.Amount,
+			Spend:              s.CurrentSpend,
+			Percentage:         s.Percentage,
+			Forecast:           s.ForecastedSpend,
+			ForecastPercentage: s.ForecastPercentage,
+			Health:             strings.ToLower(healthStatusLabel(s.Health)),
+		})
+	}
+	if out.Recommendations == nil {
+		out.Recommendations = []engine.Recommendation{}
+	}
+	if out.Properties == nil {
+		out.Properties = map[string]interface{}{}
+	}
+	return out
+}
+
+// renderResourceShowJSON writes the detail as a JSON object.
+func renderResourceShowJSON(cmd *cobra.Command, detail *resourceDetail) error {
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(detail.toJSON()); err != nil {
+		return fmt.Errorf("encoding resource JSON: %w", err)
+	}
+	return nil
+}
+
+// renderResourceShowTable writes the detail as a section per kind of data.
+func renderResourceShowTable(cmd *cobra.Command, detail *resourceDetail) error {
+	r := detail.Resource
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintf(tw, "Resource:\t%s\n", r.ID)
+	fmt.Fprintf(tw, "Type:\t%s\n", r.Type)
+	if r.Provider != "" {
+		fmt.Fprintf(tw, "Provider:\t%s\n", r.Provider)
+	}
+	if r.SourcePosition != "" {
+		fmt.Fprintf(tw, "Source:\t%s\n", r.SourcePosition)
+	}
+
+	fmt.Fprintln(tw, "\nCOSTS")
+	if p := detail.Projected; p != nil {
+		fmt.Fprintf(tw, "Projected monthly:\t%.2f %s\n", p.Monthly, p.Currency)
+		if p.Adapter != "" {
+			fmt.Fprintf(tw, "Priced by:\t%s\n", p.Adapter)
+		}
+		if p.Notes != "" {
+			fmt.Fprintf(tw, "Notes:\t%s\n", p.Notes)
+		}
+	} else {
+		fmt.Fprintln(tw, "Projected monthly:\tnot available")
+	}
+	if a := detail.Actual; a != nil {
+		currency := a.Currency
+		if currency == "" {
+			currency = defaultCurrency
+		}
+		fmt.Fprintf(tw, "Actual %s to %s:\t%.2f %s\n",
+			a.From.Format("2006-01-02"), a.To.AddDate(0, 0, -1).Format("2006-01-02"), a.Total, currency)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	writeResourceBudgets(cmd, detail.Budgets)
+	writeResourceRecommendations(cmd, detail.Recommendations)
+	if detail.Projected != nil {
+		writeResourceSustainability(cmd, detail.Projected.Sustainability)
+	}
+	writeResourceProperties(cmd, r.Properties)
+	return nil
+}
+
+// writeResourceBudgets writes the budget scopes the resource counts toward.
+func writeResourceBudgets(cmd *cobra.Command, scopes []*engine.ScopedBudgetStatus) {
+	cmd.Println("\nBUDGETS")
+	if len(scopes) == 0 {
+		cmd.Println("No budgets apply to this resource.")
+		return
+	}
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "SCOPE\tSPEND\tLIMIT\tUSED\tHEALTH")
+	for _, s := range scopes {
+		fmt.Fprintf(tw, "%s\t%.2f\t%.2f %s\t%.0f%%\t%s\n", s.ScopeIdentifier(),
+			s.CurrentSpend, s.Budget.Amount, s.Currency, s.Percentage, healthStatusLabel(s.Health))
+	}
+	_ = tw.Flush()
+}
+
+// writeResourceRecommendations writes the open recommendations of the resource.
+func writeResourceRecommendations(cmd *cobra.Command, recs []engine.Recommendation) {
+	cmd.Println("\nRECOMMENDATIONS")
+	if len(recs) == 0 {
+		cmd.Println("No open recommendations.")
+		return
+	}
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	fmt.Fprintln(tw, "TYPE\tSAVINGS\tDESCRIPTION")
+	for _, rec := range recs {
+		fmt.Fprintf(tw, "%s\t%.2f %s\t%s\n", rec.Type, rec.EstimatedSavings, rec.Currency, rec.Description)
+	}
+	_ = tw.Flush()
+}
+
+// writeResourceSustainability writes the sustainability metrics by name.
+func writeResourceSustainability(cmd *cobra.Command, metrics map[string]engine.SustainabilityMetric) {
+	if len(metrics) == 0 {
+		return
+	}
+	cmd.Println("\nSUSTAINABILITY")
+	names := make([]string, 0, len(metrics))
+	for name := range metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	for _, name := range names {
+		fmt.Fprintf(tw, "%s:\t%.4g %s\n", name, metrics[name].Value, metrics[name].Unit)
+	}
+	_ = tw.Flush()
+}
+
+// writeResourceProperties writes the descriptor properties by name, with
+// values that are not strings encoded as JSON.
+func writeResourceProperties(cmd *cobra.Command, properties map[string]interface{}) {
+	cmd.Println("\nPROPERTIES")
+	if len(properties) == 0 {
+		cmd.Println("No properties.")
+		return
+	}
+	keys := make([]string, 0, len(properties))
+	for key := range properties {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tw := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, tabPadding, ' ', 0)
+	for _, key := range keys {
+		value, ok := properties[key].(string)
+		if !ok {
+			value = fmt.Sprintf("%v", properties[key])
+			if encoded, err := json.Marshal(properties[key]); err == nil {
+				value = string(encoded)
+			}
+		}
+		fmt.Fprintf(tw, "%s:\t%s\n", key, value)
+	}
+	_ = tw.Flush()
+}
@@ -0,0 +1,222 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func resourceShowFixtures() []engine.ResourceDescriptor {
+	return []engine.ResourceDescriptor{
+		{
+			ID:   "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			Type: "aws:ec2/instance:Instance", Provider: "aws",
+			Properties: map[string]interface{}{"pulumi:cloudId": "i-0abc123", "instanceType": "t3.micro"},
+		},
+		{
+			ID:   "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets",
+			Type: "aws:s3/bucket:Bucket", Provider: "aws",
+			Properties: map[string]interface{}{"arn": "arn:aws:s3:::assets"},
+		},
+		{
+			ID:   "urn:pulumi:dev::app::aws:s3/bucket:Bucket$aws:s3/bucketPolicy:BucketPolicy::assets",
+			Type: "aws:s3/bucketPolicy:BucketPolicy", Provider: "aws",
+		},
+	}
+}
+
+func TestFindResource(t *testing.T) {
+	resources := resourceShowFixtures()
+	tests := []struct {
+		name    string
+		ref     string
+		want    string
+		wantErr string
+	}{
+		{"urn", resources[1].ID, resources[1].ID, ""},
+		{"cloud id", "i-0abc123", resources[0].ID, ""},
+		{"arn", "arn:aws:s3:::assets", resources[1].ID, ""},
+		{"name", "web", resources[0].ID, ""},
+		{"ambiguous name", "assets", "", `"assets" matches 2 resources`},
+		{"missing", "db", "", `resource "db" not found among 3 resources`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := findResource(resources, tt.ref)
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got.ID)
+		})
+	}
+}
+
+func TestFindResource_AmbiguousListsURNs(t *testing.T) {
+	resources := resourceShowFixtures()
+	_, err := findResource(resources, "assets")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), resources[1].ID)
+	assert.Contains(t, err.Error(), resources[2].ID)
+}
+
+func TestMatchBudgetScopes(t *testing.T) {
+	scopes := []*engine.ScopedBudgetStatus{
+		{ScopeType: engine.ScopeTypeGlobal},
+		{ScopeType: engine.ScopeTypeProvider, ScopeKey: "aws"},
+		{ScopeType: engine.ScopeTypeProvider, ScopeKey: "gcp"},
+		{ScopeType: engine.ScopeTypeTag, ScopeKey: "team:platform"},
+		{ScopeType: engine.ScopeTypeType, ScopeKey: "aws:ec2/instance:Instance"},
+		{ScopeType: engine.ScopeTypeStack, ScopeKey: "prod"},
+	}
+
+	t.Run("allocated scopes", func(t *testing.T) {
+		spend := &scopeSpend{
+			provider: map[string]float64{"aws": 0},
+			tag:      map[string]float64{"team:platform": 10},
+			typ:      map[string]float64{},
+			stack:    map[string]float64{},
+		}
+		var ids []string
+		for _, s := range matchBudgetScopes(scopes, spend) {
+			ids = append(ids, s.ScopeIdentifier())
+		}
+		assert.Equal(t, []string{"global", "provider:aws", "tag:team:platform"}, ids)
+	})
+
+	t.Run("no allocation", func(t *testing.T) {
+		matched := matchBudgetScopes(scopes, nil)
+		require.Len(t, matched, 1)
+		assert.Equal(t, "global", matched[0].ScopeIdentifier())
+	})
+}
+
+func TestCollectResourceDetail(t *testing.T) {
+	resources := resourceShowFixtures()
+	eng := &apiTestEngine{mockRecommendationFetcher: mockRecommendationFetcher{
+		result: &engine.RecommendationsResult{Recommendations: []engine.Recommendation{
+			{ResourceID: resources[0].ID, Type: "Right-sizing", EstimatedSavings: 3, Currency: "USD"},
+		}},
+	}}
+	cmd := &cobra.Command{}
+	cmd.SetContext(context.Background())
+	now := time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC)
+
+	detail, err := collectResourceDetail(context.Background(), cmd, eng, resourceShowParams{days: 7},
+		resources, resources[0], now)
+	require.NoError(t, err)
+
+	assert.Len(t, eng.priced, len(resources), "all resources are priced for budget evaluation")
+	require.NotNil(t, detail.Projected)
+	assert.InDelta(t, 10.0, detail.Projected.Monthly, 0.001)
+	require.Len(t, detail.Recommendations, 1)
+	assert.Equal(t, "Right-sizing", detail.Recommendations[0].Type)
+
+	require.Len(t, eng.actual.Resources, 1)
+	assert.Equal(t, resources[0].ID, eng.actual.Resources[0].ID)
+	assert.Equal(t, time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC), eng.actual.From)
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), eng.actual.To)
+	require.NotNil(t, detail.Actual)
+	assert.InDelta(t, 4.5, detail.Actual.Total, 0.001)
+	assert.Equal(t, "USD", detail.Actual.Currency)
+}
+
+func TestRenderResourceShowJSON(t *testing.T) {
+	resource := resourceShowFixtures()[0]
+	detail := &resourceDetail{
+		Resource: resource,
+		Projected: &engine.CostResult{
+			ResourceID: resource.ID, Currency: "USD", Monthly: 7.5, Adapter: "aws-public",
+			Sustainability: map[string]engine.SustainabilityMetric{"carbon_footprint": {Value: 1.2, Unit: "kgCO2e"}},
+		},
+		Budgets: []*engine.ScopedBudgetStatus{
+			{ScopeType: engine.ScopeTypeGlobal, Budget: config.ScopedBudget{Amount: 100}, CurrentSpend: 40,
+				Percentage: 40, Currency: "USD"},
+		},
+	}
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderResourceShowJSON(cmd, detail))
+
+	var got resourceShowJSON
+	require.NoError(t, json.Unmarshal(out.Bytes(), &got))
+	assert.Equal(t, resource.ID, got.ID)
+	require.NotNil(t, got.Projected)
+	assert.InDelta(t, 7.5, got.Projected.Monthly, 0.001)
+	assert.Equal(t, "aws-public", got.Projected.Adapter)
+	require.Len(t, got.Budgets, 1)
+	assert.Equal(t, "global", got.Budgets[0].Scope)
+	assert.Empty(t, got.Recommendations)
+	assert.NotNil(t, got.Recommendations, "recommendations encode as an empty array")
+	assert.Equal(t, "kgCO2e", got.Sustainability["carbon_footprint"].Unit)
+	assert.Equal(t, "t3.micro", got.Properties["instanceType"])
+}
+
+func TestRenderResourceShowTable(t *testing.T) {
+	resource := resourceShowFixtures()[0]
+	detail := &resourceDetail{
+		Resource:  resource,
+		Projected: &engine.CostResult{ResourceID: resource.ID, Currency: "USD", Monthly: 7.5},
+		Actual: &resourceActualJSON{
+			From: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC),
+			Currency: "USD", Total: 1.75,
+		},
+		Recommendations: []engine.Recommendation{
+			{Type: "Right-sizing", Description: "Use t3.nano", EstimatedSavings: 3, Currency: "USD"},
+		},
+	}
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	require.NoError(t, renderResourceShowTable(cmd, detail))
+
+	text := out.String()
+	assert.Contains(t, text, "Resource:")
+	assert.Contains(t, text, resource.ID)
+	assert.Contains(t, text, "7.50 USD")
+	assert.Contains(t, text, "Actual 2026-03-01 to 2026-03-07:")
+	assert.Contains(t, text, "1.75 USD")
+	assert.Contains(t, text, "No budgets apply to this resource.")
+	assert.Contains(t, text, "Use t3.nano")
+	assert.NotContains(t, text, "SUSTAINABILITY")
+	assert.Contains(t, text, "instanceType:")
+	assert.Contains(t, text, "t3.micro")
+}
+
+func TestResourceShow_RejectsInvalidFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{"no ref", []string{}, "accepts 1 arg(s)"},
+		{"output", []string{"web", "--output", "yaml"}, `unsupported output format "yaml"`},
+		{"days", []string{"web", "--days", "0"}, "--days must be at least 1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := NewResourceShowCmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
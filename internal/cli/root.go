@@ -1,6 +1,7 @@
 package cli
 
 import (
+	"errors"
 	"fmt"
 	"os"
 
@@ -9,8 +10,10 @@ import (
 	"golang.org/x/term"
 
 	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/migration"
+	"github.com/rshade/finfocus/internal/tui"
 )
 
 // isTerminal checks if the given file is a terminal.
@@ -37,6 +40,7 @@ func NewRootCmdWithArgs(
 	lookupEnv func(string) (string, bool),
 ) *cobra.Command {
 	var logResult *logging.LogPathResult
+	finishTracing := func() {}
 
 	// Detect plugin mode from binary name or environment variable
 	pluginMode := DetectPluginMode(args, lookupEnv)
@@ -56,6 +60,12 @@ func NewRootCmdWithArgs(
 		Version: ver,
 		Example: example,
 		PersistentPreRunE: func(cmd *cobra.Command, _ []string) error {
+			silenceForStructuredErrors(cmd)
+			if err := setupProfile(cmd); err != nil {
+				return err
+			}
+			setupOutputControls(cmd)
+
 			// Validate cache-ttl is non-negative (negative values cause undefined cache expiry behavior)
 			cacheTTL, _ := cmd.Flags().GetInt("cache-ttl")
 			if cacheTTL < 0 {
@@ -71,7 +81,7 @@ func NewRootCmdWithArgs(
 				}
 
 				// Alias reminder - use precomputed pluginMode for consistency with tests
-				if os.Getenv("FINFOCUS_HIDE_ALIAS_HINT") == "" && !pluginMode {
+				if os.Getenv("FINFOCUS_HIDE_ALIAS_HINT") == "" && !pluginMode && !tui.Quiet() {
 					msg := "Tip: Add 'alias fin=finfocus' to your shell profile for a shorter command!"
 					cmd.PrintErrln(msg)
 				}
@@ -79,10 +89,30 @@ func NewRootCmdWithArgs(
 
 			result := setupLogging(cmd)
 			logResult = &result
-			return nil
+			setupTheme(cmd)
+			checkConfigFile(cmd)
+
+			finish, err := setupTracing(cmd, ver)
+			if err != nil {
+				return err
+			}
+			finishTracing = finish
+			if err = setupQueryOutput(cmd); err != nil {
+				return err
+			}
+			if err = setupSchemaOutput(cmd); err != nil {
+				return err
+			}
+			if err = setupSKUMappings(cmd); err != nil {
+				return err
+			}
+			return setupCurrency(cmd)
 		},
 		PersistentPostRunE: func(cmd *cobra.Command, _ []string) error {
-			return cleanupLogging(cmd, logResult)
+			finishTracing()
+			schemaErr := finishSchemaOutput(cmd)
+			queryErr := finishQueryOutput(cmd)
+			return errors.Join(schemaErr, queryErr, cleanupLogging(cmd, logResult))
 		},
 	}
 
@@ -91,7 +121,26 @@ func NewRootCmdWithArgs(
 		Bool("skip-version-check", false, "skip plugin spec version compatibility check")
 	cmd.PersistentFlags().
 		Int("cache-ttl", 0, "cache TTL in seconds (0 = use config default, overrides config file and env var)")
-	cmd.AddCommand(newCostCmd(), newPluginCmd(), newConfigCmd(), NewAnalyzerCmd(), NewOverviewCmd())
+	cmd.PersistentFlags().
+		String("otel-endpoint", "", "export OpenTelemetry traces to this OTLP/HTTP collector (e.g. http://localhost:4318)")
+	cmd.PersistentFlags().
+		String("currency", "", "convert all costs, savings, and budgets to this currency (e.g. EUR)")
+	cmd.PersistentFlags().
+		String("query", "", "JMESPath expression to apply to JSON output before printing (e.g. 'summary.totalMonthly')")
+	cmd.PersistentFlags().
+		String("schema-version", schemaVersionV1, "JSON output schema version: v1 or v2 (see 'finfocus schema print')")
+	cmd.PersistentFlags().
+		String("profile", "", "apply this config profile over the config file (overrides FINFOCUS_PROFILE)")
+	cmd.PersistentFlags().
+		Bool("check-config", false, "check the config file before running and print any problems it has")
+	addOutputControlFlags(cmd)
+	cmd.SetFlagErrorFunc(flagUsageError)
+	cmd.AddCommand(
+		newCostCmd(), newPluginCmd(), newConfigCmd(), NewAnalyzerCmd(), NewOverviewCmd(), newReportCmd(),
+		newNotifyCmd(), newServeCmd(), newPolicyCmd(), newHooksCmd(), NewDashboardCmd(), newBudgetCmd(),
+		newResourceCmd(), newBaselineCmd(), newSchemaCmd(), NewInitCmd(), newDebugCmd(), newDaemonCmd(),
+	)
+	registerCompletions(cmd)
 
 	return cmd
 }
@@ -149,17 +198,22 @@ type CostFlags struct {
 	ExitCode        int
 	BudgetScope     string // Filter which budget scopes to display (T025)
 	Stack           string // Pulumi stack name for auto-detection
+	FailOn          string // Minimum aggregated budget health that fails the command
+	RecordTraffic   string // Directory to record plugin requests and responses to
+	ReplayTraffic   string // Directory to replay recorded plugin responses from
 }
 
 // newCostCmd creates the "cost" command group with persistent flags, budget-related overrides, validation, and subcommands.
 //
-// The returned *cobra.Command includes persistent flags for budget behavior (--exit-on-threshold, --exit-code, --budget-scope)
-// and a --stack flag for Pulumi stack selection used during auto-detection. Its PersistentPreRunE arranges for the root command's
+// The returned *cobra.Command includes persistent flags for budget behavior (--exit-on-threshold, --exit-code, --fail-on,
+// --budget-scope), a --stack flag for Pulumi stack selection used during auto-detection, and --record-traffic /
+// --replay-traffic for capturing and replaying plugin traffic. Its PersistentPreRunE arranges for the root command's
 // PersistentPreRunE to run, ensures the global configuration has a Budgets structure so CLI flag overrides can be applied,
 // applies explicit CLI flag values to the global config when those flags were changed, and validates the global scoped budget
 // configuration when exit-on-threshold is enabled.
 //
-// Returns a configured command that contains the projected, actual, recommendations, and estimate cost subcommands.
+// Returns a configured command that contains the projected, actual, recommendations, estimate, and anomalies cost
+// subcommands.
 //
 //nolint:gocognit // CLI command setup with flag validation naturally has high branching.
 func newCostCmd() *cobra.Command {
@@ -173,11 +227,23 @@ func newCostCmd() *cobra.Command {
 			// Cobra child commands override parent's PersistentPreRunE, so we must call explicitly.
 			// Navigate to the root command to avoid recursion. We pass root itself as the command
 			// to prevent Cobra from traversing back through the parent chain.
+			silenceForStructuredErrors(cmd)
 			root := cmd.Root()
 			if root != nil && root.PersistentPreRunE != nil && root != cmd {
 				if err := root.PersistentPreRunE(root, args); err != nil {
 					return err
 				}
+				adoptRootContext(cmd, root)
+				if err := setupSchemaOutput(cmd); err != nil {
+					return err
+				}
+			}
+
+			// Reject unknown --fail-on levels before any work is done
+			if cmd.Flags().Changed("fail-on") {
+				if _, err := engine.ParseHealthLevel(flags.FailOn); err != nil {
+					return fmt.Errorf("invalid --fail-on value: %w", err)
+				}
 			}
 
 			// Apply CLI flag overrides to the global config if flags were explicitly set
@@ -186,6 +252,12 @@ func newCostCmd() *cobra.Command {
 				return nil
 			}
 
+			if flags.RecordTraffic != "" && flags.ReplayTraffic != "" {
+				return errors.New("--record-traffic and --replay-traffic cannot be used together")
+			}
+			cfg.PluginHostConfig.RecordDir = flags.RecordTraffic
+			cfg.PluginHostConfig.ReplayDir = flags.ReplayTraffic
+
 			// Ensure budgets config structure exists for CLI flag overrides
 			if cfg.Cost.Budgets == nil {
 				cfg.Cost.Budgets = &config.BudgetsConfig{}
@@ -219,15 +291,29 @@ func newCostCmd() *cobra.Command {
 	cmd.PersistentFlags().IntVar(&flags.ExitCode, "exit-code", 1,
 		"Exit code to use when budget thresholds are exceeded (0-255)")
 
+	// Add persistent flag for health-based exit policy in CI pipelines
+	cmd.PersistentFlags().StringVar(&flags.FailOn, "fail-on", "",
+		"Exit with --exit-code when aggregated budget health reaches this level: ok, warning, critical, exceeded")
+
 	// Add persistent flag for budget scope filtering (T025)
 	cmd.PersistentFlags().StringVar(&flags.BudgetScope, "budget-scope", "",
-		"Filter budget scopes to display: global, provider, provider=aws, tag, type (comma-separated)")
+		"Filter budget scopes to display: global, provider, provider=aws, tag, type, stack, stack=prod (comma-separated)")
 
 	// Add persistent flag for Pulumi stack selection during auto-detection
 	cmd.PersistentFlags().StringVar(&flags.Stack, "stack", "",
-		"Pulumi stack name for auto-detection (ignored with --pulumi-json/--pulumi-state)")
+		"Pulumi stack for auto-detection and stack budgets; org/project/stack fetches state from Pulumi Cloud")
 
-	cmd.AddCommand(NewCostProjectedCmd(), NewCostActualCmd(), NewCostRecommendationsCmd(), NewCostEstimateCmd())
+	// Add persistent flags for plugin traffic record and replay
+	cmd.PersistentFlags().StringVar(&flags.RecordTraffic, "record-traffic", "",
+		"Record every plugin request and response to this directory")
+	cmd.PersistentFlags().StringVar(&flags.ReplayTraffic, "replay-traffic", "",
+		"Answer plugin requests from a --record-traffic directory without launching plugins")
+
+	cmd.AddCommand(
+		NewCostProjectedCmd(), NewCostActualCmd(), NewCostRecommendationsCmd(),
+		NewCostEstimateCmd(), NewCostAnomaliesCmd(), NewCostVarianceCmd(), NewCostAllocateCmd(), NewCostTopCmd(),
+		NewCostCommitmentsCmd(), NewCostSimulateCmd(), NewCostOptimizeRegionCmd(),
+	)
 	return cmd
 }
 
@@ -236,8 +322,8 @@ func newPluginCmd() *cobra.Command {
 	cmd := &cobra.Command{Use: "plugin", Short: "Plugin management commands"}
 	cmd.AddCommand(
 		NewPluginValidateCmd(), NewPluginListCmd(), NewPluginInitCmd(),
-		NewPluginInstallCmd(), NewPluginUpdateCmd(), NewPluginRemoveCmd(),
-		NewPluginConformanceCmd(), NewPluginCertifyCmd(), NewPluginInspectCmd(),
+		NewPluginInstallCmd(), NewPluginUpdateCmd(), NewPluginRemoveCmd(), NewPluginUseCmd(),
+		NewPluginConformanceCmd(), NewPluginCertifyCmd(), NewPluginInspectCmd(), NewPluginDoctorCmd(),
 	)
 	return cmd
 }
@@ -0,0 +1,278 @@
+package cli
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/policy"
+)
+
+// jsonSchemaDialect is the JSON Schema draft of the documents 'schema print'
+// emits.
+const jsonSchemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// costResultsDocument is the --output json document of engine.RenderResults.
+type costResultsDocument struct {
+	FinFocus engine.AggregatedResults `json:"finfocus"`
+}
+
+// outputDocuments maps commands to a value of the type of their default
+// --output json document, from which 'schema print' derives its schema.
+//
+//nolint:gochecknoglobals // Static registry of command output types.
+var outputDocuments = map[string]any{
+	"cost projected":                costResultsDocument{},
+	"cost actual":                   []engine.CostResult{},
+	"cost recommendations":          recommendationsJSONOutput{},
+	"cost recommendations history":  historyJSONOutput{},
+	"cost recommendations expiring": []expiringSnoozeJSON{},
+	"cost recommendations sync":     config.DismissalSyncResult{},
+	"cost anomalies":                anomaliesJSONOutput{},
+	"cost variance":                 engine.VarianceReport{},
+	"cost allocate":                 engine.AllocationReport{},
+	"cost top":                      costTopJSON{},
+	"cost commitments":              engine.CommitmentReport{},
+	"cost simulate schedule":        engine.ScheduleSavingsReport{},
+	"cost optimize-region":          engine.RegionOptimizationReport{},
+	"report org":                    engine.OrgReport{},
+	"report chargeback":             engine.ChargebackReport{},
+	"policy check":                  policy.Result{},
+	"budget status":                 []budgetStatusJSON{},
+	"resource show":                 resourceShowJSON{},
+	"baseline list":                 []baselineSummary{},
+	"plugin list":                   []PluginJSONEntry{},
+	"plugin doctor":                 []pluginDiagnosis{},
+}
+
+// newSchemaCmd creates the schema command group.
+func newSchemaCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "schema", Short: "JSON output schemas"}
+	cmd.AddCommand(NewSchemaPrintCmd())
+	return cmd
+}
+
+// NewSchemaPrintCmd creates the "print" subcommand, which prints the JSON
+// Schema of a command's JSON output.
+func NewSchemaPrintCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "print <command>",
+		Short: "Print the JSON Schema of a command's JSON output",
+		Long: `Print the JSON Schema (draft 2020-12) of the --output json document of a
+command in the schema version of --schema-version, for validating the output
+in pipelines.
+
+JSON output carries its schema version in a schema_version field. In v1, the
+default, each command keeps its document layout and documents that are objects
+gain the field. In v2, every document, and every NDJSON line, is wrapped in an
+envelope of schema_version, command, and data, and the results of cost
+projected are no longer nested under "finfocus".
+
+Run without a command to list the commands with a schema.`,
+		Example: `  # Schema of cost projected --output json
+  finfocus schema print cost projected
+
+  # Schema of the v2 envelope of budget status
+  finfocus schema print budget status --schema-version v2
+
+  # List the commands with a schema
+  finfocus schema print`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return executeSchemaPrint(cmd, args)
+		},
+	}
+	return cmd
+}
+
+// executeSchemaPrint prints the schema of the command named by args, or the
+// commands with a schema when args is empty.
+func executeSchemaPrint(cmd *cobra.Command, args []string) error {
+	version, _ := cmd.Flags().GetString("schema-version")
+	if version == "" {
+		version = schemaVersionV1
+	}
+	if err := validateSchemaVersion(version); err != nil {
+		return err
+	}
+
+	if len(args) == 0 {
+		for _, name := range schemaCommands() {
+			cmd.Println(name)
+		}
+		return nil
+	}
+
+	name := strings.Join(args, " ")
+	document, ok := outputDocuments[name]
+	if !ok {
+		return &usageError{err: fmt.Errorf("no JSON output schema for %q: use one of %s",
+			name, strings.Join(schemaCommands(), ", "))}
+	}
+
+	schema := outputSchema(name, version, document)
+	encoder := json.NewEncoder(cmd.OutOrStdout())
+	encoder.SetIndent("", "  ")
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(schema); err != nil {
+		return fmt.Errorf("encoding schema: %w", err)
+	}
+	return nil
+}
+
+// schemaCommands returns the commands with a JSON output schema, sorted.
+func schemaCommands() []string {
+	names := make([]string, 0, len(outputDocuments))
+	for name := range outputDocuments {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// outputSchema returns the JSON Schema of the JSON output of command in the
+// schema version, as schemaWriter lays it out.
+func outputSchema(command, version string, document any) map[string]any {
+	schema := typeSchema(reflect.TypeOf(document), map[reflect.Type]bool{})
+
+	if version == schemaVersionV2 {
+		if properties, ok := schema["properties"].(map[string]any); ok && len(properties) == 1 {
+			if inner, nested := properties[legacyResultsKey].(map[string]any); nested {
+				schema = inner
+			}
+		}
+		schema = map[string]any{
+			"type":     "object",
+			"required": []string{schemaVersionField, "command", "data"},
+			"properties": map[string]any{
+				schemaVersionField: map[string]any{"const": version},
+				"command":          map[string]any{"const": command},
+				"data":             schema,
+			},
+		}
+	} else if properties, ok := schema["properties"].(map[string]any); ok {
+		properties[schemaVersionField] = map[string]any{"const": version}
+		required, _ := schema["required"].([]string)
+		schema["required"] = append([]string{schemaVersionField}, required...)
+	}
+
+	schema["$schema"] = jsonSchemaDialect
+	schema["title"] = fmt.Sprintf("finfocus %s --output json (%s)", command, version)
+	return schema
+}
+
+//nolint:gochecknoglobals // Reflected types compared against in typeSchema.
+var (
+	timeType          = reflect.TypeOf(time.Time{})
+	jsonMarshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// typeSchema returns the JSON Schema of the JSON encoding of t. expanding
+// holds the struct types being expanded, so recursive types end in a plain
+// object schema. Types with their own JSON encoding accept any value.
+func typeSchema(t reflect.Type, expanding map[reflect.Type]bool) map[string]any {
+	switch {
+	case t == timeType:
+		return map[string]any{"type": "string", "format": "date-time"}
+	case t.Implements(jsonMarshalerType) || reflect.PointerTo(t).Implements(jsonMarshalerType):
+		return map[string]any{}
+	case t.Implements(textMarshalerType) || reflect.PointerTo(t).Implements(textMarshalerType):
+		return map[string]any{"type": "string"}
+	}
+
+	//nolint:exhaustive // Kinds without a JSON encoding accept any value.
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(typeSchema(t.Elem(), expanding))
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return nullable(map[string]any{"type": "string", "contentEncoding": "base64"})
+		}
+		return nullable(map[string]any{"type": "array", "items": typeSchema(t.Elem(), expanding)})
+	case reflect.Array:
+		return map[string]any{"type": "array", "items": typeSchema(t.Elem(), expanding)}
+	case reflect.Map:
+		return nullable(map[string]any{"type": "object", "additionalProperties": typeSchema(t.Elem(), expanding)})
+	case reflect.Struct:
+		if expanding[t] {
+			return map[string]any{"type": "object"}
+		}
+		expanding[t] = true
+		defer delete(expanding, t)
+		properties := map[string]any{}
+		var required []string
+		addStructFields(t, expanding, properties, &required)
+		sort.Strings(required)
+		schema := map[string]any{"type": "object", "properties": properties}
+		if len(required) > 0 {
+			schema["required"] = required
+		}
+		return schema
+	default:
+		return map[string]any{}
+	}
+}
+
+// addStructFields adds the JSON fields of struct type t to properties, and
+// those without omitempty to required. Embedded structs without a JSON name
+// contribute their fields, as encoding/json flattens them.
+func addStructFields(t reflect.Type, expanding map[reflect.Type]bool, properties map[string]any, required *[]string) {
+	for i := range t.NumField() {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				addStructFields(embedded, expanding, properties, required)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+		properties[name] = typeSchema(field.Type, expanding)
+		if !strings.Contains(","+options+",", ",omitempty,") {
+			*required = append(*required, name)
+		}
+	}
+}
+
+// nullable returns schema extended to accept null, as nil pointers, slices,
+// and maps encode.
+func nullable(schema map[string]any) map[string]any {
+	if len(schema) == 0 {
+		return schema
+	}
+	if kind, ok := schema["type"].(string); ok {
+		schema["type"] = []string{kind, "null"}
+		return schema
+	}
+	return map[string]any{"anyOf": []any{schema, map[string]any{"type": "null"}}}
+}
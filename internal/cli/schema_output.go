@@ -0,0 +1,205 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// JSON output schema versions selected with --schema-version.
+const (
+	// schemaVersionV1 keeps the layout of each command's JSON document and adds
+	// a schema_version field to documents that are objects.
+	schemaVersionV1 = "v1"
+	// schemaVersionV2 wraps every document in an envelope naming the schema
+	// version and the command, with the document under data.
+	schemaVersionV2 = "v2"
+
+	// schemaVersionField is the field naming the schema version of a document.
+	schemaVersionField = "schema_version"
+	// legacyResultsKey is the key the cost result documents of v1 nest their
+	// results under; v2 drops it.
+	legacyResultsKey = "finfocus"
+)
+
+// schemaWriter rewrites each JSON document written to it in the layout of a
+// schema version and writes it to out. Documents may span several writes, and
+// several documents may follow each other, as in NDJSON output. Rewritten
+// documents keep the layout of their document: indented documents stay
+// indented. Output that is not JSON is passed on unchanged.
+type schemaWriter struct {
+	out         io.Writer
+	version     string
+	command     string
+	pending     []byte
+	passthrough bool
+}
+
+// Write buffers p and writes every complete document in the schema version.
+func (w *schemaWriter) Write(p []byte) (int, error) {
+	if w.passthrough {
+		if _, err := w.out.Write(p); err != nil {
+			return 0, err
+		}
+		return len(p), nil
+	}
+
+	w.pending = append(w.pending, p...)
+	for {
+		doc := bytes.TrimLeft(w.pending, " \t\r\n")
+		if len(doc) == 0 {
+			w.pending = w.pending[:0]
+			return len(p), nil
+		}
+
+		var raw json.RawMessage
+		dec := json.NewDecoder(bytes.NewReader(doc))
+		err := dec.Decode(&raw)
+		if errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF) {
+			return len(p), nil // The document continues in a later write.
+		}
+		if err != nil {
+			w.passthrough = true
+			if _, err = w.out.Write(w.pending); err != nil {
+				return 0, err
+			}
+			w.pending = nil
+			return len(p), nil
+		}
+		indent := bytes.ContainsRune(doc[:dec.InputOffset()], '\n')
+		w.pending = append(w.pending[:0], doc[dec.InputOffset():]...)
+
+		if err = w.writeDocument(raw, indent); err != nil {
+			return 0, err
+		}
+	}
+}
+
+// writeDocument writes one document in the schema version.
+func (w *schemaWriter) writeDocument(raw json.RawMessage, indent bool) error {
+	versioned, err := versionDocument(raw, w.version, w.command)
+	if err != nil {
+		return err
+	}
+	if indent {
+		var buf bytes.Buffer
+		if err = json.Indent(&buf, versioned, "", "  "); err != nil {
+			return fmt.Errorf("indenting JSON output: %w", err)
+		}
+		versioned = buf.Bytes()
+	}
+	if _, err = w.out.Write(append(versioned, '\n')); err != nil {
+		return fmt.Errorf("writing JSON output: %w", err)
+	}
+	return nil
+}
+
+// versionDocument returns a JSON document of command in the layout of the
+// schema version: with a schema_version field in v1, leaving documents that
+// are not objects or that already name a version as they are, or in an
+// envelope in v2, whatever the document.
+func versionDocument(raw json.RawMessage, version, command string) ([]byte, error) {
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, raw); err != nil {
+		return nil, fmt.Errorf("compacting JSON output: %w", err)
+	}
+	var fields map[string]json.RawMessage
+	isObject := json.Unmarshal(compact.Bytes(), &fields) == nil
+	if version == schemaVersionV2 {
+		data := json.RawMessage(compact.Bytes())
+		if inner, ok := fields[legacyResultsKey]; ok && len(fields) == 1 {
+			data = inner
+		}
+		var buf bytes.Buffer
+		encoder := json.NewEncoder(&buf)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(engine.SchemaEnvelope{SchemaVersion: version, Command: command, Data: data}); err != nil {
+			return nil, fmt.Errorf("encoding JSON output: %w", err)
+		}
+		return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+	}
+
+	if _, versioned := fields[schemaVersionField]; versioned || !isObject {
+		return compact.Bytes(), nil
+	}
+	field := fmt.Sprintf("{%q:%q", schemaVersionField, version)
+	if len(fields) == 0 {
+		return []byte(field + "}"), nil
+	}
+	return append([]byte(field+","), compact.Bytes()[1:]...), nil
+}
+
+// validateSchemaVersion checks the value of --schema-version.
+func validateSchemaVersion(version string) error {
+	if version != schemaVersionV1 && version != schemaVersionV2 {
+		return fmt.Errorf("invalid --schema-version %q: use %s or %s", version, schemaVersionV1, schemaVersionV2)
+	}
+	return nil
+}
+
+// schemaCommandName returns the name of cmd below the root command, such as
+// "cost projected", which v2 documents and 'schema print' use.
+func schemaCommandName(cmd *cobra.Command) string {
+	return strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+}
+
+// writesJSON reports whether cmd writes JSON output: its --output is json or
+// ndjson, or its --json flag is set.
+func writesJSON(cmd *cobra.Command) bool {
+	if flag := cmd.Flags().Lookup("output"); flag != nil {
+		format := config.GetOutputFormat(flag.Value.String())
+		return format == outputFormatJSON || format == outputFormatNDJSON
+	}
+	if flag := cmd.Flags().Lookup("json"); flag != nil {
+		return flag.Value.String() == "true"
+	}
+	return false
+}
+
+// setupSchemaOutput validates --schema-version and routes the output of the
+// command tree through a schemaWriter when cmd writes JSON. It is a no-op for
+// other output and when the output is already routed, as command groups run
+// the root setup on their subcommands' behalf.
+func setupSchemaOutput(cmd *cobra.Command) error {
+	version, _ := cmd.Flags().GetString("schema-version")
+	if version == "" {
+		return nil
+	}
+	if err := validateSchemaVersion(version); err != nil {
+		return err
+	}
+	if !writesJSON(cmd) {
+		return nil
+	}
+	root := cmd.Root()
+	if _, routed := root.OutOrStdout().(*schemaWriter); routed {
+		return nil
+	}
+	root.SetOut(&schemaWriter{out: root.OutOrStdout(), version: version, command: schemaCommandName(cmd)})
+	return nil
+}
+
+// finishSchemaOutput restores the output of the command tree, passing on any
+// output left that is not a complete JSON document.
+func finishSchemaOutput(cmd *cobra.Command) error {
+	root := cmd.Root()
+	w, routed := root.OutOrStdout().(*schemaWriter)
+	if !routed {
+		return nil
+	}
+	root.SetOut(w.out)
+	if len(bytes.TrimSpace(w.pending)) > 0 {
+		if _, err := w.out.Write(w.pending); err != nil {
+			return fmt.Errorf("writing JSON output: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVersionDocument(t *testing.T) {
+	tests := []struct {
+		name, version, doc, want string
+	}{
+		{"v1 object", schemaVersionV1, `{"b": 1, "a": "<x>"}`, `{"schema_version":"v1","b":1,"a":"<x>"}`},
+		{"v1 empty object", schemaVersionV1, `{}`, `{"schema_version":"v1"}`},
+		{"v1 array unchanged", schemaVersionV1, `[1, 2]`, `[1,2]`},
+		{"v1 already versioned", schemaVersionV1, `{"schema_version":"1.0","a":1}`, `{"schema_version":"1.0","a":1}`},
+		{"v2 wraps versioned", schemaVersionV2, `{"schema_version":"1.0","a":1}`,
+			`{"schema_version":"v2","command":"budget status","data":{"schema_version":"1.0","a":1}}`},
+		{"v2 envelope", schemaVersionV2, `[{"scope":"global"}]`,
+			`{"schema_version":"v2","command":"budget status","data":[{"scope":"global"}]}`},
+		{"v2 drops finfocus", schemaVersionV2, `{"finfocus":{"resources":[]}}`,
+			`{"schema_version":"v2","command":"budget status","data":{"resources":[]}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := versionDocument(json.RawMessage(tt.doc), tt.version, "budget status")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, string(got))
+		})
+	}
+}
+
+func TestSchemaWriter(t *testing.T) {
+	t.Run("indented document split across writes", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &schemaWriter{out: &out, version: schemaVersionV1, command: "cost top"}
+		doc := "{\n  \"total\": 12.5,\n  \"items\": []\n}\n"
+		for _, part := range []string{doc[:7], doc[7:]} {
+			n, err := w.Write([]byte(part))
+			require.NoError(t, err)
+			assert.Len(t, part, n)
+		}
+		assert.Equal(t, "{\n  \"schema_version\": \"v1\",\n  \"total\": 12.5,\n  \"items\": []\n}\n", out.String())
+	})
+
+	t.Run("one envelope per NDJSON line", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &schemaWriter{out: &out, version: schemaVersionV2, command: "cost actual"}
+		_, err := fmt.Fprint(w, "{\"id\":\"a\"}\n{\"id\":\"b\"}\n")
+		require.NoError(t, err)
+		assert.Equal(t, `{"schema_version":"v2","command":"cost actual","data":{"id":"a"}}`+"\n"+
+			`{"schema_version":"v2","command":"cost actual","data":{"id":"b"}}`+"\n", out.String())
+	})
+
+	t.Run("other output is passed on", func(t *testing.T) {
+		var out bytes.Buffer
+		w := &schemaWriter{out: &out, version: schemaVersionV2}
+		_, err := fmt.Fprint(w, "No plugins found.\n")
+		require.NoError(t, err)
+		_, err = fmt.Fprint(w, "{}\n")
+		require.NoError(t, err)
+		assert.Equal(t, "No plugins found.\n{}\n", out.String())
+	})
+}
+
+func TestRootCmd_SchemaVersionFlag(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("FINFOCUS_HOME", filepath.Join(home, ".finfocus"))
+	t.Setenv("FINFOCUS_LOG_LEVEL", "error")
+	t.Setenv("FINFOCUS_SKIP_MIGRATION_CHECK", "1")
+
+	run := func(args ...string) (string, error) {
+		root := NewRootCmd("test")
+		var out bytes.Buffer
+		root.SetOut(&out)
+		root.SetErr(&bytes.Buffer{})
+		root.SetArgs(args)
+		execErr := root.Execute()
+		return out.String(), execErr
+	}
+
+	out, err := run("cost", "simulate", "schedule", "--cron", "0 19 * * 1-5", "--output", "json")
+	require.NoError(t, err)
+	var v1 map[string]interface{}
+	require.NoError(t, json.Unmarshal([]byte(out), &v1))
+	assert.Equal(t, "v1", v1["schema_version"])
+	assert.Equal(t, "0 19 * * 1-5", v1["stop"])
+
+	out, err = run("cost", "simulate", "schedule", "--cron", "0 19 * * 1-5", "--output", "json",
+		"--schema-version", "v2", "--query", "[schema_version, command, data.stop]")
+	require.NoError(t, err)
+	assert.JSONEq(t, `["v2", "cost simulate schedule", "0 19 * * 1-5"]`, out)
+
+	out, err = run("baseline", "list", "--output", "json", "--schema-version", "v2")
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"schema_version": "v2", "command": "baseline list", "data": []}`, out)
+
+	out, err = run("cost", "simulate", "schedule", "--cron", "0 19 * * 1-5")
+	require.NoError(t, err)
+	assert.NotContains(t, out, "schema_version", "table output is left alone")
+
+	_, err = run("baseline", "list", "--schema-version", "v3")
+	require.ErrorContains(t, err, `invalid --schema-version "v3": use v1 or v2`)
+}
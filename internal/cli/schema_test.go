@@ -0,0 +1,94 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type schemaTestItem struct {
+	Name string            `json:"name"`
+	Cost float64           `json:"cost,omitempty"`
+	Tags map[string]string `json:"tags,omitempty"`
+	At   time.Time         `json:"at"`
+	Next *schemaTestItem   `json:"next,omitempty"`
+	Skip string            `json:"-"`
+}
+
+type schemaTestDocument struct {
+	schemaTestItem
+	Items []schemaTestItem `json:"items"`
+}
+
+func TestTypeSchema(t *testing.T) {
+	schema := typeSchema(reflect.TypeOf(schemaTestDocument{}), map[reflect.Type]bool{})
+
+	properties, ok := schema["properties"].(map[string]any)
+	require.True(t, ok)
+	assert.ElementsMatch(t, []string{"name", "cost", "tags", "at", "next", "items"}, keys(properties),
+		"embedded fields are flattened and ignored fields left out")
+	assert.Equal(t, []string{"at", "items", "name"}, schema["required"], "omitempty fields are optional")
+	assert.Equal(t, map[string]any{"type": "string", "format": "date-time"}, properties["at"])
+	assert.Equal(t, []string{"object", "null"}, properties["tags"].(map[string]any)["type"])
+
+	items := properties["items"].(map[string]any)
+	assert.Equal(t, []string{"array", "null"}, items["type"])
+	next := items["items"].(map[string]any)["properties"].(map[string]any)["next"].(map[string]any)
+	assert.Equal(t, []string{"object", "null"}, next["type"], "recursion ends in a plain object")
+}
+
+func TestExecuteSchemaPrint(t *testing.T) {
+	printSchema := func(version string, args ...string) map[string]any {
+		t.Helper()
+		cmd := NewSchemaPrintCmd()
+		cmd.Flags().String("schema-version", version, "")
+		var out bytes.Buffer
+		cmd.SetOut(&out)
+		require.NoError(t, executeSchemaPrint(cmd, args))
+		var schema map[string]any
+		require.NoError(t, json.Unmarshal(out.Bytes(), &schema))
+		return schema
+	}
+
+	v1 := printSchema("v1", "cost", "projected")
+	assert.Equal(t, jsonSchemaDialect, v1["$schema"])
+	assert.Equal(t, "finfocus cost projected --output json (v1)", v1["title"])
+	v1Properties := v1["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"const": "v1"}, v1Properties["schema_version"])
+	assert.Contains(t, v1Properties, "finfocus")
+	assert.Equal(t, []any{"schema_version", "finfocus"}, v1["required"])
+
+	v2 := printSchema("v2", "cost", "projected")
+	v2Properties := v2["properties"].(map[string]any)
+	assert.Equal(t, map[string]any{"const": "cost projected"}, v2Properties["command"])
+	data := v2Properties["data"].(map[string]any)
+	assert.Contains(t, data["properties"], "resources", "v2 data drops the finfocus key")
+
+	budgets := printSchema("v1", "budget", "status")
+	assert.NotContains(t, budgets, "properties", "arrays gain no field in v1")
+
+	cmd := NewSchemaPrintCmd()
+	cmd.Flags().String("schema-version", "v1", "")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+	require.NoError(t, executeSchemaPrint(cmd, nil))
+	assert.Contains(t, out.String(), "cost projected\n")
+
+	err := executeSchemaPrint(cmd, []string{"cost", "unknown"})
+	var usageErr *usageError
+	require.ErrorAs(t, err, &usageErr)
+	assert.Contains(t, err.Error(), `no JSON output schema for "cost unknown"`)
+}
+
+func keys(m map[string]any) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// HTTP server settings shared by the serve subcommands.
+const (
+	// serveReadHeaderTimeout bounds how long a client may take to send headers.
+	serveReadHeaderTimeout = 10 * time.Second
+	// serveShutdownTimeout bounds how long in-flight requests may finish on shutdown.
+	serveShutdownTimeout = 5 * time.Second
+)
+
+// newServeCmd creates the serve command group for long-running servers.
+func newServeCmd() *cobra.Command {
+	cmd := &cobra.Command{Use: "serve", Short: "Long-running servers"}
+	cmd.AddCommand(NewServeMetricsCmd(), NewServeAPICmd(), NewServeGRPCCmd())
+	return cmd
+}
+
+// listenTCP opens the TCP listener for a serve subcommand.
+func listenTCP(ctx context.Context, addr string) (net.Listener, error) {
+	listener, err := (&net.ListenConfig{}).Listen(ctx, "tcp", addr)
+	if err != nil {
+		return nil, fmt.Errorf("listening on %s: %w", addr, err)
+	}
+	return listener, nil
+}
+
+// serveHTTP serves handler on listener until ctx is done, then shuts the
+// server down gracefully. Requests inherit the values of ctx, such as the
+// logger, but not its cancellation, so in-flight requests can finish. It
+// returns early if the server fails.
+func serveHTTP(ctx context.Context, listener net.Listener, handler http.Handler) error {
+	server := &http.Server{
+		Handler:           handler,
+		ReadHeaderTimeout: serveReadHeaderTimeout,
+		BaseContext:       func(net.Listener) context.Context { return context.WithoutCancel(ctx) },
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	select {
+	case err := <-serveErr:
+		if errors.Is(err, http.ErrServerClosed) {
+			return nil
+		}
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), serveShutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(shutdownCtx); err != nil {
+			return fmt.Errorf("stopping server: %w", err)
+		}
+		return nil
+	}
+}
+
+// writeHealthz answers liveness probes.
+func writeHealthz(w http.ResponseWriter, _ *http.Request) {
+	_, _ = w.Write([]byte("ok\n"))
+}
@@ -0,0 +1,437 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// API server settings.
+const (
+	defaultAPIListen = ":8080"
+	// apiMaxBodyBytes caps request bodies, which carry resource descriptors.
+	apiMaxBodyBytes = 32 << 20
+)
+
+// errNoSingleCurrency is returned by the budgets endpoint when the priced
+// resources mix currencies, which budgets cannot be evaluated against.
+var errNoSingleCurrency = errors.New("budgets need results in a single currency")
+
+// serveAPIParams holds the parameters for the serve api command execution.
+type serveAPIParams struct {
+	listen  string
+	adapter string
+}
+
+// apiEngine is the part of the engine the API server calls.
+type apiEngine interface {
+	projectedCostEngine
+	GetActualCostWithOptionsAndErrors(
+		ctx context.Context,
+		request engine.ActualCostRequest,
+	) (*engine.CostResultWithErrors, error)
+}
+
+// apiResourceRequest selects the resources an API call works on: the given
+// descriptors, or the deployed state of stack when none are given.
+type apiResourceRequest struct {
+	Resources []engine.ResourceDescriptor `json:"resources,omitempty"`
+	Stack     string                      `json:"stack,omitempty"`
+	Filter    []string                    `json:"filter,omitempty"`
+}
+
+// apiActualRequest is the body of POST /v1/cost/actual.
+type apiActualRequest struct {
+	apiResourceRequest
+
+	From    string `json:"from"`
+	To      string `json:"to,omitempty"`
+	GroupBy string `json:"groupBy,omitempty"`
+}
+
+// apiCostResponse is the body returned by the cost endpoints.
+type apiCostResponse struct {
+	Results  []engine.CostResult `json:"results"`
+	Total    float64             `json:"total"`
+	Currency string              `json:"currency,omitempty"`
+	Errors   []apiResourceError  `json:"errors,omitempty"`
+
+	// tags maps the priced resources to their tags for tag budgets.
+	tags map[string]map[string]string
+}
+
+// apiResourceError reports a plugin failure for one resource.
+type apiResourceError struct {
+	ResourceID   string `json:"resourceId"`
+	ResourceType string `json:"resourceType"`
+	Plugin       string `json:"plugin"`
+	Error        string `json:"error"`
+}
+
+// apiBudgetsResponse is the body returned by POST /v1/budgets.
+type apiBudgetsResponse struct {
+	TotalMonthly float64     `json:"totalMonthly"`
+	Currency     string      `json:"currency"`
+	Budgets      []apiBudget `json:"budgets"`
+	// Unallocated is the spend matching no tag budget, set when tag budgets are configured.
+	Unallocated *engine.UnallocatedSpend `json:"unallocated,omitempty"`
+}
+
+// apiBudget is the status of one budget scope.
+type apiBudget struct {
+	Scope      string  `json:"scope"`
+	Currency   string  `json:"currency"`
+	Amount     float64 `json:"amount"`
+	Spend      float64 `json:"spend"`
+	Percentage float64 `json:"percentage"`
+	Health     string  `json:"health"`
+}
+
+// apiErrorResponse is the body of every non-2xx response.
+type apiErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// apiRequestError marks errors caused by the request rather than the server.
+type apiRequestError struct {
+	err error
+}
+
+func (e *apiRequestError) Error() string { return e.err.Error() }
+
+func (e *apiRequestError) Unwrap() error { return e.err }
+
+// NewServeAPICmd creates the "api" subcommand, which serves the cost engine
+// over a small JSON API.
+func NewServeAPICmd() *cobra.Command {
+	var params serveAPIParams
+
+	cmd := &cobra.Command{
+		Use:   "api",
+		Short: "Serve projected costs, actual costs, recommendations, and budgets over HTTP",
+		Long: `Serve the cost engine over a JSON API so dashboards and internal tools can
+query FinFocus without running the CLI for every request.
+
+Plugins are started once and reused by every request, and plugin responses go
+through the same response cache as the CLI.
+
+Endpoints (all take and return JSON):
+  POST /v1/cost/projected   Projected monthly costs
+  POST /v1/cost/actual      Actual costs; also takes from, to, and groupBy
+  POST /v1/recommendations  Cost optimization recommendations
+  POST /v1/budgets          Budget status for the projected costs
+  GET  /healthz             Liveness probe
+
+Each POST body selects resources with "resources" (a list of {type, id,
+provider, properties}) or "stack" (a Pulumi stack whose deployed state is
+read), plus optional "filter" expressions. When both are omitted, the server's
+--stack or the Pulumi stack in its working directory is used.`,
+		Example: `  # Serve on :8080
+  finfocus serve api
+
+  # Price a Pulumi Cloud stack
+  curl -s localhost:8080/v1/cost/projected -d '{"stack": "acme/web/prod"}'
+
+  # Actual costs for one resource type since the start of the month
+  curl -s localhost:8080/v1/cost/actual \
+    -d '{"stack": "prod", "filter": ["type=aws:ec2/instance"], "from": "2025-01-01"}'`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeServeAPI(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.listen, "listen", defaultAPIListen, "Address to serve the API on")
+	cmd.Flags().String("stack", "", "Pulumi stack used when a request names no resources or stack")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+
+	return cmd
+}
+
+// executeServeAPI serves the API until the command is interrupted.
+func executeServeAPI(cmd *cobra.Command, params serveAPIParams) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	audit := newAuditContext(ctx, "serve api", map[string]string{"listen": params.listen})
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	listener, err := listenTCP(ctx, params.listen)
+	if err != nil {
+		return err
+	}
+
+	server := &apiServer{cmd: cmd, eng: eng, defaultStack: getStackFlag(cmd), resolve: resolveResourcesFromPulumi}
+	cmd.PrintErrf("Serving API on http://%s\n", listener.Addr())
+	if err = serveHTTP(ctx, listener, server.routes()); err != nil {
+		return fmt.Errorf("serving API: %w", err)
+	}
+	cmd.PrintErrln("API server stopped")
+	return nil
+}
+
+// apiServer answers API requests with a shared engine.
+type apiServer struct {
+	cmd          *cobra.Command
+	eng          apiEngine
+	defaultStack string
+	resolve      func(ctx context.Context, stack string, mode pulumiMode) ([]engine.ResourceDescriptor, error)
+
+	// budgetMu serializes budget evaluation, which redirects the command output.
+	budgetMu sync.Mutex
+}
+
+// routes returns the API handler.
+func (s *apiServer) routes() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /v1/cost/projected", s.handleProjected)
+	mux.HandleFunc("POST /v1/cost/actual", s.handleActual)
+	mux.HandleFunc("POST /v1/recommendations", s.handleRecommendations)
+	mux.HandleFunc("POST /v1/budgets", s.handleBudgets)
+	mux.HandleFunc("GET /healthz", writeHealthz)
+	return mux
+}
+
+func (s *apiServer) handleProjected(w http.ResponseWriter, r *http.Request) {
+	resp, err := s.projected(w, r)
+	s.respond(w, r, "projected_cost", resp, err)
+}
+
+func (s *apiServer) handleActual(w http.ResponseWriter, r *http.Request) {
+	var req apiActualRequest
+	if err := decodeAPIRequest(w, r, &req); err != nil {
+		s.respond(w, r, "actual_cost", nil, err)
+		return
+	}
+	resp, err := s.actual(r.Context(), req)
+	s.respond(w, r, "actual_cost", resp, err)
+}
+
+func (s *apiServer) handleRecommendations(w http.ResponseWriter, r *http.Request) {
+	var req apiResourceRequest
+	if err := decodeAPIRequest(w, r, &req); err != nil {
+		s.respond(w, r, "recommendations", nil, err)
+		return
+	}
+	resources, err := s.resources(r.Context(), req)
+	if err != nil {
+		s.respond(w, r, "recommendations", nil, err)
+		return
+	}
+	resp, err := s.eng.GetRecommendationsForResources(r.Context(), resources)
+	if err != nil {
+		err = fmt.Errorf("fetching recommendations: %w", err)
+	}
+	s.respond(w, r, "recommendations", resp, err)
+}
+
+func (s *apiServer) handleBudgets(w http.ResponseWriter, r *http.Request) {
+	projected, err := s.projected(w, r)
+	if err != nil {
+		s.respond(w, r, "budgets", nil, err)
+		return
+	}
+	resp, err := s.budgets(projected)
+	s.respond(w, r, "budgets", resp, err)
+}
+
+// projected decodes the resource request from r and prices its resources.
+func (s *apiServer) projected(w http.ResponseWriter, r *http.Request) (*apiCostResponse, error) {
+	var req apiResourceRequest
+	if err := decodeAPIRequest(w, r, &req); err != nil {
+		return nil, err
+	}
+	resources, err := s.resources(r.Context(), req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.eng.StreamProjectedCostWithErrors(r.Context(), resources, nil)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	fetchAndMergeRecommendations(r.Context(), s.eng, resources, result.Results)
+
+	resp := newAPICostResponse(result)
+	resp.tags = resourceTagIndex(resources)
+	for _, c := range result.Results {
+		resp.Total += c.Monthly
+	}
+	return resp, nil
+}
+
+// actual fetches the actual costs requested by req.
+func (s *apiServer) actual(ctx context.Context, req apiActualRequest) (*apiCostResponse, error) {
+	if req.From == "" {
+		return nil, &apiRequestError{errors.New("from is required")}
+	}
+	to := req.To
+	if to == "" {
+		to = time.Now().UTC().Format(time.RFC3339)
+	}
+	from, toTime, err := ParseTimeRange(req.From, to)
+	if err != nil {
+		return nil, &apiRequestError{err}
+	}
+	if req.GroupBy != "" && !engine.GroupBy(req.GroupBy).IsValid() {
+		return nil, &apiRequestError{fmt.Errorf("invalid groupBy %q", req.GroupBy)}
+	}
+
+	resources, err := s.resources(ctx, req.apiResourceRequest)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.eng.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+		Resources: resources,
+		From:      from,
+		To:        toTime,
+		GroupBy:   req.GroupBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching actual costs: %w", err)
+	}
+
+	resp := newAPICostResponse(result)
+	for _, c := range result.Results {
+		resp.Total += c.TotalCost
+	}
+	return resp, nil
+}
+
+// budgets evaluates the configured budgets against projected costs.
+func (s *apiServer) budgets(projected *apiCostResponse) (*apiBudgetsResponse, error) {
+	if _, mixed := extractCurrencyFromResults(projected.Results); mixed {
+		return nil, &apiRequestError{errNoSingleCurrency}
+	}
+
+	s.budgetMu.Lock()
+	budgets, err := evaluateBudgetsQuietly(s.cmd, projected.Results, projected.tags, projected.Total, projected.Currency)
+	s.budgetMu.Unlock()
+	if err != nil {
+		return nil, fmt.Errorf("evaluating budgets: %w", err)
+	}
+
+	resp := &apiBudgetsResponse{
+		TotalMonthly: projected.Total,
+		Currency:     projected.Currency,
+		Budgets:      []apiBudget{},
+	}
+	for _, b := range budgetScopes(budgets) {
+		resp.Budgets = append(resp.Budgets, apiBudget{
+			Scope:      b.ScopeIdentifier(),
+			Currency:   b.Currency,
+			Amount:     b.Budget.Amount,
+			Spend:      b.CurrentSpend,
+			Percentage: b.Percentage,
+			Health:     strings.ToLower(healthStatusLabel(b.Health)),
+		})
+	}
+	if budgets != nil && budgets.ScopedResult != nil {
+		resp.Unallocated = budgets.ScopedResult.Unallocated
+	}
+	return resp, nil
+}
+
+// resources resolves and filters the resources selected by req.
+func (s *apiServer) resources(ctx context.Context, req apiResourceRequest) ([]engine.ResourceDescriptor, error) {
+	resources := req.Resources
+	if len(resources) > 0 {
+		for i, r := range resources {
+			if err := r.Validate(); err != nil {
+				return nil, &apiRequestError{fmt.Errorf("invalid resource at index %d: %w", i, err)}
+			}
+		}
+	} else {
+		stack := req.Stack
+		if stack == "" {
+			stack = s.defaultStack
+		}
+		var err error
+		if resources, err = s.resolve(ctx, stack, modePulumiExport); err != nil {
+			return nil, err
+		}
+	}
+
+	resources, err := ApplyFilters(ctx, resources, req.Filter)
+	if err != nil {
+		return nil, &apiRequestError{fmt.Errorf("applying filters: %w", err)}
+	}
+	return resources, nil
+}
+
+// respond writes body as JSON, or err as an error response. Request errors
+// are answered with 400 and everything else with 500.
+func (s *apiServer) respond(w http.ResponseWriter, r *http.Request, operation string, body any, err error) {
+	ctx := r.Context()
+	log := logging.FromContext(ctx)
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusInternalServerError
+		var reqErr *apiRequestError
+		if errors.As(err, &reqErr) {
+			status = http.StatusBadRequest
+		}
+		body = apiErrorResponse{Error: err.Error()}
+		log.Warn().Ctx(ctx).Str("component", "cli").Str("operation", operation).Int("status", status).
+			Err(err).Msg("API request failed")
+	} else {
+		log.Debug().Ctx(ctx).Str("component", "cli").Str("operation", operation).Msg("API request served")
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if encErr := json.NewEncoder(w).Encode(body); encErr != nil {
+		log.Debug().Ctx(ctx).Str("component", "cli").Err(encErr).Msg("writing API response failed")
+	}
+}
+
+// decodeAPIRequest decodes the JSON request body into v. An empty body
+// leaves v unchanged.
+func decodeAPIRequest(w http.ResponseWriter, r *http.Request, v any) error {
+	dec := json.NewDecoder(http.MaxBytesReader(w, r.Body, apiMaxBodyBytes))
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return &apiRequestError{fmt.Errorf("invalid request body: %w", err)}
+	}
+	return nil
+}
+
+// newAPICostResponse converts engine results and errors into a response.
+func newAPICostResponse(result *engine.CostResultWithErrors) *apiCostResponse {
+	resp := &apiCostResponse{Results: result.Results}
+	if resp.Results == nil {
+		resp.Results = []engine.CostResult{}
+	}
+	resp.Currency, _ = extractCurrencyFromResults(result.Results)
+	for _, e := range result.Errors {
+		resp.Errors = append(resp.Errors, apiResourceError{
+			ResourceID:   e.ResourceID,
+			ResourceType: e.ResourceType,
+			Plugin:       e.PluginName,
+			Error:        e.Error.Error(),
+		})
+	}
+	return resp
+}
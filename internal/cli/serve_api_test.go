@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// apiTestEngine prices every resource at 10 USD a month and records the
+// resources and actual cost requests it receives.
+type apiTestEngine struct {
+	mockRecommendationFetcher
+
+	priced []engine.ResourceDescriptor
+	actual engine.ActualCostRequest
+}
+
+func (e *apiTestEngine) StreamProjectedCostWithErrors(
+	_ context.Context,
+	resources []engine.ResourceDescriptor,
+	_ engine.ProjectedResultFunc,
+) (*engine.CostResultWithErrors, error) {
+	e.priced = resources
+	result := &engine.CostResultWithErrors{}
+	for _, r := range resources {
+		result.Results = append(result.Results,
+			engine.CostResult{ResourceID: r.ID, ResourceType: r.Type, Currency: "USD", Monthly: 10})
+	}
+	return result, nil
+}
+
+func (e *apiTestEngine) GetActualCostWithOptionsAndErrors(
+	_ context.Context,
+	request engine.ActualCostRequest,
+) (*engine.CostResultWithErrors, error) {
+	e.actual = request
+	return &engine.CostResultWithErrors{
+		Results: []engine.CostResult{{ResourceID: "web", Currency: "USD", TotalCost: 4.5}},
+		Errors: []engine.ErrorDetail{
+			{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", PluginName: "aws", Error: errors.New("boom")},
+		},
+	}, nil
+}
+
+func newAPITestServer(eng *apiTestEngine) *apiServer {
+	return &apiServer{
+		cmd: &cobra.Command{},
+		eng: eng,
+		resolve: func(_ context.Context, stack string, _ pulumiMode) ([]engine.ResourceDescriptor, error) {
+			if stack == "missing" {
+				return nil, errors.New("stack not found")
+			}
+			return []engine.ResourceDescriptor{
+				{ID: stack + "-web", Type: "aws:ec2/instance:Instance", Provider: "aws"},
+				{ID: stack + "-bucket", Type: "aws:s3/bucket:Bucket", Provider: "aws"},
+			}, nil
+		},
+		defaultStack: "dev",
+	}
+}
+
+func apiCall(t *testing.T, s *apiServer, method, path, body string, out any) int {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	s.routes().ServeHTTP(rec, httptest.NewRequest(method, path, strings.NewReader(body)))
+	if out != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out), rec.Body.String())
+	}
+	return rec.Code
+}
+
+func TestAPIServer_Projected(t *testing.T) {
+	eng := &apiTestEngine{mockRecommendationFetcher: mockRecommendationFetcher{result: &engine.RecommendationsResult{
+		Recommendations: []engine.Recommendation{{ResourceID: "prod-web", Type: "RIGHTSIZE"}},
+	}}}
+	s := newAPITestServer(eng)
+
+	var resp apiCostResponse
+	code := apiCall(t, s, http.MethodPost, "/v1/cost/projected",
+		`{"stack": "prod", "filter": ["type=aws:ec2/instance"]}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, "prod-web", resp.Results[0].ResourceID)
+	assert.Len(t, resp.Results[0].Recommendations, 1)
+	assert.InDelta(t, 10.0, resp.Total, 0.001)
+	assert.Equal(t, "USD", resp.Currency)
+
+	code = apiCall(t, s, http.MethodPost, "/v1/cost/projected", "", &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, eng.priced, 2, "an empty body prices the default stack")
+	assert.Equal(t, "dev-web", eng.priced[0].ID)
+
+	code = apiCall(t, s, http.MethodPost, "/v1/cost/projected",
+		`{"resources": [{"id": "x", "type": "aws:ec2/instance:Instance", "provider": "aws"}]}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "x", eng.priced[0].ID)
+}
+
+func TestAPIServer_Errors(t *testing.T) {
+	s := newAPITestServer(&apiTestEngine{})
+
+	tests := []struct {
+		name, method, path, body string
+		wantCode                 int
+		wantError                string
+	}{
+		{"unknown field", http.MethodPost, "/v1/cost/projected", `{"stacks": "prod"}`,
+			http.StatusBadRequest, "invalid request body"},
+		{"invalid resource", http.MethodPost, "/v1/cost/projected", `{"resources": [{"id": "x"}]}`,
+			http.StatusBadRequest, "invalid resource at index 0"},
+		{"bad filter", http.MethodPost, "/v1/recommendations", `{"filter": ["nonsense"]}`,
+			http.StatusBadRequest, "applying filters"},
+		{"missing from", http.MethodPost, "/v1/cost/actual", `{}`, http.StatusBadRequest, "from is required"},
+		{"bad groupBy", http.MethodPost, "/v1/cost/actual", `{"from": "2025-01-01", "to": "2025-01-31", "groupBy": "hourly"}`,
+			http.StatusBadRequest, "invalid groupBy"},
+		{"resolve failure", http.MethodPost, "/v1/budgets", `{"stack": "missing"}`,
+			http.StatusInternalServerError, "stack not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var resp apiErrorResponse
+			assert.Equal(t, tt.wantCode, apiCall(t, s, tt.method, tt.path, tt.body, &resp))
+			assert.Contains(t, resp.Error, tt.wantError)
+		})
+	}
+
+	assert.Equal(t, http.StatusMethodNotAllowed, apiCall(t, s, http.MethodGet, "/v1/cost/projected", "", nil))
+	assert.Equal(t, http.StatusOK, apiCall(t, s, http.MethodGet, "/healthz", "", nil))
+}
+
+func TestAPIServer_Actual(t *testing.T) {
+	eng := &apiTestEngine{}
+	s := newAPITestServer(eng)
+
+	var resp apiCostResponse
+	code := apiCall(t, s, http.MethodPost, "/v1/cost/actual",
+		`{"stack": "prod", "from": "2025-01-01", "to": "2025-01-31", "groupBy": "daily"}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 4.5, resp.Total, 0.001)
+	require.Len(t, resp.Errors, 1)
+	assert.Equal(t, apiResourceError{
+		ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Plugin: "aws", Error: "boom",
+	}, resp.Errors[0])
+
+	assert.Equal(t, "daily", eng.actual.GroupBy)
+	assert.Len(t, eng.actual.Resources, 2)
+	assert.Equal(t, "2025-01-01", eng.actual.From.Format("2006-01-02"))
+	assert.Equal(t, "2025-01-31", eng.actual.To.Format("2006-01-02"))
+}
+
+func TestAPIServer_Budgets(t *testing.T) {
+	prev := config.GetGlobalConfig()
+	t.Cleanup(func() { config.SetGlobalConfig(prev) })
+	config.SetGlobalConfig(&config.Config{Cost: config.CostConfig{Budgets: &config.BudgetsConfig{
+		Global: &config.ScopedBudget{Amount: 25, Currency: "USD"},
+	}}})
+
+	s := newAPITestServer(&apiTestEngine{})
+	var resp apiBudgetsResponse
+	code := apiCall(t, s, http.MethodPost, "/v1/budgets", `{"stack": "prod"}`, &resp)
+	require.Equal(t, http.StatusOK, code)
+	assert.InDelta(t, 20.0, resp.TotalMonthly, 0.001)
+	require.Len(t, resp.Budgets, 1)
+	assert.Equal(t, "global", resp.Budgets[0].Scope)
+	assert.InDelta(t, 25.0, resp.Budgets[0].Amount, 0.001)
+	assert.InDelta(t, 80.0, resp.Budgets[0].Percentage, 0.001)
+	assert.NotEmpty(t, resp.Budgets[0].Health)
+}
@@ -0,0 +1,111 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/gateway"
+	"github.com/rshade/finfocus/pkg/version"
+)
+
+// defaultGRPCListen is the address the gateway serves on by default.
+const defaultGRPCListen = ":50051"
+
+// errNoGatewayPlugins is returned when no plugin has a connection to proxy,
+// for example when every plugin replays recorded responses.
+var errNoGatewayPlugins = errors.New("no running plugins to serve")
+
+// serveGRPCParams holds the parameters for the serve grpc command execution.
+type serveGRPCParams struct {
+	listen  string
+	adapter string
+}
+
+// NewServeGRPCCmd creates the "grpc" subcommand, which re-exposes the
+// installed plugins as a single CostSourceService.
+func NewServeGRPCCmd() *cobra.Command {
+	var params serveGRPCParams
+
+	cmd := &cobra.Command{
+		Use:   "grpc",
+		Short: "Serve the installed plugins as one CostSourceService gateway",
+		Long: `Serve the finfocus-spec CostSourceService over gRPC in front of every
+installed plugin, so tools that already speak the plugin protocol can use
+FinFocus as a single cost source.
+
+Single-resource calls (GetProjectedCost, GetPricingSpec, EstimateCost, DryRun,
+Supports) are routed by the routing configuration, in priority order, falling
+back to the next plugin when one fails. Without routing configuration every
+plugin is tried in order. GetActualCost asks each plugin until one reports
+costs. GetRecommendations and GetBudgets merge the responses of all plugins,
+and DismissRecommendation is forwarded until a plugin accepts it.
+
+Plugins are started once and kept running. Requests and responses are
+forwarded unchanged; the plugin response cache is not used.`,
+		Example: `  # Serve on :50051
+  finfocus serve grpc
+
+  # Serve one plugin on a local address
+  finfocus serve grpc --adapter aws-public --listen 127.0.0.1:9000`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeServeGRPC(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.listen, "listen", defaultGRPCListen, "Address to serve the gateway on")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+
+	return cmd
+}
+
+// executeServeGRPC serves the gateway until the command is interrupted.
+func executeServeGRPC(cmd *cobra.Command, params serveGRPCParams) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	audit := newAuditContext(ctx, "serve grpc", map[string]string{"listen": params.listen})
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	backends := gateway.BackendsFromClients(clients)
+	if len(backends) == 0 {
+		return errNoGatewayPlugins
+	}
+	cfg := config.New()
+	srv := gateway.NewServer(backends, createRouterForEngine(ctx, cfg, clients), version.GetVersion())
+
+	listener, err := listenTCP(ctx, params.listen)
+	if err != nil {
+		return err
+	}
+
+	grpcServer := grpc.NewServer()
+	pbc.RegisterCostSourceServiceServer(grpcServer, srv)
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- grpcServer.Serve(listener)
+	}()
+	cmd.PrintErrf("Serving CostSourceService gateway for %d plugin(s) on %s\n", len(backends), listener.Addr())
+
+	select {
+	case err = <-serveErr:
+		if err != nil {
+			return fmt.Errorf("serving gRPC: %w", err)
+		}
+	case <-ctx.Done():
+		grpcServer.GracefulStop()
+	}
+	cmd.PrintErrln("gRPC gateway stopped")
+	return nil
+}
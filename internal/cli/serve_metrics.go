@@ -0,0 +1,282 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/metrics"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// Metrics server settings.
+const (
+	defaultMetricsListen   = ":9090"
+	defaultMetricsInterval = 15 * time.Minute
+	// minMetricsInterval keeps refreshes from hammering plugins and cloud APIs.
+	minMetricsInterval = time.Minute
+)
+
+// serveMetricsParams holds the parameters for the serve metrics command execution.
+type serveMetricsParams struct {
+	listen    string
+	interval  time.Duration
+	planPaths []string
+	adapter   string
+	filter    []string
+	tagLabels []string
+}
+
+// NewServeMetricsCmd creates the "metrics" subcommand, which prices the stack
+// periodically and exposes the results as Prometheus metrics.
+func NewServeMetricsCmd() *cobra.Command {
+	var params serveMetricsParams
+
+	cmd := &cobra.Command{
+		Use:   "metrics",
+		Short: "Expose projected costs, budgets, and savings as Prometheus metrics",
+		Long: `Run the cost engine periodically and expose the results on /metrics for
+Prometheus to scrape.
+
+Each refresh reloads the resources, prices them, evaluates the configured
+budgets, and fetches recommendations. The previous results are served until a
+refresh succeeds; failed refreshes are counted in finfocus_refresh_errors_total.
+
+Metrics:
+  finfocus_projected_monthly_cost          Projected monthly cost
+  finfocus_resources                       Number of priced resources
+  finfocus_budget_limit                    Budget amount per scope
+  finfocus_budget_spend                    Projected spend per budget scope
+  finfocus_budget_utilization_ratio        Spend divided by budget (1 = 100%)
+  finfocus_recommendation_savings_monthly  Estimated savings of open recommendations
+  finfocus_recommendations                 Number of open recommendations
+  finfocus_refresh_success                 Whether the last refresh succeeded
+
+Cost metrics are labelled by provider, stack, resource_type, and currency, plus
+one tag_<key> label per --tag-label. Resources are summed per label set rather
+than exported individually, so keep --tag-label to low-cardinality tags.
+
+Resources come from --pulumi-json (repeatable, globs allowed, re-read on every
+refresh) or, when it is omitted, from the deployed state of the Pulumi stack in
+the current directory or of --stack.`,
+		Example: `  # Serve metrics for the current Pulumi stack on :9090
+  finfocus serve metrics
+
+  # Several plans, refreshed every 5 minutes, with team and env labels
+  finfocus serve metrics --pulumi-json 'plans/*.json' --interval 5m --tag-label team --tag-label env
+
+  # A Pulumi Cloud stack on a custom address
+  finfocus serve metrics --stack acme/web/prod --listen 127.0.0.1:9100`,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			return executeServeMetrics(cmd, params)
+		},
+	}
+
+	cmd.Flags().StringVar(&params.listen, "listen", defaultMetricsListen, "Address to serve /metrics on")
+	cmd.Flags().DurationVar(&params.interval, "interval", defaultMetricsInterval, "Time between refreshes")
+	cmd.Flags().StringArrayVar(&params.planPaths, "pulumi-json", nil,
+		"Path or glob of Pulumi preview JSON (repeatable)")
+	cmd.Flags().String("stack", "", "Pulumi stack to price when --pulumi-json is omitted")
+	cmd.Flags().StringVar(&params.adapter, "adapter", "", "Use only the specified adapter plugin")
+	cmd.Flags().StringArrayVar(&params.filter, "filter", []string{},
+		"Resource filter expressions (e.g., 'type=aws:ec2/instance', 'tag:env=prod')")
+	cmd.Flags().StringArrayVar(&params.tagLabels, "tag-label", nil,
+		"Resource tag to add as a tag_<key> label on cost metrics (repeatable)")
+
+	return cmd
+}
+
+// executeServeMetrics serves /metrics and refreshes the exported values every
+// interval until the command is interrupted.
+func executeServeMetrics(cmd *cobra.Command, params serveMetricsParams) error {
+	if params.interval < minMetricsInterval {
+		return fmt.Errorf("--interval must be at least %s, got %s", minMetricsInterval, params.interval)
+	}
+
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+	log := logging.FromContext(ctx)
+
+	audit := newAuditContext(ctx, "serve metrics", map[string]string{"listen": params.listen})
+	clients, cleanup, err := openPlugins(ctx, params.adapter, audit)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	cfg := config.New()
+	enablePluginResponseCache(ctx, cmd, cfg, clients)
+	eng := engine.New(clients, spec.NewLoader(cfg.SpecDir)).WithRouter(createRouterForEngine(ctx, cfg, clients))
+
+	exporter := metrics.NewExporter(params.tagLabels)
+	listener, err := listenTCP(ctx, params.listen)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", exporter.Handler())
+	mux.HandleFunc("/healthz", writeHealthz)
+
+	refresh := func() {
+		start := time.Now()
+		snapshot, refreshErr := collectMetricsSnapshot(ctx, cmd, eng, params)
+		if refreshErr != nil {
+			if ctx.Err() == nil {
+				log.Error().Ctx(ctx).Err(refreshErr).Str("component", "cli").Str("operation", "serve_metrics").
+					Msg("metrics refresh failed")
+			}
+			exporter.RecordFailure(start)
+			return
+		}
+		exporter.Update(snapshot, start)
+		log.Info().Ctx(ctx).Str("component", "cli").Str("operation", "serve_metrics").
+			Int("resource_count", len(snapshot.Costs)).Dur("duration_ms", time.Since(start)).
+			Msg("metrics refreshed")
+	}
+
+	go func() {
+		ticker := time.NewTicker(params.interval)
+		defer ticker.Stop()
+		refresh()
+		for {
+			select {
+			case <-ticker.C:
+				refresh()
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	cmd.PrintErrf("Serving metrics on http://%s/metrics (refresh every %s)\n", listener.Addr(), params.interval)
+	if err = serveHTTP(ctx, listener, mux); err != nil {
+		return fmt.Errorf("serving metrics: %w", err)
+	}
+	cmd.PrintErrln("Metrics server stopped")
+	return nil
+}
+
+// collectMetricsSnapshot loads and prices the resources, evaluates budgets,
+// and fetches recommendations for one refresh.
+func collectMetricsSnapshot(
+	ctx context.Context,
+	cmd *cobra.Command,
+	eng projectedCostEngine,
+	params serveMetricsParams,
+) (metrics.Snapshot, error) {
+	stackFlag := getStackFlag(cmd)
+
+	planPaths, err := expandPlanPaths(params.planPaths)
+	if err != nil {
+		return metrics.Snapshot{}, err
+	}
+
+	var resources []engine.ResourceDescriptor
+	var stacks map[string]string
+	if len(planPaths) > 0 {
+		resources, stacks, err = loadMultiplePlans(ctx, planPaths, nil)
+	} else {
+		resources, err = resolveResourcesFromPulumi(ctx, stackFlag, modePulumiExport)
+	}
+	if err != nil {
+		return metrics.Snapshot{}, err
+	}
+
+	resources, err = ApplyFilters(ctx, resources, params.filter)
+	if err != nil {
+		return metrics.Snapshot{}, fmt.Errorf("applying filters: %w", err)
+	}
+
+	result, err := eng.StreamProjectedCostWithErrors(ctx, resources, nil)
+	if err != nil {
+		return metrics.Snapshot{}, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	fetchAndMergeRecommendations(ctx, eng, resources, result.Results)
+
+	var budgets *BudgetRenderResult
+	if currency, mixed := extractCurrencyFromResults(result.Results); !mixed {
+		total := 0.0
+		for _, r := range result.Results {
+			total += r.Monthly
+		}
+		budgets, err = evaluateBudgetsQuietly(cmd, result.Results, resourceTagIndex(resources), total, currency)
+		if err != nil {
+			return metrics.Snapshot{}, fmt.Errorf("evaluating budgets: %w", err)
+		}
+	}
+
+	return buildMetricsSnapshot(resources, result.Results, stacks, stackFlag, budgets), nil
+}
+
+// buildMetricsSnapshot converts priced results into metric samples. A
+// resource's stack comes from stacks, then its URN, then defaultStack.
+func buildMetricsSnapshot(
+	resources []engine.ResourceDescriptor,
+	results []engine.CostResult,
+	stacks map[string]string,
+	defaultStack string,
+	budgets *BudgetRenderResult,
+) metrics.Snapshot {
+	byID := make(map[string]engine.ResourceDescriptor, len(resources))
+	for _, r := range resources {
+		byID[r.ID] = r
+	}
+	stackOf := func(id string) string {
+		if stack := stacks[id]; stack != "" {
+			return stack
+		}
+		if stack := engine.ExtractStackFromURN(id); stack != "" {
+			return stack
+		}
+		return defaultStack
+	}
+	providerOf := func(id, resourceType string) string {
+		if provider := byID[id].Provider; provider != "" {
+			return provider
+		}
+		return engine.ExtractProvider(resourceType)
+	}
+
+	var snapshot metrics.Snapshot
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		snapshot.Costs = append(snapshot.Costs, metrics.CostSample{
+			Provider:     providerOf(r.ResourceID, r.ResourceType),
+			Stack:        stackOf(r.ResourceID),
+			ResourceType: r.ResourceType,
+			Currency:     r.Currency,
+			Tags:         engine.ResourceTags(byID[r.ResourceID].Properties),
+			Monthly:      r.Monthly,
+		})
+		for _, rec := range r.Recommendations {
+			snapshot.Savings = append(snapshot.Savings, metrics.SavingsSample{
+				Provider: providerOf(r.ResourceID, r.ResourceType),
+				Stack:    stackOf(r.ResourceID),
+				Action:   rec.Type,
+				Currency: rec.Currency,
+				Monthly:  rec.EstimatedSavings,
+			})
+		}
+	}
+
+	for _, s := range budgetScopes(budgets) {
+		snapshot.Budgets = append(snapshot.Budgets, metrics.BudgetSample{
+			Scope:    s.ScopeIdentifier(),
+			Currency: s.Currency,
+			Limit:    s.Budget.Amount,
+			Spend:    s.CurrentSpend,
+		})
+	}
+	return snapshot
+}
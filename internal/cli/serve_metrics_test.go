@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/metrics"
+)
+
+func TestBuildMetricsSnapshot(t *testing.T) {
+	resources := []engine.ResourceDescriptor{
+		{ID: commentWebURN, Type: "aws:ec2/instance:Instance", Provider: "aws",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{"team": "web"}}},
+		{ID: "bucket", Type: "aws:s3/bucket:Bucket"},
+		{ID: "broken", Type: "aws:rds/instance:Instance"},
+	}
+	results := []engine.CostResult{
+		{ResourceID: commentWebURN, ResourceType: "aws:ec2/instance:Instance", Monthly: 150, Currency: "USD",
+			Recommendations: []engine.Recommendation{
+				{Type: "RIGHTSIZE", EstimatedSavings: 40, Currency: "USD"},
+			}},
+		{ResourceID: "bucket", ResourceType: "aws:s3/bucket:Bucket", Monthly: 2, Currency: "USD"},
+		{ResourceID: "broken", ResourceType: "aws:rds/instance:Instance",
+			Error: &engine.StructuredError{Code: engine.ErrCodePluginError}},
+	}
+	budgets := &BudgetRenderResult{LegacyStatus: &engine.BudgetStatus{
+		Budget:       config.BudgetConfig{Amount: 200, Currency: "USD"},
+		CurrentSpend: 152,
+		Percentage:   76,
+		Currency:     "USD",
+	}}
+
+	snapshot := buildMetricsSnapshot(resources, results, map[string]string{"bucket": "plan-a"}, "dev", budgets)
+
+	require.Len(t, snapshot.Costs, 2, "errored results are not exported")
+	assert.Equal(t, metrics.CostSample{
+		Provider: "aws", Stack: "prod", ResourceType: "aws:ec2/instance:Instance", Currency: "USD",
+		Tags: map[string]string{"team": "web"}, Monthly: 150,
+	}, snapshot.Costs[0])
+	assert.Equal(t, "plan-a", snapshot.Costs[1].Stack, "plan stack label wins")
+	assert.Equal(t, "aws", snapshot.Costs[1].Provider, "provider falls back to the resource type")
+
+	assert.Equal(t, []metrics.SavingsSample{
+		{Provider: "aws", Stack: "prod", Action: "RIGHTSIZE", Currency: "USD", Monthly: 40},
+	}, snapshot.Savings)
+	assert.Equal(t, []metrics.BudgetSample{
+		{Scope: "global", Currency: "USD", Limit: 200, Spend: 152},
+	}, snapshot.Budgets)
+
+	snapshot = buildMetricsSnapshot(resources[1:2], results[1:2], nil, "dev", nil)
+	assert.Equal(t, "dev", snapshot.Costs[0].Stack, "--stack is the last fallback")
+	assert.Empty(t, snapshot.Budgets)
+}
+
+func TestServeMetrics_IntervalValidation(t *testing.T) {
+	cmd := NewServeMetricsCmd()
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--interval", "10s"})
+	require.ErrorContains(t, cmd.Execute(), "--interval must be at least 1m0s")
+}
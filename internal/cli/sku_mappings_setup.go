@@ -0,0 +1,59 @@
+package cli
+
+import (
+	"context"
+
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/skus"
+)
+
+// setupSKUMappings installs the SKU and region mapping rules of the mappings
+// file in the configuration directory and the default regions of
+// defaults.region on the command context, and the SKU catalog overrides of
+// cost.sku_catalog and the provider aliases of cost.provider_aliases, so that
+// the plugin requests of resources the built-in extraction does not know
+// carry a SKU and region.
+func setupSKUMappings(cmd *cobra.Command) error {
+	cfg := config.GetGlobalConfig()
+	if cfg != nil {
+		skus.SetProviderAliases(cfg.Cost.ProviderAliases)
+	}
+	if cfg != nil && cfg.Cost.SKUCatalog != "" {
+		overrides, err := skus.LoadCatalog(cfg.Cost.SKUCatalog)
+		if err != nil {
+			return err
+		}
+		skus.SetOverrides(overrides)
+	}
+
+	rules, err := config.LoadMappingRules("")
+	if err != nil {
+		return err
+	}
+	var defaultRegions map[string]string
+	if cfg != nil {
+		defaultRegions = cfg.DefaultRegions()
+	}
+	if len(rules) == 0 && len(defaultRegions) == 0 {
+		return nil
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if len(rules) > 0 {
+		logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "cli").
+			Int("rules", len(rules)).Msg("loaded SKU mapping rules")
+		ctx = skus.NewContext(ctx, rules)
+	}
+	if len(defaultRegions) > 0 {
+		ctx = proto.ContextWithDefaultRegions(ctx, defaultRegions)
+	}
+	cmd.SetContext(ctx)
+	return nil
+}
@@ -0,0 +1,24 @@
+package cli
+
+import (
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// setupTheme applies the tui.theme and tui.colors config to the interactive
+// views and styled output. An invalid theme falls back to the dark theme with
+// a warning, so 'config set' can still fix it.
+func setupTheme(cmd *cobra.Command) {
+	var settings *config.TUIConfig
+	if cfg := config.GetGlobalConfig(); cfg != nil {
+		settings = cfg.TUI
+	}
+	theme, err := tui.ResolveTheme(settings.ThemeName(), settings.ThemeColors())
+	if err != nil {
+		cmd.PrintErrf("Warning: %v; using the %s theme\n", err, config.TUIThemeDark)
+		theme = tui.DarkTheme()
+	}
+	tui.ApplyTheme(theme)
+}
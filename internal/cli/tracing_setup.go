@@ -0,0 +1,67 @@
+package cli
+
+import (
+	"context"
+	"time"
+
+	"github.com/spf13/cobra"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+// tracingShutdownTimeout bounds how long exiting waits for spans to be exported.
+const tracingShutdownTimeout = 5 * time.Second
+
+// setupTracing installs the OTLP exporter when tracing is configured and
+// starts the span that covers the whole command. The endpoint comes from
+// --otel-endpoint, then the OTEL_EXPORTER_OTLP_* variables, then the
+// tracing.endpoint config key. The returned function ends the command span and
+// flushes pending spans; it is never nil.
+func setupTracing(cmd *cobra.Command, version string) (func(), error) {
+	endpoint, _ := cmd.Flags().GetString("otel-endpoint")
+	if endpoint != "" {
+		if err := config.ValidateTracingEndpoint(endpoint); err != nil {
+			return func() {}, err
+		}
+	} else if cfg := config.GetGlobalConfig(); cfg != nil && !tracing.Enabled("") {
+		endpoint = cfg.TracingEndpoint()
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	shutdown, err := tracing.Setup(ctx, endpoint, version)
+	if err != nil {
+		return func() {}, err
+	}
+
+	ctx, span := tracing.Tracer().Start(ctx, cmd.CommandPath(),
+		trace.WithAttributes(attribute.String(tracing.AttrTraceID, logging.TraceIDFromContext(ctx))))
+	cmd.SetContext(ctx)
+
+	return func() {
+		span.End()
+		shutdownCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), tracingShutdownTimeout)
+		defer cancel()
+		if shutdownErr := shutdown(shutdownCtx); shutdownErr != nil {
+			logging.FromContext(ctx).Warn().Ctx(ctx).Err(shutdownErr).Msg("failed to export traces")
+		}
+	}, nil
+}
+
+// adoptRootContext gives cmd the context prepared by the root command's
+// PersistentPreRunE when a command group runs it on root's behalf, and names
+// the command span after cmd.
+func adoptRootContext(cmd, root *cobra.Command) {
+	ctx := root.Context()
+	if ctx == nil {
+		return
+	}
+	trace.SpanFromContext(ctx).SetName(cmd.CommandPath())
+	cmd.SetContext(ctx)
+}
@@ -0,0 +1,38 @@
+package cli
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+func newTracingTestCmd(endpoint string) *cobra.Command {
+	cmd := &cobra.Command{Use: "finfocus"}
+	cmd.Flags().String("otel-endpoint", endpoint, "")
+	cmd.SetContext(context.Background())
+	return cmd
+}
+
+func TestSetupTracing_RejectsInvalidEndpoint(t *testing.T) {
+	_, err := setupTracing(newTracingTestCmd("localhost:4318"), "v1.0.0")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "invalid tracing endpoint")
+}
+
+func TestSetupTracing_DisabledStartsNoRecordingSpan(t *testing.T) {
+	t.Setenv(tracing.EnvEndpoint, "")
+	t.Setenv(tracing.EnvTracesEndpoint, "")
+
+	cmd := newTracingTestCmd("")
+	finish, err := setupTracing(cmd, "v1.0.0")
+	require.NoError(t, err)
+	defer finish()
+
+	assert.False(t, trace.SpanFromContext(cmd.Context()).IsRecording())
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// writeViewExport creates path and writes a TUI view to it with render,
+// passing the format selected by the file extension: engine.OutputJSON for
+// ".json" or "csv" for ".csv".
+func writeViewExport(path string, render func(w io.Writer, format string) error) error {
+	var format string
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		format = string(engine.OutputJSON)
+	case ".csv":
+		format = exportFormatCSV
+	default:
+		return fmt.Errorf("unsupported export file %q: use a .json or .csv extension", path)
+	}
+
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return fmt.Errorf("creating export file: %w", err)
+	}
+	if renderErr := render(f, format); renderErr != nil {
+		_ = f.Close()
+		return renderErr
+	}
+	if closeErr := f.Close(); closeErr != nil {
+		return fmt.Errorf("closing export file: %w", closeErr)
+	}
+	return nil
+}
+
+// exportRecommendationsView writes the recommendations shown in the TUI with
+// the same renderers as --output json and --output csv.
+func exportRecommendationsView(_ context.Context, path string, recs []engine.Recommendation) error {
+	return writeViewExport(path, func(w io.Writer, format string) error {
+		result := &engine.RecommendationsResult{
+			Recommendations: recs,
+			TotalSavings:    calculateTotalSavings(recs),
+		}
+		if len(recs) > 0 {
+			result.Currency = recs[0].Currency
+		}
+		if format == exportFormatCSV {
+			columns, err := resolveRecommendationColumns(nil, recs)
+			if err != nil {
+				return err
+			}
+			return renderRecommendationsCSV(w, result, columns)
+		}
+		return renderRecommendationsJSON(w, result, nil)
+	})
+}
+
+// costViewExporter returns the exporter of the cost TUI. JSON uses the
+// renderer of --output json for projected or actual costs; CSV writes the
+// per-day rows of cost actual --export, dating results without a start date
+// at from.
+func costViewExporter(actual bool, from time.Time) tui.ViewExporter[engine.CostResult] {
+	return func(ctx context.Context, path string, results []engine.CostResult) error {
+		return writeViewExport(path, func(w io.Writer, format string) error {
+			switch {
+			case format == exportFormatCSV:
+				cw := &csvRowWriter{w: csv.NewWriter(w)}
+				if _, err := streamCostRows(cw, results, from); err != nil {
+					return fmt.Errorf("writing csv export: %w", err)
+				}
+				return cw.Close()
+			case actual:
+				return engine.RenderActualCostJSON(w, results, false)
+			default:
+				return engine.RenderResultsWithContext(ctx, w, engine.OutputJSON, results)
+			}
+		})
+	}
+}
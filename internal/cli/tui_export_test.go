@@ -0,0 +1,68 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func TestExportRecommendationsView(t *testing.T) {
+	dir := t.TempDir()
+	recs := []engine.Recommendation{
+		{ID: "rec-1", ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 20, Currency: "USD"},
+		{ID: "rec-2", ResourceID: "db", Type: "TERMINATE", EstimatedSavings: 5, Currency: "USD"},
+	}
+
+	csvPath := filepath.Join(dir, "view.CSV")
+	require.NoError(t, exportRecommendationsView(context.Background(), csvPath, recs))
+	data, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.Equal(t, "resource,action,description,savings,currency\n"+
+		"web,Rightsize,,20.00,USD\ndb,Terminate,,5.00,USD\n", string(data))
+
+	jsonPath := filepath.Join(dir, "view.json")
+	require.NoError(t, exportRecommendationsView(context.Background(), jsonPath, recs))
+	data, err = os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var out recommendationsJSONOutput
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.InDelta(t, 25.0, out.TotalSavings, 0.001)
+	require.Len(t, out.Recommendations, 2)
+	assert.Equal(t, "web", out.Recommendations[0].ResourceID)
+
+	err = exportRecommendationsView(context.Background(), filepath.Join(dir, "view.txt"), recs)
+	require.ErrorContains(t, err, "use a .json or .csv extension")
+	assert.NoFileExists(t, filepath.Join(dir, "view.txt"))
+}
+
+func TestCostViewExporter(t *testing.T) {
+	dir := t.TempDir()
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Monthly: 73, Currency: "USD"},
+	}
+
+	csvPath := filepath.Join(dir, "costs.csv")
+	require.NoError(t, costViewExporter(false, from)(context.Background(), csvPath, results))
+	data, err := os.ReadFile(csvPath)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "date,resource_id,resource_type")
+	assert.Contains(t, string(data), "2026-03-01,web,aws:ec2/instance:Instance,aws")
+
+	jsonPath := filepath.Join(dir, "costs.json")
+	require.NoError(t, costViewExporter(true, from)(context.Background(), jsonPath, results))
+	data, err = os.ReadFile(jsonPath)
+	require.NoError(t, err)
+	var actual []engine.CostResult
+	require.NoError(t, json.Unmarshal(data, &actual))
+	require.Len(t, actual, 1)
+	assert.Equal(t, "web", actual[0].ResourceID)
+}
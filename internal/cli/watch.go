@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/signal"
+	"syscall"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/spf13/cobra"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/tui"
+)
+
+// minWatchInterval keeps --watch from hammering plugins.
+const minWatchInterval = 5 * time.Second
+
+// watchTimeLayout formats refresh times in plain watch output.
+const watchTimeLayout = "15:04:05"
+
+// watchFrameFunc runs the query of a watched command once and writes its
+// output to cmd.OutOrStdout(), which runWatch redirects for each refresh.
+type watchFrameFunc func(ctx context.Context) error
+
+// validateWatchInterval checks a --watch interval; zero disables watching.
+func validateWatchInterval(interval time.Duration) error {
+	if interval != 0 && interval < minWatchInterval {
+		return fmt.Errorf("--watch must be at least %s, got %s", minWatchInterval, interval)
+	}
+	return nil
+}
+
+// runWatch re-runs frame every interval until the command is interrupted.
+// On an interactive terminal with table output the output is re-rendered
+// in place with the values that changed since the previous refresh
+// highlighted. Otherwise each refresh is written in full: table output after
+// a header line and with "* " before changed lines, JSON output as is. A
+// failed refresh is reported and the next one still runs.
+func runWatch(cmd *cobra.Command, title, output string, interval time.Duration, frame watchFrameFunc) error {
+	ctx, stop := signal.NotifyContext(cmd.Context(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	out := cmd.OutOrStdout()
+	render := func(renderCtx context.Context) (string, error) {
+		var buf bytes.Buffer
+		cmd.SetOut(&buf)
+		defer cmd.SetOut(out)
+		err := frame(renderCtx)
+		return buf.String(), err
+	}
+
+	if shouldUseInteractiveTUI(out, output, false) {
+		model := tui.NewWatchModel(ctx, title, render, interval)
+		if _, err := tea.NewProgram(model, tea.WithAltScreen(), tea.WithContext(ctx)).Run(); err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return fmt.Errorf("running %s: %w", title, err)
+		}
+		return nil
+	}
+
+	return runPlainWatch(ctx, cmd, output, interval, render)
+}
+
+// runPlainWatch writes a refresh every interval until ctx is done.
+func runPlainWatch(
+	ctx context.Context,
+	cmd *cobra.Command,
+	output string,
+	interval time.Duration,
+	render tui.WatchRenderer,
+) error {
+	log := logging.FromContext(ctx)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	prev := ""
+	for {
+		frame, err := render(ctx)
+		if ctx.Err() != nil {
+			return nil
+		}
+		switch {
+		case err != nil:
+			log.Warn().Ctx(ctx).Err(err).Str("component", "cli").Str("operation", "watch").Msg("watch refresh failed")
+			cmd.PrintErrf("Refresh failed at %s: %v\n", time.Now().Format(watchTimeLayout), err)
+		case output == outputFormatTable:
+			cmd.Printf("--- %s (every %s) ---\n", time.Now().Format(watchTimeLayout), interval)
+			cmd.Print(tui.MarkChangedLines(prev, frame))
+			prev = frame
+		default:
+			cmd.Print(frame)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
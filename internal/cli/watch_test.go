@@ -0,0 +1,106 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateWatchInterval(t *testing.T) {
+	require.NoError(t, validateWatchInterval(0))
+	require.NoError(t, validateWatchInterval(minWatchInterval))
+	require.ErrorContains(t, validateWatchInterval(time.Second), "--watch must be at least 5s")
+}
+
+func TestRunPlainWatch_MarksChangedLines(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := &cobra.Command{}
+	var out, errOut bytes.Buffer
+	cmd.SetOut(&out)
+	cmd.SetErr(&errOut)
+
+	refreshes := []struct {
+		frame string
+		err   error
+	}{
+		{frame: "web  $10.00\ndb   $25.00\n"},
+		{err: errors.New("plugin timeout")},
+		{frame: "web  $12.00\ndb   $25.00\n"},
+		{frame: "dropped\n"},
+	}
+	calls := 0
+	render := func(context.Context) (string, error) {
+		r := refreshes[calls]
+		calls++
+		if calls == len(refreshes) {
+			cancel() // Interrupted during the last refresh, which is dropped.
+		}
+		return r.frame, r.err
+	}
+
+	require.NoError(t, runPlainWatch(ctx, cmd, outputFormatTable, time.Millisecond, render))
+
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 2, strings.Count(out.String(), "(every 1ms) ---"))
+	assert.Contains(t, out.String(), "web  $10.00\ndb   $25.00\n")
+	assert.Contains(t, out.String(), "* web  $12.00\n  db   $25.00\n")
+	assert.Contains(t, errOut.String(), "plugin timeout")
+	assert.NotContains(t, out.String(), "dropped")
+}
+
+func TestRunPlainWatch_JSONFramesAreUnchanged(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cmd := &cobra.Command{}
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	calls := 0
+	render := func(context.Context) (string, error) {
+		calls++
+		if calls == 3 {
+			cancel()
+		}
+		return fmt.Sprintf("{\"refresh\": %d}\n", calls), nil
+	}
+
+	require.NoError(t, runPlainWatch(ctx, cmd, outputFormatJSON, time.Millisecond, render))
+	assert.Equal(t, "{\"refresh\": 1}\n{\"refresh\": 2}\n", out.String())
+}
+
+func TestWatchFlags(t *testing.T) {
+	tests := []struct {
+		name string
+		cmd  func() *cobra.Command
+		args []string
+		want string
+	}{
+		{"cost actual interval", NewCostActualCmd, []string{"--watch", "1s"}, "--watch must be at least 5s"},
+		{"budget status interval", NewBudgetStatusCmd, []string{"--watch", "1s"}, "--watch must be at least 5s"},
+		{
+			"budget status interactive", NewBudgetStatusCmd, []string{"--watch", "10s", "--interactive"},
+			"[interactive watch] were all set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := tt.cmd()
+			var out bytes.Buffer
+			cmd.SetOut(&out)
+			cmd.SetErr(&out)
+			cmd.SetArgs(tt.args)
+			err := cmd.Execute()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.want)
+		})
+	}
+}
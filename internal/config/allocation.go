@@ -0,0 +1,148 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// Shared-cost distribution strategies accepted by cost.allocation.shared[].strategy.
+const (
+	// AllocationStrategyEven splits a shared pool equally between teams.
+	AllocationStrategyEven = "even"
+	// AllocationStrategyProportional splits a shared pool in proportion to each team's direct spend.
+	AllocationStrategyProportional = "proportional"
+	// AllocationStrategyFixed splits a shared pool by configured percentages.
+	AllocationStrategyFixed = "fixed"
+)
+
+// Fixed percentages must add up to fullPercentage within percentageSumTolerance.
+const (
+	fullPercentage         = 100.0
+	percentageSumTolerance = 0.01
+)
+
+// ErrInvalidAllocation is returned for invalid cost.allocation settings.
+var ErrInvalidAllocation = errors.New("invalid allocation configuration")
+
+// AllocationConfig attributes costs to teams for 'finfocus cost allocate' and
+// chargeback reports.
+//
+// A resource is owned by the team of the first rule whose selector matches
+// its tags, or else by the value of its TagKey tag. Resources matched by a
+// shared pool are not owned by one team; their cost is distributed between
+// teams by the pool's strategy.
+type AllocationConfig struct {
+	// TagKey is the resource tag that names the owning team (default "team").
+	TagKey string `yaml:"tag_key,omitempty" json:"tag_key,omitempty"`
+
+	// Rules map tag selectors to teams and take precedence over TagKey.
+	Rules []AllocationRule `yaml:"rules,omitempty" json:"rules,omitempty"`
+
+	// Shared lists pools of shared costs and how to distribute them.
+	Shared []SharedCostPool `yaml:"shared,omitempty" json:"shared,omitempty"`
+}
+
+// AllocationRule assigns resources matching Selector to Team.
+type AllocationRule struct {
+	// Selector is a tag selector in "key:value" or "key:*" format.
+	Selector string `yaml:"selector" json:"selector"`
+
+	// Team receives the cost of matching resources.
+	Team string `yaml:"team" json:"team"`
+}
+
+// SharedCostPool is a set of shared resources whose cost is distributed
+// between teams.
+type SharedCostPool struct {
+	// Name identifies the pool in reports, e.g. "networking".
+	Name string `yaml:"name" json:"name"`
+
+	// Selector is a tag selector in "key:value" or "key:*" format matching
+	// the pool's resources.
+	Selector string `yaml:"selector" json:"selector"`
+
+	// Strategy is "even", "proportional", or "fixed" (default "even").
+	Strategy string `yaml:"strategy,omitempty" json:"strategy,omitempty"`
+
+	// Teams limits the even and proportional strategies to these teams.
+	// Empty distributes the pool between every team with direct spend.
+	Teams []string `yaml:"teams,omitempty" json:"teams,omitempty"`
+
+	// Percentages maps teams to their share of the pool for the fixed
+	// strategy. They must add up to 100.
+	Percentages map[string]float64 `yaml:"percentages,omitempty" json:"percentages,omitempty"`
+}
+
+// DistributionStrategy returns the pool's strategy, applying the default.
+func (p SharedCostPool) DistributionStrategy() string {
+	if p.Strategy == "" {
+		return AllocationStrategyEven
+	}
+	return p.Strategy
+}
+
+// Validate checks the selectors, teams, and shared pool settings.
+func (c *AllocationConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for i, rule := range c.Rules {
+		if _, err := ParseTagSelector(rule.Selector); err != nil {
+			return fmt.Errorf("%w: rules[%d]: %w", ErrInvalidAllocation, i, err)
+		}
+		if rule.Team == "" {
+			return fmt.Errorf("%w: rules[%d]: team is required", ErrInvalidAllocation, i)
+		}
+	}
+
+	names := make(map[string]bool, len(c.Shared))
+	for i, pool := range c.Shared {
+		if pool.Name == "" {
+			return fmt.Errorf("%w: shared[%d]: name is required", ErrInvalidAllocation, i)
+		}
+		if names[pool.Name] {
+			return fmt.Errorf("%w: shared pool %q is defined twice", ErrInvalidAllocation, pool.Name)
+		}
+		names[pool.Name] = true
+		if err := pool.validate(); err != nil {
+			return fmt.Errorf("%w: shared pool %q: %w", ErrInvalidAllocation, pool.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks one shared pool.
+func (p SharedCostPool) validate() error {
+	if _, err := ParseTagSelector(p.Selector); err != nil {
+		return err
+	}
+
+	switch p.DistributionStrategy() {
+	case AllocationStrategyEven, AllocationStrategyProportional:
+		if len(p.Percentages) > 0 {
+			return fmt.Errorf("percentages require strategy %q", AllocationStrategyFixed)
+		}
+	case AllocationStrategyFixed:
+		if len(p.Percentages) == 0 {
+			return errors.New("strategy fixed needs percentages")
+		}
+		if len(p.Teams) > 0 {
+			return errors.New("strategy fixed takes its teams from percentages")
+		}
+		sum := 0.0
+		for team, pct := range p.Percentages {
+			if pct <= 0 {
+				return fmt.Errorf("percentage for team %q must be positive, got %g", team, pct)
+			}
+			sum += pct
+		}
+		if math.Abs(sum-fullPercentage) > percentageSumTolerance {
+			return fmt.Errorf("percentages must add up to 100, got %g", sum)
+		}
+	default:
+		return fmt.Errorf("unknown strategy %q (supported: %s, %s, %s)", p.Strategy,
+			AllocationStrategyEven, AllocationStrategyProportional, AllocationStrategyFixed)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAllocationConfig_Validate(t *testing.T) {
+	valid := &AllocationConfig{
+		TagKey: "owner",
+		Rules:  []AllocationRule{{Selector: "project:recs", Team: "data"}},
+		Shared: []SharedCostPool{
+			{Name: "network", Selector: "shared:network", Strategy: AllocationStrategyProportional},
+			{
+				Name: "support", Selector: "cost-center:*", Strategy: AllocationStrategyFixed,
+				Percentages: map[string]float64{"payments": 60, "frontend": 40},
+			},
+		},
+	}
+	require.NoError(t, valid.Validate())
+	require.NoError(t, (*AllocationConfig)(nil).Validate())
+
+	tests := map[string]*AllocationConfig{
+		"bad rule selector": {Rules: []AllocationRule{{Selector: "team", Team: "x"}}},
+		"rule without team": {Rules: []AllocationRule{{Selector: "team:x"}}},
+		"pool without name": {Shared: []SharedCostPool{{Selector: "shared:*"}}},
+		"duplicate pool": {Shared: []SharedCostPool{
+			{Name: "a", Selector: "shared:*"}, {Name: "a", Selector: "infra:*"},
+		}},
+		"unknown strategy": {Shared: []SharedCostPool{{Name: "a", Selector: "shared:*", Strategy: "random"}}},
+		"fixed without percentages": {Shared: []SharedCostPool{
+			{Name: "a", Selector: "shared:*", Strategy: AllocationStrategyFixed},
+		}},
+		"percentages not adding up": {Shared: []SharedCostPool{{
+			Name: "a", Selector: "shared:*", Strategy: AllocationStrategyFixed,
+			Percentages: map[string]float64{"x": 50, "y": 40},
+		}}},
+		"percentages without fixed": {Shared: []SharedCostPool{{
+			Name: "a", Selector: "shared:*", Percentages: map[string]float64{"x": 100},
+		}}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, cfg.Validate(), ErrInvalidAllocation)
+		})
+	}
+}
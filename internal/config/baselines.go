@@ -0,0 +1,151 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// baselineExt is the file extension of saved baselines.
+const baselineExt = ".json"
+
+// baselineNamePattern matches valid baseline names, which become file names.
+var baselineNamePattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// ErrBaselineNotFound is returned when no baseline is saved under a name.
+var ErrBaselineNotFound = errors.New("baseline not found")
+
+// BaselineInfo describes a saved baseline.
+type BaselineInfo struct {
+	// Name is the name the baseline was saved under.
+	Name string
+	// SavedAt is when the baseline was last saved.
+	SavedAt time.Time
+}
+
+// BaselineStore keeps named cost results, one JSON file per name, so later
+// runs can be compared with them by name rather than by path.
+type BaselineStore struct {
+	dir string
+}
+
+// NewBaselineStore creates a new BaselineStore backed by the given directory.
+// If dir is empty, it defaults to the baselines directory in the directory
+// returned by ResolveConfigDir (normally ~/.finfocus/baselines).
+func NewBaselineStore(dir string) *BaselineStore {
+	if dir == "" {
+		dir = filepath.Join(ResolveConfigDir(), "baselines")
+	}
+	return &BaselineStore{dir: dir}
+}
+
+// Dir returns the directory the baselines are saved in.
+func (s *BaselineStore) Dir() string {
+	return s.dir
+}
+
+// ValidateBaselineName checks that name can be used as a baseline name:
+// letters, digits, '.', '_', and '-', starting with a letter or digit.
+func ValidateBaselineName(name string) error {
+	if !baselineNamePattern.MatchString(name) {
+		return fmt.Errorf("invalid baseline name %q: use letters, digits, '.', '_', and '-'", name)
+	}
+	return nil
+}
+
+// path returns the file a baseline is saved in.
+func (s *BaselineStore) path(name string) string {
+	return filepath.Join(s.dir, name+baselineExt)
+}
+
+// Save writes data under name atomically, replacing any baseline saved under
+// the same name.
+func (s *BaselineStore) Save(name string, data []byte) error {
+	if err := ValidateBaselineName(name); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o750); err != nil {
+		return fmt.Errorf("creating baselines directory: %w", err)
+	}
+
+	path := s.path(name)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("writing baseline temp file: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("renaming baseline temp file: %w", err)
+	}
+	return nil
+}
+
+// Load returns the data saved under name, or ErrBaselineNotFound.
+func (s *BaselineStore) Load(name string) ([]byte, error) {
+	if err := ValidateBaselineName(name); err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(s.path(name))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("%w: %s", ErrBaselineNotFound, name)
+		}
+		return nil, fmt.Errorf("reading baseline %s: %w", name, err)
+	}
+	return data, nil
+}
+
+// Exists reports whether a baseline is saved under name.
+func (s *BaselineStore) Exists(name string) bool {
+	if ValidateBaselineName(name) != nil {
+		return false
+	}
+	info, err := os.Stat(s.path(name))
+	return err == nil && info.Mode().IsRegular()
+}
+
+// List returns the saved baselines sorted by name. A missing directory means
+// no baselines have been saved.
+func (s *BaselineStore) List() ([]BaselineInfo, error) {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading baselines directory: %w", err)
+	}
+
+	var baselines []BaselineInfo
+	for _, entry := range entries {
+		name, ok := strings.CutSuffix(entry.Name(), baselineExt)
+		if !ok || !entry.Type().IsRegular() || ValidateBaselineName(name) != nil {
+			continue
+		}
+		info, infoErr := entry.Info()
+		if infoErr != nil {
+			continue
+		}
+		baselines = append(baselines, BaselineInfo{Name: name, SavedAt: info.ModTime()})
+	}
+	sort.Slice(baselines, func(i, j int) bool { return baselines[i].Name < baselines[j].Name })
+	return baselines, nil
+}
+
+// Delete removes the baseline saved under name, or returns ErrBaselineNotFound.
+func (s *BaselineStore) Delete(name string) error {
+	if err := ValidateBaselineName(name); err != nil {
+		return err
+	}
+	if err := os.Remove(s.path(name)); err != nil {
+		if os.IsNotExist(err) {
+			return fmt.Errorf("%w: %s", ErrBaselineNotFound, name)
+		}
+		return fmt.Errorf("deleting baseline %s: %w", name, err)
+	}
+	return nil
+}
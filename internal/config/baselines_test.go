@@ -0,0 +1,58 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBaselineStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", dir)
+	assert.Equal(t, filepath.Join(dir, "baselines"), NewBaselineStore("").Dir())
+}
+
+func TestBaselineStore(t *testing.T) {
+	store := NewBaselineStore(filepath.Join(t.TempDir(), "baselines"))
+
+	baselines, err := store.List()
+	require.NoError(t, err)
+	assert.Empty(t, baselines, "no directory yet")
+
+	require.NoError(t, store.Save("main", []byte(`[{"resourceId":"web"}]`)))
+	require.NoError(t, store.Save("release-1.2", []byte(`[]`)))
+	require.NoError(t, store.Save("main", []byte(`[{"resourceId":"db"}]`)))
+	require.NoError(t, os.WriteFile(filepath.Join(store.Dir(), "notes.txt"), []byte("x"), 0o600))
+
+	assert.True(t, store.Exists("main"))
+	assert.False(t, store.Exists("missing"))
+	assert.False(t, store.Exists("../main"))
+
+	data, err := store.Load("main")
+	require.NoError(t, err)
+	assert.JSONEq(t, `[{"resourceId":"db"}]`, string(data), "saving again replaces the baseline")
+
+	baselines, err = store.List()
+	require.NoError(t, err)
+	require.Len(t, baselines, 2)
+	assert.Equal(t, "main", baselines[0].Name)
+	assert.Equal(t, "release-1.2", baselines[1].Name)
+	assert.False(t, baselines[0].SavedAt.IsZero())
+
+	require.NoError(t, store.Delete("main"))
+	_, err = store.Load("main")
+	require.ErrorIs(t, err, ErrBaselineNotFound)
+	require.ErrorIs(t, store.Delete("main"), ErrBaselineNotFound)
+}
+
+func TestValidateBaselineName(t *testing.T) {
+	for _, name := range []string{"main", "pr-42", "v1.2.3", "nightly_run"} {
+		assert.NoError(t, ValidateBaselineName(name), name)
+	}
+	for _, name := range []string{"", ".hidden", "../etc", "a/b", "with space"} {
+		assert.Error(t, ValidateBaselineName(name), name)
+	}
+}
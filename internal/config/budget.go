@@ -3,6 +3,7 @@ package config
 import (
 	"errors"
 	"fmt"
+	"strings"
 )
 
 // AlertType represents the type of budget alert evaluation.
@@ -201,8 +202,38 @@ type CostConfig struct {
 
 	// Cache contains the cache configuration for query result caching.
 	Cache CacheConfig `yaml:"cache,omitempty" json:"cache,omitempty"`
+
+	// Allocation attributes costs to teams and distributes shared costs.
+	Allocation *AllocationConfig `yaml:"allocation,omitempty" json:"allocation,omitempty"`
+
+	// Chargeback configures the markups and discounts of chargeback invoices.
+	Chargeback *ChargebackConfig `yaml:"chargeback,omitempty" json:"chargeback,omitempty"`
+
+	// Profiles are the usage profiles that --usage-profile scales projected
+	// costs by, keyed by name.
+	Profiles UsageProfiles `yaml:"profiles,omitempty" json:"profiles,omitempty"`
+
+	// Regions configures the candidate regions of cost optimize-region.
+	Regions *RegionsConfig `yaml:"regions,omitempty" json:"regions,omitempty"`
+
+	// SKUCatalog is the path of a SKU catalog file whose entries extend and
+	// override the built-in catalog of well-known SKUs.
+	SKUCatalog string `yaml:"sku_catalog,omitempty" json:"sku_catalog,omitempty"`
+
+	// ProviderAliases maps the namespaces of providers published under custom
+	// names (the my-org of my-org:aws-wrapper:Instance) to the provider whose
+	// resources they wrap, e.g. aws.
+	ProviderAliases map[string]string `yaml:"provider_aliases,omitempty" json:"provider_aliases,omitempty"`
+
+	// SpillThreshold is the number of cost results or recommendations a
+	// command holds in memory before moving them to a temporary file; zero
+	// uses the default of 10000.
+	SpillThreshold int `yaml:"spill_threshold,omitempty" json:"spill_threshold,omitempty"`
 }
 
+// ErrNegativeSpillThreshold is returned for a negative cost.spill_threshold.
+var ErrNegativeSpillThreshold = errors.New("spill threshold cannot be negative")
+
 // CacheConfig defines caching behavior for query results.
 type CacheConfig struct {
 	// Enabled controls whether caching is enabled (default: true).
@@ -216,6 +247,33 @@ type CacheConfig struct {
 
 	// MaxSizeMB is the maximum cache size in megabytes (default: 100, 0 = unlimited).
 	MaxSizeMB int `yaml:"max_size_mb" json:"max_size_mb"`
+
+	// OperationTTLSeconds overrides TTLSeconds per plugin operation. Keys are
+	// "projected_cost", "actual_cost", and "recommendations".
+	OperationTTLSeconds map[string]int `yaml:"operation_ttl_seconds,omitempty" json:"operation_ttl_seconds,omitempty"`
+}
+
+// cacheOperations lists the operation names accepted in OperationTTLSeconds.
+//
+//nolint:gochecknoglobals // Read-only lookup table.
+var cacheOperations = map[string]bool{
+	"projected_cost":  true,
+	"actual_cost":     true,
+	"recommendations": true,
+}
+
+// Validate checks that per-operation TTLs name known operations and are positive.
+func (c CacheConfig) Validate() error {
+	for op, ttl := range c.OperationTTLSeconds {
+		if !cacheOperations[op] {
+			return fmt.Errorf("operation_ttl_seconds: unknown operation %q "+
+				"(supported: projected_cost, actual_cost, recommendations)", op)
+		}
+		if ttl <= 0 {
+			return fmt.Errorf("operation_ttl_seconds.%s must be positive, got %d", op, ttl)
+		}
+	}
+	return nil
 }
 
 // Validate validates the cost configuration.
@@ -231,6 +289,36 @@ func (c CostConfig) Validate() error {
 		// Warnings are non-fatal and available via GetBudgetsWarnings()
 	}
 
+	if err := c.Cache.Validate(); err != nil {
+		return fmt.Errorf("cache: %w", err)
+	}
+
+	if err := c.Allocation.Validate(); err != nil {
+		return fmt.Errorf("allocation: %w", err)
+	}
+
+	if err := c.Chargeback.Validate(); err != nil {
+		return fmt.Errorf("chargeback: %w", err)
+	}
+
+	if err := c.Profiles.Validate(); err != nil {
+		return fmt.Errorf("profiles: %w", err)
+	}
+
+	if err := c.Regions.Validate(); err != nil {
+		return fmt.Errorf("regions: %w", err)
+	}
+
+	for alias, provider := range c.ProviderAliases {
+		if strings.TrimSpace(alias) == "" || strings.TrimSpace(provider) == "" {
+			return fmt.Errorf("provider_aliases: %q: %w", alias, ErrEmptyProviderAlias)
+		}
+	}
+
+	if c.SpillThreshold < 0 {
+		return fmt.Errorf("spill_threshold: %d: %w", c.SpillThreshold, ErrNegativeSpillThreshold)
+	}
+
 	return nil
 }
 
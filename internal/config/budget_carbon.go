@@ -0,0 +1,107 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// CarbonBudgetUnit is the unit of carbon budget amounts and footprints.
+const CarbonBudgetUnit = "kgCO2e"
+
+// Carbon budget validation errors.
+var (
+	// ErrCarbonBudgetCurrency is returned when a carbon budget sets a currency.
+	ErrCarbonBudgetCurrency = errors.New("carbon budgets are in " + CarbonBudgetUnit + " and take no currency")
+
+	// ErrCarbonBudgetRollover is returned when a carbon budget enables rollover.
+	ErrCarbonBudgetRollover = errors.New("carbon budgets do not support rollover")
+)
+
+// CarbonBudgetsConfig holds carbon budgets, in kgCO2e per period, with the
+// same global, provider, tag, and type scopes as cost budgets. Footprints
+// come from the carbon_footprint sustainability metric reported by plugins.
+type CarbonBudgetsConfig struct {
+	// Global is the carbon budget that applies to all resources.
+	// Required if any scoped carbon budgets are defined.
+	Global *ScopedBudget `yaml:"global,omitempty" json:"global,omitempty"`
+
+	// Providers maps cloud provider names (aws, gcp, azure) to their carbon budgets.
+	Providers map[string]*ScopedBudget `yaml:"providers,omitempty" json:"providers,omitempty"`
+
+	// Tags defines carbon budgets scoped by resource tags with priority ordering.
+	Tags []TagBudget `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// Types maps resource types (e.g., "aws:ec2/instance") to their carbon budgets.
+	Types map[string]*ScopedBudget `yaml:"types,omitempty" json:"types,omitempty"`
+}
+
+// HasCarbonBudgets returns true if any carbon budget is configured and enabled.
+func (b *BudgetsConfig) HasCarbonBudgets() bool {
+	return b != nil && b.Carbon.Scopes(b).IsEnabled()
+}
+
+// Scopes returns the carbon budgets as a BudgetsConfig for the scoped budget
+// evaluator, with the exit settings of parent, which may be nil. Amounts are
+// in CarbonBudgetUnit rather than a currency.
+func (c *CarbonBudgetsConfig) Scopes(parent *BudgetsConfig) *BudgetsConfig {
+	if c == nil {
+		return nil
+	}
+	scopes := &BudgetsConfig{
+		Global:    c.Global,
+		Providers: c.Providers,
+		Tags:      c.Tags,
+		Types:     c.Types,
+	}
+	if parent != nil {
+		scopes.ExitOnThreshold = parent.ExitOnThreshold
+		scopes.ExitCode = parent.ExitCode
+	}
+	return scopes
+}
+
+// Validate checks if the carbon budgets configuration is valid. It applies
+// the cost budget rules, except that carbon budgets take no currency and do
+// not roll over. Returns a list of warnings and an error for fatal issues.
+func (c *CarbonBudgetsConfig) Validate() ([]string, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	scopes := c.Scopes(nil)
+	warnings, err := scopes.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	check := func(scope string, budget *ScopedBudget) error {
+		switch {
+		case budget == nil:
+			return nil
+		case budget.Currency != "":
+			return fmt.Errorf("%s: %w", scope, ErrCarbonBudgetCurrency)
+		case budget.Rollover:
+			return fmt.Errorf("%s: %w", scope, ErrCarbonBudgetRollover)
+		}
+		return nil
+	}
+	if err = check("global budget", c.Global); err != nil {
+		return nil, err
+	}
+	for name, budget := range c.Providers {
+		if err = check(fmt.Sprintf("provider %q budget", name), budget); err != nil {
+			return nil, err
+		}
+	}
+	for i := range c.Tags {
+		if err = check(fmt.Sprintf("tag budget[%d] %q", i, c.Tags[i].Selector), &c.Tags[i].ScopedBudget); err != nil {
+			return nil, err
+		}
+	}
+	for typeName, budget := range c.Types {
+		if err = check(fmt.Sprintf("type %q budget", typeName), budget); err != nil {
+			return nil, err
+		}
+	}
+	return warnings, nil
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestCarbonBudgetsConfig_YAML(t *testing.T) {
+	var cfg BudgetsConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+carbon:
+  global:
+    amount: 500
+  providers:
+    aws:
+      amount: 300
+  tags:
+    - selector: "team:platform"
+      priority: 10
+      amount: 100
+  types:
+    aws:ec2/instance:
+      amount: 200
+`), &cfg))
+
+	require.NotNil(t, cfg.Carbon)
+	assert.InDelta(t, 500.0, cfg.Carbon.Global.Amount, 0.001)
+	assert.InDelta(t, 300.0, cfg.Carbon.Providers["aws"].Amount, 0.001)
+	assert.Equal(t, "team:platform", cfg.Carbon.Tags[0].Selector)
+	assert.InDelta(t, 200.0, cfg.Carbon.Types["aws:ec2/instance"].Amount, 0.001)
+	assert.True(t, cfg.HasCarbonBudgets())
+	assert.False(t, cfg.IsEnabled(), "carbon budgets do not enable cost budgets")
+
+	_, err := cfg.Validate()
+	require.NoError(t, err)
+}
+
+func TestCarbonBudgetsConfig_Scopes(t *testing.T) {
+	exitCode := 3
+	parent := &BudgetsConfig{ExitOnThreshold: true, ExitCode: &exitCode}
+	carbon := &CarbonBudgetsConfig{
+		Global: &ScopedBudget{Amount: 500},
+		Types:  map[string]*ScopedBudget{"aws:ec2/instance": {Amount: 200}},
+	}
+
+	scopes := carbon.Scopes(parent)
+	assert.Same(t, carbon.Global, scopes.Global)
+	assert.Equal(t, carbon.Types, scopes.Types)
+	assert.True(t, scopes.ExitOnThreshold)
+	assert.Equal(t, &exitCode, scopes.ExitCode)
+
+	var nilCarbon *CarbonBudgetsConfig
+	assert.Nil(t, nilCarbon.Scopes(parent))
+	assert.False(t, (&BudgetsConfig{}).HasCarbonBudgets())
+	assert.False(t, (&BudgetsConfig{Carbon: &CarbonBudgetsConfig{Global: &ScopedBudget{}}}).HasCarbonBudgets())
+}
+
+func TestCarbonBudgetsConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		carbon  *CarbonBudgetsConfig
+		wantErr error
+	}{
+		{
+			name:   "nil",
+			carbon: nil,
+		},
+		{
+			name:   "global only",
+			carbon: &CarbonBudgetsConfig{Global: &ScopedBudget{Amount: 500}},
+		},
+		{
+			name:    "scoped without global",
+			carbon:  &CarbonBudgetsConfig{Providers: map[string]*ScopedBudget{"aws": {Amount: 100}}},
+			wantErr: ErrGlobalBudgetRequired,
+		},
+		{
+			name:    "currency",
+			carbon:  &CarbonBudgetsConfig{Global: &ScopedBudget{Amount: 500, Currency: "USD"}},
+			wantErr: ErrCarbonBudgetCurrency,
+		},
+		{
+			name: "rollover",
+			carbon: &CarbonBudgetsConfig{
+				Global: &ScopedBudget{Amount: 500},
+				Types:  map[string]*ScopedBudget{"aws:ec2/instance": {Amount: 200, Rollover: true}},
+			},
+			wantErr: ErrCarbonBudgetRollover,
+		},
+		{
+			name: "tag currency",
+			carbon: &CarbonBudgetsConfig{
+				Global: &ScopedBudget{Amount: 500},
+				Tags: []TagBudget{
+					{ScopedBudget: ScopedBudget{Amount: 100, Currency: "EUR"}, Selector: "team:platform"},
+				},
+			},
+			wantErr: ErrCarbonBudgetCurrency,
+		},
+		{
+			name:    "negative amount",
+			carbon:  &CarbonBudgetsConfig{Global: &ScopedBudget{Amount: -1}},
+			wantErr: ErrBudgetAmountNegative,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			budgets := &BudgetsConfig{
+				Global: &ScopedBudget{Amount: 1000, Currency: "USD"},
+				Carbon: tt.carbon,
+			}
+			_, err := budgets.Validate()
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				assert.Contains(t, err.Error(), "carbon")
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
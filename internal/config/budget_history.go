@@ -0,0 +1,175 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// ErrHistoryCorrupted indicates the budget history file exists but contains invalid data.
+var ErrHistoryCorrupted = errors.New("budget history file corrupted")
+
+// BudgetHistoryVersion is the current schema version for the budget history file.
+const BudgetHistoryVersion = 1
+
+// BudgetPeriodRecord captures a budget scope's effective amount and spend for one period.
+type BudgetPeriodRecord struct {
+	// Amount is the effective budget amount for the period, including any rollover.
+	Amount float64 `json:"amount"`
+	// Spend is the most recently recorded spend for the period.
+	Spend float64 `json:"spend"`
+	// Currency is the budget currency at the time of recording.
+	Currency string `json:"currency,omitempty"`
+	// RecordedAt is when the record was last updated.
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// budgetHistoryData is the serialized form of the budget history store.
+type budgetHistoryData struct {
+	Version int                                       `json:"version"`
+	Scopes  map[string]map[string]*BudgetPeriodRecord `json:"scopes"`
+}
+
+// BudgetHistoryStore persists per-scope, per-period budget spend as a JSON file.
+// Scopes are keyed by their identifier ("global", "provider:aws", "stack:prod")
+// and periods by their period key ("2026-01" for monthly budgets).
+type BudgetHistoryStore struct {
+	mu       sync.RWMutex
+	filePath string
+	scopes   map[string]map[string]*BudgetPeriodRecord
+}
+
+// NewBudgetHistoryStore creates a new BudgetHistoryStore backed by the given file path.
+// If filePath is empty, it defaults to budget_history.json in the directory
+// returned by ResolveConfigDir (normally ~/.finfocus).
+func NewBudgetHistoryStore(filePath string) *BudgetHistoryStore {
+	if filePath == "" {
+		filePath = filepath.Join(ResolveConfigDir(), "budget_history.json")
+	}
+
+	return &BudgetHistoryStore{
+		filePath: filePath,
+		scopes:   make(map[string]map[string]*BudgetPeriodRecord),
+	}
+}
+
+// FilePath returns the path to the budget history file.
+func (s *BudgetHistoryStore) FilePath() string {
+	return s.filePath
+}
+
+// Load reads the budget history from the JSON file.
+// If the file does not exist, the store starts empty.
+// If the file is corrupted, ErrHistoryCorrupted is returned.
+func (s *BudgetHistoryStore) Load() error {
+	unlock, lockErr := acquireLockFile(s.filePath + ".lock")
+	if lockErr != nil {
+		return fmt.Errorf("acquiring file lock: %w", lockErr)
+	}
+	defer unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.scopes = make(map[string]map[string]*BudgetPeriodRecord)
+			return nil
+		}
+		return fmt.Errorf("reading budget history file: %w", err)
+	}
+
+	var storeData budgetHistoryData
+	if unmarshalErr := json.Unmarshal(data, &storeData); unmarshalErr != nil {
+		s.scopes = make(map[string]map[string]*BudgetPeriodRecord)
+		return fmt.Errorf("%w: %w", ErrHistoryCorrupted, unmarshalErr)
+	}
+
+	if storeData.Version != BudgetHistoryVersion {
+		s.scopes = make(map[string]map[string]*BudgetPeriodRecord)
+		return fmt.Errorf("%w: unsupported version %d (expected %d)",
+			ErrHistoryCorrupted, storeData.Version, BudgetHistoryVersion)
+	}
+
+	if storeData.Scopes == nil {
+		storeData.Scopes = make(map[string]map[string]*BudgetPeriodRecord)
+	}
+
+	s.scopes = storeData.Scopes
+	return nil
+}
+
+// Save writes the budget history to the JSON file atomically.
+func (s *BudgetHistoryStore) Save() error {
+	unlock, lockErr := acquireLockFile(s.filePath + ".lock")
+	if lockErr != nil {
+		return fmt.Errorf("acquiring file lock: %w", lockErr)
+	}
+	defer unlock()
+
+	s.mu.RLock()
+	data, err := json.MarshalIndent(budgetHistoryData{
+		Version: BudgetHistoryVersion,
+		Scopes:  s.scopes,
+	}, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshaling budget history: %w", err)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(s.filePath), 0o750); mkdirErr != nil {
+		return fmt.Errorf("creating budget history directory: %w", mkdirErr)
+	}
+
+	tmpPath := s.filePath + ".tmp"
+	if writeErr := os.WriteFile(tmpPath, data, 0o600); writeErr != nil {
+		return fmt.Errorf("writing budget history temp file: %w", writeErr)
+	}
+
+	if renameErr := os.Rename(tmpPath, s.filePath); renameErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("renaming budget history temp file: %w", renameErr)
+	}
+
+	return nil
+}
+
+// GetPeriod returns a copy of the record for a scope and period.
+// Returns nil and false if no record exists.
+func (s *BudgetHistoryStore) GetPeriod(scope, period string) (*BudgetPeriodRecord, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	record, ok := s.scopes[scope][period]
+	if !ok || record == nil {
+		return nil, false
+	}
+
+	recordCopy := *record
+	return &recordCopy, true
+}
+
+// RecordPeriod stores the effective amount and spend for a scope and period,
+// replacing any previous record for the same period.
+func (s *BudgetHistoryStore) RecordPeriod(scope, period string, record BudgetPeriodRecord) error {
+	if scope == "" {
+		return errors.New("budget scope cannot be empty")
+	}
+	if period == "" {
+		return errors.New("budget period cannot be empty")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.scopes[scope] == nil {
+		s.scopes[scope] = make(map[string]*BudgetPeriodRecord)
+	}
+	s.scopes[scope][period] = &record
+	return nil
+}
@@ -0,0 +1,93 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewBudgetHistoryStore(t *testing.T) {
+	t.Run("with explicit path", func(t *testing.T) {
+		expected := filepath.Join(t.TempDir(), "history.json")
+		assert.Equal(t, expected, NewBudgetHistoryStore(expected).FilePath())
+	})
+
+	t.Run("with empty path uses config dir", func(t *testing.T) {
+		dir := t.TempDir()
+		t.Setenv("FINFOCUS_HOME", dir)
+		assert.Equal(t, filepath.Join(dir, "budget_history.json"), NewBudgetHistoryStore("").FilePath())
+	})
+}
+
+func TestBudgetHistoryStore_LoadSave(t *testing.T) {
+	t.Parallel()
+
+	t.Run("missing file starts empty", func(t *testing.T) {
+		t.Parallel()
+		store := NewBudgetHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+		require.NoError(t, store.Load())
+		_, ok := store.GetPeriod("global", "2026-01")
+		assert.False(t, ok)
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "history.json")
+		recordedAt := time.Date(2026, 1, 31, 12, 0, 0, 0, time.UTC)
+
+		store := NewBudgetHistoryStore(path)
+		require.NoError(t, store.RecordPeriod("provider:aws", "2026-01", BudgetPeriodRecord{
+			Amount: 500, Spend: 420, Currency: "USD", RecordedAt: recordedAt,
+		}))
+		require.NoError(t, store.Save())
+
+		reloaded := NewBudgetHistoryStore(path)
+		require.NoError(t, reloaded.Load())
+		record, ok := reloaded.GetPeriod("provider:aws", "2026-01")
+		require.True(t, ok)
+		assert.InDelta(t, 500.0, record.Amount, 0.001)
+		assert.InDelta(t, 420.0, record.Spend, 0.001)
+		assert.Equal(t, "USD", record.Currency)
+		assert.True(t, recordedAt.Equal(record.RecordedAt))
+	})
+
+	t.Run("corrupted file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "history.json")
+		require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+		err := NewBudgetHistoryStore(path).Load()
+		require.ErrorIs(t, err, ErrHistoryCorrupted)
+	})
+
+	t.Run("unsupported version", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "history.json")
+		require.NoError(t, os.WriteFile(path, []byte(`{"version":99,"scopes":{}}`), 0o600))
+		err := NewBudgetHistoryStore(path).Load()
+		require.ErrorIs(t, err, ErrHistoryCorrupted)
+	})
+}
+
+func TestBudgetHistoryStore_RecordPeriod(t *testing.T) {
+	t.Parallel()
+
+	store := NewBudgetHistoryStore(filepath.Join(t.TempDir(), "history.json"))
+	require.Error(t, store.RecordPeriod("", "2026-01", BudgetPeriodRecord{}))
+	require.Error(t, store.RecordPeriod("global", "", BudgetPeriodRecord{}))
+
+	require.NoError(t, store.RecordPeriod("global", "2026-01", BudgetPeriodRecord{Amount: 100, Spend: 10}))
+	require.NoError(t, store.RecordPeriod("global", "2026-01", BudgetPeriodRecord{Amount: 100, Spend: 60}))
+
+	record, ok := store.GetPeriod("global", "2026-01")
+	require.True(t, ok)
+	assert.InDelta(t, 60.0, record.Spend, 0.001, "later records replace earlier ones")
+
+	// Mutating the returned copy must not affect the store
+	record.Spend = 0
+	again, _ := store.GetPeriod("global", "2026-01")
+	assert.InDelta(t, 60.0, again.Spend, 0.001)
+}
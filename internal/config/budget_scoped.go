@@ -18,6 +18,9 @@ var (
 	// ErrInvalidTagSelector is returned when a tag selector doesn't match the required format.
 	ErrInvalidTagSelector = errors.New("invalid tag selector format")
 
+	// ErrEmptyStackName is returned when a stack budget is keyed by an empty stack name.
+	ErrEmptyStackName = errors.New("stack budget name cannot be empty")
+
 	// ErrDuplicateTagPriority is returned when multiple tag budgets have the same priority.
 	// This is a warning condition, not a hard error.
 	ErrDuplicateTagPriority = errors.New("duplicate tag budget priority")
@@ -56,6 +59,10 @@ type ScopedBudget struct {
 	// If empty, uses default thresholds (50%, 80%, 100% actual).
 	Alerts []AlertConfig `yaml:"alerts,omitempty" json:"alerts,omitempty"`
 
+	// Rollover carries unspent budget from the previous period into the current one.
+	// The carried amount is derived from the recorded budget history.
+	Rollover bool `yaml:"rollover,omitempty" json:"rollover,omitempty"`
+
 	// ExitOnThreshold overrides the global setting for this scope.
 	// If nil, inherits from BudgetsConfig.ExitOnThreshold.
 	ExitOnThreshold *bool `yaml:"exit_on_threshold,omitempty" json:"exit_on_threshold,omitempty"`
@@ -308,6 +315,13 @@ type BudgetsConfig struct {
 	// Patterns use exact matching (e.g., "aws:ec2/instance").
 	Types map[string]*ScopedBudget `yaml:"types,omitempty" json:"types,omitempty"`
 
+	// Stacks maps Pulumi stack names (e.g., "dev", "prod") to their budgets.
+	// Stack names use exact matching against the stack segment of resource URNs.
+	Stacks map[string]*ScopedBudget `yaml:"stacks,omitempty" json:"stacks,omitempty"`
+
+	// Carbon holds carbon budgets in kgCO2e, evaluated alongside the cost budgets.
+	Carbon *CarbonBudgetsConfig `yaml:"carbon,omitempty" json:"carbon,omitempty"`
+
 	// ExitOnThreshold applies to all scopes unless overridden.
 	ExitOnThreshold bool `yaml:"exit_on_threshold,omitempty" json:"exit_on_threshold,omitempty"`
 
@@ -316,12 +330,12 @@ type BudgetsConfig struct {
 	ExitCode *int `yaml:"exit_code,omitempty" json:"exit_code,omitempty"`
 }
 
-// HasScopedBudgets returns true if any provider, tag, or type budgets are defined.
+// HasScopedBudgets returns true if any provider, tag, type, or stack budgets are defined.
 func (b *BudgetsConfig) HasScopedBudgets() bool {
 	if b == nil {
 		return false
 	}
-	return len(b.Providers) > 0 || len(b.Tags) > 0 || len(b.Types) > 0
+	return len(b.Providers) > 0 || len(b.Tags) > 0 || len(b.Types) > 0 || len(b.Stacks) > 0
 }
 
 // HasGlobalBudget returns true if a global budget is configured and enabled.
@@ -360,6 +374,48 @@ func (b *BudgetsConfig) IsEnabled() bool {
 		}
 	}
 
+	// Check stack budgets
+	for _, st := range b.Stacks {
+		if st != nil && st.IsEnabled() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasRollover returns true if any enabled budget (global or scoped) has rollover enabled.
+func (b *BudgetsConfig) HasRollover() bool {
+	if b == nil {
+		return false
+	}
+
+	rolls := func(s *ScopedBudget) bool { return s.IsEnabled() && s.Rollover }
+
+	if rolls(b.Global) {
+		return true
+	}
+	for _, p := range b.Providers {
+		if rolls(p) {
+			return true
+		}
+	}
+	for i := range b.Tags {
+		if rolls(&b.Tags[i].ScopedBudget) {
+			return true
+		}
+	}
+	for _, t := range b.Types {
+		if rolls(t) {
+			return true
+		}
+	}
+	for _, st := range b.Stacks {
+		if rolls(st) {
+			return true
+		}
+	}
+
 	return false
 }
 
@@ -442,6 +498,16 @@ func (b *BudgetsConfig) Validate() ([]string, error) {
 		return nil, err
 	}
 
+	if err = b.validateStackBudgets(globalCurrency); err != nil {
+		return nil, err
+	}
+
+	carbonWarnings, err := b.Carbon.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("carbon: %w", err)
+	}
+	warnings = append(warnings, carbonWarnings...)
+
 	// Validate exit code
 	if b.ExitCode != nil && (*b.ExitCode < MinExitCode || *b.ExitCode > MaxExitCode) {
 		return nil, fmt.Errorf("%w: got %d", ErrExitCodeOutOfRange, *b.ExitCode)
@@ -498,3 +564,19 @@ func (b *BudgetsConfig) validateTypeBudgets(globalCurrency string) error {
 	}
 	return nil
 }
+
+// validateStackBudgets validates all Pulumi stack budget configurations.
+func (b *BudgetsConfig) validateStackBudgets(globalCurrency string) error {
+	for stackName, stackBudget := range b.Stacks {
+		if strings.TrimSpace(stackName) == "" {
+			return ErrEmptyStackName
+		}
+		if stackBudget == nil {
+			continue
+		}
+		if validErr := stackBudget.Validate(globalCurrency); validErr != nil {
+			return fmt.Errorf("stack %q budget: %w", stackName, validErr)
+		}
+	}
+	return nil
+}
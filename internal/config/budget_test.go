@@ -265,6 +265,26 @@ func TestCostConfig_Validate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name:    "provider aliases",
+			cost:    CostConfig{ProviderAliases: map[string]string{"my-org": "aws"}},
+			wantErr: false,
+		},
+		{
+			name:    "provider alias without a provider",
+			cost:    CostConfig{ProviderAliases: map[string]string{"my-org": ""}},
+			wantErr: true,
+		},
+		{
+			name:    "spill threshold",
+			cost:    CostConfig{SpillThreshold: 500},
+			wantErr: false,
+		},
+		{
+			name:    "negative spill threshold",
+			cost:    CostConfig{SpillThreshold: -1},
+			wantErr: true,
+		},
 	}
 
 	for _, tc := range tests {
@@ -0,0 +1,70 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidChargeback is returned for invalid cost.chargeback settings.
+var ErrInvalidChargeback = errors.New("invalid chargeback configuration")
+
+// ChargebackConfig configures the invoices produced by 'finfocus report chargeback'.
+type ChargebackConfig struct {
+	// Issuer names the department issuing the invoices, e.g. "Platform Engineering".
+	Issuer string `yaml:"issuer,omitempty" json:"issuer,omitempty"`
+
+	// ChargebackRates are the markup and discount applied to every team
+	// without an entry in Teams.
+	ChargebackRates `yaml:",inline" json:",inline"`
+
+	// Teams overrides the markup and discount for individual teams. An entry
+	// replaces both default rates.
+	Teams map[string]ChargebackRates `yaml:"teams,omitempty" json:"teams,omitempty"`
+}
+
+// ChargebackRates adjust an invoice subtotal.
+type ChargebackRates struct {
+	// MarkupPercent is added to the subtotal, e.g. to recover platform overhead.
+	MarkupPercent float64 `yaml:"markup_percent,omitempty" json:"markup_percent,omitempty"`
+
+	// DiscountPercent is deducted from the subtotal, e.g. for committed-use savings.
+	DiscountPercent float64 `yaml:"discount_percent,omitempty" json:"discount_percent,omitempty"`
+}
+
+// RatesFor returns the markup and discount that apply to team.
+func (c *ChargebackConfig) RatesFor(team string) ChargebackRates {
+	if c == nil {
+		return ChargebackRates{}
+	}
+	if rates, ok := c.Teams[team]; ok {
+		return rates
+	}
+	return c.ChargebackRates
+}
+
+// Validate checks that markups are non-negative and discounts are between 0 and 100.
+func (c *ChargebackConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	if err := c.ChargebackRates.validate(); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidChargeback, err)
+	}
+	for team, rates := range c.Teams {
+		if err := rates.validate(); err != nil {
+			return fmt.Errorf("%w: team %q: %w", ErrInvalidChargeback, team, err)
+		}
+	}
+	return nil
+}
+
+// validate checks one set of rates.
+func (r ChargebackRates) validate() error {
+	if r.MarkupPercent < 0 {
+		return fmt.Errorf("markup_percent must not be negative, got %g", r.MarkupPercent)
+	}
+	if r.DiscountPercent < 0 || r.DiscountPercent > fullPercentage {
+		return fmt.Errorf("discount_percent must be between 0 and 100, got %g", r.DiscountPercent)
+	}
+	return nil
+}
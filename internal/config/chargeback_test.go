@@ -0,0 +1,39 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestChargebackConfig_RatesFor(t *testing.T) {
+	cfg := &ChargebackConfig{
+		ChargebackRates: ChargebackRates{MarkupPercent: 10},
+		Teams:           map[string]ChargebackRates{"payments": {DiscountPercent: 5}},
+	}
+
+	assert.Equal(t, ChargebackRates{MarkupPercent: 10}, cfg.RatesFor("frontend"))
+	assert.Equal(t, ChargebackRates{DiscountPercent: 5}, cfg.RatesFor("payments"),
+		"a team entry replaces both default rates")
+	assert.Equal(t, ChargebackRates{}, (*ChargebackConfig)(nil).RatesFor("payments"))
+}
+
+func TestChargebackConfig_Validate(t *testing.T) {
+	require.NoError(t, (*ChargebackConfig)(nil).Validate())
+	require.NoError(t, (&ChargebackConfig{
+		ChargebackRates: ChargebackRates{MarkupPercent: 150, DiscountPercent: 100},
+	}).Validate())
+
+	tests := map[string]*ChargebackConfig{
+		"negative markup":       {ChargebackRates: ChargebackRates{MarkupPercent: -1}},
+		"negative discount":     {ChargebackRates: ChargebackRates{DiscountPercent: -1}},
+		"discount above 100":    {ChargebackRates: ChargebackRates{DiscountPercent: 101}},
+		"invalid team override": {Teams: map[string]ChargebackRates{"x": {MarkupPercent: -5}}},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.ErrorIs(t, cfg.Validate(), ErrInvalidChargeback)
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// configComments are the comments SaveCommented writes above settings, keyed
+// by the dotted path of the setting.
+//
+//nolint:gochecknoglobals // Static table of config file comments.
+var configComments = map[string]string{
+	"output":                "Output formatting.",
+	"output.default_format": "Format of command output when --output is not given: table, json, or ndjson.",
+	"output.precision":      "Decimal places shown for costs.",
+	"output.no_color":       "Draw no colors, as with --no-color or NO_COLOR.",
+	"output.plain":          "Write plain text without styling or interactive views, as with --plain.",
+	"output.quiet":          "Leave out tips and progress displays, as with --quiet.",
+	"plugins": "Plugin-specific settings, keyed by plugin name. Installed plugins are in\n" +
+		"the plugins directory of the finfocus home; see 'finfocus plugin list'.",
+	"logging": "Logging. Logs go to the JSON file below at file_level, rotated by size and age;\n" +
+		"--debug also shows them on the console for one run.",
+	"analyzer":    "Pulumi analyzer (finfocus analyzer serve) timeouts and plugins.",
+	"plugin_host": "Plugin process behavior: spec compatibility and RPC resilience.",
+	"cost":        "Cost calculation settings.",
+	"cost.budgets": "Budgets compared with costs by 'budget status' and the cost commands.\n" +
+		"Scopes: global, providers, tags, types, and stacks.",
+	"cost.budgets.global":    "Budget for all resources together.",
+	"cost.cache":             "Cache of plugin query results.",
+	"cost.cache.ttl_seconds": "How long cached results are reused, in seconds.",
+	"currency": "Currency conversion: costs, savings, and budgets are reported in target.\n" +
+		"Rates come from the European Central Bank unless a source is configured.",
+}
+
+// SaveCommented saves the configuration to the config file like Save, with
+// comments describing the main settings, for config files meant to be read
+// and edited by hand.
+func (c *Config) SaveCommented() error {
+	if c.profile != "" {
+		return c.errProfileActive()
+	}
+	if c.projectPath != "" {
+		return c.errProjectActive()
+	}
+	defer c.withSecretReferences()()
+	if err := os.MkdirAll(filepath.Dir(c.configPath), 0700); err != nil {
+		return fmt.Errorf("failed to create config directory: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := doc.Encode(c); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	commentNode(&doc, "")
+
+	var buf bytes.Buffer
+	buf.WriteString("# FinFocus configuration. See 'finfocus config list' for the current values\n")
+	buf.WriteString("# and 'finfocus config set <key> <value>' to change them.\n\n")
+	encoder := yaml.NewEncoder(&buf)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	return os.WriteFile(c.configPath, buf.Bytes(), 0600)
+}
+
+// Path returns the path of the config file.
+func (c *Config) Path() string {
+	return c.configPath
+}
+
+// commentNode sets the comments of configComments on the keys of the mapping
+// node, whose dotted path is prefix, and of the mappings below it.
+func commentNode(node *yaml.Node, prefix string) {
+	if node.Kind == yaml.DocumentNode {
+		for _, child := range node.Content {
+			commentNode(child, prefix)
+		}
+		return
+	}
+	if node.Kind != yaml.MappingNode {
+		return
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+		path := key.Value
+		if prefix != "" {
+			path = prefix + "." + key.Value
+		}
+		if comment, ok := configComments[path]; ok {
+			key.HeadComment = comment
+		}
+		commentNode(value, path)
+	}
+}
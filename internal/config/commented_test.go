@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveCommented(t *testing.T) {
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+
+	cfg := New()
+	cfg.Output.DefaultFormat = "json"
+	cfg.Cost.Budgets = &BudgetsConfig{Global: &ScopedBudget{Amount: 1000, Currency: "USD"}}
+	require.NoError(t, cfg.SaveCommented())
+	assert.Equal(t, filepath.Join(home, "config.yaml"), cfg.Path())
+
+	data, err := os.ReadFile(cfg.Path())
+	require.NoError(t, err)
+	text := string(data)
+	assert.Contains(t, text, "# Output formatting.\noutput:\n")
+	assert.Contains(t, text, "    # Decimal places shown for costs.\n    precision: 2\n")
+	assert.Contains(t, text, "        # Budget for all resources together.\n        global:\n")
+
+	loaded := New()
+	assert.Equal(t, "json", loaded.Output.DefaultFormat)
+	require.NotNil(t, loaded.Cost.Budgets)
+	assert.InDelta(t, 1000, loaded.Cost.Budgets.Global.Amount, 1e-9)
+}
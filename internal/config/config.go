@@ -13,6 +13,8 @@ import (
 	"gopkg.in/yaml.v3"
 
 	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
+
+	"github.com/rshade/finfocus/internal/secrets"
 )
 
 // Duration is a wrapper around time.Duration that supports YAML/JSON parsing.
@@ -57,6 +59,11 @@ const (
 	// Cache defaults.
 	defaultCacheTTLSeconds = 3600 // 1 hour default
 	defaultCacheMaxSizeMB  = 100  // 100 MB default
+
+	// Log file rotation defaults.
+	defaultLogMaxSizeMB  = 10
+	defaultLogMaxAgeDays = 7
+	defaultLogMaxFiles   = 5
 )
 
 // ErrConfigCorrupted is returned in strict mode when the config file exists but cannot be parsed.
@@ -80,8 +87,51 @@ type Config struct {
 	// If nil, automatic provider-based routing is used (FR-023 backward compatibility).
 	Routing *RoutingConfig `yaml:"routing,omitempty" json:"routing,omitempty"`
 
+	// Recommendations configures the recommendations workflow, including the
+	// shared dismissal store remote. Nil when not configured.
+	Recommendations *RecommendationsConfig `yaml:"recommendations,omitempty" json:"recommendations,omitempty"`
+
+	// Pulumi configures Pulumi Cloud access for fully qualified --stack names.
+	// Nil when not configured.
+	Pulumi *PulumiConfig `yaml:"pulumi,omitempty" json:"pulumi,omitempty"`
+
+	// Notify configures where 'finfocus notify' delivers digests. Nil when
+	// not configured.
+	Notify *NotifyConfig `yaml:"notify,omitempty" json:"notify,omitempty"`
+
+	// Tracing configures OpenTelemetry trace export. Nil when not configured.
+	Tracing *TracingConfig `yaml:"tracing,omitempty" json:"tracing,omitempty"`
+
+	// Currency configures conversion of all costs into one currency. Nil
+	// when not configured.
+	Currency *CurrencyConfig `yaml:"currency,omitempty" json:"currency,omitempty"`
+
+	// TUI holds preferences of the interactive views: the color theme and
+	// the column layouts the views save. Nil until one is set.
+	TUI *TUIConfig `yaml:"tui,omitempty" json:"tui,omitempty"`
+
+	// Defaults holds the default region of each provider's resources. Nil
+	// when not configured.
+	Defaults *DefaultsConfig `yaml:"defaults,omitempty" json:"defaults,omitempty"`
+
+	// Policy holds the cost policies 'policy check' enforces when --policy
+	// is omitted. Nil when not configured.
+	Policy *PolicyConfig `yaml:"policy,omitempty" json:"policy,omitempty"`
+
+	// Profiles are named sets of settings, such as the plugins, budgets, and
+	// currency of one client or environment, layered over the settings above
+	// when selected with --profile or FINFOCUS_PROFILE. Each profile has the
+	// layout of the config file.
+	Profiles map[string]yaml.Node `yaml:"profiles,omitempty" json:"-"`
+
 	// Internal fields
 	configPath string
+	profile    string
+	// projectPath is the path of the applied project config file.
+	projectPath string
+	// secrets are the settings loaded or set from secret references, keyed
+	// by dotted key.
+	secrets map[string]secretRef
 }
 
 // PluginHostConfig defines plugin host behavior settings.
@@ -90,12 +140,30 @@ type PluginHostConfig struct {
 	// When true, plugins with incompatible spec versions will fail to load.
 	// When false (default), a warning is logged but initialization continues.
 	StrictCompatibility bool `yaml:"strict_compatibility" json:"strict_compatibility"`
+
+	// Resilience sets the default timeout, retry, and circuit-breaker policy for plugin RPCs.
+	Resilience ResilienceConfig `yaml:"resilience,omitempty" json:"resilience,omitempty"`
+
+	// Plugins overrides Resilience for individual plugins, keyed by plugin name.
+	Plugins map[string]ResilienceConfig `yaml:"plugins,omitempty" json:"plugins,omitempty"`
+
+	// RecordDir and ReplayDir are set from the cost commands' --record-traffic and
+	// --replay-traffic flags for the current run; they are never read from or
+	// written to the config file.
+	RecordDir string `yaml:"-" json:"-"`
+	ReplayDir string `yaml:"-" json:"-"`
 }
 
 // OutputConfig defines output formatting preferences.
 type OutputConfig struct {
 	DefaultFormat string `yaml:"default_format" json:"default_format"`
 	Precision     int    `yaml:"precision"      json:"precision"`
+
+	// NoColor, Plain, and Quiet are the defaults of the --no-color,
+	// --plain, and --quiet flags.
+	NoColor bool `yaml:"no_color,omitempty" json:"no_color,omitempty"`
+	Plain   bool `yaml:"plain,omitempty"    json:"plain,omitempty"`
+	Quiet   bool `yaml:"quiet,omitempty"    json:"quiet,omitempty"`
 }
 
 // PluginConfig defines plugin-specific configuration.
@@ -108,8 +176,15 @@ type LoggingConfig struct {
 	Level   string      `yaml:"level"   json:"level"`
 	Format  string      `yaml:"format"  json:"format"`  // "json" or "text"
 	Outputs []LogOutput `yaml:"outputs" json:"outputs"` // Multiple output destinations
-	File    string      `yaml:"file"    json:"file"`    // Legacy: single file output
+	File    string      `yaml:"file"    json:"file"`    // JSON log file, rotated by size and age
 	Audit   AuditConfig `yaml:"audit"   json:"audit"`   // Audit logging configuration
+
+	// FileLevel is the level of the log file, independent of Level, so the
+	// file holds debug logs for bug reports without --debug.
+	FileLevel  string `yaml:"file_level"   json:"file_level"`
+	MaxSizeMB  int    `yaml:"max_size_mb"  json:"max_size_mb"`  // Log file rotation size (0 = never)
+	MaxAgeDays int    `yaml:"max_age_days" json:"max_age_days"` // Age of rotated log files removed (0 = never)
+	MaxFiles   int    `yaml:"max_files"    json:"max_files"`    // Rotated log files kept (0 = all)
 }
 
 // AuditConfig defines audit logging settings for cost query operations.
@@ -178,12 +253,16 @@ func ResolveConfigDir() string {
 	return filepath.Join(homeDir, ".finfocus")
 }
 
-// New creates a new configuration with defaults.
-// In strict mode (FINFOCUS_CONFIG_STRICT=true), corrupted config files cause a panic.
-func New() *Config {
-	finfocusDir := ResolveConfigDir()
+// FilePath returns the path of the config file in the directory returned by
+// ResolveConfigDir (normally ~/.finfocus/config.yaml).
+func FilePath() string {
+	return filepath.Join(ResolveConfigDir(), "config.yaml")
+}
 
-	cfg := &Config{
+// defaultConfig returns the default configuration of the finfocus home
+// finfocusDir, without reading the config file.
+func defaultConfig(finfocusDir string) *Config {
+	return &Config{
 		// Legacy fields
 		PluginDir: filepath.Join(finfocusDir, "plugins"),
 		SpecDir:   filepath.Join(finfocusDir, "specs"),
@@ -198,6 +277,11 @@ func New() *Config {
 			Level:  "info",
 			Format: "text",
 			File:   filepath.Join(finfocusDir, "logs", "finfocus.log"),
+
+			FileLevel:  "debug",
+			MaxSizeMB:  defaultLogMaxSizeMB,
+			MaxAgeDays: defaultLogMaxAgeDays,
+			MaxFiles:   defaultLogMaxFiles,
 			Outputs: []LogOutput{
 				{
 					Type:   "console",
@@ -225,6 +309,27 @@ func New() *Config {
 
 		configPath: filepath.Join(finfocusDir, "config.yaml"),
 	}
+}
+
+// New creates a new configuration with defaults, layering the project
+// config file of the working directory over the user config file.
+// In strict mode (FINFOCUS_CONFIG_STRICT=true), corrupted config files cause a panic.
+func New() *Config {
+	return newConfig(true)
+}
+
+// NewUserConfig creates a new configuration like New, without a project
+// config file, for commands that change the user config file.
+func NewUserConfig() *Config {
+	return newConfig(false)
+}
+
+// newConfig creates a new configuration, with the project config file of
+// the working directory when withProject is true.
+func newConfig(withProject bool) *Config {
+	finfocusDir := ResolveConfigDir()
+
+	cfg := defaultConfig(finfocusDir)
 
 	// Check for strict mode
 	strictMode := os.Getenv("FINFOCUS_CONFIG_STRICT") == "true" ||
@@ -254,6 +359,32 @@ func New() *Config {
 		}
 	}
 
+	// Layer the selected profile over the file
+	if err := cfg.applyActiveProfile(); err != nil {
+		if strictMode {
+			panic(fmt.Sprintf("STRICT MODE: %v", err))
+		}
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	// Layer the project config file over the user configuration
+	if withProject {
+		if err := cfg.applyProjectFile(); err != nil {
+			if strictMode {
+				panic(fmt.Sprintf("STRICT MODE: %v", err))
+			}
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		}
+	}
+
+	// Resolve secret references, leaving settings that do not resolve empty
+	if err := cfg.resolveSecrets(); err != nil {
+		if strictMode {
+			panic(fmt.Sprintf("STRICT MODE: %v", err))
+		}
+		warnSecretsOnce(err)
+	}
+
 	// Apply environment variable overrides
 	cfg.applyEnvOverrides()
 
@@ -266,48 +397,7 @@ func New() *Config {
 func NewStrict() (*Config, error) {
 	finfocusDir := ResolveConfigDir()
 
-	cfg := &Config{
-		// Legacy fields
-		PluginDir: filepath.Join(finfocusDir, "plugins"),
-		SpecDir:   filepath.Join(finfocusDir, "specs"),
-
-		// New configuration
-		Output: OutputConfig{
-			DefaultFormat: "table",
-			Precision:     defaultPrecision,
-		},
-		Plugins: make(map[string]PluginConfig),
-		Logging: LoggingConfig{
-			Level:  "info",
-			Format: "text",
-			File:   filepath.Join(finfocusDir, "logs", "finfocus.log"),
-			Outputs: []LogOutput{
-				{
-					Type:   "console",
-					Level:  "info",
-					Format: "text",
-				},
-			},
-		},
-		Analyzer: AnalyzerConfig{
-			Timeout: AnalyzerTimeout{
-				PerResource:   Duration(defaultPerResourceTimeout),
-				Total:         Duration(defaultTotalTimeout),
-				WarnThreshold: Duration(defaultWarnThresholdTimeout),
-			},
-			Plugins: make(map[string]AnalyzerPlugin),
-		},
-		Cost: CostConfig{
-			Cache: CacheConfig{
-				Enabled:    true,
-				TTLSeconds: defaultCacheTTLSeconds,
-				Directory:  filepath.Join(finfocusDir, "cache"),
-				MaxSizeMB:  defaultCacheMaxSizeMB,
-			},
-		},
-
-		configPath: filepath.Join(finfocusDir, "config.yaml"),
-	}
+	cfg := defaultConfig(finfocusDir)
 
 	// Load from file with strict error handling
 	if loadErr := cfg.Load(); loadErr != nil {
@@ -323,6 +413,21 @@ func NewStrict() (*Config, error) {
 		}
 	}
 
+	// Layer the selected profile over the file
+	if err := cfg.applyActiveProfile(); err != nil {
+		return nil, err
+	}
+
+	// Layer the project config file over the user configuration
+	if err := cfg.applyProjectFile(); err != nil {
+		return nil, err
+	}
+
+	// Resolve secret references
+	if err := cfg.resolveSecrets(); err != nil {
+		return nil, err
+	}
+
 	// Apply environment variable overrides
 	cfg.applyEnvOverrides()
 
@@ -344,8 +449,19 @@ func (c *Config) Load() error {
 	return yaml.Unmarshal(data, c)
 }
 
-// Save saves the current configuration to the config file.
+// Save saves the current configuration to the config file. Settings loaded
+// or set from secret references are saved as the references. It returns
+// ErrProfileActive when a profile is applied, and ErrProjectActive when a
+// project config file is.
 func (c *Config) Save() error {
+	if c.profile != "" {
+		return c.errProfileActive()
+	}
+	if c.projectPath != "" {
+		return c.errProjectActive()
+	}
+	defer c.withSecretReferences()()
+
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(c.configPath), 0700); err != nil {
 		return fmt.Errorf("failed to create config directory: %w", err)
@@ -359,8 +475,22 @@ func (c *Config) Save() error {
 	return os.WriteFile(c.configPath, data, 0600)
 }
 
-// Set sets a configuration value using dot notation.
+// Set sets a configuration value using dot notation. Secret settings accept
+// secret references, such as env://SLACK_BOT_TOKEN, which are resolved
+// right away and saved as references.
 func (c *Config) Set(key, value string) error {
+	if secrets.IsReference(value) {
+		return c.setSecretReference(key, value)
+	}
+	if err := c.setValue(key, value); err != nil {
+		return err
+	}
+	delete(c.secrets, key)
+	return nil
+}
+
+// setValue sets a configuration value using dot notation.
+func (c *Config) setValue(key, value string) error {
 	parts := strings.Split(key, ".")
 	if len(parts) < 1 {
 		return errors.New("invalid key format")
@@ -377,6 +507,22 @@ func (c *Config) Set(key, value string) error {
 		return c.setPluginHostValue(parts[1:], value)
 	case "cost":
 		return c.setCostValue(parts[1:], value)
+	case "recommendations":
+		return c.setRecommendationsValue(parts[1:], value)
+	case "pulumi":
+		return c.setPulumiValue(parts[1:], value)
+	case "notify":
+		return c.setNotifyValue(parts[1:], value)
+	case "tracing":
+		return c.setTracingValue(parts[1:], value)
+	case "currency":
+		return c.setCurrencyValue(parts[1:], value)
+	case "tui":
+		return c.setTUIValue(parts[1:], value)
+	case "defaults":
+		return c.setDefaultsValue(parts[1:], value)
+	case "policy":
+		return c.setPolicyValue(parts[1:], value)
 	default:
 		return fmt.Errorf("unknown configuration section: %s", parts[0])
 	}
@@ -400,6 +546,22 @@ func (c *Config) Get(key string) (interface{}, error) {
 		return c.getPluginHostValue(parts[1:])
 	case "cost":
 		return c.getCostValue(parts[1:])
+	case "recommendations":
+		return c.getRecommendationsValue(parts[1:])
+	case "pulumi":
+		return c.getPulumiValue(parts[1:])
+	case "notify":
+		return c.getNotifyValue(parts[1:])
+	case "tracing":
+		return c.getTracingValue(parts[1:])
+	case "currency":
+		return c.getCurrencyValue(parts[1:])
+	case "tui":
+		return c.getTUIValue(parts[1:])
+	case "defaults":
+		return c.getDefaultsValue(parts[1:])
+	case "policy":
+		return c.getPolicyValue(parts[1:])
 	default:
 		return nil, fmt.Errorf("unknown configuration section: %s", parts[0])
 	}
@@ -408,13 +570,21 @@ func (c *Config) Get(key string) (interface{}, error) {
 // List returns all configuration as a map.
 func (c *Config) List() map[string]interface{} {
 	return map[string]interface{}{
-		"output":      c.Output,
-		"plugins":     c.Plugins,
-		"logging":     c.Logging,
-		"analyzer":    c.Analyzer,
-		"plugin_host": c.PluginHostConfig,
-		"cost":        c.Cost,
-		"routing":     c.Routing,
+		"output":          c.Output,
+		"plugins":         c.listedPlugins(),
+		"logging":         c.Logging,
+		"analyzer":        c.listedAnalyzer(),
+		"plugin_host":     c.PluginHostConfig,
+		"cost":            c.Cost,
+		"routing":         c.Routing,
+		"recommendations": c.Recommendations,
+		"pulumi":          c.Pulumi.redacted(),
+		"notify":          c.Notify.redacted(),
+		"tracing":         c.Tracing,
+		"currency":        c.Currency.redacted(),
+		"tui":             c.TUI,
+		"defaults":        c.Defaults,
+		"policy":          c.Policy,
 	}
 }
 
@@ -452,6 +622,10 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("plugin configuration validation failed: %w", err)
 	}
 
+	if err := c.PluginHostConfig.Validate(); err != nil {
+		return fmt.Errorf("plugin_host configuration validation failed: %w", err)
+	}
+
 	// Validate cost configuration
 	if err := c.Cost.Validate(); err != nil {
 		return fmt.Errorf("cost configuration validation failed: %w", err)
@@ -464,6 +638,46 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate recommendations configuration if present
+	if err := c.Recommendations.Validate(); err != nil {
+		return fmt.Errorf("recommendations configuration validation failed: %w", err)
+	}
+
+	// Validate Pulumi Cloud configuration if present
+	if err := c.Pulumi.Validate(); err != nil {
+		return fmt.Errorf("pulumi configuration validation failed: %w", err)
+	}
+
+	// Validate notification configuration if present
+	if err := c.Notify.Validate(); err != nil {
+		return fmt.Errorf("notify configuration validation failed: %w", err)
+	}
+
+	// Validate tracing configuration if present
+	if err := c.Tracing.Validate(); err != nil {
+		return fmt.Errorf("tracing configuration validation failed: %w", err)
+	}
+
+	// Validate currency configuration if present
+	if err := c.Currency.Validate(); err != nil {
+		return fmt.Errorf("currency configuration validation failed: %w", err)
+	}
+
+	// Validate TUI theme configuration if present
+	if err := c.TUI.Validate(); err != nil {
+		return fmt.Errorf("tui configuration validation failed: %w", err)
+	}
+
+	// Validate default regions if present
+	if err := c.Defaults.Validate(); err != nil {
+		return fmt.Errorf("defaults configuration validation failed: %w", err)
+	}
+
+	// Validate policies if present
+	if err := c.Policy.Validate(); err != nil {
+		return fmt.Errorf("policy configuration validation failed: %w", err)
+	}
+
 	return nil
 }
 
@@ -476,6 +690,15 @@ func (c *Config) validateLogging() error {
 		}
 	}
 
+	if c.Logging.FileLevel != "" {
+		if err := isValidLevel(c.Logging.FileLevel); err != nil {
+			return fmt.Errorf("file_level: %w", err)
+		}
+	}
+	if c.Logging.MaxSizeMB < 0 || c.Logging.MaxAgeDays < 0 || c.Logging.MaxFiles < 0 {
+		return errors.New("max_size_mb, max_age_days, and max_files must not be negative (0 means unlimited)")
+	}
+
 	// Validate logging format
 	if c.Logging.Format != "" {
 		if err := isValidFormat(c.Logging.Format); err != nil {
@@ -862,6 +1085,19 @@ func (c *Config) setOutputValue(parts []string, value string) error {
 			return fmt.Errorf("precision must be a number: %w", err)
 		}
 		c.Output.Precision = p
+	case "no_color", "plain", "quiet":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%s must be a boolean: %w", parts[0], err)
+		}
+		switch parts[0] {
+		case "no_color":
+			c.Output.NoColor = b
+		case "plain":
+			c.Output.Plain = b
+		default:
+			c.Output.Quiet = b
+		}
 	default:
 		return fmt.Errorf("unknown output setting: %s", parts[0])
 	}
@@ -899,6 +1135,21 @@ func (c *Config) setLoggingValue(parts []string, value string) error {
 		c.Logging.Level = value
 	case "file":
 		c.Logging.File = value
+	case "file_level":
+		c.Logging.FileLevel = value
+	case "max_size_mb", "max_age_days", "max_files":
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid %s value: %s", parts[0], value)
+		}
+		switch parts[0] {
+		case "max_size_mb":
+			c.Logging.MaxSizeMB = n
+		case "max_age_days":
+			c.Logging.MaxAgeDays = n
+		default:
+			c.Logging.MaxFiles = n
+		}
 	default:
 		return fmt.Errorf("unknown logging setting: %s", parts[0])
 	}
@@ -917,18 +1168,25 @@ func (c *Config) getOutputValue(parts []string) (interface{}, error) {
 		return c.Output.DefaultFormat, nil
 	case "precision":
 		return c.Output.Precision, nil
+	case "no_color":
+		return c.Output.NoColor, nil
+	case "plain":
+		return c.Output.Plain, nil
+	case "quiet":
+		return c.Output.Quiet, nil
 	default:
 		return nil, fmt.Errorf("unknown output setting: %s", parts[0])
 	}
 }
 
 func (c *Config) getPluginValue(parts []string) (interface{}, error) {
+	plugins := c.listedPlugins()
 	if len(parts) < 1 {
-		return c.Plugins, nil
+		return plugins, nil
 	}
 
 	pluginName := parts[0]
-	plugin, exists := c.Plugins[pluginName]
+	plugin, exists := plugins[pluginName]
 	if !exists {
 		return nil, fmt.Errorf("plugin not found: %s", pluginName)
 	}
@@ -956,6 +1214,14 @@ func (c *Config) getLoggingValue(parts []string) (interface{}, error) {
 		return c.Logging.Level, nil
 	case "file":
 		return c.Logging.File, nil
+	case "file_level":
+		return c.Logging.FileLevel, nil
+	case "max_size_mb":
+		return c.Logging.MaxSizeMB, nil
+	case "max_age_days":
+		return c.Logging.MaxAgeDays, nil
+	case "max_files":
+		return c.Logging.MaxFiles, nil
 	default:
 		return nil, fmt.Errorf("unknown logging setting: %s", parts[0])
 	}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -142,6 +143,13 @@ func TestConfig_SetGetValues(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 4, value)
 
+	for _, key := range []string{"output.no_color", "output.plain", "output.quiet"} {
+		require.NoError(t, cfg.Set(key, "true"))
+		value, err = cfg.Get(key)
+		require.NoError(t, err)
+		assert.Equal(t, true, value, key)
+	}
+
 	// Test plugin values
 	err = cfg.Set("plugins.aws.region", "us-west-2")
 	require.NoError(t, err)
@@ -173,6 +181,48 @@ func TestConfig_SetGetValues(t *testing.T) {
 	value, err = cfg.Get("plugin_host.strict_compatibility")
 	require.NoError(t, err)
 	assert.Equal(t, false, value)
+
+	// Test recommendations values
+	err = cfg.Set("recommendations.min_savings", "25.5")
+	require.NoError(t, err)
+
+	value, err = cfg.Get("recommendations.min_savings")
+	require.NoError(t, err)
+	assert.InDelta(t, 25.5, value, 0.0001)
+	assert.InDelta(t, 25.5, cfg.MinRecommendationSavings(), 0.0001)
+
+	// Test pulumi values; the access token is never returned in clear text
+	require.NoError(t, cfg.Set("pulumi.access_token", "pul-secret"))
+	require.NoError(t, cfg.Set("pulumi.api_url", "https://pulumi.example.com"))
+	assert.Equal(t, "pul-secret", cfg.PulumiAccessToken())
+	assert.Equal(t, "https://pulumi.example.com", cfg.PulumiAPIURL())
+
+	value, err = cfg.Get("pulumi.access_token")
+	require.NoError(t, err)
+	assert.Equal(t, "********", value)
+	assert.NotContains(t, fmt.Sprint(cfg.List()["pulumi"]), "pul-secret")
+
+	// Test notify values; the webhook URL and bot token are never returned in clear text
+	require.NoError(t, cfg.Set("notify.slack.webhook_url", "https://hooks.slack.com/services/T/B/secret"))
+	require.NoError(t, cfg.Set("notify.slack.channel", "#finops"))
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/secret", cfg.SlackSettings().WebhookURL)
+
+	value, err = cfg.Get("notify.slack.channel")
+	require.NoError(t, err)
+	assert.Equal(t, "#finops", value)
+	value, err = cfg.Get("notify.slack.webhook_url")
+	require.NoError(t, err)
+	assert.Equal(t, "********", value)
+	assert.NotContains(t, fmt.Sprint(cfg.List()["notify"]), "secret")
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/secret", cfg.SlackSettings().WebhookURL,
+		"redaction does not modify the config")
+
+	// Test tracing values
+	require.NoError(t, cfg.Set("tracing.endpoint", "http://localhost:4318"))
+	value, err = cfg.Get("tracing.endpoint")
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:4318", value)
+	assert.Equal(t, "http://localhost:4318", cfg.TracingEndpoint())
 }
 
 func TestConfig_SetErrors(t *testing.T) {
@@ -194,6 +244,11 @@ func TestConfig_SetErrors(t *testing.T) {
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "precision must be a number")
 
+	// Invalid output control value
+	err = cfg.Set("output.quiet", "sometimes")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "quiet must be a boolean")
+
 	// Invalid plugin key format
 	err = cfg.Set("plugins.aws", "value")
 	assert.Error(t, err)
@@ -208,6 +263,38 @@ func TestConfig_SetErrors(t *testing.T) {
 	err = cfg.Set("plugin_host.strict_compatibility", "not-a-bool")
 	assert.Error(t, err)
 	assert.Contains(t, err.Error(), "strict_compatibility must be a boolean")
+
+	// Invalid recommendations min_savings value
+	err = cfg.Set("recommendations.min_savings", "-5")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a non-negative number")
+
+	// Invalid pulumi key and api_url
+	err = cfg.Set("pulumi.token", "value")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown pulumi setting")
+
+	err = cfg.Set("pulumi.api_url", "api.pulumi.com")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an http(s) URL")
+
+	// Invalid notify key and webhook URL
+	err = cfg.Set("notify.slack.token", "value")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown notify setting")
+
+	err = cfg.Set("notify.slack.webhook_url", "http://hooks.slack.com/services/x")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an https URL")
+
+	// Invalid tracing key and endpoint
+	err = cfg.Set("tracing.insecure", "true")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unknown tracing setting")
+
+	err = cfg.Set("tracing.endpoint", "localhost:4318")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "must be an http(s) URL")
 }
 
 func TestConfig_GetErrors(t *testing.T) {
@@ -0,0 +1,214 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Exchange rate sources accepted by currency.source.
+const (
+	CurrencySourceStatic            = "static"
+	CurrencySourceECB               = "ecb"
+	CurrencySourceOpenExchangeRates = "openexchangerates"
+)
+
+// errUnknownCurrencyKey is returned for unsupported currency.* keys.
+var errUnknownCurrencyKey = errors.New("unknown currency setting (supported: currency.target, currency.source, " +
+	"currency.base, currency.rates.<CODE>, currency.app_id, currency.cache_ttl_seconds)")
+
+// CurrencyConfig configures conversion of costs into a single currency.
+type CurrencyConfig struct {
+	// Target is the currency all costs are converted into when --currency is
+	// not given. Empty reports costs in the currencies plugins return.
+	Target string `yaml:"target,omitempty" json:"target,omitempty"`
+
+	// Source selects where exchange rates come from: "static" (Rates below),
+	// "ecb" (European Central Bank daily rates), or "openexchangerates".
+	// Defaults to "static" when Rates is set and "ecb" otherwise.
+	Source string `yaml:"source,omitempty" json:"source,omitempty"`
+
+	// Base is the currency the static Rates are quoted against (default USD).
+	Base string `yaml:"base,omitempty" json:"base,omitempty"`
+
+	// Rates maps currency codes to how many units one unit of Base buys.
+	Rates map[string]float64 `yaml:"rates,omitempty" json:"rates,omitempty"`
+
+	// AppID authenticates openexchangerates.org requests.
+	// OPENEXCHANGERATES_APP_ID takes precedence when set.
+	AppID string `yaml:"app_id,omitempty" json:"app_id,omitempty"`
+
+	// CacheTTLSeconds is how long fetched rates are reused (default 86400).
+	CacheTTLSeconds int `yaml:"cache_ttl_seconds,omitempty" json:"cache_ttl_seconds,omitempty"`
+}
+
+// Validate checks currency codes, the source, rates, and the cache TTL.
+func (c *CurrencyConfig) Validate() error {
+	if c == nil {
+		return nil
+	}
+	for _, code := range []string{c.Target, c.Base} {
+		if code != "" {
+			if err := validateCurrencyCode(code); err != nil {
+				return err
+			}
+		}
+	}
+	switch c.Source {
+	case "", CurrencySourceStatic, CurrencySourceECB, CurrencySourceOpenExchangeRates:
+	default:
+		return fmt.Errorf("invalid currency source %q (supported: %s, %s, %s)", c.Source,
+			CurrencySourceStatic, CurrencySourceECB, CurrencySourceOpenExchangeRates)
+	}
+	if c.Source == CurrencySourceStatic && len(c.Rates) == 0 {
+		return errors.New("currency source static needs currency.rates")
+	}
+	for code, rate := range c.Rates {
+		if err := validateCurrencyCode(code); err != nil {
+			return err
+		}
+		if rate <= 0 {
+			return fmt.Errorf("currency rate for %s must be positive, got %g", code, rate)
+		}
+	}
+	if c.CacheTTLSeconds < 0 {
+		return fmt.Errorf("currency cache_ttl_seconds must not be negative, got %d", c.CacheTTLSeconds)
+	}
+	return nil
+}
+
+// RateSource returns the configured rate source, applying the default.
+func (c *CurrencyConfig) RateSource() string {
+	switch {
+	case c == nil:
+		return CurrencySourceECB
+	case c.Source != "":
+		return c.Source
+	case len(c.Rates) > 0:
+		return CurrencySourceStatic
+	default:
+		return CurrencySourceECB
+	}
+}
+
+// RateBase returns the currency static rates are quoted against.
+func (c *CurrencyConfig) RateBase() string {
+	if c == nil || c.Base == "" {
+		return "USD"
+	}
+	return c.Base
+}
+
+// redacted returns a copy of c with the app ID masked.
+func (c *CurrencyConfig) redacted() *CurrencyConfig {
+	if c == nil {
+		return nil
+	}
+	out := *c
+	if out.AppID != "" {
+		out.AppID = redactedValue
+	}
+	return &out
+}
+
+// CurrencyTarget returns the configured target currency, or "" if none.
+func (c *Config) CurrencyTarget() string {
+	if c.Currency == nil {
+		return ""
+	}
+	return c.Currency.Target
+}
+
+// setCurrencyValue sets a currency.* configuration value.
+func (c *Config) setCurrencyValue(parts []string, value string) error {
+	if len(parts) == 0 {
+		return errUnknownCurrencyKey
+	}
+	updated := CurrencyConfig{}
+	if c.Currency != nil {
+		updated = *c.Currency
+	}
+
+	switch {
+	case len(parts) == 1 && parts[0] == "target":
+		updated.Target = strings.ToUpper(value)
+	case len(parts) == 1 && parts[0] == "source":
+		updated.Source = strings.ToLower(value)
+	case len(parts) == 1 && parts[0] == "base":
+		updated.Base = strings.ToUpper(value)
+	case len(parts) == 1 && parts[0] == "app_id":
+		updated.AppID = value
+	case len(parts) == 1 && parts[0] == "cache_ttl_seconds":
+		ttl, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid cache_ttl_seconds %q: %w", value, err)
+		}
+		updated.CacheTTLSeconds = ttl
+	case len(parts) == 2 && parts[0] == "rates": //nolint:mnd // rates.<CODE>
+		rate, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid rate %q: %w", value, err)
+		}
+		rates := make(map[string]float64, len(updated.Rates)+1)
+		for k, v := range updated.Rates {
+			rates[k] = v
+		}
+		rates[strings.ToUpper(parts[1])] = rate
+		updated.Rates = rates
+	default:
+		return errUnknownCurrencyKey
+	}
+
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	c.Currency = &updated
+	return nil
+}
+
+// getCurrencyValue gets a currency.* configuration value.
+func (c *Config) getCurrencyValue(parts []string) (interface{}, error) {
+	settings := c.Currency.redacted()
+	if len(parts) == 0 {
+		return settings, nil
+	}
+	if settings == nil {
+		settings = &CurrencyConfig{}
+	}
+	switch {
+	case len(parts) == 1 && parts[0] == "target":
+		return settings.Target, nil
+	case len(parts) == 1 && parts[0] == "source":
+		return settings.RateSource(), nil
+	case len(parts) == 1 && parts[0] == "base":
+		return settings.RateBase(), nil
+	case len(parts) == 1 && parts[0] == "app_id":
+		return settings.AppID, nil
+	case len(parts) == 1 && parts[0] == "cache_ttl_seconds":
+		return settings.CacheTTLSeconds, nil
+	case len(parts) == 1 && parts[0] == "rates":
+		return settings.Rates, nil
+	case len(parts) == 2 && parts[0] == "rates": //nolint:mnd // rates.<CODE>
+		rate, ok := settings.Rates[strings.ToUpper(parts[1])]
+		if !ok {
+			return nil, fmt.Errorf("no rate configured for %s", strings.ToUpper(parts[1]))
+		}
+		return rate, nil
+	default:
+		return nil, errUnknownCurrencyKey
+	}
+}
+
+// validateCurrencyCode checks that code is a three-letter uppercase ISO 4217 code.
+func validateCurrencyCode(code string) error {
+	if len(code) != currencyCodeLength {
+		return fmt.Errorf("invalid currency code %q: must be 3 uppercase letters", code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("invalid currency code %q: must be 3 uppercase letters", code)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_SetGetCurrency(t *testing.T) {
+	cfg := &Config{}
+
+	require.NoError(t, cfg.Set("currency.target", "eur"))
+	require.NoError(t, cfg.Set("currency.rates.eur", "0.9"))
+	require.NoError(t, cfg.Set("currency.app_id", "secret"))
+
+	got, err := cfg.Get("currency.target")
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", got)
+
+	got, err = cfg.Get("currency.rates.EUR")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.9, got, 0.0001)
+
+	got, err = cfg.Get("currency.source")
+	require.NoError(t, err)
+	assert.Equal(t, CurrencySourceStatic, got, "rates imply the static source")
+
+	got, err = cfg.Get("currency.app_id")
+	require.NoError(t, err)
+	assert.Equal(t, redactedValue, got)
+	assert.Equal(t, "secret", cfg.Currency.AppID)
+	assert.Equal(t, "EUR", cfg.CurrencyTarget())
+}
+
+func TestConfig_SetCurrencyRejectsInvalid(t *testing.T) {
+	cfg := &Config{}
+
+	require.Error(t, cfg.Set("currency.target", "euro"))
+	require.Error(t, cfg.Set("currency.source", "bank"))
+	require.Error(t, cfg.Set("currency.rates.EUR", "-1"))
+	require.Error(t, cfg.Set("currency.cache_ttl_seconds", "-5"))
+	require.Error(t, cfg.Set("currency.unknown", "x"))
+	assert.Nil(t, cfg.Currency, "rejected values must not be stored")
+}
+
+func TestCurrencyConfig_Validate(t *testing.T) {
+	require.NoError(t, (*CurrencyConfig)(nil).Validate())
+	require.NoError(t, (&CurrencyConfig{Target: "EUR", Source: CurrencySourceECB}).Validate())
+	require.Error(t, (&CurrencyConfig{Source: CurrencySourceStatic}).Validate())
+	require.Error(t, (&CurrencyConfig{Rates: map[string]float64{"eur": 1}}).Validate())
+}
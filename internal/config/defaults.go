@@ -0,0 +1,106 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errUnknownDefaultsKey is returned for unsupported defaults.* keys.
+var errUnknownDefaultsKey = errors.New(
+	"unknown defaults setting (supported: defaults.region.<provider>, defaults.stack)")
+
+// DefaultsConfig holds the attributes of resources that neither their
+// properties nor the environment name, and the stack commands use when
+// --stack is omitted.
+type DefaultsConfig struct {
+	// Region maps providers, e.g. "aws", "azure", or "gcp", to the region of
+	// their resources that no property or provider environment variable, such
+	// as AWS_REGION or ARM_LOCATION, names.
+	Region map[string]string `yaml:"region,omitempty" json:"region,omitempty"`
+
+	// Stack is the Pulumi stack of commands run without --stack, usually set
+	// by the project config file of an IaC repository.
+	Stack string `yaml:"stack,omitempty" json:"stack,omitempty"`
+}
+
+// Validate checks that every default region names a provider and a region.
+func (d *DefaultsConfig) Validate() error {
+	if d == nil {
+		return nil
+	}
+	for provider, region := range d.Region {
+		if strings.TrimSpace(provider) == "" {
+			return errors.New("region: provider cannot be empty")
+		}
+		if strings.TrimSpace(region) == "" {
+			return fmt.Errorf("region.%s: %w", provider, ErrEmptyRegion)
+		}
+	}
+	return nil
+}
+
+// DefaultRegions returns the configured default region of each provider,
+// keyed by lowercase provider name, or nil if none is configured.
+func (c *Config) DefaultRegions() map[string]string {
+	if c.Defaults == nil || len(c.Defaults.Region) == 0 {
+		return nil
+	}
+	regions := make(map[string]string, len(c.Defaults.Region))
+	for provider, region := range c.Defaults.Region {
+		regions[strings.ToLower(provider)] = region
+	}
+	return regions
+}
+
+// DefaultStack returns the stack of commands run without --stack, or "" for
+// none.
+func (c *Config) DefaultStack() string {
+	if c.Defaults == nil {
+		return ""
+	}
+	return c.Defaults.Stack
+}
+
+// setDefaultsValue sets a defaults.* configuration value.
+func (c *Config) setDefaultsValue(parts []string, value string) error {
+	if len(parts) == 1 && parts[0] == "stack" {
+		if c.Defaults == nil {
+			c.Defaults = &DefaultsConfig{}
+		}
+		c.Defaults.Stack = value
+		return nil
+	}
+	if len(parts) != 2 || parts[0] != "region" || parts[1] == "" {
+		return errUnknownDefaultsKey
+	}
+	if c.Defaults == nil {
+		c.Defaults = &DefaultsConfig{}
+	}
+	if c.Defaults.Region == nil {
+		c.Defaults.Region = make(map[string]string)
+	}
+	provider := strings.ToLower(parts[1])
+	if value == "" {
+		delete(c.Defaults.Region, provider)
+		return nil
+	}
+	c.Defaults.Region[provider] = value
+	return nil
+}
+
+// getDefaultsValue gets a defaults.* configuration value.
+func (c *Config) getDefaultsValue(parts []string) (interface{}, error) {
+	switch {
+	case len(parts) == 0:
+		return c.Defaults, nil
+	case len(parts) == 1 && parts[0] == "stack":
+		return c.DefaultStack(), nil
+	case len(parts) == 1 && parts[0] == "region":
+		return c.DefaultRegions(), nil
+	case len(parts) == 2 && parts[0] == "region":
+		return c.DefaultRegions()[strings.ToLower(parts[1])], nil
+	default:
+		return nil, errUnknownDefaultsKey
+	}
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestDefaultsConfig_YAML(t *testing.T) {
+	var cfg Config
+	require.NoError(t, yaml.Unmarshal([]byte(`
+defaults:
+  region:
+    Azure: westeurope
+    gcp: us-central1
+`), &cfg))
+
+	require.NoError(t, cfg.Defaults.Validate())
+	assert.Equal(t, map[string]string{"azure": "westeurope", "gcp": "us-central1"}, cfg.DefaultRegions())
+}
+
+func TestDefaultsConfig_SetGet(t *testing.T) {
+	cfg := &Config{}
+	assert.Nil(t, cfg.DefaultRegions())
+
+	require.NoError(t, cfg.Set("defaults.region.aws", "us-east-2"))
+	value, err := cfg.Get("defaults.region.AWS")
+	require.NoError(t, err)
+	assert.Equal(t, "us-east-2", value)
+
+	require.NoError(t, cfg.Set("defaults.region.aws", ""))
+	assert.Nil(t, cfg.DefaultRegions())
+
+	require.NoError(t, cfg.Set("defaults.stack", "prod"))
+	assert.Equal(t, "prod", cfg.DefaultStack())
+	value, err = cfg.Get("defaults.stack")
+	require.NoError(t, err)
+	assert.Equal(t, "prod", value)
+
+	err = cfg.Set("defaults.zone.aws", "us-east-2a")
+	require.ErrorIs(t, err, errUnknownDefaultsKey)
+	_, err = cfg.Get("defaults.region.aws.extra")
+	require.ErrorIs(t, err, errUnknownDefaultsKey)
+}
+
+func TestDefaultsConfig_Validate(t *testing.T) {
+	require.NoError(t, (*DefaultsConfig)(nil).Validate())
+
+	err := (&DefaultsConfig{Region: map[string]string{"aws": " "}}).Validate()
+	require.ErrorIs(t, err, ErrEmptyRegion)
+	assert.Contains(t, err.Error(), "region.aws")
+}
@@ -0,0 +1,419 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDismissalRemote indicates the dismissal remote specification cannot be parsed.
+var ErrInvalidDismissalRemote = errors.New("invalid dismissal remote")
+
+// dismissalStateFileName is the file name used for shared dismissal state.
+const dismissalStateFileName = "dismissed.json"
+
+// DismissalRemoteKind identifies the backend used to share dismissal state.
+type DismissalRemoteKind string
+
+const (
+	// DismissalRemoteS3 stores state in an S3 object via the aws CLI.
+	DismissalRemoteS3 DismissalRemoteKind = "s3"
+	// DismissalRemoteGCS stores state in a GCS object via the gcloud CLI.
+	DismissalRemoteGCS DismissalRemoteKind = "gcs"
+	// DismissalRemoteGit stores state in a local directory; when the directory
+	// is a git working copy, pulls and pushes go through git.
+	DismissalRemoteGit DismissalRemoteKind = "git"
+)
+
+// DismissalSyncDirection selects which way a sync moves dismissal state.
+type DismissalSyncDirection string
+
+const (
+	// SyncPull merges remote state into the local store.
+	SyncPull DismissalSyncDirection = "pull"
+	// SyncPush merges local state into the remote.
+	SyncPush DismissalSyncDirection = "push"
+	// SyncBoth pulls, merges, then pushes the merged state.
+	SyncBoth DismissalSyncDirection = "both"
+)
+
+// DismissalCommandRunner executes an external command and returns its stdout, stderr, and error.
+// It allows the remote backends to be tested without spawning real subprocesses.
+type DismissalCommandRunner interface {
+	Run(ctx context.Context, dir string, name string, args ...string) (stdout []byte, stderr []byte, err error)
+}
+
+// dismissalExecRunner is the default DismissalCommandRunner using exec.CommandContext.
+type dismissalExecRunner struct{}
+
+func (dismissalExecRunner) Run(ctx context.Context, dir string, name string, args ...string) ([]byte, []byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// DismissalRemote is a shared location for dismissal state.
+type DismissalRemote struct {
+	kind     DismissalRemoteKind
+	location string
+	runner   DismissalCommandRunner
+}
+
+// ParseDismissalRemote parses a remote specification:
+//   - s3://bucket/path/dismissed.json
+//   - gs://bucket/path/dismissed.json
+//   - a filesystem path (optionally file://) to a git working copy or shared directory
+//
+// Bucket URLs ending in "/" or naming only a bucket get dismissed.json appended.
+func ParseDismissalRemote(spec string) (*DismissalRemote, error) {
+	spec = strings.TrimSpace(spec)
+	if spec == "" {
+		return nil, fmt.Errorf("%w: empty remote", ErrInvalidDismissalRemote)
+	}
+
+	remote := &DismissalRemote{runner: dismissalExecRunner{}}
+	switch {
+	case strings.HasPrefix(spec, "s3://"), strings.HasPrefix(spec, "gs://"):
+		scheme, rest, _ := strings.Cut(spec, "://")
+		bucket, key, _ := strings.Cut(rest, "/")
+		if bucket == "" {
+			return nil, fmt.Errorf("%w: %q has no bucket", ErrInvalidDismissalRemote, spec)
+		}
+		if key == "" || strings.HasSuffix(key, "/") {
+			key += dismissalStateFileName
+		}
+		remote.kind = DismissalRemoteS3
+		if scheme == "gs" {
+			remote.kind = DismissalRemoteGCS
+		}
+		remote.location = scheme + "://" + bucket + "/" + key
+	case strings.Contains(spec, "://") && !strings.HasPrefix(spec, "file://"):
+		return nil, fmt.Errorf("%w: unsupported scheme in %q (use s3://, gs://, or a path)",
+			ErrInvalidDismissalRemote, spec)
+	default:
+		remote.kind = DismissalRemoteGit
+		remote.location = filepath.Clean(strings.TrimPrefix(spec, "file://"))
+	}
+
+	return remote, nil
+}
+
+// WithRunner replaces the command runner used to reach the remote (for tests).
+func (r *DismissalRemote) WithRunner(runner DismissalCommandRunner) *DismissalRemote {
+	r.runner = runner
+	return r
+}
+
+// Kind returns the remote backend kind.
+func (r *DismissalRemote) Kind() DismissalRemoteKind {
+	return r.kind
+}
+
+// String returns the remote location.
+func (r *DismissalRemote) String() string {
+	return r.location
+}
+
+// Pull fetches the remote dismissal state. It returns nil data and no error
+// when the remote holds no state yet.
+func (r *DismissalRemote) Pull(ctx context.Context) ([]byte, error) {
+	switch r.kind {
+	case DismissalRemoteS3:
+		return r.pullObject(ctx, "aws", "s3", "cp", r.location, "-")
+	case DismissalRemoteGCS:
+		return r.pullObject(ctx, "gcloud", "storage", "cat", r.location)
+	case DismissalRemoteGit:
+		if r.isGitRepo() {
+			if err := r.run(ctx, r.location, "git", "pull", "--rebase", "--quiet"); err != nil {
+				return nil, err
+			}
+		}
+		data, err := os.ReadFile(filepath.Join(r.location, dismissalStateFileName))
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("reading remote dismissal state: %w", err)
+		}
+		return data, nil
+	}
+	return nil, fmt.Errorf("%w: unknown kind %q", ErrInvalidDismissalRemote, r.kind)
+}
+
+// Push uploads dismissal state to the remote. Git remotes commit and push the
+// state file only when it changed.
+func (r *DismissalRemote) Push(ctx context.Context, data []byte) error {
+	switch r.kind {
+	case DismissalRemoteS3, DismissalRemoteGCS:
+		tmp, err := os.CreateTemp("", "finfocus-dismissed-*.json")
+		if err != nil {
+			return fmt.Errorf("creating temp file for push: %w", err)
+		}
+		defer os.Remove(tmp.Name())
+		if _, writeErr := tmp.Write(data); writeErr != nil {
+			_ = tmp.Close()
+			return fmt.Errorf("writing temp file for push: %w", writeErr)
+		}
+		if closeErr := tmp.Close(); closeErr != nil {
+			return fmt.Errorf("closing temp file for push: %w", closeErr)
+		}
+		if r.kind == DismissalRemoteS3 {
+			return r.run(ctx, "", "aws", "s3", "cp", tmp.Name(), r.location, "--only-show-errors")
+		}
+		return r.run(ctx, "", "gcloud", "storage", "cp", tmp.Name(), r.location)
+	case DismissalRemoteGit:
+		return r.pushGit(ctx, data)
+	}
+	return fmt.Errorf("%w: unknown kind %q", ErrInvalidDismissalRemote, r.kind)
+}
+
+// pushGit writes the state file and, for git working copies, commits and pushes it.
+func (r *DismissalRemote) pushGit(ctx context.Context, data []byte) error {
+	if err := os.MkdirAll(r.location, 0o750); err != nil {
+		return fmt.Errorf("creating remote dismissal directory: %w", err)
+	}
+	path := filepath.Join(r.location, dismissalStateFileName)
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0o600); err != nil {
+		return fmt.Errorf("writing remote dismissal state: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("renaming remote dismissal state: %w", err)
+	}
+
+	if !r.isGitRepo() {
+		return nil
+	}
+
+	status, stderr, err := r.runner.Run(ctx, r.location, "git", "status", "--porcelain", "--", dismissalStateFileName)
+	if err != nil {
+		return commandError("git status", stderr, err)
+	}
+	if len(bytes.TrimSpace(status)) == 0 {
+		return nil
+	}
+
+	if addErr := r.run(ctx, r.location, "git", "add", "--", dismissalStateFileName); addErr != nil {
+		return addErr
+	}
+	if commitErr := r.run(ctx, r.location, "git", "commit", "--quiet",
+		"-m", "Update finfocus recommendation dismissals", "--", dismissalStateFileName); commitErr != nil {
+		return commitErr
+	}
+	return r.run(ctx, r.location, "git", "push", "--quiet")
+}
+
+// pullObject reads an object via a cloud CLI, treating "not found" as empty state.
+func (r *DismissalRemote) pullObject(ctx context.Context, name string, args ...string) ([]byte, error) {
+	stdout, stderr, err := r.runner.Run(ctx, "", name, args...)
+	if err != nil {
+		if isObjectNotFound(stderr) {
+			return nil, nil
+		}
+		return nil, commandError(name+" "+args[0], stderr, err)
+	}
+	return stdout, nil
+}
+
+// run executes a command and wraps failures with its stderr.
+func (r *DismissalRemote) run(ctx context.Context, dir, name string, args ...string) error {
+	_, stderr, err := r.runner.Run(ctx, dir, name, args...)
+	if err != nil {
+		return commandError(name+" "+args[0], stderr, err)
+	}
+	return nil
+}
+
+// isGitRepo reports whether the remote directory is a git working copy.
+func (r *DismissalRemote) isGitRepo() bool {
+	_, err := os.Stat(filepath.Join(r.location, ".git"))
+	return err == nil
+}
+
+// isObjectNotFound recognizes the "missing object" messages of the aws and gcloud CLIs.
+func isObjectNotFound(stderr []byte) bool {
+	msg := strings.ToLower(string(stderr))
+	return strings.Contains(msg, "(404)") ||
+		strings.Contains(msg, "nosuchkey") ||
+		strings.Contains(msg, "does not exist") ||
+		strings.Contains(msg, "matched no objects") ||
+		strings.Contains(msg, "no urls matched")
+}
+
+// commandError formats a failed external command with its trimmed stderr.
+func commandError(what string, stderr []byte, err error) error {
+	if msg := strings.TrimSpace(string(stderr)); msg != "" {
+		return fmt.Errorf("%s failed: %w: %s", what, err, msg)
+	}
+	return fmt.Errorf("%s failed: %w", what, err)
+}
+
+// DismissalMergeStats counts the outcome of merging one set of records into another.
+type DismissalMergeStats struct {
+	Added     int `json:"added"`
+	Updated   int `json:"updated"`
+	Unchanged int `json:"unchanged"`
+}
+
+// Changed reports whether the merge modified the destination.
+func (m DismissalMergeStats) Changed() bool {
+	return m.Added+m.Updated > 0
+}
+
+// DismissalSyncResult reports what a sync did.
+type DismissalSyncResult struct {
+	Remote string              `json:"remote"`
+	Pulled DismissalMergeStats `json:"pulled"`
+	Pushed bool                `json:"pushed"`
+}
+
+// LastModified returns the time of the record's most recent lifecycle change,
+// used to resolve sync conflicts. It is the latest history timestamp, or
+// DismissedAt when the record has no history.
+func (r *DismissalRecord) LastModified() time.Time {
+	latest := r.DismissedAt
+	for _, event := range r.History {
+		if event.Timestamp.After(latest) {
+			latest = event.Timestamp
+		}
+	}
+	return latest
+}
+
+// ParseDismissalState decodes serialized dismissal state as written by Save.
+// Empty data yields an empty map.
+func ParseDismissalState(data []byte) (map[string]*DismissalRecord, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		return make(map[string]*DismissalRecord), nil
+	}
+
+	var storeData dismissalStoreData
+	if err := json.Unmarshal(data, &storeData); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrStoreCorrupted, err)
+	}
+	if storeData.Version != DismissalStoreVersion {
+		return nil, fmt.Errorf("%w: unsupported version %d (expected %d)",
+			ErrStoreCorrupted, storeData.Version, DismissalStoreVersion)
+	}
+	if storeData.Dismissals == nil {
+		storeData.Dismissals = make(map[string]*DismissalRecord)
+	}
+	return storeData.Dismissals, nil
+}
+
+// marshalDismissalState encodes records in the store's file format.
+func marshalDismissalState(records map[string]*DismissalRecord) ([]byte, error) {
+	data, err := json.MarshalIndent(dismissalStoreData{
+		Version:    DismissalStoreVersion,
+		Dismissals: records,
+	}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("marshaling dismissal state: %w", err)
+	}
+	return data, nil
+}
+
+// mergeDismissalRecords merges src into dst. Records missing from dst are
+// added; when both sides have a record, the one with the later LastModified
+// wins. Ties keep dst.
+func mergeDismissalRecords(dst, src map[string]*DismissalRecord) DismissalMergeStats {
+	var stats DismissalMergeStats
+	for id, incoming := range src {
+		if incoming == nil {
+			continue
+		}
+		existing, ok := dst[id]
+		switch {
+		case !ok || existing == nil:
+			dst[id] = copyDismissalRecord(incoming)
+			stats.Added++
+		case incoming.LastModified().After(existing.LastModified()):
+			dst[id] = copyDismissalRecord(incoming)
+			stats.Updated++
+		default:
+			stats.Unchanged++
+		}
+	}
+	return stats
+}
+
+// Merge merges records into the store using last-modified-wins conflict
+// resolution. Callers must Save to persist the result.
+func (s *DismissalStore) Merge(records map[string]*DismissalRecord) DismissalMergeStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return mergeDismissalRecords(s.dismissals, records)
+}
+
+// SyncDismissals synchronizes a loaded store with a remote.
+//
+// Pull merges remote records into the local store and saves it. Push merges
+// local records over the remote state and uploads the result, leaving the
+// local store unchanged. Both does a pull followed by a push. Pushes are
+// skipped when the remote already reflects every local record.
+func SyncDismissals(
+	ctx context.Context,
+	store *DismissalStore,
+	remote *DismissalRemote,
+	direction DismissalSyncDirection,
+) (*DismissalSyncResult, error) {
+	switch direction {
+	case SyncPull, SyncPush, SyncBoth:
+	default:
+		return nil, fmt.Errorf("invalid sync direction %q (valid: pull, push, both)", direction)
+	}
+
+	result := &DismissalSyncResult{Remote: remote.String()}
+
+	data, err := remote.Pull(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pulling dismissals from %s: %w", remote, err)
+	}
+	remoteRecords, err := ParseDismissalState(data)
+	if err != nil {
+		return nil, fmt.Errorf("remote dismissal state at %s: %w", remote, err)
+	}
+
+	if direction != SyncPush {
+		result.Pulled = store.Merge(remoteRecords)
+		if result.Pulled.Changed() {
+			if saveErr := store.Save(); saveErr != nil {
+				return nil, fmt.Errorf("saving merged dismissals: %w", saveErr)
+			}
+		}
+	}
+
+	if direction == SyncPull {
+		return result, nil
+	}
+
+	pushStats := mergeDismissalRecords(remoteRecords, store.GetAllRecords())
+	if !pushStats.Changed() && data != nil {
+		return result, nil
+	}
+
+	payload, err := marshalDismissalState(remoteRecords)
+	if err != nil {
+		return nil, err
+	}
+	if pushErr := remote.Push(ctx, payload); pushErr != nil {
+		return nil, fmt.Errorf("pushing dismissals to %s: %w", remote, pushErr)
+	}
+	result.Pushed = true
+	return result, nil
+}
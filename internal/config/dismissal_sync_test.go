@@ -0,0 +1,235 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeDismissalRunner records commands and serves canned responses.
+type fakeDismissalRunner struct {
+	calls  []string
+	stdout []byte
+	stderr []byte
+	err    error
+}
+
+func (f *fakeDismissalRunner) Run(_ context.Context, _ string, name string, args ...string) ([]byte, []byte, error) {
+	f.calls = append(f.calls, name+" "+strings.Join(args, " "))
+	return f.stdout, f.stderr, f.err
+}
+
+func TestParseDismissalRemote(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		spec     string
+		kind     DismissalRemoteKind
+		location string
+		wantErr  bool
+	}{
+		{spec: "s3://team-bucket/finfocus/dismissed.json", kind: DismissalRemoteS3,
+			location: "s3://team-bucket/finfocus/dismissed.json"},
+		{spec: "s3://team-bucket", kind: DismissalRemoteS3, location: "s3://team-bucket/dismissed.json"},
+		{spec: "gs://team-bucket/state/", kind: DismissalRemoteGCS, location: "gs://team-bucket/state/dismissed.json"},
+		{spec: "/srv/finfocus-state", kind: DismissalRemoteGit, location: "/srv/finfocus-state"},
+		{spec: "file:///srv/state/", kind: DismissalRemoteGit, location: "/srv/state"},
+		{spec: "", wantErr: true},
+		{spec: "s3://", wantErr: true},
+		{spec: "https://example.com/state", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			t.Parallel()
+			remote, err := ParseDismissalRemote(tt.spec)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidDismissalRemote)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.kind, remote.Kind())
+			assert.Equal(t, tt.location, remote.String())
+		})
+	}
+}
+
+func TestDismissalRemote_ObjectBackends(t *testing.T) {
+	t.Parallel()
+
+	t.Run("s3 missing object is empty state", func(t *testing.T) {
+		t.Parallel()
+		runner := &fakeDismissalRunner{
+			stderr: []byte("fatal error: An error occurred (404) when calling the HeadObject operation"),
+			err:    errors.New("exit status 1"),
+		}
+		remote, err := ParseDismissalRemote("s3://bucket/dismissed.json")
+		require.NoError(t, err)
+		remote.WithRunner(runner)
+
+		data, err := remote.Pull(context.Background())
+		require.NoError(t, err)
+		assert.Nil(t, data)
+		assert.Equal(t, []string{"aws s3 cp s3://bucket/dismissed.json -"}, runner.calls)
+	})
+
+	t.Run("gcs pull failure surfaces stderr", func(t *testing.T) {
+		t.Parallel()
+		runner := &fakeDismissalRunner{stderr: []byte("permission denied"), err: errors.New("exit status 1")}
+		remote, err := ParseDismissalRemote("gs://bucket/dismissed.json")
+		require.NoError(t, err)
+		remote.WithRunner(runner)
+
+		_, err = remote.Pull(context.Background())
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "permission denied")
+	})
+
+	t.Run("gcs push copies object", func(t *testing.T) {
+		t.Parallel()
+		runner := &fakeDismissalRunner{}
+		remote, err := ParseDismissalRemote("gs://bucket/dismissed.json")
+		require.NoError(t, err)
+		remote.WithRunner(runner)
+
+		require.NoError(t, remote.Push(context.Background(), []byte("{}")))
+		require.Len(t, runner.calls, 1)
+		assert.True(t, strings.HasPrefix(runner.calls[0], "gcloud storage cp "))
+		assert.True(t, strings.HasSuffix(runner.calls[0], " gs://bucket/dismissed.json"))
+	})
+}
+
+func TestDismissalRecord_LastModified(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	record := &DismissalRecord{DismissedAt: base}
+	assert.Equal(t, base, record.LastModified())
+
+	record.History = []LifecycleEvent{
+		{Action: ActionDismissed, Timestamp: base},
+		{Action: ActionUndismissed, Timestamp: base.Add(time.Hour)},
+	}
+	assert.Equal(t, base.Add(time.Hour), record.LastModified())
+}
+
+func TestSyncDismissals(t *testing.T) {
+	t.Parallel()
+
+	base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	newRecord := func(id string, status DismissalStatus, at time.Time) *DismissalRecord {
+		return &DismissalRecord{
+			RecommendationID: id,
+			Status:           status,
+			Reason:           "NOT_APPLICABLE",
+			DismissedAt:      at,
+		}
+	}
+
+	setup := func(t *testing.T) (*DismissalStore, *DismissalRemote, string) {
+		t.Helper()
+		store, err := NewDismissalStore(filepath.Join(t.TempDir(), "dismissed.json"))
+		require.NoError(t, err)
+		require.NoError(t, store.Load())
+
+		remoteDir := t.TempDir()
+		remote, err := ParseDismissalRemote(remoteDir)
+		require.NoError(t, err)
+		return store, remote, remoteDir
+	}
+
+	writeRemote := func(t *testing.T, dir string, records map[string]*DismissalRecord) {
+		t.Helper()
+		data, err := marshalDismissalState(records)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, dismissalStateFileName), data, 0o600))
+	}
+
+	readRemote := func(t *testing.T, dir string) map[string]*DismissalRecord {
+		t.Helper()
+		data, err := os.ReadFile(filepath.Join(dir, dismissalStateFileName))
+		require.NoError(t, err)
+		records, err := ParseDismissalState(data)
+		require.NoError(t, err)
+		return records
+	}
+
+	t.Run("pull merges remote records", func(t *testing.T) {
+		t.Parallel()
+		store, remote, dir := setup(t)
+		require.NoError(t, store.Set(newRecord("local", StatusDismissed, base)))
+		writeRemote(t, dir, map[string]*DismissalRecord{
+			"remote": newRecord("remote", StatusDismissed, base),
+		})
+
+		result, err := SyncDismissals(context.Background(), store, remote, SyncPull)
+		require.NoError(t, err)
+		assert.Equal(t, DismissalMergeStats{Added: 1}, result.Pulled)
+		assert.False(t, result.Pushed)
+
+		_, ok := store.Get("remote")
+		assert.True(t, ok)
+		assert.NotContains(t, readRemote(t, dir), "local", "pull must not modify the remote")
+	})
+
+	t.Run("newer record wins conflicts", func(t *testing.T) {
+		t.Parallel()
+		store, remote, dir := setup(t)
+		require.NoError(t, store.Set(newRecord("shared", StatusDismissed, base)))
+		require.NoError(t, store.Set(newRecord("stale", StatusSnoozed, base.Add(2*time.Hour))))
+		writeRemote(t, dir, map[string]*DismissalRecord{
+			"shared": newRecord("shared", StatusSnoozed, base.Add(time.Hour)),
+			"stale":  newRecord("stale", StatusDismissed, base),
+		})
+
+		result, err := SyncDismissals(context.Background(), store, remote, SyncBoth)
+		require.NoError(t, err)
+		assert.Equal(t, DismissalMergeStats{Updated: 1, Unchanged: 1}, result.Pulled)
+		assert.True(t, result.Pushed)
+
+		shared, _ := store.Get("shared")
+		assert.Equal(t, StatusSnoozed, shared.Status)
+
+		remoteRecords := readRemote(t, dir)
+		assert.Equal(t, StatusSnoozed, remoteRecords["stale"].Status)
+		assert.Equal(t, StatusSnoozed, remoteRecords["shared"].Status)
+	})
+
+	t.Run("push creates remote state and skips when unchanged", func(t *testing.T) {
+		t.Parallel()
+		store, remote, dir := setup(t)
+		require.NoError(t, store.Set(newRecord("local", StatusDismissed, base)))
+
+		result, err := SyncDismissals(context.Background(), store, remote, SyncPush)
+		require.NoError(t, err)
+		assert.True(t, result.Pushed)
+		assert.Contains(t, readRemote(t, dir), "local")
+
+		result, err = SyncDismissals(context.Background(), store, remote, SyncPush)
+		require.NoError(t, err)
+		assert.False(t, result.Pushed)
+	})
+
+	t.Run("corrupted remote is rejected", func(t *testing.T) {
+		t.Parallel()
+		store, remote, dir := setup(t)
+		require.NoError(t, os.WriteFile(filepath.Join(dir, dismissalStateFileName), []byte("{not json"), 0o600))
+
+		_, err := SyncDismissals(context.Background(), store, remote, SyncBoth)
+		require.ErrorIs(t, err, ErrStoreCorrupted)
+	})
+
+	t.Run("invalid direction", func(t *testing.T) {
+		t.Parallel()
+		store, remote, _ := setup(t)
+		_, err := SyncDismissals(context.Background(), store, remote, "sideways")
+		require.Error(t, err)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
 	"sync"
 	"syscall"
 	"time"
@@ -116,8 +117,13 @@ func (s *DismissalStore) lockFilePath() string {
 // acquireFileLock acquires a cross-process advisory lockfile.
 // Returns a cleanup function that releases the lock.
 func (s *DismissalStore) acquireFileLock() (func(), error) {
-	lockPath := s.lockFilePath()
+	return acquireLockFile(s.lockFilePath())
+}
 
+// acquireLockFile acquires a cross-process advisory lockfile at lockPath.
+// It is shared by the JSON-backed stores in this package.
+// Returns a cleanup function that releases the lock.
+func acquireLockFile(lockPath string) (func(), error) {
 	// Ensure directory exists
 	if err := os.MkdirAll(filepath.Dir(lockPath), 0o750); err != nil {
 		return nil, fmt.Errorf("creating lock directory: %w", err)
@@ -377,6 +383,36 @@ func (s *DismissalStore) GetExpiredSnoozes() []*DismissalRecord {
 	return expired
 }
 
+// GetExpiringSnoozes returns snoozed records whose ExpiresAt falls within the
+// given window from now, ordered by expiry (soonest first). Already-expired
+// snoozes are excluded; see GetExpiredSnoozes.
+func (s *DismissalStore) GetExpiringSnoozes(within time.Duration) []*DismissalRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	now := time.Now()
+	deadline := now.Add(within)
+	var expiring []*DismissalRecord
+
+	for _, record := range s.dismissals {
+		if record.Status != StatusSnoozed || record.ExpiresAt == nil {
+			continue
+		}
+		if !record.ExpiresAt.Before(now) && !record.ExpiresAt.After(deadline) {
+			expiring = append(expiring, copyDismissalRecord(record))
+		}
+	}
+
+	sort.Slice(expiring, func(i, j int) bool {
+		if expiring[i].ExpiresAt.Equal(*expiring[j].ExpiresAt) {
+			return expiring[i].RecommendationID < expiring[j].RecommendationID
+		}
+		return expiring[i].ExpiresAt.Before(*expiring[j].ExpiresAt)
+	})
+
+	return expiring
+}
+
 // CleanExpiredSnoozes transitions snoozed records whose ExpiresAt has passed to active status.
 // Returns the number of snoozes that were cleaned.
 func (s *DismissalStore) CleanExpiredSnoozes() (int, error) {
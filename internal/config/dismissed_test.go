@@ -360,6 +360,40 @@ func TestDismissalStore_GetExpiredSnoozes(t *testing.T) {
 	assert.Equal(t, "rec-expired", expired[0].RecommendationID)
 }
 
+func TestDismissalStore_GetExpiringSnoozes(t *testing.T) {
+	t.Parallel()
+
+	store, err := NewDismissalStore(filepath.Join(t.TempDir(), "d.json"))
+	require.NoError(t, err)
+
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	tomorrow := now.Add(24 * time.Hour)
+	soon := now.Add(time.Hour)
+	nextMonth := now.Add(30 * 24 * time.Hour)
+
+	for id, expiresAt := range map[string]time.Time{
+		"rec-expired":    past,
+		"rec-tomorrow":   tomorrow,
+		"rec-soon":       soon,
+		"rec-next-month": nextMonth,
+	} {
+		require.NoError(t, store.Set(&DismissalRecord{
+			RecommendationID: id,
+			Status:           StatusSnoozed,
+			DismissedAt:      past,
+			ExpiresAt:        &expiresAt,
+		}))
+	}
+
+	expiring := store.GetExpiringSnoozes(7 * 24 * time.Hour)
+	require.Len(t, expiring, 2)
+	assert.Equal(t, "rec-soon", expiring[0].RecommendationID)
+	assert.Equal(t, "rec-tomorrow", expiring[1].RecommendationID)
+
+	assert.Empty(t, store.GetExpiringSnoozes(0))
+}
+
 func TestDismissalStore_CleanExpiredSnoozes(t *testing.T) {
 	t.Parallel()
 
@@ -0,0 +1,639 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"runtime"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rshade/finfocus/internal/secrets"
+)
+
+// LintSeverity is the severity of a problem found in a config file.
+type LintSeverity string
+
+// Lint severities.
+const (
+	// LintError marks settings that are invalid and make commands fail or
+	// misbehave.
+	LintError LintSeverity = "error"
+	// LintWarning marks settings that are valid but likely not what was meant.
+	LintWarning LintSeverity = "warning"
+)
+
+// Cache TTLs outside minLintCacheTTLSeconds to maxLintCacheTTLSeconds, the
+// range the cache accepts from the environment, are reported.
+const (
+	minLintCacheTTLSeconds = 60
+	maxLintCacheTTLSeconds = 7 * 24 * 60 * 60
+)
+
+// yamlErrorLine matches the line reference of yaml decoding errors.
+var yamlErrorLine = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// LintOptions selects optional checks of LintFile.
+type LintOptions struct {
+	// InstalledPlugins are the names of the installed plugins. When not nil,
+	// settings under plugins for other plugins are reported.
+	InstalledPlugins []string
+}
+
+// LintIssue is a problem found in a config file.
+type LintIssue struct {
+	// Severity is LintError or LintWarning.
+	Severity LintSeverity `json:"severity"`
+	// Key is the dotted path of the setting, such as
+	// "cost.budgets.tags[1].selector". Empty for the file as a whole.
+	Key string `json:"key,omitempty"`
+	// Line is the line of the setting in the file, or 0 when unknown.
+	Line int `json:"line,omitempty"`
+	// Message describes the problem and how to fix it.
+	Message string `json:"message"`
+}
+
+// String formats the issue as "line 12: key: message".
+func (i LintIssue) String() string {
+	var b strings.Builder
+	if i.Line > 0 {
+		fmt.Fprintf(&b, "line %d: ", i.Line)
+	}
+	if i.Key != "" {
+		b.WriteString(i.Key + ": ")
+	}
+	b.WriteString(i.Message)
+	return b.String()
+}
+
+// LintFile checks the config file at path for invalid settings, and for
+// valid settings that are likely mistakes: unknown keys, overlapping and
+// tied budget scopes, currency codes, cache TTL ranges, plugin paths,
+// selector and pattern syntax, and secret references and secrets stored in
+// plain text, of the base configuration and of each
+// profile. Issues are sorted by line. A file that cannot be read is
+// returned as an error.
+func LintFile(path string, opts LintOptions) ([]LintIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err = yaml.Unmarshal(data, &root); err != nil {
+		return []LintIssue{yamlIssue(LintError, err.Error())}, nil
+	}
+	l := &linter{lines: map[string]int{}}
+	indexLines(&root, "", l.lines)
+
+	cfg := defaultConfig(ResolveConfigDir())
+	cfg.configPath = path
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(cfg); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return []LintIssue{yamlIssue(LintError, err.Error())}, nil
+		}
+		l.addTypeErrors(typeErr)
+	}
+
+	if opts.InstalledPlugins != nil {
+		l.lintPluginSettings(cfg.Plugins, opts.InstalledPlugins)
+	}
+	l.lint(cfg)
+	l.lintProfiles(path, data, cfg.ProfileNames(), opts)
+	sort.SliceStable(l.issues, func(i, j int) bool { return l.issues[i].Line < l.issues[j].Line })
+	return l.issues, nil
+}
+
+// LintProjectFile checks the project config file at path like LintFile,
+// and reports settings project config files cannot set. The budgets and
+// policies it sets are checked layered over the user config file, so that
+// budgets relying on its global budget are not reported. Issues are sorted
+// by line. A file that cannot be read is returned as an error.
+func LintProjectFile(path string) ([]LintIssue, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root yaml.Node
+	if err = yaml.Unmarshal(data, &root); err != nil {
+		return []LintIssue{yamlIssue(LintError, err.Error())}, nil
+	}
+	l := &linter{lines: map[string]int{}, partial: true}
+	indexLines(&root, "", l.lines)
+	if len(root.Content) == 0 {
+		return nil, nil
+	}
+	if root.Content[0].Kind != yaml.MappingNode {
+		return []LintIssue{{
+			Severity: LintError, Line: root.Content[0].Line, Message: "expected a mapping of settings",
+		}}, nil
+	}
+	if keys := unsupportedProjectKeys(root.Content[0]); len(keys) > 0 {
+		for _, key := range keys {
+			l.add(LintError, key, "%v; set it in the user config file", errProjectSetting)
+		}
+		sort.SliceStable(l.issues, func(i, j int) bool { return l.issues[i].Line < l.issues[j].Line })
+		return l.issues, nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err = decoder.Decode(defaultConfig(ResolveConfigDir())); err != nil {
+		var typeErr *yaml.TypeError
+		if !errors.As(err, &typeErr) {
+			return []LintIssue{yamlIssue(LintError, err.Error())}, nil
+		}
+		l.addTypeErrors(typeErr)
+	}
+
+	cfg := defaultConfig(ResolveConfigDir())
+	_ = cfg.Load() // problems of the user config file are reported by LintFile
+	if err = cfg.ApplyProjectFile(path); err == nil {
+		l.lint(cfg)
+	}
+	sort.SliceStable(l.issues, func(i, j int) bool { return l.issues[i].Line < l.issues[j].Line })
+	return l.issues, nil
+}
+
+// lintProfiles checks the profiles of the config file at path, whose
+// contents are data: their keys, and the configuration each profile
+// produces. Problems of the configuration are reported only for settings
+// the profile sets, so those of the base configuration are not repeated
+// for every profile.
+func (l *linter) lintProfiles(path string, data []byte, names []string, opts LintOptions) {
+	if len(names) == 0 {
+		return
+	}
+	var file struct {
+		Profiles map[string]Config    `yaml:"profiles"`
+		Other    map[string]yaml.Node `yaml:",inline"`
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&file); err != nil {
+		var typeErr *yaml.TypeError
+		if errors.As(err, &typeErr) {
+			l.addTypeErrors(typeErr)
+		}
+	}
+
+	for _, name := range names {
+		if _, nested := l.lines["profiles."+name+".profiles"]; nested {
+			l.add(LintWarning, "profiles."+name+".profiles", "profiles cannot be nested; the setting is ignored")
+		}
+		cfg := defaultConfig(ResolveConfigDir())
+		cfg.configPath = path
+		_ = yaml.Unmarshal(data, cfg) // decoding errors were reported above
+		if err := cfg.ApplyProfile(name); err != nil {
+			continue
+		}
+		l.profile = name
+		if opts.InstalledPlugins != nil {
+			l.lintPluginSettings(cfg.Plugins, opts.InstalledPlugins)
+		}
+		l.lint(cfg)
+		l.profile = ""
+	}
+}
+
+// addTypeErrors records the errors of decoding the config file. Unknown keys
+// are warnings: they are ignored when the configuration is loaded.
+func (l *linter) addTypeErrors(typeErr *yaml.TypeError) {
+	for _, msg := range typeErr.Errors {
+		if strings.Contains(msg, " not found in type ") {
+			issue := yamlIssue(LintWarning, msg)
+			issue.Message += "; the setting is ignored, check its spelling"
+			l.issues = append(l.issues, issue)
+			continue
+		}
+		l.issues = append(l.issues, yamlIssue(LintError, msg))
+	}
+}
+
+// HasLintErrors reports whether issues include an error.
+func HasLintErrors(issues []LintIssue) bool {
+	for _, issue := range issues {
+		if issue.Severity == LintError {
+			return true
+		}
+	}
+	return false
+}
+
+// yamlIssue returns an issue for a yaml decoding error message, taking its
+// line from the message.
+func yamlIssue(severity LintSeverity, msg string) LintIssue {
+	issue := LintIssue{Severity: severity, Message: msg}
+	if m := yamlErrorLine.FindStringSubmatch(msg); m != nil {
+		issue.Line, _ = strconv.Atoi(m[1])
+		issue.Message = m[2]
+	}
+	return issue
+}
+
+// indexLines records the line of every key and sequence item below node in
+// lines, keyed by dotted path.
+func indexLines(node *yaml.Node, path string, lines map[string]int) {
+	switch node.Kind {
+	case yaml.DocumentNode:
+		for _, child := range node.Content {
+			indexLines(child, path, lines)
+		}
+	case yaml.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key := node.Content[i]
+			keyPath := key.Value
+			if path != "" {
+				keyPath = path + "." + key.Value
+			}
+			lines[keyPath] = key.Line
+			indexLines(node.Content[i+1], keyPath, lines)
+		}
+	case yaml.SequenceNode:
+		for i, item := range node.Content {
+			itemPath := fmt.Sprintf("%s[%d]", path, i)
+			lines[itemPath] = item.Line
+			indexLines(item, itemPath, lines)
+		}
+	case yaml.ScalarNode, yaml.AliasNode:
+	}
+}
+
+// linter collects the issues of a config file.
+type linter struct {
+	lines  map[string]int
+	issues []LintIssue
+	// profile is the profile whose configuration is checked, or "" for
+	// the base configuration.
+	profile string
+	// partial is set when the file sets only part of the configuration
+	// checked, as project config files do; issues of settings it does not
+	// set are dropped.
+	partial bool
+}
+
+// add records an issue for the setting key, at the line of key or of the
+// closest enclosing setting in the file. While a profile is checked, key is
+// taken within the profile, and issues of settings the profile does not set
+// are dropped.
+func (l *linter) add(severity LintSeverity, key, format string, args ...any) {
+	within := ""
+	if l.profile != "" {
+		within = "profiles." + l.profile
+		if key == "" {
+			key = within
+		} else {
+			key = within + "." + key
+		}
+	}
+	line := l.line(key, within)
+	if (within != "" || l.partial) && line == 0 {
+		return
+	}
+	l.issues = append(l.issues, LintIssue{
+		Severity: severity, Key: key, Line: line, Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// line returns the line of the setting key, or of its closest enclosing
+// setting below within, or 0 when there is none in the file.
+func (l *linter) line(key, within string) int {
+	for path := key; path != "" && (path == key || len(path) > len(within)); {
+		if line, ok := l.lines[path]; ok {
+			return line
+		}
+		cut := strings.LastIndexAny(path, ".[")
+		if cut < 0 {
+			break
+		}
+		path = path[:cut]
+	}
+	return 0
+}
+
+// lint runs the checks on cfg. The checks of Validate not covered here are
+// reported when nothing else is wrong, without a line.
+func (l *linter) lint(cfg *Config) {
+	l.lintOutput(cfg.Output)
+	l.lintCache(cfg.Cost.Cache)
+	l.lintBudgets(cfg.Cost.Budgets)
+	l.lintCurrency(cfg.Currency)
+	l.lintSelectors(cfg)
+	l.lintPluginPaths(cfg.Analyzer.Plugins)
+	l.lintSecrets(cfg)
+	l.lintPolicy(cfg)
+
+	if !HasLintErrors(l.issues) {
+		if err := cfg.Validate(); err != nil {
+			l.add(LintError, "", "%v", err)
+		}
+	}
+}
+
+// lintOutput checks the output settings.
+func (l *linter) lintOutput(output OutputConfig) {
+	switch output.DefaultFormat {
+	case "table", "json", "ndjson":
+	default:
+		l.add(LintError, "output.default_format", "invalid output format %q: use table, json, or ndjson",
+			output.DefaultFormat)
+	}
+	if output.Precision < 0 || output.Precision > 10 {
+		l.add(LintError, "output.precision", "precision %d is out of range: use 0 to 10", output.Precision)
+	}
+}
+
+// lintCache checks the cache TTLs and size.
+func (l *linter) lintCache(cache CacheConfig) {
+	if cache.Enabled {
+		switch ttl := cache.TTLSeconds; {
+		case ttl < 0:
+			l.add(LintError, "cost.cache.ttl_seconds", "TTL must not be negative, got %d", ttl)
+		case ttl == 0:
+			l.add(LintWarning, "cost.cache.ttl_seconds", "a TTL of 0 uses the default of %d seconds; "+
+				"set cost.cache.enabled to false to disable caching", defaultCacheTTLSeconds)
+		default:
+			l.lintTTLRange("cost.cache.ttl_seconds", ttl)
+		}
+	}
+	for _, op := range sortedKeys(cache.OperationTTLSeconds) {
+		key := "cost.cache.operation_ttl_seconds." + op
+		ttl := cache.OperationTTLSeconds[op]
+		switch {
+		case !cacheOperations[op]:
+			l.add(LintError, key, "unknown operation %q: use projected_cost, actual_cost, or recommendations", op)
+		case ttl <= 0:
+			l.add(LintError, key, "TTL must be positive, got %d; remove it to use cost.cache.ttl_seconds", ttl)
+		default:
+			l.lintTTLRange(key, ttl)
+		}
+	}
+	if cache.MaxSizeMB < 0 {
+		l.add(LintError, "cost.cache.max_size_mb", "must not be negative, got %d (0 means unlimited)",
+			cache.MaxSizeMB)
+	}
+}
+
+// lintTTLRange reports a positive cache TTL outside the usual range.
+func (l *linter) lintTTLRange(key string, ttl int) {
+	switch {
+	case ttl < minLintCacheTTLSeconds:
+		l.add(LintWarning, key, "a TTL of %d seconds (under a minute) queries plugins again on almost every run; "+
+			"use at least %d", ttl, minLintCacheTTLSeconds)
+	case ttl > maxLintCacheTTLSeconds:
+		l.add(LintWarning, key, "a TTL of %d seconds (over 7 days) reuses prices long after they change; "+
+			"use at most %d", ttl, maxLintCacheTTLSeconds)
+	}
+}
+
+// lintBudgets checks the budget scopes, their currencies, and overlapping
+// and tied scopes.
+func (l *linter) lintBudgets(budgets *BudgetsConfig) {
+	if budgets == nil {
+		return
+	}
+	const prefix = "cost.budgets"
+
+	if budgets.HasScopedBudgets() && !budgets.HasGlobalBudget() {
+		l.add(LintError, prefix, "provider, tag, type, and stack budgets need a global budget: "+
+			"add cost.budgets.global with an amount")
+	}
+	globalCurrency := budgets.GetGlobalCurrency()
+	if budgets.Global != nil {
+		l.lintScopedBudget(prefix+".global", budgets.Global, "")
+	}
+
+	lowered := map[string]string{}
+	for _, name := range sortedKeys(budgets.Providers) {
+		key := prefix + ".providers." + name
+		l.lintScopedBudget(key, budgets.Providers[name], globalCurrency)
+		if other, ok := lowered[strings.ToLower(name)]; ok {
+			l.add(LintWarning, key, "overlaps provider budget %q: providers match without regard to case, "+
+				"so only one of them applies; merge them", other)
+			continue
+		}
+		lowered[strings.ToLower(name)] = name
+	}
+
+	l.lintTagBudgets(prefix+".tags", budgets.Tags, globalCurrency)
+
+	for _, name := range sortedKeys(budgets.Types) {
+		l.lintScopedBudget(prefix+".types."+name, budgets.Types[name], globalCurrency)
+	}
+	for _, name := range sortedKeys(budgets.Stacks) {
+		key := prefix + ".stacks." + name
+		if strings.TrimSpace(name) == "" {
+			l.add(LintError, key, "stack budget name cannot be empty")
+			continue
+		}
+		l.lintScopedBudget(key, budgets.Stacks[name], globalCurrency)
+	}
+
+	if budgets.ExitCode != nil && (*budgets.ExitCode < MinExitCode || *budgets.ExitCode > MaxExitCode) {
+		l.add(LintError, prefix+".exit_code", "exit code %d is out of range: use %d to %d",
+			*budgets.ExitCode, MinExitCode, MaxExitCode)
+	}
+}
+
+// lintScopedBudget checks one budget scope.
+func (l *linter) lintScopedBudget(key string, budget *ScopedBudget, globalCurrency string) {
+	if budget == nil {
+		return
+	}
+	if budget.Currency != "" {
+		if err := validateCurrencyCode(budget.Currency); err != nil {
+			l.add(LintError, key+".currency", "%v", err)
+			return
+		}
+	}
+	if err := budget.Validate(globalCurrency); err != nil {
+		if errors.Is(err, ErrCurrencyMismatch) {
+			l.add(LintError, key+".currency", "%v: use %s or remove it to inherit the global currency",
+				err, globalCurrency)
+			return
+		}
+		l.add(LintError, key, "%v", err)
+	}
+}
+
+// lintTagBudgets checks the tag budget selectors, and reports tag budgets
+// that tie with another matching the same resources at the same priority,
+// and those that never apply because a higher-priority budget matches every
+// resource they match.
+func (l *linter) lintTagBudgets(prefix string, tags []TagBudget, globalCurrency string) {
+	parsed := make([]*ParsedTagSelector, len(tags))
+	for i := range tags {
+		key := fmt.Sprintf("%s[%d]", prefix, i)
+		selector, err := ParseTagSelector(tags[i].Selector)
+		if err != nil {
+			l.add(LintError, key+".selector", "%v", err)
+			continue
+		}
+		parsed[i] = selector
+		l.lintScopedBudget(key, &tags[i].ScopedBudget, globalCurrency)
+	}
+
+	for j := range tags {
+		for i := range j {
+			a, b := parsed[i], parsed[j]
+			if a == nil || b == nil || a.Key != b.Key || (a.Value != b.Value && !a.IsWildcard && !b.IsWildcard) {
+				continue
+			}
+			// Report on the later budget, or on the one that never applies.
+			later, earlier := j, i
+			if tags[i].Priority < tags[j].Priority {
+				later, earlier = i, j
+			}
+			key := fmt.Sprintf("%s[%d]", prefix, later)
+			switch {
+			case tags[i].Priority == tags[j].Priority:
+				l.add(LintWarning, key, "ties with %q at priority %d: resources matching both are assigned to "+
+					"one of them; give them different priorities", tags[earlier].Selector, tags[i].Priority)
+			case parsed[earlier].IsWildcard || parsed[earlier].Value == parsed[later].Value:
+				l.add(LintWarning, key, "never applies: %q has a higher priority (%d) and matches every resource "+
+					"%q matches", tags[earlier].Selector, tags[earlier].Priority, tags[later].Selector)
+			}
+		}
+	}
+}
+
+// lintCurrency checks the currency conversion settings.
+func (l *linter) lintCurrency(currency *CurrencyConfig) {
+	if currency == nil {
+		return
+	}
+	before := len(l.issues)
+	if currency.Target != "" {
+		if err := validateCurrencyCode(currency.Target); err != nil {
+			l.add(LintError, "currency.target", "%v", err)
+		}
+	}
+	if currency.Base != "" {
+		if err := validateCurrencyCode(currency.Base); err != nil {
+			l.add(LintError, "currency.base", "%v", err)
+		}
+	}
+	for _, code := range sortedKeys(currency.Rates) {
+		if err := validateCurrencyCode(code); err != nil {
+			l.add(LintError, "currency.rates."+code, "%v", err)
+		}
+	}
+	if len(l.issues) == before {
+		if err := currency.Validate(); err != nil {
+			l.add(LintError, "currency", "%v", err)
+		}
+	}
+}
+
+// lintSelectors checks the syntax of the allocation tag selectors and the
+// routing patterns.
+func (l *linter) lintSelectors(cfg *Config) {
+	if allocation := cfg.Cost.Allocation; allocation != nil {
+		for i, rule := range allocation.Rules {
+			if _, err := ParseTagSelector(rule.Selector); err != nil {
+				l.add(LintError, fmt.Sprintf("cost.allocation.rules[%d].selector", i), "%v", err)
+			}
+		}
+		for i, pool := range allocation.Shared {
+			if _, err := ParseTagSelector(pool.Selector); err != nil {
+				l.add(LintError, fmt.Sprintf("cost.allocation.shared[%d].selector", i), "%v", err)
+			}
+		}
+	}
+
+	if cfg.Routing == nil {
+		return
+	}
+	for i, plugin := range cfg.Routing.Plugins {
+		for j, pattern := range plugin.Patterns {
+			if err := validatePattern(plugin.Name, j, pattern); err != nil {
+				l.add(LintError, fmt.Sprintf("routing.plugins[%d].patterns[%d]", i, j), "%v", err)
+			}
+		}
+	}
+}
+
+// lintPolicy checks that the policy files exist.
+func (l *linter) lintPolicy(cfg *Config) {
+	for i, file := range cfg.PolicyFiles() {
+		if _, err := os.Stat(file); err != nil {
+			l.add(LintError, fmt.Sprintf("policy.files[%d]", i), "policy file %s: %v", file,
+				errors.Unwrap(err))
+		}
+	}
+}
+
+// lintPluginPaths checks that the binaries of the enabled analyzer plugins
+// exist and are executable.
+func (l *linter) lintPluginPaths(plugins map[string]AnalyzerPlugin) {
+	for _, name := range sortedKeys(plugins) {
+		plugin := plugins[name]
+		if !plugin.Enabled || plugin.Path == "" {
+			continue
+		}
+		key := "analyzer.plugins." + name + ".path"
+		info, err := os.Stat(plugin.Path)
+		switch {
+		case err != nil:
+			l.add(LintError, key, "plugin binary %s does not exist: install the plugin or fix the path",
+				plugin.Path)
+		case info.IsDir():
+			l.add(LintError, key, "%s is a directory: point path at the plugin binary", plugin.Path)
+		case runtime.GOOS != "windows" && info.Mode().Perm()&0o111 == 0:
+			l.add(LintError, key, "plugin binary %s is not executable: run chmod +x %s", plugin.Path, plugin.Path)
+		}
+	}
+}
+
+// lintSecrets checks that secret references resolve, and reports secrets
+// stored in plain text.
+func (l *linter) lintSecrets(cfg *Config) {
+	resolver := cfg.secretResolver()
+	for _, key := range cfg.secretKeys() {
+		value, _ := cfg.secretValue(key)
+		switch {
+		case secrets.IsReference(value):
+			if _, err := resolver.Resolve(context.Background(), value); err != nil {
+				l.add(LintError, key, "%v", err)
+			}
+		case value != "" && slices.Contains(fixedSecretKeys, key):
+			l.add(LintWarning, key, "secret stored in plain text: use a reference such as env://VAR, "+
+				"file://path, keychain://service, or aws-sm://secret-name")
+		}
+	}
+}
+
+// lintPluginSettings reports settings of plugins that are not installed.
+func (l *linter) lintPluginSettings(plugins map[string]PluginConfig, installed []string) {
+	isInstalled := make(map[string]bool, len(installed))
+	for _, name := range installed {
+		isInstalled[name] = true
+	}
+	for _, name := range sortedKeys(plugins) {
+		if !isInstalled[name] {
+			l.add(LintWarning, "plugins."+name, "no plugin named %q is installed, so these settings are unused; "+
+				"check 'finfocus plugin list' for its name", name)
+		}
+	}
+}
+
+// sortedKeys returns the keys of m, sorted.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,232 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// lintConfig writes content to a config file and lints it.
+func lintConfig(t *testing.T, content string, opts LintOptions) []LintIssue {
+	t.Helper()
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	issues, err := LintFile(path, opts)
+	require.NoError(t, err)
+	return issues
+}
+
+// findIssue returns the issue for key, failing the test when there is none.
+func findIssue(t *testing.T, issues []LintIssue, key string) LintIssue {
+	t.Helper()
+	for _, issue := range issues {
+		if issue.Key == key {
+			return issue
+		}
+	}
+	require.Failf(t, "issue not found", "no issue for %s in %v", key, issues)
+	return LintIssue{}
+}
+
+func TestLintFileClean(t *testing.T) {
+	issues := lintConfig(t, `output:
+  default_format: json
+cost:
+  budgets:
+    global:
+      amount: 1000
+      currency: USD
+    tags:
+      - selector: "team:platform"
+        amount: 200
+        priority: 10
+      - selector: "team:data"
+        amount: 300
+        priority: 10
+`, LintOptions{})
+	assert.Empty(t, issues)
+}
+
+func TestLintFileBudgets(t *testing.T) {
+	issues := lintConfig(t, `cost:
+  budgets:
+    global:
+      amount: 1000
+      currency: USD
+    providers:
+      aws:
+        amount: 100
+      AWS:
+        amount: 200
+        currency: EUR
+    tags:
+      - selector: "team:*"
+        amount: 500
+        priority: 20
+      - selector: "team:platform"
+        amount: 200
+        priority: 10
+      - selector: "env:prod"
+        amount: 300
+      - selector: "env:prod"
+        amount: 400
+      - selector: "bad selector"
+        amount: 50
+`, LintOptions{})
+	assert.True(t, HasLintErrors(issues))
+
+	overlap := findIssue(t, issues, "cost.budgets.providers.aws")
+	assert.Equal(t, LintWarning, overlap.Severity)
+	assert.Equal(t, 7, overlap.Line)
+	assert.Contains(t, overlap.Message, `overlaps provider budget "AWS"`)
+
+	mismatch := findIssue(t, issues, "cost.budgets.providers.AWS.currency")
+	assert.Equal(t, LintError, mismatch.Severity)
+	assert.Equal(t, 11, mismatch.Line)
+	assert.Contains(t, mismatch.Message, "remove it to inherit the global currency")
+
+	never := findIssue(t, issues, "cost.budgets.tags[1]")
+	assert.Equal(t, 16, never.Line)
+	assert.Contains(t, never.Message, `never applies: "team:*" has a higher priority (20)`)
+
+	tie := findIssue(t, issues, "cost.budgets.tags[3]")
+	assert.Equal(t, 21, tie.Line)
+	assert.Contains(t, tie.Message, `ties with "env:prod" at priority 0`)
+
+	selector := findIssue(t, issues, "cost.budgets.tags[4].selector")
+	assert.Equal(t, LintError, selector.Severity)
+	assert.Equal(t, 23, selector.Line)
+
+	for i := 1; i < len(issues); i++ {
+		assert.LessOrEqual(t, issues[i-1].Line, issues[i].Line, "issues are sorted by line")
+	}
+}
+
+func TestLintFileScopedWithoutGlobal(t *testing.T) {
+	issues := lintConfig(t, `cost:
+  budgets:
+    stacks:
+      prod:
+        amount: 100
+`, LintOptions{})
+	issue := findIssue(t, issues, "cost.budgets")
+	assert.Equal(t, LintError, issue.Severity)
+	assert.Equal(t, 2, issue.Line)
+}
+
+func TestLintFileUnknownKeyAndTypes(t *testing.T) {
+	issues := lintConfig(t, `output:
+  default_fromat: json
+  precision: many
+`, LintOptions{})
+	require.Len(t, issues, 2)
+	assert.Equal(t, LintIssue{
+		Severity: LintWarning, Line: 2,
+		Message: "field default_fromat not found in type config.OutputConfig; " +
+			"the setting is ignored, check its spelling",
+	}, issues[0])
+	assert.Equal(t, LintError, issues[1].Severity)
+	assert.Equal(t, 3, issues[1].Line)
+	assert.Equal(t, "line 3: "+issues[1].Message, issues[1].String())
+}
+
+func TestLintFileSyntaxError(t *testing.T) {
+	issues := lintConfig(t, "output:\n  default_format: [json\n", LintOptions{})
+	require.Len(t, issues, 1)
+	assert.Equal(t, LintError, issues[0].Severity)
+	assert.Positive(t, issues[0].Line)
+}
+
+func TestLintFileCacheAndCurrency(t *testing.T) {
+	issues := lintConfig(t, `cost:
+  cache:
+    ttl_seconds: 30
+    operation_ttl_seconds:
+      projected_cost: 1209600
+      forecast: 60
+currency:
+  target: usd
+  rates:
+    EURO: 1.1
+`, LintOptions{})
+
+	short := findIssue(t, issues, "cost.cache.ttl_seconds")
+	assert.Equal(t, LintWarning, short.Severity)
+	assert.Equal(t, 3, short.Line)
+	assert.Contains(t, short.Message, "under a minute")
+
+	long := findIssue(t, issues, "cost.cache.operation_ttl_seconds.projected_cost")
+	assert.Contains(t, long.Message, "over 7 days")
+
+	op := findIssue(t, issues, "cost.cache.operation_ttl_seconds.forecast")
+	assert.Equal(t, LintError, op.Severity)
+
+	assert.Equal(t, LintError, findIssue(t, issues, "currency.target").Severity)
+	assert.Equal(t, 10, findIssue(t, issues, "currency.rates.EURO").Line)
+}
+
+func TestLintFilePlugins(t *testing.T) {
+	dir := t.TempDir()
+	notExecutable := filepath.Join(dir, "plugin")
+	require.NoError(t, os.WriteFile(notExecutable, []byte("#!/bin/sh\n"), 0600))
+
+	content := `plugins:
+  aws-public:
+    region: us-east-1
+  kubecost:
+    endpoint: http://localhost:9090
+analyzer:
+  plugins:
+    missing:
+      path: ` + filepath.Join(dir, "missing") + `
+      enabled: true
+    disabled:
+      path: ` + filepath.Join(dir, "missing") + `
+      enabled: false
+    directory:
+      path: ` + dir + `
+      enabled: true
+    plain:
+      path: ` + notExecutable + `
+      enabled: true
+`
+	issues := lintConfig(t, content, LintOptions{InstalledPlugins: []string{"aws-public"}})
+
+	unused := findIssue(t, issues, "plugins.kubecost")
+	assert.Equal(t, LintWarning, unused.Severity)
+	assert.Equal(t, 4, unused.Line)
+
+	assert.Contains(t, findIssue(t, issues, "analyzer.plugins.missing.path").Message, "does not exist")
+	assert.Contains(t, findIssue(t, issues, "analyzer.plugins.directory.path").Message, "is a directory")
+	for _, issue := range issues {
+		assert.False(t, strings.HasPrefix(issue.Key, "analyzer.plugins.disabled"), "disabled plugins are skipped")
+		assert.NotEqual(t, "plugins.aws-public", issue.Key)
+	}
+
+	issues = lintConfig(t, content, LintOptions{})
+	for _, issue := range issues {
+		assert.NotEqual(t, "plugins.kubecost", issue.Key, "plugin settings are only checked when asked")
+	}
+}
+
+func TestLintFileSelectors(t *testing.T) {
+	issues := lintConfig(t, `cost:
+  allocation:
+    rules:
+      - selector: "team"
+        team: platform
+routing:
+  plugins:
+    - name: aws-public
+      patterns:
+        - type: regex
+          pattern: "aws:(ec2"
+`, LintOptions{})
+	assert.Equal(t, 4, findIssue(t, issues, "cost.allocation.rules[0].selector").Line)
+	assert.Equal(t, 10, findIssue(t, issues, "routing.plugins[0].patterns[0]").Line)
+}
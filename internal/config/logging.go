@@ -162,6 +162,7 @@ func init() {
 //   - Level, Format are copied directly
 //   - If File is set, Output becomes "file" and File is passed through
 //   - If File is empty, Output defaults to "stderr"
+//   - FileLevel and the rotation limits apply to the file
 func (lc *LoggingConfig) ToLoggingConfig() logging.Config {
 	output := "stderr"
 	if lc.File != "" {
@@ -169,11 +170,17 @@ func (lc *LoggingConfig) ToLoggingConfig() logging.Config {
 	}
 
 	return logging.Config{
-		Level:  lc.Level,
-		Format: lc.Format,
-		Output: output,
-		File:   lc.File,
-		Caller: false, // Default, can be extended if needed
+		Level:     lc.Level,
+		Format:    lc.Format,
+		Output:    output,
+		File:      lc.File,
+		Caller:    false, // Default, can be extended if needed
+		FileLevel: lc.FileLevel,
+		Rotate: logging.RotateOptions{
+			MaxSizeMB:  lc.MaxSizeMB,
+			MaxAge:     time.Duration(lc.MaxAgeDays) * hoursPerDay * time.Hour,
+			MaxBackups: lc.MaxFiles,
+		},
 	}
 }
 
@@ -3,8 +3,10 @@ package config_test
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
 	"github.com/rshade/finfocus/internal/config"
@@ -169,3 +171,31 @@ func TestGetLoggingConfig(t *testing.T) {
 	// Should return valid defaults
 	assert.NotEmpty(t, loggingCfg.Level, "Level should have a default value")
 }
+
+func TestLoggingConfig_FileRotation(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	cfg := config.New()
+	assert.Equal(t, "debug", cfg.Logging.FileLevel, "the log file keeps debug logs by default")
+
+	require.NoError(t, cfg.Set("logging.file_level", "info"))
+	require.NoError(t, cfg.Set("logging.max_size_mb", "20"))
+	require.NoError(t, cfg.Set("logging.max_age_days", "3"))
+	require.NoError(t, cfg.Set("logging.max_files", "0"))
+	value, err := cfg.Get("logging.max_size_mb")
+	require.NoError(t, err)
+	assert.Equal(t, 20, value)
+	require.Error(t, cfg.Set("logging.max_files", "many"))
+
+	logCfg := cfg.Logging.ToLoggingConfig()
+	assert.Equal(t, "info", logCfg.FileLevel)
+	assert.Equal(t, 20, logCfg.Rotate.MaxSizeMB)
+	assert.Equal(t, 72*time.Hour, logCfg.Rotate.MaxAge)
+	assert.Zero(t, logCfg.Rotate.MaxBackups)
+
+	require.NoError(t, cfg.Validate())
+	require.NoError(t, cfg.Set("logging.max_age_days", "-1"))
+	require.ErrorContains(t, cfg.Validate(), "must not be negative")
+	require.NoError(t, cfg.Set("logging.max_age_days", "7"))
+	require.NoError(t, cfg.Set("logging.file_level", "verbose"))
+	require.ErrorContains(t, cfg.Validate(), "file_level")
+}
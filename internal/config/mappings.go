@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rshade/finfocus/internal/skus"
+)
+
+// MappingsFileName is the file in the configuration directory that holds the
+// user-supplied SKU and region mapping rules.
+const MappingsFileName = "mappings.yaml"
+
+// ErrEmptyMappingRule is returned for a mapping rule that matches no type or
+// resolves neither a SKU nor a region.
+var ErrEmptyMappingRule = errors.New("mapping rule needs type or match, and sku or region")
+
+// ErrEmptyProviderAlias is returned for a cost.provider_aliases entry that
+// names no namespace or no provider.
+var ErrEmptyProviderAlias = errors.New("provider alias needs a namespace and a provider")
+
+// MappingRule maps the properties of resources of a type to their SKU and
+// region, e.g. for a niche provider or a custom component resource.
+type MappingRule struct {
+	// Provider limits the rule to one provider, e.g. "digitalocean".
+	Provider string `yaml:"provider,omitempty" json:"provider,omitempty"`
+
+	// Type is a resource type the rule applies to, e.g.
+	// "digitalocean:index/droplet:Droplet".
+	Type string `yaml:"type,omitempty" json:"type,omitempty"`
+
+	// Match is a regular expression of the resource types the rule applies to.
+	Match string `yaml:"match,omitempty" json:"match,omitempty"`
+
+	// SKU and Region are expressions such as "${size}" or "${region|location}"
+	// that name the properties holding them; see skus.Rule.
+	SKU    string `yaml:"sku,omitempty"    json:"sku,omitempty"`
+	Region string `yaml:"region,omitempty" json:"region,omitempty"`
+}
+
+// Rule validates the mapping rule and compiles it.
+func (m MappingRule) Rule() (skus.Rule, error) {
+	if (m.Type == "" && m.Match == "") || (m.SKU == "" && m.Region == "") {
+		return skus.Rule{}, ErrEmptyMappingRule
+	}
+	rule := skus.Rule{Provider: m.Provider, Type: m.Type, SKU: m.SKU, Region: m.Region}
+	if m.Match != "" {
+		match, err := regexp.Compile(m.Match)
+		if err != nil {
+			return skus.Rule{}, fmt.Errorf("match: %w", err)
+		}
+		rule.Match = match
+	}
+	if err := skus.ValidateExpression(m.SKU); err != nil {
+		return skus.Rule{}, fmt.Errorf("sku: %w", err)
+	}
+	if err := skus.ValidateExpression(m.Region); err != nil {
+		return skus.Rule{}, fmt.Errorf("region: %w", err)
+	}
+	return rule, nil
+}
+
+// mappingsFile is the structure of the mappings file.
+type mappingsFile struct {
+	Mappings []MappingRule `yaml:"mappings"`
+}
+
+// LoadMappingRules reads and compiles the mapping rules in the file at path,
+// or in MappingsFileName in the directory returned by ResolveConfigDir when
+// path is empty. A missing file holds no rules.
+func LoadMappingRules(path string) (skus.Rules, error) {
+	if path == "" {
+		path = filepath.Join(ResolveConfigDir(), MappingsFileName)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading mappings file: %w", err)
+	}
+
+	var file mappingsFile
+	if unmarshalErr := yaml.Unmarshal(data, &file); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing mappings file %s: %w", path, unmarshalErr)
+	}
+	rules := make(skus.Rules, 0, len(file.Mappings))
+	for i, mapping := range file.Mappings {
+		rule, ruleErr := mapping.Rule()
+		if ruleErr != nil {
+			return nil, fmt.Errorf("%s: mappings[%d]: %w", path, i, ruleErr)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
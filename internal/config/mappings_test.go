@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadMappingRules(t *testing.T) {
+	path := filepath.Join(t.TempDir(), MappingsFileName)
+	require.NoError(t, os.WriteFile(path, []byte(`
+mappings:
+  - provider: digitalocean
+    match: "^digitalocean:index/droplet"
+    sku: "${size}"
+    region: "${region}"
+  - type: "acme:index/server:Server"
+    sku: "${plan|tier}"
+`), 0o600))
+
+	rules, err := LoadMappingRules(path)
+	require.NoError(t, err)
+	require.Len(t, rules, 2)
+	assert.True(t, rules[0].Matches("digitalocean", "digitalocean:index/droplet:Droplet"))
+	assert.False(t, rules[0].Matches("aws", "digitalocean:index/droplet:Droplet"))
+
+	sku, region := rules.Resolve("acme", "acme:index/server:Server", map[string]string{"tier": "large"})
+	assert.Equal(t, "large", sku)
+	assert.Empty(t, region)
+}
+
+func TestLoadMappingRules_MissingFile(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	rules, err := LoadMappingRules("")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+}
+
+func TestLoadMappingRules_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "no type", content: `mappings: [{sku: "${size}"}]`, wantErr: "needs type or match"},
+		{name: "no expression", content: `mappings: [{type: "acme:index/server:Server"}]`, wantErr: "sku or region"},
+		{name: "bad regex", content: `mappings: [{match: "(", sku: "${size}"}]`, wantErr: "mappings[0]: match"},
+		{name: "bad expression", content: `mappings: [{type: t, region: "${zone"}]`, wantErr: "region: unterminated"},
+		{name: "bad YAML", content: `mappings: {`, wantErr: "parsing mappings file"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), MappingsFileName)
+			require.NoError(t, os.WriteFile(path, []byte(tt.content), 0o600))
+
+			_, err := LoadMappingRules(path)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
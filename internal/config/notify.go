@@ -0,0 +1,135 @@
+package config
+
+import (
+	"errors"
+	"net/url"
+)
+
+// errUnknownNotifyKey is returned for unsupported notify.* keys.
+var errUnknownNotifyKey = errors.New(
+	"unknown notify setting (supported: notify.slack.webhook_url, notify.slack.bot_token, notify.slack.channel)")
+
+// NotifyConfig holds settings for the 'finfocus notify' commands.
+type NotifyConfig struct {
+	// Slack configures where Slack digests are posted. Nil when not configured.
+	Slack *SlackConfig `yaml:"slack,omitempty" json:"slack,omitempty"`
+}
+
+// SlackConfig configures Slack delivery, either through an incoming webhook or
+// through a bot token and channel. SLACK_WEBHOOK_URL and SLACK_BOT_TOKEN take
+// precedence when set.
+type SlackConfig struct {
+	// WebhookURL is an incoming webhook URL; the webhook decides the channel.
+	WebhookURL string `yaml:"webhook_url,omitempty" json:"webhook_url,omitempty"`
+
+	// BotToken is a bot token with the chat:write scope, used with Channel.
+	BotToken string `yaml:"bot_token,omitempty" json:"bot_token,omitempty"`
+
+	// Channel is the channel ID or name bot token messages are posted to.
+	Channel string `yaml:"channel,omitempty" json:"channel,omitempty"`
+}
+
+// Validate checks that a configured webhook URL is an https URL.
+func (n *NotifyConfig) Validate() error {
+	if n == nil || n.Slack == nil || n.Slack.WebhookURL == "" {
+		return nil
+	}
+	return validateSlackWebhookURL(n.Slack.WebhookURL)
+}
+
+// redacted returns a copy of n with the webhook URL and bot token masked.
+func (n *NotifyConfig) redacted() *NotifyConfig {
+	if n == nil {
+		return nil
+	}
+	out := *n
+	if n.Slack != nil {
+		slack := *n.Slack
+		if slack.WebhookURL != "" {
+			slack.WebhookURL = redactedValue
+		}
+		if slack.BotToken != "" {
+			slack.BotToken = redactedValue
+		}
+		out.Slack = &slack
+	}
+	return &out
+}
+
+// SlackSettings returns the configured Slack settings, or an empty value if none.
+func (c *Config) SlackSettings() SlackConfig {
+	return c.Notify.SlackSettings()
+}
+
+// setNotifyValue sets a notify.* configuration value.
+func (c *Config) setNotifyValue(parts []string, value string) error {
+	if len(parts) != 2 || parts[0] != "slack" { //nolint:mnd // slack.<key>
+		return errUnknownNotifyKey
+	}
+
+	settings := c.SlackSettings()
+	switch parts[1] {
+	case "webhook_url":
+		if value != "" {
+			if err := validateSlackWebhookURL(value); err != nil {
+				return err
+			}
+		}
+		settings.WebhookURL = value
+	case "bot_token":
+		settings.BotToken = value
+	case "channel":
+		settings.Channel = value
+	default:
+		return errUnknownNotifyKey
+	}
+
+	if c.Notify == nil {
+		c.Notify = &NotifyConfig{}
+	}
+	c.Notify.Slack = &settings
+	return nil
+}
+
+// getNotifyValue gets a notify.* configuration value. The webhook URL and bot
+// token are never returned in clear text.
+func (c *Config) getNotifyValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Notify.redacted(), nil
+	}
+	if parts[0] != "slack" || len(parts) > 2 { //nolint:mnd // slack.<key>
+		return nil, errUnknownNotifyKey
+	}
+
+	settings := c.Notify.redacted().SlackSettings()
+	if len(parts) == 1 {
+		return settings, nil
+	}
+	switch parts[1] {
+	case "webhook_url":
+		return settings.WebhookURL, nil
+	case "bot_token":
+		return settings.BotToken, nil
+	case "channel":
+		return settings.Channel, nil
+	default:
+		return nil, errUnknownNotifyKey
+	}
+}
+
+// SlackSettings returns n's Slack settings, or an empty value if none.
+func (n *NotifyConfig) SlackSettings() SlackConfig {
+	if n == nil || n.Slack == nil {
+		return SlackConfig{}
+	}
+	return *n.Slack
+}
+
+// validateSlackWebhookURL checks that raw is an absolute https URL.
+func validateSlackWebhookURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || u.Scheme != "https" {
+		return errors.New("invalid notify.slack.webhook_url: must be an https URL")
+	}
+	return nil
+}
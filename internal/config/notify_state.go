@@ -0,0 +1,149 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// NotifyStateVersion is the current schema version for the notify state file.
+const NotifyStateVersion = 1
+
+// DigestState records what the last digest of one kind reported.
+type DigestState struct {
+	// LastSent is when the digest was last delivered.
+	LastSent time.Time `json:"last_sent"`
+	// SeenRecommendations holds the fingerprints of the recommendations the
+	// last digest knew about, so the next one can report only new ones.
+	SeenRecommendations []string `json:"seen_recommendations,omitempty"`
+}
+
+// notifyStateData is the serialized form of the notify state store.
+type notifyStateData struct {
+	Version int                     `json:"version"`
+	Digests map[string]*DigestState `json:"digests"`
+}
+
+// NotifyStateStore persists per-digest delivery state as a JSON file.
+type NotifyStateStore struct {
+	mu       sync.RWMutex
+	filePath string
+	digests  map[string]*DigestState
+}
+
+// NewNotifyStateStore creates a new NotifyStateStore backed by the given file path.
+// If filePath is empty, it defaults to notify_state.json in the directory
+// returned by ResolveConfigDir (normally ~/.finfocus).
+func NewNotifyStateStore(filePath string) *NotifyStateStore {
+	if filePath == "" {
+		filePath = filepath.Join(ResolveConfigDir(), "notify_state.json")
+	}
+
+	return &NotifyStateStore{
+		filePath: filePath,
+		digests:  make(map[string]*DigestState),
+	}
+}
+
+// FilePath returns the path to the notify state file.
+func (s *NotifyStateStore) FilePath() string {
+	return s.filePath
+}
+
+// Load reads the notify state from the JSON file.
+// If the file does not exist, the store starts empty.
+// If the file is corrupted, ErrHistoryCorrupted is returned.
+func (s *NotifyStateStore) Load() error {
+	unlock, lockErr := acquireLockFile(s.filePath + ".lock")
+	if lockErr != nil {
+		return fmt.Errorf("acquiring file lock: %w", lockErr)
+	}
+	defer unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.digests = make(map[string]*DigestState)
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return fmt.Errorf("reading notify state file: %w", err)
+	}
+
+	var storeData notifyStateData
+	if unmarshalErr := json.Unmarshal(data, &storeData); unmarshalErr != nil {
+		return fmt.Errorf("%w: %w", ErrHistoryCorrupted, unmarshalErr)
+	}
+	if storeData.Version != NotifyStateVersion {
+		return fmt.Errorf("%w: unsupported version %d (expected %d)",
+			ErrHistoryCorrupted, storeData.Version, NotifyStateVersion)
+	}
+
+	if storeData.Digests != nil {
+		s.digests = storeData.Digests
+	}
+	return nil
+}
+
+// Save writes the notify state to the JSON file atomically.
+func (s *NotifyStateStore) Save() error {
+	unlock, lockErr := acquireLockFile(s.filePath + ".lock")
+	if lockErr != nil {
+		return fmt.Errorf("acquiring file lock: %w", lockErr)
+	}
+	defer unlock()
+
+	s.mu.RLock()
+	data, err := json.MarshalIndent(notifyStateData{
+		Version: NotifyStateVersion,
+		Digests: s.digests,
+	}, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshaling notify state: %w", err)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(s.filePath), 0o750); mkdirErr != nil {
+		return fmt.Errorf("creating notify state directory: %w", mkdirErr)
+	}
+
+	tmpPath := s.filePath + ".tmp"
+	if writeErr := os.WriteFile(tmpPath, data, 0o600); writeErr != nil {
+		return fmt.Errorf("writing notify state temp file: %w", writeErr)
+	}
+
+	if renameErr := os.Rename(tmpPath, s.filePath); renameErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("renaming notify state temp file: %w", renameErr)
+	}
+
+	return nil
+}
+
+// Digest returns the state recorded for the named digest and whether one exists.
+func (s *NotifyStateStore) Digest(name string) (DigestState, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	state, ok := s.digests[name]
+	if !ok {
+		return DigestState{}, false
+	}
+	out := *state
+	out.SeenRecommendations = append([]string(nil), state.SeenRecommendations...)
+	return out, true
+}
+
+// SetDigest replaces the state recorded for the named digest.
+func (s *NotifyStateStore) SetDigest(name string, state DigestState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	state.SeenRecommendations = append([]string(nil), state.SeenRecommendations...)
+	s.digests[name] = &state
+}
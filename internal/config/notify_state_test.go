@@ -0,0 +1,50 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewNotifyStateStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", dir)
+	assert.Equal(t, filepath.Join(dir, "notify_state.json"), NewNotifyStateStore("").FilePath())
+}
+
+func TestNotifyStateStore_LoadSave(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "notify_state.json")
+	sentAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+	store := NewNotifyStateStore(path)
+	require.NoError(t, store.Load())
+	_, ok := store.Digest("slack-daily")
+	assert.False(t, ok)
+
+	seen := []string{"rec-1", "rec-2"}
+	store.SetDigest("slack-daily", DigestState{LastSent: sentAt, SeenRecommendations: seen})
+	seen[0] = "mutated"
+	require.NoError(t, store.Save())
+
+	reloaded := NewNotifyStateStore(path)
+	require.NoError(t, reloaded.Load())
+	state, ok := reloaded.Digest("slack-daily")
+	require.True(t, ok)
+	assert.True(t, sentAt.Equal(state.LastSent))
+	assert.Equal(t, []string{"rec-1", "rec-2"}, state.SeenRecommendations, "the store keeps its own copy")
+}
+
+func TestNotifyStateStore_Corrupted(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "notify_state.json")
+	require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+	require.ErrorIs(t, NewNotifyStateStore(path).Load(), ErrHistoryCorrupted)
+
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":99}`), 0o600))
+	require.ErrorIs(t, NewNotifyStateStore(path).Load(), ErrHistoryCorrupted)
+}
@@ -0,0 +1,101 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+var errUnknownPolicyKey = errors.New("unknown policy setting (supported: policy.files, policy.require_tags)")
+
+// PolicyConfig holds the cost policies 'policy check' enforces when --policy
+// is omitted, usually set by the project config file of an IaC repository.
+type PolicyConfig struct {
+	// Files are policy files, relative to the directory of the config file
+	// that lists them.
+	Files []string `yaml:"files,omitempty" json:"files,omitempty"`
+
+	// RequireTags lists tags every resource must have with a non-empty value.
+	RequireTags []string `yaml:"require_tags,omitempty" json:"require_tags,omitempty"`
+}
+
+// Validate checks that no policy file or required tag is empty.
+func (p *PolicyConfig) Validate() error {
+	if p == nil {
+		return nil
+	}
+	for i, file := range p.Files {
+		if strings.TrimSpace(file) == "" {
+			return fmt.Errorf("files[%d]: path cannot be empty", i)
+		}
+	}
+	for i, tag := range p.RequireTags {
+		if strings.TrimSpace(tag) == "" {
+			return fmt.Errorf("require_tags[%d]: tag cannot be empty", i)
+		}
+	}
+	return nil
+}
+
+// PolicyFiles returns the paths of the configured policy files. Relative
+// paths of the user config file are resolved against its directory; those
+// of a project config file were resolved when it was applied.
+func (c *Config) PolicyFiles() []string {
+	if c.Policy == nil || len(c.Policy.Files) == 0 {
+		return nil
+	}
+	files := make([]string, len(c.Policy.Files))
+	for i, file := range c.Policy.Files {
+		if !filepath.IsAbs(file) {
+			file = filepath.Join(filepath.Dir(c.configPath), file)
+		}
+		files[i] = file
+	}
+	return files
+}
+
+// RequiredTags returns the tags every resource must have, or nil for none.
+func (c *Config) RequiredTags() []string {
+	if c.Policy == nil {
+		return nil
+	}
+	return c.Policy.RequireTags
+}
+
+// setPolicyValue sets a policy.* configuration value from a comma-separated
+// list. An empty value clears the setting.
+func (c *Config) setPolicyValue(parts []string, value string) error {
+	if len(parts) != 1 || (parts[0] != "files" && parts[0] != "require_tags") {
+		return errUnknownPolicyKey
+	}
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	if c.Policy == nil {
+		c.Policy = &PolicyConfig{}
+	}
+	if parts[0] == "files" {
+		c.Policy.Files = items
+	} else {
+		c.Policy.RequireTags = items
+	}
+	return nil
+}
+
+// getPolicyValue gets a policy.* configuration value.
+func (c *Config) getPolicyValue(parts []string) (interface{}, error) {
+	switch {
+	case len(parts) == 0:
+		return c.Policy, nil
+	case len(parts) == 1 && parts[0] == "files":
+		return c.PolicyFiles(), nil
+	case len(parts) == 1 && parts[0] == "require_tags":
+		return c.RequiredTags(), nil
+	default:
+		return nil, errUnknownPolicyKey
+	}
+}
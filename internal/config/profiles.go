@@ -0,0 +1,87 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvProfile names the config profile to apply, like the --profile flag.
+const EnvProfile = "FINFOCUS_PROFILE"
+
+// ErrUnknownProfile is returned when the selected profile is not in the
+// config file.
+var ErrUnknownProfile = errors.New("unknown config profile")
+
+// ErrProfileActive is returned by Save while a profile is applied, since
+// saving would write the profile's settings into the base configuration.
+var ErrProfileActive = errors.New("cannot save the configuration while a profile is applied")
+
+// ActiveProfile returns the name of the profile selected with
+// FINFOCUS_PROFILE, or "" for none.
+func ActiveProfile() string {
+	return strings.TrimSpace(os.Getenv(EnvProfile))
+}
+
+// CheckProfile returns ErrUnknownProfile when the config file does not
+// define the named profile. An empty name selects no profile and is valid.
+func CheckProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+	cfg := defaultConfig(ResolveConfigDir())
+	if err := cfg.Load(); err != nil && !os.IsNotExist(err) {
+		// Unreadable files are reported when the configuration is loaded.
+		return nil //nolint:nilerr // Not a profile problem.
+	}
+	return cfg.ApplyProfile(name)
+}
+
+// ProfileNames returns the names of the configured profiles, sorted.
+func (c *Config) ProfileNames() []string {
+	return sortedKeys(c.Profiles)
+}
+
+// Profile returns the name of the applied profile, or "" for none.
+func (c *Config) Profile() string {
+	return c.profile
+}
+
+// ApplyProfile layers the settings of the named profile over the
+// configuration. Settings the profile sets replace those of the base
+// configuration; maps such as plugins and budget scopes gain the profile's
+// entries, replacing entries of the same name, and lists are replaced
+// whole. An empty name applies nothing.
+func (c *Config) ApplyProfile(name string) error {
+	if name == "" {
+		return nil
+	}
+	node, ok := c.Profiles[name]
+	if !ok {
+		if len(c.Profiles) == 0 {
+			return fmt.Errorf("%w %q: the config file defines no profiles", ErrUnknownProfile, name)
+		}
+		return fmt.Errorf("%w %q: use one of %s", ErrUnknownProfile, name,
+			strings.Join(c.ProfileNames(), ", "))
+	}
+
+	profiles := c.Profiles
+	if err := node.Decode(c); err != nil {
+		return fmt.Errorf("applying config profile %q: %w", name, err)
+	}
+	c.Profiles = profiles
+	c.profile = name
+	return nil
+}
+
+// applyActiveProfile applies the profile selected with FINFOCUS_PROFILE.
+func (c *Config) applyActiveProfile() error {
+	return c.ApplyProfile(ActiveProfile())
+}
+
+// errProfileActive returns ErrProfileActive for the applied profile.
+func (c *Config) errProfileActive() error {
+	return fmt.Errorf("%w (%q): unset --profile and %s to change the config file",
+		ErrProfileActive, c.profile, EnvProfile)
+}
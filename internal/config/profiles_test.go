@@ -0,0 +1,157 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const profilesConfig = `output:
+  default_format: json
+plugins:
+  aws-public:
+    region: us-east-1
+cost:
+  budgets:
+    global:
+      amount: 1000
+      currency: USD
+    providers:
+      aws:
+        amount: 500
+profiles:
+  client-a:
+    plugins:
+      kubecost:
+        endpoint: http://kubecost.client-a:9090
+    cost:
+      budgets:
+        global:
+          amount: 250
+        providers:
+          gcp:
+            amount: 100
+    currency:
+      target: EUR
+  staging:
+    output:
+      precision: 4
+`
+
+// writeProfilesConfig writes profilesConfig to the config file of a new
+// finfocus home.
+func writeProfilesConfig(t *testing.T) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	t.Setenv(EnvProfile, "")
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(profilesConfig), 0600))
+}
+
+func TestApplyProfile(t *testing.T) {
+	writeProfilesConfig(t)
+
+	base := New()
+	assert.Empty(t, base.Profile())
+	assert.Equal(t, []string{"client-a", "staging"}, base.ProfileNames())
+	assert.InDelta(t, 1000, base.Cost.Budgets.Global.Amount, 1e-9)
+	assert.Empty(t, base.CurrencyTarget())
+
+	t.Setenv(EnvProfile, "client-a")
+	cfg := New()
+	assert.Equal(t, "client-a", cfg.Profile())
+	assert.Equal(t, "json", cfg.Output.DefaultFormat, "settings the profile does not set are kept")
+	assert.Contains(t, cfg.Plugins, "aws-public")
+	assert.Equal(t, "http://kubecost.client-a:9090", cfg.Plugins["kubecost"].Config["endpoint"])
+	assert.InDelta(t, 250, cfg.Cost.Budgets.Global.Amount, 1e-9)
+	assert.Equal(t, "USD", cfg.Cost.Budgets.Global.Currency, "profile settings are merged into the base ones")
+	assert.Contains(t, cfg.Cost.Budgets.Providers, "aws")
+	assert.Contains(t, cfg.Cost.Budgets.Providers, "gcp")
+	assert.Equal(t, "EUR", cfg.CurrencyTarget())
+	assert.Equal(t, []string{"client-a", "staging"}, cfg.ProfileNames())
+
+	t.Setenv("FINFOCUS_OUTPUT_PRECISION", "6")
+	t.Setenv(EnvProfile, "staging")
+	assert.Equal(t, 6, New().Output.Precision, "environment variables override profiles")
+}
+
+func TestApplyProfileUnknown(t *testing.T) {
+	writeProfilesConfig(t)
+
+	require.NoError(t, CheckProfile(""))
+	require.NoError(t, CheckProfile("staging"))
+	err := CheckProfile("prod")
+	require.ErrorIs(t, err, ErrUnknownProfile)
+	assert.Contains(t, err.Error(), "use one of client-a, staging")
+
+	t.Setenv(EnvProfile, "prod")
+	_, err = NewStrict()
+	require.ErrorIs(t, err, ErrUnknownProfile)
+
+	err = (&Config{}).ApplyProfile("prod")
+	assert.Contains(t, err.Error(), "the config file defines no profiles")
+}
+
+func TestSaveWithProfile(t *testing.T) {
+	writeProfilesConfig(t)
+	t.Setenv(EnvProfile, "client-a")
+
+	cfg := New()
+	require.ErrorIs(t, cfg.Save(), ErrProfileActive)
+	require.ErrorIs(t, cfg.SaveCommented(), ErrProfileActive)
+
+	t.Setenv(EnvProfile, "")
+	cfg = New()
+	cfg.Output.Precision = 3
+	require.NoError(t, cfg.Save())
+
+	t.Setenv(EnvProfile, "client-a")
+	cfg = New()
+	assert.Equal(t, 3, cfg.Output.Precision)
+	assert.InDelta(t, 250, cfg.Cost.Budgets.Global.Amount, 1e-9, "profiles survive saving")
+}
+
+func TestLintFileProfiles(t *testing.T) {
+	issues := lintConfig(t, `cost:
+  cache:
+    ttl_seconds: 30
+profiles:
+  work:
+    output:
+      default_fromat: json
+    currency:
+      target: euro
+    profiles:
+      nested: {}
+  home:
+    output:
+      precision: 4
+`, LintOptions{})
+
+	// The base TTL is reported once, not again for each profile.
+	count := 0
+	for _, issue := range issues {
+		if issue.Line == 3 {
+			count++
+		}
+	}
+	assert.Equal(t, 1, count)
+
+	assert.Equal(t, LintIssue{
+		Severity: LintWarning, Line: 7,
+		Message: "field default_fromat not found in type config.OutputConfig; " +
+			"the setting is ignored, check its spelling",
+	}, findIssue(t, issues, ""))
+
+	target := findIssue(t, issues, "profiles.work.currency.target")
+	assert.Equal(t, LintError, target.Severity)
+	assert.Equal(t, 9, target.Line)
+
+	assert.Equal(t, 10, findIssue(t, issues, "profiles.work.profiles").Line)
+	for _, issue := range issues {
+		assert.NotContains(t, issue.Key, "profiles.home")
+	}
+}
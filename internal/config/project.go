@@ -0,0 +1,157 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ProjectFileName is the name of project config files, which an IaC
+// repository checks in to share its cost policy.
+const ProjectFileName = ".finfocus.yaml"
+
+// EnvNoProjectConfig disables project config files when set to "1" or
+// "true".
+const EnvNoProjectConfig = "FINFOCUS_NO_PROJECT_CONFIG"
+
+// ErrProjectActive is returned by Save while a project config file is
+// applied, since saving would write the project's settings into the user
+// config file.
+var ErrProjectActive = errors.New("cannot save the configuration while a project config file is applied")
+
+// errProjectSetting is returned for settings a project config file cannot
+// set.
+var errProjectSetting = errors.New("project config files can set only cost.budgets, policy, and defaults")
+
+// FindProjectFile returns the path of the project config file in dir or
+// its closest ancestor, or "" when there is none.
+func FindProjectFile(dir string) string {
+	for {
+		path := filepath.Join(dir, ProjectFileName)
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			return path
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// ProjectFile returns the path of the applied project config file, or ""
+// for none.
+func (c *Config) ProjectFile() string {
+	return c.projectPath
+}
+
+// ApplyProjectFile layers the settings of the project config file at path
+// over the configuration, as ApplyProfile does for profiles. Project config
+// files can set only budgets, policies, and defaults, so a repository cannot
+// change the plugins or credentials of whoever runs finfocus in it. Relative
+// policy files are taken from the directory of the project config file.
+func (c *Config) ApplyProjectFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading project config file: %w", err)
+	}
+	var doc yaml.Node
+	if err = yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("parsing project config file %s: %w", path, err)
+	}
+	if len(doc.Content) > 0 {
+		root := doc.Content[0]
+		if root.Kind != yaml.MappingNode {
+			return fmt.Errorf("project config file %s: expected a mapping of settings", path)
+		}
+		if keys := unsupportedProjectKeys(root); len(keys) > 0 {
+			return fmt.Errorf("project config file %s: %w, not %s", path, errProjectSetting,
+				strings.Join(keys, ", "))
+		}
+		if err = root.Decode(c); err != nil {
+			return fmt.Errorf("applying project config file %s: %w", path, err)
+		}
+		if mappingValue(mappingValue(root, "policy"), "files") != nil {
+			for i, file := range c.Policy.Files {
+				if !filepath.IsAbs(file) {
+					c.Policy.Files[i] = filepath.Join(filepath.Dir(path), file)
+				}
+			}
+		}
+	}
+	c.projectPath = path
+	return nil
+}
+
+// ProjectFilePath returns the path of the project config file of the
+// working directory, found by FindProjectFile, or "" when there is none or
+// FINFOCUS_NO_PROJECT_CONFIG is set.
+func ProjectFilePath() string {
+	if v := os.Getenv(EnvNoProjectConfig); v == "1" || v == "true" {
+		return ""
+	}
+	dir, err := os.Getwd()
+	if err != nil {
+		return ""
+	}
+	return FindProjectFile(dir)
+}
+
+// applyProjectFile applies the project config file of the working
+// directory, if any.
+func (c *Config) applyProjectFile() error {
+	path := ProjectFilePath()
+	if path == "" {
+		return nil
+	}
+	return c.ApplyProjectFile(path)
+}
+
+// errProjectActive returns ErrProjectActive for the applied project config
+// file.
+func (c *Config) errProjectActive() error {
+	return fmt.Errorf("%w (%s): run the command outside the project or set %s=1",
+		ErrProjectActive, c.projectPath, EnvNoProjectConfig)
+}
+
+// unsupportedProjectKeys returns the dotted keys of the settings in root, a
+// project config file, that project config files cannot set.
+func unsupportedProjectKeys(root *yaml.Node) []string {
+	var keys []string
+	for i := 0; i+1 < len(root.Content); i += 2 {
+		switch section := root.Content[i].Value; section {
+		case "policy", "defaults":
+		case "cost":
+			cost := root.Content[i+1]
+			if cost.Kind != yaml.MappingNode {
+				continue
+			}
+			for j := 0; j+1 < len(cost.Content); j += 2 {
+				if cost.Content[j].Value != "budgets" {
+					keys = append(keys, "cost."+cost.Content[j].Value)
+				}
+			}
+		default:
+			keys = append(keys, section)
+		}
+	}
+	return keys
+}
+
+// mappingValue returns the value of key in the mapping node, or nil when
+// node is not a mapping or has no such key.
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
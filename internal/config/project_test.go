@@ -0,0 +1,141 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const projectUserConfig = `cost:
+  budgets:
+    global:
+      amount: 1000
+      currency: USD
+    providers:
+      aws:
+        amount: 500
+policy:
+  files: [user-policies.yaml]
+`
+
+const projectConfig = `cost:
+  budgets:
+    providers:
+      gcp:
+        amount: 200
+policy:
+  files: [policies/cost.yaml]
+  require_tags: [team, cost-center]
+defaults:
+  stack: prod
+`
+
+// writeProjectConfig writes projectUserConfig to the config file of a new
+// finfocus home and projectConfig to the project config file of a new
+// repository, and changes to a directory below it. It returns the
+// repository directory.
+func writeProjectConfig(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	t.Setenv(EnvProfile, "")
+	t.Setenv(EnvNoProjectConfig, "")
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(projectUserConfig), 0600))
+
+	repo := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectFileName), []byte(projectConfig), 0600))
+	dir := filepath.Join(repo, "infra", "prod")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	t.Chdir(dir)
+	return repo
+}
+
+func TestFindProjectFile(t *testing.T) {
+	repo := t.TempDir()
+	dir := filepath.Join(repo, "a", "b")
+	require.NoError(t, os.MkdirAll(dir, 0700))
+	assert.Empty(t, FindProjectFile(dir))
+
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectFileName), nil, 0600))
+	assert.Equal(t, filepath.Join(repo, ProjectFileName), FindProjectFile(dir))
+
+	require.NoError(t, os.Mkdir(filepath.Join(repo, "a", ProjectFileName), 0700))
+	assert.Equal(t, filepath.Join(repo, ProjectFileName), FindProjectFile(dir), "directories are skipped")
+}
+
+func TestApplyProjectFile(t *testing.T) {
+	repo := writeProjectConfig(t)
+
+	cfg := New()
+	assert.Equal(t, filepath.Join(repo, ProjectFileName), cfg.ProjectFile())
+	assert.InDelta(t, 1000, cfg.Cost.Budgets.Global.Amount, 1e-9, "user budgets are kept")
+	assert.InDelta(t, 500, cfg.Cost.Budgets.Providers["aws"].Amount, 1e-9)
+	assert.InDelta(t, 200, cfg.Cost.Budgets.Providers["gcp"].Amount, 1e-9)
+	assert.Equal(t, "prod", cfg.DefaultStack())
+	assert.Equal(t, []string{"team", "cost-center"}, cfg.RequiredTags())
+	assert.Equal(t, []string{filepath.Join(repo, "policies", "cost.yaml")}, cfg.PolicyFiles(),
+		"project policy files are relative to the project")
+
+	require.ErrorIs(t, cfg.Save(), ErrProjectActive)
+	require.ErrorIs(t, cfg.SaveCommented(), ErrProjectActive)
+
+	user := NewUserConfig()
+	assert.Empty(t, user.ProjectFile())
+	assert.Empty(t, user.DefaultStack())
+	assert.Equal(t, []string{filepath.Join(ResolveConfigDir(), "user-policies.yaml")}, user.PolicyFiles())
+	require.NoError(t, user.Set("defaults.stack", "dev"))
+	require.NoError(t, user.Save())
+
+	t.Setenv(EnvNoProjectConfig, "1")
+	assert.Empty(t, New().ProjectFile())
+	assert.Equal(t, "dev", New().DefaultStack())
+}
+
+func TestApplyProjectFileUnsupportedSettings(t *testing.T) {
+	repo := writeProjectConfig(t)
+	require.NoError(t, os.WriteFile(filepath.Join(repo, ProjectFileName), []byte(`plugins:
+  evil:
+    path: /tmp/evil
+cost:
+  cache:
+    enabled: false
+defaults:
+  stack: prod
+`), 0600))
+
+	_, err := NewStrict()
+	require.ErrorIs(t, err, errProjectSetting)
+	assert.Contains(t, err.Error(), "not plugins, cost.cache")
+
+	cfg := New()
+	assert.Empty(t, cfg.ProjectFile(), "the project config file is not applied")
+	assert.Empty(t, cfg.DefaultStack())
+}
+
+func TestLintProjectFile(t *testing.T) {
+	repo := writeProjectConfig(t)
+	path := filepath.Join(repo, ProjectFileName)
+
+	issues, err := LintProjectFile(path)
+	require.NoError(t, err)
+	require.Len(t, issues, 1, "budgets relying on the user's global budget are fine")
+	missing := findIssue(t, issues, "policy.files[0]")
+	assert.Equal(t, LintError, missing.Severity)
+	assert.Equal(t, 7, missing.Line)
+	assert.Contains(t, missing.Message, "no such file or directory")
+
+	require.NoError(t, os.WriteFile(path, []byte(`defaults:
+  stack: prod
+logging:
+  level: debug
+`), 0600))
+	issues, err = LintProjectFile(path)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	assert.Equal(t, "logging", issues[0].Key)
+	assert.Equal(t, 3, issues[0].Line)
+	assert.Contains(t, issues[0].Message, "set it in the user config file")
+}
@@ -0,0 +1,245 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// ProjectionHistoryVersion is the current schema version for the projection history file.
+const ProjectionHistoryVersion = 1
+
+// maxProjectionSnapshots is the number of snapshots retained per stack; older
+// snapshots are pruned when new ones are recorded.
+const maxProjectionSnapshots = 50
+
+// ProjectedResourceRecord captures one resource's projected monthly cost.
+type ProjectedResourceRecord struct {
+	// ResourceType is the Pulumi resource type (e.g., "aws:ec2/instance:Instance").
+	ResourceType string `json:"resource_type"`
+	// Provider is the cloud provider, used to query actual costs later.
+	Provider string `json:"provider,omitempty"`
+	// Monthly is the projected monthly cost.
+	Monthly float64 `json:"monthly"`
+	// Currency is the ISO 4217 currency code of Monthly.
+	Currency string `json:"currency,omitempty"`
+	// Tags are the resource's tags or labels, used to group organization reports.
+	Tags map[string]string `json:"tags,omitempty"`
+}
+
+// ProjectionSnapshot is the set of projected costs recorded for a stack at one point in time.
+type ProjectionSnapshot struct {
+	// RecordedAt is when the projection was recorded (normally at deploy time).
+	RecordedAt time.Time `json:"recorded_at"`
+	// Resources maps resource IDs to their projected costs.
+	Resources map[string]ProjectedResourceRecord `json:"resources"`
+}
+
+// projectionHistoryData is the serialized form of the projection history store.
+type projectionHistoryData struct {
+	Version int                              `json:"version"`
+	Stacks  map[string][]*ProjectionSnapshot `json:"stacks"`
+}
+
+// ProjectionHistoryStore persists projected cost snapshots per Pulumi stack as a
+// JSON file, so later actual spend can be compared against what was projected.
+type ProjectionHistoryStore struct {
+	mu       sync.RWMutex
+	filePath string
+	stacks   map[string][]*ProjectionSnapshot
+}
+
+// NewProjectionHistoryStore creates a new ProjectionHistoryStore backed by the given file path.
+// If filePath is empty, it defaults to projection_history.json in the directory
+// returned by ResolveConfigDir (normally ~/.finfocus).
+func NewProjectionHistoryStore(filePath string) *ProjectionHistoryStore {
+	if filePath == "" {
+		filePath = filepath.Join(ResolveConfigDir(), "projection_history.json")
+	}
+
+	return &ProjectionHistoryStore{
+		filePath: filePath,
+		stacks:   make(map[string][]*ProjectionSnapshot),
+	}
+}
+
+// FilePath returns the path to the projection history file.
+func (s *ProjectionHistoryStore) FilePath() string {
+	return s.filePath
+}
+
+// Load reads the projection history from the JSON file.
+// If the file does not exist, the store starts empty.
+// If the file is corrupted, ErrHistoryCorrupted is returned.
+func (s *ProjectionHistoryStore) Load() error {
+	unlock, lockErr := acquireLockFile(s.filePath + ".lock")
+	if lockErr != nil {
+		return fmt.Errorf("acquiring file lock: %w", lockErr)
+	}
+	defer unlock()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := os.ReadFile(s.filePath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			s.stacks = make(map[string][]*ProjectionSnapshot)
+			return nil
+		}
+		return fmt.Errorf("reading projection history file: %w", err)
+	}
+
+	var storeData projectionHistoryData
+	if unmarshalErr := json.Unmarshal(data, &storeData); unmarshalErr != nil {
+		s.stacks = make(map[string][]*ProjectionSnapshot)
+		return fmt.Errorf("%w: %w", ErrHistoryCorrupted, unmarshalErr)
+	}
+
+	if storeData.Version != ProjectionHistoryVersion {
+		s.stacks = make(map[string][]*ProjectionSnapshot)
+		return fmt.Errorf("%w: unsupported version %d (expected %d)",
+			ErrHistoryCorrupted, storeData.Version, ProjectionHistoryVersion)
+	}
+
+	if storeData.Stacks == nil {
+		storeData.Stacks = make(map[string][]*ProjectionSnapshot)
+	}
+
+	s.stacks = storeData.Stacks
+	return nil
+}
+
+// Save writes the projection history to the JSON file atomically.
+func (s *ProjectionHistoryStore) Save() error {
+	unlock, lockErr := acquireLockFile(s.filePath + ".lock")
+	if lockErr != nil {
+		return fmt.Errorf("acquiring file lock: %w", lockErr)
+	}
+	defer unlock()
+
+	s.mu.RLock()
+	data, err := json.MarshalIndent(projectionHistoryData{
+		Version: ProjectionHistoryVersion,
+		Stacks:  s.stacks,
+	}, "", "  ")
+	s.mu.RUnlock()
+	if err != nil {
+		return fmt.Errorf("marshaling projection history: %w", err)
+	}
+
+	if mkdirErr := os.MkdirAll(filepath.Dir(s.filePath), 0o750); mkdirErr != nil {
+		return fmt.Errorf("creating projection history directory: %w", mkdirErr)
+	}
+
+	tmpPath := s.filePath + ".tmp"
+	if writeErr := os.WriteFile(tmpPath, data, 0o600); writeErr != nil {
+		return fmt.Errorf("writing projection history temp file: %w", writeErr)
+	}
+
+	if renameErr := os.Rename(tmpPath, s.filePath); renameErr != nil {
+		_ = os.Remove(tmpPath)
+		return fmt.Errorf("renaming projection history temp file: %w", renameErr)
+	}
+
+	return nil
+}
+
+// RecordSnapshot appends a snapshot for the stack, keeping snapshots ordered by
+// RecordedAt and pruning the oldest beyond the retention limit.
+func (s *ProjectionHistoryStore) RecordSnapshot(stack string, snapshot ProjectionSnapshot) error {
+	if stack == "" {
+		return errors.New("projection stack cannot be empty")
+	}
+	if snapshot.RecordedAt.IsZero() {
+		return errors.New("projection snapshot time cannot be zero")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	snapshots := append(s.stacks[stack], &snapshot)
+	sort.SliceStable(snapshots, func(i, j int) bool {
+		return snapshots[i].RecordedAt.Before(snapshots[j].RecordedAt)
+	})
+	if len(snapshots) > maxProjectionSnapshots {
+		snapshots = snapshots[len(snapshots)-maxProjectionSnapshots:]
+	}
+	s.stacks[stack] = snapshots
+	return nil
+}
+
+// SnapshotAt returns a copy of the most recent snapshot for the stack recorded
+// at or before t, i.e. the projection in effect at that time.
+// Returns nil and false if no such snapshot exists.
+func (s *ProjectionHistoryStore) SnapshotAt(stack string, t time.Time) (*ProjectionSnapshot, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	snapshots := s.stacks[stack]
+	for i := len(snapshots) - 1; i >= 0; i-- {
+		if snapshots[i] != nil && !snapshots[i].RecordedAt.After(t) {
+			snapshotCopy := copyProjectionSnapshot(snapshots[i])
+			return &snapshotCopy, true
+		}
+	}
+	return nil, false
+}
+
+// Stacks returns the names of all stacks with recorded projections, sorted.
+func (s *ProjectionHistoryStore) Stacks() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	stacks := make([]string, 0, len(s.stacks))
+	for stack, snapshots := range s.stacks {
+		if len(snapshots) > 0 {
+			stacks = append(stacks, stack)
+		}
+	}
+	sort.Strings(stacks)
+	return stacks
+}
+
+// SnapshotsBetween returns copies of the stack's snapshots that were in effect
+// during [from, to): the most recent snapshot recorded at or before from,
+// followed by every snapshot recorded after from and before to, oldest first.
+func (s *ProjectionHistoryStore) SnapshotsBetween(stack string, from, to time.Time) []ProjectionSnapshot {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var result []ProjectionSnapshot
+	for _, snapshot := range s.stacks[stack] {
+		if snapshot == nil || !snapshot.RecordedAt.Before(to) {
+			continue
+		}
+		if !snapshot.RecordedAt.After(from) {
+			// A later snapshot at or before from supersedes this one.
+			result = result[:0]
+		}
+		result = append(result, copyProjectionSnapshot(snapshot))
+	}
+	return result
+}
+
+// copyProjectionSnapshot returns a copy of snapshot that does not share its maps.
+func copyProjectionSnapshot(snapshot *ProjectionSnapshot) ProjectionSnapshot {
+	snapshotCopy := *snapshot
+	snapshotCopy.Resources = make(map[string]ProjectedResourceRecord, len(snapshot.Resources))
+	for id, record := range snapshot.Resources {
+		if record.Tags != nil {
+			tags := make(map[string]string, len(record.Tags))
+			for k, v := range record.Tags {
+				tags[k] = v
+			}
+			record.Tags = tags
+		}
+		snapshotCopy.Resources[id] = record
+	}
+	return snapshotCopy
+}
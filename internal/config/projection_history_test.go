@@ -0,0 +1,132 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProjectionHistoryStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", dir)
+	assert.Equal(t, filepath.Join(dir, "projection_history.json"), NewProjectionHistoryStore("").FilePath())
+}
+
+func TestProjectionHistoryStore_LoadSave(t *testing.T) {
+	t.Parallel()
+
+	t.Run("round trip", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "projections.json")
+		recordedAt := time.Date(2026, 1, 5, 9, 0, 0, 0, time.UTC)
+
+		store := NewProjectionHistoryStore(path)
+		require.NoError(t, store.Load())
+		require.NoError(t, store.RecordSnapshot("prod", ProjectionSnapshot{
+			RecordedAt: recordedAt,
+			Resources: map[string]ProjectedResourceRecord{
+				"web": {ResourceType: "aws:ec2/instance:Instance", Provider: "aws", Monthly: 70, Currency: "USD"},
+			},
+		}))
+		require.NoError(t, store.Save())
+
+		reloaded := NewProjectionHistoryStore(path)
+		require.NoError(t, reloaded.Load())
+		snapshot, ok := reloaded.SnapshotAt("prod", recordedAt)
+		require.True(t, ok)
+		assert.True(t, recordedAt.Equal(snapshot.RecordedAt))
+		assert.InDelta(t, 70.0, snapshot.Resources["web"].Monthly, 0.001)
+	})
+
+	t.Run("corrupted file", func(t *testing.T) {
+		t.Parallel()
+		path := filepath.Join(t.TempDir(), "projections.json")
+		require.NoError(t, os.WriteFile(path, []byte("{not json"), 0o600))
+		require.ErrorIs(t, NewProjectionHistoryStore(path).Load(), ErrHistoryCorrupted)
+	})
+}
+
+func TestProjectionHistoryStore_SnapshotAt(t *testing.T) {
+	store := NewProjectionHistoryStore(filepath.Join(t.TempDir(), "projections.json"))
+	jan := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+
+	// Recorded out of order; the store keeps them sorted.
+	require.NoError(t, store.RecordSnapshot("prod", ProjectionSnapshot{
+		RecordedAt: feb, Resources: map[string]ProjectedResourceRecord{"web": {Monthly: 90}},
+	}))
+	require.NoError(t, store.RecordSnapshot("prod", ProjectionSnapshot{
+		RecordedAt: jan, Resources: map[string]ProjectedResourceRecord{"web": {Monthly: 70}},
+	}))
+
+	_, ok := store.SnapshotAt("prod", jan.Add(-time.Hour))
+	assert.False(t, ok, "nothing recorded before the first snapshot")
+
+	snapshot, ok := store.SnapshotAt("prod", time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.InDelta(t, 70.0, snapshot.Resources["web"].Monthly, 0.001)
+
+	snapshot, ok = store.SnapshotAt("prod", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC))
+	require.True(t, ok)
+	assert.InDelta(t, 90.0, snapshot.Resources["web"].Monthly, 0.001)
+
+	snapshot.Resources["web"] = ProjectedResourceRecord{Monthly: 1}
+	again, _ := store.SnapshotAt("prod", feb)
+	assert.InDelta(t, 90.0, again.Resources["web"].Monthly, 0.001, "returned snapshot is a copy")
+
+	_, ok = store.SnapshotAt("staging", feb)
+	assert.False(t, ok)
+
+	require.Error(t, store.RecordSnapshot("", ProjectionSnapshot{RecordedAt: jan}))
+	require.Error(t, store.RecordSnapshot("prod", ProjectionSnapshot{}))
+}
+
+func TestProjectionHistoryStore_Retention(t *testing.T) {
+	store := NewProjectionHistoryStore(filepath.Join(t.TempDir(), "projections.json"))
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := range maxProjectionSnapshots + 5 {
+		snapshot := ProjectionSnapshot{RecordedAt: start.Add(time.Duration(i) * time.Hour)}
+		require.NoError(t, store.RecordSnapshot("prod", snapshot))
+	}
+
+	assert.Len(t, store.stacks["prod"], maxProjectionSnapshots)
+	_, ok := store.SnapshotAt("prod", start.Add(4*time.Hour))
+	assert.False(t, ok, "oldest snapshots are pruned")
+}
+
+func TestProjectionHistoryStore_SnapshotsBetween(t *testing.T) {
+	store := NewProjectionHistoryStore(filepath.Join(t.TempDir(), "projections.json"))
+	dec := time.Date(2025, 12, 20, 0, 0, 0, 0, time.UTC)
+	jan := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	jan2 := time.Date(2026, 1, 20, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2026, 2, 10, 0, 0, 0, 0, time.UTC)
+	for i, at := range []time.Time{dec, jan, jan2, feb} {
+		require.NoError(t, store.RecordSnapshot("prod", ProjectionSnapshot{
+			RecordedAt: at,
+			Resources: map[string]ProjectedResourceRecord{
+				"web": {Monthly: float64(i), Tags: map[string]string{"team": "web"}},
+			},
+		}))
+	}
+	require.NoError(t, store.RecordSnapshot("dev", ProjectionSnapshot{RecordedAt: feb}))
+
+	assert.Equal(t, []string{"dev", "prod"}, store.Stacks())
+
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := store.SnapshotsBetween("prod", from, to)
+	require.Len(t, snapshots, 3, "snapshot in effect at from plus those recorded in the window")
+	assert.True(t, dec.Equal(snapshots[0].RecordedAt))
+	assert.True(t, jan2.Equal(snapshots[2].RecordedAt))
+
+	snapshots[0].Resources["web"].Tags["team"] = "changed"
+	again := store.SnapshotsBetween("prod", from, to)
+	assert.Equal(t, "web", again[0].Resources["web"].Tags["team"], "returned snapshots are copies")
+
+	assert.Empty(t, store.SnapshotsBetween("dev", from, to), "nothing in effect before the first snapshot")
+	assert.Len(t, store.SnapshotsBetween("prod", jan, to), 2, "snapshot recorded exactly at from is in effect")
+}
@@ -0,0 +1,117 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// errUnknownPulumiKey is returned for unsupported pulumi.* keys.
+var errUnknownPulumiKey = errors.New("unknown pulumi setting (supported: pulumi.access_token, pulumi.api_url)")
+
+// redactedValue replaces secrets in config get/list output.
+const redactedValue = "********"
+
+// PulumiConfig holds settings for fetching stacks from the Pulumi Cloud API.
+type PulumiConfig struct {
+	// AccessToken authenticates Pulumi Cloud requests. PULUMI_ACCESS_TOKEN
+	// takes precedence when set.
+	AccessToken string `yaml:"access_token,omitempty" json:"access_token,omitempty"`
+
+	// APIURL overrides the Pulumi Cloud API endpoint (default https://api.pulumi.com).
+	APIURL string `yaml:"api_url,omitempty" json:"api_url,omitempty"`
+}
+
+// Validate checks that a configured API URL is an absolute http(s) URL.
+func (p *PulumiConfig) Validate() error {
+	if p == nil || p.APIURL == "" {
+		return nil
+	}
+	return validatePulumiAPIURL(p.APIURL)
+}
+
+// redacted returns a copy of p with the access token masked.
+func (p *PulumiConfig) redacted() *PulumiConfig {
+	if p == nil {
+		return nil
+	}
+	out := *p
+	if out.AccessToken != "" {
+		out.AccessToken = redactedValue
+	}
+	return &out
+}
+
+// PulumiAccessToken returns the configured Pulumi Cloud access token, or "" if none.
+func (c *Config) PulumiAccessToken() string {
+	if c.Pulumi == nil {
+		return ""
+	}
+	return c.Pulumi.AccessToken
+}
+
+// PulumiAPIURL returns the configured Pulumi Cloud API URL, or "" if none.
+func (c *Config) PulumiAPIURL() string {
+	if c.Pulumi == nil {
+		return ""
+	}
+	return c.Pulumi.APIURL
+}
+
+// setPulumiValue sets a pulumi.* configuration value.
+func (c *Config) setPulumiValue(parts []string, value string) error {
+	if len(parts) != 1 {
+		return errUnknownPulumiKey
+	}
+	switch parts[0] {
+	case "access_token":
+		if c.Pulumi == nil {
+			c.Pulumi = &PulumiConfig{}
+		}
+		c.Pulumi.AccessToken = value
+	case "api_url":
+		if value != "" {
+			if err := validatePulumiAPIURL(value); err != nil {
+				return err
+			}
+		}
+		if c.Pulumi == nil {
+			c.Pulumi = &PulumiConfig{}
+		}
+		c.Pulumi.APIURL = value
+	default:
+		return errUnknownPulumiKey
+	}
+	return nil
+}
+
+// getPulumiValue gets a pulumi.* configuration value. The access token is
+// never returned in clear text.
+func (c *Config) getPulumiValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Pulumi.redacted(), nil
+	}
+	if len(parts) != 1 {
+		return nil, errUnknownPulumiKey
+	}
+	switch parts[0] {
+	case "access_token":
+		if c.PulumiAccessToken() == "" {
+			return "", nil
+		}
+		return redactedValue, nil
+	case "api_url":
+		return c.PulumiAPIURL(), nil
+	default:
+		return nil, errUnknownPulumiKey
+	}
+}
+
+// validatePulumiAPIURL checks that raw is an absolute http or https URL.
+func validatePulumiAPIURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid pulumi api_url %q: must be an http(s) URL", raw)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// errUnknownRecommendationsKey is returned for unsupported recommendations.* keys.
+var errUnknownRecommendationsKey = errors.New(
+	"unknown recommendations setting (supported: recommendations.min_savings, recommendations.dismissals.remote)")
+
+// RecommendationsConfig holds settings for the recommendations workflow.
+type RecommendationsConfig struct {
+	// MinSavings hides recommendations whose estimated monthly savings are
+	// below this amount. Zero shows all recommendations.
+	MinSavings float64 `yaml:"min_savings,omitempty" json:"min_savings,omitempty"`
+
+	// Dismissals configures how dismissal state is shared across a team.
+	Dismissals DismissalsConfig `yaml:"dismissals,omitempty" json:"dismissals,omitempty"`
+}
+
+// DismissalsConfig configures the dismissal store.
+type DismissalsConfig struct {
+	// Remote is the shared location the local dismissal store syncs with:
+	// an s3://bucket/key URL, a gs://bucket/key URL, or the path to a local
+	// git working copy (or shared directory) holding dismissed.json.
+	Remote string `yaml:"remote,omitempty" json:"remote,omitempty"`
+}
+
+// Validate checks the minimum savings threshold and that the configured
+// dismissal remote can be parsed.
+func (r *RecommendationsConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+	if r.MinSavings < 0 {
+		return fmt.Errorf("min_savings must be zero or greater, got %g", r.MinSavings)
+	}
+	if r.Dismissals.Remote == "" {
+		return nil
+	}
+	if _, err := ParseDismissalRemote(r.Dismissals.Remote); err != nil {
+		return fmt.Errorf("dismissals.remote: %w", err)
+	}
+	return nil
+}
+
+// DismissalRemoteURL returns the configured dismissal remote, or "" if none.
+func (c *Config) DismissalRemoteURL() string {
+	if c.Recommendations == nil {
+		return ""
+	}
+	return c.Recommendations.Dismissals.Remote
+}
+
+// MinRecommendationSavings returns the configured minimum savings threshold, or 0 if none.
+func (c *Config) MinRecommendationSavings() float64 {
+	if c.Recommendations == nil {
+		return 0
+	}
+	return c.Recommendations.MinSavings
+}
+
+// setRecommendationsValue sets a recommendations.* configuration value.
+func (c *Config) setRecommendationsValue(parts []string, value string) error {
+	if len(parts) == 1 && parts[0] == "min_savings" {
+		minSavings, err := strconv.ParseFloat(value, 64)
+		if err != nil || minSavings < 0 {
+			return fmt.Errorf("invalid min_savings %q: must be a non-negative number", value)
+		}
+		if c.Recommendations == nil {
+			c.Recommendations = &RecommendationsConfig{}
+		}
+		c.Recommendations.MinSavings = minSavings
+		return nil
+	}
+	if len(parts) != 2 || parts[0] != "dismissals" || parts[1] != "remote" {
+		return errUnknownRecommendationsKey
+	}
+	if value != "" {
+		if _, err := ParseDismissalRemote(value); err != nil {
+			return err
+		}
+	}
+	if c.Recommendations == nil {
+		c.Recommendations = &RecommendationsConfig{}
+	}
+	c.Recommendations.Dismissals.Remote = value
+	return nil
+}
+
+// getRecommendationsValue gets a recommendations.* configuration value.
+func (c *Config) getRecommendationsValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Recommendations, nil
+	}
+	if len(parts) == 1 && parts[0] == "min_savings" {
+		return c.MinRecommendationSavings(), nil
+	}
+	if len(parts) != 2 || parts[0] != "dismissals" || parts[1] != "remote" {
+		return nil, errUnknownRecommendationsKey
+	}
+	return c.DismissalRemoteURL(), nil
+}
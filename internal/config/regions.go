@@ -0,0 +1,47 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrEmptyRegion is returned when a candidate region or latency annotation
+// names no region.
+var ErrEmptyRegion = errors.New("region cannot be empty")
+
+// RegionsConfig configures the regions that 'cost optimize-region' prices
+// resources in.
+type RegionsConfig struct {
+	// Candidates are the regions to price resources in when --regions is not
+	// given, e.g. "us-east-1" or "eu-north-1".
+	Candidates []string `yaml:"candidates,omitempty" json:"candidates,omitempty"`
+
+	// Latency annotates regions with the latency constraints of relocating
+	// workloads there, e.g. "+90 ms to US users", keyed by region.
+	Latency map[string]string `yaml:"latency,omitempty" json:"latency,omitempty"`
+}
+
+// Validate checks that every candidate and annotation names a region and
+// that no candidate is listed twice.
+func (r *RegionsConfig) Validate() error {
+	if r == nil {
+		return nil
+	}
+	seen := make(map[string]bool, len(r.Candidates))
+	for i, region := range r.Candidates {
+		if strings.TrimSpace(region) == "" {
+			return fmt.Errorf("candidates[%d]: %w", i, ErrEmptyRegion)
+		}
+		if seen[region] {
+			return fmt.Errorf("candidates[%d]: duplicate region %q", i, region)
+		}
+		seen[region] = true
+	}
+	for region := range r.Latency {
+		if strings.TrimSpace(region) == "" {
+			return fmt.Errorf("latency: %w", ErrEmptyRegion)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestRegionsConfig_YAML(t *testing.T) {
+	var cost CostConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+regions:
+  candidates: [us-east-1, eu-north-1]
+  latency:
+    eu-north-1: "+90 ms to US users"
+`), &cost))
+
+	require.NotNil(t, cost.Regions)
+	assert.Equal(t, []string{"us-east-1", "eu-north-1"}, cost.Regions.Candidates)
+	assert.Equal(t, "+90 ms to US users", cost.Regions.Latency["eu-north-1"])
+	require.NoError(t, cost.Validate())
+}
+
+func TestRegionsConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		regions *RegionsConfig
+		wantErr string
+	}{
+		{name: "nil"},
+		{name: "valid", regions: &RegionsConfig{Candidates: []string{"us-west-2"}}},
+		{
+			name:    "empty candidate",
+			regions: &RegionsConfig{Candidates: []string{"us-west-2", " "}},
+			wantErr: "candidates[1]: region cannot be empty",
+		},
+		{
+			name:    "duplicate candidate",
+			regions: &RegionsConfig{Candidates: []string{"us-west-2", "us-west-2"}},
+			wantErr: `duplicate region "us-west-2"`,
+		},
+		{
+			name:    "empty latency region",
+			regions: &RegionsConfig{Latency: map[string]string{"": "+10 ms"}},
+			wantErr: "latency: region cannot be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.regions.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
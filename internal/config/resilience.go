@@ -0,0 +1,95 @@
+package config
+
+import "fmt"
+
+// ResilienceConfig tunes how plugin RPCs are timed out, retried, and cut off
+// by the circuit breaker. Zero durations and nil counts mean "use the default".
+//
+// YAML Location: ~/.finfocus/config.yaml under "plugin_host"
+//
+// Example:
+//
+//	plugin_host:
+//	  resilience:
+//	    timeout: 30s
+//	    max_retries: 2
+//	  plugins:
+//	    aws-ce:
+//	      timeout: 2m
+//	      failure_threshold: 3
+type ResilienceConfig struct {
+	// Timeout bounds a single RPC attempt.
+	Timeout Duration `yaml:"timeout,omitempty" json:"timeout,omitempty"`
+
+	// MaxRetries is the number of extra attempts made after a transient
+	// failure. Zero disables retries.
+	MaxRetries *int `yaml:"max_retries,omitempty" json:"max_retries,omitempty"`
+
+	// InitialBackoff is the delay before the first retry; it doubles on each
+	// subsequent retry up to MaxBackoff.
+	InitialBackoff Duration `yaml:"initial_backoff,omitempty" json:"initial_backoff,omitempty"`
+	MaxBackoff     Duration `yaml:"max_backoff,omitempty"     json:"max_backoff,omitempty"`
+
+	// FailureThreshold is the number of consecutive failed calls after which
+	// the plugin is skipped for the rest of the run. Zero disables the breaker.
+	FailureThreshold *int `yaml:"failure_threshold,omitempty" json:"failure_threshold,omitempty"`
+}
+
+// Merge returns r with every field that is set in override replaced.
+func (r ResilienceConfig) Merge(override ResilienceConfig) ResilienceConfig {
+	if override.Timeout != 0 {
+		r.Timeout = override.Timeout
+	}
+	if override.MaxRetries != nil {
+		r.MaxRetries = override.MaxRetries
+	}
+	if override.InitialBackoff != 0 {
+		r.InitialBackoff = override.InitialBackoff
+	}
+	if override.MaxBackoff != 0 {
+		r.MaxBackoff = override.MaxBackoff
+	}
+	if override.FailureThreshold != nil {
+		r.FailureThreshold = override.FailureThreshold
+	}
+	return r
+}
+
+// Validate rejects negative values and a maximum backoff below the initial one.
+func (r ResilienceConfig) Validate() error {
+	switch {
+	case r.Timeout < 0:
+		return fmt.Errorf("timeout must not be negative, got %s", r.Timeout.Duration())
+	case r.MaxRetries != nil && *r.MaxRetries < 0:
+		return fmt.Errorf("max_retries must not be negative, got %d", *r.MaxRetries)
+	case r.InitialBackoff < 0:
+		return fmt.Errorf("initial_backoff must not be negative, got %s", r.InitialBackoff.Duration())
+	case r.MaxBackoff < 0:
+		return fmt.Errorf("max_backoff must not be negative, got %s", r.MaxBackoff.Duration())
+	case r.MaxBackoff != 0 && r.MaxBackoff < r.InitialBackoff:
+		return fmt.Errorf("max_backoff (%s) must not be less than initial_backoff (%s)",
+			r.MaxBackoff.Duration(), r.InitialBackoff.Duration())
+	case r.FailureThreshold != nil && *r.FailureThreshold < 0:
+		return fmt.Errorf("failure_threshold must not be negative, got %d", *r.FailureThreshold)
+	}
+	return nil
+}
+
+// ResilienceFor returns the resilience settings for a plugin: the global
+// settings with that plugin's overrides applied.
+func (h PluginHostConfig) ResilienceFor(pluginName string) ResilienceConfig {
+	return h.Resilience.Merge(h.Plugins[pluginName])
+}
+
+// Validate checks the global and per-plugin resilience settings.
+func (h PluginHostConfig) Validate() error {
+	if err := h.Resilience.Validate(); err != nil {
+		return fmt.Errorf("resilience: %w", err)
+	}
+	for name, r := range h.Plugins {
+		if err := r.Validate(); err != nil {
+			return fmt.Errorf("plugins.%s: %w", name, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,282 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/rshade/finfocus/internal/secrets"
+)
+
+// errNotSecretSetting is returned when a secret reference is set on a
+// setting that does not accept one.
+var errNotSecretSetting = errors.New("secret references are accepted only by pulumi.access_token, " +
+	"notify.slack.webhook_url, notify.slack.bot_token, currency.app_id, plugins.<name>.<key>, " +
+	"and analyzer.plugins.<name>.env.<VAR>")
+
+// secretCache holds the secrets resolved for every configuration loaded by
+// the process, so each secret is read once.
+//
+//nolint:gochecknoglobals // Process-wide cache of resolved secrets.
+var secretCache = secrets.NewCache()
+
+// warnedSecrets holds the secret resolution errors already printed, since
+// a command may load the configuration several times.
+//
+//nolint:gochecknoglobals // Process-wide record of printed warnings.
+var warnedSecrets sync.Map
+
+// secretRef is a setting whose value in the config file is a secret
+// reference.
+type secretRef struct {
+	// ref is the reference in the config file, such as env://SLACK_TOKEN.
+	ref string
+	// resolved is the secret it resolved to, or "" when it did not resolve.
+	resolved string
+}
+
+// fixedSecretKeys are the settings that hold secrets, besides plugin
+// settings and analyzer plugin environment variables.
+//
+//nolint:gochecknoglobals // Static list of secret settings.
+var fixedSecretKeys = []string{
+	"pulumi.access_token", "notify.slack.webhook_url", "notify.slack.bot_token", "currency.app_id",
+}
+
+// secretKeys returns the dotted keys of the settings that may hold secret
+// references: the fixed secret settings, the string settings of plugins,
+// and the environment variables of analyzer plugins.
+func (c *Config) secretKeys() []string {
+	keys := append([]string(nil), fixedSecretKeys...)
+	for _, name := range sortedKeys(c.Plugins) {
+		for _, key := range sortedKeys(c.Plugins[name].Config) {
+			if _, ok := c.Plugins[name].Config[key].(string); ok {
+				keys = append(keys, "plugins."+name+"."+key)
+			}
+		}
+	}
+	for _, name := range sortedKeys(c.Analyzer.Plugins) {
+		for _, key := range sortedKeys(c.Analyzer.Plugins[name].Env) {
+			keys = append(keys, "analyzer.plugins."+name+".env."+key)
+		}
+	}
+	return keys
+}
+
+// secretValue returns the value of the secret setting key, and whether key
+// is a setting that accepts secret references.
+func (c *Config) secretValue(key string) (string, bool) {
+	switch key {
+	case "pulumi.access_token":
+		return c.PulumiAccessToken(), true
+	case "notify.slack.webhook_url":
+		return c.SlackSettings().WebhookURL, true
+	case "notify.slack.bot_token":
+		return c.SlackSettings().BotToken, true
+	case "currency.app_id":
+		if c.Currency == nil {
+			return "", true
+		}
+		return c.Currency.AppID, true
+	}
+	if rest, ok := strings.CutPrefix(key, "analyzer.plugins."); ok {
+		name, variable, found := strings.Cut(rest, ".env.")
+		if !found || name == "" || variable == "" {
+			return "", false
+		}
+		return c.Analyzer.Plugins[name].Env[variable], true
+	}
+	if rest, ok := strings.CutPrefix(key, "plugins."); ok {
+		name, setting, found := strings.Cut(rest, ".")
+		if !found || name == "" || setting == "" {
+			return "", false
+		}
+		value, _ := c.Plugins[name].Config[setting].(string)
+		return value, true
+	}
+	return "", false
+}
+
+// setSecretValue sets the secret setting key, which must exist unless it is
+// one of the fixed secret settings.
+func (c *Config) setSecretValue(key, value string) {
+	switch key {
+	case "pulumi.access_token":
+		if c.Pulumi == nil {
+			c.Pulumi = &PulumiConfig{}
+		}
+		c.Pulumi.AccessToken = value
+		return
+	case "notify.slack.webhook_url", "notify.slack.bot_token":
+		settings := c.SlackSettings()
+		if key == "notify.slack.webhook_url" {
+			settings.WebhookURL = value
+		} else {
+			settings.BotToken = value
+		}
+		if c.Notify == nil {
+			c.Notify = &NotifyConfig{}
+		}
+		c.Notify.Slack = &settings
+		return
+	case "currency.app_id":
+		if c.Currency == nil {
+			c.Currency = &CurrencyConfig{}
+		}
+		c.Currency.AppID = value
+		return
+	}
+	if rest, ok := strings.CutPrefix(key, "analyzer.plugins."); ok {
+		name, variable, _ := strings.Cut(rest, ".env.")
+		if plugin, exists := c.Analyzer.Plugins[name]; exists && plugin.Env != nil {
+			plugin.Env[variable] = value
+		}
+		return
+	}
+	name, setting, _ := strings.Cut(strings.TrimPrefix(key, "plugins."), ".")
+	if plugin, exists := c.Plugins[name]; exists && plugin.Config != nil {
+		plugin.Config[setting] = value
+	}
+}
+
+// resolveSecrets replaces the secret references of the configuration with
+// the secrets they refer to, remembering the references so Save writes
+// them back. A reference that does not resolve leaves its setting empty,
+// so the reference itself is never used as a credential, and is returned
+// in the error with the others.
+func (c *Config) resolveSecrets() error {
+	var errs []error
+	for _, key := range c.secretKeys() {
+		value, _ := c.secretValue(key)
+		if !secrets.IsReference(value) {
+			continue
+		}
+		resolved, err := c.secretResolver().Resolve(context.Background(), value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", key, err))
+		}
+		c.setSecretValue(key, resolved)
+		if c.secrets == nil {
+			c.secrets = map[string]secretRef{}
+		}
+		c.secrets[key] = secretRef{ref: value, resolved: resolved}
+	}
+	return errors.Join(errs...)
+}
+
+// secretResolver returns a resolver reading relative file:// paths from the
+// directory of the config file.
+func (c *Config) secretResolver() *secrets.Resolver {
+	return secrets.NewResolver(filepath.Dir(c.configPath)).WithCache(secretCache)
+}
+
+// warnSecretsOnce prints a warning for references that did not resolve,
+// once per process for the same error.
+func warnSecretsOnce(err error) {
+	if _, warned := warnedSecrets.LoadOrStore(err.Error(), true); !warned {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+}
+
+// setSecretReference sets the secret setting key to the secret ref refers
+// to, remembering ref so Save writes it instead of the secret.
+func (c *Config) setSecretReference(key, ref string) error {
+	if _, ok := c.secretValue(key); !ok {
+		return fmt.Errorf("%w, not %s", errNotSecretSetting, key)
+	}
+	resolved, err := c.secretResolver().Resolve(context.Background(), ref)
+	if err != nil {
+		return err
+	}
+	if err = c.setValue(key, resolved); err != nil {
+		return err
+	}
+	if c.secrets == nil {
+		c.secrets = map[string]secretRef{}
+	}
+	c.secrets[key] = secretRef{ref: ref, resolved: resolved}
+	return nil
+}
+
+// SecretReference returns the secret reference the setting key was loaded
+// or set from, or "" when its value is not a reference.
+func (c *Config) SecretReference(key string) string {
+	ref, ok := c.secrets[key]
+	if !ok {
+		return ""
+	}
+	if value, _ := c.secretValue(key); value != ref.resolved {
+		// Changed since it was resolved.
+		return ""
+	}
+	return ref.ref
+}
+
+// withSecretReferences puts the secret references back in place of the
+// secrets they resolved to and returns a function restoring the secrets,
+// for writing and showing the configuration. Settings changed since they
+// were resolved keep their new value.
+func (c *Config) withSecretReferences() func() {
+	var restore []func()
+	for _, key := range sortedKeys(c.secrets) {
+		if ref := c.SecretReference(key); ref != "" {
+			resolved := c.secrets[key].resolved
+			c.setSecretValue(key, ref)
+			restore = append(restore, func() { c.setSecretValue(key, resolved) })
+		}
+	}
+	return func() {
+		for _, fn := range restore {
+			fn()
+		}
+	}
+}
+
+// listedPlugins returns a copy of the plugin settings for config get and
+// list, showing settings loaded from secret references as the references.
+func (c *Config) listedPlugins() map[string]PluginConfig {
+	if c.Plugins == nil {
+		return nil
+	}
+	plugins := make(map[string]PluginConfig, len(c.Plugins))
+	for name, plugin := range c.Plugins {
+		settings := make(map[string]interface{}, len(plugin.Config))
+		for key, value := range plugin.Config {
+			if ref := c.SecretReference("plugins." + name + "." + key); ref != "" {
+				value = ref
+			}
+			settings[key] = value
+		}
+		plugins[name] = PluginConfig{Config: settings}
+	}
+	return plugins
+}
+
+// listedAnalyzer returns a copy of the analyzer settings for config list,
+// showing plugin environment variables loaded from secret references as the
+// references.
+func (c *Config) listedAnalyzer() AnalyzerConfig {
+	analyzer := c.Analyzer
+	if c.Analyzer.Plugins == nil {
+		return analyzer
+	}
+	analyzer.Plugins = make(map[string]AnalyzerPlugin, len(c.Analyzer.Plugins))
+	for name, plugin := range c.Analyzer.Plugins {
+		if plugin.Env != nil {
+			env := make(map[string]string, len(plugin.Env))
+			for variable, value := range plugin.Env {
+				if ref := c.SecretReference("analyzer.plugins." + name + ".env." + variable); ref != "" {
+					value = ref
+				}
+				env[variable] = value
+			}
+			plugin.Env = env
+		}
+		analyzer.Plugins[name] = plugin
+	}
+	return analyzer
+}
@@ -0,0 +1,130 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const secretsConfig = `plugins:
+  kubecost:
+    endpoint: http://localhost:9090
+    api_key: file://kubecost.key
+pulumi:
+  access_token: env://FINFOCUS_TEST_PULUMI_TOKEN
+notify:
+  slack:
+    bot_token: env://FINFOCUS_TEST_SLACK_TOKEN
+    channel: "#finops"
+`
+
+// writeSecretsConfig writes secretsConfig and the kubecost key file to a new
+// finfocus home and returns the config file path.
+func writeSecretsConfig(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	t.Setenv(EnvProfile, "")
+	t.Setenv("FINFOCUS_TEST_PULUMI_TOKEN", "pul-123")
+	t.Setenv("FINFOCUS_TEST_SLACK_TOKEN", "xoxb-456")
+	require.NoError(t, os.WriteFile(filepath.Join(home, "kubecost.key"), []byte("kc-789\n"), 0600))
+	path := filepath.Join(home, "config.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(secretsConfig), 0600))
+	return path
+}
+
+func TestSecretReferencesResolved(t *testing.T) {
+	path := writeSecretsConfig(t)
+
+	cfg := New()
+	assert.Equal(t, "pul-123", cfg.PulumiAccessToken())
+	assert.Equal(t, "xoxb-456", cfg.SlackSettings().BotToken)
+	assert.Equal(t, "kc-789", cfg.Plugins["kubecost"].Config["api_key"])
+	assert.Equal(t, "env://FINFOCUS_TEST_SLACK_TOKEN", cfg.SecretReference("notify.slack.bot_token"))
+
+	// config get and list show references rather than the secrets.
+	value, err := cfg.Get("plugins.kubecost.api_key")
+	require.NoError(t, err)
+	assert.Equal(t, "file://kubecost.key", value)
+	listed := cfg.List()["plugins"].(map[string]PluginConfig)
+	assert.Equal(t, "file://kubecost.key", listed["kubecost"].Config["api_key"])
+	assert.Equal(t, "kc-789", cfg.Plugins["kubecost"].Config["api_key"], "listing leaves the secret in place")
+
+	// Saving writes the references back, and the secrets stay resolved.
+	require.NoError(t, cfg.Set("notify.slack.channel", "#costs"))
+	require.NoError(t, cfg.Save())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "access_token: env://FINFOCUS_TEST_PULUMI_TOKEN")
+	assert.Contains(t, string(data), "bot_token: env://FINFOCUS_TEST_SLACK_TOKEN")
+	assert.Contains(t, string(data), "api_key: file://kubecost.key")
+	assert.NotContains(t, string(data), "xoxb-456")
+	assert.Equal(t, "xoxb-456", cfg.SlackSettings().BotToken)
+
+	// A secret replaced by a plain value is saved as the value.
+	require.NoError(t, cfg.Set("pulumi.access_token", "plain"))
+	assert.Empty(t, cfg.SecretReference("pulumi.access_token"))
+	require.NoError(t, cfg.Save())
+	data, err = os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "access_token: plain")
+}
+
+func TestSecretReferencesUnresolved(t *testing.T) {
+	writeSecretsConfig(t)
+	t.Setenv("FINFOCUS_TEST_SLACK_TOKEN", "")
+	require.NoError(t, os.Unsetenv("FINFOCUS_TEST_SLACK_TOKEN"))
+
+	cfg := New()
+	assert.Empty(t, cfg.SlackSettings().BotToken, "the reference is never used as the secret")
+	assert.Equal(t, "pul-123", cfg.PulumiAccessToken())
+
+	_, err := NewStrict()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "notify.slack.bot_token")
+	assert.Contains(t, err.Error(), "FINFOCUS_TEST_SLACK_TOKEN is not set")
+}
+
+func TestSetSecretReference(t *testing.T) {
+	path := writeSecretsConfig(t)
+	t.Setenv("FINFOCUS_TEST_WEBHOOK", "https://hooks.slack.com/services/T/B/X")
+
+	cfg := New()
+	require.NoError(t, cfg.Set("notify.slack.webhook_url", "env://FINFOCUS_TEST_WEBHOOK"))
+	assert.Equal(t, "https://hooks.slack.com/services/T/B/X", cfg.SlackSettings().WebhookURL)
+	require.NoError(t, cfg.Save())
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Contains(t, string(data), "webhook_url: env://FINFOCUS_TEST_WEBHOOK")
+
+	require.ErrorContains(t, cfg.Set("notify.slack.bot_token", "env://FINFOCUS_TEST_UNSET"),
+		"FINFOCUS_TEST_UNSET is not set")
+	require.ErrorIs(t, cfg.Set("output.default_format", "env://FINFOCUS_TEST_WEBHOOK"), errNotSecretSetting)
+}
+
+func TestLintFileSecrets(t *testing.T) {
+	t.Setenv("FINFOCUS_TEST_SLACK_TOKEN", "xoxb-456")
+	issues := lintConfig(t, `pulumi:
+  access_token: pul-plaintext
+notify:
+  slack:
+    bot_token: env://FINFOCUS_TEST_SLACK_TOKEN
+plugins:
+  kubecost:
+    api_key: file://missing.key
+`, LintOptions{})
+	require.Len(t, issues, 2)
+
+	plain := findIssue(t, issues, "pulumi.access_token")
+	assert.Equal(t, LintWarning, plain.Severity)
+	assert.Equal(t, 2, plain.Line)
+	assert.Contains(t, plain.Message, "secret stored in plain text")
+
+	missing := findIssue(t, issues, "plugins.kubecost.api_key")
+	assert.Equal(t, LintError, missing.Severity)
+	assert.Equal(t, 8, missing.Line)
+	assert.Contains(t, missing.Message, `cannot resolve secret reference "file://missing.key"`)
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// errUnknownTracingKey is returned for unsupported tracing.* keys.
+var errUnknownTracingKey = errors.New("unknown tracing setting (supported: tracing.endpoint)")
+
+// TracingConfig configures OpenTelemetry trace export.
+type TracingConfig struct {
+	// Endpoint is the OTLP/HTTP collector URL traces are exported to, e.g.
+	// http://localhost:4318. The --otel-endpoint flag and the standard
+	// OTEL_EXPORTER_OTLP_ENDPOINT variables take precedence when set.
+	Endpoint string `yaml:"endpoint,omitempty" json:"endpoint,omitempty"`
+}
+
+// Validate checks that a configured endpoint is an absolute http(s) URL.
+func (t *TracingConfig) Validate() error {
+	if t == nil || t.Endpoint == "" {
+		return nil
+	}
+	return ValidateTracingEndpoint(t.Endpoint)
+}
+
+// TracingEndpoint returns the configured OTLP endpoint, or "" if none.
+func (c *Config) TracingEndpoint() string {
+	if c.Tracing == nil {
+		return ""
+	}
+	return c.Tracing.Endpoint
+}
+
+// setTracingValue sets a tracing.* configuration value.
+func (c *Config) setTracingValue(parts []string, value string) error {
+	if len(parts) != 1 || parts[0] != "endpoint" {
+		return errUnknownTracingKey
+	}
+	if value != "" {
+		if err := ValidateTracingEndpoint(value); err != nil {
+			return err
+		}
+	}
+	if c.Tracing == nil {
+		c.Tracing = &TracingConfig{}
+	}
+	c.Tracing.Endpoint = value
+	return nil
+}
+
+// getTracingValue gets a tracing.* configuration value.
+func (c *Config) getTracingValue(parts []string) (interface{}, error) {
+	if len(parts) == 0 {
+		return c.Tracing, nil
+	}
+	if len(parts) != 1 || parts[0] != "endpoint" {
+		return nil, errUnknownTracingKey
+	}
+	return c.TracingEndpoint(), nil
+}
+
+// ValidateTracingEndpoint checks that raw is an absolute http or https URL.
+func ValidateTracingEndpoint(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return fmt.Errorf("invalid tracing endpoint %q: must be an http(s) URL", raw)
+	}
+	return nil
+}
@@ -0,0 +1,180 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// Themes of the interactive views.
+const (
+	// TUIThemeAuto picks the dark or light theme from the terminal background.
+	TUIThemeAuto         = "auto"
+	TUIThemeDark         = "dark"
+	TUIThemeLight        = "light"
+	TUIThemeHighContrast = "high-contrast"
+)
+
+// maxANSIColor is the highest ANSI 256-color palette index.
+const maxANSIColor = 255
+
+// hexColorPattern matches "#rgb" and "#rrggbb" colors.
+var hexColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+
+var errUnknownTUIKey = errors.New("unknown tui setting (supported: tui.theme, tui.colors.<role>)")
+
+// TUIThemes returns the themes accepted by tui.theme.
+func TUIThemes() []string {
+	return []string{TUIThemeAuto, TUIThemeDark, TUIThemeLight, TUIThemeHighContrast}
+}
+
+// TUIColorRoles returns the palette roles that tui.colors can override.
+func TUIColorRoles() []string {
+	return []string{
+		"ok", "warning", "critical", "info",
+		"header", "label", "value", "border", "highlight", "muted",
+		"selected_bg", "spinner", "subtle", "accent", "priority_medium",
+	}
+}
+
+// TUIConfig holds preferences of the interactive views. The theme is set
+// with 'config set'; layouts are written by the views themselves.
+type TUIConfig struct {
+	// Theme is the color theme: auto (the default), dark, light, or
+	// high-contrast. NO_COLOR disables colors whatever the theme.
+	Theme string `yaml:"theme,omitempty" json:"theme,omitempty"`
+
+	// Colors override palette roles of the theme, keyed by role (e.g.
+	// "header"), with "#rrggbb" or "#rgb" hex colors or ANSI 256-color
+	// indexes.
+	Colors map[string]string `yaml:"colors,omitempty" json:"colors,omitempty"`
+
+	// Layouts are the column layouts of list views, keyed by view name
+	// (e.g. "recommendations").
+	Layouts map[string]ListLayout `yaml:"layouts,omitempty" json:"layouts,omitempty"`
+}
+
+// ListLayout is the saved column layout of a list view.
+type ListLayout struct {
+	// Columns are the keys of the visible columns in display order. Empty
+	// shows the view's default columns.
+	Columns []string `yaml:"columns,omitempty" json:"columns,omitempty"`
+
+	// SortBy is the key of the column the list is sorted by.
+	SortBy string `yaml:"sort_by,omitempty" json:"sort_by,omitempty"`
+}
+
+// Validate checks the theme name and the palette overrides.
+func (t *TUIConfig) Validate() error {
+	if t == nil {
+		return nil
+	}
+	if t.Theme != "" && !slices.Contains(TUIThemes(), t.Theme) {
+		return fmt.Errorf("invalid tui theme %q (supported: %s)", t.Theme, strings.Join(TUIThemes(), ", "))
+	}
+	for role, color := range t.Colors {
+		if !slices.Contains(TUIColorRoles(), role) {
+			return fmt.Errorf("unknown tui color role %q (supported: %s)", role, strings.Join(TUIColorRoles(), ", "))
+		}
+		if err := validateTUIColor(color); err != nil {
+			return fmt.Errorf("tui color %s: %w", role, err)
+		}
+	}
+	return nil
+}
+
+// validateTUIColor accepts hex colors and ANSI 256-color indexes.
+func validateTUIColor(color string) error {
+	if hexColorPattern.MatchString(color) {
+		return nil
+	}
+	if n, err := strconv.Atoi(color); err == nil && n >= 0 && n <= maxANSIColor {
+		return nil
+	}
+	return fmt.Errorf("invalid color %q (use #rrggbb, #rgb, or an ANSI color 0-%d)", color, maxANSIColor)
+}
+
+// ThemeName returns the configured theme, or TUIThemeAuto when unset.
+func (t *TUIConfig) ThemeName() string {
+	if t == nil || t.Theme == "" {
+		return TUIThemeAuto
+	}
+	return t.Theme
+}
+
+// ThemeColors returns the palette overrides, or nil when none are set.
+func (t *TUIConfig) ThemeColors() map[string]string {
+	if t == nil {
+		return nil
+	}
+	return t.Colors
+}
+
+// ListLayout returns the saved layout of the list view, or a zero layout if
+// none is saved.
+func (c *Config) ListLayout(view string) ListLayout {
+	if c.TUI == nil {
+		return ListLayout{}
+	}
+	return c.TUI.Layouts[view]
+}
+
+// SetListLayout records the layout of the list view. Call Save to persist it.
+func (c *Config) SetListLayout(view string, layout ListLayout) {
+	if c.TUI == nil {
+		c.TUI = &TUIConfig{}
+	}
+	if c.TUI.Layouts == nil {
+		c.TUI.Layouts = make(map[string]ListLayout)
+	}
+	c.TUI.Layouts[view] = layout
+}
+
+// setTUIValue sets tui.theme or tui.colors.<role>.
+func (c *Config) setTUIValue(parts []string, value string) error {
+	updated := TUIConfig{}
+	if c.TUI != nil {
+		updated = *c.TUI
+	}
+	switch {
+	case len(parts) == 1 && parts[0] == "theme":
+		updated.Theme = strings.ToLower(value)
+	case len(parts) == 2 && parts[0] == "colors": //nolint:mnd // colors.<role>
+		colors := make(map[string]string, len(updated.Colors)+1)
+		for k, v := range updated.Colors {
+			colors[k] = v
+		}
+		colors[parts[1]] = value
+		updated.Colors = colors
+	default:
+		return errUnknownTUIKey
+	}
+	if err := updated.Validate(); err != nil {
+		return err
+	}
+	c.TUI = &updated
+	return nil
+}
+
+// getTUIValue returns tui.theme, tui.colors, or tui.colors.<role>.
+func (c *Config) getTUIValue(parts []string) (interface{}, error) {
+	switch {
+	case len(parts) == 0:
+		return c.TUI, nil
+	case len(parts) == 1 && parts[0] == "theme":
+		return c.TUI.ThemeName(), nil
+	case len(parts) == 1 && parts[0] == "colors":
+		return c.TUI.ThemeColors(), nil
+	case len(parts) == 2 && parts[0] == "colors": //nolint:mnd // colors.<role>
+		color, ok := c.TUI.ThemeColors()[parts[1]]
+		if !ok {
+			return nil, fmt.Errorf("no color configured for %s", parts[1])
+		}
+		return color, nil
+	default:
+		return nil, errUnknownTUIKey
+	}
+}
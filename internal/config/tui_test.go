@@ -0,0 +1,77 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfig_ListLayout(t *testing.T) {
+	stubHome(t)
+	cfg := New()
+	assert.Equal(t, ListLayout{}, cfg.ListLayout("recommendations"), "no layout is saved by default")
+
+	layout := ListLayout{Columns: []string{"savings", "resource"}, SortBy: "resource"}
+	cfg.SetListLayout("recommendations", layout)
+	require.NoError(t, cfg.Save())
+
+	reloaded := New()
+	assert.Equal(t, layout, reloaded.ListLayout("recommendations"))
+	assert.Equal(t, ListLayout{}, reloaded.ListLayout("costs"))
+}
+
+func TestTUIConfig_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     *TUIConfig
+		wantErr string
+	}{
+		{name: "nil"},
+		{name: "theme and colors", cfg: &TUIConfig{
+			Theme:  TUIThemeHighContrast,
+			Colors: map[string]string{"header": "#875fff", "accent": "#abc", "muted": "240"},
+		}},
+		{name: "unknown theme", cfg: &TUIConfig{Theme: "solarized"}, wantErr: `invalid tui theme "solarized"`},
+		{
+			name: "unknown role", cfg: &TUIConfig{Colors: map[string]string{"title": "1"}},
+			wantErr: `unknown tui color role "title"`,
+		},
+		{name: "bad hex", cfg: &TUIConfig{Colors: map[string]string{"ok": "#12345"}}, wantErr: `invalid color "#12345"`},
+		{name: "ANSI out of range", cfg: &TUIConfig{Colors: map[string]string{"ok": "256"}}, wantErr: `invalid color "256"`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.wantErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestConfig_SetAndGetTUITheme(t *testing.T) {
+	stubHome(t)
+	cfg := New()
+
+	theme, err := cfg.Get("tui.theme")
+	require.NoError(t, err)
+	assert.Equal(t, TUIThemeAuto, theme)
+
+	require.NoError(t, cfg.Set("tui.theme", "Light"))
+	require.NoError(t, cfg.Set("tui.colors.header", "#005f87"))
+	require.Error(t, cfg.Set("tui.theme", "neon"))
+	require.Error(t, cfg.Set("tui.colors.header", "blue"))
+	require.ErrorIs(t, cfg.Set("tui.font", "mono"), errUnknownTUIKey)
+
+	theme, err = cfg.Get("tui.theme")
+	require.NoError(t, err)
+	assert.Equal(t, TUIThemeLight, theme)
+	color, err := cfg.Get("tui.colors.header")
+	require.NoError(t, err)
+	assert.Equal(t, "#005f87", color)
+	_, err = cfg.Get("tui.colors.accent")
+	require.Error(t, err)
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ErrUnknownUsageProfile is returned when a usage profile is not configured.
+var ErrUnknownUsageProfile = errors.New("unknown usage profile")
+
+const (
+	hoursPerDay = 24.0
+	daysPerWeek = 7.0
+	// weeksPerMonth makes an always-on profile run the 730 hours per month
+	// that projections assume.
+	weeksPerMonth = 730.0 / (hoursPerDay * daysPerWeek)
+)
+
+// UsageProfile describes how many hours a resource runs, e.g. a development
+// environment that is stopped outside working hours.
+type UsageProfile struct {
+	// HoursPerDay the resource runs, up to 24. Zero means 24.
+	HoursPerDay float64 `yaml:"hours_per_day,omitempty" json:"hours_per_day,omitempty"`
+
+	// DaysPerWeek the resource runs, up to 7. Zero means 7.
+	DaysPerWeek float64 `yaml:"days_per_week,omitempty" json:"days_per_week,omitempty"`
+}
+
+// HoursPerMonth returns the hours per month the profile runs; 730 for a
+// resource that always runs.
+func (p UsageProfile) HoursPerMonth() float64 {
+	hours, days := p.HoursPerDay, p.DaysPerWeek
+	if hours == 0 {
+		hours = hoursPerDay
+	}
+	if days == 0 {
+		days = daysPerWeek
+	}
+	return hours * days * weeksPerMonth
+}
+
+// Validate checks that the hours and days are in range.
+func (p UsageProfile) Validate() error {
+	if p.HoursPerDay < 0 || p.HoursPerDay > hoursPerDay {
+		return fmt.Errorf("hours_per_day must be between 0 and 24, got %g", p.HoursPerDay)
+	}
+	if p.DaysPerWeek < 0 || p.DaysPerWeek > daysPerWeek {
+		return fmt.Errorf("days_per_week must be between 0 and 7, got %g", p.DaysPerWeek)
+	}
+	return nil
+}
+
+// UsageProfiles maps profile names, as given to --usage-profile, to profiles.
+type UsageProfiles map[string]UsageProfile
+
+// Validate checks every profile.
+func (p UsageProfiles) Validate() error {
+	for name, profile := range p {
+		if err := profile.Validate(); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// Lookup returns the profile called name, or ErrUnknownUsageProfile listing
+// the configured profiles.
+func (p UsageProfiles) Lookup(name string) (UsageProfile, error) {
+	if profile, ok := p[name]; ok {
+		return profile, nil
+	}
+	names := make([]string, 0, len(p))
+	for configured := range p {
+		names = append(names, configured)
+	}
+	if len(names) == 0 {
+		return UsageProfile{}, fmt.Errorf("%w %q: no profiles are configured under cost.profiles",
+			ErrUnknownUsageProfile, name)
+	}
+	slices.Sort(names)
+	return UsageProfile{}, fmt.Errorf("%w %q: configured profiles are %s",
+		ErrUnknownUsageProfile, name, strings.Join(names, ", "))
+}
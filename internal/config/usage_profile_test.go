@@ -0,0 +1,67 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func TestUsageProfile_HoursPerMonth(t *testing.T) {
+	assert.InDelta(t, 730.0, UsageProfile{}.HoursPerMonth(), 0.001, "an empty profile always runs")
+	assert.InDelta(t, 730.0, UsageProfile{HoursPerDay: 24, DaysPerWeek: 7}.HoursPerMonth(), 0.001)
+	assert.InDelta(t, 217.26, UsageProfile{HoursPerDay: 10, DaysPerWeek: 5}.HoursPerMonth(), 0.01)
+	assert.InDelta(t, 365.0, UsageProfile{HoursPerDay: 12}.HoursPerMonth(), 0.001)
+}
+
+func TestUsageProfiles_Validate(t *testing.T) {
+	require.NoError(t, UsageProfiles(nil).Validate())
+	require.NoError(t, UsageProfiles{"dev": {HoursPerDay: 10, DaysPerWeek: 5}}.Validate())
+
+	tests := map[string]UsageProfile{
+		"negative hours": {HoursPerDay: -1},
+		"too many hours": {HoursPerDay: 25},
+		"negative days":  {DaysPerWeek: -1},
+		"too many days":  {DaysPerWeek: 8},
+	}
+	for name, profile := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := UsageProfiles{"dev": profile}.Validate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), "dev:")
+		})
+	}
+}
+
+func TestUsageProfiles_Lookup(t *testing.T) {
+	profiles := UsageProfiles{"dev": {HoursPerDay: 10}, "ci": {HoursPerDay: 2}}
+
+	profile, err := profiles.Lookup("dev")
+	require.NoError(t, err)
+	assert.InDelta(t, 10.0, profile.HoursPerDay, 0.001)
+
+	_, err = profiles.Lookup("staging")
+	require.ErrorIs(t, err, ErrUnknownUsageProfile)
+	assert.Contains(t, err.Error(), "ci, dev")
+
+	_, err = UsageProfiles(nil).Lookup("dev")
+	require.ErrorIs(t, err, ErrUnknownUsageProfile)
+	assert.Contains(t, err.Error(), "no profiles are configured")
+}
+
+func TestCostConfig_Profiles(t *testing.T) {
+	var cfg CostConfig
+	require.NoError(t, yaml.Unmarshal([]byte(`
+profiles:
+  dev:
+    hours_per_day: 10
+    days_per_week: 5
+`), &cfg))
+	assert.Equal(t, UsageProfile{HoursPerDay: 10, DaysPerWeek: 5}, cfg.Profiles["dev"])
+
+	cfg.Profiles["broken"] = UsageProfile{HoursPerDay: 30}
+	err := cfg.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "profiles: broken")
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -753,3 +754,41 @@ func TestValidatePattern(t *testing.T) {
 		})
 	}
 }
+
+func TestPluginHostConfig_Resilience(t *testing.T) {
+	two, negative := 2, -1
+	host := PluginHostConfig{
+		Resilience: ResilienceConfig{Timeout: Duration(30 * time.Second), MaxRetries: &two},
+		Plugins: map[string]ResilienceConfig{
+			"aws-ce": {Timeout: Duration(2 * time.Minute)},
+		},
+	}
+	require.NoError(t, host.Validate())
+
+	merged := host.ResilienceFor("aws-ce")
+	assert.Equal(t, Duration(2*time.Minute), merged.Timeout)
+	require.NotNil(t, merged.MaxRetries)
+	assert.Equal(t, 2, *merged.MaxRetries, "unset overrides keep the global value")
+	assert.Equal(t, host.Resilience, host.ResilienceFor("other"))
+
+	host.Plugins["aws-ce"] = ResilienceConfig{MaxRetries: &negative}
+	require.ErrorContains(t, host.Validate(), "plugins.aws-ce: max_retries")
+
+	host.Plugins = nil
+	host.Resilience = ResilienceConfig{
+		InitialBackoff: Duration(time.Second),
+		MaxBackoff:     Duration(time.Millisecond),
+	}
+	require.ErrorContains(t, host.Validate(), "max_backoff")
+}
+
+func TestCacheConfig_ValidateOperationTTLs(t *testing.T) {
+	valid := CacheConfig{OperationTTLSeconds: map[string]int{"projected_cost": 86400, "actual_cost": 600}}
+	require.NoError(t, CostConfig{Cache: valid}.Validate())
+
+	unknown := CacheConfig{OperationTTLSeconds: map[string]int{"budgets": 60}}
+	require.ErrorContains(t, CostConfig{Cache: unknown}.Validate(), `unknown operation "budgets"`)
+
+	negative := CacheConfig{OperationTTLSeconds: map[string]int{"recommendations": 0}}
+	require.ErrorContains(t, negative.Validate(), "must be positive")
+}
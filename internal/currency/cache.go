@@ -0,0 +1,95 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// DefaultCacheTTL is how long fetched rates are reused before fetching again.
+const DefaultCacheTTL = 24 * time.Hour
+
+// cacheFileMode keeps the rates cache private to the user.
+const cacheFileMode = 0o600
+
+// cachedRates is the on-disk form of the rates cache.
+type cachedRates struct {
+	FetchedAt time.Time `json:"fetchedAt"`
+	Rates     *Rates    `json:"rates"`
+}
+
+// CachedSource reuses the rates of another source for a TTL by caching them
+// in a file. When a refresh fails, the stale cached rates are used instead.
+type CachedSource struct {
+	source Source
+	path   string
+	ttl    time.Duration
+	now    func() time.Time
+}
+
+// NewCachedSource caches the rates of source in the file at path for ttl.
+func NewCachedSource(source Source, path string, ttl time.Duration) *CachedSource {
+	return &CachedSource{source: source, path: path, ttl: ttl, now: time.Now}
+}
+
+// Rates returns the cached rates while they are fresh, and fetches and
+// caches new ones otherwise.
+func (s *CachedSource) Rates(ctx context.Context) (*Rates, error) {
+	log := logging.FromContext(ctx)
+	cached := s.load()
+	if cached != nil && s.now().Sub(cached.FetchedAt) < s.ttl {
+		return cached.Rates, nil
+	}
+
+	rates, err := s.source.Rates(ctx)
+	if err != nil {
+		if cached == nil {
+			return nil, err
+		}
+		log.Warn().Ctx(ctx).Str("component", "currency").Err(err).
+			Time("fetched_at", cached.FetchedAt).Msg("using stale cached exchange rates")
+		return cached.Rates, nil
+	}
+
+	if saveErr := s.save(&cachedRates{FetchedAt: s.now(), Rates: rates}); saveErr != nil {
+		log.Debug().Ctx(ctx).Str("component", "currency").Err(saveErr).Msg("caching exchange rates failed")
+	}
+	return rates, nil
+}
+
+// load reads the cache file, returning nil when it is missing or unreadable.
+func (s *CachedSource) load() *cachedRates {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil
+	}
+	var cached cachedRates
+	if err = json.Unmarshal(data, &cached); err != nil || cached.Rates == nil {
+		return nil
+	}
+	return &cached
+}
+
+// save writes the cache file atomically.
+func (s *CachedSource) save(cached *cachedRates) error {
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return fmt.Errorf("encoding exchange rates: %w", err)
+	}
+	if err = os.MkdirAll(filepath.Dir(s.path), 0o700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+	tmp := s.path + ".tmp"
+	if err = os.WriteFile(tmp, data, cacheFileMode); err != nil {
+		return fmt.Errorf("writing exchange rates cache: %w", err)
+	}
+	if err = os.Rename(tmp, s.path); err != nil {
+		return fmt.Errorf("writing exchange rates cache: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,75 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingSource returns its rates or error and counts calls.
+type countingSource struct {
+	rates *Rates
+	err   error
+	calls int
+}
+
+func (s *countingSource) Rates(context.Context) (*Rates, error) {
+	s.calls++
+	return s.rates, s.err
+}
+
+func TestCachedSource_ReusesFreshRates(t *testing.T) {
+	src := &countingSource{rates: testRates()}
+	cached := NewCachedSource(src, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+
+	for range 2 {
+		rates, err := cached.Rates(context.Background())
+		require.NoError(t, err)
+		assert.InDelta(t, 1.25, rates.Rates["USD"], 1e-9)
+	}
+	assert.Equal(t, 1, src.calls)
+}
+
+func TestCachedSource_RefreshesExpiredRates(t *testing.T) {
+	src := &countingSource{rates: testRates()}
+	cached := NewCachedSource(src, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	_, err := cached.Rates(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	_, err = cached.Rates(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 2, src.calls)
+}
+
+func TestCachedSource_FallsBackToStaleRates(t *testing.T) {
+	src := &countingSource{rates: testRates()}
+	cached := NewCachedSource(src, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+	now := time.Now()
+	cached.now = func() time.Time { return now }
+
+	_, err := cached.Rates(context.Background())
+	require.NoError(t, err)
+
+	now = now.Add(2 * time.Hour)
+	src.rates, src.err = nil, errors.New("offline")
+	rates, err := cached.Rates(context.Background())
+	require.NoError(t, err)
+	assert.InDelta(t, 1.25, rates.Rates["USD"], 1e-9)
+}
+
+func TestCachedSource_NoCacheReturnsError(t *testing.T) {
+	src := &countingSource{err: ErrFetchFailed}
+	cached := NewCachedSource(src, filepath.Join(t.TempDir(), "rates.json"), time.Hour)
+
+	_, err := cached.Rates(context.Background())
+	require.ErrorIs(t, err, ErrFetchFailed)
+}
@@ -0,0 +1,128 @@
+// Package currency converts costs between currencies.
+//
+// Plugins report costs in their native currency. A Converter normalizes them
+// to one target currency using exchange rates from a Source: rates from the
+// configuration file, or rates fetched from the European Central Bank or
+// openexchangerates.org and cached on disk.
+//
+// The converter travels with the request context, like the logger, so every
+// engine call made under a context created by NewContext reports its costs
+// in the target currency.
+package currency
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// ErrUnknownCurrency is returned when no exchange rate is known for a currency.
+var ErrUnknownCurrency = errors.New("no exchange rate for currency")
+
+// codeLength is the length of an ISO 4217 currency code.
+const codeLength = 3
+
+// Rates are exchange rates against a base currency: one unit of Base buys
+// Rates[code] units of code.
+type Rates struct {
+	Base   string             `json:"base"`
+	Rates  map[string]float64 `json:"rates"`
+	Date   time.Time          `json:"date"`
+	Source string             `json:"source"`
+}
+
+// rate returns how many units of code one unit of Base buys.
+func (r *Rates) rate(code string) (float64, error) {
+	if code == r.Base {
+		return 1, nil
+	}
+	v, ok := r.Rates[code]
+	if !ok || v <= 0 {
+		return 0, fmt.Errorf("%w %s (rates from %s)", ErrUnknownCurrency, code, r.Source)
+	}
+	return v, nil
+}
+
+// Rate returns the factor that converts an amount in from into to.
+func (r *Rates) Rate(from, to string) (float64, error) {
+	fromRate, err := r.rate(from)
+	if err != nil {
+		return 0, err
+	}
+	toRate, err := r.rate(to)
+	if err != nil {
+		return 0, err
+	}
+	return toRate / fromRate, nil
+}
+
+// Converter converts amounts into a single target currency.
+type Converter struct {
+	target string
+	rates  *Rates
+}
+
+// NewConverter returns a converter into target using rates. It fails when
+// target has no rate.
+func NewConverter(target string, rates *Rates) (*Converter, error) {
+	target = strings.ToUpper(target)
+	if err := ValidateCode(target); err != nil {
+		return nil, err
+	}
+	if _, err := rates.rate(target); err != nil {
+		return nil, err
+	}
+	return &Converter{target: target, rates: rates}, nil
+}
+
+// Target returns the currency amounts are converted into.
+func (c *Converter) Target() string {
+	return c.target
+}
+
+// Rates returns the exchange rates the converter uses.
+func (c *Converter) Rates() *Rates {
+	return c.rates
+}
+
+// Convert converts amount from the from currency into the target currency.
+func (c *Converter) Convert(amount float64, from string) (float64, error) {
+	if from == c.target || amount == 0 {
+		return amount, nil
+	}
+	rate, err := c.rates.Rate(from, c.target)
+	if err != nil {
+		return 0, err
+	}
+	return amount * rate, nil
+}
+
+// ValidateCode checks that code is a three-letter uppercase ISO 4217 code.
+func ValidateCode(code string) error {
+	if len(code) != codeLength {
+		return fmt.Errorf("invalid currency code %q: must be 3 uppercase letters", code)
+	}
+	for _, r := range code {
+		if r < 'A' || r > 'Z' {
+			return fmt.Errorf("invalid currency code %q: must be 3 uppercase letters", code)
+		}
+	}
+	return nil
+}
+
+// converterKey is the context key of the converter.
+type converterKey struct{}
+
+// NewContext returns a copy of ctx that carries c.
+func NewContext(ctx context.Context, c *Converter) context.Context {
+	return context.WithValue(ctx, converterKey{}, c)
+}
+
+// FromContext returns the converter carried by ctx, or nil when costs are
+// reported in their native currencies.
+func FromContext(ctx context.Context) *Converter {
+	c, _ := ctx.Value(converterKey{}).(*Converter)
+	return c
+}
@@ -0,0 +1,61 @@
+package currency
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testRates() *Rates {
+	return &Rates{Base: "EUR", Rates: map[string]float64{"USD": 1.25, "GBP": 0.8}, Source: SourceStatic}
+}
+
+func TestRates_Rate(t *testing.T) {
+	rates := testRates()
+
+	rate, err := rates.Rate("USD", "EUR")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.8, rate, 1e-9)
+
+	rate, err = rates.Rate("USD", "GBP")
+	require.NoError(t, err)
+	assert.InDelta(t, 0.64, rate, 1e-9)
+
+	_, err = rates.Rate("JPY", "EUR")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestConverter_Convert(t *testing.T) {
+	conv, err := NewConverter("eur", testRates())
+	require.NoError(t, err)
+	assert.Equal(t, "EUR", conv.Target())
+
+	got, err := conv.Convert(100, "USD")
+	require.NoError(t, err)
+	assert.InDelta(t, 80, got, 1e-9)
+
+	got, err = conv.Convert(100, "EUR")
+	require.NoError(t, err)
+	assert.InDelta(t, 100, got, 1e-9)
+
+	_, err = conv.Convert(100, "JPY")
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+}
+
+func TestNewConverter_RejectsUnknownTarget(t *testing.T) {
+	_, err := NewConverter("JPY", testRates())
+	require.ErrorIs(t, err, ErrUnknownCurrency)
+
+	_, err = NewConverter("EURO", testRates())
+	require.Error(t, err)
+}
+
+func TestContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	conv, err := NewConverter("USD", testRates())
+	require.NoError(t, err)
+	assert.Same(t, conv, FromContext(NewContext(context.Background(), conv)))
+}
@@ -0,0 +1,199 @@
+package currency
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/restapi"
+)
+
+// Rate source settings.
+const (
+	// SourceStatic uses the rates from the configuration file.
+	SourceStatic = "static"
+	// SourceECB fetches the daily euro reference rates of the European Central Bank.
+	SourceECB = "ecb"
+	// SourceOpenExchangeRates fetches the latest rates from openexchangerates.org.
+	SourceOpenExchangeRates = "openexchangerates"
+
+	// DefaultECBURL is the ECB daily reference rate feed.
+	DefaultECBURL = "https://www.ecb.europa.eu/stats/eurofxref/eurofxref-daily.xml"
+	// DefaultOpenExchangeRatesURL is the openexchangerates.org latest rates endpoint.
+	DefaultOpenExchangeRatesURL = "https://openexchangerates.org/api/latest.json"
+	// DefaultFetchTimeout bounds a single rate request.
+	DefaultFetchTimeout = 30 * time.Second
+
+	// EnvOpenExchangeRatesAppID is the environment variable holding the openexchangerates.org app ID.
+	EnvOpenExchangeRatesAppID = "OPENEXCHANGERATES_APP_ID"
+
+	// maxRatesBody bounds the size of a rates response.
+	maxRatesBody = 1 << 20
+)
+
+// ErrFetchFailed is returned when exchange rates cannot be fetched.
+var ErrFetchFailed = errors.New("fetching exchange rates failed")
+
+// Source provides exchange rates.
+type Source interface {
+	Rates(ctx context.Context) (*Rates, error)
+}
+
+// StaticSource serves fixed rates, typically from the configuration file.
+type StaticSource struct {
+	rates *Rates
+}
+
+// NewStaticSource returns a source of rates against base.
+func NewStaticSource(base string, rates map[string]float64) *StaticSource {
+	return &StaticSource{rates: &Rates{Base: base, Rates: rates, Source: SourceStatic}}
+}
+
+// Rates returns the static rates.
+func (s *StaticSource) Rates(context.Context) (*Rates, error) {
+	return s.rates, nil
+}
+
+// ECBSource fetches the euro foreign exchange reference rates published
+// every working day by the European Central Bank.
+type ECBSource struct {
+	HTTPClient *http.Client
+	URL        string
+}
+
+// NewECBSource returns a source reading the ECB daily feed.
+func NewECBSource() *ECBSource {
+	return &ECBSource{HTTPClient: &http.Client{Timeout: DefaultFetchTimeout}, URL: DefaultECBURL}
+}
+
+// ecbEnvelope is the part of the ECB feed holding the rates.
+type ecbEnvelope struct {
+	Days []struct {
+		Time  string `xml:"time,attr"`
+		Rates []struct {
+			Currency string  `xml:"currency,attr"`
+			Rate     float64 `xml:"rate,attr"`
+		} `xml:"Cube"`
+	} `xml:"Cube>Cube"`
+}
+
+// Rates fetches the latest ECB reference rates, which are against EUR.
+func (s *ECBSource) Rates(ctx context.Context) (*Rates, error) {
+	body, err := fetch(ctx, s.HTTPClient, s.URL, SourceECB)
+	if err != nil {
+		return nil, err
+	}
+
+	var env ecbEnvelope
+	if err = xml.Unmarshal(body, &env); err != nil {
+		return nil, fmt.Errorf("%w: parsing ECB feed: %w", ErrFetchFailed, err)
+	}
+	if len(env.Days) == 0 || len(env.Days[0].Rates) == 0 {
+		return nil, fmt.Errorf("%w: ECB feed has no rates", ErrFetchFailed)
+	}
+
+	day := env.Days[0]
+	rates := &Rates{Base: "EUR", Rates: make(map[string]float64, len(day.Rates)), Source: SourceECB}
+	for _, r := range day.Rates {
+		rates.Rates[r.Currency] = r.Rate
+	}
+	if date, parseErr := time.Parse(time.DateOnly, day.Time); parseErr == nil {
+		rates.Date = date
+	}
+	return rates, nil
+}
+
+// OpenExchangeRatesSource fetches the latest rates from openexchangerates.org.
+type OpenExchangeRatesSource struct {
+	HTTPClient *http.Client
+	URL        string
+	appID      string
+}
+
+// NewOpenExchangeRatesSource returns a source authenticating with appID.
+func NewOpenExchangeRatesSource(appID string) *OpenExchangeRatesSource {
+	return &OpenExchangeRatesSource{
+		HTTPClient: &http.Client{Timeout: DefaultFetchTimeout},
+		URL:        DefaultOpenExchangeRatesURL,
+		appID:      appID,
+	}
+}
+
+// openExchangeRatesResponse is the body of the latest rates endpoint.
+type openExchangeRatesResponse struct {
+	Timestamp int64              `json:"timestamp"`
+	Base      string             `json:"base"`
+	Rates     map[string]float64 `json:"rates"`
+}
+
+// Rates fetches the latest rates, which are against USD on the free plan.
+func (s *OpenExchangeRatesSource) Rates(ctx context.Context) (*Rates, error) {
+	if s.appID == "" {
+		return nil, fmt.Errorf("%w: openexchangerates needs an app ID; set %s or currency.app_id",
+			ErrFetchFailed, EnvOpenExchangeRatesAppID)
+	}
+	endpoint := s.URL + "?app_id=" + url.QueryEscape(s.appID)
+	body, err := fetch(ctx, s.HTTPClient, endpoint, SourceOpenExchangeRates)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp openExchangeRatesResponse
+	if err = json.Unmarshal(body, &resp); err != nil {
+		return nil, fmt.Errorf("%w: parsing openexchangerates response: %w", ErrFetchFailed, err)
+	}
+	if resp.Base == "" || len(resp.Rates) == 0 {
+		return nil, fmt.Errorf("%w: openexchangerates response has no rates", ErrFetchFailed)
+	}
+	return &Rates{
+		Base:   resp.Base,
+		Rates:  resp.Rates,
+		Date:   time.Unix(resp.Timestamp, 0).UTC(),
+		Source: SourceOpenExchangeRates,
+	}, nil
+}
+
+// fetch GETs endpoint and returns its body. The logged endpoint omits the
+// query, which may carry credentials.
+func fetch(ctx context.Context, client *http.Client, endpoint, source string) ([]byte, error) {
+	logged, _, _ := strings.Cut(endpoint, "?")
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "currency").
+		Str("source", source).Str("endpoint", logged).Msg("fetching exchange rates")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating %s request: %w", source, err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: %w", ErrFetchFailed, source, redactURLError(err))
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return nil, fmt.Errorf("%w from %s: HTTP %d: %s",
+			ErrFetchFailed, source, resp.StatusCode, restapi.ErrorBody(resp.Body))
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRatesBody))
+	if err != nil {
+		return nil, fmt.Errorf("%w from %s: reading response: %w", ErrFetchFailed, source, err)
+	}
+	return body, nil
+}
+
+// redactURLError drops the URL, which may carry an app ID, from HTTP client errors.
+func redactURLError(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return urlErr.Err
+	}
+	return err
+}
@@ -0,0 +1,76 @@
+package currency
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const ecbFeed = `<?xml version="1.0" encoding="UTF-8"?>
+<gesmes:Envelope xmlns:gesmes="http://www.gesmes.org/xml/2002-08-01"
+  xmlns="http://www.ecb.int/vocabulary/2002-08-01/eurofxref">
+  <gesmes:subject>Reference rates</gesmes:subject>
+  <Cube>
+    <Cube time="2025-01-30">
+      <Cube currency="USD" rate="1.0412"/>
+      <Cube currency="GBP" rate="0.8362"/>
+    </Cube>
+  </Cube>
+</gesmes:Envelope>`
+
+func TestECBSource_Rates(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(ecbFeed))
+	}))
+	defer srv.Close()
+
+	src := NewECBSource()
+	src.URL = srv.URL
+	rates, err := src.Rates(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "EUR", rates.Base)
+	assert.Equal(t, SourceECB, rates.Source)
+	assert.Equal(t, "2025-01-30", rates.Date.Format("2006-01-02"))
+	assert.InDelta(t, 1.0412, rates.Rates["USD"], 1e-9)
+	assert.InDelta(t, 0.8362, rates.Rates["GBP"], 1e-9)
+}
+
+func TestOpenExchangeRatesSource_Rates(t *testing.T) {
+	var gotAppID string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotAppID = r.URL.Query().Get("app_id")
+		_, _ = w.Write([]byte(`{"timestamp":1738195200,"base":"USD","rates":{"EUR":0.96,"GBP":0.8}}`))
+	}))
+	defer srv.Close()
+
+	src := NewOpenExchangeRatesSource("app-123")
+	src.URL = srv.URL
+	rates, err := src.Rates(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, "app-123", gotAppID)
+	assert.Equal(t, "USD", rates.Base)
+	assert.InDelta(t, 0.96, rates.Rates["EUR"], 1e-9)
+}
+
+func TestOpenExchangeRatesSource_Errors(t *testing.T) {
+	_, err := NewOpenExchangeRatesSource("").Rates(context.Background())
+	require.ErrorIs(t, err, ErrFetchFailed)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "invalid app_id", http.StatusUnauthorized)
+	}))
+	defer srv.Close()
+
+	src := NewOpenExchangeRatesSource("secret-id")
+	src.URL = srv.URL
+	_, err = src.Rates(context.Background())
+	require.ErrorIs(t, err, ErrFetchFailed)
+	assert.Contains(t, err.Error(), "HTTP 401")
+	assert.NotContains(t, err.Error(), "secret-id")
+}
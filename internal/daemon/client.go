@@ -0,0 +1,109 @@
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// ErrNotRunning is returned when no daemon listens on the socket.
+var ErrNotRunning = errors.New("daemon is not running")
+
+// Launcher is a pluginhost.Launcher that reaches plugins through the daemon
+// listening on a socket instead of starting them.
+type Launcher struct {
+	socket string
+}
+
+// NewLauncher creates a launcher for the daemon listening on socket.
+func NewLauncher(socket string) *Launcher {
+	return &Launcher{socket: socket}
+}
+
+// Start connects to the plugin binary at path through the daemon, which
+// starts the plugin on the first call unless it is already running. Closing
+// the connection leaves the plugin running. The daemon starts plugins without
+// arguments, so args are not supported.
+func (l *Launcher) Start(_ context.Context, path string, args ...string) (*grpc.ClientConn, func() error, error) {
+	if len(args) > 0 {
+		return nil, nil, fmt.Errorf("daemon cannot start %s with arguments", path)
+	}
+	conn, err := dial(l.socket,
+		grpc.WithChainUnaryInterceptor(
+			pluginhost.TraceInterceptor(),
+			func(
+				ctx context.Context, method string, req, reply any,
+				cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption,
+			) error {
+				ctx = metadata.AppendToOutgoingContext(ctx, headerPluginPath, path)
+				return invoker(ctx, method, req, reply, cc, opts...)
+			},
+		),
+		grpc.WithChainStreamInterceptor(func(
+			ctx context.Context, desc *grpc.StreamDesc, cc *grpc.ClientConn, method string,
+			streamer grpc.Streamer, opts ...grpc.CallOption,
+		) (grpc.ClientStream, error) {
+			ctx = metadata.AppendToOutgoingContext(ctx, headerPluginPath, path)
+			return streamer(ctx, desc, cc, method, opts...)
+		}),
+	)
+	if err != nil {
+		return nil, nil, err
+	}
+	return conn, conn.Close, nil
+}
+
+// GetStatus returns the status of the daemon listening on socket, or
+// ErrNotRunning when there is none.
+func GetStatus(ctx context.Context, socket string) (*Status, error) {
+	var st Status
+	if err := call(ctx, socket, methodStatus, &st); err != nil {
+		return nil, err
+	}
+	return &st, nil
+}
+
+// Stop asks the daemon listening on socket to stop its plugins and exit. It
+// returns ErrNotRunning when there is none.
+func Stop(ctx context.Context, socket string) error {
+	return call(ctx, socket, methodShutdown, &struct{}{})
+}
+
+// call invokes a daemon method and decodes its JSON answer into out.
+func call(ctx context.Context, socket, method string, out any) error {
+	if _, err := os.Stat(socket); err != nil {
+		return ErrNotRunning
+	}
+	conn, err := dial(socket)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	var resp frame
+	if err = conn.Invoke(ctx, method, &frame{}, &resp, grpc.ForceCodec(rawCodec{})); err != nil {
+		return fmt.Errorf("%w: %w", ErrNotRunning, err)
+	}
+	if err = json.Unmarshal(resp.data, out); err != nil {
+		return fmt.Errorf("decoding daemon answer: %w", err)
+	}
+	return nil
+}
+
+// dial creates a connection to the daemon listening on socket.
+func dial(socket string, opts ...grpc.DialOption) (*grpc.ClientConn, error) {
+	conn, err := grpc.NewClient("unix:"+socket,
+		append([]grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}, opts...)...)
+	if err != nil {
+		return nil, fmt.Errorf("connecting to daemon: %w", err)
+	}
+	return conn, nil
+}
@@ -0,0 +1,469 @@
+// Package daemon keeps plugin processes running between CLI invocations.
+//
+// Starting a plugin process dominates the latency of small queries, so the
+// daemon launches each plugin once and multiplexes the calls of every CLI
+// invocation to it over a Unix socket. Calls are forwarded as raw gRPC
+// messages: the CLI talks to the daemon exactly as it would to the plugin,
+// and names the plugin binary in a request header.
+//
+// A plugin process is restarted when its binary changes on disk, for example
+// when the plugin is reinstalled, and stopped once it has been idle for the
+// idle timeout. The daemon itself exits after the idle timeout without calls.
+// The CLI uses the daemon only when it runs the same finfocus version, and
+// stops a daemon of another version.
+package daemon
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// DefaultIdleTimeout is how long plugins and the daemon stay up without calls.
+const DefaultIdleTimeout = 30 * time.Minute
+
+// SocketFileName is the name of the daemon socket in the finfocus home.
+const SocketFileName = "daemon.sock"
+
+// headerPluginPath names the plugin binary a forwarded call is for.
+const headerPluginPath = "x-finfocus-plugin-path"
+
+// Methods the daemon answers itself rather than forwarding to a plugin.
+const (
+	methodStatus   = "/finfocus.daemon.v1.Daemon/Status"
+	methodShutdown = "/finfocus.daemon.v1.Daemon/Shutdown"
+)
+
+// minSweepInterval bounds how often idle plugins are looked for.
+const minSweepInterval = time.Second
+
+// SocketPath returns the path of the daemon socket in the finfocus home,
+// normally ~/.finfocus/daemon.sock.
+func SocketPath() string {
+	return filepath.Join(config.ResolveConfigDir(), SocketFileName)
+}
+
+// Status describes a running daemon.
+type Status struct {
+	PID         int            `json:"pid"`
+	Version     string         `json:"version"`
+	Started     time.Time      `json:"started"`
+	IdleTimeout time.Duration  `json:"idleTimeout"`
+	Plugins     []PluginStatus `json:"plugins"`
+}
+
+// PluginStatus describes a plugin process kept by the daemon.
+type PluginStatus struct {
+	Path     string    `json:"path"`
+	Started  time.Time `json:"started"`
+	LastUsed time.Time `json:"lastUsed"`
+	Calls    int64     `json:"calls"`
+}
+
+// process is a running plugin.
+type process struct {
+	PluginStatus
+
+	// modTime is the modification time of the binary the process was
+	// started from; a newer binary restarts the process.
+	modTime time.Time
+	conn    *grpc.ClientConn
+	close   func() error
+
+	// active counts the calls in flight; stale marks a process to close once
+	// they finish.
+	active int
+	stale  bool
+}
+
+// launch is a plugin start in progress. Calls for the plugin wait for done
+// instead of starting it again; err holds the failure of the start.
+type launch struct {
+	done chan struct{}
+	err  error
+}
+
+// Server forwards plugin calls to plugin processes it keeps running.
+type Server struct {
+	launcher    pluginhost.Launcher
+	version     string
+	idleTimeout time.Duration
+	started     time.Time
+
+	// ctx outlives the calls and bounds the plugin processes.
+	ctx context.Context
+
+	mu        sync.Mutex
+	processes map[string]*process
+	launches  map[string]*launch
+	lastCall  time.Time
+
+	shutdown     chan struct{}
+	shutdownOnce sync.Once
+}
+
+// NewServer creates a daemon that launches plugins with launcher and reports
+// version. An idleTimeout of zero or less uses DefaultIdleTimeout.
+func NewServer(launcher pluginhost.Launcher, version string, idleTimeout time.Duration) *Server {
+	if idleTimeout <= 0 {
+		idleTimeout = DefaultIdleTimeout
+	}
+	now := time.Now()
+	return &Server{
+		launcher:    launcher,
+		version:     version,
+		idleTimeout: idleTimeout,
+		started:     now,
+		processes:   make(map[string]*process),
+		launches:    make(map[string]*launch),
+		lastCall:    now,
+		shutdown:    make(chan struct{}),
+	}
+}
+
+// Serve answers calls on listener until ctx is done, a client asks the
+// daemon to shut down, or no call has arrived for the idle timeout. Plugin
+// processes run under ctx and are stopped before Serve returns.
+func (s *Server) Serve(ctx context.Context, listener net.Listener) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	s.ctx = ctx
+	defer s.closeAll()
+
+	server := grpc.NewServer(
+		grpc.ForceServerCodec(rawCodec{}),
+		grpc.UnknownServiceHandler(s.handle),
+	)
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- server.Serve(listener)
+	}()
+
+	sweep := time.NewTicker(max(s.idleTimeout/4, minSweepInterval))
+	defer sweep.Stop()
+	for {
+		select {
+		case err := <-serveErr:
+			if err != nil {
+				return fmt.Errorf("serving daemon: %w", err)
+			}
+			return nil
+		case <-ctx.Done():
+			server.GracefulStop()
+			return nil
+		case <-s.shutdown:
+			server.GracefulStop()
+			return nil
+		case <-sweep.C:
+			if s.sweep() {
+				logging.FromContext(ctx).Info().
+					Ctx(ctx).
+					Str("component", "daemon").
+					Dur("idle_timeout", s.idleTimeout).
+					Msg("daemon idle, shutting down")
+				server.GracefulStop()
+				return nil
+			}
+		}
+	}
+}
+
+// handle answers daemon methods and forwards every other call to the plugin
+// named by its headers. Calls are proxied as streams, so unary and streaming
+// RPCs are forwarded alike.
+func (s *Server) handle(_ any, stream grpc.ServerStream) error {
+	method, _ := grpc.MethodFromServerStream(stream)
+	switch method {
+	case methodStatus:
+		return s.sendJSON(stream, s.status())
+	case methodShutdown:
+		s.shutdownOnce.Do(func() { close(s.shutdown) })
+		return s.sendJSON(stream, struct{}{})
+	}
+
+	md, _ := metadata.FromIncomingContext(stream.Context())
+	paths := md.Get(headerPluginPath)
+	if len(paths) == 0 {
+		return status.Errorf(codes.InvalidArgument, "missing %s header", headerPluginPath)
+	}
+	proc, err := s.acquire(paths[0])
+	if err != nil {
+		return status.Error(codes.Unavailable, err.Error())
+	}
+	defer s.release(proc)
+
+	// Forward the caller's headers, such as its trace ID, but not those
+	// describing the connection to the daemon.
+	out := md.Copy()
+	for _, key := range []string{headerPluginPath, ":authority", "content-type", "user-agent"} {
+		out.Delete(key)
+	}
+	ctx, cancel := context.WithCancel(metadata.NewOutgoingContext(stream.Context(), out))
+	defer cancel()
+	upstream, err := proc.conn.NewStream(ctx, &proxyStreamDesc, method, grpc.ForceCodec(rawCodec{}))
+	if err != nil {
+		return err
+	}
+	return proxy(stream, upstream)
+}
+
+// proxyStreamDesc describes forwarded calls: any number of messages in both
+// directions covers every kind of RPC.
+//
+//nolint:gochecknoglobals // Read-only stream description.
+var proxyStreamDesc = grpc.StreamDesc{ServerStreams: true, ClientStreams: true}
+
+// proxy copies the caller's messages to the plugin and the plugin's messages,
+// headers, and trailers back until the plugin ends the call.
+func proxy(caller grpc.ServerStream, upstream grpc.ClientStream) error {
+	sendErr := make(chan error, 1)
+	go func() {
+		for {
+			var req frame
+			if err := caller.RecvMsg(&req); err != nil {
+				if errors.Is(err, io.EOF) {
+					err = upstream.CloseSend()
+				}
+				sendErr <- err
+				return
+			}
+			if err := upstream.SendMsg(&req); err != nil {
+				// The plugin ended the call; its status is returned by RecvMsg.
+				sendErr <- nil
+				return
+			}
+		}
+	}()
+
+	if header, err := upstream.Header(); err == nil && len(header) > 0 {
+		if err = caller.SendHeader(header); err != nil {
+			return err
+		}
+	}
+	for {
+		var resp frame
+		if err := upstream.RecvMsg(&resp); err != nil {
+			caller.SetTrailer(upstream.Trailer())
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if err := caller.SendMsg(&resp); err != nil {
+			return err
+		}
+		select {
+		case err := <-sendErr:
+			if err != nil {
+				return err
+			}
+		default:
+		}
+	}
+}
+
+// sendJSON answers a daemon method with v encoded as JSON.
+func (s *Server) sendJSON(stream grpc.ServerStream, v any) error {
+	var req frame
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	return stream.SendMsg(&frame{data: data})
+}
+
+// acquire returns the running process of the plugin binary at path, starting
+// it when none runs or its binary has changed since it started. The start runs
+// outside s.mu, so a slow plugin holds up only the calls waiting for it.
+func (s *Server) acquire(path string) (*process, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("plugin binary: %w", err)
+	}
+
+	for {
+		s.mu.Lock()
+		s.lastCall = time.Now()
+		proc, ok := s.processes[path]
+		if ok && !proc.modTime.Equal(info.ModTime()) {
+			logging.FromContext(s.ctx).Info().
+				Ctx(s.ctx).
+				Str("component", "daemon").
+				Str("plugin_path", path).
+				Msg("plugin binary changed, restarting plugin")
+			s.retire(proc)
+			ok = false
+		}
+		if ok {
+			proc.active++
+			proc.Calls++
+			proc.LastUsed = s.lastCall
+			s.mu.Unlock()
+			return proc, nil
+		}
+
+		l, starting := s.launches[path]
+		if !starting {
+			l = &launch{done: make(chan struct{})}
+			s.launches[path] = l
+		}
+		s.mu.Unlock()
+		if !starting {
+			s.start(path, info.ModTime(), l)
+		}
+		<-l.done
+		if l.err != nil {
+			return nil, l.err
+		}
+	}
+}
+
+// start launches the plugin binary at path, last modified at modTime, adds it
+// to the pool, and ends l.
+func (s *Server) start(path string, modTime time.Time, l *launch) {
+	conn, closeFn, err := s.launcher.Start(s.ctx, path)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.launches, path)
+	defer close(l.done)
+	if err != nil {
+		l.err = fmt.Errorf("starting plugin %s: %w", path, err)
+		return
+	}
+	now := time.Now()
+	s.processes[path] = &process{
+		PluginStatus: PluginStatus{Path: path, Started: now, LastUsed: now},
+		modTime:      modTime,
+		conn:         conn,
+		close:        closeFn,
+	}
+}
+
+// release ends a call to proc. A plugin whose connection has failed, for
+// example because the process exited, is retired so that the next call
+// starts it again.
+func (s *Server) release(proc *process) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	proc.active--
+	proc.LastUsed = time.Now()
+	if state := proc.conn.GetState(); state == connectivity.TransientFailure || state == connectivity.Shutdown {
+		if s.processes[proc.Path] == proc {
+			s.retire(proc)
+		}
+	}
+	if proc.stale && proc.active == 0 {
+		_ = proc.close()
+	}
+}
+
+// retire removes proc from the pool and closes it once no call is in
+// flight. s.mu must be held.
+func (s *Server) retire(proc *process) {
+	delete(s.processes, proc.Path)
+	proc.stale = true
+	if proc.active == 0 {
+		_ = proc.close()
+	}
+}
+
+// sweep stops the plugins idle for the idle timeout and reports whether the
+// daemon itself has been idle that long.
+func (s *Server) sweep() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for _, proc := range s.processes {
+		if proc.active == 0 && now.Sub(proc.LastUsed) >= s.idleTimeout {
+			logging.FromContext(s.ctx).Debug().
+				Ctx(s.ctx).
+				Str("component", "daemon").
+				Str("plugin_path", proc.Path).
+				Msg("stopping idle plugin")
+			s.retire(proc)
+		}
+	}
+	return len(s.processes) == 0 && now.Sub(s.lastCall) >= s.idleTimeout
+}
+
+// closeAll stops every plugin process.
+func (s *Server) closeAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, proc := range s.processes {
+		s.retire(proc)
+	}
+}
+
+// status describes the daemon and its plugins.
+func (s *Server) status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	st := Status{
+		PID:         os.Getpid(),
+		Version:     s.version,
+		Started:     s.started,
+		IdleTimeout: s.idleTimeout,
+		Plugins:     make([]PluginStatus, 0, len(s.processes)),
+	}
+	for _, proc := range s.processes {
+		st.Plugins = append(st.Plugins, proc.PluginStatus)
+	}
+	sort.Slice(st.Plugins, func(i, j int) bool { return st.Plugins[i].Path < st.Plugins[j].Path })
+	return st
+}
+
+// frame is a gRPC message forwarded without decoding.
+type frame struct {
+	data []byte
+}
+
+// rawCodec passes frames through unchanged. It is named proto so that
+// plugins see the content type of the calls the CLI makes.
+type rawCodec struct{}
+
+// errNotFrame is returned when rawCodec is given a message other than a frame.
+var errNotFrame = errors.New("daemon codec: message is not a frame")
+
+func (rawCodec) Marshal(v any) ([]byte, error) {
+	f, ok := v.(*frame)
+	if !ok {
+		return nil, errNotFrame
+	}
+	return f.data, nil
+}
+
+func (rawCodec) Unmarshal(data []byte, v any) error {
+	f, ok := v.(*frame)
+	if !ok {
+		return errNotFrame
+	}
+	f.data = append([]byte(nil), data...)
+	return nil
+}
+
+func (rawCodec) Name() string {
+	return "proto"
+}
@@ -0,0 +1,273 @@
+package daemon
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// namedPlugin answers Name with its name.
+type namedPlugin struct {
+	pbc.UnimplementedCostSourceServiceServer
+
+	name string
+}
+
+func (p namedPlugin) Name(context.Context, *pbc.NameRequest) (*pbc.NameResponse, error) {
+	return &pbc.NameResponse{Name: p.name}, nil
+}
+
+// streamPages answers the StreamRecommendations server stream, which the
+// generated service does not describe, with three pages of one
+// recommendation each.
+func streamPages(_ any, stream grpc.ServerStream) error {
+	if method, _ := grpc.MethodFromServerStream(stream); method != proto.StreamRecommendationsMethod {
+		return status.Errorf(codes.Unimplemented, "unknown method %s", method)
+	}
+	var req pbc.GetRecommendationsRequest
+	if err := stream.RecvMsg(&req); err != nil {
+		return err
+	}
+	for i := range 3 {
+		page := &pbc.GetRecommendationsResponse{
+			Recommendations: []*pbc.Recommendation{{Id: fmt.Sprintf("rec-%d", i)}},
+		}
+		if i < 2 {
+			page.NextPageToken = fmt.Sprintf("page-%d", i+1)
+		}
+		if err := stream.SendMsg(page); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fakeLauncher serves an in-process plugin named after the binary for every
+// start, and counts the starts. A start waits for gate when it is set.
+type fakeLauncher struct {
+	t      *testing.T
+	starts atomic.Int64
+	gate   chan struct{}
+}
+
+func (l *fakeLauncher) Start(_ context.Context, path string, _ ...string) (*grpc.ClientConn, func() error, error) {
+	l.starts.Add(1)
+	if l.gate != nil {
+		<-l.gate
+	}
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "tcp", "127.0.0.1:0")
+	require.NoError(l.t, err)
+	server := grpc.NewServer(grpc.UnknownServiceHandler(streamPages))
+	pbc.RegisterCostSourceServiceServer(server, namedPlugin{name: filepath.Base(path)})
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(l.t, err)
+	return conn, func() error {
+		server.Stop()
+		return conn.Close()
+	}, nil
+}
+
+// startDaemon serves a daemon on a socket in a temporary directory.
+func startDaemon(t *testing.T, idleTimeout time.Duration) (string, *fakeLauncher, <-chan error) {
+	t.Helper()
+	launcher := &fakeLauncher{t: t}
+	socket, done := serveDaemon(t, launcher, idleTimeout)
+	return socket, launcher, done
+}
+
+// serveDaemon serves a daemon that starts plugins with launcher on a socket
+// in a temporary directory.
+func serveDaemon(t *testing.T, launcher *fakeLauncher, idleTimeout time.Duration) (string, <-chan error) {
+	t.Helper()
+	// Unix socket paths are short, so keep the directory name short too.
+	dir, err := os.MkdirTemp("", "ffd")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(dir) })
+	socket := filepath.Join(dir, SocketFileName)
+	listener, err := (&net.ListenConfig{}).Listen(context.Background(), "unix", socket)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	done := make(chan error, 1)
+	go func() { done <- NewServer(launcher, "1.2.3", idleTimeout).Serve(ctx, listener) }()
+	return socket, done
+}
+
+// writePlugin creates an empty plugin binary named name in dir.
+func writePlugin(t *testing.T, dir, name string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, nil, 0o600))
+	return path
+}
+
+// pluginName asks the plugin at path for its name through the daemon.
+func pluginName(t *testing.T, socket, path string) string {
+	t.Helper()
+	conn, closeFn, err := NewLauncher(socket).Start(context.Background(), path)
+	require.NoError(t, err)
+	defer func() { _ = closeFn() }()
+	resp, err := pbc.NewCostSourceServiceClient(conn).Name(context.Background(), &pbc.NameRequest{})
+	require.NoError(t, err)
+	return resp.GetName()
+}
+
+func TestServer_KeepsPluginsRunningAcrossConnections(t *testing.T) {
+	socket, launcher, _ := startDaemon(t, time.Minute)
+	bin := t.TempDir()
+	aws, azure := writePlugin(t, bin, "aws-public"), writePlugin(t, bin, "azure-public")
+
+	assert.Equal(t, "aws-public", pluginName(t, socket, aws))
+	assert.Equal(t, "aws-public", pluginName(t, socket, aws))
+	assert.Equal(t, "azure-public", pluginName(t, socket, azure))
+	assert.EqualValues(t, 2, launcher.starts.Load(), "each plugin is started once")
+
+	st, err := GetStatus(context.Background(), socket)
+	require.NoError(t, err)
+	assert.Equal(t, "1.2.3", st.Version)
+	assert.Equal(t, os.Getpid(), st.PID)
+	require.Len(t, st.Plugins, 2)
+	assert.Equal(t, aws, st.Plugins[0].Path)
+	assert.EqualValues(t, 2, st.Plugins[0].Calls)
+}
+
+func TestServer_ForwardsStreams(t *testing.T) {
+	socket, _, _ := startDaemon(t, time.Minute)
+	path := writePlugin(t, t.TempDir(), "aws-public")
+	conn, closeFn, err := NewLauncher(socket).Start(context.Background(), path)
+	require.NoError(t, err)
+	defer func() { _ = closeFn() }()
+
+	stream, err := conn.NewStream(context.Background(),
+		&grpc.StreamDesc{ServerStreams: true}, proto.StreamRecommendationsMethod)
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(&pbc.GetRecommendationsRequest{}))
+	require.NoError(t, stream.CloseSend())
+	var ids []string
+	for {
+		var page pbc.GetRecommendationsResponse
+		err = stream.RecvMsg(&page)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		require.NoError(t, err)
+		for _, rec := range page.GetRecommendations() {
+			ids = append(ids, rec.GetId())
+		}
+	}
+	assert.Equal(t, []string{"rec-0", "rec-1", "rec-2"}, ids)
+
+	// Plugin errors reach the caller, so clients can fall back to paging.
+	stream, err = conn.NewStream(context.Background(),
+		&grpc.StreamDesc{ServerStreams: true}, "/finfocus.v1.CostSourceService/StreamNothing")
+	require.NoError(t, err)
+	require.NoError(t, stream.SendMsg(&pbc.GetRecommendationsRequest{}))
+	require.NoError(t, stream.CloseSend())
+	err = stream.RecvMsg(&pbc.GetRecommendationsResponse{})
+	assert.Equal(t, codes.Unimplemented, status.Code(err))
+}
+
+func TestServer_RestartsPluginWhenBinaryChanges(t *testing.T) {
+	socket, launcher, _ := startDaemon(t, time.Minute)
+	path := writePlugin(t, t.TempDir(), "aws-public")
+
+	pluginName(t, socket, path)
+	later := time.Now().Add(time.Hour)
+	require.NoError(t, os.Chtimes(path, later, later))
+	pluginName(t, socket, path)
+	assert.EqualValues(t, 2, launcher.starts.Load())
+}
+
+func TestServer_SlowStartDoesNotBlockStatus(t *testing.T) {
+	launcher := &fakeLauncher{t: t, gate: make(chan struct{})}
+	socket, _ := serveDaemon(t, launcher, time.Minute)
+	path := writePlugin(t, t.TempDir(), "aws-public")
+
+	names := make(chan string, 2)
+	for range 2 {
+		go func() {
+			conn, closeFn, err := NewLauncher(socket).Start(context.Background(), path)
+			if err != nil {
+				names <- err.Error()
+				return
+			}
+			defer func() { _ = closeFn() }()
+			resp, err := pbc.NewCostSourceServiceClient(conn).Name(context.Background(), &pbc.NameRequest{})
+			if err != nil {
+				names <- err.Error()
+				return
+			}
+			names <- resp.GetName()
+		}()
+	}
+	require.Eventually(t, func() bool { return launcher.starts.Load() == 1 }, 5*time.Second, 5*time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+	st, err := GetStatus(ctx, socket)
+	require.NoError(t, err, "status answers while a plugin is starting")
+	assert.Empty(t, st.Plugins)
+
+	close(launcher.gate)
+	for range 2 {
+		select {
+		case name := <-names:
+			assert.Equal(t, "aws-public", name)
+		case <-time.After(5 * time.Second):
+			t.Fatal("call did not finish after the plugin started")
+		}
+	}
+	assert.EqualValues(t, 1, launcher.starts.Load(), "concurrent calls share one start")
+}
+
+func TestServer_StopsWhenAskedOrIdle(t *testing.T) {
+	socket, _, done := startDaemon(t, time.Minute)
+	pluginName(t, socket, writePlugin(t, t.TempDir(), "aws-public"))
+	require.NoError(t, Stop(context.Background(), socket))
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("daemon did not stop")
+	}
+	_, err := GetStatus(context.Background(), socket)
+	require.ErrorIs(t, err, ErrNotRunning)
+
+	_, _, done = startDaemon(t, time.Millisecond)
+	select {
+	case err = <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("idle daemon did not stop")
+	}
+}
+
+func TestServer_RejectsCallsWithoutPlugin(t *testing.T) {
+	socket, _, _ := startDaemon(t, time.Minute)
+	conn, err := dial(socket)
+	require.NoError(t, err)
+	defer conn.Close()
+	_, err = pbc.NewCostSourceServiceClient(conn).Name(context.Background(), &pbc.NameRequest{})
+	require.Error(t, err)
+}
@@ -0,0 +1,146 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Accuracy describes how a cost was derived, from most to least reliable.
+// Unlike Confidence, which rates the data source of actual costs, it applies
+// to every cost result.
+type Accuracy string
+
+const (
+	// AccuracyExact is a cost from billing data, or a fixed price that the
+	// plugin's billing detail names as such.
+	AccuracyExact Accuracy = "exact"
+	// AccuracyEstimated is a cost that a plugin priced from published rates
+	// or usage, or that was estimated from the runtime of a resource.
+	AccuracyEstimated Accuracy = "estimated"
+	// AccuracyHeuristic is a cost from a local pricing spec, a modeled data
+	// transfer line item, a billing detail that names a fallback or default,
+	// or the runtime of an imported resource whose creation time is unknown.
+	AccuracyHeuristic Accuracy = "heuristic"
+	// AccuracyUnknown is a placeholder for a resource that was not priced, or
+	// a result that failed validation or carries a plugin error.
+	AccuracyUnknown Accuracy = "unknown"
+)
+
+// Adapters of results that the engine prices without a plugin.
+const (
+	specAdapter     = "local-spec"
+	estimateAdapter = "estimate"
+)
+
+// ParseAccuracy parses an accuracy level, ignoring case.
+func ParseAccuracy(s string) (Accuracy, error) {
+	switch accuracy := Accuracy(strings.ToLower(strings.TrimSpace(s))); accuracy {
+	case AccuracyExact, AccuracyEstimated, AccuracyHeuristic, AccuracyUnknown:
+		return accuracy, nil
+	default:
+		return "", fmt.Errorf("invalid accuracy %q: use exact, estimated, heuristic, or unknown", s)
+	}
+}
+
+// accuracyLevels returns the accuracy levels from least to most reliable.
+func accuracyLevels() []Accuracy {
+	return []Accuracy{AccuracyUnknown, AccuracyHeuristic, AccuracyEstimated, AccuracyExact}
+}
+
+// rank orders accuracy levels; higher is more reliable. An empty or invalid
+// level ranks as AccuracyUnknown.
+func (a Accuracy) rank() int {
+	return max(slices.Index(accuracyLevels(), a), 0)
+}
+
+// AtLeast reports whether a is at least as reliable as minimum.
+func (a Accuracy) AtLeast(minimum Accuracy) bool {
+	return a.rank() >= minimum.rank()
+}
+
+// DisplayLabel returns the level for table display, or "-" when it is not set.
+func (a Accuracy) DisplayLabel() string {
+	if a == "" {
+		return "-"
+	}
+	return string(a)
+}
+
+// lowerAccuracy returns the less reliable of a and b, so that an aggregate is
+// only as accurate as its least accurate member.
+func lowerAccuracy(a, b Accuracy) Accuracy {
+	if b.rank() < a.rank() {
+		return b
+	}
+	return a
+}
+
+// heuristicBillingDetails are the words in a plugin's billing detail that mark
+// a price as a fallback or an assumption rather than a rate.
+func heuristicBillingDetails() []string {
+	return []string{"heuristic", "fallback", "default", "approx", "assum", "guess"}
+}
+
+// exactBillingDetails are the words in a plugin's billing detail that mark a
+// price as fixed.
+func exactBillingDetails() []string {
+	return []string{"exact", "fixed", "flat"}
+}
+
+// ClassifyAccuracy derives the accuracy of a cost result from its adapter,
+// its errors, its plugin's billing detail (Notes), and, for actual costs, its
+// data source.
+func ClassifyAccuracy(result CostResult) Accuracy {
+	switch {
+	case result.Error != nil, result.Adapter == placeholderAdapter:
+		return AccuracyUnknown
+	case result.Adapter == specAdapter, result.Adapter == TransferAdapter:
+		return AccuracyHeuristic
+	case result.Adapter == estimateAdapter:
+		if result.Confidence == ConfidenceLow {
+			return AccuracyHeuristic
+		}
+		return AccuracyEstimated
+	case !result.StartDate.IsZero():
+		// Actual costs from a plugin come from billing data.
+		return AccuracyExact
+	}
+
+	detail := strings.ToLower(result.Notes)
+	for _, word := range heuristicBillingDetails() {
+		if strings.Contains(detail, word) {
+			return AccuracyHeuristic
+		}
+	}
+	for _, word := range exactBillingDetails() {
+		if strings.Contains(detail, word) {
+			return AccuracyExact
+		}
+	}
+	return AccuracyEstimated
+}
+
+// annotateAccuracy sets the accuracy of results that have none.
+func annotateAccuracy(results []CostResult) {
+	for i := range results {
+		if results[i].Accuracy == "" {
+			results[i].Accuracy = ClassifyAccuracy(results[i])
+		}
+	}
+}
+
+// FilterByAccuracy splits results into those at least as accurate as minimum
+// and the rest, which --min-confidence leaves out of totals.
+func FilterByAccuracy(results []CostResult, minimum Accuracy) ([]CostResult, []CostResult) {
+	kept := make([]CostResult, 0, len(results))
+	var excluded []CostResult
+	for _, result := range results {
+		if result.Accuracy.AtLeast(minimum) {
+			kept = append(kept, result)
+		} else {
+			excluded = append(excluded, result)
+		}
+	}
+	return kept, excluded
+}
@@ -0,0 +1,72 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccuracy(t *testing.T) {
+	got, err := ParseAccuracy(" Heuristic ")
+	require.NoError(t, err)
+	assert.Equal(t, AccuracyHeuristic, got)
+
+	_, err = ParseAccuracy("precise")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use exact, estimated, heuristic, or unknown")
+}
+
+func TestClassifyAccuracy(t *testing.T) {
+	tests := []struct {
+		name   string
+		result CostResult
+		want   Accuracy
+	}{
+		{"plugin rate", CostResult{Adapter: "aws-public", Notes: "On-demand Linux pricing"}, AccuracyEstimated},
+		{"fixed price", CostResult{Adapter: "aws-public", Notes: "Flat monthly fee"}, AccuracyExact},
+		{"fallback price", CostResult{Adapter: "aws-public", Notes: "Default pricing"}, AccuracyHeuristic},
+		{"billing data", CostResult{Adapter: "aws-ce", StartDate: time.Now()}, AccuracyExact},
+		{"local spec", CostResult{Adapter: specAdapter}, AccuracyHeuristic},
+		{"data transfer", CostResult{Adapter: TransferAdapter}, AccuracyHeuristic},
+		{"runtime estimate", CostResult{Adapter: estimateAdapter, Confidence: ConfidenceMedium}, AccuracyEstimated},
+		{"imported estimate", CostResult{Adapter: estimateAdapter, Confidence: ConfidenceLow}, AccuracyHeuristic},
+		{"placeholder", CostResult{Adapter: placeholderAdapter}, AccuracyUnknown},
+		{"plugin error", CostResult{Error: &StructuredError{Code: ErrCodePluginError}}, AccuracyUnknown},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ClassifyAccuracy(tt.result))
+		})
+	}
+}
+
+func TestFilterByAccuracy(t *testing.T) {
+	results := []CostResult{
+		{ResourceID: "web", Accuracy: AccuracyExact},
+		{ResourceID: "db", Accuracy: AccuracyEstimated},
+		{ResourceID: "spec", Accuracy: AccuracyHeuristic},
+		{ResourceID: "broken", Accuracy: AccuracyUnknown},
+	}
+
+	kept, excluded := FilterByAccuracy(results, AccuracyEstimated)
+	require.Len(t, kept, 2)
+	assert.Equal(t, "web", kept[0].ResourceID)
+	assert.Equal(t, "db", kept[1].ResourceID)
+	require.Len(t, excluded, 2)
+	assert.Equal(t, "spec", excluded[0].ResourceID)
+
+	kept, excluded = FilterByAccuracy(results, AccuracyUnknown)
+	assert.Len(t, kept, 4)
+	assert.Empty(t, excluded)
+}
+
+func TestAggregateResultsInternal_LowestAccuracy(t *testing.T) {
+	aggregated := AggregateResultsInternal([]CostResult{
+		{ResourceID: "a", Monthly: 10, Accuracy: AccuracyExact},
+		{ResourceID: "b", Monthly: 5, Accuracy: AccuracyHeuristic},
+		{ResourceID: "c", Monthly: 1, Accuracy: AccuracyEstimated},
+	}, "group")
+	assert.Equal(t, AccuracyHeuristic, aggregated.Accuracy)
+}
@@ -1,6 +1,8 @@
 package engine
 
 import (
+	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -470,6 +472,28 @@ func TestAggregateResults_ByAdapter(t *testing.T) {
 	assert.Equal(t, 20.0, aggregated.Summary.ByAdapter["local-spec"])
 }
 
+// TestAggregateResults_ByStack tests per-stack subtotals for aggregated plans.
+func TestAggregateResults_ByStack(t *testing.T) {
+	results := []CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", Stack: "dev", Monthly: 10.0, Currency: "USD"},
+		{ResourceType: "aws:ec2/instance:Instance", Stack: "prod", Monthly: 30.0, Currency: "USD"},
+		{ResourceType: "aws:s3/bucket:Bucket", Stack: "prod", Monthly: 5.0, Currency: "USD"},
+	}
+
+	aggregated := AggregateResults(results)
+
+	assert.InDelta(t, 10.0, aggregated.Summary.ByStack["dev"], 0.001)
+	assert.InDelta(t, 35.0, aggregated.Summary.ByStack["prod"], 0.001)
+
+	var buf strings.Builder
+	require.NoError(t, renderTable(context.Background(), &buf, aggregated))
+	assert.Contains(t, buf.String(), "BY STACK")
+	assert.Contains(t, buf.String(), "Stack  Resource")
+
+	single := AggregateResults([]CostResult{{ResourceType: "aws:s3/bucket:Bucket", Monthly: 5.0}})
+	assert.Nil(t, single.Summary.ByStack, "single-plan results carry no stack")
+}
+
 func TestAggregation_ZeroCostsNoDivideByZero(t *testing.T) {
 	results := []CostResult{
 		{
@@ -0,0 +1,327 @@
+package engine
+
+import (
+	"cmp"
+	"sort"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// UnallocatedTeam is the team reported for costs no rule, team tag, or
+// shared pool attributes to a team.
+const UnallocatedTeam = "(unallocated)"
+
+// AllocationItem is one resource's cost to allocate.
+type AllocationItem struct {
+	ResourceID   string
+	ResourceType string
+	Tags         map[string]string
+	Cost         float64
+	Currency     string
+}
+
+// AllocationLine is the part of one resource's cost attributed to one team.
+// A resource in a shared pool produces one line per team receiving a share.
+type AllocationLine struct {
+	Team         string  `json:"team"`
+	ResourceID   string  `json:"resourceId"`
+	ResourceType string  `json:"resourceType"`
+	Amount       float64 `json:"amount"`
+	// Pool names the shared pool the amount was distributed from, if any.
+	Pool string `json:"pool,omitempty"`
+	// Workload is the ID of the workload whose share of a shared resource the
+	// amount is, with AllocateCostsByDependency.
+	Workload string `json:"workload,omitempty"`
+}
+
+// TeamAllocation is the cost attributed to one team.
+type TeamAllocation struct {
+	Team string `json:"team"`
+	// Direct is the cost of resources the team owns.
+	Direct float64 `json:"direct"`
+	// Shared is the team's share of shared pools.
+	Shared float64 `json:"shared"`
+	// Dependencies is the team's share of the shared resources its workloads
+	// depend on, with AllocateCostsByDependency.
+	Dependencies float64 `json:"dependencies,omitempty"`
+	Total        float64 `json:"total"`
+	// Resources is the number of resources the team owns directly.
+	Resources int `json:"resources"`
+}
+
+// SharedPoolAllocation is the cost of one shared pool and how it was distributed.
+type SharedPoolAllocation struct {
+	Name      string             `json:"name"`
+	Strategy  string             `json:"strategy"`
+	Total     float64            `json:"total"`
+	Resources int                `json:"resources"`
+	Shares    map[string]float64 `json:"shares"`
+}
+
+// AllocationReport attributes costs to teams.
+type AllocationReport struct {
+	Currency string  `json:"currency"`
+	TagKey   string  `json:"tagKey"`
+	Total    float64 `json:"total"`
+	// MixedCurrencies is true when the costs use more than one currency;
+	// totals are then sums of unconverted amounts.
+	MixedCurrencies bool `json:"mixedCurrencies,omitempty"`
+
+	// Unallocated is the cost attributed to no team, also reported as the
+	// UnallocatedTeam entry of Teams. UnallocatedResources counts the
+	// resources owned by no team.
+	Unallocated          float64 `json:"unallocated"`
+	UnallocatedResources int     `json:"unallocatedResources"`
+
+	Teams []TeamAllocation       `json:"teams"`
+	Pools []SharedPoolAllocation `json:"pools,omitempty"`
+	// Workloads is the effective cost of each workload, with
+	// AllocateCostsByDependency.
+	Workloads []WorkloadCost   `json:"workloads,omitempty"`
+	Lines     []AllocationLine `json:"lines"`
+}
+
+// allocationRule is a parsed config.AllocationRule.
+type allocationRule struct {
+	selector *config.ParsedTagSelector
+	team     string
+}
+
+// allocationPool accumulates the resources of one shared pool.
+type allocationPool struct {
+	cfg      config.SharedCostPool
+	selector *config.ParsedTagSelector
+	items    []AllocationItem
+	total    float64
+}
+
+// AllocateCosts attributes items to teams according to cfg, which may be nil.
+//
+// Each item goes to the first shared pool whose selector matches its tags;
+// otherwise to the team of the first matching rule; otherwise to the team
+// named by its cfg.TagKey tag (DefaultOrgTeamTag when unset); otherwise to
+// UnallocatedTeam. Shared pools are then distributed between teams:
+//   - even splits a pool equally between its teams;
+//   - proportional splits it by each team's direct spend, falling back to
+//     even when the teams have no direct spend;
+//   - fixed splits it by configured percentages.
+//
+// Pools without explicit teams are distributed between every team with
+// direct spend, excluding UnallocatedTeam; a pool with no teams to receive
+// it is reported as unallocated. Selectors are expected to be valid (see
+// config.AllocationConfig.Validate); invalid ones never match.
+func AllocateCosts(items []AllocationItem, cfg *config.AllocationConfig) *AllocationReport {
+	if cfg == nil {
+		cfg = &config.AllocationConfig{}
+	}
+	a := newAllocator(cfg)
+	for _, item := range items {
+		a.assign(item)
+	}
+	for _, pool := range a.pools {
+		a.distribute(pool)
+	}
+	return a.finish()
+}
+
+// allocator accumulates an AllocationReport.
+type allocator struct {
+	report      *AllocationReport
+	rules       []allocationRule
+	pools       []*allocationPool
+	teams       map[string]*TeamAllocation
+	currencySet bool
+}
+
+// newAllocator parses the rules and pools of cfg.
+func newAllocator(cfg *config.AllocationConfig) *allocator {
+	a := &allocator{
+		report: &AllocationReport{
+			Currency: defaultCurrency,
+			TagKey:   cmp.Or(cfg.TagKey, DefaultOrgTeamTag),
+		},
+		teams: make(map[string]*TeamAllocation),
+	}
+	for _, r := range cfg.Rules {
+		if sel, err := config.ParseTagSelector(r.Selector); err == nil {
+			a.rules = append(a.rules, allocationRule{selector: sel, team: r.Team})
+		}
+	}
+	for _, p := range cfg.Shared {
+		if sel, err := config.ParseTagSelector(p.Selector); err == nil {
+			a.pools = append(a.pools, &allocationPool{cfg: p, selector: sel})
+		}
+	}
+	return a
+}
+
+// team returns the allocation of the named team, creating it on first use.
+func (a *allocator) team(name string) *TeamAllocation {
+	t, ok := a.teams[name]
+	if !ok {
+		t = &TeamAllocation{Team: name}
+		a.teams[name] = t
+	}
+	return t
+}
+
+// count adds item to the currency and total of the report.
+func (a *allocator) count(item AllocationItem) {
+	if item.Currency != "" {
+		if !a.currencySet {
+			a.report.Currency = item.Currency
+			a.currencySet = true
+		} else if item.Currency != a.report.Currency {
+			a.report.MixedCurrencies = true
+		}
+	}
+	a.report.Total += item.Cost
+}
+
+// assign adds item to its shared pool or to its owning team.
+func (a *allocator) assign(item AllocationItem) {
+	a.count(item)
+	if pool := matchPool(a.pools, item.Tags); pool != nil {
+		pool.items = append(pool.items, item)
+		pool.total += item.Cost
+		return
+	}
+	owner := ownerTeam(a.rules, a.report.TagKey, item.Tags)
+	t := a.team(owner)
+	t.Direct += item.Cost
+	t.Resources++
+	a.report.Lines = append(a.report.Lines, AllocationLine{
+		Team: owner, ResourceID: item.ResourceID, ResourceType: item.ResourceType, Amount: item.Cost,
+	})
+}
+
+// distribute splits pool between teams. It must run after every item is
+// assigned, since proportional shares depend on direct spend.
+func (a *allocator) distribute(pool *allocationPool) {
+	if len(pool.items) == 0 {
+		return
+	}
+	direct := make(map[string]float64, len(a.teams))
+	for name, t := range a.teams {
+		if name != UnallocatedTeam && t.Direct > 0 {
+			direct[name] = t.Direct
+		}
+	}
+	shares := poolShares(pool.cfg, direct)
+	if len(shares) == 0 {
+		shares = map[string]float64{UnallocatedTeam: 1}
+	}
+
+	summary := SharedPoolAllocation{
+		Name: pool.cfg.Name, Strategy: pool.cfg.DistributionStrategy(),
+		Total: pool.total, Resources: len(pool.items), Shares: make(map[string]float64, len(shares)),
+	}
+	for _, name := range sortedKeys(shares) {
+		share := shares[name]
+		a.team(name).Shared += pool.total * share
+		summary.Shares[name] = pool.total * share
+		for _, item := range pool.items {
+			a.report.Lines = append(a.report.Lines, AllocationLine{
+				Team: name, ResourceID: item.ResourceID, ResourceType: item.ResourceType,
+				Amount: item.Cost * share, Pool: pool.cfg.Name,
+			})
+		}
+	}
+	a.report.Pools = append(a.report.Pools, summary)
+}
+
+// finish totals the teams and the unallocated bucket and orders the report:
+// teams by total, largest first, and lines by team.
+func (a *allocator) finish() *AllocationReport {
+	report := a.report
+	for _, t := range a.teams {
+		t.Total = t.Direct + t.Shared + t.Dependencies
+		report.Teams = append(report.Teams, *t)
+	}
+	if t, ok := a.teams[UnallocatedTeam]; ok {
+		report.Unallocated, report.UnallocatedResources = t.Total, t.Resources
+	}
+	sort.Slice(report.Teams, func(i, j int) bool {
+		if report.Teams[i].Total != report.Teams[j].Total {
+			return report.Teams[i].Total > report.Teams[j].Total
+		}
+		return report.Teams[i].Team < report.Teams[j].Team
+	})
+	sort.SliceStable(report.Lines, func(i, j int) bool {
+		return report.Lines[i].Team < report.Lines[j].Team
+	})
+	return report
+}
+
+// matchPool returns the first pool whose selector matches tags.
+func matchPool(pools []*allocationPool, tags map[string]string) *allocationPool {
+	for _, p := range pools {
+		if p.selector.Matches(tags) {
+			return p
+		}
+	}
+	return nil
+}
+
+// ownerTeam returns the team owning a resource with tags.
+func ownerTeam(rules []allocationRule, tagKey string, tags map[string]string) string {
+	for _, r := range rules {
+		if r.selector.Matches(tags) {
+			return r.team
+		}
+	}
+	if owner := tags[tagKey]; owner != "" {
+		return owner
+	}
+	return UnallocatedTeam
+}
+
+// poolShares returns the fraction of a pool each team receives. direct holds
+// the direct spend of every team that owns resources.
+func poolShares(pool config.SharedCostPool, direct map[string]float64) map[string]float64 {
+	if pool.DistributionStrategy() == config.AllocationStrategyFixed {
+		shares := make(map[string]float64, len(pool.Percentages))
+		for name, pct := range pool.Percentages {
+			shares[name] = pct / driftPercentMultiplier
+		}
+		return shares
+	}
+
+	recipients := pool.Teams
+	if len(recipients) == 0 {
+		recipients = sortedKeys(direct)
+	}
+	if len(recipients) == 0 {
+		return nil
+	}
+
+	shares := make(map[string]float64, len(recipients))
+	if pool.DistributionStrategy() == config.AllocationStrategyProportional {
+		sum := 0.0
+		for _, name := range recipients {
+			sum += direct[name]
+		}
+		if sum > 0 {
+			for _, name := range recipients {
+				if direct[name] > 0 {
+					shares[name] = direct[name] / sum
+				}
+			}
+			return shares
+		}
+	}
+	for _, name := range recipients {
+		shares[name] = 1 / float64(len(recipients))
+	}
+	return shares
+}
+
+// sortedKeys returns the keys of m in ascending order.
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,187 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// AttributionDependencies is the allocation mode that attributes the costs of
+// shared resources, such as a NAT gateway, to the workloads that depend on
+// them in the Pulumi dependency graph.
+const AttributionDependencies = "dependencies"
+
+// ParseAttribution parses an --attribution value; empty means costs are
+// allocated to the owners of their resources only.
+func ParseAttribution(s string) (string, error) {
+	switch attribution := strings.ToLower(strings.TrimSpace(s)); attribution {
+	case "", AttributionDependencies:
+		return attribution, nil
+	default:
+		return "", fmt.Errorf("invalid attribution %q: use dependencies", s)
+	}
+}
+
+// WorkloadCost is the effective cost of a workload: an allocated resource
+// that no other allocated resource depends on, with its share of the shared
+// resources it depends on.
+type WorkloadCost struct {
+	ResourceID   string `json:"resourceId"`
+	ResourceType string `json:"resourceType"`
+	Team         string `json:"team"`
+	// Direct is the cost of the workload itself.
+	Direct float64 `json:"direct"`
+	// Attributed is the workload's share of the shared resources it depends on.
+	Attributed float64 `json:"attributed"`
+	Effective  float64 `json:"effective"`
+	// SharedResources counts the shared resources the workload has a share of.
+	SharedResources int `json:"sharedResources"`
+}
+
+// ResourceDependencies returns the dependencies of each resource that has
+// any, keyed by resource ID.
+func ResourceDependencies(resources []ResourceDescriptor) map[string][]string {
+	dependencies := make(map[string][]string)
+	for _, resource := range resources {
+		if len(resource.Dependencies) > 0 {
+			dependencies[resource.ID] = resource.Dependencies
+		}
+	}
+	return dependencies
+}
+
+// AllocateCostsByDependency attributes items to teams like AllocateCosts,
+// except for shared resources: an item that another item depends on, directly
+// or through resources in dependencies, which lists the dependencies of each
+// resource by ID. The cost of a shared resource is attributed to the
+// workloads that depend on it, the items nothing depends on, in proportion to
+// their own cost (evenly when they have none), and goes to the teams owning
+// them. Items in shared pools are distributed by their pools and are neither
+// shared resources nor workloads. A shared resource without workloads, such as
+// one in a dependency cycle, goes to its own team.
+//
+// The report lists the effective cost of every workload.
+func AllocateCostsByDependency(
+	items []AllocationItem,
+	cfg *config.AllocationConfig,
+	dependencies map[string][]string,
+) *AllocationReport {
+	if cfg == nil {
+		cfg = &config.AllocationConfig{}
+	}
+	a := newAllocator(cfg)
+
+	candidates := make(map[string]AllocationItem, len(items))
+	for _, item := range items {
+		if matchPool(a.pools, item.Tags) == nil {
+			candidates[item.ResourceID] = item
+		}
+	}
+	dependents := make(map[string][]string)
+	for id, deps := range dependencies {
+		for _, dep := range deps {
+			dependents[dep] = append(dependents[dep], id)
+		}
+	}
+	reached := make(map[string][]string, len(candidates))
+	for id := range candidates {
+		if ids := dependentItems(id, dependents, candidates); len(ids) > 0 {
+			reached[id] = ids
+		}
+	}
+
+	workloads := make(map[string]*WorkloadCost)
+	for id, item := range candidates {
+		if _, shared := reached[id]; !shared {
+			workloads[id] = &WorkloadCost{
+				ResourceID: id, ResourceType: item.ResourceType,
+				Team: ownerTeam(a.rules, a.report.TagKey, item.Tags), Direct: item.Cost,
+			}
+		}
+	}
+
+	for _, item := range items {
+		var recipients []AllocationItem
+		for _, id := range reached[item.ResourceID] {
+			if _, ok := workloads[id]; ok {
+				recipients = append(recipients, candidates[id])
+			}
+		}
+		if len(recipients) == 0 {
+			a.assign(item)
+			continue
+		}
+		a.attribute(item, recipients, workloads)
+	}
+	for _, pool := range a.pools {
+		a.distribute(pool)
+	}
+
+	report := a.finish()
+	for _, w := range workloads {
+		w.Effective = w.Direct + w.Attributed
+		report.Workloads = append(report.Workloads, *w)
+	}
+	sort.Slice(report.Workloads, func(i, j int) bool {
+		if report.Workloads[i].Effective != report.Workloads[j].Effective {
+			return report.Workloads[i].Effective > report.Workloads[j].Effective
+		}
+		return report.Workloads[i].ResourceID < report.Workloads[j].ResourceID
+	})
+	return report
+}
+
+// attribute splits the cost of a shared item between the workloads that
+// depend on it, in proportion to their cost, and adds the shares to their
+// teams and to workloads. Zero shares are left out.
+func (a *allocator) attribute(item AllocationItem, recipients []AllocationItem, workloads map[string]*WorkloadCost) {
+	a.count(item)
+	sum := 0.0
+	for _, w := range recipients {
+		sum += w.Cost
+	}
+	for _, w := range recipients {
+		share := 1 / float64(len(recipients))
+		if sum > 0 {
+			share = w.Cost / sum
+		}
+		amount := item.Cost * share
+		if amount == 0 {
+			continue
+		}
+		workload := workloads[w.ResourceID]
+		a.team(workload.Team).Dependencies += amount
+		workload.Attributed += amount
+		workload.SharedResources++
+		a.report.Lines = append(a.report.Lines, AllocationLine{
+			Team: workload.Team, ResourceID: item.ResourceID, ResourceType: item.ResourceType,
+			Amount: amount, Workload: w.ResourceID,
+		})
+	}
+}
+
+// dependentItems returns the IDs of the items that depend on the resource id,
+// directly or through other resources, in ascending order.
+func dependentItems(id string, dependents map[string][]string, items map[string]AllocationItem) []string {
+	visited := map[string]bool{id: true}
+	queue := []string{id}
+	var found []string
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+		for _, dependent := range dependents[current] {
+			if visited[dependent] {
+				continue
+			}
+			visited[dependent] = true
+			queue = append(queue, dependent)
+			if _, ok := items[dependent]; ok {
+				found = append(found, dependent)
+			}
+		}
+	}
+	sort.Strings(found)
+	return found
+}
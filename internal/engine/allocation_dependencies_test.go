@@ -0,0 +1,105 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// dependencyGraph has api and web reach the NAT gateway through a route table
+// that is not allocated, and the subnet directly.
+func dependencyGraph() map[string][]string {
+	return map[string][]string{
+		"api":    {"subnet"},
+		"web":    {"subnet"},
+		"subnet": {"routes"},
+		"routes": {"nat"},
+	}
+}
+
+func TestAllocateCostsByDependency_Proportional(t *testing.T) {
+	items := allocationItems()
+	items = append(items, AllocationItem{ResourceID: "subnet", ResourceType: "aws:ec2/subnet:Subnet", Currency: "USD"})
+	report := AllocateCostsByDependency(items, &config.AllocationConfig{}, dependencyGraph())
+
+	teams := teamTotals(report)
+	assert.InDelta(t, 30, teams["payments"].Dependencies, 1e-9, "3/4 of the NAT gateway follows api")
+	assert.InDelta(t, 330, teams["payments"].Total, 1e-9)
+	assert.InDelta(t, 10, teams["frontend"].Dependencies, 1e-9)
+	assert.InDelta(t, 60, teams[UnallocatedTeam].Total, 1e-9, "the NAT gateway no longer counts as unallocated")
+	assert.InDelta(t, 500, report.Total, 1e-9)
+
+	require.Len(t, report.Workloads, 4, "the NAT gateway and subnet are shared")
+	api := report.Workloads[0]
+	assert.Equal(t, "api", api.ResourceID)
+	assert.Equal(t, "payments", api.Team)
+	assert.InDelta(t, 300, api.Direct, 1e-9)
+	assert.InDelta(t, 30, api.Attributed, 1e-9)
+	assert.InDelta(t, 330, api.Effective, 1e-9)
+	assert.Equal(t, 1, api.SharedResources, "the subnet has no cost to attribute")
+
+	var natLines []AllocationLine
+	for _, line := range report.Lines {
+		if line.ResourceID == "nat" {
+			natLines = append(natLines, line)
+		}
+	}
+	require.Len(t, natLines, 2)
+	assert.Equal(t, "frontend", natLines[0].Team)
+	assert.Equal(t, "web", natLines[0].Workload)
+}
+
+func TestAllocateCostsByDependency_PoolsWin(t *testing.T) {
+	report := AllocateCostsByDependency(allocationItems(), &config.AllocationConfig{
+		Shared: []config.SharedCostPool{{Name: "network", Selector: "shared:network"}},
+	}, map[string][]string{"api": {"nat"}})
+
+	teams := teamTotals(report)
+	assert.InDelta(t, 0, teams["payments"].Dependencies, 1e-9)
+	assert.InDelta(t, 20, teams["payments"].Shared, 1e-9, "the pool splits the NAT gateway")
+	require.Len(t, report.Pools, 1)
+}
+
+func TestAllocateCostsByDependency_EvenAndCycles(t *testing.T) {
+	items := []AllocationItem{
+		{ResourceID: "lb", Cost: 20, Tags: map[string]string{"team": "platform"}},
+		{ResourceID: "a", Tags: map[string]string{"team": "red"}},
+		{ResourceID: "b", Tags: map[string]string{"team": "blue"}},
+		{ResourceID: "x", Cost: 5, Tags: map[string]string{"team": "red"}},
+		{ResourceID: "y", Cost: 5, Tags: map[string]string{"team": "blue"}},
+	}
+	report := AllocateCostsByDependency(items, nil, map[string][]string{
+		"a": {"lb"}, "b": {"lb"}, "x": {"y"}, "y": {"x"},
+	})
+
+	teams := teamTotals(report)
+	assert.InDelta(t, 10, teams["red"].Dependencies, 1e-9, "workloads without cost split evenly")
+	assert.InDelta(t, 10, teams["blue"].Dependencies, 1e-9)
+	assert.InDelta(t, 5, teams["red"].Direct, 1e-9, "resources in a cycle keep their owners")
+	_, ok := teams["platform"]
+	assert.False(t, ok)
+}
+
+func TestResourceDependencies(t *testing.T) {
+	deps := ResourceDependencies([]ResourceDescriptor{
+		{ID: "api", Dependencies: []string{"subnet"}},
+		{ID: "subnet"},
+	})
+	assert.Equal(t, map[string][]string{"api": {"subnet"}}, deps)
+}
+
+func TestParseAttribution(t *testing.T) {
+	got, err := ParseAttribution(" Dependencies ")
+	require.NoError(t, err)
+	assert.Equal(t, AttributionDependencies, got)
+
+	got, err = ParseAttribution("")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	_, err = ParseAttribution("tags")
+	require.ErrorContains(t, err, `invalid attribution "tags"`)
+}
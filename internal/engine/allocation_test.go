@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func allocationItems() []AllocationItem {
+	return []AllocationItem{
+		{ResourceID: "api", ResourceType: "aws:ec2/instance:Instance", Cost: 300, Currency: "USD",
+			Tags: map[string]string{"team": "payments"}},
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Cost: 100, Currency: "USD",
+			Tags: map[string]string{"team": "frontend"}},
+		{ResourceID: "ml", ResourceType: "aws:sagemaker/endpoint:Endpoint", Cost: 50, Currency: "USD",
+			Tags: map[string]string{"project": "recs"}},
+		{ResourceID: "nat", ResourceType: "aws:ec2/natGateway:NatGateway", Cost: 40, Currency: "USD",
+			Tags: map[string]string{"shared": "network"}},
+		{ResourceID: "orphan", ResourceType: "aws:s3/bucket:Bucket", Cost: 10, Currency: "USD"},
+	}
+}
+
+func teamTotals(report *AllocationReport) map[string]TeamAllocation {
+	out := make(map[string]TeamAllocation, len(report.Teams))
+	for _, t := range report.Teams {
+		out[t.Team] = t
+	}
+	return out
+}
+
+func TestAllocateCosts_TagsAndRules(t *testing.T) {
+	report := AllocateCosts(allocationItems(), &config.AllocationConfig{
+		Rules: []config.AllocationRule{{Selector: "project:recs", Team: "data"}},
+	})
+
+	teams := teamTotals(report)
+	assert.InDelta(t, 300, teams["payments"].Total, 1e-9)
+	assert.InDelta(t, 50, teams["data"].Total, 1e-9)
+	assert.InDelta(t, 50, teams[UnallocatedTeam].Direct, 1e-9, "untagged resources are unallocated")
+	assert.InDelta(t, 500, report.Total, 1e-9)
+	assert.Equal(t, "payments", report.Teams[0].Team, "teams are ordered by total")
+	assert.Equal(t, "team", report.TagKey)
+	assert.InDelta(t, 50, report.Unallocated, 1e-9)
+	assert.Equal(t, 2, report.UnallocatedResources)
+}
+
+func TestAllocateCosts_SharedStrategies(t *testing.T) {
+	tests := []struct {
+		name string
+		pool config.SharedCostPool
+		want map[string]float64
+	}{
+		{
+			name: "even between teams with direct spend",
+			pool: config.SharedCostPool{Strategy: config.AllocationStrategyEven},
+			want: map[string]float64{"payments": 20, "frontend": 20},
+		},
+		{
+			name: "proportional to direct spend",
+			pool: config.SharedCostPool{Strategy: config.AllocationStrategyProportional},
+			want: map[string]float64{"payments": 30, "frontend": 10},
+		},
+		{
+			name: "fixed percentages",
+			pool: config.SharedCostPool{
+				Strategy:    config.AllocationStrategyFixed,
+				Percentages: map[string]float64{"payments": 25, "platform": 75},
+			},
+			want: map[string]float64{"payments": 10, "platform": 30},
+		},
+		{
+			name: "even between listed teams",
+			pool: config.SharedCostPool{Teams: []string{"payments", "frontend", "data"}},
+			want: map[string]float64{"payments": 40.0 / 3, "frontend": 40.0 / 3, "data": 40.0 / 3},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.pool.Name, tc.pool.Selector = "network", "shared:network"
+			items := allocationItems()[:2]
+			items = append(items, allocationItems()[3])
+
+			report := AllocateCosts(items, &config.AllocationConfig{Shared: []config.SharedCostPool{tc.pool}})
+
+			require.Len(t, report.Pools, 1)
+			assert.InDelta(t, 40, report.Pools[0].Total, 1e-9)
+			teams := teamTotals(report)
+			for team, want := range tc.want {
+				assert.InDelta(t, want, teams[team].Shared, 1e-9, team)
+				assert.InDelta(t, want, report.Pools[0].Shares[team], 1e-9, team)
+			}
+
+			sum := 0.0
+			for _, line := range report.Lines {
+				sum += line.Amount
+			}
+			assert.InDelta(t, report.Total, sum, 1e-9, "lines add up to the total")
+		})
+	}
+}
+
+func TestAllocateCosts_SharedWithoutRecipientsIsUnallocated(t *testing.T) {
+	report := AllocateCosts(allocationItems()[3:4], &config.AllocationConfig{
+		Shared: []config.SharedCostPool{{Name: "network", Selector: "shared:*"}},
+	})
+
+	teams := teamTotals(report)
+	assert.InDelta(t, 40, teams[UnallocatedTeam].Shared, 1e-9)
+}
+
+func TestAllocateCosts_MixedCurrencies(t *testing.T) {
+	items := allocationItems()
+	items[1].Currency = "EUR"
+	report := AllocateCosts(items, nil)
+	assert.True(t, report.MixedCurrencies)
+	assert.Equal(t, "USD", report.Currency)
+}
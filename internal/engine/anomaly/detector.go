@@ -0,0 +1,344 @@
+package anomaly
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Detection errors.
+var (
+	// ErrInvalidMethod is returned when an unknown detection method is requested.
+	ErrInvalidMethod = errors.New("invalid anomaly detection method")
+
+	// ErrInvalidSensitivity is returned when an unknown sensitivity level is requested.
+	ErrInvalidSensitivity = errors.New("invalid anomaly sensitivity")
+
+	// ErrInvalidThreshold is returned when an explicit threshold is not positive.
+	ErrInvalidThreshold = errors.New("anomaly threshold must be greater than 0")
+)
+
+// Method identifies the statistical technique used to detect anomalies.
+type Method string
+
+const (
+	// MethodZScore flags points more than Threshold standard deviations from the mean.
+	MethodZScore Method = "zscore"
+	// MethodIQR flags points outside Q1 - Threshold*IQR and Q3 + Threshold*IQR.
+	MethodIQR Method = "iqr"
+)
+
+// Sensitivity is a named preset for the detection threshold.
+// Higher sensitivity uses a lower threshold and reports more anomalies.
+type Sensitivity string
+
+const (
+	// SensitivityLow reports only extreme deviations.
+	SensitivityLow Sensitivity = "low"
+	// SensitivityMedium is the default balance between noise and recall.
+	SensitivityMedium Sensitivity = "medium"
+	// SensitivityHigh reports moderate deviations.
+	SensitivityHigh Sensitivity = "high"
+)
+
+// Direction describes whether an anomalous value is above or below the baseline.
+type Direction string
+
+const (
+	// DirectionSpike indicates spend above the baseline.
+	DirectionSpike Direction = "spike"
+	// DirectionDrop indicates spend below the baseline.
+	DirectionDrop Direction = "drop"
+)
+
+// DefaultMinPoints is the minimum number of points a series needs before
+// detection is attempted. Shorter series are skipped because their statistics
+// are too unstable to be meaningful.
+const DefaultMinPoints = 7
+
+// Quantiles used by the IQR method.
+const (
+	quartileLower  = 0.25
+	quartileMedian = 0.5
+	quartileUpper  = 0.75
+)
+
+// thresholds maps each method and sensitivity to its default threshold.
+//
+//nolint:gochecknoglobals // Lookup table for sensitivity presets.
+var thresholds = map[Method]map[Sensitivity]float64{
+	MethodZScore: {
+		SensitivityLow:    3.0,
+		SensitivityMedium: 2.5,
+		SensitivityHigh:   2.0,
+	},
+	MethodIQR: {
+		SensitivityLow:    3.0,
+		SensitivityMedium: 2.0,
+		SensitivityHigh:   1.5,
+	},
+}
+
+// Point is a single daily observation in a cost series.
+type Point struct {
+	Date  time.Time `json:"date"`
+	Value float64   `json:"value"`
+}
+
+// Series is a daily cost series for one key (resource, type, or provider).
+type Series struct {
+	Key      string  `json:"key"`
+	Currency string  `json:"currency,omitempty"`
+	Points   []Point `json:"points"`
+}
+
+// Anomaly describes a single day whose spend deviates from its series.
+type Anomaly struct {
+	// Key identifies the series (resource ID, resource type, or provider).
+	Key string `json:"key"`
+	// Date is the day of the anomalous observation.
+	Date time.Time `json:"date"`
+	// Value is the observed spend on that day.
+	Value float64 `json:"value"`
+	// Expected is the series baseline (mean for z-score, median for IQR).
+	Expected float64 `json:"expected"`
+	// Score is the magnitude of the deviation in threshold units
+	// (standard deviations for z-score, IQR multiples from the nearest quartile for IQR).
+	Score float64 `json:"score"`
+	// Direction reports whether the value is a spike or a drop.
+	Direction Direction `json:"direction"`
+	// Currency is the series currency.
+	Currency string `json:"currency,omitempty"`
+}
+
+// Detector finds anomalies in daily cost series.
+type Detector struct {
+	method    Method
+	threshold float64
+	minPoints int
+}
+
+// ValidMethods returns the supported detection method names.
+func ValidMethods() []string {
+	return []string{string(MethodZScore), string(MethodIQR)}
+}
+
+// ValidSensitivities returns the supported sensitivity names in increasing order.
+func ValidSensitivities() []string {
+	return []string{string(SensitivityLow), string(SensitivityMedium), string(SensitivityHigh)}
+}
+
+// NewDetector creates a Detector for the given method and sensitivity.
+// Method and sensitivity names are case-insensitive; empty values default to
+// z-score and medium sensitivity.
+func NewDetector(method Method, sensitivity Sensitivity) (*Detector, error) {
+	if method == "" {
+		method = MethodZScore
+	}
+	if sensitivity == "" {
+		sensitivity = SensitivityMedium
+	}
+	method = Method(strings.ToLower(strings.TrimSpace(string(method))))
+	sensitivity = Sensitivity(strings.ToLower(strings.TrimSpace(string(sensitivity))))
+
+	presets, ok := thresholds[method]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (valid: %s)",
+			ErrInvalidMethod, method, strings.Join(ValidMethods(), ", "))
+	}
+	threshold, ok := presets[sensitivity]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q (valid: %s)",
+			ErrInvalidSensitivity, sensitivity, strings.Join(ValidSensitivities(), ", "))
+	}
+
+	return &Detector{
+		method:    method,
+		threshold: threshold,
+		minPoints: DefaultMinPoints,
+	}, nil
+}
+
+// WithThreshold overrides the sensitivity preset with an explicit threshold.
+func (d *Detector) WithThreshold(threshold float64) (*Detector, error) {
+	if threshold <= 0 || math.IsNaN(threshold) || math.IsInf(threshold, 0) {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidThreshold, threshold)
+	}
+	d.threshold = threshold
+	return d, nil
+}
+
+// WithMinPoints overrides the minimum series length required for detection.
+// Values below 3 are raised to 3, the smallest length with meaningful spread.
+func (d *Detector) WithMinPoints(minPoints int) *Detector {
+	const smallestUsefulSeries = 3
+	d.minPoints = max(minPoints, smallestUsefulSeries)
+	return d
+}
+
+// Method returns the detection method in use.
+func (d *Detector) Method() Method {
+	return d.method
+}
+
+// Threshold returns the effective detection threshold.
+func (d *Detector) Threshold() float64 {
+	return d.threshold
+}
+
+// Detect returns the anomalies in a single series in chronological order.
+// Series shorter than the minimum point count yield no anomalies.
+func (d *Detector) Detect(series Series) []Anomaly {
+	if len(series.Points) < d.minPoints {
+		return nil
+	}
+
+	values := make([]float64, len(series.Points))
+	for i, p := range series.Points {
+		values[i] = p.Value
+	}
+
+	var anomalies []Anomaly
+	switch d.method {
+	case MethodIQR:
+		anomalies = d.detectIQR(series, values)
+	default:
+		anomalies = d.detectZScore(series, values)
+	}
+
+	sort.SliceStable(anomalies, func(i, j int) bool {
+		return anomalies[i].Date.Before(anomalies[j].Date)
+	})
+	return anomalies
+}
+
+// DetectAll runs detection across every series and returns all anomalies
+// ordered by score (highest first), then by key and date for stable output.
+func (d *Detector) DetectAll(series []Series) []Anomaly {
+	var all []Anomaly
+	for _, s := range series {
+		all = append(all, d.Detect(s)...)
+	}
+
+	sort.SliceStable(all, func(i, j int) bool {
+		if all[i].Score != all[j].Score {
+			return all[i].Score > all[j].Score
+		}
+		if all[i].Key != all[j].Key {
+			return all[i].Key < all[j].Key
+		}
+		return all[i].Date.Before(all[j].Date)
+	})
+	return all
+}
+
+// detectZScore flags points whose absolute z-score meets the threshold.
+// A series with zero variance has no anomalies.
+func (d *Detector) detectZScore(series Series, values []float64) []Anomaly {
+	mean, stddev := meanStdDev(values)
+	if stddev == 0 {
+		return nil
+	}
+
+	var anomalies []Anomaly
+	for _, p := range series.Points {
+		z := (p.Value - mean) / stddev
+		if math.Abs(z) < d.threshold {
+			continue
+		}
+		anomalies = append(anomalies, newAnomaly(series, p, mean, math.Abs(z)))
+	}
+	return anomalies
+}
+
+// detectIQR flags points outside the Tukey fences. The score is the distance
+// from the nearest quartile in IQR multiples, so flagged points always score at
+// least the threshold. When the IQR is zero, any point that differs from the
+// quartiles is flagged with a score equal to the threshold.
+func (d *Detector) detectIQR(series Series, values []float64) []Anomaly {
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+
+	q1 := quantile(sorted, quartileLower)
+	q3 := quantile(sorted, quartileUpper)
+	median := quantile(sorted, quartileMedian)
+	iqr := q3 - q1
+
+	lower := q1 - d.threshold*iqr
+	upper := q3 + d.threshold*iqr
+
+	var anomalies []Anomaly
+	for _, p := range series.Points {
+		var distance float64
+		switch {
+		case p.Value > upper:
+			distance = p.Value - q3
+		case p.Value < lower:
+			distance = q1 - p.Value
+		default:
+			continue
+		}
+
+		score := d.threshold
+		if iqr > 0 {
+			score = distance / iqr
+		}
+		anomalies = append(anomalies, newAnomaly(series, p, median, score))
+	}
+	return anomalies
+}
+
+// newAnomaly builds an Anomaly for a point relative to its baseline.
+func newAnomaly(series Series, p Point, expected, score float64) Anomaly {
+	direction := DirectionSpike
+	if p.Value < expected {
+		direction = DirectionDrop
+	}
+	return Anomaly{
+		Key:       series.Key,
+		Date:      p.Date,
+		Value:     p.Value,
+		Expected:  expected,
+		Score:     score,
+		Direction: direction,
+		Currency:  series.Currency,
+	}
+}
+
+// meanStdDev returns the mean and population standard deviation of values.
+func meanStdDev(values []float64) (float64, float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	mean := sum / float64(len(values))
+
+	var sq float64
+	for _, v := range values {
+		sq += (v - mean) * (v - mean)
+	}
+	return mean, math.Sqrt(sq / float64(len(values)))
+}
+
+// quantile returns the q-th quantile of sorted values using linear interpolation.
+func quantile(sorted []float64, q float64) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	pos := q * float64(len(sorted)-1)
+	lo := int(math.Floor(pos))
+	hi := int(math.Ceil(pos))
+	if lo == hi {
+		return sorted[lo]
+	}
+	frac := pos - float64(lo)
+	return sorted[lo] + frac*(sorted[hi]-sorted[lo])
+}
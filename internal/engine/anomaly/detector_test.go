@@ -0,0 +1,171 @@
+package anomaly
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// makeSeries builds a daily series starting 2026-01-01 from the given values.
+func makeSeries(key string, values ...float64) Series {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	points := make([]Point, len(values))
+	for i, v := range values {
+		points[i] = Point{Date: start.AddDate(0, 0, i), Value: v}
+	}
+	return Series{Key: key, Currency: "USD", Points: points}
+}
+
+func TestNewDetector(t *testing.T) {
+	tests := []struct {
+		name          string
+		method        Method
+		sensitivity   Sensitivity
+		wantMethod    Method
+		wantThreshold float64
+		wantErr       error
+	}{
+		{name: "defaults", wantMethod: MethodZScore, wantThreshold: 2.5},
+		{name: "zscore low", method: MethodZScore, sensitivity: SensitivityLow, wantMethod: MethodZScore, wantThreshold: 3.0},
+		{name: "iqr high", method: MethodIQR, sensitivity: SensitivityHigh, wantMethod: MethodIQR, wantThreshold: 1.5},
+		{name: "case insensitive", method: "IQR", sensitivity: " Medium ", wantMethod: MethodIQR, wantThreshold: 2.0},
+		{name: "unknown method", method: "mad", wantErr: ErrInvalidMethod},
+		{name: "unknown sensitivity", sensitivity: "extreme", wantErr: ErrInvalidSensitivity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := NewDetector(tt.method, tt.sensitivity)
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantMethod, d.Method())
+			assert.InDelta(t, tt.wantThreshold, d.Threshold(), 0.001)
+		})
+	}
+}
+
+func TestDetector_WithThreshold(t *testing.T) {
+	d, err := NewDetector(MethodZScore, SensitivityMedium)
+	require.NoError(t, err)
+
+	_, err = d.WithThreshold(0)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+	_, err = d.WithThreshold(-1)
+	require.ErrorIs(t, err, ErrInvalidThreshold)
+
+	d, err = d.WithThreshold(1.2)
+	require.NoError(t, err)
+	assert.InDelta(t, 1.2, d.Threshold(), 0.001)
+}
+
+func TestDetector_ZScore(t *testing.T) {
+	d, err := NewDetector(MethodZScore, SensitivityMedium)
+	require.NoError(t, err)
+
+	t.Run("flags spike", func(t *testing.T) {
+		series := makeSeries("i-123", 10, 11, 9, 10, 10, 11, 9, 10, 50, 10)
+		anomalies := d.Detect(series)
+		require.Len(t, anomalies, 1)
+		assert.Equal(t, "i-123", anomalies[0].Key)
+		assert.Equal(t, time.Date(2026, 1, 9, 0, 0, 0, 0, time.UTC), anomalies[0].Date)
+		assert.InDelta(t, 50.0, anomalies[0].Value, 0.001)
+		assert.InDelta(t, 14.0, anomalies[0].Expected, 0.001)
+		assert.Equal(t, DirectionSpike, anomalies[0].Direction)
+		assert.Equal(t, "USD", anomalies[0].Currency)
+		assert.GreaterOrEqual(t, anomalies[0].Score, 2.5)
+	})
+
+	t.Run("flags drop", func(t *testing.T) {
+		series := makeSeries("i-123", 100, 101, 99, 100, 100, 101, 99, 100, 0, 100)
+		anomalies := d.Detect(series)
+		require.Len(t, anomalies, 1)
+		assert.Equal(t, DirectionDrop, anomalies[0].Direction)
+	})
+
+	t.Run("flat series has no anomalies", func(t *testing.T) {
+		assert.Empty(t, d.Detect(makeSeries("flat", 5, 5, 5, 5, 5, 5, 5, 5)))
+	})
+
+	t.Run("short series skipped", func(t *testing.T) {
+		assert.Empty(t, d.Detect(makeSeries("short", 1, 1, 100)))
+	})
+}
+
+func TestDetector_IQR(t *testing.T) {
+	d, err := NewDetector(MethodIQR, SensitivityMedium)
+	require.NoError(t, err)
+
+	t.Run("flags spike with median baseline", func(t *testing.T) {
+		series := makeSeries("bucket", 10, 12, 11, 13, 10, 12, 11, 13, 60)
+		anomalies := d.Detect(series)
+		require.Len(t, anomalies, 1)
+		assert.InDelta(t, 60.0, anomalies[0].Value, 0.001)
+		assert.InDelta(t, 12.0, anomalies[0].Expected, 0.001)
+		assert.Equal(t, DirectionSpike, anomalies[0].Direction)
+		assert.GreaterOrEqual(t, anomalies[0].Score, 2.0)
+	})
+
+	t.Run("zero IQR still flags differing points", func(t *testing.T) {
+		series := makeSeries("steady", 10, 10, 10, 10, 10, 10, 10, 10, 30)
+		anomalies := d.Detect(series)
+		require.Len(t, anomalies, 1)
+		assert.InDelta(t, 2.0, anomalies[0].Score, 0.001)
+	})
+
+	t.Run("normal variation not flagged", func(t *testing.T) {
+		assert.Empty(t, d.Detect(makeSeries("normal", 10, 12, 11, 13, 10, 12, 11, 13, 14)))
+	})
+}
+
+func TestDetector_WithMinPoints(t *testing.T) {
+	d, err := NewDetector(MethodIQR, SensitivityMedium)
+	require.NoError(t, err)
+
+	series := makeSeries("short", 10, 10, 10, 40)
+	assert.Empty(t, d.Detect(series))
+	assert.Len(t, d.WithMinPoints(4).Detect(series), 1)
+	assert.Empty(t, d.WithMinPoints(1).Detect(makeSeries("tiny", 1, 50)), "minimum is clamped to 3")
+}
+
+func TestDetector_DetectAll_OrdersByScore(t *testing.T) {
+	d, err := NewDetector(MethodIQR, SensitivityHigh)
+	require.NoError(t, err)
+
+	anomalies := d.DetectAll([]Series{
+		makeSeries("small", 10, 10, 10, 10, 10, 10, 11, 12, 25),
+		makeSeries("large", 10, 10, 10, 10, 10, 10, 11, 12, 90),
+	})
+	require.Len(t, anomalies, 2)
+	assert.Equal(t, "large", anomalies[0].Key)
+	assert.Equal(t, "small", anomalies[1].Key)
+}
+
+func TestSeriesBuilder(t *testing.T) {
+	b := NewSeriesBuilder()
+	day1 := time.Date(2026, 1, 1, 15, 0, 0, 0, time.UTC)
+	day2 := time.Date(2026, 1, 2, 3, 0, 0, 0, time.UTC)
+
+	b.Add("aws", day2, 4, "USD")
+	b.Add("aws", day1, 1, "USD")
+	b.Add("aws", day1, 2, "")
+	b.Add("gcp", day1, 7, "EUR")
+	b.Add("", day1, 100, "USD")
+
+	series := b.Series()
+	require.Len(t, series, 2)
+
+	assert.Equal(t, "aws", series[0].Key)
+	assert.Equal(t, "USD", series[0].Currency)
+	require.Len(t, series[0].Points, 2)
+	assert.Equal(t, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), series[0].Points[0].Date)
+	assert.InDelta(t, 3.0, series[0].Points[0].Value, 0.001)
+	assert.InDelta(t, 4.0, series[0].Points[1].Value, 0.001)
+
+	assert.Equal(t, "gcp", series[1].Key)
+	assert.Equal(t, "EUR", series[1].Currency)
+}
@@ -0,0 +1,12 @@
+// Package anomaly provides statistical anomaly detection over daily cost series.
+//
+// This package flags days whose spend deviates sharply from the rest of a series.
+// Key features:
+//   - Z-score detection (distance from the mean in standard deviations)
+//   - IQR detection (Tukey fences around the interquartile range)
+//   - Named sensitivity levels (low, medium, high) with optional explicit thresholds
+//   - Spike and drop classification with a baseline for comparison
+//
+// Series are built by callers (e.g., the "cost anomalies" command) from daily
+// actual-cost data, keyed by resource, resource type, or provider.
+package anomaly
@@ -0,0 +1,67 @@
+package anomaly
+
+import (
+	"sort"
+	"time"
+)
+
+// SeriesBuilder accumulates daily spend observations per key and produces
+// chronologically ordered series. Multiple observations for the same key and
+// day are summed, which allows resources to be rolled up into types or providers.
+type SeriesBuilder struct {
+	values   map[string]map[time.Time]float64
+	currency map[string]string
+}
+
+// NewSeriesBuilder creates an empty SeriesBuilder.
+func NewSeriesBuilder() *SeriesBuilder {
+	return &SeriesBuilder{
+		values:   make(map[string]map[time.Time]float64),
+		currency: make(map[string]string),
+	}
+}
+
+// Add records spend for key on the UTC day containing day.
+// Empty keys are ignored.
+func (b *SeriesBuilder) Add(key string, day time.Time, value float64, currency string) {
+	if key == "" {
+		return
+	}
+
+	year, month, date := day.UTC().Date()
+	dayStart := time.Date(year, month, date, 0, 0, 0, 0, time.UTC)
+
+	if b.values[key] == nil {
+		b.values[key] = make(map[time.Time]float64)
+	}
+	b.values[key][dayStart] += value
+
+	if currency != "" && b.currency[key] == "" {
+		b.currency[key] = currency
+	}
+}
+
+// Series returns one series per key, sorted by key, with points in date order.
+// Days without observations are omitted rather than treated as zero spend so
+// that missing billing data is not reported as a drop.
+func (b *SeriesBuilder) Series() []Series {
+	keys := make([]string, 0, len(b.values))
+	for key := range b.values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	series := make([]Series, 0, len(keys))
+	for _, key := range keys {
+		days := b.values[key]
+		points := make([]Point, 0, len(days))
+		for day, value := range days {
+			points = append(points, Point{Date: day, Value: value})
+		}
+		sort.Slice(points, func(i, j int) bool {
+			return points[i].Date.Before(points[j].Date)
+		})
+		series = append(series, Series{Key: key, Currency: b.currency[key], Points: points})
+	}
+	return series
+}
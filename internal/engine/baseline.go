@@ -0,0 +1,167 @@
+package engine
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// IncreaseLimit is the largest cost increase a gate such as --max-increase
+// accepts: an amount, or a percentage of the cost that increases.
+type IncreaseLimit struct {
+	Value float64
+	// Percent is true when Value is a percentage.
+	Percent bool
+}
+
+// ParseIncreaseLimit parses an increase limit: an amount such as "100" or a
+// percentage such as "10%".
+func ParseIncreaseLimit(s string) (IncreaseLimit, error) {
+	value, percent := strings.CutSuffix(strings.TrimSpace(s), "%")
+	parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || parsed < 0 {
+		return IncreaseLimit{}, fmt.Errorf(
+			"invalid increase %q: use an amount such as 100 or a percentage such as 10%%", s)
+	}
+	return IncreaseLimit{Value: parsed, Percent: percent}, nil
+}
+
+// Exceeded reports whether an increase of change on a cost of before exceeds
+// the limit. Any increase from a cost of zero exceeds a percentage limit.
+func (l IncreaseLimit) Exceeded(before, change float64) bool {
+	if change <= 0 {
+		return false
+	}
+	if !l.Percent {
+		return change > l.Value
+	}
+	if before <= 0 {
+		return true
+	}
+	return change/before*percentageMultiplier > l.Value
+}
+
+// String returns the limit as it is written on the command line.
+func (l IncreaseLimit) String() string {
+	if l.Percent {
+		return strconv.FormatFloat(l.Value, 'f', -1, 64) + "%"
+	}
+	return fmt.Sprintf("%.2f", l.Value)
+}
+
+// ExceedingChanges returns whether the total change of delta exceeds limit,
+// and the resource changes that exceed it, in the order of delta.Changes.
+func ExceedingChanges(delta *ProjectionDelta, limit IncreaseLimit) (bool, []ResourceCostChange) {
+	var exceeding []ResourceCostChange
+	for _, change := range delta.Changes {
+		if limit.Exceeded(change.Before, change.Change) {
+			exceeding = append(exceeding, change)
+		}
+	}
+	return limit.Exceeded(delta.Baseline, delta.Change), exceeding
+}
+
+// CompareWithBaseline compares results with the results of an earlier run,
+// such as a saved --output json result, as CalculateProjectionDelta compares
+// them with a recorded snapshot. Baseline results with errors are left out.
+func CompareWithBaseline(baseline, results []CostResult) *ProjectionDelta {
+	snapshot := config.ProjectionSnapshot{
+		Resources: make(map[string]config.ProjectedResourceRecord, len(baseline)),
+	}
+	for _, r := range baseline {
+		if r.ResourceID == "" || r.Error != nil {
+			continue
+		}
+		record := snapshot.Resources[r.ResourceID]
+		record.ResourceType = r.ResourceType
+		record.Currency = r.Currency
+		record.Monthly += r.Monthly
+		snapshot.Resources[r.ResourceID] = record
+	}
+	return CalculateProjectionDelta(snapshot, results)
+}
+
+// SchemaEnvelope is the envelope of the v2 JSON output schema, which names
+// the schema version and the command and holds the document of v1, without
+// its "finfocus" key, under data.
+type SchemaEnvelope struct {
+	SchemaVersion string          `json:"schema_version"`
+	Command       string          `json:"command"`
+	Data          json.RawMessage `json:"data"`
+}
+
+// unwrapSchemaEnvelope returns the data of a v2 envelope, or doc when it is
+// not one.
+func unwrapSchemaEnvelope(doc []byte) []byte {
+	var envelope SchemaEnvelope
+	if json.Unmarshal(doc, &envelope) == nil && envelope.SchemaVersion != "" && len(envelope.Data) > 0 {
+		return envelope.Data
+	}
+	return doc
+}
+
+// ParseCostResults parses cost results written by RenderResults: the JSON
+// document of OutputJSON, NDJSON with one result per line and an optional
+// trailing summary record, or a JSON array of results, in any JSON output
+// schema version.
+func ParseCostResults(data []byte) ([]CostResult, error) {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return nil, errors.New("no cost results")
+	}
+	trimmed = unwrapSchemaEnvelope(trimmed)
+
+	if trimmed[0] == '[' {
+		var results []CostResult
+		if err := json.Unmarshal(trimmed, &results); err != nil {
+			return nil, fmt.Errorf("parsing cost results: %w", err)
+		}
+		return results, nil
+	}
+
+	var document struct {
+		FinFocus *AggregatedResults `json:"finfocus"`
+		// Resources is set in the v2 document, which drops "finfocus".
+		Resources []CostResult `json:"resources"`
+	}
+	if err := json.Unmarshal(trimmed, &document); err == nil {
+		if document.FinFocus != nil {
+			return document.FinFocus.Resources, nil
+		}
+		if document.Resources != nil {
+			return document.Resources, nil
+		}
+	}
+
+	var results []CostResult
+	scanner := bufio.NewScanner(bytes.NewReader(trimmed))
+	scanner.Buffer(make([]byte, 0, bufio.MaxScanTokenSize), len(trimmed))
+	for line := 1; scanner.Scan(); line++ {
+		text := bytes.TrimSpace(scanner.Bytes())
+		if len(text) == 0 {
+			continue
+		}
+		text = unwrapSchemaEnvelope(text)
+		var record struct {
+			Type string `json:"type"`
+		}
+		if json.Unmarshal(text, &record) == nil && record.Type == NDJSONSummaryType {
+			continue
+		}
+		var result CostResult
+		if err := json.Unmarshal(text, &result); err != nil {
+			return nil, fmt.Errorf("parsing cost results: line %d: %w", line, err)
+		}
+		results = append(results, result)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("parsing cost results: %w", err)
+	}
+	return results, nil
+}
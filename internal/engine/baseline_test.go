@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseIncreaseLimit(t *testing.T) {
+	limit, err := ParseIncreaseLimit("100")
+	require.NoError(t, err)
+	assert.Equal(t, IncreaseLimit{Value: 100}, limit)
+	assert.Equal(t, "100.00", limit.String())
+
+	limit, err = ParseIncreaseLimit(" 12.5% ")
+	require.NoError(t, err)
+	assert.Equal(t, IncreaseLimit{Value: 12.5, Percent: true}, limit)
+	assert.Equal(t, "12.5%", limit.String())
+
+	for _, invalid := range []string{"", "ten", "-5", "%"} {
+		_, err = ParseIncreaseLimit(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestIncreaseLimit_Exceeded(t *testing.T) {
+	amount := IncreaseLimit{Value: 50}
+	assert.True(t, amount.Exceeded(100, 60))
+	assert.False(t, amount.Exceeded(100, 50))
+	assert.False(t, amount.Exceeded(100, -80), "decreases never exceed")
+
+	percent := IncreaseLimit{Value: 10, Percent: true}
+	assert.True(t, percent.Exceeded(100, 11))
+	assert.False(t, percent.Exceeded(100, 10))
+	assert.True(t, percent.Exceeded(0, 1), "any increase from zero exceeds a percentage")
+}
+
+func TestCompareWithBaseline(t *testing.T) {
+	baseline := []CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 100},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Monthly: 200},
+		{ResourceID: "old", ResourceType: "aws:s3/bucket:Bucket", Monthly: 5},
+		{ResourceID: "failed", Monthly: 99, Error: &StructuredError{Code: ErrCodePluginError}},
+	}
+	results := []CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 115},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Monthly: 205},
+		{ResourceID: "cache", ResourceType: "aws:elasticache/cluster:Cluster", Monthly: 20},
+	}
+
+	delta := CompareWithBaseline(baseline, results)
+	assert.InDelta(t, 305, delta.Baseline, 1e-9, "failed baseline results are left out")
+	assert.InDelta(t, 340, delta.Projected, 1e-9)
+
+	total, exceeding := ExceedingChanges(delta, IncreaseLimit{Value: 10, Percent: true})
+	assert.True(t, total, "+11.5% in total")
+	require.Len(t, exceeding, 2)
+	assert.Equal(t, "cache", exceeding[0].ResourceID, "added resources exceed a percentage")
+	assert.Equal(t, "web", exceeding[1].ResourceID)
+
+	total, exceeding = ExceedingChanges(delta, IncreaseLimit{Value: 50})
+	assert.False(t, total)
+	assert.Empty(t, exceeding)
+}
+
+func TestParseCostResults(t *testing.T) {
+	results := []CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 8},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 130},
+	}
+	for _, format := range []OutputFormat{OutputJSON, OutputNDJSON} {
+		t.Run(string(format), func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, RenderResults(&buf, format, results))
+
+			parsed, err := ParseCostResults(buf.Bytes())
+			require.NoError(t, err)
+			require.Len(t, parsed, 2)
+			assert.Equal(t, "db", parsed[1].ResourceID)
+			assert.InDelta(t, 130, parsed[1].Monthly, 1e-9)
+		})
+	}
+
+	parsed, err := ParseCostResults([]byte(`[{"resourceId": "web", "monthly": 8}]`))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1)
+
+	parsed, err = ParseCostResults([]byte(`{"schema_version": "v2", "command": "cost projected",
+		"data": {"summary": {}, "resources": [{"resourceId": "web", "monthly": 8}]}}`))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1, "v2 document")
+
+	parsed, err = ParseCostResults([]byte(
+		`{"schema_version":"v2","command":"cost projected","data":{"resourceId":"web","monthly":8}}` + "\n" +
+			`{"schema_version":"v2","command":"cost projected","data":{"resourceId":"db","monthly":130}}`))
+	require.NoError(t, err)
+	require.Len(t, parsed, 2, "v2 NDJSON")
+	assert.Equal(t, "db", parsed[1].ResourceID)
+
+	parsed, err = ParseCostResults([]byte(`{"resourceId":"web","monthly":8}` + "\n" +
+		`{"type":"summary","resources":1,"totalMonthly":8,"currency":"USD","errors":0}`))
+	require.NoError(t, err)
+	require.Len(t, parsed, 1, "the summary record is not a result")
+
+	_, err = ParseCostResults([]byte("  "))
+	require.Error(t, err)
+	_, err = ParseCostResults([]byte("RESOURCE  MONTHLY\nweb  8.00"))
+	require.Error(t, err)
+}
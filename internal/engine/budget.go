@@ -10,6 +10,8 @@ import (
 
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 
+	"github.com/rshade/finfocus/internal/engine/filterexpr"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
 	"github.com/rshade/finfocus/internal/logging"
 )
 
@@ -27,8 +29,9 @@ var ErrInvalidBudget = errors.New("invalid budget")
 
 // BudgetFilterOptions contains criteria for filtering budgets.
 type BudgetFilterOptions struct {
-	Providers []string          // Filter by provider names (case-insensitive, OR logic)
-	Tags      map[string]string // Filter by metadata tags (case-sensitive, AND logic, supports glob patterns)
+	Providers   []string           // Filter by provider names (case-insensitive, OR logic)
+	Tags        map[string]string  // Filter by metadata tags (case-sensitive, AND logic, supports glob patterns)
+	Expressions []*filterexpr.Expr // Filter expressions over BudgetFilterSchema fields (AND logic)
 }
 
 // BudgetResult contains the complete budget health response.
@@ -407,6 +410,22 @@ func FilterBudgetsByProvider(ctx context.Context, budgets []*pbc.Budget, provide
 	return filtered
 }
 
+// FilterBudgetsByExpr returns the budgets that every expression matches.
+// No expressions return all budgets.
+func FilterBudgetsByExpr(budgets []*pbc.Budget, exprs []*filterexpr.Expr) []*pbc.Budget {
+	if len(exprs) == 0 {
+		return budgets
+	}
+	return pipeline.Filter(func(b *pbc.Budget) bool {
+		for _, expr := range exprs {
+			if !expr.Match(BudgetRecord(b)) {
+				return false
+			}
+		}
+		return true
+	})(budgets)
+}
+
 // MatchesProvider checks if a budget matches any of the given providers.
 // Returns true if providers is empty (no filtering).
 // Returns false if budget is nil.
@@ -441,8 +460,11 @@ func (e *Engine) GetBudgets(ctx context.Context, filter *BudgetFilterOptions) (*
 			result.Errors = append(result.Errors, fmt.Errorf("plugin %s: %w", client.Name, err))
 			continue
 		}
-		if resp != nil && len(resp.GetBudgets()) > 0 {
-			allBudgets = append(allBudgets, resp.GetBudgets()...)
+		for _, b := range resp.GetBudgets() {
+			// Commitments are reported as budgets but are not spending limits.
+			if b.GetMetadata()[CommitmentTypeKey] == "" {
+				allBudgets = append(allBudgets, b)
+			}
 		}
 	}
 
@@ -454,12 +476,15 @@ func (e *Engine) GetBudgets(ctx context.Context, filter *BudgetFilterOptions) (*
 	// Apply provider filter first (OR logic), then tag filter (AND logic)
 	var providers []string
 	var tags map[string]string
+	var exprs []*filterexpr.Expr
 	if filter != nil {
 		providers = filter.Providers
 		tags = filter.Tags
+		exprs = filter.Expressions
 	}
 	filteredBudgets := FilterBudgetsByProvider(ctx, allBudgets, providers)
 	filteredBudgets = FilterBudgetsByTags(ctx, filteredBudgets, tags)
+	filteredBudgets = FilterBudgetsByExpr(filteredBudgets, exprs)
 
 	// 3. Process each budget
 	now := time.Now()
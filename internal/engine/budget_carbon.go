@@ -0,0 +1,69 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/greenops"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// BudgetMetricCarbon marks a ScopedBudgetResult of carbon budgets.
+const BudgetMetricCarbon = "carbon"
+
+// CarbonFootprintKg returns the carbon footprint that a result's plugin
+// reported, in kgCO2e, and whether one was reported in a recognized unit.
+func CarbonFootprintKg(result CostResult) (float64, bool) {
+	metric, ok := result.Sustainability[greenops.CarbonMetricKey]
+	if !ok {
+		metric, ok = result.Sustainability[greenops.DeprecatedCarbonKey]
+	}
+	if !ok {
+		return 0, false
+	}
+	kg, err := greenops.NormalizeToKg(metric.Value, metric.Unit)
+	if err != nil {
+		return 0, false
+	}
+	return kg, true
+}
+
+// CarbonBudgetCosts returns copies of results whose Monthly is the carbon
+// footprint in kgCO2e, so that the scoped budget evaluator allocates
+// footprints to carbon budgets the way it allocates costs to cost budgets.
+// Results without a footprint count as zero and are returned in missing.
+func CarbonBudgetCosts(ctx context.Context, results []CostResult) ([]CostResult, int) {
+	footprints := make([]CostResult, 0, len(results))
+	missing := 0
+	for _, result := range results {
+		kg, ok := CarbonFootprintKg(result)
+		if !ok && result.Error == nil {
+			missing++
+		}
+		result.Monthly = kg
+		result.Currency = config.CarbonBudgetUnit
+		footprints = append(footprints, result)
+	}
+	if missing > 0 {
+		logging.FromContext(ctx).Debug().Ctx(ctx).
+			Str("component", "engine").
+			Int("missing", missing).
+			Msg("resources without a carbon footprint count as zero toward carbon budgets")
+	}
+	return footprints, missing
+}
+
+// MarkCarbonBudgetResult marks an evaluated result as carbon budgets, with
+// every amount in kgCO2e.
+func MarkCarbonBudgetResult(result *ScopedBudgetResult) {
+	if result == nil {
+		return
+	}
+	result.Metric = BudgetMetricCarbon
+	for _, status := range result.AllScopes() {
+		status.Currency = config.CarbonBudgetUnit
+	}
+	if result.Unallocated != nil {
+		result.Unallocated.Currency = config.CarbonBudgetUnit
+	}
+}
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestCarbonFootprintKg(t *testing.T) {
+	tests := []struct {
+		name   string
+		result CostResult
+		want   float64
+		wantOK bool
+	}{
+		{
+			name: "kilograms",
+			result: CostResult{Sustainability: map[string]SustainabilityMetric{
+				"carbon_footprint": {Value: 12.5, Unit: "kgCO2e"},
+			}},
+			want:   12.5,
+			wantOK: true,
+		},
+		{
+			name: "grams are normalized",
+			result: CostResult{Sustainability: map[string]SustainabilityMetric{
+				"carbon_footprint": {Value: 2500, Unit: "gCO2e"},
+			}},
+			want:   2.5,
+			wantOK: true,
+		},
+		{
+			name: "deprecated key",
+			result: CostResult{Sustainability: map[string]SustainabilityMetric{
+				"gCO2e": {Value: 1000, Unit: "g"},
+			}},
+			want:   1,
+			wantOK: true,
+		},
+		{
+			name: "unknown unit",
+			result: CostResult{Sustainability: map[string]SustainabilityMetric{
+				"carbon_footprint": {Value: 1, Unit: "furlongs"},
+			}},
+		},
+		{
+			name:   "no metrics",
+			result: CostResult{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := CarbonFootprintKg(tt.result)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.InDelta(t, tt.want, got, 0.0001)
+		})
+	}
+}
+
+func TestCarbonBudgetCosts(t *testing.T) {
+	results := []CostResult{
+		{
+			ResourceID: "web", ResourceType: "aws:ec2/instance", Monthly: 70, Currency: "USD",
+			Sustainability: map[string]SustainabilityMetric{"carbon_footprint": {Value: 40, Unit: "kg"}},
+		},
+		{ResourceID: "bucket", ResourceType: "aws:s3/bucket", Monthly: 5, Currency: "USD"},
+		{ResourceID: "broken", ResourceType: "aws:rds/instance", Error: &StructuredError{Message: "no pricing"}},
+	}
+
+	footprints, missing := CarbonBudgetCosts(context.Background(), results)
+	require.Len(t, footprints, 3)
+	assert.Equal(t, 1, missing, "errored results are not counted as missing")
+	assert.InDelta(t, 40.0, footprints[0].Monthly, 0.001)
+	assert.Equal(t, config.CarbonBudgetUnit, footprints[0].Currency)
+	assert.Equal(t, "aws:ec2/instance", footprints[0].ResourceType)
+	assert.InDelta(t, 0.0, footprints[1].Monthly, 0.001)
+	assert.InDelta(t, 70.0, results[0].Monthly, 0.001, "results are not modified")
+}
+
+func TestMarkCarbonBudgetResult(t *testing.T) {
+	result := &ScopedBudgetResult{
+		Global:      &ScopedBudgetStatus{ScopeType: ScopeTypeGlobal},
+		ByProvider:  map[string]*ScopedBudgetStatus{"aws": {ScopeType: ScopeTypeProvider, ScopeKey: "aws"}},
+		ByTag:       []*ScopedBudgetStatus{{ScopeType: ScopeTypeTag, ScopeKey: "team:platform"}},
+		Unallocated: &UnallocatedSpend{},
+	}
+
+	MarkCarbonBudgetResult(result)
+
+	assert.Equal(t, BudgetMetricCarbon, result.Metric)
+	for _, status := range result.AllScopes() {
+		assert.Equal(t, config.CarbonBudgetUnit, status.Currency)
+	}
+	assert.Equal(t, config.CarbonBudgetUnit, result.Unallocated.Currency)
+	MarkCarbonBudgetResult(nil)
+}
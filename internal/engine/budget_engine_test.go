@@ -8,6 +8,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 
@@ -17,9 +19,12 @@ import (
 
 // mockCostSourceClient implements proto.CostSourceClient for testing.
 type mockCostSourceClient struct {
-	budgets []*pbc.Budget
-	err     error
-	name    string
+	budgets         []*pbc.Budget
+	recommendations []*proto.Recommendation
+	err             error
+	name            string
+	estimate        func(*proto.EstimateCostRequest) (*proto.EstimateCostResponse, error)
+	projected       func(*proto.GetProjectedCostRequest) (*proto.GetProjectedCostResponse, error)
 }
 
 func (m *mockCostSourceClient) GetBudgets(
@@ -46,6 +51,9 @@ func (m *mockCostSourceClient) GetProjectedCost(
 	in *proto.GetProjectedCostRequest,
 	opts ...grpc.CallOption,
 ) (*proto.GetProjectedCostResponse, error) {
+	if m.projected != nil {
+		return m.projected(in)
+	}
 	return &proto.GetProjectedCostResponse{}, nil
 }
 
@@ -62,7 +70,7 @@ func (m *mockCostSourceClient) GetRecommendations(
 	in *proto.GetRecommendationsRequest,
 	opts ...grpc.CallOption,
 ) (*proto.GetRecommendationsResponse, error) {
-	return &proto.GetRecommendationsResponse{}, nil
+	return &proto.GetRecommendationsResponse{Recommendations: m.recommendations}, nil
 }
 
 func (m *mockCostSourceClient) GetPluginInfo(
@@ -89,6 +97,17 @@ func (m *mockCostSourceClient) DismissRecommendation(
 	return &proto.DismissRecommendationResponse{Success: true}, nil
 }
 
+func (m *mockCostSourceClient) EstimateCost(
+	ctx context.Context,
+	in *proto.EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.EstimateCostResponse, error) {
+	if m.estimate != nil {
+		return m.estimate(in)
+	}
+	return nil, status.Error(codes.Unimplemented, "EstimateCost not implemented")
+}
+
 func TestEngine_GetBudgets(t *testing.T) {
 	ctx := context.Background()
 
@@ -2,6 +2,9 @@ package engine
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"strings"
 
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 
@@ -42,6 +45,44 @@ var healthSeverityMap = map[pbc.BudgetHealthStatus]int{ //nolint:gochecknoglobal
 	pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED: severityUnspecified,
 }
 
+// ErrInvalidHealthLevel is returned when a health level name cannot be parsed.
+var ErrInvalidHealthLevel = errors.New("invalid budget health level")
+
+// healthLevelNames maps lowercase level names to their BudgetHealthStatus values.
+// These names are used by CLI flags such as --fail-on.
+var healthLevelNames = map[string]pbc.BudgetHealthStatus{ //nolint:gochecknoglobals // Constant lookup table
+	"ok":       pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+	"warning":  pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING,
+	"critical": pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL,
+	"exceeded": pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED,
+}
+
+// ValidHealthLevels returns the accepted health level names in ascending severity order.
+func ValidHealthLevels() []string {
+	return []string{"ok", "warning", "critical", "exceeded"}
+}
+
+// ParseHealthLevel parses a case-insensitive health level name (ok, warning,
+// critical, exceeded) into its BudgetHealthStatus value.
+func ParseHealthLevel(level string) (pbc.BudgetHealthStatus, error) {
+	health, ok := healthLevelNames[strings.ToLower(strings.TrimSpace(level))]
+	if !ok {
+		return pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED,
+			fmt.Errorf("%w: %q (valid: %s)", ErrInvalidHealthLevel, level,
+				strings.Join(ValidHealthLevels(), ", "))
+	}
+	return health, nil
+}
+
+// HealthAtOrAbove reports whether health is at least as severe as threshold.
+// UNSPECIFIED health never meets a threshold, so unevaluated budgets cannot trigger failures.
+func HealthAtOrAbove(health, threshold pbc.BudgetHealthStatus) bool {
+	if health == pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED {
+		return false
+	}
+	return healthSeverity(health) >= healthSeverity(threshold)
+}
+
 // CalculateBudgetHealthFromPercentage calculates health status from a raw utilization percentage.
 //
 // Thresholds:
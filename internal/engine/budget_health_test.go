@@ -433,6 +433,82 @@ func TestCalculateBudgetHealthResults(t *testing.T) {
 	}
 }
 
+// TestParseHealthLevel tests parsing of --fail-on level names.
+func TestParseHealthLevel(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected pbc.BudgetHealthStatus
+		wantErr  bool
+	}{
+		{name: "ok", input: "ok", expected: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK},
+		{name: "warning", input: "warning", expected: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING},
+		{name: "critical uppercase", input: "CRITICAL", expected: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL},
+		{name: "exceeded with spaces", input: " exceeded ", expected: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED},
+		{name: "unknown level", input: "severe", wantErr: true},
+		{name: "empty", input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseHealthLevel(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidHealthLevel)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+// TestHealthAtOrAbove tests severity comparison used by the --fail-on policy.
+func TestHealthAtOrAbove(t *testing.T) {
+	tests := []struct {
+		name      string
+		health    pbc.BudgetHealthStatus
+		threshold pbc.BudgetHealthStatus
+		expected  bool
+	}{
+		{
+			name:      "exceeded meets critical",
+			health:    pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED,
+			threshold: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL,
+			expected:  true,
+		},
+		{
+			name:      "warning meets warning",
+			health:    pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING,
+			threshold: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING,
+			expected:  true,
+		},
+		{
+			name:      "warning below exceeded",
+			health:    pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING,
+			threshold: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED,
+			expected:  false,
+		},
+		{
+			name:      "ok meets ok",
+			health:    pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+			threshold: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+			expected:  true,
+		},
+		{
+			name:      "unspecified never meets",
+			health:    pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED,
+			threshold: pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK,
+			expected:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, HealthAtOrAbove(tt.health, tt.threshold))
+		})
+	}
+}
+
 // TestHealthThresholdConstants verifies the threshold constants match spec.
 func TestHealthThresholdConstants(t *testing.T) {
 	assert.Equal(t, 80.0, HealthThresholdWarning)
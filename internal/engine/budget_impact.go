@@ -0,0 +1,104 @@
+package engine
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// BudgetImpact is the share of one budget scope that projected costs would
+// use after a deploy.
+type BudgetImpact struct {
+	// Scope identifies the budget scope, as in BudgetAllocation.AllocatedScopes.
+	Scope string `json:"scope"`
+	// Resources counts the projected resources allocated to the scope.
+	Resources int     `json:"resources"`
+	Projected float64 `json:"projected"`
+	Budget    float64 `json:"budget"`
+	Currency  string  `json:"currency,omitempty"`
+	// Percentage is Projected / Budget * 100.
+	Percentage float64                `json:"percentage"`
+	Health     pbc.BudgetHealthStatus `json:"health"`
+}
+
+// ProjectBudgetImpact allocates the monthly cost of each projected result to
+// its budget scopes with AllocateCosts and returns the share of each scope's
+// budget that the allocated costs would use, highest first. tags maps
+// resource IDs to their tags for tag budgets. Results with an error are
+// skipped; budgets with rollover are measured against their effective amount.
+func (e *ScopedBudgetEvaluator) ProjectBudgetImpact(
+	ctx context.Context,
+	costs []CostResult,
+	tags map[string]map[string]string,
+) []BudgetImpact {
+	impacts := make(map[string]*BudgetImpact)
+	for _, cost := range costs {
+		if ctx.Err() != nil {
+			break
+		}
+		if cost.Error != nil {
+			continue
+		}
+		allocation := e.AllocateCosts(ctx, cost.ResourceType, tags[cost.ResourceID], cost.Monthly)
+		for _, scope := range allocation.AllocatedScopes {
+			impact, ok := impacts[scope]
+			if !ok {
+				budget := e.scopeBudget(scope)
+				if budget == nil {
+					continue
+				}
+				effective, _ := e.EffectiveBudget(scope, budget)
+				impact = &BudgetImpact{Scope: scope, Budget: effective.Amount, Currency: effective.Currency}
+				impacts[scope] = impact
+			}
+			impact.Resources++
+			impact.Projected += cost.Monthly
+		}
+	}
+
+	result := make([]BudgetImpact, 0, len(impacts))
+	for _, impact := range impacts {
+		if impact.Budget > 0 {
+			impact.Percentage = impact.Projected / impact.Budget * percentageMultiplier
+		}
+		impact.Health = CalculateBudgetHealthFromPercentage(impact.Percentage)
+		result = append(result, *impact)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Percentage != result[j].Percentage {
+			return result[i].Percentage > result[j].Percentage
+		}
+		return result[i].Scope < result[j].Scope
+	})
+	return result
+}
+
+// scopeBudget returns the budget of a scope identifier such as
+// "provider:aws", or nil when none is configured.
+func (e *ScopedBudgetEvaluator) scopeBudget(scope string) *config.ScopedBudget {
+	scopeType, key, _ := strings.Cut(scope, ":")
+	switch ScopeType(scopeType) {
+	case ScopeTypeGlobal:
+		if e.config == nil {
+			return nil
+		}
+		return e.config.Global
+	case ScopeTypeProvider:
+		return e.GetProviderBudget(key)
+	case ScopeTypeType:
+		return e.GetTypeBudget(key)
+	case ScopeTypeStack:
+		return e.GetStackBudget(key)
+	case ScopeTypeTag:
+		for i := range e.tagBudgets {
+			if e.tagBudgets[i].Selector == key {
+				return &e.tagBudgets[i].ScopedBudget
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// budgetPeriodKeyLayout formats monthly budget period keys (e.g., "2026-01").
+const budgetPeriodKeyLayout = "2006-01"
+
+// SpendHistory provides recorded budget amounts and spend for prior periods.
+// config.BudgetHistoryStore satisfies this interface.
+type SpendHistory interface {
+	GetPeriod(scope, period string) (*config.BudgetPeriodRecord, bool)
+}
+
+// BudgetPeriodBounds returns the start (inclusive) and end (exclusive) of the
+// monthly budget period containing t. Period boundaries are computed in UTC so
+// history keys are stable regardless of the local time zone.
+func BudgetPeriodBounds(t time.Time) (time.Time, time.Time) {
+	year, month, _ := t.UTC().Date()
+	start := time.Date(year, month, 1, 0, 0, 0, 0, time.UTC)
+	return start, start.AddDate(0, 1, 0)
+}
+
+// BudgetPeriodKey returns the history key for the monthly budget period containing t.
+func BudgetPeriodKey(t time.Time) string {
+	start, _ := BudgetPeriodBounds(t)
+	return start.Format(budgetPeriodKeyLayout)
+}
+
+// PreviousBudgetPeriodKey returns the history key for the period immediately
+// before the one containing t.
+func PreviousBudgetPeriodKey(t time.Time) string {
+	start, _ := BudgetPeriodBounds(t)
+	return start.AddDate(0, -1, 0).Format(budgetPeriodKeyLayout)
+}
+
+// CalculateRolloverAmount returns the unspent amount carried into the current
+// period for a scope. The carried amount is the previous period's effective
+// budget minus its recorded spend, floored at zero. Returns 0 when rollover is
+// disabled, no history is available, or the previous period was not recorded.
+func CalculateRolloverAmount(
+	budget *config.ScopedBudget,
+	history SpendHistory,
+	scope string,
+	now time.Time,
+) float64 {
+	if !budget.IsEnabled() || !budget.Rollover || history == nil {
+		return 0
+	}
+
+	record, ok := history.GetPeriod(scope, PreviousBudgetPeriodKey(now))
+	if !ok {
+		return 0
+	}
+
+	return max(0, record.Amount-record.Spend)
+}
+
+// WithSpendHistory attaches historical spend to the evaluator so budgets with
+// rollover enabled are evaluated against their effective amounts. A nil now
+// function defaults to time.Now. Returns the evaluator for chaining.
+func (e *ScopedBudgetEvaluator) WithSpendHistory(history SpendHistory, now func() time.Time) *ScopedBudgetEvaluator {
+	if now == nil {
+		now = time.Now
+	}
+	e.history = history
+	e.now = now
+	return e
+}
+
+// EffectiveBudget returns the budget to evaluate for a scope along with the
+// amount carried forward from the previous period. When no rollover applies
+// the original budget is returned unchanged; otherwise a copy is returned with
+// the carried amount added so the configuration is never mutated.
+func (e *ScopedBudgetEvaluator) EffectiveBudget(
+	scope string,
+	budget *config.ScopedBudget,
+) (*config.ScopedBudget, float64) {
+	if e.history == nil {
+		return budget, 0
+	}
+
+	carried := CalculateRolloverAmount(budget, e.history, scope, e.now())
+	if carried <= 0 {
+		return budget, 0
+	}
+
+	effective := *budget
+	effective.Amount += carried
+	return &effective, carried
+}
@@ -11,6 +11,7 @@ import (
 
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/skus"
 )
 
 // ScopeType identifies the category of a budget scope.
@@ -29,8 +30,14 @@ const (
 
 	// ScopeTypeType represents a per-resource-type budget (e.g., aws:ec2/instance).
 	ScopeTypeType ScopeType = "type"
+
+	// ScopeTypeStack represents a per-Pulumi-stack budget (e.g., dev, prod).
+	ScopeTypeStack ScopeType = "stack"
 )
 
+// pulumiURNPrefix is the fixed prefix of every Pulumi resource URN.
+const pulumiURNPrefix = "urn:pulumi:"
+
 // percentageMultiplier converts decimal ratios to percentage values.
 const percentageMultiplier = 100
 
@@ -49,7 +56,7 @@ func (s ScopeType) String() string {
 // IsValid returns true if the scope type is a recognized value.
 func (s ScopeType) IsValid() bool {
 	switch s {
-	case ScopeTypeGlobal, ScopeTypeProvider, ScopeTypeTag, ScopeTypeType:
+	case ScopeTypeGlobal, ScopeTypeProvider, ScopeTypeTag, ScopeTypeType, ScopeTypeStack:
 		return true
 	default:
 		return false
@@ -65,6 +72,7 @@ type ScopedBudgetStatus struct {
 	// For provider: "aws", "gcp", etc.
 	// For tag: "team:platform", "env:prod", etc.
 	// For type: "aws:ec2/instance", etc.
+	// For stack: "dev", "prod", etc.
 	// For global: empty string.
 	ScopeKey string `json:"scope_key,omitempty"`
 
@@ -94,6 +102,10 @@ type ScopedBudgetStatus struct {
 
 	// Currency is the budget currency for display.
 	Currency string `json:"currency,omitempty"`
+
+	// RolloverAmount is the unspent amount carried from the previous period.
+	// It is already included in Budget.Amount.
+	RolloverAmount float64 `json:"rollover_amount,omitempty"`
 }
 
 // IsOverBudget returns true if current spend exceeds the budget amount.
@@ -164,6 +176,13 @@ type ScopedBudgetResult struct {
 	// ByType maps resource types to their budget statuses.
 	ByType map[string]*ScopedBudgetStatus `json:"by_type,omitempty"`
 
+	// ByStack maps Pulumi stack names to their budget statuses.
+	ByStack map[string]*ScopedBudgetStatus `json:"by_stack,omitempty"`
+
+	// Unallocated is the spend no tag budget accounts for. It is only set
+	// when tag budgets are configured.
+	Unallocated *UnallocatedSpend `json:"unallocated,omitempty"`
+
 	// OverallHealth is the worst health status across all scopes.
 	OverallHealth pbc.BudgetHealthStatus `json:"overall_health"`
 
@@ -175,6 +194,26 @@ type ScopedBudgetResult struct {
 
 	// Warnings contains all warnings generated during evaluation.
 	Warnings []string `json:"warnings,omitempty"`
+
+	// Metric is BudgetMetricCarbon for carbon budgets and empty for cost budgets.
+	Metric string `json:"metric,omitempty"`
+}
+
+// UnallocatedSpend is the cost of resources that match no tag budget, such as
+// untagged resources. It makes spend outside every team's budget visible
+// instead of silently dropping it from the tag scopes.
+type UnallocatedSpend struct {
+	// CurrentSpend is the total cost of resources matching no tag budget.
+	CurrentSpend float64 `json:"current_spend"`
+
+	// Percentage is CurrentSpend as a share of all evaluated spend.
+	Percentage float64 `json:"percentage"`
+
+	// Resources is the number of resources matching no tag budget.
+	Resources int `json:"resources"`
+
+	// Currency is the currency for display.
+	Currency string `json:"currency,omitempty"`
 }
 
 // HasExceededBudgets returns true if any budget has EXCEEDED health status.
@@ -224,6 +263,16 @@ func (r *ScopedBudgetResult) AllScopes() []*ScopedBudgetStatus {
 		scopes = append(scopes, r.ByType[key])
 	}
 
+	// Add stack scopes (sorted by key for deterministic output)
+	stackKeys := make([]string, 0, len(r.ByStack))
+	for key := range r.ByStack {
+		stackKeys = append(stackKeys, key)
+	}
+	sort.Strings(stackKeys)
+	for _, key := range stackKeys {
+		scopes = append(scopes, r.ByStack[key])
+	}
+
 	return scopes
 }
 
@@ -234,10 +283,13 @@ func (r *ScopedBudgetResult) AllScopes() []*ScopedBudgetStatus {
 //   - "azure:compute/virtualMachine" -> "azure"
 //   - "unknown" -> "unknown"
 //   - ":ec2/instance" -> "" (colon at start, no provider)
+//
+// A provider namespace configured in cost.provider_aliases is replaced by the
+// provider it aliases, e.g. "my-org:aws-wrapper:Instance" -> "aws".
 func ExtractProvider(resourceType string) string {
 	idx := strings.Index(resourceType, ":")
 	if idx > 0 {
-		return strings.ToLower(resourceType[:idx])
+		return strings.ToLower(skus.CanonicalProvider(resourceType[:idx]))
 	}
 	if idx == 0 {
 		// Colon at start means no valid provider
@@ -246,6 +298,38 @@ func ExtractProvider(resourceType string) string {
 	return strings.ToLower(resourceType)
 }
 
+// ExtractStackFromURN extracts the Pulumi stack name from a resource URN.
+// URNs have the form "urn:pulumi:<stack>::<project>::<type>::<name>".
+// Examples:
+//   - "urn:pulumi:prod::webapp::aws:ec2/instance:Instance::web" -> "prod"
+//   - "i-0abc123" -> "" (not a URN)
+func ExtractStackFromURN(urn string) string {
+	rest, ok := strings.CutPrefix(urn, pulumiURNPrefix)
+	if !ok {
+		return ""
+	}
+	stack, _, found := strings.Cut(rest, "::")
+	if !found {
+		return ""
+	}
+	return stack
+}
+
+// ResolveActiveStack determines the Pulumi stack a set of cost results belongs to.
+// An explicit override (e.g., the --stack flag) wins; otherwise the stack segment of
+// the first resource URN is used. Returns an empty string when no stack can be resolved.
+func ResolveActiveStack(results []CostResult, override string) string {
+	if override != "" {
+		return override
+	}
+	for _, r := range results {
+		if stack := ExtractStackFromURN(r.ResourceID); stack != "" {
+			return stack
+		}
+	}
+	return ""
+}
+
 // CalculateHealthFromPercentage calculates health status from a raw utilization percentage.
 // This is a convenience wrapper around CalculateBudgetHealthFromPercentage for scoped budgets.
 func CalculateHealthFromPercentage(percentage float64) pbc.BudgetHealthStatus {
@@ -293,6 +377,16 @@ type ScopedBudgetEvaluator struct {
 
 	// typeIndex maps resource types to their budgets.
 	typeIndex map[string]*config.ScopedBudget
+
+	// stackIndex maps Pulumi stack names to their budgets.
+	stackIndex map[string]*config.ScopedBudget
+
+	// history provides prior-period spend for budgets with rollover enabled.
+	// Nil disables rollover.
+	history SpendHistory
+
+	// now returns the current time used for period boundaries (injectable for testing).
+	now func() time.Time
 }
 
 // NewScopedBudgetEvaluator creates a new evaluator for the given configuration.
@@ -306,6 +400,8 @@ func NewScopedBudgetEvaluator(cfg *config.BudgetsConfig) *ScopedBudgetEvaluator
 			providerIndex: make(map[string]*config.ScopedBudget),
 			tagBudgets:    nil,
 			typeIndex:     make(map[string]*config.ScopedBudget),
+			stackIndex:    make(map[string]*config.ScopedBudget),
+			now:           time.Now,
 		}
 	}
 
@@ -353,12 +449,23 @@ func NewScopedBudgetEvaluator(cfg *config.BudgetsConfig) *ScopedBudgetEvaluator
 		typeIndex[name] = budget
 	}
 
+	// Build stack index (skip nil and disabled budgets)
+	stackIndex := make(map[string]*config.ScopedBudget, len(cfg.Stacks))
+	for name, budget := range cfg.Stacks {
+		if budget == nil || budget.IsDisabled() {
+			continue
+		}
+		stackIndex[name] = budget
+	}
+
 	return &ScopedBudgetEvaluator{
 		config:        cfg,
 		providerIndex: providerIndex,
 		tagBudgets:    tagBudgets,
 		parsedTags:    parsedTags,
 		typeIndex:     typeIndex,
+		stackIndex:    stackIndex,
+		now:           time.Now,
 	}
 }
 
@@ -372,6 +479,16 @@ func (e *ScopedBudgetEvaluator) GetTypeBudget(resourceType string) *config.Scope
 	return e.typeIndex[resourceType]
 }
 
+// GetStackBudget returns the budget for a Pulumi stack, or nil if not configured.
+func (e *ScopedBudgetEvaluator) GetStackBudget(stack string) *config.ScopedBudget {
+	return e.stackIndex[stack]
+}
+
+// HasTagBudgets returns true if any valid tag budget is configured.
+func (e *ScopedBudgetEvaluator) HasTagBudgets() bool {
+	return len(e.parsedTags) > 0
+}
+
 // MatchTagBudgets returns all tag budgets that match the given tags.
 // Results are returned in priority order (highest first).
 // Uses pre-parsed selectors for efficiency (parsed once in NewScopedBudgetEvaluator).
@@ -665,6 +782,76 @@ func CalculateTypeBudgetStatus(
 	return status
 }
 
+// AllocateCostToStack allocates a resource's cost to its Pulumi stack budget.
+// The stack is taken from the resource URN, falling back to activeStack when the
+// resource ID is not a URN. Returns a BudgetAllocation with stack scope if a
+// matching budget exists.
+func (e *ScopedBudgetEvaluator) AllocateCostToStack(
+	ctx context.Context,
+	resourceID string,
+	resourceType string,
+	activeStack string,
+	cost float64,
+) *BudgetAllocation {
+	allocation := &BudgetAllocation{
+		ResourceID:      resourceID,
+		ResourceType:    resourceType,
+		Provider:        ExtractProvider(resourceType),
+		Cost:            cost,
+		AllocatedScopes: []string{},
+	}
+
+	stack := ExtractStackFromURN(resourceID)
+	if stack == "" {
+		stack = activeStack
+	}
+	if stack == "" || e.GetStackBudget(stack) == nil {
+		return allocation
+	}
+
+	allocation.AllocatedScopes = append(allocation.AllocatedScopes, fmt.Sprintf("stack:%s", stack))
+
+	logger := logging.FromContext(ctx).With().
+		Str("component", "engine").
+		Str("operation", "AllocateCostToStack").
+		Logger()
+
+	logger.Debug().
+		Str("resource_id", resourceID).
+		Str("stack", stack).
+		Float64("cost", cost).
+		Msg("allocated cost to stack budget")
+
+	return allocation
+}
+
+// CalculateStackBudgetStatus calculates the budget status for a Pulumi stack scope.
+func CalculateStackBudgetStatus(
+	stack string,
+	budget *config.ScopedBudget,
+	currentSpend float64,
+) *ScopedBudgetStatus {
+	var percentage float64
+	if budget.Amount > 0 {
+		percentage = (currentSpend / budget.Amount) * percentageMultiplier
+	}
+
+	health := CalculateHealthFromPercentage(percentage)
+
+	status := &ScopedBudgetStatus{
+		ScopeType:    ScopeTypeStack,
+		ScopeKey:     stack,
+		Budget:       *budget,
+		CurrentSpend: currentSpend,
+		Percentage:   percentage,
+		Health:       health,
+		Currency:     budget.Currency,
+	}
+
+	enrichScopedBudgetStatus(status, budget)
+	return status
+}
+
 // AllocateCosts allocates a resource's cost to all applicable budget scopes.
 // This is the main entry point for multi-scope cost allocation.
 // Returns a BudgetAllocation with all scopes that received the cost.
@@ -817,6 +1004,11 @@ func CalculateOverallHealth(result *ScopedBudgetResult) pbc.BudgetHealthStatus {
 		statuses = append(statuses, status.Health)
 	}
 
+	// Collect health from stacks
+	for _, status := range result.ByStack {
+		statuses = append(statuses, status.Health)
+	}
+
 	return AggregateHealthStatuses(statuses)
 }
 
@@ -865,6 +1057,18 @@ func IdentifyCriticalScopes(result *ScopedBudgetResult) []string {
 		}
 	}
 
+	// Check stacks (sorted for deterministic output)
+	stackKeys := make([]string, 0, len(result.ByStack))
+	for key := range result.ByStack {
+		stackKeys = append(stackKeys, key)
+	}
+	sort.Strings(stackKeys)
+	for _, key := range stackKeys {
+		if isCriticalOrExceeded(result.ByStack[key].Health) {
+			criticalScopes = append(criticalScopes, result.ByStack[key].ScopeIdentifier())
+		}
+	}
+
 	return criticalScopes
 }
 
@@ -110,6 +110,12 @@ func (s *FileStore) Get(key string) (*CacheEntry, error) {
 // Set stores a cache entry with the given key and data.
 // If the entry already exists, it will be overwritten.
 func (s *FileStore) Set(key string, data json.RawMessage) error {
+	return s.SetWithTTL(key, data, s.ttlSeconds)
+}
+
+// SetWithTTL stores a cache entry that expires after ttlSeconds instead of the
+// store's default TTL. A non-positive ttlSeconds uses the default.
+func (s *FileStore) SetWithTTL(key string, data json.RawMessage, ttlSeconds int) error {
 	if !s.enabled {
 		return ErrCacheDisabled
 	}
@@ -121,7 +127,10 @@ func (s *FileStore) Set(key string, data json.RawMessage) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	entry := NewCacheEntry(key, data, s.ttlSeconds)
+	if ttlSeconds <= 0 {
+		ttlSeconds = s.ttlSeconds
+	}
+	entry := NewCacheEntry(key, data, ttlSeconds)
 	entryData, err := json.MarshalIndent(entry, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal cache entry: %w", err)
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// otherServiceLabel labels invoice lines for resources without a type.
+const otherServiceLabel = "other"
+
+// ChargebackLine is the cost of one service on a team's invoice.
+type ChargebackLine struct {
+	// Service is the provider and service of the resources, e.g. "aws:ec2".
+	Service string `json:"service"`
+	// Resources is the number of distinct resources billed on the line.
+	Resources int `json:"resources"`
+	// Direct is the cost of resources the team owns.
+	Direct float64 `json:"direct"`
+	// Shared is the team's share of shared pools.
+	Shared float64 `json:"shared"`
+	Amount float64 `json:"amount"`
+}
+
+// ChargebackInvoice bills one team for a period.
+type ChargebackInvoice struct {
+	// Number identifies the invoice, e.g. "2025-03-payments".
+	Number   string           `json:"number"`
+	Team     string           `json:"team"`
+	Currency string           `json:"currency"`
+	Lines    []ChargebackLine `json:"lines"`
+
+	Subtotal        float64 `json:"subtotal"`
+	MarkupPercent   float64 `json:"markupPercent,omitempty"`
+	Markup          float64 `json:"markup,omitempty"`
+	DiscountPercent float64 `json:"discountPercent,omitempty"`
+	Discount        float64 `json:"discount,omitempty"`
+	Total           float64 `json:"total"`
+}
+
+// ChargebackReport holds the invoices of every team for a period and their summary.
+type ChargebackReport struct {
+	Period   string    `json:"period"`
+	From     time.Time `json:"from"`
+	To       time.Time `json:"to"`
+	Issuer   string    `json:"issuer,omitempty"`
+	Currency string    `json:"currency"`
+	// MixedCurrencies is true when the costs use more than one currency;
+	// totals are then sums of unconverted amounts.
+	MixedCurrencies bool `json:"mixedCurrencies,omitempty"`
+
+	Subtotal float64 `json:"subtotal"`
+	Markup   float64 `json:"markup"`
+	Discount float64 `json:"discount"`
+	Total    float64 `json:"total"`
+
+	Invoices []ChargebackInvoice `json:"invoices"`
+}
+
+// ChargebackInput carries the data needed to build a ChargebackReport.
+type ChargebackInput struct {
+	// Period labels the invoices, e.g. "2025-03".
+	Period     string
+	From       time.Time
+	To         time.Time
+	Allocation *AllocationReport
+	Config     *config.ChargebackConfig
+}
+
+// BuildChargeback turns an allocation into one invoice per team. Each
+// invoice has a line per service holding the team's direct cost and its
+// share of shared pools, and applies the team's markup and discount from
+// the chargeback config to the subtotal. Invoices are ordered by total,
+// largest first, matching the allocation's team order.
+func BuildChargeback(input ChargebackInput) *ChargebackReport {
+	alloc := input.Allocation
+	if alloc == nil {
+		alloc = &AllocationReport{Currency: defaultCurrency}
+	}
+	report := &ChargebackReport{
+		Period:          input.Period,
+		From:            input.From,
+		To:              input.To,
+		Currency:        alloc.Currency,
+		MixedCurrencies: alloc.MixedCurrencies,
+	}
+	if input.Config != nil {
+		report.Issuer = input.Config.Issuer
+	}
+
+	linesByTeam := make(map[string][]AllocationLine)
+	for _, line := range alloc.Lines {
+		linesByTeam[line.Team] = append(linesByTeam[line.Team], line)
+	}
+
+	for _, team := range alloc.Teams {
+		invoice := ChargebackInvoice{
+			Number:   input.Period + "-" + team.Team,
+			Team:     team.Team,
+			Currency: alloc.Currency,
+			Lines:    chargebackLines(linesByTeam[team.Team]),
+		}
+		for _, line := range invoice.Lines {
+			invoice.Subtotal += line.Amount
+		}
+
+		rates := input.Config.RatesFor(team.Team)
+		invoice.MarkupPercent = rates.MarkupPercent
+		invoice.DiscountPercent = rates.DiscountPercent
+		invoice.Markup = invoice.Subtotal * rates.MarkupPercent / driftPercentMultiplier
+		invoice.Discount = invoice.Subtotal * rates.DiscountPercent / driftPercentMultiplier
+		invoice.Total = invoice.Subtotal + invoice.Markup - invoice.Discount
+
+		report.Subtotal += invoice.Subtotal
+		report.Markup += invoice.Markup
+		report.Discount += invoice.Discount
+		report.Total += invoice.Total
+		report.Invoices = append(report.Invoices, invoice)
+	}
+	return report
+}
+
+// chargebackLines groups a team's allocation lines by service, largest amount first.
+func chargebackLines(lines []AllocationLine) []ChargebackLine {
+	byService := make(map[string]*ChargebackLine)
+	resources := make(map[string]map[string]bool)
+	for _, l := range lines {
+		service := chargebackService(l.ResourceType)
+		line, ok := byService[service]
+		if !ok {
+			line = &ChargebackLine{Service: service}
+			byService[service] = line
+			resources[service] = make(map[string]bool)
+		}
+		if l.Pool != "" {
+			line.Shared += l.Amount
+		} else {
+			line.Direct += l.Amount
+		}
+		line.Amount += l.Amount
+		resources[service][l.ResourceID] = true
+	}
+
+	out := make([]ChargebackLine, 0, len(byService))
+	for service, line := range byService {
+		line.Resources = len(resources[service])
+		out = append(out, *line)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Amount != out[j].Amount {
+			return out[i].Amount > out[j].Amount
+		}
+		return out[i].Service < out[j].Service
+	})
+	return out
+}
+
+// chargebackService returns the "provider:service" label of a resource type,
+// e.g. "aws:ec2" for "aws:ec2/instance:Instance". Types without a service are
+// labeled with their provider, or "other" when that is empty too.
+func chargebackService(resourceType string) string {
+	provider := ExtractProvider(resourceType)
+	if !strings.Contains(resourceType, ":") || provider == "" {
+		return cmp.Or(provider, otherServiceLabel)
+	}
+	return provider + ":" + extractService(resourceType)
+}
@@ -0,0 +1,82 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestBuildChargeback(t *testing.T) {
+	alloc := AllocateCosts(allocationItems(), &config.AllocationConfig{
+		Rules:  []config.AllocationRule{{Selector: "project:recs", Team: "data"}},
+		Shared: []config.SharedCostPool{{Name: "network", Selector: "shared:network"}},
+	})
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+
+	report := BuildChargeback(ChargebackInput{
+		Period:     "2025-03",
+		From:       from,
+		To:         from.AddDate(0, 1, 0),
+		Allocation: alloc,
+		Config: &config.ChargebackConfig{
+			Issuer:          "Platform",
+			ChargebackRates: config.ChargebackRates{MarkupPercent: 10},
+			Teams:           map[string]config.ChargebackRates{"frontend": {DiscountPercent: 20}},
+		},
+	})
+
+	require.Len(t, report.Invoices, len(alloc.Teams))
+	assert.Equal(t, "Platform", report.Issuer)
+	assert.Equal(t, "USD", report.Currency)
+
+	payments := report.Invoices[0]
+	assert.Equal(t, "2025-03-payments", payments.Number)
+	require.Len(t, payments.Lines, 1, "own instance and shared NAT gateway are both aws:ec2")
+	line := payments.Lines[0]
+	assert.Equal(t, "aws:ec2", line.Service)
+	assert.Equal(t, 2, line.Resources)
+	assert.InDelta(t, 300, line.Direct, 1e-9)
+	assert.InDelta(t, 13.333333, line.Shared, 1e-6, "network pool split evenly between three teams")
+	assert.InDelta(t, 313.333333, payments.Subtotal, 1e-6)
+	assert.InDelta(t, 31.333333, payments.Markup, 1e-6)
+	assert.InDelta(t, 344.666667, payments.Total, 1e-6)
+
+	var frontend ChargebackInvoice
+	for _, inv := range report.Invoices {
+		if inv.Team == "frontend" {
+			frontend = inv
+		}
+	}
+	assert.Zero(t, frontend.Markup, "team override replaces the default markup")
+	assert.InDelta(t, 20, frontend.DiscountPercent, 1e-9)
+	assert.InDelta(t, frontend.Subtotal*0.8, frontend.Total, 1e-9)
+
+	sum := 0.0
+	for _, inv := range report.Invoices {
+		sum += inv.Total
+	}
+	assert.InDelta(t, sum, report.Total, 1e-9)
+	assert.InDelta(t, alloc.Total, report.Subtotal, 1e-9, "every allocated cost is invoiced")
+}
+
+func TestBuildChargeback_NoAllocation(t *testing.T) {
+	report := BuildChargeback(ChargebackInput{Period: "2025-03"})
+	assert.Empty(t, report.Invoices)
+	assert.Equal(t, defaultCurrency, report.Currency)
+}
+
+func TestChargebackService(t *testing.T) {
+	tests := map[string]string{
+		"aws:ec2/instance:Instance":     "aws:ec2",
+		"gcp:compute/instance:Instance": "gcp:compute",
+		"kubernetes":                    "kubernetes",
+		"":                              otherServiceLabel,
+	}
+	for resourceType, want := range tests {
+		assert.Equal(t, want, chargebackService(resourceType), resourceType)
+	}
+}
@@ -0,0 +1,78 @@
+package engine
+
+import "github.com/rshade/finfocus/internal/engine/spill"
+
+// resultCollector gathers the results of resources priced concurrently in
+// resource order. Results in order move to a spill.Store, which writes them
+// to disk past its threshold, so a very large stack keeps in memory only the
+// results that arrived ahead of an earlier resource still being priced. The
+// store is handed to the caller rather than read back.
+type resultCollector struct {
+	store   *spill.Store[CostResult]
+	errors  []ErrorDetail
+	pending map[int]collectedResource
+	next    int
+
+	// threshold is the spill threshold of store.
+	threshold int
+}
+
+// collectedResource holds the results and errors of one resource.
+type collectedResource struct {
+	results []CostResult
+	errors  []ErrorDetail
+}
+
+// newResultCollector creates a collector whose store spills past threshold
+// results, or spill.DefaultThreshold when threshold is zero or less.
+func newResultCollector(threshold int) *resultCollector {
+	return &resultCollector{
+		store:     spill.New[CostResult](threshold, ""),
+		pending:   make(map[int]collectedResource),
+		threshold: threshold,
+	}
+}
+
+// add records the results and errors of the resource at index. The results
+// of every resource now in order go to fn, which may change them, and then
+// to the store; resources without results are skipped.
+func (c *resultCollector) add(index int, results []CostResult, errors []ErrorDetail, fn ResultBatchFunc) error {
+	c.pending[index] = collectedResource{results: results, errors: errors}
+	for next, ok := c.pending[c.next]; ok; next, ok = c.pending[c.next] {
+		delete(c.pending, c.next)
+		c.next++
+		c.errors = append(c.errors, next.errors...)
+		if len(next.results) == 0 {
+			continue
+		}
+		if fn != nil {
+			if err := fn(next.results); err != nil {
+				return err
+			}
+		}
+		if err := c.store.Append(next.results...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// take hands the collected results and errors in resource order to the
+// caller, who must close them.
+func (c *resultCollector) take() *CostResultStore {
+	taken := &CostResultStore{Results: c.store, Errors: c.errors, threshold: c.threshold}
+	if taken.Errors == nil {
+		taken.Errors = []ErrorDetail{}
+	}
+	c.store = nil
+	return taken
+}
+
+// close removes any file the results spilled to, unless take handed them
+// over.
+func (c *resultCollector) close() error {
+	if c.store == nil {
+		return nil
+	}
+	return c.store.Close()
+}
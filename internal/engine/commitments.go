@@ -0,0 +1,471 @@
+package engine
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Types of commitment.
+const (
+	CommitmentReservedInstance = "reserved_instance"
+	CommitmentSavingsPlan      = "savings_plan"
+)
+
+// Metadata keys of a budget that describes a commitment. Plugins report
+// their commitment inventory through GetBudgets: a budget with
+// CommitmentTypeKey set is a commitment rather than a spending limit.
+const (
+	// CommitmentTypeKey is CommitmentReservedInstance or CommitmentSavingsPlan.
+	CommitmentTypeKey = "commitment_type"
+	// CommitmentInstanceTypeKey is the instance type a reservation covers.
+	CommitmentInstanceTypeKey = "instance_type"
+	// CommitmentCountKey is the number of instances reserved (default 1).
+	CommitmentCountKey = "count"
+	// CommitmentHourlyKey is the hourly spend of a savings plan; without it
+	// the budget amount is taken as the monthly commitment.
+	CommitmentHourlyKey = "hourly_commitment"
+	// CommitmentRegionKey limits a reservation to one region.
+	CommitmentRegionKey = "region"
+	// CommitmentExpiresKey is the end of the term, in RFC 3339 or YYYY-MM-DD.
+	CommitmentExpiresKey = "expires"
+)
+
+// Finding kinds of a commitment report.
+const (
+	CommitmentFindingPurchase      = "purchase"
+	CommitmentFindingUnderutilized = "underutilized"
+	CommitmentFindingExpiring      = "expiring"
+)
+
+// Defaults of CommitmentOptions.
+const (
+	// DefaultReservationDiscount is the typical saving of a reservation over
+	// on-demand, used to estimate purchase opportunities.
+	DefaultReservationDiscount = 0.3
+	// DefaultUnderutilizedPercent flags commitments used below this level.
+	DefaultUnderutilizedPercent = 80.0
+	// DefaultExpiringWithin flags commitments ending within this time.
+	DefaultExpiringWithin = 30 * 24 * time.Hour
+)
+
+// Commitment is a reserved instance or savings plan reported by a plugin.
+type Commitment struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Source string `json:"source,omitempty"`
+	Type   string `json:"type"`
+	// InstanceType, Region, and Count describe a reservation.
+	InstanceType string `json:"instanceType,omitempty"`
+	Region       string `json:"region,omitempty"`
+	Count        int    `json:"count,omitempty"`
+	// Hourly is the hourly spend a savings plan commits to.
+	Hourly   float64    `json:"hourly,omitempty"`
+	Currency string     `json:"currency"`
+	Expires  *time.Time `json:"expires,omitempty"`
+}
+
+// CommitmentFromBudget returns the commitment a budget describes, or nil
+// when the budget has no CommitmentTypeKey metadata.
+func CommitmentFromBudget(b *pbc.Budget) (*Commitment, error) {
+	metadata := b.GetMetadata()
+	kind := metadata[CommitmentTypeKey]
+	if kind == "" {
+		return nil, nil //nolint:nilnil // A budget without commitment metadata is not a commitment.
+	}
+
+	c := &Commitment{
+		ID:       b.GetId(),
+		Name:     cmp.Or(b.GetName(), b.GetId()),
+		Source:   b.GetSource(),
+		Type:     kind,
+		Region:   metadata[CommitmentRegionKey],
+		Currency: cmp.Or(b.GetAmount().GetCurrency(), defaultCurrency),
+	}
+	switch kind {
+	case CommitmentReservedInstance:
+		c.InstanceType = metadata[CommitmentInstanceTypeKey]
+		if c.InstanceType == "" {
+			return nil, fmt.Errorf("commitment %s: reserved instance without %s", c.ID, CommitmentInstanceTypeKey)
+		}
+		c.Count = 1
+		if count := metadata[CommitmentCountKey]; count != "" {
+			n, err := strconv.Atoi(count)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("commitment %s: invalid %s %q", c.ID, CommitmentCountKey, count)
+			}
+			c.Count = n
+		}
+	case CommitmentSavingsPlan:
+		c.Hourly = b.GetAmount().GetLimit() / hoursPerMonth
+		if hourly := metadata[CommitmentHourlyKey]; hourly != "" {
+			h, err := strconv.ParseFloat(hourly, 64)
+			if err != nil || h <= 0 {
+				return nil, fmt.Errorf("commitment %s: invalid %s %q", c.ID, CommitmentHourlyKey, hourly)
+			}
+			c.Hourly = h
+		}
+		if c.Hourly <= 0 {
+			return nil, fmt.Errorf("commitment %s: savings plan without an amount or %s", c.ID, CommitmentHourlyKey)
+		}
+	default:
+		return nil, fmt.Errorf("commitment %s: unknown %s %q", c.ID, CommitmentTypeKey, kind)
+	}
+
+	if expires := metadata[CommitmentExpiresKey]; expires != "" {
+		t, err := time.Parse(time.RFC3339, expires)
+		if err != nil {
+			t, err = time.Parse("2006-01-02", expires)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("commitment %s: invalid %s %q", c.ID, CommitmentExpiresKey, expires)
+		}
+		c.Expires = &t
+	}
+	return c, nil
+}
+
+// GetCommitments collects the commitment inventory of all plugins from the
+// budgets they report. Plugins that fail, and budgets that describe invalid
+// commitments, are reported in the returned errors.
+func (e *Engine) GetCommitments(ctx context.Context) ([]Commitment, []error) {
+	logger := logging.FromContext(ctx).With().
+		Str("component", "engine").
+		Str("operation", "GetCommitments").
+		Logger()
+
+	var commitments []Commitment
+	var errs []error
+	for _, client := range e.clients {
+		resp, err := client.API.GetBudgets(ctx, &pbc.GetBudgetsRequest{})
+		if err != nil {
+			logger.Warn().Str("plugin", client.Name).Err(err).Msg("failed to get commitments from plugin")
+			errs = append(errs, fmt.Errorf("plugin %s: %w", client.Name, err))
+			continue
+		}
+		for _, b := range resp.GetBudgets() {
+			c, parseErr := CommitmentFromBudget(b)
+			if parseErr != nil {
+				errs = append(errs, fmt.Errorf("plugin %s: %w", client.Name, parseErr))
+				continue
+			}
+			if c != nil {
+				commitments = append(commitments, *c)
+			}
+		}
+	}
+
+	logger.Debug().Int("commitment_count", len(commitments)).Msg("commitment retrieval complete")
+	return commitments, errs
+}
+
+// CommitmentOptions tunes AnalyzeCommitments.
+type CommitmentOptions struct {
+	// Now is the time expiry is measured from.
+	Now time.Time
+	// ReservationDiscount is the estimated saving of a reservation, from 0
+	// to 1; zero uses DefaultReservationDiscount.
+	ReservationDiscount float64
+	// UnderutilizedPercent flags commitments used below it; zero uses
+	// DefaultUnderutilizedPercent.
+	UnderutilizedPercent float64
+	// ExpiringWithin flags commitments ending within it; zero uses
+	// DefaultExpiringWithin.
+	ExpiringWithin time.Duration
+}
+
+// CommitmentUtilization is how much of one commitment current usage uses.
+type CommitmentUtilization struct {
+	Commitment
+	// Used is the number of instances a reservation covers, or the hourly
+	// spend a savings plan covers.
+	Used float64 `json:"used"`
+	// Committed is Count for a reservation and Hourly for a savings plan.
+	Committed   float64 `json:"committed"`
+	Utilization float64 `json:"utilizationPercent"`
+}
+
+// CommitmentCoverage is the share of one instance type's usage that
+// commitments cover.
+type CommitmentCoverage struct {
+	InstanceType string `json:"instanceType"`
+	Region       string `json:"region,omitempty"`
+	Running      int    `json:"running"`
+	Reserved     int    `json:"reserved"`
+	// Uncovered counts the instances commitments do not fully cover.
+	Uncovered int `json:"uncovered"`
+	// Monthly is the on-demand cost of the instances and UncoveredMonthly
+	// the part of it that commitments do not cover.
+	Monthly          float64 `json:"monthly"`
+	UncoveredMonthly float64 `json:"uncoveredMonthly"`
+	Coverage         float64 `json:"coveragePercent"`
+}
+
+// CommitmentFinding is a purchase opportunity or a problem with a commitment.
+type CommitmentFinding struct {
+	Kind    string `json:"kind"`
+	Message string `json:"message"`
+	// MonthlySavings estimates what acting on the finding saves per month.
+	MonthlySavings float64 `json:"monthlySavings,omitempty"`
+}
+
+// CommitmentReport compares commitments with the usage of a set of resources.
+type CommitmentReport struct {
+	Currency string `json:"currency"`
+	// EligibleHourly is the on-demand hourly cost of compute resources that
+	// commitments can cover, EligibleMonthly its monthly equivalent, and
+	// CoveredHourly the part commitments cover.
+	EligibleHourly          float64                 `json:"eligibleHourly"`
+	EligibleMonthly         float64                 `json:"eligibleMonthly"`
+	CoveredHourly           float64                 `json:"coveredHourly"`
+	Coverage                float64                 `json:"coveragePercent"`
+	Commitments             []CommitmentUtilization `json:"commitments"`
+	InstanceTypes           []CommitmentCoverage    `json:"instanceTypes"`
+	Findings                []CommitmentFinding     `json:"findings"`
+	PotentialMonthlySavings float64                 `json:"potentialMonthlySavings"`
+}
+
+// commitmentUsage is one compute resource that commitments can cover.
+type commitmentUsage struct {
+	instanceType string
+	region       string
+	hourly       float64
+	covered      float64
+}
+
+// AnalyzeCommitments compares commitments with the priced compute resources.
+// Reservations cover running instances of their instance type (and region,
+// when both are known) up to their count. Savings plans then cover the
+// remaining compute spend up to their hourly commitment, in order. Running
+// instances no reservation or plan covers are purchase opportunities;
+// commitments used below opts.UnderutilizedPercent or ending within
+// opts.ExpiringWithin are flagged as well.
+func AnalyzeCommitments(
+	commitments []Commitment,
+	resources []ResourceDescriptor,
+	results []CostResult,
+	opts CommitmentOptions,
+) *CommitmentReport {
+	opts = commitmentDefaults(opts)
+	report := &CommitmentReport{
+		Currency:      defaultCurrency,
+		Commitments:   make([]CommitmentUtilization, 0, len(commitments)),
+		InstanceTypes: []CommitmentCoverage{},
+		Findings:      []CommitmentFinding{},
+	}
+	if currency := commitmentCurrency(results); currency != "" {
+		report.Currency = currency
+	}
+
+	usage := commitmentUsages(resources, results)
+	for _, u := range usage {
+		report.EligibleHourly += u.hourly
+	}
+	report.EligibleMonthly = report.EligibleHourly * hoursPerMonth
+
+	// Reservations first, since they are the most specific.
+	sorted := make([]Commitment, len(commitments))
+	copy(sorted, commitments)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Type == CommitmentReservedInstance && sorted[j].Type != CommitmentReservedInstance
+	})
+	reserved := make(map[string]int)
+	for _, c := range sorted {
+		util := CommitmentUtilization{Commitment: c}
+		switch c.Type {
+		case CommitmentReservedInstance:
+			util.Committed = float64(c.Count)
+			for _, u := range usage {
+				if int(util.Used) == c.Count {
+					break
+				}
+				if u.covered == 0 && strings.EqualFold(u.instanceType, c.InstanceType) &&
+					(c.Region == "" || u.region == "" || c.Region == u.region) {
+					u.covered = u.hourly
+					util.Used++
+				}
+			}
+			reserved[strings.ToLower(c.InstanceType)] += c.Count
+		case CommitmentSavingsPlan:
+			util.Committed = c.Hourly
+			for _, u := range usage {
+				if remaining := c.Hourly - util.Used; remaining > 0 && u.covered < u.hourly {
+					covered := min(u.hourly-u.covered, remaining)
+					u.covered += covered
+					util.Used += covered
+				}
+			}
+		}
+		if util.Committed > 0 {
+			util.Utilization = util.Used / util.Committed * percentageMultiplier
+		}
+		report.Commitments = append(report.Commitments, util)
+	}
+
+	for _, u := range usage {
+		report.CoveredHourly += u.covered
+	}
+	if report.EligibleHourly > 0 {
+		report.Coverage = report.CoveredHourly / report.EligibleHourly * percentageMultiplier
+	}
+
+	report.InstanceTypes = commitmentCoverageByType(usage, reserved)
+	report.Findings = commitmentFindings(report, opts)
+	for _, f := range report.Findings {
+		report.PotentialMonthlySavings += f.MonthlySavings
+	}
+	return report
+}
+
+// commitmentDefaults fills the zero fields of opts.
+func commitmentDefaults(opts CommitmentOptions) CommitmentOptions {
+	if opts.Now.IsZero() {
+		opts.Now = time.Now()
+	}
+	if opts.ReservationDiscount <= 0 {
+		opts.ReservationDiscount = DefaultReservationDiscount
+	}
+	if opts.UnderutilizedPercent <= 0 {
+		opts.UnderutilizedPercent = DefaultUnderutilizedPercent
+	}
+	if opts.ExpiringWithin <= 0 {
+		opts.ExpiringWithin = DefaultExpiringWithin
+	}
+	return opts
+}
+
+// commitmentCurrency returns the currency of the first priced result.
+func commitmentCurrency(results []CostResult) string {
+	for _, r := range results {
+		if r.Error == nil && r.Currency != "" {
+			return r.Currency
+		}
+	}
+	return ""
+}
+
+// commitmentUsages returns the priced compute resources, largest hourly
+// cost first so commitments cover the most expensive usage.
+func commitmentUsages(resources []ResourceDescriptor, results []CostResult) []*commitmentUsage {
+	byID := make(map[string]ResourceDescriptor, len(resources))
+	for _, r := range resources {
+		byID[r.ID] = r
+	}
+
+	var usage []*commitmentUsage
+	for _, result := range results {
+		hourly := result.Hourly
+		if hourly <= 0 {
+			hourly = result.Monthly / hoursPerMonth
+		}
+		if result.Error != nil || hourly <= 0 || resourceTypeDimension(result.ResourceType) != DimensionCompute {
+			continue
+		}
+		resource := byID[result.ResourceID]
+		instanceType, _ := getStringProperty(resource.Properties, "instanceType")
+		if instanceType == "" {
+			instanceType, _ = getStringProperty(resource.Properties, "instanceClass")
+		}
+		usage = append(usage, &commitmentUsage{
+			instanceType: instanceType,
+			region:       resourceRegion(resource.Properties),
+			hourly:       hourly,
+		})
+	}
+	sort.SliceStable(usage, func(i, j int) bool { return usage[i].hourly > usage[j].hourly })
+	return usage
+}
+
+// commitmentCoverageByType summarizes the coverage of each instance type,
+// least covered first.
+func commitmentCoverageByType(usage []*commitmentUsage, reserved map[string]int) []CommitmentCoverage {
+	byType := make(map[string]*CommitmentCoverage)
+	var order []string
+	for _, u := range usage {
+		if u.instanceType == "" {
+			continue
+		}
+		key := u.instanceType + "\x00" + u.region
+		coverage, ok := byType[key]
+		if !ok {
+			coverage = &CommitmentCoverage{InstanceType: u.instanceType, Region: u.region}
+			byType[key] = coverage
+			order = append(order, key)
+		}
+		coverage.Running++
+		coverage.Monthly += u.hourly * hoursPerMonth
+		if u.covered < u.hourly {
+			coverage.Uncovered++
+			coverage.UncoveredMonthly += (u.hourly - u.covered) * hoursPerMonth
+		}
+	}
+
+	out := make([]CommitmentCoverage, 0, len(order))
+	for _, key := range order {
+		coverage := byType[key]
+		coverage.Reserved = reserved[strings.ToLower(coverage.InstanceType)]
+		coverage.Coverage = (coverage.Monthly - coverage.UncoveredMonthly) / coverage.Monthly * percentageMultiplier
+		out = append(out, *coverage)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if out[i].Coverage != out[j].Coverage {
+			return out[i].Coverage < out[j].Coverage
+		}
+		return out[i].Monthly > out[j].Monthly
+	})
+	return out
+}
+
+// commitmentFindings lists purchase opportunities for uncovered instances,
+// then underutilized and expiring commitments.
+func commitmentFindings(report *CommitmentReport, opts CommitmentOptions) []CommitmentFinding {
+	findings := []CommitmentFinding{}
+	for _, coverage := range report.InstanceTypes {
+		if coverage.Uncovered == 0 {
+			continue
+		}
+		where := ""
+		if coverage.Region != "" {
+			where = " in " + coverage.Region
+		}
+		findings = append(findings, CommitmentFinding{
+			Kind: CommitmentFindingPurchase,
+			Message: fmt.Sprintf("Reserve %d x %s%s: %.2f %s/month runs on demand",
+				coverage.Uncovered, coverage.InstanceType, where, coverage.UncoveredMonthly, report.Currency),
+			MonthlySavings: coverage.UncoveredMonthly * opts.ReservationDiscount,
+		})
+	}
+
+	for _, c := range report.Commitments {
+		if c.Utilization < opts.UnderutilizedPercent {
+			unused := ""
+			if c.Type == CommitmentSavingsPlan {
+				unused = fmt.Sprintf(" (%.2f %s/month unused)",
+					(c.Committed-c.Used)*hoursPerMonth, c.Currency)
+			}
+			findings = append(findings, CommitmentFinding{
+				Kind:    CommitmentFindingUnderutilized,
+				Message: fmt.Sprintf("%s is %.0f%% utilized%s", c.Name, c.Utilization, unused),
+			})
+		}
+		if c.Expires != nil && c.Expires.Sub(opts.Now) <= opts.ExpiringWithin {
+			verb := "expires"
+			if c.Expires.Before(opts.Now) {
+				verb = "expired"
+			}
+			findings = append(findings, CommitmentFinding{
+				Kind:    CommitmentFindingExpiring,
+				Message: fmt.Sprintf("%s %s on %s", c.Name, verb, c.Expires.Format("2006-01-02")),
+			})
+		}
+	}
+	return findings
+}
@@ -0,0 +1,161 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+func commitmentBudget(id string, limit float64, metadata map[string]string) *pbc.Budget {
+	return &pbc.Budget{
+		Id: id, Name: id, Source: "aws-billing",
+		Amount:   &pbc.BudgetAmount{Limit: limit, Currency: "USD"},
+		Metadata: metadata,
+	}
+}
+
+func TestCommitmentFromBudget(t *testing.T) {
+	c, err := CommitmentFromBudget(commitmentBudget("ri-1", 0, map[string]string{
+		CommitmentTypeKey: CommitmentReservedInstance, CommitmentInstanceTypeKey: "m5.large",
+		CommitmentCountKey: "3", CommitmentRegionKey: "us-east-1", CommitmentExpiresKey: "2026-12-31",
+	}))
+	require.NoError(t, err)
+	require.NotNil(t, c)
+	assert.Equal(t, 3, c.Count)
+	assert.Equal(t, "us-east-1", c.Region)
+	require.NotNil(t, c.Expires)
+	assert.Equal(t, time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), *c.Expires)
+
+	sp, err := CommitmentFromBudget(commitmentBudget("sp-1", 730, map[string]string{
+		CommitmentTypeKey: CommitmentSavingsPlan,
+	}))
+	require.NoError(t, err)
+	assert.InDelta(t, 1.0, sp.Hourly, 0.001, "the amount is the monthly commitment")
+
+	sp, err = CommitmentFromBudget(commitmentBudget("sp-2", 730, map[string]string{
+		CommitmentTypeKey: CommitmentSavingsPlan, CommitmentHourlyKey: "2.5",
+	}))
+	require.NoError(t, err)
+	assert.InDelta(t, 2.5, sp.Hourly, 0.001)
+
+	none, err := CommitmentFromBudget(commitmentBudget("budget", 1000, nil))
+	require.NoError(t, err)
+	assert.Nil(t, none, "budgets without commitment metadata are not commitments")
+
+	for name, metadata := range map[string]map[string]string{
+		"unknown type":     {CommitmentTypeKey: "spot"},
+		"no instance type": {CommitmentTypeKey: CommitmentReservedInstance},
+		"bad count": {
+			CommitmentTypeKey: CommitmentReservedInstance, CommitmentInstanceTypeKey: "m5.large",
+			CommitmentCountKey: "0",
+		},
+		"no amount": {CommitmentTypeKey: CommitmentSavingsPlan},
+		"bad expires": {
+			CommitmentTypeKey: CommitmentSavingsPlan, CommitmentHourlyKey: "1", CommitmentExpiresKey: "soon",
+		},
+	} {
+		_, err := CommitmentFromBudget(commitmentBudget("bad", 0, metadata))
+		assert.Error(t, err, name)
+	}
+}
+
+func TestEngine_GetCommitments(t *testing.T) {
+	ri := commitmentBudget("ri-1", 0, map[string]string{
+		CommitmentTypeKey: CommitmentReservedInstance, CommitmentInstanceTypeKey: "m5.large",
+	})
+	invalid := commitmentBudget("ri-2", 0, map[string]string{CommitmentTypeKey: CommitmentReservedInstance})
+	spending := commitmentBudget("monthly", 1000, nil)
+	eng := New([]*pluginhost.Client{
+		{Name: "aws", API: &mockCostSourceClient{budgets: []*pbc.Budget{ri, invalid, spending}}},
+		{Name: "broken", API: &mockCostSourceClient{err: errors.New("unavailable")}},
+	}, nil)
+
+	commitments, errs := eng.GetCommitments(context.Background())
+	require.Len(t, commitments, 1)
+	assert.Equal(t, "ri-1", commitments[0].ID)
+	assert.Len(t, errs, 2)
+
+	budgets, err := eng.GetBudgets(context.Background(), nil)
+	require.NoError(t, err)
+	require.Len(t, budgets.Budgets, 1, "commitments are left out of budget health")
+	assert.Equal(t, "monthly", budgets.Budgets[0].GetId())
+}
+
+func TestAnalyzeCommitments(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	expires := now.AddDate(0, 0, 10)
+	instance := func(id, instanceType string) ResourceDescriptor {
+		return ResourceDescriptor{ID: id, Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+			"instanceType": instanceType, "availabilityZone": "us-east-1a",
+		}}
+	}
+	resources := []ResourceDescriptor{
+		instance("web-1", "m5.large"), instance("web-2", "m5.large"), instance("web-3", "m5.large"),
+		instance("batch", "c5.xlarge"),
+		{ID: "bucket", Type: "aws:s3/bucket:Bucket"},
+	}
+	results := []CostResult{
+		{ResourceID: "web-1", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Hourly: 0.1},
+		{ResourceID: "web-2", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Hourly: 0.1},
+		{ResourceID: "web-3", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Hourly: 0.1},
+		{ResourceID: "batch", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 146},
+		{ResourceID: "bucket", ResourceType: "aws:s3/bucket:Bucket", Currency: "USD", Monthly: 50},
+	}
+	commitments := []Commitment{
+		{ID: "sp", Name: "compute-sp", Type: CommitmentSavingsPlan, Hourly: 0.1, Currency: "USD"},
+		{
+			ID: "ri", Name: "web-ri", Type: CommitmentReservedInstance, InstanceType: "m5.large",
+			Region: "us-east-1", Count: 2, Currency: "USD", Expires: &expires,
+		},
+		{ID: "ri-west", Name: "west-ri", Type: CommitmentReservedInstance, InstanceType: "m5.large",
+			Region: "us-west-2", Count: 1, Currency: "USD"},
+	}
+
+	report := AnalyzeCommitments(commitments, resources, results, CommitmentOptions{Now: now})
+
+	assert.InDelta(t, 0.5, report.EligibleHourly, 0.001, "storage is not eligible")
+	assert.InDelta(t, 0.3, report.CoveredHourly, 0.001)
+	assert.InDelta(t, 60.0, report.Coverage, 0.001)
+
+	require.Len(t, report.Commitments, 3)
+	assert.Equal(t, "ri", report.Commitments[0].ID, "reservations apply first")
+	assert.InDelta(t, 100.0, report.Commitments[0].Utilization, 0.001)
+	assert.InDelta(t, 0.0, report.Commitments[1].Utilization, 0.001, "the region does not match")
+	assert.Equal(t, "sp", report.Commitments[2].ID)
+	assert.InDelta(t, 100.0, report.Commitments[2].Utilization, 0.001, "the plan covers the largest usage first")
+
+	require.Len(t, report.InstanceTypes, 2)
+	assert.Equal(t, "c5.xlarge", report.InstanceTypes[0].InstanceType, "least covered first")
+	assert.InDelta(t, 50.0, report.InstanceTypes[0].Coverage, 0.001)
+	assert.InDelta(t, 73.0, report.InstanceTypes[0].UncoveredMonthly, 0.001)
+	assert.Equal(t, "m5.large", report.InstanceTypes[1].InstanceType)
+	assert.Equal(t, "us-east-1", report.InstanceTypes[1].Region)
+	assert.Equal(t, 3, report.InstanceTypes[1].Reserved)
+	assert.Equal(t, 1, report.InstanceTypes[1].Uncovered)
+
+	var kinds []string
+	for _, f := range report.Findings {
+		kinds = append(kinds, f.Kind)
+	}
+	assert.Equal(t, []string{
+		CommitmentFindingPurchase, CommitmentFindingPurchase,
+		CommitmentFindingExpiring, CommitmentFindingUnderutilized,
+	}, kinds)
+	assert.InDelta(t, (73.0+73.0)*DefaultReservationDiscount, report.PotentialMonthlySavings, 0.001)
+}
+
+func TestAnalyzeCommitments_NoUsage(t *testing.T) {
+	report := AnalyzeCommitments(nil, nil, nil, CommitmentOptions{})
+	assert.Empty(t, report.Commitments)
+	assert.Empty(t, report.Findings)
+	assert.NotNil(t, report.Findings)
+	assert.InDelta(t, 0.0, report.Coverage, 0.001)
+}
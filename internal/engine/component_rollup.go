@@ -0,0 +1,188 @@
+package engine
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// RollupComponents is the --rollup mode that aggregates the costs of
+// resources under the Pulumi component resources that enclose them.
+const RollupComponents = "components"
+
+// urnParts is the number of "::"-separated parts of a URN after its prefix:
+// stack, project, type, and name.
+const urnParts = 4
+
+// ParseRollup parses a --rollup value; empty means no roll-up.
+func ParseRollup(s string) (string, error) {
+	switch rollup := strings.ToLower(strings.TrimSpace(s)); rollup {
+	case "", RollupComponents:
+		return rollup, nil
+	default:
+		return "", fmt.Errorf("invalid rollup %q: use components", s)
+	}
+}
+
+// ComponentNode is a node of a component roll-up: a component resource with
+// the costs of the resources below it rolled up, or a costed resource.
+type ComponentNode struct {
+	URN  string `json:"urn"`
+	Type string `json:"type"`
+	Name string `json:"name"`
+	// Component is true for component resources.
+	Component bool `json:"component,omitempty"`
+	// Cost is the cost of the resource and everything below it: TotalCost
+	// for actual costs, else Monthly.
+	Cost float64 `json:"cost"`
+	// Resources counts the costed resources at or below the node.
+	Resources int              `json:"resources"`
+	Children  []*ComponentNode `json:"children,omitempty"`
+}
+
+// ComponentRollup is the costs of a set of results arranged as a tree of the
+// component resources that enclose them. Resources outside any component are
+// roots of their own.
+type ComponentRollup struct {
+	Currency string `json:"currency"`
+	// MixedCurrencies is true when the results use more than one currency;
+	// costs are then sums of unconverted amounts.
+	MixedCurrencies bool             `json:"mixedCurrencies,omitempty"`
+	Total           float64          `json:"total"`
+	Roots           []*ComponentNode `json:"roots"`
+}
+
+// ResourceComponents returns the enclosing components of each resource that
+// is inside one, keyed by resource ID.
+func ResourceComponents(resources []ResourceDescriptor) map[string][]string {
+	components := make(map[string][]string)
+	for _, resource := range resources {
+		if len(resource.Components) > 0 {
+			components[resource.ID] = resource.Components
+		}
+	}
+	return components
+}
+
+// RollupCost returns the cost of a result in a roll-up: TotalCost when
+// present (actual costs), otherwise Monthly.
+func RollupCost(result CostResult) float64 {
+	if result.TotalCost > 0 {
+		return result.TotalCost
+	}
+	return result.Monthly
+}
+
+// BuildComponentRollup arranges results under the components that enclose
+// their resources, as components lists them by resource ID outermost first,
+// and rolls their costs up. A result of a component resource itself, such as
+// the placeholder of a component in a preview, adds to the cost of its node
+// but is not counted as a resource. Siblings are ordered by cost, highest
+// first, then by name.
+func BuildComponentRollup(results []CostResult, components map[string][]string) *ComponentRollup {
+	rollup := &ComponentRollup{Currency: defaultCurrency, Roots: []*ComponentNode{}}
+	nodes := make(map[string]*ComponentNode)
+	// component returns the node of a component, creating it and its
+	// ancestors as needed.
+	var component func(chain []string) *ComponentNode
+	component = func(chain []string) *ComponentNode {
+		urn := chain[len(chain)-1]
+		if node, ok := nodes[urn]; ok {
+			return node
+		}
+		resourceType, name := SplitURN(urn)
+		node := &ComponentNode{URN: urn, Type: resourceType, Name: name, Component: true}
+		nodes[urn] = node
+		if len(chain) == 1 {
+			rollup.Roots = append(rollup.Roots, node)
+		} else {
+			parent := component(chain[:len(chain)-1])
+			parent.Children = append(parent.Children, node)
+		}
+		return node
+	}
+
+	isComponent := make(map[string]bool)
+	for _, chain := range components {
+		for _, urn := range chain {
+			isComponent[urn] = true
+		}
+	}
+
+	currencySet := false
+	for _, result := range results {
+		if result.Currency != "" {
+			if !currencySet {
+				rollup.Currency = result.Currency
+				currencySet = true
+			} else if result.Currency != rollup.Currency {
+				rollup.MixedCurrencies = true
+			}
+		}
+		cost := RollupCost(result)
+		rollup.Total += cost
+
+		chain := components[result.ResourceID]
+		self := isComponent[result.ResourceID]
+		if self {
+			chain = append(slices.Clone(chain), result.ResourceID)
+		}
+		for i := range chain {
+			node := component(chain[:i+1])
+			node.Cost += cost
+			if !self {
+				node.Resources++
+			}
+		}
+		if self {
+			continue
+		}
+		_, name := SplitURN(result.ResourceID)
+		leaf := &ComponentNode{
+			URN: result.ResourceID, Type: result.ResourceType, Name: name, Cost: cost, Resources: 1,
+		}
+		if len(chain) == 0 {
+			rollup.Roots = append(rollup.Roots, leaf)
+		} else {
+			parent := nodes[chain[len(chain)-1]]
+			parent.Children = append(parent.Children, leaf)
+		}
+	}
+
+	sortComponentNodes(rollup.Roots)
+	return rollup
+}
+
+// sortComponentNodes orders nodes and their descendants by cost, highest
+// first.
+func sortComponentNodes(nodes []*ComponentNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		if nodes[i].Cost != nodes[j].Cost {
+			return nodes[i].Cost > nodes[j].Cost
+		}
+		return nodes[i].Name < nodes[j].Name
+	})
+	for _, node := range nodes {
+		sortComponentNodes(node.Children)
+	}
+}
+
+// SplitURN returns the type and name of the resource a Pulumi URN names. The
+// type of a child resource is the last of the "$"-separated types of its
+// URN. An ID that is not a URN is returned as the name.
+// Examples:
+//   - "urn:pulumi:dev::app::my:web:Site$aws:s3/bucket:Bucket::assets" -> "aws:s3/bucket:Bucket", "assets"
+//   - "i-0abc123" -> "", "i-0abc123"
+func SplitURN(urn string) (string, string) {
+	rest, ok := strings.CutPrefix(urn, pulumiURNPrefix)
+	if !ok {
+		return "", urn
+	}
+	parts := strings.SplitN(rest, "::", urnParts)
+	if len(parts) < urnParts {
+		return "", urn
+	}
+	qualifiedType := parts[2]
+	return qualifiedType[strings.LastIndex(qualifiedType, "$")+1:], parts[3]
+}
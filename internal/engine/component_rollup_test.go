@@ -0,0 +1,91 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	rollupSiteURN  = "urn:pulumi:dev::app::my:web:Site::site"
+	rollupCDNURN   = "urn:pulumi:dev::app::my:web:Site$my:web:CDN::cdn"
+	rollupWebURN   = "urn:pulumi:dev::app::my:web:Site$aws:ec2/instance:Instance::web"
+	rollupEdgeURN  = "urn:pulumi:dev::app::my:web:Site$my:web:CDN$aws:cloudfront/distribution:Distribution::edge"
+	rollupTableURN = "urn:pulumi:dev::app::aws:dynamodb/table:Table::sessions"
+)
+
+func TestBuildComponentRollup(t *testing.T) {
+	results := []CostResult{
+		{ResourceID: rollupWebURN, ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 30},
+		{ResourceID: rollupEdgeURN, ResourceType: "aws:cloudfront/distribution:Distribution", Monthly: 50},
+		{ResourceID: rollupTableURN, ResourceType: "aws:dynamodb/table:Table", Currency: "USD", Monthly: 100},
+		{ResourceID: rollupSiteURN, ResourceType: "my:web:Site", Adapter: placeholderAdapter},
+	}
+	components := map[string][]string{
+		rollupWebURN:  {rollupSiteURN},
+		rollupCDNURN:  {rollupSiteURN},
+		rollupEdgeURN: {rollupSiteURN, rollupCDNURN},
+	}
+
+	rollup := BuildComponentRollup(results, components)
+
+	assert.Equal(t, "USD", rollup.Currency)
+	assert.False(t, rollup.MixedCurrencies)
+	assert.InDelta(t, 180.0, rollup.Total, 1e-9)
+	require.Len(t, rollup.Roots, 2)
+
+	table := rollup.Roots[0]
+	assert.Equal(t, "sessions", table.Name, "roots are ordered by cost")
+	assert.False(t, table.Component)
+
+	site := rollup.Roots[1]
+	assert.True(t, site.Component)
+	assert.Equal(t, "site", site.Name)
+	assert.Equal(t, "my:web:Site", site.Type)
+	assert.InDelta(t, 80.0, site.Cost, 1e-9)
+	assert.Equal(t, 2, site.Resources, "the placeholder of the component is not a resource")
+	require.Len(t, site.Children, 2)
+
+	cdn := site.Children[0]
+	assert.Equal(t, "cdn", cdn.Name)
+	assert.Equal(t, "my:web:CDN", cdn.Type)
+	assert.InDelta(t, 50.0, cdn.Cost, 1e-9)
+	require.Len(t, cdn.Children, 1)
+	assert.Equal(t, rollupEdgeURN, cdn.Children[0].URN)
+	assert.Equal(t, "web", site.Children[1].Name)
+}
+
+func TestBuildComponentRollup_ActualCosts(t *testing.T) {
+	rollup := BuildComponentRollup([]CostResult{
+		{ResourceID: rollupWebURN, Currency: "USD", TotalCost: 12, Monthly: 30},
+		{ResourceID: rollupTableURN, Currency: "EUR", TotalCost: 3},
+	}, map[string][]string{rollupWebURN: {rollupSiteURN}})
+
+	assert.True(t, rollup.MixedCurrencies)
+	assert.InDelta(t, 15.0, rollup.Total, 1e-9)
+	assert.InDelta(t, 12.0, rollup.Roots[0].Cost, 1e-9, "actual costs roll up TotalCost")
+}
+
+func TestSplitURN(t *testing.T) {
+	resourceType, name := SplitURN(rollupEdgeURN)
+	assert.Equal(t, "aws:cloudfront/distribution:Distribution", resourceType)
+	assert.Equal(t, "edge", name)
+
+	resourceType, name = SplitURN("i-0abc123")
+	assert.Empty(t, resourceType)
+	assert.Equal(t, "i-0abc123", name)
+}
+
+func TestParseRollup(t *testing.T) {
+	rollup, err := ParseRollup(" Components ")
+	require.NoError(t, err)
+	assert.Equal(t, RollupComponents, rollup)
+
+	rollup, err = ParseRollup("")
+	require.NoError(t, err)
+	assert.Empty(t, rollup)
+
+	_, err = ParseRollup("stacks")
+	require.Error(t, err)
+}
@@ -0,0 +1,176 @@
+package engine
+
+import (
+	"sort"
+	"strings"
+)
+
+// Pricing dimensions that a cost is broken down into.
+const (
+	DimensionCompute      = "compute"
+	DimensionStorage      = "storage"
+	DimensionDataTransfer = "data_transfer"
+	DimensionLicense      = "license"
+	DimensionOther        = "other"
+)
+
+// Bases of a dimension breakdown: the projected monthly cost or the actual
+// total cost of each result.
+const (
+	DimensionBasisMonthly = "monthly"
+	DimensionBasisTotal   = "total"
+)
+
+// PricingDimensions returns the pricing dimensions in display order.
+func PricingDimensions() []string {
+	return []string{
+		DimensionCompute, DimensionStorage, DimensionDataTransfer, DimensionLicense, DimensionOther,
+	}
+}
+
+// ResourceDimensions is the cost of one resource split by pricing dimension.
+type ResourceDimensions struct {
+	ResourceID   string             `json:"resourceId"`
+	ResourceType string             `json:"resourceType"`
+	Total        float64            `json:"total"`
+	Dimensions   map[string]float64 `json:"dimensions"`
+}
+
+// DimensionBreakdown splits the cost of a set of results by pricing dimension.
+type DimensionBreakdown struct {
+	// Basis is DimensionBasisMonthly or DimensionBasisTotal.
+	Basis    string `json:"basis"`
+	Currency string `json:"currency"`
+	// MixedCurrencies is true when the results use more than one currency;
+	// totals are then sums of unconverted amounts.
+	MixedCurrencies bool `json:"mixedCurrencies,omitempty"`
+	// Dimensions lists the dimensions with a cost, in PricingDimensions order.
+	Dimensions []string             `json:"dimensions"`
+	Totals     map[string]float64   `json:"totals"`
+	Total      float64              `json:"total"`
+	Resources  []ResourceDimensions `json:"resources"`
+}
+
+// ClassifyPricingDimension returns the dimension that a CostBreakdown key
+// names, or "" when the key names none. Rate keys such as "unit_price" and
+// one-time cost hints under OneTimeBreakdownPrefix are not monthly costs and
+// also return "".
+func ClassifyPricingDimension(key string) string {
+	k := strings.ToLower(key)
+	if strings.HasSuffix(k, "price") || strings.HasSuffix(k, "_rate") || strings.HasPrefix(k, OneTimeBreakdownPrefix) {
+		return ""
+	}
+	switch {
+	case containsAny(k, "license", "licence", "software", "subscription"):
+		return DimensionLicense
+	case containsAny(k, "transfer", "egress", "ingress", "bandwidth", "network", "traffic"):
+		return DimensionDataTransfer
+	case containsAny(k, "storage", "disk", "volume", "snapshot", "backup", "iops"):
+		return DimensionStorage
+	case containsAny(k, "compute", "cpu", "memory", "instance", "runtime", "invocation"):
+		return DimensionCompute
+	default:
+		return ""
+	}
+}
+
+// resourceTypeDimension infers the dimension of a resource's cost from its
+// type, for costs whose breakdown names no dimension.
+func resourceTypeDimension(resourceType string) string {
+	t := strings.ToLower(resourceType)
+	switch {
+	case containsAny(t, "natgateway", "cloudfront", "cdn", "transfer", "vpn", "directconnect", "egress"):
+		return DimensionDataTransfer
+	case containsAny(t, "/volume", ":s3", "bucket", "storage", "disk", "efs", "snapshot", "filesystem"):
+		return DimensionStorage
+	case containsAny(t, "instance", ":ec2", "lambda", "function", "compute", "container", "cluster",
+		"virtualmachine", "deployment", "statefulset"):
+		return DimensionCompute
+	default:
+		return DimensionOther
+	}
+}
+
+// containsAny reports whether s contains any of substrs.
+func containsAny(s string, substrs ...string) bool {
+	for _, sub := range substrs {
+		if strings.Contains(s, sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// CostDimensions splits the cost of result by pricing dimension. Breakdown
+// components that name a dimension are assigned to it; the rest of the cost,
+// including components that name none, is assigned to the dimension inferred
+// from the resource type, or DimensionOther. basis selects the Monthly or
+// TotalCost field as the cost.
+func CostDimensions(result CostResult, basis string) map[string]float64 {
+	cost := result.Monthly
+	if basis == DimensionBasisTotal {
+		cost = result.TotalCost
+	}
+
+	dimensions := make(map[string]float64)
+	classified := 0.0
+	for key, value := range result.Breakdown {
+		if dimension := ClassifyPricingDimension(key); dimension != "" && value != 0 {
+			dimensions[dimension] += value
+			classified += value
+		}
+	}
+	if remainder := cost - classified; remainder > 0 {
+		dimensions[resourceTypeDimension(result.ResourceType)] += remainder
+	}
+	return dimensions
+}
+
+// BuildDimensionBreakdown splits the cost of each result by pricing dimension
+// and sums the dimensions over all results. Results with an error are
+// skipped.
+func BuildDimensionBreakdown(results []CostResult, basis string) *DimensionBreakdown {
+	breakdown := &DimensionBreakdown{
+		Basis:     basis,
+		Currency:  defaultCurrency,
+		Totals:    make(map[string]float64),
+		Resources: make([]ResourceDimensions, 0, len(results)),
+	}
+	currencySet := false
+	for _, result := range results {
+		if result.Error != nil {
+			continue
+		}
+		if result.Currency != "" {
+			if !currencySet {
+				breakdown.Currency = result.Currency
+				currencySet = true
+			} else if result.Currency != breakdown.Currency {
+				breakdown.MixedCurrencies = true
+			}
+		}
+
+		resource := ResourceDimensions{
+			ResourceID:   result.ResourceID,
+			ResourceType: result.ResourceType,
+			Dimensions:   CostDimensions(result, basis),
+		}
+		for dimension, value := range resource.Dimensions {
+			resource.Total += value
+			breakdown.Totals[dimension] += value
+		}
+		breakdown.Total += resource.Total
+		breakdown.Resources = append(breakdown.Resources, resource)
+	}
+
+	breakdown.Dimensions = make([]string, 0, len(breakdown.Totals))
+	for _, dimension := range PricingDimensions() {
+		if _, ok := breakdown.Totals[dimension]; ok {
+			breakdown.Dimensions = append(breakdown.Dimensions, dimension)
+		}
+	}
+	sort.SliceStable(breakdown.Resources, func(i, j int) bool {
+		return breakdown.Resources[i].Total > breakdown.Resources[j].Total
+	})
+	return breakdown
+}
@@ -0,0 +1,84 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClassifyPricingDimension(t *testing.T) {
+	tests := map[string]string{
+		"compute_hours":     DimensionCompute,
+		"vCPU":              DimensionCompute,
+		"ebs_storage":       DimensionStorage,
+		"provisioned_iops":  DimensionStorage,
+		"data_transfer_out": DimensionDataTransfer,
+		"egress":            DimensionDataTransfer,
+		"windows_license":   DimensionLicense,
+		"software":          DimensionLicense,
+		"unit_price":        "",
+		"storage_price":     "",
+		"base_cost":         "",
+		"aws-cost-explorer": "",
+	}
+	for key, want := range tests {
+		assert.Equal(t, want, ClassifyPricingDimension(key), key)
+	}
+}
+
+func TestCostDimensions(t *testing.T) {
+	t.Run("classified components and remainder", func(t *testing.T) {
+		dims := CostDimensions(CostResult{
+			ResourceType: "aws:ec2/instance:Instance", Monthly: 100,
+			Breakdown: map[string]float64{"ebs_storage": 20, "data_transfer": 5, "unit_price": 0.1},
+		}, DimensionBasisMonthly)
+		assert.Equal(t, map[string]float64{
+			DimensionCompute: 75, DimensionStorage: 20, DimensionDataTransfer: 5,
+		}, dims)
+	})
+
+	t.Run("unclassified cost follows the resource type", func(t *testing.T) {
+		dims := CostDimensions(CostResult{
+			ResourceType: "aws:s3/bucket:Bucket", Monthly: 12, Breakdown: map[string]float64{"base_cost": 12},
+		}, DimensionBasisMonthly)
+		assert.Equal(t, map[string]float64{DimensionStorage: 12}, dims)
+
+		dims = CostDimensions(CostResult{ResourceType: "aws:iam/role:Role", Monthly: 1}, DimensionBasisMonthly)
+		assert.Equal(t, map[string]float64{DimensionOther: 1}, dims)
+	})
+
+	t.Run("total basis", func(t *testing.T) {
+		dims := CostDimensions(CostResult{
+			ResourceType: "aws:ec2/natGateway:NatGateway", Monthly: 99, TotalCost: 8,
+		}, DimensionBasisTotal)
+		assert.Equal(t, map[string]float64{DimensionDataTransfer: 8}, dims)
+	})
+}
+
+func TestBuildDimensionBreakdown(t *testing.T) {
+	breakdown := BuildDimensionBreakdown([]CostResult{
+		{ResourceID: "bucket", ResourceType: "aws:s3/bucket:Bucket", Currency: "USD", Monthly: 10},
+		{
+			ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 50,
+			Breakdown: map[string]float64{"windows_license": 15},
+		},
+		{ResourceID: "broken", Monthly: 5, Error: &StructuredError{Code: "PLUGIN_ERROR"}},
+	}, DimensionBasisMonthly)
+
+	assert.Equal(t, "USD", breakdown.Currency)
+	assert.False(t, breakdown.MixedCurrencies)
+	assert.Equal(t, []string{DimensionCompute, DimensionStorage, DimensionLicense}, breakdown.Dimensions)
+	assert.InDelta(t, 60.0, breakdown.Total, 0.001)
+	assert.InDelta(t, 35.0, breakdown.Totals[DimensionCompute], 0.001)
+	require.Len(t, breakdown.Resources, 2, "results with an error are skipped")
+	assert.Equal(t, "web", breakdown.Resources[0].ResourceID, "largest cost first")
+	assert.InDelta(t, 50.0, breakdown.Resources[0].Total, 0.001)
+}
+
+func TestBuildDimensionBreakdown_Empty(t *testing.T) {
+	breakdown := BuildDimensionBreakdown(nil, DimensionBasisTotal)
+	assert.Empty(t, breakdown.Resources)
+	assert.Empty(t, breakdown.Dimensions)
+	assert.Equal(t, defaultCurrency, breakdown.Currency)
+}
@@ -0,0 +1,150 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// cronField is the range of one field of a cron expression.
+type cronField struct {
+	name      string
+	low, high int
+}
+
+// Indexes of the fields of a cron expression.
+const (
+	cronMinute = iota
+	cronHour
+	cronDayOfMonth
+	cronMonth
+	cronDayOfWeek
+	cronFieldCount
+)
+
+// cronSunday is the day of week 7, an alias of 0.
+const cronSunday = 7
+
+// cronFields returns the fields of a cron expression, in order.
+func cronFields() [cronFieldCount]cronField {
+	return [cronFieldCount]cronField{
+		{"minute", 0, 59},
+		{"hour", 0, 23},
+		{"day of month", 1, 31},
+		{"month", 1, 12},
+		{"day of week", 0, cronSunday},
+	}
+}
+
+// CronSchedule is a parsed five-field cron expression
+// ("minute hour day-of-month month day-of-week").
+type CronSchedule struct {
+	expr string
+	// sets holds the matching values of each field, indexed by value.
+	sets [cronFieldCount][]bool
+	// domAny and dowAny record day fields starting with *: when both day
+	// fields are restricted, a time matches when either does, as in cron.
+	domAny, dowAny bool
+}
+
+// ParseCronSchedule parses a five-field cron expression. Fields accept *,
+// values, ranges (1-5), lists (1,3,5), and steps (*/15, 9-17/2).
+func ParseCronSchedule(expr string) (*CronSchedule, error) {
+	parts := strings.Fields(expr)
+	if len(parts) != cronFieldCount {
+		return nil, fmt.Errorf("cron expression %q must have 5 fields "+
+			"(minute hour day-of-month month day-of-week), got %d", expr, len(parts))
+	}
+	schedule := &CronSchedule{
+		expr:   strings.Join(parts, " "),
+		domAny: strings.HasPrefix(parts[cronDayOfMonth], "*"),
+		dowAny: strings.HasPrefix(parts[cronDayOfWeek], "*"),
+	}
+	for i, field := range cronFields() {
+		set, err := parseCronField(parts[i], field)
+		if err != nil {
+			return nil, fmt.Errorf("cron expression %q: %s: %w", expr, field.name, err)
+		}
+		schedule.sets[i] = set
+	}
+	if schedule.sets[cronDayOfWeek][cronSunday] {
+		schedule.sets[cronDayOfWeek][0] = true
+	}
+	return schedule, nil
+}
+
+// parseCronField returns the values that one field matches.
+func parseCronField(s string, field cronField) ([]bool, error) {
+	set := make([]bool, field.high+1)
+	for _, item := range strings.Split(s, ",") {
+		rangePart, stepPart, hasStep := strings.Cut(item, "/")
+		step := 1
+		if hasStep {
+			n, err := strconv.Atoi(stepPart)
+			if err != nil || n <= 0 {
+				return nil, fmt.Errorf("invalid step %q", stepPart)
+			}
+			step = n
+		}
+
+		low, high := field.low, field.high
+		if rangePart != "*" {
+			lowPart, highPart, isRange := strings.Cut(rangePart, "-")
+			var err error
+			if low, err = cronValue(lowPart, field); err != nil {
+				return nil, err
+			}
+			high = low
+			if isRange {
+				if high, err = cronValue(highPart, field); err != nil {
+					return nil, err
+				}
+			} else if hasStep {
+				high = field.high
+			}
+			if high < low {
+				return nil, fmt.Errorf("invalid range %q", rangePart)
+			}
+		}
+		for v := low; v <= high; v += step {
+			set[v] = true
+		}
+	}
+	return set, nil
+}
+
+// cronValue parses one value of a field and checks its range.
+func cronValue(s string, field cronField) (int, error) {
+	v, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %q", s)
+	}
+	if v < field.low || v > field.high {
+		return 0, fmt.Errorf("value %d out of range %d-%d", v, field.low, field.high)
+	}
+	return v, nil
+}
+
+// String returns the cron expression.
+func (c *CronSchedule) String() string {
+	return c.expr
+}
+
+// Matches reports whether the schedule fires in the minute of t.
+func (c *CronSchedule) Matches(t time.Time) bool {
+	if !c.sets[cronMinute][t.Minute()] || !c.sets[cronHour][t.Hour()] || !c.sets[cronMonth][int(t.Month())] {
+		return false
+	}
+	dom, dow := c.sets[cronDayOfMonth][t.Day()], c.sets[cronDayOfWeek][int(t.Weekday())]
+	switch {
+	case c.domAny && c.dowAny:
+		return true
+	case c.domAny:
+		return dow
+	case c.dowAny:
+		return dom
+	default:
+		return dom || dow
+	}
+}
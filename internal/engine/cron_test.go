@@ -0,0 +1,55 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	// Monday 2025-03-03.
+	monday := time.Date(2025, time.March, 3, 19, 0, 0, 0, time.UTC)
+
+	schedule, err := ParseCronSchedule("0 19 * * 1-5")
+	require.NoError(t, err)
+	assert.Equal(t, "0 19 * * 1-5", schedule.String())
+	assert.True(t, schedule.Matches(monday))
+	assert.False(t, schedule.Matches(monday.Add(time.Minute)))
+	assert.False(t, schedule.Matches(monday.AddDate(0, 0, 5)), "Saturday")
+
+	schedule, err = ParseCronSchedule("*/15 9-17/2 * * 0,7")
+	require.NoError(t, err)
+	sunday := time.Date(2025, time.March, 2, 11, 45, 0, 0, time.UTC)
+	assert.True(t, schedule.Matches(sunday))
+	assert.False(t, schedule.Matches(sunday.Add(time.Hour)), "10 is not in 9-17/2")
+
+	// With both day fields restricted, either matches.
+	schedule, err = ParseCronSchedule("0 0 1 * 1")
+	require.NoError(t, err)
+	assert.True(t, schedule.Matches(time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)), "first of the month")
+	assert.True(t, schedule.Matches(time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)), "a Monday")
+	assert.False(t, schedule.Matches(time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)))
+}
+
+func TestParseCronSchedule_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"0 19 * *":       "must have 5 fields",
+		"60 19 * * 1-5":  "minute: value 60 out of range 0-59",
+		"0 19 * * 5-1":   `day of week: invalid range "5-1"`,
+		"0 19 * * */0":   `invalid step "0"`,
+		"0 19 * * mon":   `invalid value "mon"`,
+		"0 19 0 * 1-5":   "day of month: value 0 out of range 1-31",
+		"0 19 * 13 1-5":  "month: value 13 out of range 1-12",
+		"0 24 * * 1-5":   "hour: value 24 out of range 0-23",
+		"0 19 * * 1-5 *": "got 6",
+	}
+	for expr, want := range tests {
+		t.Run(expr, func(t *testing.T) {
+			_, err := ParseCronSchedule(expr)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), want)
+		})
+	}
+}
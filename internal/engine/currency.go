@@ -0,0 +1,143 @@
+package engine
+
+import (
+	"context"
+
+	"github.com/rshade/finfocus/internal/currency"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// convertResults converts results in place into the target currency of the
+// converter carried by ctx. Without a converter it does nothing. A result
+// whose currency has no exchange rate is left in its native currency and a
+// warning is logged, so mixed totals remain visible rather than wrong.
+func convertResults(ctx context.Context, results []CostResult) {
+	conv := currency.FromContext(ctx)
+	if conv == nil {
+		return
+	}
+	for i := range results {
+		if err := convertResult(conv, &results[i]); err != nil {
+			logging.FromContext(ctx).Warn().
+				Ctx(ctx).
+				Str("component", "engine").
+				Str("resource_id", results[i].ResourceID).
+				Str("currency", results[i].Currency).
+				Str("target_currency", conv.Target()).
+				Err(err).
+				Msg("leaving cost in native currency")
+		}
+	}
+}
+
+// convertResult converts every monetary field of r into the converter's target.
+func convertResult(conv *currency.Converter, r *CostResult) error {
+	from := r.Currency
+	if from == "" {
+		from = defaultCurrency
+	}
+	rate, err := conv.Convert(1, from)
+	if err != nil {
+		return err
+	}
+
+	r.Monthly *= rate
+	r.Hourly *= rate
+	r.TotalCost *= rate
+	r.Delta *= rate
+	for i := range r.DailyCosts {
+		r.DailyCosts[i] *= rate
+	}
+	for k, v := range r.Breakdown {
+		r.Breakdown[k] = v * rate
+	}
+	r.Currency = conv.Target()
+
+	for i := range r.Recommendations {
+		if recErr := convertRecommendation(conv, &r.Recommendations[i], from); recErr != nil {
+			return recErr
+		}
+	}
+	return nil
+}
+
+// convertRecommendation converts the savings and costs of rec into the
+// converter's target. fallback is used when rec names no currency.
+func convertRecommendation(conv *currency.Converter, rec *Recommendation, fallback string) error {
+	from := rec.Currency
+	if from == "" {
+		from = fallback
+	}
+	rate, err := conv.Convert(1, from)
+	if err != nil {
+		return err
+	}
+	rec.EstimatedSavings *= rate
+	rec.CurrentCost *= rate
+	rec.ProjectedCost *= rate
+	rec.Currency = conv.Target()
+	return nil
+}
+
+// convertRecommendations converts result into the target currency of the
+// converter carried by ctx and recomputes the total savings. Savings that
+// cannot be converted are left out of the total.
+func convertRecommendations(ctx context.Context, result *RecommendationsResult) {
+	conv := currency.FromContext(ctx)
+	if conv == nil {
+		return
+	}
+	result.TotalSavings = 0
+	for i := range result.Recommendations {
+		rec := &result.Recommendations[i]
+		if err := convertRecommendation(conv, rec, defaultCurrency); err != nil {
+			logging.FromContext(ctx).Warn().
+				Ctx(ctx).
+				Str("component", "engine").
+				Str("recommendation_id", rec.ID).
+				Str("target_currency", conv.Target()).
+				Err(err).
+				Msg("leaving savings in native currency")
+			continue
+		}
+		result.TotalSavings += rec.EstimatedSavings
+	}
+	result.Currency = conv.Target()
+}
+
+// convertEstimate converts an estimate returned by a plugin into the target
+// currency of the converter carried by ctx.
+func convertEstimate(ctx context.Context, result *EstimateResult) {
+	conv := currency.FromContext(ctx)
+	if conv == nil || result.Baseline == nil {
+		return
+	}
+	from := result.Baseline.Currency
+	if from == "" {
+		from = defaultCurrency
+	}
+	rate, err := conv.Convert(1, from)
+	if err != nil {
+		logging.FromContext(ctx).Warn().
+			Ctx(ctx).
+			Str("component", "engine").
+			Str("currency", from).
+			Str("target_currency", conv.Target()).
+			Err(err).
+			Msg("leaving estimate in native currency")
+		return
+	}
+	results := []CostResult{*result.Baseline}
+	if result.Modified != nil {
+		results = append(results, *result.Modified)
+	}
+	convertResults(ctx, results)
+	result.Baseline = &results[0]
+	if result.Modified != nil {
+		result.Modified = &results[1]
+	}
+	result.TotalChange *= rate
+	for i := range result.Deltas {
+		result.Deltas[i].CostChange *= rate
+	}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/currency"
+)
+
+func eurContext(t *testing.T) context.Context {
+	t.Helper()
+	rates := &currency.Rates{Base: "EUR", Rates: map[string]float64{"USD": 1.25, "GBP": 0.8}}
+	conv, err := currency.NewConverter("EUR", rates)
+	require.NoError(t, err)
+	return currency.NewContext(context.Background(), conv)
+}
+
+func TestConvertResults(t *testing.T) {
+	results := []CostResult{
+		{
+			ResourceID: "usd", Currency: "USD", Monthly: 100, Hourly: 0.5, TotalCost: 50,
+			DailyCosts: []float64{25, 25}, Breakdown: map[string]float64{"compute": 100},
+			Recommendations: []Recommendation{{EstimatedSavings: 10, CurrentCost: 100, ProjectedCost: 90}},
+		},
+		{ResourceID: "default", Monthly: 10},
+		{ResourceID: "gbp", Currency: "GBP", Monthly: 8},
+		{ResourceID: "unknown", Currency: "JPY", Monthly: 1000},
+	}
+
+	convertResults(eurContext(t), results)
+
+	assert.Equal(t, "EUR", results[0].Currency)
+	assert.InDelta(t, 80, results[0].Monthly, 1e-9)
+	assert.InDelta(t, 0.4, results[0].Hourly, 1e-9)
+	assert.InDelta(t, 40, results[0].TotalCost, 1e-9)
+	assert.InDeltaSlice(t, []float64{20, 20}, results[0].DailyCosts, 1e-9)
+	assert.InDelta(t, 80, results[0].Breakdown["compute"], 1e-9)
+	assert.InDelta(t, 8, results[0].Recommendations[0].EstimatedSavings, 1e-9)
+	assert.Equal(t, "EUR", results[0].Recommendations[0].Currency)
+
+	assert.Equal(t, "EUR", results[1].Currency, "an empty currency is treated as USD")
+	assert.InDelta(t, 8, results[1].Monthly, 1e-9)
+	assert.InDelta(t, 10, results[2].Monthly, 1e-9)
+
+	assert.Equal(t, "JPY", results[3].Currency, "unknown currencies stay native")
+	assert.InDelta(t, 1000, results[3].Monthly, 1e-9)
+}
+
+func TestConvertResults_NoConverter(t *testing.T) {
+	results := []CostResult{{Currency: "USD", Monthly: 100}}
+	convertResults(context.Background(), results)
+	assert.Equal(t, "USD", results[0].Currency)
+	assert.InDelta(t, 100, results[0].Monthly, 1e-9)
+}
+
+func TestConvertRecommendations(t *testing.T) {
+	result := &RecommendationsResult{
+		Recommendations: []Recommendation{
+			{ID: "a", EstimatedSavings: 50, Currency: "USD"},
+			{ID: "b", EstimatedSavings: 8, Currency: "GBP"},
+			{ID: "c", EstimatedSavings: 99, Currency: "JPY"},
+		},
+		TotalSavings: 157,
+		Currency:     "USD",
+	}
+
+	convertRecommendations(eurContext(t), result)
+
+	assert.Equal(t, "EUR", result.Currency)
+	assert.InDelta(t, 40, result.Recommendations[0].EstimatedSavings, 1e-9)
+	assert.InDelta(t, 10, result.Recommendations[1].EstimatedSavings, 1e-9)
+	assert.Equal(t, "JPY", result.Recommendations[2].Currency)
+	assert.InDelta(t, 50, result.TotalSavings, 1e-9, "unconvertible savings are left out of the total")
+}
+
+func TestConvertEstimate(t *testing.T) {
+	result := &EstimateResult{
+		Baseline:    &CostResult{Currency: "USD", Monthly: 100},
+		Modified:    &CostResult{Currency: "USD", Monthly: 150},
+		TotalChange: 50,
+		Deltas:      []CostDelta{{Property: "instanceType", CostChange: 50}},
+	}
+
+	convertEstimate(eurContext(t), result)
+
+	assert.InDelta(t, 80, result.Baseline.Monthly, 1e-9)
+	assert.InDelta(t, 120, result.Modified.Monthly, 1e-9)
+	assert.InDelta(t, 40, result.TotalChange, 1e-9)
+	assert.InDelta(t, 40, result.Deltas[0].CostChange, 1e-9)
+	assert.Equal(t, "EUR", result.Modified.Currency)
+}
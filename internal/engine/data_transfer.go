@@ -0,0 +1,235 @@
+package engine
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk/mapping"
+)
+
+// Kinds of modeled data transfer.
+const (
+	TransferInterAZ     = "inter_az"
+	TransferInterRegion = "inter_region"
+	TransferEgress      = "egress"
+)
+
+// TransferAdapter is the adapter name of synthetic transfer line items.
+const TransferAdapter = "transfer-model"
+
+// TransferVolumeTag is the resource tag that overrides the assumed monthly
+// transfer volume of a resource, in GB.
+const TransferVolumeTag = "finfocus:transfer-gb"
+
+// DefaultTransferGBPerMonth is the monthly volume assumed for each transfer
+// path when neither TransferOptions nor TransferVolumeTag give one.
+const DefaultTransferGBPerMonth = 100.0
+
+// List prices per GB (AWS on-demand, us-east-1). Inter-AZ traffic is billed
+// in each direction.
+const (
+	interAZRatePerGB     = 0.02
+	interRegionRatePerGB = 0.02
+	egressRatePerGB      = 0.09
+	// natProcessingPerGB is the NAT gateway data processing charge, billed
+	// on top of egress.
+	natProcessingPerGB = 0.045
+)
+
+// minCrossZoneSubnets is the number of subnets from which a load balancer
+// spans availability zones.
+const minCrossZoneSubnets = 2
+
+// TransferEstimate is the modeled monthly cost of one data transfer path.
+type TransferEstimate struct {
+	// ResourceID and ResourceType identify the resource whose topology
+	// implies the transfer.
+	ResourceID   string `json:"resourceId"`
+	ResourceType string `json:"resourceType"`
+	// Kind is TransferInterAZ, TransferInterRegion, or TransferEgress.
+	Kind string `json:"kind"`
+	// Hint describes the topology the estimate was inferred from.
+	Hint       string  `json:"hint"`
+	GBPerMonth float64 `json:"gbPerMonth"`
+	RatePerGB  float64 `json:"ratePerGB"`
+	Monthly    float64 `json:"monthly"`
+}
+
+// TransferOptions configures EstimateDataTransfer.
+type TransferOptions struct {
+	// GBPerMonth is the volume assumed for each transfer path; zero uses
+	// DefaultTransferGBPerMonth.
+	GBPerMonth float64
+}
+
+// EstimateDataTransfer models the data transfer costs implied by the
+// topology hints of resources: VPC peering, cross-zone load balancing,
+// internet-facing load balancers, NAT gateways, and S3, RDS, and DynamoDB
+// replication. Plugins price resources one at a time, so these costs are
+// otherwise missing from projections. Volumes are assumptions: each path
+// uses opts.GBPerMonth unless the resource has a TransferVolumeTag tag.
+func EstimateDataTransfer(resources []ResourceDescriptor, opts TransferOptions) []TransferEstimate {
+	defaultGB := opts.GBPerMonth
+	if defaultGB <= 0 {
+		defaultGB = DefaultTransferGBPerMonth
+	}
+
+	var estimates []TransferEstimate
+	for _, resource := range resources {
+		gb := defaultGB
+		if value, ok := lookupResourceTag(resource.Properties, TransferVolumeTag); ok {
+			if parsed, err := strconv.ParseFloat(value, 64); err == nil && parsed >= 0 {
+				gb = parsed
+			}
+		}
+		for _, path := range transferPaths(resource) {
+			path.ResourceID = resource.ID
+			path.ResourceType = resource.Type
+			path.GBPerMonth = gb
+			path.Monthly = gb * path.RatePerGB
+			estimates = append(estimates, path)
+		}
+	}
+	return estimates
+}
+
+// transferPaths returns the transfer paths implied by one resource, with
+// their kind, hint, and rate.
+func transferPaths(resource ResourceDescriptor) []TransferEstimate {
+	props := resource.Properties
+	t := strings.ToLower(resource.Type)
+	region := resourceRegion(props)
+
+	switch {
+	case strings.Contains(t, "vpcpeeringconnection") && !containsAny(t, "accepter", "options"):
+		peerRegion, _ := getStringProperty(props, "peerRegion")
+		if peerRegion != "" && region != "" && peerRegion != region {
+			return []TransferEstimate{{
+				Kind: TransferInterRegion, RatePerGB: interRegionRatePerGB,
+				Hint: fmt.Sprintf("VPC peering %s to %s", region, peerRegion),
+			}}
+		}
+		return []TransferEstimate{{Kind: TransferInterAZ, RatePerGB: interAZRatePerGB, Hint: "VPC peering"}}
+
+	case strings.Contains(t, "loadbalancer:") && !strings.Contains(t, "listener"):
+		var paths []TransferEstimate
+		if internal, _ := props["internal"].(bool); !internal {
+			paths = append(paths, TransferEstimate{
+				Kind: TransferEgress, RatePerGB: egressRatePerGB, Hint: "internet-facing load balancer",
+			})
+		}
+		// Application load balancers do not charge for cross-zone traffic.
+		lbType, _ := getStringProperty(props, "loadBalancerType")
+		if lbType != "" && lbType != "application" && propertyBool(props, "enableCrossZoneLoadBalancing") &&
+			max(propertyLen(props, "subnets"), propertyLen(props, "subnetMappings")) >= minCrossZoneSubnets {
+			paths = append(paths, TransferEstimate{
+				Kind: TransferInterAZ, RatePerGB: interAZRatePerGB, Hint: "cross-zone load balancing to targets",
+			})
+		}
+		return paths
+
+	case strings.Contains(t, "natgateway"):
+		if connectivity, _ := getStringProperty(props, "connectivityType"); connectivity == "private" {
+			return nil
+		}
+		return []TransferEstimate{{
+			Kind: TransferEgress, RatePerGB: egressRatePerGB + natProcessingPerGB,
+			Hint: "NAT gateway egress and processing",
+		}}
+
+	case strings.Contains(t, "s3/bucketreplicationconfig"),
+		strings.Contains(t, "s3/bucket:") && propertyLen(props, "replicationConfiguration") > 0:
+		return []TransferEstimate{{Kind: TransferInterRegion, RatePerGB: interRegionRatePerGB, Hint: "S3 replication"}}
+
+	case strings.Contains(t, "rds/instance"):
+		// Same-region read replicas replicate for free.
+		source, _ := getStringProperty(props, "replicateSourceDb")
+		if sourceRegion := regionFromARN(source); sourceRegion != "" && sourceRegion != region {
+			return []TransferEstimate{{
+				Kind: TransferInterRegion, RatePerGB: interRegionRatePerGB,
+				Hint: fmt.Sprintf("RDS read replica of %s", sourceRegion),
+			}}
+		}
+		return nil
+
+	case strings.Contains(t, "dynamodb/table:"):
+		paths := make([]TransferEstimate, 0, propertyLen(props, "replicas"))
+		for range propertyLen(props, "replicas") {
+			paths = append(paths, TransferEstimate{
+				Kind: TransferInterRegion, RatePerGB: interRegionRatePerGB, Hint: "DynamoDB global table replica",
+			})
+		}
+		return paths
+
+	default:
+		return nil
+	}
+}
+
+// TransferLineItems presents transfer estimates as synthetic cost results,
+// one per path, so they are rendered, totaled, and budgeted with the
+// resources. The cost is broken down under DimensionDataTransfer.
+func TransferLineItems(estimates []TransferEstimate) []CostResult {
+	results := make([]CostResult, 0, len(estimates))
+	for _, estimate := range estimates {
+		results = append(results, CostResult{
+			ResourceType: estimate.ResourceType,
+			ResourceID:   estimate.ResourceID + "#transfer:" + estimate.Kind,
+			Adapter:      TransferAdapter,
+			Currency:     defaultCurrency,
+			Monthly:      estimate.Monthly,
+			Hourly:       estimate.Monthly / hoursPerMonth,
+			Notes: fmt.Sprintf("Estimated %s transfer (%s): %.0f GB/month at %.3f/GB",
+				strings.ReplaceAll(estimate.Kind, "_", "-"), estimate.Hint, estimate.GBPerMonth, estimate.RatePerGB),
+			Breakdown: map[string]float64{DimensionDataTransfer: estimate.Monthly},
+			Accuracy:  AccuracyHeuristic,
+		})
+	}
+	return results
+}
+
+// resourceRegion returns the region of a resource from its region or
+// availabilityZone property, or "" when it has neither.
+func resourceRegion(props map[string]interface{}) string {
+	if region, _ := getStringProperty(props, "region"); region != "" {
+		return region
+	}
+	az, _ := getStringProperty(props, "availabilityZone")
+	return mapping.ExtractAWSRegionFromAZ(az)
+}
+
+// regionFromARN returns the region field of an ARN, or "" when s is not one.
+func regionFromARN(s string) string {
+	const regionField = 3
+	parts := strings.Split(s, ":")
+	if len(parts) <= regionField || parts[0] != "arn" {
+		return ""
+	}
+	return parts[regionField]
+}
+
+// propertyBool reports whether a property is true, given as a bool or string.
+func propertyBool(props map[string]interface{}, key string) bool {
+	switch v := props[key].(type) {
+	case bool:
+		return v
+	case string:
+		b, _ := strconv.ParseBool(v)
+		return b
+	default:
+		return false
+	}
+}
+
+// propertyLen returns the number of elements of a list or map property.
+func propertyLen(props map[string]interface{}, key string) int {
+	switch v := props[key].(type) {
+	case []interface{}:
+		return len(v)
+	case map[string]interface{}:
+		return len(v)
+	default:
+		return 0
+	}
+}
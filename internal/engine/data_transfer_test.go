@@ -0,0 +1,131 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateDataTransfer(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource ResourceDescriptor
+		want     []string // kinds
+		monthly  float64
+	}{
+		{
+			name: "same-region peering",
+			resource: ResourceDescriptor{Type: "aws:ec2/vpcPeeringConnection:VpcPeeringConnection",
+				Properties: map[string]interface{}{"region": "us-east-1"}},
+			want: []string{TransferInterAZ}, monthly: 2,
+		},
+		{
+			name: "cross-region peering",
+			resource: ResourceDescriptor{Type: "aws:ec2/vpcPeeringConnection:VpcPeeringConnection",
+				Properties: map[string]interface{}{"region": "us-east-1", "peerRegion": "eu-west-1"}},
+			want: []string{TransferInterRegion}, monthly: 2,
+		},
+		{
+			name:     "peering accepter",
+			resource: ResourceDescriptor{Type: "aws:ec2/vpcPeeringConnectionAccepter:VpcPeeringConnectionAccepter"},
+		},
+		{
+			name: "internet-facing cross-zone NLB",
+			resource: ResourceDescriptor{Type: "aws:lb/loadBalancer:LoadBalancer", Properties: map[string]interface{}{
+				"loadBalancerType": "network", "enableCrossZoneLoadBalancing": true,
+				"subnets": []interface{}{"subnet-a", "subnet-b"},
+			}},
+			want: []string{TransferEgress, TransferInterAZ}, monthly: 11,
+		},
+		{
+			name: "internal ALB",
+			resource: ResourceDescriptor{Type: "aws:lb/loadBalancer:LoadBalancer", Properties: map[string]interface{}{
+				"internal": true, "loadBalancerType": "application", "enableCrossZoneLoadBalancing": true,
+				"subnets": []interface{}{"subnet-a", "subnet-b"},
+			}},
+		},
+		{
+			name:     "NAT gateway",
+			resource: ResourceDescriptor{Type: "aws:ec2/natGateway:NatGateway"},
+			want:     []string{TransferEgress}, monthly: 13.5,
+		},
+		{
+			name: "S3 replication",
+			resource: ResourceDescriptor{Type: "aws:s3/bucket:Bucket", Properties: map[string]interface{}{
+				"replicationConfiguration": map[string]interface{}{"role": "arn:aws:iam::1:role/r"},
+			}},
+			want: []string{TransferInterRegion}, monthly: 2,
+		},
+		{
+			name: "cross-region read replica",
+			resource: ResourceDescriptor{Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+				"availabilityZone":  "eu-west-1a",
+				"replicateSourceDb": "arn:aws:rds:us-east-1:123456789012:db:primary",
+			}},
+			want: []string{TransferInterRegion}, monthly: 2,
+		},
+		{
+			name: "same-region read replica",
+			resource: ResourceDescriptor{Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+				"region": "us-east-1", "replicateSourceDb": "arn:aws:rds:us-east-1:123456789012:db:primary",
+			}},
+		},
+		{
+			name: "global table",
+			resource: ResourceDescriptor{Type: "aws:dynamodb/table:Table", Properties: map[string]interface{}{
+				"replicas": []interface{}{map[string]interface{}{"regionName": "eu-west-1"},
+					map[string]interface{}{"regionName": "ap-south-1"}},
+			}},
+			want: []string{TransferInterRegion, TransferInterRegion}, monthly: 4,
+		},
+		{
+			name:     "no topology hints",
+			resource: ResourceDescriptor{Type: "aws:ec2/instance:Instance"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.resource.ID = "r"
+			estimates := EstimateDataTransfer([]ResourceDescriptor{tt.resource}, TransferOptions{})
+			var kinds []string
+			monthly := 0.0
+			for _, e := range estimates {
+				kinds = append(kinds, e.Kind)
+				monthly += e.Monthly
+				assert.Equal(t, "r", e.ResourceID)
+				assert.InDelta(t, DefaultTransferGBPerMonth, e.GBPerMonth, 0.001)
+			}
+			assert.Equal(t, tt.want, kinds)
+			assert.InDelta(t, tt.monthly, monthly, 0.001)
+		})
+	}
+}
+
+func TestEstimateDataTransfer_Volume(t *testing.T) {
+	resources := []ResourceDescriptor{
+		{ID: "nat", Type: "aws:ec2/natGateway:NatGateway"},
+		{ID: "tagged", Type: "aws:ec2/natGateway:NatGateway",
+			Properties: map[string]interface{}{"tags": map[string]interface{}{TransferVolumeTag: "1000"}}},
+	}
+	estimates := EstimateDataTransfer(resources, TransferOptions{GBPerMonth: 10})
+	require.Len(t, estimates, 2)
+	assert.InDelta(t, 10.0, estimates[0].GBPerMonth, 0.001)
+	assert.InDelta(t, 1000.0, estimates[1].GBPerMonth, 0.001, "the tag overrides the assumed volume")
+	assert.InDelta(t, 135.0, estimates[1].Monthly, 0.001)
+}
+
+func TestTransferLineItems(t *testing.T) {
+	items := TransferLineItems([]TransferEstimate{{
+		ResourceID: "urn:nat", ResourceType: "aws:ec2/natGateway:NatGateway", Kind: TransferEgress,
+		Hint: "NAT gateway egress and processing", GBPerMonth: 100, RatePerGB: 0.135, Monthly: 13.5,
+	}})
+	require.Len(t, items, 1)
+	item := items[0]
+	assert.Equal(t, "urn:nat#transfer:egress", item.ResourceID)
+	assert.Equal(t, TransferAdapter, item.Adapter)
+	assert.Equal(t, "USD", item.Currency)
+	assert.InDelta(t, 13.5, item.Monthly, 0.001)
+	assert.Contains(t, item.Notes, "100 GB/month")
+	assert.Equal(t, map[string]float64{DimensionDataTransfer: 13.5}, CostDimensions(item, DimensionBasisMonthly))
+}
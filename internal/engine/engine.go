@@ -2,7 +2,6 @@ package engine
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
@@ -15,9 +14,10 @@ import (
 
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/engine/batch"
-	"github.com/rshade/finfocus/internal/engine/cache"
+	"github.com/rshade/finfocus/internal/engine/spill"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/pluginruntime"
 	"github.com/rshade/finfocus/internal/proto"
 )
 
@@ -57,6 +57,9 @@ type ContextKey string
 const (
 	// ContextKeyUtilization is the context key for passing utilization rate.
 	ContextKeyUtilization ContextKey = "utilization"
+	// ContextKeyPricingModel is the context key for the pricing model, one of
+	// PricingModels, that projections request from plugins.
+	ContextKeyPricingModel ContextKey = "pricing_model"
 
 	// Timeout constants for engine operations.
 	defaultQueryTimeout = 60 * time.Second // Overall query timeout.
@@ -106,9 +109,19 @@ type Router interface {
 type Engine struct {
 	clients        []*pluginhost.Client
 	loader         SpecLoader
-	cache          *cache.FileStore
 	router         Router                 // Optional router for plugin selection; if nil, queries all plugins
 	dismissalStore *config.DismissalStore // Optional dismissal store; if nil, created on demand
+	noDedupe       bool                   // Disables cross-plugin recommendation deduplication
+
+	// recommendationProgress optionally receives the progress of GetRecommendationsForResources.
+	recommendationProgress RecommendationProgressFunc
+
+	// results optionally caches projected cost results by resource content hash.
+	results *resultCache
+
+	// spillThreshold is the number of cost results collected in memory before
+	// they spill to disk; zero or less uses spill.DefaultThreshold.
+	spillThreshold int
 }
 
 // New creates a new Engine with the given plugin clients and spec loader.
@@ -116,17 +129,9 @@ func New(clients []*pluginhost.Client, loader SpecLoader) *Engine {
 	return &Engine{
 		clients: clients,
 		loader:  loader,
-		cache:   nil, // Cache is optional, set via WithCache
 	}
 }
 
-// WithCache sets the cache store for the engine.
-// This is optional - if not set, no caching will be performed.
-func (e *Engine) WithCache(cacheStore *cache.FileStore) *Engine {
-	e.cache = cacheStore
-	return e
-}
-
 // WithRouter sets the router for intelligent plugin selection.
 // This is optional - if not set, all plugins are queried for each resource.
 func (e *Engine) WithRouter(router Router) *Engine {
@@ -141,6 +146,22 @@ func (e *Engine) WithDismissalStore(store *config.DismissalStore) *Engine {
 	return e
 }
 
+// WithSpillThreshold sets the number of cost results a calculation holds in
+// memory before moving them to a temporary file. Zero or less uses
+// spill.DefaultThreshold.
+func (e *Engine) WithSpillThreshold(threshold int) *Engine {
+	e.spillThreshold = threshold
+	return e
+}
+
+// WithRecommendationDedupe enables or disables merging of duplicate
+// recommendations reported by multiple plugins for the same resource and
+// action type. Deduplication is enabled by default.
+func (e *Engine) WithRecommendationDedupe(enabled bool) *Engine {
+	e.noDedupe = !enabled
+	return e
+}
+
 func (e *Engine) getConcurrencyMultiplier() int {
 	if val := os.Getenv(envConcurrencyMultiplier); val != "" {
 		if i, err := strconv.Atoi(val); err == nil && i > 0 {
@@ -482,6 +503,9 @@ func (e *Engine) GetProjectedCost(
 		results = append(results, cr.results...)
 	}
 
+	annotateAccuracy(results)
+	convertResults(ctx, results)
+
 	if ctx.Err() != nil {
 		return results, fmt.Errorf("projected cost calculation cancelled: %w", ctx.Err())
 	}
@@ -498,12 +522,53 @@ func (e *Engine) GetProjectedCost(
 }
 
 // GetProjectedCostWithErrors calculates projected costs with comprehensive error tracking.
-//
-//nolint:funlen,gocognit // Parallel implementation requires worker setup
 func (e *Engine) GetProjectedCostWithErrors(
 	ctx context.Context,
 	resources []ResourceDescriptor,
 ) (*CostResultWithErrors, error) {
+	return e.StreamProjectedCostWithErrors(ctx, resources, nil)
+}
+
+// ProjectedResultFunc receives the cost results for one resource. Returning
+// an error stops further calls and is returned by StreamProjectedCostWithErrors.
+type ProjectedResultFunc func(results []CostResult) error
+
+// ResultBatchFunc receives the cost results for one resource before they are
+// stored, and may change them. Returning an error stops further calls and is
+// returned by the streaming method that called it.
+type ResultBatchFunc func(results []CostResult) error
+
+// StreamProjectedCostWithErrors is GetProjectedCostWithErrors that also hands
+// each resource's results to fn as soon as that resource and every resource
+// before it have been priced, so output can start rendering before a large
+// plan completes while keeping input order. fn runs on the calling goroutine
+// and may be nil. The returned value holds the complete results in memory;
+// use StreamProjectedCostToStore for plans too large for that.
+func (e *Engine) StreamProjectedCostWithErrors(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+	fn ProjectedResultFunc,
+) (*CostResultWithErrors, error) {
+	store, err := e.StreamProjectedCostToStore(ctx, resources, ResultBatchFunc(fn))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Close() }()
+	return store.Collect()
+}
+
+// StreamProjectedCostToStore prices resources like
+// StreamProjectedCostWithErrors but returns the results in a CostResultStore,
+// which spills them to disk past the threshold set by WithSpillThreshold.
+// Each resource's results go to fn in input order before they are stored,
+// and changes fn makes to them are stored. The caller must close the store.
+//
+//nolint:funlen,gocognit // Parallel implementation requires worker setup
+func (e *Engine) StreamProjectedCostToStore(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+	fn ResultBatchFunc,
+) (*CostResultStore, error) {
 	type job struct {
 		index    int
 		resource ResourceDescriptor
@@ -515,9 +580,12 @@ func (e *Engine) GetProjectedCostWithErrors(
 		errors  []ErrorDetail
 	}
 
+	ctx, span := startOperationSpan(ctx, spanProjectedCost, len(resources))
+	defer span.End()
+
 	numWorkers := e.getWorkerCount(len(resources))
 	if numWorkers == 0 {
-		return &CostResultWithErrors{}, nil
+		return NewCostResultStore(e.spillThreshold), nil
 	}
 
 	jobs := make(chan job, len(resources))
@@ -532,30 +600,43 @@ func (e *Engine) GetProjectedCostWithErrors(
 			}
 
 			resource := j.resource
+			spanCtx, resourceSpan := startResourceSpan(ctx, spanProjectedResource, resource)
 			var resourceResults []CostResult
 			var resourceErrors []ErrorDetail
-			log := logging.FromContext(ctx)
+			log := logging.FromContext(spanCtx)
 
 			// Select plugin matches using router (if configured) or all clients
-			selectedMatches := e.selectPluginMatchesForResource(ctx, resource, "ProjectedCosts")
+			selectedMatches := e.selectPluginMatchesForResource(spanCtx, resource, "ProjectedCosts")
 			if selectedMatches == nil {
-				continue // Resource intentionally filtered (e.g., internal Pulumi type)
+				// Resource intentionally filtered (e.g., internal Pulumi type); report
+				// it empty so ordered streaming does not wait on it.
+				resourceSpan.End()
+				resultsChan <- workerResult{index: j.index}
+				continue
+			}
+
+			// Reuse the results of an unchanged resource from a previous run
+			var cacheKey string
+			reused := false
+			if e.results != nil {
+				cacheKey = resultCacheKey(spanCtx, resource, selectedMatches)
+				resourceResults, reused = e.results.cachedResults(spanCtx, cacheKey, resource)
 			}
 
 			// Try each selected plugin with fallback chain logic
 			fallbackChainBroken := false
 			for i, match := range selectedMatches {
-				if fallbackChainBroken {
+				if fallbackChainBroken || reused {
 					break
 				}
 
 				client := match.Client
-				pluginResult, err := e.getProjectedCostFromPlugin(ctx, client, resource)
+				pluginResult, err := e.getProjectedCostFromPlugin(spanCtx, client, resource)
 				if err != nil {
 					// Check if fallback is enabled for this plugin
 					if !match.Fallback {
 						log.Info().
-							Ctx(ctx).
+							Ctx(spanCtx).
 							Str("component", "engine").
 							Str("resource_type", resource.Type).
 							Str("plugin", client.Name).
@@ -566,7 +647,7 @@ func (e *Engine) GetProjectedCostWithErrors(
 						// Log fallback event at INFO level per FR-020
 						nextPlugin := selectedMatches[i+1].Client.Name
 						log.Info().
-							Ctx(ctx).
+							Ctx(spanCtx).
 							Str("component", "engine").
 							Str("resource_type", resource.Type).
 							Str("failed_plugin", client.Name).
@@ -591,11 +672,15 @@ func (e *Engine) GetProjectedCostWithErrors(
 				}
 			}
 
+			// Only complete plugin results are worth reusing; fallbacks are cheap
+			// and failed plugins deserve another attempt.
+			cacheable := !reused && len(resourceResults) > 0 && len(resourceErrors) == 0
+
 			// If no results from plugins, try spec fallback
 			if len(resourceResults) == 0 {
 				fallbackUsed := false
 				if e.loader != nil {
-					if specRes := e.getProjectedCostFromSpec(ctx, resource); specRes != nil {
+					if specRes := e.getProjectedCostFromSpec(spanCtx, resource); specRes != nil {
 						resourceResults = append(resourceResults, *specRes)
 						fallbackUsed = true
 					}
@@ -620,6 +705,21 @@ func (e *Engine) GetProjectedCostWithErrors(
 				}
 			}
 
+			if e.results != nil {
+				switch {
+				case reused:
+					e.results.reused.Add(1)
+				case cacheable:
+					e.results.queried.Add(1)
+					e.results.cacheResults(spanCtx, cacheKey, resourceResults)
+				default:
+					e.results.queried.Add(1)
+				}
+			}
+
+			annotateAccuracy(resourceResults)
+			convertResults(spanCtx, resourceResults)
+			endResourceSpan(resourceSpan, len(resourceResults), resourceErrors)
 			resultsChan <- workerResult{
 				index:   j.index,
 				results: resourceResults,
@@ -643,30 +743,26 @@ func (e *Engine) GetProjectedCostWithErrors(
 		close(resultsChan)
 	}()
 
-	var collectedResults []workerResult
+	collector := newResultCollector(e.spillThreshold)
+	defer func() { _ = collector.close() }()
+	var collectErr error
 	for res := range resultsChan {
-		collectedResults = append(collectedResults, res)
+		if collectErr != nil {
+			continue
+		}
+		collectErr = collector.add(res.index, res.results, res.errors, fn)
+	}
+	if e.results != nil {
+		e.results.logUsage(ctx)
 	}
 
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-
-	sort.Slice(collectedResults, func(i, j int) bool {
-		return collectedResults[i].index < collectedResults[j].index
-	})
-
-	finalResult := &CostResultWithErrors{
-		Results: []CostResult{},
-		Errors:  []ErrorDetail{},
+	if collectErr != nil {
+		return nil, fmt.Errorf("collecting projected cost results: %w", collectErr)
 	}
-
-	for _, cr := range collectedResults {
-		finalResult.Results = append(finalResult.Results, cr.results...)
-		finalResult.Errors = append(finalResult.Errors, cr.errors...)
-	}
-
-	return finalResult, nil
+	return collector.take(), nil
 }
 
 // GetActualCost retrieves historical actual costs from plugins for the specified time range.
@@ -921,6 +1017,10 @@ func (e *Engine) GetActualCostWithOptions(
 		}
 	}
 
+	// Convert before grouping so aggregates never mix currencies.
+	annotateAccuracy(results)
+	convertResults(ctx, results)
+
 	// Group results if requested
 	if request.GroupBy != "" {
 		log.Debug().
@@ -958,6 +1058,51 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 	ctx context.Context,
 	request ActualCostRequest,
 ) (*CostResultWithErrors, error) {
+	return e.StreamActualCostWithErrors(ctx, request, nil)
+}
+
+// ActualResultFunc receives the actual cost result for one resource.
+// Returning an error stops further calls and is returned by
+// StreamActualCostWithErrors.
+type ActualResultFunc func(result CostResult) error
+
+// StreamActualCostWithErrors is GetActualCostWithOptionsAndErrors that also
+// hands each resource's result to fn as soon as that resource and every
+// resource before it have been queried, keeping input order. Results reach fn
+// before any request.GroupBy grouping, which needs them all. fn runs on the
+// calling goroutine and may be nil. The returned value holds the complete
+// results in memory; use StreamActualCostToStore for stacks too large for
+// that.
+func (e *Engine) StreamActualCostWithErrors(
+	ctx context.Context,
+	request ActualCostRequest,
+	fn ActualResultFunc,
+) (*CostResultWithErrors, error) {
+	var batchFn ResultBatchFunc
+	if fn != nil {
+		batchFn = func(results []CostResult) error { return fn(results[0]) }
+	}
+	store, err := e.StreamActualCostToStore(ctx, request, batchFn)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = store.Close() }()
+	return store.Collect()
+}
+
+// StreamActualCostToStore queries actual costs like StreamActualCostWithErrors
+// but returns the results in a CostResultStore, which spills them to disk
+// past the threshold set by WithSpillThreshold. Each resource's result goes
+// to fn in input order before it is stored, and changes fn makes to it are
+// stored. Grouping by request.GroupBy reads the results into memory, as the
+// groups need all of them. The caller must close the store.
+//
+//nolint:funlen,gocognit // Parallel implementation requires worker setup
+func (e *Engine) StreamActualCostToStore(
+	ctx context.Context,
+	request ActualCostRequest,
+	fn ResultBatchFunc,
+) (*CostResultStore, error) {
 	type job struct {
 		index    int
 		resource ResourceDescriptor
@@ -969,9 +1114,12 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 		errors []ErrorDetail
 	}
 
+	ctx, span := startOperationSpan(ctx, spanActualCost, len(request.Resources))
+	defer span.End()
+
 	numWorkers := e.getWorkerCount(len(request.Resources))
 	if numWorkers == 0 {
-		return &CostResultWithErrors{}, nil
+		return NewCostResultStore(e.spillThreshold), nil
 	}
 
 	jobs := make(chan job, len(request.Resources))
@@ -993,7 +1141,19 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 				continue
 			}
 
-			resourceResult, errors := e.getActualCostForResource(ctx, resource, request)
+			spanCtx, resourceSpan := startResourceSpan(ctx, spanActualResource, resource)
+			resourceResult, errors := e.getActualCostForResource(spanCtx, resource, request)
+			resultCount := 0
+			if resourceResult != nil {
+				resultCount = 1
+				// Convert here rather than after collection so streamed
+				// results match the returned ones.
+				resourceResults := []CostResult{*resourceResult}
+				annotateAccuracy(resourceResults)
+				convertResults(spanCtx, resourceResults)
+				resourceResult = &resourceResults[0]
+			}
+			endResourceSpan(resourceSpan, resultCount, errors)
 			resultsChan <- workerResult{index: j.index, result: resourceResult, errors: errors}
 		}
 	}
@@ -1013,37 +1173,53 @@ func (e *Engine) GetActualCostWithOptionsAndErrors(
 		close(resultsChan)
 	}()
 
-	var collectedResults []workerResult
+	collector := newResultCollector(e.spillThreshold)
+	defer func() { _ = collector.close() }()
+	var collectErr error
 	for res := range resultsChan {
-		collectedResults = append(collectedResults, res)
+		if collectErr != nil {
+			continue
+		}
+		var results []CostResult
+		if res.result != nil {
+			results = []CostResult{*res.result}
+		}
+		collectErr = collector.add(res.index, results, res.errors, fn)
 	}
 
 	if ctx.Err() != nil {
 		return nil, ctx.Err()
 	}
-
-	sort.Slice(collectedResults, func(i, j int) bool {
-		return collectedResults[i].index < collectedResults[j].index
-	})
-
-	result := &CostResultWithErrors{
-		Results: []CostResult{},
-		Errors:  []ErrorDetail{},
+	if collectErr != nil {
+		return nil, fmt.Errorf("collecting actual cost results: %w", collectErr)
 	}
+	store := collector.take()
 
-	for _, cr := range collectedResults {
-		if cr.result != nil {
-			result.Results = append(result.Results, *cr.result)
+	// Results are converted as they are queried, so grouped aggregates never
+	// mix currencies.
+	if request.GroupBy != "" {
+		if err := e.groupStore(store, GroupBy(request.GroupBy)); err != nil {
+			_ = store.Close()
+			return nil, fmt.Errorf("grouping actual cost results: %w", err)
 		}
-		result.Errors = append(result.Errors, cr.errors...)
 	}
 
-	// Group results if requested
-	if request.GroupBy != "" {
-		result.Results = e.GroupResults(result.Results, GroupBy(request.GroupBy))
-	}
+	return store, nil
+}
 
-	return result, nil
+// groupStore replaces the results of store with their groups by groupBy.
+func (e *Engine) groupStore(store *CostResultStore, groupBy GroupBy) error {
+	collected, err := store.Collect()
+	if err != nil {
+		return err
+	}
+	grouped := spill.New[CostResult](store.threshold, "")
+	if err = grouped.Append(e.GroupResults(collected.Results, groupBy)...); err != nil {
+		_ = grouped.Close()
+		return err
+	}
+	store.replace(grouped)
+	return nil
 }
 
 // getActualCostForResource processes a single resource for actual cost with error tracking.
@@ -1292,7 +1468,7 @@ func (e *Engine) getProjectedCostFromPlugin(
 				ID:         resource.ID,
 				Type:       resource.Type,
 				Provider:   resource.Provider,
-				Properties: ConvertToProto(resource.Properties),
+				Properties: withPricingModelTag(ctx, ConvertToProto(resource.Properties)),
 			},
 		},
 	}
@@ -1332,6 +1508,10 @@ func (e *Engine) getProjectedCostFromPlugin(
 		}
 		return engineResult, nil
 	}
+	if errors.Is(err, pluginruntime.ErrCircuitOpen) {
+		// Surface skipped plugins so the caller records them in ErrorDetail.
+		return nil, err
+	}
 
 	return nil, ErrNoCostData
 }
@@ -1851,6 +2031,14 @@ func AggregateResults(results []CostResult) *AggregatedResults {
 
 		// Aggregate by adapter
 		summary.ByAdapter[result.Adapter] += result.Monthly
+
+		// Aggregate by stack when results span several stacks
+		if result.Stack != "" {
+			if summary.ByStack == nil {
+				summary.ByStack = make(map[string]float64)
+			}
+			summary.ByStack[result.Stack] += result.Monthly
+		}
 	}
 
 	return &AggregatedResults{
@@ -2119,9 +2307,11 @@ func AggregateResultsInternal(results []CostResult, groupName string) CostResult
 		EndDate:      first.EndDate,
 		CostPeriod:   first.CostPeriod,
 		Breakdown:    make(map[string]float64),
+		Accuracy:     first.Accuracy,
 	}
 
 	for _, result := range results {
+		aggregated.Accuracy = lowerAccuracy(aggregated.Accuracy, result.Accuracy)
 		aggregated.Monthly += result.Monthly
 		aggregated.Hourly += result.Hourly
 		aggregated.TotalCost += result.TotalCost
@@ -2731,9 +2921,14 @@ func createSortedAggregations(
 // The plugin is expected to populate ResourceID from the Id field sent in ResourceDescriptor.
 func convertProtoRecommendation(rec *proto.Recommendation) Recommendation {
 	engineRec := Recommendation{
-		ResourceID:  rec.ResourceID,
-		Type:        rec.ActionType,
-		Description: rec.Description,
+		ID:             rec.ID,
+		ResourceID:     rec.ResourceID,
+		Type:           rec.ActionType,
+		Description:    rec.Description,
+		Source:         rec.Source,
+		CurrentSKU:     rec.CurrentSKU,
+		RecommendedSKU: rec.RecommendedSKU,
+		Metadata:       rec.Metadata,
 	}
 
 	if engineRec.Type == "" {
@@ -2743,6 +2938,9 @@ func convertProtoRecommendation(rec *proto.Recommendation) Recommendation {
 	if rec.Impact != nil {
 		engineRec.EstimatedSavings = rec.Impact.EstimatedSavings
 		engineRec.Currency = rec.Impact.Currency
+		engineRec.CurrentCost = rec.Impact.CurrentCost
+		engineRec.ProjectedCost = rec.Impact.ProjectedCost
+		engineRec.SavingsPercentage = rec.Impact.SavingsPercentage
 	}
 
 	engineRec.Reasoning = rec.Reasoning
@@ -2753,7 +2951,7 @@ func convertProtoRecommendation(rec *proto.Recommendation) Recommendation {
 // GetRecommendationsForResources fetches cost optimization recommendations for the given resources.
 // For large datasets (>100 resources), it uses batch processing to improve performance and memory usage.
 //
-//nolint:gocognit,funlen // Complex orchestration function with caching and batch processing.
+//nolint:gocognit,funlen // Complex orchestration function with batch processing.
 func (e *Engine) GetRecommendationsForResources(
 	ctx context.Context,
 	resources []ResourceDescriptor,
@@ -2767,30 +2965,8 @@ func (e *Engine) GetRecommendationsForResources(
 		return result, nil
 	}
 
-	// Check cache if enabled
-	//nolint:nestif // Cache lookup requires nested checks for key generation, cache hit, and unmarshaling.
-	if e.cache != nil && e.cache.IsEnabled() {
-		cacheKey, keyErr := e.generateRecommendationsCacheKey(resources)
-		if keyErr == nil {
-			if cachedEntry, cacheErr := e.cache.Get(cacheKey); cacheErr == nil && cachedEntry != nil {
-				log.Debug().
-					Ctx(ctx).
-					Str("component", "engine").
-					Str("cache_key", cacheKey).
-					Msg("cache hit for recommendations")
-
-				// Unmarshal cached result
-				var cachedResult RecommendationsResult
-				if unmarshalErr := json.Unmarshal(cachedEntry.Data, &cachedResult); unmarshalErr == nil {
-					return &cachedResult, nil
-				}
-				log.Warn().
-					Ctx(ctx).
-					Str("component", "engine").
-					Msg("failed to unmarshal cached recommendations")
-			}
-		}
-	}
+	ctx, span := startOperationSpan(ctx, spanRecommendations, len(resources))
+	defer span.End()
 
 	// Load dismissal store to filter excluded recommendation IDs
 	excludedIDs := loadExcludedRecommendationIDs(ctx, e.dismissalStore)
@@ -2838,53 +3014,38 @@ func (e *Engine) GetRecommendationsForResources(
 		}
 	}
 
-	// Store result in cache if enabled
-	//nolint:nestif // Cache storage requires nested checks for key generation, marshaling, and storage.
-	if e.cache != nil && e.cache.IsEnabled() {
-		cacheKey, keyErr := e.generateRecommendationsCacheKey(resources)
-		if keyErr == nil {
-			resultData, marshalErr := json.Marshal(result)
-			if marshalErr == nil {
-				if setErr := e.cache.Set(cacheKey, json.RawMessage(resultData)); setErr != nil {
-					log.Warn().
-						Ctx(ctx).
-						Str("component", "engine").
-						Err(setErr).
-						Msg("failed to store recommendations in cache")
-				} else {
-					log.Debug().
-						Ctx(ctx).
-						Str("component", "engine").
-						Str("cache_key", cacheKey).
-						Msg("stored recommendations in cache")
-				}
-			}
-		}
-	}
+	convertRecommendations(ctx, result)
+
+	// Deduplicate last so --no-dedupe sees every plugin's recommendations.
+	e.dedupeRecommendations(ctx, result)
 
 	return result, nil
 }
 
-// generateRecommendationsCacheKey generates a cache key for the given resources.
-func (e *Engine) generateRecommendationsCacheKey(resources []ResourceDescriptor) (string, error) {
-	// Extract resource types for key generation
-	resourceTypes := make([]string, 0, len(resources))
-	for _, r := range resources {
-		resourceTypes = append(resourceTypes, r.Type)
+// dedupeRecommendations merges cross-plugin duplicates in result unless
+// deduplication is disabled, and recomputes the total savings.
+func (e *Engine) dedupeRecommendations(ctx context.Context, result *RecommendationsResult) {
+	if e.noDedupe || len(result.Recommendations) < 2 {
+		return
 	}
 
-	// Build cache key using resource types and operation
-	keyParams := cache.KeyParams{
-		Operation:     "recommendations",
-		Provider:      "multi", // Cross-provider recommendations
-		ResourceTypes: resourceTypes,
+	deduped, merged := DeduplicateRecommendations(result.Recommendations)
+	if merged == 0 {
+		return
 	}
 
-	key, err := cache.GenerateKey(keyParams)
-	if err != nil {
-		return "", fmt.Errorf("generating recommendations cache key: %w", err)
+	result.Recommendations = deduped
+	result.TotalSavings = 0
+	for _, rec := range deduped {
+		result.TotalSavings += rec.EstimatedSavings
 	}
-	return key, nil
+
+	logging.FromContext(ctx).Debug().
+		Ctx(ctx).
+		Str("component", "engine").
+		Int("merged_count", merged).
+		Int("recommendation_count", len(deduped)).
+		Msg("deduplicated cross-plugin recommendations")
 }
 
 // fetchRecommendationsSequential fetches recommendations without batching (for small datasets).
@@ -2916,7 +3077,10 @@ func (e *Engine) fetchRecommendationsSequential(
 		req.ExcludedRecommendationIDs = excludedIDs
 	}
 
-	resp, err := client.API.GetRecommendations(ctx, req)
+	received, err := streamPluginRecommendations(ctx, client, req, result)
+	e.reportRecommendationProgress(RecommendationProgress{
+		Plugin: client.Name, Batches: 1, Resources: len(resources), Recommendations: received, Err: err,
+	})
 	if err != nil {
 		return err
 	}
@@ -2925,21 +3089,41 @@ func (e *Engine) fetchRecommendationsSequential(
 		Ctx(ctx).
 		Str("component", "engine").
 		Str("plugin", client.Name).
-		Int("recommendation_count", len(resp.Recommendations)).
+		Int("recommendation_count", received).
 		Msg("received recommendations from plugin")
 
-	for _, rec := range resp.Recommendations {
-		engineRec := convertProtoRecommendation(rec)
-		if result.Currency == defaultCurrency && engineRec.Currency != "" {
-			result.Currency = engineRec.Currency
-		}
-		result.TotalSavings += engineRec.EstimatedSavings
-		result.Recommendations = append(result.Recommendations, engineRec)
-	}
-
 	return nil
 }
 
+// streamPluginRecommendations collects every recommendation page the plugin
+// returns for req into result and reports how many were received. Pages are
+// streamed when the plugin supports it and fetched through unary paging
+// otherwise.
+func streamPluginRecommendations(
+	ctx context.Context,
+	client *pluginhost.Client,
+	req *proto.GetRecommendationsRequest,
+	result *RecommendationsResult,
+) (int, error) {
+	received := 0
+	err := proto.StreamRecommendations(ctx, client.API, req, func(page *proto.GetRecommendationsResponse) error {
+		received += len(page.Recommendations)
+		for _, rec := range page.Recommendations {
+			engineRec := convertProtoRecommendation(rec)
+			if engineRec.Source == "" {
+				engineRec.Source = client.Name
+			}
+			if result.Currency == defaultCurrency && engineRec.Currency != "" {
+				result.Currency = engineRec.Currency
+			}
+			result.TotalSavings += engineRec.EstimatedSavings
+			result.Recommendations = append(result.Recommendations, engineRec)
+		}
+		return nil
+	})
+	return received, err
+}
+
 // fetchRecommendationsWithBatching fetches recommendations using batch processing for large datasets.
 func (e *Engine) fetchRecommendationsWithBatching(
 	ctx context.Context,
@@ -2982,7 +3166,15 @@ func (e *Engine) fetchRecommendationsWithBatching(
 				req.ExcludedRecommendationIDs = excludedIDs
 			}
 
-			resp, recErr := client.API.GetRecommendations(ctx, req)
+			received, recErr := streamPluginRecommendations(ctx, client, req, result)
+			e.reportRecommendationProgress(RecommendationProgress{
+				Plugin:          client.Name,
+				Batch:           batchIndex,
+				Batches:         RecommendationBatches(len(resources)),
+				Resources:       len(batchResources),
+				Recommendations: received,
+				Err:             recErr,
+			})
 			if recErr != nil {
 				log.Warn().
 					Ctx(ctx).
@@ -3000,19 +3192,9 @@ func (e *Engine) fetchRecommendationsWithBatching(
 				Str("component", "engine").
 				Str("plugin", client.Name).
 				Int("batch_index", batchIndex).
-				Int("recommendation_count", len(resp.Recommendations)).
+				Int("recommendation_count", received).
 				Msg("received recommendations for batch")
 
-			// Aggregate results (thread-safe append)
-			for _, rec := range resp.Recommendations {
-				engineRec := convertProtoRecommendation(rec)
-				if result.Currency == defaultCurrency && engineRec.Currency != "" {
-					result.Currency = engineRec.Currency
-				}
-				result.TotalSavings += engineRec.EstimatedSavings
-				result.Recommendations = append(result.Recommendations, engineRec)
-			}
-
 			return nil
 		},
 	)
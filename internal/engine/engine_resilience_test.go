@@ -0,0 +1,43 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/pluginruntime"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// unavailableClient fails every projected cost call as if the plugin had crashed.
+type unavailableClient struct {
+	proto.CostSourceClient
+}
+
+func (unavailableClient) GetProjectedCost(
+	context.Context, *proto.GetProjectedCostRequest, ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	return nil, status.Error(codes.Unavailable, "plugin exited")
+}
+
+func TestGetProjectedCostWithErrors_RecordsOpenCircuit(t *testing.T) {
+	api := pluginruntime.Wrap("crashy", unavailableClient{}, pluginruntime.Policy{FailureThreshold: 1})
+	eng := New([]*pluginhost.Client{{Name: "crashy", API: api}}, nil)
+	resources := []ResourceDescriptor{{ID: "bucket", Type: "aws:s3/bucket:Bucket", Provider: "aws"}}
+
+	_, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	require.True(t, api.Breaker().Open())
+
+	result, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, result.Errors, 1)
+	assert.Equal(t, "crashy", result.Errors[0].PluginName)
+	assert.ErrorIs(t, result.Errors[0].Error, pluginruntime.ErrCircuitOpen)
+}
@@ -0,0 +1,233 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// slowFirstClient prices resources named by their index and answers earlier
+// resources more slowly, so workers finish out of order.
+type slowFirstClient struct {
+	proto.CostSourceClient
+
+	count int
+}
+
+func (c slowFirstClient) GetProjectedCost(
+	_ context.Context, in *proto.GetProjectedCostRequest, _ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	idx, err := strconv.Atoi(in.Resources[0].ID)
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(time.Duration(c.count-idx) * time.Millisecond)
+	return &proto.GetProjectedCostResponse{Results: []*proto.CostResult{
+		{Currency: "USD", MonthlyCost: float64(idx)},
+	}}, nil
+}
+
+func (c slowFirstClient) GetActualCost(
+	_ context.Context, in *proto.GetActualCostRequest, _ ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	idx, err := strconv.Atoi(in.ResourceIDs[0])
+	if err != nil {
+		return nil, err
+	}
+	time.Sleep(time.Duration(c.count-idx) * time.Millisecond)
+	return &proto.GetActualCostResponse{Results: []*proto.ActualCostResult{
+		{Currency: "USD", TotalCost: float64(idx)},
+	}}, nil
+}
+
+func TestStreamProjectedCostWithErrors_EmitsInInputOrder(t *testing.T) {
+	const count = 8
+	eng := New([]*pluginhost.Client{{Name: "slow", API: slowFirstClient{count: count}}}, nil)
+	resources := make([]ResourceDescriptor, count)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+
+	var streamed []string
+	result, err := eng.StreamProjectedCostWithErrors(context.Background(), resources,
+		func(batch []CostResult) error {
+			for _, r := range batch {
+				streamed = append(streamed, r.ResourceID)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+
+	var final []string
+	for _, r := range result.Results {
+		final = append(final, r.ResourceID)
+	}
+	assert.Len(t, streamed, count)
+	assert.Equal(t, final, streamed)
+}
+
+func TestStreamProjectedCostWithErrors_SpillsLargeResults(t *testing.T) {
+	const count = 8
+	resources := make([]ResourceDescriptor, count)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+	eng := New([]*pluginhost.Client{{Name: "slow", API: slowFirstClient{count: count}}}, nil)
+	inMemory, err := eng.StreamProjectedCostWithErrors(context.Background(), resources, nil)
+	require.NoError(t, err)
+
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+	spilled, err := eng.WithSpillThreshold(2).StreamProjectedCostWithErrors(context.Background(), resources, nil)
+	require.NoError(t, err)
+	// Spilled results are read back from JSON, so compare them as JSON.
+	want, err := json.Marshal(inMemory)
+	require.NoError(t, err)
+	got, err := json.Marshal(spilled)
+	require.NoError(t, err)
+	assert.JSONEq(t, string(want), string(got))
+	entries, err := os.ReadDir(tmp)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "the spill file is removed")
+}
+
+func TestStreamProjectedCostToStore_KeepsResultsSpilled(t *testing.T) {
+	const count = 8
+	resources := make([]ResourceDescriptor, count)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+	t.Setenv("TMPDIR", t.TempDir())
+	eng := New([]*pluginhost.Client{{Name: "slow", API: slowFirstClient{count: count}}}, nil).WithSpillThreshold(2)
+
+	store, err := eng.StreamProjectedCostToStore(context.Background(), resources, func(batch []CostResult) error {
+		batch[0].Stack = "prod"
+		return nil
+	})
+	require.NoError(t, err)
+	defer store.Close()
+	assert.True(t, store.Results.Spilled(), "results stay on disk rather than being read back")
+	assert.Equal(t, count, store.Results.Len())
+
+	var ids []string
+	require.NoError(t, store.Results.Each(func(r CostResult) error {
+		assert.Equal(t, "prod", r.Stack, "changes made by fn are stored")
+		ids = append(ids, r.ResourceID)
+		return nil
+	}))
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5", "6", "7"}, ids)
+}
+
+func TestStreamActualCostToStore_Groups(t *testing.T) {
+	const count = 4
+	resources := make([]ResourceDescriptor, count)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+	to := time.Now()
+	request := ActualCostRequest{
+		Resources: resources, From: to.AddDate(0, 0, -7), To: to, GroupBy: string(GroupByProvider),
+	}
+	eng := New([]*pluginhost.Client{{Name: "slow", API: slowFirstClient{count: count}}}, nil).WithSpillThreshold(1)
+
+	store, err := eng.StreamActualCostToStore(context.Background(), request, nil)
+	require.NoError(t, err)
+	defer store.Close()
+	grouped, err := store.Results.Slice(0, store.Results.Len())
+	require.NoError(t, err)
+	require.Len(t, grouped, 1)
+	assert.InDelta(t, 6.0, grouped[0].TotalCost, 1e-9)
+}
+
+func TestStreamProjectedCostWithErrors_StopsOnCallbackError(t *testing.T) {
+	eng := New([]*pluginhost.Client{{Name: "slow", API: slowFirstClient{count: 3}}}, nil)
+	resources := []ResourceDescriptor{
+		{ID: "0", Type: "aws:ec2/instance:Instance", Provider: "aws"},
+		{ID: "1", Type: "aws:ec2/instance:Instance", Provider: "aws"},
+	}
+
+	calls := 0
+	broken := errors.New("broken pipe")
+	_, err := eng.StreamProjectedCostWithErrors(context.Background(), resources, func([]CostResult) error {
+		calls++
+		return broken
+	})
+	require.ErrorIs(t, err, broken)
+	assert.Equal(t, 1, calls)
+}
+
+func TestStreamActualCostWithErrors_EmitsInInputOrder(t *testing.T) {
+	const count = 8
+	eng := New([]*pluginhost.Client{{Name: "slow", API: slowFirstClient{count: count}}}, nil)
+	resources := make([]ResourceDescriptor, count)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+	to := time.Now()
+	request := ActualCostRequest{Resources: resources, From: to.AddDate(0, 0, -7), To: to}
+
+	var streamed []CostResult
+	result, err := eng.StreamActualCostWithErrors(context.Background(), request, func(r CostResult) error {
+		streamed = append(streamed, r)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, result.Results, streamed, "streamed in input order, annotated as returned")
+	assert.InDelta(t, 7.0, streamed[count-1].TotalCost, 1e-9)
+
+	calls := 0
+	broken := errors.New("broken pipe")
+	_, err = eng.StreamActualCostWithErrors(context.Background(), request, func(CostResult) error {
+		calls++
+		return broken
+	})
+	require.ErrorIs(t, err, broken)
+	assert.Equal(t, 1, calls)
+}
+
+// pagedRecommendationsClient splits its recommendations over two pages.
+type pagedRecommendationsClient struct {
+	proto.CostSourceClient
+}
+
+func (pagedRecommendationsClient) GetRecommendations(
+	_ context.Context, in *proto.GetRecommendationsRequest, _ ...grpc.CallOption,
+) (*proto.GetRecommendationsResponse, error) {
+	page, resourceID := 1, "web"
+	if in.PageToken != "" {
+		page, resourceID = 2, "db"
+	}
+	resp := &proto.GetRecommendationsResponse{Recommendations: []*proto.Recommendation{{
+		ID:         fmt.Sprintf("rec-%d", page),
+		ResourceID: resourceID,
+		Impact:     &proto.RecommendationImpact{EstimatedSavings: 10, Currency: "USD"},
+	}}}
+	if page == 1 {
+		resp.NextPageToken = "page-2"
+	}
+	return resp, nil
+}
+
+func TestGetRecommendationsForResources_FollowsPages(t *testing.T) {
+	eng := New([]*pluginhost.Client{{Name: "recs", API: pagedRecommendationsClient{}}}, nil)
+
+	result, err := eng.GetRecommendationsForResources(context.Background(), []ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Provider: "aws"},
+		{ID: "db", Type: "aws:rds/instance:Instance", Provider: "aws"},
+	})
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 2)
+	assert.InDelta(t, 20.0, result.TotalSavings, 0.001)
+}
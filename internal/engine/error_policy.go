@@ -0,0 +1,111 @@
+package engine
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ErrorPolicy controls how resources that failed to be costed count in the
+// totals of a cost calculation.
+type ErrorPolicy string
+
+const (
+	// ErrorPolicyFail fails the command when any resource failed.
+	ErrorPolicyFail ErrorPolicy = "fail"
+	// ErrorPolicyOmit leaves failed resources out of the results and totals,
+	// and reports how many were left out.
+	ErrorPolicyOmit ErrorPolicy = "omit"
+	// ErrorPolicyZero keeps failed resources in the results as $0
+	// placeholders. It is the default.
+	ErrorPolicyZero ErrorPolicy = "zero"
+)
+
+// placeholderAdapter is the adapter of the $0 results that stand in for
+// resources no plugin or spec priced.
+const placeholderAdapter = "none"
+
+// ParseErrorPolicy parses an --on-error value; empty means ErrorPolicyZero.
+func ParseErrorPolicy(s string) (ErrorPolicy, error) {
+	switch policy := ErrorPolicy(strings.ToLower(strings.TrimSpace(s))); policy {
+	case "":
+		return ErrorPolicyZero, nil
+	case ErrorPolicyFail, ErrorPolicyOmit, ErrorPolicyZero:
+		return policy, nil
+	default:
+		return "", fmt.Errorf("invalid error policy %q: use fail, omit, or zero", s)
+	}
+}
+
+// PartialFailureError is returned by ApplyErrorPolicy under ErrorPolicyFail
+// when resources failed. Report describes the failures.
+type PartialFailureError struct {
+	Report *ErrorReport
+}
+
+func (e *PartialFailureError) Error() string {
+	return e.Report.Message + " (--on-error fail)"
+}
+
+// FailedResources returns the IDs of the resources that failed: those with
+// errors, or results carrying a plugin or validation error, and no result
+// other than a placeholder. A resource that a fallback plugin priced after
+// another plugin failed did not fail.
+func (c *CostResultWithErrors) FailedResources() map[string]bool {
+	failed := make(map[string]bool, len(c.Errors))
+	for _, detail := range c.Errors {
+		failed[detail.ResourceID] = true
+	}
+	for _, result := range c.Results {
+		if result.Error != nil && result.Error.Code != ErrCodeNoCostData {
+			failed[result.ResourceID] = true
+		}
+	}
+	for _, result := range c.Results {
+		if !isFailurePlaceholder(result, failed) {
+			delete(failed, result.ResourceID)
+		}
+	}
+	return failed
+}
+
+// ApplyErrorPolicy applies policy to the results. ErrorPolicyFail returns a
+// *PartialFailureError when resources failed, ErrorPolicyOmit moves their
+// placeholder results to Omitted, and ErrorPolicyZero changes nothing.
+func (c *CostResultWithErrors) ApplyErrorPolicy(policy ErrorPolicy) error {
+	switch policy {
+	case ErrorPolicyFail:
+		failed := c.FailedResources()
+		if len(failed) == 0 {
+			return nil
+		}
+		report := c.ErrorReport()
+		if report == nil {
+			report = &ErrorReport{Code: ErrCodePartialFailure}
+		}
+		report.Message = fmt.Sprintf("%d resource(s) failed", len(failed))
+		return &PartialFailureError{Report: report}
+	case ErrorPolicyOmit:
+		failed := c.FailedResources()
+		kept := make([]CostResult, 0, len(c.Results))
+		for _, result := range c.Results {
+			if isFailurePlaceholder(result, failed) {
+				c.Omitted = append(c.Omitted, result)
+				continue
+			}
+			kept = append(kept, result)
+		}
+		c.Results = kept
+	case ErrorPolicyZero:
+	}
+	return nil
+}
+
+// isFailurePlaceholder reports whether result stands in for a resource that
+// failed: it carries a plugin or validation error, or it is the $0
+// placeholder of a resource in failed.
+func isFailurePlaceholder(result CostResult, failed map[string]bool) bool {
+	if result.Error != nil && result.Error.Code != ErrCodeNoCostData {
+		return true
+	}
+	return failed[result.ResourceID] && result.Adapter == placeholderAdapter
+}
@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseErrorPolicy(t *testing.T) {
+	for input, want := range map[string]ErrorPolicy{
+		"": ErrorPolicyZero, "zero": ErrorPolicyZero, "omit": ErrorPolicyOmit, " FAIL ": ErrorPolicyFail,
+	} {
+		got, err := ParseErrorPolicy(input)
+		require.NoError(t, err, input)
+		assert.Equal(t, want, got, input)
+	}
+
+	_, err := ParseErrorPolicy("skip")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "use fail, omit, or zero")
+}
+
+// partialResult returns a calculation in which "web" was priced, "db" failed
+// and is a $0 placeholder, "cache" failed with its first plugin but was priced
+// by a fallback, and "queue" has no pricing data without failing.
+func partialResult() *CostResultWithErrors {
+	return &CostResultWithErrors{
+		Results: []CostResult{
+			{ResourceID: "web", Adapter: "aws-public", Monthly: 10},
+			{ResourceID: "db", Adapter: "none", Error: &StructuredError{Code: ErrCodeNoCostData}},
+			{ResourceID: "cache", Adapter: "aws-ce", Monthly: 5},
+			{ResourceID: "queue", Adapter: "none", Error: &StructuredError{Code: ErrCodeNoCostData}},
+		},
+		Errors: []ErrorDetail{
+			{ResourceID: "db", PluginName: "aws-public", Error: errors.New("boom")},
+			{ResourceID: "cache", PluginName: "aws-public", Error: errors.New("boom")},
+		},
+	}
+}
+
+func TestCostResultWithErrors_FailedResources(t *testing.T) {
+	assert.Equal(t, map[string]bool{"db": true}, partialResult().FailedResources())
+
+	// A result carrying a plugin error failed even without an error detail.
+	result := &CostResultWithErrors{Results: []CostResult{
+		{ResourceID: "vm", Adapter: "gcp", Error: &StructuredError{Code: ErrCodePluginError}},
+	}}
+	assert.Equal(t, map[string]bool{"vm": true}, result.FailedResources())
+}
+
+func TestCostResultWithErrors_ApplyErrorPolicy(t *testing.T) {
+	t.Run("zero", func(t *testing.T) {
+		result := partialResult()
+		require.NoError(t, result.ApplyErrorPolicy(ErrorPolicyZero))
+		assert.Len(t, result.Results, 4)
+		assert.Empty(t, result.Omitted)
+	})
+
+	t.Run("omit", func(t *testing.T) {
+		result := partialResult()
+		require.NoError(t, result.ApplyErrorPolicy(ErrorPolicyOmit))
+		ids := make([]string, 0, len(result.Results))
+		for _, r := range result.Results {
+			ids = append(ids, r.ResourceID)
+		}
+		assert.Equal(t, []string{"web", "cache", "queue"}, ids)
+		require.Len(t, result.Omitted, 1)
+		assert.Equal(t, "db", result.Omitted[0].ResourceID)
+		assert.Contains(t, result.ErrorSummary(), "1 failed resource(s) omitted from totals")
+
+		report := result.ErrorReport()
+		require.NotNil(t, report)
+		assert.Equal(t, 1, report.Omitted)
+		assert.Equal(t, "2 resource(s) failed; 1 omitted from totals", report.Message)
+	})
+
+	t.Run("fail", func(t *testing.T) {
+		err := partialResult().ApplyErrorPolicy(ErrorPolicyFail)
+		var partialErr *PartialFailureError
+		require.ErrorAs(t, err, &partialErr)
+		assert.Equal(t, "1 resource(s) failed (--on-error fail)", err.Error())
+		assert.Len(t, partialErr.Report.Failures, 2)
+
+		recovered := &CostResultWithErrors{
+			Results: []CostResult{{ResourceID: "cache", Adapter: "aws-ce", Monthly: 5}},
+			Errors:  []ErrorDetail{{ResourceID: "cache", PluginName: "aws-public", Error: errors.New("boom")}},
+		}
+		require.NoError(t, recovered.ApplyErrorPolicy(ErrorPolicyFail))
+	})
+}
+
+// partialStore returns partialResult in a CostResultStore that spills past
+// one result.
+func partialStore(t *testing.T) *CostResultStore {
+	t.Helper()
+	t.Setenv("TMPDIR", t.TempDir())
+	partial := partialResult()
+	store := NewCostResultStore(1)
+	t.Cleanup(func() { _ = store.Close() })
+	require.NoError(t, store.Results.Append(partial.Results...))
+	store.Errors = partial.Errors
+	return store
+}
+
+func TestCostResultStore_ApplyErrorPolicy(t *testing.T) {
+	failed, err := partialStore(t).FailedResources()
+	require.NoError(t, err)
+	assert.Equal(t, map[string]bool{"db": true}, failed)
+
+	store := partialStore(t)
+	require.NoError(t, store.ApplyErrorPolicy(ErrorPolicyOmit))
+	var ids []string
+	require.NoError(t, store.Results.Each(func(r CostResult) error {
+		ids = append(ids, r.ResourceID)
+		return nil
+	}))
+	assert.Equal(t, []string{"web", "cache", "queue"}, ids)
+	require.Len(t, store.Omitted, 1)
+	assert.Equal(t, "2 resource(s) failed; 1 omitted from totals", store.ErrorReport().Message)
+
+	err = partialStore(t).ApplyErrorPolicy(ErrorPolicyFail)
+	var partialErr *PartialFailureError
+	require.ErrorAs(t, err, &partialErr)
+	assert.Equal(t, "1 resource(s) failed", partialErr.Report.Message)
+}
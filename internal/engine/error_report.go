@@ -0,0 +1,127 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ErrorReportSchema identifies the structure of ErrorReport. Fields are only
+// ever added to a schema version; removing or renaming one bumps it.
+const ErrorReportSchema = "finfocus/error/v1"
+
+// Error codes of ErrorReport, in addition to the StructuredError codes that
+// resource failures use. Like those, they are stable and additive-only.
+const (
+	ErrCodeCommandFailed   = "COMMAND_FAILED"
+	ErrCodeUsageError      = "USAGE_ERROR"
+	ErrCodePartialFailure  = "PARTIAL_FAILURE"
+	ErrCodeBudgetExceeded  = "BUDGET_EXCEEDED"
+	ErrCodePolicyViolation = "POLICY_VIOLATION"
+)
+
+// ResourceFailure is one resource that a plugin failed to cost.
+type ResourceFailure struct {
+	ResourceType string `json:"resourceType"`
+	ResourceID   string `json:"resourceId"`
+	Plugin       string `json:"plugin,omitempty"`
+	// Code is ErrCodeTimeoutError when the plugin call timed out and
+	// ErrCodePluginError otherwise.
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// ErrorReport is the machine-readable description of a failed command, or of
+// the partial failures of a command that otherwise succeeded, that commands
+// emit with --output json or ndjson.
+type ErrorReport struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+	// ExitCode is the process exit code, or 0 for partial failures.
+	ExitCode int `json:"exitCode"`
+	// Failures lists the resources that failed, and Plugins the sorted names of
+	// the plugins that failed.
+	Failures []ResourceFailure `json:"failures,omitempty"`
+	Plugins  []string          `json:"plugins,omitempty"`
+	// Omitted counts the failed resources left out of the results and totals
+	// with --on-error omit.
+	Omitted int `json:"omitted,omitempty"`
+}
+
+// ErrorEnvelope wraps an ErrorReport with its schema version.
+type ErrorEnvelope struct {
+	Schema string      `json:"schema"`
+	Error  ErrorReport `json:"error"`
+}
+
+// NewErrorEnvelope wraps report in an envelope of the current schema.
+func NewErrorEnvelope(report ErrorReport) ErrorEnvelope {
+	return ErrorEnvelope{Schema: ErrorReportSchema, Error: report}
+}
+
+// ErrorReport returns the partial failures of the calculation as an
+// ErrorReport with code ErrCodePartialFailure, or nil when there are none.
+func (c *CostResultWithErrors) ErrorReport() *ErrorReport {
+	if !c.HasErrors() {
+		return nil
+	}
+	report := &ErrorReport{
+		Code:     ErrCodePartialFailure,
+		Message:  fmt.Sprintf("%d resource(s) failed", len(c.Errors)),
+		Failures: make([]ResourceFailure, 0, len(c.Errors)),
+	}
+	plugins := make(map[string]bool)
+	for _, detail := range c.Errors {
+		failure := ResourceFailure{
+			ResourceType: detail.ResourceType,
+			ResourceID:   detail.ResourceID,
+			Plugin:       detail.PluginName,
+			Code:         failureCode(detail.Error),
+		}
+		if detail.Error != nil {
+			failure.Message = detail.Error.Error()
+		}
+		report.Failures = append(report.Failures, failure)
+		if detail.PluginName != "" {
+			plugins[detail.PluginName] = true
+		}
+	}
+	report.Plugins = sortedKeys(plugins)
+	if len(c.Omitted) > 0 {
+		report.Omitted = len(c.Omitted)
+		report.Message += fmt.Sprintf("; %d omitted from totals", len(c.Omitted))
+	}
+	return report
+}
+
+// ErrorReport returns the plugin errors of the result as an ErrorReport with
+// code ErrCodePartialFailure, or nil when there are none.
+func (r *RecommendationsResult) ErrorReport() *ErrorReport {
+	if !r.HasErrors() {
+		return nil
+	}
+	plugins := make(map[string]bool, len(r.Errors))
+	for _, e := range r.Errors {
+		plugins[e.PluginName] = true
+	}
+	return &ErrorReport{
+		Code:    ErrCodePartialFailure,
+		Message: r.ErrorSummary(),
+		Plugins: sortedKeys(plugins),
+	}
+}
+
+// failureCode classifies the error of a failed plugin call.
+func failureCode(err error) string {
+	if errors.Is(err, context.DeadlineExceeded) {
+		return ErrCodeTimeoutError
+	}
+	var grpcErr interface{ GRPCStatus() *status.Status }
+	if errors.As(err, &grpcErr) && grpcErr.GRPCStatus().Code() == codes.DeadlineExceeded {
+		return ErrCodeTimeoutError
+	}
+	return ErrCodePluginError
+}
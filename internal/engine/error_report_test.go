@@ -0,0 +1,60 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestCostResultWithErrors_ErrorReport(t *testing.T) {
+	assert.Nil(t, (&CostResultWithErrors{}).ErrorReport())
+
+	result := &CostResultWithErrors{Errors: []ErrorDetail{
+		{ResourceType: "aws:ec2:Instance", ResourceID: "web", PluginName: "aws-public",
+			Error: fmt.Errorf("plugin call failed: %w", errors.New("boom"))},
+		{ResourceType: "aws:rds:Instance", ResourceID: "db", PluginName: "aws-public",
+			Error: fmt.Errorf("plugin call failed: %w", context.DeadlineExceeded)},
+		{ResourceType: "gcp:compute:Instance", ResourceID: "vm", PluginName: "gcp",
+			Error: status.Error(codes.DeadlineExceeded, "too slow")},
+	}}
+	report := result.ErrorReport()
+	require.NotNil(t, report)
+	assert.Equal(t, ErrCodePartialFailure, report.Code)
+	assert.Equal(t, "3 resource(s) failed", report.Message)
+	assert.Zero(t, report.ExitCode)
+	assert.Equal(t, []string{"aws-public", "gcp"}, report.Plugins)
+	require.Len(t, report.Failures, 3)
+	assert.Equal(t, ResourceFailure{
+		ResourceType: "aws:ec2:Instance", ResourceID: "web", Plugin: "aws-public",
+		Code: ErrCodePluginError, Message: "plugin call failed: boom",
+	}, report.Failures[0])
+	assert.Equal(t, ErrCodeTimeoutError, report.Failures[1].Code)
+	assert.Equal(t, ErrCodeTimeoutError, report.Failures[2].Code)
+}
+
+func TestRecommendationsResult_ErrorReport(t *testing.T) {
+	assert.Nil(t, (&RecommendationsResult{}).ErrorReport())
+
+	result := &RecommendationsResult{Errors: []RecommendationError{
+		{PluginName: "kubecost", Error: "unavailable"},
+		{PluginName: "aws-public", Error: "throttled"},
+	}}
+	report := result.ErrorReport()
+	require.NotNil(t, report)
+	assert.Equal(t, ErrCodePartialFailure, report.Code)
+	assert.Equal(t, "kubecost: unavailable; aws-public: throttled", report.Message)
+	assert.Equal(t, []string{"aws-public", "kubecost"}, report.Plugins)
+	assert.Empty(t, report.Failures)
+}
+
+func TestNewErrorEnvelope(t *testing.T) {
+	envelope := NewErrorEnvelope(ErrorReport{Code: ErrCodeCommandFailed, Message: "failed", ExitCode: 1})
+	assert.Equal(t, ErrorReportSchema, envelope.Schema)
+	assert.Equal(t, ErrCodeCommandFailed, envelope.Error.Code)
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
 )
 
 // EstimateCost performs what-if cost analysis with property overrides.
@@ -119,6 +120,7 @@ func (e *Engine) EstimateCost(
 		}
 
 		if result != nil {
+			convertEstimate(ctx, result)
 			log.Info().
 				Ctx(ctx).
 				Str("component", "engine").
@@ -160,17 +162,69 @@ func (e *Engine) EstimateCost(
 }
 
 // tryEstimateCostRPC attempts to call the EstimateCost RPC on a plugin.
+// Plugins without the RPC return codes.Unimplemented, which EstimateCost
+// treats as a signal to fall back to GetProjectedCost.
 func (e *Engine) tryEstimateCostRPC(
-	_ context.Context,
-	_ *pluginhost.Client,
-	_ *EstimateRequest,
+	ctx context.Context,
+	client *pluginhost.Client,
+	request *EstimateRequest,
 ) (*EstimateResult, error) {
-	// The EstimateCost RPC is not yet defined in finfocus-spec v0.5.6
-	// When the RPC is added to the spec, this method should:
-	// 1. Build the proto request using proto.BuildEstimateCostRequest
-	// 2. Call client.API.EstimateCost(ctx, protoReq)
-	// 3. Convert the response to EstimateResult
-	return nil, status.Error(codes.Unimplemented, "EstimateCost RPC not yet implemented in plugins")
+	resource := &proto.ResourceDescriptor{
+		ID:         request.Resource.ID,
+		Type:       request.Resource.Type,
+		Provider:   request.Resource.Provider,
+		Properties: ConvertToProto(request.Resource.Properties),
+	}
+	protoReq, err := proto.BuildEstimateCostRequest(resource, request.PropertyOverrides)
+	if err != nil {
+		return nil, err
+	}
+	protoReq.UsageProfile = request.UsageProfile
+
+	resp, err := client.API.EstimateCost(ctx, protoReq)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil || resp.Baseline == nil || resp.Modified == nil {
+		return nil, nil //nolint:nilnil // An empty response means this plugin has no estimate; try the next one.
+	}
+
+	baseline := estimateCostResult(client.Name, request.Resource, resp.Baseline)
+	modified := estimateCostResult(client.Name, request.Resource, resp.Modified)
+	deltas := make([]CostDelta, 0, len(resp.Deltas))
+	for _, d := range resp.Deltas {
+		if d == nil {
+			continue
+		}
+		deltas = append(deltas, CostDelta{
+			Property:      d.Property,
+			OriginalValue: d.OriginalValue,
+			NewValue:      d.NewValue,
+			CostChange:    d.CostChange,
+		})
+	}
+
+	return &EstimateResult{
+		Resource:    request.Resource,
+		Baseline:    baseline,
+		Modified:    modified,
+		TotalChange: modified.Monthly - baseline.Monthly,
+		Deltas:      deltas,
+	}, nil
+}
+
+// estimateCostResult converts a plugin estimate into an engine CostResult.
+func estimateCostResult(adapter string, resource *ResourceDescriptor, result *proto.CostResult) *CostResult {
+	return &CostResult{
+		ResourceType: resource.Type,
+		ResourceID:   resource.ID,
+		Adapter:      adapter,
+		Currency:     result.Currency,
+		Monthly:      result.MonthlyCost,
+		Hourly:       result.HourlyCost,
+		Notes:        result.Notes,
+		Breakdown:    result.CostBreakdown,
+	}
 }
 
 // estimateCostFallback calculates cost estimation using two GetProjectedCost calls.
@@ -8,6 +8,9 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
 )
 
 // errNoSpec is a sentinel error for missing specs in tests.
@@ -109,6 +112,51 @@ func TestEstimateCost_Fallback(t *testing.T) {
 	})
 }
 
+// TestEstimateCost_PluginRPC tests that a plugin implementing EstimateCost is
+// used instead of the fallback, and that Unimplemented plugins are skipped.
+func TestEstimateCost_PluginRPC(t *testing.T) {
+	var captured *proto.EstimateCostRequest
+	rpc := &mockCostSourceClient{
+		estimate: func(in *proto.EstimateCostRequest) (*proto.EstimateCostResponse, error) {
+			captured = in
+			return &proto.EstimateCostResponse{
+				Baseline: &proto.CostResult{Currency: "USD", MonthlyCost: 8},
+				Modified: &proto.CostResult{Currency: "USD", MonthlyCost: 80},
+				Deltas: []*proto.CostDelta{
+					{Property: "instanceType", OriginalValue: "t3.micro", NewValue: "m5.large", CostChange: 62},
+					{Property: "volumeSize", OriginalValue: "8", NewValue: "100", CostChange: 10},
+				},
+			}, nil
+		},
+	}
+	eng := New([]*pluginhost.Client{
+		{Name: "legacy", API: &mockCostSourceClient{}},
+		{Name: "aws-public", API: rpc},
+	}, &mockSpecLoader{})
+
+	result, err := eng.EstimateCost(context.Background(), &EstimateRequest{
+		Resource: &ResourceDescriptor{
+			Provider:   "aws",
+			Type:       "aws:ec2/instance:Instance",
+			ID:         "web",
+			Properties: map[string]interface{}{"instanceType": "t3.micro", "volumeSize": 8},
+		},
+		PropertyOverrides: map[string]string{"instanceType": "m5.large", "volumeSize": "100"},
+	})
+	require.NoError(t, err)
+
+	require.NotNil(t, captured)
+	assert.Equal(t, "8", captured.Resource.Properties["volumeSize"])
+	assert.Equal(t, "m5.large", captured.PropertyOverrides["instanceType"])
+
+	assert.False(t, result.UsedFallback)
+	assert.Equal(t, "aws-public", result.Baseline.Adapter)
+	assert.InDelta(t, 72.0, result.TotalChange, 0.001)
+	require.Len(t, result.Deltas, 2)
+	assert.Equal(t, "volumeSize", result.Deltas[1].Property)
+	assert.InDelta(t, 10.0, result.Deltas[1].CostChange, 0.001)
+}
+
 // TestEstimateCost_ResourceValidation tests that invalid resources are rejected.
 func TestEstimateCost_ResourceValidation(t *testing.T) {
 	t.Run("empty resource type", func(t *testing.T) {
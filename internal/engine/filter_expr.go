@@ -0,0 +1,149 @@
+package engine
+
+import (
+	"strings"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/engine/filterexpr"
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+)
+
+// ResourceFilterSchema returns the fields that filter expressions over
+// resources may use: type, provider, service, id, region, and tag.<key>.
+func ResourceFilterSchema() filterexpr.Schema {
+	return filterexpr.Schema{
+		Fields: map[string]filterexpr.Kind{
+			"type":     filterexpr.String,
+			"provider": filterexpr.String,
+			"service":  filterexpr.String,
+			"id":       filterexpr.String,
+			"region":   filterexpr.String,
+		},
+		Tags: true,
+	}
+}
+
+// ResourceRecord exposes a resource to filter expressions parsed with
+// ResourceFilterSchema.
+func ResourceRecord(resource ResourceDescriptor) filterexpr.Record {
+	return func(field string) any {
+		switch field {
+		case "type":
+			return resource.Type
+		case "provider":
+			return ExtractProvider(resource.Type)
+		case "service":
+			return extractService(resource.Type)
+		case "id":
+			return resource.ID
+		case "region":
+			return resourceRegion(resource.Properties)
+		}
+		value, _ := lookupResourceTag(resource.Properties, strings.TrimPrefix(field, filterexpr.TagPrefix))
+		return value
+	}
+}
+
+// FilterResourcesByExpr returns the resources that expr matches.
+func FilterResourcesByExpr(resources []ResourceDescriptor, expr *filterexpr.Expr) []ResourceDescriptor {
+	return pipeline.Filter(func(resource ResourceDescriptor) bool {
+		return expr.Match(ResourceRecord(resource))
+	})(resources)
+}
+
+// RecommendationFilterSchema returns the fields that filter expressions over
+// recommendations may use. savings, cost, and projected are the estimated
+// monthly savings and the monthly cost before and after the change; action
+// is the normalized action type (e.g. RIGHTSIZE); type, provider, and
+// tag.<key> describe the affected resource.
+func RecommendationFilterSchema() filterexpr.Schema {
+	return filterexpr.Schema{
+		Fields: map[string]filterexpr.Kind{
+			"savings":   filterexpr.Number,
+			"cost":      filterexpr.Number,
+			"projected": filterexpr.Number,
+			"currency":  filterexpr.String,
+			"action":    filterexpr.String,
+			"resource":  filterexpr.String,
+			"type":      filterexpr.String,
+			"provider":  filterexpr.String,
+			"source":    filterexpr.String,
+			"status":    filterexpr.String,
+		},
+		Tags: true,
+	}
+}
+
+// RecommendationRecord exposes a recommendation to filter expressions parsed
+// with RecommendationFilterSchema. resource is the affected resource and may
+// be nil when it is not in the plan; its fields are then empty.
+func RecommendationRecord(rec Recommendation, resource *ResourceDescriptor) filterexpr.Record {
+	return func(field string) any {
+		switch field {
+		case "savings":
+			return rec.EstimatedSavings
+		case "cost":
+			return rec.CurrentCost
+		case "projected":
+			return rec.ProjectedCost
+		case "currency":
+			return rec.Currency
+		case "action":
+			return NormalizeRemediationActionType(rec.Type)
+		case "resource":
+			return rec.ResourceID
+		case "source":
+			return rec.Source
+		case "status":
+			return string(rec.Status)
+		}
+		if resource == nil {
+			return ""
+		}
+		if field == "type" || field == "provider" {
+			return ResourceRecord(*resource)(field)
+		}
+		value, _ := lookupResourceTag(resource.Properties, strings.TrimPrefix(field, filterexpr.TagPrefix))
+		return value
+	}
+}
+
+// BudgetFilterSchema returns the fields that filter expressions over plugin
+// budgets may use: name, provider (the reporting source), amount, currency,
+// and tag.<key> for budget metadata.
+func BudgetFilterSchema() filterexpr.Schema {
+	return filterexpr.Schema{
+		Fields: map[string]filterexpr.Kind{
+			"name":     filterexpr.String,
+			"provider": filterexpr.String,
+			"amount":   filterexpr.Number,
+			"currency": filterexpr.String,
+		},
+		Tags: true,
+	}
+}
+
+// BudgetRecord exposes a budget to filter expressions parsed with
+// BudgetFilterSchema. Metadata keys are matched ignoring case.
+func BudgetRecord(budget *pbc.Budget) filterexpr.Record {
+	return func(field string) any {
+		switch field {
+		case "name":
+			return budget.GetName()
+		case "provider":
+			return budget.GetSource()
+		case "amount":
+			return budget.GetAmount().GetLimit()
+		case "currency":
+			return budget.GetAmount().GetCurrency()
+		}
+		key := strings.TrimPrefix(field, filterexpr.TagPrefix)
+		for k, v := range budget.GetMetadata() {
+			if strings.EqualFold(k, key) {
+				return v
+			}
+		}
+		return ""
+	}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/engine/filterexpr"
+)
+
+func mustParseFilter(t *testing.T, input string, schema filterexpr.Schema) *filterexpr.Expr {
+	t.Helper()
+	expr, err := filterexpr.Parse(input, schema)
+	require.NoError(t, err)
+	return expr
+}
+
+func TestFilterResourcesByExpr(t *testing.T) {
+	resources := []ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+			"region": "us-east-1", "tags": map[string]interface{}{"Env": "prod-us"},
+		}},
+		{ID: "db", Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+			"availabilityZone": "eu-west-1a", "tags": map[string]interface{}{"env": "dev"},
+		}},
+		{ID: "vm", Type: "gcp:compute/instance:Instance"},
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{`provider == "aws"`, []string{"web", "db"}},
+		{`service == "rds" || type =~ "gcp:*"`, []string{"db", "vm"}},
+		{`tag.env =~ "prod*"`, []string{"web"}},
+		{`region == "eu-west-1"`, []string{"db"}},
+		{`id != "web" && tag.env == ""`, []string{"vm"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filtered := FilterResourcesByExpr(resources, mustParseFilter(t, tt.expr, ResourceFilterSchema()))
+			ids := make([]string, 0, len(filtered))
+			for _, r := range filtered {
+				ids = append(ids, r.ID)
+			}
+			assert.Equal(t, tt.want, ids)
+		})
+	}
+}
+
+func TestRecommendationRecord(t *testing.T) {
+	resource := &ResourceDescriptor{
+		ID:         "web",
+		Type:       "aws:ec2/instance:Instance",
+		Properties: map[string]interface{}{"tags": map[string]interface{}{"team": "payments"}},
+	}
+	rec := Recommendation{
+		ResourceID: "web", Type: "RECOMMENDATION_ACTION_TYPE_RIGHTSIZE",
+		EstimatedSavings: 120, CurrentCost: 300, ProjectedCost: 180, Source: "aws-ce",
+	}
+	schema := RecommendationFilterSchema()
+
+	match := func(input string, resource *ResourceDescriptor) bool {
+		return mustParseFilter(t, input, schema).Match(RecommendationRecord(rec, resource))
+	}
+	assert.True(t, match(`savings > 100 && action == "RIGHTSIZE" && provider == "aws"`, resource))
+	assert.True(t, match(`cost == 300 && projected < 200 && source == "aws-ce"`, resource))
+	assert.True(t, match(`tag.team == "payments" && type =~ "aws:ec2/*"`, resource))
+	assert.False(t, match(`provider == "aws"`, nil), "resource fields are empty without the resource")
+	assert.True(t, match(`savings > 100`, nil))
+}
+
+func TestFilterBudgetsByExpr(t *testing.T) {
+	budgets := []*pbc.Budget{
+		{Id: "1", Name: "Prod", Source: "kubecost", Amount: &pbc.BudgetAmount{Limit: 5000, Currency: "USD"},
+			Metadata: map[string]string{"Namespace": "prod-us"}},
+		{Id: "2", Name: "Dev", Source: "aws-budgets",
+			Amount: &pbc.BudgetAmount{Limit: 500, Currency: "USD"}},
+	}
+	schema := BudgetFilterSchema()
+
+	filtered := FilterBudgetsByExpr(budgets, []*filterexpr.Expr{
+		mustParseFilter(t, `amount >= 1000`, schema),
+		mustParseFilter(t, `tag.namespace =~ "prod-*" && provider == "KUBECOST"`, schema),
+	})
+	require.Len(t, filtered, 1)
+	assert.Equal(t, "1", filtered[0].GetId())
+
+	assert.Len(t, FilterBudgetsByExpr(budgets, nil), 2)
+	assert.Len(t, FilterBudgetsByExpr(budgets, []*filterexpr.Expr{mustParseFilter(t, `name == "dev"`, schema)}), 1)
+}
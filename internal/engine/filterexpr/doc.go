@@ -0,0 +1,20 @@
+// Package filterexpr implements the filter expression language shared by the
+// --filter flags of the cost, recommendation, and budget commands.
+//
+// An expression compares fields of a result with literals and combines the
+// comparisons with &&, ||, !, and parentheses:
+//
+//	savings > 100 && provider == "aws" && tag.env =~ "prod*"
+//
+// Comparison operators are ==, !=, >, >=, <, <=, =~ (glob match), and !~
+// (glob mismatch). Number fields take number literals and every operator
+// except the glob matches; string fields take double-quoted literals and
+// ==, !=, =~, and !~. String comparisons ignore case, and globs match the
+// whole value with * for any run of characters and ? for one character.
+// && binds tighter than ||.
+//
+// Expressions are parsed once against a Schema, which names the fields of a
+// kind of result, and are then matched against each result through a Record.
+// Parsing reports the column of syntax errors and suggests the closest field
+// for unknown ones.
+package filterexpr
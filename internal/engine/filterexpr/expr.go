@@ -0,0 +1,364 @@
+package filterexpr
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kind is the type of a field.
+type Kind int
+
+const (
+	// String fields hold text and compare with ==, !=, =~, and !~.
+	String Kind = iota
+	// Number fields hold amounts and compare with ==, !=, >, >=, <, and <=.
+	Number
+)
+
+// TagPrefix starts the fields that name resource tags, e.g. tag.env.
+const TagPrefix = "tag."
+
+// Schema names the fields that expressions over one kind of result may use.
+type Schema struct {
+	// Fields maps lowercase field names to their kind.
+	Fields map[string]Kind
+	// Tags allows tag.<key> fields, which are strings.
+	Tags bool
+}
+
+// fieldNames returns the sorted field names of the schema for error
+// messages, with tag.<key> last when tags are allowed.
+func (s Schema) fieldNames() []string {
+	names := make([]string, 0, len(s.Fields)+1)
+	for name := range s.Fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if s.Tags {
+		names = append(names, TagPrefix+"<key>")
+	}
+	return names
+}
+
+// Record returns the value of a field of one result: a string for String
+// fields and a float64 for Number fields. Field names are the lowercase
+// schema names, or tag.<key> with the key as written in the expression;
+// missing tags are "".
+type Record func(field string) any
+
+// Expr is a parsed filter expression.
+type Expr struct {
+	source string
+	root   node
+}
+
+// String returns the expression as it was written.
+func (e *Expr) String() string {
+	return e.source
+}
+
+// Match reports whether the result that record describes satisfies the
+// expression. A nil expression matches every result.
+func (e *Expr) Match(record Record) bool {
+	if e == nil {
+		return true
+	}
+	return e.root.match(record)
+}
+
+// Parse parses an expression and checks its fields and literals against
+// schema. Errors are *SyntaxError values that locate the problem.
+func Parse(input string, schema Schema) (*Expr, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, syntaxErrorf(input, 0, "empty expression")
+	}
+	tokens, err := lex(input)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{input: input, tokens: tokens, schema: schema}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, p.errorAt(next, "unexpected %s; join comparisons with && or ||", next.describe())
+	}
+	return &Expr{source: input, root: root}, nil
+}
+
+// node is a node of a parsed expression.
+type node interface {
+	match(record Record) bool
+}
+
+type andNode struct{ left, right node }
+
+func (n andNode) match(record Record) bool { return n.left.match(record) && n.right.match(record) }
+
+type orNode struct{ left, right node }
+
+func (n orNode) match(record Record) bool { return n.left.match(record) || n.right.match(record) }
+
+type notNode struct{ operand node }
+
+func (n notNode) match(record Record) bool { return !n.operand.match(record) }
+
+// compareNode compares a field with a literal.
+type compareNode struct {
+	field  string
+	op     string
+	number float64
+	text   string
+	glob   *regexp.Regexp
+}
+
+func (n compareNode) match(record Record) bool {
+	switch value := record(n.field).(type) {
+	case float64:
+		return compareNumbers(value, n.op, n.number)
+	case string:
+		switch n.op {
+		case "==":
+			return strings.EqualFold(value, n.text)
+		case "!=":
+			return !strings.EqualFold(value, n.text)
+		case "=~":
+			return n.glob.MatchString(value)
+		case "!~":
+			return !n.glob.MatchString(value)
+		}
+	}
+	return false
+}
+
+// compareNumbers applies a comparison operator to two numbers.
+func compareNumbers(a float64, op string, b float64) bool {
+	switch op {
+	case "==":
+		return a == b
+	case "!=":
+		return a != b
+	case ">":
+		return a > b
+	case ">=":
+		return a >= b
+	case "<":
+		return a < b
+	case "<=":
+		return a <= b
+	}
+	return false
+}
+
+// compileGlob compiles a glob into a case-insensitive regular expression that
+// matches whole values.
+func compileGlob(glob string) *regexp.Regexp {
+	var b strings.Builder
+	b.WriteString("(?is)^")
+	for _, r := range glob {
+		switch r {
+		case '*':
+			b.WriteString(".*")
+		case '?':
+			b.WriteString(".")
+		default:
+			b.WriteString(regexp.QuoteMeta(string(r)))
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// parser is a recursive descent parser over the tokens of an expression.
+type parser struct {
+	input  string
+	tokens []token
+	pos    int
+	schema Schema
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) errorAt(t token, format string, args ...any) error {
+	return syntaxErrorf(p.input, t.pos, format, args...)
+}
+
+// parseOr parses: and { "||" and }.
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenOr {
+		p.next()
+		right, rightErr := p.parseAnd()
+		if rightErr != nil {
+			return nil, rightErr
+		}
+		left = orNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseAnd parses: unary { "&&" unary }.
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokenAnd {
+		p.next()
+		right, rightErr := p.parseUnary()
+		if rightErr != nil {
+			return nil, rightErr
+		}
+		left = andNode{left: left, right: right}
+	}
+	return left, nil
+}
+
+// parseUnary parses: "!" unary | "(" or ")" | comparison.
+func (p *parser) parseUnary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokenNot:
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{operand: operand}, nil
+	case tokenLParen:
+		inner, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, p.errorAt(closing, "expected \")\" to close the \"(\" at column %d, found %s",
+				t.pos+1, closing.describe())
+		}
+		return inner, nil
+	case tokenIdent:
+		return p.parseComparison(t)
+	default:
+		return nil, p.errorAt(t, "expected a field name, found %s (fields: %s)",
+			t.describe(), strings.Join(p.schema.fieldNames(), ", "))
+	}
+}
+
+// parseComparison parses the operator and literal that follow a field name.
+func (p *parser) parseComparison(field token) (node, error) {
+	name, kind, err := p.resolveField(field)
+	if err != nil {
+		return nil, err
+	}
+	op := p.next()
+	if op.kind != tokenOp {
+		return nil, p.errorAt(op, "expected a comparison operator after %q, found %s "+
+			"(operators: ==, !=, >, >=, <, <=, =~, !~)", field.text, op.describe())
+	}
+	value := p.next()
+	n := compareNode{field: name, op: op.text}
+
+	switch kind {
+	case Number:
+		if op.text == "=~" || op.text == "!~" {
+			return nil, p.errorAt(op, "%q is a number field; %s only matches string fields", field.text, op.text)
+		}
+		if value.kind != tokenNumber {
+			return nil, p.errorAt(value, "%q is a number field; expected a number after %s, found %s",
+				field.text, op.text, value.describe())
+		}
+		n.number, _ = strconv.ParseFloat(value.text, 64)
+	case String:
+		switch op.text {
+		case ">", ">=", "<", "<=":
+			return nil, p.errorAt(op, "%q is a string field; %s only compares number fields", field.text, op.text)
+		}
+		switch value.kind {
+		case tokenString:
+		case tokenIdent, tokenNumber:
+			return nil, p.errorAt(value, "%q is a string field; quote the value: %s %s %q",
+				field.text, field.text, op.text, value.text)
+		default:
+			return nil, p.errorAt(value, "%q is a string field; expected a quoted string after %s, found %s",
+				field.text, op.text, value.describe())
+		}
+		n.text = value.text
+		if op.text == "=~" || op.text == "!~" {
+			n.glob = compileGlob(value.text)
+		}
+	}
+	return n, nil
+}
+
+// resolveField returns the record name and kind of a field, or an error
+// that suggests the closest known field.
+func (p *parser) resolveField(field token) (string, Kind, error) {
+	lower := strings.ToLower(field.text)
+	if kind, ok := p.schema.Fields[lower]; ok {
+		return lower, kind, nil
+	}
+	if p.schema.Tags && strings.HasPrefix(lower, TagPrefix) {
+		if len(field.text) == len(TagPrefix) {
+			return "", 0, p.errorAt(field, "missing tag key: use %s<key>, e.g. %senv", TagPrefix, TagPrefix)
+		}
+		return TagPrefix + field.text[len(TagPrefix):], String, nil
+	}
+
+	msg := fmt.Sprintf("unknown field %q", field.text)
+	if suggestion := closestField(lower, p.schema); suggestion != "" {
+		msg += fmt.Sprintf("; did you mean %q?", suggestion)
+	}
+	return "", 0, p.errorAt(field, "%s (fields: %s)", msg, strings.Join(p.schema.fieldNames(), ", "))
+}
+
+// maxSuggestionDistance is the largest edit distance at which an unknown
+// field is taken for a misspelling of a known one.
+const maxSuggestionDistance = 2
+
+// closestField returns the schema field closest to name, or "" when none is
+// within maxSuggestionDistance edits.
+func closestField(name string, schema Schema) string {
+	best, bestDistance := "", maxSuggestionDistance+1
+	for _, candidate := range schema.fieldNames() {
+		if d := editDistance(name, candidate); d < bestDistance {
+			best, bestDistance = candidate, d
+		}
+	}
+	return best
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
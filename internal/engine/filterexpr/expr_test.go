@@ -0,0 +1,108 @@
+package filterexpr
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testSchema() Schema {
+	return Schema{
+		Fields: map[string]Kind{"savings": Number, "provider": String, "action": String},
+		Tags:   true,
+	}
+}
+
+func testRecord(values map[string]any) Record {
+	return func(field string) any {
+		if v, ok := values[field]; ok {
+			return v
+		}
+		return ""
+	}
+}
+
+func TestParse_Match(t *testing.T) {
+	record := testRecord(map[string]any{
+		"savings":  150.0,
+		"provider": "AWS",
+		"action":   "RIGHTSIZE",
+		"tag.env":  "production",
+	})
+
+	tests := []struct {
+		expr string
+		want bool
+	}{
+		{`savings > 100`, true},
+		{`savings <= 100`, false},
+		{`savings == 150 && provider == "aws"`, true},
+		{`provider != "aws"`, false},
+		{`tag.env =~ "prod*"`, true},
+		{`tag.env !~ "prod*"`, false},
+		{`tag.env =~ "PROD?CTION"`, true},
+		{`tag.team == ""`, true},
+		{`savings > 100 && provider == "aws" && tag.env =~ "prod*"`, true},
+		{`savings > 1000 || action == "rightsize"`, true},
+		{`provider == "gcp" || savings > 100 && action == "TERMINATE"`, false},
+		{`(provider == "gcp" || savings > 100) && action == "RIGHTSIZE"`, true},
+		{`!(savings > 100)`, false},
+		{`!action == "TERMINATE"`, true},
+		{`savings >= -1.5e2`, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			expr, err := Parse(tt.expr, testSchema())
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, expr.Match(record))
+			assert.Equal(t, tt.expr, expr.String())
+		})
+	}
+}
+
+func TestExpr_NilMatchesEverything(t *testing.T) {
+	var expr *Expr
+	assert.True(t, expr.Match(testRecord(nil)))
+}
+
+func TestParse_Errors(t *testing.T) {
+	tests := []struct {
+		expr    string
+		column  int
+		message string
+	}{
+		{``, 1, "empty expression"},
+		{`savngs > 100`, 1, `unknown field "savngs"; did you mean "savings"?`},
+		{`cost > 100`, 1, `unknown field "cost" (fields: action, provider, savings, tag.<key>)`},
+		{`savings >`, 10, "expected a number after >, found end of expression"},
+		{`savings > "100"`, 11, `"savings" is a number field; expected a number`},
+		{`savings =~ "1*"`, 9, "=~ only matches string fields"},
+		{`provider > "a"`, 10, "> only compares number fields"},
+		{`provider == aws`, 13, `quote the value: provider == "aws"`},
+		{`provider = "aws"`, 10, `unexpected "="; use == to compare`},
+		{`savings > 1 & provider == "aws"`, 13, `unexpected "&"; use &&`},
+		{`(savings > 1`, 13, `expected ")" to close the "(" at column 1`},
+		{`savings > 1 provider == "aws"`, 13, "join comparisons with && or ||"},
+		{`provider == "aws`, 13, "unterminated string literal"},
+		{`savings 100`, 9, "expected a comparison operator after \"savings\""},
+		{`tag. == "x"`, 1, "missing tag key"},
+		{`&& savings > 1`, 1, "expected a field name"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := Parse(tt.expr, testSchema())
+			var syntaxErr *SyntaxError
+			require.ErrorAs(t, err, &syntaxErr)
+			assert.Equal(t, tt.column, syntaxErr.Column)
+			assert.Contains(t, syntaxErr.Message, tt.message)
+			assert.Contains(t, err.Error(), "invalid filter syntax")
+		})
+	}
+}
+
+func TestParse_TagsDisallowedWithoutSchemaSupport(t *testing.T) {
+	_, err := Parse(`tag.env == "prod"`, Schema{Fields: map[string]Kind{"savings": Number}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `unknown field "tag.env"`)
+}
@@ -0,0 +1,174 @@
+package filterexpr
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// tokenKind identifies a lexical token.
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenString
+	tokenNumber
+	tokenOp
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+// token is a lexical token and the 0-based offset it starts at.
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+// describe returns how a token is named in error messages.
+func (t token) describe() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return strconv.Quote(t.text)
+}
+
+// twoCharTokens are the tokens of two characters, checked before the single
+// character ones.
+func twoCharTokens() map[string]tokenKind {
+	return map[string]tokenKind{
+		"&&": tokenAnd, "||": tokenOr,
+		"==": tokenOp, "!=": tokenOp, ">=": tokenOp, "<=": tokenOp, "=~": tokenOp, "!~": tokenOp,
+	}
+}
+
+// lex splits an expression into tokens, ending with a tokenEOF.
+func lex(input string) ([]token, error) {
+	var tokens []token
+	pairs := twoCharTokens()
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n' || c == '\r':
+			i++
+			continue
+		case i+1 < len(input) && pairs[input[i:i+2]] != 0:
+			tokens = append(tokens, token{kind: pairs[input[i:i+2]], text: input[i : i+2], pos: i})
+			i += 2
+			continue
+		}
+
+		start := i
+		switch {
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == '!':
+			tokens = append(tokens, token{kind: tokenNot, text: "!", pos: i})
+			i++
+		case c == '>' || c == '<':
+			tokens = append(tokens, token{kind: tokenOp, text: string(c), pos: i})
+			i++
+		case c == '=':
+			return nil, syntaxErrorf(input, i, "unexpected \"=\"; use == to compare")
+		case c == '&' || c == '|':
+			return nil, syntaxErrorf(input, i, "unexpected %q; use %s%s", string(c), string(c), string(c))
+		case c == '"':
+			end, err := scanString(input, i)
+			if err != nil {
+				return nil, err
+			}
+			value, err := strconv.Unquote(input[i:end])
+			if err != nil {
+				return nil, syntaxErrorf(input, i, "invalid string literal %s", input[i:end])
+			}
+			tokens = append(tokens, token{kind: tokenString, text: value, pos: start})
+			i = end
+		case c == '-' || c == '.' || isDigit(c):
+			i = scanNumber(input, i)
+			if _, err := strconv.ParseFloat(input[start:i], 64); err != nil {
+				return nil, syntaxErrorf(input, start, "invalid number %q", input[start:i])
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: input[start:i], pos: start})
+		case isIdentStart(c):
+			i++
+			for i < len(input) && isIdentPart(input[i]) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: input[start:i], pos: start})
+		default:
+			return nil, syntaxErrorf(input, i, "unexpected character %q", string(c))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(input)}), nil
+}
+
+// scanString returns the offset just past the string literal starting at
+// the double quote at start.
+func scanString(input string, start int) (int, error) {
+	for i := start + 1; i < len(input); i++ {
+		switch input[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1, nil
+		}
+	}
+	return 0, syntaxErrorf(input, start, "unterminated string literal")
+}
+
+// scanNumber returns the offset just past the number literal starting at
+// start, including a leading sign, a fraction, and an exponent.
+func scanNumber(input string, start int) int {
+	i := start + 1
+	for i < len(input) {
+		c := input[i]
+		exponentSign := (c == '-' || c == '+') && (input[i-1] == 'e' || input[i-1] == 'E')
+		if !isDigit(c) && c != '.' && c != 'e' && c != 'E' && !exponentSign {
+			break
+		}
+		i++
+	}
+	return i
+}
+
+// isDigit reports whether c is a decimal digit.
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// isIdentStart reports whether c starts a field name.
+func isIdentStart(c byte) bool {
+	return c == '_' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+// isIdentPart reports whether c continues a field name. Tag keys such as
+// tag.cost-center or tag.app:tier may contain '-', ':', '/', and '.'.
+func isIdentPart(c byte) bool {
+	return isIdentStart(c) || isDigit(c) || strings.IndexByte("-:/.", c) >= 0
+}
+
+// SyntaxError reports an invalid expression and the position of the problem.
+type SyntaxError struct {
+	// Expr is the expression and Column the 1-based column of the problem.
+	Expr    string
+	Column  int
+	Message string
+}
+
+// Error returns the message with the expression and column it applies to.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("invalid filter syntax in %q at column %d: %s", e.Expr, e.Column, e.Message)
+}
+
+// syntaxErrorf returns a SyntaxError at the 0-based offset pos of input.
+func syntaxErrorf(input string, pos int, format string, args ...any) error {
+	return &SyntaxError{Expr: input, Column: pos + 1, Message: fmt.Sprintf(format, args...)}
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sync/atomic"
+
+	"github.com/rshade/finfocus/internal/engine/cache"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// resultCacheOperation keys the per-resource results of projected cost
+// calculations in the cache.
+const resultCacheOperation = "projected_result"
+
+// resultCache holds the projected cost results of resources by content
+// hash, so that a run against a slightly changed plan queries plugins only
+// for the resources that changed.
+type resultCache struct {
+	store      *cache.FileStore
+	ttlSeconds int
+
+	// reused and queried count the resources of the current calculation
+	// whose results came from the cache and from plugins.
+	reused  atomic.Int64
+	queried atomic.Int64
+}
+
+// WithResultCache makes projected cost calculations reuse the cached results
+// of resources whose type, provider, and properties are unchanged, whatever
+// their ID, querying plugins only for new and changed resources. Results are
+// kept for ttlSeconds, or the store's TTL when it is zero or less. A nil or
+// disabled store leaves every resource to the plugins.
+func (e *Engine) WithResultCache(store *cache.FileStore, ttlSeconds int) *Engine {
+	if store == nil || !store.IsEnabled() {
+		e.results = nil
+		return e
+	}
+	e.results = &resultCache{store: store, ttlSeconds: ttlSeconds}
+	return e
+}
+
+// ResourceHash returns the content address of resource: the hex SHA256 of
+// its type, provider, and properties as plugins receive them. Resources that
+// differ only in ID or URN hash alike, so a renamed or moved resource keeps
+// its cached results.
+func ResourceHash(resource ResourceDescriptor) string {
+	// Maps marshal with sorted keys, so equal properties hash alike.
+	data, _ := json.Marshal(struct {
+		Type       string            `json:"type"`
+		Provider   string            `json:"provider"`
+		Properties map[string]string `json:"properties"`
+	}{resource.Type, resource.Provider, ConvertToProto(resource.Properties)})
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+// resultCacheKey returns the cache key of resource's results when priced by
+// matches under the pricing model of ctx, so that a change of plugin,
+// plugin version, routing, or pricing model queries the plugins again. The
+// SKU and region that plugins receive are part of the key too, so a change
+// of the mapping rules, SKU catalog, provider aliases, or default regions
+// that resolves the resource differently also misses the cache.
+func resultCacheKey(ctx context.Context, resource ResourceDescriptor, matches []PluginMatch) string {
+	sku, region := proto.ResolveSKUAndRegion(ctx, resource.Provider, resource.Type, ConvertToProto(resource.Properties))
+	parts := []string{ResourceHash(resource), PricingModelFromContext(ctx), "sku=" + sku, "region=" + region}
+	for _, match := range matches {
+		identity := match.Client.Name
+		if match.Client.Metadata != nil && match.Client.Metadata.Version != "" {
+			identity += "@" + match.Client.Metadata.Version
+		}
+		parts = append(parts, identity)
+	}
+	return cache.GenerateSimpleKey(resultCacheOperation, resource.Provider, parts...)
+}
+
+// cachedResults returns the results cached under key, relabeled with the
+// ID of resource.
+func (c *resultCache) cachedResults(ctx context.Context, key string, resource ResourceDescriptor) ([]CostResult, bool) {
+	entry, err := c.store.Get(key)
+	if err != nil {
+		return nil, false
+	}
+	var results []CostResult
+	if err = json.Unmarshal(entry.Data, &results); err != nil || len(results) == 0 {
+		return nil, false
+	}
+	for i := range results {
+		results[i].ResourceID = resource.ID
+		results[i].ResourceType = resource.Type
+	}
+	logging.FromContext(ctx).Debug().
+		Ctx(ctx).
+		Str("component", "engine").
+		Str("resource_type", resource.Type).
+		Str("resource_id", resource.ID).
+		Msg("reusing cached results of unchanged resource")
+	return results, true
+}
+
+// cacheResults stores the results of a resource under key. Results with
+// errors are not stored, so failed resources are queried again.
+func (c *resultCache) cacheResults(ctx context.Context, key string, results []CostResult) {
+	for _, result := range results {
+		if result.Error != nil {
+			return
+		}
+	}
+	data, err := json.Marshal(results)
+	if err != nil {
+		return
+	}
+	if err = c.store.SetWithTTL(key, data, c.ttlSeconds); err != nil {
+		logging.FromContext(ctx).Warn().
+			Ctx(ctx).
+			Str("component", "engine").
+			Err(err).
+			Msg("failed to cache resource results")
+	}
+}
+
+// logUsage logs how many resources reused cached results and resets the
+// counts for the next calculation.
+func (c *resultCache) logUsage(ctx context.Context) {
+	reused, queried := c.reused.Swap(0), c.queried.Swap(0)
+	if reused == 0 && queried == 0 {
+		return
+	}
+	logging.FromContext(ctx).Info().
+		Ctx(ctx).
+		Str("component", "engine").
+		Int64("reused", reused).
+		Int64("queried", queried).
+		Msg("reused cached results of unchanged resources")
+}
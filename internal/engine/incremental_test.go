@@ -0,0 +1,119 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/rshade/finfocus/internal/engine/cache"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// countingClient prices resources by the number of their "size" property and
+// counts the resources it is asked to price. Resources of type fail error.
+type countingClient struct {
+	proto.CostSourceClient
+
+	calls *atomic.Int64
+}
+
+func (c countingClient) GetProjectedCost(
+	_ context.Context, in *proto.GetProjectedCostRequest, _ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	c.calls.Add(1)
+	if in.Resources[0].Type == "fail" {
+		return nil, errors.New("plugin unavailable")
+	}
+	return &proto.GetProjectedCostResponse{Results: []*proto.CostResult{
+		{Currency: "USD", MonthlyCost: float64(len(in.Resources[0].Properties["size"]))},
+	}}, nil
+}
+
+func newIncrementalEngine(t *testing.T) (*Engine, *atomic.Int64) {
+	t.Helper()
+	store, err := cache.NewFileStore(t.TempDir(), true, 3600, 10)
+	require.NoError(t, err)
+	calls := &atomic.Int64{}
+	eng := New([]*pluginhost.Client{{Name: "counting", API: countingClient{calls: calls}}}, nil).
+		WithResultCache(store, 0)
+	return eng, calls
+}
+
+func TestResourceHash(t *testing.T) {
+	resource := ResourceDescriptor{
+		ID: "a", Type: "aws:ec2/instance:Instance", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro", "tags": map[string]interface{}{"env": "dev"}},
+	}
+	renamed := resource
+	renamed.ID = "b"
+	assert.Equal(t, ResourceHash(resource), ResourceHash(renamed), "the ID is not part of the content")
+
+	changed := resource
+	changed.Properties = map[string]interface{}{
+		"instanceType": "t3.large", "tags": map[string]interface{}{"env": "dev"},
+	}
+	assert.NotEqual(t, ResourceHash(resource), ResourceHash(changed))
+}
+
+func TestGetProjectedCost_ReusesResultsOfUnchangedResources(t *testing.T) {
+	eng, calls := newIncrementalEngine(t)
+	ctx := context.Background()
+	resources := []ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Provider: "aws",
+			Properties: map[string]interface{}{"size": "xx"}},
+		{ID: "db", Type: "aws:rds/instance:Instance", Provider: "aws",
+			Properties: map[string]interface{}{"size": "xxx"}},
+	}
+	_, err := eng.GetProjectedCostWithErrors(ctx, resources)
+	require.NoError(t, err)
+	require.EqualValues(t, 2, calls.Load())
+
+	// Rename one resource and change the other: only the change is priced.
+	resources[0].ID = "web-renamed"
+	resources[1].Properties = map[string]interface{}{"size": "xxxx"}
+	result, err := eng.GetProjectedCostWithErrors(ctx, resources)
+	require.NoError(t, err)
+	assert.EqualValues(t, 3, calls.Load())
+	require.Len(t, result.Results, 2)
+	assert.Equal(t, "web-renamed", result.Results[0].ResourceID)
+	assert.InDelta(t, 2.0, result.Results[0].Monthly, 0.001)
+	assert.Equal(t, "db", result.Results[1].ResourceID)
+	assert.InDelta(t, 4.0, result.Results[1].Monthly, 0.001)
+}
+
+func TestGetProjectedCost_RequeriesFailedResources(t *testing.T) {
+	eng, calls := newIncrementalEngine(t)
+	resources := []ResourceDescriptor{{ID: "broken", Type: "fail", Provider: "aws"}}
+	for range 2 {
+		result, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+		require.NoError(t, err)
+		require.Len(t, result.Errors, 1)
+	}
+	assert.EqualValues(t, 2, calls.Load(), "failed resources are not cached")
+}
+
+func TestGetProjectedCost_RequeriesWhenResolutionChanges(t *testing.T) {
+	eng, calls := newIncrementalEngine(t)
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	resources := []ResourceDescriptor{{ID: "web", Type: "aws:ec2/instance:Instance", Provider: "aws",
+		Properties: map[string]interface{}{"size": "xx"}}}
+
+	east := proto.ContextWithDefaultRegions(context.Background(), map[string]string{"aws": "us-east-1"})
+	for range 2 {
+		_, err := eng.GetProjectedCostWithErrors(east, resources)
+		require.NoError(t, err)
+	}
+	require.EqualValues(t, 1, calls.Load())
+
+	west := proto.ContextWithDefaultRegions(context.Background(), map[string]string{"aws": "us-west-2"})
+	_, err := eng.GetProjectedCostWithErrors(west, resources)
+	require.NoError(t, err)
+	assert.EqualValues(t, 2, calls.Load(), "a new default region resolves a new region")
+}
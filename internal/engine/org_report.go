@@ -0,0 +1,224 @@
+package engine
+
+import (
+	"cmp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// Default tag keys used to group an organization report.
+const (
+	DefaultOrgTeamTag        = "team"
+	DefaultOrgEnvironmentTag = "environment"
+)
+
+// OrgRollupEntry is the projected cost of one group in an organization report.
+type OrgRollupEntry struct {
+	Key string `json:"key"`
+	// Monthly is the projected monthly cost of the group, averaged over the
+	// report period by how long each projection was in effect.
+	Monthly   float64 `json:"monthly"`
+	Resources int     `json:"resources"`
+	Stacks    int     `json:"stacks"`
+}
+
+// OrgStackSummary is one stack's contribution to an organization report.
+type OrgStackSummary struct {
+	Stack     string  `json:"stack"`
+	Monthly   float64 `json:"monthly"`
+	Resources int     `json:"resources"`
+	// Snapshots is the number of recorded projections in effect during the period.
+	Snapshots int `json:"snapshots"`
+}
+
+// OrgReport rolls up recorded projections for every stack over a period.
+type OrgReport struct {
+	From           time.Time `json:"from"`
+	To             time.Time `json:"to"`
+	Currency       string    `json:"currency"`
+	TeamTag        string    `json:"teamTag"`
+	EnvironmentTag string    `json:"environmentTag"`
+	TotalMonthly   float64   `json:"totalMonthly"`
+	// MixedCurrencies is true when the projections use more than one currency;
+	// totals are then sums of unconverted amounts.
+	MixedCurrencies bool `json:"mixedCurrencies,omitempty"`
+
+	Stacks        []OrgStackSummary `json:"stacks"`
+	ByProvider    []OrgRollupEntry  `json:"byProvider"`
+	ByTeam        []OrgRollupEntry  `json:"byTeam"`
+	ByEnvironment []OrgRollupEntry  `json:"byEnvironment"`
+}
+
+// OrgReportInput carries the data needed to build an OrgReport.
+type OrgReportInput struct {
+	From time.Time
+	To   time.Time
+	// TeamTag and EnvironmentTag name the resource tags to group by. Empty
+	// values select DefaultOrgTeamTag and DefaultOrgEnvironmentTag.
+	TeamTag        string
+	EnvironmentTag string
+	// Snapshots maps each stack to its projections in effect during the
+	// period, oldest first (see config.ProjectionHistoryStore.SnapshotsBetween).
+	Snapshots map[string][]config.ProjectionSnapshot
+}
+
+// orgGroup accumulates one rollup entry.
+type orgGroup struct {
+	monthly   float64
+	resources map[string]bool
+	stacks    map[string]bool
+}
+
+// BuildOrgReport rolls up recorded projections by provider, team tag, and
+// environment. Each snapshot contributes in proportion to the share of the
+// period it was in effect, so a stack deployed halfway through the period
+// contributes half of its projected monthly cost. Resources without the team
+// tag are grouped as UntaggedGroupKey; resources without the environment tag
+// fall back to the stack name.
+func BuildOrgReport(input OrgReportInput) *OrgReport {
+	report := &OrgReport{
+		From:           input.From,
+		To:             input.To,
+		Currency:       defaultCurrency,
+		TeamTag:        cmp.Or(input.TeamTag, DefaultOrgTeamTag),
+		EnvironmentTag: cmp.Or(input.EnvironmentTag, DefaultOrgEnvironmentTag),
+	}
+
+	period := input.To.Sub(input.From)
+	if period <= 0 {
+		return report
+	}
+
+	providers := make(map[string]*orgGroup)
+	teams := make(map[string]*orgGroup)
+	environments := make(map[string]*orgGroup)
+	currencySet := false
+
+	stackNames := make([]string, 0, len(input.Snapshots))
+	for stack := range input.Snapshots {
+		stackNames = append(stackNames, stack)
+	}
+	sort.Strings(stackNames)
+
+	for _, stack := range stackNames {
+		snapshots := input.Snapshots[stack]
+		summary := OrgStackSummary{Stack: stack}
+		stackResources := make(map[string]bool)
+
+		for i, snapshot := range snapshots {
+			start := snapshot.RecordedAt
+			if start.Before(input.From) {
+				start = input.From
+			}
+			end := input.To
+			if i+1 < len(snapshots) && snapshots[i+1].RecordedAt.Before(end) {
+				end = snapshots[i+1].RecordedAt
+			}
+			if !end.After(start) {
+				continue
+			}
+			summary.Snapshots++
+			weight := float64(end.Sub(start)) / float64(period)
+
+			for id, record := range snapshot.Resources {
+				if record.Currency != "" {
+					if !currencySet {
+						report.Currency = record.Currency
+						currencySet = true
+					} else if record.Currency != report.Currency {
+						report.MixedCurrencies = true
+					}
+				}
+
+				monthly := record.Monthly * weight
+				resourceKey := stack + "\x00" + id
+				stackResources[resourceKey] = true
+				summary.Monthly += monthly
+
+				addOrgCost(providers, orgProviderKey(record), stack, resourceKey, monthly)
+				addOrgCost(teams, orgTagValue(record.Tags, report.TeamTag, UntaggedGroupKey),
+					stack, resourceKey, monthly)
+				addOrgCost(environments, orgTagValue(record.Tags, report.EnvironmentTag, orgStackName(stack)),
+					stack, resourceKey, monthly)
+			}
+		}
+
+		if summary.Snapshots == 0 {
+			continue
+		}
+		summary.Resources = len(stackResources)
+		report.TotalMonthly += summary.Monthly
+		report.Stacks = append(report.Stacks, summary)
+	}
+
+	report.ByProvider = orgEntries(providers)
+	report.ByTeam = orgEntries(teams)
+	report.ByEnvironment = orgEntries(environments)
+	return report
+}
+
+// addOrgCost adds monthly to the named group, creating it if needed.
+func addOrgCost(groups map[string]*orgGroup, key, stack, resourceKey string, monthly float64) {
+	group, ok := groups[key]
+	if !ok {
+		group = &orgGroup{resources: make(map[string]bool), stacks: make(map[string]bool)}
+		groups[key] = group
+	}
+	group.monthly += monthly
+	group.resources[resourceKey] = true
+	group.stacks[stack] = true
+}
+
+// orgEntries converts groups to entries ordered by cost, largest first.
+func orgEntries(groups map[string]*orgGroup) []OrgRollupEntry {
+	entries := make([]OrgRollupEntry, 0, len(groups))
+	for key, group := range groups {
+		entries = append(entries, OrgRollupEntry{
+			Key:       key,
+			Monthly:   group.monthly,
+			Resources: len(group.resources),
+			Stacks:    len(group.stacks),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Monthly != entries[j].Monthly {
+			return entries[i].Monthly > entries[j].Monthly
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
+
+// orgProviderKey returns the recorded provider, falling back to the provider
+// prefix of the resource type.
+func orgProviderKey(record config.ProjectedResourceRecord) string {
+	if record.Provider != "" {
+		return strings.ToLower(record.Provider)
+	}
+	if provider := ExtractProvider(record.ResourceType); provider != "" {
+		return provider
+	}
+	return UnknownGroupKey
+}
+
+// orgTagValue looks up key in tags case-insensitively, returning fallback when
+// the tag is missing or empty.
+func orgTagValue(tags map[string]string, key, fallback string) string {
+	for k, v := range tags {
+		if strings.EqualFold(k, key) && v != "" {
+			return v
+		}
+	}
+	return fallback
+}
+
+// orgStackName returns the stack segment of an org/project/stack name.
+func orgStackName(stack string) string {
+	if i := strings.LastIndex(stack, "/"); i >= 0 {
+		return stack[i+1:]
+	}
+	return stack
+}
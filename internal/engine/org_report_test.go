@@ -0,0 +1,109 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestBuildOrgReport(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 1, 31, 0, 0, 0, 0, time.UTC)
+	mid := time.Date(2026, 1, 16, 0, 0, 0, 0, time.UTC)
+
+	report := BuildOrgReport(OrgReportInput{
+		From: from,
+		To:   to,
+		Snapshots: map[string][]config.ProjectionSnapshot{
+			// In effect for the whole period.
+			"acme/web/prod": {{
+				RecordedAt: from.AddDate(0, -1, 0),
+				Resources: map[string]config.ProjectedResourceRecord{
+					"web": {
+						ResourceType: "aws:ec2/instance:Instance", Provider: "aws", Monthly: 100, Currency: "USD",
+						Tags: map[string]string{"Team": "web", "environment": "production"},
+					},
+					"bucket": {ResourceType: "gcp:storage/bucket:Bucket", Monthly: 20, Currency: "USD"},
+				},
+			}},
+			// Deployed halfway through, then resized at the end of the period.
+			"dev": {
+				{
+					RecordedAt: mid,
+					Resources: map[string]config.ProjectedResourceRecord{
+						"web": {Provider: "aws", Monthly: 40, Currency: "USD", Tags: map[string]string{"team": "web"}},
+					},
+				},
+				{
+					RecordedAt: to,
+					Resources: map[string]config.ProjectedResourceRecord{
+						"web": {Provider: "aws", Monthly: 400, Currency: "USD"},
+					},
+				},
+			},
+			"empty": nil,
+		},
+	})
+
+	assert.Equal(t, DefaultOrgTeamTag, report.TeamTag)
+	assert.Equal(t, DefaultOrgEnvironmentTag, report.EnvironmentTag)
+	assert.Equal(t, "USD", report.Currency)
+	assert.False(t, report.MixedCurrencies)
+	assert.InDelta(t, 140.0, report.TotalMonthly, 0.001)
+
+	require.Len(t, report.Stacks, 2, "stacks with nothing in effect are omitted")
+	assert.Equal(t, OrgStackSummary{Stack: "acme/web/prod", Monthly: 120, Resources: 2, Snapshots: 1}, report.Stacks[0])
+	assert.Equal(t, "dev", report.Stacks[1].Stack)
+	assert.InDelta(t, 20.0, report.Stacks[1].Monthly, 0.001, "half the period at 40/month")
+	assert.Equal(t, 1, report.Stacks[1].Snapshots, "snapshot recorded at the end of the period is not in effect")
+
+	require.Len(t, report.ByProvider, 2)
+	assert.Equal(t, "aws", report.ByProvider[0].Key)
+	assert.InDelta(t, 120.0, report.ByProvider[0].Monthly, 0.001)
+	assert.Equal(t, 2, report.ByProvider[0].Stacks)
+	assert.Equal(t, "gcp", report.ByProvider[1].Key, "provider falls back to the resource type")
+
+	require.Len(t, report.ByTeam, 2)
+	assert.Equal(t, OrgRollupEntry{Key: "web", Monthly: 120, Resources: 2, Stacks: 2}, report.ByTeam[0])
+	assert.Equal(t, UntaggedGroupKey, report.ByTeam[1].Key)
+
+	require.Len(t, report.ByEnvironment, 3)
+	assert.Equal(t, "production", report.ByEnvironment[0].Key)
+	assert.Equal(t, "dev", report.ByEnvironment[1].Key, "environment falls back to the stack name")
+	assert.Equal(t, "prod", report.ByEnvironment[2].Key, "qualified stack names use the stack segment")
+}
+
+func TestBuildOrgReport_CustomTagsAndMixedCurrencies(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := BuildOrgReport(OrgReportInput{
+		From:           from,
+		To:             from.AddDate(0, 1, 0),
+		TeamTag:        "owner",
+		EnvironmentTag: "env",
+		Snapshots: map[string][]config.ProjectionSnapshot{
+			"prod": {{
+				RecordedAt: from,
+				Resources: map[string]config.ProjectedResourceRecord{
+					"a": {Provider: "aws", Monthly: 10, Currency: "USD", Tags: map[string]string{"owner": "data", "env": "prd"}},
+					"b": {Provider: "azure", Monthly: 5, Currency: "EUR"},
+				},
+			}},
+		},
+	})
+
+	assert.True(t, report.MixedCurrencies)
+	assert.Equal(t, "owner", report.TeamTag)
+	assert.Equal(t, "data", report.ByTeam[0].Key)
+	assert.Equal(t, "prd", report.ByEnvironment[0].Key)
+}
+
+func TestBuildOrgReport_EmptyPeriod(t *testing.T) {
+	at := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	report := BuildOrgReport(OrgReportInput{From: at, To: at})
+	assert.Empty(t, report.Stacks)
+	assert.Zero(t, report.TotalMonthly)
+}
@@ -0,0 +1,18 @@
+// Package pipeline provides composable transforms over result slices.
+//
+// Commands that list costs or recommendations filter, sort, paginate, and
+// group their results the same way. Each transform is a Stage that returns a
+// new slice and leaves its input unmodified, so stages compose with Apply:
+//
+//	page := pipeline.Apply(results,
+//		pipeline.Filter(func(r engine.CostResult) bool { return r.Monthly > 0 }),
+//		pipeline.Sort(pipeline.Descending(func(a, b engine.CostResult) bool { return a.Monthly < b.Monthly })),
+//		pipeline.Page[engine.CostResult](2, 20, 0),
+//	)
+//
+// GroupBy changes the element type and is therefore applied last, outside Apply.
+//
+// Results too many to hold in memory live in a spill.Store instead of a slice.
+// FilterStore and PaginateStore filter and page a store while reading it a
+// chunk or a page at a time.
+package pipeline
@@ -0,0 +1,121 @@
+package pipeline
+
+import "sort"
+
+// Stage transforms a slice of results. Stages return a new slice or a
+// subslice of their input and never modify the input's elements or order.
+type Stage[T any] func(items []T) []T
+
+// Apply runs items through stages in order.
+func Apply[T any](items []T, stages ...Stage[T]) []T {
+	for _, stage := range stages {
+		items = stage(items)
+	}
+	return items
+}
+
+// Filter keeps the items for which keep returns true, in their input order.
+func Filter[T any](keep func(T) bool) Stage[T] {
+	return func(items []T) []T {
+		kept := make([]T, 0, len(items))
+		for _, item := range items {
+			if keep(item) {
+				kept = append(kept, item)
+			}
+		}
+		return kept
+	}
+}
+
+// Sort orders a copy of the items by less. The sort is stable: items that
+// compare equal keep their input order.
+func Sort[T any](less func(a, b T) bool) Stage[T] {
+	return func(items []T) []T {
+		sorted := make([]T, len(items))
+		copy(sorted, items)
+		sort.SliceStable(sorted, func(i, j int) bool { return less(sorted[i], sorted[j]) })
+		return sorted
+	}
+}
+
+// Descending reverses less, for sorting largest first while keeping ties in
+// their input order.
+func Descending[T any](less func(a, b T) bool) func(a, b T) bool {
+	return func(a, b T) bool { return less(b, a) }
+}
+
+// Limit keeps the first n items. A limit of zero or less keeps every item.
+func Limit[T any](n int) Stage[T] {
+	return func(items []T) []T {
+		if n <= 0 || n >= len(items) {
+			return items
+		}
+		return items[:n]
+	}
+}
+
+// Paginate keeps up to limit items starting at offset. A limit of zero or
+// less keeps every item from offset on; an offset past the end keeps none.
+func Paginate[T any](offset, limit int) Stage[T] {
+	return func(items []T) []T {
+		if offset >= len(items) {
+			return []T{}
+		}
+		if offset < 0 {
+			offset = 0
+		}
+		return Limit[T](limit)(items[offset:])
+	}
+}
+
+// Page keeps the items of a 1-based page of pageSize items, or limit items
+// from the start of the page when limit is positive. A page past the end is
+// capped to the last page, so that asking for too high a page still shows
+// the final results.
+func Page[T any](page, pageSize, limit int) Stage[T] {
+	return func(items []T) []T {
+		offset, limit := PageWindow(page, pageSize, limit, len(items))
+		return Paginate[T](offset, limit)(items)
+	}
+}
+
+// PageWindow returns the offset and limit of the items Page keeps of count
+// items.
+func PageWindow(page, pageSize, limit, count int) (int, int) {
+	offset := (page - 1) * pageSize
+	if offset >= count && count > 0 {
+		size := pageSize
+		if size <= 0 {
+			size = count
+		}
+		offset = ((count - 1) / size) * size
+	}
+	if limit <= 0 {
+		limit = pageSize
+	}
+	return offset, limit
+}
+
+// Group holds the items that share a key.
+type Group[K comparable, T any] struct {
+	Key   K
+	Items []T
+}
+
+// GroupBy splits items into groups by key. Groups are ordered by the first
+// item of each, and items keep their input order within a group.
+func GroupBy[T any, K comparable](items []T, key func(T) K) []Group[K, T] {
+	index := make(map[K]int)
+	var groups []Group[K, T]
+	for _, item := range items {
+		k := key(item)
+		i, ok := index[k]
+		if !ok {
+			i = len(groups)
+			index[k] = i
+			groups = append(groups, Group[K, T]{Key: k})
+		}
+		groups[i].Items = append(groups[i].Items, item)
+	}
+	return groups
+}
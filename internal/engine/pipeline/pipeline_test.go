@@ -0,0 +1,84 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	name string
+	cost float64
+}
+
+func byCost(a, b item) bool { return a.cost < b.cost }
+
+func TestApply_ComposesStages(t *testing.T) {
+	items := []item{{"a", 3}, {"b", 0}, {"c", 5}, {"d", 1}, {"e", 5}}
+
+	got := Apply(items,
+		Filter(func(i item) bool { return i.cost > 0 }),
+		Sort(Descending(byCost)),
+		Limit[item](3),
+	)
+
+	assert.Equal(t, []item{{"c", 5}, {"e", 5}, {"a", 3}}, got)
+	assert.Equal(t, item{"a", 3}, items[0], "input must not be modified")
+}
+
+func TestSort_IsStable(t *testing.T) {
+	items := []item{{"a", 1}, {"b", 2}, {"c", 1}}
+
+	assert.Equal(t, []item{{"a", 1}, {"c", 1}, {"b", 2}}, Sort(byCost)(items))
+	assert.Equal(t, []item{{"b", 2}, {"a", 1}, {"c", 1}}, Sort(Descending(byCost))(items))
+}
+
+func TestLimit(t *testing.T) {
+	items := []int{1, 2, 3}
+
+	assert.Equal(t, []int{1, 2}, Limit[int](2)(items))
+	assert.Equal(t, items, Limit[int](0)(items))
+	assert.Equal(t, items, Limit[int](5)(items))
+}
+
+func TestPaginate(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	assert.Equal(t, []int{2, 3}, Paginate[int](1, 2)(items))
+	assert.Equal(t, []int{4, 5}, Paginate[int](3, 0)(items))
+	assert.Equal(t, []int{}, Paginate[int](5, 2)(items))
+}
+
+func TestPage(t *testing.T) {
+	items := []int{1, 2, 3, 4, 5}
+
+	tests := []struct {
+		name                  string
+		page, pageSize, limit int
+		want                  []int
+	}{
+		{name: "first page", page: 1, pageSize: 2, want: []int{1, 2}},
+		{name: "partial last page", page: 3, pageSize: 2, want: []int{5}},
+		{name: "page past the end is capped", page: 9, pageSize: 2, want: []int{5}},
+		{name: "limit within page", page: 2, pageSize: 2, limit: 1, want: []int{3}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Page[int](tt.page, tt.pageSize, tt.limit)(items))
+		})
+	}
+
+	assert.Equal(t, []int{}, Page[int](2, 2, 0)(nil))
+}
+
+func TestGroupBy(t *testing.T) {
+	items := []item{{"web", 1}, {"db", 2}, {"web", 3}}
+
+	groups := GroupBy(items, func(i item) string { return i.name })
+
+	assert.Equal(t, []Group[string, item]{
+		{Key: "web", Items: []item{{"web", 1}, {"web", 3}}},
+		{Key: "db", Items: []item{{"db", 2}}},
+	}, groups)
+	assert.Empty(t, GroupBy([]item(nil), func(i item) string { return i.name }))
+}
@@ -0,0 +1,64 @@
+package pipeline
+
+import (
+	"sort"
+
+	"github.com/rshade/finfocus/internal/engine/spill"
+)
+
+// FilterStore appends the items of src for which keep returns true to dst,
+// in their input order. It reads src a chunk at a time, so results spilled
+// to disk are filtered without loading them all into memory.
+func FilterStore[T any](src, dst *spill.Store[T], keep func(T) bool) error {
+	return src.Each(func(item T) error {
+		if !keep(item) {
+			return nil
+		}
+		return dst.Append(item)
+	})
+}
+
+// PaginateStore returns up to limit items of store starting at offset, as
+// Paginate does for a slice, reading only those items from disk.
+func PaginateStore[T any](store *spill.Store[T], offset, limit int) ([]T, error) {
+	count := store.Len()
+	if offset >= count {
+		return []T{}, nil
+	}
+	offset = max(offset, 0)
+	end := count
+	if limit > 0 {
+		end = min(offset+limit, count)
+	}
+	return store.Slice(offset, end)
+}
+
+// SortStore appends the items of src to dst in the stable order of less
+// applied to their keys. Only the key of each item is held in memory, so key
+// should return just the part of an item less compares; the items are then
+// read back from src one at a time in sorted order.
+func SortStore[T, K any](src, dst *spill.Store[T], key func(T) K, less func(a, b K) bool) error {
+	type keyed struct {
+		index int
+		key   K
+	}
+	keys := make([]keyed, 0, src.Len())
+	err := src.Each(func(item T) error {
+		keys = append(keys, keyed{index: len(keys), key: key(item)})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	sort.SliceStable(keys, func(i, j int) bool { return less(keys[i].key, keys[j].key) })
+	for _, k := range keys {
+		item, atErr := src.At(k.index)
+		if atErr != nil {
+			return atErr
+		}
+		if err = dst.Append(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
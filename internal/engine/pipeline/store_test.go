@@ -0,0 +1,48 @@
+package pipeline
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine/spill"
+)
+
+func TestFilterStore_AndPaginateStore(t *testing.T) {
+	src := spill.New[int](2, t.TempDir())
+	defer src.Close()
+	require.NoError(t, src.Append(1, 2, 3, 4, 5, 6))
+	require.True(t, src.Spilled())
+
+	dst := spill.New[int](2, t.TempDir())
+	defer dst.Close()
+	require.NoError(t, FilterStore(src, dst, func(n int) bool { return n%2 == 0 }))
+	assert.Equal(t, 3, dst.Len())
+
+	page, err := PaginateStore(dst, 1, 5)
+	require.NoError(t, err)
+	assert.Equal(t, []int{4, 6}, page)
+
+	page, err = PaginateStore(src, 0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, page, "no limit keeps every item")
+
+	page, err = PaginateStore(src, 9, 2)
+	require.NoError(t, err)
+	assert.Empty(t, page)
+}
+
+func TestSortStore(t *testing.T) {
+	src := spill.New[int](2, t.TempDir())
+	defer src.Close()
+	require.NoError(t, src.Append(31, 12, 23, 11, 32))
+
+	dst := spill.New[int](2, t.TempDir())
+	defer dst.Close()
+	tens := func(n int) int { return n / 10 }
+	require.NoError(t, SortStore(src, dst, tens, func(a, b int) bool { return a < b }))
+	sorted, err := dst.Slice(0, dst.Len())
+	require.NoError(t, err)
+	assert.Equal(t, []int{12, 11, 23, 31, 32}, sorted, "items with equal keys keep their order")
+}
@@ -0,0 +1,116 @@
+package engine
+
+import (
+	"math"
+	"sort"
+)
+
+// PreviewDelta is the change in projected monthly cost that a Pulumi preview
+// implies: the cost of the resources it creates, less the cost of those it
+// deletes, plus the change in cost of those it updates or replaces.
+type PreviewDelta struct {
+	Currency string `json:"currency"`
+	// MixedCurrencies is true when the results use more than one currency;
+	// amounts are then sums of unconverted costs.
+	MixedCurrencies bool `json:"mixedCurrencies,omitempty"`
+	// Added is the monthly cost of created resources, Removed the monthly cost
+	// of deleted resources, and Modified the net change in monthly cost of
+	// updated and replaced resources.
+	Added    float64 `json:"added"`
+	Removed  float64 `json:"removed"`
+	Modified float64 `json:"modified"`
+	// Change is Added - Removed + Modified.
+	Change float64 `json:"change"`
+	// Failed counts changed resources with a result that carries an error;
+	// their cost on that side of the change counts as zero.
+	Failed int `json:"failed,omitempty"`
+	// OneTime is the one-time cost of the replacements, which Change does not
+	// include, and OneTimeCosts its estimates.
+	OneTime      float64         `json:"oneTime,omitempty"`
+	OneTimeCosts []ChurnEstimate `json:"oneTimeCosts,omitempty"`
+	// Changes lists resources whose monthly cost changes or that have a
+	// one-time cost, largest absolute monthly change first.
+	Changes []ResourceCostChange `json:"changes"`
+}
+
+// CalculatePreviewDelta prices the changes of a Pulumi preview. operations
+// maps the ID of each resource the preview changes to its operation (create,
+// update, replace, or delete); before holds the results of the resources as
+// deployed and after their results as the deployment leaves them. oneTime
+// holds the one-time costs of the replacements, as EstimateReplacementChurn
+// returns them.
+func CalculatePreviewDelta(
+	operations map[string]string,
+	before, after []CostResult,
+	oneTime []ChurnEstimate,
+) *PreviewDelta {
+	delta := &PreviewDelta{Currency: defaultCurrency, Changes: []ResourceCostChange{}}
+	currencySet := false
+	costs := func(results []CostResult) (map[string]float64, map[string]string, map[string]bool) {
+		monthly := make(map[string]float64, len(results))
+		types := make(map[string]string, len(results))
+		failed := make(map[string]bool)
+		for _, r := range results {
+			types[r.ResourceID] = r.ResourceType
+			if r.Error != nil {
+				failed[r.ResourceID] = true
+				continue
+			}
+			if r.Currency != "" {
+				if !currencySet {
+					delta.Currency = r.Currency
+					currencySet = true
+				} else if r.Currency != delta.Currency {
+					delta.MixedCurrencies = true
+				}
+			}
+			monthly[r.ResourceID] += r.Monthly
+		}
+		return monthly, types, failed
+	}
+	beforeCosts, beforeTypes, beforeFailed := costs(before)
+	afterCosts, afterTypes, afterFailed := costs(after)
+	oneTimeCosts := make(map[string]float64, len(oneTime))
+	for _, estimate := range oneTime {
+		oneTimeCosts[estimate.ResourceID] += estimate.Amount
+		delta.OneTime += estimate.Amount
+	}
+	delta.OneTimeCosts = oneTime
+
+	for _, id := range sortedKeys(operations) {
+		op := operations[id]
+		change := ResourceCostChange{ResourceID: id, ResourceType: afterTypes[id], Operation: op}
+		if change.ResourceType == "" {
+			change.ResourceType = beforeTypes[id]
+		}
+		switch op {
+		case opCreate:
+			change.After, change.Added = afterCosts[id], true
+			delta.Added += change.After
+		case opDelete:
+			change.Before, change.Removed = beforeCosts[id], true
+			delta.Removed += change.Before
+		default:
+			change.Before, change.After = beforeCosts[id], afterCosts[id]
+			delta.Modified += change.After - change.Before
+		}
+		change.Change = change.After - change.Before
+		change.OneTime = oneTimeCosts[id]
+		if beforeFailed[id] || afterFailed[id] {
+			delta.Failed++
+		}
+		if change.Change != 0 || change.OneTime != 0 {
+			delta.Changes = append(delta.Changes, change)
+		}
+	}
+
+	delta.Change = delta.Added - delta.Removed + delta.Modified
+	sort.SliceStable(delta.Changes, func(i, j int) bool {
+		ci, cj := math.Abs(delta.Changes[i].Change), math.Abs(delta.Changes[j].Change)
+		if ci != cj {
+			return ci > cj
+		}
+		return delta.Changes[i].OneTime > delta.Changes[j].OneTime
+	})
+	return delta
+}
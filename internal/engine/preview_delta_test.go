@@ -0,0 +1,86 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculatePreviewDelta(t *testing.T) {
+	operations := map[string]string{
+		"web":   "create",
+		"api":   "update",
+		"db":    "replace",
+		"cache": "delete",
+		"role":  "create",
+	}
+	before := []CostResult{
+		{ResourceID: "api", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 15},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 12},
+		{ResourceID: "cache", ResourceType: "aws:elasticache/cluster:Cluster", Currency: "USD", Monthly: 25},
+	}
+	after := []CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 8},
+		{ResourceID: "api", ResourceType: "aws:ec2/instance:Instance", Currency: "USD", Monthly: 60},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Currency: "USD", Monthly: 130},
+		{ResourceID: "role", ResourceType: "aws:iam/role:Role", Currency: "USD"},
+	}
+
+	delta := CalculatePreviewDelta(operations, before, after, nil)
+
+	assert.Equal(t, "USD", delta.Currency)
+	assert.InDelta(t, 8, delta.Added, 1e-9)
+	assert.InDelta(t, 25, delta.Removed, 1e-9)
+	assert.InDelta(t, 163, delta.Modified, 1e-9)
+	assert.InDelta(t, 146, delta.Change, 1e-9)
+	assert.Zero(t, delta.Failed)
+
+	require.Len(t, delta.Changes, 4, "resources whose cost does not change are left out")
+	db := delta.Changes[0]
+	assert.Equal(t, "db", db.ResourceID, "largest change first")
+	assert.Equal(t, "replace", db.Operation)
+	assert.InDelta(t, 118, db.Change, 1e-9)
+	cache := delta.Changes[2]
+	assert.Equal(t, "cache", cache.ResourceID)
+	assert.True(t, cache.Removed)
+	assert.InDelta(t, -25, cache.Change, 1e-9)
+}
+
+func TestCalculatePreviewDelta_FailedResults(t *testing.T) {
+	delta := CalculatePreviewDelta(
+		map[string]string{"api": "update", "web": "create"},
+		[]CostResult{{ResourceID: "api", Monthly: 15, Error: &StructuredError{Code: ErrCodePluginError}}},
+		[]CostResult{
+			{ResourceID: "api", Currency: "EUR", Monthly: 20},
+			{ResourceID: "web", Currency: "USD", Monthly: 5},
+		},
+		nil,
+	)
+
+	assert.Equal(t, 1, delta.Failed)
+	assert.InDelta(t, 25, delta.Change, 1e-9, "failed results count as zero")
+	assert.True(t, delta.MixedCurrencies)
+}
+
+func TestCalculatePreviewDelta_OneTimeCosts(t *testing.T) {
+	operations := map[string]string{"db": "replace", "vol": "replace"}
+	results := []CostResult{
+		{ResourceID: "db", Currency: "USD", Monthly: 100},
+		{ResourceID: "vol", Currency: "USD", Monthly: 40},
+	}
+	oneTime := []ChurnEstimate{
+		{ResourceID: "vol", Kind: ChurnSnapshotRestore, Amount: 25},
+		{ResourceID: "vol", Kind: ChurnIOPSRamp, Amount: 5},
+	}
+
+	delta := CalculatePreviewDelta(operations, results, results, oneTime)
+
+	assert.Zero(t, delta.Change, "one-time costs are not monthly costs")
+	assert.InDelta(t, 30, delta.OneTime, 1e-9)
+	assert.Len(t, delta.OneTimeCosts, 2)
+	require.Len(t, delta.Changes, 1, "a replacement with a one-time cost is listed")
+	assert.Equal(t, "vol", delta.Changes[0].ResourceID)
+	assert.InDelta(t, 40, delta.Changes[0].Before, 1e-9)
+	assert.InDelta(t, 30, delta.Changes[0].OneTime, 1e-9)
+}
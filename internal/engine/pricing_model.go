@@ -0,0 +1,121 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Pricing models a projection can request from plugins.
+const (
+	PricingModelOnDemand   = "on-demand"
+	PricingModelSpot       = "spot"
+	PricingModelReserved1Y = "reserved-1y"
+	PricingModelReserved3Y = "reserved-3y"
+)
+
+// PricingModelTag is the resource tag that passes the requested pricing model
+// to plugins. It is only sent for models other than on-demand, so plugins that
+// predate it keep receiving unchanged requests.
+const PricingModelTag = "finfocus:pricing-model"
+
+// PricingModels returns the supported pricing models in display order.
+func PricingModels() []string {
+	return []string{PricingModelOnDemand, PricingModelSpot, PricingModelReserved1Y, PricingModelReserved3Y}
+}
+
+// ValidatePricingModel returns an error when model is not a supported
+// pricing model.
+func ValidatePricingModel(model string) error {
+	if !slices.Contains(PricingModels(), model) {
+		return fmt.Errorf("unsupported pricing model %q: use %s", model, strings.Join(PricingModels(), ", "))
+	}
+	return nil
+}
+
+// PricingModelFromContext returns the pricing model stored in ctx under
+// ContextKeyPricingModel, or PricingModelOnDemand when there is none.
+func PricingModelFromContext(ctx context.Context) string {
+	if model, ok := ctx.Value(ContextKeyPricingModel).(string); ok && model != "" {
+		return model
+	}
+	return PricingModelOnDemand
+}
+
+// withPricingModelTag adds PricingModelTag to the tags of a plugin request
+// when ctx requests a pricing model other than on-demand.
+func withPricingModelTag(ctx context.Context, tags map[string]string) map[string]string {
+	if model := PricingModelFromContext(ctx); model != PricingModelOnDemand {
+		tags[PricingModelTag] = model
+	}
+	return tags
+}
+
+// PricingModelCost is the monthly cost of one resource under each pricing
+// model. Models under which the resource could not be priced are absent.
+type PricingModelCost struct {
+	ResourceID   string             `json:"resourceId"`
+	ResourceType string             `json:"resourceType"`
+	Monthly      map[string]float64 `json:"monthly"`
+}
+
+// PricingModelComparison sets the monthly costs of a set of resources under
+// several pricing models side by side.
+type PricingModelComparison struct {
+	Currency string `json:"currency"`
+	// Models lists the compared pricing models, in PricingModels order.
+	Models []string           `json:"models"`
+	Totals map[string]float64 `json:"totals"`
+	// Savings is the monthly saving of each model over on-demand.
+	Savings   map[string]float64 `json:"savings"`
+	Resources []PricingModelCost `json:"resources"`
+}
+
+// ComparePricingModels builds a side-by-side comparison from the results of
+// pricing the same resources under each model in results. Results with an
+// error are skipped. Resources keep the order in which they first appear.
+func ComparePricingModels(results map[string][]CostResult) *PricingModelComparison {
+	comparison := &PricingModelComparison{
+		Currency: defaultCurrency,
+		Totals:   make(map[string]float64),
+		Savings:  make(map[string]float64),
+	}
+	index := make(map[string]int)
+	currencySet := false
+	for _, model := range PricingModels() {
+		modelResults, ok := results[model]
+		if !ok {
+			continue
+		}
+		comparison.Models = append(comparison.Models, model)
+		comparison.Totals[model] = 0
+		for _, result := range modelResults {
+			if result.Error != nil {
+				continue
+			}
+			if !currencySet && result.Currency != "" {
+				comparison.Currency = result.Currency
+				currencySet = true
+			}
+			i, seen := index[result.ResourceID]
+			if !seen {
+				i = len(comparison.Resources)
+				index[result.ResourceID] = i
+				comparison.Resources = append(comparison.Resources, PricingModelCost{
+					ResourceID:   result.ResourceID,
+					ResourceType: result.ResourceType,
+					Monthly:      make(map[string]float64),
+				})
+			}
+			comparison.Resources[i].Monthly[model] += result.Monthly
+			comparison.Totals[model] += result.Monthly
+		}
+	}
+	if onDemand, ok := comparison.Totals[PricingModelOnDemand]; ok {
+		for _, model := range comparison.Models {
+			comparison.Savings[model] = onDemand - comparison.Totals[model]
+		}
+	}
+	return comparison
+}
@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+func TestValidatePricingModel(t *testing.T) {
+	for _, model := range PricingModels() {
+		require.NoError(t, ValidatePricingModel(model), model)
+	}
+	err := ValidatePricingModel("preemptible")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "spot")
+}
+
+func TestPricingModelFromContext(t *testing.T) {
+	assert.Equal(t, PricingModelOnDemand, PricingModelFromContext(context.Background()))
+	ctx := context.WithValue(context.Background(), ContextKeyPricingModel, PricingModelSpot)
+	assert.Equal(t, PricingModelSpot, PricingModelFromContext(ctx))
+}
+
+func TestProjectedCostPassesPricingModelTag(t *testing.T) {
+	var mu sync.Mutex
+	var tags []map[string]string
+	mock := &mockCostSourceClient{
+		projected: func(in *proto.GetProjectedCostRequest) (*proto.GetProjectedCostResponse, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			tags = append(tags, in.Resources[0].Properties)
+			return &proto.GetProjectedCostResponse{
+				Results: []*proto.CostResult{{Currency: "USD", MonthlyCost: 10}},
+			}, nil
+		},
+	}
+	eng := New([]*pluginhost.Client{{Name: "mock", API: mock}}, nil)
+	resources := []ResourceDescriptor{{
+		ID: "web", Type: "aws:ec2/instance:Instance", Provider: "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}}
+
+	_, err := eng.GetProjectedCostWithErrors(context.Background(), resources)
+	require.NoError(t, err)
+	ctx := context.WithValue(context.Background(), ContextKeyPricingModel, PricingModelReserved3Y)
+	_, err = eng.GetProjectedCostWithErrors(ctx, resources)
+	require.NoError(t, err)
+
+	require.Len(t, tags, 2)
+	assert.NotContains(t, tags[0], PricingModelTag, "on-demand requests are unchanged")
+	assert.Equal(t, PricingModelReserved3Y, tags[1][PricingModelTag])
+	assert.Equal(t, "t3.micro", tags[1]["instanceType"])
+}
+
+func TestComparePricingModels(t *testing.T) {
+	comparison := ComparePricingModels(map[string][]CostResult{
+		PricingModelOnDemand: {
+			{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "EUR", Monthly: 100},
+			{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Currency: "EUR", Monthly: 50},
+		},
+		PricingModelSpot: {
+			{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "EUR", Monthly: 30},
+			{ResourceID: "db", Error: &StructuredError{Code: "unsupported"}},
+		},
+		PricingModelReserved1Y: {
+			{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Currency: "EUR", Monthly: 35},
+			{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Currency: "EUR", Monthly: 60},
+		},
+	})
+
+	assert.Equal(t, "EUR", comparison.Currency)
+	assert.Equal(t, []string{PricingModelOnDemand, PricingModelSpot, PricingModelReserved1Y}, comparison.Models)
+	require.Len(t, comparison.Resources, 2)
+	assert.Equal(t, "web", comparison.Resources[0].ResourceID)
+	assert.Equal(t, map[string]float64{
+		PricingModelOnDemand: 100, PricingModelSpot: 30, PricingModelReserved1Y: 60,
+	}, comparison.Resources[0].Monthly)
+	assert.NotContains(t, comparison.Resources[1].Monthly, PricingModelSpot)
+	assert.InDelta(t, 150.0, comparison.Totals[PricingModelOnDemand], 0.001)
+	assert.InDelta(t, 120.0, comparison.Savings[PricingModelSpot], 0.001)
+	assert.InDelta(t, 55.0, comparison.Savings[PricingModelReserved1Y], 0.001)
+	assert.InDelta(t, 0.0, comparison.Savings[PricingModelOnDemand], 0.001)
+}
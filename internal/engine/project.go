@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -24,6 +25,12 @@ const (
 	OutputJSON OutputFormat = "json"
 	// OutputNDJSON renders results as newline-delimited JSON for streaming.
 	OutputNDJSON OutputFormat = "ndjson"
+	// OutputCSV renders results as comma-separated values (recommendations only).
+	OutputCSV OutputFormat = "csv"
+	// OutputMarkdown renders results as a Markdown table (recommendations only).
+	OutputMarkdown OutputFormat = "markdown"
+	// OutputSARIF renders results as a SARIF 2.1.0 log (recommendations only).
+	OutputSARIF OutputFormat = "sarif"
 )
 
 const (
@@ -265,6 +272,22 @@ func renderBreakdowns(w io.Writer, aggregated *AggregatedResults) {
 		}
 		fmt.Fprintf(w, "\n")
 	}
+
+	// Print per-stack subtotals when several stacks were costed together
+	if len(aggregated.Summary.ByStack) > 0 {
+		fmt.Fprintf(w, "BY STACK\n")
+		fmt.Fprintf(w, "--------\n")
+		stacks := make([]string, 0, len(aggregated.Summary.ByStack))
+		for stack := range aggregated.Summary.ByStack {
+			stacks = append(stacks, stack)
+		}
+		sort.Strings(stacks)
+		for _, stack := range stacks {
+			cost := aggregated.Summary.ByStack[stack]
+			fmt.Fprintf(w, "%s:\t%.2f %s\n", stack, cost, aggregated.Summary.Currency)
+		}
+		fmt.Fprintf(w, "\n")
+	}
 }
 
 // renderSustainabilitySummary aggregates sustainability metrics across all resources
@@ -391,8 +414,14 @@ func renderCarbonEquivalencies(ctx context.Context, w io.Writer, sustainTotals m
 func renderResourceDetails(w io.Writer, aggregated *AggregatedResults) {
 	fmt.Fprintf(w, "RESOURCE DETAILS\n")
 	fmt.Fprintf(w, "================\n")
-	fmt.Fprintln(w, "Resource\tAdapter\tMonthly\tHourly\tCurrency\tRecommendations\tNotes")
-	fmt.Fprintln(w, "--------\t-------\t-------\t------\t--------\t---------------\t-----")
+	showStack := len(aggregated.Summary.ByStack) > 0
+	if showStack {
+		fmt.Fprintln(w, "Stack\tResource\tAdapter\tAccuracy\tMonthly\tHourly\tCurrency\tRecommendations\tNotes")
+		fmt.Fprintln(w, "-----\t--------\t-------\t--------\t-------\t------\t--------\t---------------\t-----")
+	} else {
+		fmt.Fprintln(w, "Resource\tAdapter\tAccuracy\tMonthly\tHourly\tCurrency\tRecommendations\tNotes")
+		fmt.Fprintln(w, "--------\t-------\t--------\t-------\t------\t--------\t---------------\t-----")
+	}
 
 	for _, result := range aggregated.Resources {
 		resource := fmt.Sprintf("%s/%s", result.ResourceType, result.ResourceID)
@@ -403,9 +432,13 @@ func renderResourceDetails(w io.Writer, aggregated *AggregatedResults) {
 		notes := formatResourceNotes(result)
 		recs := formatRecommendationCount(len(result.Recommendations))
 
-		fmt.Fprintf(w, "%s\t%s\t%.2f\t%.4f\t%s\t%s\t%s\n",
+		if showStack {
+			fmt.Fprintf(w, "%s\t", cmp.Or(result.Stack, "-"))
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%.4f\t%s\t%s\t%s\n",
 			resource,
 			result.Adapter,
+			result.Accuracy.DisplayLabel(),
 			result.Monthly,
 			result.Hourly,
 			result.Currency,
@@ -513,8 +546,8 @@ func buildActualCostHeaderColumns(hasActualCosts bool, showConfidence bool) ([]s
 	}
 
 	if showConfidence {
-		headers = append(headers, "Confidence")
-		separators = append(separators, "----------")
+		headers = append(headers, "Confidence", "Accuracy")
+		separators = append(separators, "----------", "--------")
 	}
 
 	headers = append(headers, "Currency", "Recommendations", "Notes")
@@ -576,7 +609,7 @@ func buildActualCostRowColumns(
 	}
 
 	if showConfidence {
-		columns = append(columns, result.Confidence.DisplayLabel())
+		columns = append(columns, result.Confidence.DisplayLabel(), result.Accuracy.DisplayLabel())
 	}
 
 	recs := formatRecommendationCount(len(result.Recommendations))
@@ -628,6 +661,37 @@ func renderNDJSON(writer io.Writer, results []CostResult) error {
 	return nil
 }
 
+// NewNDJSONSummary totals results for the summary record of NDJSON output,
+// in the currency of the first result that names one. failures is the number
+// of failed plugin calls.
+func NewNDJSONSummary(results []CostResult, failures int) NDJSONSummary {
+	summary := NDJSONSummary{Type: NDJSONSummaryType, Errors: failures}
+	for _, result := range results {
+		summary.add(result)
+	}
+	return summary.withDefaultCurrency()
+}
+
+// add counts result in the summary.
+func (s *NDJSONSummary) add(result CostResult) {
+	s.Resources++
+	s.TotalMonthly += result.Monthly
+	s.TotalHourly += result.Hourly
+	s.TotalCost += result.TotalCost
+	if s.Currency == "" {
+		s.Currency = result.Currency
+	}
+}
+
+// withDefaultCurrency returns the summary in the default currency when no
+// result named one.
+func (s NDJSONSummary) withDefaultCurrency() NDJSONSummary {
+	if s.Currency == "" {
+		s.Currency = defaultCurrency
+	}
+	return s
+}
+
 // renderCrossProviderTable writes a cross-provider cost table to stdout.
 // It formats one row per aggregation period and one column per provider, with the first
 // column labeled "Date" when groupBy is GroupByDaily or "Month" otherwise.
@@ -0,0 +1,101 @@
+package engine
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// ResourceCostChange is the change in one resource's projected monthly cost.
+type ResourceCostChange struct {
+	ResourceID   string  `json:"resourceId"`
+	ResourceType string  `json:"resourceType"`
+	Before       float64 `json:"before"`
+	After        float64 `json:"after"`
+	Change       float64 `json:"change"`
+	// Added and Removed mark resources missing from the baseline or the new projection.
+	Added   bool `json:"added,omitempty"`
+	Removed bool `json:"removed,omitempty"`
+	// Operation is the Pulumi operation that changes the resource in a
+	// PreviewDelta: create, update, replace, or delete.
+	Operation string `json:"operation,omitempty"`
+	// OneTime is the one-time cost of replacing the resource in a
+	// PreviewDelta, apart from its monthly cost.
+	OneTime float64 `json:"oneTime,omitempty"`
+}
+
+// ProjectionDelta compares a new projection with a recorded baseline.
+type ProjectionDelta struct {
+	BaselineAt time.Time `json:"baselineAt"`
+	Baseline   float64   `json:"baseline"`
+	Projected  float64   `json:"projected"`
+	Change     float64   `json:"change"`
+	// Changes lists resources whose cost changed, largest absolute change first.
+	Changes []ResourceCostChange `json:"changes"`
+}
+
+// ChangePercent returns the change as a percentage of the baseline, or 0 when
+// the baseline is zero.
+func (d *ProjectionDelta) ChangePercent() float64 {
+	if d.Baseline == 0 {
+		return 0
+	}
+	return d.Change / d.Baseline * percentageMultiplier
+}
+
+// CalculateProjectionDelta compares results with a recorded baseline snapshot.
+// Results with errors are left out of the projected total, matching how
+// snapshots are recorded.
+func CalculateProjectionDelta(baseline config.ProjectionSnapshot, results []CostResult) *ProjectionDelta {
+	delta := &ProjectionDelta{BaselineAt: baseline.RecordedAt}
+	seen := make(map[string]bool, len(results))
+
+	for _, r := range results {
+		if r.Error != nil {
+			continue
+		}
+		delta.Projected += r.Monthly
+		if r.ResourceID == "" {
+			continue
+		}
+		seen[r.ResourceID] = true
+		before, ok := baseline.Resources[r.ResourceID]
+		change := ResourceCostChange{
+			ResourceID:   r.ResourceID,
+			ResourceType: r.ResourceType,
+			Before:       before.Monthly,
+			After:        r.Monthly,
+			Change:       r.Monthly - before.Monthly,
+			Added:        !ok,
+		}
+		if change.Change != 0 || change.Added {
+			delta.Changes = append(delta.Changes, change)
+		}
+	}
+
+	for id, record := range baseline.Resources {
+		delta.Baseline += record.Monthly
+		if seen[id] {
+			continue
+		}
+		delta.Changes = append(delta.Changes, ResourceCostChange{
+			ResourceID:   id,
+			ResourceType: record.ResourceType,
+			Before:       record.Monthly,
+			Change:       -record.Monthly,
+			Removed:      true,
+		})
+	}
+
+	delta.Change = delta.Projected - delta.Baseline
+	sort.Slice(delta.Changes, func(i, j int) bool {
+		ci, cj := math.Abs(delta.Changes[i].Change), math.Abs(delta.Changes[j].Change)
+		if ci != cj {
+			return ci > cj
+		}
+		return delta.Changes[i].ResourceID < delta.Changes[j].ResourceID
+	})
+	return delta
+}
@@ -0,0 +1,52 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestCalculateProjectionDelta(t *testing.T) {
+	recordedAt := time.Date(2026, 1, 5, 0, 0, 0, 0, time.UTC)
+	baseline := config.ProjectionSnapshot{
+		RecordedAt: recordedAt,
+		Resources: map[string]config.ProjectedResourceRecord{
+			"web":   {ResourceType: "aws:ec2/instance:Instance", Monthly: 50},
+			"db":    {ResourceType: "aws:rds/instance:Instance", Monthly: 30},
+			"queue": {ResourceType: "aws:sqs/queue:Queue", Monthly: 20},
+		},
+	}
+	results := []CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 100},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Monthly: 30},
+		{ResourceID: "cache", ResourceType: "aws:elasticache/cluster:Cluster", Monthly: 15},
+		{ResourceID: "broken", Monthly: 999, Error: &StructuredError{Code: ErrCodePluginError}},
+	}
+
+	delta := CalculateProjectionDelta(baseline, results)
+
+	assert.Equal(t, recordedAt, delta.BaselineAt)
+	assert.InDelta(t, 100.0, delta.Baseline, 0.001)
+	assert.InDelta(t, 145.0, delta.Projected, 0.001, "errored results are excluded")
+	assert.InDelta(t, 45.0, delta.Change, 0.001)
+	assert.InDelta(t, 45.0, delta.ChangePercent(), 0.001)
+
+	require.Len(t, delta.Changes, 3, "unchanged resources are omitted")
+	assert.Equal(t, "web", delta.Changes[0].ResourceID)
+	assert.InDelta(t, 50.0, delta.Changes[0].Change, 0.001)
+	assert.Equal(t, ResourceCostChange{
+		ResourceID: "queue", ResourceType: "aws:sqs/queue:Queue", Before: 20, Change: -20, Removed: true,
+	}, delta.Changes[1])
+	assert.Equal(t, "cache", delta.Changes[2].ResourceID)
+	assert.True(t, delta.Changes[2].Added)
+}
+
+func TestProjectionDelta_ChangePercentZeroBaseline(t *testing.T) {
+	delta := CalculateProjectionDelta(config.ProjectionSnapshot{}, []CostResult{{ResourceID: "a", Monthly: 10}})
+	assert.InDelta(t, 10.0, delta.Change, 0.001)
+	assert.Zero(t, delta.ChangePercent())
+}
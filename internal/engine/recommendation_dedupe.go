@@ -0,0 +1,148 @@
+package engine
+
+import (
+	"sort"
+)
+
+// dedupeKey identifies recommendations that describe the same action on the
+// same resource, regardless of which plugin produced them.
+type dedupeKey struct {
+	resourceID string
+	actionType string
+}
+
+// DeduplicateRecommendations merges recommendations that target the same
+// resource with the same action type, as happens when several plugins (e.g.
+// aws and kubecost) both suggest rightsizing one instance.
+//
+// The recommendation with the highest estimated savings represents each
+// group, keeping its ID, description, and savings. The merged result lists
+// every contributing source in Sources, each source's estimate in
+// SourceSavings, the union of Reasoning strings, and the union of Metadata
+// (the representative's values win on conflict). Recommendations without a
+// resource ID are never merged. Output order follows the first occurrence of
+// each group. It returns the deduplicated slice and the number of
+// recommendations merged away.
+func DeduplicateRecommendations(recs []Recommendation) ([]Recommendation, int) {
+	groups := make(map[dedupeKey][]int, len(recs))
+	order := make([]dedupeKey, 0, len(recs))
+	result := make([]Recommendation, 0, len(recs))
+
+	for i, rec := range recs {
+		if rec.ResourceID == "" {
+			continue
+		}
+		key := dedupeKey{resourceID: rec.ResourceID, actionType: rec.Type}
+		if _, seen := groups[key]; !seen {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	merged := 0
+	firstOf := make(map[int]dedupeKey, len(order))
+	for _, key := range order {
+		firstOf[groups[key][0]] = key
+	}
+
+	for i, rec := range recs {
+		if rec.ResourceID == "" {
+			result = append(result, rec)
+			continue
+		}
+		key, first := firstOf[i]
+		if !first {
+			continue
+		}
+		members := groups[key]
+		if len(members) == 1 {
+			result = append(result, rec)
+			continue
+		}
+		group := make([]Recommendation, 0, len(members))
+		for _, idx := range members {
+			group = append(group, recs[idx])
+		}
+		result = append(result, mergeRecommendations(group))
+		merged += len(members) - 1
+	}
+
+	return result, merged
+}
+
+// mergeRecommendations combines duplicate recommendations into one, using the
+// highest-savings entry as the representative.
+func mergeRecommendations(group []Recommendation) Recommendation {
+	best := 0
+	for i := range group {
+		if group[i].EstimatedSavings > group[best].EstimatedSavings {
+			best = i
+		}
+	}
+
+	merged := group[best]
+	merged.Reasoning = nil
+	merged.Metadata = nil
+	merged.SourceSavings = make(map[string]float64, len(group))
+
+	sources := make(map[string]bool, len(group))
+	seenReasons := make(map[string]bool)
+	for _, rec := range group {
+		for _, src := range rec.AllSources() {
+			sources[src] = true
+			if savings, ok := merged.SourceSavings[src]; !ok || rec.EstimatedSavings > savings {
+				merged.SourceSavings[src] = rec.EstimatedSavings
+			}
+		}
+		for _, reason := range rec.Reasoning {
+			if !seenReasons[reason] {
+				seenReasons[reason] = true
+				merged.Reasoning = append(merged.Reasoning, reason)
+			}
+		}
+	}
+
+	// Apply metadata from the other entries first so the representative wins.
+	for i, rec := range group {
+		if i != best {
+			mergeMetadata(&merged, rec.Metadata)
+		}
+	}
+	mergeMetadata(&merged, group[best].Metadata)
+
+	merged.Sources = make([]string, 0, len(sources))
+	for src := range sources {
+		merged.Sources = append(merged.Sources, src)
+	}
+	sort.Strings(merged.Sources)
+	if len(merged.SourceSavings) == 0 {
+		merged.SourceSavings = nil
+	}
+
+	return merged
+}
+
+// mergeMetadata copies metadata entries into rec, overwriting existing keys.
+func mergeMetadata(rec *Recommendation, metadata map[string]string) {
+	if len(metadata) == 0 {
+		return
+	}
+	if rec.Metadata == nil {
+		rec.Metadata = make(map[string]string, len(metadata))
+	}
+	for k, v := range metadata {
+		rec.Metadata[k] = v
+	}
+}
+
+// AllSources returns every data source that contributed this recommendation:
+// Sources when it was merged from several plugins, otherwise Source alone.
+func (r Recommendation) AllSources() []string {
+	if len(r.Sources) > 0 {
+		return r.Sources
+	}
+	if r.Source == "" {
+		return nil
+	}
+	return []string{r.Source}
+}
@@ -0,0 +1,94 @@
+package engine
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+func TestDeduplicateRecommendations(t *testing.T) {
+	recs := []Recommendation{
+		{
+			ID: "aws-1", ResourceID: "i-123", Type: "RIGHTSIZE", Source: "aws", EstimatedSavings: 40,
+			Reasoning: []string{"CPU below 10%"}, Metadata: map[string]string{"region": "us-east-1"},
+		},
+		{ID: "vol-1", ResourceID: "vol-9", Type: "DELETE_UNUSED", Source: "aws", EstimatedSavings: 5},
+		{
+			ID: "kc-1", ResourceID: "i-123", Type: "RIGHTSIZE", Source: "kubecost", EstimatedSavings: 55,
+			Description: "Downsize to m5.large",
+			Reasoning:   []string{"CPU below 10%", "Memory below 20%"},
+			Metadata:    map[string]string{"region": "us-east-2", "cluster": "prod"},
+		},
+		{ID: "kc-2", ResourceID: "i-123", Type: "TERMINATE", Source: "kubecost", EstimatedSavings: 90},
+		{ID: "x-1", Type: "RIGHTSIZE", Source: "aws"},
+		{ID: "x-2", Type: "RIGHTSIZE", Source: "kubecost"},
+	}
+
+	deduped, merged := DeduplicateRecommendations(recs)
+	assert.Equal(t, 1, merged)
+	require.Len(t, deduped, 5)
+
+	rightsize := deduped[0]
+	assert.Equal(t, "kc-1", rightsize.ID, "highest savings represents the group")
+	assert.Equal(t, "Downsize to m5.large", rightsize.Description)
+	assert.InDelta(t, 55.0, rightsize.EstimatedSavings, 0.001)
+	assert.Equal(t, []string{"aws", "kubecost"}, rightsize.Sources)
+	assert.Equal(t, map[string]float64{"aws": 40, "kubecost": 55}, rightsize.SourceSavings)
+	assert.Equal(t, []string{"CPU below 10%", "Memory below 20%"}, rightsize.Reasoning)
+	assert.Equal(t, map[string]string{"region": "us-east-2", "cluster": "prod"}, rightsize.Metadata)
+
+	assert.Equal(t, "vol-1", deduped[1].ID, "order follows first occurrence")
+	assert.Equal(t, "kc-2", deduped[2].ID, "different action types are not merged")
+	assert.Empty(t, deduped[2].Sources)
+	assert.Equal(t, []string{"x-1", "x-2"}, []string{deduped[3].ID, deduped[4].ID},
+		"recommendations without a resource ID are kept")
+
+	assert.Equal(t, map[string]string{"region": "us-east-1"}, recs[0].Metadata, "input is not mutated")
+}
+
+func TestRecommendation_AllSources(t *testing.T) {
+	assert.Nil(t, Recommendation{}.AllSources())
+	assert.Equal(t, []string{"aws"}, Recommendation{Source: "aws"}.AllSources())
+	assert.Equal(t, []string{"aws", "kubecost"},
+		Recommendation{Source: "kubecost", Sources: []string{"aws", "kubecost"}}.AllSources())
+}
+
+func TestGetRecommendationsForResources_Dedupe(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	impact := func(savings float64) *proto.RecommendationImpact {
+		return &proto.RecommendationImpact{EstimatedSavings: savings, Currency: "USD"}
+	}
+	clients := []*pluginhost.Client{
+		{Name: "aws", API: &mockCostSourceClient{recommendations: []*proto.Recommendation{
+			{ID: "aws-1", ResourceID: "i-123", ActionType: "RIGHTSIZE", Impact: impact(40)},
+		}}},
+		{Name: "kubecost", API: &mockCostSourceClient{recommendations: []*proto.Recommendation{
+			{ID: "kc-1", ResourceID: "i-123", ActionType: "RIGHTSIZE", Impact: impact(55)},
+		}}},
+	}
+	resources := []ResourceDescriptor{{ID: "i-123", Type: "aws:ec2/instance:Instance", Provider: "aws"}}
+
+	store, err := config.NewDismissalStore("")
+	require.NoError(t, err)
+
+	result, err := New(clients, nil).WithDismissalStore(store).
+		GetRecommendationsForResources(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 1)
+	assert.Equal(t, []string{"aws", "kubecost"}, result.Recommendations[0].Sources)
+	assert.InDelta(t, 55.0, result.TotalSavings, 0.001)
+
+	result, err = New(clients, nil).WithDismissalStore(store).WithRecommendationDedupe(false).
+		GetRecommendationsForResources(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, result.Recommendations, 2)
+	assert.Equal(t, "aws", result.Recommendations[0].Source, "plugin name fills in a missing source")
+	assert.InDelta(t, 95.0, result.TotalSavings, 0.001)
+}
@@ -0,0 +1,225 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+)
+
+// ErrInvalidRecommendationGroupBy is returned for unknown --group-by values.
+var ErrInvalidRecommendationGroupBy = errors.New("invalid recommendation group-by")
+
+// RecommendationGroupKind identifies the dimension recommendations are grouped by.
+type RecommendationGroupKind string
+
+const (
+	// RecommendationGroupByResourceType groups by Pulumi resource type.
+	RecommendationGroupByResourceType RecommendationGroupKind = "resource-type"
+	// RecommendationGroupByAction groups by recommended action type.
+	RecommendationGroupByAction RecommendationGroupKind = "action"
+	// RecommendationGroupByProvider groups by cloud provider.
+	RecommendationGroupByProvider RecommendationGroupKind = "provider"
+	// RecommendationGroupByTag groups by the value of a resource tag.
+	RecommendationGroupByTag RecommendationGroupKind = "tag"
+)
+
+// Group keys used when a recommendation cannot be attributed.
+const (
+	// UnknownGroupKey is used when the resource type or provider is unknown.
+	UnknownGroupKey = "unknown"
+	// UntaggedGroupKey is used when the resource lacks the grouping tag.
+	UntaggedGroupKey = "(untagged)"
+)
+
+// RecommendationGroupSpec is a parsed --group-by value.
+type RecommendationGroupSpec struct {
+	Kind RecommendationGroupKind
+	// TagKey is the tag name when Kind is RecommendationGroupByTag.
+	TagKey string
+}
+
+// String returns the spec in its flag form (e.g., "tag:team").
+func (s RecommendationGroupSpec) String() string {
+	if s.Kind == RecommendationGroupByTag {
+		return string(s.Kind) + ":" + s.TagKey
+	}
+	return string(s.Kind)
+}
+
+// RecommendationGroup aggregates the recommendations sharing a group key.
+type RecommendationGroup struct {
+	Key             string           `json:"key"`
+	Count           int              `json:"count"`
+	TotalSavings    float64          `json:"totalSavings"`
+	Currency        string           `json:"currency"`
+	Recommendations []Recommendation `json:"recommendations"`
+}
+
+// ParseRecommendationGroupBy parses a group-by value: resource-type, action,
+// provider, or tag:KEY. Matching of the kind is case-insensitive; tag keys are
+// kept as given.
+func ParseRecommendationGroupBy(value string) (RecommendationGroupSpec, error) {
+	trimmed := strings.TrimSpace(value)
+	lower := strings.ToLower(trimmed)
+
+	switch RecommendationGroupKind(lower) {
+	case RecommendationGroupByResourceType, RecommendationGroupByAction, RecommendationGroupByProvider:
+		return RecommendationGroupSpec{Kind: RecommendationGroupKind(lower)}, nil
+	case RecommendationGroupByTag:
+		// bare "tag" falls through to the missing-key error below
+	}
+
+	if strings.HasPrefix(lower, "tag:") {
+		tagKey := strings.TrimSpace(trimmed[len("tag:"):])
+		if tagKey == "" {
+			return RecommendationGroupSpec{}, fmt.Errorf("%w: tag key is required (tag:KEY)",
+				ErrInvalidRecommendationGroupBy)
+		}
+		return RecommendationGroupSpec{Kind: RecommendationGroupByTag, TagKey: tagKey}, nil
+	}
+
+	return RecommendationGroupSpec{}, fmt.Errorf(
+		"%w: %q (valid: resource-type, action, provider, tag:KEY)", ErrInvalidRecommendationGroupBy, value)
+}
+
+// GroupRecommendations aggregates recommendations by the given spec. Resource
+// type, provider, and tags are looked up from resources by ResourceID.
+// Groups are ordered by total savings (highest first), then by key; within a
+// group, recommendations keep their input order.
+func GroupRecommendations(
+	recs []Recommendation,
+	resources []ResourceDescriptor,
+	spec RecommendationGroupSpec,
+) []RecommendationGroup {
+	byID := make(map[string]*ResourceDescriptor, len(resources))
+	for i := range resources {
+		byID[resources[i].ID] = &resources[i]
+	}
+
+	keyed := pipeline.GroupBy(recs, func(rec Recommendation) string {
+		return recommendationGroupKey(rec, byID[rec.ResourceID], spec)
+	})
+	var groups []RecommendationGroup
+	for _, g := range keyed {
+		group := RecommendationGroup{Key: g.Key, Count: len(g.Items), Recommendations: g.Items}
+		for _, rec := range g.Items {
+			group.TotalSavings += rec.EstimatedSavings
+			if group.Currency == "" && rec.Currency != "" {
+				group.Currency = rec.Currency
+			}
+		}
+		groups = append(groups, group)
+	}
+
+	for i := range groups {
+		if groups[i].Currency == "" {
+			groups[i].Currency = defaultCurrency
+		}
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		if groups[i].TotalSavings != groups[j].TotalSavings {
+			return groups[i].TotalSavings > groups[j].TotalSavings
+		}
+		return groups[i].Key < groups[j].Key
+	})
+	return groups
+}
+
+// recommendationGroupKey returns the group key for a recommendation.
+func recommendationGroupKey(rec Recommendation, resource *ResourceDescriptor, spec RecommendationGroupSpec) string {
+	switch spec.Kind {
+	case RecommendationGroupByAction:
+		if rec.Type == "" {
+			return UnknownGroupKey
+		}
+		return NormalizeRemediationActionType(rec.Type)
+	case RecommendationGroupByProvider:
+		if resource == nil || ExtractProvider(resource.Type) == "" {
+			return UnknownGroupKey
+		}
+		return ExtractProvider(resource.Type)
+	case RecommendationGroupByTag:
+		if resource == nil {
+			return UntaggedGroupKey
+		}
+		if value, ok := lookupResourceTag(resource.Properties, spec.TagKey); ok {
+			return value
+		}
+		return UntaggedGroupKey
+	case RecommendationGroupByResourceType:
+		fallthrough
+	default:
+		if resource == nil || resource.Type == "" {
+			return UnknownGroupKey
+		}
+		return resource.Type
+	}
+}
+
+// ResourceTags returns the resource's tags as a flat string map, merged from
+// the "tags" and "labels" property maps. Returns nil when there are none.
+func ResourceTags(properties map[string]interface{}) map[string]string {
+	var tags map[string]string
+	add := func(k string, v interface{}) {
+		if tags == nil {
+			tags = make(map[string]string)
+		}
+		tags[k] = fmt.Sprintf("%v", v)
+	}
+	for k, v := range properties {
+		kl := strings.ToLower(k)
+		if kl != "tags" && kl != "labels" {
+			continue
+		}
+		switch m := v.(type) {
+		case map[string]interface{}:
+			for mk, mv := range m {
+				add(mk, mv)
+			}
+		case map[string]string:
+			for mk, mv := range m {
+				add(mk, mv)
+			}
+		}
+	}
+	return tags
+}
+
+// lookupResourceTag finds a tag value in the "tags" or "labels" property maps.
+// Keys are compared case-insensitively. Empty values are treated as missing.
+func lookupResourceTag(properties map[string]interface{}, tagKey string) (string, bool) {
+	for k, v := range properties {
+		kl := strings.ToLower(k)
+		if kl != "tags" && kl != "labels" {
+			continue
+		}
+		var value interface{}
+		found := false
+		switch m := v.(type) {
+		case map[string]interface{}:
+			for mk, mv := range m {
+				if strings.EqualFold(mk, tagKey) {
+					value, found = mv, true
+					break
+				}
+			}
+		case map[string]string:
+			for mk, mv := range m {
+				if strings.EqualFold(mk, tagKey) {
+					value, found = mv, true
+					break
+				}
+			}
+		}
+		if found {
+			if s := fmt.Sprintf("%v", value); s != "" {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRecommendationGroupBy(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    RecommendationGroupSpec
+		wantErr bool
+	}{
+		{input: "resource-type", want: RecommendationGroupSpec{Kind: RecommendationGroupByResourceType}},
+		{input: "ACTION", want: RecommendationGroupSpec{Kind: RecommendationGroupByAction}},
+		{input: " provider ", want: RecommendationGroupSpec{Kind: RecommendationGroupByProvider}},
+		{input: "tag:CostCenter", want: RecommendationGroupSpec{Kind: RecommendationGroupByTag, TagKey: "CostCenter"}},
+		{input: "tag", wantErr: true},
+		{input: "tag:", wantErr: true},
+		{input: "region", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseRecommendationGroupBy(tt.input)
+			if tt.wantErr {
+				require.ErrorIs(t, err, ErrInvalidRecommendationGroupBy)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGroupRecommendations(t *testing.T) {
+	resources := []ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"team": "platform"},
+		}},
+		{ID: "db", Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+			"tags": map[string]interface{}{"Team": "data"},
+		}},
+		{ID: "vm", Type: "gcp:compute/instance:Instance", Properties: map[string]interface{}{
+			"labels": map[string]string{"team": "platform"},
+		}},
+	}
+	recs := []Recommendation{
+		{ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 50, Currency: "USD"},
+		{ResourceID: "db", Type: "RECOMMENDATION_ACTION_TYPE_RIGHTSIZE", EstimatedSavings: 120, Currency: "USD"},
+		{ResourceID: "vm", Type: "TERMINATE", EstimatedSavings: 30, Currency: "USD"},
+		{ResourceID: "orphan", Type: "DELETE_UNUSED", EstimatedSavings: 5},
+	}
+
+	keys := func(groups []RecommendationGroup) []string {
+		out := make([]string, len(groups))
+		for i, g := range groups {
+			out[i] = g.Key
+		}
+		return out
+	}
+
+	t.Run("resource type", func(t *testing.T) {
+		groups := GroupRecommendations(recs, resources, RecommendationGroupSpec{Kind: RecommendationGroupByResourceType})
+		assert.Equal(t, []string{
+			"aws:rds/instance:Instance", "aws:ec2/instance:Instance", "gcp:compute/instance:Instance", UnknownGroupKey,
+		}, keys(groups))
+	})
+
+	t.Run("action normalizes enum names", func(t *testing.T) {
+		groups := GroupRecommendations(recs, resources, RecommendationGroupSpec{Kind: RecommendationGroupByAction})
+		require.Len(t, groups, 3)
+		assert.Equal(t, "RIGHTSIZE", groups[0].Key)
+		assert.Equal(t, 2, groups[0].Count)
+		assert.InDelta(t, 170.0, groups[0].TotalSavings, 0.001)
+	})
+
+	t.Run("provider", func(t *testing.T) {
+		groups := GroupRecommendations(recs, resources, RecommendationGroupSpec{Kind: RecommendationGroupByProvider})
+		assert.Equal(t, []string{"aws", "gcp", UnknownGroupKey}, keys(groups))
+		assert.Equal(t, "USD", groups[2].Currency, "groups without currency use the default")
+	})
+
+	t.Run("tag matches tags and labels case-insensitively", func(t *testing.T) {
+		groups := GroupRecommendations(recs, resources,
+			RecommendationGroupSpec{Kind: RecommendationGroupByTag, TagKey: "team"})
+		assert.Equal(t, []string{"data", "platform", UntaggedGroupKey}, keys(groups))
+		assert.Equal(t, 2, groups[1].Count)
+	})
+
+	t.Run("empty input", func(t *testing.T) {
+		assert.Empty(t, GroupRecommendations(nil, resources, RecommendationGroupSpec{Kind: RecommendationGroupByAction}))
+	})
+}
@@ -0,0 +1,48 @@
+package engine
+
+// RecommendationProgress reports one completed GetRecommendations request
+// of a plugin, for progress displays.
+type RecommendationProgress struct {
+	// Plugin is the name of the plugin the request was sent to.
+	Plugin string
+	// Batch is the 0-based index of the request among the plugin's requests.
+	Batch int
+	// Batches is the number of requests the plugin is sent.
+	Batches int
+	// Resources is the number of resources in the request.
+	Resources int
+	// Recommendations is the number of recommendations the plugin returned.
+	Recommendations int
+	// Err is the error of a failed request, after which the plugin is sent
+	// no more requests.
+	Err error
+}
+
+// RecommendationProgressFunc receives the progress of
+// GetRecommendationsForResources. It is called from the goroutine fetching
+// the recommendations, so it must return quickly.
+type RecommendationProgressFunc func(RecommendationProgress)
+
+// WithRecommendationProgress sets a function that receives the progress of
+// GetRecommendationsForResources as each plugin request completes.
+func (e *Engine) WithRecommendationProgress(fn RecommendationProgressFunc) *Engine {
+	e.recommendationProgress = fn
+	return e
+}
+
+// RecommendationBatches returns the number of GetRecommendations requests
+// GetRecommendationsForResources sends each plugin for n resources.
+func RecommendationBatches(n int) int {
+	if n <= 0 {
+		return 0
+	}
+	return (n + batchProcessingThreshold - 1) / batchProcessingThreshold
+}
+
+// reportRecommendationProgress passes progress to the progress function, if
+// one is set.
+func (e *Engine) reportRecommendationProgress(progress RecommendationProgress) {
+	if e.recommendationProgress != nil {
+		e.recommendationProgress(progress)
+	}
+}
@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+func TestRecommendationBatches(t *testing.T) {
+	assert.Equal(t, 0, RecommendationBatches(0))
+	assert.Equal(t, 1, RecommendationBatches(1))
+	assert.Equal(t, 1, RecommendationBatches(batchProcessingThreshold))
+	assert.Equal(t, 2, RecommendationBatches(batchProcessingThreshold+1))
+}
+
+func TestGetRecommendationsForResources_ReportsProgress(t *testing.T) {
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+	resources := make([]ResourceDescriptor, 2*batchProcessingThreshold+50)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: "r" + strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+
+	var progress []RecommendationProgress
+	eng := New([]*pluginhost.Client{{Name: "recs", API: pagedRecommendationsClient{}}}, nil).
+		WithRecommendationProgress(func(p RecommendationProgress) { progress = append(progress, p) })
+
+	_, err := eng.GetRecommendationsForResources(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Equal(t, []RecommendationProgress{
+		{Plugin: "recs", Batch: 0, Batches: 3, Resources: batchProcessingThreshold, Recommendations: 2},
+		{Plugin: "recs", Batch: 1, Batches: 3, Resources: batchProcessingThreshold, Recommendations: 2},
+		{Plugin: "recs", Batch: 2, Batches: 3, Resources: 50, Recommendations: 2},
+	}, progress)
+
+	progress = nil
+	_, err = eng.GetRecommendationsForResources(context.Background(), resources[:2])
+	require.NoError(t, err)
+	assert.Equal(t, []RecommendationProgress{
+		{Plugin: "recs", Batches: 1, Resources: 2, Recommendations: 2},
+	}, progress, "small requests are one batch")
+}
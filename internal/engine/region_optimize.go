@@ -0,0 +1,270 @@
+package engine
+
+import (
+	"maps"
+	"sort"
+	"strings"
+)
+
+// regionZoneProperties are the properties that pin a resource to a zone of
+// its region, which relocating the resource clears.
+func regionZoneProperties() []string {
+	return []string{"availabilityZone", "availability_zone", "zone"}
+}
+
+// RelocateResource returns a copy of resource placed in region, so that
+// plugins price it there: the region property, and the location property
+// that Azure resources use, are set to region and zone properties are
+// removed. The properties of resource are not modified.
+func RelocateResource(resource ResourceDescriptor, region string) ResourceDescriptor {
+	props := make(map[string]interface{}, len(resource.Properties)+1)
+	maps.Copy(props, resource.Properties)
+	for _, key := range regionZoneProperties() {
+		delete(props, key)
+	}
+	props["region"] = region
+	if _, ok := props["location"]; ok {
+		props["location"] = region
+	}
+	resource.Properties = props
+	return resource
+}
+
+// MatchesResourceTypes reports whether a resource type starts with one of
+// prefixes, ignoring case, e.g. "aws:ec2" matches "aws:ec2/instance:Instance".
+// Every type matches when prefixes is empty.
+func MatchesResourceTypes(resourceType string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	lower := strings.ToLower(resourceType)
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(lower, strings.ToLower(prefix)) {
+			return true
+		}
+	}
+	return false
+}
+
+// RegionOption is the projected cost and carbon footprint of one resource,
+// or of all of them, relocated to a candidate region.
+type RegionOption struct {
+	Region  string  `json:"region"`
+	Monthly float64 `json:"monthly"`
+	// CostDelta is Monthly minus the current monthly cost; negative saves.
+	CostDelta float64 `json:"costDelta"`
+	// CarbonKg is the monthly carbon footprint in kgCO2e, and CarbonDeltaKg
+	// the change from the current footprint. They are only set when
+	// HasCarbon is true, i.e. plugins reported footprints both in the
+	// current and the candidate region.
+	CarbonKg      float64 `json:"carbonKg,omitempty"`
+	CarbonDeltaKg float64 `json:"carbonDeltaKg,omitempty"`
+	HasCarbon     bool    `json:"hasCarbon"`
+	// Latency is the configured latency constraint annotation of the region.
+	Latency string `json:"latency,omitempty"`
+	// Unpriced counts the resources that no plugin priced in the region; they
+	// are left out of the totals of a region option.
+	Unpriced int `json:"unpriced,omitempty"`
+	// Error is why no plugin priced the resource in the region.
+	Error string `json:"error,omitempty"`
+}
+
+// RegionResource compares the current cost of one resource with its cost in
+// each candidate region.
+type RegionResource struct {
+	ResourceID   string  `json:"resourceId"`
+	ResourceType string  `json:"resourceType"`
+	Region       string  `json:"region,omitempty"`
+	Currency     string  `json:"currency"`
+	Monthly      float64 `json:"monthly"`
+	CarbonKg     float64 `json:"carbonKg,omitempty"`
+	HasCarbon    bool    `json:"hasCarbon"`
+	// Options holds one option per candidate region, cheapest first.
+	Options []RegionOption `json:"options"`
+	// Cheapest and Greenest are the candidate regions with the lowest cost
+	// and carbon footprint, or "" when no candidate lowers them.
+	Cheapest string `json:"cheapest,omitempty"`
+	Greenest string `json:"greenest,omitempty"`
+}
+
+// RegionOptimizationReport compares the current cost and carbon footprint of
+// resources with relocating them to candidate regions.
+type RegionOptimizationReport struct {
+	Currency  string  `json:"currency"`
+	Monthly   float64 `json:"monthly"`
+	CarbonKg  float64 `json:"carbonKg,omitempty"`
+	HasCarbon bool    `json:"hasCarbon"`
+	// Regions holds the totals of relocating every resource to each
+	// candidate region, cheapest first.
+	Regions   []RegionOption   `json:"regions"`
+	Resources []RegionResource `json:"resources"`
+	// Savings and CarbonSavingsKg are the monthly cost and carbon saved by
+	// moving each resource to its cheapest or greenest region respectively.
+	Savings         float64 `json:"savings"`
+	CarbonSavingsKg float64 `json:"carbonSavingsKg,omitempty"`
+}
+
+// RegionOptimizationInput carries the data needed to build a
+// RegionOptimizationReport.
+type RegionOptimizationInput struct {
+	// Resources are the compared resources, and Regions their current region
+	// keyed by resource ID.
+	Resources []ResourceDescriptor
+	Regions   map[string]string
+	// Current holds the projected costs of Resources where they are, and
+	// Candidates those of Resources relocated to each candidate region.
+	Current    []CostResult
+	Candidates map[string][]CostResult
+	// Latency holds the latency constraint annotations keyed by region.
+	Latency map[string]string
+}
+
+// BuildRegionOptimization compares the cost and carbon footprint of each
+// resource in its current region with its cost and footprint in every
+// candidate region. Resources that no plugin priced where they are cannot
+// be compared and are left out.
+func BuildRegionOptimization(input RegionOptimizationInput) *RegionOptimizationReport {
+	report := &RegionOptimizationReport{Currency: defaultCurrency}
+	current := resultsByResource(input.Current)
+	candidates := make(map[string]map[string]CostResult, len(input.Candidates))
+	for region, results := range input.Candidates {
+		candidates[region] = resultsByResource(results)
+	}
+	regions := make([]string, 0, len(candidates))
+	for region := range candidates {
+		regions = append(regions, region)
+	}
+	sort.Strings(regions)
+
+	totals := make(map[string]*RegionOption, len(regions))
+	for _, region := range regions {
+		totals[region] = &RegionOption{Region: region, Latency: input.Latency[region], HasCarbon: true}
+	}
+
+	currencySet := false
+	for _, resource := range input.Resources {
+		now, ok := current[resource.ID]
+		if !ok || now.Error != nil {
+			continue
+		}
+		if !currencySet && now.Currency != "" {
+			report.Currency = now.Currency
+			currencySet = true
+		}
+		item := RegionResource{
+			ResourceID:   resource.ID,
+			ResourceType: resource.Type,
+			Region:       input.Regions[resource.ID],
+			Currency:     now.Currency,
+			Monthly:      now.Monthly,
+		}
+		item.CarbonKg, item.HasCarbon = CarbonFootprintKg(now)
+		report.Monthly += item.Monthly
+		report.CarbonKg += item.CarbonKg
+		report.HasCarbon = report.HasCarbon || item.HasCarbon
+
+		for _, region := range regions {
+			option := relocationOption(item, region, candidates[region], input.Latency[region])
+			item.Options = append(item.Options, option)
+			addRegionTotal(totals[region], option)
+		}
+		sortRegionOptions(item.Options)
+		savings, carbonSavings := pickRegions(&item)
+		report.Savings += savings
+		report.CarbonSavingsKg += carbonSavings
+		report.Resources = append(report.Resources, item)
+	}
+
+	for _, region := range regions {
+		total := totals[region]
+		total.HasCarbon = total.HasCarbon && report.HasCarbon
+		if !total.HasCarbon {
+			total.CarbonKg, total.CarbonDeltaKg = 0, 0
+		}
+		report.Regions = append(report.Regions, *total)
+	}
+	sortRegionOptions(report.Regions)
+	return report
+}
+
+// resultsByResource indexes results by resource ID.
+func resultsByResource(results []CostResult) map[string]CostResult {
+	byID := make(map[string]CostResult, len(results))
+	for _, result := range results {
+		byID[result.ResourceID] = result
+	}
+	return byID
+}
+
+// relocationOption compares item with its cost in region.
+func relocationOption(item RegionResource, region string, priced map[string]CostResult, latency string) RegionOption {
+	option := RegionOption{Region: region, Latency: latency}
+	result, ok := priced[item.ResourceID]
+	switch {
+	case !ok:
+		option.Error = "no plugin priced the resource in this region"
+		option.Unpriced = 1
+		return option
+	case result.Error != nil:
+		option.Error = result.Error.Message
+		option.Unpriced = 1
+		return option
+	}
+	option.Monthly = result.Monthly
+	option.CostDelta = result.Monthly - item.Monthly
+	if kg, hasCarbon := CarbonFootprintKg(result); hasCarbon && item.HasCarbon {
+		option.CarbonKg, option.CarbonDeltaKg, option.HasCarbon = kg, kg-item.CarbonKg, true
+	}
+	return option
+}
+
+// addRegionTotal adds the option of one resource to the totals of its region.
+// Unpriced resources are counted instead, and a resource without a carbon
+// footprint makes the carbon total of the region unknown.
+func addRegionTotal(total *RegionOption, option RegionOption) {
+	if option.Unpriced > 0 {
+		total.Unpriced++
+		return
+	}
+	total.Monthly += option.Monthly
+	total.CostDelta += option.CostDelta
+	if !option.HasCarbon {
+		total.HasCarbon = false
+		return
+	}
+	total.CarbonKg += option.CarbonKg
+	total.CarbonDeltaKg += option.CarbonDeltaKg
+}
+
+// pickRegions sets the cheapest and greenest regions of item and returns the
+// monthly cost and carbon saved by moving it to them.
+func pickRegions(item *RegionResource) (float64, float64) {
+	savings, carbonSavings := 0.0, 0.0
+	for _, option := range item.Options {
+		if option.Error != "" {
+			continue
+		}
+		if -option.CostDelta > savings {
+			savings, item.Cheapest = -option.CostDelta, option.Region
+		}
+		if option.HasCarbon && -option.CarbonDeltaKg > carbonSavings {
+			carbonSavings, item.Greenest = -option.CarbonDeltaKg, option.Region
+		}
+	}
+	return savings, carbonSavings
+}
+
+// sortRegionOptions orders options cheapest first, with options that leave
+// resources unpriced last, as their costs do not compare, and ties by region.
+func sortRegionOptions(options []RegionOption) {
+	sort.SliceStable(options, func(i, j int) bool {
+		a, b := options[i], options[j]
+		if (a.Unpriced == 0) != (b.Unpriced == 0) {
+			return a.Unpriced == 0
+		}
+		if a.Monthly != b.Monthly {
+			return a.Monthly < b.Monthly
+		}
+		return a.Region < b.Region
+	})
+}
@@ -0,0 +1,117 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelocateResource(t *testing.T) {
+	resource := ResourceDescriptor{
+		ID:   "web",
+		Type: "aws:ec2/instance:Instance",
+		Properties: map[string]interface{}{
+			"instanceType":     "t3.large",
+			"availabilityZone": "us-east-1a",
+		},
+	}
+
+	relocated := RelocateResource(resource, "eu-north-1")
+	assert.Equal(t, "eu-north-1", relocated.Properties["region"])
+	assert.NotContains(t, relocated.Properties, "availabilityZone")
+	assert.Equal(t, "t3.large", relocated.Properties["instanceType"])
+	assert.Equal(t, "us-east-1a", resource.Properties["availabilityZone"], "original is not modified")
+
+	azure := RelocateResource(ResourceDescriptor{
+		Properties: map[string]interface{}{"location": "eastus"},
+	}, "swedencentral")
+	assert.Equal(t, "swedencentral", azure.Properties["location"])
+}
+
+func TestMatchesResourceTypes(t *testing.T) {
+	assert.True(t, MatchesResourceTypes("aws:ec2/instance:Instance", nil))
+	assert.True(t, MatchesResourceTypes("aws:ec2/instance:Instance", []string{"aws:rds", "AWS:EC2"}))
+	assert.False(t, MatchesResourceTypes("aws:s3/bucket:Bucket", []string{"aws:ec2"}))
+}
+
+func carbonResult(id string, monthly, kg float64) CostResult {
+	return CostResult{
+		ResourceID: id, Currency: "USD", Monthly: monthly,
+		Sustainability: map[string]SustainabilityMetric{"carbon_footprint": {Value: kg, Unit: "kgCO2e"}},
+	}
+}
+
+func TestBuildRegionOptimization(t *testing.T) {
+	resources := []ResourceDescriptor{
+		{ID: "web", Type: "aws:ec2/instance:Instance"},
+		{ID: "db", Type: "aws:rds/instance:Instance"},
+		{ID: "broken", Type: "aws:ec2/instance:Instance"},
+	}
+	report := BuildRegionOptimization(RegionOptimizationInput{
+		Resources: resources,
+		Regions:   map[string]string{"web": "us-east-1", "db": "us-east-1"},
+		Current: []CostResult{
+			carbonResult("web", 100, 50),
+			carbonResult("db", 200, 80),
+			{ResourceID: "broken", Error: &StructuredError{Message: "no pricing"}},
+		},
+		Candidates: map[string][]CostResult{
+			"us-west-2":  {carbonResult("web", 90, 40), carbonResult("db", 210, 70)},
+			"eu-north-1": {carbonResult("web", 110, 5)},
+		},
+		Latency: map[string]string{"eu-north-1": "+90 ms to US users"},
+	})
+
+	assert.Equal(t, "USD", report.Currency)
+	assert.InDelta(t, 300.0, report.Monthly, 0.001)
+	assert.InDelta(t, 130.0, report.CarbonKg, 0.001)
+	require.Len(t, report.Resources, 2, "resources not priced where they are are left out")
+
+	web := report.Resources[0]
+	assert.Equal(t, "us-east-1", web.Region)
+	assert.Equal(t, "us-west-2", web.Cheapest)
+	assert.Equal(t, "eu-north-1", web.Greenest)
+	require.Len(t, web.Options, 2)
+	assert.Equal(t, "us-west-2", web.Options[0].Region, "cheapest first")
+	assert.InDelta(t, -45.0, web.Options[1].CarbonDeltaKg, 0.001)
+
+	db := report.Resources[1]
+	assert.Empty(t, db.Cheapest, "no region is cheaper")
+	assert.Equal(t, "us-west-2", db.Greenest)
+	assert.Equal(t, "eu-north-1", db.Options[1].Region, "unpriced options last")
+	assert.NotEmpty(t, db.Options[1].Error)
+
+	assert.InDelta(t, 10.0, report.Savings, 0.001)
+	assert.InDelta(t, 55.0, report.CarbonSavingsKg, 0.001)
+
+	require.Len(t, report.Regions, 2)
+	west := report.Regions[0]
+	assert.Equal(t, "us-west-2", west.Region)
+	assert.InDelta(t, 300.0, west.Monthly, 0.001)
+	assert.InDelta(t, 0.0, west.CostDelta, 0.001)
+	assert.True(t, west.HasCarbon)
+	assert.InDelta(t, -20.0, west.CarbonDeltaKg, 0.001)
+
+	north := report.Regions[1]
+	assert.Equal(t, "eu-north-1", north.Region)
+	assert.Equal(t, 1, north.Unpriced)
+	assert.Equal(t, "+90 ms to US users", north.Latency)
+}
+
+func TestBuildRegionOptimization_WithoutCarbon(t *testing.T) {
+	report := BuildRegionOptimization(RegionOptimizationInput{
+		Resources: []ResourceDescriptor{{ID: "web"}},
+		Current:   []CostResult{{ResourceID: "web", Currency: "EUR", Monthly: 100}},
+		Candidates: map[string][]CostResult{
+			"eu-west-1": {{ResourceID: "web", Currency: "EUR", Monthly: 80}},
+		},
+	})
+
+	assert.Equal(t, "EUR", report.Currency)
+	assert.False(t, report.HasCarbon)
+	assert.False(t, report.Regions[0].HasCarbon)
+	assert.Equal(t, "eu-west-1", report.Resources[0].Cheapest)
+	assert.Empty(t, report.Resources[0].Greenest)
+	assert.InDelta(t, 20.0, report.Savings, 0.001)
+}
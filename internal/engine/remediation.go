@@ -0,0 +1,331 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Remediation errors.
+var (
+	// ErrRemediationUnsupportedAction is returned for action types that have no
+	// generated remediation (only RIGHTSIZE, TERMINATE, and DELETE_UNUSED do).
+	ErrRemediationUnsupportedAction = errors.New("remediation not supported for action type")
+
+	// ErrRemediationIncomplete is returned when a recommendation lacks the details
+	// needed to generate a plan (e.g., a rightsize without a target SKU).
+	ErrRemediationIncomplete = errors.New("recommendation lacks details required for remediation")
+
+	// ErrInvalidRemediationFormat is returned for unknown plan formats.
+	ErrInvalidRemediationFormat = errors.New("invalid remediation format")
+)
+
+// Recommendation metadata keys that plugins may set to supply their own
+// remediation instead of the generated defaults.
+const (
+	// RemediationMetadataScript holds shell commands used verbatim in script plans.
+	RemediationMetadataScript = "remediation.script"
+	// RemediationMetadataProperty names the Pulumi resource property to change.
+	RemediationMetadataProperty = "remediation.property"
+	// RemediationMetadataValue holds the new value for the Pulumi resource property.
+	RemediationMetadataValue = "remediation.value"
+)
+
+// RemediationFormat selects the kind of remediation plan to generate.
+type RemediationFormat string
+
+const (
+	// RemediationFormatScript generates a shell script of provider CLI commands.
+	RemediationFormatScript RemediationFormat = "script"
+	// RemediationFormatPulumi generates a YAML patch describing Pulumi resource changes.
+	RemediationFormatPulumi RemediationFormat = "pulumi"
+)
+
+// Remediation action types, normalized from plugin action type strings.
+const (
+	remediationActionRightsize    = "RIGHTSIZE"
+	remediationActionTerminate    = "TERMINATE"
+	remediationActionDeleteUnused = "DELETE_UNUSED"
+	actionTypeEnumPrefix          = "RECOMMENDATION_ACTION_TYPE_"
+)
+
+// RemediationPlan is a reviewable plan for implementing a single recommendation.
+// Plans are written to disk for review; finfocus never executes them.
+type RemediationPlan struct {
+	RecommendationID string            `json:"recommendationId"`
+	ResourceID       string            `json:"resourceId"`
+	ActionType       string            `json:"actionType"`
+	Format           RemediationFormat `json:"format"`
+	// Content is the generated script or patch document.
+	Content string `json:"content"`
+	// FromPlugin is true when the plan uses plugin-supplied remediation metadata.
+	FromPlugin bool `json:"fromPlugin"`
+}
+
+// remediationTarget describes how a resource type is remediated: the Pulumi
+// property holding its size and the provider CLI templates. Templates use
+// $RESOURCE_ID and $TARGET shell variables defined at the top of the script.
+type remediationTarget struct {
+	sizeProperty string
+	rightsize    []string
+	terminate    []string
+}
+
+// remediationTargets maps Pulumi resource types to their remediation templates.
+//
+//nolint:gochecknoglobals // Lookup table for provider CLI templates.
+var remediationTargets = map[string]remediationTarget{
+	"aws:ec2/instance:Instance": {
+		sizeProperty: "instanceType",
+		rightsize: []string{
+			`aws ec2 stop-instances --instance-ids "$RESOURCE_ID"`,
+			`aws ec2 wait instance-stopped --instance-ids "$RESOURCE_ID"`,
+			`aws ec2 modify-instance-attribute --instance-id "$RESOURCE_ID" --instance-type "Value=$TARGET"`,
+			`aws ec2 start-instances --instance-ids "$RESOURCE_ID"`,
+		},
+		terminate: []string{`aws ec2 terminate-instances --instance-ids "$RESOURCE_ID"`},
+	},
+	"aws:ebs/volume:Volume": {
+		sizeProperty: "type",
+		rightsize:    []string{`aws ec2 modify-volume --volume-id "$RESOURCE_ID" --volume-type "$TARGET"`},
+		terminate:    []string{`aws ec2 delete-volume --volume-id "$RESOURCE_ID"`},
+	},
+	"aws:rds/instance:Instance": {
+		sizeProperty: "instanceClass",
+		rightsize: []string{
+			`aws rds modify-db-instance --db-instance-identifier "$RESOURCE_ID" ` +
+				`--db-instance-class "$TARGET" --apply-immediately`,
+		},
+		terminate: []string{
+			`aws rds delete-db-instance --db-instance-identifier "$RESOURCE_ID" --final-db-snapshot-identifier "$RESOURCE_ID-final"`,
+		},
+	},
+	"gcp:compute/instance:Instance": {
+		sizeProperty: "machineType",
+		rightsize: []string{
+			`gcloud compute instances stop "$RESOURCE_ID"`,
+			`gcloud compute instances set-machine-type "$RESOURCE_ID" --machine-type "$TARGET"`,
+			`gcloud compute instances start "$RESOURCE_ID"`,
+		},
+		terminate: []string{`gcloud compute instances delete "$RESOURCE_ID"`},
+	},
+	"azure-native:compute:VirtualMachine": {
+		sizeProperty: "hardwareProfile.vmSize",
+		rightsize:    []string{`az vm resize --ids "$RESOURCE_ID" --size "$TARGET"`},
+		terminate:    []string{`az vm delete --ids "$RESOURCE_ID" --yes`},
+	},
+}
+
+// ValidRemediationFormats returns the supported remediation plan formats.
+func ValidRemediationFormats() []string {
+	return []string{string(RemediationFormatScript), string(RemediationFormatPulumi)}
+}
+
+// NormalizeRemediationActionType returns the short action type name
+// (e.g., "RIGHTSIZE") for both short and proto enum forms.
+func NormalizeRemediationActionType(actionType string) string {
+	upper := strings.ToUpper(strings.TrimSpace(actionType))
+	return strings.TrimPrefix(upper, actionTypeEnumPrefix)
+}
+
+// BuildRemediationPlan translates a RIGHTSIZE, TERMINATE, or DELETE_UNUSED
+// recommendation into a remediation plan in the requested format.
+//
+// resource is the descriptor of the affected resource and may be nil when the
+// resource is not in the plan; it supplies the resource type used to pick
+// provider CLI commands and Pulumi property names. Plugin-supplied metadata
+// (RemediationMetadataScript, RemediationMetadataProperty, RemediationMetadataValue)
+// takes precedence over the generated defaults.
+func BuildRemediationPlan(
+	rec Recommendation,
+	resource *ResourceDescriptor,
+	format RemediationFormat,
+) (*RemediationPlan, error) {
+	action := NormalizeRemediationActionType(rec.Type)
+	switch action {
+	case remediationActionRightsize, remediationActionTerminate, remediationActionDeleteUnused:
+	default:
+		return nil, fmt.Errorf("%w: %q (supported: RIGHTSIZE, TERMINATE, DELETE_UNUSED)",
+			ErrRemediationUnsupportedAction, rec.Type)
+	}
+
+	resourceType := ""
+	if resource != nil {
+		resourceType = resource.Type
+	}
+
+	plan := &RemediationPlan{
+		RecommendationID: rec.ID,
+		ResourceID:       rec.ResourceID,
+		ActionType:       action,
+		Format:           format,
+	}
+
+	var err error
+	switch format {
+	case RemediationFormatScript:
+		plan.Content, plan.FromPlugin, err = buildRemediationScript(rec, action, resourceType)
+	case RemediationFormatPulumi:
+		plan.Content, plan.FromPlugin, err = buildRemediationPatch(rec, action, resourceType)
+	default:
+		return nil, fmt.Errorf("%w: %q (valid: %s)",
+			ErrInvalidRemediationFormat, format, strings.Join(ValidRemediationFormats(), ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return plan, nil
+}
+
+// buildRemediationScript renders a shell script of provider CLI commands.
+// Plugin-supplied commands are used verbatim; otherwise commands come from the
+// resource type's templates, falling back to a targeted pulumi destroy for
+// removals of resources identified by URN.
+func buildRemediationScript(rec Recommendation, action, resourceType string) (string, bool, error) {
+	var b strings.Builder
+	b.WriteString("#!/usr/bin/env bash\n")
+	writeRemediationHeader(&b, rec, action, resourceType)
+	b.WriteString("set -euo pipefail\n\n")
+
+	if script := strings.TrimSpace(rec.Metadata[RemediationMetadataScript]); script != "" {
+		b.WriteString("# Commands supplied by the recommendation source.\n")
+		b.WriteString(script)
+		b.WriteString("\n")
+		return b.String(), true, nil
+	}
+
+	target, known := remediationTargets[resourceType]
+	isURN := strings.HasPrefix(rec.ResourceID, pulumiURNPrefix)
+
+	var commands []string
+	switch action {
+	case remediationActionRightsize:
+		if rec.RecommendedSKU == "" {
+			return "", false, fmt.Errorf("%w: rightsize recommendation has no target SKU", ErrRemediationIncomplete)
+		}
+		if !known {
+			return "", false, fmt.Errorf("%w: no provider CLI commands for resource type %q",
+				ErrRemediationIncomplete, resourceType)
+		}
+		commands = target.rightsize
+	default:
+		switch {
+		case known:
+			commands = target.terminate
+		case isURN:
+			commands = []string{`pulumi destroy --target "$RESOURCE_ID"`}
+		default:
+			return "", false, fmt.Errorf("%w: no provider CLI commands for resource type %q",
+				ErrRemediationIncomplete, resourceType)
+		}
+	}
+
+	if isURN && known {
+		b.WriteString("# RESOURCE_ID is a Pulumi URN; replace it with the cloud resource ID\n")
+		b.WriteString("# (see `pulumi stack export`) before running these commands.\n")
+	}
+	fmt.Fprintf(&b, "RESOURCE_ID=%s\n", shellQuote(rec.ResourceID))
+	if action == remediationActionRightsize {
+		fmt.Fprintf(&b, "TARGET=%s\n", shellQuote(rec.RecommendedSKU))
+	}
+	b.WriteString("\n")
+	for _, command := range commands {
+		b.WriteString(command)
+		b.WriteString("\n")
+	}
+
+	return b.String(), false, nil
+}
+
+// pulumiPatch is the YAML document produced for the pulumi format.
+type pulumiPatch struct {
+	Recommendation string              `yaml:"recommendation,omitempty"`
+	Action         string              `yaml:"action"`
+	Resource       string              `yaml:"resource"`
+	Type           string              `yaml:"type,omitempty"`
+	Changes        []pulumiPatchChange `yaml:"changes"`
+}
+
+// pulumiPatchChange is a single property change or resource removal.
+type pulumiPatchChange struct {
+	Op       string `yaml:"op"`
+	Property string `yaml:"property,omitempty"`
+	From     string `yaml:"from,omitempty"`
+	To       string `yaml:"to,omitempty"`
+}
+
+// buildRemediationPatch renders a YAML patch describing the change to make in
+// the Pulumi program: a property replacement for rightsizing, or a resource
+// removal for terminations.
+func buildRemediationPatch(rec Recommendation, action, resourceType string) (string, bool, error) {
+	patch := pulumiPatch{
+		Recommendation: rec.ID,
+		Action:         action,
+		Resource:       rec.ResourceID,
+		Type:           resourceType,
+	}
+
+	fromPlugin := false
+	if action == remediationActionRightsize {
+		property := rec.Metadata[RemediationMetadataProperty]
+		value := rec.Metadata[RemediationMetadataValue]
+		fromPlugin = property != "" || value != ""
+		if property == "" {
+			property = remediationTargets[resourceType].sizeProperty
+		}
+		if value == "" {
+			value = rec.RecommendedSKU
+		}
+		if property == "" || value == "" {
+			return "", false, fmt.Errorf("%w: cannot determine property change for resource type %q",
+				ErrRemediationIncomplete, resourceType)
+		}
+		patch.Changes = []pulumiPatchChange{{Op: "replace", Property: property, From: rec.CurrentSKU, To: value}}
+	} else {
+		patch.Changes = []pulumiPatchChange{{Op: "remove"}}
+	}
+
+	data, err := yaml.Marshal(patch)
+	if err != nil {
+		return "", false, fmt.Errorf("marshaling remediation patch: %w", err)
+	}
+
+	var b strings.Builder
+	writeRemediationHeader(&b, rec, action, resourceType)
+	if action != remediationActionRightsize {
+		b.WriteString("# Remove the resource from your Pulumi program, or run:\n")
+		fmt.Fprintf(&b, "#   pulumi destroy --target %s\n", shellQuote(rec.ResourceID))
+	}
+	b.Write(data)
+	return b.String(), fromPlugin, nil
+}
+
+// writeRemediationHeader writes the review comment block shared by all formats.
+func writeRemediationHeader(b *strings.Builder, rec Recommendation, action, resourceType string) {
+	b.WriteString("# finfocus remediation plan (generated for review; not executed by finfocus)\n")
+	if rec.ID != "" {
+		fmt.Fprintf(b, "# Recommendation: %s\n", rec.ID)
+	}
+	fmt.Fprintf(b, "# Action: %s\n", action)
+	fmt.Fprintf(b, "# Resource: %s\n", rec.ResourceID)
+	if resourceType != "" {
+		fmt.Fprintf(b, "# Type: %s\n", resourceType)
+	}
+	if rec.Description != "" {
+		fmt.Fprintf(b, "# Description: %s\n", strings.ReplaceAll(rec.Description, "\n", " "))
+	}
+	if rec.EstimatedSavings > 0 {
+		fmt.Fprintf(b, "# Estimated savings: %.2f %s/month\n", rec.EstimatedSavings, rec.Currency)
+	}
+	for _, reason := range rec.Reasoning {
+		fmt.Fprintf(b, "# Note: %s\n", strings.ReplaceAll(reason, "\n", " "))
+	}
+	b.WriteString("\n")
+}
+
+// shellQuote wraps s in single quotes for safe use in a POSIX shell.
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'"'"'`) + "'"
+}
@@ -0,0 +1,181 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildRemediationPlan_Script(t *testing.T) {
+	ec2 := &ResourceDescriptor{Type: "aws:ec2/instance:Instance", ID: "i-0abc"}
+
+	tests := []struct {
+		name     string
+		rec      Recommendation
+		resource *ResourceDescriptor
+		contains []string
+		absent   []string
+	}{
+		{
+			name: "rightsize ec2 with proto enum action type",
+			rec: Recommendation{
+				ID: "rec-1", ResourceID: "i-0abc", Type: "RECOMMENDATION_ACTION_TYPE_RIGHTSIZE",
+				CurrentSKU: "m5.xlarge", RecommendedSKU: "m5.large",
+				EstimatedSavings: 70, Currency: "USD",
+			},
+			resource: ec2,
+			contains: []string{
+				"#!/usr/bin/env bash",
+				"# Recommendation: rec-1",
+				"RESOURCE_ID='i-0abc'",
+				"TARGET='m5.large'",
+				`aws ec2 modify-instance-attribute --instance-id "$RESOURCE_ID" --instance-type "Value=$TARGET"`,
+				"# Estimated savings: 70.00 USD/month",
+			},
+		},
+		{
+			name:     "terminate ec2",
+			rec:      Recommendation{ID: "rec-2", ResourceID: "i-0abc", Type: "TERMINATE"},
+			resource: ec2,
+			contains: []string{`aws ec2 terminate-instances --instance-ids "$RESOURCE_ID"`},
+			absent:   []string{"TARGET="},
+		},
+		{
+			name: "delete unused URN without known type falls back to pulumi destroy",
+			rec: Recommendation{
+				ID: "rec-3", ResourceID: "urn:pulumi:dev::app::custom:index:Thing::x", Type: "DELETE_UNUSED",
+			},
+			contains: []string{`pulumi destroy --target "$RESOURCE_ID"`},
+		},
+		{
+			name: "URN with known type warns to substitute cloud ID",
+			rec: Recommendation{
+				ResourceID: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web", Type: "TERMINATE",
+			},
+			resource: ec2,
+			contains: []string{"RESOURCE_ID is a Pulumi URN"},
+		},
+		{
+			name: "plugin script used verbatim",
+			rec: Recommendation{
+				ResourceID: "i-0abc", Type: "RIGHTSIZE",
+				Metadata: map[string]string{RemediationMetadataScript: "custom-cli resize i-0abc"},
+			},
+			contains: []string{"custom-cli resize i-0abc"},
+			absent:   []string{"aws ec2"},
+		},
+		{
+			name:     "resource ID with quotes is shell-escaped",
+			rec:      Recommendation{ResourceID: "it's", Type: "TERMINATE"},
+			resource: ec2,
+			contains: []string{`RESOURCE_ID='it'"'"'s'`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan, err := BuildRemediationPlan(tt.rec, tt.resource, RemediationFormatScript)
+			require.NoError(t, err)
+			for _, want := range tt.contains {
+				assert.Contains(t, plan.Content, want)
+			}
+			for _, unwanted := range tt.absent {
+				assert.NotContains(t, plan.Content, unwanted)
+			}
+		})
+	}
+}
+
+func TestBuildRemediationPlan_Pulumi(t *testing.T) {
+	rds := &ResourceDescriptor{Type: "aws:rds/instance:Instance", ID: "db-1"}
+
+	t.Run("rightsize uses type property", func(t *testing.T) {
+		rec := Recommendation{
+			ID: "rec-1", ResourceID: "db-1", Type: "RIGHTSIZE",
+			CurrentSKU: "db.r5.large", RecommendedSKU: "db.t3.medium",
+		}
+		plan, err := BuildRemediationPlan(rec, rds, RemediationFormatPulumi)
+		require.NoError(t, err)
+		assert.False(t, plan.FromPlugin)
+		assert.Equal(t, "RIGHTSIZE", plan.ActionType)
+		assert.Contains(t, plan.Content, "property: instanceClass")
+		assert.Contains(t, plan.Content, "from: db.r5.large")
+		assert.Contains(t, plan.Content, "to: db.t3.medium")
+	})
+
+	t.Run("plugin metadata overrides property and value", func(t *testing.T) {
+		rec := Recommendation{
+			ResourceID: "res-1", Type: "RIGHTSIZE",
+			Metadata: map[string]string{
+				RemediationMetadataProperty: "sku.name",
+				RemediationMetadataValue:    "Standard_B2s",
+			},
+		}
+		plan, err := BuildRemediationPlan(rec, nil, RemediationFormatPulumi)
+		require.NoError(t, err)
+		assert.True(t, plan.FromPlugin)
+		assert.Contains(t, plan.Content, "property: sku.name")
+		assert.Contains(t, plan.Content, "to: Standard_B2s")
+	})
+
+	t.Run("terminate produces remove op", func(t *testing.T) {
+		rec := Recommendation{ResourceID: "db-1", Type: "TERMINATE"}
+		plan, err := BuildRemediationPlan(rec, rds, RemediationFormatPulumi)
+		require.NoError(t, err)
+		assert.Contains(t, plan.Content, "op: remove")
+		assert.Contains(t, plan.Content, "pulumi destroy --target 'db-1'")
+	})
+}
+
+func TestBuildRemediationPlan_Errors(t *testing.T) {
+	ec2 := &ResourceDescriptor{Type: "aws:ec2/instance:Instance"}
+
+	tests := []struct {
+		name     string
+		rec      Recommendation
+		resource *ResourceDescriptor
+		format   RemediationFormat
+		wantErr  error
+	}{
+		{
+			name:    "unsupported action",
+			rec:     Recommendation{Type: "PURCHASE_COMMITMENT"},
+			format:  RemediationFormatScript,
+			wantErr: ErrRemediationUnsupportedAction,
+		},
+		{
+			name:     "invalid format",
+			rec:      Recommendation{Type: "TERMINATE"},
+			resource: ec2,
+			format:   "terraform",
+			wantErr:  ErrInvalidRemediationFormat,
+		},
+		{
+			name:     "rightsize without target",
+			rec:      Recommendation{Type: "RIGHTSIZE"},
+			resource: ec2,
+			format:   RemediationFormatScript,
+			wantErr:  ErrRemediationIncomplete,
+		},
+		{
+			name:    "rightsize unknown type",
+			rec:     Recommendation{Type: "RIGHTSIZE", RecommendedSKU: "small"},
+			format:  RemediationFormatPulumi,
+			wantErr: ErrRemediationIncomplete,
+		},
+		{
+			name:    "terminate non-URN unknown type",
+			rec:     Recommendation{Type: "TERMINATE", ResourceID: "abc"},
+			format:  RemediationFormatScript,
+			wantErr: ErrRemediationIncomplete,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := BuildRemediationPlan(tt.rec, tt.resource, tt.format)
+			require.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+}
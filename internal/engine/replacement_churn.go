@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Kinds of one-time replacement cost.
+const (
+	ChurnReplication     = "replication"
+	ChurnSnapshotRestore = "snapshot_restore"
+	ChurnIOPSRamp        = "iops_ramp"
+)
+
+// OneTimeBreakdownPrefix marks the CostBreakdown keys through which a plugin
+// reports the one-time cost of replacing a resource, such as
+// "one_time_snapshot_restore". They are hints, not part of the monthly cost.
+const OneTimeBreakdownPrefix = "one_time_"
+
+// List prices used to model replacement costs (AWS on-demand, us-east-1).
+const (
+	// snapshotRatePerGB is the monthly snapshot storage price; the snapshot
+	// taken to seed a replacement is assumed to be kept for a month.
+	snapshotRatePerGB = 0.05
+	// provisionedIOPSRate is the monthly price of one provisioned IOPS.
+	provisionedIOPSRate = 0.065
+	// churnRampHours is how long a replacement with provisioned IOPS is
+	// assumed to run next to the resource it replaces while it warms up.
+	churnRampHours = 24
+)
+
+// ChurnEstimate is one modeled or plugin-reported one-time cost of replacing
+// a resource.
+type ChurnEstimate struct {
+	ResourceID   string `json:"resourceId"`
+	ResourceType string `json:"resourceType"`
+	// Kind is ChurnReplication, ChurnSnapshotRestore, ChurnIOPSRamp, or a
+	// kind reported by a plugin.
+	Kind string `json:"kind"`
+	// Hint describes what the estimate was derived from.
+	Hint   string  `json:"hint"`
+	Amount float64 `json:"amount"`
+}
+
+// EstimateReplacementChurn estimates the one-time costs of replacing
+// resources, which recurring monthly costs do not show: re-replicating their
+// data, restoring it from a snapshot, and paying for provisioned IOPS while
+// the replacement warms up. resources are the replacements and results
+// their priced results. A plugin that reports costs under
+// OneTimeBreakdownPrefix keys of a result is taken at its word for that
+// resource; otherwise the costs are modeled from storage size, replication,
+// and provisioned IOPS properties.
+func EstimateReplacementChurn(resources []ResourceDescriptor, results []CostResult) []ChurnEstimate {
+	byID := make(map[string]CostResult, len(results))
+	for _, result := range results {
+		byID[result.ResourceID] = result
+	}
+
+	var estimates []ChurnEstimate
+	for _, resource := range resources {
+		result := byID[resource.ID]
+		found := pluginChurn(result)
+		if found == nil {
+			found = modelChurn(resource)
+		}
+		for _, estimate := range found {
+			estimate.ResourceID = resource.ID
+			estimate.ResourceType = resource.Type
+			estimates = append(estimates, estimate)
+		}
+	}
+	return estimates
+}
+
+// pluginChurn returns the one-time costs a plugin reports in the breakdown of
+// result, ordered by kind.
+func pluginChurn(result CostResult) []ChurnEstimate {
+	var estimates []ChurnEstimate
+	for key, amount := range result.Breakdown {
+		kind, ok := strings.CutPrefix(strings.ToLower(key), OneTimeBreakdownPrefix)
+		if !ok || kind == "" || amount <= 0 {
+			continue
+		}
+		estimates = append(estimates, ChurnEstimate{
+			Kind: kind, Hint: fmt.Sprintf("reported by %s", result.Adapter), Amount: amount,
+		})
+	}
+	sort.Slice(estimates, func(i, j int) bool { return estimates[i].Kind < estimates[j].Kind })
+	return estimates
+}
+
+// modelChurn models the one-time costs of replacing a volume or database
+// instance from its properties.
+func modelChurn(resource ResourceDescriptor) []ChurnEstimate {
+	t := strings.ToLower(resource.Type)
+	if !strings.Contains(t, "ebs/volume") && !strings.Contains(t, "rds/instance") {
+		return nil
+	}
+	props := resource.Properties
+	gb, _ := getStorageSize(resource)
+
+	var estimates []ChurnEstimate
+	if gb > 0 {
+		estimates = append(estimates, ChurnEstimate{
+			Kind: ChurnSnapshotRestore, Amount: gb * snapshotRatePerGB,
+			Hint: fmt.Sprintf("%.0f GB snapshot at %.3f/GB", gb, snapshotRatePerGB),
+		})
+		region := resourceRegion(props)
+		source, _ := getStringProperty(props, "replicateSourceDb")
+		switch sourceRegion := regionFromARN(source); {
+		case sourceRegion != "" && sourceRegion != region:
+			estimates = append(estimates, ChurnEstimate{
+				Kind: ChurnReplication, Amount: gb * interRegionRatePerGB,
+				Hint: fmt.Sprintf("%.0f GB re-replicated from %s at %.3f/GB", gb, sourceRegion, interRegionRatePerGB),
+			})
+		case propertyBool(props, "multiAz"):
+			estimates = append(estimates, ChurnEstimate{
+				Kind: ChurnReplication, Amount: gb * interAZRatePerGB,
+				Hint: fmt.Sprintf("%.0f GB re-synced to the Multi-AZ standby at %.3f/GB", gb, interAZRatePerGB),
+			})
+		}
+	}
+
+	if iops, ok := parseFloatValue(props["iops"]); ok && iops > 0 && provisionedIOPS(props) {
+		estimates = append(estimates, ChurnEstimate{
+			Kind: ChurnIOPSRamp, Amount: iops * provisionedIOPSRate * churnRampHours / hoursPerMonth,
+			Hint: fmt.Sprintf("%.0f provisioned IOPS for a %dh warm-up", iops, churnRampHours),
+		})
+	}
+	return estimates
+}
+
+// provisionedIOPS reports whether a volume or database instance uses a
+// storage type billed per provisioned IOPS.
+func provisionedIOPS(props map[string]interface{}) bool {
+	storageType, _ := getStringProperty(props, "type")
+	if storageType == "" {
+		storageType, _ = getStringProperty(props, "storageType")
+	}
+	return strings.HasPrefix(strings.ToLower(storageType), "io")
+}
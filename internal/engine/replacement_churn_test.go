@@ -0,0 +1,89 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEstimateReplacementChurn(t *testing.T) {
+	tests := []struct {
+		name     string
+		resource ResourceDescriptor
+		want     []string // kinds
+		amount   float64
+	}{
+		{
+			name: "gp3 volume",
+			resource: ResourceDescriptor{Type: "aws:ebs/volume:Volume",
+				Properties: map[string]interface{}{"size": 100.0, "type": "gp3", "iops": 3000.0}},
+			want: []string{ChurnSnapshotRestore}, amount: 5,
+		},
+		{
+			name: "io2 volume",
+			resource: ResourceDescriptor{Type: "aws:ebs/volume:Volume",
+				Properties: map[string]interface{}{"size": 100.0, "type": "io2", "iops": 7300.0}},
+			want: []string{ChurnSnapshotRestore, ChurnIOPSRamp}, amount: 5 + 15.6,
+		},
+		{
+			name: "Multi-AZ database",
+			resource: ResourceDescriptor{Type: "aws:rds/instance:Instance",
+				Properties: map[string]interface{}{"allocatedStorage": 200.0, "multiAz": true}},
+			want: []string{ChurnSnapshotRestore, ChurnReplication}, amount: 10 + 4,
+		},
+		{
+			name: "cross-region read replica",
+			resource: ResourceDescriptor{Type: "aws:rds/instance:Instance", Properties: map[string]interface{}{
+				"allocatedStorage": "50", "region": "us-east-1",
+				"replicateSourceDb": "arn:aws:rds:eu-west-1:123456789012:db:primary",
+			}},
+			want: []string{ChurnSnapshotRestore, ChurnReplication}, amount: 2.5 + 1,
+		},
+		{
+			name:     "compute instance",
+			resource: ResourceDescriptor{Type: "aws:ec2/instance:Instance"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.resource.ID = "r"
+			estimates := EstimateReplacementChurn([]ResourceDescriptor{tt.resource}, nil)
+
+			kinds := make([]string, 0, len(estimates))
+			amount := 0.0
+			for _, estimate := range estimates {
+				kinds = append(kinds, estimate.Kind)
+				amount += estimate.Amount
+				assert.Equal(t, "r", estimate.ResourceID)
+				assert.NotEmpty(t, estimate.Hint)
+			}
+			if len(tt.want) == 0 {
+				assert.Empty(t, kinds)
+				return
+			}
+			assert.Equal(t, tt.want, kinds)
+			assert.InDelta(t, tt.amount, amount, 1e-9)
+		})
+	}
+}
+
+func TestEstimateReplacementChurn_PluginHints(t *testing.T) {
+	resource := ResourceDescriptor{ID: "vol", Type: "aws:ebs/volume:Volume",
+		Properties: map[string]interface{}{"size": 100.0}}
+	result := CostResult{ResourceID: "vol", Adapter: "aws-public", Monthly: 8, Breakdown: map[string]float64{
+		"unit_price":                0.08,
+		"one_time_snapshot_restore": 3,
+		"one_time_fast_restore":     0.75,
+	}}
+
+	estimates := EstimateReplacementChurn([]ResourceDescriptor{resource}, []CostResult{result})
+
+	require.Len(t, estimates, 2, "plugin hints replace the model")
+	assert.Equal(t, "fast_restore", estimates[0].Kind)
+	assert.InDelta(t, 0.75, estimates[0].Amount, 1e-9)
+	assert.Equal(t, ChurnSnapshotRestore, estimates[1].Kind)
+	assert.Equal(t, "reported by aws-public", estimates[1].Hint)
+	assert.Empty(t, ClassifyPricingDimension("one_time_snapshot_restore"), "hints are not monthly costs")
+}
@@ -0,0 +1,170 @@
+package engine
+
+import (
+	"fmt"
+
+	"github.com/rshade/finfocus/internal/engine/pipeline"
+	"github.com/rshade/finfocus/internal/engine/spill"
+)
+
+// CostResultStore holds the results of a cost calculation in a spill.Store,
+// which moves them to a temporary file past its threshold, so a very large
+// stack is filtered, sorted, and rendered without holding every result in
+// memory. Callers must Close it to remove the file.
+type CostResultStore struct {
+	Results *spill.Store[CostResult]
+	Errors  []ErrorDetail
+	// Omitted holds the placeholder results of failed resources that
+	// ApplyErrorPolicy left out of Results under ErrorPolicyOmit.
+	Omitted []CostResult
+
+	// threshold is the spill threshold of Results and of the stores that
+	// replace it.
+	threshold int
+}
+
+// NewCostResultStore creates an empty store whose results spill past
+// threshold results, or spill.DefaultThreshold when threshold is zero or
+// less.
+func NewCostResultStore(threshold int) *CostResultStore {
+	return &CostResultStore{
+		Results:   spill.New[CostResult](threshold, ""),
+		Errors:    []ErrorDetail{},
+		threshold: threshold,
+	}
+}
+
+// Close removes any file the results spilled to.
+func (s *CostResultStore) Close() error {
+	return s.Results.Close()
+}
+
+// Collect reads every result into memory. It is for outputs and checks that
+// need all of them at once, such as aggregated tables and budgets.
+func (s *CostResultStore) Collect() (*CostResultWithErrors, error) {
+	results, err := s.Results.Slice(0, s.Results.Len())
+	if err != nil {
+		return nil, fmt.Errorf("reading cost results: %w", err)
+	}
+	return &CostResultWithErrors{Results: results, Errors: s.Errors, Omitted: s.Omitted}, nil
+}
+
+// Filter replaces the results with those for which keep returns true,
+// keeping their order, and returns how many it left out.
+func (s *CostResultStore) Filter(keep func(CostResult) bool) (int, error) {
+	dropped := 0
+	kept := spill.New[CostResult](s.threshold, "")
+	err := pipeline.FilterStore(s.Results, kept, func(result CostResult) bool {
+		if keep(result) {
+			return true
+		}
+		dropped++
+		return false
+	})
+	if err != nil {
+		_ = kept.Close()
+		return 0, fmt.Errorf("filtering cost results: %w", err)
+	}
+	s.replace(kept)
+	return dropped, nil
+}
+
+// Sort replaces the results with the stable order of less applied to the
+// key of each result. Only the keys are held in memory, so key should copy
+// just the fields less compares.
+func (s *CostResultStore) Sort(key func(CostResult) CostResult, less func(a, b CostResult) bool) error {
+	sorted := spill.New[CostResult](s.threshold, "")
+	if err := pipeline.SortStore(s.Results, sorted, key, less); err != nil {
+		_ = sorted.Close()
+		return fmt.Errorf("sorting cost results: %w", err)
+	}
+	s.replace(sorted)
+	return nil
+}
+
+// replace closes the results and replaces them with results.
+func (s *CostResultStore) replace(results *spill.Store[CostResult]) {
+	_ = s.Results.Close()
+	s.Results = results
+}
+
+// FailedResources is CostResultWithErrors.FailedResources for the stored
+// results.
+func (s *CostResultStore) FailedResources() (map[string]bool, error) {
+	failed := make(map[string]bool, len(s.Errors))
+	for _, detail := range s.Errors {
+		failed[detail.ResourceID] = true
+	}
+	err := s.Results.Each(func(result CostResult) error {
+		if result.Error != nil && result.Error.Code != ErrCodeNoCostData {
+			failed[result.ResourceID] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading cost results: %w", err)
+	}
+	err = s.Results.Each(func(result CostResult) error {
+		if !isFailurePlaceholder(result, failed) {
+			delete(failed, result.ResourceID)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading cost results: %w", err)
+	}
+	return failed, nil
+}
+
+// ApplyErrorPolicy is CostResultWithErrors.ApplyErrorPolicy for the stored
+// results.
+func (s *CostResultStore) ApplyErrorPolicy(policy ErrorPolicy) error {
+	switch policy {
+	case ErrorPolicyFail:
+		failed, err := s.FailedResources()
+		if err != nil || len(failed) == 0 {
+			return err
+		}
+		report := s.ErrorReport()
+		if report == nil {
+			report = &ErrorReport{Code: ErrCodePartialFailure}
+		}
+		report.Message = fmt.Sprintf("%d resource(s) failed", len(failed))
+		return &PartialFailureError{Report: report}
+	case ErrorPolicyOmit:
+		failed, err := s.FailedResources()
+		if err != nil {
+			return err
+		}
+		_, err = s.Filter(func(result CostResult) bool {
+			if isFailurePlaceholder(result, failed) {
+				s.Omitted = append(s.Omitted, result)
+				return false
+			}
+			return true
+		})
+		return err
+	case ErrorPolicyZero:
+	}
+	return nil
+}
+
+// ErrorReport returns the partial failures of the calculation as an
+// ErrorReport with code ErrCodePartialFailure, or nil when there are none.
+func (s *CostResultStore) ErrorReport() *ErrorReport {
+	return (&CostResultWithErrors{Errors: s.Errors, Omitted: s.Omitted}).ErrorReport()
+}
+
+// NDJSONSummary is NewNDJSONSummary for the stored results, reading them a
+// chunk at a time.
+func (s *CostResultStore) NDJSONSummary() (NDJSONSummary, error) {
+	summary := NDJSONSummary{Type: NDJSONSummaryType, Errors: len(s.Errors)}
+	err := s.Results.Each(func(result CostResult) error {
+		summary.add(result)
+		return nil
+	})
+	if err != nil {
+		return NDJSONSummary{}, fmt.Errorf("reading cost results: %w", err)
+	}
+	return summary.withDefaultCurrency(), nil
+}
@@ -0,0 +1,96 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Scenario is a named set of property overrides compared against a shared baseline.
+type Scenario struct {
+	Name        string            `json:"name" yaml:"name"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
+	Set         map[string]string `json:"set" yaml:"set"`
+}
+
+// ScenarioResult is the estimate for one scenario. Error is set instead of
+// Estimate when the scenario could not be estimated.
+type ScenarioResult struct {
+	Scenario Scenario        `json:"scenario"`
+	Estimate *EstimateResult `json:"estimate,omitempty"`
+	// DeltaPercent is the monthly change relative to the baseline. Zero when
+	// the baseline costs nothing.
+	DeltaPercent float64 `json:"deltaPercent"`
+	Error        string  `json:"error,omitempty"`
+}
+
+// ScenarioComparison holds the baseline and every scenario's estimate for one resource.
+type ScenarioComparison struct {
+	Resource  *ResourceDescriptor `json:"resource"`
+	Baseline  *CostResult         `json:"baseline"`
+	Scenarios []ScenarioResult    `json:"scenarios"`
+}
+
+// CompareScenarios estimates each scenario against resource and returns the
+// results in scenario order. A scenario that fails is reported in its
+// ScenarioResult rather than aborting the comparison; an error is returned
+// only when every scenario fails or ctx is canceled.
+func (e *Engine) CompareScenarios(
+	ctx context.Context,
+	resource *ResourceDescriptor,
+	scenarios []Scenario,
+) (*ScenarioComparison, error) {
+	if resource == nil {
+		return nil, errors.New("scenario comparison resource cannot be nil")
+	}
+	if len(scenarios) == 0 {
+		return nil, errors.New("at least one scenario is required")
+	}
+
+	log := logging.FromContext(ctx)
+	comparison := &ScenarioComparison{
+		Resource:  resource,
+		Scenarios: make([]ScenarioResult, 0, len(scenarios)),
+	}
+
+	var failed int
+	var lastErr error
+	for _, scenario := range scenarios {
+		result := ScenarioResult{Scenario: scenario}
+		estimate, err := e.EstimateCost(ctx, &EstimateRequest{
+			Resource:          resource,
+			PropertyOverrides: maps.Clone(scenario.Set),
+		})
+		switch {
+		case errors.Is(err, context.Canceled):
+			return nil, err
+		case err != nil:
+			log.Warn().
+				Ctx(ctx).
+				Str("component", "engine").
+				Str("scenario", scenario.Name).
+				Err(err).
+				Msg("scenario estimation failed")
+			failed++
+			lastErr = err
+			result.Error = err.Error()
+		default:
+			result.Estimate = estimate
+			if comparison.Baseline == nil {
+				comparison.Baseline = estimate.Baseline
+			}
+			if estimate.Baseline != nil && estimate.Baseline.Monthly != 0 {
+				result.DeltaPercent = estimate.TotalChange / estimate.Baseline.Monthly * percentageMultiplier
+			}
+		}
+		comparison.Scenarios = append(comparison.Scenarios, result)
+	}
+
+	if failed == len(scenarios) {
+		return nil, fmt.Errorf("all %d scenarios failed: %w", len(scenarios), lastErr)
+	}
+	return comparison, nil
+}
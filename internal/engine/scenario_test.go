@@ -0,0 +1,73 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+func TestCompareScenarios(t *testing.T) {
+	prices := map[string]float64{"t3.medium": 30, "t3.large": 60, "m5.large": 70}
+	api := &mockCostSourceClient{
+		estimate: func(in *proto.EstimateCostRequest) (*proto.EstimateCostResponse, error) {
+			instanceType := in.PropertyOverrides["instanceType"]
+			modified, ok := prices[instanceType]
+			if !ok {
+				return nil, errors.New("unknown instance type " + instanceType)
+			}
+			return &proto.EstimateCostResponse{
+				Baseline: &proto.CostResult{Currency: "USD", MonthlyCost: prices[in.Resource.Properties["instanceType"]]},
+				Modified: &proto.CostResult{Currency: "USD", MonthlyCost: modified},
+			}, nil
+		},
+	}
+	eng := New([]*pluginhost.Client{{Name: "aws-public", API: api}}, &mockSpecLoader{})
+	resource := &ResourceDescriptor{
+		Provider:   "aws",
+		Type:       "aws:ec2/instance:Instance",
+		ID:         "web",
+		Properties: map[string]interface{}{"instanceType": "t3.medium"},
+	}
+
+	t.Run("partial failure", func(t *testing.T) {
+		comparison, err := eng.CompareScenarios(context.Background(), resource, []Scenario{
+			{Name: "large", Set: map[string]string{"instanceType": "t3.large"}},
+			{Name: "invalid", Set: map[string]string{"instance type": "x9.huge"}},
+			{Name: "m5", Set: map[string]string{"instanceType": "m5.large"}},
+		})
+		require.NoError(t, err)
+
+		require.NotNil(t, comparison.Baseline)
+		assert.InDelta(t, 30.0, comparison.Baseline.Monthly, 0.001)
+		require.Len(t, comparison.Scenarios, 3)
+
+		assert.Equal(t, "large", comparison.Scenarios[0].Scenario.Name)
+		assert.InDelta(t, 30.0, comparison.Scenarios[0].Estimate.TotalChange, 0.001)
+		assert.InDelta(t, 100.0, comparison.Scenarios[0].DeltaPercent, 0.001)
+
+		assert.Nil(t, comparison.Scenarios[1].Estimate)
+		assert.NotEmpty(t, comparison.Scenarios[1].Error)
+
+		assert.InDelta(t, 70.0, comparison.Scenarios[2].Estimate.Modified.Monthly, 0.001)
+	})
+
+	t.Run("all scenarios fail", func(t *testing.T) {
+		failing := New(nil, &mockSpecLoader{})
+		_, err := failing.CompareScenarios(context.Background(), &ResourceDescriptor{}, []Scenario{
+			{Name: "a", Set: map[string]string{"instanceType": "t3.large"}},
+		})
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "all 1 scenarios failed")
+	})
+
+	t.Run("requires scenarios", func(t *testing.T) {
+		_, err := eng.CompareScenarios(context.Background(), resource, nil)
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,211 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// ScheduleGroupByStack groups a schedule savings report by stack. Tag
+// groupings use TopSpendersTagPrefix, e.g. "tag:team".
+const ScheduleGroupByStack = "stack"
+
+// scheduleReferenceYear is the non-leap year that schedules are simulated
+// over, so that day-of-month and month fields count in proportion.
+const scheduleReferenceYear = 2025
+
+// ScheduleSavingsGroup is the cost of the schedulable resources of one group
+// and what stopping them on the schedule saves.
+type ScheduleSavingsGroup struct {
+	Key       string `json:"key"`
+	Resources int    `json:"resources"`
+	Stacks    int    `json:"stacks"`
+	// Monthly is the projected monthly cost of the group's schedulable
+	// resources running all the time.
+	Monthly float64 `json:"monthly"`
+	// Savings is the monthly cost saved by stopping them on the schedule.
+	Savings float64 `json:"savings"`
+}
+
+// ScheduleSavingsReport estimates the savings of stopping the non-production
+// compute resources of the recorded projections on a schedule.
+type ScheduleSavingsReport struct {
+	Stop    string `json:"stop"`
+	Start   string `json:"start"`
+	GroupBy string `json:"groupBy"`
+	// RunningFraction is the share of the time that resources run on the
+	// schedule, and RunningHoursPerMonth the hours per month it amounts to.
+	RunningFraction      float64 `json:"runningFraction"`
+	RunningHoursPerMonth float64 `json:"runningHoursPerMonth"`
+	Currency             string  `json:"currency"`
+	// MixedCurrencies is true when the projections use more than one currency;
+	// totals are then sums of unconverted amounts.
+	MixedCurrencies bool    `json:"mixedCurrencies,omitempty"`
+	Monthly         float64 `json:"monthly"`
+	Savings         float64 `json:"savings"`
+	// SkippedProduction counts the compute resources left out because their
+	// environment names production.
+	SkippedProduction int `json:"skippedProduction"`
+	// Groups lists the groups with schedulable resources, largest savings first.
+	Groups []ScheduleSavingsGroup `json:"groups"`
+}
+
+// ScheduleSavingsInput carries the data needed to build a ScheduleSavingsReport.
+type ScheduleSavingsInput struct {
+	// Stop and Start are when resources are stopped and started again.
+	Stop  *CronSchedule
+	Start *CronSchedule
+	// GroupBy is ScheduleGroupByStack or a tag key with TopSpendersTagPrefix.
+	GroupBy string
+	// EnvTag is the tag that names the environment of a resource; resources
+	// without it are in the environment of their stack name. Empty means
+	// DefaultOrgEnvironmentTag.
+	EnvTag string
+	// Snapshots maps each stack to its latest recorded projection.
+	Snapshots map[string]config.ProjectionSnapshot
+}
+
+// ValidateScheduleGroupBy checks a schedule savings grouping.
+func ValidateScheduleGroupBy(by string) error {
+	switch {
+	case by == ScheduleGroupByStack:
+		return nil
+	case strings.HasPrefix(by, TopSpendersTagPrefix) && len(by) > len(TopSpendersTagPrefix):
+		return nil
+	default:
+		return fmt.Errorf("invalid grouping %q: use stack or tag:<key>", by)
+	}
+}
+
+// ScheduleRunningFraction returns the share of the time that a resource runs
+// when it is stopped whenever stop fires and started whenever start fires;
+// when both fire in the same minute, it is started. The schedules are
+// simulated minute by minute over a year, starting from the state that the
+// year ends in.
+func ScheduleRunningFraction(stop, start *CronSchedule) float64 {
+	from := time.Date(scheduleReferenceYear, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(1, 0, 0)
+	running := true
+	simulate := func() (int, int) {
+		runningMinutes, minutes := 0, 0
+		for t := from; t.Before(to); t = t.Add(time.Minute) {
+			if stop.Matches(t) {
+				running = false
+			}
+			if start.Matches(t) {
+				running = true
+			}
+			if running {
+				runningMinutes++
+			}
+			minutes++
+		}
+		return runningMinutes, minutes
+	}
+	// The first pass settles the state the year starts in.
+	simulate()
+	runningMinutes, minutes := simulate()
+	return float64(runningMinutes) / float64(minutes)
+}
+
+// IsProductionEnvironment reports whether a stack or environment name names
+// production, such as "prod", "production", "prd", "live", or "prod-eu".
+func IsProductionEnvironment(name string) bool {
+	segments := strings.FieldsFunc(strings.ToLower(orgStackName(name)), func(r rune) bool {
+		return r == '-' || r == '_' || r == '.'
+	})
+	for _, segment := range segments {
+		switch segment {
+		case "prod", "production", "prd", "live":
+			return true
+		}
+	}
+	return false
+}
+
+// scheduleGroup accumulates one group.
+type scheduleGroup struct {
+	monthly   float64
+	resources int
+	stacks    map[string]bool
+}
+
+// BuildScheduleSavings estimates what stopping the schedulable resources of
+// each stack's latest projection on the schedule saves. Resources are
+// schedulable when they are billed by the hour, i.e. compute, and their
+// environment, from the EnvTag tag or the stack name, is not production.
+func BuildScheduleSavings(input ScheduleSavingsInput) *ScheduleSavingsReport {
+	envTag := input.EnvTag
+	if envTag == "" {
+		envTag = DefaultOrgEnvironmentTag
+	}
+	fraction := ScheduleRunningFraction(input.Stop, input.Start)
+	report := &ScheduleSavingsReport{
+		Stop:                 input.Stop.String(),
+		Start:                input.Start.String(),
+		GroupBy:              input.GroupBy,
+		RunningFraction:      fraction,
+		RunningHoursPerMonth: fraction * hoursPerMonth,
+		Currency:             defaultCurrency,
+	}
+
+	tagKey := strings.TrimPrefix(input.GroupBy, TopSpendersTagPrefix)
+	groups := make(map[string]*scheduleGroup)
+	currencySet := false
+	for stack, snapshot := range input.Snapshots {
+		for _, record := range snapshot.Resources {
+			if record.Monthly <= 0 || resourceTypeDimension(record.ResourceType) != DimensionCompute {
+				continue
+			}
+			if IsProductionEnvironment(orgTagValue(record.Tags, envTag, stack)) {
+				report.SkippedProduction++
+				continue
+			}
+			if record.Currency != "" {
+				if !currencySet {
+					report.Currency = record.Currency
+					currencySet = true
+				} else if record.Currency != report.Currency {
+					report.MixedCurrencies = true
+				}
+			}
+
+			key := stack
+			if input.GroupBy != ScheduleGroupByStack {
+				key = orgTagValue(record.Tags, tagKey, UntaggedGroupKey)
+			}
+			group, ok := groups[key]
+			if !ok {
+				group = &scheduleGroup{stacks: make(map[string]bool)}
+				groups[key] = group
+			}
+			group.monthly += record.Monthly
+			group.resources++
+			group.stacks[stack] = true
+		}
+	}
+
+	report.Groups = make([]ScheduleSavingsGroup, 0, len(groups))
+	for key, group := range groups {
+		savings := group.monthly * (1 - fraction)
+		report.Groups = append(report.Groups, ScheduleSavingsGroup{
+			Key:       key,
+			Resources: group.resources,
+			Stacks:    len(group.stacks),
+			Monthly:   group.monthly,
+			Savings:   savings,
+		})
+		report.Monthly += group.monthly
+		report.Savings += savings
+	}
+	sort.Slice(report.Groups, func(i, j int) bool {
+		if report.Groups[i].Savings != report.Groups[j].Savings {
+			return report.Groups[i].Savings > report.Groups[j].Savings
+		}
+		return report.Groups[i].Key < report.Groups[j].Key
+	})
+	return report
+}
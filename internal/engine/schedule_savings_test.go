@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func mustCron(t *testing.T, expr string) *CronSchedule {
+	t.Helper()
+	schedule, err := ParseCronSchedule(expr)
+	require.NoError(t, err)
+	return schedule
+}
+
+func TestScheduleRunningFraction(t *testing.T) {
+	// Running 07:00-19:00 on weekdays is 60 of 168 hours a week.
+	fraction := ScheduleRunningFraction(mustCron(t, "0 19 * * 1-5"), mustCron(t, "0 7 * * 1-5"))
+	assert.InDelta(t, 60.0/168, fraction, 0.005)
+
+	// Stopped on Friday evening and started on Monday morning.
+	fraction = ScheduleRunningFraction(mustCron(t, "0 19 * * 5"), mustCron(t, "0 7 * * 1"))
+	assert.InDelta(t, 108.0/168, fraction, 0.005)
+}
+
+func TestIsProductionEnvironment(t *testing.T) {
+	for _, name := range []string{"prod", "Production", "acme/webapp/prd", "prod-eu", "live"} {
+		assert.True(t, IsProductionEnvironment(name), name)
+	}
+	for _, name := range []string{"dev", "staging", "acme/webapp/qa", "product-demo", ""} {
+		assert.False(t, IsProductionEnvironment(name), name)
+	}
+}
+
+func TestBuildScheduleSavings(t *testing.T) {
+	instance := "aws:ec2/instance:Instance"
+	snapshots := map[string]config.ProjectionSnapshot{
+		"dev": {Resources: map[string]config.ProjectedResourceRecord{
+			"web":    {ResourceType: instance, Monthly: 100, Currency: "USD", Tags: map[string]string{"team": "web"}},
+			"api":    {ResourceType: instance, Monthly: 50, Currency: "USD", Tags: map[string]string{"team": "api"}},
+			"bucket": {ResourceType: "aws:s3/bucket:Bucket", Monthly: 30, Currency: "USD"},
+			"pinned": {ResourceType: instance, Monthly: 40, Tags: map[string]string{"Environment": "production"}},
+		}},
+		"staging": {Resources: map[string]config.ProjectedResourceRecord{
+			"web": {ResourceType: instance, Monthly: 200, Currency: "USD", Tags: map[string]string{"team": "web"}},
+		}},
+		"prod": {Resources: map[string]config.ProjectedResourceRecord{
+			"web": {ResourceType: instance, Monthly: 500, Currency: "USD", Tags: map[string]string{"team": "web"}},
+		}},
+	}
+	input := ScheduleSavingsInput{
+		Stop:      mustCron(t, "0 19 * * 1-5"),
+		Start:     mustCron(t, "0 7 * * 1-5"),
+		GroupBy:   ScheduleGroupByStack,
+		Snapshots: snapshots,
+	}
+
+	report := BuildScheduleSavings(input)
+	off := 1 - report.RunningFraction
+	assert.InDelta(t, 60.0/168*730, report.RunningHoursPerMonth, 4)
+	assert.Equal(t, "0 19 * * 1-5", report.Stop)
+	assert.Equal(t, 2, report.SkippedProduction, "the prod stack and the production-tagged instance")
+	assert.InDelta(t, 350.0, report.Monthly, 0.001)
+	assert.InDelta(t, 350*off, report.Savings, 0.001)
+	require.Len(t, report.Groups, 2)
+	assert.Equal(t, "staging", report.Groups[0].Key, "ranked by savings")
+	assert.Equal(t, 2, report.Groups[1].Resources)
+
+	input.GroupBy = "tag:team"
+	report = BuildScheduleSavings(input)
+	require.Len(t, report.Groups, 2)
+	assert.Equal(t, ScheduleSavingsGroup{Key: "web", Resources: 2, Stacks: 2, Monthly: 300, Savings: 300 * off},
+		report.Groups[0])
+	assert.Equal(t, "api", report.Groups[1].Key)
+}
+
+func TestValidateScheduleGroupBy(t *testing.T) {
+	require.NoError(t, ValidateScheduleGroupBy("stack"))
+	require.NoError(t, ValidateScheduleGroupBy("tag:team"))
+	require.Error(t, ValidateScheduleGroupBy("tag:"))
+	require.Error(t, ValidateScheduleGroupBy("service"))
+}
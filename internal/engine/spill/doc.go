@@ -0,0 +1,15 @@
+// Package spill provides a result store that moves to disk when it grows
+// large.
+//
+// Very large stacks produce more cost results and recommendations than fit
+// in the memory target of the CLI. A Store holds results in memory like a
+// slice until it holds more than a threshold of them, then writes them to a
+// temporary file with an index of where each result starts, and reads them
+// back on demand. Key features:
+//   - Append-only, with random access by index and range reads for windows
+//     such as the visible rows of a virtual list
+//   - One sequential file of JSON lines, removed by Close
+//   - Safe for concurrent use
+//
+// Items are stored as JSON, so only their exported fields survive a spill.
+package spill
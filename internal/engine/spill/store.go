@@ -0,0 +1,228 @@
+package spill
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+)
+
+// DefaultThreshold is the number of items a Store holds in memory before it
+// spills them to disk. At a few kilobytes per cost result, it keeps the
+// results of a store to a few tens of megabytes.
+const DefaultThreshold = 10000
+
+// eachChunk is the number of items Each reads from disk at a time.
+const eachChunk = 1000
+
+// spillFilePattern names the temporary files of spilled stores.
+const spillFilePattern = "finfocus-results-*.jsonl"
+
+// ErrClosed is returned by the methods of a closed Store.
+var ErrClosed = errors.New("result store is closed")
+
+// Store is an append-only sequence of items that moves to an indexed
+// temporary file once it holds more than its threshold of items. It is safe
+// for concurrent use; Close removes its file.
+type Store[T any] struct {
+	mu sync.Mutex
+
+	// threshold is the number of items held in memory before spilling.
+	threshold int
+
+	// dir is the directory of the temporary file; empty for the default.
+	dir string
+
+	// items holds the items until the store spills.
+	items []T
+
+	// file and writer hold the items once the store has spilled.
+	file   *os.File
+	writer *bufio.Writer
+
+	// offsets holds the offset in file at which each item starts, followed
+	// by the end of the last item.
+	offsets []int64
+
+	closed bool
+}
+
+// New creates a store that spills to a temporary file in dir once it holds
+// more than threshold items. A threshold of zero or less uses
+// DefaultThreshold, and an empty dir the default temporary directory.
+func New[T any](threshold int, dir string) *Store[T] {
+	if threshold <= 0 {
+		threshold = DefaultThreshold
+	}
+	return &Store[T]{threshold: threshold, dir: dir}
+}
+
+// Append adds items to the end of the store, spilling it to disk when it
+// grows past its threshold.
+func (s *Store[T]) Append(items ...T) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return ErrClosed
+	}
+	if s.file == nil {
+		if len(s.items)+len(items) <= s.threshold {
+			s.items = append(s.items, items...)
+			return nil
+		}
+		if err := s.spill(); err != nil {
+			return err
+		}
+	}
+	for _, item := range items {
+		if err := s.write(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// spill moves the items held in memory to a new temporary file.
+func (s *Store[T]) spill() error {
+	file, err := os.CreateTemp(s.dir, spillFilePattern)
+	if err != nil {
+		return fmt.Errorf("creating result spill file: %w", err)
+	}
+	s.file = file
+	s.writer = bufio.NewWriter(file)
+	s.offsets = []int64{0}
+	for _, item := range s.items {
+		if err = s.write(item); err != nil {
+			return err
+		}
+	}
+	s.items = nil
+	return nil
+}
+
+// write appends one item to the spill file and indexes it.
+func (s *Store[T]) write(item T) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return fmt.Errorf("encoding result: %w", err)
+	}
+	n, err := s.writer.Write(append(data, '\n'))
+	if err != nil {
+		return fmt.Errorf("writing result spill file: %w", err)
+	}
+	s.offsets = append(s.offsets, s.offsets[len(s.offsets)-1]+int64(n))
+	return nil
+}
+
+// Len returns the number of items in the store.
+func (s *Store[T]) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.file == nil {
+		return len(s.items)
+	}
+	return len(s.offsets) - 1
+}
+
+// Spilled reports whether the store has moved its items to disk.
+func (s *Store[T]) Spilled() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.file != nil
+}
+
+// At returns the item at index i.
+func (s *Store[T]) At(i int) (T, error) {
+	items, err := s.Slice(i, i+1)
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	return items[0], nil
+}
+
+// Slice returns the items from index from up to, but not including, to. A
+// spilled store reads them with a single read of the spill file.
+func (s *Store[T]) Slice(from, to int) ([]T, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil, ErrClosed
+	}
+	count := len(s.items)
+	if s.file != nil {
+		count = len(s.offsets) - 1
+	}
+	if from < 0 || to > count || from > to {
+		return nil, fmt.Errorf("items %d to %d out of range of %d", from, to, count)
+	}
+	if s.file == nil {
+		return append([]T(nil), s.items[from:to]...), nil
+	}
+
+	if err := s.writer.Flush(); err != nil {
+		return nil, fmt.Errorf("writing result spill file: %w", err)
+	}
+	data := make([]byte, s.offsets[to]-s.offsets[from])
+	if _, err := s.file.ReadAt(data, s.offsets[from]); err != nil {
+		return nil, fmt.Errorf("reading result spill file: %w", err)
+	}
+	items := make([]T, 0, to-from)
+	for i := from; i < to; i++ {
+		start, end := s.offsets[i]-s.offsets[from], s.offsets[i+1]-s.offsets[from]
+		var item T
+		if err := json.Unmarshal(bytes.TrimSuffix(data[start:end], []byte("\n")), &item); err != nil {
+			return nil, fmt.Errorf("decoding result %d: %w", i, err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// Each calls fn with every item in order, reading a spilled store a chunk at
+// a time. It stops at the first error fn returns and returns it. Items
+// appended while Each runs may or may not be visited.
+func (s *Store[T]) Each(fn func(item T) error) error {
+	for from := 0; from < s.Len(); from += eachChunk {
+		items, err := s.Slice(from, min(from+eachChunk, s.Len()))
+		if err != nil {
+			return err
+		}
+		for _, item := range items {
+			if err = fn(item); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// Close releases the items of the store and removes its spill file.
+func (s *Store[T]) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.closed {
+		return nil
+	}
+	s.closed = true
+	s.items = nil
+	s.offsets = nil
+	if s.file == nil {
+		return nil
+	}
+	name := s.file.Name()
+	closeErr := s.file.Close()
+	if err := os.Remove(name); err != nil {
+		return fmt.Errorf("removing result spill file: %w", err)
+	}
+	if closeErr != nil {
+		return fmt.Errorf("closing result spill file: %w", closeErr)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package spill
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type result struct {
+	ID      string             `json:"id"`
+	Monthly float64            `json:"monthly"`
+	Tags    map[string]float64 `json:"tags,omitempty"`
+}
+
+func TestStore_InMemoryUntilThreshold(t *testing.T) {
+	dir := t.TempDir()
+	store := New[result](3, dir)
+	require.NoError(t, store.Append(result{ID: "a"}, result{ID: "b"}))
+	require.NoError(t, store.Append(result{ID: "c"}))
+	assert.False(t, store.Spilled())
+	assert.Equal(t, 3, store.Len())
+
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	assert.Empty(t, entries, "nothing is written below the threshold")
+
+	items, err := store.Slice(1, 3)
+	require.NoError(t, err)
+	assert.Equal(t, []result{{ID: "b"}, {ID: "c"}}, items)
+	require.NoError(t, store.Close())
+}
+
+func TestStore_SpillsPastThreshold(t *testing.T) {
+	dir := t.TempDir()
+	store := New[result](2, dir)
+	for i := range 5 {
+		require.NoError(t, store.Append(result{
+			ID: "r" + strconv.Itoa(i), Monthly: float64(i), Tags: map[string]float64{"cpu": float64(i)},
+		}))
+	}
+	assert.True(t, store.Spilled())
+	assert.Equal(t, 5, store.Len())
+
+	files, err := filepath.Glob(filepath.Join(dir, "finfocus-results-*.jsonl"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	item, err := store.At(3)
+	require.NoError(t, err)
+	assert.Equal(t, result{ID: "r3", Monthly: 3, Tags: map[string]float64{"cpu": 3}}, item)
+
+	items, err := store.Slice(1, 4)
+	require.NoError(t, err)
+	require.Len(t, items, 3)
+	assert.Equal(t, "r1", items[0].ID)
+	assert.Equal(t, "r3", items[2].ID)
+
+	_, err = store.Slice(4, 6)
+	require.Error(t, err)
+
+	require.NoError(t, store.Close())
+	_, err = os.Stat(files[0])
+	require.ErrorIs(t, err, os.ErrNotExist, "Close removes the spill file")
+	require.ErrorIs(t, store.Append(result{}), ErrClosed)
+	_, err = store.At(0)
+	require.ErrorIs(t, err, ErrClosed)
+	require.NoError(t, store.Close(), "Close is idempotent")
+}
+
+func TestStore_Each(t *testing.T) {
+	store := New[result](10, t.TempDir())
+	defer store.Close()
+	for i := range eachChunk + 20 {
+		require.NoError(t, store.Append(result{ID: strconv.Itoa(i)}))
+	}
+
+	var ids []string
+	require.NoError(t, store.Each(func(r result) error {
+		ids = append(ids, r.ID)
+		return nil
+	}))
+	require.Len(t, ids, eachChunk+20)
+	assert.Equal(t, strconv.Itoa(eachChunk+19), ids[len(ids)-1])
+
+	stop := errors.New("stop")
+	visited := 0
+	err := store.Each(func(result) error {
+		visited++
+		return stop
+	})
+	require.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, visited)
+}
@@ -0,0 +1,224 @@
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// Groupings of a top spenders report.
+const (
+	TopSpendersByResource = "resource"
+	TopSpendersByService  = "service"
+	// TopSpendersTagPrefix prefixes a tag key to group by that tag, e.g. "tag:team".
+	TopSpendersTagPrefix = "tag:"
+)
+
+// TopSpender is one cost contributor of a top spenders report.
+type TopSpender struct {
+	Key string `json:"key"`
+	// Monthly is the projected monthly cost of the contributor, averaged over
+	// the period by how long each projection was in effect.
+	Monthly float64 `json:"monthly"`
+	// PreviousMonthly is Monthly over the previous period of equal length.
+	PreviousMonthly float64 `json:"previousMonthly"`
+	Change          float64 `json:"change"`
+	// New marks contributors with no cost in the previous period.
+	New bool `json:"new,omitempty"`
+	// Resources and Stacks count the contributor's resources and stacks in
+	// the period.
+	Resources int `json:"resources"`
+	Stacks    int `json:"stacks"`
+}
+
+// ChangePercent returns the change as a percentage of the previous period, or
+// 0 when the contributor had no cost then.
+func (s TopSpender) ChangePercent() float64 {
+	if s.PreviousMonthly == 0 {
+		return 0
+	}
+	return s.Change / s.PreviousMonthly * percentageMultiplier
+}
+
+// TopSpendersReport ranks the cost contributors of a period and compares
+// each with the previous period of equal length.
+type TopSpendersReport struct {
+	By           string    `json:"by"`
+	From         time.Time `json:"from"`
+	To           time.Time `json:"to"`
+	PreviousFrom time.Time `json:"previousFrom"`
+	Currency     string    `json:"currency"`
+	// MixedCurrencies is true when the projections use more than one currency;
+	// totals are then sums of unconverted amounts.
+	MixedCurrencies      bool    `json:"mixedCurrencies,omitempty"`
+	TotalMonthly         float64 `json:"totalMonthly"`
+	PreviousTotalMonthly float64 `json:"previousTotalMonthly"`
+	// Spenders lists the contributors of either period, largest Monthly first.
+	Spenders []TopSpender `json:"spenders"`
+}
+
+// TopSpendersInput carries the data needed to build a TopSpendersReport.
+type TopSpendersInput struct {
+	// By is TopSpendersByResource, TopSpendersByService, or a tag key with
+	// TopSpendersTagPrefix.
+	By string
+	// From and To bound the period; the previous period ends at From.
+	From time.Time
+	To   time.Time
+	// Snapshots maps each stack to its projections in effect during both
+	// periods, oldest first (see config.ProjectionHistoryStore.SnapshotsBetween).
+	Snapshots map[string][]config.ProjectionSnapshot
+}
+
+// PreviousPeriodStart returns the start of the period of equal length that
+// ends at from.
+func PreviousPeriodStart(from, to time.Time) time.Time {
+	return from.Add(-to.Sub(from))
+}
+
+// ValidateTopSpendersBy checks a top spenders grouping.
+func ValidateTopSpendersBy(by string) error {
+	switch {
+	case by == TopSpendersByResource, by == TopSpendersByService:
+		return nil
+	case strings.HasPrefix(by, TopSpendersTagPrefix) && len(by) > len(TopSpendersTagPrefix):
+		return nil
+	default:
+		return fmt.Errorf("invalid grouping %q: use resource, service, or tag:<key>", by)
+	}
+}
+
+// topSpenderGroup accumulates one contributor.
+type topSpenderGroup struct {
+	monthly   float64
+	previous  float64
+	resources map[string]bool
+	stacks    map[string]bool
+}
+
+// BuildTopSpenders ranks recorded projections by contributor over the period
+// and the previous period of equal length. As in BuildOrgReport, each
+// snapshot contributes in proportion to the share of a period it was in
+// effect. Resources are keyed by ID, services by "provider:service", and tag
+// groupings by the tag value, with UntaggedGroupKey for untagged resources.
+func BuildTopSpenders(input TopSpendersInput) *TopSpendersReport {
+	report := &TopSpendersReport{
+		By:           input.By,
+		From:         input.From,
+		To:           input.To,
+		PreviousFrom: PreviousPeriodStart(input.From, input.To),
+		Currency:     defaultCurrency,
+	}
+	if !input.To.After(input.From) {
+		return report
+	}
+
+	groups := make(map[string]*topSpenderGroup)
+	currencySet := false
+	add := func(stack, id string, record config.ProjectedResourceRecord, monthly float64, current bool) {
+		if record.Currency != "" {
+			if !currencySet {
+				report.Currency = record.Currency
+				currencySet = true
+			} else if record.Currency != report.Currency {
+				report.MixedCurrencies = true
+			}
+		}
+		key := topSpenderKey(input.By, id, record)
+		group, ok := groups[key]
+		if !ok {
+			group = &topSpenderGroup{resources: make(map[string]bool), stacks: make(map[string]bool)}
+			groups[key] = group
+		}
+		if !current {
+			group.previous += monthly
+			report.PreviousTotalMonthly += monthly
+			return
+		}
+		group.monthly += monthly
+		report.TotalMonthly += monthly
+		group.resources[stack+"\x00"+id] = true
+		group.stacks[stack] = true
+	}
+
+	for stack, snapshots := range input.Snapshots {
+		for _, current := range []bool{false, true} {
+			from, to := report.PreviousFrom, input.From
+			if current {
+				from, to = input.From, input.To
+			}
+			weighSnapshots(snapshots, from, to, func(id string, record config.ProjectedResourceRecord, monthly float64) {
+				add(stack, id, record, monthly, current)
+			})
+		}
+	}
+
+	report.Spenders = make([]TopSpender, 0, len(groups))
+	for key, group := range groups {
+		report.Spenders = append(report.Spenders, TopSpender{
+			Key:             key,
+			Monthly:         group.monthly,
+			PreviousMonthly: group.previous,
+			Change:          group.monthly - group.previous,
+			New:             group.previous == 0 && group.monthly > 0,
+			Resources:       len(group.resources),
+			Stacks:          len(group.stacks),
+		})
+	}
+	sort.Slice(report.Spenders, func(i, j int) bool {
+		if report.Spenders[i].Monthly != report.Spenders[j].Monthly {
+			return report.Spenders[i].Monthly > report.Spenders[j].Monthly
+		}
+		return report.Spenders[i].Key < report.Spenders[j].Key
+	})
+	return report
+}
+
+// weighSnapshots calls fn with the monthly cost of every resource of the
+// snapshots weighted by the share of [from, to) its snapshot was in effect.
+// A snapshot is in effect from when it was recorded until the next one.
+func weighSnapshots(
+	snapshots []config.ProjectionSnapshot,
+	from, to time.Time,
+	fn func(id string, record config.ProjectedResourceRecord, monthly float64),
+) {
+	period := to.Sub(from)
+	if period <= 0 {
+		return
+	}
+	for i, snapshot := range snapshots {
+		start := snapshot.RecordedAt
+		if start.Before(from) {
+			start = from
+		}
+		end := to
+		if i+1 < len(snapshots) && snapshots[i+1].RecordedAt.Before(end) {
+			end = snapshots[i+1].RecordedAt
+		}
+		if !end.After(start) {
+			continue
+		}
+		weight := float64(end.Sub(start)) / float64(period)
+		for id, record := range snapshot.Resources {
+			fn(id, record, record.Monthly*weight)
+		}
+	}
+}
+
+// topSpenderKey returns the key of the contributor that a resource counts toward.
+func topSpenderKey(by, id string, record config.ProjectedResourceRecord) string {
+	switch {
+	case by == TopSpendersByService:
+		if record.ResourceType == "" {
+			return orgProviderKey(record)
+		}
+		return chargebackService(record.ResourceType)
+	case strings.HasPrefix(by, TopSpendersTagPrefix):
+		return orgTagValue(record.Tags, strings.TrimPrefix(by, TopSpendersTagPrefix), UntaggedGroupKey)
+	default:
+		return id
+	}
+}
@@ -0,0 +1,126 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func topSpendersSnapshots(from time.Time) map[string][]config.ProjectionSnapshot {
+	return map[string][]config.ProjectionSnapshot{
+		"prod": {
+			// In effect for the whole previous period.
+			{
+				RecordedAt: from.AddDate(0, 0, -60),
+				Resources: map[string]config.ProjectedResourceRecord{
+					"web": {
+						ResourceType: "aws:ec2/instance:Instance", Monthly: 100, Currency: "USD",
+						Tags: map[string]string{"team": "web"},
+					},
+					"bucket": {ResourceType: "aws:s3/bucket:Bucket", Monthly: 10, Currency: "USD"},
+				},
+			},
+			// Web resized and a database added at the start of the period.
+			{
+				RecordedAt: from,
+				Resources: map[string]config.ProjectedResourceRecord{
+					"web": {
+						ResourceType: "aws:ec2/instance:Instance", Monthly: 150, Currency: "USD",
+						Tags: map[string]string{"Team": "web"},
+					},
+					"bucket": {ResourceType: "aws:s3/bucket:Bucket", Monthly: 10, Currency: "USD"},
+					"db": {
+						ResourceType: "aws:rds/instance:Instance", Monthly: 80, Currency: "USD",
+						Tags: map[string]string{"team": "data"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBuildTopSpenders_ByResource(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+
+	report := BuildTopSpenders(TopSpendersInput{
+		By: TopSpendersByResource, From: from, To: to, Snapshots: topSpendersSnapshots(from),
+	})
+
+	assert.Equal(t, from.AddDate(0, 0, -30), report.PreviousFrom)
+	assert.Equal(t, "USD", report.Currency)
+	assert.InDelta(t, 240.0, report.TotalMonthly, 0.001)
+	assert.InDelta(t, 110.0, report.PreviousTotalMonthly, 0.001)
+
+	require.Len(t, report.Spenders, 3)
+	assert.Equal(t, "web", report.Spenders[0].Key, "largest cost first")
+	assert.InDelta(t, 50.0, report.Spenders[0].Change, 0.001)
+	assert.InDelta(t, 50.0, report.Spenders[0].ChangePercent(), 0.001)
+	assert.Equal(t, "db", report.Spenders[1].Key)
+	assert.True(t, report.Spenders[1].New)
+	assert.InDelta(t, 0.0, report.Spenders[1].ChangePercent(), 0.001)
+	assert.Equal(t, "bucket", report.Spenders[2].Key)
+	assert.InDelta(t, 0.0, report.Spenders[2].Change, 0.001)
+	assert.Equal(t, 1, report.Spenders[2].Resources)
+	assert.Equal(t, 1, report.Spenders[2].Stacks)
+}
+
+func TestBuildTopSpenders_Groupings(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 30)
+	snapshots := topSpendersSnapshots(from)
+
+	byService := BuildTopSpenders(TopSpendersInput{By: TopSpendersByService, From: from, To: to, Snapshots: snapshots})
+	keys := make([]string, 0, len(byService.Spenders))
+	for _, s := range byService.Spenders {
+		keys = append(keys, s.Key)
+	}
+	assert.Equal(t, []string{"aws:ec2", "aws:rds", "aws:s3"}, keys)
+
+	byTeam := BuildTopSpenders(TopSpendersInput{By: "tag:team", From: from, To: to, Snapshots: snapshots})
+	require.Len(t, byTeam.Spenders, 3)
+	assert.Equal(t, "web", byTeam.Spenders[0].Key, "tag keys match case-insensitively")
+	assert.InDelta(t, 100.0, byTeam.Spenders[0].PreviousMonthly, 0.001)
+	assert.Equal(t, "data", byTeam.Spenders[1].Key)
+	assert.Equal(t, UntaggedGroupKey, byTeam.Spenders[2].Key)
+}
+
+func TestBuildTopSpenders_PartialPeriod(t *testing.T) {
+	from := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, 10)
+
+	report := BuildTopSpenders(TopSpendersInput{
+		By: TopSpendersByResource, From: from, To: to,
+		Snapshots: map[string][]config.ProjectionSnapshot{
+			"dev": {{
+				RecordedAt: from.AddDate(0, 0, 5),
+				Resources:  map[string]config.ProjectedResourceRecord{"web": {Monthly: 40, Currency: "EUR"}},
+			}},
+		},
+	})
+
+	assert.Equal(t, "EUR", report.Currency)
+	require.Len(t, report.Spenders, 1)
+	assert.InDelta(t, 20.0, report.Spenders[0].Monthly, 0.001, "in effect for half of the period")
+	assert.InDelta(t, 0.0, report.Spenders[0].PreviousMonthly, 0.001)
+}
+
+func TestBuildTopSpenders_EmptyPeriod(t *testing.T) {
+	at := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	report := BuildTopSpenders(TopSpendersInput{By: TopSpendersByResource, From: at, To: at})
+	assert.Empty(t, report.Spenders)
+	assert.Equal(t, defaultCurrency, report.Currency)
+}
+
+func TestValidateTopSpendersBy(t *testing.T) {
+	for _, by := range []string{"resource", "service", "tag:team"} {
+		require.NoError(t, ValidateTopSpendersBy(by), by)
+	}
+	for _, by := range []string{"", "provider", "tag:"} {
+		require.Error(t, ValidateTopSpendersBy(by), by)
+	}
+}
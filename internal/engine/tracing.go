@@ -0,0 +1,46 @@
+package engine
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+// Span names for engine operations and the per-resource work inside them.
+const (
+	spanProjectedCost     = "engine.ProjectedCost"
+	spanActualCost        = "engine.ActualCost"
+	spanRecommendations   = "engine.Recommendations"
+	spanProjectedResource = "engine.ProjectedCost.resource"
+	spanActualResource    = "engine.ActualCost.resource"
+)
+
+// startOperationSpan starts the span covering one engine call over resources.
+func startOperationSpan(ctx context.Context, name string, resources int) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(attribute.Int(tracing.AttrResources, resources)))
+}
+
+// startResourceSpan starts the span covering the pricing of one resource.
+// Plugin RPC spans and cache events for the resource nest under it.
+func startResourceSpan(ctx context.Context, name string, resource ResourceDescriptor) (context.Context, trace.Span) {
+	return tracing.Tracer().Start(ctx, name, trace.WithAttributes(
+		attribute.String(tracing.AttrResourceID, resource.ID),
+		attribute.String(tracing.AttrResourceType, resource.Type),
+	))
+}
+
+// endResourceSpan records the plugin errors of a resource and ends its span.
+// The span is marked failed only when no cost result was produced.
+func endResourceSpan(span trace.Span, results int, errs []ErrorDetail) {
+	for _, detail := range errs {
+		span.RecordError(detail.Error, trace.WithAttributes(attribute.String(tracing.AttrPlugin, detail.PluginName)))
+	}
+	if results == 0 && len(errs) > 0 {
+		span.SetStatus(codes.Error, errs[len(errs)-1].Error.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,57 @@
+package engine
+
+import (
+	"context"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/proto"
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+func TestStreamProjectedCostWithErrors_Spans(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+
+	client := proto.NewTracingClient("slow", slowFirstClient{count: 2})
+	eng := New([]*pluginhost.Client{{Name: "slow", API: client}}, nil)
+	resources := make([]ResourceDescriptor, 2)
+	for i := range resources {
+		resources[i] = ResourceDescriptor{ID: strconv.Itoa(i), Type: "aws:ec2/instance:Instance", Provider: "aws"}
+	}
+
+	_, err := eng.StreamProjectedCostWithErrors(context.Background(), resources, nil)
+	require.NoError(t, err)
+
+	byName := make(map[string][]sdktrace.ReadOnlySpan)
+	for _, span := range rec.Ended() {
+		byName[span.Name()] = append(byName[span.Name()], span)
+	}
+	require.Len(t, byName[spanProjectedCost], 1)
+	require.Len(t, byName[spanProjectedResource], 2)
+	require.Len(t, byName["plugin.GetProjectedCost"], 2)
+
+	operation := byName[spanProjectedCost][0].SpanContext().SpanID()
+	resourceSpans := make(map[string]bool)
+	for _, span := range byName[spanProjectedResource] {
+		assert.Equal(t, operation, span.Parent().SpanID())
+		resourceSpans[span.SpanContext().SpanID().String()] = true
+		for _, kv := range span.Attributes() {
+			if kv.Key == tracing.AttrResourceType {
+				assert.Equal(t, "aws:ec2/instance:Instance", kv.Value.AsString())
+			}
+		}
+	}
+	for _, span := range byName["plugin.GetProjectedCost"] {
+		assert.True(t, resourceSpans[span.Parent().SpanID().String()], "plugin RPCs nest under their resource")
+	}
+}
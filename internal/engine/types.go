@@ -33,6 +33,15 @@ type ResourceDescriptor struct {
 	ID         string                 `json:"id"`
 	Provider   string                 `json:"provider"`
 	Properties map[string]interface{} `json:"properties"`
+	// SourcePosition is where the resource is declared in the Pulumi program,
+	// as recorded by Pulumi (e.g. "project:///index.ts#12,5"). Empty if unknown.
+	SourcePosition string `json:"sourcePosition,omitempty"`
+	// Components are the URNs of the Pulumi component resources that enclose
+	// the resource, outermost first. Empty for resources outside components.
+	Components []string `json:"components,omitempty"`
+	// Dependencies are the IDs (URNs) of the resources the resource depends
+	// on, as recorded in the Pulumi dependency graph.
+	Dependencies []string `json:"dependencies,omitempty"`
 }
 
 // Validate checks that the ResourceDescriptor has valid fields and returns an error if validation fails.
@@ -132,6 +141,10 @@ type SustainabilityMetric struct {
 //		Currency:        "USD",
 //	}
 type Recommendation struct {
+	// ID is the plugin-assigned recommendation identifier, used by the
+	// dismiss, snooze, and apply subcommands. Empty if the plugin supplied none.
+	ID string `json:"id,omitempty"`
+
 	// ResourceID identifies the resource this recommendation applies to.
 	ResourceID string `json:"resourceId,omitempty"`
 
@@ -150,6 +163,15 @@ type Recommendation struct {
 	// Empty if EstimatedSavings is zero.
 	Currency string `json:"currency,omitempty"`
 
+	// CurrentCost and ProjectedCost are the resource's cost before and after
+	// implementing the recommendation, as reported by the plugin. Zero when
+	// the plugin supplies no impact breakdown.
+	CurrentCost   float64 `json:"currentCost,omitempty"`
+	ProjectedCost float64 `json:"projectedCost,omitempty"`
+
+	// SavingsPercentage is the plugin-reported savings as a percentage of CurrentCost.
+	SavingsPercentage float64 `json:"savingsPercentage,omitempty"`
+
 	// Status indicates the lifecycle state of this recommendation.
 	// Empty or "Active" for active recommendations, "Dismissed" or "Snoozed"
 	// for dismissed/snoozed recommendations shown via --include-dismissed.
@@ -159,6 +181,26 @@ type Recommendation struct {
 	// prerequisites or risks for implementing this recommendation
 	// (e.g., "Ensure application compatibility with ARM64 architecture").
 	Reasoning []string `json:"reasoning,omitempty"`
+
+	// Source identifies the data source (e.g., "aws", "kubecost").
+	Source string `json:"source,omitempty"`
+
+	// Sources lists every data source that reported this recommendation when
+	// duplicates from several plugins were merged (see DeduplicateRecommendations).
+	Sources []string `json:"sources,omitempty"`
+
+	// SourceSavings holds each source's savings estimate for a merged
+	// recommendation, keyed by source name.
+	SourceSavings map[string]float64 `json:"sourceSavings,omitempty"`
+
+	// CurrentSKU and RecommendedSKU carry the rightsize action detail
+	// (instance type or SKU) when the plugin supplies one.
+	CurrentSKU     string `json:"currentSku,omitempty"`
+	RecommendedSKU string `json:"recommendedSku,omitempty"`
+
+	// Metadata carries provider-specific details from the plugin, including
+	// optional remediation hints (see RemediationMetadataScript).
+	Metadata map[string]string `json:"metadata,omitempty"`
 }
 
 // RecommendationStatus represents the lifecycle state of a recommendation.
@@ -242,6 +284,15 @@ type CostResult struct {
 	// MEDIUM: Runtime-based estimate from Pulumi timestamps
 	// LOW: Imported resource (timestamp may be inaccurate)
 	Confidence Confidence `json:"confidence,omitempty"`
+
+	// Accuracy describes how the cost was derived: exact, estimated,
+	// heuristic, or unknown. The engine sets it on every result it returns.
+	Accuracy Accuracy `json:"accuracy,omitempty"`
+
+	// Stack names the stack or plan the resource came from when several are
+	// costed together (cost projected with more than one --pulumi-json).
+	// Empty for a single plan.
+	Stack string `json:"stack,omitempty"`
 }
 
 // ErrorDetail captures information about a failed resource cost calculation.
@@ -257,6 +308,9 @@ type ErrorDetail struct {
 type CostResultWithErrors struct {
 	Results []CostResult
 	Errors  []ErrorDetail
+	// Omitted holds the placeholder results of failed resources that
+	// ApplyErrorPolicy left out of Results under ErrorPolicyOmit.
+	Omitted []CostResult
 }
 
 // HasErrors returns true if any errors were encountered during cost calculation.
@@ -285,6 +339,9 @@ func (c *CostResultWithErrors) ErrorSummary() string {
 			fmt.Sprintf("  - %s (%s): %v\n", err.ResourceType, err.ResourceID, err.Error),
 		)
 	}
+	if len(c.Omitted) > 0 {
+		summary.WriteString(fmt.Sprintf("%d failed resource(s) omitted from totals\n", len(c.Omitted)))
+	}
 
 	return summary.String()
 }
@@ -501,6 +558,27 @@ type CostSummary struct {
 	ByService    map[string]float64 `json:"byService"`
 	ByAdapter    map[string]float64 `json:"byAdapter"`
 	Resources    []CostResult       `json:"resources"`
+
+	// ByStack subtotals monthly cost per CostResult.Stack. Nil unless the
+	// results carry stacks.
+	ByStack map[string]float64 `json:"byStack,omitempty"`
+}
+
+// NDJSONSummaryType is the type of the summary record that ends cost NDJSON
+// output.
+const NDJSONSummaryType = "summary"
+
+// NDJSONSummary is the last record of cost NDJSON output, written once every
+// result has been, so consumers reading results as they arrive know the
+// output is complete.
+type NDJSONSummary struct {
+	Type         string  `json:"type"`
+	Resources    int     `json:"resources"`
+	TotalMonthly float64 `json:"totalMonthly"`
+	TotalHourly  float64 `json:"totalHourly"`
+	TotalCost    float64 `json:"totalCost"` // Actual cost over the period
+	Currency     string  `json:"currency"`
+	Errors       int     `json:"errors"` // Plugin calls that failed
 }
 
 // AggregatedResults contains cost results with summary and aggregation data.
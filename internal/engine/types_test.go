@@ -860,3 +860,23 @@ func TestErrorCodeConstants(t *testing.T) {
 	assert.Equal(t, "TIMEOUT_ERROR", ErrCodeTimeoutError)
 	assert.Equal(t, "NO_COST_DATA", ErrCodeNoCostData)
 }
+
+// TestConvertProtoRecommendationImpact verifies that the impact breakdown is
+// carried over for the detail view.
+func TestConvertProtoRecommendationImpact(t *testing.T) {
+	engineRec := convertProtoRecommendation(&proto.Recommendation{
+		ResourceID: "web",
+		ActionType: "RIGHTSIZE",
+		Impact: &proto.RecommendationImpact{
+			EstimatedSavings:  30,
+			Currency:          "USD",
+			CurrentCost:       120,
+			ProjectedCost:     90,
+			SavingsPercentage: 25,
+		},
+	})
+
+	assert.InDelta(t, 120.0, engineRec.CurrentCost, 0.001)
+	assert.InDelta(t, 90.0, engineRec.ProjectedCost, 0.001)
+	assert.InDelta(t, 25.0, engineRec.SavingsPercentage, 0.001)
+}
@@ -0,0 +1,50 @@
+package engine
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// ApplyUsageProfile scales the hourly-billed cost of each result to the
+// hours per month of profile, in place, and notes the profile on the scaled
+// results. Results are hourly-billed when they have an hourly rate and a
+// compute resource type. Breakdown components of other dimensions, such as
+// an instance's storage, accrue while the resource is stopped and are not
+// scaled. Results with an error are left unchanged.
+func ApplyUsageProfile(results []CostResult, name string, profile config.UsageProfile) {
+	hours := profile.HoursPerMonth()
+	factor := hours / hoursPerMonth
+	for i := range results {
+		result := &results[i]
+		if result.Error != nil || result.Hourly <= 0 || resourceTypeDimension(result.ResourceType) != DimensionCompute {
+			continue
+		}
+
+		fixed := 0.0
+		breakdown := make(map[string]float64, len(result.Breakdown))
+		for key, value := range result.Breakdown {
+			// Keys that name no dimension may be rates and are kept as is.
+			dimension := ClassifyPricingDimension(key)
+			if dimension == DimensionCompute {
+				breakdown[key] = value * factor
+				continue
+			}
+			breakdown[key] = value
+			if dimension != "" {
+				fixed += value
+			}
+		}
+		if len(breakdown) > 0 {
+			result.Breakdown = breakdown
+		}
+		result.Monthly = fixed + math.Max(result.Monthly-fixed, 0)*factor
+
+		note := fmt.Sprintf("Usage profile %s: %.0f hours/month", name, hours)
+		if result.Notes != "" {
+			note = result.Notes + "; " + note
+		}
+		result.Notes = note
+	}
+}
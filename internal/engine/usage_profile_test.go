@@ -0,0 +1,39 @@
+package engine
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestApplyUsageProfile(t *testing.T) {
+	results := []CostResult{
+		{
+			ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 100, Hourly: 100.0 / 730,
+			Breakdown: map[string]float64{"compute_hours": 80, "ebs_storage": 20, "unit_price": 0.1},
+			Notes:     "on-demand",
+		},
+		{ResourceID: "fn", ResourceType: "aws:lambda/function:Function", Monthly: 73, Hourly: 0.1},
+		{ResourceID: "assets", ResourceType: "aws:s3/bucket:Bucket", Monthly: 20, Hourly: 20.0 / 730},
+		{ResourceID: "api", ResourceType: "aws:ec2/instance:Instance", Monthly: 50},
+		{ResourceID: "db", ResourceType: "aws:ec2/instance:Instance", Hourly: 1, Error: &StructuredError{}},
+	}
+
+	// 12 hours a day runs half of the month.
+	ApplyUsageProfile(results, "dev", config.UsageProfile{HoursPerDay: 12})
+
+	assert.InDelta(t, 60.0, results[0].Monthly, 0.001, "storage accrues while stopped")
+	assert.InDelta(t, 40.0, results[0].Breakdown["compute_hours"], 0.001)
+	assert.InDelta(t, 20.0, results[0].Breakdown["ebs_storage"], 0.001)
+	assert.InDelta(t, 0.1, results[0].Breakdown["unit_price"], 0.001, "rates are not scaled")
+	assert.InDelta(t, 100.0/730, results[0].Hourly, 0.001, "the hourly rate is unchanged")
+	assert.Equal(t, "on-demand; Usage profile dev: 365 hours/month", results[0].Notes)
+
+	assert.InDelta(t, 36.5, results[1].Monthly, 0.001)
+	assert.InDelta(t, 20.0, results[2].Monthly, 0.001, "storage resources are not hourly-billed")
+	assert.Empty(t, results[2].Notes)
+	assert.InDelta(t, 50.0, results[3].Monthly, 0.001, "results without an hourly rate are kept")
+	assert.Empty(t, results[4].Notes)
+}
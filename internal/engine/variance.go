@@ -0,0 +1,140 @@
+package engine
+
+import (
+	"sort"
+	"time"
+)
+
+// DefaultVarianceThreshold is the default percentage by which actual spend
+// must exceed the projection for a resource to be flagged.
+const DefaultVarianceThreshold = 10.0
+
+// ResourceVariance compares one resource's projected and actual cost over a period.
+type ResourceVariance struct {
+	ResourceID   string  `json:"resourceId"`
+	ResourceType string  `json:"resourceType"`
+	Projected    float64 `json:"projected"`
+	Actual       float64 `json:"actual"`
+	Variance     float64 `json:"variance"`
+	// VariancePercent is Variance relative to Projected. Zero when there is no projection.
+	VariancePercent float64 `json:"variancePercent"`
+	// Exceeded is true when actual spend exceeds the projection by more than the threshold,
+	// or when a resource with no projected cost incurred spend.
+	Exceeded bool `json:"exceeded"`
+	// NoActual is true when no actual cost data was returned for the resource.
+	NoActual bool `json:"noActual,omitempty"`
+}
+
+// VarianceReport is the projected vs actual comparison for a stack over a period.
+type VarianceReport struct {
+	Stack       string    `json:"stack"`
+	Period      string    `json:"period"`
+	From        time.Time `json:"from"`
+	To          time.Time `json:"to"`
+	ProjectedAt time.Time `json:"projectedAt"`
+	Currency    string    `json:"currency"`
+	Threshold   float64   `json:"threshold"`
+
+	TotalProjected       float64 `json:"totalProjected"`
+	TotalActual          float64 `json:"totalActual"`
+	TotalVariancePercent float64 `json:"totalVariancePercent"`
+	ExceededCount        int     `json:"exceededCount"`
+
+	Resources []ResourceVariance `json:"resources"`
+}
+
+// VarianceInput carries the data needed to build a VarianceReport.
+type VarianceInput struct {
+	Stack       string
+	Period      string
+	From        time.Time
+	To          time.Time
+	ProjectedAt time.Time
+	Threshold   float64
+	// MonthHours is the number of hours in the full period month; projected
+	// monthly costs are prorated by (To-From)/MonthHours for partial periods.
+	MonthHours float64
+	// Projected holds projected monthly costs (CostResult.Monthly) per resource.
+	Projected []CostResult
+	// Actual holds actual costs (CostResult.TotalCost) for the period.
+	Actual []CostResult
+}
+
+// CalculateVariance joins projected and actual costs by resource ID and
+// computes per-resource variance. Projected monthly costs are prorated to the
+// length of the period. Resources are ordered by variance percentage, largest
+// overrun first. Actual results that carry errors are treated as missing.
+func CalculateVariance(input VarianceInput) *VarianceReport {
+	report := &VarianceReport{
+		Stack:       input.Stack,
+		Period:      input.Period,
+		From:        input.From,
+		To:          input.To,
+		ProjectedAt: input.ProjectedAt,
+		Threshold:   input.Threshold,
+		Currency:    defaultCurrency,
+	}
+
+	proration := 1.0
+	if input.MonthHours > 0 {
+		proration = input.To.Sub(input.From).Hours() / input.MonthHours
+		if proration > 1 {
+			proration = 1
+		}
+	}
+
+	actualByID := make(map[string]float64, len(input.Actual))
+	for _, r := range input.Actual {
+		if r.Error != nil {
+			continue
+		}
+		actualByID[r.ResourceID] += r.TotalCost
+	}
+
+	for _, p := range input.Projected {
+		if p.Currency != "" && report.Currency == defaultCurrency {
+			report.Currency = p.Currency
+		}
+
+		actual, hasActual := actualByID[p.ResourceID]
+		v := ResourceVariance{
+			ResourceID:   p.ResourceID,
+			ResourceType: p.ResourceType,
+			Projected:    p.Monthly * proration,
+			Actual:       actual,
+			NoActual:     !hasActual,
+		}
+		v.Variance = v.Actual - v.Projected
+		if v.Projected > 0 {
+			v.VariancePercent = v.Variance / v.Projected * driftPercentMultiplier
+			v.Exceeded = v.VariancePercent > input.Threshold
+		} else {
+			v.Exceeded = v.Actual > 0
+		}
+
+		report.TotalProjected += v.Projected
+		report.TotalActual += v.Actual
+		if v.Exceeded {
+			report.ExceededCount++
+		}
+		report.Resources = append(report.Resources, v)
+	}
+
+	if report.TotalProjected > 0 {
+		report.TotalVariancePercent = (report.TotalActual - report.TotalProjected) /
+			report.TotalProjected * driftPercentMultiplier
+	}
+
+	sort.SliceStable(report.Resources, func(i, j int) bool {
+		a, b := report.Resources[i], report.Resources[j]
+		if a.Exceeded != b.Exceeded {
+			return a.Exceeded
+		}
+		if a.VariancePercent != b.VariancePercent {
+			return a.VariancePercent > b.VariancePercent
+		}
+		return a.ResourceID < b.ResourceID
+	})
+
+	return report
+}
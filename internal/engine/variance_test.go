@@ -0,0 +1,77 @@
+package engine
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateVariance(t *testing.T) {
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 1, 0)
+
+	report := CalculateVariance(VarianceInput{
+		Stack:      "prod",
+		Period:     "2026-01",
+		From:       from,
+		To:         to,
+		Threshold:  DefaultVarianceThreshold,
+		MonthHours: to.Sub(from).Hours(),
+		Projected: []CostResult{
+			{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 100, Currency: "USD"},
+			{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Monthly: 200, Currency: "USD"},
+			{ResourceID: "bucket", ResourceType: "aws:s3/bucket:Bucket", Monthly: 0, Currency: "USD"},
+			{ResourceID: "cache", ResourceType: "aws:elasticache/cluster:Cluster", Monthly: 50, Currency: "USD"},
+		},
+		Actual: []CostResult{
+			{ResourceID: "web", TotalCost: 125},
+			{ResourceID: "db", TotalCost: 205},
+			{ResourceID: "bucket", TotalCost: 3},
+			{ResourceID: "cache", TotalCost: 80, Error: &StructuredError{Code: "PLUGIN_ERROR"}},
+		},
+	})
+
+	require.Len(t, report.Resources, 4)
+	assert.Equal(t, "USD", report.Currency)
+	assert.Equal(t, 2, report.ExceededCount)
+
+	web := report.Resources[0]
+	assert.Equal(t, "web", web.ResourceID, "largest overrun first")
+	assert.True(t, web.Exceeded)
+	assert.InDelta(t, 25.0, web.Variance, 0.001)
+	assert.InDelta(t, 25.0, web.VariancePercent, 0.001)
+
+	assert.Equal(t, "bucket", report.Resources[1].ResourceID)
+	assert.True(t, report.Resources[1].Exceeded, "spend without a projection is flagged")
+
+	db := report.Resources[2]
+	assert.False(t, db.Exceeded, "2.5% is within the threshold")
+
+	cache := report.Resources[3]
+	assert.True(t, cache.NoActual, "errored actuals count as missing")
+	assert.InDelta(t, -100.0, cache.VariancePercent, 0.001)
+
+	assert.InDelta(t, 350.0, report.TotalProjected, 0.001)
+	assert.InDelta(t, 333.0, report.TotalActual, 0.001)
+}
+
+func TestCalculateVariance_ProratesPartialPeriod(t *testing.T) {
+	from := time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)
+	monthHours := from.AddDate(0, 1, 0).Sub(from).Hours()
+
+	report := CalculateVariance(VarianceInput{
+		From:       from,
+		To:         from.AddDate(0, 0, 15),
+		Threshold:  DefaultVarianceThreshold,
+		MonthHours: monthHours,
+		Projected:  []CostResult{{ResourceID: "web", Monthly: 300}},
+		Actual:     []CostResult{{ResourceID: "web", TotalCost: 150}},
+	})
+
+	require.Len(t, report.Resources, 1)
+	assert.InDelta(t, 150.0, report.Resources[0].Projected, 0.001)
+	assert.InDelta(t, 0.0, report.Resources[0].VariancePercent, 0.001)
+	assert.False(t, report.Resources[0].Exceeded)
+}
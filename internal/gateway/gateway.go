@@ -0,0 +1,464 @@
+// Package gateway re-exposes the installed plugins as a single
+// CostSourceService, so tooling that already speaks the finfocus-spec protocol
+// can use finfocus as a multiplexing proxy in front of several plugins.
+//
+// Requests for one resource are routed the way the engine routes them: to the
+// plugins the routing configuration selects, in priority order, falling back
+// to the next plugin when one fails. Requests that span plugins, such as
+// recommendations and budgets, are fanned out and their responses merged.
+// Messages are forwarded unchanged, so plugins see exactly what the caller
+// sent.
+package gateway
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// ServiceName is the name the gateway reports from Name and GetPluginInfo.
+const ServiceName = "finfocus"
+
+// featureProjectedCosts is the routing feature of the single-resource RPCs.
+const featureProjectedCosts = "ProjectedCosts"
+
+// maxRecommendationPages bounds how many pages are read from one plugin.
+const maxRecommendationPages = 100
+
+// Backend is a plugin the gateway forwards requests to.
+type Backend struct {
+	Name   string
+	Client pbc.CostSourceServiceClient
+}
+
+// BackendsFromClients builds a backend for every plugin client with a live
+// gRPC connection. Clients replaying recorded responses have none and are
+// skipped.
+func BackendsFromClients(clients []*pluginhost.Client) []Backend {
+	backends := make([]Backend, 0, len(clients))
+	for _, c := range clients {
+		if c == nil || c.Conn == nil {
+			continue
+		}
+		backends = append(backends, Backend{Name: c.Name, Client: pbc.NewCostSourceServiceClient(c.Conn)})
+	}
+	return backends
+}
+
+// Server implements pbc.CostSourceServiceServer on top of several plugins.
+type Server struct {
+	pbc.UnimplementedCostSourceServiceServer
+
+	backends []Backend
+	router   engine.Router
+	version  string
+}
+
+// NewServer creates a gateway over backends. When router is nil every
+// resource is offered to every backend in order.
+func NewServer(backends []Backend, router engine.Router, version string) *Server {
+	return &Server{backends: backends, router: router, version: version}
+}
+
+// Name returns the gateway's name.
+func (s *Server) Name(context.Context, *pbc.NameRequest) (*pbc.NameResponse, error) {
+	return &pbc.NameResponse{Name: ServiceName}, nil
+}
+
+// GetPluginInfo describes the gateway. Providers and capabilities are the
+// union of those of the backends that answer.
+func (s *Server) GetPluginInfo(ctx context.Context, _ *pbc.GetPluginInfoRequest) (*pbc.GetPluginInfoResponse, error) {
+	providers := map[string]bool{}
+	capabilities := map[pbc.PluginCapability]bool{}
+	for _, b := range s.backends {
+		info, err := b.Client.GetPluginInfo(ctx, &pbc.GetPluginInfoRequest{})
+		if err != nil {
+			s.logFailure(ctx, b, "GetPluginInfo", err)
+			continue
+		}
+		for _, p := range info.GetProviders() {
+			providers[p] = true
+		}
+		for _, c := range info.GetCapabilities() {
+			capabilities[c] = true
+		}
+	}
+
+	resp := &pbc.GetPluginInfoResponse{
+		Name:        ServiceName,
+		Version:     s.version,
+		SpecVersion: pluginsdk.SpecVersion,
+		Metadata:    map[string]string{"backends": fmt.Sprint(len(s.backends))},
+	}
+	for p := range providers {
+		resp.Providers = append(resp.Providers, p)
+	}
+	sort.Strings(resp.Providers)
+	for c := range capabilities {
+		resp.Capabilities = append(resp.Capabilities, c)
+	}
+	sort.Slice(resp.Capabilities, func(i, j int) bool { return resp.Capabilities[i] < resp.Capabilities[j] })
+	return resp, nil
+}
+
+// Supports reports whether any plugin routed to the resource supports it.
+func (s *Server) Supports(ctx context.Context, req *pbc.SupportsRequest) (*pbc.SupportsResponse, error) {
+	candidates := s.candidates(ctx, req.GetResource(), featureProjectedCosts)
+	var last *pbc.SupportsResponse
+	for _, c := range candidates {
+		resp, err := c.backend.Client.Supports(ctx, req)
+		if err != nil {
+			s.logFailure(ctx, c.backend, "Supports", err)
+			continue
+		}
+		if resp.GetSupported() {
+			return resp, nil
+		}
+		last = resp
+	}
+	if last != nil {
+		return last, nil
+	}
+	return &pbc.SupportsResponse{
+		Supported: false,
+		Reason:    fmt.Sprintf("no plugin supports %s", req.GetResource().GetResourceType()),
+	}, nil
+}
+
+// GetProjectedCost answers with the first routed plugin that prices the resource.
+func (s *Server) GetProjectedCost(
+	ctx context.Context,
+	req *pbc.GetProjectedCostRequest,
+) (*pbc.GetProjectedCostResponse, error) {
+	return firstSuccess(ctx, s, "GetProjectedCost", s.candidates(ctx, req.GetResource(), featureProjectedCosts),
+		func(ctx context.Context, client pbc.CostSourceServiceClient) (*pbc.GetProjectedCostResponse, error) {
+			return client.GetProjectedCost(ctx, req)
+		})
+}
+
+// GetPricingSpec answers with the first routed plugin that knows the resource's pricing.
+func (s *Server) GetPricingSpec(
+	ctx context.Context,
+	req *pbc.GetPricingSpecRequest,
+) (*pbc.GetPricingSpecResponse, error) {
+	return firstSuccess(ctx, s, "GetPricingSpec", s.candidates(ctx, req.GetResource(), featureProjectedCosts),
+		func(ctx context.Context, client pbc.CostSourceServiceClient) (*pbc.GetPricingSpecResponse, error) {
+			return client.GetPricingSpec(ctx, req)
+		})
+}
+
+// EstimateCost answers with the first routed plugin that estimates the resource type.
+func (s *Server) EstimateCost(ctx context.Context, req *pbc.EstimateCostRequest) (*pbc.EstimateCostResponse, error) {
+	resource := &pbc.ResourceDescriptor{ResourceType: req.GetResourceType()}
+	return firstSuccess(ctx, s, "EstimateCost", s.candidates(ctx, resource, featureProjectedCosts),
+		func(ctx context.Context, client pbc.CostSourceServiceClient) (*pbc.EstimateCostResponse, error) {
+			return client.EstimateCost(ctx, req)
+		})
+}
+
+// DryRun answers with the first routed plugin that can simulate the resource.
+func (s *Server) DryRun(ctx context.Context, req *pbc.DryRunRequest) (*pbc.DryRunResponse, error) {
+	return firstSuccess(ctx, s, "DryRun", s.candidates(ctx, req.GetResource(), featureProjectedCosts),
+		func(ctx context.Context, client pbc.CostSourceServiceClient) (*pbc.DryRunResponse, error) {
+			return client.DryRun(ctx, req)
+		})
+}
+
+// GetActualCost answers with the first plugin that reports costs for the
+// resource. Actual cost requests carry no resource type to route on, so every
+// plugin is asked in order until one returns results.
+func (s *Server) GetActualCost(ctx context.Context, req *pbc.GetActualCostRequest) (*pbc.GetActualCostResponse, error) {
+	var (
+		empty   *pbc.GetActualCostResponse
+		lastErr error
+	)
+	for _, b := range s.backends {
+		resp, err := b.Client.GetActualCost(ctx, req)
+		if err != nil {
+			s.logFailure(ctx, b, "GetActualCost", err)
+			lastErr = err
+			continue
+		}
+		if len(resp.GetResults()) > 0 {
+			return resp, nil
+		}
+		empty = resp
+	}
+	if empty != nil {
+		return empty, nil
+	}
+	return nil, noBackendError(lastErr, "no plugin reports actual costs for %s", req.GetResourceId())
+}
+
+// GetRecommendations merges the recommendations of every plugin. Each plugin
+// is paged through in full, so the merged response is never paginated.
+func (s *Server) GetRecommendations(
+	ctx context.Context,
+	req *pbc.GetRecommendationsRequest,
+) (*pbc.GetRecommendationsResponse, error) {
+	merged := &pbc.GetRecommendationsResponse{}
+	answered := false
+	var lastErr error
+	for _, b := range s.backends {
+		recs, summary, err := recommendationsFrom(ctx, b.Client, req)
+		if err != nil {
+			s.logFailure(ctx, b, "GetRecommendations", err)
+			lastErr = err
+			continue
+		}
+		answered = true
+		merged.Recommendations = append(merged.Recommendations, recs...)
+		merged.Summary = mergeRecommendationSummary(merged.Summary, summary)
+	}
+	if !answered && lastErr != nil {
+		return nil, lastErr
+	}
+	return merged, nil
+}
+
+// DismissRecommendation forwards the dismissal to every plugin and succeeds if
+// the plugin that issued the recommendation accepts it.
+func (s *Server) DismissRecommendation(
+	ctx context.Context,
+	req *pbc.DismissRecommendationRequest,
+) (*pbc.DismissRecommendationResponse, error) {
+	var (
+		rejected *pbc.DismissRecommendationResponse
+		lastErr  error
+	)
+	for _, b := range s.backends {
+		resp, err := b.Client.DismissRecommendation(ctx, req)
+		if err != nil {
+			s.logFailure(ctx, b, "DismissRecommendation", err)
+			lastErr = err
+			continue
+		}
+		if resp.GetSuccess() {
+			return resp, nil
+		}
+		rejected = resp
+	}
+	if rejected != nil {
+		return rejected, nil
+	}
+	return nil, noBackendError(lastErr, "no plugin knows recommendation %s", req.GetRecommendationId())
+}
+
+// GetBudgets merges the budgets of every plugin.
+func (s *Server) GetBudgets(ctx context.Context, req *pbc.GetBudgetsRequest) (*pbc.GetBudgetsResponse, error) {
+	merged := &pbc.GetBudgetsResponse{}
+	answered := false
+	var lastErr error
+	for _, b := range s.backends {
+		resp, err := b.Client.GetBudgets(ctx, req)
+		if err != nil {
+			s.logFailure(ctx, b, "GetBudgets", err)
+			lastErr = err
+			continue
+		}
+		answered = true
+		merged.Budgets = append(merged.Budgets, resp.GetBudgets()...)
+		merged.Summary = mergeBudgetSummary(merged.Summary, resp.GetSummary())
+	}
+	if !answered && lastErr != nil {
+		return nil, lastErr
+	}
+	return merged, nil
+}
+
+// candidate is a backend selected for a request and whether the next
+// candidate may be tried when it fails.
+type candidate struct {
+	backend  Backend
+	fallback bool
+}
+
+// candidates returns the backends to try for resource, in order.
+func (s *Server) candidates(ctx context.Context, resource *pbc.ResourceDescriptor, feature string) []candidate {
+	if s.router == nil {
+		all := make([]candidate, 0, len(s.backends))
+		for _, b := range s.backends {
+			all = append(all, candidate{backend: b, fallback: true})
+		}
+		return all
+	}
+
+	byName := make(map[string]Backend, len(s.backends))
+	for _, b := range s.backends {
+		byName[b.Name] = b
+	}
+	var selected []candidate
+	for _, m := range s.router.SelectPlugins(ctx, engineResource(resource), feature) {
+		if m.Client == nil {
+			continue
+		}
+		if b, ok := byName[m.Client.Name]; ok {
+			selected = append(selected, candidate{backend: b, fallback: s.router.ShouldFallback(b.Name)})
+		}
+	}
+	return selected
+}
+
+// engineResource converts resource into the descriptor the router matches on.
+func engineResource(resource *pbc.ResourceDescriptor) engine.ResourceDescriptor {
+	properties := map[string]interface{}{}
+	if region := resource.GetRegion(); region != "" {
+		properties["region"] = region
+	}
+	if sku := resource.GetSku(); sku != "" {
+		properties["sku"] = sku
+	}
+	for k, v := range resource.GetTags() {
+		properties[k] = v
+	}
+	return engine.ResourceDescriptor{
+		Type:       resource.GetResourceType(),
+		ID:         resource.GetId(),
+		Provider:   resource.GetProvider(),
+		Properties: properties,
+	}
+}
+
+// firstSuccess calls the candidates in order and returns the first response.
+// It stops at a failing candidate whose fallback is disabled.
+func firstSuccess[Resp any](
+	ctx context.Context,
+	s *Server,
+	method string,
+	candidates []candidate,
+	call func(context.Context, pbc.CostSourceServiceClient) (Resp, error),
+) (Resp, error) {
+	var (
+		zero    Resp
+		lastErr error
+	)
+	for _, c := range candidates {
+		resp, err := call(ctx, c.backend.Client)
+		if err == nil {
+			return resp, nil
+		}
+		s.logFailure(ctx, c.backend, method, err)
+		lastErr = err
+		if !c.fallback {
+			break
+		}
+	}
+	return zero, noBackendError(lastErr, "no plugin is routed for %s", method)
+}
+
+// recommendationsFrom reads every page of recommendations from client.
+func recommendationsFrom(
+	ctx context.Context,
+	client pbc.CostSourceServiceClient,
+	req *pbc.GetRecommendationsRequest,
+) ([]*pbc.Recommendation, *pbc.RecommendationSummary, error) {
+	page := &pbc.GetRecommendationsRequest{
+		Filter:                    req.GetFilter(),
+		ProjectionPeriod:          req.GetProjectionPeriod(),
+		PageSize:                  req.GetPageSize(),
+		ExcludedRecommendationIds: req.GetExcludedRecommendationIds(),
+		TargetResources:           req.GetTargetResources(),
+		UsageProfile:              req.GetUsageProfile(),
+	}
+	var (
+		recs    []*pbc.Recommendation
+		summary *pbc.RecommendationSummary
+	)
+	for range maxRecommendationPages {
+		resp, err := client.GetRecommendations(ctx, page)
+		if err != nil {
+			return nil, nil, err
+		}
+		recs = append(recs, resp.GetRecommendations()...)
+		if summary == nil {
+			summary = resp.GetSummary()
+		}
+		if resp.GetNextPageToken() == "" {
+			break
+		}
+		page.PageToken = resp.GetNextPageToken()
+	}
+	return recs, summary, nil
+}
+
+// mergeRecommendationSummary adds b to a. Either may be nil.
+func mergeRecommendationSummary(a, b *pbc.RecommendationSummary) *pbc.RecommendationSummary {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		a = &pbc.RecommendationSummary{
+			Currency:         b.GetCurrency(),
+			ProjectionPeriod: b.GetProjectionPeriod(),
+		}
+	}
+	a.TotalRecommendations += b.GetTotalRecommendations()
+	a.TotalEstimatedSavings += b.GetTotalEstimatedSavings()
+	a.CountByCategory = addCounts(a.CountByCategory, b.GetCountByCategory())
+	a.SavingsByCategory = addCounts(a.SavingsByCategory, b.GetSavingsByCategory())
+	a.CountByActionType = addCounts(a.CountByActionType, b.GetCountByActionType())
+	a.SavingsByActionType = addCounts(a.SavingsByActionType, b.GetSavingsByActionType())
+	return a
+}
+
+// mergeBudgetSummary adds b to a. Either may be nil.
+func mergeBudgetSummary(a, b *pbc.BudgetSummary) *pbc.BudgetSummary {
+	if b == nil {
+		return a
+	}
+	if a == nil {
+		a = &pbc.BudgetSummary{}
+	}
+	a.TotalBudgets += b.GetTotalBudgets()
+	a.BudgetsOk += b.GetBudgetsOk()
+	a.BudgetsWarning += b.GetBudgetsWarning()
+	a.BudgetsExceeded += b.GetBudgetsExceeded()
+	a.BudgetsCritical += b.GetBudgetsCritical()
+	return a
+}
+
+// addCounts adds the values of b to those of a.
+func addCounts[V int32 | float64](a, b map[string]V) map[string]V {
+	if len(b) == 0 {
+		return a
+	}
+	if a == nil {
+		a = make(map[string]V, len(b))
+	}
+	for k, v := range b {
+		a[k] += v
+	}
+	return a
+}
+
+// noBackendError returns the last plugin error, which keeps its gRPC status,
+// or NotFound when no plugin was asked.
+func noBackendError(lastErr error, format string, args ...any) error {
+	if lastErr != nil {
+		return lastErr
+	}
+	return status.Error(codes.NotFound, fmt.Sprintf(format, args...))
+}
+
+// logFailure records a failed plugin call.
+func (s *Server) logFailure(ctx context.Context, b Backend, method string, err error) {
+	log := logging.FromContext(ctx)
+	event := log.Warn()
+	if st, ok := status.FromError(err); ok && st.Code() == codes.Unimplemented {
+		event = log.Debug()
+	} else if errors.Is(err, context.Canceled) {
+		event = log.Debug()
+	}
+	event.Ctx(ctx).Str("component", "gateway").Str("plugin", b.Name).Str("method", method).
+		Err(err).Msg("plugin call failed")
+}
@@ -0,0 +1,222 @@
+package gateway_test
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/gateway"
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+// fakePlugin answers the RPCs the tests exercise. A nil price makes
+// GetProjectedCost fail with Unavailable.
+type fakePlugin struct {
+	pbc.CostSourceServiceClient
+
+	price     *float64
+	providers []string
+	recs      [][]*pbc.Recommendation // pages
+	budgets   []*pbc.Budget
+	dismiss   bool
+	calls     int
+	lastToken string
+}
+
+func (p *fakePlugin) GetPluginInfo(
+	context.Context, *pbc.GetPluginInfoRequest, ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return &pbc.GetPluginInfoResponse{Providers: p.providers}, nil
+}
+
+func (p *fakePlugin) GetProjectedCost(
+	context.Context, *pbc.GetProjectedCostRequest, ...grpc.CallOption,
+) (*pbc.GetProjectedCostResponse, error) {
+	p.calls++
+	if p.price == nil {
+		return nil, status.Error(codes.Unavailable, "plugin down")
+	}
+	return &pbc.GetProjectedCostResponse{CostPerMonth: *p.price, Currency: "USD"}, nil
+}
+
+func (p *fakePlugin) GetRecommendations(
+	_ context.Context, req *pbc.GetRecommendationsRequest, _ ...grpc.CallOption,
+) (*pbc.GetRecommendationsResponse, error) {
+	page := p.calls
+	p.calls++
+	p.lastToken = req.GetPageToken()
+	resp := &pbc.GetRecommendationsResponse{Recommendations: p.recs[page]}
+	if page+1 < len(p.recs) {
+		resp.NextPageToken = "next"
+	}
+	return resp, nil
+}
+
+func (p *fakePlugin) GetBudgets(
+	context.Context, *pbc.GetBudgetsRequest, ...grpc.CallOption,
+) (*pbc.GetBudgetsResponse, error) {
+	return &pbc.GetBudgetsResponse{
+		Budgets: p.budgets,
+		Summary: &pbc.BudgetSummary{TotalBudgets: int32(len(p.budgets))},
+	}, nil
+}
+
+func (p *fakePlugin) DismissRecommendation(
+	_ context.Context, req *pbc.DismissRecommendationRequest, _ ...grpc.CallOption,
+) (*pbc.DismissRecommendationResponse, error) {
+	if !p.dismiss {
+		return nil, status.Error(codes.NotFound, "unknown recommendation")
+	}
+	return &pbc.DismissRecommendationResponse{Success: true, RecommendationId: req.GetRecommendationId()}, nil
+}
+
+// fakeRouter selects plugins by name in the given order.
+type fakeRouter struct {
+	selected []string
+	fallback map[string]bool
+}
+
+func (r *fakeRouter) SelectPlugins(context.Context, engine.ResourceDescriptor, string) []engine.PluginMatch {
+	matches := make([]engine.PluginMatch, 0, len(r.selected))
+	for _, name := range r.selected {
+		matches = append(matches, engine.PluginMatch{Client: &pluginhost.Client{Name: name}})
+	}
+	return matches
+}
+
+func (r *fakeRouter) ShouldFallback(name string) bool { return r.fallback[name] }
+
+func price(v float64) *float64 { return &v }
+
+func projectedRequest() *pbc.GetProjectedCostRequest {
+	return &pbc.GetProjectedCostRequest{
+		Resource: &pbc.ResourceDescriptor{Provider: "aws", ResourceType: "aws:ec2/instance:Instance"},
+	}
+}
+
+func TestGetProjectedCost_FallsBackInOrder(t *testing.T) {
+	down := &fakePlugin{}
+	up := &fakePlugin{price: price(42)}
+	srv := gateway.NewServer([]gateway.Backend{{Name: "a", Client: down}, {Name: "b", Client: up}}, nil, "v1")
+
+	resp, err := srv.GetProjectedCost(context.Background(), projectedRequest())
+	require.NoError(t, err)
+	assert.InDelta(t, 42.0, resp.GetCostPerMonth(), 0.001)
+	assert.Equal(t, 1, down.calls)
+}
+
+func TestGetProjectedCost_FollowsRouter(t *testing.T) {
+	a := &fakePlugin{price: price(1)}
+	b := &fakePlugin{price: price(2)}
+	c := &fakePlugin{price: price(3)}
+	backends := []gateway.Backend{{Name: "a", Client: a}, {Name: "b", Client: b}, {Name: "c", Client: c}}
+
+	t.Run("priority order", func(t *testing.T) {
+		srv := gateway.NewServer(backends, &fakeRouter{selected: []string{"c", "a"}}, "v1")
+		resp, err := srv.GetProjectedCost(context.Background(), projectedRequest())
+		require.NoError(t, err)
+		assert.InDelta(t, 3.0, resp.GetCostPerMonth(), 0.001)
+	})
+
+	t.Run("fallback disabled", func(t *testing.T) {
+		down := &fakePlugin{}
+		withDown := append([]gateway.Backend{{Name: "down", Client: down}}, backends...)
+		srv := gateway.NewServer(withDown, &fakeRouter{selected: []string{"down", "a"}}, "v1")
+		_, err := srv.GetProjectedCost(context.Background(), projectedRequest())
+		assert.Equal(t, codes.Unavailable, status.Code(err))
+	})
+
+	t.Run("no match", func(t *testing.T) {
+		srv := gateway.NewServer(backends, &fakeRouter{}, "v1")
+		_, err := srv.GetProjectedCost(context.Background(), projectedRequest())
+		assert.Equal(t, codes.NotFound, status.Code(err))
+	})
+}
+
+func TestGetRecommendations_MergesAllPages(t *testing.T) {
+	paged := &fakePlugin{recs: [][]*pbc.Recommendation{{{Id: "r1"}}, {{Id: "r2"}}}}
+	single := &fakePlugin{recs: [][]*pbc.Recommendation{{{Id: "r3"}}}}
+	srv := gateway.NewServer([]gateway.Backend{{Name: "a", Client: paged}, {Name: "b", Client: single}}, nil, "v1")
+
+	resp, err := srv.GetRecommendations(context.Background(), &pbc.GetRecommendationsRequest{PageToken: "caller"})
+	require.NoError(t, err)
+
+	var ids []string
+	for _, r := range resp.GetRecommendations() {
+		ids = append(ids, r.GetId())
+	}
+	assert.Equal(t, []string{"r1", "r2", "r3"}, ids)
+	assert.Empty(t, resp.GetNextPageToken())
+	assert.Equal(t, "next", paged.lastToken)
+	assert.Empty(t, single.lastToken, "the caller's page token is not forwarded")
+}
+
+func TestGetBudgets_Merges(t *testing.T) {
+	a := &fakePlugin{budgets: []*pbc.Budget{{Id: "a1"}, {Id: "a2"}}}
+	b := &fakePlugin{budgets: []*pbc.Budget{{Id: "b1"}}}
+	srv := gateway.NewServer([]gateway.Backend{{Name: "a", Client: a}, {Name: "b", Client: b}}, nil, "v1")
+
+	resp, err := srv.GetBudgets(context.Background(), &pbc.GetBudgetsRequest{})
+	require.NoError(t, err)
+	assert.Len(t, resp.GetBudgets(), 3)
+	assert.Equal(t, int32(3), resp.GetSummary().GetTotalBudgets())
+}
+
+func TestDismissRecommendation(t *testing.T) {
+	owner := &fakePlugin{dismiss: true}
+	other := &fakePlugin{}
+
+	srv := gateway.NewServer([]gateway.Backend{{Name: "other", Client: other}, {Name: "owner", Client: owner}}, nil, "v1")
+	resp, err := srv.DismissRecommendation(context.Background(), &pbc.DismissRecommendationRequest{RecommendationId: "r1"})
+	require.NoError(t, err)
+	assert.True(t, resp.GetSuccess())
+
+	srv = gateway.NewServer([]gateway.Backend{{Name: "other", Client: other}}, nil, "v1")
+	_, err = srv.DismissRecommendation(context.Background(), &pbc.DismissRecommendationRequest{RecommendationId: "r1"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestServer_OverGRPC(t *testing.T) {
+	a := &fakePlugin{providers: []string{"aws"}, price: price(5)}
+	b := &fakePlugin{providers: []string{"azure", "aws"}}
+	srv := gateway.NewServer([]gateway.Backend{{Name: "a", Client: a}, {Name: "b", Client: b}}, nil, "v1.2.3")
+
+	listener := bufconn.Listen(1 << 20)
+	grpcServer := grpc.NewServer()
+	pbc.RegisterCostSourceServiceServer(grpcServer, srv)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	client := pbc.NewCostSourceServiceClient(conn)
+	ctx := context.Background()
+
+	name, err := client.Name(ctx, &pbc.NameRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, gateway.ServiceName, name.GetName())
+
+	info, err := client.GetPluginInfo(ctx, &pbc.GetPluginInfoRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.3", info.GetVersion())
+	assert.Equal(t, []string{"aws", "azure"}, info.GetProviders())
+
+	cost, err := client.GetProjectedCost(ctx, projectedRequest())
+	require.NoError(t, err)
+	assert.InDelta(t, 5.0, cost.GetCostPerMonth(), 0.001)
+}
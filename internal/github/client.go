@@ -0,0 +1,232 @@
+// Package github integrates finfocus with GitHub: pull request comments through
+// the REST API and GitHub Actions job summaries.
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/restapi"
+)
+
+// GitHub API settings.
+const (
+	// DefaultAPIURL is the GitHub REST API endpoint.
+	DefaultAPIURL = "https://api.github.com"
+	// DefaultTimeout bounds a single GitHub API request.
+	DefaultTimeout = 30 * time.Second
+
+	// EnvToken is the environment variable holding the GitHub token.
+	EnvToken = "GITHUB_TOKEN" //nolint:gosec // Environment variable name, not a credential.
+	// EnvAltToken is the token variable used by the gh CLI, checked after EnvToken.
+	EnvAltToken = "GH_TOKEN" //nolint:gosec // Environment variable name, not a credential.
+	// EnvRepository names the owner/repo to post to (set by GitHub Actions).
+	EnvRepository = "GITHUB_REPOSITORY"
+	// EnvAPIURL overrides DefaultAPIURL (set by GitHub Actions on GitHub Enterprise Server).
+	EnvAPIURL = "GITHUB_API_URL"
+	// EnvStepSummary is the job summary file GitHub Actions renders on the run page.
+	EnvStepSummary = "GITHUB_STEP_SUMMARY"
+	// EnvActions is "true" in GitHub Actions.
+	EnvActions = "GITHUB_ACTIONS"
+
+	acceptHeader     = "application/vnd.github+json"
+	apiVersionHeader = "2022-11-28"
+	// commentsPerPage is the page size used when searching for an existing comment.
+	commentsPerPage = 100
+	// actionsBotLogin writes the comments of the GITHUB_TOKEN of GitHub Actions.
+	actionsBotLogin = "github-actions[bot]"
+)
+
+var (
+	// ErrNoToken indicates no GitHub token is configured.
+	ErrNoToken = errors.New("no GitHub token")
+
+	// ErrInvalidRepository indicates the repository is not in owner/repo form.
+	ErrInvalidRepository = errors.New("invalid GitHub repository")
+
+	// ErrRequestFailed indicates a GitHub API request failed.
+	ErrRequestFailed = errors.New("github request failed")
+)
+
+// Repository identifies a GitHub repository.
+type Repository struct {
+	Owner string
+	Name  string
+}
+
+// String returns the repository in owner/repo form.
+func (r Repository) String() string {
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRepository parses an owner/repo name.
+func ParseRepository(name string) (Repository, error) {
+	owner, repo, ok := strings.Cut(strings.TrimSpace(name), "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return Repository{}, fmt.Errorf("%w: %q (expected owner/repo)", ErrInvalidRepository, name)
+	}
+	return Repository{Owner: owner, Name: repo}, nil
+}
+
+// ResolveToken returns the GitHub token from GITHUB_TOKEN, falling back to
+// GH_TOKEN. It returns "" when neither is set.
+func ResolveToken() string {
+	if token := strings.TrimSpace(os.Getenv(EnvToken)); token != "" {
+		return token
+	}
+	return strings.TrimSpace(os.Getenv(EnvAltToken))
+}
+
+// IssueComment is a comment on an issue or pull request.
+type IssueComment struct {
+	ID      int64  `json:"id"`
+	Body    string `json:"body"`
+	HTMLURL string `json:"html_url"`
+	User    struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+// Client calls the GitHub REST API.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	token      string
+	user       restapi.User
+}
+
+// NewClient returns a Client that authenticates with token. An empty baseURL
+// selects DefaultAPIURL.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// UpsertIssueComment keeps a single "sticky" comment on pull request (or issue)
+// number: the first comment of the token's user whose body contains marker is
+// replaced with body, and a new comment is created when there is none. body should contain marker
+// so the next call finds it. It reports whether a comment was created. Errors
+// wrap ErrNoToken or ErrRequestFailed.
+func (c *Client) UpsertIssueComment(
+	ctx context.Context,
+	repo Repository,
+	number int,
+	marker, body string,
+) (*IssueComment, bool, error) {
+	if c.token == "" {
+		return nil, false, fmt.Errorf("%w; set %s or %s", ErrNoToken, EnvToken, EnvAltToken)
+	}
+	return restapi.UpsertComment[IssueComment](ctx, issueThread{c: c, repo: repo, number: number}, marker, body)
+}
+
+// issueThread is the comment thread of an issue or pull request.
+type issueThread struct {
+	c      *Client
+	repo   Repository
+	number int
+}
+
+func (t issueThread) FirstPage() string {
+	return fmt.Sprintf("%s?per_page=%d&page=1", t.commentsURL(), commentsPerPage)
+}
+
+func (t issueThread) Page(ctx context.Context, endpoint string) ([]IssueComment, string, error) {
+	var comments []IssueComment
+	if err := t.c.api().Do(ctx, http.MethodGet, endpoint, nil, &comments); err != nil {
+		return nil, "", err
+	}
+	return comments, restapi.NextPage(endpoint, len(comments), commentsPerPage), nil
+}
+
+func (issueThread) Body(comment *IssueComment) string {
+	return comment.Body
+}
+
+func (issueThread) Author(comment *IssueComment) string {
+	return comment.User.Login
+}
+
+func (t issueThread) CurrentUser(ctx context.Context) (string, error) {
+	return t.c.user.Get(ctx, t.c.currentUser)
+}
+
+func (t issueThread) Create(ctx context.Context, body string) (*IssueComment, error) {
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "github").Str("operation", "create_comment").
+		Str("repository", t.repo.String()).Int("number", t.number).
+		Msg("creating pull request comment")
+	var comment IssueComment
+	if err := t.c.api().Do(ctx, http.MethodPost, t.commentsURL(), commentPayload(body), &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (t issueThread) Update(ctx context.Context, existing *IssueComment, body string) (*IssueComment, error) {
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "github").Str("operation", "update_comment").
+		Str("repository", t.repo.String()).Int("number", t.number).Int64("comment_id", existing.ID).
+		Msg("updating pull request comment")
+	endpoint := fmt.Sprintf("%s/repos/%s/%s/issues/comments/%d", t.c.BaseURL,
+		url.PathEscape(t.repo.Owner), url.PathEscape(t.repo.Name), existing.ID)
+	var comment IssueComment
+	if err := t.c.api().Do(ctx, http.MethodPatch, endpoint, commentPayload(body), &comment); err != nil {
+		return nil, err
+	}
+	return &comment, nil
+}
+
+func (t issueThread) commentsURL() string {
+	return fmt.Sprintf("%s/repos/%s/%s/issues/%d/comments", t.c.BaseURL,
+		url.PathEscape(t.repo.Owner), url.PathEscape(t.repo.Name), t.number)
+}
+
+// currentUser returns the login of the token's user. The GITHUB_TOKEN of
+// GitHub Actions cannot read it, so inside Actions a failed lookup falls back
+// to the bot that token comments as.
+func (c *Client) currentUser(ctx context.Context) (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if err := c.api().Do(ctx, http.MethodGet, c.BaseURL+"/user", nil, &user); err != nil {
+		if os.Getenv(EnvActions) == "true" {
+			return actionsBotLogin, nil
+		}
+		return "", err
+	}
+	if user.Login == "" {
+		return "", fmt.Errorf("%w: GET /user: no login in response", ErrRequestFailed)
+	}
+	return user.Login, nil
+}
+
+// commentPayload returns the request body setting the text of a comment.
+func commentPayload(body string) map[string]string {
+	return map[string]string{"body": body}
+}
+
+// api returns the JSON client of the GitHub API.
+func (c *Client) api() *restapi.Client {
+	return &restapi.Client{
+		HTTPClient: c.HTTPClient,
+		API:        "GitHub",
+		Header: http.Header{
+			"Accept":               {acceptHeader},
+			"Authorization":        {"Bearer " + c.token},
+			"X-Github-Api-Version": {apiVersionHeader},
+		},
+		ErrFailed: ErrRequestFailed,
+		AuthHint:  "check that " + EnvToken + " can write pull request comments",
+	}
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMarker = "<!-- finfocus -->"
+
+func TestParseRepository(t *testing.T) {
+	repo, err := ParseRepository("acme/infra")
+	require.NoError(t, err)
+	assert.Equal(t, Repository{Owner: "acme", Name: "infra"}, repo)
+	assert.Equal(t, "acme/infra", repo.String())
+
+	for _, bad := range []string{"", "acme", "acme/", "/infra", "a/b/c"} {
+		_, err = ParseRepository(bad)
+		require.ErrorIs(t, err, ErrInvalidRepository, bad)
+	}
+}
+
+func TestResolveToken(t *testing.T) {
+	t.Setenv(EnvToken, "")
+	t.Setenv(EnvAltToken, "")
+	assert.Empty(t, ResolveToken())
+
+	t.Setenv(EnvAltToken, "gh")
+	assert.Equal(t, "gh", ResolveToken())
+
+	t.Setenv(EnvToken, "actions")
+	assert.Equal(t, "actions", ResolveToken(), "GITHUB_TOKEN takes precedence")
+}
+
+// fakeCommentsAPI serves the issue comment endpoints from an in-memory list,
+// authenticating every request as login.
+type fakeCommentsAPI struct {
+	login    string
+	comments []IssueComment
+	requests []string
+	auth     string
+}
+
+// issueComment returns a comment with body written by login.
+func issueComment(id int64, login, body string) IssueComment {
+	comment := IssueComment{ID: id, Body: body}
+	comment.User.Login = login
+	return comment
+}
+
+func (f *fakeCommentsAPI) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.requests = append(f.requests, r.Method+" "+r.URL.Path)
+	f.auth = r.Header.Get("Authorization")
+
+	var payload struct {
+		Body string `json:"body"`
+	}
+	if r.Body != nil {
+		_ = json.NewDecoder(r.Body).Decode(&payload)
+	}
+
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/user":
+		_ = json.NewEncoder(w).Encode(map[string]string{"login": f.login})
+	case r.Method == http.MethodGet && r.URL.Path == "/repos/acme/infra/issues/7/comments":
+		page := f.comments
+		if r.URL.Query().Get("page") != "1" {
+			page = nil
+		}
+		_ = json.NewEncoder(w).Encode(page)
+	case r.Method == http.MethodPost && r.URL.Path == "/repos/acme/infra/issues/7/comments":
+		comment := issueComment(int64(len(f.comments)+1), f.login, payload.Body)
+		f.comments = append(f.comments, comment)
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(comment)
+	case r.Method == http.MethodPatch && strings.HasPrefix(r.URL.Path, "/repos/acme/infra/issues/comments/"):
+		for i := range f.comments {
+			if r.URL.Path == fmt.Sprintf("/repos/acme/infra/issues/comments/%d", f.comments[i].ID) {
+				f.comments[i].Body = payload.Body
+				_ = json.NewEncoder(w).Encode(f.comments[i])
+				return
+			}
+		}
+		http.NotFound(w, r)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestClient_UpsertIssueComment(t *testing.T) {
+	api := &fakeCommentsAPI{login: "finfocus-bot", comments: []IssueComment{issueComment(1, "alice", "LGTM")}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "secret")
+	repo := Repository{Owner: "acme", Name: "infra"}
+
+	comment, created, err := client.UpsertIssueComment(context.Background(), repo, 7, testMarker, testMarker+"\nfirst")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, int64(2), comment.ID)
+	assert.Equal(t, "Bearer secret", api.auth)
+
+	comment, created, err = client.UpsertIssueComment(context.Background(), repo, 7, testMarker, testMarker+"\nsecond")
+	require.NoError(t, err)
+	assert.False(t, created, "the marked comment is updated")
+	assert.Equal(t, int64(2), comment.ID)
+
+	require.Len(t, api.comments, 2)
+	assert.Equal(t, "LGTM", api.comments[0].Body)
+	assert.Equal(t, testMarker+"\nsecond", api.comments[1].Body)
+	assert.Equal(t, "PATCH /repos/acme/infra/issues/comments/2", api.requests[len(api.requests)-1])
+	assert.Equal(t, "GET /user", api.requests[0])
+	assert.NotContains(t, api.requests[1:], "GET /user", "the current user is looked up once")
+}
+
+func TestClient_UpsertIssueComment_IgnoresOtherAuthors(t *testing.T) {
+	t.Setenv(EnvActions, "")
+	api := &fakeCommentsAPI{login: "finfocus-bot", comments: []IssueComment{
+		issueComment(1, "mallory", "quoting "+testMarker),
+	}}
+	server := httptest.NewServer(api)
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "secret")
+	repo := Repository{Owner: "acme", Name: "infra"}
+
+	comment, created, err := client.UpsertIssueComment(context.Background(), repo, 7, testMarker, testMarker+"\nreport")
+	require.NoError(t, err)
+	assert.True(t, created, "a marked comment of another user is left alone")
+	assert.Equal(t, int64(2), comment.ID)
+	assert.Equal(t, "quoting "+testMarker, api.comments[0].Body)
+}
+
+func TestClient_UpsertIssueComment_ActionsToken(t *testing.T) {
+	t.Setenv(EnvActions, "true")
+	api := &fakeCommentsAPI{comments: []IssueComment{issueComment(1, actionsBotLogin, testMarker+"\nold")}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/user" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		api.ServeHTTP(w, r)
+	}))
+	t.Cleanup(server.Close)
+
+	comment, created, err := NewClient(server.URL, "secret").UpsertIssueComment(
+		context.Background(), Repository{Owner: "acme", Name: "infra"}, 7, testMarker, testMarker+"\nnew")
+	require.NoError(t, err)
+	assert.False(t, created, "the comments of github-actions[bot] belong to the GITHUB_TOKEN")
+	assert.Equal(t, int64(1), comment.ID)
+}
+
+func TestClient_UpsertIssueComment_Errors(t *testing.T) {
+	t.Setenv(EnvActions, "")
+	repo := Repository{Owner: "acme", Name: "infra"}
+
+	_, _, err := NewClient("", "").UpsertIssueComment(context.Background(), repo, 7, testMarker, "body")
+	require.ErrorIs(t, err, ErrNoToken)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	t.Cleanup(server.Close)
+
+	_, _, err = NewClient(server.URL, "secret").UpsertIssueComment(context.Background(), repo, 7, testMarker, "body")
+	require.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "HTTP 403")
+}
@@ -0,0 +1,189 @@
+// Package gitlab posts finfocus reports to merge requests through the GitLab REST API.
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/restapi"
+)
+
+// GitLab API settings.
+const (
+	// DefaultAPIURL is the GitLab.com REST API endpoint.
+	DefaultAPIURL = "https://gitlab.com/api/v4"
+	// DefaultTimeout bounds a single GitLab API request.
+	DefaultTimeout = 30 * time.Second
+
+	// EnvToken is the environment variable holding the GitLab access token.
+	EnvToken = "GITLAB_TOKEN" //nolint:gosec // Environment variable name, not a credential.
+	// EnvProject names the project to post to, by numeric ID or full path
+	// (CI_PROJECT_ID is set by GitLab CI).
+	EnvProject = "CI_PROJECT_ID"
+	// EnvAPIURL overrides DefaultAPIURL (CI_API_V4_URL is set by GitLab CI).
+	EnvAPIURL = "CI_API_V4_URL"
+
+	// notesPerPage is the page size used when searching for an existing note.
+	notesPerPage = 100
+)
+
+var (
+	// ErrNoToken indicates no GitLab token is configured.
+	ErrNoToken = errors.New("no GitLab token")
+
+	// ErrNoProject indicates no GitLab project is configured.
+	ErrNoProject = errors.New("no GitLab project")
+
+	// ErrRequestFailed indicates a GitLab API request failed.
+	ErrRequestFailed = errors.New("gitlab request failed")
+)
+
+// ResolveToken returns the GitLab token from GITLAB_TOKEN, or "" when unset.
+func ResolveToken() string {
+	return strings.TrimSpace(os.Getenv(EnvToken))
+}
+
+// Note is a comment on a merge request.
+type Note struct {
+	ID     int64  `json:"id"`
+	Body   string `json:"body"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+}
+
+// Client calls the GitLab REST API.
+type Client struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	token      string
+	user       restapi.User
+}
+
+// NewClient returns a Client that authenticates with token. An empty baseURL
+// selects DefaultAPIURL.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// UpsertMergeRequestNote keeps a single "sticky" note on merge request iid of
+// project (a numeric ID or full path): the first note of the token's user
+// whose body contains marker is replaced with body, and a new note is created
+// when there is none.
+// body should contain marker so the next call finds it. It reports whether a
+// note was created. Errors wrap ErrNoToken, ErrNoProject, or ErrRequestFailed.
+func (c *Client) UpsertMergeRequestNote(
+	ctx context.Context,
+	project string,
+	iid int,
+	marker, body string,
+) (*Note, bool, error) {
+	if c.token == "" {
+		return nil, false, fmt.Errorf("%w; set %s", ErrNoToken, EnvToken)
+	}
+	if strings.TrimSpace(project) == "" {
+		return nil, false, fmt.Errorf("%w; set %s", ErrNoProject, EnvProject)
+	}
+
+	thread := noteThread{
+		c:        c,
+		project:  project,
+		iid:      iid,
+		notesURL: fmt.Sprintf("%s/projects/%s/merge_requests/%d/notes", c.BaseURL, url.PathEscape(project), iid),
+	}
+	return restapi.UpsertComment[Note](ctx, thread, marker, body)
+}
+
+// noteThread is the note thread of a merge request.
+type noteThread struct {
+	c        *Client
+	project  string
+	iid      int
+	notesURL string
+}
+
+func (t noteThread) FirstPage() string {
+	return fmt.Sprintf("%s?per_page=%d&page=1&sort=asc", t.notesURL, notesPerPage)
+}
+
+func (t noteThread) Page(ctx context.Context, endpoint string) ([]Note, string, error) {
+	var notes []Note
+	if err := t.c.api().Do(ctx, http.MethodGet, endpoint, nil, &notes); err != nil {
+		return nil, "", err
+	}
+	return notes, restapi.NextPage(endpoint, len(notes), notesPerPage), nil
+}
+
+func (noteThread) Body(note *Note) string {
+	return note.Body
+}
+
+func (noteThread) Author(note *Note) string {
+	return note.Author.Username
+}
+
+func (t noteThread) CurrentUser(ctx context.Context) (string, error) {
+	return t.c.user.Get(ctx, t.c.currentUser)
+}
+
+func (t noteThread) Create(ctx context.Context, body string) (*Note, error) {
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "gitlab").Str("operation", "create_note").
+		Str("project", t.project).Int("iid", t.iid).
+		Msg("creating merge request note")
+	var note Note
+	if err := t.c.api().Do(ctx, http.MethodPost, t.notesURL, map[string]string{"body": body}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+func (t noteThread) Update(ctx context.Context, existing *Note, body string) (*Note, error) {
+	logging.FromContext(ctx).Debug().Ctx(ctx).Str("component", "gitlab").Str("operation", "update_note").
+		Str("project", t.project).Int("iid", t.iid).Int64("note_id", existing.ID).
+		Msg("updating merge request note")
+	endpoint := fmt.Sprintf("%s/%d", t.notesURL, existing.ID)
+	var note Note
+	if err := t.c.api().Do(ctx, http.MethodPut, endpoint, map[string]string{"body": body}, &note); err != nil {
+		return nil, err
+	}
+	return &note, nil
+}
+
+// currentUser returns the username of the token's user.
+func (c *Client) currentUser(ctx context.Context) (string, error) {
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := c.api().Do(ctx, http.MethodGet, c.BaseURL+"/user", nil, &user); err != nil {
+		return "", err
+	}
+	if user.Username == "" {
+		return "", fmt.Errorf("%w: GET /user: no username in response", ErrRequestFailed)
+	}
+	return user.Username, nil
+}
+
+// api returns the JSON client of the GitLab API.
+func (c *Client) api() *restapi.Client {
+	return &restapi.Client{
+		HTTPClient: c.HTTPClient,
+		API:        "GitLab",
+		Header:     http.Header{"Private-Token": {c.token}},
+		ErrFailed:  ErrRequestFailed,
+		AuthHint:   "check that " + EnvToken + " has the api scope",
+	}
+}
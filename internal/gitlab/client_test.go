@@ -0,0 +1,89 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testMarker = "[//]: # (finfocus)"
+
+// note returns a note with body written by username.
+func note(id int64, username, body string) Note {
+	n := Note{ID: id, Body: body}
+	n.Author.Username = username
+	return n
+}
+
+func TestClient_UpsertMergeRequestNote(t *testing.T) {
+	notes := []Note{note(1, "alice", "LGTM"), note(2, "mallory", "quoting "+testMarker)}
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.EscapedPath())
+		assert.Equal(t, "secret", r.Header.Get("Private-Token"))
+
+		var payload Note
+		if r.Body != nil {
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+		}
+		switch {
+		case r.URL.Path == "/user":
+			_ = json.NewEncoder(w).Encode(map[string]string{"username": "finfocus-bot"})
+		case r.Method == http.MethodGet:
+			_ = json.NewEncoder(w).Encode(notes)
+		case r.Method == http.MethodPost:
+			payload = note(int64(len(notes)+1), "finfocus-bot", payload.Body)
+			notes = append(notes, payload)
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(payload)
+		case r.Method == http.MethodPut:
+			notes[2].Body = payload.Body
+			_ = json.NewEncoder(w).Encode(notes[2])
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient(server.URL, "secret")
+	first, created, err := client.UpsertMergeRequestNote(context.Background(), "acme/infra", 7, testMarker,
+		testMarker+"\nfirst")
+	require.NoError(t, err)
+	assert.True(t, created, "a marked note of another user is left alone")
+	assert.Equal(t, int64(3), first.ID)
+
+	_, created, err = client.UpsertMergeRequestNote(context.Background(), "acme/infra", 7, testMarker,
+		testMarker+"\nsecond")
+	require.NoError(t, err)
+	assert.False(t, created, "the marked note is updated")
+
+	assert.Equal(t, testMarker+"\nsecond", notes[2].Body)
+	assert.Equal(t, "quoting "+testMarker, notes[1].Body)
+	assert.Equal(t, []string{
+		"GET /user",
+		"GET /projects/acme%2Finfra/merge_requests/7/notes",
+		"POST /projects/acme%2Finfra/merge_requests/7/notes",
+		"GET /projects/acme%2Finfra/merge_requests/7/notes",
+		"PUT /projects/acme%2Finfra/merge_requests/7/notes/3",
+	}, requests, "the current user is looked up once and project paths are URL-encoded")
+}
+
+func TestClient_UpsertMergeRequestNote_Errors(t *testing.T) {
+	_, _, err := NewClient("", "").UpsertMergeRequestNote(context.Background(), "42", 7, testMarker, "body")
+	require.ErrorIs(t, err, ErrNoToken)
+
+	_, _, err = NewClient("", "secret").UpsertMergeRequestNote(context.Background(), "", 7, testMarker, "body")
+	require.ErrorIs(t, err, ErrNoProject)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	t.Cleanup(server.Close)
+
+	_, _, err = NewClient(server.URL, "secret").UpsertMergeRequestNote(context.Background(), "42", 7, testMarker, "body")
+	require.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "HTTP 401")
+}
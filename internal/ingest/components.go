@@ -0,0 +1,20 @@
+package ingest
+
+import "slices"
+
+// pulumiStackType is the type of the stack resource, the parent of every
+// resource that is declared outside a component.
+const pulumiStackType = "pulumi:pulumi:Stack"
+
+// componentAncestry returns the URNs of the component resources that enclose
+// a resource whose parent is parent, outermost first, following parents,
+// which maps URNs to the URNs of their parents. The stack is not a component.
+func componentAncestry(parent string, parents map[string]string) []string {
+	var chain []string
+	for parent != "" && extractTypeFromURN(parent) != pulumiStackType && !slices.Contains(chain, parent) {
+		chain = append(chain, parent)
+		parent = parents[parent]
+	}
+	slices.Reverse(chain)
+	return chain
+}
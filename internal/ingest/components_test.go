@@ -0,0 +1,70 @@
+package ingest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/ingest"
+)
+
+const (
+	componentsStackURN = "urn:pulumi:dev::app::pulumi:pulumi:Stack::app-dev"
+	componentsSiteURN  = "urn:pulumi:dev::app::my:web:Site::site"
+	componentsCDNURN   = "urn:pulumi:dev::app::my:web:Site$my:web:CDN::cdn"
+	componentsEdgeURN  = "urn:pulumi:dev::app::my:web:Site$my:web:CDN$aws:cloudfront/distribution:Distribution::edge"
+	componentsTableURN = "urn:pulumi:dev::app::aws:dynamodb/table:Table::sessions"
+)
+
+func TestPulumiPlan_Components(t *testing.T) {
+	plan, err := ingest.ParsePulumiPlan([]byte(`{"steps": [
+		{"op": "create", "urn": "` + componentsStackURN + `", "newState": {"type": "pulumi:pulumi:Stack"}},
+		{"op": "create", "urn": "` + componentsSiteURN + `",
+			"newState": {"type": "my:web:Site", "parent": "` + componentsStackURN + `"}},
+		{"op": "same", "urn": "` + componentsCDNURN + `",
+			"oldState": {"type": "my:web:CDN", "parent": "` + componentsSiteURN + `"}},
+		{"op": "create", "urn": "` + componentsEdgeURN + `",
+			"newState": {"type": "aws:cloudfront/distribution:Distribution", "parent": "` + componentsCDNURN + `"}},
+		{"op": "create", "urn": "` + componentsTableURN + `",
+			"newState": {"type": "aws:dynamodb/table:Table", "parent": "` + componentsStackURN + `"}}
+	]}`))
+	require.NoError(t, err)
+
+	byURN := make(map[string]ingest.PulumiResource)
+	for _, r := range plan.GetResources() {
+		byURN[r.URN] = r
+	}
+	edge := byURN[componentsEdgeURN]
+	assert.Equal(t, componentsCDNURN, edge.Parent)
+	assert.Equal(t, []string{componentsSiteURN, componentsCDNURN}, edge.Components)
+	assert.Equal(t, []string{componentsSiteURN}, byURN[componentsCDNURN].Components, "old state parents count")
+	assert.Empty(t, byURN[componentsTableURN].Components, "the stack is not a component")
+	assert.Empty(t, byURN[componentsSiteURN].Components)
+
+	desc, err := ingest.MapResource(edge)
+	require.NoError(t, err)
+	assert.Equal(t, edge.Components, desc.Components)
+}
+
+func TestStackExport_Components(t *testing.T) {
+	state, err := ingest.ParseStackExport([]byte(`{"version": 3, "deployment": {"resources": [
+		{"urn": "` + componentsStackURN + `", "type": "pulumi:pulumi:Stack"},
+		{"urn": "` + componentsSiteURN + `", "type": "my:web:Site", "parent": "` + componentsStackURN + `"},
+		{"urn": "` + componentsCDNURN + `", "type": "my:web:CDN", "parent": "` + componentsSiteURN + `"},
+		{"urn": "` + componentsEdgeURN + `", "type": "aws:cloudfront/distribution:Distribution",
+			"custom": true, "parent": "` + componentsCDNURN + `"},
+		{"urn": "` + componentsTableURN + `", "type": "aws:dynamodb/table:Table",
+			"custom": true, "parent": "` + componentsStackURN + `"}
+	]}}`))
+	require.NoError(t, err)
+
+	resources := state.GetCustomResources()
+	require.Len(t, resources, 2, "components are not custom resources")
+	assert.Equal(t, []string{componentsSiteURN, componentsCDNURN}, resources[0].Components)
+	assert.Empty(t, resources[1].Components)
+
+	descriptors, err := ingest.MapStateResources(resources)
+	require.NoError(t, err)
+	assert.Equal(t, []string{componentsSiteURN, componentsCDNURN}, descriptors[0].Components)
+}
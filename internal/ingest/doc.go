@@ -11,6 +11,12 @@
 //   - Resource properties and configurations
 //   - Resource dependencies and relationships
 //
+// # Kubernetes Manifests
+//
+// LoadK8sManifests reads Deployment and StatefulSet workloads from manifest
+// files and MapK8sWorkloads converts them to resource descriptors carrying
+// replica counts and aggregate CPU and memory requests.
+//
 // # Resource Descriptors
 //
 // Output is a normalized set of ResourceDescriptor objects that provide
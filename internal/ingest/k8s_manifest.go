@@ -0,0 +1,445 @@
+package ingest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Kubernetes workload property keys set by MapK8sWorkload. CPU values are in
+// cores and memory and storage values in bytes; the per-pod values are the sum
+// of the pod's container requests or limits, or of its volume claim templates,
+// and the totals multiply them by the replica count.
+const (
+	PropertyK8sKind           = "kind"
+	PropertyK8sNamespace      = "namespace"
+	PropertyK8sName           = "name"
+	PropertyK8sReplicas       = "replicas"
+	PropertyK8sCPURequest     = "cpuRequest"
+	PropertyK8sMemoryRequest  = "memoryRequest"
+	PropertyK8sCPULimit       = "cpuLimit"
+	PropertyK8sMemoryLimit    = "memoryLimit"
+	PropertyK8sStorageRequest = "storageRequest"
+	PropertyK8sStorageClass   = "storageClass"
+	PropertyK8sTotalCPU       = "totalCpu"
+	PropertyK8sTotalMemory    = "totalMemory"
+	PropertyK8sTotalStorage   = "totalStorage"
+	// PropertyK8sTags holds the labels of the object and its namespace, under
+	// the "namespace" key.
+	PropertyK8sTags = "tags"
+)
+
+// Kubernetes kinds that are priced.
+const (
+	k8sKindDeployment  = "Deployment"
+	k8sKindStatefulSet = "StatefulSet"
+	k8sKindPVC         = "PersistentVolumeClaim"
+)
+
+const (
+	k8sProvider         = "kubernetes"
+	k8sDefaultNamespace = "default"
+	// k8sClusterRegion is the region reported for workloads whose pod template
+	// does not pin one with a topology.kubernetes.io/region node selector.
+	k8sClusterRegion = "cluster"
+	k8sRegionLabel   = "topology.kubernetes.io/region"
+)
+
+// K8sWorkload is a Deployment, StatefulSet, or PersistentVolumeClaim read
+// from a Kubernetes manifest or a Pulumi kubernetes resource.
+type K8sWorkload struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+	Labels     map[string]string
+	Replicas   int64
+	// CPURequest is the sum of container CPU requests for one pod, in cores.
+	CPURequest float64
+	// MemoryRequest is the sum of container memory requests for one pod, in bytes.
+	MemoryRequest int64
+	// CPULimit and MemoryLimit are the sums of container limits for one pod;
+	// containers without a limit add nothing.
+	CPULimit    float64
+	MemoryLimit int64
+	// Storage is the storage requested by a PersistentVolumeClaim, or by the
+	// volume claim templates of one StatefulSet pod, in bytes.
+	Storage      int64
+	StorageClass string
+	Region       string
+	// Source is the manifest file the workload was read from.
+	Source string
+}
+
+// k8sObject is the subset of a Kubernetes object needed for cost estimation.
+type k8sObject struct {
+	APIVersion string `yaml:"apiVersion"`
+	Kind       string `yaml:"kind"`
+	Metadata   struct {
+		Name      string            `yaml:"name"`
+		Namespace string            `yaml:"namespace"`
+		Labels    map[string]string `yaml:"labels"`
+	} `yaml:"metadata"`
+	Spec struct {
+		Replicas *int64 `yaml:"replicas"`
+		// Resources and StorageClassName are those of a PersistentVolumeClaim.
+		Resources struct {
+			Requests map[string]k8sQuantity `yaml:"requests"`
+		} `yaml:"resources"`
+		StorageClassName string `yaml:"storageClassName"`
+		// VolumeClaimTemplates are the claims of each StatefulSet pod.
+		VolumeClaimTemplates []k8sObject `yaml:"volumeClaimTemplates"`
+		Template             struct {
+			Spec struct {
+				NodeSelector map[string]string `yaml:"nodeSelector"`
+				Containers   []struct {
+					Name      string `yaml:"name"`
+					Resources struct {
+						Requests map[string]k8sQuantity `yaml:"requests"`
+						Limits   map[string]k8sQuantity `yaml:"limits"`
+					} `yaml:"resources"`
+				} `yaml:"containers"`
+			} `yaml:"spec"`
+		} `yaml:"template"`
+	} `yaml:"spec"`
+	// Items holds the objects of a kind: List, as produced by kubectl get -o yaml.
+	Items []k8sObject `yaml:"items"`
+}
+
+// k8sQuantity keeps the literal text of a quantity so that both "500m" and
+// unquoted numbers such as 2 or 0.5 are accepted.
+type k8sQuantity string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (q *k8sQuantity) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.ScalarNode {
+		return fmt.Errorf("line %d: quantity must be a scalar", node.Line)
+	}
+	*q = k8sQuantity(node.Value)
+	return nil
+}
+
+// LoadK8sManifests reads Deployments, StatefulSets, and PersistentVolumeClaims
+// from a manifest file or from every .yaml, .yml, and .json file under a
+// directory.
+func LoadK8sManifests(path string) ([]K8sWorkload, error) {
+	return LoadK8sManifestsWithContext(context.Background(), path)
+}
+
+// LoadK8sManifestsWithContext is LoadK8sManifests with logging from ctx.
+// Objects of other kinds are skipped. Directories are walked recursively,
+// skipping hidden directories, and files are read in lexical order.
+func LoadK8sManifestsWithContext(ctx context.Context, path string) ([]K8sWorkload, error) {
+	log := logging.FromContext(ctx)
+	log.Debug().
+		Ctx(ctx).
+		Str("component", "ingest").
+		Str("operation", "load_k8s_manifests").
+		Str("manifest_path", path).
+		Msg("loading Kubernetes manifests")
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading Kubernetes manifests: %w", err)
+	}
+
+	files := []string{path}
+	if info.IsDir() {
+		files, err = k8sManifestFiles(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var workloads []K8sWorkload
+	for _, file := range files {
+		data, readErr := os.ReadFile(file)
+		if readErr != nil {
+			return nil, fmt.Errorf("reading Kubernetes manifest: %w", readErr)
+		}
+		parsed, parseErr := ParseK8sManifests(data)
+		if parseErr != nil {
+			return nil, fmt.Errorf("parsing Kubernetes manifest %s: %w", file, parseErr)
+		}
+		for i := range parsed {
+			parsed[i].Source = file
+		}
+		workloads = append(workloads, parsed...)
+	}
+
+	log.Debug().
+		Ctx(ctx).
+		Str("component", "ingest").
+		Int("file_count", len(files)).
+		Int("workload_count", len(workloads)).
+		Msg("Kubernetes manifests loaded")
+
+	return workloads, nil
+}
+
+// k8sManifestFiles lists the manifest files under dir.
+func k8sManifestFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if p != dir && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		switch strings.ToLower(filepath.Ext(p)) {
+		case ".yaml", ".yml", ".json":
+			files = append(files, p)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("listing Kubernetes manifests: %w", err)
+	}
+	return files, nil
+}
+
+// ParseK8sManifests parses a multi-document YAML (or JSON) manifest and
+// returns its Deployments, StatefulSets, and PersistentVolumeClaims, including
+// those inside a List.
+func ParseK8sManifests(data []byte) ([]K8sWorkload, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	var workloads []K8sWorkload
+	for doc := 1; ; doc++ {
+		var obj k8sObject
+		err := dec.Decode(&obj)
+		if errors.Is(err, io.EOF) {
+			return workloads, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", doc, err)
+		}
+		parsed, err := collectK8sWorkloads(obj)
+		if err != nil {
+			return nil, fmt.Errorf("document %d: %w", doc, err)
+		}
+		workloads = append(workloads, parsed...)
+	}
+}
+
+// collectK8sWorkloads returns the workload in obj, or those in its items.
+func collectK8sWorkloads(obj k8sObject) ([]K8sWorkload, error) {
+	if strings.HasSuffix(obj.Kind, "List") {
+		var workloads []K8sWorkload
+		for _, item := range obj.Items {
+			parsed, err := collectK8sWorkloads(item)
+			if err != nil {
+				return nil, err
+			}
+			workloads = append(workloads, parsed...)
+		}
+		return workloads, nil
+	}
+	if obj.Kind != k8sKindDeployment && obj.Kind != k8sKindStatefulSet && obj.Kind != k8sKindPVC {
+		return nil, nil
+	}
+
+	workload := K8sWorkload{
+		APIVersion: obj.APIVersion,
+		Kind:       obj.Kind,
+		Name:       obj.Metadata.Name,
+		Namespace:  obj.Metadata.Namespace,
+		Labels:     obj.Metadata.Labels,
+		Replicas:   1,
+		Region:     k8sClusterRegion,
+	}
+	if workload.Name == "" {
+		return nil, fmt.Errorf("%s has no metadata.name", obj.Kind)
+	}
+	if workload.Namespace == "" {
+		workload.Namespace = k8sDefaultNamespace
+	}
+	if obj.Kind == k8sKindPVC {
+		storage, err := parseK8sMemory(string(obj.Spec.Resources.Requests["storage"]))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", obj.Kind, workload.Name, err)
+		}
+		workload.Storage, workload.StorageClass = storage, obj.Spec.StorageClassName
+		return []K8sWorkload{workload}, nil
+	}
+	if obj.Spec.Replicas != nil {
+		workload.Replicas = *obj.Spec.Replicas
+	}
+	if region := obj.Spec.Template.Spec.NodeSelector[k8sRegionLabel]; region != "" {
+		workload.Region = region
+	}
+
+	for _, c := range obj.Spec.Template.Spec.Containers {
+		// Kubernetes defaults a missing request to the container's limit.
+		cpu, ok := c.Resources.Requests["cpu"]
+		if !ok {
+			cpu = c.Resources.Limits["cpu"]
+		}
+		memory, ok := c.Resources.Requests["memory"]
+		if !ok {
+			memory = c.Resources.Limits["memory"]
+		}
+
+		cores, err := parseK8sCPU(string(cpu))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s container %s: %w", obj.Kind, workload.Name, c.Name, err)
+		}
+		bytesValue, err := parseK8sMemory(string(memory))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s container %s: %w", obj.Kind, workload.Name, c.Name, err)
+		}
+		workload.CPURequest += cores
+		workload.MemoryRequest += bytesValue
+
+		cpuLimit, err := parseK8sCPU(string(c.Resources.Limits["cpu"]))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s container %s: %w", obj.Kind, workload.Name, c.Name, err)
+		}
+		memoryLimit, err := parseK8sMemory(string(c.Resources.Limits["memory"]))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s container %s: %w", obj.Kind, workload.Name, c.Name, err)
+		}
+		workload.CPULimit += cpuLimit
+		workload.MemoryLimit += memoryLimit
+	}
+
+	for _, claim := range obj.Spec.VolumeClaimTemplates {
+		storage, err := parseK8sMemory(string(claim.Spec.Resources.Requests["storage"]))
+		if err != nil {
+			return nil, fmt.Errorf("%s %s volume claim %s: %w", obj.Kind, workload.Name, claim.Metadata.Name, err)
+		}
+		workload.Storage += storage
+		if workload.StorageClass == "" {
+			workload.StorageClass = claim.Spec.StorageClassName
+		}
+	}
+
+	return []K8sWorkload{workload}, nil
+}
+
+// parseK8sCPU parses a CPU quantity such as "500m", "2", or "0.5" into cores.
+// An empty quantity is zero.
+func parseK8sCPU(q string) (float64, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return 0, nil
+	}
+	scale := 1.0
+	if strings.HasSuffix(q, "m") {
+		q, scale = strings.TrimSuffix(q, "m"), 1e-3
+	}
+	v, err := strconv.ParseFloat(q, 64)
+	if err != nil || v < 0 {
+		return 0, fmt.Errorf("invalid CPU quantity %q", q)
+	}
+	return v * scale, nil
+}
+
+// k8sMemorySuffixes maps quantity suffixes to multipliers. Binary suffixes
+// are listed before decimal ones so that "Mi" is not read as "M".
+//
+//nolint:gochecknoglobals // Package-level lookup table, initialized once.
+var k8sMemorySuffixes = []struct {
+	suffix string
+	factor float64
+}{
+	{"Ki", 1 << 10}, {"Mi", 1 << 20}, {"Gi", 1 << 30}, {"Ti", 1 << 40}, {"Pi", 1 << 50}, {"Ei", 1 << 60},
+	{"k", 1e3}, {"M", 1e6}, {"G", 1e9}, {"T", 1e12}, {"P", 1e15}, {"E", 1e18},
+	{"m", 1e-3},
+}
+
+// parseK8sMemory parses a memory quantity such as "512Mi", "1G", or "1e9"
+// into bytes. An empty quantity is zero.
+func parseK8sMemory(q string) (int64, error) {
+	q = strings.TrimSpace(q)
+	if q == "" {
+		return 0, nil
+	}
+	factor := 1.0
+	number := q
+	for _, s := range k8sMemorySuffixes {
+		if strings.HasSuffix(q, s.suffix) {
+			number, factor = strings.TrimSuffix(q, s.suffix), s.factor
+			break
+		}
+	}
+	v, err := strconv.ParseFloat(number, 64)
+	if err != nil || v < 0 || math.IsInf(v*factor, 0) || v*factor > math.MaxInt64 {
+		return 0, fmt.Errorf("invalid memory quantity %q", q)
+	}
+	return int64(math.Ceil(v * factor)), nil
+}
+
+// MapK8sWorkload converts a workload into a ResourceDescriptor of type
+// "kubernetes:<apiVersion>:<Kind>" with its requests, limits, storage, and
+// replica count as properties, and its labels and namespace as tags. The
+// lowercase kind is reported as the SKU so that plugins such as kubecost and
+// opencost receive a complete projected cost request.
+func MapK8sWorkload(w K8sWorkload) engine.ResourceDescriptor {
+	apiVersion := w.APIVersion
+	if apiVersion == "" {
+		apiVersion = "apps/v1"
+		if w.Kind == k8sKindPVC {
+			apiVersion = "v1"
+		}
+	}
+	kind := strings.ToLower(w.Kind)
+
+	tags := make(map[string]interface{}, len(w.Labels)+1)
+	for k, v := range w.Labels {
+		tags[k] = v
+	}
+	tags[PropertyK8sNamespace] = w.Namespace
+
+	properties := map[string]interface{}{
+		PropertyK8sKind:           w.Kind,
+		PropertyK8sNamespace:      w.Namespace,
+		PropertyK8sName:           w.Name,
+		PropertyK8sReplicas:       float64(w.Replicas),
+		PropertyK8sCPURequest:     w.CPURequest,
+		PropertyK8sMemoryRequest:  float64(w.MemoryRequest),
+		PropertyK8sCPULimit:       w.CPULimit,
+		PropertyK8sMemoryLimit:    float64(w.MemoryLimit),
+		PropertyK8sStorageRequest: float64(w.Storage),
+		PropertyK8sTotalCPU:       w.CPURequest * float64(w.Replicas),
+		PropertyK8sTotalMemory:    float64(w.MemoryRequest) * float64(w.Replicas),
+		PropertyK8sTotalStorage:   float64(w.Storage) * float64(w.Replicas),
+		PropertyK8sTags:           tags,
+		"sku":                     kind,
+		"region":                  w.Region,
+	}
+	if w.StorageClass != "" {
+		properties[PropertyK8sStorageClass] = w.StorageClass
+	}
+	return engine.ResourceDescriptor{
+		Type:       fmt.Sprintf("%s:%s:%s", k8sProvider, apiVersion, w.Kind),
+		ID:         fmt.Sprintf("%s/%s/%s", w.Namespace, kind, w.Name),
+		Provider:   k8sProvider,
+		Properties: properties,
+	}
+}
+
+// MapK8sWorkloads converts workloads to ResourceDescriptors, preserving order.
+func MapK8sWorkloads(workloads []K8sWorkload) []engine.ResourceDescriptor {
+	descriptors := make([]engine.ResourceDescriptor, 0, len(workloads))
+	for _, w := range workloads {
+		descriptors = append(descriptors, MapK8sWorkload(w))
+	}
+	return descriptors
+}
@@ -0,0 +1,212 @@
+package ingest_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/ingest"
+)
+
+const k8sManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: shop
+spec:
+  replicas: 3
+  template:
+    spec:
+      nodeSelector:
+        topology.kubernetes.io/region: us-east-1
+      containers:
+        - name: app
+          resources:
+            requests:
+              cpu: 500m
+              memory: 512Mi
+        - name: sidecar
+          resources:
+            limits:
+              cpu: 0.25
+              memory: 128M
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  template:
+    spec:
+      containers:
+        - name: postgres
+          resources:
+            requests:
+              cpu: 2
+              memory: 4Gi
+`
+
+func TestParseK8sManifests(t *testing.T) {
+	workloads, err := ingest.ParseK8sManifests([]byte(k8sManifest))
+	require.NoError(t, err)
+	require.Len(t, workloads, 2, "the Service is skipped")
+
+	web := workloads[0]
+	assert.Equal(t, "Deployment", web.Kind)
+	assert.Equal(t, "shop", web.Namespace)
+	assert.Equal(t, int64(3), web.Replicas)
+	assert.InDelta(t, 0.75, web.CPURequest, 1e-9, "limits stand in for missing requests")
+	assert.Equal(t, int64(512<<20+128e6), web.MemoryRequest)
+	assert.Equal(t, "us-east-1", web.Region)
+
+	db := workloads[1]
+	assert.Equal(t, "default", db.Namespace)
+	assert.Equal(t, int64(1), db.Replicas, "replicas defaults to 1")
+	assert.InDelta(t, 2.0, db.CPURequest, 1e-9)
+	assert.Equal(t, int64(4<<30), db.MemoryRequest)
+	assert.Equal(t, "cluster", db.Region)
+}
+
+func TestParseK8sManifests_List(t *testing.T) {
+	workloads, err := ingest.ParseK8sManifests([]byte(`{"apiVersion": "v1", "kind": "List", "items": [
+		{"apiVersion": "apps/v1", "kind": "Deployment", "metadata": {"name": "api"}, "spec": {"replicas": 2}}
+	]}`))
+	require.NoError(t, err)
+	require.Len(t, workloads, 1)
+	assert.Equal(t, "api", workloads[0].Name)
+	assert.Equal(t, int64(2), workloads[0].Replicas)
+}
+
+func TestParseK8sManifests_Errors(t *testing.T) {
+	tests := map[string]string{
+		"bad cpu":      "kind: Deployment\nmetadata: {name: a}\nspec: {template: {spec: {containers: [{name: c, resources: {requests: {cpu: lots}}}]}}}\n",
+		"bad memory":   "kind: Deployment\nmetadata: {name: a}\nspec: {template: {spec: {containers: [{name: c, resources: {requests: {memory: 1Qi}}}]}}}\n",
+		"missing name": "kind: StatefulSet\nmetadata: {}\n",
+		"invalid yaml": "kind: [Deployment\n",
+	}
+	for name, manifest := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ingest.ParseK8sManifests([]byte(manifest))
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestLoadK8sManifests_Directory(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "app.yaml"), []byte(k8sManifest), 0o600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a manifest"), 0o600))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".git"), 0o750))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "x.yaml"), []byte("kind: [broken"), 0o600))
+
+	workloads, err := ingest.LoadK8sManifests(dir)
+	require.NoError(t, err)
+	require.Len(t, workloads, 2)
+	assert.Equal(t, filepath.Join(dir, "app.yaml"), workloads[0].Source)
+
+	_, err = ingest.LoadK8sManifests(filepath.Join(dir, "missing"))
+	require.Error(t, err)
+}
+
+func TestMapK8sWorkload(t *testing.T) {
+	desc := ingest.MapK8sWorkload(ingest.K8sWorkload{
+		APIVersion:    "apps/v1",
+		Kind:          "Deployment",
+		Name:          "web",
+		Namespace:     "shop",
+		Replicas:      3,
+		CPURequest:    0.5,
+		MemoryRequest: 1 << 30,
+		Region:        "cluster",
+	})
+
+	assert.Equal(t, "kubernetes:apps/v1:Deployment", desc.Type)
+	assert.Equal(t, "kubernetes", desc.Provider)
+	assert.Equal(t, "shop/deployment/web", desc.ID)
+	assert.InDelta(t, 3.0, desc.Properties[ingest.PropertyK8sReplicas], 1e-9)
+	assert.InDelta(t, 1.5, desc.Properties[ingest.PropertyK8sTotalCPU], 1e-9)
+	assert.InDelta(t, float64(3<<30), desc.Properties[ingest.PropertyK8sTotalMemory], 1e-9)
+	assert.Equal(t, "deployment", desc.Properties["sku"])
+	assert.Equal(t, "cluster", desc.Properties["region"])
+}
+
+func TestParseK8sManifests_StorageAndLimits(t *testing.T) {
+	workloads, err := ingest.ParseK8sManifests([]byte(`apiVersion: v1
+kind: PersistentVolumeClaim
+metadata:
+  name: data
+  labels:
+    team: payments
+spec:
+  storageClassName: gp3
+  resources:
+    requests:
+      storage: 20Gi
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+        - name: postgres
+          resources:
+            requests:
+              cpu: 1
+              memory: 2Gi
+            limits:
+              cpu: 2
+              memory: 4Gi
+  volumeClaimTemplates:
+    - metadata:
+        name: pgdata
+      spec:
+        resources:
+          requests:
+            storage: 50Gi
+`))
+	require.NoError(t, err)
+	require.Len(t, workloads, 2)
+
+	pvc := workloads[0]
+	assert.Equal(t, "PersistentVolumeClaim", pvc.Kind)
+	assert.Equal(t, int64(20<<30), pvc.Storage)
+	assert.Equal(t, "gp3", pvc.StorageClass)
+	assert.Equal(t, map[string]string{"team": "payments"}, pvc.Labels)
+
+	db := workloads[1]
+	assert.InDelta(t, 2.0, db.CPULimit, 1e-9)
+	assert.Equal(t, int64(4<<30), db.MemoryLimit)
+	assert.Equal(t, int64(50<<30), db.Storage, "volume claim templates are summed per pod")
+}
+
+func TestMapK8sWorkload_StorageAndTags(t *testing.T) {
+	desc := ingest.MapK8sWorkload(ingest.K8sWorkload{
+		Kind:         "PersistentVolumeClaim",
+		Name:         "data",
+		Namespace:    "shop",
+		Labels:       map[string]string{"team": "payments"},
+		Replicas:     1,
+		Storage:      20 << 30,
+		StorageClass: "gp3",
+		Region:       "cluster",
+	})
+
+	assert.Equal(t, "kubernetes:v1:PersistentVolumeClaim", desc.Type)
+	assert.Equal(t, "shop/persistentvolumeclaim/data", desc.ID)
+	assert.InDelta(t, float64(20<<30), desc.Properties[ingest.PropertyK8sTotalStorage], 1e-9)
+	assert.Equal(t, "gp3", desc.Properties[ingest.PropertyK8sStorageClass])
+	assert.Equal(t, map[string]interface{}{"team": "payments", "namespace": "shop"},
+		desc.Properties[ingest.PropertyK8sTags])
+}
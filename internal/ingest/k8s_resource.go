@@ -0,0 +1,54 @@
+package ingest
+
+import (
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// enrichK8sProperties adds the properties that MapK8sWorkload sets for a
+// Deployment, StatefulSet, or PersistentVolumeClaim to the properties of a
+// Pulumi kubernetes resource, whose requests, limits, and storage are nested
+// in its spec, so that plugins such as kubecost and opencost can price it.
+// The resource name from its URN stands in for a metadata.name that a preview
+// has not yet generated. Existing properties are kept, and the properties are
+// left as they are for other resources and kinds, or objects that cannot be
+// read.
+func enrichK8sProperties(resourceType, urn string, properties map[string]interface{}) {
+	if extractProvider(resourceType) != k8sProvider || properties == nil {
+		return
+	}
+	data, err := yaml.Marshal(properties)
+	if err != nil {
+		return
+	}
+	var obj k8sObject
+	if yaml.Unmarshal(data, &obj) != nil {
+		return
+	}
+	if obj.Kind == "" {
+		// The class of the type token, e.g. Deployment in kubernetes:apps/v1:Deployment.
+		obj.Kind = resourceType[strings.LastIndex(resourceType, ":")+1:]
+	}
+	if obj.Metadata.Name == "" {
+		obj.Metadata.Name = urnName(urn)
+	}
+
+	workloads, err := collectK8sWorkloads(obj)
+	if err != nil || len(workloads) != 1 {
+		return
+	}
+	for k, v := range MapK8sWorkload(workloads[0]).Properties {
+		if _, exists := properties[k]; !exists {
+			properties[k] = v
+		}
+	}
+}
+
+// urnName returns the resource name at the end of a Pulumi URN.
+func urnName(urn string) string {
+	if i := strings.LastIndex(urn, "::"); i >= 0 {
+		return urn[i+len("::"):]
+	}
+	return ""
+}
@@ -0,0 +1,85 @@
+package ingest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/ingest"
+)
+
+func TestMapResource_KubernetesDeployment(t *testing.T) {
+	desc, err := ingest.MapResource(ingest.PulumiResource{
+		Type: "kubernetes:apps/v1:Deployment",
+		URN:  "urn:pulumi:dev::k8s-app::kubernetes:apps/v1:Deployment::web",
+		Inputs: map[string]interface{}{
+			"metadata": map[string]interface{}{
+				"namespace": "shop",
+				"labels":    map[string]interface{}{"app": "web"},
+			},
+			"spec": map[string]interface{}{
+				"replicas": float64(2),
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{
+								"name": "app",
+								"resources": map[string]interface{}{
+									"requests": map[string]interface{}{"cpu": "250m", "memory": "256Mi"},
+									"limits":   map[string]interface{}{"cpu": float64(1), "memory": "1Gi"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	props := desc.Properties
+	assert.Equal(t, "web", props[ingest.PropertyK8sName], "the URN names a resource without metadata.name")
+	assert.Equal(t, "shop", props[ingest.PropertyK8sNamespace])
+	assert.InDelta(t, 0.5, props[ingest.PropertyK8sTotalCPU], 1e-9)
+	assert.InDelta(t, float64(512<<20), props[ingest.PropertyK8sTotalMemory], 1e-9)
+	assert.InDelta(t, 1.0, props[ingest.PropertyK8sCPULimit], 1e-9)
+	assert.InDelta(t, float64(1<<30), props[ingest.PropertyK8sMemoryLimit], 1e-9)
+	assert.Equal(t, map[string]interface{}{"app": "web", "namespace": "shop"}, props[ingest.PropertyK8sTags])
+	assert.Equal(t, "deployment", props["sku"])
+	assert.Contains(t, props, "spec", "the nested inputs are kept")
+}
+
+func TestMapStateResource_KubernetesPVC(t *testing.T) {
+	desc, err := ingest.MapStateResource(ingest.StackExportResource{
+		Type: "kubernetes:core/v1:PersistentVolumeClaim",
+		URN:  "urn:pulumi:dev::k8s-app::kubernetes:core/v1:PersistentVolumeClaim::data",
+		Outputs: map[string]interface{}{
+			"kind":     "PersistentVolumeClaim",
+			"metadata": map[string]interface{}{"name": "data-1a2b3c", "namespace": "shop"},
+			"spec": map[string]interface{}{
+				"storageClassName": "standard",
+				"resources": map[string]interface{}{
+					"requests": map[string]interface{}{"storage": "10Gi"},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	props := desc.Properties
+	assert.Equal(t, "data-1a2b3c", props[ingest.PropertyK8sName])
+	assert.InDelta(t, float64(10<<30), props[ingest.PropertyK8sStorageRequest], 1e-9)
+	assert.Equal(t, "standard", props[ingest.PropertyK8sStorageClass])
+}
+
+func TestMapResource_KubernetesOtherKinds(t *testing.T) {
+	inputs := map[string]interface{}{"metadata": map[string]interface{}{"name": "web"}}
+	desc, err := ingest.MapResource(ingest.PulumiResource{
+		Type:   "kubernetes:core/v1:Service",
+		URN:    "urn:pulumi:dev::k8s-app::kubernetes:core/v1:Service::web",
+		Inputs: inputs,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, inputs, desc.Properties, "only priced kinds get properties")
+}
@@ -5,6 +5,7 @@ import (
 	"strings"
 
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/skus"
 )
 
 const unknownProvider = "unknown"
@@ -29,23 +30,32 @@ func MergeProperties(outputs, inputs map[string]interface{}) map[string]interfac
 // MapResource converts a PulumiResource into an engine.ResourceDescriptor.
 // The returned descriptor contains the resource Type, URN as ID, the provider
 // derived from the resource type, and Properties produced by merging the
-// resource's outputs with its inputs (inputs take precedence).
+// resource's outputs with its inputs (inputs take precedence). Kubernetes
+// Deployments, StatefulSets, and PersistentVolumeClaims also get the flat
+// request, limit, storage, and tag properties that MapK8sWorkload sets.
 // The function does not currently produce an error; the returned error is nil.
 func MapResource(pulumiResource PulumiResource) (engine.ResourceDescriptor, error) {
 	provider := extractProvider(pulumiResource.Type)
+	properties := MergeProperties(pulumiResource.Outputs, pulumiResource.Inputs)
+	enrichK8sProperties(pulumiResource.Type, pulumiResource.URN, properties)
 
 	return engine.ResourceDescriptor{
-		Type:       pulumiResource.Type,
-		ID:         pulumiResource.URN,
-		Provider:   provider,
-		Properties: MergeProperties(pulumiResource.Outputs, pulumiResource.Inputs),
+		Type:           pulumiResource.Type,
+		ID:             pulumiResource.URN,
+		Provider:       provider,
+		Properties:     properties,
+		SourcePosition: pulumiResource.SourcePosition,
+		Components:     pulumiResource.Components,
+		Dependencies:   pulumiResource.Dependencies,
 	}, nil
 }
 
+// extractProvider returns the provider namespace of a Pulumi type token, or
+// the provider it is an alias of.
 func extractProvider(resourceType string) string {
 	parts := strings.Split(resourceType, ":")
 	if len(parts) > 0 && parts[0] != "" {
-		return parts[0]
+		return skus.CanonicalProvider(parts[0])
 	}
 	return unknownProvider
 }
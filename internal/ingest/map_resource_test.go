@@ -214,6 +214,21 @@ func TestMapResource(t *testing.T) {
 							},
 						},
 					},
+					"kind":           "Deployment",
+					"name":           "nginx-deployment",
+					"namespace":      "default",
+					"region":         "cluster",
+					"sku":            "deployment",
+					"replicas":       3.0,
+					"cpuRequest":     0.0,
+					"memoryRequest":  0.0,
+					"cpuLimit":       0.0,
+					"memoryLimit":    0.0,
+					"totalCpu":       0.0,
+					"totalMemory":    0.0,
+					"storageRequest": 0.0,
+					"totalStorage":   0.0,
+					"tags":           map[string]interface{}{"namespace": "default"},
 				},
 			},
 			wantErr: false,
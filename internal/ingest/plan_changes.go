@@ -0,0 +1,101 @@
+package ingest
+
+// Operations of a PulumiResourceChange.
+const (
+	ChangeCreate  = "create"
+	ChangeUpdate  = "update"
+	ChangeReplace = "replace"
+	ChangeDelete  = "delete"
+)
+
+// PulumiResourceChange is the change a Pulumi preview makes to one resource.
+// Before is the resource as deployed, nil for a created resource, and After
+// the resource as the deployment leaves it, nil for a deleted resource.
+type PulumiResourceChange struct {
+	URN string
+	// Op is ChangeCreate, ChangeUpdate, ChangeReplace, or ChangeDelete.
+	Op     string
+	Before *PulumiResource
+	After  *PulumiResource
+}
+
+// GetResourceChanges returns the resources that the plan creates, updates,
+// replaces, or deletes, in the order of their first step. The steps of a
+// replacement (replace, create-replacement, and delete-replaced) are merged
+// into one ChangeReplace. Steps that leave a resource as it is, such as same,
+// read, refresh, and import, are left out.
+func (p *PulumiPlan) GetResourceChanges() []PulumiResourceChange {
+	var changes []PulumiResourceChange
+	index := make(map[string]int)
+	parents := make(map[string]string, len(p.Steps))
+
+	for _, step := range p.Steps {
+		parents[step.URN] = resolveStepParent(step)
+		op, ok := changeOperation(step.Op)
+		if !ok {
+			continue
+		}
+		i, seen := index[step.URN]
+		if !seen {
+			i = len(changes)
+			index[step.URN] = i
+			changes = append(changes, PulumiResourceChange{URN: step.URN, Op: op})
+		}
+		change := &changes[i]
+		if op == ChangeReplace {
+			change.Op = ChangeReplace
+		}
+		if change.Before == nil && step.OldState != nil && step.Op != "create" && step.Op != "create-replacement" {
+			before := stateResource(step.URN, step.OldState)
+			change.Before = &before
+		}
+		if change.After == nil && step.Op != "delete" && step.Op != "delete-replaced" {
+			after := stepResource(step)
+			change.After = &after
+		}
+	}
+
+	for i := range changes {
+		for _, resource := range []*PulumiResource{changes[i].Before, changes[i].After} {
+			if resource != nil {
+				resource.Components = componentAncestry(resource.Parent, parents)
+			}
+		}
+	}
+	return changes
+}
+
+// changeOperation returns the PulumiResourceChange operation of a step
+// operation, or false for steps that do not change a resource.
+func changeOperation(op string) (string, bool) {
+	switch op {
+	case "create":
+		return ChangeCreate, true
+	case "update":
+		return ChangeUpdate, true
+	case "delete":
+		return ChangeDelete, true
+	case "replace", "create-replacement", "delete-replaced":
+		return ChangeReplace, true
+	default:
+		return "", false
+	}
+}
+
+// stateResource returns the resource a state of the resource urn describes.
+func stateResource(urn string, state *PulumiState) PulumiResource {
+	resType := state.Type
+	if resType == "" {
+		resType = extractTypeFromURN(urn)
+	}
+	return PulumiResource{
+		Type:           resType,
+		URN:            urn,
+		Provider:       extractProviderFromURN(urn),
+		Inputs:         state.Inputs,
+		Outputs:        state.Outputs,
+		SourcePosition: state.SourcePosition,
+		Parent:         state.Parent,
+		Dependencies:   state.Dependencies,
+	}
+}
@@ -0,0 +1,66 @@
+package ingest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/ingest"
+)
+
+func TestPulumiPlan_GetResourceChanges(t *testing.T) {
+	const (
+		webURN    = "urn:pulumi:dev::app::aws:ec2/instance:Instance::web"
+		apiURN    = "urn:pulumi:dev::app::aws:ec2/instance:Instance::api"
+		dbURN     = "urn:pulumi:dev::app::aws:rds/instance:Instance::db"
+		cacheURN  = "urn:pulumi:dev::app::aws:elasticache/cluster:Cluster::cache"
+		bucketURN = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets"
+	)
+	plan, err := ingest.ParsePulumiPlan([]byte(`{"steps": [
+		{"op": "create", "urn": "` + webURN + `",
+			"newState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.micro"}}},
+		{"op": "update", "urn": "` + apiURN + `",
+			"oldState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.small"}},
+			"newState": {"type": "aws:ec2/instance:Instance", "inputs": {"instanceType": "t3.large"}}},
+		{"op": "create-replacement", "urn": "` + dbURN + `",
+			"newState": {"type": "aws:rds/instance:Instance", "inputs": {"instanceClass": "db.m5.large"}}},
+		{"op": "replace", "urn": "` + dbURN + `",
+			"oldState": {"type": "aws:rds/instance:Instance", "inputs": {"instanceClass": "db.t3.micro"}},
+			"newState": {"type": "aws:rds/instance:Instance", "inputs": {"instanceClass": "db.m5.large"}}},
+		{"op": "delete-replaced", "urn": "` + dbURN + `",
+			"oldState": {"type": "aws:rds/instance:Instance", "inputs": {"instanceClass": "db.t3.micro"}}},
+		{"op": "delete", "urn": "` + cacheURN + `",
+			"oldState": {"type": "aws:elasticache/cluster:Cluster", "inputs": {"nodeType": "cache.t3.small"}}},
+		{"op": "same", "urn": "` + bucketURN + `", "oldState": {"type": "aws:s3/bucket:Bucket"}}
+	]}`))
+	require.NoError(t, err)
+
+	changes := plan.GetResourceChanges()
+	require.Len(t, changes, 4, "unchanged resources are left out")
+
+	web := changes[0]
+	assert.Equal(t, ingest.ChangeCreate, web.Op)
+	assert.Nil(t, web.Before)
+	require.NotNil(t, web.After)
+	assert.Equal(t, "t3.micro", web.After.Inputs["instanceType"])
+
+	api := changes[1]
+	assert.Equal(t, ingest.ChangeUpdate, api.Op)
+	require.NotNil(t, api.Before)
+	assert.Equal(t, "t3.small", api.Before.Inputs["instanceType"])
+	assert.Equal(t, "t3.large", api.After.Inputs["instanceType"])
+
+	db := changes[2]
+	assert.Equal(t, ingest.ChangeReplace, db.Op, "the steps of a replacement are merged")
+	require.NotNil(t, db.Before)
+	require.NotNil(t, db.After)
+	assert.Equal(t, "db.t3.micro", db.Before.Inputs["instanceClass"])
+	assert.Equal(t, "db.m5.large", db.After.Inputs["instanceClass"])
+
+	cache := changes[3]
+	assert.Equal(t, ingest.ChangeDelete, cache.Op)
+	require.NotNil(t, cache.Before)
+	assert.Equal(t, "aws:elasticache/cluster:Cluster", cache.Before.Type)
+	assert.Nil(t, cache.After)
+}
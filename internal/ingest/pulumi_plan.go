@@ -38,6 +38,14 @@ type PulumiState struct {
 	Inputs   map[string]interface{} `json:"inputs"`
 	Outputs  map[string]interface{} `json:"outputs"`
 	Provider string                 `json:"provider"`
+	// Parent is the URN of the component or stack that the resource is a
+	// child of.
+	Parent string `json:"parent,omitempty"`
+	// Dependencies are the URNs of the resources the resource depends on.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// SourcePosition is where the resource is declared in the program
+	// (e.g. "project:///index.ts#12,5"), when recorded by the Pulumi CLI.
+	SourcePosition string `json:"sourcePosition,omitempty"`
 }
 
 // PulumiResource contains the detailed information about a resource in a Pulumi step.
@@ -47,6 +55,15 @@ type PulumiResource struct {
 	Provider string
 	Inputs   map[string]interface{}
 	Outputs  map[string]interface{}
+	// SourcePosition is the Pulumi source position of the resource declaration, if known.
+	SourcePosition string
+	// Parent is the URN of the component or stack that the resource is a
+	// child of, and Components are the URNs of the components that enclose
+	// it, outermost first.
+	Parent     string
+	Components []string
+	// Dependencies are the URNs of the resources the resource depends on.
+	Dependencies []string
 }
 
 // ParsePulumiPlan parses a Pulumi plan from JSON bytes.
@@ -142,37 +159,14 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 	var skippedOps []string
 
 	for _, step := range p.Steps {
-		//nolint:nestif // Complexity is acceptable for this resource extraction logic
 		if step.Op == "create" || step.Op == "update" || step.Op == "same" {
-			resType := step.Type
-			inputs := step.Inputs
-
-			// Prioritize NewState for Create/Update operations if available
-			if step.NewState != nil {
-				if resType == "" {
-					resType = step.NewState.Type
-				}
-				if inputs == nil {
-					inputs = step.NewState.Inputs
-				}
-			}
-
-			if resType == "" {
-				resType = extractTypeFromURN(step.URN)
-			}
-
-			resources = append(resources, PulumiResource{
-				Type:     resType,
-				URN:      step.URN,
-				Provider: extractProviderFromURN(step.URN),
-				Inputs:   inputs,
-				Outputs:  resolveStepOutputs(step),
-			})
+			resource := stepResource(step)
+			resources = append(resources, resource)
 			log.Debug().
 				Ctx(ctx).
 				Str("component", "ingest").
 				Str("resource_type", step.Type).
-				Str("extracted_type", resType).
+				Str("extracted_type", resource.Type).
 				Str("operation", step.Op).
 				Str("urn", step.URN).
 				Msg("extracted resource from plan")
@@ -181,6 +175,14 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 		}
 	}
 
+	parents := make(map[string]string, len(p.Steps))
+	for _, step := range p.Steps {
+		parents[step.URN] = resolveStepParent(step)
+	}
+	for i := range resources {
+		resources[i].Components = componentAncestry(resources[i].Parent, parents)
+	}
+
 	log.Debug().
 		Ctx(ctx).
 		Str("component", "ingest").
@@ -192,6 +194,74 @@ func (p *PulumiPlan) GetResourcesWithContext(ctx context.Context) []PulumiResour
 	return resources
 }
 
+// resolveStepSourcePosition returns the source position from the step's new
+// state, falling back to its old state.
+func resolveStepSourcePosition(step PulumiStep) string {
+	if step.NewState != nil && step.NewState.SourcePosition != "" {
+		return step.NewState.SourcePosition
+	}
+	if step.OldState != nil {
+		return step.OldState.SourcePosition
+	}
+	return ""
+}
+
+// stepResource returns the resource of a step as the step leaves it,
+// preferring the step's own type and inputs over those of its new state.
+func stepResource(step PulumiStep) PulumiResource {
+	resType := step.Type
+	inputs := step.Inputs
+
+	// Prioritize NewState for Create/Update operations if available
+	if step.NewState != nil {
+		if resType == "" {
+			resType = step.NewState.Type
+		}
+		if inputs == nil {
+			inputs = step.NewState.Inputs
+		}
+	}
+
+	if resType == "" {
+		resType = extractTypeFromURN(step.URN)
+	}
+
+	return PulumiResource{
+		Type:           resType,
+		URN:            step.URN,
+		Provider:       extractProviderFromURN(step.URN),
+		Inputs:         inputs,
+		Outputs:        resolveStepOutputs(step),
+		SourcePosition: resolveStepSourcePosition(step),
+		Parent:         resolveStepParent(step),
+		Dependencies:   resolveStepDependencies(step),
+	}
+}
+
+// resolveStepParent returns the parent URN from the step's new state,
+// falling back to its old state.
+func resolveStepParent(step PulumiStep) string {
+	if step.NewState != nil && step.NewState.Parent != "" {
+		return step.NewState.Parent
+	}
+	if step.OldState != nil {
+		return step.OldState.Parent
+	}
+	return ""
+}
+
+// resolveStepDependencies returns the dependencies from the step's new state,
+// falling back to its old state.
+func resolveStepDependencies(step PulumiStep) []string {
+	if step.NewState != nil && len(step.NewState.Dependencies) > 0 {
+		return step.NewState.Dependencies
+	}
+	if step.OldState != nil {
+		return step.OldState.Dependencies
+	}
+	return nil
+}
+
 // resolveStepOutputs picks the best available Outputs for a step.
 // resolveStepOutputs returns the outputs map for a PulumiStep.
 // It selects outputs with the following priority: step-level Outputs, NewState.Outputs,
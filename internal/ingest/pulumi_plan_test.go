@@ -696,3 +696,27 @@ func TestLoadPulumiPlan_DelegationEquivalence(t *testing.T) {
 		})
 	}
 }
+
+func TestPulumiPlan_Dependencies(t *testing.T) {
+	const (
+		natURN      = "urn:pulumi:dev::app::aws:ec2/natGateway:NatGateway::nat"
+		instanceURN = "urn:pulumi:dev::app::aws:ec2/instance:Instance::api"
+		bucketURN   = "urn:pulumi:dev::app::aws:s3/bucket:Bucket::assets"
+	)
+	plan, err := ingest.ParsePulumiPlan([]byte(`{"steps": [
+		{"op": "create", "urn": "` + instanceURN + `",
+			"newState": {"type": "aws:ec2/instance:Instance", "dependencies": ["` + natURN + `"]}},
+		{"op": "same", "urn": "` + bucketURN + `",
+			"oldState": {"type": "aws:s3/bucket:Bucket", "dependencies": ["` + natURN + `"]}}
+	]}`))
+	require.NoError(t, err)
+
+	resources := plan.GetResources()
+	require.Len(t, resources, 2)
+	assert.Equal(t, []string{natURN}, resources[0].Dependencies)
+	assert.Equal(t, []string{natURN}, resources[1].Dependencies, "old state dependencies count")
+
+	desc, err := ingest.MapResource(resources[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{natURN}, desc.Dependencies)
+}
@@ -0,0 +1,53 @@
+package ingest
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// SourceLocation is a position in a Pulumi program's source code.
+type SourceLocation struct {
+	// Path is relative to the Pulumi project directory for project:// positions,
+	// and absolute for file:// positions.
+	Path string
+	// Line and Column are 1-based. Column is zero when not recorded.
+	Line   int
+	Column int
+}
+
+// ParseSourcePosition parses a Pulumi resource source position of the form
+// "project:///index.ts#12,5" or "file:///abs/path/main.go#12,5". It reports
+// false when the position is empty or not in a recognized form.
+func ParseSourcePosition(pos string) (SourceLocation, bool) {
+	if pos == "" {
+		return SourceLocation{}, false
+	}
+	u, err := url.Parse(pos)
+	if err != nil || u.Path == "" || u.Fragment == "" {
+		return SourceLocation{}, false
+	}
+
+	var loc SourceLocation
+	switch u.Scheme {
+	case "project":
+		loc.Path = strings.TrimPrefix(u.Path, "/")
+	case "file":
+		loc.Path = u.Path
+	default:
+		return SourceLocation{}, false
+	}
+
+	lineStr, colStr, hasCol := strings.Cut(u.Fragment, ",")
+	line, err := strconv.Atoi(lineStr)
+	if err != nil || line < 1 {
+		return SourceLocation{}, false
+	}
+	loc.Line = line
+	if hasCol {
+		if col, colErr := strconv.Atoi(colStr); colErr == nil && col > 0 {
+			loc.Column = col
+		}
+	}
+	return loc, true
+}
@@ -0,0 +1,51 @@
+package ingest_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/ingest"
+)
+
+func TestParseSourcePosition(t *testing.T) {
+	tests := []struct {
+		pos  string
+		want ingest.SourceLocation
+		ok   bool
+	}{
+		{pos: "project:///index.ts#12,5", want: ingest.SourceLocation{Path: "index.ts", Line: 12, Column: 5}, ok: true},
+		{pos: "project:///infra/db/main.go#7", want: ingest.SourceLocation{Path: "infra/db/main.go", Line: 7}, ok: true},
+		{pos: "file:///home/me/app/__main__.py#3,1", want: ingest.SourceLocation{
+			Path: "/home/me/app/__main__.py", Line: 3, Column: 1,
+		}, ok: true},
+		{pos: ""},
+		{pos: "project:///index.ts"},
+		{pos: "project:///index.ts#zero,1"},
+		{pos: "https://example.com/index.ts#1,1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pos, func(t *testing.T) {
+			got, ok := ingest.ParseSourcePosition(tt.pos)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGetResources_SourcePosition(t *testing.T) {
+	plan, err := ingest.ParsePulumiPlan([]byte(`{"steps": [
+		{"op": "create", "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::logs",
+		 "newState": {"type": "aws:s3/bucket:Bucket", "sourcePosition": "project:///index.ts#4,15"}},
+		{"op": "same", "urn": "urn:pulumi:dev::app::aws:s3/bucket:Bucket::old",
+		 "oldState": {"type": "aws:s3/bucket:Bucket", "sourcePosition": "project:///index.ts#9,1"}}
+	]}`))
+	require.NoError(t, err)
+
+	descriptors, err := ingest.MapResources(plan.GetResources())
+	require.NoError(t, err)
+	require.Len(t, descriptors, 2)
+	assert.Equal(t, "project:///index.ts#4,15", descriptors[0].SourcePosition)
+	assert.Equal(t, "project:///index.ts#9,1", descriptors[1].SourcePosition, "falls back to the old state")
+}
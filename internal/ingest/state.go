@@ -63,6 +63,17 @@ type StackExportResource struct {
 	// Modified tracks when the resource state was last altered.
 	// Available since Pulumi v3.60.0 (March 2023).
 	Modified *time.Time `json:"modified,omitempty"`
+	// SourcePosition is where the resource is declared in the program
+	// (e.g. "project:///index.ts#12,5"), when recorded by the Pulumi CLI.
+	SourcePosition string `json:"sourcePosition,omitempty"`
+	// Parent is the URN of the component or stack that the resource is a
+	// child of.
+	Parent string `json:"parent,omitempty"`
+	// Dependencies are the URNs of the resources the resource depends on.
+	Dependencies []string `json:"dependencies,omitempty"`
+	// Components are the URNs of the components that enclose the resource,
+	// outermost first, as GetCustomResources finds them.
+	Components []string `json:"-"`
 }
 
 // ParseStackExport parses Pulumi state JSON from bytes.
@@ -144,7 +155,8 @@ func LoadStackExportWithContext(ctx context.Context, path string) (*StackExport,
 }
 
 // GetCustomResources returns only custom resources (cloud resources) from state.
-// Component resources and providers are filtered out.
+// Component resources and providers are filtered out; the components that
+// enclose each resource are recorded in its Components.
 func (s *StackExport) GetCustomResources() []StackExportResource {
 	return s.GetCustomResourcesWithContext(context.Background())
 }
@@ -154,12 +166,17 @@ func (s *StackExport) GetCustomResourcesWithContext(ctx context.Context) []Stack
 	log := logging.FromContext(ctx)
 	// Pre-allocate with estimate; most resources in typical stacks are custom
 	resources := make([]StackExportResource, 0, len(s.Deployment.Resources))
+	parents := make(map[string]string, len(s.Deployment.Resources))
 
 	for _, r := range s.Deployment.Resources {
+		parents[r.URN] = r.Parent
 		if r.Custom {
 			resources = append(resources, r)
 		}
 	}
+	for i := range resources {
+		resources[i].Components = componentAncestry(resources[i].Parent, parents)
+	}
 
 	log.Debug().
 		Str("component", "ingest").
@@ -170,19 +187,15 @@ func (s *StackExport) GetCustomResourcesWithContext(ctx context.Context) []Stack
 	return resources
 }
 
-// MapStateResource converts a StackExportResource to a ResourceDescriptor.
-// Timestamps, cloud identifiers (ID, ARN), and outputs are injected into Properties.
+// MapStateResource converts a StackExportResource into an engine.ResourceDescriptor.
 // Properties are built by merging Outputs (base) with Inputs (overlay), so provider-
 // computed values like size, iops, and tagsAll are included while user-declared inputs
-// MapStateResource converts a StackExportResource into an engine.ResourceDescriptor.
-// It merges the resource's outputs with its inputs (inputs take precedence on conflict),
-// injects Pulumi-specific metadata (created/modified timestamps as RFC3339 strings, external flag,
-// cloud resource ID, URN), and, if present, copies the merged "arn" property into the Pulumi ARN key.
-// The given resource's Type becomes the descriptor Type and the resource URN is used as the descriptor ID.
-//
-// The resource parameter is the StackExportResource to convert.
+// win on conflict. Created/modified timestamps (RFC3339), the external flag, the cloud
+// resource ID, the URN, and the "arn" output are injected under the pulumi:* keys used
+// for actual cost lookups, and Kubernetes resources get the properties that
+// MapK8sWorkload sets. The resource URN becomes the descriptor ID.
 //
-// It returns the mapped engine.ResourceDescriptor and an error. The function currently does not return non-nil errors.
+// The function does not currently produce an error; the returned error is nil.
 func MapStateResource(resource StackExportResource) (engine.ResourceDescriptor, error) {
 	provider := extractProvider(resource.Type)
 
@@ -191,6 +204,7 @@ func MapStateResource(resource StackExportResource) (engine.ResourceDescriptor,
 	if properties == nil {
 		properties = make(map[string]interface{})
 	}
+	enrichK8sProperties(resource.Type, resource.URN, properties)
 
 	// Inject timestamps as RFC3339 strings
 	if resource.Created != nil {
@@ -215,10 +229,13 @@ func MapStateResource(resource StackExportResource) (engine.ResourceDescriptor,
 	}
 
 	return engine.ResourceDescriptor{
-		Type:       resource.Type,
-		ID:         resource.URN,
-		Provider:   provider,
-		Properties: properties,
+		Type:           resource.Type,
+		ID:             resource.URN,
+		Provider:       provider,
+		Properties:     properties,
+		SourcePosition: resource.SourcePosition,
+		Components:     resource.Components,
+		Dependencies:   resource.Dependencies,
 	}, nil
 }
 
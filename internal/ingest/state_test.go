@@ -754,3 +754,15 @@ func TestMapStateResource_GoldenEKSState(t *testing.T) {
 	assert.Equal(t, "i-0bastion1234abcde", bastion.Properties[ingest.PropertyPulumiCloudID])
 	assert.Equal(t, "true", bastion.Properties[ingest.PropertyPulumiExternal])
 }
+
+func TestMapStateResource_Dependencies(t *testing.T) {
+	state, err := ingest.ParseStackExport([]byte(`{"version": 3, "deployment": {"resources": [
+		{"urn": "urn:pulumi:dev::app::aws:ec2/instance:Instance::api", "type": "aws:ec2/instance:Instance",
+			"custom": true, "dependencies": ["urn:pulumi:dev::app::aws:ec2/natGateway:NatGateway::nat"]}
+	]}}`))
+	require.NoError(t, err)
+
+	desc, err := ingest.MapStateResource(state.GetCustomResources()[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"urn:pulumi:dev::app::aws:ec2/natGateway:NatGateway::nat"}, desc.Dependencies)
+}
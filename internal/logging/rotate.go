@@ -0,0 +1,189 @@
+package logging
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// bytesPerMB converts RotateOptions.MaxSizeMB to bytes.
+const bytesPerMB = 1024 * 1024
+
+// backupTimeFormat is the timestamp of rotated log file names. It sorts in
+// time order.
+const backupTimeFormat = "20060102T150405.000"
+
+// RotateOptions bounds the size and age of a log file and of the backups
+// it is rotated to.
+type RotateOptions struct {
+	// MaxSizeMB rotates the file before it grows past this size. 0 never
+	// rotates by size.
+	MaxSizeMB int
+	// MaxAge removes backups last written longer ago than this, and rotates
+	// the file when it is opened if it was. 0 keeps files of any age.
+	MaxAge time.Duration
+	// MaxBackups is the number of backups kept. 0 keeps all.
+	MaxBackups int
+}
+
+// RotatingFile is a log file that is renamed to a timestamped backup, such
+// as finfocus-20261015T101500.000.log, when it would grow past its maximum
+// size, and whose oldest backups are removed. A RotatingFile is safe for
+// concurrent use.
+type RotatingFile struct {
+	path string
+	opts RotateOptions
+	now  func() time.Time
+
+	mu   sync.Mutex
+	file *os.File
+	size int64
+}
+
+// OpenRotatingFile opens the log file at path for appending, creating it and
+// its directory if needed.
+func OpenRotatingFile(path string, opts RotateOptions) (*RotatingFile, error) {
+	return openRotatingFile(path, opts, time.Now)
+}
+
+// openRotatingFile opens a rotating file whose clock is now.
+func openRotatingFile(path string, opts RotateOptions, now func() time.Time) (*RotatingFile, error) {
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return nil, err
+	}
+	r := &RotatingFile{path: path, opts: opts, now: now}
+	if info, err := os.Stat(path); err == nil && info.Size() > 0 && r.expired(info) {
+		if err = r.rotate(); err != nil {
+			return nil, err
+		}
+		return r, nil
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	r.prune()
+	return r, nil
+}
+
+// Name returns the path of the log file.
+func (r *RotatingFile) Name() string {
+	return r.path
+}
+
+// Write appends p to the log file, first rotating it when p would take it
+// past the maximum size. An entry larger than the maximum size is written
+// to a file of its own.
+func (r *RotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if limit := int64(r.opts.MaxSizeMB) * bytesPerMB; limit > 0 && r.size > 0 && r.size+int64(len(p)) > limit {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// Close closes the log file.
+func (r *RotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
+
+// open opens the log file for appending.
+func (r *RotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	info, err := file.Stat()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+	r.file, r.size = file, info.Size()
+	return nil
+}
+
+// rotate renames the log file to a backup, opens a new one, and removes
+// the backups that are too old or too many.
+func (r *RotatingFile) rotate() error {
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			return err
+		}
+		r.file = nil
+	}
+	if err := os.Rename(r.path, r.backupPath(r.now())); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("rotating log file: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return err
+	}
+	r.prune()
+	return nil
+}
+
+// backupPath returns the path of the backup rotated at t.
+func (r *RotatingFile) backupPath(t time.Time) string {
+	ext := filepath.Ext(r.path)
+	return strings.TrimSuffix(r.path, ext) + "-" + t.Format(backupTimeFormat) + ext
+}
+
+// expired reports whether a file was last written longer ago than MaxAge.
+func (r *RotatingFile) expired(info os.FileInfo) bool {
+	return r.opts.MaxAge > 0 && r.now().Sub(info.ModTime()) > r.opts.MaxAge
+}
+
+// prune removes the expired backups and those beyond MaxBackups, newest
+// first. Errors are ignored: a backup left behind is removed next time.
+func (r *RotatingFile) prune() {
+	if r.opts.MaxAge <= 0 && r.opts.MaxBackups <= 0 {
+		return
+	}
+	ext := filepath.Ext(r.path)
+	prefix := filepath.Base(strings.TrimSuffix(r.path, ext)) + "-"
+	entries, err := os.ReadDir(filepath.Dir(r.path))
+	if err != nil {
+		return
+	}
+
+	var backups []os.DirEntry
+	for _, entry := range entries {
+		name := entry.Name()
+		stamp := strings.TrimSuffix(strings.TrimPrefix(name, prefix), ext)
+		if entry.IsDir() || !strings.HasPrefix(name, prefix) || !strings.HasSuffix(name, ext) {
+			continue
+		}
+		if _, parseErr := time.Parse(backupTimeFormat, stamp); parseErr == nil {
+			backups = append(backups, entry)
+		}
+	}
+	sort.Slice(backups, func(i, j int) bool { return backups[i].Name() > backups[j].Name() })
+
+	for i, backup := range backups {
+		remove := r.opts.MaxBackups > 0 && i >= r.opts.MaxBackups
+		if info, infoErr := backup.Info(); !remove && infoErr == nil {
+			remove = r.expired(info)
+		}
+		if remove {
+			_ = os.Remove(filepath.Join(filepath.Dir(r.path), backup.Name()))
+		}
+	}
+}
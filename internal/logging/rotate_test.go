@@ -0,0 +1,99 @@
+package logging
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// logFiles returns the names of the files in dir, sorted.
+func logFiles(t *testing.T, dir string) []string {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	return names
+}
+
+func TestRotatingFile_RotatesBySize(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "finfocus.log")
+	clock := time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)
+	now := func() time.Time {
+		clock = clock.Add(time.Second)
+		return clock
+	}
+
+	file, err := openRotatingFile(path, RotateOptions{MaxSizeMB: 1, MaxBackups: 2}, now)
+	require.NoError(t, err)
+	defer file.Close()
+
+	entry := []byte(strings.Repeat("x", 400*1024) + "\n")
+	for range 8 {
+		_, err = file.Write(entry)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, []string{
+		"finfocus-20261015T100002.000.log",
+		"finfocus-20261015T100003.000.log",
+		"finfocus.log",
+	}, logFiles(t, dir), "rotated every two entries, keeping the two newest backups")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2*len(entry)), info.Size())
+}
+
+func TestRotatingFile_RemovesExpiredFiles(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "finfocus.log")
+	old := time.Now().Add(-10 * 24 * time.Hour)
+	stale := filepath.Join(dir, "finfocus-20261001T100000.000.log")
+	unrelated := filepath.Join(dir, "finfocus-notes.log")
+	for _, name := range []string{path, stale, unrelated} {
+		require.NoError(t, os.WriteFile(name, []byte("old\n"), 0600))
+		require.NoError(t, os.Chtimes(name, old, old))
+	}
+
+	file, err := OpenRotatingFile(path, RotateOptions{MaxAge: 7 * 24 * time.Hour})
+	require.NoError(t, err)
+	defer file.Close()
+
+	assert.Equal(t, []string{"finfocus-notes.log", "finfocus.log"}, logFiles(t, dir),
+		"the stale log file is rotated and removed with the expired backup")
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+}
+
+func TestNewLoggerWithPath_FileLevelAndConsole(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "finfocus.log")
+	result := NewLoggerWithPath(Config{
+		Level:     "error",
+		Format:    "text",
+		Output:    "file",
+		File:      path,
+		FileLevel: "debug",
+	})
+	defer result.Close()
+	require.True(t, result.UsingFile)
+
+	result.Logger.Debug().Str("component", "test").Msg("debug detail")
+	content, err := os.ReadFile(path)
+	require.NoError(t, err)
+	var entry map[string]any
+	require.NoError(t, json.Unmarshal(content, &entry), "the file is JSON whatever the format")
+	assert.Equal(t, "debug detail", entry["message"])
+	assert.Equal(t, "debug", entry["level"])
+}
@@ -7,7 +7,6 @@ import (
 	"fmt"
 	"io"
 	"os"
-	"path/filepath"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -39,6 +38,10 @@ type Config struct {
 	File       string // File path when Output is "file"
 	Caller     bool   // Include file:line in output
 	StackTrace bool   // Include stack trace on errors
+
+	FileLevel string        // Log level of the file when Output is "file"; Level when empty
+	Console   bool          // Also write to stderr at Level when Output is "file"
+	Rotate    RotateOptions // Rotation of the file when Output is "file"
 }
 
 // TracingHook implements zerolog.Hook to automatically inject trace_id from context.
@@ -70,17 +73,18 @@ type LogPathResult struct {
 	UsingFile      bool           // True if logging to file
 	FallbackUsed   bool           // True if fallback to stderr occurred
 	FallbackReason string         // Reason for fallback (if any)
-	file           *os.File       // Internal: file handle for cleanup
+	file           io.Closer      // Internal: file handle for cleanup
 	pluginLogFile  *os.File       // Internal: separate file handle for plugin I/O
 }
 
 // NewLoggerWithPath creates a zerolog logger according to cfg and reports the chosen log destination.
 //
 // If cfg.Output is "file" and cfg.File is non-empty, NewLoggerWithPath attempts to open or create
-// the specified file and, on success, returns a logger that writes to that file and sets LogPathResult.FilePath
-// and LogPathResult.UsingFile = true. If opening the file fails, the function falls back to stderr,
-// sets LogPathResult.FallbackUsed = true and LogPathResult.FallbackReason to the error string, and
-// returns a logger that writes to stderr. If cfg.File is empty the function uses stderr.
+// the specified file, rotated according to cfg.Rotate, and, on success, returns a logger that writes
+// JSON to that file at cfg.FileLevel, and to stderr at cfg.Level when cfg.Console is set. It sets
+// LogPathResult.FilePath and LogPathResult.UsingFile = true. If opening the file fails, the function
+// falls back to stderr, sets LogPathResult.FallbackUsed = true and LogPathResult.FallbackReason to the
+// error string, and returns a logger that writes to stderr. If cfg.File is empty the function uses stderr.
 //
 // If cfg.Output is "stdout" the returned logger writes to stdout. For any other cfg.Output value
 // the returned logger writes to stderr.
@@ -93,21 +97,15 @@ func NewLoggerWithPath(cfg Config) LogPathResult {
 	switch cfg.Output {
 	case "file":
 		if cfg.File != "" {
-			// Ensure log directory exists before opening the file.
-			if mkdirErr := os.MkdirAll(filepath.Dir(cfg.File), 0700); mkdirErr != nil {
-				result.FallbackUsed = true
-				result.FallbackReason = mkdirErr.Error()
-				result.Logger = newLoggerWithWriter(cfg, os.Stderr)
-				break
-			}
-			file, err := os.OpenFile(cfg.File, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+			// The log directory is created before the file is opened.
+			file, err := OpenRotatingFile(cfg.File, cfg.Rotate)
 			if err != nil {
 				// Fall back to stderr.
 				result.FallbackUsed = true
 				result.FallbackReason = err.Error()
 				result.Logger = newLoggerWithWriter(cfg, os.Stderr)
 			} else {
-				result.Logger = newLoggerWithWriter(cfg, file)
+				result.Logger = newFileLogger(cfg, file)
 				result.FilePath = cfg.File
 				result.UsingFile = true
 				result.file = file // Store file handle for cleanup
@@ -162,23 +160,49 @@ func NewLoggerWithWriter(cfg Config, writer io.Writer) zerolog.Logger {
 
 // newLoggerWithWriter is the internal implementation for creating loggers.
 func newLoggerWithWriter(cfg Config, writer io.Writer) zerolog.Logger {
-	// Apply format transformation
-	output := writer
+	return newLoggerWithLevel(cfg, formatWriter(cfg, writer), parseLevel(cfg.Level))
+}
+
+// newFileLogger creates a logger writing JSON to file at cfg.FileLevel, and
+// to stderr at cfg.Level in cfg.Format when cfg.Console is set, so the file
+// keeps its detail whatever the console shows.
+func newFileLogger(cfg Config, file io.Writer) zerolog.Logger {
+	level := parseLevel(cfg.Level)
+	if cfg.FileLevel != "" {
+		level = parseLevel(cfg.FileLevel)
+	}
+	writers := []io.Writer{levelWriter{Writer: file, level: level}}
+	if cfg.Console {
+		consoleLevel := parseLevel(cfg.Level)
+		writers = append(writers, levelWriter{Writer: formatWriter(cfg, os.Stderr), level: consoleLevel})
+		level = min(level, consoleLevel)
+	}
+	return newLoggerWithLevel(cfg, zerolog.MultiLevelWriter(writers...), level)
+}
+
+// formatWriter wraps writer to write in cfg.Format: human-readable for
+// "console" and "text", JSON otherwise.
+func formatWriter(cfg Config, writer io.Writer) io.Writer {
 	if cfg.Format == "console" || cfg.Format == "text" {
-		output = zerolog.ConsoleWriter{
+		return zerolog.ConsoleWriter{
 			Out:        writer,
 			TimeFormat: time.RFC3339,
 			NoColor:    false,
 		}
 	}
+	return writer
+}
 
+// newLoggerWithLevel creates a logger writing events of at least level to
+// output.
+func newLoggerWithLevel(cfg Config, output io.Writer, level zerolog.Level) zerolog.Logger {
 	// Create base logger with timestamp
 	logger := zerolog.New(output).
 		With().
 		Timestamp().
 		Logger().
 		Hook(TracingHook{}).
-		Level(parseLevel(cfg.Level))
+		Level(level)
 
 	// Add caller info if configured
 	if cfg.Caller {
@@ -188,6 +212,22 @@ func newLoggerWithWriter(cfg Config, writer io.Writer) zerolog.Logger {
 	return logger
 }
 
+// levelWriter writes the events of at least level to the embedded writer,
+// for loggers whose writers log at different levels.
+type levelWriter struct {
+	io.Writer
+	level zerolog.Level
+}
+
+// WriteLevel implements zerolog.LevelWriter, dropping events below the
+// writer's level.
+func (w levelWriter) WriteLevel(level zerolog.Level, p []byte) (int, error) {
+	if level < w.level {
+		return len(p), nil
+	}
+	return w.Write(p)
+}
+
 // createWriter selects an io.Writer based on the provided LoggingConfig.
 // If cfg.Output is "stdout" it returns os.Stdout. If cfg.Output is "file" and
 // cfg.File is a non-empty path it attempts to open (or create) the file for
@@ -0,0 +1,292 @@
+// Package metrics exposes finfocus cost data as Prometheus metrics.
+//
+// The Exporter holds the latest Snapshot and serves it on scrape, so every
+// scrape sees one complete refresh rather than a partially updated set of
+// gauges. Samples are summed per label set, which keeps cardinality at the
+// level of provider, stack, resource type, and the configured tag labels
+// rather than individual resources.
+package metrics
+
+import (
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Namespace prefixes every finfocus metric name.
+const Namespace = "finfocus"
+
+// tagLabelPrefix prefixes the label created for each configured tag key.
+const tagLabelPrefix = "tag_"
+
+// invalidLabelChars matches characters that are not allowed in label names.
+var invalidLabelChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// CostSample is the projected monthly cost of one resource.
+type CostSample struct {
+	Provider     string
+	Stack        string
+	ResourceType string
+	Currency     string
+	Tags         map[string]string
+	Monthly      float64
+}
+
+// BudgetSample is the utilization of one budget scope.
+type BudgetSample struct {
+	// Scope identifies the budget, e.g. "global", "provider:aws", or "stack:prod".
+	Scope    string
+	Currency string
+	Limit    float64
+	Spend    float64
+}
+
+// SavingsSample is the monthly savings one recommendation would achieve.
+type SavingsSample struct {
+	Provider string
+	Stack    string
+	Action   string
+	Currency string
+	Monthly  float64
+}
+
+// Snapshot is the result of one refresh.
+type Snapshot struct {
+	Costs   []CostSample
+	Budgets []BudgetSample
+	Savings []SavingsSample
+}
+
+// sample is an aggregated metric value with its label values.
+type sample struct {
+	labels []string
+	value  float64
+}
+
+// Exporter is a prometheus.Collector serving the latest Snapshot together with
+// the status of the most recent refresh.
+type Exporter struct {
+	registry *prometheus.Registry
+	tagKeys  []string
+
+	costDesc         *prometheus.Desc
+	resourcesDesc    *prometheus.Desc
+	budgetLimitDesc  *prometheus.Desc
+	budgetSpendDesc  *prometheus.Desc
+	budgetUtilDesc   *prometheus.Desc
+	savingsDesc      *prometheus.Desc
+	recsDesc         *prometheus.Desc
+	refreshOKDesc    *prometheus.Desc
+	refreshTimeDesc  *prometheus.Desc
+	refreshDurDesc   *prometheus.Desc
+	refreshErrorDesc *prometheus.Desc
+
+	mu            sync.RWMutex
+	costs         []sample
+	resources     []sample
+	budgetLimits  []sample
+	budgetSpend   []sample
+	budgetUtil    []sample
+	savings       []sample
+	recs          []sample
+	refreshed     bool
+	lastOK        bool
+	lastRefresh   time.Time
+	lastDuration  time.Duration
+	refreshErrors int
+}
+
+// NewExporter returns an Exporter registered with its own registry, alongside
+// the Go runtime and process collectors. Each tag key becomes a cost label
+// named tag_<key>, with characters invalid in label names replaced by "_".
+func NewExporter(tagKeys []string) *Exporter {
+	e := &Exporter{registry: prometheus.NewRegistry()}
+
+	costLabels := []string{"provider", "stack", "resource_type", "currency"}
+	seen := make(map[string]bool)
+	for _, key := range tagKeys {
+		label := TagLabel(key)
+		if key == "" || seen[label] {
+			continue
+		}
+		seen[label] = true
+		e.tagKeys = append(e.tagKeys, key)
+		costLabels = append(costLabels, label)
+	}
+
+	e.costDesc = prometheus.NewDesc(Namespace+"_projected_monthly_cost",
+		"Projected monthly cost of the priced resources.", costLabels, nil)
+	e.resourcesDesc = prometheus.NewDesc(Namespace+"_resources",
+		"Number of priced resources.", costLabels, nil)
+	e.budgetLimitDesc = prometheus.NewDesc(Namespace+"_budget_limit",
+		"Configured budget amount.", []string{"scope", "currency"}, nil)
+	e.budgetSpendDesc = prometheus.NewDesc(Namespace+"_budget_spend",
+		"Projected spend counted against the budget.", []string{"scope", "currency"}, nil)
+	e.budgetUtilDesc = prometheus.NewDesc(Namespace+"_budget_utilization_ratio",
+		"Projected spend divided by the budget amount (1 = 100%).", []string{"scope"}, nil)
+	e.savingsDesc = prometheus.NewDesc(Namespace+"_recommendation_savings_monthly",
+		"Estimated monthly savings of the open recommendations.",
+		[]string{"provider", "stack", "action", "currency"}, nil)
+	e.recsDesc = prometheus.NewDesc(Namespace+"_recommendations",
+		"Number of open recommendations.", []string{"provider", "stack", "action"}, nil)
+	e.refreshOKDesc = prometheus.NewDesc(Namespace+"_refresh_success",
+		"Whether the last refresh succeeded (1) or failed (0).", nil, nil)
+	e.refreshTimeDesc = prometheus.NewDesc(Namespace+"_last_refresh_timestamp_seconds",
+		"Unix time of the last refresh attempt.", nil, nil)
+	e.refreshDurDesc = prometheus.NewDesc(Namespace+"_last_refresh_duration_seconds",
+		"Duration of the last refresh attempt.", nil, nil)
+	e.refreshErrorDesc = prometheus.NewDesc(Namespace+"_refresh_errors_total",
+		"Number of failed refreshes.", nil, nil)
+
+	e.registry.MustRegister(e, collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}))
+	return e
+}
+
+// TagLabel returns the label name used for tag key.
+func TagLabel(key string) string {
+	return tagLabelPrefix + invalidLabelChars.ReplaceAllString(strings.ToLower(key), "_")
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (e *Exporter) Handler() http.Handler {
+	return promhttp.HandlerFor(e.registry, promhttp.HandlerOpts{})
+}
+
+// Update replaces the served metrics with snapshot and records a successful
+// refresh that started at start.
+func (e *Exporter) Update(snapshot Snapshot, start time.Time) {
+	costs, resources := e.aggregateCosts(snapshot.Costs)
+
+	budgetLimits := make([]sample, 0, len(snapshot.Budgets))
+	budgetSpend := make([]sample, 0, len(snapshot.Budgets))
+	budgetUtil := make([]sample, 0, len(snapshot.Budgets))
+	for _, b := range snapshot.Budgets {
+		budgetLimits = append(budgetLimits, sample{labels: []string{b.Scope, b.Currency}, value: b.Limit})
+		budgetSpend = append(budgetSpend, sample{labels: []string{b.Scope, b.Currency}, value: b.Spend})
+		if b.Limit > 0 {
+			budgetUtil = append(budgetUtil, sample{labels: []string{b.Scope}, value: b.Spend / b.Limit})
+		}
+	}
+
+	savingsAgg := newAggregator()
+	recsAgg := newAggregator()
+	for _, s := range snapshot.Savings {
+		savingsAgg.add([]string{s.Provider, s.Stack, s.Action, s.Currency}, s.Monthly)
+		recsAgg.add([]string{s.Provider, s.Stack, s.Action}, 1)
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.costs, e.resources = costs, resources
+	e.budgetLimits, e.budgetSpend, e.budgetUtil = budgetLimits, budgetSpend, budgetUtil
+	e.savings, e.recs = savingsAgg.samples(), recsAgg.samples()
+	e.recordRefresh(start, true)
+}
+
+// RecordFailure records a failed refresh that started at start. The metrics
+// of the last successful refresh keep being served.
+func (e *Exporter) RecordFailure(start time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.refreshErrors++
+	e.recordRefresh(start, false)
+}
+
+func (e *Exporter) recordRefresh(start time.Time, ok bool) {
+	e.refreshed = true
+	e.lastOK = ok
+	e.lastRefresh = start
+	e.lastDuration = time.Since(start)
+}
+
+// aggregateCosts sums costs and counts resources per cost label set.
+func (e *Exporter) aggregateCosts(costs []CostSample) ([]sample, []sample) {
+	costAgg := newAggregator()
+	countAgg := newAggregator()
+	for _, c := range costs {
+		labels := []string{c.Provider, c.Stack, c.ResourceType, c.Currency}
+		for _, key := range e.tagKeys {
+			labels = append(labels, c.Tags[key])
+		}
+		costAgg.add(labels, c.Monthly)
+		countAgg.add(labels, 1)
+	}
+	return costAgg.samples(), countAgg.samples()
+}
+
+// Describe implements prometheus.Collector.
+func (e *Exporter) Describe(ch chan<- *prometheus.Desc) {
+	for _, d := range []*prometheus.Desc{
+		e.costDesc, e.resourcesDesc, e.budgetLimitDesc, e.budgetSpendDesc, e.budgetUtilDesc,
+		e.savingsDesc, e.recsDesc, e.refreshOKDesc, e.refreshTimeDesc, e.refreshDurDesc, e.refreshErrorDesc,
+	} {
+		ch <- d
+	}
+}
+
+// Collect implements prometheus.Collector.
+func (e *Exporter) Collect(ch chan<- prometheus.Metric) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	emit := func(desc *prometheus.Desc, samples []sample) {
+		for _, s := range samples {
+			ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, s.value, s.labels...)
+		}
+	}
+	emit(e.costDesc, e.costs)
+	emit(e.resourcesDesc, e.resources)
+	emit(e.budgetLimitDesc, e.budgetLimits)
+	emit(e.budgetSpendDesc, e.budgetSpend)
+	emit(e.budgetUtilDesc, e.budgetUtil)
+	emit(e.savingsDesc, e.savings)
+	emit(e.recsDesc, e.recs)
+
+	ch <- prometheus.MustNewConstMetric(e.refreshErrorDesc, prometheus.CounterValue, float64(e.refreshErrors))
+	if !e.refreshed {
+		return
+	}
+	ok := 0.0
+	if e.lastOK {
+		ok = 1
+	}
+	ch <- prometheus.MustNewConstMetric(e.refreshOKDesc, prometheus.GaugeValue, ok)
+	ch <- prometheus.MustNewConstMetric(e.refreshTimeDesc, prometheus.GaugeValue,
+		float64(e.lastRefresh.UnixNano())/float64(time.Second))
+	ch <- prometheus.MustNewConstMetric(e.refreshDurDesc, prometheus.GaugeValue, e.lastDuration.Seconds())
+}
+
+// aggregator sums values per label set, preserving first-seen order.
+type aggregator struct {
+	index map[string]int
+	out   []sample
+}
+
+func newAggregator() *aggregator {
+	return &aggregator{index: make(map[string]int)}
+}
+
+func (a *aggregator) add(labels []string, value float64) {
+	key := strings.Join(labels, "\x00")
+	if i, ok := a.index[key]; ok {
+		a.out[i].value += value
+		return
+	}
+	a.index[key] = len(a.out)
+	a.out = append(a.out, sample{labels: labels, value: value})
+}
+
+func (a *aggregator) samples() []sample {
+	sort.SliceStable(a.out, func(i, j int) bool {
+		return strings.Join(a.out[i].labels, "\x00") < strings.Join(a.out[j].labels, "\x00")
+	})
+	return a.out
+}
@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func scrape(t *testing.T, e *Exporter) string {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	e.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, err := io.ReadAll(rec.Body)
+	require.NoError(t, err)
+	return string(body)
+}
+
+func TestTagLabel(t *testing.T) {
+	assert.Equal(t, "tag_team", TagLabel("team"))
+	assert.Equal(t, "tag_cost_center", TagLabel("Cost-Center"))
+	assert.Equal(t, "tag_app_kubernetes_io_name", TagLabel("app.kubernetes.io/name"))
+}
+
+func TestExporter_Update(t *testing.T) {
+	e := NewExporter([]string{"team", "team", ""})
+	e.Update(Snapshot{
+		Costs: []CostSample{
+			{Provider: "aws", Stack: "prod", ResourceType: "aws:ec2/instance:Instance", Currency: "USD",
+				Tags: map[string]string{"team": "web"}, Monthly: 100},
+			{Provider: "aws", Stack: "prod", ResourceType: "aws:ec2/instance:Instance", Currency: "USD",
+				Tags: map[string]string{"team": "web"}, Monthly: 50},
+			{Provider: "aws", Stack: "prod", ResourceType: "aws:s3/bucket:Bucket", Currency: "USD", Monthly: 2},
+		},
+		Budgets: []BudgetSample{{Scope: "global", Currency: "USD", Limit: 200, Spend: 152}},
+		Savings: []SavingsSample{
+			{Provider: "aws", Stack: "prod", Action: "RIGHTSIZE", Currency: "USD", Monthly: 30},
+			{Provider: "aws", Stack: "prod", Action: "RIGHTSIZE", Currency: "USD", Monthly: 10},
+		},
+	}, time.Now())
+
+	out := scrape(t, e)
+	assert.Contains(t, out, `finfocus_projected_monthly_cost{currency="USD",provider="aws",`+
+		`resource_type="aws:ec2/instance:Instance",stack="prod",tag_team="web"} 150`)
+	assert.Contains(t, out, `finfocus_resources{currency="USD",provider="aws",`+
+		`resource_type="aws:ec2/instance:Instance",stack="prod",tag_team="web"} 2`)
+	assert.Contains(t, out, `finfocus_projected_monthly_cost{currency="USD",provider="aws",`+
+		`resource_type="aws:s3/bucket:Bucket",stack="prod",tag_team=""} 2`)
+	assert.Contains(t, out, `finfocus_budget_limit{currency="USD",scope="global"} 200`)
+	assert.Contains(t, out, `finfocus_budget_spend{currency="USD",scope="global"} 152`)
+	assert.Contains(t, out, `finfocus_budget_utilization_ratio{scope="global"} 0.76`)
+	assert.Contains(t, out,
+		`finfocus_recommendation_savings_monthly{action="RIGHTSIZE",currency="USD",provider="aws",stack="prod"} 40`)
+	assert.Contains(t, out, `finfocus_recommendations{action="RIGHTSIZE",provider="aws",stack="prod"} 2`)
+	assert.Contains(t, out, "finfocus_refresh_success 1")
+	assert.Contains(t, out, "finfocus_refresh_errors_total 0")
+	assert.Contains(t, out, "go_goroutines")
+}
+
+func TestExporter_FailureKeepsLastSnapshot(t *testing.T) {
+	e := NewExporter(nil)
+	assert.NotContains(t, scrape(t, e), "finfocus_refresh_success", "no refresh yet")
+
+	e.Update(Snapshot{Costs: []CostSample{{Provider: "aws", Stack: "prod", ResourceType: "t", Monthly: 5}}},
+		time.Now())
+	e.RecordFailure(time.Now())
+
+	out := scrape(t, e)
+	assert.Contains(t, out, `finfocus_projected_monthly_cost{currency="",provider="aws",resource_type="t",stack="prod"} 5`)
+	assert.Contains(t, out, "finfocus_refresh_success 0")
+	assert.Contains(t, out, "finfocus_refresh_errors_total 1")
+
+	e.Update(Snapshot{}, time.Now())
+	assert.NotContains(t, scrape(t, e), "finfocus_projected_monthly_cost{", "an update replaces the snapshot")
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 
 	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -15,7 +17,8 @@ const TraceIDMetadataKey = "x-finfocus-trace-id"
 
 // TraceInterceptor returns a gRPC unary client interceptor that propagates trace IDs.
 // The interceptor extracts the trace ID from the context and injects it into gRPC metadata,
-// allowing end-to-end request tracing across process boundaries to plugins.
+// allowing end-to-end request tracing across process boundaries to plugins. When
+// OpenTelemetry tracing is enabled, the W3C traceparent header is injected as well.
 func TraceInterceptor() grpc.UnaryClientInterceptor {
 	return func(
 		ctx context.Context,
@@ -33,6 +36,14 @@ func TraceInterceptor() grpc.UnaryClientInterceptor {
 			ctx = metadata.AppendToOutgoingContext(ctx, TraceIDMetadataKey, traceID)
 		}
 
+		// Propagate the OpenTelemetry span context (traceparent) so plugins
+		// can attach their own spans to the caller's trace.
+		carrier := propagation.MapCarrier{}
+		otel.GetTextMapPropagator().Inject(ctx, carrier)
+		for key, value := range carrier {
+			ctx = metadata.AppendToOutgoingContext(ctx, key, value)
+		}
+
 		// Log the gRPC call
 		log := logging.FromContext(ctx)
 		log.Debug().
@@ -8,6 +8,9 @@ import (
 	"github.com/rs/zerolog"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/metadata"
 
@@ -177,3 +180,25 @@ func TestLoggedInterceptor(t *testing.T) {
 	assert.ErrorIs(t, err, assert.AnError)
 	assert.Contains(t, buf.String(), "gRPC call failed")
 }
+
+func TestTraceInterceptor_InjectsTraceparent(t *testing.T) {
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() { otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator()) })
+
+	ctx, span := sdktrace.NewTracerProvider().Tracer("test").Start(context.Background(), "call")
+	defer span.End()
+
+	var captured context.Context
+	invoker := func(invokerCtx context.Context, _ string, _, _ interface{}, _ *grpc.ClientConn,
+		_ ...grpc.CallOption) error {
+		captured = invokerCtx
+		return nil
+	}
+	require.NoError(t, TraceInterceptor()(ctx, "/test.Service/Method", nil, nil, nil, invoker))
+
+	md, ok := metadata.FromOutgoingContext(captured)
+	require.True(t, ok)
+	values := md.Get("traceparent")
+	require.Len(t, values, 1)
+	assert.Contains(t, values[0], span.SpanContext().TraceID().String())
+}
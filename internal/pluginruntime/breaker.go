@@ -0,0 +1,66 @@
+package pluginruntime
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrCircuitOpen is returned for calls skipped because the plugin's circuit
+// breaker has opened.
+var ErrCircuitOpen = errors.New("plugin circuit breaker open")
+
+// Breaker counts consecutive failed calls to a plugin. Once the count reaches
+// the threshold the breaker opens and stays open; there is no half-open state
+// because a run is short-lived and a plugin that keeps failing is unlikely to
+// recover before it ends. Breaker is safe for concurrent use.
+type Breaker struct {
+	threshold int
+
+	mu       sync.Mutex
+	failures int
+	open     bool
+	lastErr  error
+}
+
+// NewBreaker returns a breaker that opens after threshold consecutive
+// failures. A threshold of zero or less never opens.
+func NewBreaker(threshold int) *Breaker {
+	return &Breaker{threshold: threshold}
+}
+
+// Allow returns an error wrapping ErrCircuitOpen, and the failure that opened
+// the breaker, if calls should be skipped.
+func (b *Breaker) Allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if !b.open {
+		return nil
+	}
+	return fmt.Errorf("%w after %d consecutive failures: %w", ErrCircuitOpen, b.failures, b.lastErr)
+}
+
+// Record notes the outcome of a call. It reports whether this call opened the
+// breaker so the caller can log the transition once.
+func (b *Breaker) Record(err error) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if err == nil {
+		b.failures = 0
+		return false
+	}
+	b.failures++
+	b.lastErr = err
+	if b.open || b.threshold <= 0 || b.failures < b.threshold {
+		return false
+	}
+	b.open = true
+	return true
+}
+
+// Open reports whether the breaker has opened.
+func (b *Breaker) Open() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.open
+}
@@ -0,0 +1,237 @@
+package pluginruntime
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"google.golang.org/grpc"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// Client is a proto.CostSourceClient that applies a Policy to every cost RPC.
+// Name and GetPluginInfo are handshake calls and pass straight through.
+type Client struct {
+	name    string
+	api     proto.CostSourceClient
+	policy  Policy
+	breaker *Breaker
+}
+
+var (
+	_ proto.CostSourceClient = (*Client)(nil)
+	_ proto.StreamingClient  = (*Client)(nil)
+)
+
+// Wrap returns api decorated with policy. name identifies the plugin in errors
+// and logs.
+func Wrap(name string, api proto.CostSourceClient, policy Policy) *Client {
+	return &Client{
+		name:    name,
+		api:     api,
+		policy:  policy,
+		breaker: NewBreaker(policy.FailureThreshold),
+	}
+}
+
+// Breaker returns the client's circuit breaker.
+func (c *Client) Breaker() *Breaker {
+	return c.breaker
+}
+
+// invoke runs call under the client's policy: it fails fast while the breaker
+// is open, bounds each attempt by the policy timeout, retries transient
+// failures with exponential backoff, and records the final outcome on the
+// breaker. Cancellation of ctx itself is not counted against the plugin.
+func invoke[T any](ctx context.Context, c *Client, rpc string, call func(context.Context) (T, error)) (T, error) {
+	return invokeWithRetries(ctx, c, rpc, c.policy.MaxRetries, call)
+}
+
+// invokeWithRetries is invoke with an explicit retry budget.
+func invokeWithRetries[T any](
+	ctx context.Context,
+	c *Client,
+	rpc string,
+	maxRetries int,
+	call func(context.Context) (T, error),
+) (T, error) {
+	var zero T
+	if err := c.breaker.Allow(); err != nil {
+		return zero, fmt.Errorf("plugin %s skipped: %w", c.name, err)
+	}
+
+	log := logging.FromContext(ctx)
+	var (
+		resp T
+		err  error
+	)
+	for attempt := 0; ; attempt++ {
+		resp, err = attemptCall(ctx, c.policy.Timeout, call)
+		if err == nil || !IsTransient(err) || attempt >= maxRetries || ctx.Err() != nil {
+			break
+		}
+
+		delay := c.policy.backoff(attempt + 1)
+		log.Debug().
+			Ctx(ctx).
+			Str("component", "pluginruntime").
+			Str("plugin", c.name).
+			Str("rpc", rpc).
+			Int("attempt", attempt+1).
+			Dur("backoff", delay).
+			Err(err).
+			Msg("transient plugin error, retrying")
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return zero, err
+		case <-timer.C:
+		}
+	}
+
+	if ctx.Err() != nil {
+		return resp, err
+	}
+	outcome := err
+	if !IsTransient(err) {
+		// The plugin answered, even if it rejected the request.
+		outcome = nil
+	}
+	if c.breaker.Record(outcome) {
+		log.Warn().
+			Ctx(ctx).
+			Str("component", "pluginruntime").
+			Str("plugin", c.name).
+			Str("rpc", rpc).
+			Int("failure_threshold", c.policy.FailureThreshold).
+			Err(err).
+			Msg("plugin circuit breaker opened; skipping plugin for the rest of this run")
+	}
+	return resp, err
+}
+
+func attemptCall[T any](ctx context.Context, timeout time.Duration, call func(context.Context) (T, error)) (T, error) {
+	if timeout <= 0 {
+		return call(ctx)
+	}
+	attemptCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	return call(attemptCtx)
+}
+
+// Name implements proto.CostSourceClient.
+func (c *Client) Name(ctx context.Context, in *proto.Empty, opts ...grpc.CallOption) (*proto.NameResponse, error) {
+	return c.api.Name(ctx, in, opts...)
+}
+
+// GetPluginInfo implements proto.CostSourceClient.
+func (c *Client) GetPluginInfo(
+	ctx context.Context,
+	in *proto.Empty,
+	opts ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return c.api.GetPluginInfo(ctx, in, opts...)
+}
+
+// GetProjectedCost implements proto.CostSourceClient.
+func (c *Client) GetProjectedCost(
+	ctx context.Context,
+	in *proto.GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	return invoke(ctx, c, "GetProjectedCost", func(ctx context.Context) (*proto.GetProjectedCostResponse, error) {
+		return c.api.GetProjectedCost(ctx, in, opts...)
+	})
+}
+
+// GetActualCost implements proto.CostSourceClient.
+func (c *Client) GetActualCost(
+	ctx context.Context,
+	in *proto.GetActualCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetActualCostResponse, error) {
+	return invoke(ctx, c, "GetActualCost", func(ctx context.Context) (*proto.GetActualCostResponse, error) {
+		return c.api.GetActualCost(ctx, in, opts...)
+	})
+}
+
+// GetRecommendations implements proto.CostSourceClient.
+func (c *Client) GetRecommendations(
+	ctx context.Context,
+	in *proto.GetRecommendationsRequest,
+	opts ...grpc.CallOption,
+) (*proto.GetRecommendationsResponse, error) {
+	return invoke(ctx, c, "GetRecommendations", func(ctx context.Context) (*proto.GetRecommendationsResponse, error) {
+		return c.api.GetRecommendations(ctx, in, opts...)
+	})
+}
+
+// GetBudgets implements proto.CostSourceClient.
+func (c *Client) GetBudgets(
+	ctx context.Context,
+	in *pbc.GetBudgetsRequest,
+	opts ...grpc.CallOption,
+) (*pbc.GetBudgetsResponse, error) {
+	return invoke(ctx, c, "GetBudgets", func(ctx context.Context) (*pbc.GetBudgetsResponse, error) {
+		return c.api.GetBudgets(ctx, in, opts...)
+	})
+}
+
+// DryRun implements proto.CostSourceClient.
+func (c *Client) DryRun(
+	ctx context.Context,
+	in *pbc.DryRunRequest,
+	opts ...grpc.CallOption,
+) (*pbc.DryRunResponse, error) {
+	return invoke(ctx, c, "DryRun", func(ctx context.Context) (*pbc.DryRunResponse, error) {
+		return c.api.DryRun(ctx, in, opts...)
+	})
+}
+
+// DismissRecommendation implements proto.CostSourceClient.
+func (c *Client) DismissRecommendation(
+	ctx context.Context,
+	in *proto.DismissRecommendationRequest,
+	opts ...grpc.CallOption,
+) (*proto.DismissRecommendationResponse, error) {
+	return invoke(ctx, c, "DismissRecommendation",
+		func(ctx context.Context) (*proto.DismissRecommendationResponse, error) {
+			return c.api.DismissRecommendation(ctx, in, opts...)
+		})
+}
+
+// EstimateCost implements proto.CostSourceClient.
+func (c *Client) EstimateCost(
+	ctx context.Context,
+	in *proto.EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.EstimateCostResponse, error) {
+	return invoke(ctx, c, "EstimateCost", func(ctx context.Context) (*proto.EstimateCostResponse, error) {
+		return c.api.EstimateCost(ctx, in, opts...)
+	})
+}
+
+// StreamRecommendations implements proto.StreamingClient. When the wrapped
+// client cannot stream, the unary RPC is paged through c so that every page
+// gets the full policy. A stream may have delivered pages before failing, so
+// it is bounded by the timeout and breaker but never retried.
+func (c *Client) StreamRecommendations(
+	ctx context.Context,
+	in *proto.GetRecommendationsRequest,
+	fn proto.RecommendationPageFunc,
+	opts ...grpc.CallOption,
+) error {
+	streamer, ok := c.api.(proto.StreamingClient)
+	if !ok {
+		return proto.PageRecommendations(ctx, c, in, fn, opts...)
+	}
+	_, err := invokeWithRetries(ctx, c, "StreamRecommendations", 0, func(ctx context.Context) (struct{}, error) {
+		return struct{}{}, streamer.StreamRecommendations(ctx, in, fn, opts...)
+	})
+	return err
+}
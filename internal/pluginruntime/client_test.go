@@ -0,0 +1,178 @@
+package pluginruntime
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// flakyClient fails GetProjectedCost with the queued errors, then succeeds.
+// Other RPCs panic via the nil embedded interface.
+type flakyClient struct {
+	proto.CostSourceClient
+
+	errs  []error
+	calls atomic.Int32
+	block bool
+}
+
+func (c *flakyClient) GetProjectedCost(
+	ctx context.Context, _ *proto.GetProjectedCostRequest, _ ...grpc.CallOption,
+) (*proto.GetProjectedCostResponse, error) {
+	n := int(c.calls.Add(1))
+	if c.block {
+		<-ctx.Done()
+		return nil, status.FromContextError(ctx.Err()).Err()
+	}
+	if n <= len(c.errs) {
+		return nil, c.errs[n-1]
+	}
+	return &proto.GetProjectedCostResponse{}, nil
+}
+
+func testPolicy() Policy {
+	return Policy{
+		MaxRetries:       2,
+		InitialBackoff:   time.Millisecond,
+		MaxBackoff:       2 * time.Millisecond,
+		FailureThreshold: 2,
+	}
+}
+
+func TestClient_RetriesTransientErrors(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	api := &flakyClient{errs: []error{unavailable, unavailable}}
+	c := Wrap("aws-public", api, testPolicy())
+
+	_, err := c.GetProjectedCost(context.Background(), &proto.GetProjectedCostRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int32(3), api.calls.Load())
+	assert.False(t, c.Breaker().Open())
+}
+
+func TestClient_DoesNotRetryPermanentErrors(t *testing.T) {
+	api := &flakyClient{errs: []error{status.Error(codes.InvalidArgument, "bad sku")}}
+	c := Wrap("aws-public", api, testPolicy())
+
+	_, err := c.GetProjectedCost(context.Background(), &proto.GetProjectedCostRequest{})
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, int32(1), api.calls.Load())
+}
+
+func TestClient_BreakerSkipsPluginAfterThreshold(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "connection refused")
+	errs := make([]error, 6)
+	for i := range errs {
+		errs[i] = unavailable
+	}
+	api := &flakyClient{errs: errs}
+	c := Wrap("aws-public", api, testPolicy())
+
+	for range 2 {
+		_, err := c.GetProjectedCost(context.Background(), &proto.GetProjectedCostRequest{})
+		require.Equal(t, codes.Unavailable, status.Code(err))
+	}
+	require.True(t, c.Breaker().Open())
+	calls := api.calls.Load()
+
+	_, err := c.GetProjectedCost(context.Background(), &proto.GetProjectedCostRequest{})
+	require.ErrorIs(t, err, ErrCircuitOpen)
+	assert.Contains(t, err.Error(), "aws-public")
+	assert.Contains(t, err.Error(), "connection refused")
+	assert.Equal(t, calls, api.calls.Load(), "open breaker skips the plugin")
+}
+
+func TestClient_TimeoutIsPerAttempt(t *testing.T) {
+	api := &flakyClient{block: true}
+	policy := testPolicy()
+	policy.Timeout = 5 * time.Millisecond
+	policy.MaxRetries = 1
+	c := Wrap("slow", api, policy)
+
+	_, err := c.GetProjectedCost(context.Background(), &proto.GetProjectedCostRequest{})
+	require.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.Equal(t, int32(2), api.calls.Load(), "timed-out attempts are retried")
+}
+
+func TestClient_CancelledContextIsNotAFailure(t *testing.T) {
+	api := &flakyClient{block: true}
+	policy := testPolicy()
+	policy.FailureThreshold = 1
+	c := Wrap("slow", api, policy)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := c.GetProjectedCost(ctx, &proto.GetProjectedCostRequest{})
+	require.Error(t, err)
+	assert.False(t, c.Breaker().Open())
+}
+
+func TestBreaker_SuccessResetsCount(t *testing.T) {
+	b := NewBreaker(2)
+	failure := errors.New("boom")
+
+	assert.False(t, b.Record(failure))
+	assert.False(t, b.Record(nil))
+	assert.False(t, b.Record(failure))
+	require.NoError(t, b.Allow())
+	assert.True(t, b.Record(failure), "second consecutive failure opens the breaker")
+	require.ErrorIs(t, b.Allow(), ErrCircuitOpen)
+	assert.False(t, b.Record(failure), "transition is reported once")
+
+	disabled := NewBreaker(0)
+	for range 10 {
+		disabled.Record(failure)
+	}
+	assert.False(t, disabled.Open())
+}
+
+// pagedClient serves two recommendation pages and fails the first attempt at
+// each page with Unavailable.
+type pagedClient struct {
+	proto.CostSourceClient
+
+	calls int
+}
+
+func (c *pagedClient) GetRecommendations(
+	_ context.Context, in *proto.GetRecommendationsRequest, _ ...grpc.CallOption,
+) (*proto.GetRecommendationsResponse, error) {
+	c.calls++
+	if c.calls%2 == 1 {
+		return nil, status.Error(codes.Unavailable, "connection reset")
+	}
+	if in.PageToken == "" {
+		return &proto.GetRecommendationsResponse{
+			Recommendations: []*proto.Recommendation{{ID: "rec-1"}},
+			NextPageToken:   "page-2",
+		}, nil
+	}
+	return &proto.GetRecommendationsResponse{Recommendations: []*proto.Recommendation{{ID: "rec-2"}}}, nil
+}
+
+func TestClient_StreamRecommendationsPagesWithPolicy(t *testing.T) {
+	api := &pagedClient{}
+	c := Wrap("aws-ce", api, testPolicy())
+
+	var ids []string
+	err := proto.StreamRecommendations(context.Background(), c, &proto.GetRecommendationsRequest{},
+		func(page *proto.GetRecommendationsResponse) error {
+			for _, rec := range page.Recommendations {
+				ids = append(ids, rec.ID)
+			}
+			return nil
+		})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"rec-1", "rec-2"}, ids)
+	assert.Equal(t, 4, api.calls, "each page is retried under the policy")
+}
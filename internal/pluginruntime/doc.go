@@ -0,0 +1,15 @@
+// Package pluginruntime adds timeouts, retries, and circuit breaking to plugin RPCs.
+//
+// Wrap decorates a proto.CostSourceClient so that every cost RPC:
+//   - runs under a per-attempt timeout
+//   - is retried with exponential backoff when the plugin returns a transient
+//     gRPC code (Unavailable, DeadlineExceeded, ResourceExhausted, Aborted)
+//   - is skipped once the plugin has failed FailureThreshold calls in a row
+//
+// An open breaker stays open for the life of the wrapped client, which is one
+// CLI run. Skipped calls fail fast with an error wrapping ErrCircuitOpen, so the
+// engine records them in ErrorDetail like any other plugin failure.
+//
+// Policies come from the plugin_host section of the configuration; see
+// config.ResilienceConfig and PolicyFromConfig.
+package pluginruntime
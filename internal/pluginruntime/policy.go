@@ -0,0 +1,97 @@
+package pluginruntime
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+const (
+	defaultTimeout          = 30 * time.Second
+	defaultMaxRetries       = 2
+	defaultInitialBackoff   = 200 * time.Millisecond
+	defaultMaxBackoff       = 2 * time.Second
+	defaultFailureThreshold = 5
+)
+
+// Policy controls how calls to one plugin are timed out, retried, and cut off.
+type Policy struct {
+	// Timeout bounds each attempt. Zero means no per-attempt timeout.
+	Timeout time.Duration
+	// MaxRetries is the number of extra attempts after a transient failure.
+	MaxRetries int
+	// InitialBackoff is the delay before the first retry; it doubles per retry.
+	InitialBackoff time.Duration
+	// MaxBackoff caps the retry delay.
+	MaxBackoff time.Duration
+	// FailureThreshold is the number of consecutive failed calls that opens the
+	// circuit breaker. Zero disables the breaker.
+	FailureThreshold int
+}
+
+// DefaultPolicy returns the policy used when nothing is configured.
+func DefaultPolicy() Policy {
+	return Policy{
+		Timeout:          defaultTimeout,
+		MaxRetries:       defaultMaxRetries,
+		InitialBackoff:   defaultInitialBackoff,
+		MaxBackoff:       defaultMaxBackoff,
+		FailureThreshold: defaultFailureThreshold,
+	}
+}
+
+// PolicyFromConfig applies configured values over DefaultPolicy.
+func PolicyFromConfig(cfg config.ResilienceConfig) Policy {
+	p := DefaultPolicy()
+	if cfg.Timeout > 0 {
+		p.Timeout = cfg.Timeout.Duration()
+	}
+	if cfg.MaxRetries != nil {
+		p.MaxRetries = *cfg.MaxRetries
+	}
+	if cfg.InitialBackoff > 0 {
+		p.InitialBackoff = cfg.InitialBackoff.Duration()
+	}
+	if cfg.MaxBackoff > 0 {
+		p.MaxBackoff = cfg.MaxBackoff.Duration()
+	}
+	if cfg.FailureThreshold != nil {
+		p.FailureThreshold = *cfg.FailureThreshold
+	}
+	if p.MaxBackoff < p.InitialBackoff {
+		p.MaxBackoff = p.InitialBackoff
+	}
+	return p
+}
+
+// backoff returns the delay before retry number attempt (starting at 1).
+func (p Policy) backoff(attempt int) time.Duration {
+	d := p.InitialBackoff
+	for i := 1; i < attempt && d < p.MaxBackoff; i++ {
+		d *= 2
+	}
+	return min(d, p.MaxBackoff)
+}
+
+// IsTransient reports whether err is a gRPC failure worth retrying: the plugin
+// was unreachable, overloaded, or too slow, rather than rejecting the request.
+func IsTransient(err error) bool {
+	if err == nil {
+		return false
+	}
+	switch status.Code(err) {
+	case codes.Unavailable, codes.DeadlineExceeded, codes.ResourceExhausted, codes.Aborted:
+		return true
+	case codes.Unknown:
+		// A per-attempt timeout that fired outside a gRPC call surfaces as a
+		// plain context error rather than a status.
+		return errors.Is(err, context.DeadlineExceeded)
+	default:
+		return false
+	}
+}
@@ -0,0 +1,59 @@
+package pluginruntime
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+func TestPolicyFromConfig(t *testing.T) {
+	assert.Equal(t, DefaultPolicy(), PolicyFromConfig(config.ResilienceConfig{}))
+
+	zero := 0
+	p := PolicyFromConfig(config.ResilienceConfig{
+		Timeout:          config.Duration(2 * time.Minute),
+		MaxRetries:       &zero,
+		InitialBackoff:   config.Duration(5 * time.Second),
+		FailureThreshold: &zero,
+	})
+	assert.Equal(t, 2*time.Minute, p.Timeout)
+	assert.Equal(t, 0, p.MaxRetries, "explicit zero disables retries")
+	assert.Equal(t, 0, p.FailureThreshold, "explicit zero disables the breaker")
+	assert.Equal(t, 5*time.Second, p.MaxBackoff, "max backoff is raised to the initial backoff")
+}
+
+func TestPolicy_Backoff(t *testing.T) {
+	p := Policy{InitialBackoff: 100 * time.Millisecond, MaxBackoff: 350 * time.Millisecond}
+	assert.Equal(t, 100*time.Millisecond, p.backoff(1))
+	assert.Equal(t, 200*time.Millisecond, p.backoff(2))
+	assert.Equal(t, 350*time.Millisecond, p.backoff(3))
+	assert.Equal(t, 350*time.Millisecond, p.backoff(10))
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		err  error
+		want bool
+	}{
+		{nil, false},
+		{status.Error(codes.Unavailable, "down"), true},
+		{status.Error(codes.DeadlineExceeded, "slow"), true},
+		{status.Error(codes.ResourceExhausted, "throttled"), true},
+		{status.Error(codes.Aborted, "conflict"), true},
+		{status.Error(codes.InvalidArgument, "bad"), false},
+		{status.Error(codes.Unimplemented, "no"), false},
+		{fmt.Errorf("wrapped: %w", context.DeadlineExceeded), true},
+		{errors.New("plain"), false},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, IsTransient(tt.err), "%v", tt.err)
+	}
+}
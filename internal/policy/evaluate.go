@@ -0,0 +1,259 @@
+package policy
+
+import (
+	"fmt"
+	"math"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// anyTagValue in Match.Tags matches any value of a present tag.
+const anyTagValue = "*"
+
+// instanceTypeKeys are the resource properties that hold an instance type,
+// in lookup order. Nested properties are separated by dots.
+//
+//nolint:gochecknoglobals // Lookup table of provider property names.
+var instanceTypeKeys = []string{
+	"instanceType", "instanceClass", "nodeType", "machineType", "vmSize", "hardwareProfile.vmSize",
+}
+
+// instanceSizeRanks ranks the named instance sizes below xlarge. Multiples
+// of xlarge rank by their multiplier, so 4xlarge ranks 4.
+//
+//nolint:gochecknoglobals // Lookup table of instance size names.
+var instanceSizeRanks = map[string]float64{
+	"nano": -4, "micro": -3, "small": -2, "medium": -1, "large": 0, "xlarge": 1,
+}
+
+// gpuInstanceType matches instance types of GPU instance families: AWS P and
+// G families, GCP accelerator-optimized machine types, and Azure N-series sizes.
+var gpuInstanceType = regexp.MustCompile(`^(?:[pg]r?\d[a-z]*\.|a\d-|g\d-|standard_n[cdv])`)
+
+// Resource is one priced resource of the plan being checked.
+type Resource struct {
+	ID   string
+	Type string
+	Tags map[string]string
+	// InstanceType is the resource's instance type, e.g. "m5.8xlarge",
+	// or empty if it has none (see InstanceType).
+	InstanceType string
+	// Monthly is the projected monthly cost.
+	Monthly float64
+}
+
+// Input is the plan a policy check evaluates.
+type Input struct {
+	Resources []Resource
+	Currency  string
+	// Delta is the change of the plan's projected monthly cost against the
+	// recorded baseline, or nil when no baseline is recorded.
+	Delta *float64
+}
+
+// Violation is a resource, or the plan as a whole, breaking a policy.
+type Violation struct {
+	Policy      string   `json:"policy"`
+	Severity    Severity `json:"severity"`
+	Description string   `json:"description,omitempty"`
+	// ResourceID and ResourceType are empty for plan-wide violations.
+	ResourceID   string `json:"resourceId,omitempty"`
+	ResourceType string `json:"resourceType,omitempty"`
+	Message      string `json:"message"`
+}
+
+// SkippedPolicy is a policy that could not be evaluated.
+type SkippedPolicy struct {
+	Policy string `json:"policy"`
+	Reason string `json:"reason"`
+}
+
+// Result is the outcome of a policy check.
+type Result struct {
+	Policies   int             `json:"policies"`
+	Resources  int             `json:"resources"`
+	Violations []Violation     `json:"violations"`
+	Skipped    []SkippedPolicy `json:"skipped,omitempty"`
+}
+
+// Count returns the number of violations with the given severity.
+func (r *Result) Count(severity Severity) int {
+	n := 0
+	for _, v := range r.Violations {
+		if v.Severity == severity {
+			n++
+		}
+	}
+	return n
+}
+
+// Evaluate checks every policy of file against in. Violations are ordered by
+// policy, in file order, then by resource, in input order.
+func Evaluate(file *File, in Input) *Result {
+	result := &Result{Resources: len(in.Resources), Violations: []Violation{}}
+	if file == nil {
+		return result
+	}
+	result.Policies = len(file.Policies)
+
+	for _, p := range file.Policies {
+		if p.MaxMonthlyDelta != nil {
+			evaluateDelta(result, p, in)
+			continue
+		}
+		for _, r := range in.Resources {
+			if !p.Match.matches(r) {
+				continue
+			}
+			if msg := p.check(r, in.Currency); msg != "" {
+				result.Violations = append(result.Violations, Violation{
+					Policy: p.Name, Severity: p.EffectiveSeverity(), Description: p.Description,
+					ResourceID: r.ID, ResourceType: r.Type, Message: msg,
+				})
+			}
+		}
+	}
+	return result
+}
+
+// evaluateDelta checks the plan-wide max_monthly_delta rule of p.
+func evaluateDelta(result *Result, p Policy, in Input) {
+	if in.Delta == nil {
+		result.Skipped = append(result.Skipped, SkippedPolicy{
+			Policy: p.Name, Reason: "no recorded baseline to compare the plan against",
+		})
+		return
+	}
+	if *in.Delta <= *p.MaxMonthlyDelta {
+		return
+	}
+	result.Violations = append(result.Violations, Violation{
+		Policy: p.Name, Severity: p.EffectiveSeverity(), Description: p.Description,
+		Message: fmt.Sprintf("projected monthly cost increases by %s, more than %s",
+			money(*in.Delta, in.Currency), money(*p.MaxMonthlyDelta, in.Currency)),
+	})
+}
+
+// check applies the per-resource rule of p to r and returns the violation
+// message, or "" when r complies.
+func (p Policy) check(r Resource, currency string) string {
+	switch {
+	case p.MaxInstanceSize != "":
+		size, ok := instanceSize(r.InstanceType)
+		if !ok {
+			return ""
+		}
+		limit, _ := sizeRank(p.MaxInstanceSize)
+		if rank, _ := sizeRank(size); rank > limit {
+			return fmt.Sprintf("instance type %s is larger than %s", r.InstanceType, p.MaxInstanceSize)
+		}
+	case p.MaxMonthlyCost != nil:
+		if r.Monthly > *p.MaxMonthlyCost {
+			return fmt.Sprintf("projected monthly cost %s exceeds %s",
+				money(r.Monthly, currency), money(*p.MaxMonthlyCost, currency))
+		}
+	case len(p.RequireTags) > 0:
+		var missing []string
+		for _, key := range p.RequireTags {
+			if r.Tags[key] == "" {
+				missing = append(missing, key)
+			}
+		}
+		if len(missing) > 0 {
+			return "missing required tags: " + strings.Join(missing, ", ")
+		}
+	}
+	return ""
+}
+
+// matches reports whether r satisfies every set field of m.
+func (m Match) matches(r Resource) bool {
+	if len(m.Types) > 0 {
+		matched := false
+		for _, pattern := range m.Types {
+			if ok, _ := filepath.Match(pattern, r.Type); ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return false
+		}
+	}
+	for key, want := range m.Tags {
+		got, ok := r.Tags[key]
+		if !ok || (want != anyTagValue && got != want) {
+			return false
+		}
+	}
+	return !m.GPU || IsGPUInstanceType(r.InstanceType)
+}
+
+// InstanceType returns the instance type held by a resource's properties,
+// e.g. "instanceType" for EC2 or "machineType" for GCP, or "" if none is set.
+func InstanceType(properties map[string]interface{}) string {
+	for _, key := range instanceTypeKeys {
+		var value interface{} = properties
+		for _, part := range strings.Split(key, ".") {
+			m, ok := value.(map[string]interface{})
+			if !ok {
+				value = nil
+				break
+			}
+			value = m[part]
+		}
+		if s, ok := value.(string); ok && s != "" {
+			return s
+		}
+	}
+	return ""
+}
+
+// IsGPUInstanceType reports whether instanceType belongs to a GPU instance
+// family, such as AWS p4d.24xlarge, GCP a2-highgpu-1g, or Azure Standard_NC6.
+func IsGPUInstanceType(instanceType string) bool {
+	return gpuInstanceType.MatchString(strings.ToLower(instanceType))
+}
+
+// instanceSize returns the size suffix of an instance type in the AWS
+// family.size format, e.g. "8xlarge" for "m5.8xlarge" or "large" for
+// "db.r5.large". ok is false when the type has no comparable size.
+func instanceSize(instanceType string) (string, bool) {
+	idx := strings.LastIndex(instanceType, ".")
+	if idx < 0 {
+		return "", false
+	}
+	size := strings.ToLower(instanceType[idx+1:])
+	if _, ok := sizeRank(size); !ok {
+		return "", false
+	}
+	return size, true
+}
+
+// sizeRank orders instance sizes: nano < ... < large < xlarge < 2xlarge < ...
+// Bare-metal sizes rank above every other size.
+func sizeRank(size string) (float64, bool) {
+	size = strings.ToLower(size)
+	if rank, ok := instanceSizeRanks[size]; ok {
+		return rank, true
+	}
+	if strings.HasPrefix(size, "metal") {
+		return math.Inf(1), true
+	}
+	multiple, ok := strings.CutSuffix(size, "xlarge")
+	if !ok {
+		return 0, false
+	}
+	n, err := strconv.ParseFloat(multiple, 64)
+	if err != nil || n <= 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+// money formats an amount with its currency.
+func money(amount float64, currency string) string {
+	return strings.TrimSpace(fmt.Sprintf("%.2f %s", amount, currency))
+}
@@ -0,0 +1,118 @@
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func planResources() []Resource {
+	return []Resource{
+		{ID: "dev-big", Type: "aws:ec2/instance:Instance", InstanceType: "m5.8xlarge", Monthly: 1100,
+			Tags: map[string]string{"env": "dev"}},
+		{ID: "dev-ok", Type: "aws:ec2/instance:Instance", InstanceType: "m5.4xlarge", Monthly: 550,
+			Tags: map[string]string{"env": "dev"}},
+		{ID: "prod-big", Type: "aws:ec2/instance:Instance", InstanceType: "m5.16xlarge", Monthly: 2200,
+			Tags: map[string]string{"env": "prod"}},
+		{ID: "gpu", Type: "aws:ec2/instance:Instance", InstanceType: "p4d.24xlarge", Monthly: 23000,
+			Tags: map[string]string{"env": "prod"}},
+		{ID: "gpu-approved", Type: "gcp:compute/instance:Instance", InstanceType: "a2-highgpu-1g", Monthly: 2700,
+			Tags: map[string]string{"approved-by": "cfo"}},
+		{ID: "bucket", Type: "aws:s3/bucket:Bucket", Monthly: 5},
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	file, err := Parse([]byte(examplePolicies))
+	require.NoError(t, err)
+	delta := 620.0
+
+	result := Evaluate(file, Input{Resources: planResources(), Currency: "USD", Delta: &delta})
+
+	assert.Equal(t, 3, result.Policies)
+	assert.Equal(t, 6, result.Resources)
+	require.Len(t, result.Violations, 3)
+
+	assert.Equal(t, Violation{
+		Policy: "dev-instance-size", Severity: SeverityError, Description: "Dev environments stay small",
+		ResourceID: "dev-big", ResourceType: "aws:ec2/instance:Instance",
+		Message: "instance type m5.8xlarge is larger than 4xlarge",
+	}, result.Violations[0])
+
+	assert.Equal(t, "monthly-delta", result.Violations[1].Policy)
+	assert.Empty(t, result.Violations[1].ResourceID, "delta violations are plan-wide")
+	assert.Equal(t, "projected monthly cost increases by 620.00 USD, more than 500.00 USD",
+		result.Violations[1].Message)
+
+	assert.Equal(t, "gpu", result.Violations[2].ResourceID)
+	assert.Equal(t, SeverityWarning, result.Violations[2].Severity)
+	assert.Equal(t, "missing required tags: approved-by", result.Violations[2].Message)
+
+	assert.Equal(t, 2, result.Count(SeverityError))
+	assert.Equal(t, 1, result.Count(SeverityWarning))
+}
+
+func TestEvaluate_DeltaWithoutBaseline(t *testing.T) {
+	limit := 500.0
+	file := &File{Policies: []Policy{{Name: "delta", MaxMonthlyDelta: &limit}}}
+
+	result := Evaluate(file, Input{Resources: planResources()})
+
+	assert.Empty(t, result.Violations)
+	require.Len(t, result.Skipped, 1)
+	assert.Equal(t, "delta", result.Skipped[0].Policy)
+}
+
+func TestEvaluate_MonthlyCostAndTypes(t *testing.T) {
+	limit := 1000.0
+	file := &File{Policies: []Policy{{
+		Name: "cap", MaxMonthlyCost: &limit, Match: Match{Types: []string{"aws:ec2/*"}},
+	}}}
+
+	result := Evaluate(file, Input{Resources: planResources(), Currency: "USD"})
+
+	var ids []string
+	for _, v := range result.Violations {
+		ids = append(ids, v.ResourceID)
+	}
+	assert.Equal(t, []string{"dev-big", "prod-big", "gpu"}, ids, "GCP instance is excluded by the type match")
+	assert.Equal(t, "projected monthly cost 1100.00 USD exceeds 1000.00 USD", result.Violations[0].Message)
+}
+
+func TestInstanceType(t *testing.T) {
+	assert.Equal(t, "m5.large", InstanceType(map[string]interface{}{"instanceType": "m5.large"}))
+	assert.Equal(t, "db.r5.xlarge", InstanceType(map[string]interface{}{"instanceClass": "db.r5.xlarge"}))
+	assert.Equal(t, "Standard_NC6", InstanceType(map[string]interface{}{
+		"hardwareProfile": map[string]interface{}{"vmSize": "Standard_NC6"},
+	}))
+	assert.Empty(t, InstanceType(map[string]interface{}{"bucket": "logs"}))
+	assert.Empty(t, InstanceType(nil))
+}
+
+func TestIsGPUInstanceType(t *testing.T) {
+	for _, it := range []string{"p3.2xlarge", "p4d.24xlarge", "g4dn.xlarge", "g5.48xlarge", "gr6.4xlarge",
+		"a2-highgpu-1g", "g2-standard-4", "Standard_NC6s_v3", "Standard_ND40rs_v2"} {
+		assert.True(t, IsGPUInstanceType(it), it)
+	}
+	for _, it := range []string{"m5.large", "m6g.xlarge", "t3.micro", "n2-standard-8", "Standard_D4s_v3", ""} {
+		assert.False(t, IsGPUInstanceType(it), it)
+	}
+}
+
+func TestSizeRank(t *testing.T) {
+	order := []string{"nano", "micro", "small", "medium", "large", "xlarge", "2xlarge", "4xlarge", "24xlarge", "metal"}
+	for i := 1; i < len(order); i++ {
+		lower, ok := sizeRank(order[i-1])
+		require.True(t, ok, order[i-1])
+		higher, ok := sizeRank(order[i])
+		require.True(t, ok, order[i])
+		assert.Less(t, lower, higher, "%s < %s", order[i-1], order[i])
+	}
+
+	_, ok := instanceSize("n2-standard-8")
+	assert.False(t, ok, "non-AWS types have no comparable size")
+	size, ok := instanceSize("db.r5.2xlarge")
+	require.True(t, ok)
+	assert.Equal(t, "2xlarge", size)
+}
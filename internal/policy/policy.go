@@ -0,0 +1,181 @@
+// Package policy evaluates cost guardrails against the resources of a plan.
+//
+// A policy file lists named policies. Each policy selects resources with an
+// optional match (resource types, tags, or GPU instance families) and applies
+// exactly one rule to them:
+//
+//   - max_instance_size caps the size of instance types, e.g. "4xlarge";
+//   - max_monthly_cost caps the projected monthly cost of each resource;
+//   - require_tags lists tags every matched resource must carry;
+//   - max_monthly_delta caps the change in the plan's projected monthly
+//     cost against a recorded baseline.
+//
+// Violations are reported per resource, or once for the plan for
+// max_monthly_delta, with the policy's severity.
+//
+// Policies can also be written in Rego and evaluated with embedded OPA; see
+// RegoPolicies.
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ErrInvalidPolicy is returned for policy files that fail validation.
+var ErrInvalidPolicy = errors.New("invalid policy")
+
+// Severity is how a violation affects the check result.
+type Severity string
+
+// Policy severities.
+const (
+	// SeverityError violations fail the check.
+	SeverityError Severity = "error"
+	// SeverityWarning violations are reported without failing the check
+	// unless warnings are configured to fail it.
+	SeverityWarning Severity = "warning"
+)
+
+// File is the content of a policy file.
+type File struct {
+	Policies []Policy `yaml:"policies" json:"policies"`
+}
+
+// Policy is one named cost guardrail.
+type Policy struct {
+	// Name identifies the policy in violations, e.g. "dev-instance-size".
+	Name string `yaml:"name" json:"name"`
+
+	// Description explains the policy to whoever reads a violation.
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+
+	// Severity is "error" (default) or "warning".
+	Severity Severity `yaml:"severity,omitempty" json:"severity,omitempty"`
+
+	// Match limits the policy to matching resources. Empty matches every resource.
+	Match Match `yaml:"match,omitempty" json:"match,omitempty"`
+
+	// MaxInstanceSize is the largest allowed instance size, e.g. "4xlarge".
+	MaxInstanceSize string `yaml:"max_instance_size,omitempty" json:"max_instance_size,omitempty"`
+
+	// MaxMonthlyCost is the largest allowed projected monthly cost of a resource.
+	MaxMonthlyCost *float64 `yaml:"max_monthly_cost,omitempty" json:"max_monthly_cost,omitempty"`
+
+	// MaxMonthlyDelta is the largest allowed increase of the plan's projected
+	// monthly cost over the recorded baseline. Matched resources are ignored.
+	MaxMonthlyDelta *float64 `yaml:"max_monthly_delta,omitempty" json:"max_monthly_delta,omitempty"`
+
+	// RequireTags lists tags every matched resource must have with a non-empty value.
+	RequireTags []string `yaml:"require_tags,omitempty" json:"require_tags,omitempty"`
+}
+
+// Match selects the resources a policy applies to. All set fields must match.
+type Match struct {
+	// Types are resource type patterns in filepath.Match syntax, e.g.
+	// "aws:ec2/instance:*". A resource matches if any pattern does.
+	Types []string `yaml:"types,omitempty" json:"types,omitempty"`
+
+	// Tags maps tag keys to required values. A value of "*" requires only
+	// that the tag is present.
+	Tags map[string]string `yaml:"tags,omitempty" json:"tags,omitempty"`
+
+	// GPU matches resources whose instance type belongs to a GPU instance family.
+	GPU bool `yaml:"gpu,omitempty" json:"gpu,omitempty"`
+}
+
+// EffectiveSeverity returns the policy's severity, applying the default.
+func (p Policy) EffectiveSeverity() Severity {
+	if p.Severity == "" {
+		return SeverityError
+	}
+	return p.Severity
+}
+
+// Load reads and validates a policy file.
+func Load(filePath string) (*File, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("reading policy file: %w", err)
+	}
+	return Parse(data)
+}
+
+// Parse decodes and validates the YAML content of a policy file.
+func Parse(data []byte) (*File, error) {
+	var file File
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing policy file: %w", err)
+	}
+	if err := file.Validate(); err != nil {
+		return nil, err
+	}
+	return &file, nil
+}
+
+// Validate checks that policies are named uniquely and each has exactly one
+// valid rule.
+func (f *File) Validate() error {
+	if len(f.Policies) == 0 {
+		return fmt.Errorf("%w: no policies defined", ErrInvalidPolicy)
+	}
+	names := make(map[string]bool, len(f.Policies))
+	for i, p := range f.Policies {
+		if p.Name == "" {
+			return fmt.Errorf("%w: policies[%d]: name is required", ErrInvalidPolicy, i)
+		}
+		if names[p.Name] {
+			return fmt.Errorf("%w: policy %q is defined twice", ErrInvalidPolicy, p.Name)
+		}
+		names[p.Name] = true
+		if err := p.validate(); err != nil {
+			return fmt.Errorf("%w: policy %q: %w", ErrInvalidPolicy, p.Name, err)
+		}
+	}
+	return nil
+}
+
+// validate checks one policy.
+func (p Policy) validate() error {
+	switch p.EffectiveSeverity() {
+	case SeverityError, SeverityWarning:
+	default:
+		return fmt.Errorf("unknown severity %q (supported: %s, %s)", p.Severity, SeverityError, SeverityWarning)
+	}
+
+	rules := 0
+	if p.MaxInstanceSize != "" {
+		rules++
+		if _, ok := sizeRank(p.MaxInstanceSize); !ok {
+			return fmt.Errorf("max_instance_size %q is not an instance size such as large or 4xlarge",
+				p.MaxInstanceSize)
+		}
+	}
+	if p.MaxMonthlyCost != nil {
+		rules++
+		if *p.MaxMonthlyCost < 0 {
+			return fmt.Errorf("max_monthly_cost must not be negative, got %g", *p.MaxMonthlyCost)
+		}
+	}
+	if p.MaxMonthlyDelta != nil {
+		rules++
+	}
+	if len(p.RequireTags) > 0 {
+		rules++
+	}
+	if rules != 1 {
+		return fmt.Errorf("exactly one of max_instance_size, max_monthly_cost, max_monthly_delta, "+
+			"or require_tags is required, got %d", rules)
+	}
+
+	for _, pattern := range p.Match.Types {
+		if _, err := filepath.Match(pattern, ""); err != nil {
+			return fmt.Errorf("match type pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,69 @@
+package policy
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const examplePolicies = `
+policies:
+  - name: dev-instance-size
+    description: Dev environments stay small
+    match:
+      tags:
+        env: dev
+    max_instance_size: 4xlarge
+  - name: monthly-delta
+    max_monthly_delta: 500
+  - name: gpu-approval
+    severity: warning
+    match:
+      gpu: true
+    require_tags: [approved-by]
+`
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "policies.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(examplePolicies), 0o600))
+
+	file, err := Load(path)
+	require.NoError(t, err)
+	require.Len(t, file.Policies, 3)
+	assert.Equal(t, "4xlarge", file.Policies[0].MaxInstanceSize)
+	assert.Equal(t, map[string]string{"env": "dev"}, file.Policies[0].Match.Tags)
+	assert.Equal(t, SeverityError, file.Policies[0].EffectiveSeverity())
+	require.NotNil(t, file.Policies[1].MaxMonthlyDelta)
+	assert.InDelta(t, 500, *file.Policies[1].MaxMonthlyDelta, 1e-9)
+	assert.True(t, file.Policies[2].Match.GPU)
+	assert.Equal(t, SeverityWarning, file.Policies[2].EffectiveSeverity())
+
+	_, err = Load(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := map[string]string{
+		"no policies":      `policies: []`,
+		"missing name":     `policies: [{max_monthly_cost: 10}]`,
+		"duplicate name":   `policies: [{name: a, max_monthly_cost: 10}, {name: a, max_monthly_cost: 20}]`,
+		"no rule":          `policies: [{name: a}]`,
+		"two rules":        `policies: [{name: a, max_monthly_cost: 10, require_tags: [owner]}]`,
+		"bad size":         `policies: [{name: a, max_instance_size: huge}]`,
+		"negative cost":    `policies: [{name: a, max_monthly_cost: -1}]`,
+		"unknown severity": `policies: [{name: a, severity: fatal, max_monthly_cost: 10}]`,
+		"bad type pattern": `policies: [{name: a, match: {types: ["aws:[ec2"]}, max_monthly_cost: 10}]`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse([]byte(data))
+			require.ErrorIs(t, err, ErrInvalidPolicy)
+		})
+	}
+
+	_, err := Parse([]byte("policies: {"))
+	require.Error(t, err)
+}
@@ -0,0 +1,184 @@
+package policy
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/open-policy-agent/opa/v1/rego"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// DefaultRegoPackage is the package Rego policies define their rules in.
+const DefaultRegoPackage = "finfocus"
+
+// Rego rules read from the policy package, with the severity of their messages.
+const (
+	regoDenyRule = "deny"
+	regoWarnRule = "warn"
+)
+
+// RegoInput is the input document of Rego policies. Resources are the plan's
+// resource descriptors and Costs their normalized cost results, as emitted by
+// 'cost projected --output json'.
+type RegoInput struct {
+	Currency string `json:"currency"`
+	// Delta is the change of the plan's projected monthly cost against the
+	// recorded baseline, or null when no baseline is recorded.
+	Delta     *float64                    `json:"delta"`
+	Resources []engine.ResourceDescriptor `json:"resources"`
+	Costs     []engine.CostResult         `json:"costs"`
+}
+
+// RegoPolicies are compiled Rego policy modules, evaluated with embedded OPA.
+//
+// The modules define "deny" and "warn" rules in one package. Each rule is a
+// set of messages that are reported as error and warning violations. A
+// message is either a string or an object with a "msg" string and optional
+// "resource" (resource ID) and "policy" (name) strings:
+//
+//	package finfocus
+//
+//	deny contains msg if {
+//		some c in input.costs
+//		c.monthly > 1000
+//		msg := {"msg": sprintf("costs %v a month", [c.monthly]), "resource": c.resourceId}
+//	}
+type RegoPolicies struct {
+	// Modules is the number of loaded module files.
+	Modules int
+
+	pkg   string
+	query rego.PreparedEvalQuery
+}
+
+// LoadRego reads and compiles the Rego modules at paths. Directories are
+// searched recursively for .rego files, skipping _test.rego files. pkg is
+// the package holding the rules, DefaultRegoPackage if empty.
+func LoadRego(ctx context.Context, paths []string, pkg string) (*RegoPolicies, error) {
+	if pkg == "" {
+		pkg = DefaultRegoPackage
+	}
+	files, err := regoFiles(paths)
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("%w: no Rego modules found in %s", ErrInvalidPolicy, strings.Join(paths, ", "))
+	}
+
+	options := []func(*rego.Rego){rego.Query("data." + pkg)}
+	for _, file := range files {
+		data, readErr := os.ReadFile(file)
+		if readErr != nil {
+			return nil, fmt.Errorf("reading Rego module: %w", readErr)
+		}
+		options = append(options, rego.Module(file, string(data)))
+	}
+
+	query, err := rego.New(options...).PrepareForEval(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: compiling Rego modules: %w", ErrInvalidPolicy, err)
+	}
+	return &RegoPolicies{Modules: len(files), pkg: pkg, query: query}, nil
+}
+
+// regoFiles expands paths to the Rego module files they contain, sorted.
+func regoFiles(paths []string) ([]string, error) {
+	var files []string
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, fmt.Errorf("reading Rego policies: %w", err)
+		}
+		if !info.IsDir() {
+			files = append(files, path)
+			continue
+		}
+		err = filepath.WalkDir(path, func(file string, d fs.DirEntry, walkErr error) error {
+			if walkErr != nil {
+				return walkErr
+			}
+			if !d.IsDir() && strings.HasSuffix(file, ".rego") && !strings.HasSuffix(file, "_test.rego") {
+				files = append(files, file)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("reading Rego policies: %w", err)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Evaluate runs the policies against in and returns the violations of the
+// deny rule followed by those of the warn rule, each in Rego set order.
+func (p *RegoPolicies) Evaluate(ctx context.Context, in RegoInput) ([]Violation, error) {
+	results, err := p.query.Eval(ctx, rego.EvalInput(in))
+	if err != nil {
+		return nil, fmt.Errorf("evaluating Rego policies: %w", err)
+	}
+	if len(results) == 0 || len(results[0].Expressions) == 0 {
+		return nil, nil
+	}
+	doc, ok := results[0].Expressions[0].Value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("%w: data.%s is not a package", ErrInvalidPolicy, p.pkg)
+	}
+
+	types := make(map[string]string, len(in.Resources))
+	for _, r := range in.Resources {
+		types[r.ID] = r.Type
+	}
+
+	var violations []Violation
+	for _, rule := range []struct {
+		name     string
+		severity Severity
+	}{{regoDenyRule, SeverityError}, {regoWarnRule, SeverityWarning}} {
+		value, found := doc[rule.name]
+		if !found {
+			continue
+		}
+		messages, isSet := value.([]interface{})
+		if !isSet {
+			return nil, fmt.Errorf("%w: %s.%s must be a set of messages", ErrInvalidPolicy, p.pkg, rule.name)
+		}
+		for _, message := range messages {
+			v, msgErr := regoViolation(message, p.pkg+"."+rule.name, rule.severity)
+			if msgErr != nil {
+				return nil, msgErr
+			}
+			v.ResourceType = types[v.ResourceID]
+			violations = append(violations, v)
+		}
+	}
+	return violations, nil
+}
+
+// regoViolation converts one message of a Rego rule to a violation named
+// after the rule unless the message names its policy.
+func regoViolation(message interface{}, rule string, severity Severity) (Violation, error) {
+	v := Violation{Policy: rule, Severity: severity}
+	switch m := message.(type) {
+	case string:
+		v.Message = m
+	case map[string]interface{}:
+		v.Message, _ = m["msg"].(string)
+		v.ResourceID, _ = m["resource"].(string)
+		if name, _ := m["policy"].(string); name != "" {
+			v.Policy = name
+		}
+	}
+	if v.Message == "" {
+		return v, fmt.Errorf("%w: %s: message %v is neither a string nor an object with a msg string",
+			ErrInvalidPolicy, rule, message)
+	}
+	return v, nil
+}
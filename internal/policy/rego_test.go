@@ -0,0 +1,131 @@
+package policy
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+const regoCostPolicy = `package finfocus
+
+deny contains msg if {
+	some c in input.costs
+	c.monthly > 1000
+	msg := {"msg": sprintf("costs %v a month", [c.monthly]), "resource": c.resourceId, "policy": "cost-cap"}
+}
+
+warn contains "plan grows by more than 100" if {
+	input.delta > 100
+}
+`
+
+const regoTagPolicy = `package finfocus
+
+deny contains sprintf("%s has no owner tag", [r.id]) if {
+	some r in input.resources
+	not r.properties.tags.owner
+}
+`
+
+func writeRego(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func regoTestInput(delta float64) RegoInput {
+	return RegoInput{
+		Currency: "USD",
+		Delta:    &delta,
+		Resources: []engine.ResourceDescriptor{
+			{ID: "big", Type: "aws:ec2/instance:Instance", Properties: map[string]interface{}{
+				"tags": map[string]interface{}{"owner": "ops"},
+			}},
+			{ID: "small", Type: "aws:s3/bucket:Bucket", Properties: map[string]interface{}{}},
+		},
+		Costs: []engine.CostResult{
+			{ResourceID: "big", ResourceType: "aws:ec2/instance:Instance", Monthly: 1500, Currency: "USD"},
+			{ResourceID: "small", ResourceType: "aws:s3/bucket:Bucket", Monthly: 5, Currency: "USD"},
+		},
+	}
+}
+
+func TestRegoPolicies_Evaluate(t *testing.T) {
+	dir := t.TempDir()
+	writeRego(t, dir, "cost.rego", regoCostPolicy)
+	writeRego(t, dir, "tags.rego", regoTagPolicy)
+	writeRego(t, dir, "cost_test.rego", "package finfocus\n\ntest_nothing if { true }\n")
+	writeRego(t, dir, "README.md", "not a module")
+
+	policies, err := LoadRego(context.Background(), []string{dir}, "")
+	require.NoError(t, err)
+	assert.Equal(t, 2, policies.Modules, "_test.rego and non-Rego files are skipped")
+
+	violations, err := policies.Evaluate(context.Background(), regoTestInput(250))
+	require.NoError(t, err)
+	assert.Equal(t, []Violation{
+		{Policy: "finfocus.deny", Severity: SeverityError, Message: "small has no owner tag"},
+		{Policy: "cost-cap", Severity: SeverityError, ResourceID: "big",
+			ResourceType: "aws:ec2/instance:Instance", Message: "costs 1500 a month"},
+		{Policy: "finfocus.warn", Severity: SeverityWarning, Message: "plan grows by more than 100"},
+	}, violations)
+
+	violations, err = policies.Evaluate(context.Background(), RegoInput{})
+	require.NoError(t, err)
+	assert.Empty(t, violations)
+}
+
+func TestRegoPolicies_Package(t *testing.T) {
+	path := writeRego(t, t.TempDir(), "main.rego", "package main\n\ndeny contains \"always\" if { true }\n")
+
+	policies, err := LoadRego(context.Background(), []string{path}, "main")
+	require.NoError(t, err)
+	violations, err := policies.Evaluate(context.Background(), RegoInput{})
+	require.NoError(t, err)
+	require.Len(t, violations, 1)
+	assert.Equal(t, "main.deny", violations[0].Policy)
+
+	policies, err = LoadRego(context.Background(), []string{path}, "")
+	require.NoError(t, err)
+	violations, err = policies.Evaluate(context.Background(), RegoInput{})
+	require.NoError(t, err)
+	assert.Empty(t, violations, "rules outside the package are ignored")
+}
+
+func TestLoadRego_Invalid(t *testing.T) {
+	dir := t.TempDir()
+
+	_, err := LoadRego(context.Background(), []string{dir}, "")
+	require.ErrorIs(t, err, ErrInvalidPolicy, "empty directory")
+
+	_, err = LoadRego(context.Background(), []string{filepath.Join(dir, "missing.rego")}, "")
+	require.Error(t, err)
+
+	broken := writeRego(t, dir, "broken.rego", "package finfocus\n\ndeny contains msg if {\n")
+	_, err = LoadRego(context.Background(), []string{broken}, "")
+	require.ErrorIs(t, err, ErrInvalidPolicy)
+}
+
+func TestRegoPolicies_InvalidMessages(t *testing.T) {
+	tests := map[string]string{
+		"number message":     "package finfocus\n\ndeny contains 42 if { true }\n",
+		"object without msg": "package finfocus\n\ndeny contains {\"resource\": \"a\"} if { true }\n",
+		"not a set":          "package finfocus\n\ndeny := true\n",
+	}
+	for name, module := range tests {
+		t.Run(name, func(t *testing.T) {
+			path := writeRego(t, t.TempDir(), "p.rego", module)
+			policies, err := LoadRego(context.Background(), []string{path}, "")
+			require.NoError(t, err)
+			_, err = policies.Evaluate(context.Background(), RegoInput{})
+			require.ErrorIs(t, err, ErrInvalidPolicy)
+		})
+	}
+}
@@ -1,16 +1,19 @@
 package proto
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
-	"os"
+	"slices"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/structpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/rshade/finfocus-spec/sdk/go/pluginsdk"
@@ -21,8 +24,8 @@ import (
 	"github.com/rshade/finfocus/internal/skus"
 )
 
-// ErrEstimateCostNotSupported indicates the EstimateCost RPC is not yet implemented.
-var ErrEstimateCostNotSupported = errors.New("EstimateCost RPC not yet implemented in finfocus-spec v0.5.6")
+// ErrEstimateResourceRequired indicates an EstimateCost request without a typed resource.
+var ErrEstimateResourceRequired = errors.New("estimate request requires a resource with a type")
 
 // ErrPropertiesMultiResource indicates Properties cannot be used with multiple ResourceIDs
 // because each resource requires its own cloud ID, ARN, and tag mappings.
@@ -33,6 +36,8 @@ var ErrPropertiesMultiResource = errors.New(
 const (
 	// maxErrorsToDisplay is the maximum number of errors to show in summary before truncating.
 	maxErrorsToDisplay = 5
+	// hoursPerMonth converts monthly estimates to hourly rates (matches engine).
+	hoursPerMonth = 730
 	// awsProvider is the AWS provider name constant.
 	awsProvider = "aws"
 
@@ -103,7 +108,7 @@ func GetProjectedCostWithErrors(
 
 	for _, resource := range resources {
 		// Pre-flight validation: construct proto request and validate before gRPC call
-		sku, region := resolveSKUAndRegion(resource.Provider, resource.Type, resource.Properties)
+		sku, region := resolveResourceSKUAndRegion(ctx, resource.Provider, resource.Type, resource.Properties)
 		protoReq := &pbc.GetProjectedCostRequest{
 			Resource: &pbc.ResourceDescriptor{
 				Id:           resource.ID,
@@ -385,7 +390,7 @@ func GetActualCostWithErrors(
 		// but actual cost requests only carry tags. Enrich tags so plugins like aws-public
 		// can look up costs by instance type / volume type.
 		if req.Provider != "" {
-			enrichTagsWithSKUAndRegion(tags, req.Provider, req.ResourceType, req.Properties)
+			enrichTagsWithSKUAndRegion(ctx, tags, req.Provider, req.ResourceType, req.Properties)
 		}
 
 		// Pre-flight validation: construct proto request and validate before gRPC call
@@ -587,6 +592,12 @@ type Recommendation struct {
 	// Metadata contains additional provider-specific information.
 	Metadata map[string]string
 
+	// CurrentSKU is the current instance type or SKU from a rightsize action detail.
+	CurrentSKU string
+
+	// RecommendedSKU is the target instance type or SKU from a rightsize action detail.
+	RecommendedSKU string
+
 	// Reasoning carries plugin-provided warnings and caveats mapped from
 	// proto Recommendation.Reasoning (field 14). These explain prerequisites
 	// or risks associated with implementing the recommendation.
@@ -687,18 +698,33 @@ type CostSourceClient interface {
 		in *DismissRecommendationRequest,
 		opts ...grpc.CallOption,
 	) (*DismissRecommendationResponse, error)
+	EstimateCost(
+		ctx context.Context,
+		in *EstimateCostRequest,
+		opts ...grpc.CallOption,
+	) (*EstimateCostResponse, error)
 }
 
 // NewCostSourceClient creates a new cost source client using the real proto client.
 func NewCostSourceClient(conn *grpc.ClientConn) CostSourceClient {
 	return &clientAdapter{
 		client: pbc.NewCostSourceServiceClient(conn),
+		conn:   conn,
 	}
 }
 
 // clientAdapter adapts the generated client to our internal interface.
 type clientAdapter struct {
 	client pbc.CostSourceServiceClient
+
+	// conn opens server streams for RPCs the generated client does not
+	// declare. It is nil in tests that only mock the generated client, which
+	// makes streaming calls fall back to unary RPCs.
+	conn grpc.ClientConnInterface
+
+	// streamUnsupported records that the plugin answered a streaming call
+	// with Unimplemented, so later calls go straight to the unary fallback.
+	streamUnsupported atomic.Bool
 }
 
 func (c *clientAdapter) Name(
@@ -784,12 +810,16 @@ func (c *clientAdapter) DismissRecommendation(
 // resolveSKUAndRegion determines the SKU and region for a resource based on its provider, type, and stringified properties.
 //
 // resolveSKUAndRegion examines provider-specific fields and fallbacks to derive a SKU and a region for pricing/enrichment.
-// For AWS it attempts AWS-specific SKU/region extraction, parses region from an ARN when present, and finally falls back to well-known SKU mappings.
+// For AWS it attempts AWS-specific SKU/region extraction and parses region from an ARN when present.
 // For Azure and GCP it uses provider-specific extractors. For other providers it uses generic SKU and region extractors.
-// If the region remains empty for AWS resources, the function will also consult AWS environment variables `AWS_REGION` and `AWS_DEFAULT_REGION`.
+// A SKU that no extractor finds falls back to the well-known SKU catalog of the skus package.
+// If the region remains empty, the function consults the provider's own environment variables: `AWS_REGION` and
+// `AWS_DEFAULT_REGION` for AWS, `ARM_LOCATION` and `AZURE_DEFAULTS_LOCATION` for Azure, and `CLOUDSDK_COMPUTE_REGION`
+// and `GOOGLE_REGION` for GCP.
 //
 // Parameters:
-//   - provider: cloud provider identifier (e.g., "aws", "azure", "gcp").
+//   - provider: cloud provider identifier (e.g., "aws", "azure", "gcp"), or an alias of one in
+//     cost.provider_aliases.
 //   - resourceType: the resource type token used for well-known SKU resolution when direct extraction fails.
 //   - properties: map of stringified resource properties used by extractors (keys like ARN, tags, sku fields).
 //
@@ -797,6 +827,7 @@ func (c *clientAdapter) DismissRecommendation(
 //   - sku: the resolved SKU string, or an empty string if none could be determined.
 //   - region: the resolved region string, or an empty string if none could be determined.
 func resolveSKUAndRegion(provider, resourceType string, properties map[string]string) (string, string) {
+	provider = skus.CanonicalProvider(provider)
 	var sku, region string
 	switch strings.ToLower(provider) {
 	case awsProvider:
@@ -805,11 +836,6 @@ func resolveSKUAndRegion(provider, resourceType string, properties map[string]st
 			// Fallback for RDS and other AWS resources not covered by ExtractAWSSKU
 			sku = mapping.ExtractSKU(properties, "dbInstanceClass", "sku", "type", "tier")
 		}
-		if sku == "" {
-			// Fallback to well-known SKU mappings for resources with fixed costs
-			// (e.g., EKS clusters have $0.10/hr but no SKU property in state)
-			sku = skus.ResolveSKU(provider, resourceType, properties)
-		}
 		region = mapping.ExtractAWSRegion(properties)
 		if region == "" {
 			// Fallback: parse region from ARN (arn:aws:service:region:account:...)
@@ -826,23 +852,53 @@ func resolveSKUAndRegion(provider, resourceType string, properties map[string]st
 		region = mapping.ExtractRegion(properties)
 	}
 
-	// Fallback to AWS environment variables for region if still empty
-	// IMPORTANT: Only apply AWS-specific env vars to AWS resources to avoid
+	if sku == "" {
+		// Fallback to the well-known SKU catalog for resources with fixed costs
+		// (e.g., EKS clusters have $0.10/hr but no SKU property in state)
+		sku = skus.ResolveSKU(provider, resourceType, properties)
+	}
+
+	// Fallback to the provider's environment variables for region if still empty
+	// IMPORTANT: Only apply a provider's own env vars to its resources to avoid
 	// incorrect region assignment for Azure/GCP resources (SC-001 fix)
-	if region == "" && strings.ToLower(provider) == "aws" {
-		if envReg := os.Getenv("AWS_REGION"); envReg != "" {
-			region = envReg
-		} else {
-			envReg = os.Getenv("AWS_DEFAULT_REGION")
-			if envReg != "" {
-				region = envReg
-			}
-		}
+	if region == "" {
+		region = envRegion(provider)
 	}
 
 	return sku, region
 }
 
+// resolveResourceSKUAndRegion resolves the SKU and region of a resource with
+// the user's mapping rules carried by ctx first, with resolveSKUAndRegion for
+// whatever the rules leave unresolved, and with the configured default region
+// of the provider, also carried by ctx, last. Rules and default regions apply
+// to the provider that an aliased provider namespace stands for.
+func resolveResourceSKUAndRegion(
+	ctx context.Context,
+	provider, resourceType string,
+	properties map[string]string,
+) (string, string) {
+	provider = skus.CanonicalProvider(provider)
+	sku, region := skus.FromContext(ctx).Resolve(provider, resourceType, properties)
+	if sku != "" && region != "" {
+		return sku, region
+	}
+	builtinSKU, builtinRegion := resolveSKUAndRegion(provider, resourceType, properties)
+	return cmp.Or(sku, builtinSKU), cmp.Or(region, builtinRegion, defaultRegion(ctx, provider))
+}
+
+// ResolveSKUAndRegion returns the SKU and region that projected cost requests
+// carry for a resource with the given string properties under ctx, so that
+// callers caching results can tell when the user's rules, catalog, provider
+// aliases, or default regions resolve a resource differently.
+func ResolveSKUAndRegion(
+	ctx context.Context,
+	provider, resourceType string,
+	properties map[string]string,
+) (string, string) {
+	return resolveResourceSKUAndRegion(ctx, provider, resourceType, properties)
+}
+
 // resolveActualCostIdentifiers extracts the cloud identifier, ARN, and tags from a resource's properties.
 // resourceID is used as the fallback cloud identifier when no cloud ID is present in properties.
 //
@@ -957,17 +1013,19 @@ func toStringMap(m map[string]interface{}) map[string]string {
 // adds "sku" or "region" when the resolved values are non-empty.
 //
 // Parameters:
+//   - ctx: carries the user's SKU and region mapping rules, if any.
 //   - tags: map to be mutated with optional "sku" and "region" entries.
 //   - provider: cloud provider identifier (e.g., "aws", "azure") used for resolution.
 //   - resourceType: resource type token used to help determine SKU/region.
 //   - properties: resource properties that are converted to strings and used for resolution.
 func enrichTagsWithSKUAndRegion(
+	ctx context.Context,
 	tags map[string]string,
 	provider, resourceType string,
 	properties map[string]interface{},
 ) {
 	stringProps := toStringMap(properties)
-	sku, region := resolveSKUAndRegion(provider, resourceType, stringProps)
+	sku, region := resolveResourceSKUAndRegion(ctx, provider, resourceType, stringProps)
 	if sku != "" {
 		if _, exists := tags["sku"]; !exists {
 			tags["sku"] = sku
@@ -987,10 +1045,11 @@ func (c *clientAdapter) GetProjectedCost(
 ) (*GetProjectedCostResponse, error) {
 	// Convert internal request to proto request
 	var results []*CostResult
+	var lastErr error
 
 	for _, resource := range in.Resources {
 		// Extract SKU and region from properties using intelligent mapping
-		sku, region := resolveSKUAndRegion(resource.Provider, resource.Type, resource.Properties)
+		sku, region := resolveResourceSKUAndRegion(ctx, resource.Provider, resource.Type, resource.Properties)
 
 		req := &pbc.GetProjectedCostRequest{
 			Resource: &pbc.ResourceDescriptor{
@@ -1006,6 +1065,7 @@ func (c *clientAdapter) GetProjectedCost(
 		resp, err := c.client.GetProjectedCost(ctx, req, opts...)
 		if err != nil {
 			// Continue to next resource on error
+			lastErr = err
 			continue
 		}
 
@@ -1043,6 +1103,12 @@ func (c *clientAdapter) GetProjectedCost(
 		results = append(results, result)
 	}
 
+	// Partial failures are skipped, but when every resource failed the RPC
+	// error is returned so callers (and the retry layer) can see it.
+	if len(results) == 0 && lastErr != nil {
+		return nil, lastErr
+	}
+
 	return &GetProjectedCostResponse{Results: results}, nil
 }
 
@@ -1063,7 +1129,7 @@ func (c *clientAdapter) GetActualCost(
 		// pre-validated path. It is intentionally idempotent — direct callers of
 		// clientAdapter.GetActualCost may not have pre-enriched tags.
 		if in.Provider != "" {
-			enrichTagsWithSKUAndRegion(tags, in.Provider, in.ResourceType, in.Properties)
+			enrichTagsWithSKUAndRegion(ctx, tags, in.Provider, in.ResourceType, in.Properties)
 		}
 
 		req := &pbc.GetActualCostRequest{
@@ -1178,7 +1244,7 @@ type CostDelta struct {
 	CostChange float64 `json:"costChange"`
 }
 
-// BuildEstimateCostRequest constructs an EstimateCostRequest proto message.
+// BuildEstimateCostRequest constructs an EstimateCostRequest.
 //
 // This is the adapter layer function that converts engine-level types to
 // proto-level types for gRPC communication with plugins.
@@ -1188,13 +1254,108 @@ type CostDelta struct {
 //   - overrides: Property overrides to apply for the modified calculation
 //
 // Returns:
-//   - *EstimateCostRequest: The internal request (nil when RPC not implemented)
-//   - error: ErrEstimateCostNotSupported until the RPC is implemented
+//   - *EstimateCostRequest: The request with a copy of overrides
+//   - error: ErrEstimateResourceRequired when resource is nil or has no type
 func BuildEstimateCostRequest(
-	_ *ResourceDescriptor,
-	_ map[string]string,
+	resource *ResourceDescriptor,
+	overrides map[string]string,
 ) (*EstimateCostRequest, error) {
-	return nil, ErrEstimateCostNotSupported
+	if resource == nil || resource.Type == "" {
+		return nil, ErrEstimateResourceRequired
+	}
+	copied := make(map[string]string, len(overrides))
+	for k, v := range overrides {
+		copied[k] = v
+	}
+	return &EstimateCostRequest{Resource: resource, PropertyOverrides: copied}, nil
+}
+
+// EstimateCost prices the resource once with its original properties, once
+// with every override applied, and once per override when there are several,
+// so that each changed property gets its own delta against the baseline.
+func (c *clientAdapter) EstimateCost(
+	ctx context.Context,
+	in *EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*EstimateCostResponse, error) {
+	if in == nil || in.Resource == nil || in.Resource.Type == "" {
+		return nil, ErrEstimateResourceRequired
+	}
+
+	estimate := func(overrides map[string]string) (*CostResult, error) {
+		req, err := toPbcEstimateCostRequest(in.Resource, overrides)
+		if err != nil {
+			return nil, err
+		}
+		resp, err := c.client.EstimateCost(ctx, req, opts...)
+		if err != nil {
+			return nil, fmt.Errorf("EstimateCost RPC failed: %w", err)
+		}
+		return fromPbcEstimateCostResponse(resp), nil
+	}
+
+	baseline, err := estimate(nil)
+	if err != nil {
+		return nil, err
+	}
+	modified, err := estimate(in.PropertyOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(in.PropertyOverrides))
+	for k := range in.PropertyOverrides {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	deltas := make([]*CostDelta, 0, len(keys))
+	for _, key := range keys {
+		single := modified
+		if len(keys) > 1 {
+			if single, err = estimate(map[string]string{key: in.PropertyOverrides[key]}); err != nil {
+				return nil, err
+			}
+		}
+		deltas = append(deltas, &CostDelta{
+			Property:      key,
+			OriginalValue: in.Resource.Properties[key],
+			NewValue:      in.PropertyOverrides[key],
+			CostChange:    single.MonthlyCost - baseline.MonthlyCost,
+		})
+	}
+
+	return &EstimateCostResponse{Baseline: baseline, Modified: modified, Deltas: deltas}, nil
+}
+
+// toPbcEstimateCostRequest builds the plugin request for resource with
+// overrides layered over its properties.
+func toPbcEstimateCostRequest(
+	resource *ResourceDescriptor,
+	overrides map[string]string,
+) (*pbc.EstimateCostRequest, error) {
+	attrs := make(map[string]any, len(resource.Properties)+len(overrides))
+	for k, v := range resource.Properties {
+		attrs[k] = v
+	}
+	for k, v := range overrides {
+		attrs[k] = v
+	}
+	attributes, err := structpb.NewStruct(attrs)
+	if err != nil {
+		return nil, fmt.Errorf("converting estimate attributes: %w", err)
+	}
+	return &pbc.EstimateCostRequest{ResourceType: resource.Type, Attributes: attributes}, nil
+}
+
+// fromPbcEstimateCostResponse converts a plugin estimate into a CostResult.
+// The RPC reports monthly cost only, so the hourly rate is derived from it.
+func fromPbcEstimateCostResponse(resp *pbc.EstimateCostResponse) *CostResult {
+	return &CostResult{
+		Currency:    cmp.Or(resp.GetCurrency(), "USD"),
+		MonthlyCost: resp.GetCostMonthly(),
+		HourlyCost:  resp.GetCostMonthly() / hoursPerMonth,
+	}
 }
 
 func (c *clientAdapter) GetRecommendations(
@@ -1202,7 +1363,16 @@ func (c *clientAdapter) GetRecommendations(
 	in *GetRecommendationsRequest,
 	opts ...grpc.CallOption,
 ) (*GetRecommendationsResponse, error) {
-	// Convert internal request to proto request
+	resp, err := c.client.GetRecommendations(ctx, toPbcRecommendationsRequest(ctx, in), opts...)
+	if err != nil {
+		return nil, err
+	}
+	return fromPbcRecommendationsResponse(resp), nil
+}
+
+// toPbcRecommendationsRequest converts an internal recommendations request to
+// the proto request, resolving SKU and region for each target resource.
+func toPbcRecommendationsRequest(ctx context.Context, in *GetRecommendationsRequest) *pbc.GetRecommendationsRequest {
 	req := &pbc.GetRecommendationsRequest{
 		ProjectionPeriod:          in.ProjectionPeriod,
 		PageSize:                  in.PageSize,
@@ -1212,7 +1382,7 @@ func (c *clientAdapter) GetRecommendations(
 
 	// Convert target resources if provided
 	for _, resource := range in.TargetResources {
-		sku, region := resolveSKUAndRegion(resource.Provider, resource.Type, resource.Properties)
+		sku, region := resolveResourceSKUAndRegion(ctx, resource.Provider, resource.Type, resource.Properties)
 		req.TargetResources = append(req.TargetResources, &pbc.ResourceDescriptor{
 			Id:           resource.ID,
 			Provider:     resource.Provider,
@@ -1222,12 +1392,12 @@ func (c *clientAdapter) GetRecommendations(
 			Tags:         resource.Properties,
 		})
 	}
+	return req
+}
 
-	resp, err := c.client.GetRecommendations(ctx, req, opts...)
-	if err != nil {
-		return nil, err
-	}
-
+// fromPbcRecommendationsResponse converts a proto recommendations response (or
+// streamed page) to the internal format.
+func fromPbcRecommendationsResponse(resp *pbc.GetRecommendationsResponse) *GetRecommendationsResponse {
 	// Convert proto recommendations to internal format
 	var recommendations []*Recommendation
 	for _, rec := range resp.GetRecommendations() {
@@ -1246,6 +1416,13 @@ func (c *clientAdapter) GetRecommendations(
 			protoRec.ResourceID = rec.GetResource().GetId()
 		}
 
+		// Extract rightsize targets, preferring instance types over raw SKUs
+		if rightsize := rec.GetRightsize(); rightsize != nil {
+			protoRec.CurrentSKU = cmp.Or(rightsize.GetCurrentInstanceType(), rightsize.GetCurrentSku())
+			protoRec.RecommendedSKU = cmp.Or(
+				rightsize.GetRecommendedInstanceType(), rightsize.GetRecommendedSku())
+		}
+
 		// Convert impact if available
 		if rec.GetImpact() != nil {
 			protoRec.Impact = &RecommendationImpact{
@@ -1263,5 +1440,5 @@ func (c *clientAdapter) GetRecommendations(
 	return &GetRecommendationsResponse{
 		Recommendations: recommendations,
 		NextPageToken:   resp.GetNextPageToken(),
-	}, nil
+	}
 }
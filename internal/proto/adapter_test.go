@@ -16,6 +16,7 @@ import (
 
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 	"github.com/rshade/finfocus/internal/awsutil"
+	"github.com/rshade/finfocus/internal/skus"
 )
 
 // mockCostSourceClient is a mock implementation of CostSourceClient for testing.
@@ -56,6 +57,11 @@ type mockCostSourceClient struct {
 		in *DismissRecommendationRequest,
 		opts ...grpc.CallOption,
 	) (*DismissRecommendationResponse, error)
+	estimateCostFunc func(
+		ctx context.Context,
+		in *EstimateCostRequest,
+		opts ...grpc.CallOption,
+	) (*EstimateCostResponse, error)
 }
 
 func (m *mockCostSourceClient) Name(
@@ -146,6 +152,17 @@ func (m *mockCostSourceClient) DismissRecommendation(
 	return &DismissRecommendationResponse{Success: true}, nil
 }
 
+func (m *mockCostSourceClient) EstimateCost(
+	ctx context.Context,
+	in *EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*EstimateCostResponse, error) {
+	if m.estimateCostFunc != nil {
+		return m.estimateCostFunc(ctx, in, opts...)
+	}
+	return &EstimateCostResponse{}, nil
+}
+
 // T020: Unit test for DryRun wrapper.
 func TestDryRun(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
@@ -1865,6 +1882,45 @@ func TestResolveSKUAndRegion_AWSRegionFallbackScope(t *testing.T) {
 			envVars:        map[string]string{},
 			expectedRegion: "",
 		},
+		{
+			name:           "Azure resource uses ARM_LOCATION fallback",
+			provider:       "azure",
+			properties:     map[string]string{"vmSize": "Standard_B1s"},
+			envVars:        map[string]string{"ARM_LOCATION": "westeurope", "AZURE_DEFAULTS_LOCATION": "eastus"},
+			expectedRegion: "westeurope",
+		},
+		{
+			name:           "Azure-native resource uses AZURE_DEFAULTS_LOCATION fallback",
+			provider:       "azure-native",
+			properties:     map[string]string{"vmSize": "Standard_B1s"},
+			envVars:        map[string]string{"AZURE_DEFAULTS_LOCATION": "eastus"},
+			expectedRegion: "eastus",
+		},
+		{
+			name:       "GCP resource uses CLOUDSDK_COMPUTE_REGION fallback",
+			provider:   "gcp",
+			properties: map[string]string{"machineType": "n1-standard-1"},
+			envVars: map[string]string{
+				"CLOUDSDK_COMPUTE_REGION": "us-central1",
+				"GOOGLE_REGION":           "europe-west1",
+			},
+			expectedRegion: "us-central1",
+		},
+		{
+			name:           "Google-native resource uses GOOGLE_REGION fallback",
+			provider:       "google-native",
+			properties:     map[string]string{"machineType": "e2-micro"},
+			envVars:        map[string]string{"GOOGLE_REGION": "europe-west1"},
+			expectedRegion: "europe-west1",
+		},
+		{
+			name:       "AWS resource does NOT use Azure or GCP fallbacks",
+			provider:   "aws",
+			properties: map[string]string{"instanceType": "t3.micro"},
+			envVars:    map[string]string{"ARM_LOCATION": "westeurope", "GOOGLE_REGION": "europe-west1"},
+			// AWS should NOT inherit another provider's region
+			expectedRegion: "",
+		},
 	}
 
 	for _, tt := range tests {
@@ -1883,6 +1939,20 @@ func TestResolveSKUAndRegion_AWSRegionFallbackScope(t *testing.T) {
 	}
 }
 
+// TestResolveSKUAndRegion_ProviderAlias verifies that a provider namespace in
+// cost.provider_aliases is resolved like the provider it aliases.
+func TestResolveSKUAndRegion_ProviderAlias(t *testing.T) {
+	skus.SetProviderAliases(map[string]string{"my-org": "aws"})
+	t.Cleanup(func() { skus.SetProviderAliases(nil) })
+	t.Setenv("AWS_REGION", "us-east-2")
+
+	sku, region := resolveSKUAndRegion("my-org", "my-org:aws-wrapper:Instance",
+		map[string]string{"instanceType": "t3.micro"})
+	if sku != "t3.micro" || region != "us-east-2" {
+		t.Errorf("resolveSKUAndRegion(my-org) = (%q, %q), want (t3.micro, us-east-2)", sku, region)
+	}
+}
+
 // T011: TestGetActualCost_ValidationFailure_InvalidTimeRange verifies that
 // requests with end time before start time trigger pre-flight validation failure.
 func TestGetActualCost_ValidationFailure_InvalidTimeRange(t *testing.T) {
@@ -2755,7 +2825,7 @@ func TestEnrichTagsWithSKUAndRegion(t *testing.T) {
 			"availabilityZone": "us-west-2a",
 		}
 
-		enrichTagsWithSKUAndRegion(tags, "aws", "aws:ec2/instance:Instance", props)
+		enrichTagsWithSKUAndRegion(context.Background(), tags, "aws", "aws:ec2/instance:Instance", props)
 
 		assert.Equal(t, "t3.medium", tags["sku"])
 		assert.Contains(t, tags["region"], "us-west-2")
@@ -2772,7 +2842,7 @@ func TestEnrichTagsWithSKUAndRegion(t *testing.T) {
 			"region":       "us-east-1",
 		}
 
-		enrichTagsWithSKUAndRegion(tags, "aws", "aws:ec2/instance:Instance", props)
+		enrichTagsWithSKUAndRegion(context.Background(), tags, "aws", "aws:ec2/instance:Instance", props)
 
 		assert.Equal(t, "existing-sku", tags["sku"])
 		assert.Equal(t, "existing-region", tags["region"])
@@ -2785,7 +2855,7 @@ func TestEnrichTagsWithSKUAndRegion(t *testing.T) {
 			"pulumi:arn":   "arn:aws:ec2:us-east-1:123456789012:instance/i-0abc",
 		}
 
-		enrichTagsWithSKUAndRegion(tags, "aws", "aws:ec2/instance:Instance", props)
+		enrichTagsWithSKUAndRegion(context.Background(), tags, "aws", "aws:ec2/instance:Instance", props)
 
 		assert.Equal(t, "t3.medium", tags["sku"])
 		assert.Equal(t, "us-east-1", tags["region"])
@@ -2799,7 +2869,7 @@ func TestEnrichTagsWithSKUAndRegion(t *testing.T) {
 			"pulumi:arn":       "arn:aws:ec2:us-east-1:123456789012:instance/i-0abc",
 		}
 
-		enrichTagsWithSKUAndRegion(tags, "aws", "aws:ec2/instance:Instance", props)
+		enrichTagsWithSKUAndRegion(context.Background(), tags, "aws", "aws:ec2/instance:Instance", props)
 
 		assert.Contains(t, tags["region"], "us-west-2", "AZ-based region should win over ARN")
 	})
@@ -2808,7 +2878,7 @@ func TestEnrichTagsWithSKUAndRegion(t *testing.T) {
 		tags := map[string]string{"Name": "test"}
 		props := map[string]interface{}{}
 
-		enrichTagsWithSKUAndRegion(tags, "aws", "", props)
+		enrichTagsWithSKUAndRegion(context.Background(), tags, "aws", "", props)
 
 		_, hasSKU := tags["sku"]
 		assert.False(t, hasSKU)
@@ -2820,10 +2890,37 @@ func TestEnrichTagsWithSKUAndRegion(t *testing.T) {
 			"name": "my-cluster",
 		}
 
-		enrichTagsWithSKUAndRegion(tags, "aws", "aws:eks/cluster:Cluster", props)
+		enrichTagsWithSKUAndRegion(context.Background(), tags, "aws", "aws:eks/cluster:Cluster", props)
 
 		assert.Equal(t, "cluster", tags["sku"])
 	})
+
+	t.Run("mapping rules resolve resources the built-in extraction does not know", func(t *testing.T) {
+		tags := map[string]string{}
+		props := map[string]interface{}{"size": "s-2vcpu-4gb", "region": "nyc3"}
+		ctx := skus.NewContext(context.Background(), skus.Rules{
+			{Type: "digitalocean:index/droplet:Droplet", SKU: "${size}"},
+		})
+
+		enrichTagsWithSKUAndRegion(ctx, tags, "digitalocean", "digitalocean:index/droplet:Droplet", props)
+
+		assert.Equal(t, "s-2vcpu-4gb", tags["sku"])
+		assert.Equal(t, "nyc3", tags["region"], "built-in extraction resolves what the rules leave unresolved")
+	})
+}
+
+func TestResolveResourceSKUAndRegion_RulesTakePrecedence(t *testing.T) {
+	props := map[string]string{"instanceType": "t3.medium", "region": "us-east-1", "reservedType": "t3.large"}
+	ctx := skus.NewContext(context.Background(), skus.Rules{
+		{Provider: "aws", Type: "aws:ec2/instance:Instance", SKU: "${reservedType}"},
+	})
+
+	sku, region := resolveResourceSKUAndRegion(ctx, "aws", "aws:ec2/instance:Instance", props)
+	assert.Equal(t, "t3.large", sku)
+	assert.Equal(t, "us-east-1", region)
+
+	sku, _ = resolveResourceSKUAndRegion(context.Background(), "aws", "aws:ec2/instance:Instance", props)
+	assert.Equal(t, "t3.medium", sku)
 }
 
 func TestRegionFromARN(t *testing.T) {
@@ -2989,15 +3086,56 @@ func TestActualCost_ProtoTagsContainSKUAndRegion(t *testing.T) {
 	})
 }
 
+func TestClientAdapter_GetProjectedCost_ReturnsErrorWhenAllResourcesFail(t *testing.T) {
+	unavailable := status.Error(codes.Unavailable, "plugin exited")
+	mockGRPC := &mockPbcCostSourceServiceClient{
+		getProjectedCostFunc: func(
+			_ context.Context, in *pbc.GetProjectedCostRequest, _ ...grpc.CallOption,
+		) (*pbc.GetProjectedCostResponse, error) {
+			if in.GetResource().GetId() == "ok" {
+				return &pbc.GetProjectedCostResponse{Currency: "USD", CostPerMonth: 5}, nil
+			}
+			return nil, unavailable
+		},
+	}
+	adapter := &clientAdapter{client: mockGRPC}
+
+	resp, err := adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{{ID: "bad", Provider: "aws"}, {ID: "ok", Provider: "aws"}},
+	})
+	require.NoError(t, err, "partial failures are skipped")
+	require.Len(t, resp.Results, 1)
+
+	_, err = adapter.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{{ID: "bad", Provider: "aws"}},
+	})
+	require.ErrorIs(t, err, unavailable)
+}
+
 // mockPbcCostSourceServiceClient mocks the generated pbc.CostSourceServiceClient
-// gRPC interface. Only GetActualCost uses a configurable callback; the rest return
-// empty success responses.
+// gRPC interface. GetActualCost and GetProjectedCost use configurable callbacks;
+// the rest return empty success responses.
 type mockPbcCostSourceServiceClient struct {
 	getActualCostFunc func(
 		ctx context.Context,
 		in *pbc.GetActualCostRequest,
 		opts ...grpc.CallOption,
 	) (*pbc.GetActualCostResponse, error)
+	getProjectedCostFunc func(
+		ctx context.Context,
+		in *pbc.GetProjectedCostRequest,
+		opts ...grpc.CallOption,
+	) (*pbc.GetProjectedCostResponse, error)
+	getRecommendationsFunc func(
+		ctx context.Context,
+		in *pbc.GetRecommendationsRequest,
+		opts ...grpc.CallOption,
+	) (*pbc.GetRecommendationsResponse, error)
+	estimateCostFunc func(
+		ctx context.Context,
+		in *pbc.EstimateCostRequest,
+		opts ...grpc.CallOption,
+	) (*pbc.EstimateCostResponse, error)
 }
 
 func (m *mockPbcCostSourceServiceClient) Name(
@@ -3022,8 +3160,11 @@ func (m *mockPbcCostSourceServiceClient) GetActualCost(
 }
 
 func (m *mockPbcCostSourceServiceClient) GetProjectedCost(
-	_ context.Context, _ *pbc.GetProjectedCostRequest, _ ...grpc.CallOption,
+	ctx context.Context, in *pbc.GetProjectedCostRequest, opts ...grpc.CallOption,
 ) (*pbc.GetProjectedCostResponse, error) {
+	if m.getProjectedCostFunc != nil {
+		return m.getProjectedCostFunc(ctx, in, opts...)
+	}
 	return &pbc.GetProjectedCostResponse{}, nil
 }
 
@@ -3034,14 +3175,20 @@ func (m *mockPbcCostSourceServiceClient) GetPricingSpec(
 }
 
 func (m *mockPbcCostSourceServiceClient) EstimateCost(
-	_ context.Context, _ *pbc.EstimateCostRequest, _ ...grpc.CallOption,
+	ctx context.Context, in *pbc.EstimateCostRequest, opts ...grpc.CallOption,
 ) (*pbc.EstimateCostResponse, error) {
+	if m.estimateCostFunc != nil {
+		return m.estimateCostFunc(ctx, in, opts...)
+	}
 	return &pbc.EstimateCostResponse{}, nil
 }
 
 func (m *mockPbcCostSourceServiceClient) GetRecommendations(
-	_ context.Context, _ *pbc.GetRecommendationsRequest, _ ...grpc.CallOption,
+	ctx context.Context, in *pbc.GetRecommendationsRequest, opts ...grpc.CallOption,
 ) (*pbc.GetRecommendationsResponse, error) {
+	if m.getRecommendationsFunc != nil {
+		return m.getRecommendationsFunc(ctx, in, opts...)
+	}
 	return &pbc.GetRecommendationsResponse{}, nil
 }
 
@@ -3302,3 +3449,85 @@ func TestGetActualCostWithErrors_StructuredError_GRPCTimeout(t *testing.T) {
 		"gRPC status-wrapped deadline should be classified as TIMEOUT_ERROR")
 	assert.Contains(t, result.Results[0].StructuredError.Message, "deadline exceeded")
 }
+
+func TestClientAdapter_EstimateCost(t *testing.T) {
+	prices := map[string]float64{"t3.micro": 8, "m5.large": 70}
+	var calls int
+	mockGRPC := &mockPbcCostSourceServiceClient{
+		estimateCostFunc: func(
+			_ context.Context,
+			in *pbc.EstimateCostRequest,
+			_ ...grpc.CallOption,
+		) (*pbc.EstimateCostResponse, error) {
+			calls++
+			assert.Equal(t, "aws:ec2/instance:Instance", in.GetResourceType())
+			attrs := in.GetAttributes().GetFields()
+			monthly := prices[attrs["instanceType"].GetStringValue()]
+			if attrs["volumeSize"].GetStringValue() == "100" {
+				monthly += 10
+			}
+			return &pbc.EstimateCostResponse{Currency: "USD", CostMonthly: monthly}, nil
+		},
+	}
+	adapter := &clientAdapter{client: mockGRPC}
+	resource := &ResourceDescriptor{
+		ID:         "web",
+		Type:       "aws:ec2/instance:Instance",
+		Provider:   "aws",
+		Properties: map[string]string{"instanceType": "t3.micro", "volumeSize": "8"},
+	}
+
+	t.Run("per-property deltas", func(t *testing.T) {
+		calls = 0
+		req, err := BuildEstimateCostRequest(resource, map[string]string{
+			"volumeSize":   "100",
+			"instanceType": "m5.large",
+		})
+		require.NoError(t, err)
+
+		resp, err := adapter.EstimateCost(context.Background(), req)
+		require.NoError(t, err)
+		assert.Equal(t, 4, calls, "baseline, combined, and one call per property")
+		assert.InDelta(t, 8.0, resp.Baseline.MonthlyCost, 0.001)
+		assert.InDelta(t, 80.0, resp.Modified.MonthlyCost, 0.001)
+		assert.InDelta(t, 80.0/hoursPerMonth, resp.Modified.HourlyCost, 0.0001)
+		require.Len(t, resp.Deltas, 2)
+		assert.Equal(t, &CostDelta{
+			Property: "instanceType", OriginalValue: "t3.micro", NewValue: "m5.large", CostChange: 62,
+		}, resp.Deltas[0])
+		assert.Equal(t, &CostDelta{
+			Property: "volumeSize", OriginalValue: "8", NewValue: "100", CostChange: 10,
+		}, resp.Deltas[1])
+	})
+
+	t.Run("single override reuses the modified estimate", func(t *testing.T) {
+		calls = 0
+		resp, err := adapter.EstimateCost(context.Background(), &EstimateCostRequest{
+			Resource:          resource,
+			PropertyOverrides: map[string]string{"instanceType": "m5.large"},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, calls)
+		require.Len(t, resp.Deltas, 1)
+		assert.InDelta(t, 62.0, resp.Deltas[0].CostChange, 0.001)
+	})
+
+	t.Run("unimplemented is preserved", func(t *testing.T) {
+		unimplemented := &clientAdapter{client: &mockPbcCostSourceServiceClient{
+			estimateCostFunc: func(
+				context.Context, *pbc.EstimateCostRequest, ...grpc.CallOption,
+			) (*pbc.EstimateCostResponse, error) {
+				return nil, status.Error(codes.Unimplemented, "no")
+			},
+		}}
+		_, err := unimplemented.EstimateCost(context.Background(), &EstimateCostRequest{Resource: resource})
+		assert.Equal(t, codes.Unimplemented, status.Code(err))
+	})
+
+	t.Run("requires a typed resource", func(t *testing.T) {
+		_, err := BuildEstimateCostRequest(&ResourceDescriptor{}, nil)
+		require.ErrorIs(t, err, ErrEstimateResourceRequired)
+		_, err = adapter.EstimateCost(context.Background(), &EstimateCostRequest{})
+		require.ErrorIs(t, err, ErrEstimateResourceRequired)
+	})
+}
@@ -0,0 +1,205 @@
+package proto
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	"github.com/rshade/finfocus/internal/engine/cache"
+	"github.com/rshade/finfocus/internal/logging"
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+// Cache operation names. They key per-operation TTLs in CacheOptions and are
+// part of every response cache key.
+const (
+	CacheOpProjectedCost   = "projected_cost"
+	CacheOpActualCost      = "actual_cost"
+	CacheOpRecommendations = "recommendations"
+)
+
+// CacheOptions configures NewCachingClient.
+type CacheOptions struct {
+	// Store holds cached responses. A nil or disabled store turns caching off.
+	Store *cache.FileStore
+
+	// TTLSeconds overrides the store's default TTL per operation, keyed by the
+	// CacheOp constants. Missing or non-positive entries use the store default.
+	TTLSeconds map[string]int
+}
+
+// cachingClient caches successful cost RPC responses in a file store, keyed
+// by a SHA256 of the plugin identity, the operation, and the normalized
+// request. Other RPCs pass through unchanged.
+type cachingClient struct {
+	CostSourceClient
+
+	plugin string
+	opts   CacheOptions
+}
+
+// NewCachingClient wraps api so that GetProjectedCost, GetActualCost, and
+// GetRecommendations responses are served from opts.Store when a fresh entry
+// exists. plugin identifies the plugin in cache keys and should include its
+// version so that upgrades do not serve stale responses. Errors and responses
+// that carry per-resource plugin errors are never cached.
+func NewCachingClient(plugin string, api CostSourceClient, opts CacheOptions) CostSourceClient {
+	if opts.Store == nil || !opts.Store.IsEnabled() {
+		return api
+	}
+	return &cachingClient{CostSourceClient: api, plugin: plugin, opts: opts}
+}
+
+// RequestCacheKey returns the hex SHA256 cache key for a request. Map keys
+// are serialized in sorted order by encoding/json, so equal requests produce
+// equal keys regardless of map iteration order. resolved lists the SKU and
+// region the adapter resolves for the resources of the request, which depend
+// on more than the request: the user's mapping rules, SKU catalog, provider
+// aliases, and default regions.
+func RequestCacheKey(plugin, operation string, req any, resolved ...string) (string, error) {
+	data, err := json.Marshal(struct {
+		Plugin    string   `json:"plugin"`
+		Operation string   `json:"operation"`
+		Request   any      `json:"request"`
+		Resolved  []string `json:"resolved,omitempty"`
+	}{plugin, operation, req, resolved})
+	if err != nil {
+		return "", fmt.Errorf("marshaling %s request for cache key: %w", operation, err)
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// resolvedCacheKey returns the SKU and region the adapter sends for a
+// resource under ctx, for RequestCacheKey.
+func resolvedCacheKey(ctx context.Context, provider, resourceType string, properties map[string]string) string {
+	sku, region := resolveResourceSKUAndRegion(ctx, provider, resourceType, properties)
+	return sku + "|" + region
+}
+
+// traceCacheEvent records a cache hit or miss on the active span.
+func (c *cachingClient) traceCacheEvent(ctx context.Context, event, operation string) {
+	trace.SpanFromContext(ctx).AddEvent(event, trace.WithAttributes(
+		attribute.String(tracing.AttrPlugin, c.plugin),
+		attribute.String(tracing.AttrCacheOp, operation),
+	))
+}
+
+// cachedCall serves resp from the cache when possible, otherwise invokes call
+// and stores a cacheable result under the operation's TTL.
+func cachedCall[Resp any](
+	ctx context.Context,
+	c *cachingClient,
+	operation string,
+	req any,
+	resolved []string,
+	cacheable func(*Resp) bool,
+	call func() (*Resp, error),
+) (*Resp, error) {
+	log := logging.FromContext(ctx)
+	key, keyErr := RequestCacheKey(c.plugin, operation, req, resolved...)
+	if keyErr != nil {
+		log.Debug().Ctx(ctx).Str("component", "adapter").Err(keyErr).Msg("skipping response cache")
+		return call()
+	}
+
+	if entry, err := c.opts.Store.Get(key); err == nil {
+		var cached Resp
+		if unmarshalErr := json.Unmarshal(entry.Data, &cached); unmarshalErr == nil {
+			log.Debug().
+				Ctx(ctx).
+				Str("component", "adapter").
+				Str("plugin", c.plugin).
+				Str("operation", operation).
+				Str("cache_key", key).
+				Msg("cache hit")
+			c.traceCacheEvent(ctx, tracing.EventCacheHit, operation)
+			return &cached, nil
+		}
+	}
+	c.traceCacheEvent(ctx, tracing.EventCacheMiss, operation)
+
+	resp, err := call()
+	if err != nil || resp == nil || !cacheable(resp) {
+		return resp, err
+	}
+
+	data, marshalErr := json.Marshal(resp)
+	if marshalErr != nil {
+		return resp, nil
+	}
+	if setErr := c.opts.Store.SetWithTTL(key, data, c.opts.TTLSeconds[operation]); setErr != nil {
+		log.Warn().
+			Ctx(ctx).
+			Str("component", "adapter").
+			Str("operation", operation).
+			Err(setErr).
+			Msg("failed to store plugin response in cache")
+	}
+	return resp, nil
+}
+
+func (c *cachingClient) GetProjectedCost(
+	ctx context.Context,
+	in *GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*GetProjectedCostResponse, error) {
+	resolved := make([]string, 0, len(in.Resources))
+	for _, r := range in.Resources {
+		resolved = append(resolved, resolvedCacheKey(ctx, r.Provider, r.Type, r.Properties))
+	}
+	return cachedCall(ctx, c, CacheOpProjectedCost, in, resolved,
+		func(resp *GetProjectedCostResponse) bool {
+			for _, r := range resp.Results {
+				if r == nil || r.StructuredError != nil {
+					return false
+				}
+			}
+			return true
+		},
+		func() (*GetProjectedCostResponse, error) {
+			return c.CostSourceClient.GetProjectedCost(ctx, in, opts...)
+		})
+}
+
+func (c *cachingClient) GetActualCost(
+	ctx context.Context,
+	in *GetActualCostRequest,
+	opts ...grpc.CallOption,
+) (*GetActualCostResponse, error) {
+	var resolved []string
+	if in.Provider != "" {
+		resolved = []string{resolvedCacheKey(ctx, in.Provider, in.ResourceType, toStringMap(in.Properties))}
+	}
+	return cachedCall(ctx, c, CacheOpActualCost, in, resolved,
+		func(*GetActualCostResponse) bool { return true },
+		func() (*GetActualCostResponse, error) {
+			return c.CostSourceClient.GetActualCost(ctx, in, opts...)
+		})
+}
+
+func (c *cachingClient) GetRecommendations(
+	ctx context.Context,
+	in *GetRecommendationsRequest,
+	opts ...grpc.CallOption,
+) (*GetRecommendationsResponse, error) {
+	// Excluded IDs are a set; sort a copy so dismissal order does not change the key.
+	normalized := *in
+	normalized.ExcludedRecommendationIDs = slices.Sorted(slices.Values(in.ExcludedRecommendationIDs))
+	var resolved []string
+	for _, r := range in.TargetResources {
+		resolved = append(resolved, resolvedCacheKey(ctx, r.Provider, r.Type, r.Properties))
+	}
+	return cachedCall(ctx, c, CacheOpRecommendations, &normalized, resolved,
+		func(*GetRecommendationsResponse) bool { return true },
+		func() (*GetRecommendationsResponse, error) {
+			return c.CostSourceClient.GetRecommendations(ctx, in, opts...)
+		})
+}
@@ -0,0 +1,168 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	"github.com/rshade/finfocus/internal/engine/cache"
+	"github.com/rshade/finfocus/internal/skus"
+)
+
+func newTestCacheStore(t *testing.T) *cache.FileStore {
+	t.Helper()
+	store, err := cache.NewFileStore(t.TempDir(), true, cache.DefaultTTLSeconds, 0)
+	require.NoError(t, err)
+	return store
+}
+
+func TestCachingClient_GetProjectedCost(t *testing.T) {
+	calls := 0
+	mock := &mockCostSourceClient{
+		getProjectedFunc: func(
+			context.Context, *GetProjectedCostRequest, ...grpc.CallOption,
+		) (*GetProjectedCostResponse, error) {
+			calls++
+			return &GetProjectedCostResponse{Results: []*CostResult{{Currency: "USD", MonthlyCost: 7.3}}}, nil
+		},
+	}
+	store := newTestCacheStore(t)
+	client := NewCachingClient("aws-public@1.0.0", mock, CacheOptions{Store: store})
+
+	req := func(props map[string]string) *GetProjectedCostRequest {
+		return &GetProjectedCostRequest{Resources: []*ResourceDescriptor{
+			{ID: "web", Type: "aws:ec2/instance:Instance", Provider: "aws", Properties: props},
+		}}
+	}
+
+	first, err := client.GetProjectedCost(context.Background(), req(map[string]string{"a": "1", "b": "2"}))
+	require.NoError(t, err)
+	second, err := client.GetProjectedCost(context.Background(), req(map[string]string{"b": "2", "a": "1"}))
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls, "equal requests are served from cache")
+	assert.Equal(t, first, second)
+
+	_, err = client.GetProjectedCost(context.Background(), req(map[string]string{"a": "other"}))
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "different requests miss")
+
+	other := NewCachingClient("aws-public@1.1.0", mock, CacheOptions{Store: store})
+	_, err = other.GetProjectedCost(context.Background(), req(map[string]string{"a": "1", "b": "2"}))
+	require.NoError(t, err)
+	assert.Equal(t, 3, calls, "plugin version is part of the key")
+}
+
+func TestCachingClient_KeysByResolvedSKUAndRegion(t *testing.T) {
+	calls := 0
+	mock := &mockCostSourceClient{
+		getProjectedFunc: func(
+			context.Context, *GetProjectedCostRequest, ...grpc.CallOption,
+		) (*GetProjectedCostResponse, error) {
+			calls++
+			return &GetProjectedCostResponse{Results: []*CostResult{{Currency: "USD", MonthlyCost: 7.3}}}, nil
+		},
+	}
+	client := NewCachingClient("aws-public@1.0.0", mock, CacheOptions{Store: newTestCacheStore(t)})
+	req := &GetProjectedCostRequest{Resources: []*ResourceDescriptor{{
+		ID:       "web",
+		Type:     "aws:ec2/instance:Instance",
+		Provider: "aws",
+		Properties: map[string]string{
+			"instanceType": "t3.medium", "region": "us-east-1", "reservedType": "t3.large",
+		},
+	}}}
+
+	_, err := client.GetProjectedCost(context.Background(), req)
+	require.NoError(t, err)
+	_, err = client.GetProjectedCost(context.Background(), req)
+	require.NoError(t, err)
+	assert.Equal(t, 1, calls)
+
+	ctx := skus.NewContext(context.Background(), skus.Rules{
+		{Provider: "aws", Type: "aws:ec2/instance:Instance", SKU: "${reservedType}"},
+	})
+	_, err = client.GetProjectedCost(ctx, req)
+	require.NoError(t, err)
+	assert.Equal(t, 2, calls, "a mapping that changes the SKU misses")
+
+	ctx = ContextWithDefaultRegions(ctx, map[string]string{"aws": "eu-west-1"})
+	req.Resources[0].Properties = map[string]string{"instanceType": "t3.large"}
+	_, err = client.GetProjectedCost(ctx, req)
+	require.NoError(t, err)
+	_, err = client.GetProjectedCost(ContextWithDefaultRegions(ctx, map[string]string{"aws": "us-west-2"}), req)
+	require.NoError(t, err)
+	assert.Equal(t, 4, calls, "a default region that changes the region misses")
+}
+
+func TestCachingClient_SkipsErrors(t *testing.T) {
+	calls := 0
+	mock := &mockCostSourceClient{
+		getProjectedFunc: func(
+			context.Context, *GetProjectedCostRequest, ...grpc.CallOption,
+		) (*GetProjectedCostResponse, error) {
+			calls++
+			return &GetProjectedCostResponse{Results: []*CostResult{
+				{StructuredError: &StructuredError{Code: ErrCodePluginError, Message: "throttled"}},
+			}}, nil
+		},
+		getActualFunc: func(context.Context, *GetActualCostRequest, ...grpc.CallOption) (*GetActualCostResponse, error) {
+			calls++
+			return nil, errors.New("unavailable")
+		},
+	}
+	client := NewCachingClient("p", mock, CacheOptions{Store: newTestCacheStore(t)})
+
+	for range 2 {
+		_, _ = client.GetProjectedCost(context.Background(), &GetProjectedCostRequest{})
+		_, _ = client.GetActualCost(context.Background(), &GetActualCostRequest{ResourceIDs: []string{"x"}})
+	}
+	assert.Equal(t, 4, calls)
+}
+
+func TestCachingClient_RecommendationsAndTTL(t *testing.T) {
+	var seen []string
+	mock := &mockCostSourceClient{
+		getRecommendationsFunc: func(
+			_ context.Context, in *GetRecommendationsRequest, _ ...grpc.CallOption,
+		) (*GetRecommendationsResponse, error) {
+			seen = in.ExcludedRecommendationIDs
+			return &GetRecommendationsResponse{Recommendations: []*Recommendation{{ID: "rec-1"}}}, nil
+		},
+	}
+	store := newTestCacheStore(t)
+	client := NewCachingClient("p", mock, CacheOptions{
+		Store:      store,
+		TTLSeconds: map[string]int{CacheOpRecommendations: 120},
+	})
+
+	_, err := client.GetRecommendations(context.Background(),
+		&GetRecommendationsRequest{ExcludedRecommendationIDs: []string{"b", "a"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, seen, "the plugin receives the original request")
+
+	seen = nil
+	resp, err := client.GetRecommendations(context.Background(),
+		&GetRecommendationsRequest{ExcludedRecommendationIDs: []string{"a", "b"}})
+	require.NoError(t, err)
+	assert.Nil(t, seen, "exclusion order does not affect the key")
+	require.Len(t, resp.Recommendations, 1)
+
+	key, err := RequestCacheKey("p", CacheOpRecommendations,
+		&GetRecommendationsRequest{ExcludedRecommendationIDs: []string{"a", "b"}})
+	require.NoError(t, err)
+	entry, err := store.Get(key)
+	require.NoError(t, err)
+	assert.Equal(t, 120, entry.TTLSeconds)
+}
+
+func TestNewCachingClient_DisabledStore(t *testing.T) {
+	mock := &mockCostSourceClient{}
+	disabled, err := cache.NewFileStore("", false, 0, 0)
+	require.NoError(t, err)
+	assert.Same(t, mock, NewCachingClient("p", mock, CacheOptions{Store: disabled}))
+	assert.Same(t, mock, NewCachingClient("p", mock, CacheOptions{}))
+}
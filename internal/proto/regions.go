@@ -0,0 +1,68 @@
+package proto
+
+import (
+	"context"
+	"os"
+	"strings"
+)
+
+const (
+	// azureProvider and gcpProvider are the provider families whose native
+	// providers (azure-native, google-native) share their region fallbacks.
+	azureProvider = "azure"
+	gcpProvider   = "gcp"
+)
+
+// defaultRegionsKey is the context key of the configured default regions.
+type defaultRegionsKey struct{}
+
+// ContextWithDefaultRegions returns a copy of ctx that carries the default
+// region of each provider, keyed by lowercase provider name, for resources
+// whose properties and environment name no region.
+func ContextWithDefaultRegions(ctx context.Context, regions map[string]string) context.Context {
+	return context.WithValue(ctx, defaultRegionsKey{}, regions)
+}
+
+// defaultRegion returns the default region that ctx carries for provider,
+// falling back to the default of its provider family, or "" if there is none.
+func defaultRegion(ctx context.Context, provider string) string {
+	regions, _ := ctx.Value(defaultRegionsKey{}).(map[string]string)
+	if region := regions[strings.ToLower(provider)]; region != "" {
+		return region
+	}
+	return regions[providerFamily(provider)]
+}
+
+// envRegion returns the region that the environment variables of provider's
+// CLI and SDKs name, or "" if they name none. Each provider only honors its
+// own variables, so an Azure resource never inherits AWS_REGION.
+func envRegion(provider string) string {
+	var names []string
+	switch providerFamily(provider) {
+	case awsProvider:
+		names = []string{"AWS_REGION", "AWS_DEFAULT_REGION"}
+	case azureProvider:
+		names = []string{"ARM_LOCATION", "AZURE_DEFAULTS_LOCATION"}
+	case gcpProvider:
+		names = []string{"CLOUDSDK_COMPUTE_REGION", "GOOGLE_REGION"}
+	}
+	for _, name := range names {
+		if region := os.Getenv(name); region != "" {
+			return region
+		}
+	}
+	return ""
+}
+
+// providerFamily returns the lowercase provider, with the azure-native and
+// google-native providers folded into azure and gcp.
+func providerFamily(provider string) string {
+	switch provider = strings.ToLower(provider); provider {
+	case "azure-native":
+		return azureProvider
+	case "google-native":
+		return gcpProvider
+	default:
+		return provider
+	}
+}
@@ -0,0 +1,70 @@
+package proto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveResourceSKUAndRegion_DefaultRegions(t *testing.T) {
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	t.Setenv("ARM_LOCATION", "")
+	t.Setenv("AZURE_DEFAULTS_LOCATION", "")
+	ctx := ContextWithDefaultRegions(context.Background(), map[string]string{
+		"aws":   "us-east-2",
+		"azure": "westeurope",
+	})
+
+	tests := []struct {
+		name       string
+		provider   string
+		properties map[string]string
+		envVars    map[string]string
+		expected   string
+	}{
+		{
+			name:       "configured default for the provider",
+			provider:   "aws",
+			properties: map[string]string{"instanceType": "t3.micro"},
+			expected:   "us-east-2",
+		},
+		{
+			name:       "azure-native falls back to the azure default",
+			provider:   "azure-native",
+			properties: map[string]string{"vmSize": "Standard_B1s"},
+			expected:   "westeurope",
+		},
+		{
+			name:       "property takes precedence over the default",
+			provider:   "aws",
+			properties: map[string]string{"instanceType": "t3.micro", "region": "eu-west-2"},
+			expected:   "eu-west-2",
+		},
+		{
+			name:       "environment takes precedence over the default",
+			provider:   "azure",
+			properties: map[string]string{"vmSize": "Standard_B1s"},
+			envVars:    map[string]string{"ARM_LOCATION": "northeurope"},
+			expected:   "northeurope",
+		},
+		{
+			name:       "no default for other providers",
+			provider:   "gcp",
+			properties: map[string]string{"machineType": "n1-standard-1"},
+			expected:   "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for key, val := range tt.envVars {
+				t.Setenv(key, val)
+			}
+
+			_, region := resolveResourceSKUAndRegion(ctx, tt.provider, "", tt.properties)
+			assert.Equal(t, tt.expected, region)
+		})
+	}
+}
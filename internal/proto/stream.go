@@ -0,0 +1,156 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// StreamRecommendationsMethod is the server-streaming variant of
+// GetRecommendations. It takes a GetRecommendationsRequest and streams
+// GetRecommendationsResponse pages, so plugins with thousands of
+// recommendations can deliver them without building a single response.
+// Plugins that do not register it answer Unimplemented and the client falls
+// back to paging through the unary RPC.
+const StreamRecommendationsMethod = "/finfocus.v1.CostSourceService/StreamRecommendations"
+
+// maxRecommendationPages bounds unary paging so that a plugin returning the
+// same next page token forever cannot hang the caller.
+const maxRecommendationPages = 1000
+
+var streamRecommendationsDesc = grpc.StreamDesc{
+	StreamName:    "StreamRecommendations",
+	ServerStreams: true,
+}
+
+// RecommendationPageFunc receives one page of recommendations. Returning an
+// error stops the stream and is returned to the caller.
+type RecommendationPageFunc func(page *GetRecommendationsResponse) error
+
+// StreamingClient is implemented by clients that can deliver recommendations
+// incrementally. Implementations fall back to unary paging when the plugin
+// does not support streaming.
+type StreamingClient interface {
+	StreamRecommendations(
+		ctx context.Context,
+		in *GetRecommendationsRequest,
+		fn RecommendationPageFunc,
+		opts ...grpc.CallOption,
+	) error
+}
+
+// StreamRecommendations delivers every recommendation page for in to fn. It
+// streams when api implements StreamingClient and otherwise follows
+// NextPageToken through GetRecommendations, so decorators that only implement
+// CostSourceClient (such as the response cache) keep working.
+func StreamRecommendations(
+	ctx context.Context,
+	api CostSourceClient,
+	in *GetRecommendationsRequest,
+	fn RecommendationPageFunc,
+	opts ...grpc.CallOption,
+) error {
+	if streamer, ok := api.(StreamingClient); ok {
+		return streamer.StreamRecommendations(ctx, in, fn, opts...)
+	}
+	return PageRecommendations(ctx, api, in, fn, opts...)
+}
+
+// PageRecommendations calls GetRecommendations until the plugin stops
+// returning a next page token, delivering each page to fn.
+func PageRecommendations(
+	ctx context.Context,
+	api CostSourceClient,
+	in *GetRecommendationsRequest,
+	fn RecommendationPageFunc,
+	opts ...grpc.CallOption,
+) error {
+	req := *in
+	seen := make(map[string]bool)
+	for range maxRecommendationPages {
+		resp, err := api.GetRecommendations(ctx, &req, opts...)
+		if err != nil {
+			return err
+		}
+		if fnErr := fn(resp); fnErr != nil {
+			return fnErr
+		}
+		if resp.NextPageToken == "" || seen[resp.NextPageToken] {
+			return nil
+		}
+		seen[resp.NextPageToken] = true
+		req.PageToken = resp.NextPageToken
+	}
+	return fmt.Errorf("recommendations exceeded %d pages", maxRecommendationPages)
+}
+
+// StreamRecommendations implements StreamingClient. It opens the
+// StreamRecommendationsMethod server stream and falls back to unary paging
+// when the plugin answers Unimplemented before sending any page.
+func (c *clientAdapter) StreamRecommendations(
+	ctx context.Context,
+	in *GetRecommendationsRequest,
+	fn RecommendationPageFunc,
+	opts ...grpc.CallOption,
+) error {
+	if c.conn == nil || c.streamUnsupported.Load() {
+		return PageRecommendations(ctx, c, in, fn, opts...)
+	}
+
+	pages, err := c.streamRecommendations(ctx, in, fn, opts...)
+	if pages == 0 && status.Code(err) == codes.Unimplemented {
+		c.streamUnsupported.Store(true)
+		logging.FromContext(ctx).Debug().
+			Ctx(ctx).
+			Str("component", "adapter").
+			Msg("plugin does not stream recommendations, falling back to unary paging")
+		return PageRecommendations(ctx, c, in, fn, opts...)
+	}
+	return err
+}
+
+// streamRecommendations reads the server stream and returns the number of
+// pages delivered to fn.
+func (c *clientAdapter) streamRecommendations(
+	ctx context.Context,
+	in *GetRecommendationsRequest,
+	fn RecommendationPageFunc,
+	opts ...grpc.CallOption,
+) (int, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	stream, err := c.conn.NewStream(ctx, &streamRecommendationsDesc, StreamRecommendationsMethod, opts...)
+	if err != nil {
+		return 0, err
+	}
+	if sendErr := stream.SendMsg(toPbcRecommendationsRequest(ctx, in)); sendErr != nil && !errors.Is(sendErr, io.EOF) {
+		return 0, sendErr
+	}
+	if closeErr := stream.CloseSend(); closeErr != nil {
+		return 0, closeErr
+	}
+
+	pages := 0
+	for {
+		resp := new(pbc.GetRecommendationsResponse)
+		if recvErr := stream.RecvMsg(resp); recvErr != nil {
+			if errors.Is(recvErr, io.EOF) {
+				return pages, nil
+			}
+			return pages, recvErr
+		}
+		pages++
+		if fnErr := fn(fromPbcRecommendationsResponse(resp)); fnErr != nil {
+			return pages, fnErr
+		}
+	}
+}
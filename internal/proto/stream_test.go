@@ -0,0 +1,137 @@
+package proto
+
+import (
+	"context"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	gproto "google.golang.org/protobuf/proto"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+)
+
+// fakeStreamConn serves StreamRecommendationsMethod from a fixed list of
+// pages, or fails the stream with err.
+type fakeStreamConn struct {
+	grpc.ClientConnInterface
+
+	pages   []*pbc.GetRecommendationsResponse
+	err     error
+	streams int
+	method  string
+	sent    *pbc.GetRecommendationsRequest
+}
+
+func (c *fakeStreamConn) NewStream(
+	_ context.Context, _ *grpc.StreamDesc, method string, _ ...grpc.CallOption,
+) (grpc.ClientStream, error) {
+	c.streams++
+	c.method = method
+	return &fakeClientStream{conn: c}, nil
+}
+
+type fakeClientStream struct {
+	conn *fakeStreamConn
+	next int
+}
+
+func (s *fakeClientStream) Header() (metadata.MD, error) { return nil, nil }
+func (s *fakeClientStream) Trailer() metadata.MD         { return nil }
+func (s *fakeClientStream) CloseSend() error             { return nil }
+func (s *fakeClientStream) Context() context.Context     { return context.Background() }
+
+func (s *fakeClientStream) SendMsg(m any) error {
+	s.conn.sent, _ = m.(*pbc.GetRecommendationsRequest)
+	return nil
+}
+
+func (s *fakeClientStream) RecvMsg(m any) error {
+	if s.conn.err != nil {
+		return s.conn.err
+	}
+	if s.next >= len(s.conn.pages) {
+		return io.EOF
+	}
+	gproto.Merge(m.(*pbc.GetRecommendationsResponse), s.conn.pages[s.next])
+	s.next++
+	return nil
+}
+
+func collectPages(t *testing.T, stream func(RecommendationPageFunc) error) []string {
+	t.Helper()
+	var ids []string
+	require.NoError(t, stream(func(page *GetRecommendationsResponse) error {
+		for _, rec := range page.Recommendations {
+			ids = append(ids, rec.ID)
+		}
+		return nil
+	}))
+	return ids
+}
+
+func TestClientAdapter_StreamRecommendations(t *testing.T) {
+	conn := &fakeStreamConn{pages: []*pbc.GetRecommendationsResponse{
+		{Recommendations: []*pbc.Recommendation{{Id: "rec-1"}, {Id: "rec-2"}}},
+		{Recommendations: []*pbc.Recommendation{{Id: "rec-3"}}},
+	}}
+	adapter := &clientAdapter{client: &mockPbcCostSourceServiceClient{}, conn: conn}
+
+	ids := collectPages(t, func(fn RecommendationPageFunc) error {
+		return adapter.StreamRecommendations(context.Background(),
+			&GetRecommendationsRequest{ProjectionPeriod: "monthly"}, fn)
+	})
+	assert.Equal(t, []string{"rec-1", "rec-2", "rec-3"}, ids)
+	assert.Equal(t, StreamRecommendationsMethod, conn.method)
+	assert.Equal(t, "monthly", conn.sent.GetProjectionPeriod())
+}
+
+func TestClientAdapter_StreamRecommendations_FallsBackToUnaryPaging(t *testing.T) {
+	var tokens []string
+	mock := &mockPbcCostSourceServiceClient{
+		getRecommendationsFunc: func(
+			_ context.Context, in *pbc.GetRecommendationsRequest, _ ...grpc.CallOption,
+		) (*pbc.GetRecommendationsResponse, error) {
+			tokens = append(tokens, in.GetPageToken())
+			if in.GetPageToken() == "" {
+				return &pbc.GetRecommendationsResponse{
+					Recommendations: []*pbc.Recommendation{{Id: "rec-1"}},
+					NextPageToken:   "page-2",
+				}, nil
+			}
+			return &pbc.GetRecommendationsResponse{Recommendations: []*pbc.Recommendation{{Id: "rec-2"}}}, nil
+		},
+	}
+	conn := &fakeStreamConn{err: status.Error(codes.Unimplemented, "unknown method")}
+	adapter := &clientAdapter{client: mock, conn: conn}
+
+	stream := func(fn RecommendationPageFunc) error {
+		return adapter.StreamRecommendations(context.Background(), &GetRecommendationsRequest{}, fn)
+	}
+	assert.Equal(t, []string{"rec-1", "rec-2"}, collectPages(t, stream))
+	assert.Equal(t, []string{"", "page-2"}, tokens)
+
+	collectPages(t, stream)
+	assert.Equal(t, 1, conn.streams, "an Unimplemented stream is not retried")
+}
+
+func TestStreamRecommendations_PagesNonStreamingClients(t *testing.T) {
+	mock := &mockCostSourceClient{
+		getRecommendationsFunc: func(
+			context.Context, *GetRecommendationsRequest, ...grpc.CallOption,
+		) (*GetRecommendationsResponse, error) {
+			// A misbehaving plugin that always points at the same page.
+			return &GetRecommendationsResponse{Recommendations: []*Recommendation{{ID: "rec"}}, NextPageToken: "same"}, nil
+		},
+	}
+
+	ids := collectPages(t, func(fn RecommendationPageFunc) error {
+		return StreamRecommendations(context.Background(), mock, &GetRecommendationsRequest{}, fn)
+	})
+	assert.Equal(t, []string{"rec", "rec"}, ids, "a repeated page token ends paging")
+}
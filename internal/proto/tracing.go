@@ -0,0 +1,139 @@
+package proto
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+// tracingClient records an OpenTelemetry span around every plugin RPC.
+type tracingClient struct {
+	api    CostSourceClient
+	plugin string
+}
+
+// NewTracingClient wraps api so that each RPC runs in a "plugin.<Method>"
+// span carrying the plugin name and, for the cost RPCs, the number of
+// resources requested. Spans are no-ops until tracing.Setup installs an
+// exporter.
+func NewTracingClient(plugin string, api CostSourceClient) CostSourceClient {
+	return &tracingClient{api: api, plugin: plugin}
+}
+
+// traced runs call inside a span for method and records its error.
+func traced[Resp any](
+	ctx context.Context,
+	c *tracingClient,
+	method string,
+	resources int,
+	call func(context.Context) (Resp, error),
+) (Resp, error) {
+	attrs := []attribute.KeyValue{
+		attribute.String(tracing.AttrPlugin, c.plugin),
+		attribute.String("rpc.system", "grpc"),
+		attribute.String("rpc.method", method),
+	}
+	if resources >= 0 {
+		attrs = append(attrs, attribute.Int(tracing.AttrResources, resources))
+	}
+	ctx, span := tracing.Tracer().Start(ctx, "plugin."+method,
+		trace.WithSpanKind(trace.SpanKindClient), trace.WithAttributes(attrs...))
+	resp, err := call(ctx)
+	tracing.End(span, err)
+	return resp, err
+}
+
+func (c *tracingClient) Name(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NameResponse, error) {
+	return traced(ctx, c, "Name", -1, func(ctx context.Context) (*NameResponse, error) {
+		return c.api.Name(ctx, in, opts...)
+	})
+}
+
+func (c *tracingClient) GetPluginInfo(
+	ctx context.Context,
+	in *Empty,
+	opts ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return traced(ctx, c, "GetPluginInfo", -1, func(ctx context.Context) (*pbc.GetPluginInfoResponse, error) {
+		return c.api.GetPluginInfo(ctx, in, opts...)
+	})
+}
+
+func (c *tracingClient) GetProjectedCost(
+	ctx context.Context,
+	in *GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*GetProjectedCostResponse, error) {
+	return traced(ctx, c, "GetProjectedCost", len(in.Resources),
+		func(ctx context.Context) (*GetProjectedCostResponse, error) {
+			return c.api.GetProjectedCost(ctx, in, opts...)
+		})
+}
+
+func (c *tracingClient) GetActualCost(
+	ctx context.Context,
+	in *GetActualCostRequest,
+	opts ...grpc.CallOption,
+) (*GetActualCostResponse, error) {
+	return traced(ctx, c, "GetActualCost", len(in.ResourceIDs),
+		func(ctx context.Context) (*GetActualCostResponse, error) {
+			return c.api.GetActualCost(ctx, in, opts...)
+		})
+}
+
+func (c *tracingClient) GetRecommendations(
+	ctx context.Context,
+	in *GetRecommendationsRequest,
+	opts ...grpc.CallOption,
+) (*GetRecommendationsResponse, error) {
+	return traced(ctx, c, "GetRecommendations", len(in.TargetResources),
+		func(ctx context.Context) (*GetRecommendationsResponse, error) {
+			return c.api.GetRecommendations(ctx, in, opts...)
+		})
+}
+
+func (c *tracingClient) GetBudgets(
+	ctx context.Context,
+	in *pbc.GetBudgetsRequest,
+	opts ...grpc.CallOption,
+) (*pbc.GetBudgetsResponse, error) {
+	return traced(ctx, c, "GetBudgets", -1, func(ctx context.Context) (*pbc.GetBudgetsResponse, error) {
+		return c.api.GetBudgets(ctx, in, opts...)
+	})
+}
+
+func (c *tracingClient) DryRun(
+	ctx context.Context,
+	in *pbc.DryRunRequest,
+	opts ...grpc.CallOption,
+) (*pbc.DryRunResponse, error) {
+	return traced(ctx, c, "DryRun", -1, func(ctx context.Context) (*pbc.DryRunResponse, error) {
+		return c.api.DryRun(ctx, in, opts...)
+	})
+}
+
+func (c *tracingClient) DismissRecommendation(
+	ctx context.Context,
+	in *DismissRecommendationRequest,
+	opts ...grpc.CallOption,
+) (*DismissRecommendationResponse, error) {
+	return traced(ctx, c, "DismissRecommendation", -1,
+		func(ctx context.Context) (*DismissRecommendationResponse, error) {
+			return c.api.DismissRecommendation(ctx, in, opts...)
+		})
+}
+
+func (c *tracingClient) EstimateCost(
+	ctx context.Context,
+	in *EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*EstimateCostResponse, error) {
+	return traced(ctx, c, "EstimateCost", 1, func(ctx context.Context) (*EstimateCostResponse, error) {
+		return c.api.EstimateCost(ctx, in, opts...)
+	})
+}
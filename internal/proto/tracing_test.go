@@ -0,0 +1,106 @@
+package proto
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace/noop"
+	"google.golang.org/grpc"
+
+	"github.com/rshade/finfocus/internal/tracing"
+)
+
+// useSpanRecorder installs a global tracer provider that records ended spans.
+func useSpanRecorder(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	rec := tracetest.NewSpanRecorder()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)))
+	t.Cleanup(func() { otel.SetTracerProvider(noop.NewTracerProvider()) })
+	return rec
+}
+
+func spanAttrs(span sdktrace.ReadOnlySpan) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes() {
+		attrs[kv.Key] = kv.Value
+	}
+	return attrs
+}
+
+func TestTracingClient_Spans(t *testing.T) {
+	rec := useSpanRecorder(t)
+	mock := &mockCostSourceClient{
+		getProjectedFunc: func(
+			context.Context, *GetProjectedCostRequest, ...grpc.CallOption,
+		) (*GetProjectedCostResponse, error) {
+			return &GetProjectedCostResponse{}, nil
+		},
+		getActualFunc: func(
+			context.Context, *GetActualCostRequest, ...grpc.CallOption,
+		) (*GetActualCostResponse, error) {
+			return nil, errors.New("deadline exceeded")
+		},
+	}
+	client := NewTracingClient("aws-public", mock)
+
+	_, err := client.GetProjectedCost(context.Background(), &GetProjectedCostRequest{
+		Resources: []*ResourceDescriptor{{ID: "a"}, {ID: "b"}},
+	})
+	require.NoError(t, err)
+	_, err = client.GetActualCost(context.Background(), &GetActualCostRequest{ResourceIDs: []string{"a"}})
+	require.Error(t, err)
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+
+	assert.Equal(t, "plugin.GetProjectedCost", spans[0].Name())
+	attrs := spanAttrs(spans[0])
+	assert.Equal(t, "aws-public", attrs[tracing.AttrPlugin].AsString())
+	assert.Equal(t, "GetProjectedCost", attrs["rpc.method"].AsString())
+	assert.Equal(t, int64(2), attrs[tracing.AttrResources].AsInt64())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+
+	assert.Equal(t, "plugin.GetActualCost", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "deadline exceeded", spans[1].Status().Description)
+}
+
+func TestCachingClient_TracesHitsAndMisses(t *testing.T) {
+	rec := useSpanRecorder(t)
+	mock := &mockCostSourceClient{
+		getProjectedFunc: func(
+			context.Context, *GetProjectedCostRequest, ...grpc.CallOption,
+		) (*GetProjectedCostResponse, error) {
+			return &GetProjectedCostResponse{Results: []*CostResult{{Currency: "USD", MonthlyCost: 1}}}, nil
+		},
+	}
+	client := NewCachingClient("aws-public@1.0.0", NewTracingClient("aws-public", mock),
+		CacheOptions{Store: newTestCacheStore(t)})
+	req := &GetProjectedCostRequest{Resources: []*ResourceDescriptor{{ID: "web", Type: "aws:ec2/instance:Instance"}}}
+
+	ctx, span := tracing.Tracer().Start(context.Background(), "resource")
+	_, err := client.GetProjectedCost(ctx, req)
+	require.NoError(t, err)
+	_, err = client.GetProjectedCost(ctx, req)
+	require.NoError(t, err)
+	span.End()
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2, "only the miss reaches the plugin")
+	assert.Equal(t, "plugin.GetProjectedCost", spans[0].Name())
+	assert.Equal(t, spans[1].SpanContext().SpanID(), spans[0].Parent().SpanID())
+
+	events := spans[1].Events()
+	require.Len(t, events, 2)
+	assert.Equal(t, tracing.EventCacheMiss, events[0].Name)
+	assert.Equal(t, tracing.EventCacheHit, events[1].Name)
+	assert.Contains(t, events[1].Attributes, attribute.String(tracing.AttrCacheOp, CacheOpProjectedCost))
+}
@@ -0,0 +1,451 @@
+package proto
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	gproto "google.golang.org/protobuf/proto"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Operation names for the RPCs that only appear in recorded traffic. The cost
+// RPCs reuse the CacheOp constants.
+const (
+	trafficOpName       = "name"
+	trafficOpPluginInfo = "plugin_info"
+	trafficOpBudgets    = "budgets"
+	trafficOpDryRun     = "dry_run"
+	trafficOpDismissal  = "dismiss_recommendation"
+	trafficOpEstimate   = "estimate_cost"
+)
+
+const (
+	trafficManifestFile   = "plugin.json"
+	trafficDirPermissions = 0o750
+	trafficFilePermission = 0o600
+)
+
+// ErrNoRecording is returned during replay when the recording holds no
+// response for a request.
+var ErrNoRecording = errors.New("no recorded plugin response")
+
+// RecordedPlugin is a plugin reconstructed from a traffic recording.
+type RecordedPlugin struct {
+	Name     string
+	Metadata *PluginMetadata
+	API      CostSourceClient
+}
+
+// trafficManifest identifies the plugin a recording directory belongs to.
+type trafficManifest struct {
+	Name     string          `json:"name"`
+	Metadata *PluginMetadata `json:"metadata,omitempty"`
+}
+
+// trafficEntry is one recorded request and its outcome.
+type trafficEntry struct {
+	Plugin    string          `json:"plugin"`
+	Operation string          `json:"operation"`
+	Request   json.RawMessage `json:"request"`
+	Response  json.RawMessage `json:"response,omitempty"`
+	Error     *trafficError   `json:"error,omitempty"`
+}
+
+// trafficError preserves the gRPC status of a failed call so replay returns
+// the same error.
+type trafficError struct {
+	Code    codes.Code `json:"code"`
+	Message string     `json:"message"`
+}
+
+// recordingClient writes every request and response that passes through it
+// to dir/<plugin>/<operation>/<request hash>.json.
+type recordingClient struct {
+	api    CostSourceClient
+	plugin string
+	dir    string
+}
+
+// NewRecordingClient wraps api so that every RPC and its response or error is
+// written under dir for later replay with LoadRecordedPlugins. metadata is
+// stored alongside the traffic so replayed plugins route like the original.
+func NewRecordingClient(
+	plugin string,
+	metadata *PluginMetadata,
+	api CostSourceClient,
+	dir string,
+) (CostSourceClient, error) {
+	pluginDir := filepath.Join(dir, filepath.Base(plugin))
+	if err := os.MkdirAll(pluginDir, trafficDirPermissions); err != nil {
+		return nil, fmt.Errorf("creating traffic directory: %w", err)
+	}
+	data, err := json.MarshalIndent(trafficManifest{Name: plugin, Metadata: metadata}, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("encoding traffic manifest: %w", err)
+	}
+	if writeErr := writeFileAtomic(filepath.Join(pluginDir, trafficManifestFile), data); writeErr != nil {
+		return nil, fmt.Errorf("writing traffic manifest: %w", writeErr)
+	}
+	return &recordingClient{api: api, plugin: plugin, dir: pluginDir}, nil
+}
+
+// LoadRecordedPlugins returns a replaying client for every plugin recorded
+// under dir. Replayed clients never contact a plugin: each request is answered
+// with the recorded response or error, or ErrNoRecording when it was not seen
+// during recording.
+func LoadRecordedPlugins(dir string) ([]RecordedPlugin, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("reading traffic recording: %w", err)
+	}
+
+	var plugins []RecordedPlugin
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		pluginDir := filepath.Join(dir, entry.Name())
+		data, readErr := os.ReadFile(filepath.Join(pluginDir, trafficManifestFile))
+		if errors.Is(readErr, os.ErrNotExist) {
+			continue
+		}
+		if readErr != nil {
+			return nil, fmt.Errorf("reading traffic manifest: %w", readErr)
+		}
+		var manifest trafficManifest
+		if unmarshalErr := json.Unmarshal(data, &manifest); unmarshalErr != nil {
+			return nil, fmt.Errorf("parsing traffic manifest %s: %w", pluginDir, unmarshalErr)
+		}
+		plugins = append(plugins, RecordedPlugin{
+			Name:     manifest.Name,
+			Metadata: manifest.Metadata,
+			API:      &replayClient{plugin: manifest.Name, dir: pluginDir},
+		})
+	}
+	if len(plugins) == 0 {
+		return nil, fmt.Errorf("no recorded plugins found in %s", dir)
+	}
+	return plugins, nil
+}
+
+// trafficKey hashes a request so that equal requests map to the same
+// recording. Proto messages use deterministic binary encoding; internal
+// request types use encoding/json, which sorts map keys.
+func trafficKey(operation string, req any) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+	switch r := req.(type) {
+	case gproto.Message:
+		data, err = gproto.MarshalOptions{Deterministic: true}.Marshal(r)
+	case *GetRecommendationsRequest:
+		// Excluded IDs are a set; sort a copy so dismissal order does not change the key.
+		normalized := *r
+		normalized.ExcludedRecommendationIDs = slices.Sorted(slices.Values(r.ExcludedRecommendationIDs))
+		data, err = json.Marshal(&normalized)
+	default:
+		data, err = json.Marshal(req)
+	}
+	if err != nil {
+		return "", fmt.Errorf("encoding %s request: %w", operation, err)
+	}
+	sum := sha256.Sum256(append([]byte(operation+"\x00"), data...))
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// encodeTraffic encodes proto messages with protojson and everything else
+// with encoding/json.
+func encodeTraffic(v any) (json.RawMessage, error) {
+	if m, ok := v.(gproto.Message); ok {
+		return protojson.Marshal(m)
+	}
+	return json.Marshal(v)
+}
+
+// decodeTraffic is the inverse of encodeTraffic.
+func decodeTraffic(data []byte, v any) error {
+	if m, ok := v.(gproto.Message); ok {
+		return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, m)
+	}
+	return json.Unmarshal(data, v)
+}
+
+func trafficPath(pluginDir, operation, key string) string {
+	return filepath.Join(pluginDir, operation, key+".json")
+}
+
+// writeFileAtomic writes data through a temporary file so that concurrent
+// writers of the same recording never leave a partial file behind.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".traffic-*")
+	if err != nil {
+		return err
+	}
+	if _, writeErr := tmp.Write(data); writeErr != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return writeErr
+	}
+	if closeErr := tmp.Close(); closeErr != nil {
+		_ = os.Remove(tmp.Name())
+		return closeErr
+	}
+	if chmodErr := os.Chmod(tmp.Name(), trafficFilePermission); chmodErr != nil {
+		_ = os.Remove(tmp.Name())
+		return chmodErr
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+func (c *recordingClient) record(operation string, req, resp any, callErr error) error {
+	key, err := trafficKey(operation, req)
+	if err != nil {
+		return err
+	}
+	entry := trafficEntry{Plugin: c.plugin, Operation: operation}
+	if entry.Request, err = encodeTraffic(req); err != nil {
+		return fmt.Errorf("encoding %s request: %w", operation, err)
+	}
+	if callErr != nil {
+		st := status.Convert(callErr)
+		entry.Error = &trafficError{Code: st.Code(), Message: st.Message()}
+	} else if entry.Response, err = encodeTraffic(resp); err != nil {
+		return fmt.Errorf("encoding %s response: %w", operation, err)
+	}
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding %s recording: %w", operation, err)
+	}
+	path := trafficPath(c.dir, operation, key)
+	if mkdirErr := os.MkdirAll(filepath.Dir(path), trafficDirPermissions); mkdirErr != nil {
+		return mkdirErr
+	}
+	return writeFileAtomic(path, data)
+}
+
+// recorded runs call and records its outcome. Recording failures are logged
+// and never change the result the caller sees.
+func recorded[Resp any](
+	ctx context.Context,
+	c *recordingClient,
+	operation string,
+	req any,
+	call func() (Resp, error),
+) (Resp, error) {
+	resp, err := call()
+	if recErr := c.record(operation, req, resp, err); recErr != nil {
+		logging.FromContext(ctx).Warn().
+			Ctx(ctx).
+			Str("component", "adapter").
+			Str("plugin", c.plugin).
+			Str("operation", operation).
+			Err(recErr).
+			Msg("failed to record plugin traffic")
+	}
+	return resp, err
+}
+
+func (c *recordingClient) Name(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*NameResponse, error) {
+	return recorded(ctx, c, trafficOpName, in, func() (*NameResponse, error) {
+		return c.api.Name(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) GetPluginInfo(
+	ctx context.Context,
+	in *Empty,
+	opts ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return recorded(ctx, c, trafficOpPluginInfo, in, func() (*pbc.GetPluginInfoResponse, error) {
+		return c.api.GetPluginInfo(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) GetProjectedCost(
+	ctx context.Context,
+	in *GetProjectedCostRequest,
+	opts ...grpc.CallOption,
+) (*GetProjectedCostResponse, error) {
+	return recorded(ctx, c, CacheOpProjectedCost, in, func() (*GetProjectedCostResponse, error) {
+		return c.api.GetProjectedCost(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) GetActualCost(
+	ctx context.Context,
+	in *GetActualCostRequest,
+	opts ...grpc.CallOption,
+) (*GetActualCostResponse, error) {
+	return recorded(ctx, c, CacheOpActualCost, in, func() (*GetActualCostResponse, error) {
+		return c.api.GetActualCost(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) GetRecommendations(
+	ctx context.Context,
+	in *GetRecommendationsRequest,
+	opts ...grpc.CallOption,
+) (*GetRecommendationsResponse, error) {
+	return recorded(ctx, c, CacheOpRecommendations, in, func() (*GetRecommendationsResponse, error) {
+		return c.api.GetRecommendations(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) GetBudgets(
+	ctx context.Context,
+	in *pbc.GetBudgetsRequest,
+	opts ...grpc.CallOption,
+) (*pbc.GetBudgetsResponse, error) {
+	return recorded(ctx, c, trafficOpBudgets, in, func() (*pbc.GetBudgetsResponse, error) {
+		return c.api.GetBudgets(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) DryRun(
+	ctx context.Context,
+	in *pbc.DryRunRequest,
+	opts ...grpc.CallOption,
+) (*pbc.DryRunResponse, error) {
+	return recorded(ctx, c, trafficOpDryRun, in, func() (*pbc.DryRunResponse, error) {
+		return c.api.DryRun(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) DismissRecommendation(
+	ctx context.Context,
+	in *DismissRecommendationRequest,
+	opts ...grpc.CallOption,
+) (*DismissRecommendationResponse, error) {
+	return recorded(ctx, c, trafficOpDismissal, in, func() (*DismissRecommendationResponse, error) {
+		return c.api.DismissRecommendation(ctx, in, opts...)
+	})
+}
+
+func (c *recordingClient) EstimateCost(
+	ctx context.Context,
+	in *EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*EstimateCostResponse, error) {
+	return recorded(ctx, c, trafficOpEstimate, in, func() (*EstimateCostResponse, error) {
+		return c.api.EstimateCost(ctx, in, opts...)
+	})
+}
+
+// replayClient answers RPCs from a recording made by recordingClient.
+type replayClient struct {
+	plugin string
+	dir    string
+}
+
+// replayed looks up the recorded outcome of req.
+func replayed[R any](c *replayClient, operation string, req any) (*R, error) {
+	key, err := trafficKey(operation, req)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(trafficPath(c.dir, operation, key))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: plugin %s, %s request %s", ErrNoRecording, c.plugin, operation, key[:12])
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading recorded %s response: %w", operation, err)
+	}
+
+	var entry trafficEntry
+	if unmarshalErr := json.Unmarshal(data, &entry); unmarshalErr != nil {
+		return nil, fmt.Errorf("parsing recorded %s response: %w", operation, unmarshalErr)
+	}
+	if entry.Error != nil {
+		return nil, status.Error(entry.Error.Code, entry.Error.Message)
+	}
+	resp := new(R)
+	if decodeErr := decodeTraffic(entry.Response, resp); decodeErr != nil {
+		return nil, fmt.Errorf("decoding recorded %s response: %w", operation, decodeErr)
+	}
+	return resp, nil
+}
+
+// Name returns the recorded plugin name without consulting the recording, so
+// replay works even if Name was never called while recording.
+func (c *replayClient) Name(context.Context, *Empty, ...grpc.CallOption) (*NameResponse, error) {
+	return &NameResponse{Name: c.plugin}, nil
+}
+
+func (c *replayClient) GetPluginInfo(
+	_ context.Context,
+	in *Empty,
+	_ ...grpc.CallOption,
+) (*pbc.GetPluginInfoResponse, error) {
+	return replayed[pbc.GetPluginInfoResponse](c, trafficOpPluginInfo, in)
+}
+
+func (c *replayClient) GetProjectedCost(
+	_ context.Context,
+	in *GetProjectedCostRequest,
+	_ ...grpc.CallOption,
+) (*GetProjectedCostResponse, error) {
+	return replayed[GetProjectedCostResponse](c, CacheOpProjectedCost, in)
+}
+
+func (c *replayClient) GetActualCost(
+	_ context.Context,
+	in *GetActualCostRequest,
+	_ ...grpc.CallOption,
+) (*GetActualCostResponse, error) {
+	return replayed[GetActualCostResponse](c, CacheOpActualCost, in)
+}
+
+func (c *replayClient) GetRecommendations(
+	_ context.Context,
+	in *GetRecommendationsRequest,
+	_ ...grpc.CallOption,
+) (*GetRecommendationsResponse, error) {
+	return replayed[GetRecommendationsResponse](c, CacheOpRecommendations, in)
+}
+
+func (c *replayClient) GetBudgets(
+	_ context.Context,
+	in *pbc.GetBudgetsRequest,
+	_ ...grpc.CallOption,
+) (*pbc.GetBudgetsResponse, error) {
+	return replayed[pbc.GetBudgetsResponse](c, trafficOpBudgets, in)
+}
+
+func (c *replayClient) DryRun(
+	_ context.Context,
+	in *pbc.DryRunRequest,
+	_ ...grpc.CallOption,
+) (*pbc.DryRunResponse, error) {
+	return replayed[pbc.DryRunResponse](c, trafficOpDryRun, in)
+}
+
+func (c *replayClient) DismissRecommendation(
+	_ context.Context,
+	in *DismissRecommendationRequest,
+	_ ...grpc.CallOption,
+) (*DismissRecommendationResponse, error) {
+	return replayed[DismissRecommendationResponse](c, trafficOpDismissal, in)
+}
+
+func (c *replayClient) EstimateCost(
+	_ context.Context,
+	in *EstimateCostRequest,
+	_ ...grpc.CallOption,
+) (*EstimateCostResponse, error) {
+	return replayed[EstimateCostResponse](c, trafficOpEstimate, in)
+}
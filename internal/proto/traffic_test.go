@@ -0,0 +1,111 @@
+package proto
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+)
+
+func TestTrafficRecordAndReplay(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	mock := &mockCostSourceClient{
+		getProjectedFunc: func(
+			_ context.Context, in *GetProjectedCostRequest, _ ...grpc.CallOption,
+		) (*GetProjectedCostResponse, error) {
+			calls++
+			if in.Resources[0].ID == "broken" {
+				return nil, status.Error(codes.InvalidArgument, "unknown sku")
+			}
+			return &GetProjectedCostResponse{Results: []*CostResult{{Currency: "USD", MonthlyCost: 7.3}}}, nil
+		},
+		getRecommendationsFunc: func(
+			context.Context, *GetRecommendationsRequest, ...grpc.CallOption,
+		) (*GetRecommendationsResponse, error) {
+			calls++
+			return &GetRecommendationsResponse{Recommendations: []*Recommendation{{ID: "rec-1"}}}, nil
+		},
+	}
+	meta := &PluginMetadata{Name: "aws-public", Version: "1.2.0", SupportedProviders: []string{"aws"}}
+	recorder, err := NewRecordingClient("aws-public", meta, mock, dir)
+	require.NoError(t, err)
+
+	req := func(id string) *GetProjectedCostRequest {
+		return &GetProjectedCostRequest{Resources: []*ResourceDescriptor{
+			{ID: id, Type: "aws:ec2/instance:Instance", Provider: "aws", Properties: map[string]string{"a": "1"}},
+		}}
+	}
+	live, err := recorder.GetProjectedCost(context.Background(), req("web"))
+	require.NoError(t, err)
+	_, err = recorder.GetProjectedCost(context.Background(), req("broken"))
+	require.Error(t, err)
+	_, err = recorder.GetRecommendations(context.Background(),
+		&GetRecommendationsRequest{ExcludedRecommendationIDs: []string{"b", "a"}})
+	require.NoError(t, err)
+	require.Equal(t, 3, calls)
+
+	plugins, err := LoadRecordedPlugins(dir)
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "aws-public", plugins[0].Name)
+	assert.Equal(t, meta, plugins[0].Metadata)
+	replay := plugins[0].API
+
+	replayed, err := replay.GetProjectedCost(context.Background(), req("web"))
+	require.NoError(t, err)
+	assert.Equal(t, live, replayed)
+
+	_, err = replay.GetProjectedCost(context.Background(), req("broken"))
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "recorded errors replay with their status")
+
+	recs, err := replay.GetRecommendations(context.Background(),
+		&GetRecommendationsRequest{ExcludedRecommendationIDs: []string{"a", "b"}})
+	require.NoError(t, err)
+	require.Len(t, recs.Recommendations, 1)
+
+	_, err = replay.GetProjectedCost(context.Background(), req("never-seen"))
+	require.ErrorIs(t, err, ErrNoRecording)
+	assert.Equal(t, 3, calls, "replay never calls the plugin")
+
+	name, err := replay.Name(context.Background(), &Empty{})
+	require.NoError(t, err)
+	assert.Equal(t, "aws-public", name.Name)
+}
+
+// budgetsClient answers GetBudgets with a fixed proto response.
+type budgetsClient struct {
+	CostSourceClient
+}
+
+func (budgetsClient) GetBudgets(
+	context.Context, *pbc.GetBudgetsRequest, ...grpc.CallOption,
+) (*pbc.GetBudgetsResponse, error) {
+	return &pbc.GetBudgetsResponse{Budgets: []*pbc.Budget{{Id: "b-1", Name: "team"}}}, nil
+}
+
+func TestTrafficReplay_ProtoMessages(t *testing.T) {
+	dir := t.TempDir()
+	recorder, err := NewRecordingClient("budgets", nil, budgetsClient{}, dir)
+	require.NoError(t, err)
+	_, err = recorder.GetBudgets(context.Background(), &pbc.GetBudgetsRequest{})
+	require.NoError(t, err)
+
+	plugins, err := LoadRecordedPlugins(dir)
+	require.NoError(t, err)
+	resp, err := plugins[0].API.GetBudgets(context.Background(), &pbc.GetBudgetsRequest{})
+	require.NoError(t, err)
+	require.Len(t, resp.GetBudgets(), 1)
+	assert.Equal(t, "team", resp.GetBudgets()[0].GetName())
+}
+
+func TestLoadRecordedPlugins_Empty(t *testing.T) {
+	_, err := LoadRecordedPlugins(t.TempDir())
+	require.Error(t, err)
+}
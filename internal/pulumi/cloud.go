@@ -0,0 +1,143 @@
+package pulumi
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Pulumi Cloud API settings.
+const (
+	// DefaultCloudURL is the Pulumi Cloud API endpoint.
+	DefaultCloudURL = "https://api.pulumi.com"
+	// DefaultCloudTimeout bounds a single Pulumi Cloud API request.
+	DefaultCloudTimeout = 60 * time.Second
+
+	// EnvAccessToken is the environment variable holding the Pulumi Cloud access token.
+	EnvAccessToken = "PULUMI_ACCESS_TOKEN" //nolint:gosec // Environment variable name, not a credential.
+
+	// cloudAcceptHeader selects the Pulumi Cloud API version.
+	cloudAcceptHeader = "application/vnd.pulumi+8"
+	// maxCloudErrorBody limits how much of an error response is echoed back to the user.
+	maxCloudErrorBody = 512
+)
+
+// StackRef is a fully qualified Pulumi Cloud stack name (org/project/stack).
+type StackRef struct {
+	Org     string
+	Project string
+	Stack   string
+}
+
+// String returns the reference in org/project/stack form.
+func (r StackRef) String() string {
+	return r.Org + "/" + r.Project + "/" + r.Stack
+}
+
+// ParseStackRef parses an org/project/stack name. It reports false for plain
+// stack names (e.g. "dev") and anything that is not exactly three non-empty
+// segments, so callers can fall back to local CLI detection.
+func ParseStackRef(name string) (StackRef, bool) {
+	parts := strings.Split(name, "/")
+	if len(parts) != 3 { //nolint:mnd // org/project/stack
+		return StackRef{}, false
+	}
+	for _, part := range parts {
+		if strings.TrimSpace(part) == "" {
+			return StackRef{}, false
+		}
+	}
+	return StackRef{Org: parts[0], Project: parts[1], Stack: parts[2]}, true
+}
+
+// ResolveAccessToken returns the Pulumi Cloud access token from
+// PULUMI_ACCESS_TOKEN, falling back to configured (the pulumi.access_token
+// config value). It returns "" when neither is set.
+func ResolveAccessToken(configured string) string {
+	if token := strings.TrimSpace(os.Getenv(EnvAccessToken)); token != "" {
+		return token
+	}
+	return strings.TrimSpace(configured)
+}
+
+// CloudClient fetches stack state from the Pulumi Cloud REST API.
+type CloudClient struct {
+	HTTPClient *http.Client
+	BaseURL    string
+	token      string
+}
+
+// NewCloudClient returns a CloudClient that authenticates with token. An empty
+// baseURL selects DefaultCloudURL.
+func NewCloudClient(baseURL, token string) *CloudClient {
+	if baseURL == "" {
+		baseURL = DefaultCloudURL
+	}
+	return &CloudClient{
+		HTTPClient: &http.Client{Timeout: DefaultCloudTimeout},
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		token:      token,
+	}
+}
+
+// ExportStack returns the latest checkpoint of ref in `pulumi stack export`
+// format. Errors wrap ErrNoAccessToken, ErrCloudStackNotFound, or
+// ErrCloudRequestFailed.
+func (c *CloudClient) ExportStack(ctx context.Context, ref StackRef) ([]byte, error) {
+	if c.token == "" {
+		return nil, NoAccessTokenError()
+	}
+
+	endpoint := fmt.Sprintf("%s/api/stacks/%s/%s/%s/export", c.BaseURL,
+		url.PathEscape(ref.Org), url.PathEscape(ref.Project), url.PathEscape(ref.Stack))
+
+	log := logging.FromContext(ctx)
+	log.Debug().
+		Ctx(ctx).
+		Str("component", "pulumi").
+		Str("operation", "cloud_export").
+		Str("stack", ref.String()).
+		Str("endpoint", endpoint).
+		Msg("fetching stack state from Pulumi Cloud")
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("creating Pulumi Cloud request: %w", err)
+	}
+	req.Header.Set("Accept", cloudAcceptHeader)
+	req.Header.Set("Authorization", "token "+c.token)
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrCloudRequestFailed, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return nil, fmt.Errorf("%w: HTTP %d; check %s or pulumi.access_token",
+			ErrCloudRequestFailed, resp.StatusCode, EnvAccessToken)
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("%w: %s", ErrCloudStackNotFound, ref)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxCloudErrorBody))
+		return nil, fmt.Errorf("%w: HTTP %d: %s",
+			ErrCloudRequestFailed, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading response: %w", ErrCloudRequestFailed, err)
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("%w: empty response body", ErrCloudRequestFailed)
+	}
+	return data, nil
+}
@@ -0,0 +1,101 @@
+package pulumi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseStackRef(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want StackRef
+		ok   bool
+	}{
+		{
+			name: "fully qualified",
+			in:   "acme/webapp/prod",
+			want: StackRef{Org: "acme", Project: "webapp", Stack: "prod"},
+			ok:   true,
+		},
+		{name: "plain stack", in: "prod"},
+		{name: "org/stack", in: "acme/prod"},
+		{name: "empty segment", in: "acme//prod"},
+		{name: "too many segments", in: "a/b/c/d"},
+		{name: "empty", in: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ParseStackRef(tt.in)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestResolveAccessToken(t *testing.T) {
+	t.Setenv(EnvAccessToken, "")
+	assert.Empty(t, ResolveAccessToken(""))
+	assert.Equal(t, "from-config", ResolveAccessToken("from-config"))
+
+	t.Setenv(EnvAccessToken, "from-env")
+	assert.Equal(t, "from-env", ResolveAccessToken("from-config"), "environment takes precedence")
+}
+
+func TestCloudClient_ExportStack(t *testing.T) {
+	const state = `{"version":3,"deployment":{"resources":[]}}`
+	var gotPath, gotAuth, gotAccept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotAuth = r.Header.Get("Authorization")
+		gotAccept = r.Header.Get("Accept")
+		_, _ = w.Write([]byte(state))
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewCloudClient(server.URL+"/", "secret")
+	data, err := client.ExportStack(context.Background(), StackRef{Org: "acme", Project: "webapp", Stack: "prod"})
+	require.NoError(t, err)
+	assert.JSONEq(t, state, string(data))
+	assert.Equal(t, "/api/stacks/acme/webapp/prod/export", gotPath)
+	assert.Equal(t, "token secret", gotAuth)
+	assert.Equal(t, cloudAcceptHeader, gotAccept)
+}
+
+func TestCloudClient_ExportStack_Errors(t *testing.T) {
+	ref := StackRef{Org: "acme", Project: "webapp", Stack: "prod"}
+
+	t.Run("no token", func(t *testing.T) {
+		_, err := NewCloudClient("", "").ExportStack(context.Background(), ref)
+		require.ErrorIs(t, err, ErrNoAccessToken)
+		assert.Contains(t, err.Error(), EnvAccessToken)
+	})
+
+	tests := []struct {
+		name    string
+		status  int
+		wantErr error
+		wantMsg string
+	}{
+		{name: "unauthorized", status: http.StatusUnauthorized, wantErr: ErrCloudRequestFailed, wantMsg: EnvAccessToken},
+		{name: "not found", status: http.StatusNotFound, wantErr: ErrCloudStackNotFound, wantMsg: "acme/webapp/prod"},
+		{name: "server error", status: http.StatusInternalServerError, wantErr: ErrCloudRequestFailed, wantMsg: "boom"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+				http.Error(w, "boom", tt.status)
+			}))
+			t.Cleanup(server.Close)
+
+			_, err := NewCloudClient(server.URL, "secret").ExportStack(context.Background(), ref)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
@@ -28,6 +28,15 @@ var (
 
 	// ErrExportFailed indicates pulumi stack export returned a non-zero exit code.
 	ErrExportFailed = errors.New("pulumi stack export failed")
+
+	// ErrNoAccessToken indicates no Pulumi Cloud access token is configured.
+	ErrNoAccessToken = errors.New("no Pulumi Cloud access token")
+
+	// ErrCloudStackNotFound indicates the Pulumi Cloud API has no such stack.
+	ErrCloudStackNotFound = errors.New("stack not found in Pulumi Cloud")
+
+	// ErrCloudRequestFailed indicates a Pulumi Cloud API request failed.
+	ErrCloudRequestFailed = errors.New("pulumi cloud request failed")
 )
 
 // NotFoundError returns a user-facing error that wraps ErrPulumiNotFound
@@ -70,3 +79,10 @@ func ExportError(stderr string) error {
 	}
 	return fmt.Errorf("%w: %s", ErrExportFailed, trimmed)
 }
+
+// NoAccessTokenError returns an error wrapping ErrNoAccessToken that explains
+// how to provide a token for fully qualified --stack names.
+func NoAccessTokenError() error {
+	return fmt.Errorf("%w; set %s or run 'finfocus config set pulumi.access_token <token>'",
+		ErrNoAccessToken, EnvAccessToken)
+}
@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// activeVersionFile is the file in a plugin's directory that pins the version
+// used at runtime. Without it, the latest installed version is used.
+const activeVersionFile = ".active-version"
+
+// activeVersionPerm is the permission for the active version file.
+const activeVersionPerm = 0600
+
+// ReadActiveVersion returns the pinned version for the named plugin under
+// pluginRoot, or an empty string when no version is pinned.
+func ReadActiveVersion(pluginRoot, name string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(pluginRoot, name, activeVersionFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("reading active version for %s: %w", name, err)
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// SetActiveVersion pins the named plugin to version. The version must already
+// be installed under pluginRoot.
+func SetActiveVersion(pluginRoot, name, version string) error {
+	versionDir := filepath.Join(pluginRoot, name, version)
+	info, err := os.Stat(versionDir)
+	if err != nil || !info.IsDir() {
+		return fmt.Errorf("plugin %s@%s is not installed", name, version)
+	}
+
+	path := filepath.Join(pluginRoot, name, activeVersionFile)
+	tmp := path + ".tmp"
+	if writeErr := os.WriteFile(tmp, []byte(version+"\n"), activeVersionPerm); writeErr != nil {
+		return fmt.Errorf("writing active version for %s: %w", name, writeErr)
+	}
+	if renameErr := os.Rename(tmp, path); renameErr != nil {
+		_ = os.Remove(tmp)
+		return fmt.Errorf("writing active version for %s: %w", name, renameErr)
+	}
+	return nil
+}
+
+// ClearActiveVersion removes the version pin for the named plugin so the
+// latest installed version is used. Clearing an unpinned plugin is a no-op.
+func ClearActiveVersion(pluginRoot, name string) error {
+	err := os.Remove(filepath.Join(pluginRoot, name, activeVersionFile))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("clearing active version for %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/pluginhost"
+)
+
+func TestActiveVersion(t *testing.T) {
+	dir := createMultiVersionPluginDir(t)
+
+	active, err := ReadActiveVersion(dir, "testplugin")
+	require.NoError(t, err)
+	assert.Empty(t, active, "no pin by default")
+
+	require.NoError(t, SetActiveVersion(dir, "testplugin", "v1.0.0"))
+	active, err = ReadActiveVersion(dir, "testplugin")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.0.0", active)
+
+	require.ErrorContains(t, SetActiveVersion(dir, "testplugin", "v9.9.9"), "not installed")
+
+	require.NoError(t, ClearActiveVersion(dir, "testplugin"))
+	require.NoError(t, ClearActiveVersion(dir, "testplugin"), "clearing twice is a no-op")
+	active, err = ReadActiveVersion(dir, "testplugin")
+	require.NoError(t, err)
+	assert.Empty(t, active)
+}
+
+func TestListLatestPlugins_HonorsActiveVersion(t *testing.T) {
+	dir := createMultiVersionPluginDir(t)
+	reg := &Registry{root: dir, launcher: pluginhost.NewProcessLauncher()}
+
+	require.NoError(t, SetActiveVersion(dir, "testplugin", "v1.0.0"))
+	plugins, warnings, err := reg.ListLatestPlugins()
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "v1.0.0", plugins[0].Version, "pinned version wins over latest")
+	assert.Empty(t, warnings)
+
+	// A pin to a version that has since been removed falls back to latest.
+	require.NoError(t, SetActiveVersion(dir, "testplugin", "v2.0.0"))
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "testplugin", "v2.0.0")))
+	plugins, warnings, err = reg.ListLatestPlugins()
+	require.NoError(t, err)
+	require.Len(t, plugins, 1)
+	assert.Equal(t, "v1.0.0", plugins[0].Version)
+	require.Len(t, warnings, 1)
+	assert.Contains(t, warnings[0], "pinned to v2.0.0")
+}
@@ -0,0 +1,102 @@
+package registry
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrChecksumMismatch is returned when a downloaded file does not match its published checksum.
+var ErrChecksumMismatch = errors.New("checksum mismatch")
+
+// ErrChecksumUnavailable is returned when checksum verification is required but
+// the release does not publish a checksum for the downloaded asset.
+var ErrChecksumUnavailable = errors.New("no published checksum for asset")
+
+// sha256HexLen is the length of a hex-encoded SHA-256 digest.
+const sha256HexLen = 64
+
+// FindChecksumAsset returns the release asset holding the SHA-256 checksum for
+// assetName, or nil when the release publishes none. A per-asset
+// "<asset>.sha256" file is preferred over an aggregate checksums file such as
+// GoReleaser's "<project>_<version>_checksums.txt" or "SHA256SUMS".
+func FindChecksumAsset(release *GitHubRelease, assetName string) *ReleaseAsset {
+	for i := range release.Assets {
+		if release.Assets[i].Name == assetName+".sha256" {
+			return &release.Assets[i]
+		}
+	}
+	for i := range release.Assets {
+		name := strings.ToLower(release.Assets[i].Name)
+		if strings.HasSuffix(name, "checksums.txt") || name == "sha256sums" || name == "sha256sums.txt" {
+			return &release.Assets[i]
+		}
+	}
+	return nil
+}
+
+// ParseChecksums finds the SHA-256 digest for assetName in checksum file data.
+// It accepts the sha256sum format ("<hex>  <name>", with an optional "*" binary
+// marker before the name) and bare per-asset files containing only the digest.
+// The returned digest is lower-case hex.
+func ParseChecksums(data []byte, assetName string) (string, bool) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		switch len(fields) {
+		case 1:
+			// Bare digest, as published in "<asset>.sha256" files.
+			if isSHA256Hex(fields[0]) {
+				return strings.ToLower(fields[0]), true
+			}
+		case 2: //nolint:mnd // digest and file name
+			name := strings.TrimPrefix(fields[1], "*")
+			if name == assetName && isSHA256Hex(fields[0]) {
+				return strings.ToLower(fields[0]), true
+			}
+		}
+	}
+	return "", false
+}
+
+// FileSHA256 returns the hex-encoded SHA-256 digest of the file at path.
+func FileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("opening %s: %w", path, err)
+	}
+	defer func() { _ = f.Close() }()
+
+	h := sha256.New()
+	if _, copyErr := io.Copy(h, f); copyErr != nil {
+		return "", fmt.Errorf("hashing %s: %w", path, copyErr)
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// VerifyFileSHA256 returns ErrChecksumMismatch if the SHA-256 digest of the
+// file at path differs from expected.
+func VerifyFileSHA256(path, expected string) error {
+	actual, err := FileSHA256(path)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(actual, expected) {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+func isSHA256Hex(s string) bool {
+	if len(s) != sha256HexLen {
+		return false
+	}
+	_, err := hex.DecodeString(s)
+	return err == nil
+}
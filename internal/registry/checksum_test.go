@@ -0,0 +1,60 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFindChecksumAsset(t *testing.T) {
+	release := &GitHubRelease{Assets: []ReleaseAsset{
+		{Name: "kubecost_1.0.0_linux_amd64.tar.gz"},
+		{Name: "kubecost_1.0.0_checksums.txt"},
+	}}
+	got := FindChecksumAsset(release, "kubecost_1.0.0_linux_amd64.tar.gz")
+	require.NotNil(t, got)
+	assert.Equal(t, "kubecost_1.0.0_checksums.txt", got.Name)
+
+	release.Assets = append(release.Assets, ReleaseAsset{Name: "kubecost_1.0.0_linux_amd64.tar.gz.sha256"})
+	got = FindChecksumAsset(release, "kubecost_1.0.0_linux_amd64.tar.gz")
+	require.NotNil(t, got)
+	assert.Equal(t, "kubecost_1.0.0_linux_amd64.tar.gz.sha256", got.Name, "per-asset file is preferred")
+
+	assert.Nil(t, FindChecksumAsset(&GitHubRelease{Assets: []ReleaseAsset{{Name: "a.tar.gz"}}}, "a.tar.gz"))
+}
+
+func TestParseChecksums(t *testing.T) {
+	sumA := strings.Repeat("a", sha256HexLen)
+	sumB := strings.Repeat("B", sha256HexLen)
+
+	data := []byte(sumA + "  plugin_linux_amd64.tar.gz\n" + sumB + " *plugin_darwin_arm64.tar.gz\n")
+	got, ok := ParseChecksums(data, "plugin_darwin_arm64.tar.gz")
+	require.True(t, ok)
+	assert.Equal(t, strings.ToLower(sumB), got, "binary marker is stripped and digest lower-cased")
+
+	_, ok = ParseChecksums(data, "plugin_windows_amd64.zip")
+	assert.False(t, ok)
+
+	got, ok = ParseChecksums([]byte(sumA+"\n"), "anything")
+	require.True(t, ok, "bare digest files apply to their asset")
+	assert.Equal(t, sumA, got)
+
+	_, ok = ParseChecksums([]byte("not-a-digest  plugin.tar.gz\n"), "plugin.tar.gz")
+	assert.False(t, ok)
+}
+
+func TestVerifyFileSHA256(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "archive.tar.gz")
+	content := []byte("plugin archive")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	sum := sha256.Sum256(content)
+
+	require.NoError(t, VerifyFileSHA256(path, strings.ToUpper(hex.EncodeToString(sum[:]))))
+	require.ErrorIs(t, VerifyFileSHA256(path, strings.Repeat("0", sha256HexLen)), ErrChecksumMismatch)
+}
@@ -45,6 +45,8 @@ type PluginSpecifier struct {
 	IsURL   bool
 	Owner   string
 	Repo    string
+	// OCI is set when the specifier is an oci:// artifact reference.
+	OCI *OCIReference
 }
 
 var (
@@ -96,6 +98,7 @@ func ValidateRegistryEntry(entry RegistryEntry) error {
 //   - "kubecost" - registry plugin, latest version
 //   - "kubecost@v1.0.0" - registry plugin, specific version
 //   - "github.com/owner/repo" - GitHub URL, latest version
+//   - "oci://ghcr.io/owner/repo@v1.0.0" - OCI artifact, version tag required
 //
 // ParsePluginSpecifier parses a plugin specifier string into a PluginSpecifier.
 // It accepts either a registry name or a GitHub URL with an optional version suffix
@@ -109,6 +112,9 @@ func ValidateRegistryEntry(entry RegistryEntry) error {
 // When the input is a registry name, IsURL is false and Name is set to the given name.
 // The Version field is set if a `@version` suffix is provided; otherwise it is empty.
 //
+// When the input is an `oci://` reference, OCI is set and Name is derived from the last
+// repository path segment the same way; a version tag is required.
+//
 // The function returns an error if `spec` is empty or if a GitHub URL does not match
 // the expected `github.com/owner/repo` format.
 func ParsePluginSpecifier(spec string) (*PluginSpecifier, error) {
@@ -124,6 +130,22 @@ func ParsePluginSpecifier(spec string) (*PluginSpecifier, error) {
 		version = parts[1]
 	}
 
+	if strings.HasPrefix(nameOrURL, ociSchemePrefix) {
+		ref, err := ParseOCIReference(nameOrURL)
+		if err != nil {
+			return nil, err
+		}
+		if version == "" {
+			return nil, fmt.Errorf("OCI plugin references require a version tag (%s@v1.0.0)", nameOrURL)
+		}
+		repoName := ref.Repository[strings.LastIndex(ref.Repository, "/")+1:]
+		return &PluginSpecifier{
+			Name:    strings.TrimPrefix(repoName, "finfocus-plugin-"),
+			Version: version,
+			OCI:     &ref,
+		}, nil
+	}
+
 	// Check if it's a GitHub URL
 	if strings.HasPrefix(nameOrURL, "github.com/") {
 		matches := githubPattern.FindStringSubmatch(nameOrURL)
@@ -25,6 +25,8 @@ type InstallOptions struct {
 	FallbackToLatest bool              // Automatically install latest stable version if requested version lacks assets
 	NoFallback       bool              // Disable fallback behavior entirely (fail if requested version lacks assets)
 	Metadata         map[string]string // User-supplied metadata (e.g., region=us-west-2), stored as plugin.metadata.json
+	RequireChecksum  bool              // Fail if the release publishes no checksum for the downloaded asset
+	Activate         bool              // Pin the installed version as the plugin's active version
 }
 
 // InstallResult contains the result of a plugin installation.
@@ -36,11 +38,13 @@ type InstallResult struct {
 	Repository       string
 	WasFallback      bool   // True if installed version differs from requested
 	RequestedVersion string // Original version requested (empty if @latest)
+	Checksum         string // Verified SHA-256 of the downloaded archive (empty if none was published)
 }
 
 // Installer handles plugin installation from registry or URLs.
 type Installer struct {
 	client    *GitHubClient
+	oci       *OCIClient
 	pluginDir string
 }
 
@@ -74,6 +78,7 @@ func NewInstaller(pluginDir string) *Installer {
 	}
 	return &Installer{
 		client:    NewGitHubClient(),
+		oci:       NewOCIClient(),
 		pluginDir: pluginDir,
 	}
 }
@@ -100,6 +105,7 @@ func NewInstallerWithClient(client *GitHubClient, pluginDir string) *Installer {
 	}
 	return &Installer{
 		client:    client,
+		oci:       NewOCIClient(),
 		pluginDir: pluginDir,
 	}
 }
@@ -221,12 +227,68 @@ func (i *Installer) Install(
 	}
 	defer unlock()
 
+	if spec.OCI != nil {
+		return i.installFromOCI(spec, opts, progress)
+	}
 	if spec.IsURL {
 		return i.installFromURL(spec, opts, progress)
 	}
 	return i.installFromRegistry(spec, opts, progress)
 }
 
+// installFromOCI installs a plugin from an OCI artifact. The blob is verified
+// against its content digest during download.
+func (i *Installer) installFromOCI(
+	spec *PluginSpecifier,
+	opts InstallOptions,
+	progress func(msg string),
+) (*InstallResult, error) {
+	installDir, err := i.prepareInstallDir(spec.Name, spec.Version, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if progress != nil {
+		progress(fmt.Sprintf("Resolving %s@%s...", spec.OCI, spec.Version))
+	}
+	artifact, err := i.oci.ResolveArtifact(*spec.OCI, spec.Version, spec.Name, ociAssetHints(opts))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve artifact: %w", err)
+	}
+
+	if progress != nil {
+		progress(fmt.Sprintf("Downloading %s (%d bytes)...", artifact.Name, artifact.Size))
+	}
+	tmpPath, cleanup, err := createDownloadTemp(artifact.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if downloadErr := i.oci.DownloadBlob(artifact, tmpPath, downloadProgress(progress)); downloadErr != nil {
+		return nil, fmt.Errorf("failed to download: %w", downloadErr)
+	}
+	if progress != nil {
+		progress("Verified " + artifact.Digest)
+	}
+
+	result, err := i.installArchive(spec.Name, spec.Version, spec.OCI.String(), tmpPath, installDir, opts, progress)
+	if err != nil {
+		return nil, err
+	}
+	result.Repository = spec.OCI.String()
+	result.Checksum = strings.TrimPrefix(artifact.Digest, "sha256:")
+	return result, nil
+}
+
+// ociAssetHints returns asset hints carrying a user-supplied region, if any.
+func ociAssetHints(opts InstallOptions) *AssetNamingHints {
+	if region := opts.Metadata["region"]; region != "" {
+		return &AssetNamingHints{Region: region}
+	}
+	return nil
+}
+
 // installFromRegistry installs a plugin from the embedded registry.
 func (i *Installer) installFromRegistry(
 	spec *PluginSpecifier,
@@ -335,8 +397,6 @@ func (i *Installer) installFromURL(
 }
 
 // installRelease downloads and installs a specific release.
-//
-//nolint:gocognit,funlen // Complex but necessary installation logic with many steps
 func (i *Installer) installRelease(
 	name string,
 	release *GitHubRelease,
@@ -347,20 +407,9 @@ func (i *Installer) installRelease(
 ) (*InstallResult, error) {
 	version := release.TagName
 
-	// Determine plugin directory
-	pluginDir := i.pluginDir
-	if opts.PluginDir != "" {
-		pluginDir = opts.PluginDir
-	}
-
-	// Check if already installed
-	installDir := filepath.Join(pluginDir, name, version)
-	if _, err := os.Stat(installDir); err == nil && !opts.Force {
-		return nil, fmt.Errorf(
-			"plugin %s@%s already installed. Use --force to reinstall",
-			name,
-			version,
-		)
+	installDir, err := i.prepareInstallDir(name, version, opts)
+	if err != nil {
+		return nil, err
 	}
 
 	// Override region in asset hints if user supplied region via --metadata
@@ -383,39 +432,150 @@ func (i *Installer) installRelease(
 		progress(fmt.Sprintf("Downloading %s (%d bytes)...", asset.Name, asset.Size))
 	}
 
-	// Determine extension for temp file
+	tmpPath, cleanup, err := createDownloadTemp(asset.Name)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	if downloadErr := i.client.DownloadAsset(
+		asset.BrowserDownloadURL, tmpPath, downloadProgress(progress),
+	); downloadErr != nil {
+		return nil, fmt.Errorf("failed to download: %w", downloadErr)
+	}
+
+	checksum, err := i.verifyReleaseChecksum(release, asset, tmpPath, opts, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := i.installArchive(
+		name, version, fmt.Sprintf("github.com/%s", repository), tmpPath, installDir, opts, progress,
+	)
+	if err != nil {
+		return nil, err
+	}
+	result.Checksum = checksum
+	return result, nil
+}
+
+// rootDir returns override when set, otherwise the installer's plugin directory.
+func (i *Installer) rootDir(override string) string {
+	if override != "" {
+		return override
+	}
+	return i.pluginDir
+}
+
+// prepareInstallDir resolves the install directory for name@version and
+// returns an error if that version is already installed and opts.Force is unset.
+func (i *Installer) prepareInstallDir(name, version string, opts InstallOptions) (string, error) {
+	installDir := filepath.Join(i.rootDir(opts.PluginDir), name, version)
+	if _, err := os.Stat(installDir); err == nil && !opts.Force {
+		return "", fmt.Errorf(
+			"plugin %s@%s already installed. Use --force to reinstall",
+			name,
+			version,
+		)
+	}
+	return installDir, nil
+}
+
+// createDownloadTemp creates a temp file whose extension matches assetName so
+// ExtractArchive can detect the archive format. The returned cleanup removes it.
+func createDownloadTemp(assetName string) (string, func(), error) {
 	pattern := "finfocus-plugin-*"
 	switch {
-	case strings.HasSuffix(asset.Name, extZip):
+	case strings.HasSuffix(assetName, extZip):
 		pattern += extZip
-	case strings.HasSuffix(asset.Name, extTarGz):
+	case strings.HasSuffix(assetName, extTarGz):
 		pattern += extTarGz
-	case strings.HasSuffix(asset.Name, ".tgz"):
+	case strings.HasSuffix(assetName, ".tgz"):
 		pattern += ".tgz"
 	default:
-		pattern += filepath.Ext(asset.Name)
+		pattern += filepath.Ext(assetName)
 	}
 
-	// Create temp file for download
 	tmpFile, err := os.CreateTemp("", pattern)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create temp file: %w", err)
+		return "", nil, fmt.Errorf("failed to create temp file: %w", err)
 	}
 	tmpPath := tmpFile.Name()
 	_ = tmpFile.Close()
-	defer func() { _ = os.Remove(tmpPath) }()
+	return tmpPath, func() { _ = os.Remove(tmpPath) }, nil
+}
 
-	// Download asset
-	downloadProgress := func(downloaded, total int64) {
+// downloadProgress adapts a message callback to a byte-count progress callback.
+func downloadProgress(progress func(msg string)) func(downloaded, total int64) {
+	return func(downloaded, total int64) {
 		if progress != nil && total > 0 {
 			pct := float64(downloaded) / float64(total) * 100 //nolint:mnd // percentage calculation
 			progress(fmt.Sprintf("Downloading... %.0f%%", pct))
 		}
 	}
-	if downloadErr := i.client.DownloadAsset(asset.BrowserDownloadURL, tmpPath, downloadProgress); downloadErr != nil {
-		return nil, fmt.Errorf("failed to download: %w", downloadErr)
+}
+
+// verifyReleaseChecksum checks the downloaded asset at path against the
+// SHA-256 published in the release's checksum asset and returns the verified
+// digest. When the release publishes no checksum for the asset, verification
+// is skipped with a warning unless opts.RequireChecksum is set.
+func (i *Installer) verifyReleaseChecksum(
+	release *GitHubRelease,
+	asset *ReleaseAsset,
+	path string,
+	opts InstallOptions,
+	progress func(msg string),
+) (string, error) {
+	unavailable := func(reason string) (string, error) {
+		if opts.RequireChecksum {
+			return "", fmt.Errorf("%w %s: %s", ErrChecksumUnavailable, asset.Name, reason)
+		}
+		if progress != nil {
+			progress(fmt.Sprintf("Warning: %s; skipping checksum verification", reason))
+		}
+		return "", nil
+	}
+
+	checksumAsset := FindChecksumAsset(release, asset.Name)
+	if checksumAsset == nil {
+		return unavailable("release publishes no checksums")
 	}
 
+	sumPath, cleanup, err := createDownloadTemp(checksumAsset.Name)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+	if downloadErr := i.client.DownloadAsset(checksumAsset.BrowserDownloadURL, sumPath, nil); downloadErr != nil {
+		return "", fmt.Errorf("failed to download %s: %w", checksumAsset.Name, downloadErr)
+	}
+	data, err := os.ReadFile(sumPath)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", checksumAsset.Name, err)
+	}
+
+	expected, ok := ParseChecksums(data, asset.Name)
+	if !ok {
+		return unavailable(fmt.Sprintf("%s has no entry for %s", checksumAsset.Name, asset.Name))
+	}
+	if verifyErr := VerifyFileSHA256(path, expected); verifyErr != nil {
+		return "", fmt.Errorf("verifying %s: %w", asset.Name, verifyErr)
+	}
+	if progress != nil {
+		progress(fmt.Sprintf("Verified SHA-256 checksum from %s", checksumAsset.Name))
+	}
+	return expected, nil
+}
+
+// installArchive extracts a downloaded plugin archive into installDir,
+// validates the binary, writes metadata, records the plugin in config unless
+// opts.NoSave is set, and pins it as the active version if opts.Activate is set.
+// source is the URL recorded in config (e.g. "github.com/owner/repo").
+func (i *Installer) installArchive(
+	name, version, source, archivePath, installDir string,
+	opts InstallOptions,
+	progress func(msg string),
+) (*InstallResult, error) {
 	// Create install directory
 	if mkdirErr := os.MkdirAll(installDir, 0750); mkdirErr != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", mkdirErr)
@@ -426,7 +586,7 @@ func (i *Installer) installRelease(
 	}
 
 	// Extract archive
-	if extractErr := ExtractArchive(tmpPath, installDir); extractErr != nil {
+	if extractErr := ExtractArchive(archivePath, installDir); extractErr != nil {
 		_ = os.RemoveAll(installDir)
 		return nil, fmt.Errorf("failed to extract: %w", extractErr)
 	}
@@ -456,7 +616,7 @@ func (i *Installer) installRelease(
 	if !opts.NoSave {
 		plugin := config.InstalledPlugin{
 			Name:    name,
-			URL:     fmt.Sprintf("github.com/%s", repository),
+			URL:     source,
 			Version: version,
 		}
 		if addErr := config.AddInstalledPlugin(plugin); addErr != nil {
@@ -467,6 +627,12 @@ func (i *Installer) installRelease(
 		}
 	}
 
+	if opts.Activate {
+		if activateErr := SetActiveVersion(i.rootDir(opts.PluginDir), name, version); activateErr != nil {
+			return nil, activateErr
+		}
+	}
+
 	if progress != nil {
 		progress(fmt.Sprintf("Successfully installed %s@%s", name, version))
 	}
@@ -581,6 +747,10 @@ func (i *Installer) Update(
 		return nil, fmt.Errorf("plugin %q is not installed", name)
 	}
 
+	if strings.HasPrefix(installed.URL, ociSchemePrefix) {
+		return i.updateFromOCI(name, installed, opts, progress)
+	}
+
 	// Look up in registry first, then try as URL
 	owner, repo, assetHints, err := i.resolvePluginSource(name, installed.URL)
 	if err != nil {
@@ -662,25 +832,91 @@ func (i *Installer) Update(
 		return nil, err
 	}
 
+	i.finishUpdate(name, oldVersion, newVersion, pluginDir, progress)
+
+	return &UpdateResult{
+		Name:       name,
+		OldVersion: oldVersion,
+		NewVersion: newVersion,
+		Path:       result.Path,
+	}, nil
+}
+
+// updateFromOCI updates a plugin installed from an OCI artifact to opts.Version
+// or, when unset, the highest semantic-version tag in its repository.
+func (i *Installer) updateFromOCI(
+	name string,
+	installed *config.InstalledPlugin,
+	opts UpdateOptions,
+	progress func(msg string),
+) (*UpdateResult, error) {
+	ref, err := ParseOCIReference(installed.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	newVersion := opts.Version
+	if newVersion == "" {
+		if progress != nil {
+			progress(fmt.Sprintf("Checking for updates to %s...", name))
+		}
+		if newVersion, err = i.oci.LatestTag(ref); err != nil {
+			return nil, fmt.Errorf("failed to list tags: %w", err)
+		}
+	}
+
+	oldVersion := installed.Version
+	result := &UpdateResult{Name: name, OldVersion: oldVersion, NewVersion: newVersion}
+	if cmp, cmpErr := CompareVersions(newVersion, oldVersion); cmpErr == nil && (cmp == 0 ||
+		(cmp < 0 && opts.Version == "")) {
+		result.WasUpToDate = true
+		return result, nil
+	}
+	if opts.DryRun {
+		if progress != nil {
+			progress(fmt.Sprintf("Would update %s from %s to %s", name, oldVersion, newVersion))
+		}
+		return result, nil
+	}
+
+	pluginDir := i.rootDir(opts.PluginDir)
+	installResult, err := i.installFromOCI(&PluginSpecifier{Name: name, Version: newVersion, OCI: &ref},
+		InstallOptions{Force: true, NoSave: true, PluginDir: pluginDir}, progress)
+	if err != nil {
+		return nil, err
+	}
+
+	i.finishUpdate(name, oldVersion, newVersion, pluginDir, progress)
+	result.Path = installResult.Path
+	return result, nil
+}
+
+// finishUpdate removes the old version directory, moves an existing version
+// pin to the new version, and records the new version in config.
+func (i *Installer) finishUpdate(name, oldVersion, newVersion, pluginDir string, progress func(msg string)) {
+	warn := func(format string, args ...any) {
+		if progress != nil {
+			progress(fmt.Sprintf(format, args...))
+		}
+	}
+
 	// Remove old version directory
 	oldDir := filepath.Join(pluginDir, name, oldVersion)
 	if oldVersion != newVersion {
 		_ = os.RemoveAll(oldDir)
 	}
 
-	// Update config
-	if updateErr := config.UpdateInstalledPluginVersion(name, newVersion); updateErr != nil {
-		if progress != nil {
-			progress(fmt.Sprintf("Warning: failed to update config: %v", updateErr))
+	// A pin on the replaced version follows the update
+	if active, _ := ReadActiveVersion(pluginDir, name); active == oldVersion && oldVersion != newVersion {
+		if pinErr := SetActiveVersion(pluginDir, name, newVersion); pinErr != nil {
+			warn("Warning: failed to update active version: %v", pinErr)
 		}
 	}
 
-	return &UpdateResult{
-		Name:       name,
-		OldVersion: oldVersion,
-		NewVersion: newVersion,
-		Path:       result.Path,
-	}, nil
+	// Update config
+	if updateErr := config.UpdateInstalledPluginVersion(name, newVersion); updateErr != nil {
+		warn("Warning: failed to update config: %v", updateErr)
+	}
 }
 
 // resolvePluginSource resolves the GitHub owner, repo, and asset hints for a plugin.
@@ -803,6 +1039,13 @@ func (i *Installer) RemoveOtherVersions(
 		result.BytesFreed += size
 	}
 
+	// Only keepVersion remains, so any pin now refers to a removed version
+	if active, _ := ReadActiveVersion(pluginDir, name); active != "" && active != keepVersion {
+		if clearErr := ClearActiveVersion(pluginDir, name); clearErr != nil && progress != nil {
+			progress(fmt.Sprintf("Warning: %v", clearErr))
+		}
+	}
+
 	return result, nil
 }
 
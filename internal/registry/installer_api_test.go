@@ -4,13 +4,17 @@ import (
 	"archive/tar"
 	"archive/zip"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"testing"
 
 	"github.com/rshade/finfocus/internal/config"
@@ -234,3 +238,81 @@ func createZip(path, filename string, content []byte) error {
 	}
 	return nil
 }
+
+func TestInstall_VerifiesReleaseChecksum(t *testing.T) {
+	ext := ".tar.gz"
+	if runtime.GOOS == "windows" {
+		ext = ".zip"
+	}
+	assetName := fmt.Sprintf("aws-public_v1.0.0_%s_%s%s", runtime.GOOS, runtime.GOARCH, ext)
+	archive := createMockArchive(t, "aws-public")
+	sum := sha256.Sum256(archive)
+
+	tests := []struct {
+		name      string
+		checksums string // empty: release publishes no checksums
+		opts      InstallOptions
+		wantErr   error
+	}{
+		{name: "matching checksum", checksums: hex.EncodeToString(sum[:]) + "  " + assetName + "\n"},
+		{
+			name:      "mismatched checksum",
+			checksums: strings.Repeat("0", sha256HexLen) + "  " + assetName + "\n",
+			wantErr:   ErrChecksumMismatch,
+		},
+		{name: "no checksums published"},
+		{name: "no checksums but required", opts: InstallOptions{RequireChecksum: true}, wantErr: ErrChecksumUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config.ResetGlobalConfigForTest()
+			tmpHome := t.TempDir()
+			t.Setenv("HOME", tmpHome)
+			config.InitGlobalConfig()
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/rshade/finfocus-plugin-aws-public/releases/latest":
+					release := GitHubRelease{TagName: "v1.0.0", Assets: []ReleaseAsset{
+						{Name: assetName, BrowserDownloadURL: "http://" + r.Host + "/download/asset"},
+					}}
+					if tt.checksums != "" {
+						release.Assets = append(release.Assets, ReleaseAsset{
+							Name: "checksums.txt", BrowserDownloadURL: "http://" + r.Host + "/download/checksums",
+						})
+					}
+					_ = json.NewEncoder(w).Encode(release)
+				case "/download/asset":
+					_, _ = w.Write(archive)
+				case "/download/checksums":
+					_, _ = w.Write([]byte(tt.checksums))
+				default:
+					http.NotFound(w, r)
+				}
+			}))
+			defer server.Close()
+
+			client := NewGitHubClient()
+			client.HTTPClient = server.Client()
+			client.BaseURL = server.URL
+			installer := NewInstallerWithClient(client, filepath.Join(tmpHome, "plugins"))
+
+			opts := tt.opts
+			opts.NoSave = true
+			result, err := installer.Install("aws-public", opts, nil)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected %v, got %v", tt.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Install failed: %v", err)
+			}
+			if tt.checksums != "" && result.Checksum != hex.EncodeToString(sum[:]) {
+				t.Errorf("expected verified checksum to be reported, got %q", result.Checksum)
+			}
+		})
+	}
+}
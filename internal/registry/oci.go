@@ -0,0 +1,434 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// OCI media types accepted when resolving a plugin artifact.
+const (
+	ociMediaTypeIndex        = "application/vnd.oci.image.index.v1+json"
+	ociMediaTypeManifest     = "application/vnd.oci.image.manifest.v1+json"
+	dockerMediaTypeList      = "application/vnd.docker.distribution.manifest.list.v2+json"
+	dockerMediaTypeManifest  = "application/vnd.docker.distribution.manifest.v2+json"
+	ociAnnotationTitle       = "org.opencontainers.image.title"
+	ociSchemePrefix          = "oci://"
+	ociTokenEnv              = "FINFOCUS_OCI_TOKEN"
+	ociMaxManifestBytes      = 4 * 1024 * 1024
+	ociAuthBearerPrefix      = "bearer "
+	ociAuthParamPartsPerPair = 2
+)
+
+// OCIReference identifies a plugin artifact repository in an OCI registry,
+// e.g. "ghcr.io/rshade/finfocus-plugin-kubecost".
+type OCIReference struct {
+	Registry   string
+	Repository string
+}
+
+// String returns the reference in "oci://registry/repository" form.
+func (r OCIReference) String() string {
+	return ociSchemePrefix + r.Registry + "/" + r.Repository
+}
+
+// ParseOCIReference parses "oci://registry/repository" (the scheme is optional).
+func ParseOCIReference(ref string) (OCIReference, error) {
+	trimmed := strings.TrimPrefix(ref, ociSchemePrefix)
+	registryHost, repository, ok := strings.Cut(trimmed, "/")
+	if !ok || registryHost == "" || repository == "" || strings.ContainsAny(repository, "@: ") {
+		return OCIReference{}, fmt.Errorf("invalid OCI reference %q (expected oci://registry/repository)", ref)
+	}
+	return OCIReference{Registry: registryHost, Repository: repository}, nil
+}
+
+// ociDescriptor describes a manifest or blob in an OCI registry.
+type ociDescriptor struct {
+	MediaType   string            `json:"mediaType"`
+	Digest      string            `json:"digest"`
+	Size        int64             `json:"size"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	Platform    *struct {
+		OS           string `json:"os"`
+		Architecture string `json:"architecture"`
+	} `json:"platform,omitempty"`
+}
+
+// ociManifest is the subset of an OCI image manifest or index used for plugin artifacts.
+type ociManifest struct {
+	MediaType string          `json:"mediaType"`
+	Manifests []ociDescriptor `json:"manifests,omitempty"`
+	Layers    []ociDescriptor `json:"layers,omitempty"`
+}
+
+// OCIArtifact is a resolved plugin archive stored as a blob in an OCI registry.
+type OCIArtifact struct {
+	Reference OCIReference
+	Tag       string
+	// Name is the archive file name from the layer's title annotation.
+	Name   string
+	Digest string
+	Size   int64
+}
+
+// OCIClient downloads plugin archives published as OCI artifacts (for example
+// with `oras push`). It speaks the OCI distribution API directly and supports
+// anonymous bearer-token auth, or a token from FINFOCUS_OCI_TOKEN.
+type OCIClient struct {
+	HTTPClient *http.Client
+	// Scheme is the URL scheme used to reach registries; "https" by default.
+	Scheme string
+	token  string
+}
+
+// NewOCIClient creates an OCIClient using the package download timeout.
+func NewOCIClient() *OCIClient {
+	return &OCIClient{
+		HTTPClient: &http.Client{Timeout: downloadTimeout},
+		Scheme:     "https",
+		token:      os.Getenv(ociTokenEnv),
+	}
+}
+
+// ResolveArtifact finds the archive for the current platform in the artifact
+// tagged tag. Image indexes are resolved to the manifest for runtime.GOOS and
+// runtime.GOARCH; within a manifest, layers are matched by their title
+// annotation using the same naming rules as GitHub release assets.
+func (c *OCIClient) ResolveArtifact(
+	ref OCIReference,
+	tag, projectName string,
+	hints *AssetNamingHints,
+) (*OCIArtifact, error) {
+	manifest, err := c.fetchManifest(ref, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(manifest.Manifests) > 0 {
+		desc, platformErr := selectPlatformManifest(manifest.Manifests)
+		if platformErr != nil {
+			return nil, platformErr
+		}
+		manifest, err = c.fetchManifest(ref, desc.Digest)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	layer, err := selectArtifactLayer(manifest.Layers, tag, projectName, hints)
+	if err != nil {
+		return nil, err
+	}
+	return &OCIArtifact{
+		Reference: ref,
+		Tag:       tag,
+		Name:      layer.Annotations[ociAnnotationTitle],
+		Digest:    layer.Digest,
+		Size:      layer.Size,
+	}, nil
+}
+
+// LatestTag returns the highest semantic-version tag in the repository.
+// Tags that are not valid semantic versions, and prereleases, are ignored.
+func (c *OCIClient) LatestTag(ref OCIReference) (string, error) {
+	resp, err := c.get(ref, c.endpoint(ref, "tags/list"), "application/json")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+
+	var tags struct {
+		Tags []string `json:"tags"`
+	}
+	if decodeErr := json.NewDecoder(io.LimitReader(resp.Body, ociMaxManifestBytes)).Decode(&tags); decodeErr != nil {
+		return "", fmt.Errorf("decoding tag list for %s: %w", ref, decodeErr)
+	}
+
+	var latest string
+	var latestVer *semver.Version
+	for _, tag := range tags.Tags {
+		v, parseErr := semver.NewVersion(tag)
+		if parseErr != nil || v.Prerelease() != "" {
+			continue
+		}
+		if latestVer == nil || v.GreaterThan(latestVer) {
+			latest, latestVer = tag, v
+		}
+	}
+	if latest == "" {
+		return "", fmt.Errorf("no semantic version tags found for %s", ref)
+	}
+	return latest, nil
+}
+
+// DownloadBlob streams the artifact blob to destPath and verifies it against
+// its content digest, returning ErrChecksumMismatch on mismatch.
+//
+//nolint:mnd // buffer size
+func (c *OCIClient) DownloadBlob(
+	artifact *OCIArtifact,
+	destPath string,
+	progress func(downloaded, total int64),
+) error {
+	algo, expected, ok := strings.Cut(artifact.Digest, ":")
+	if !ok || algo != "sha256" {
+		return fmt.Errorf("unsupported digest %q", artifact.Digest)
+	}
+
+	resp, err := c.get(artifact.Reference, c.endpoint(artifact.Reference, "blobs/"+artifact.Digest), "")
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	out, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	defer out.Close()
+
+	h := sha256.New()
+	var downloaded int64
+	buf := make([]byte, 32*1024)
+	for {
+		n, readErr := resp.Body.Read(buf)
+		if n > 0 {
+			if _, writeErr := io.MultiWriter(out, h).Write(buf[:n]); writeErr != nil {
+				return fmt.Errorf("failed to write: %w", writeErr)
+			}
+			downloaded += int64(n)
+			if progress != nil {
+				progress(downloaded, artifact.Size)
+			}
+		}
+		if errors.Is(readErr, io.EOF) {
+			break
+		}
+		if readErr != nil {
+			return fmt.Errorf("read error: %w", readErr)
+		}
+	}
+
+	if actual := hex.EncodeToString(h.Sum(nil)); actual != expected {
+		return fmt.Errorf("%w: expected sha256 %s, got %s", ErrChecksumMismatch, expected, actual)
+	}
+	return nil
+}
+
+// fetchManifest fetches and decodes the manifest or index for reference (a tag or digest).
+func (c *OCIClient) fetchManifest(ref OCIReference, reference string) (*ociManifest, error) {
+	accept := strings.Join([]string{
+		ociMediaTypeIndex, ociMediaTypeManifest, dockerMediaTypeList, dockerMediaTypeManifest,
+	}, ", ")
+	resp, err := c.get(ref, c.endpoint(ref, "manifests/"+reference), accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var manifest ociManifest
+	if decodeErr := json.NewDecoder(io.LimitReader(resp.Body, ociMaxManifestBytes)).Decode(&manifest); decodeErr != nil {
+		return nil, fmt.Errorf("decoding manifest %s@%s: %w", ref, reference, decodeErr)
+	}
+	return &manifest, nil
+}
+
+func (c *OCIClient) endpoint(ref OCIReference, path string) string {
+	scheme := c.Scheme
+	if scheme == "" {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s", scheme, ref.Registry, ref.Repository, path)
+}
+
+// get performs a GET, answering a bearer-token challenge once if the registry
+// requires auth. The caller must close the response body on success.
+//
+//nolint:noctx // context not needed for downloads, matching GitHubClient
+func (c *OCIClient) get(ref OCIReference, endpoint, accept string) (*http.Response, error) {
+	token := c.token
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, endpoint, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := c.HTTPClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("request to %s failed: %w", ref.Registry, err)
+		}
+		if resp.StatusCode == http.StatusOK {
+			return resp, nil
+		}
+		_ = resp.Body.Close()
+
+		if resp.StatusCode == http.StatusUnauthorized && attempt == 0 {
+			token, err = c.fetchToken(resp.Header.Get("WWW-Authenticate"))
+			if err != nil {
+				return nil, fmt.Errorf("authenticating to %s: %w", ref.Registry, err)
+			}
+			continue
+		}
+		if resp.StatusCode == http.StatusNotFound {
+			return nil, fmt.Errorf("not found: %s", endpoint)
+		}
+		return nil, fmt.Errorf("registry %s returned status %d", ref.Registry, resp.StatusCode)
+	}
+}
+
+// fetchToken exchanges a "Bearer realm=...,service=...,scope=..." challenge
+// for a registry token. FINFOCUS_OCI_TOKEN, when set, is sent as a password
+// for private repositories; otherwise the token is requested anonymously.
+//
+//nolint:noctx // context not needed, matching GitHubClient
+func (c *OCIClient) fetchToken(challenge string) (string, error) {
+	if !strings.HasPrefix(strings.ToLower(challenge), ociAuthBearerPrefix) {
+		return "", fmt.Errorf("unsupported auth challenge %q", challenge)
+	}
+	params := parseAuthParams(challenge[len(ociAuthBearerPrefix):])
+	realm := params["realm"]
+	if realm == "" {
+		return "", errors.New("auth challenge has no realm")
+	}
+
+	q := url.Values{}
+	for _, key := range []string{"service", "scope"} {
+		if v := params[key]; v != "" {
+			q.Set(key, v)
+		}
+	}
+	req, err := http.NewRequest(http.MethodGet, realm+"?"+q.Encode(), nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	if c.token != "" {
+		req.SetBasicAuth("finfocus", c.token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("token request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token request returned status %d", resp.StatusCode)
+	}
+
+	var body struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if decodeErr := json.NewDecoder(resp.Body).Decode(&body); decodeErr != nil {
+		return "", fmt.Errorf("decoding token response: %w", decodeErr)
+	}
+	if body.Token != "" {
+		return body.Token, nil
+	}
+	if body.AccessToken != "" {
+		return body.AccessToken, nil
+	}
+	return "", errors.New("token response contained no token")
+}
+
+// parseAuthParams parses comma-separated key="value" pairs from an auth challenge.
+func parseAuthParams(s string) map[string]string {
+	params := make(map[string]string)
+	for _, part := range splitAuthParams(s) {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", ociAuthParamPartsPerPair)
+		if len(kv) != ociAuthParamPartsPerPair {
+			continue
+		}
+		params[strings.ToLower(kv[0])] = strings.Trim(kv[1], `"`)
+	}
+	return params
+}
+
+// splitAuthParams splits on commas outside double quotes; scopes such as
+// "repository:a/b:pull,push" contain commas.
+func splitAuthParams(s string) []string {
+	var parts []string
+	var current strings.Builder
+	inQuotes := false
+	for _, r := range s {
+		switch {
+		case r == '"':
+			inQuotes = !inQuotes
+			current.WriteRune(r)
+		case r == ',' && !inQuotes:
+			parts = append(parts, current.String())
+			current.Reset()
+		default:
+			current.WriteRune(r)
+		}
+	}
+	return append(parts, current.String())
+}
+
+// selectPlatformManifest picks the index entry for the current platform.
+func selectPlatformManifest(manifests []ociDescriptor) (*ociDescriptor, error) {
+	var available []string
+	for i := range manifests {
+		p := manifests[i].Platform
+		if p == nil {
+			continue
+		}
+		if p.OS == runtime.GOOS && p.Architecture == runtime.GOARCH {
+			return &manifests[i], nil
+		}
+		available = append(available, p.OS+"/"+p.Architecture)
+	}
+	return nil, fmt.Errorf("no asset found for %s/%s. Available: %v", runtime.GOOS, runtime.GOARCH, available)
+}
+
+// selectArtifactLayer matches titled layers against the platform asset naming
+// rules. A single layer in a platform-specific manifest is used as-is.
+func selectArtifactLayer(
+	layers []ociDescriptor,
+	tag, projectName string,
+	hints *AssetNamingHints,
+) (*ociDescriptor, error) {
+	if len(layers) == 0 {
+		return nil, errors.New("artifact has no layers")
+	}
+
+	release := &GitHubRelease{TagName: tag}
+	for _, l := range layers {
+		if title := l.Annotations[ociAnnotationTitle]; title != "" {
+			release.Assets = append(release.Assets, ReleaseAsset{Name: title, Size: l.Size})
+		}
+	}
+
+	asset, err := FindPlatformAssetWithHints(release, projectName, hints)
+	if err != nil {
+		if len(layers) == 1 && isArchiveName(layers[0].Annotations[ociAnnotationTitle]) {
+			return &layers[0], nil
+		}
+		return nil, err
+	}
+	for i := range layers {
+		if layers[i].Annotations[ociAnnotationTitle] == asset.Name {
+			return &layers[i], nil
+		}
+	}
+	return nil, fmt.Errorf("layer for %s not found", asset.Name)
+}
+
+func isArchiveName(name string) bool {
+	return strings.HasSuffix(name, extTarGz) || strings.HasSuffix(name, ".tgz") || strings.HasSuffix(name, extZip)
+}
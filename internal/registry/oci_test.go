@@ -0,0 +1,181 @@
+package registry
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// newMockOCIRegistry serves a multi-platform plugin artifact for
+// "finfocus/finfocus-plugin-kubecost" behind a bearer-token challenge.
+func newMockOCIRegistry(t *testing.T, archive []byte, blobDigest string) *httptest.Server {
+	t.Helper()
+
+	ext := extTarGz
+	if runtime.GOOS == osWindows {
+		ext = extZip
+	}
+	title := fmt.Sprintf("kubecost_v1.2.0_%s_%s%s", runtime.GOOS, runtime.GOARCH, ext)
+
+	platformManifest, err := json.Marshal(map[string]any{
+		"mediaType": ociMediaTypeManifest,
+		"layers": []map[string]any{{
+			"mediaType":   "application/vnd.oci.image.layer.v1.tar+gzip",
+			"digest":      blobDigest,
+			"size":        len(archive),
+			"annotations": map[string]string{ociAnnotationTitle: title},
+		}},
+	})
+	require.NoError(t, err)
+	platformDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(platformManifest))
+
+	index, err := json.Marshal(map[string]any{
+		"mediaType": ociMediaTypeIndex,
+		"manifests": []map[string]any{
+			{"digest": "sha256:other", "platform": map[string]string{"os": "plan9", "architecture": "mips"}},
+			{"digest": platformDigest, "platform": map[string]string{
+				"os": runtime.GOOS, "architecture": runtime.GOARCH,
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	const repo = "/v2/finfocus/finfocus-plugin-kubecost/"
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/token" {
+			assert.Equal(t, "repository:finfocus/finfocus-plugin-kubecost:pull", r.URL.Query().Get("scope"))
+			_ = json.NewEncoder(w).Encode(map[string]string{"token": "anon"})
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer anon" {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="%s/token",service="mock",scope="repository:finfocus/finfocus-plugin-kubecost:pull"`,
+				server.URL))
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		switch r.URL.Path {
+		case repo + "manifests/v1.2.0":
+			_, _ = w.Write(index)
+		case repo + "manifests/" + platformDigest:
+			_, _ = w.Write(platformManifest)
+		case repo + "blobs/" + blobDigest:
+			_, _ = w.Write(archive)
+		case repo + "tags/list":
+			_ = json.NewEncoder(w).Encode(map[string][]string{"tags": {"v1.0.0", "v1.2.0", "v2.0.0-rc.1", "latest"}})
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func newTestOCIInstaller(t *testing.T, server *httptest.Server, pluginDir string) *Installer {
+	t.Helper()
+	installer := NewInstaller(pluginDir)
+	installer.oci = &OCIClient{HTTPClient: server.Client(), Scheme: "http"}
+	return installer
+}
+
+func TestInstall_FromOCI(t *testing.T) {
+	config.ResetGlobalConfigForTest()
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	config.InitGlobalConfig()
+
+	archive := createMockArchive(t, "kubecost")
+	sum := sha256.Sum256(archive)
+	server := newMockOCIRegistry(t, archive, "sha256:"+hex.EncodeToString(sum[:]))
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	pluginDir := filepath.Join(tmpHome, "plugins")
+	installer := newTestOCIInstaller(t, server, pluginDir)
+
+	spec := fmt.Sprintf("oci://%s/finfocus/finfocus-plugin-kubecost@v1.2.0", host)
+	result, err := installer.Install(spec, InstallOptions{Activate: true}, nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, "kubecost", result.Name)
+	assert.Equal(t, "v1.2.0", result.Version)
+	assert.Equal(t, hex.EncodeToString(sum[:]), result.Checksum)
+	assert.NotEmpty(t, findPluginBinary(filepath.Join(pluginDir, "kubecost", "v1.2.0"), "kubecost"))
+
+	active, err := ReadActiveVersion(pluginDir, "kubecost")
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", active)
+
+	installed, err := config.GetInstalledPlugin("kubecost")
+	require.NoError(t, err)
+	assert.Equal(t, "oci://"+host+"/finfocus/finfocus-plugin-kubecost", installed.URL)
+}
+
+func TestInstall_FromOCI_DigestMismatch(t *testing.T) {
+	config.ResetGlobalConfigForTest()
+	tmpHome := t.TempDir()
+	t.Setenv("HOME", tmpHome)
+	config.InitGlobalConfig()
+
+	wrongDigest := "sha256:" + strings.Repeat("0", sha256HexLen)
+	server := newMockOCIRegistry(t, createMockArchive(t, "kubecost"), wrongDigest)
+	host := strings.TrimPrefix(server.URL, "http://")
+
+	pluginDir := filepath.Join(tmpHome, "plugins")
+	installer := newTestOCIInstaller(t, server, pluginDir)
+
+	_, err := installer.Install(
+		fmt.Sprintf("oci://%s/finfocus/finfocus-plugin-kubecost@v1.2.0", host), InstallOptions{NoSave: true}, nil)
+	require.ErrorIs(t, err, ErrChecksumMismatch)
+	_, statErr := os.Stat(filepath.Join(pluginDir, "kubecost", "v1.2.0"))
+	assert.True(t, os.IsNotExist(statErr), "nothing is installed when verification fails")
+}
+
+func TestOCIClient_LatestTag(t *testing.T) {
+	server := newMockOCIRegistry(t, nil, "sha256:unused")
+	client := &OCIClient{HTTPClient: server.Client(), Scheme: "http"}
+
+	tag, err := client.LatestTag(OCIReference{
+		Registry:   strings.TrimPrefix(server.URL, "http://"),
+		Repository: "finfocus/finfocus-plugin-kubecost",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "v1.2.0", tag, "prereleases and non-semver tags are ignored")
+}
+
+func TestParsePluginSpecifier_OCI(t *testing.T) {
+	spec, err := ParsePluginSpecifier("oci://ghcr.io/rshade/finfocus-plugin-kubecost@v1.0.0")
+	require.NoError(t, err)
+	require.NotNil(t, spec.OCI)
+	assert.Equal(t, "kubecost", spec.Name)
+	assert.Equal(t, "v1.0.0", spec.Version)
+	assert.Equal(t, "ghcr.io", spec.OCI.Registry)
+	assert.Equal(t, "rshade/finfocus-plugin-kubecost", spec.OCI.Repository)
+	assert.False(t, spec.IsURL)
+
+	_, err = ParsePluginSpecifier("oci://ghcr.io/rshade/finfocus-plugin-kubecost")
+	require.ErrorContains(t, err, "require a version tag")
+
+	_, err = ParsePluginSpecifier("oci://ghcr.io@v1.0.0")
+	require.ErrorContains(t, err, "invalid OCI reference")
+}
+
+func TestParseAuthParams(t *testing.T) {
+	params := parseAuthParams(`realm="https://ghcr.io/token",service="ghcr.io",scope="repository:a/b:pull,push"`)
+	assert.Equal(t, "https://ghcr.io/token", params["realm"])
+	assert.Equal(t, "ghcr.io", params["service"])
+	assert.Equal(t, "repository:a/b:pull,push", params["scope"])
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/rshade/finfocus/internal/config"
 	"github.com/rshade/finfocus/internal/logging"
 	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/pluginruntime"
 	"github.com/rshade/finfocus/internal/proto"
 )
 
@@ -32,6 +33,13 @@ func NewDefault() *Registry {
 	}
 }
 
+// WithLauncher makes Open start plugins with launcher, for example to reach
+// them through the plugin daemon instead of starting processes.
+func (r *Registry) WithLauncher(launcher pluginhost.Launcher) *Registry {
+	r.launcher = launcher
+	return r
+}
+
 // ListPlugins scans the plugin directory and returns metadata for all discovered plugins.
 // It returns an empty list if the plugin directory doesn't exist.
 //
@@ -90,10 +98,13 @@ func (r *Registry) ListPlugins() ([]PluginInfo, error) {
 	return plugins, nil
 }
 
-// ListLatestPlugins scans the plugin directory and returns only the latest version
-// of each plugin. Plugins with same name in different locations are treated as
+// ListLatestPlugins scans the plugin directory and returns only the active version
+// of each plugin: the version pinned with SetActiveVersion when it is installed,
+// otherwise the latest. Plugins with same name in different locations are treated as
 // duplicates and the latest version across all locations is selected.
-// Returns warnings for invalid or corrupted plugins.
+// Returns warnings for invalid or corrupted plugins and for stale pins.
+//
+//nolint:gocognit // Pin resolution adds a branch to the latest-version scan.
 func (r *Registry) ListLatestPlugins() ([]PluginInfo, []string, error) {
 	allPlugins, err := r.ListPlugins()
 	if err != nil {
@@ -101,9 +112,14 @@ func (r *Registry) ListLatestPlugins() ([]PluginInfo, []string, error) {
 	}
 
 	latest := make(map[string]PluginInfo)
+	pinned := make(map[string]PluginInfo)
 	var warnings []string
 
 	for _, plugin := range allPlugins {
+		if active, _ := ReadActiveVersion(r.root, plugin.Name); active != "" && active == plugin.Version {
+			pinned[plugin.Name] = plugin
+		}
+
 		v, verErr := semver.NewVersion(plugin.Version)
 		if verErr != nil {
 			warnings = append(warnings,
@@ -124,6 +140,20 @@ func (r *Registry) ListLatestPlugins() ([]PluginInfo, []string, error) {
 		}
 	}
 
+	for name, plugin := range latest {
+		if _, ok := pinned[name]; ok {
+			continue
+		}
+		if active, _ := ReadActiveVersion(r.root, name); active != "" {
+			warnings = append(warnings,
+				fmt.Sprintf("Plugin %s is pinned to %s, which is not installed; using %s",
+					name, active, plugin.Version))
+		}
+	}
+	for name, plugin := range pinned {
+		latest[name] = plugin
+	}
+
 	result := make([]PluginInfo, 0, len(latest))
 	for _, plugin := range latest {
 		result = append(result, plugin)
@@ -132,7 +162,7 @@ func (r *Registry) ListLatestPlugins() ([]PluginInfo, []string, error) {
 	return result, warnings, nil
 }
 
-// GetLatestPlugin returns the latest version of a specific plugin.
+// GetLatestPlugin returns the active version of a specific plugin (see ListLatestPlugins).
 // Returns (PluginInfo{}, false, warnings) if plugin not found or all versions are invalid.
 func (r *Registry) GetLatestPlugin(name string) (PluginInfo, bool, []string, error) {
 	plugins, warnings, err := r.ListLatestPlugins()
@@ -311,6 +341,11 @@ func (r *Registry) Open(
 		// didn't report it via GetPluginInfo.
 		mergeRegistryMetadata(client, plugin)
 
+		// Apply the configured timeout, retry, and circuit-breaker policy to
+		// every cost RPC made through this client for the rest of the run.
+		client.API = pluginruntime.Wrap(client.Name, client.API, pluginruntime.PolicyFromConfig(
+			config.GetGlobalConfig().PluginHostConfig.ResilienceFor(plugin.Name)))
+
 		log.Debug().
 			Ctx(ctx).
 			Str("component", "registry").
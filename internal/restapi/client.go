@@ -0,0 +1,83 @@
+// Package restapi holds what the REST API clients of finfocus share: sending
+// JSON requests with bounded error reports, and keeping a single "sticky"
+// comment on a pull request, merge request, or issue of a code host.
+package restapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// MaxErrorBody limits how much of an error response is echoed back to the user.
+const MaxErrorBody = 512
+
+// ErrorBody returns the start of the body of a failed response, trimmed, for
+// error messages.
+func ErrorBody(body io.Reader) string {
+	data, _ := io.ReadAll(io.LimitReader(body, MaxErrorBody))
+	return strings.TrimSpace(string(data))
+}
+
+// Client sends JSON requests to a REST API.
+type Client struct {
+	// HTTPClient sends the requests.
+	HTTPClient *http.Client
+	// API names the API in errors, such as "GitHub".
+	API string
+	// Header holds the authentication and any other headers of every request.
+	Header http.Header
+	// ErrFailed is wrapped by the errors of failed requests.
+	ErrFailed error
+	// AuthHint follows requests refused with HTTP 401 or 403, such as
+	// "check that GITHUB_TOKEN can write pull request comments".
+	AuthHint string
+}
+
+// Do sends payload, when not nil, as the JSON body of a request and decodes
+// the JSON response into out.
+func (c *Client) Do(ctx context.Context, method, endpoint string, payload, out any) error {
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return fmt.Errorf("encoding %s request: %w", c.API, err)
+		}
+		reqBody = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reqBody)
+	if err != nil {
+		return fmt.Errorf("creating %s request: %w", c.API, err)
+	}
+	for key, values := range c.Header {
+		req.Header[key] = values
+	}
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("%w: %w", c.ErrFailed, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch {
+	case resp.StatusCode == http.StatusUnauthorized || resp.StatusCode == http.StatusForbidden:
+		return fmt.Errorf("%w: %s %s: HTTP %d; %s",
+			c.ErrFailed, method, req.URL.Path, resp.StatusCode, c.AuthHint)
+	case resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices:
+		return fmt.Errorf("%w: %s %s: HTTP %d: %s",
+			c.ErrFailed, method, req.URL.Path, resp.StatusCode, ErrorBody(resp.Body))
+	}
+
+	if err = json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("%w: decoding response: %w", c.ErrFailed, err)
+	}
+	return nil
+}
@@ -0,0 +1,160 @@
+package restapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errTestFailed = errors.New("test request failed")
+
+func newTestClient() *Client {
+	return &Client{
+		HTTPClient: http.DefaultClient,
+		API:        "Test",
+		Header:     http.Header{"Authorization": {"Bearer secret"}},
+		ErrFailed:  errTestFailed,
+		AuthHint:   "check the token",
+	}
+}
+
+func TestClient_Do(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		switch r.URL.Path {
+		case "/echo":
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			var payload map[string]string
+			_ = json.NewDecoder(r.Body).Decode(&payload)
+			_ = json.NewEncoder(w).Encode(payload)
+		case "/denied":
+			w.WriteHeader(http.StatusForbidden)
+		default:
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(strings.Repeat("x", 2*MaxErrorBody)))
+		}
+	}))
+	t.Cleanup(server.Close)
+	client, base := newTestClient(), server.URL
+
+	var out map[string]string
+	payload := map[string]string{"a": "b"}
+	require.NoError(t, client.Do(context.Background(), http.MethodPost, base+"/echo", payload, &out))
+	assert.Equal(t, map[string]string{"a": "b"}, out)
+
+	err := client.Do(context.Background(), http.MethodGet, base+"/denied", nil, &out)
+	require.ErrorIs(t, err, errTestFailed)
+	assert.Contains(t, err.Error(), "HTTP 403; check the token")
+
+	err = client.Do(context.Background(), http.MethodGet, base+"/broken", nil, &out)
+	require.ErrorIs(t, err, errTestFailed)
+	assert.Contains(t, err.Error(), "HTTP 500: "+strings.Repeat("x", MaxErrorBody))
+	assert.NotContains(t, err.Error(), strings.Repeat("x", MaxErrorBody+1))
+}
+
+func TestNextPage(t *testing.T) {
+	assert.Empty(t, NextPage("https://api.test/comments?page=1&per_page=2", 1, 2))
+	assert.Equal(t, "https://api.test/comments?page=3&per_page=2",
+		NextPage("https://api.test/comments?page=2&per_page=2", 2, 2))
+	assert.Equal(t, "https://api.test/comments?page=2", NextPage("https://api.test/comments", 2, 2))
+}
+
+// fakeComment is a comment of fakeThread.
+type fakeComment struct {
+	author, body string
+}
+
+// fakeThread keeps comments in pages of two in memory and authenticates as
+// user.
+type fakeThread struct {
+	user     string
+	comments []fakeComment
+}
+
+func (f *fakeThread) FirstPage() string { return "0" }
+
+func (f *fakeThread) Page(_ context.Context, endpoint string) ([]fakeComment, string, error) {
+	from := map[string]int{"0": 0, "2": 2, "4": 4}[endpoint]
+	to := min(from+2, len(f.comments))
+	next := ""
+	if to < len(f.comments) {
+		next = map[int]string{2: "2", 4: "4"}[to]
+	}
+	return append([]fakeComment(nil), f.comments[from:to]...), next, nil
+}
+
+func (f *fakeThread) Body(comment *fakeComment) string { return comment.body }
+
+func (f *fakeThread) Author(comment *fakeComment) string { return comment.author }
+
+func (f *fakeThread) CurrentUser(context.Context) (string, error) { return f.user, nil }
+
+func (f *fakeThread) Create(_ context.Context, body string) (*fakeComment, error) {
+	f.comments = append(f.comments, fakeComment{author: f.user, body: body})
+	return &f.comments[len(f.comments)-1], nil
+}
+
+func (f *fakeThread) Update(_ context.Context, comment *fakeComment, body string) (*fakeComment, error) {
+	for i := range f.comments {
+		if f.comments[i] == *comment {
+			f.comments[i].body = body
+			return &f.comments[i], nil
+		}
+	}
+	return nil, errTestFailed
+}
+
+func TestUpsertComment(t *testing.T) {
+	thread := &fakeThread{user: "bot", comments: []fakeComment{
+		{"alice", "LGTM"}, {"alice", "nit"}, {"bot", "<!-- m --> old"},
+	}}
+
+	comment, created, err := UpsertComment[fakeComment](context.Background(), thread, "<!-- m -->", "<!-- m --> new")
+	require.NoError(t, err)
+	assert.False(t, created, "the marked comment on the second page is updated")
+	assert.Equal(t, "<!-- m --> new", comment.body)
+	assert.Equal(t, []fakeComment{{"alice", "LGTM"}, {"alice", "nit"}, {"bot", "<!-- m --> new"}}, thread.comments)
+
+	_, created, err = UpsertComment[fakeComment](context.Background(), thread, "<!-- other -->", "<!-- other -->")
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Len(t, thread.comments, 4)
+}
+
+func TestUpsertComment_IgnoresOtherAuthors(t *testing.T) {
+	thread := &fakeThread{user: "bot", comments: []fakeComment{{"mallory", "quoting <!-- m --> here"}}}
+
+	comment, created, err := UpsertComment[fakeComment](context.Background(), thread, "<!-- m -->", "<!-- m --> new")
+	require.NoError(t, err)
+	assert.True(t, created, "a marked comment of another user is left alone")
+	assert.Equal(t, "bot", comment.author)
+	assert.Equal(t, fakeComment{"mallory", "quoting <!-- m --> here"}, thread.comments[0])
+}
+
+func TestUser_Get(t *testing.T) {
+	var user User
+	calls := 0
+	fetch := func(context.Context) (string, error) {
+		calls++
+		if calls == 1 {
+			return "", errTestFailed
+		}
+		return "bot", nil
+	}
+
+	_, err := user.Get(context.Background(), fetch)
+	require.ErrorIs(t, err, errTestFailed)
+	for range 2 {
+		name, getErr := user.Get(context.Background(), fetch)
+		require.NoError(t, getErr)
+		assert.Equal(t, "bot", name)
+	}
+	assert.Equal(t, 2, calls, "a failed lookup is retried and a successful one remembered")
+}
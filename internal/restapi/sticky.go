@@ -0,0 +1,110 @@
+package restapi
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Thread is the comment thread of one pull request, merge request, or issue
+// on a code host, whose comments are of type C.
+type Thread[C any] interface {
+	// FirstPage returns the endpoint of the first page of comments.
+	FirstPage() string
+	// Page returns the comments at endpoint and the endpoint of the next
+	// page, or "" after the last page.
+	Page(ctx context.Context, endpoint string) ([]C, string, error)
+	// Body returns the text of comment.
+	Body(comment *C) string
+	// Author returns the user who wrote comment, as CurrentUser reports it.
+	Author(comment *C) string
+	// CurrentUser returns the user the client authenticates as.
+	CurrentUser(ctx context.Context) (string, error)
+	// Create adds a comment with body.
+	Create(ctx context.Context, body string) (*C, error)
+	// Update replaces the text of comment with body.
+	Update(ctx context.Context, comment *C, body string) (*C, error)
+}
+
+// UpsertComment keeps a single "sticky" comment on thread: the first comment
+// of the current user whose body contains marker is replaced with body, and a
+// new comment is created when there is none. Comments of other users are never
+// edited, even when they quote marker. body should contain marker so the next call
+// finds it. It reports whether a comment was created.
+func UpsertComment[C any](ctx context.Context, thread Thread[C], marker, body string) (*C, bool, error) {
+	existing, err := findComment(ctx, thread, marker)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing != nil {
+		comment, updateErr := thread.Update(ctx, existing, body)
+		return comment, false, updateErr
+	}
+	comment, err := thread.Create(ctx, body)
+	return comment, err == nil, err
+}
+
+// findComment returns the first comment of thread written by the current user
+// and containing marker, or nil when there is none.
+func findComment[C any](ctx context.Context, thread Thread[C], marker string) (*C, error) {
+	user, err := thread.CurrentUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for endpoint := thread.FirstPage(); endpoint != ""; {
+		comments, next, err := thread.Page(ctx, endpoint)
+		if err != nil {
+			return nil, err
+		}
+		for i := range comments {
+			if thread.Author(&comments[i]) == user && strings.Contains(thread.Body(&comments[i]), marker) {
+				return &comments[i], nil
+			}
+		}
+		endpoint = next
+	}
+	return nil, nil //nolint:nilnil // no matching comment
+}
+
+// User remembers the user a client authenticates as, so a client looks it up
+// once however many comments it keeps. The zero value is ready to use.
+type User struct {
+	mu   sync.Mutex
+	name string
+}
+
+// Get returns the remembered user, calling fetch to look it up on first use
+// and again after a failed lookup.
+func (u *User) Get(ctx context.Context, fetch func(context.Context) (string, error)) (string, error) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	if u.name != "" {
+		return u.name, nil
+	}
+	name, err := fetch(ctx)
+	if err != nil {
+		return "", err
+	}
+	u.name = name
+	return name, nil
+}
+
+// NextPage returns the endpoint of the page after endpoint for APIs paged by
+// a page query parameter, or "" when a page of count items out of perPage
+// was the last.
+func NextPage(endpoint string, count, perPage int) string {
+	if count < perPage {
+		return ""
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return ""
+	}
+	query := u.Query()
+	page, _ := strconv.Atoi(query.Get("page"))
+	query.Set("page", strconv.Itoa(max(page, 1)+1))
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -1,6 +1,10 @@
 package router
 
-import "strings"
+import (
+	"strings"
+
+	"github.com/rshade/finfocus/internal/skus"
+)
 
 // ProviderUnknown is the sentinel value for resources with indeterminate providers.
 const ProviderUnknown = "unknown"
@@ -31,6 +35,7 @@ const ProviderWildcard = "*"
 //
 // resourceType is the Pulumi resource type string (e.g. "aws:s3/bucket:Bucket").
 // The returned string is the provider name (e.g. "aws") or the ProviderUnknown sentinel when indeterminate.
+// A namespace configured in cost.provider_aliases is replaced by the provider it aliases.
 func ExtractProviderFromType(resourceType string) string {
 	if resourceType == "" {
 		return ProviderUnknown
@@ -38,7 +43,7 @@ func ExtractProviderFromType(resourceType string) string {
 
 	parts := strings.Split(resourceType, ":")
 	if parts[0] != "" {
-		return parts[0]
+		return skus.CanonicalProvider(parts[0])
 	}
 
 	return ProviderUnknown
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/rshade/finfocus/internal/skus"
 )
 
 func TestExtractProviderFromType(t *testing.T) {
@@ -76,6 +78,14 @@ func TestExtractProviderFromType(t *testing.T) {
 	}
 }
 
+func TestExtractProviderFromType_Alias(t *testing.T) {
+	skus.SetProviderAliases(map[string]string{"my-org": "aws"})
+	t.Cleanup(func() { skus.SetProviderAliases(nil) })
+
+	assert.Equal(t, "aws", ExtractProviderFromType("my-org:aws-wrapper:Instance"))
+	assert.Equal(t, "gcp", ExtractProviderFromType("gcp:compute:Instance"))
+}
+
 func TestIsGlobalProvider(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -0,0 +1,275 @@
+// Package secrets resolves references to secrets kept outside the config
+// file, so that API tokens and webhook URLs need not be stored in plain
+// text. A reference names where the secret is kept:
+//
+//   - env://VAR reads the environment variable VAR.
+//   - file://path reads a file, without its trailing newline. Relative paths
+//     are relative to the resolver's base directory.
+//   - keychain://service[/account] reads the OS keychain: the macOS login
+//     keychain through the security tool, or the Secret Service (GNOME
+//     Keyring, KWallet) on Linux through secret-tool.
+//   - aws-sm://secret-name[#json-key] reads AWS Secrets Manager through the
+//     aws CLI, with its usual credentials and region. With #json-key, the
+//     secret is a JSON object and the value of the key is returned.
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrUnresolved is returned when a reference cannot be resolved.
+var ErrUnresolved = errors.New("cannot resolve secret reference")
+
+// Reference schemes.
+const (
+	SchemeEnv      = "env"
+	SchemeFile     = "file"
+	SchemeKeychain = "keychain"
+	SchemeAWSSM    = "aws-sm"
+)
+
+// commandTimeout bounds the keychain and Secrets Manager lookups.
+const commandTimeout = 30 * time.Second
+
+// CommandRunner executes an external command and returns its stdout, stderr,
+// and error. It allows the keychain and Secrets Manager lookups to be tested
+// without spawning real subprocesses.
+type CommandRunner interface {
+	Run(ctx context.Context, name string, args ...string) (stdout []byte, stderr []byte, err error)
+}
+
+// execRunner is the default CommandRunner using exec.CommandContext.
+type execRunner struct{}
+
+func (execRunner) Run(ctx context.Context, name string, args ...string) ([]byte, []byte, error) {
+	cmd := exec.CommandContext(ctx, name, args...)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	err := cmd.Run()
+	return stdout.Bytes(), stderr.Bytes(), err
+}
+
+// IsReference reports whether value is a secret reference of a supported
+// scheme. Other values, including URLs such as https://, are plain values.
+func IsReference(value string) bool {
+	scheme, _, ok := strings.Cut(value, "://")
+	if !ok {
+		return false
+	}
+	switch scheme {
+	case SchemeEnv, SchemeFile, SchemeKeychain, SchemeAWSSM:
+		return true
+	default:
+		return false
+	}
+}
+
+// Cache holds resolved secrets so that resolvers sharing it read each
+// secret once. A Cache is safe for concurrent use.
+type Cache struct {
+	mu     sync.Mutex
+	values map[string]string
+}
+
+// NewCache returns an empty cache.
+func NewCache() *Cache {
+	return &Cache{values: map[string]string{}}
+}
+
+// Resolver resolves secret references. Resolved values are cached, so each
+// secret is read once for the life of the cache. A Resolver is safe for
+// concurrent use.
+type Resolver struct {
+	baseDir string
+	runner  CommandRunner
+	goos    string
+	cache   *Cache
+}
+
+// NewResolver returns a resolver that reads relative file:// paths from
+// baseDir, with a cache of its own.
+func NewResolver(baseDir string) *Resolver {
+	return &Resolver{baseDir: baseDir, runner: execRunner{}, goos: runtime.GOOS, cache: NewCache()}
+}
+
+// WithCache makes the resolver share cache with other resolvers.
+func (r *Resolver) WithCache(cache *Cache) *Resolver {
+	r.cache = cache
+	return r
+}
+
+// WithRunner replaces the command runner of keychain and Secrets Manager
+// lookups (for tests).
+func (r *Resolver) WithRunner(runner CommandRunner) *Resolver {
+	r.runner = runner
+	return r
+}
+
+// withGOOS sets the operating system whose keychain is read (for tests).
+func (r *Resolver) withGOOS(goos string) *Resolver {
+	r.goos = goos
+	return r
+}
+
+// Resolve returns the secret value refers to, or value itself when it is
+// not a reference.
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	if !IsReference(value) {
+		return value, nil
+	}
+	scheme, location, _ := strings.Cut(value, "://")
+	if location == "" {
+		return "", fmt.Errorf("%w %q: nothing after %s://", ErrUnresolved, value, scheme)
+	}
+
+	if scheme == SchemeFile {
+		path, err := r.filePath(location)
+		if err != nil {
+			return "", fmt.Errorf("%w %q: %w", ErrUnresolved, value, err)
+		}
+		location = path
+	}
+	key := scheme + "://" + location
+	r.cache.mu.Lock()
+	cached, ok := r.cache.values[key]
+	r.cache.mu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	var (
+		secret string
+		err    error
+	)
+	switch scheme {
+	case SchemeEnv:
+		secret, err = resolveEnv(location)
+	case SchemeFile:
+		secret, err = resolveFile(location)
+	case SchemeKeychain:
+		secret, err = r.resolveKeychain(ctx, location)
+	case SchemeAWSSM:
+		secret, err = r.resolveAWSSecret(ctx, location)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w %q: %w", ErrUnresolved, value, err)
+	}
+
+	if scheme != SchemeEnv {
+		// Environment variables are cheap to read and may change.
+		r.cache.mu.Lock()
+		r.cache.values[key] = secret
+		r.cache.mu.Unlock()
+	}
+	return secret, nil
+}
+
+// resolveEnv reads the environment variable name.
+func resolveEnv(name string) (string, error) {
+	secret, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s is not set", name)
+	}
+	return secret, nil
+}
+
+// filePath returns the absolute path of a file:// reference location.
+func (r *Resolver) filePath(path string) (string, error) {
+	if rest, ok := strings.CutPrefix(path, "~/"); ok {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(home, rest), nil
+	}
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(r.baseDir, path)
+	}
+	return filepath.Abs(path)
+}
+
+// resolveFile reads the file at path, without its trailing newline.
+func resolveFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// resolveKeychain reads the password of service, and optionally account,
+// from the OS keychain.
+func (r *Resolver) resolveKeychain(ctx context.Context, item string) (string, error) {
+	service, account, _ := strings.Cut(item, "/")
+	var name string
+	var args []string
+	switch r.goos {
+	case "darwin":
+		name, args = "security", []string{"find-generic-password", "-s", service, "-w"}
+		if account != "" {
+			args = append(args, "-a", account)
+		}
+	case "linux", "freebsd", "openbsd", "netbsd":
+		name, args = "secret-tool", []string{"lookup", "service", service}
+		if account != "" {
+			args = append(args, "account", account)
+		}
+	default:
+		return "", fmt.Errorf("the keychain is not supported on %s; use env:// or file://", r.goos)
+	}
+	return r.run(ctx, name, args...)
+}
+
+// resolveAWSSecret reads the string value of a Secrets Manager secret,
+// or of one key of a JSON secret.
+func (r *Resolver) resolveAWSSecret(ctx context.Context, location string) (string, error) {
+	id, key, hasKey := strings.Cut(location, "#")
+	secret, err := r.run(ctx, "aws", "secretsmanager", "get-secret-value",
+		"--secret-id", id, "--query", "SecretString", "--output", "text")
+	if err != nil || !hasKey {
+		return secret, err
+	}
+
+	var fields map[string]any
+	if err = json.Unmarshal([]byte(secret), &fields); err != nil {
+		return "", fmt.Errorf("secret %s is not a JSON object, so it has no key %q", id, key)
+	}
+	value, ok := fields[key]
+	if !ok {
+		return "", fmt.Errorf("secret %s has no key %q", id, key)
+	}
+	if s, isString := value.(string); isString {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
+
+// run runs a lookup command and returns its output, without the trailing
+// newline.
+func (r *Resolver) run(ctx context.Context, name string, args ...string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, commandTimeout)
+	defer cancel()
+	stdout, stderr, err := r.runner.Run(ctx, name, args...)
+	if err != nil {
+		if errors.Is(err, exec.ErrNotFound) {
+			return "", fmt.Errorf("%s is not installed", name)
+		}
+		if msg := strings.TrimSpace(string(stderr)); msg != "" {
+			return "", fmt.Errorf("%s: %s", name, msg)
+		}
+		return "", fmt.Errorf("%s: %w", name, err)
+	}
+	return strings.TrimRight(string(stdout), "\r\n"), nil
+}
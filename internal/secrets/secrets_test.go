@@ -0,0 +1,149 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeRunner records the commands it is asked to run and answers them with
+// stdout, stderr, and err.
+type fakeRunner struct {
+	stdout string
+	stderr string
+	err    error
+	calls  []string
+}
+
+func (f *fakeRunner) Run(_ context.Context, name string, args ...string) ([]byte, []byte, error) {
+	f.calls = append(f.calls, name+" "+strings.Join(args, " "))
+	return []byte(f.stdout), []byte(f.stderr), f.err
+}
+
+func TestIsReference(t *testing.T) {
+	for value, want := range map[string]bool{
+		"env://SLACK_TOKEN":         true,
+		"file://~/.slack":           true,
+		"keychain://finfocus":       true,
+		"aws-sm://prod/slack#token": true,
+		"https://hooks.slack.com/x": false,
+		"xoxb-123":                  false,
+		"vault://secret/slack":      false,
+		"":                          false,
+	} {
+		assert.Equal(t, want, IsReference(value), value)
+	}
+}
+
+func TestResolveEnvAndPlain(t *testing.T) {
+	r := NewResolver(t.TempDir())
+	t.Setenv("FINFOCUS_TEST_SECRET", "s3cret")
+
+	value, err := r.Resolve(context.Background(), "env://FINFOCUS_TEST_SECRET")
+	require.NoError(t, err)
+	assert.Equal(t, "s3cret", value)
+
+	value, err = r.Resolve(context.Background(), "plain-token")
+	require.NoError(t, err)
+	assert.Equal(t, "plain-token", value)
+
+	_, err = r.Resolve(context.Background(), "env://FINFOCUS_TEST_UNSET")
+	require.ErrorIs(t, err, ErrUnresolved)
+	assert.Contains(t, err.Error(), "environment variable FINFOCUS_TEST_UNSET is not set")
+
+	_, err = r.Resolve(context.Background(), "env://")
+	require.ErrorIs(t, err, ErrUnresolved)
+}
+
+func TestResolveFile(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("from-file\n"), 0600))
+	r := NewResolver(dir)
+
+	value, err := r.Resolve(context.Background(), "file://token")
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value, "relative to the base directory, without the newline")
+
+	value, err = r.Resolve(context.Background(), "file://"+filepath.Join(dir, "token"))
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value)
+
+	// Resolved files are cached.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "token"), []byte("changed"), 0600))
+	value, err = r.Resolve(context.Background(), "file://token")
+	require.NoError(t, err)
+	assert.Equal(t, "from-file", value)
+
+	value, err = NewResolver(dir).Resolve(context.Background(), "file://token")
+	require.NoError(t, err)
+	assert.Equal(t, "changed", value, "resolvers have their own cache unless they share one")
+
+	_, err = r.Resolve(context.Background(), "file://missing")
+	require.ErrorIs(t, err, ErrUnresolved)
+}
+
+func TestResolveKeychain(t *testing.T) {
+	runner := &fakeRunner{stdout: "keychain-secret\n"}
+
+	value, err := NewResolver("").WithRunner(runner).withGOOS("darwin").
+		Resolve(context.Background(), "keychain://finfocus/slack")
+	require.NoError(t, err)
+	assert.Equal(t, "keychain-secret", value)
+
+	_, err = NewResolver("").WithRunner(runner).withGOOS("linux").
+		Resolve(context.Background(), "keychain://finfocus")
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"security find-generic-password -s finfocus -w -a slack",
+		"secret-tool lookup service finfocus",
+	}, runner.calls)
+
+	_, err = NewResolver("").WithRunner(runner).withGOOS("windows").
+		Resolve(context.Background(), "keychain://finfocus")
+	require.ErrorIs(t, err, ErrUnresolved)
+	assert.Contains(t, err.Error(), "not supported on windows")
+}
+
+func TestResolveAWSSecret(t *testing.T) {
+	runner := &fakeRunner{stdout: `{"token":"xoxb-1","port":8080}` + "\n"}
+	r := NewResolver("").WithRunner(runner)
+
+	value, err := r.Resolve(context.Background(), "aws-sm://prod/slack#token")
+	require.NoError(t, err)
+	assert.Equal(t, "xoxb-1", value)
+	assert.Equal(t, []string{
+		"aws secretsmanager get-secret-value --secret-id prod/slack --query SecretString --output text",
+	}, runner.calls)
+
+	value, err = r.Resolve(context.Background(), "aws-sm://prod/slack#port")
+	require.NoError(t, err)
+	assert.Equal(t, "8080", value)
+
+	_, err = r.Resolve(context.Background(), "aws-sm://prod/slack#missing")
+	require.ErrorIs(t, err, ErrUnresolved)
+	assert.Contains(t, err.Error(), `has no key "missing"`)
+
+	value, err = r.Resolve(context.Background(), "aws-sm://prod/slack")
+	require.NoError(t, err)
+	assert.Equal(t, `{"token":"xoxb-1","port":8080}`, value)
+}
+
+func TestResolveCommandErrors(t *testing.T) {
+	_, err := NewResolver("").WithRunner(&fakeRunner{err: exec.ErrNotFound}).
+		Resolve(context.Background(), "aws-sm://prod/slack")
+	require.ErrorIs(t, err, ErrUnresolved)
+	assert.Contains(t, err.Error(), "aws is not installed")
+
+	_, err = NewResolver("").WithRunner(&fakeRunner{
+		stderr: "ResourceNotFoundException: Secrets Manager can't find the specified secret.\n",
+		err:    errors.New("exit status 254"),
+	}).Resolve(context.Background(), "aws-sm://prod/slack")
+	assert.Contains(t, err.Error(), "aws: ResourceNotFoundException")
+}
@@ -0,0 +1,192 @@
+package skus
+
+import (
+	_ "embed"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// pulumiTypeMaxParts is the maximum number of colon-separated segments
+	// in a Pulumi type token (provider:module/resource:ClassName).
+	pulumiTypeMaxParts = 3
+	// pulumiTypeMinParts is the minimum number of segments needed to extract
+	// the module/resource segment from a Pulumi type token.
+	pulumiTypeMinParts = 2
+)
+
+// ErrInvalidCatalogEntry is returned for a catalog entry that names no SKU.
+var ErrInvalidCatalogEntry = errors.New("catalog entry needs sku, or property and variants")
+
+//go:embed catalog.yaml
+var catalogData []byte
+
+//nolint:gochecknoglobals // Parsed once from the embedded catalog.
+var builtinCatalog = sync.OnceValue(func() *Catalog {
+	catalog, err := ParseCatalog(catalogData)
+	if err != nil {
+		panic(fmt.Sprintf("parsing embedded SKU catalog: %v", err))
+	}
+	return catalog
+})
+
+//nolint:gochecknoglobals // Set once by SetOverrides during command setup.
+var activeCatalog atomic.Pointer[Catalog]
+
+// CatalogEntry is the well-known SKU of a resource type.
+type CatalogEntry struct {
+	// SKU is the SKU of the resource type, or of the resources whose Property
+	// matches no variant.
+	SKU string `yaml:"sku,omitempty"`
+	// Property names the resource property whose lowercase value picks the SKU
+	// from Variants, e.g. loadBalancerType: network for a network load balancer.
+	Property string            `yaml:"property,omitempty"`
+	Variants map[string]string `yaml:"variants,omitempty"`
+}
+
+// Catalog maps providers, then resource types, to their well-known SKUs.
+// Resource types are the lowercase "module/resource" segment of Pulumi type
+// tokens, or "module:class" for tokens such as azure-native ones that have no
+// resource segment.
+type Catalog struct {
+	Providers map[string]map[string]CatalogEntry `yaml:"providers"`
+}
+
+// ParseCatalog parses a YAML catalog, lowercasing its providers, resource
+// types, and variants.
+func ParseCatalog(data []byte) (*Catalog, error) {
+	var parsed Catalog
+	if err := yaml.Unmarshal(data, &parsed); err != nil {
+		return nil, err
+	}
+	catalog := &Catalog{Providers: make(map[string]map[string]CatalogEntry, len(parsed.Providers))}
+	for provider, types := range parsed.Providers {
+		entries := make(map[string]CatalogEntry, len(types))
+		for resourceType, entry := range types {
+			if entry.SKU == "" && (entry.Property == "" || len(entry.Variants) == 0) {
+				return nil, fmt.Errorf("%s: %s: %w", provider, resourceType, ErrInvalidCatalogEntry)
+			}
+			variants := make(map[string]string, len(entry.Variants))
+			for value, sku := range entry.Variants {
+				variants[strings.ToLower(value)] = sku
+			}
+			entry.Variants = variants
+			entries[strings.ToLower(resourceType)] = entry
+		}
+		catalog.Providers[strings.ToLower(provider)] = entries
+	}
+	return catalog, nil
+}
+
+// LoadCatalog reads and parses the catalog file at path.
+func LoadCatalog(path string) (*Catalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading SKU catalog: %w", err)
+	}
+	catalog, err := ParseCatalog(data)
+	if err != nil {
+		return nil, fmt.Errorf("parsing SKU catalog %s: %w", path, err)
+	}
+	return catalog, nil
+}
+
+// Merge returns a catalog of the entries of c and overrides, in which the
+// entries of overrides replace those of c for the same resource type.
+func (c *Catalog) Merge(overrides *Catalog) *Catalog {
+	merged := &Catalog{Providers: make(map[string]map[string]CatalogEntry, len(c.Providers))}
+	for _, source := range []*Catalog{c, overrides} {
+		if source == nil {
+			continue
+		}
+		for provider, types := range source.Providers {
+			if merged.Providers[provider] == nil {
+				merged.Providers[provider] = make(map[string]CatalogEntry, len(types))
+			}
+			for resourceType, entry := range types {
+				merged.Providers[provider][resourceType] = entry
+			}
+		}
+	}
+	return merged
+}
+
+// Lookup returns the well-known SKU of a resource, or "" when the catalog has
+// no entry for its provider and type.
+func (c *Catalog) Lookup(provider, resourceType string, properties map[string]string) string {
+	types := c.Providers[strings.ToLower(provider)]
+	if types == nil {
+		return ""
+	}
+	for _, key := range catalogKeys(resourceType) {
+		entry, ok := types[key]
+		if !ok {
+			continue
+		}
+		if entry.Property != "" {
+			if sku := entry.Variants[strings.ToLower(properties[entry.Property])]; sku != "" {
+				return sku
+			}
+		}
+		return entry.SKU
+	}
+	return ""
+}
+
+// BuiltinCatalog returns the catalog embedded in finfocus.
+func BuiltinCatalog() *Catalog {
+	return builtinCatalog()
+}
+
+// SetOverrides makes ResolveSKU use the built-in catalog merged with
+// overrides; nil restores the built-in catalog.
+func SetOverrides(overrides *Catalog) {
+	if overrides == nil {
+		activeCatalog.Store(nil)
+		return
+	}
+	activeCatalog.Store(BuiltinCatalog().Merge(overrides))
+}
+
+// ActiveCatalog returns the catalog that ResolveSKU uses.
+func ActiveCatalog() *Catalog {
+	if catalog := activeCatalog.Load(); catalog != nil {
+		return catalog
+	}
+	return BuiltinCatalog()
+}
+
+// catalogKeys returns the catalog keys of a Pulumi type token, most specific
+// first: "eks/cluster:cluster" and "eks/cluster" for "aws:eks/cluster:Cluster".
+func catalogKeys(resourceType string) []string {
+	segment := extractPulumiSegment(resourceType)
+	if segment == "" {
+		return nil
+	}
+	parts := strings.SplitN(resourceType, ":", pulumiTypeMaxParts)
+	if len(parts) == pulumiTypeMaxParts {
+		return []string{segment + ":" + strings.ToLower(parts[2]), segment}
+	}
+	return []string{segment}
+}
+
+// extractPulumiSegment extracts the lowercase module/resource segment from a Pulumi type token.
+// It expects tokens of the form `provider:module/resource:ClassName` (for example `aws:eks/cluster:Cluster`).
+// If the token contains at least two colon-separated segments, it returns the lowercase second segment
+// (the `module/resource` portion). If the token does not contain at least two segments, it returns an empty string.
+func extractPulumiSegment(resourceType string) string {
+	// Pulumi type tokens follow the pattern: provider:module/resource:ClassName
+	// e.g., "aws:eks/cluster:Cluster" → split by ":" → ["aws", "eks/cluster", "Cluster"]
+	parts := strings.SplitN(resourceType, ":", pulumiTypeMaxParts)
+	if len(parts) < pulumiTypeMinParts {
+		return ""
+	}
+	// The middle segment contains module/resource (e.g., "eks/cluster")
+	return strings.ToLower(parts[1])
+}
@@ -0,0 +1,208 @@
+package skus
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuiltinCatalog_AWS(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		expected     string
+	}{
+		{
+			name:         "EKS cluster",
+			resourceType: "aws:eks/cluster:Cluster",
+			expected:     "cluster",
+		},
+		{
+			name:         "EKS addon",
+			resourceType: "aws:eks/addon:Addon",
+			expected:     "addon",
+		},
+		{
+			name:         "NAT gateway",
+			resourceType: "aws:ec2/natGateway:NatGateway",
+			expected:     "nat-gateway",
+		},
+		{
+			name:         "EC2 instance (not in well-known map)",
+			resourceType: "aws:ec2/instance:Instance",
+			expected:     "",
+		},
+		{
+			name:         "S3 bucket (not in well-known map)",
+			resourceType: "aws:s3/bucket:Bucket",
+			expected:     "",
+		},
+		{
+			name:         "empty type",
+			resourceType: "",
+			expected:     "",
+		},
+		{
+			name:         "malformed type (no colons)",
+			resourceType: "eks-cluster",
+			expected:     "",
+		},
+		{
+			name:         "partial type (one colon)",
+			resourceType: "aws:eks/cluster",
+			expected:     "cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := BuiltinCatalog().Lookup("aws", tt.resourceType, nil)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestExtractPulumiSegment(t *testing.T) {
+	tests := []struct {
+		name         string
+		resourceType string
+		expected     string
+	}{
+		{
+			name:         "standard three-part type",
+			resourceType: "aws:eks/cluster:Cluster",
+			expected:     "eks/cluster",
+		},
+		{
+			name:         "two-part type",
+			resourceType: "aws:ec2/instance",
+			expected:     "ec2/instance",
+		},
+		{
+			name:         "no colon",
+			resourceType: "nocolon",
+			expected:     "",
+		},
+		{
+			name:         "empty string",
+			resourceType: "",
+			expected:     "",
+		},
+		{
+			name:         "uppercase segment is lowercased",
+			resourceType: "aws:EKS/Cluster:Cluster",
+			expected:     "eks/cluster",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := extractPulumiSegment(tt.resourceType)
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestCatalog_Lookup(t *testing.T) {
+	tests := []struct {
+		name         string
+		provider     string
+		resourceType string
+		properties   map[string]string
+		expected     string
+	}{
+		{
+			name:         "load balancer type picks the variant",
+			provider:     "aws",
+			resourceType: "aws:lb/loadBalancer:LoadBalancer",
+			properties:   map[string]string{"loadBalancerType": "network"},
+			expected:     "nlb",
+		},
+		{
+			name:         "load balancer without type is an ALB",
+			provider:     "aws",
+			resourceType: "aws:lb/loadBalancer:LoadBalancer",
+			expected:     "alb",
+		},
+		{
+			name:         "Route53 zone",
+			provider:     "aws",
+			resourceType: "aws:route53/zone:Zone",
+			expected:     "hosted-zone",
+		},
+		{
+			name:         "GKE Autopilot cluster",
+			provider:     "gcp",
+			resourceType: "gcp:container/cluster:Cluster",
+			properties:   map[string]string{"enableAutopilot": "true"},
+			expected:     "gke-autopilot",
+		},
+		{
+			name:         "AKS cluster tier",
+			provider:     "azure",
+			resourceType: "azure:containerservice/kubernetesCluster:KubernetesCluster",
+			properties:   map[string]string{"skuTier": "Standard"},
+			expected:     "aks-standard",
+		},
+		{
+			name:         "azure-native token without resource segment",
+			provider:     "azure-native",
+			resourceType: "azure-native:containerservice:ManagedCluster",
+			expected:     "aks-free",
+		},
+		{
+			name:         "unknown provider",
+			provider:     "digitalocean",
+			resourceType: "digitalocean:index/droplet:Droplet",
+			expected:     "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, BuiltinCatalog().Lookup(tt.provider, tt.resourceType, tt.properties))
+		})
+	}
+}
+
+func TestSetOverrides(t *testing.T) {
+	t.Cleanup(func() { SetOverrides(nil) })
+
+	overrides, err := ParseCatalog([]byte(`
+providers:
+  AWS:
+    eks/cluster:
+      sku: eks-extended-support
+  digitalocean:
+    index/kubernetescluster:
+      sku: doks
+`))
+	require.NoError(t, err)
+	SetOverrides(overrides)
+
+	assert.Equal(t, "eks-extended-support", ResolveSKU("aws", "aws:eks/cluster:Cluster", nil))
+	assert.Equal(t, "nat-gateway", ResolveSKU("aws", "aws:ec2/natGateway:NatGateway", nil))
+	assert.Equal(t, "doks", ResolveSKU("digitalocean", "digitalocean:index/kubernetesCluster:KubernetesCluster", nil))
+
+	SetOverrides(nil)
+	assert.Equal(t, "cluster", ResolveSKU("aws", "aws:eks/cluster:Cluster", nil))
+	assert.Empty(t, ResolveSKU("digitalocean", "digitalocean:index/kubernetesCluster:KubernetesCluster", nil))
+}
+
+func TestLoadCatalog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "skus.yaml")
+	require.NoError(t, os.WriteFile(path, []byte("providers:\n  aws:\n    mq/broker:\n      sku: broker\n"), 0o600))
+	catalog, err := LoadCatalog(path)
+	require.NoError(t, err)
+	assert.Equal(t, "broker", catalog.Lookup("aws", "aws:mq/broker:Broker", nil))
+
+	require.NoError(t, os.WriteFile(path, []byte("providers:\n  aws:\n    mq/broker:\n      property: x\n"), 0o600))
+	_, err = LoadCatalog(path)
+	require.ErrorIs(t, err, ErrInvalidCatalogEntry)
+
+	_, err = LoadCatalog(filepath.Join(t.TempDir(), "missing.yaml"))
+	require.Error(t, err)
+}
@@ -0,0 +1,38 @@
+package skus
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+//nolint:gochecknoglobals // Set once by SetProviderAliases during command setup.
+var providerAliases atomic.Pointer[map[string]string]
+
+// SetProviderAliases makes CanonicalProvider map the namespaces of providers
+// published under custom names, such as my-org in my-org:aws-wrapper:Instance,
+// to the provider whose resources they wrap, such as aws. Namespaces match
+// ignoring case; nil or an empty map removes the aliases.
+func SetProviderAliases(aliases map[string]string) {
+	if len(aliases) == 0 {
+		providerAliases.Store(nil)
+		return
+	}
+	normalized := make(map[string]string, len(aliases))
+	for alias, provider := range aliases {
+		normalized[strings.ToLower(strings.TrimSpace(alias))] = strings.ToLower(strings.TrimSpace(provider))
+	}
+	providerAliases.Store(&normalized)
+}
+
+// CanonicalProvider returns the provider that provider is an alias of, or
+// provider itself when it is not an alias.
+func CanonicalProvider(provider string) string {
+	aliases := providerAliases.Load()
+	if aliases == nil {
+		return provider
+	}
+	if canonical, ok := (*aliases)[strings.ToLower(provider)]; ok {
+		return canonical
+	}
+	return provider
+}
@@ -0,0 +1,21 @@
+package skus
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetProviderAliases(t *testing.T) {
+	t.Cleanup(func() { SetProviderAliases(nil) })
+
+	assert.Equal(t, "my-org", CanonicalProvider("my-org"))
+
+	SetProviderAliases(map[string]string{"My-Org": " AWS "})
+	assert.Equal(t, "aws", CanonicalProvider("my-org"))
+	assert.Equal(t, "aws", CanonicalProvider("MY-ORG"), "namespaces match ignoring case")
+	assert.Equal(t, "gcp", CanonicalProvider("gcp"), "other providers are kept")
+
+	SetProviderAliases(nil)
+	assert.Equal(t, "my-org", CanonicalProvider("my-org"))
+}
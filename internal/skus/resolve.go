@@ -4,20 +4,13 @@
 // package maps well-known resource types to their canonical SKU identifiers.
 package skus
 
-import "strings"
-
 // ResolveSKU maps a provider and resource type to a well-known SKU identifier as a fallback
 // when property-based SKU extraction yields no result.
 //
-// For supported providers (currently only "aws"), it returns a canonical SKU for known
-// resource types; for unknown resource types or unsupported providers it returns the empty
-// string, which callers should treat as a no-op. The properties map may be consulted to
-// refine the mapping for certain resource types.
+// The SKUs come from the built-in catalog, merged with the overrides set by SetOverrides.
+// For resource types the catalog does not know it returns the empty string, which callers
+// should treat as a no-op. The properties map picks the SKU of resource types whose SKU
+// depends on a property, such as the type of a load balancer.
 func ResolveSKU(provider, resourceType string, properties map[string]string) string {
-	switch strings.ToLower(provider) {
-	case "aws":
-		return resolveAWSSKU(resourceType, properties)
-	default:
-		return ""
-	}
+	return ActiveCatalog().Lookup(provider, resourceType, properties)
 }
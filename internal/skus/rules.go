@@ -0,0 +1,131 @@
+package skus
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// ErrUnterminatedExpression is returned for an expression with a "${" that
+// is not closed by "}".
+var ErrUnterminatedExpression = errors.New("unterminated ${ in expression")
+
+// Rule is a user-supplied mapping from the properties of a resource to its
+// SKU and region, for providers and resource types that the built-in
+// extraction does not know.
+type Rule struct {
+	// Provider limits the rule to one provider; empty matches every provider.
+	Provider string
+	// Type matches one resource type exactly and Match resource types by
+	// regular expression. A rule that sets both must satisfy both.
+	Type  string
+	Match *regexp.Regexp
+	// SKU and Region are expressions: literal text in which ${name} is
+	// replaced by the value of the property called name, and ${a|b} by the
+	// first of the properties a and b that is set.
+	SKU    string
+	Region string
+}
+
+// Matches reports whether the rule applies to a resource of resourceType from
+// provider.
+func (r Rule) Matches(provider, resourceType string) bool {
+	if r.Provider != "" && !strings.EqualFold(r.Provider, provider) {
+		return false
+	}
+	if r.Type != "" && r.Type != resourceType {
+		return false
+	}
+	return r.Match == nil || r.Match.MatchString(resourceType)
+}
+
+// Rules are mapping rules in the order they are tried.
+type Rules []Rule
+
+// Resolve returns the SKU and the region of a resource from the first rule
+// that matches it and whose expression resolves; either is empty when no
+// rule resolves it.
+func (r Rules) Resolve(provider, resourceType string, properties map[string]string) (string, string) {
+	var sku, region string
+	for _, rule := range r {
+		if sku != "" && region != "" {
+			break
+		}
+		if !rule.Matches(provider, resourceType) {
+			continue
+		}
+		if sku == "" {
+			sku = Expand(rule.SKU, properties)
+		}
+		if region == "" {
+			region = Expand(rule.Region, properties)
+		}
+	}
+	return sku, region
+}
+
+// Expand evaluates expr against properties. It returns "" when expr is empty
+// or names only properties that are not set.
+func Expand(expr string, properties map[string]string) string {
+	var out strings.Builder
+	for {
+		start := strings.Index(expr, "${")
+		if start < 0 {
+			out.WriteString(expr)
+			return out.String()
+		}
+		end := strings.Index(expr[start:], "}")
+		if end < 0 {
+			return ""
+		}
+		value := firstProperty(expr[start+2:start+end], properties)
+		if value == "" {
+			return ""
+		}
+		out.WriteString(expr[:start])
+		out.WriteString(value)
+		expr = expr[start+end+1:]
+	}
+}
+
+// ValidateExpression checks that every "${" in expr is closed.
+func ValidateExpression(expr string) error {
+	for {
+		start := strings.Index(expr, "${")
+		if start < 0 {
+			return nil
+		}
+		end := strings.Index(expr[start:], "}")
+		if end < 0 {
+			return ErrUnterminatedExpression
+		}
+		expr = expr[start+end+1:]
+	}
+}
+
+// firstProperty returns the value of the first of the "|"-separated property
+// names that is set.
+func firstProperty(names string, properties map[string]string) string {
+	for name := range strings.SplitSeq(names, "|") {
+		if value := properties[strings.TrimSpace(name)]; value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// rulesKey is the context key of the mapping rules.
+type rulesKey struct{}
+
+// NewContext returns a copy of ctx that carries rules.
+func NewContext(ctx context.Context, rules Rules) context.Context {
+	return context.WithValue(ctx, rulesKey{}, rules)
+}
+
+// FromContext returns the mapping rules carried by ctx, or nil when there
+// are none.
+func FromContext(ctx context.Context) Rules {
+	rules, _ := ctx.Value(rulesKey{}).(Rules)
+	return rules
+}
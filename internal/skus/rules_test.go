@@ -0,0 +1,66 @@
+package skus
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExpand(t *testing.T) {
+	properties := map[string]string{"size": "s-2vcpu-4gb", "location": "nyc3"}
+
+	tests := []struct {
+		name     string
+		expr     string
+		expected string
+	}{
+		{name: "property", expr: "${size}", expected: "s-2vcpu-4gb"},
+		{name: "literal", expr: "standard", expected: "standard"},
+		{name: "literal and property", expr: "droplet-${size}", expected: "droplet-s-2vcpu-4gb"},
+		{name: "first set alternative", expr: "${region|location}", expected: "nyc3"},
+		{name: "missing property", expr: "${region}", expected: ""},
+		{name: "unterminated", expr: "${size", expected: ""},
+		{name: "empty", expr: "", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, Expand(tt.expr, properties))
+		})
+	}
+}
+
+func TestValidateExpression(t *testing.T) {
+	require.NoError(t, ValidateExpression("${size}-${region|location}"))
+	require.ErrorIs(t, ValidateExpression("${size}-${region"), ErrUnterminatedExpression)
+}
+
+func TestRules_Resolve(t *testing.T) {
+	rules := Rules{
+		{Provider: "digitalocean", Type: "digitalocean:index/droplet:Droplet", SKU: "${size}"},
+		{Match: regexp.MustCompile(`^digitalocean:`), SKU: "${sizeSlug}", Region: "${region}"},
+	}
+
+	sku, region := rules.Resolve("digitalocean", "digitalocean:index/droplet:Droplet",
+		map[string]string{"size": "s-1vcpu-1gb", "sizeSlug": "other", "region": "nyc3"})
+	assert.Equal(t, "s-1vcpu-1gb", sku, "the first matching rule resolves the SKU")
+	assert.Equal(t, "nyc3", region, "a later rule resolves what the first leaves unresolved")
+
+	sku, region = rules.Resolve("digitalocean", "digitalocean:index/database:Cluster",
+		map[string]string{"sizeSlug": "db-s-1vcpu-1gb"})
+	assert.Equal(t, "db-s-1vcpu-1gb", sku)
+	assert.Empty(t, region)
+
+	sku, region = rules.Resolve("aws", "aws:ec2/instance:Instance", map[string]string{"size": "t3.micro"})
+	assert.Empty(t, sku)
+	assert.Empty(t, region)
+}
+
+func TestRulesContext(t *testing.T) {
+	assert.Nil(t, FromContext(context.Background()))
+
+	rules := Rules{{Type: "acme:index/server:Server", SKU: "${plan}"}}
+	assert.Equal(t, rules, FromContext(NewContext(context.Background(), rules)))
+}
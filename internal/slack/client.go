@@ -0,0 +1,233 @@
+// Package slack posts finfocus messages to Slack through an incoming webhook
+// or the Web API.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/rshade/finfocus/internal/logging"
+)
+
+// Slack API settings.
+const (
+	// DefaultAPIURL is the Slack Web API endpoint.
+	DefaultAPIURL = "https://slack.com/api"
+	// DefaultTimeout bounds a single Slack request.
+	DefaultTimeout = 30 * time.Second
+
+	// EnvWebhookURL is the environment variable holding an incoming webhook URL.
+	EnvWebhookURL = "SLACK_WEBHOOK_URL"
+	// EnvBotToken is the environment variable holding a bot token.
+	EnvBotToken = "SLACK_BOT_TOKEN" //nolint:gosec // Environment variable name, not a credential.
+
+	// maxErrorBody limits how much of an error response is echoed back to the user.
+	maxErrorBody = 512
+)
+
+var (
+	// ErrNotConfigured indicates neither a webhook nor a bot token is configured.
+	ErrNotConfigured = errors.New("slack is not configured")
+
+	// ErrNoChannel indicates a bot token was given without a channel.
+	ErrNoChannel = errors.New("slack channel is required with a bot token")
+
+	// ErrRequestFailed indicates a Slack request failed.
+	ErrRequestFailed = errors.New("slack request failed")
+)
+
+// Message is a Block Kit message. Text is the notification fallback shown
+// where blocks cannot be rendered.
+type Message struct {
+	Channel string  `json:"channel,omitempty"`
+	Text    string  `json:"text"`
+	Blocks  []Block `json:"blocks,omitempty"`
+}
+
+// Block is a Block Kit layout block.
+type Block struct {
+	Type     string       `json:"type"`
+	Text     *TextObject  `json:"text,omitempty"`
+	Fields   []TextObject `json:"fields,omitempty"`
+	Elements []TextObject `json:"elements,omitempty"`
+}
+
+// TextObject is a Block Kit text object.
+type TextObject struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Header returns a header block.
+func Header(text string) Block {
+	return Block{Type: "header", Text: &TextObject{Type: "plain_text", Text: text}}
+}
+
+// Section returns a section block with mrkdwn text and optional mrkdwn fields.
+func Section(text string, fields ...string) Block {
+	block := Block{Type: "section"}
+	if text != "" {
+		block.Text = &TextObject{Type: "mrkdwn", Text: text}
+	}
+	for _, f := range fields {
+		block.Fields = append(block.Fields, TextObject{Type: "mrkdwn", Text: f})
+	}
+	return block
+}
+
+// Context returns a context block of mrkdwn elements.
+func Context(texts ...string) Block {
+	block := Block{Type: "context"}
+	for _, t := range texts {
+		block.Elements = append(block.Elements, TextObject{Type: "mrkdwn", Text: t})
+	}
+	return block
+}
+
+// Divider returns a divider block.
+func Divider() Block {
+	return Block{Type: "divider"}
+}
+
+// Escape escapes the characters Slack treats as control sequences in mrkdwn.
+func Escape(s string) string {
+	return strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;").Replace(s)
+}
+
+// Client delivers messages either to an incoming webhook or, with a bot
+// token, to a channel through chat.postMessage.
+type Client struct {
+	HTTPClient *http.Client
+	// BaseURL is the Web API endpoint used with a bot token.
+	BaseURL    string
+	webhookURL string
+	token      string
+	channel    string
+}
+
+// NewClient returns a Client. A webhook URL takes precedence over a bot token
+// and channel. An empty baseURL selects DefaultAPIURL.
+func NewClient(baseURL, webhookURL, token, channel string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	return &Client{
+		HTTPClient: &http.Client{Timeout: DefaultTimeout},
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		webhookURL: strings.TrimSpace(webhookURL),
+		token:      strings.TrimSpace(token),
+		channel:    strings.TrimSpace(channel),
+	}
+}
+
+// Destination describes where messages are delivered, without secrets.
+func (c *Client) Destination() string {
+	if c.webhookURL != "" {
+		return "incoming webhook"
+	}
+	return "channel " + c.channel
+}
+
+// Validate reports whether the client has a destination: ErrNotConfigured
+// without a webhook or token, and ErrNoChannel for a token without a channel.
+func (c *Client) Validate() error {
+	switch {
+	case c.webhookURL != "":
+		return nil
+	case c.token == "":
+		return fmt.Errorf("%w; set %s, or %s and a channel", ErrNotConfigured, EnvWebhookURL, EnvBotToken)
+	case c.channel == "":
+		return ErrNoChannel
+	default:
+		return nil
+	}
+}
+
+// Post delivers msg. Errors wrap ErrNotConfigured, ErrNoChannel, or ErrRequestFailed.
+func (c *Client) Post(ctx context.Context, msg Message) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+
+	log := logging.FromContext(ctx)
+	if c.webhookURL == "" {
+		log.Debug().Ctx(ctx).Str("component", "slack").Str("operation", "post_message").
+			Str("channel", c.channel).Msg("posting message to Slack channel")
+		msg.Channel = c.channel
+		body, err := c.send(ctx, c.BaseURL+"/chat.postMessage", c.token, msg)
+		if err != nil {
+			return err
+		}
+		var resp struct {
+			OK    bool   `json:"ok"`
+			Error string `json:"error"`
+		}
+		if err = json.Unmarshal(body, &resp); err != nil {
+			return fmt.Errorf("%w: decoding response: %w", ErrRequestFailed, err)
+		}
+		if !resp.OK {
+			return fmt.Errorf("%w: chat.postMessage: %s", ErrRequestFailed, resp.Error)
+		}
+		return nil
+	}
+
+	log.Debug().Ctx(ctx).Str("component", "slack").Str("operation", "post_webhook").
+		Msg("posting message to Slack webhook")
+	body, err := c.send(ctx, c.webhookURL, "", msg)
+	if err != nil {
+		return err
+	}
+	// Webhooks answer "ok" in plain text on success.
+	if strings.TrimSpace(string(body)) != "ok" {
+		return fmt.Errorf("%w: webhook: %s", ErrRequestFailed, strings.TrimSpace(string(body)))
+	}
+	return nil
+}
+
+// send posts msg as JSON and returns the response body.
+func (c *Client) send(ctx context.Context, endpoint, token string, msg Message) ([]byte, error) {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return nil, fmt.Errorf("encoding Slack message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("creating Slack request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		// The error embeds the URL, which for webhooks is a secret.
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return nil, fmt.Errorf("%w: %w", ErrRequestFailed, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: reading response: %w", ErrRequestFailed, err)
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		if len(body) > maxErrorBody {
+			body = body[:maxErrorBody]
+		}
+		return nil, fmt.Errorf("%w: HTTP %d: %s", ErrRequestFailed, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,98 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlocks(t *testing.T) {
+	msg := Message{Text: "digest", Blocks: []Block{
+		Header("Daily"),
+		Section("*Spend*", "a", "b"),
+		Divider(),
+		Context("v1"),
+	}}
+	data, err := json.Marshal(msg)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"text": "digest",
+		"blocks": [
+			{"type": "header", "text": {"type": "plain_text", "text": "Daily"}},
+			{"type": "section", "text": {"type": "mrkdwn", "text": "*Spend*"},
+			 "fields": [{"type": "mrkdwn", "text": "a"}, {"type": "mrkdwn", "text": "b"}]},
+			{"type": "divider"},
+			{"type": "context", "elements": [{"type": "mrkdwn", "text": "v1"}]}
+		]
+	}`, string(data))
+
+	assert.Equal(t, "a &lt;b&gt; &amp; c", Escape("a <b> & c"))
+}
+
+func TestPost_Webhook(t *testing.T) {
+	var got Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	c := NewClient("", srv.URL+"/services/T/B/X", "ignored", "#ignored")
+	assert.Equal(t, "incoming webhook", c.Destination())
+	require.NoError(t, c.Post(context.Background(), Message{Text: "hello"}))
+	assert.Equal(t, "hello", got.Text)
+	assert.Empty(t, got.Channel, "the webhook decides the channel")
+}
+
+func TestPost_WebhookError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte("no_service"))
+	}))
+	defer srv.Close()
+
+	err := NewClient("", srv.URL, "", "").Post(context.Background(), Message{Text: "hello"})
+	require.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "no_service")
+}
+
+func TestPost_Bot(t *testing.T) {
+	var got Message
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/chat.postMessage", r.URL.Path)
+		assert.Equal(t, "Bearer xoxb-1", r.Header.Get("Authorization"))
+		_ = json.NewDecoder(r.Body).Decode(&got)
+		_, _ = w.Write([]byte(`{"ok": true}`))
+	}))
+	defer srv.Close()
+
+	c := NewClient(srv.URL, "", "xoxb-1", "#finops")
+	assert.Equal(t, "channel #finops", c.Destination())
+	require.NoError(t, c.Post(context.Background(), Message{Text: "hello"}))
+	assert.Equal(t, "#finops", got.Channel)
+}
+
+func TestPost_BotAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = w.Write([]byte(`{"ok": false, "error": "channel_not_found"}`))
+	}))
+	defer srv.Close()
+
+	err := NewClient(srv.URL, "", "xoxb-1", "#missing").Post(context.Background(), Message{Text: "hello"})
+	require.ErrorIs(t, err, ErrRequestFailed)
+	assert.Contains(t, err.Error(), "channel_not_found")
+}
+
+func TestPost_NotConfigured(t *testing.T) {
+	err := NewClient("", "", "", "").Post(context.Background(), Message{Text: "hello"})
+	require.ErrorIs(t, err, ErrNotConfigured)
+
+	err = NewClient("", "", "xoxb-1", "").Post(context.Background(), Message{Text: "hello"})
+	require.ErrorIs(t, err, ErrNoChannel)
+}
@@ -0,0 +1,102 @@
+// Package tracing wires finfocus into OpenTelemetry.
+//
+// Instrumented code calls Tracer() and always creates spans; until Setup
+// installs an exporter the global tracer provider is a no-op, so tracing costs
+// nothing when it is not configured. Setup exports spans over OTLP/HTTP.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.38.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope of finfocus spans.
+const TracerName = "github.com/rshade/finfocus"
+
+// ServiceName is the service.name resource attribute of exported spans.
+const ServiceName = "finfocus"
+
+// Standard OpenTelemetry variables that enable tracing without --otel-endpoint.
+const (
+	EnvEndpoint       = "OTEL_EXPORTER_OTLP_ENDPOINT"
+	EnvTracesEndpoint = "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT"
+)
+
+// Span attribute keys shared by the instrumented packages.
+const (
+	AttrPlugin       = "finfocus.plugin"
+	AttrResourceID   = "finfocus.resource.id"
+	AttrResourceType = "finfocus.resource.type"
+	AttrResources    = "finfocus.resource.count"
+	AttrCacheOp      = "finfocus.cache.operation"
+	AttrTraceID      = "finfocus.trace_id"
+)
+
+// Cache event names recorded on the active span.
+const (
+	EventCacheHit  = "cache.hit"
+	EventCacheMiss = "cache.miss"
+)
+
+// Tracer returns the finfocus tracer from the global tracer provider.
+func Tracer() trace.Tracer {
+	return otel.Tracer(TracerName)
+}
+
+// Enabled reports whether an OTLP endpoint is given or set through the
+// standard OpenTelemetry environment variables.
+func Enabled(endpoint string) bool {
+	return endpoint != "" || os.Getenv(EnvEndpoint) != "" || os.Getenv(EnvTracesEndpoint) != ""
+}
+
+// Setup installs a global tracer provider that batches spans to the OTLP/HTTP
+// collector at endpoint (e.g. http://localhost:4318). An empty endpoint leaves
+// the collector to the OTEL_EXPORTER_OTLP_* environment variables. The returned
+// function flushes pending spans and must be called before exit. When tracing
+// is not enabled, Setup installs nothing and returns a no-op.
+func Setup(ctx context.Context, endpoint, version string) (func(context.Context) error, error) {
+	if !Enabled(endpoint) {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	var opts []otlptracehttp.Option
+	if endpoint != "" {
+		opts = append(opts, otlptracehttp.WithEndpointURL(endpoint))
+	}
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, fmt.Errorf("creating OTLP trace exporter: %w", err)
+	}
+
+	res, err := resource.New(ctx, resource.WithFromEnv(), resource.WithTelemetrySDK(), resource.WithAttributes(
+		semconv.ServiceName(ServiceName), semconv.ServiceVersion(strings.TrimPrefix(version, "v"))))
+	if err != nil {
+		return nil, fmt.Errorf("building trace resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{}, propagation.Baggage{}))
+	return provider.Shutdown, nil
+}
+
+// End records err on span, if any, and ends it.
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
@@ -0,0 +1,49 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestEnabled(t *testing.T) {
+	t.Setenv(EnvEndpoint, "")
+	t.Setenv(EnvTracesEndpoint, "")
+	assert.False(t, Enabled(""))
+	assert.True(t, Enabled("http://localhost:4318"))
+
+	t.Setenv(EnvTracesEndpoint, "http://collector:4318/v1/traces")
+	assert.True(t, Enabled(""))
+}
+
+func TestSetup_DisabledIsNoop(t *testing.T) {
+	t.Setenv(EnvEndpoint, "")
+	t.Setenv(EnvTracesEndpoint, "")
+
+	shutdown, err := Setup(context.Background(), "", "v1.2.3")
+	require.NoError(t, err)
+	require.NoError(t, shutdown(context.Background()))
+}
+
+func TestEnd(t *testing.T) {
+	rec := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(rec)).Tracer(TracerName)
+
+	_, ok := tracer.Start(context.Background(), "ok")
+	End(ok, nil)
+	_, failed := tracer.Start(context.Background(), "failed")
+	End(failed, errors.New("plugin timed out"))
+
+	spans := rec.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.Equal(t, "plugin timed out", spans[1].Status().Description)
+	require.Len(t, spans[1].Events(), 1, "the error is recorded as an exception event")
+}
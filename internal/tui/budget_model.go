@@ -0,0 +1,205 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// budgetStatusChromeHeight is the number of lines around the budget panel:
+// title, two rules, and the footer.
+const budgetStatusChromeHeight = 4
+
+// BudgetFetcher evaluates the budget scopes, global first.
+type BudgetFetcher func(ctx context.Context) ([]*engine.ScopedBudgetStatus, error)
+
+// budgetDataMsg carries the result of a budget refresh.
+type budgetDataMsg struct {
+	scopes []*engine.ScopedBudgetStatus
+	err    error
+}
+
+// budgetTickMsg triggers a scheduled budget refresh.
+type budgetTickMsg struct{}
+
+// BudgetStatusModel is the Bubble Tea model of 'budget status --interactive':
+// a BudgetPanel refreshed every interval and on demand. Scopes whose level
+// rose at the last refresh are marked, and the last successful evaluation
+// stays on screen when a refresh fails.
+type BudgetStatusModel struct {
+	ctx      context.Context
+	fetch    BudgetFetcher
+	interval time.Duration
+
+	panel      *BudgetPanel
+	updatedAt  time.Time
+	offset     int
+	refreshing bool
+	loading    *LoadingState
+	err        error
+	quitting   bool
+
+	width  int
+	height int
+}
+
+// NewBudgetStatusModel creates a budget view that evaluates the budgets with
+// fetch and refreshes them every interval. An interval of zero disables live
+// refresh.
+func NewBudgetStatusModel(ctx context.Context, fetch BudgetFetcher, interval time.Duration) *BudgetStatusModel {
+	return &BudgetStatusModel{
+		ctx:      ctx,
+		fetch:    fetch,
+		interval: interval,
+		loading:  NewLoadingState(),
+		width:    defaultWidth,
+		height:   defaultHeight,
+	}
+}
+
+// Init starts the first evaluation.
+func (m *BudgetStatusModel) Init() tea.Cmd {
+	m.refreshing = true
+	return tea.Batch(m.loading.Init(), m.fetchCmd())
+}
+
+// Update handles messages and updates the model state.
+func (m *BudgetStatusModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case budgetDataMsg:
+		m.refreshing = false
+		if msg.err != nil {
+			m.err = msg.err
+			return m, m.scheduleRefresh()
+		}
+		panel := NewBudgetPanel(msg.scopes)
+		if m.panel != nil {
+			panel.Raised = raisedBudgetLevels(m.panel.Scopes, msg.scopes)
+		}
+		m.panel, m.err, m.updatedAt = panel, nil, time.Now()
+		m.offset = min(m.offset, max(len(msg.scopes)-1, 0))
+		return m, m.scheduleRefresh()
+	case budgetTickMsg:
+		return m, m.startRefresh()
+	default:
+		return m, m.loading.Update(msg)
+	}
+}
+
+// handleKey handles scrolling, refresh, and quitting.
+func (m *BudgetStatusModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case keyQuit, keyEsc, keyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+	case keyDown, keyJ:
+		if m.panel != nil && m.offset < len(m.panel.Scopes)-1 {
+			m.offset++
+		}
+	case keyUp, keyK:
+		if m.offset > 0 {
+			m.offset--
+		}
+	case keyRefresh:
+		return m, m.startRefresh()
+	}
+	return m, nil
+}
+
+// startRefresh evaluates the budgets unless an evaluation is already running.
+func (m *BudgetStatusModel) startRefresh() tea.Cmd {
+	if m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return m.fetchCmd()
+}
+
+// scheduleRefresh schedules the next live refresh.
+func (m *BudgetStatusModel) scheduleRefresh() tea.Cmd {
+	if m.interval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return budgetTickMsg{} })
+}
+
+// fetchCmd runs the fetcher in the background.
+func (m *BudgetStatusModel) fetchCmd() tea.Cmd {
+	ctx, fetch := m.ctx, m.fetch
+	return func() tea.Msg {
+		scopes, err := fetch(ctx)
+		return budgetDataMsg{scopes: scopes, err: err}
+	}
+}
+
+// Err returns the error of the last refresh, or nil if it succeeded.
+func (m *BudgetStatusModel) Err() error {
+	return m.err
+}
+
+// View renders the budget panel between the title and the footer.
+func (m *BudgetStatusModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+	title := HeaderStyle.Render("FinFocus Budgets")
+	if !m.updatedAt.IsZero() {
+		updated := LabelStyle.Render("Updated " + m.updatedAt.Format(dashboardTimeLayout))
+		title += strings.Repeat(" ", max(m.width-lipgloss.Width(title)-lipgloss.Width(updated), 1)) + updated
+	}
+	b.WriteString(title)
+	b.WriteString("\n")
+	rule := LabelStyle.Render(strings.Repeat("─", max(m.width, 1)))
+	b.WriteString(rule)
+	b.WriteString("\n")
+
+	height := max(m.height-budgetStatusChromeHeight, minHeight)
+	var pane string
+	switch {
+	case m.panel != nil:
+		pane = m.panel.View(m.offset, height-BudgetPanelHeaderHeight)
+	case m.err != nil:
+		pane = CriticalStyle.Render("Error: " + m.err.Error())
+	default:
+		pane = RenderLoading(m.loading)
+	}
+	lines := strings.Split(pane, "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	b.WriteString(strings.Join(lines[:height], "\n"))
+	b.WriteString("\n")
+	b.WriteString(rule)
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter())
+	return b.String()
+}
+
+// renderFooter renders the key help and the refresh status.
+func (m *BudgetStatusModel) renderFooter() string {
+	help := LabelStyle.Render("↑↓ scroll · r refresh · q quit")
+	switch {
+	case m.refreshing:
+		return help + "  " + m.loading.spinner.View() + " Refreshing..."
+	case m.err != nil:
+		return help + "  " + CriticalStyle.Render("Refresh failed: "+m.err.Error())
+	case m.interval > 0:
+		return help + "  " + LabelStyle.Render(fmt.Sprintf("Auto-refresh every %s", m.interval))
+	default:
+		return help
+	}
+}
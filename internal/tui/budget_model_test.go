@@ -0,0 +1,81 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func updateBudgetStatus(t *testing.T, m *BudgetStatusModel, msg tea.Msg) (*BudgetStatusModel, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	bm, ok := updated.(*BudgetStatusModel)
+	require.True(t, ok)
+	return bm, cmd
+}
+
+func TestBudgetStatusModel_RefreshMarksRaisedLevels(t *testing.T) {
+	calls := 0
+	fetch := func(context.Context) ([]*engine.ScopedBudgetStatus, error) {
+		calls++
+		return []*engine.ScopedBudgetStatus{budgetScope("", 70)}, nil
+	}
+	m := NewBudgetStatusModel(context.Background(), fetch, 0)
+	m.Init()
+
+	m, cmd := updateBudgetStatus(t, m, budgetDataMsg{scopes: []*engine.ScopedBudgetStatus{budgetScope("", 70)}})
+	assert.Nil(t, cmd, "live refresh is disabled")
+	assert.NotContains(t, ansi.Strip(m.View()), "▲ OK")
+
+	m, cmd = updateBudgetStatus(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	require.NotNil(t, cmd)
+	assert.IsType(t, budgetDataMsg{}, cmd())
+	assert.Equal(t, 1, calls)
+
+	m, _ = updateBudgetStatus(t, m, budgetDataMsg{scopes: []*engine.ScopedBudgetStatus{budgetScope("", 92)}})
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "▲ CRITICAL")
+	assert.Contains(t, view, "Updated ")
+}
+
+func TestBudgetStatusModel_FailedRefreshKeepsGauges(t *testing.T) {
+	m := NewBudgetStatusModel(context.Background(), nil, time.Minute)
+	m.Init()
+
+	m, cmd := updateBudgetStatus(t, m, budgetDataMsg{scopes: []*engine.ScopedBudgetStatus{budgetScope("aws", 40)}})
+	assert.NotNil(t, cmd, "the next refresh is scheduled")
+
+	m, _ = updateBudgetStatus(t, m, budgetDataMsg{err: errors.New("plugin unavailable")})
+	require.Error(t, m.Err())
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "provider:aws")
+	assert.Contains(t, view, "Refresh failed: plugin unavailable")
+
+	m, cmd = updateBudgetStatus(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	require.NotNil(t, cmd)
+	assert.Empty(t, m.View())
+}
+
+func TestBudgetStatusModel_Scroll(t *testing.T) {
+	m := NewBudgetStatusModel(context.Background(), nil, 0)
+	m, _ = updateBudgetStatus(t, m, budgetDataMsg{scopes: []*engine.ScopedBudgetStatus{
+		budgetScope("", 10), budgetScope("aws", 20),
+	}})
+
+	for range 3 {
+		m, _ = updateBudgetStatus(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	}
+	assert.Equal(t, 1, m.offset, "scrolling stops at the last scope")
+	assert.NotContains(t, ansi.Strip(m.View()), "global")
+
+	m, _ = updateBudgetStatus(t, m, tea.KeyMsg{Type: tea.KeyUp})
+	assert.Contains(t, ansi.Strip(m.View()), "global")
+}
@@ -0,0 +1,220 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// Budget panel layout constants.
+const (
+	// BudgetPanelHeaderHeight is the number of panel lines above its rows:
+	// the legend and the column header.
+	BudgetPanelHeaderHeight = 2
+	// DefaultBudgetGaugeWidth is the default width of a budget gauge in cells.
+	DefaultBudgetGaugeWidth = 20
+
+	budgetScopeWidth   = 24
+	budgetPercentWidth = 5
+	budgetLevelWidth   = 9
+	budgetGaugeFull    = 100.0
+)
+
+// Budget gauge markers.
+const (
+	budgetFilledCell     = "█"
+	budgetEmptyCell      = "░"
+	budgetThresholdCell  = "┊"
+	budgetForecastCell   = "◆"
+	budgetOverflowCell   = "»"
+	budgetCrossedMarker  = "▲"
+	budgetLevelUnchanged = " "
+)
+
+// BudgetLevel is the health of a budget scope by the share of it spent.
+type BudgetLevel int
+
+const (
+	// BudgetLevelOK is below the warning threshold.
+	BudgetLevelOK BudgetLevel = iota
+	// BudgetLevelWarning is at or above engine.HealthThresholdWarning.
+	BudgetLevelWarning
+	// BudgetLevelCritical is at or above engine.HealthThresholdCritical.
+	BudgetLevelCritical
+	// BudgetLevelExceeded is at or above engine.HealthThresholdExceeded.
+	BudgetLevelExceeded
+)
+
+// BudgetLevelFor returns the level of a budget with percentage of it spent.
+func BudgetLevelFor(percentage float64) BudgetLevel {
+	switch {
+	case percentage >= engine.HealthThresholdExceeded:
+		return BudgetLevelExceeded
+	case percentage >= engine.HealthThresholdCritical:
+		return BudgetLevelCritical
+	case percentage >= engine.HealthThresholdWarning:
+		return BudgetLevelWarning
+	default:
+		return BudgetLevelOK
+	}
+}
+
+// String returns the level name shown in the status column.
+func (l BudgetLevel) String() string {
+	switch l {
+	case BudgetLevelOK:
+		return "OK"
+	case BudgetLevelWarning:
+		return "WARNING"
+	case BudgetLevelCritical:
+		return "CRITICAL"
+	case BudgetLevelExceeded:
+		return "EXCEEDED"
+	}
+	return ""
+}
+
+// style returns the style of gauges and labels at the level. The color steps
+// up at each threshold so a gauge changes color as spend crosses it.
+func (l BudgetLevel) style() lipgloss.Style {
+	style := lipgloss.NewStyle().Bold(true)
+	switch l {
+	case BudgetLevelOK:
+		return style.Foreground(ColorOK)
+	case BudgetLevelWarning:
+		return style.Foreground(ColorPriorityMedium)
+	case BudgetLevelCritical:
+		return style.Foreground(ColorWarning)
+	case BudgetLevelExceeded:
+		return style.Foreground(ColorCritical).Underline(true)
+	}
+	return style
+}
+
+// BudgetGauge renders the spend of a budget scope as a horizontal gauge from
+// 0 to 100% of the budget. Alert thresholds that spend has not reached are
+// marked with ┊, and the forecast end-of-period spend with ◆, or » when it
+// is beyond the budget.
+type BudgetGauge struct {
+	Width int
+}
+
+// Render returns the gauge of s.
+func (g BudgetGauge) Render(s *engine.ScopedBudgetStatus) string {
+	if g.Width <= 0 {
+		return ""
+	}
+
+	fill := BudgetLevelFor(s.Percentage).style()
+	filled := int(math.Round(math.Min(s.Percentage, budgetGaugeFull) / budgetGaugeFull * float64(g.Width)))
+	cells := make([]string, g.Width)
+	styles := make([]lipgloss.Style, g.Width)
+	for i := range cells {
+		cells[i], styles[i] = budgetEmptyCell, LabelStyle
+		if i < filled {
+			cells[i], styles[i] = budgetFilledCell, fill
+		}
+	}
+	for _, alert := range s.Alerts {
+		if at := g.cell(alert.Threshold); alert.Threshold < budgetGaugeFull && at >= filled {
+			cells[at] = budgetThresholdCell
+		}
+	}
+	if s.ForecastPercentage > 0 {
+		at := g.cell(s.ForecastPercentage)
+		cells[at], styles[at] = budgetForecastCell, BudgetLevelFor(s.ForecastPercentage).style()
+		if s.ForecastPercentage > budgetGaugeFull {
+			cells[at] = budgetOverflowCell
+		}
+	}
+
+	var b strings.Builder
+	for i, cell := range cells {
+		b.WriteString(styles[i].Render(cell))
+	}
+	return b.String()
+}
+
+// cell returns the index of the cell that percentage falls in.
+func (g BudgetGauge) cell(percentage float64) int {
+	at := int(math.Floor(percentage / budgetGaugeFull * float64(g.Width)))
+	return max(0, min(at, g.Width-1))
+}
+
+// BudgetPanel renders a gauge per budget scope with the share spent, the
+// level, the spend against the limit, and the forecast. It is the budgets
+// pane of the dashboard and the view of 'budget status'.
+type BudgetPanel struct {
+	Scopes []*engine.ScopedBudgetStatus
+	// Raised marks with ▲ the scopes, by ScopeIdentifier, whose level rose
+	// at the last refresh.
+	Raised map[string]bool
+	Gauge  BudgetGauge
+}
+
+// NewBudgetPanel creates a panel of scopes with gauges of the default width.
+func NewBudgetPanel(scopes []*engine.ScopedBudgetStatus) *BudgetPanel {
+	return &BudgetPanel{Scopes: scopes, Gauge: BudgetGauge{Width: DefaultBudgetGaugeWidth}}
+}
+
+// View renders the legend, the column header, and the rows of the scopes
+// scrolled to offset that fit in rows lines.
+func (p *BudgetPanel) View(offset, rows int) string {
+	if len(p.Scopes) == 0 {
+		return InfoStyle.Render("No budgets configured.") + "\n" +
+			LabelStyle.Render("Set cost.budgets in the config file to track spend against limits.")
+	}
+
+	var b strings.Builder
+	b.WriteString(LabelStyle.Render(fmt.Sprintf(
+		"Projected monthly spend against each budget    %s forecast  %s alert  %s level rose",
+		budgetForecastCell, budgetThresholdCell, budgetCrossedMarker)))
+	b.WriteString("\n")
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("%-*s %-*s %-*s %-14s %s",
+		budgetScopeWidth, "SCOPE", p.Gauge.Width+budgetPercentWidth+1, "USAGE",
+		budgetLevelWidth+2, "STATUS", "SPEND / LIMIT", "FORECAST")))
+	for _, s := range visibleRows(p.Scopes, offset, rows) {
+		b.WriteString("\n")
+		b.WriteString(p.renderRow(s))
+	}
+	return b.String()
+}
+
+// renderRow renders the gauge line of one scope.
+func (p *BudgetPanel) renderRow(s *engine.ScopedBudgetStatus) string {
+	level := BudgetLevelFor(s.Percentage)
+	marker := budgetLevelUnchanged
+	if p.Raised[s.ScopeIdentifier()] {
+		marker = budgetCrossedMarker
+	}
+	row := fmt.Sprintf("%-*s %s %*.0f%% %s %s %s / %s",
+		budgetScopeWidth, truncate(s.ScopeIdentifier(), budgetScopeWidth),
+		p.Gauge.Render(s), budgetPercentWidth-1, s.Percentage,
+		level.style().Render(marker), level.style().Render(fmt.Sprintf("%-*s", budgetLevelWidth, level)),
+		FormatMoneyShort(s.CurrentSpend), FormatMoney(s.Budget.Amount, s.Currency))
+	if s.ForecastedSpend > 0 {
+		row += "  " + BudgetLevelFor(s.ForecastPercentage).style().
+			Render(fmt.Sprintf("→ %s (%.0f%%)", FormatMoneyShort(s.ForecastedSpend), s.ForecastPercentage))
+	}
+	return row
+}
+
+// raisedBudgetLevels returns the scopes of next, by ScopeIdentifier, whose
+// level is above their level in prev.
+func raisedBudgetLevels(prev, next []*engine.ScopedBudgetStatus) map[string]bool {
+	levels := make(map[string]BudgetLevel, len(prev))
+	for _, s := range prev {
+		levels[s.ScopeIdentifier()] = BudgetLevelFor(s.Percentage)
+	}
+	raised := make(map[string]bool)
+	for _, s := range next {
+		if level, ok := levels[s.ScopeIdentifier()]; ok && BudgetLevelFor(s.Percentage) > level {
+			raised[s.ScopeIdentifier()] = true
+		}
+	}
+	return raised
+}
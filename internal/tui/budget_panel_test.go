@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func budgetScope(key string, percentage float64) *engine.ScopedBudgetStatus {
+	scope := &engine.ScopedBudgetStatus{
+		ScopeType: engine.ScopeTypeProvider, ScopeKey: key,
+		Budget: config.ScopedBudget{Amount: 1000}, CurrentSpend: percentage * 10,
+		Percentage: percentage, Currency: "USD",
+	}
+	if key == "" {
+		scope.ScopeType = engine.ScopeTypeGlobal
+	}
+	return scope
+}
+
+func TestBudgetLevelFor(t *testing.T) {
+	tests := []struct {
+		percentage float64
+		want       BudgetLevel
+	}{
+		{0, BudgetLevelOK},
+		{79.9, BudgetLevelOK},
+		{80, BudgetLevelWarning},
+		{90, BudgetLevelCritical},
+		{99.9, BudgetLevelCritical},
+		{100, BudgetLevelExceeded},
+		{250, BudgetLevelExceeded},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, BudgetLevelFor(tt.percentage), "%.1f%%", tt.percentage)
+	}
+	assert.Equal(t, "EXCEEDED", BudgetLevelExceeded.String())
+}
+
+func TestBudgetGauge_Render(t *testing.T) {
+	gauge := BudgetGauge{Width: 10}
+
+	withAlerts := func(s *engine.ScopedBudgetStatus, thresholds ...float64) *engine.ScopedBudgetStatus {
+		for _, threshold := range thresholds {
+			s.Alerts = append(s.Alerts, engine.ThresholdStatus{Threshold: threshold})
+		}
+		return s
+	}
+	withForecast := func(s *engine.ScopedBudgetStatus, percentage float64) *engine.ScopedBudgetStatus {
+		s.ForecastPercentage = percentage
+		return s
+	}
+
+	tests := []struct {
+		name  string
+		scope *engine.ScopedBudgetStatus
+		want  string
+	}{
+		{name: "empty", scope: budgetScope("", 0), want: "░░░░░░░░░░"},
+		{name: "half", scope: budgetScope("", 50), want: "█████░░░░░"},
+		{name: "alert thresholds ahead of spend", scope: withAlerts(budgetScope("", 35), 20, 50, 80), want: "████░┊░░┊░"},
+		{name: "forecast inside the budget", scope: withForecast(budgetScope("", 30), 75), want: "███░░░░◆░░"},
+		{name: "forecast beyond the budget", scope: withForecast(budgetScope("", 60), 140), want: "██████░░░»"},
+		{name: "exceeded", scope: budgetScope("", 180), want: "██████████"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, ansi.Strip(gauge.Render(tt.scope)))
+		})
+	}
+	assert.Empty(t, BudgetGauge{}.Render(budgetScope("", 50)))
+}
+
+func TestBudgetPanel_View(t *testing.T) {
+	global := budgetScope("", 95)
+	global.ForecastedSpend, global.ForecastPercentage = 1200, 120
+	panel := NewBudgetPanel([]*engine.ScopedBudgetStatus{global, budgetScope("aws", 40), budgetScope("gcp", 85)})
+	panel.Raised = map[string]bool{"provider:gcp": true}
+
+	lines := strings.Split(ansi.Strip(panel.View(1, 5)), "\n")
+	require.Len(t, lines, BudgetPanelHeaderHeight+2, "rows before offset are scrolled out")
+	assert.Contains(t, lines[1], "SCOPE")
+	assert.Contains(t, lines[2], "provider:aws")
+	assert.Contains(t, lines[2], "  OK ")
+	assert.Contains(t, lines[3], "▲ WARNING")
+
+	view := ansi.Strip(panel.View(0, 1))
+	assert.Contains(t, view, "CRITICAL")
+	assert.Contains(t, view, "→ $1,200.00 (120%)")
+
+	empty := ansi.Strip(NewBudgetPanel(nil).View(0, 5))
+	assert.Contains(t, empty, "No budgets configured.")
+}
+
+func TestRaisedBudgetLevels(t *testing.T) {
+	prev := []*engine.ScopedBudgetStatus{budgetScope("", 70), budgetScope("aws", 85), budgetScope("gcp", 95)}
+	next := []*engine.ScopedBudgetStatus{
+		budgetScope("", 82), budgetScope("aws", 88), budgetScope("gcp", 50), budgetScope("azure", 120),
+	}
+
+	assert.Equal(t, map[string]bool{"global": true}, raisedBudgetLevels(prev, next),
+		"only scopes known before whose level rose are marked")
+}
@@ -0,0 +1,60 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// defaultCostExportPath prefills the export prompt.
+const defaultCostExportPath = "costs.csv"
+
+// WithExporter enables exporting the filtered and sorted cost results with
+// [e]. Exports run with the model's context.
+func (m *CostViewModel) WithExporter(export ViewExporter[engine.CostResult]) *CostViewModel {
+	m.exporter = export
+	return m
+}
+
+// openExport opens the export prompt when exporting is enabled.
+func (m *CostViewModel) openExport() tea.Cmd {
+	if m.exporter == nil {
+		m.statusMsg = "Export is not available"
+		return nil
+	}
+	m.statusMsg = ""
+	m.export = newExportPrompt(defaultCostExportPath)
+	return textinput.Blink
+}
+
+// handleExportUpdate handles a message while the export prompt is open.
+func (m *CostViewModel) handleExportUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	path, closed, cmd := m.export.update(msg)
+	if !closed {
+		return m, cmd
+	}
+	m.export = nil
+	if path == "" {
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Exporting to %s...", path)
+	ctx := m.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return m, exportRowsCmd(ctx, m.exporter, path, m.results)
+}
+
+// handleViewExported reports a finished export in the status line.
+func (m *CostViewModel) handleViewExported(msg viewExportedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", msg.err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Exported %d cost result(s) to %s", msg.count, msg.path)
+	return m, nil
+}
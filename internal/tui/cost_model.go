@@ -6,12 +6,14 @@ import (
 	"sort"
 	"strings"
 
+	"github.com/charmbracelet/bubbles/spinner"
 	"github.com/charmbracelet/bubbles/table"
 	"github.com/charmbracelet/bubbles/textinput"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui/tree"
 )
 
 // Terminal and layout constants.
@@ -39,6 +41,7 @@ const (
 	keyCtrlC = "ctrl+c"
 	keySlash = "/"
 	keyS     = "s"
+	keyT     = "t"
 )
 
 // ViewState represents the current state of the TUI view.
@@ -82,6 +85,13 @@ type loadingCompleteMsg struct {
 	err     error
 }
 
+// CostResultsMsg delivers results that arrived before the loading fetcher
+// returned, so the table fills in while the rest of a large plan is still
+// being priced. The fetcher's final results replace the streamed ones.
+type CostResultsMsg struct {
+	Results []engine.CostResult
+}
+
 // CostViewModel is the Bubble Tea model for interactive cost display.
 type CostViewModel struct {
 	// View state
@@ -102,14 +112,27 @@ type CostViewModel struct {
 	showFilter bool
 
 	// Loading state
-	loading  *LoadingState
-	fetchCmd tea.Cmd
+	loading   *LoadingState
+	fetchCmd  tea.Cmd
+	streaming bool // Streamed results are shown but the fetcher has not returned.
+	stale     bool // Results changed while the detail view was open.
 
 	// Actual Cost specific
 	groupBy      engine.GroupBy
 	aggregations []engine.CrossProviderAggregation
 	isActual     bool
 
+	// Cost breakdown tree, shown instead of the table while treeView is set.
+	// With components set, the tree is the component roll-up.
+	costTree   *tree.Model[CostTreeEntry]
+	treeView   bool
+	components map[string][]string
+
+	// View export
+	export    *exportPrompt
+	exporter  ViewExporter[engine.CostResult]
+	statusMsg string
+
 	// Error handling
 	err error
 }
@@ -211,6 +234,19 @@ func (m *CostViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if loadMsg, ok := msg.(loadingCompleteMsg); ok {
 		return m.handleLoadingComplete(loadMsg)
 	}
+	if streamMsg, ok := msg.(CostResultsMsg); ok {
+		return m.handleStreamedResults(streamMsg)
+	}
+
+	// Handle finished view exports
+	if exportedMsg, ok := msg.(viewExportedMsg); ok {
+		return m.handleViewExported(exportedMsg)
+	}
+
+	// Handle export prompt
+	if m.export != nil {
+		return m.handleExportUpdate(msg)
+	}
 
 	// Handle filter input
 	if m.showFilter {
@@ -236,6 +272,12 @@ func (m *CostViewModel) handleLoadingComplete(msg loadingCompleteMsg) (tea.Model
 		m.state = ViewStateError
 		return m, tea.Quit
 	}
+	if m.streaming {
+		m.streaming = false
+		m.allResults = msg.results
+		m.refreshResults()
+		return m, nil
+	}
 	m.allResults = msg.results
 	m.results = msg.results
 	m.state = ViewStateList
@@ -244,6 +286,33 @@ func (m *CostViewModel) handleLoadingComplete(msg loadingCompleteMsg) (tea.Model
 	return m, nil
 }
 
+func (m *CostViewModel) handleStreamedResults(msg CostResultsMsg) (tea.Model, tea.Cmd) {
+	if len(msg.Results) == 0 {
+		return m, nil
+	}
+	m.allResults = append(m.allResults, msg.Results...)
+	if m.state == ViewStateLoading {
+		m.state = ViewStateList
+		m.streaming = true
+	}
+	m.refreshResults()
+	return m, nil
+}
+
+// refreshResults re-applies the filter and sort after allResults changed,
+// keeping the table cursor in place. While the detail view is open the
+// visible results are left alone so the selection does not shift.
+func (m *CostViewModel) refreshResults() {
+	if m.state == ViewStateDetail {
+		m.stale = true
+		return
+	}
+	m.stale = false
+	cursor := m.table.Cursor()
+	m.applyFilter()
+	m.table.SetCursor(cursor)
+}
+
 func (m *CostViewModel) handleFilterInput(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		switch keyMsg.String() {
@@ -264,12 +333,19 @@ func (m *CostViewModel) handleLoadingUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 }
 
 func (m *CostViewModel) handleListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if tick, ok := msg.(spinner.TickMsg); ok && m.streaming {
+		return m, m.loading.Update(tick)
+	}
 	if keyMsg, ok := msg.(tea.KeyMsg); ok {
 		switch keyMsg.String() {
 		case keyQuit, keyCtrlC:
 			m.state = ViewStateQuitting
 			return m, tea.Quit
 		case keyEnter:
+			if m.treeView {
+				m.handleTreeEnter()
+				return m, nil
+			}
 			if m.isActual && m.groupBy.IsTimeBasedGrouping() {
 				return m, nil
 			}
@@ -283,6 +359,11 @@ func (m *CostViewModel) handleListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case keyS:
 			m.cycleSort()
 			return m, nil
+		case keyE:
+			return m, m.openExport()
+		case keyT:
+			m.toggleTree()
+			return m, nil
 		case keyEsc:
 			if m.textInput.Value() != "" {
 				m.textInput.SetValue("")
@@ -291,6 +372,10 @@ func (m *CostViewModel) handleListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 	}
+	if m.treeView {
+		_, cmd := m.costTree.Update(msg)
+		return m, cmd
+	}
 	var cmd tea.Cmd
 	m.table, cmd = m.table.Update(msg)
 	return m, cmd
@@ -305,6 +390,9 @@ func (m *CostViewModel) handleGenericUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
 		case keyEsc:
 			if m.state == ViewStateDetail {
 				m.state = ViewStateList
+				if m.stale {
+					m.refreshResults()
+				}
 				m.table.Focus()
 			}
 			return m, nil
@@ -343,7 +431,8 @@ func (m *CostViewModel) applySort() {
 		return
 	}
 
-	sort.Slice(m.results, func(i, j int) bool {
+	// Ties keep their input order, which the CLI sorts by resource URN.
+	sort.SliceStable(m.results, func(i, j int) bool {
 		a, b := m.results[i], m.results[j]
 		switch m.sortBy {
 		case SortByCost:
@@ -378,6 +467,9 @@ func (m *CostViewModel) rebuildTable() {
 	default:
 		m.table = NewResultTable(m.results, availableHeight)
 	}
+	if m.treeView {
+		m.rebuildTree(availableHeight)
+	}
 }
 
 // View renders the current view.
@@ -404,6 +496,20 @@ func (m *CostViewModel) View() string {
 func (m *CostViewModel) renderListView() string {
 	summary := RenderCostSummary(m.ctx, m.results, m.width)
 	tableView := m.table.View()
+	if m.treeView {
+		tableView = m.costTree.View()
+	}
+	if m.streaming {
+		tableView = lipgloss.JoinVertical(lipgloss.Left, tableView, RenderLoading(m.loading))
+	}
+
+	if m.statusMsg != "" {
+		tableView = lipgloss.JoinVertical(lipgloss.Left, tableView, "\n"+m.statusMsg)
+	}
+
+	if m.export != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, summary, tableView, m.export.View())
+	}
 
 	if m.showFilter {
 		return lipgloss.JoinVertical(lipgloss.Left, summary, tableView, "\nFilter: "+m.textInput.View())
@@ -459,3 +459,36 @@ func TestCostViewModel_FilterByResourceType(t *testing.T) {
 	assert.Len(t, m.results, 1)
 	assert.Equal(t, "aws:ec2/instance", m.results[0].ResourceType)
 }
+
+func TestCostViewModel_StreamedResults(t *testing.T) {
+	m := NewCostViewModelWithLoading(context.Background(), func() ([]engine.CostResult, error) {
+		return nil, nil
+	})
+
+	updated, _ := m.Update(CostResultsMsg{Results: []engine.CostResult{{ResourceID: "a", Monthly: 5}}})
+	m = updated.(*CostViewModel)
+	assert.Equal(t, ViewStateList, m.state, "first streamed batch leaves the loading screen")
+	assert.True(t, m.streaming)
+	assert.Len(t, m.results, 1)
+
+	updated, _ = m.Update(CostResultsMsg{Results: []engine.CostResult{{ResourceID: "b", Monthly: 9}}})
+	m = updated.(*CostViewModel)
+	require.Len(t, m.results, 2)
+	assert.Equal(t, "b", m.results[0].ResourceID, "streamed results are sorted with the rest")
+	assert.Contains(t, m.View(), "Querying cost data")
+
+	m.selected = 0
+	m.state = ViewStateDetail
+	updated, _ = m.Update(loadingCompleteMsg{results: []engine.CostResult{
+		{ResourceID: "a", Monthly: 5}, {ResourceID: "b", Monthly: 9}, {ResourceID: "c", Monthly: 1},
+	}})
+	m = updated.(*CostViewModel)
+	assert.Equal(t, ViewStateDetail, m.state, "completion does not leave the detail view")
+	assert.False(t, m.streaming)
+	assert.Len(t, m.results, 2, "visible results stay put while the detail view is open")
+
+	updated, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m = updated.(*CostViewModel)
+	assert.Equal(t, ViewStateList, m.state)
+	assert.Len(t, m.results, 3)
+}
@@ -0,0 +1,314 @@
+package tui
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui/tree"
+)
+
+// Cost tree column widths.
+const (
+	costTreeLabelWidth = 60
+	costTreeCountWidth = 14
+	costTreeCostWidth  = 15
+)
+
+// costTreeKeySep joins the labels of a node's path into its key.
+const costTreeKeySep = "\x1f"
+
+// CostTreeLevel is the level of a node in the cost breakdown tree.
+type CostTreeLevel int
+
+// Levels of the cost breakdown tree, from the roots down. The component tree
+// has component nodes, nested as deep as the components are, above resources.
+const (
+	CostTreeProvider CostTreeLevel = iota
+	CostTreeResourceType
+	CostTreeResource
+	CostTreeComponent
+)
+
+// CostTreeEntry is a node of the cost breakdown tree: a provider, a resource
+// type, a component, or a resource, with the cost of the resources below it
+// rolled up.
+type CostTreeEntry struct {
+	Level CostTreeLevel
+	// Key identifies the node by its path, so expansion survives rebuilds.
+	Key   string
+	Label string
+	// Cost is the rolled-up cost: TotalCost for actual costs, else Monthly.
+	Cost      float64
+	Resources int
+	// Result is the cost result of a resource node; nil for other levels.
+	Result *engine.CostResult
+}
+
+// costTreeResultCost returns the cost of a result as the cost summary counts
+// it: TotalCost when present (actual costs), otherwise Monthly.
+func costTreeResultCost(r engine.CostResult) float64 {
+	if r.TotalCost > 0 {
+		return r.TotalCost
+	}
+	return r.Monthly
+}
+
+// BuildCostTree groups results by provider, then resource type, then
+// resource, with subtotals at each level. Siblings are ordered by cost,
+// highest first, then by label. Nodes whose key is in expanded start
+// expanded; with a nil set the providers are expanded.
+func BuildCostTree(results []engine.CostResult, expanded map[string]bool) []*tree.Node[CostTreeEntry] {
+	providers := make(map[string]*tree.Node[CostTreeEntry])
+	types := make(map[string]*tree.Node[CostTreeEntry])
+	var roots []*tree.Node[CostTreeEntry]
+
+	for i := range results {
+		r := &results[i]
+		cost := costTreeResultCost(*r)
+
+		provider := extractProvider(r.ResourceType)
+		p, ok := providers[provider]
+		if !ok {
+			p = newCostTreeNode(CostTreeProvider, provider, provider, expanded)
+			providers[provider] = p
+			roots = append(roots, p)
+		}
+		typeKey := provider + costTreeKeySep + r.ResourceType
+		t, ok := types[typeKey]
+		if !ok {
+			t = newCostTreeNode(CostTreeResourceType, typeKey, r.ResourceType, expanded)
+			types[typeKey] = t
+			p.Children = append(p.Children, t)
+		}
+		leaf := newCostTreeNode(CostTreeResource, typeKey+costTreeKeySep+r.ResourceID, r.ResourceID, expanded)
+		leaf.Value.Cost, leaf.Value.Resources, leaf.Value.Result = cost, 1, r
+		t.Children = append(t.Children, leaf)
+
+		for _, n := range []*tree.Node[CostTreeEntry]{p, t} {
+			n.Value.Cost += cost
+			n.Value.Resources++
+		}
+	}
+
+	sortCostTree(roots)
+	return roots
+}
+
+// BuildComponentCostTree arranges results under the Pulumi components that
+// enclose their resources, as components lists them by resource ID outermost
+// first (see engine.ResourceComponents), with subtotals at each component.
+// The result of a component itself adds to its node. Siblings are ordered by
+// cost, highest first, then by label. Nodes whose key is in expanded start
+// expanded; with a nil set the outermost components are expanded.
+func BuildComponentCostTree(
+	results []engine.CostResult,
+	components map[string][]string,
+	expanded map[string]bool,
+) []*tree.Node[CostTreeEntry] {
+	nodes := make(map[string]*tree.Node[CostTreeEntry])
+	var roots []*tree.Node[CostTreeEntry]
+	// component returns the node of the last component of chain, creating it
+	// and its ancestors as needed.
+	var component func(chain []string) *tree.Node[CostTreeEntry]
+	component = func(chain []string) *tree.Node[CostTreeEntry] {
+		urn := chain[len(chain)-1]
+		if n, ok := nodes[urn]; ok {
+			return n
+		}
+		resourceType, name := engine.SplitURN(urn)
+		n := newCostTreeNode(CostTreeComponent, urn, fmt.Sprintf("%s (%s)", name, resourceType), expanded)
+		if expanded == nil {
+			n.Expanded = len(chain) == 1
+		}
+		nodes[urn] = n
+		if len(chain) == 1 {
+			roots = append(roots, n)
+		} else {
+			parent := component(chain[:len(chain)-1])
+			parent.Children = append(parent.Children, n)
+		}
+		return n
+	}
+
+	isComponent := make(map[string]bool)
+	for _, chain := range components {
+		for _, urn := range chain {
+			isComponent[urn] = true
+		}
+	}
+
+	for i := range results {
+		r := &results[i]
+		cost := costTreeResultCost(*r)
+		chain := components[r.ResourceID]
+		self := isComponent[r.ResourceID]
+		if self {
+			chain = append(slices.Clone(chain), r.ResourceID)
+		}
+		for j := range chain {
+			n := component(chain[:j+1])
+			n.Value.Cost += cost
+			if !self {
+				n.Value.Resources++
+			}
+		}
+		if self {
+			continue
+		}
+
+		_, name := engine.SplitURN(r.ResourceID)
+		leaf := newCostTreeNode(CostTreeResource, r.ResourceID, name, expanded)
+		leaf.Value.Cost, leaf.Value.Resources, leaf.Value.Result = cost, 1, r
+		if len(chain) == 0 {
+			roots = append(roots, leaf)
+		} else {
+			parent := nodes[chain[len(chain)-1]]
+			parent.Children = append(parent.Children, leaf)
+		}
+	}
+
+	sortCostTree(roots)
+	return roots
+}
+
+// newCostTreeNode creates an empty node of the cost tree.
+func newCostTreeNode(
+	level CostTreeLevel,
+	key, label string,
+	expanded map[string]bool,
+) *tree.Node[CostTreeEntry] {
+	isExpanded := expanded[key]
+	if expanded == nil {
+		isExpanded = level == CostTreeProvider
+	}
+	return &tree.Node[CostTreeEntry]{
+		Value:    CostTreeEntry{Level: level, Key: key, Label: label},
+		Expanded: isExpanded,
+	}
+}
+
+// sortCostTree orders nodes and their descendants by cost, highest first.
+func sortCostTree(nodes []*tree.Node[CostTreeEntry]) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i].Value, nodes[j].Value
+		if a.Cost != b.Cost {
+			return a.Cost > b.Cost
+		}
+		return a.Label < b.Label
+	})
+	for _, n := range nodes {
+		sortCostTree(n.Children)
+	}
+}
+
+// expandedCostTreeKeys returns the keys of the expanded nodes of roots.
+func expandedCostTreeKeys(roots []*tree.Node[CostTreeEntry]) map[string]bool {
+	keys := make(map[string]bool)
+	for _, r := range roots {
+		r.Walk(func(n *tree.Node[CostTreeEntry], _ int) {
+			if n.Expanded {
+				keys[n.Value.Key] = true
+			}
+		})
+	}
+	return keys
+}
+
+// renderCostTreeNode renders a node of the cost tree as label, resource
+// count, and cost columns that line up across depths.
+func renderCostTreeNode(node *tree.Node[CostTreeEntry], depth int, selected bool) string {
+	e := node.Value
+	labelWidth := max(costTreeLabelWidth-tree.Indent(depth), 0)
+	label := truncate(e.Label, labelWidth)
+
+	count := ""
+	if e.Level != CostTreeResource {
+		count = fmt.Sprintf("%d resource(s)", e.Resources)
+	}
+	line := fmt.Sprintf("%-*s %*s %*s",
+		labelWidth, label,
+		costTreeCountWidth, count,
+		costTreeCostWidth, fmt.Sprintf("$%.2f", e.Cost))
+
+	switch {
+	case selected:
+		return TableSelectedStyle.Render(line)
+	case e.Level == CostTreeProvider:
+		return HeaderStyle.Render(line)
+	default:
+		return line
+	}
+}
+
+// canShowTree reports whether the cost view can switch to the tree, which
+// needs per-resource results rather than time-based aggregations.
+func (m *CostViewModel) canShowTree() bool {
+	return !(m.isActual && m.groupBy.IsTimeBasedGrouping())
+}
+
+// WithComponentTree makes the tree arrange the results under the Pulumi
+// components that enclose their resources, as components lists them by
+// resource ID outermost first, and shows the tree first.
+func (m *CostViewModel) WithComponentTree(components map[string][]string) *CostViewModel {
+	if components == nil {
+		components = map[string][]string{}
+	}
+	m.components = components
+	m.treeView = m.canShowTree()
+	if m.treeView {
+		m.rebuildTree(defaultHeight)
+	}
+	return m
+}
+
+// toggleTree switches between the table and the cost breakdown tree.
+func (m *CostViewModel) toggleTree() {
+	if !m.canShowTree() {
+		return
+	}
+	m.treeView = !m.treeView
+	m.rebuildTable()
+}
+
+// rebuildTree rebuilds the cost tree from the visible results, keeping the
+// expanded nodes and the selection of the previous tree.
+func (m *CostViewModel) rebuildTree(height int) {
+	var expanded map[string]bool
+	selectedKey := ""
+	if m.costTree != nil {
+		expanded = expandedCostTreeKeys(m.costTree.Roots())
+		if n := m.costTree.SelectedNode(); n != nil {
+			selectedKey = n.Value.Key
+		}
+	}
+	roots := BuildCostTree(m.results, expanded)
+	if m.components != nil {
+		roots = BuildComponentCostTree(m.results, m.components, expanded)
+	}
+	m.costTree = tree.New(roots, height, renderCostTreeNode)
+	if selectedKey != "" {
+		m.costTree.Select(func(n *tree.Node[CostTreeEntry]) bool { return n.Value.Key == selectedKey })
+	}
+}
+
+// handleTreeEnter opens the detail view of a selected resource and expands
+// or collapses other nodes.
+func (m *CostViewModel) handleTreeEnter() {
+	n := m.costTree.SelectedNode()
+	if n == nil {
+		return
+	}
+	if n.Value.Result == nil {
+		m.costTree.Toggle()
+		return
+	}
+	for i := range m.results {
+		if &m.results[i] == n.Value.Result {
+			m.selected = i
+			m.state = ViewStateDetail
+			return
+		}
+	}
+}
@@ -0,0 +1,153 @@
+package tui
+
+import (
+	"context"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui/tree"
+)
+
+func treeTestResults() []engine.CostResult {
+	return []engine.CostResult{
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "web", Monthly: 30},
+		{ResourceType: "aws:s3/bucket:Bucket", ResourceID: "logs", Monthly: 5},
+		{ResourceType: "aws:ec2/instance:Instance", ResourceID: "api", Monthly: 50},
+		{ResourceType: "gcp:compute/instance:Instance", ResourceID: "vm", TotalCost: 120, Monthly: 1},
+	}
+}
+
+func TestBuildCostTree_RollsUpSubtotals(t *testing.T) {
+	roots := BuildCostTree(treeTestResults(), nil)
+
+	require.Len(t, roots, 2)
+	gcp, aws := roots[0].Value, roots[1].Value
+	assert.Equal(t, "gcp", gcp.Label, "providers are ordered by cost")
+	assert.InDelta(t, 120.0, gcp.Cost, 0.001, "actual costs roll up TotalCost")
+	assert.Equal(t, "aws", aws.Label)
+	assert.InDelta(t, 85.0, aws.Cost, 0.001)
+	assert.Equal(t, 3, aws.Resources)
+	assert.True(t, roots[1].Expanded, "providers start expanded")
+
+	ec2 := roots[1].Children[0]
+	assert.Equal(t, "aws:ec2/instance:Instance", ec2.Value.Label)
+	assert.InDelta(t, 80.0, ec2.Value.Cost, 0.001)
+	assert.False(t, ec2.Expanded)
+	require.Len(t, ec2.Children, 2)
+	assert.Equal(t, "api", ec2.Children[0].Value.Label)
+	assert.Equal(t, CostTreeResource, ec2.Children[0].Value.Level)
+	require.NotNil(t, ec2.Children[0].Value.Result)
+	assert.Equal(t, "api", ec2.Children[0].Value.Result.ResourceID)
+}
+
+func TestBuildCostTree_KeepsExpandedNodes(t *testing.T) {
+	roots := BuildCostTree(treeTestResults(), nil)
+	roots[1].Children[0].Expanded = true
+	roots[0].Expanded = false
+
+	rebuilt := BuildCostTree(treeTestResults(), expandedCostTreeKeys(roots))
+	assert.False(t, rebuilt[0].Expanded)
+	assert.True(t, rebuilt[1].Expanded)
+	assert.True(t, rebuilt[1].Children[0].Expanded)
+}
+
+func TestRenderCostTreeNode_AlignsColumns(t *testing.T) {
+	roots := BuildCostTree(treeTestResults(), nil)
+	provider := renderCostTreeNode(roots[1], 0, false)
+	resourceType := renderCostTreeNode(roots[1].Children[0], 1, false)
+
+	assert.Contains(t, provider, "3 resource(s)")
+	assert.Contains(t, provider, "$85.00")
+	assert.Equal(t, lipgloss.Width(provider)+tree.Indent(0), lipgloss.Width(resourceType)+tree.Indent(1),
+		"rows end at the same column at every depth")
+}
+
+func TestCostViewModel_TreeView(t *testing.T) {
+	m := NewCostViewModel(context.Background(), treeTestResults())
+	m.rebuildTable()
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	require.True(t, m.treeView)
+	view := m.View()
+	assert.Contains(t, view, "▾ gcp")
+	assert.Contains(t, view, "▾ aws")
+
+	// Collapse gcp, expand aws > ec2, and open the most expensive instance.
+	for _, k := range []tea.KeyType{tea.KeyEnter, tea.KeyDown, tea.KeyDown, tea.KeyEnter} {
+		_, _ = m.Update(tea.KeyMsg{Type: k})
+	}
+	assert.Contains(t, m.View(), "    api")
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.Equal(t, ViewStateDetail, m.state)
+	assert.Equal(t, "api", m.results[m.selected].ResourceID)
+
+	// The tree keeps its expansion when the filter changes.
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	m.textInput.SetValue("ec2")
+	m.applyFilter()
+	assert.Contains(t, m.View(), "▾ aws:ec2/instance:Instance")
+
+	_, _ = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'t'}})
+	assert.False(t, m.treeView)
+}
+
+func componentTreeTestData() ([]engine.CostResult, map[string][]string) {
+	const (
+		site = "urn:pulumi:dev::app::my:web:Site::site"
+		cdn  = "urn:pulumi:dev::app::my:web:Site$my:web:CDN::cdn"
+	)
+	results := []engine.CostResult{
+		{ResourceType: "aws:s3/bucket:Bucket",
+			ResourceID: "urn:pulumi:dev::app::my:web:Site$aws:s3/bucket:Bucket::assets", Monthly: 5},
+		{ResourceType: "aws:cloudfront/distribution:Distribution",
+			ResourceID: "urn:pulumi:dev::app::my:web:Site$my:web:CDN$aws:cloudfront/distribution:Distribution::edge",
+			Monthly:    40},
+		{ResourceType: "aws:dynamodb/table:Table",
+			ResourceID: "urn:pulumi:dev::app::aws:dynamodb/table:Table::sessions", Monthly: 20},
+	}
+	components := map[string][]string{
+		results[0].ResourceID: {site},
+		results[1].ResourceID: {site, cdn},
+	}
+	return results, components
+}
+
+func TestBuildComponentCostTree_RollsUpComponents(t *testing.T) {
+	results, components := componentTreeTestData()
+	roots := BuildComponentCostTree(results, components, nil)
+
+	require.Len(t, roots, 2)
+	site := roots[0]
+	assert.Equal(t, "site (my:web:Site)", site.Value.Label)
+	assert.Equal(t, CostTreeComponent, site.Value.Level)
+	assert.InDelta(t, 45.0, site.Value.Cost, 0.001)
+	assert.Equal(t, 2, site.Value.Resources)
+	assert.True(t, site.Expanded, "outermost components start expanded")
+	assert.Equal(t, "sessions", roots[1].Value.Label, "resources outside components are roots")
+	assert.Equal(t, CostTreeResource, roots[1].Value.Level)
+
+	require.Len(t, site.Children, 2)
+	cdn := site.Children[0]
+	assert.Equal(t, "cdn (my:web:CDN)", cdn.Value.Label)
+	assert.InDelta(t, 40.0, cdn.Value.Cost, 0.001)
+	assert.False(t, cdn.Expanded)
+	require.Len(t, cdn.Children, 1)
+	assert.Equal(t, "edge", cdn.Children[0].Value.Label)
+}
+
+func TestCostViewModel_WithComponentTree(t *testing.T) {
+	results, components := componentTreeTestData()
+	m := NewCostViewModel(context.Background(), results).WithComponentTree(components)
+
+	require.True(t, m.treeView, "the component tree shows first")
+	view := m.View()
+	assert.Contains(t, view, "▾ site (my:web:Site)")
+	assert.Contains(t, view, "▸ cdn (my:web:CDN)")
+	assert.NotContains(t, view, "edge")
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"math"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -92,6 +93,7 @@ func RenderCostSummary(ctx context.Context, results []engine.CostResult, width i
 
 	totalCost := 0.0
 	providerCosts := make(map[string]float64)
+	stackCosts := make(map[string]float64)
 
 	recCount := 0
 	for _, r := range results {
@@ -104,6 +106,9 @@ func RenderCostSummary(ctx context.Context, results []engine.CostResult, width i
 		totalCost += cost
 		provider := extractProvider(r.ResourceType)
 		providerCosts[provider] += cost
+		if r.Stack != "" {
+			stackCosts[r.Stack] += cost
+		}
 		recCount += len(r.Recommendations)
 	}
 
@@ -149,6 +154,21 @@ func RenderCostSummary(ctx context.Context, results []engine.CostResult, width i
 	}
 	content.WriteString(LabelStyle.Render(strings.Join(providerParts, "  ")))
 
+	// Stack subtotals when several stacks were costed together.
+	if len(stackCosts) > 0 {
+		stacks := make([]string, 0, len(stackCosts))
+		for s := range stackCosts {
+			stacks = append(stacks, s)
+		}
+		sort.Strings(stacks)
+		stackParts := make([]string, 0, len(stacks))
+		for _, s := range stacks {
+			stackParts = append(stackParts, fmt.Sprintf("%s: $%.2f", s, stackCosts[s]))
+		}
+		content.WriteString("\n")
+		content.WriteString(LabelStyle.Render("Stacks: " + strings.Join(stackParts, "  ")))
+	}
+
 	// Add carbon equivalency if present.
 	if carbonInput, found := aggregateCarbonFromResults(ctx, results); found {
 		output, err := greenops.Calculate(ctx, carbonInput)
@@ -173,6 +193,11 @@ func NewResultTable(results []engine.CostResult, height int) table.Model {
 		{Title: "Recommendations", Width: 15}, //nolint:mnd // Column width.
 	}
 
+	showStack := slices.ContainsFunc(results, func(r engine.CostResult) bool { return r.Stack != "" })
+	if showStack {
+		columns = append([]table.Column{{Title: "Stack", Width: 15}}, columns...) //nolint:mnd // Column width.
+	}
+
 	rows := make([]table.Row, len(results))
 	for i, r := range results {
 		row := NewResourceRow(r)
@@ -189,6 +214,9 @@ func NewResultTable(results []engine.CostResult, height int) table.Model {
 			deltaStr,
 			recsStr,
 		}
+		if showStack {
+			rows[i] = append(table.Row{r.Stack}, rows[i]...)
+		}
 	}
 
 	t := table.New(
@@ -0,0 +1,234 @@
+package tui
+
+import (
+	"context"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// DashboardTab identifies a pane of the dashboard.
+type DashboardTab int
+
+const (
+	// DashboardTabProjected shows projected monthly costs per resource.
+	DashboardTabProjected DashboardTab = iota
+	// DashboardTabActual shows actual daily spend over time.
+	DashboardTabActual
+	// DashboardTabBudgets shows a gauge per budget scope.
+	DashboardTabBudgets
+	// DashboardTabRecommendations shows the recommendations with the largest savings.
+	DashboardTabRecommendations
+
+	dashboardTabCount
+)
+
+// String returns the tab title.
+func (t DashboardTab) String() string {
+	switch t {
+	case DashboardTabProjected:
+		return "Projected"
+	case DashboardTabActual:
+		return "Actual"
+	case DashboardTabBudgets:
+		return "Budgets"
+	case DashboardTabRecommendations:
+		return "Recommendations"
+	case dashboardTabCount:
+		return ""
+	}
+	return ""
+}
+
+// Dashboard key bindings beyond the shared keyboard constants.
+const (
+	keyTab      = "tab"
+	keyShiftTab = "shift+tab"
+	keyRight    = "right"
+	keyLeft     = "left"
+	keyH        = "h"
+	keyL        = "l"
+	keyRefresh  = "r"
+)
+
+// DashboardData is the data shown by one refresh of the dashboard.
+type DashboardData struct {
+	// Projected are the projected monthly costs of the resources, largest first.
+	Projected []engine.CostResult
+	// Actual are the actual costs of the resources over the spend window,
+	// largest first.
+	Actual []engine.CostResult
+	// DailySpend is the total actual spend per day, oldest first, starting at From.
+	DailySpend []float64
+	From       time.Time
+	// Budgets are the evaluated budget scopes, global first.
+	Budgets []*engine.ScopedBudgetStatus
+	// Recommendations are sorted by estimated savings, largest first.
+	Recommendations []engine.Recommendation
+	Currency        string
+	UpdatedAt       time.Time
+}
+
+// DashboardFetcher loads fresh dashboard data.
+type DashboardFetcher func(ctx context.Context) (*DashboardData, error)
+
+// dashboardDataMsg carries the result of a refresh.
+type dashboardDataMsg struct {
+	data *DashboardData
+	err  error
+}
+
+// dashboardTickMsg triggers a scheduled refresh.
+type dashboardTickMsg struct{}
+
+// DashboardModel is the Bubble Tea model of the multi-pane cost dashboard.
+// It refreshes its data every interval and on demand, keeping the last
+// successful data on screen when a refresh fails.
+type DashboardModel struct {
+	ctx      context.Context
+	fetch    DashboardFetcher
+	interval time.Duration
+
+	data *DashboardData
+	// budgetsRaised are the budget scopes whose level rose at the last refresh.
+	budgetsRaised map[string]bool
+	tab           DashboardTab
+	offsets       [dashboardTabCount]int
+	refreshing    bool
+	loading       *LoadingState
+	err           error
+	quitting      bool
+
+	width  int
+	height int
+}
+
+// NewDashboardModel creates a dashboard that loads its data with fetch and
+// refreshes it every interval. An interval of zero disables live refresh.
+func NewDashboardModel(ctx context.Context, fetch DashboardFetcher, interval time.Duration) *DashboardModel {
+	return &DashboardModel{
+		ctx:      ctx,
+		fetch:    fetch,
+		interval: interval,
+		loading:  NewLoadingState(),
+		width:    defaultWidth,
+		height:   defaultHeight,
+	}
+}
+
+// Init starts the first refresh. The spinner keeps ticking for the life of
+// the dashboard and is shown while a refresh runs.
+func (m *DashboardModel) Init() tea.Cmd {
+	m.refreshing = true
+	return tea.Batch(m.loading.Init(), m.fetchCmd())
+}
+
+// Update handles messages and updates the model state.
+func (m *DashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case dashboardDataMsg:
+		m.refreshing = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			if m.data != nil {
+				m.budgetsRaised = raisedBudgetLevels(m.data.Budgets, msg.data.Budgets)
+			}
+			m.data, m.err = msg.data, nil
+		}
+		return m, m.scheduleRefresh()
+	case dashboardTickMsg:
+		return m, m.startRefresh()
+	default:
+		return m, m.loading.Update(msg)
+	}
+}
+
+// handleKey handles navigation between and within panes.
+func (m *DashboardModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case keyQuit, keyEsc, keyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+	case keyTab, keyRight, keyL:
+		m.tab = (m.tab + 1) % dashboardTabCount
+	case keyShiftTab, keyLeft, keyH:
+		m.tab = (m.tab + dashboardTabCount - 1) % dashboardTabCount
+	case "1", "2", "3", "4":
+		m.tab = DashboardTab(msg.String()[0] - '1')
+	case keyDown, keyJ:
+		if m.offsets[m.tab] < m.paneRows()-1 {
+			m.offsets[m.tab]++
+		}
+	case keyUp, keyK:
+		if m.offsets[m.tab] > 0 {
+			m.offsets[m.tab]--
+		}
+	case keyRefresh:
+		return m, m.startRefresh()
+	}
+	return m, nil
+}
+
+// startRefresh fetches new data unless a refresh is already running.
+func (m *DashboardModel) startRefresh() tea.Cmd {
+	if m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return m.fetchCmd()
+}
+
+// scheduleRefresh schedules the next live refresh.
+func (m *DashboardModel) scheduleRefresh() tea.Cmd {
+	if m.interval <= 0 {
+		return nil
+	}
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return dashboardTickMsg{} })
+}
+
+// fetchCmd runs the fetcher in the background.
+func (m *DashboardModel) fetchCmd() tea.Cmd {
+	ctx, fetch := m.ctx, m.fetch
+	return func() tea.Msg {
+		data, err := fetch(ctx)
+		return dashboardDataMsg{data: data, err: err}
+	}
+}
+
+// paneRows returns the number of scrollable rows of the active pane.
+func (m *DashboardModel) paneRows() int {
+	if m.data == nil {
+		return 0
+	}
+	switch m.tab {
+	case DashboardTabProjected:
+		return len(m.data.Projected)
+	case DashboardTabActual:
+		return len(m.data.Actual)
+	case DashboardTabBudgets:
+		return len(m.data.Budgets)
+	case DashboardTabRecommendations:
+		return len(m.data.Recommendations)
+	case dashboardTabCount:
+	}
+	return 0
+}
+
+// Tab returns the active pane.
+func (m *DashboardModel) Tab() DashboardTab {
+	return m.tab
+}
+
+// Err returns the error of the last refresh, or nil if it succeeded.
+func (m *DashboardModel) Err() error {
+	return m.err
+}
@@ -0,0 +1,161 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func testDashboardData() *DashboardData {
+	return &DashboardData{
+		Projected: []engine.CostResult{
+			{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 120, Currency: "USD"},
+			{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Monthly: 80, Currency: "USD"},
+		},
+		Actual:     []engine.CostResult{{ResourceID: "web", TotalCost: 90}},
+		DailySpend: []float64{1, 4, 2, 8},
+		From:       time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Recommendations: []engine.Recommendation{
+			{ResourceID: "web", Type: "RIGHTSIZE", Description: "Use t3.small", EstimatedSavings: 40},
+		},
+		Currency:  "USD",
+		UpdatedAt: time.Date(2026, 1, 5, 12, 30, 0, 0, time.UTC),
+	}
+}
+
+func updateDashboard(t *testing.T, m *DashboardModel, msg tea.Msg) (*DashboardModel, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	dm, ok := updated.(*DashboardModel)
+	require.True(t, ok)
+	return dm, cmd
+}
+
+func TestDashboardModel_TabNavigation(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, 0)
+	m.data = testDashboardData()
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, DashboardTabActual, m.Tab())
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyShiftTab})
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyShiftTab})
+	assert.Equal(t, DashboardTabRecommendations, m.Tab(), "shift+tab wraps to the last tab")
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyRight})
+	assert.Equal(t, DashboardTabProjected, m.Tab(), "right wraps to the first tab")
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'3'}})
+	assert.Equal(t, DashboardTabBudgets, m.Tab())
+}
+
+func TestDashboardModel_ScrollIsPerTab(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, 0)
+	m.data = testDashboardData()
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	assert.Equal(t, 1, m.offsets[DashboardTabProjected], "scrolling stops at the last row")
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyTab})
+	assert.Equal(t, 0, m.offsets[DashboardTabActual])
+
+	m, _ = updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyUp})
+	assert.Equal(t, 0, m.offsets[DashboardTabActual])
+}
+
+func TestDashboardModel_Refresh(t *testing.T) {
+	calls := 0
+	fetch := func(context.Context) (*DashboardData, error) {
+		calls++
+		if calls > 1 {
+			return nil, errors.New("plugin unavailable")
+		}
+		return testDashboardData(), nil
+	}
+	m := NewDashboardModel(context.Background(), fetch, time.Minute)
+
+	require.NotNil(t, m.Init())
+	assert.True(t, m.refreshing)
+	msg := m.fetchCmd()()
+
+	m, cmd := updateDashboard(t, m, msg)
+	assert.False(t, m.refreshing)
+	require.NotNil(t, m.data)
+	require.NoError(t, m.Err())
+	assert.NotNil(t, cmd, "the next live refresh is scheduled")
+
+	m, cmd = updateDashboard(t, m, dashboardTickMsg{})
+	require.NotNil(t, cmd)
+	assert.True(t, m.refreshing)
+
+	_, again := updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	assert.Nil(t, again, "no second refresh while one runs")
+
+	m, _ = updateDashboard(t, m, cmd())
+	require.Error(t, m.Err())
+	require.NotNil(t, m.data, "the last data stays after a failed refresh")
+	assert.Contains(t, m.View(), "Refresh failed: plugin unavailable")
+}
+
+func TestDashboardModel_NoLiveRefresh(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, 0)
+	_, cmd := updateDashboard(t, m, dashboardDataMsg{data: testDashboardData()})
+	assert.Nil(t, cmd)
+}
+
+func TestDashboardModel_View(t *testing.T) {
+	m := NewDashboardModel(context.Background(), nil, 0)
+	m, _ = updateDashboard(t, m, tea.WindowSizeMsg{Width: 120, Height: 30})
+	m, _ = updateDashboard(t, m, dashboardDataMsg{data: testDashboardData()})
+
+	view := m.View()
+	assert.Contains(t, view, "FinFocus Dashboard")
+	assert.Contains(t, view, "Updated 12:30:00")
+	assert.Contains(t, view, "web")
+	assert.Contains(t, view, "$200.00")
+
+	m.tab = DashboardTabActual
+	view = m.View()
+	assert.Contains(t, view, "Spend over 4 days")
+	assert.Contains(t, view, "▂▅▃█")
+	assert.Contains(t, view, "2026-01-01 → 2026-01-04")
+
+	m.tab = DashboardTabBudgets
+	assert.Contains(t, m.View(), "No budgets configured.")
+
+	m.tab = DashboardTabRecommendations
+	view = m.View()
+	assert.Contains(t, view, "RIGHTSIZE")
+	assert.Contains(t, view, "$40.00")
+
+	m, cmd := updateDashboard(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.NotNil(t, cmd)
+	assert.Empty(t, m.View())
+}
+
+func TestRenderSparkline(t *testing.T) {
+	tests := []struct {
+		name   string
+		values []float64
+		width  int
+		want   string
+	}{
+		{"scaled to peak", []float64{1, 4, 2, 8}, 80, "▂▅▃█"},
+		{"all zero", []float64{0, 0, 0}, 80, "▁▁▁"},
+		{"keeps most recent", []float64{8, 0, 8}, 2, "▁█"},
+		{"empty", nil, 80, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, RenderSparkline(tt.values, tt.width))
+		})
+	}
+}
@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Dashboard layout constants.
+const (
+	// dashboardChromeHeight is the number of lines around the pane: title,
+	// tab bar, two rules, and the footer.
+	dashboardChromeHeight = 5
+	// dashboardPaneHeaderHeight is the number of pane lines above its rows.
+	dashboardPaneHeaderHeight = 2
+	// dashboardActualHeaderHeight is the number of actual pane lines above
+	// its rows: summary, sparkline, range, blank line, and column header.
+	dashboardActualHeaderHeight = 5
+	dashboardIDWidth            = 40
+	dashboardTypeWidth          = 28
+	dashboardScopeWidth         = 24
+	dashboardMoneyWidth         = 14
+	dashboardDescriptionWidth   = 50
+	dashboardTimeLayout         = "15:04:05"
+	dashboardDateLayout         = "2006-01-02"
+)
+
+// sparklineLevels are the bar characters of a sparkline, lowest first.
+const sparklineLevels = "▁▂▃▄▅▆▇█"
+
+// dashboardActiveTabStyle highlights the active tab.
+//
+//nolint:gochecknoglobals // Global styles are the standard pattern for lipgloss.
+var dashboardActiveTabStyle = dashboardTabStyle()
+
+func dashboardTabStyle() lipgloss.Style {
+	return selectedStyle().Bold(true)
+}
+
+// View renders the dashboard.
+func (m *DashboardModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+	b.WriteString(m.renderTitle())
+	b.WriteString("\n")
+	b.WriteString(m.renderTabs())
+	b.WriteString("\n")
+	rule := LabelStyle.Render(strings.Repeat("─", max(m.width, 1)))
+	b.WriteString(rule)
+	b.WriteString("\n")
+
+	height := max(m.height-dashboardChromeHeight, minHeight)
+	lines := strings.Split(m.renderPane(height), "\n")
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	b.WriteString(strings.Join(lines[:height], "\n"))
+	b.WriteString("\n")
+	b.WriteString(rule)
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter())
+	return b.String()
+}
+
+// renderTitle renders the title line with the time of the last refresh.
+func (m *DashboardModel) renderTitle() string {
+	title := HeaderStyle.Render("FinFocus Dashboard")
+	if m.data == nil || m.data.UpdatedAt.IsZero() {
+		return title
+	}
+	updated := LabelStyle.Render("Updated " + m.data.UpdatedAt.Format(dashboardTimeLayout))
+	gap := max(m.width-lipgloss.Width(title)-lipgloss.Width(updated), 1)
+	return title + strings.Repeat(" ", gap) + updated
+}
+
+// renderTabs renders the tab bar with the active tab highlighted.
+func (m *DashboardModel) renderTabs() string {
+	tabs := make([]string, 0, dashboardTabCount)
+	for t := range dashboardTabCount {
+		label := fmt.Sprintf(" %d %s ", t+1, t)
+		if t == m.tab {
+			tabs = append(tabs, dashboardActiveTabStyle.Render(label))
+		} else {
+			tabs = append(tabs, LabelStyle.Render(label))
+		}
+	}
+	return strings.Join(tabs, LabelStyle.Render("│"))
+}
+
+// renderFooter renders the key help and the refresh status.
+func (m *DashboardModel) renderFooter() string {
+	help := LabelStyle.Render("tab/←→ switch · ↑↓ scroll · r refresh · q quit")
+	switch {
+	case m.refreshing:
+		return help + "  " + m.loading.spinner.View() + " Refreshing..."
+	case m.err != nil:
+		return help + "  " + CriticalStyle.Render("Refresh failed: "+m.err.Error())
+	case m.interval > 0:
+		return help + "  " + LabelStyle.Render(fmt.Sprintf("Auto-refresh every %s", m.interval))
+	default:
+		return help
+	}
+}
+
+// renderPane renders the active pane in height lines.
+func (m *DashboardModel) renderPane(height int) string {
+	if m.data == nil {
+		if m.err != nil {
+			return CriticalStyle.Render("Error: " + m.err.Error())
+		}
+		return RenderLoading(m.loading)
+	}
+	offset := m.offsets[m.tab]
+	switch m.tab {
+	case DashboardTabProjected:
+		return renderDashboardProjected(m.data, offset, height-dashboardPaneHeaderHeight)
+	case DashboardTabActual:
+		return renderDashboardActual(m.data, offset, height-dashboardActualHeaderHeight, m.width)
+	case DashboardTabBudgets:
+		return renderDashboardBudgets(m.data, m.budgetsRaised, offset, height-BudgetPanelHeaderHeight)
+	case DashboardTabRecommendations:
+		return renderDashboardRecommendations(m.data, offset, height-dashboardPaneHeaderHeight)
+	case dashboardTabCount:
+	}
+	return ""
+}
+
+// renderDashboardProjected renders the projected monthly cost per resource.
+func renderDashboardProjected(data *DashboardData, offset, rows int) string {
+	total := 0.0
+	for _, r := range data.Projected {
+		total += r.Monthly
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s    %s %d\n",
+		LabelStyle.Render("Projected monthly:"), ValueStyle.Render(FormatMoney(total, data.Currency)),
+		LabelStyle.Render("Resources:"), len(data.Projected))
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("%-*s %-*s %*s",
+		dashboardIDWidth, "RESOURCE", dashboardTypeWidth, "TYPE", dashboardMoneyWidth, "MONTHLY")))
+	for _, r := range visibleRows(data.Projected, offset, rows) {
+		fmt.Fprintf(&b, "\n%-*s %-*s %*s", dashboardIDWidth, truncate(r.ResourceID, dashboardIDWidth),
+			dashboardTypeWidth, truncate(r.ResourceType, dashboardTypeWidth),
+			dashboardMoneyWidth, FormatMoneyShort(r.Monthly))
+	}
+	return b.String()
+}
+
+// renderDashboardActual renders the daily spend sparkline and the actual
+// cost per resource.
+func renderDashboardActual(data *DashboardData, offset, rows, width int) string {
+	total, peak := 0.0, 0.0
+	for _, v := range data.DailySpend {
+		total += v
+		peak = math.Max(peak, v)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s    %s %s\n",
+		LabelStyle.Render(fmt.Sprintf("Spend over %d days:", len(data.DailySpend))),
+		ValueStyle.Render(FormatMoney(total, data.Currency)),
+		LabelStyle.Render("Peak day:"), ValueStyle.Render(FormatMoneyShort(peak)))
+	b.WriteString(InfoStyle.Render(RenderSparkline(data.DailySpend, width)))
+	b.WriteString("\n")
+	if len(data.DailySpend) > 0 {
+		last := data.From.AddDate(0, 0, len(data.DailySpend)-1)
+		b.WriteString(LabelStyle.Render(data.From.Format(dashboardDateLayout) + " → " + last.Format(dashboardDateLayout)))
+	}
+	b.WriteString("\n\n")
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("%-*s %-*s %*s",
+		dashboardIDWidth, "RESOURCE", dashboardTypeWidth, "TYPE", dashboardMoneyWidth, "ACTUAL")))
+	for _, r := range visibleRows(data.Actual, offset, rows) {
+		fmt.Fprintf(&b, "\n%-*s %-*s %*s", dashboardIDWidth, truncate(r.ResourceID, dashboardIDWidth),
+			dashboardTypeWidth, truncate(r.ResourceType, dashboardTypeWidth),
+			dashboardMoneyWidth, FormatMoneyShort(r.TotalCost))
+	}
+	return b.String()
+}
+
+// renderDashboardBudgets renders a gauge per budget scope, marking the
+// scopes whose level rose at the last refresh.
+func renderDashboardBudgets(data *DashboardData, raised map[string]bool, offset, rows int) string {
+	panel := NewBudgetPanel(data.Budgets)
+	panel.Raised = raised
+	return panel.View(offset, rows)
+}
+
+// renderDashboardRecommendations renders the recommendations, largest savings first.
+func renderDashboardRecommendations(data *DashboardData, offset, rows int) string {
+	if len(data.Recommendations) == 0 {
+		return OKStyle.Render("No recommendations.")
+	}
+
+	savings := 0.0
+	for _, r := range data.Recommendations {
+		savings += r.EstimatedSavings
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s    %s %d\n",
+		LabelStyle.Render("Potential monthly savings:"), OKStyle.Render(FormatMoney(savings, data.Currency)),
+		LabelStyle.Render("Recommendations:"), len(data.Recommendations))
+	b.WriteString(HeaderStyle.Render(fmt.Sprintf("%*s  %-*s %-*s %s",
+		dashboardMoneyWidth, "SAVINGS", dashboardScopeWidth, "ACTION", dashboardIDWidth, "RESOURCE", "DESCRIPTION")))
+	for _, r := range visibleRows(data.Recommendations, offset, rows) {
+		fmt.Fprintf(&b, "\n%*s  %-*s %-*s %s", dashboardMoneyWidth, FormatMoneyShort(r.EstimatedSavings),
+			dashboardScopeWidth, truncate(r.Type, dashboardScopeWidth),
+			dashboardIDWidth, truncate(r.ResourceID, dashboardIDWidth),
+			truncate(r.Description, dashboardDescriptionWidth))
+	}
+	return b.String()
+}
+
+// visibleRows returns the rows of a pane scrolled to offset that fit in n lines.
+func visibleRows[T any](rows []T, offset, n int) []T {
+	if offset >= len(rows) || n <= 0 {
+		return nil
+	}
+	return rows[offset:min(offset+n, len(rows))]
+}
+
+// RenderSparkline renders values as a one-line bar chart scaled to the
+// largest value, keeping the most recent values that fit in width.
+//
+// Usage:
+//
+//	RenderSparkline([]float64{1, 4, 2, 8}, 80) // "▂▅▃█"
+func RenderSparkline(values []float64, width int) string {
+	if width > 0 && len(values) > width {
+		values = values[len(values)-width:]
+	}
+	peak := 0.0
+	for _, v := range values {
+		peak = math.Max(peak, v)
+	}
+
+	levels := []rune(sparklineLevels)
+	var b strings.Builder
+	for _, v := range values {
+		level := 0
+		if peak > 0 && v > 0 {
+			level = int(math.Round(v / peak * float64(len(levels)-1)))
+		}
+		b.WriteRune(levels[level])
+	}
+	return b.String()
+}
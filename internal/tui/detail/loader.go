@@ -0,0 +1,135 @@
+package detail
+
+import (
+	"context"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// LoadState is the state of a lazily loaded detail value.
+type LoadState int
+
+const (
+	// StateIdle means no load has been requested.
+	StateIdle LoadState = iota
+	// StateLoading means a fetch is in flight.
+	StateLoading
+	// StateLoaded means the value is available.
+	StateLoaded
+	// StateError means the last fetch failed and can be retried.
+	StateError
+)
+
+// FetchFunc loads the detail value for key.
+type FetchFunc[T any] func(ctx context.Context, key string) (T, error)
+
+// LoadedMsg is delivered to the Bubble Tea program when a fetch completes.
+type LoadedMsg[T any] struct {
+	Key   string
+	Value T
+	Err   error
+}
+
+// Loader fetches detail data on demand for the currently viewed key and caches
+// successful results, so revisiting an item is instant. Failed fetches are not
+// cached and can be retried.
+type Loader[T any] struct {
+	ctx   context.Context
+	fetch FetchFunc[T]
+
+	key   string
+	state LoadState
+	value T
+	err   error
+	cache map[string]T
+}
+
+// NewLoader creates a loader that runs fetch with ctx.
+func NewLoader[T any](ctx context.Context, fetch FetchFunc[T]) *Loader[T] {
+	return &Loader[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		cache: make(map[string]T),
+	}
+}
+
+// Load makes key the current key. It returns nil when the value is cached or
+// already loading, otherwise a command that performs the fetch.
+func (l *Loader[T]) Load(key string) tea.Cmd {
+	if key == l.key && (l.state == StateLoading || l.state == StateLoaded) {
+		return nil
+	}
+
+	l.key = key
+	l.err = nil
+	if value, ok := l.cache[key]; ok {
+		l.value = value
+		l.state = StateLoaded
+		return nil
+	}
+
+	var zero T
+	l.value = zero
+	l.state = StateLoading
+	ctx, fetch := l.ctx, l.fetch
+	return func() tea.Msg {
+		value, err := fetch(ctx, key)
+		return LoadedMsg[T]{Key: key, Value: value, Err: err}
+	}
+}
+
+// Retry refetches the current key after an error. It returns nil otherwise.
+func (l *Loader[T]) Retry() tea.Cmd {
+	if l.state != StateError {
+		return nil
+	}
+	key := l.key
+	l.key = ""
+	return l.Load(key)
+}
+
+// Update applies a LoadedMsg. Results for keys other than the current one are
+// cached (on success) but do not change the visible state. It reports whether
+// msg was a LoadedMsg for this loader.
+func (l *Loader[T]) Update(msg tea.Msg) bool {
+	loaded, ok := msg.(LoadedMsg[T])
+	if !ok {
+		return false
+	}
+
+	if loaded.Err == nil {
+		l.cache[loaded.Key] = loaded.Value
+	}
+	if loaded.Key != l.key {
+		return true
+	}
+
+	if loaded.Err != nil {
+		l.state = StateError
+		l.err = loaded.Err
+		return true
+	}
+	l.state = StateLoaded
+	l.value = loaded.Value
+	return true
+}
+
+// State returns the load state of the current key.
+func (l *Loader[T]) State() LoadState {
+	return l.state
+}
+
+// Value returns the loaded value for the current key (zero unless StateLoaded).
+func (l *Loader[T]) Value() T {
+	return l.value
+}
+
+// Err returns the error from the last failed fetch of the current key.
+func (l *Loader[T]) Err() error {
+	return l.err
+}
+
+// Key returns the current key.
+func (l *Loader[T]) Key() string {
+	return l.key
+}
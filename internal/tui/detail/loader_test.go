@@ -0,0 +1,73 @@
+package detail
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoader_LoadCachesSuccess(t *testing.T) {
+	calls := 0
+	loader := NewLoader(context.Background(), func(_ context.Context, key string) (string, error) {
+		calls++
+		return "value-" + key, nil
+	})
+
+	cmd := loader.Load("a")
+	require.NotNil(t, cmd)
+	assert.Equal(t, StateLoading, loader.State())
+	assert.Nil(t, loader.Load("a"), "no duplicate fetch while loading")
+
+	assert.True(t, loader.Update(cmd()))
+	assert.Equal(t, StateLoaded, loader.State())
+	assert.Equal(t, "value-a", loader.Value())
+
+	require.NotNil(t, loader.Load("b"))
+	assert.Nil(t, loader.Load("a"), "cached key loads synchronously")
+	assert.Equal(t, StateLoaded, loader.State())
+	assert.Equal(t, "value-a", loader.Value())
+	assert.Equal(t, 1, calls)
+}
+
+func TestLoader_StaleResultDoesNotChangeState(t *testing.T) {
+	loader := NewLoader(context.Background(), func(_ context.Context, key string) (string, error) {
+		return key, nil
+	})
+
+	stale := loader.Load("a")
+	require.NotNil(t, loader.Load("b"))
+
+	assert.True(t, loader.Update(stale()))
+	assert.Equal(t, StateLoading, loader.State())
+	assert.Equal(t, "b", loader.Key())
+	assert.Nil(t, loader.Load("a"), "stale success is still cached")
+}
+
+func TestLoader_RetryAfterError(t *testing.T) {
+	fail := true
+	loader := NewLoader(context.Background(), func(_ context.Context, key string) (string, error) {
+		if fail {
+			return "", errors.New("unavailable")
+		}
+		return key, nil
+	})
+
+	assert.Nil(t, loader.Retry(), "retry is a no-op before an error")
+
+	loader.Update(loader.Load("a")())
+	assert.Equal(t, StateError, loader.State())
+	require.EqualError(t, loader.Err(), "unavailable")
+
+	fail = false
+	cmd := loader.Retry()
+	require.NotNil(t, cmd)
+	loader.Update(cmd())
+	assert.Equal(t, StateLoaded, loader.State())
+	require.NoError(t, loader.Err())
+	assert.Equal(t, "a", loader.Value())
+
+	assert.False(t, loader.Update("other message"))
+}
@@ -1,8 +1,12 @@
 package tui
 
 import (
+	"io"
 	"os"
+	"sync"
 
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 	"golang.org/x/term"
 )
 
@@ -93,6 +97,87 @@ func DetectOutputMode(forceColor, noColor, plain bool) OutputMode {
 	return OutputModeInteractive
 }
 
+// OutputControls are the output settings of the global --no-color, --plain,
+// and --quiet flags, or of the output config when the flags are not given.
+type OutputControls struct {
+	// NoColor draws no colors, as NO_COLOR does.
+	NoColor bool
+	// Plain writes plain text, without styling or interactive views.
+	Plain bool
+	// Quiet leaves out tips and progress displays.
+	Quiet bool
+}
+
+// outputControls holds the controls set with SetOutputControls.
+//
+//nolint:gochecknoglobals // Set once per command before any output is rendered.
+var outputControls struct {
+	sync.Mutex
+	OutputControls
+
+	// profile is the color profile detected before controls were first set,
+	// restored when colors are enabled again.
+	profile *termenv.Profile
+}
+
+// SetOutputControls makes c the output controls of every command and view.
+// When colors are disabled, styled output is rendered without ANSI colors.
+// Call it before rendering any output.
+func SetOutputControls(c OutputControls) {
+	outputControls.Lock()
+	defer outputControls.Unlock()
+	if outputControls.profile == nil {
+		profile := lipgloss.ColorProfile()
+		outputControls.profile = &profile
+	}
+	outputControls.OutputControls = c
+	if colorEnabled(c) {
+		lipgloss.SetColorProfile(*outputControls.profile)
+	} else {
+		lipgloss.SetColorProfile(termenv.Ascii)
+	}
+}
+
+// CurrentOutputControls returns the controls last set with SetOutputControls.
+func CurrentOutputControls() OutputControls {
+	outputControls.Lock()
+	defer outputControls.Unlock()
+	return outputControls.OutputControls
+}
+
+// ColorEnabled reports whether output may be colored: neither --no-color nor
+// --plain is set, NO_COLOR is unset, and TERM is not dumb.
+func ColorEnabled() bool {
+	return colorEnabled(CurrentOutputControls())
+}
+
+func colorEnabled(c OutputControls) bool {
+	return !c.NoColor && !c.Plain && os.Getenv("NO_COLOR") == "" && os.Getenv("TERM") != "dumb"
+}
+
+// Quiet reports whether tips and progress displays are left out.
+func Quiet() bool {
+	return CurrentOutputControls().Quiet
+}
+
+// ResolveOutputMode returns the output mode of stdout under the current
+// output controls. Commands call it rather than DetectOutputMode, so that
+// the global flags and config apply everywhere.
+func ResolveOutputMode() OutputMode {
+	c := CurrentOutputControls()
+	return DetectOutputMode(false, c.NoColor, c.Plain)
+}
+
+// CanRunInteractive reports whether a full-screen view may draw on w: w is a
+// terminal, --plain is not set, and TERM is not dumb.
+func CanRunInteractive(w io.Writer) bool {
+	if CurrentOutputControls().Plain || os.Getenv("TERM") == "dumb" {
+		return false
+	}
+	f, ok := w.(interface{ Fd() uintptr })
+	return ok && term.IsTerminal(int(f.Fd()))
+}
+
 // IsTTY returns true if stdout is connected to a terminal (TTY).
 // This is useful for determining whether interactive features can be used.
 //
@@ -346,3 +346,42 @@ func TestDetectOutputMode_Integration(t *testing.T) {
 		})
 	}
 }
+
+func TestOutputControls(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+	t.Setenv("TERM", "xterm-256color")
+	t.Cleanup(func() { SetOutputControls(OutputControls{}) })
+
+	SetOutputControls(OutputControls{})
+	if !ColorEnabled() || Quiet() {
+		t.Error("expected colors and no quiet mode without controls")
+	}
+
+	SetOutputControls(OutputControls{NoColor: true, Quiet: true})
+	if ColorEnabled() {
+		t.Error("expected --no-color to disable colors")
+	}
+	if !Quiet() {
+		t.Error("expected --quiet to be reported")
+	}
+	if theme, err := ResolveTheme("dark", nil); err != nil || theme.Name != "no-color" {
+		t.Errorf("expected the no-color theme, got %q (%v)", theme.Name, err)
+	}
+
+	SetOutputControls(OutputControls{Plain: true})
+	if ResolveOutputMode() != OutputModePlain {
+		t.Error("expected --plain to select plain output")
+	}
+	if CanRunInteractive(os.Stdout) {
+		t.Error("expected --plain to disable interactive views")
+	}
+
+	SetOutputControls(OutputControls{})
+	t.Setenv("TERM", "dumb")
+	if ColorEnabled() {
+		t.Error("expected TERM=dumb to disable colors")
+	}
+	if CanRunInteractive(os.Stdout) {
+		t.Error("expected TERM=dumb to disable interactive views")
+	}
+}
@@ -0,0 +1,76 @@
+package tui
+
+import (
+	"context"
+	"slices"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// keyE opens the export prompt of list views.
+const keyE = "e"
+
+// Export prompt input constants.
+const (
+	exportInputCharLimit = 256
+	exportInputWidth     = 50
+)
+
+// ViewExporter writes the rows of a list view, in display order, to path.
+// The file extension selects the format, ".json" or ".csv"; other
+// extensions are an error.
+type ViewExporter[T any] func(ctx context.Context, path string, rows []T) error
+
+// viewExportedMsg reports the outcome of exporting a list view.
+type viewExportedMsg struct {
+	path  string
+	count int
+	err   error
+}
+
+// exportPrompt asks for the file a list view is exported to.
+type exportPrompt struct {
+	input textinput.Model
+}
+
+// newExportPrompt opens an export prompt prefilled with defaultPath.
+func newExportPrompt(defaultPath string) *exportPrompt {
+	ti := textinput.New()
+	ti.Placeholder = "path.json or path.csv"
+	ti.CharLimit = exportInputCharLimit
+	ti.Width = exportInputWidth
+	ti.SetValue(defaultPath)
+	ti.Focus()
+	return &exportPrompt{input: ti}
+}
+
+// update handles msg while the prompt is open. It reports whether the prompt
+// closed and, if the user confirmed a non-empty path, returns it.
+func (p *exportPrompt) update(msg tea.Msg) (string, bool, tea.Cmd) {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+		switch keyMsg.String() {
+		case keyEnter:
+			return p.input.Value(), true, nil
+		case keyEsc:
+			return "", true, nil
+		}
+	}
+	var cmd tea.Cmd
+	p.input, cmd = p.input.Update(msg)
+	return "", false, cmd
+}
+
+// View renders the prompt.
+func (p *exportPrompt) View() string {
+	return "\nExport to (.json or .csv): " + p.input.View()
+}
+
+// exportRowsCmd returns a command that writes a snapshot of rows to path
+// with export and reports the outcome with viewExportedMsg.
+func exportRowsCmd[T any](ctx context.Context, export ViewExporter[T], path string, rows []T) tea.Cmd {
+	rows = slices.Clone(rows)
+	return func() tea.Msg {
+		return viewExportedMsg{path: path, count: len(rows), err: export(ctx, path, rows)}
+	}
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// typePath replaces the text of an open export prompt with path.
+func typePath(t *testing.T, model tea.Model, path string) {
+	t.Helper()
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyCtrlU})
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(path)})
+}
+
+func TestRecommendationsViewModel_ExportFilteredView(t *testing.T) {
+	var gotPath string
+	var gotRecs []engine.Recommendation
+	model := NewRecommendationsViewModel(layoutTestRecommendations()).WithExporter(context.Background(),
+		func(_ context.Context, path string, recs []engine.Recommendation) error {
+			gotPath, gotRecs = path, recs
+			return nil
+		})
+	model.textInput.SetValue("unused")
+	model.applyFilter()
+
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	require.NotNil(t, model.export)
+	assert.Contains(t, model.View(), "Export to (.json or .csv): ")
+	assert.Equal(t, defaultRecommendationsExportPath, model.export.input.Value())
+
+	typePath(t, model, "out.json")
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.Nil(t, model.export)
+	_, _ = model.Update(cmd())
+
+	assert.Equal(t, "out.json", gotPath)
+	require.Len(t, gotRecs, 1, "only the filtered recommendations are exported")
+	assert.Equal(t, "r1", gotRecs[0].ResourceID)
+	assert.Equal(t, "Exported 1 recommendation(s) to out.json", model.statusMsg)
+}
+
+func TestRecommendationsViewModel_ExportCancelAndFailure(t *testing.T) {
+	model := NewRecommendationsViewModel(layoutTestRecommendations())
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	assert.Nil(t, model.export)
+	assert.Equal(t, "Export is not available", model.statusMsg)
+
+	model.WithExporter(context.Background(), func(context.Context, string, []engine.Recommendation) error {
+		return errors.New("unsupported export file")
+	})
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd, "esc cancels the export")
+	assert.Nil(t, model.export)
+
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	_, _ = model.Update(cmd())
+	assert.Equal(t, "Export failed: unsupported export file", model.statusMsg)
+}
+
+func TestCostViewModel_ExportSortedView(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceType: "aws:ec2:Instance", ResourceID: "cheap", Monthly: 5},
+		{ResourceType: "aws:ec2:Instance", ResourceID: "pricey", Monthly: 50},
+	}
+	var gotIDs []string
+	model := NewCostViewModel(context.Background(), results).WithExporter(
+		func(_ context.Context, _ string, rows []engine.CostResult) error {
+			for _, r := range rows {
+				gotIDs = append(gotIDs, r.ResourceID)
+			}
+			return nil
+		})
+
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'e'}})
+	require.NotNil(t, model.export)
+	assert.Equal(t, defaultCostExportPath, model.export.input.Value())
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	_, _ = model.Update(cmd())
+
+	assert.Equal(t, []string{"pricey", "cheap"}, gotIDs, "rows are exported in display order")
+	assert.Contains(t, model.View(), "Exported 2 cost result(s) to costs.csv")
+}
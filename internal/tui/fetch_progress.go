@@ -0,0 +1,144 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/progress"
+	"github.com/charmbracelet/bubbles/spinner"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// fetchProgressBarWidth is the width of the per-plugin progress bars.
+const fetchProgressBarWidth = 24
+
+// PluginFetchProgress is the progress of the requests sent to one plugin.
+type PluginFetchProgress struct {
+	Name            string
+	Batches         int  // Requests the plugin is sent
+	Completed       int  // Requests completed
+	Recommendations int  // Recommendations received so far
+	Failed          bool // A request failed, so the plugin is sent no more
+}
+
+// FetchProgress is a snapshot of the progress of fetching recommendations
+// from every plugin.
+type FetchProgress struct {
+	Resources  int
+	Plugins    []PluginFetchProgress
+	Elapsed    time.Duration
+	Throughput float64       // Resources per second over the last few seconds
+	ETA        time.Duration // Estimated time remaining; 0 when unknown
+}
+
+// Batches returns the number of requests completed and to send in all,
+// counting the requests a failed plugin will not be sent as completed.
+func (p FetchProgress) Batches() (int, int) {
+	var completed, total int
+	for _, plugin := range p.Plugins {
+		total += plugin.Batches
+		if plugin.Failed {
+			completed += plugin.Batches
+		} else {
+			completed += plugin.Completed
+		}
+	}
+	return completed, total
+}
+
+// Percent returns the share of requests completed, between 0 and 1.
+func (p FetchProgress) Percent() float64 {
+	completed, total := p.Batches()
+	if total == 0 {
+		return 0
+	}
+	return float64(completed) / float64(total)
+}
+
+// FetchProgressMsg updates a FetchProgressModel with a new snapshot.
+type FetchProgressMsg FetchProgress
+
+// FetchDoneMsg ends a FetchProgressModel, clearing its display.
+type FetchDoneMsg struct{}
+
+// FetchProgressModel displays the progress of fetching recommendations: the
+// completed requests, throughput, and ETA overall, and a progress bar per
+// plugin.
+type FetchProgressModel struct {
+	spinner  spinner.Model
+	bar      progress.Model
+	progress FetchProgress
+	done     bool
+}
+
+// NewFetchProgressModel creates a FetchProgressModel showing initial.
+func NewFetchProgressModel(initial FetchProgress) FetchProgressModel {
+	return FetchProgressModel{
+		spinner:  DefaultSpinner(),
+		bar:      progress.New(progress.WithDefaultGradient(), progress.WithWidth(fetchProgressBarWidth)),
+		progress: initial,
+	}
+}
+
+// Init starts the spinner.
+func (m FetchProgressModel) Init() tea.Cmd {
+	return m.spinner.Tick
+}
+
+// Update applies progress snapshots and quits on FetchDoneMsg.
+func (m FetchProgressModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case FetchProgressMsg:
+		m.progress = FetchProgress(msg)
+		return m, nil
+	case FetchDoneMsg:
+		m.done = true
+		return m, tea.Quit
+	case spinner.TickMsg:
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+	default:
+		return m, nil
+	}
+}
+
+// View renders the progress, or nothing once done so the display is
+// cleared.
+func (m FetchProgressModel) View() string {
+	if m.done {
+		return ""
+	}
+	p := m.progress
+	completed, total := p.Batches()
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Fetching recommendations for %d resources  %s",
+		m.spinner.View(), p.Resources, LabelStyle.Render(fmt.Sprintf("%d/%d batches", completed, total)))
+	if p.Throughput > 0 {
+		fmt.Fprintf(&b, "  %s", LabelStyle.Render(fmt.Sprintf("%.0f resources/s", p.Throughput)))
+	}
+	if eta := p.ETA.Round(time.Second); eta > 0 {
+		fmt.Fprintf(&b, "  %s", LabelStyle.Render("ETA "+eta.String()))
+	}
+	b.WriteString("\n")
+
+	nameWidth := 0
+	for _, plugin := range p.Plugins {
+		nameWidth = max(nameWidth, len(plugin.Name))
+	}
+	for _, plugin := range p.Plugins {
+		percent := 0.0
+		if plugin.Batches > 0 {
+			percent = float64(plugin.Completed) / float64(plugin.Batches)
+		}
+		status := fmt.Sprintf("%d/%d batches  %d recommendations",
+			plugin.Completed, plugin.Batches, plugin.Recommendations)
+		if plugin.Failed {
+			status += "  " + CriticalStyle.Render("failed")
+		}
+		fmt.Fprintf(&b, "  %-*s %s  %s\n", nameWidth, plugin.Name, m.bar.ViewAs(percent), status)
+	}
+	return b.String()
+}
@@ -0,0 +1,48 @@
+package tui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchProgress_Percent(t *testing.T) {
+	p := FetchProgress{Plugins: []PluginFetchProgress{
+		{Name: "aws", Batches: 3, Completed: 1},
+		{Name: "kubecost", Batches: 3, Completed: 1, Failed: true},
+	}}
+	completed, total := p.Batches()
+	assert.Equal(t, 4, completed, "the requests a failed plugin is not sent count as completed")
+	assert.Equal(t, 6, total)
+	assert.InDelta(t, 4.0/6, p.Percent(), 1e-9)
+	assert.Zero(t, FetchProgress{}.Percent())
+}
+
+func TestFetchProgressModel_View(t *testing.T) {
+	model := NewFetchProgressModel(FetchProgress{
+		Resources: 250,
+		Plugins:   []PluginFetchProgress{{Name: "aws", Batches: 3}, {Name: "kubecost", Batches: 3}},
+	})
+
+	updated, _ := model.Update(FetchProgressMsg{
+		Resources:  250,
+		Throughput: 120,
+		ETA:        2 * time.Second,
+		Plugins: []PluginFetchProgress{
+			{Name: "aws", Batches: 3, Completed: 2, Recommendations: 4},
+			{Name: "kubecost", Batches: 3, Completed: 1, Failed: true},
+		},
+	})
+	view := updated.View()
+	assert.Contains(t, view, "Fetching recommendations for 250 resources")
+	assert.Contains(t, view, "5/6 batches")
+	assert.Contains(t, view, "120 resources/s")
+	assert.Contains(t, view, "ETA 2s")
+	assert.Contains(t, view, "2/3 batches  4 recommendations")
+	assert.Contains(t, view, "failed")
+
+	done, cmd := updated.Update(FetchDoneMsg{})
+	assert.NotNil(t, cmd)
+	assert.Empty(t, done.View(), "the display is cleared when done")
+}
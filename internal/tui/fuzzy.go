@@ -0,0 +1,240 @@
+package tui
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Fuzzy match scoring, modelled on fzf: every matched character scores, with
+// bonuses for characters that start a word or continue a run of matches and
+// penalties for the gaps between matches.
+const (
+	fuzzyScoreMatch        = 16
+	fuzzyScoreGapStart     = -3
+	fuzzyScoreGapExtension = -1
+	// fuzzyBonusBoundary rewards a match at the start of the text or after
+	// a separator such as '-', '/', ':', or a space.
+	fuzzyBonusBoundary = 8
+	// fuzzyBonusCamel rewards an upper-case match after a lower-case letter.
+	fuzzyBonusCamel = 7
+	// fuzzyBonusConsecutive rewards a match right after the previous one.
+	fuzzyBonusConsecutive = 4
+	// fuzzyBonusFirstCharMultiplier weights the bonus of the first pattern character.
+	fuzzyBonusFirstCharMultiplier = 2
+)
+
+// FuzzyMatchStyle highlights the matched characters of fuzzy search results.
+//
+//nolint:gochecknoglobals // Global styles are the standard pattern for lipgloss.
+var FuzzyMatchStyle = fuzzyMatchStyle()
+
+func fuzzyMatchStyle() lipgloss.Style {
+	return lipgloss.NewStyle().
+		Bold(true).
+		Underline(true).
+		Foreground(ColorHighlight)
+}
+
+// FuzzyField is a named text searched by FuzzyFilter, such as a resource ID
+// or one "key=value" tag.
+type FuzzyField struct {
+	Name string
+	Text string
+}
+
+// FuzzyResult is an item matched by FuzzyFilter.
+type FuzzyResult[T any] struct {
+	Item T
+	// Score ranks the match; higher is better.
+	Score int
+	// Positions are the rune positions of the matched characters in each
+	// matched field, keyed by field name.
+	Positions map[string][]int
+}
+
+// FuzzyFilter returns the items that match every space-separated term of
+// query in at least one of their fields, best score first. Items with equal
+// scores keep their order. Each term is scored against its best field, and
+// matching is case-insensitive unless the term contains an upper-case
+// letter. An empty query matches every item with a zero score.
+//
+// Usage:
+//
+//	results := FuzzyFilter(recs, "ec2 rsz", func(r engine.Recommendation) []FuzzyField {
+//		return []FuzzyField{{Name: "resource", Text: r.ResourceID}, {Name: "type", Text: r.Type}}
+//	})
+func FuzzyFilter[T any](items []T, query string, fields func(T) []FuzzyField) []FuzzyResult[T] {
+	terms := strings.Fields(query)
+	results := make([]FuzzyResult[T], 0, len(items))
+	for _, item := range items {
+		if result, ok := fuzzyMatchItem(item, terms, fields(item)); ok {
+			results = append(results, result)
+		}
+	}
+	sort.SliceStable(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	return results
+}
+
+// fuzzyMatchItem matches every term against the best of fields.
+func fuzzyMatchItem[T any](item T, terms []string, fields []FuzzyField) (FuzzyResult[T], bool) {
+	result := FuzzyResult[T]{Item: item, Positions: make(map[string][]int)}
+	for _, term := range terms {
+		best, bestField, matched := 0, "", []int(nil)
+		for _, f := range fields {
+			score, positions, ok := FuzzyMatch(term, f.Text)
+			if ok && (matched == nil || score > best) {
+				best, bestField, matched = score, f.Name, positions
+			}
+		}
+		if matched == nil {
+			return result, false
+		}
+		result.Score += best
+		result.Positions[bestField] = mergePositions(result.Positions[bestField], matched)
+	}
+	return result, true
+}
+
+// mergePositions returns the sorted union of two position lists.
+func mergePositions(a, b []int) []int {
+	merged := append(append([]int(nil), a...), b...)
+	sort.Ints(merged)
+	out := merged[:0]
+	for i, p := range merged {
+		if i == 0 || p != merged[i-1] {
+			out = append(out, p)
+		}
+	}
+	return out
+}
+
+// FuzzyMatch reports whether the characters of pattern appear in text in
+// order, and returns the score of the match and the rune positions of the
+// matched characters. It picks the shortest window that ends at the first
+// complete match, then matches within it from the left, as fzf's v1
+// algorithm does.
+func FuzzyMatch(pattern, text string) (int, []int, bool) {
+	p := []rune(pattern)
+	if len(p) == 0 {
+		return 0, nil, true
+	}
+	t := []rune(text)
+	caseSensitive := strings.IndexFunc(pattern, unicode.IsUpper) >= 0
+	eq := func(pc, tc rune) bool {
+		if caseSensitive {
+			return pc == tc
+		}
+		return unicode.ToLower(pc) == unicode.ToLower(tc)
+	}
+
+	// Forward scan for the end of the first complete match.
+	pi, end := 0, -1
+	for ti := 0; ti < len(t) && end < 0; ti++ {
+		if eq(p[pi], t[ti]) {
+			pi++
+			if pi == len(p) {
+				end = ti
+			}
+		}
+	}
+	if end < 0 {
+		return 0, nil, false
+	}
+
+	// Backward scan for the shortest window ending there.
+	start := end
+	for pi = len(p) - 1; start >= 0; start-- {
+		if eq(p[pi], t[start]) {
+			pi--
+			if pi < 0 {
+				break
+			}
+		}
+	}
+
+	positions := make([]int, 0, len(p))
+	pi = 0
+	for ti := start; ti <= end && pi < len(p); ti++ {
+		if eq(p[pi], t[ti]) {
+			positions = append(positions, ti)
+			pi++
+		}
+	}
+	return fuzzyScore(t, positions), positions, true
+}
+
+// fuzzyScore scores the matched positions of text.
+func fuzzyScore(text []rune, positions []int) int {
+	score := 0
+	for i, pos := range positions {
+		bonus := fuzzyCharBonus(text, pos)
+		if i > 0 {
+			gap := pos - positions[i-1] - 1
+			if gap == 0 {
+				bonus = max(bonus, fuzzyBonusConsecutive)
+			} else {
+				score += fuzzyScoreGapStart + (gap-1)*fuzzyScoreGapExtension
+			}
+		} else {
+			bonus *= fuzzyBonusFirstCharMultiplier
+		}
+		score += fuzzyScoreMatch + bonus
+	}
+	return score
+}
+
+// fuzzyCharBonus returns the position bonus of the character at pos.
+func fuzzyCharBonus(text []rune, pos int) int {
+	if pos == 0 {
+		return fuzzyBonusBoundary
+	}
+	prev, cur := text[pos-1], text[pos]
+	switch {
+	case !unicode.IsLetter(prev) && !unicode.IsDigit(prev):
+		return fuzzyBonusBoundary
+	case unicode.IsLower(prev) && unicode.IsUpper(cur):
+		return fuzzyBonusCamel
+	default:
+		return 0
+	}
+}
+
+// HighlightMatches renders text with the runes at positions in match style
+// and the rest in base style. Runs of matched and unmatched runes are styled
+// separately so that a background in base covers the whole text.
+func HighlightMatches(text string, positions []int, base, match lipgloss.Style) string {
+	if len(positions) == 0 {
+		return base.Render(text)
+	}
+	matched := make(map[int]bool, len(positions))
+	for _, p := range positions {
+		matched[p] = true
+	}
+	match = match.Inherit(base)
+
+	var b, run strings.Builder
+	inMatch := false
+	flush := func() {
+		if run.Len() == 0 {
+			return
+		}
+		if inMatch {
+			b.WriteString(match.Render(run.String()))
+		} else {
+			b.WriteString(base.Render(run.String()))
+		}
+		run.Reset()
+	}
+	for i, r := range []rune(text) {
+		if matched[i] != inMatch {
+			flush()
+			inMatch = matched[i]
+		}
+		run.WriteRune(r)
+	}
+	flush()
+	return b.String()
+}
@@ -0,0 +1,77 @@
+package tui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFuzzyMatch(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		text      string
+		wantOK    bool
+		positions []int
+	}{
+		{"subsequence", "ec2i", "aws:ec2/instance", true, []int{4, 5, 6, 8}},
+		{"case-insensitive lower pattern", "web", "WebServer", true, []int{0, 1, 2}},
+		{"upper pattern is case-sensitive", "Web", "webserver", false, nil},
+		{"shortest window", "ab", "a--a-b", true, []int{3, 5}},
+		{"out of order", "ba", "ab", false, nil},
+		{"empty pattern", "", "anything", true, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, positions, ok := FuzzyMatch(tt.pattern, tt.text)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.positions, positions)
+		})
+	}
+}
+
+func TestFuzzyMatch_ScoresBoundariesAndRuns(t *testing.T) {
+	boundary, _, _ := FuzzyMatch("db", "prod-db")
+	scattered, _, _ := FuzzyMatch("db", "dashboard")
+	assert.Greater(t, boundary, scattered, "a run at a word boundary beats a scattered match")
+
+	camel, _, _ := FuzzyMatch("si", "rightSizeInstance")
+	plain, _, _ := FuzzyMatch("si", "rightsizeinstance")
+	assert.Greater(t, camel, plain, "camel-case humps score a bonus")
+}
+
+func TestFuzzyFilter(t *testing.T) {
+	type row struct{ id, kind string }
+	rows := []row{
+		{"database-replica", "TERMINATE"},
+		{"web-server", "RIGHTSIZE"},
+		{"prod-db", "RIGHTSIZE"},
+	}
+	fields := func(r row) []FuzzyField {
+		return []FuzzyField{{Name: "id", Text: r.id}, {Name: "kind", Text: r.kind}}
+	}
+
+	results := FuzzyFilter(rows, "db", fields)
+	require.Len(t, results, 2)
+	assert.Equal(t, "prod-db", results[0].Item.id, "the better match ranks first")
+	assert.Equal(t, []int{5, 6}, results[0].Positions["id"])
+
+	results = FuzzyFilter(rows, "db RIGHT", fields)
+	require.Len(t, results, 1, "every term must match")
+	assert.Equal(t, "prod-db", results[0].Item.id)
+	assert.Equal(t, []int{0, 1, 2, 3, 4}, results[0].Positions["kind"])
+
+	assert.Len(t, FuzzyFilter(rows, "  ", fields), 3, "a blank query matches everything")
+}
+
+func TestHighlightMatches(t *testing.T) {
+	base := lipgloss.NewStyle()
+	assert.Equal(t, "plain", HighlightMatches("plain", nil, base, FuzzyMatchStyle))
+
+	out := HighlightMatches("web-db", []int{4, 5}, base, FuzzyMatchStyle)
+	assert.Contains(t, out, "web-")
+	assert.Contains(t, out, "db")
+	assert.Equal(t, lipgloss.Width("web-db"), lipgloss.Width(out), "styling does not change the width")
+}
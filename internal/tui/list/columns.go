@@ -0,0 +1,272 @@
+package listview
+
+import (
+	"slices"
+	"strings"
+)
+
+// Column rendering constants.
+const (
+	// columnGap separates adjacent cells.
+	columnGap = "  "
+	// sortIndicator marks the title of the sort column.
+	sortIndicator = "▼"
+	// ellipsis replaces the end of truncated cells.
+	ellipsis = "..."
+)
+
+// Column describes one column of a list rendered from columns.
+type Column[T any] struct {
+	// Key identifies the column in a saved Layout.
+	Key string
+	// Title is the column header.
+	Title string
+	// Width is the cell width in characters.
+	Width int
+	// AlignRight right-aligns the cells, for numbers.
+	AlignRight bool
+	// Value returns the cell text of an item.
+	Value func(item T) string
+	// Less orders items for sorting by this column. Nil columns are not sortable.
+	Less func(a, b T) bool
+}
+
+// Sortable reports whether items can be sorted by the column.
+func (c Column[T]) Sortable() bool {
+	return c.Less != nil
+}
+
+// Layout is a user's arrangement of a column list: the visible columns in
+// display order and the sort column, both by Key. It is what gets persisted
+// so a list looks the same across runs.
+type Layout struct {
+	// Columns are the keys of the visible columns in display order. Empty
+	// shows every column in definition order.
+	Columns []string
+	// SortBy is the key of the sort column, or empty for the item order.
+	SortBy string
+}
+
+// ColumnSet holds the columns of a list and the user's layout of them:
+// their order, which are visible, and which one the items are sorted by.
+type ColumnSet[T any] struct {
+	// columns are all columns in display order, hidden ones included.
+	columns []Column[T]
+	// hidden holds the keys of hidden columns.
+	hidden map[string]bool
+	// sortBy is the key of the sort column, or empty.
+	sortBy string
+}
+
+// NewColumnSet creates a column set with layout applied to columns. Columns
+// named in the layout are shown in its order, followed by the remaining
+// columns hidden. Unknown keys and a sort column that is unknown or not
+// sortable are ignored, so saved layouts survive changes to the columns.
+func NewColumnSet[T any](columns []Column[T], layout Layout) *ColumnSet[T] {
+	s := &ColumnSet[T]{hidden: make(map[string]bool)}
+
+	byKey := make(map[string]Column[T], len(columns))
+	for _, c := range columns {
+		byKey[c.Key] = c
+	}
+	for _, key := range layout.Columns {
+		if c, ok := byKey[key]; ok {
+			s.columns = append(s.columns, c)
+			delete(byKey, key)
+		}
+	}
+	if len(s.columns) == 0 {
+		s.columns = slices.Clone(columns)
+	} else {
+		for _, c := range columns {
+			if _, rest := byKey[c.Key]; rest {
+				s.columns = append(s.columns, c)
+				s.hidden[c.Key] = true
+			}
+		}
+	}
+
+	s.SetSortBy(layout.SortBy)
+	return s
+}
+
+// Layout returns the current layout.
+func (s *ColumnSet[T]) Layout() Layout {
+	layout := Layout{SortBy: s.sortBy}
+	for _, c := range s.Visible() {
+		layout.Columns = append(layout.Columns, c.Key)
+	}
+	return layout
+}
+
+// All returns every column in display order, hidden ones included.
+func (s *ColumnSet[T]) All() []Column[T] {
+	return s.columns
+}
+
+// Visible returns the visible columns in display order.
+func (s *ColumnSet[T]) Visible() []Column[T] {
+	visible := make([]Column[T], 0, len(s.columns))
+	for _, c := range s.columns {
+		if !s.hidden[c.Key] {
+			visible = append(visible, c)
+		}
+	}
+	return visible
+}
+
+// IsHidden reports whether the column with key is hidden.
+func (s *ColumnSet[T]) IsHidden(key string) bool {
+	return s.hidden[key]
+}
+
+// SortBy returns the key of the sort column, or empty if items keep their order.
+func (s *ColumnSet[T]) SortBy() string {
+	return s.sortBy
+}
+
+// SetSortBy sorts by the column with key. Unknown and unsortable keys clear
+// the sort column.
+func (s *ColumnSet[T]) SetSortBy(key string) {
+	s.sortBy = ""
+	for _, c := range s.columns {
+		if c.Key == key && c.Sortable() {
+			s.sortBy = key
+			return
+		}
+	}
+}
+
+// CycleSort moves the sort column to the next visible sortable column in
+// display order, wrapping around. It reports whether the sort column changed.
+func (s *ColumnSet[T]) CycleSort() bool {
+	var sortable []string
+	for _, c := range s.Visible() {
+		if c.Sortable() {
+			sortable = append(sortable, c.Key)
+		}
+	}
+	if len(sortable) == 0 {
+		return false
+	}
+	next := sortable[(slices.Index(sortable, s.sortBy)+1)%len(sortable)]
+	changed := next != s.sortBy
+	s.sortBy = next
+	return changed
+}
+
+// less returns the order of the sort column, or nil if items keep their order.
+func (s *ColumnSet[T]) less() func(a, b T) bool {
+	for _, c := range s.columns {
+		if c.Key == s.sortBy {
+			return c.Less
+		}
+	}
+	return nil
+}
+
+// Toggle shows or hides the column at display position i. The last visible
+// column cannot be hidden. It reports whether the column changed.
+func (s *ColumnSet[T]) Toggle(i int) bool {
+	if i < 0 || i >= len(s.columns) {
+		return false
+	}
+	key := s.columns[i].Key
+	if s.hidden[key] {
+		delete(s.hidden, key)
+		return true
+	}
+	if len(s.Visible()) == 1 {
+		return false
+	}
+	s.hidden[key] = true
+	return true
+}
+
+// Move moves the column at display position i by delta positions, clamped
+// to the ends, and returns its new position.
+func (s *ColumnSet[T]) Move(i, delta int) int {
+	if i < 0 || i >= len(s.columns) {
+		return i
+	}
+	j := min(max(i+delta, 0), len(s.columns)-1)
+	c := s.columns[i]
+	s.columns = slices.Delete(s.columns, i, i+1)
+	s.columns = slices.Insert(s.columns, j, c)
+	return j
+}
+
+// Header renders the titles of the visible columns, marking the sort column.
+func (s *ColumnSet[T]) Header() string {
+	cells := make([]string, 0, len(s.columns))
+	for _, c := range s.Visible() {
+		title := c.Title
+		if c.Key == s.sortBy {
+			title = truncateCell(title, c.Width-len([]rune(sortIndicator))-1) + " " + sortIndicator
+		}
+		cells = append(cells, fitCell(title, c.Width, c.AlignRight))
+	}
+	return strings.Join(cells, columnGap)
+}
+
+// Row renders the cells of item for the visible columns.
+func (s *ColumnSet[T]) Row(item T) string {
+	cells := make([]string, 0, len(s.columns))
+	for _, c := range s.Visible() {
+		cells = append(cells, fitCell(c.Value(item), c.Width, c.AlignRight))
+	}
+	return strings.Join(cells, columnGap)
+}
+
+// RowPositions maps rune positions within the column values of item, keyed
+// by column key, to rune positions in Row(item). Positions that are hidden,
+// truncated away, or under an ellipsis are dropped. Use it to highlight
+// search matches in rendered rows.
+func (s *ColumnSet[T]) RowPositions(item T, positions map[string][]int) []int {
+	var out []int
+	offset := 0
+	for _, c := range s.Visible() {
+		value := []rune(c.Value(item))
+		shown := len([]rune(truncateCell(string(value), c.Width)))
+		if shown < len(value) && shown > len(ellipsis) {
+			shown -= len(ellipsis)
+		}
+		pad := 0
+		if c.AlignRight {
+			pad = max(c.Width-len(value), 0)
+		}
+		for _, p := range positions[c.Key] {
+			if p >= 0 && p < shown {
+				out = append(out, offset+pad+p)
+			}
+		}
+		offset += max(c.Width, 0) + len(columnGap)
+	}
+	return out
+}
+
+// fitCell truncates text to width characters and pads it to fill them.
+func fitCell(text string, width int, alignRight bool) string {
+	text = truncateCell(text, width)
+	pad := strings.Repeat(" ", max(width-len([]rune(text)), 0))
+	if alignRight {
+		return pad + text
+	}
+	return text + pad
+}
+
+// truncateCell shortens text to at most width characters, ending it with an
+// ellipsis when there is room for one.
+func truncateCell(text string, width int) string {
+	runes := []rune(text)
+	switch {
+	case width <= 0:
+		return ""
+	case len(runes) <= width:
+		return text
+	case width <= len(ellipsis):
+		return string(runes[:width])
+	default:
+		return string(runes[:width-len(ellipsis)]) + ellipsis
+	}
+}
@@ -6,6 +6,10 @@
 //   - Keyboard navigation (up/down, pgup/pgdn, home/end)
 //   - Integration with Bubble Tea's viewport and lipgloss styling
 //   - Smooth scrolling with <100ms latency target
+//   - Column lists with sorting ("s") and a column picker ("c") whose layout
+//     owners persist on LayoutChangedMsg
+//   - Lists read from a Source, such as a spill.Store of results spilled to
+//     disk, that hold only the visible rows in memory
 //
 // Virtual scrolling enables responsive TUI experiences even with massive datasets,
 // ensuring the application starts immediately without pre-rendering all rows.
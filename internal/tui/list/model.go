@@ -12,6 +12,14 @@ const defaultBufferSize = 5
 // halfViewportDivisor is used to calculate half the viewport height for centering.
 const halfViewportDivisor = 2
 
+// Source supplies the items of a list that are not held in a slice, such as
+// a spill.Store of results too many to keep in memory. Slice returns the
+// items from index from up to, but not including, to.
+type Source[T any] interface {
+	Len() int
+	Slice(from, to int) ([]T, error)
+}
+
 // RenderFunc is a function that renders an item at a given index.
 // The selected parameter indicates whether this item is currently selected.
 type RenderFunc[T any] func(item T, selected bool) string
@@ -20,9 +28,12 @@ type RenderFunc[T any] func(item T, selected bool) string
 // It renders only the visible portion of the list plus a small buffer,
 // allowing smooth scrolling with 10,000+ items without performance degradation.
 type VirtualListModel[T any] struct {
-	// items contains all list items
+	// items contains all list items, unless source supplies them
 	items []T
 
+	// source supplies the items of lists created from a Source; nil otherwise
+	source Source[T]
+
 	// renderFunc renders a single item
 	renderFunc RenderFunc[T]
 
@@ -43,6 +54,12 @@ type VirtualListModel[T any] struct {
 
 	// bufferSize is the number of extra rows to render above/below viewport
 	bufferSize int
+
+	// columns lays out the rows of a column list; nil for other lists
+	columns *ColumnSet[T]
+
+	// picker is the column picker overlay while it is shown
+	picker *columnPicker
 }
 
 // NewVirtualListModel creates a new virtual list model.
@@ -64,6 +81,36 @@ func NewVirtualListModel[T any](items []T, height, width int, renderFunc RenderF
 	return m
 }
 
+// NewVirtualListModelFromSource creates a virtual list whose items are read
+// from source as they are shown, so the list holds no more than the visible
+// rows in memory. The source must not shrink while the list shows it.
+func NewVirtualListModelFromSource[T any](
+	source Source[T],
+	height, width int,
+	renderFunc RenderFunc[T],
+) *VirtualListModel[T] {
+	m := NewVirtualListModel[T](nil, height, width, renderFunc)
+	m.source = source
+	m.updateVisibleRange()
+	return m
+}
+
+// count returns the number of items in the list.
+func (m *VirtualListModel[T]) count() int {
+	if m.source != nil {
+		return m.source.Len()
+	}
+	return len(m.items)
+}
+
+// window returns the items from index from up to to.
+func (m *VirtualListModel[T]) window(from, to int) ([]T, error) {
+	if m.source != nil {
+		return m.source.Slice(from, to)
+	}
+	return m.items[from:to], nil
+}
+
 // Init initializes the model (required for tea.Model interface).
 func (m *VirtualListModel[T]) Init() tea.Cmd {
 	return nil
@@ -73,6 +120,9 @@ func (m *VirtualListModel[T]) Init() tea.Cmd {
 func (m *VirtualListModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
+		if cmd, handled := m.handleColumnKey(msg); handled {
+			return m, cmd
+		}
 		return m.handleKeyMsg(msg), nil
 	case tea.WindowSizeMsg:
 		m.height = msg.Height
@@ -88,7 +138,7 @@ func (m *VirtualListModel[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 //
 //nolint:gocognit,exhaustive // Key handling inherently requires multiple branches for different navigation keys.
 func (m *VirtualListModel[T]) handleKeyMsg(msg tea.KeyMsg) tea.Model {
-	if len(m.items) == 0 {
+	if m.count() == 0 {
 		return m
 	}
 
@@ -100,7 +150,7 @@ func (m *VirtualListModel[T]) handleKeyMsg(msg tea.KeyMsg) tea.Model {
 		}
 
 	case tea.KeyDown:
-		if m.selected < len(m.items)-1 {
+		if m.selected < m.count()-1 {
 			m.selected++
 			m.updateVisibleRange()
 		}
@@ -114,8 +164,8 @@ func (m *VirtualListModel[T]) handleKeyMsg(msg tea.KeyMsg) tea.Model {
 
 	case tea.KeyPgDown:
 		m.selected += m.height
-		if m.selected >= len(m.items) {
-			m.selected = len(m.items) - 1
+		if m.selected >= m.count() {
+			m.selected = m.count() - 1
 		}
 		m.updateVisibleRange()
 
@@ -124,7 +174,7 @@ func (m *VirtualListModel[T]) handleKeyMsg(msg tea.KeyMsg) tea.Model {
 		m.updateVisibleRange()
 
 	case tea.KeyEnd:
-		m.selected = len(m.items) - 1
+		m.selected = m.count() - 1
 		m.updateVisibleRange()
 
 	case tea.KeyRunes:
@@ -132,7 +182,7 @@ func (m *VirtualListModel[T]) handleKeyMsg(msg tea.KeyMsg) tea.Model {
 		if len(msg.Runes) > 0 {
 			switch msg.Runes[0] {
 			case 'j':
-				if m.selected < len(m.items)-1 {
+				if m.selected < m.count()-1 {
 					m.selected++
 					m.updateVisibleRange()
 				}
@@ -154,7 +204,7 @@ func (m *VirtualListModel[T]) handleKeyMsg(msg tea.KeyMsg) tea.Model {
 // updateVisibleRange calculates the visible range of items based on selection and viewport.
 // This ensures the selected item is always visible and updates visibleFrom/visibleTo.
 func (m *VirtualListModel[T]) updateVisibleRange() {
-	if len(m.items) == 0 {
+	if m.count() == 0 {
 		m.visibleFrom = 0
 		m.visibleTo = 0
 		return
@@ -174,8 +224,8 @@ func (m *VirtualListModel[T]) updateVisibleRange() {
 	}
 
 	// Adjust if we're near the end
-	if idealTo > len(m.items) {
-		idealTo = len(m.items)
+	if idealTo > m.count() {
+		idealTo = m.count()
 		idealFrom = idealTo - m.height
 		if idealFrom < 0 {
 			idealFrom = 0
@@ -189,7 +239,10 @@ func (m *VirtualListModel[T]) updateVisibleRange() {
 
 // View renders the visible portion of the list with buffer.
 func (m *VirtualListModel[T]) View() string {
-	if len(m.items) == 0 {
+	if m.picker != nil {
+		return m.viewPicker()
+	}
+	if m.count() == 0 {
 		return ""
 	}
 
@@ -200,15 +253,20 @@ func (m *VirtualListModel[T]) View() string {
 	}
 
 	renderTo := m.visibleTo + m.bufferSize
-	if renderTo > len(m.items) {
-		renderTo = len(m.items)
+	if renderTo > m.count() {
+		renderTo = m.count()
+	}
+
+	items, err := m.window(renderFrom, renderTo)
+	if err != nil {
+		return "Error reading items: " + err.Error()
 	}
 
 	var content string
 	var contentSb199 strings.Builder
-	for i := renderFrom; i < renderTo; i++ {
-		isSelected := i == m.selected
-		line := m.renderFunc(m.items[i], isSelected)
+	for i, item := range items {
+		isSelected := renderFrom+i == m.selected
+		line := m.renderFunc(item, isSelected)
 		contentSb199.WriteString(line + "\n")
 	}
 	content += contentSb199.String()
@@ -223,7 +281,7 @@ func (m *VirtualListModel[T]) View() string {
 
 // ItemCount returns the total number of items in the list.
 func (m *VirtualListModel[T]) ItemCount() int {
-	return len(m.items)
+	return m.count()
 }
 
 // Selected returns the currently selected item index.
@@ -233,7 +291,7 @@ func (m *VirtualListModel[T]) Selected() int {
 
 // SetSelected sets the selected item index, capping to valid bounds.
 func (m *VirtualListModel[T]) SetSelected(index int) {
-	if len(m.items) == 0 {
+	if m.count() == 0 {
 		m.selected = 0
 		return
 	}
@@ -241,8 +299,8 @@ func (m *VirtualListModel[T]) SetSelected(index int) {
 	switch {
 	case index < 0:
 		m.selected = 0
-	case index >= len(m.items):
-		m.selected = len(m.items) - 1
+	case index >= m.count():
+		m.selected = m.count() - 1
 	default:
 		m.selected = index
 	}
@@ -271,10 +329,17 @@ func (m *VirtualListModel[T]) Width() int {
 }
 
 // GetSelectedItem returns the currently selected item.
-// Returns nil if list is empty.
+// Returns nil if list is empty or the item cannot be read from its source.
 func (m *VirtualListModel[T]) GetSelectedItem() *T {
-	if len(m.items) == 0 || m.selected < 0 || m.selected >= len(m.items) {
+	if m.count() == 0 || m.selected < 0 || m.selected >= m.count() {
 		return nil
 	}
+	if m.source != nil {
+		items, err := m.source.Slice(m.selected, m.selected+1)
+		if err != nil {
+			return nil
+		}
+		return &items[0]
+	}
 	return &m.items[m.selected]
 }
@@ -0,0 +1,212 @@
+package listview
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Keys of column lists and the column picker.
+const (
+	keySort      = "s"
+	keyPicker    = "c"
+	keySpace     = " "
+	keyX         = "x"
+	keyEnter     = "enter"
+	keyEsc       = "esc"
+	keyUp        = "up"
+	keyDown      = "down"
+	keyK         = "k"
+	keyJ         = "j"
+	keyMoveUp    = "K"
+	keyMoveDown  = "J"
+	keyShiftUp   = "shift+up"
+	keyShiftDown = "shift+down"
+)
+
+// Column picker rendering.
+const (
+	pickerHelp    = "space toggle · K/J move · enter done"
+	pickerChecked = "[x]"
+	pickerCleared = "[ ]"
+)
+
+// LayoutChangedMsg reports that the user changed the layout of a column
+// list, by sorting or with the column picker, so the owner can persist it.
+type LayoutChangedMsg struct {
+	Layout Layout
+}
+
+// columnPicker is the state of the column picker overlay.
+type columnPicker struct {
+	// cursor is the display position of the highlighted column.
+	cursor int
+	// changed records whether a column was toggled or moved.
+	changed bool
+}
+
+// NewColumnListModel creates a virtual list whose rows are laid out by
+// columns. The list sorts its own copy of items by the sort column, cycles
+// the sort column with "s", and opens a column picker with "c" to show,
+// hide, and reorder columns. renderFunc renders a row, typically around
+// columns.Row; Header renders the matching header line. Layout changes are
+// reported with LayoutChangedMsg.
+func NewColumnListModel[T any](
+	items []T,
+	height, width int,
+	columns *ColumnSet[T],
+	renderFunc RenderFunc[T],
+) *VirtualListModel[T] {
+	m := NewVirtualListModel(append([]T(nil), items...), height, width, renderFunc)
+	m.columns = columns
+	m.sortItems()
+	return m
+}
+
+// NewColumnListModelFromSource creates a column list whose items are read
+// from source as they are shown, like NewVirtualListModelFromSource. The list
+// cannot reorder a source, so it shows the items in source order; the owner
+// sorts them by the sort column before filling the source.
+func NewColumnListModelFromSource[T any](
+	source Source[T],
+	height, width int,
+	columns *ColumnSet[T],
+	renderFunc RenderFunc[T],
+) *VirtualListModel[T] {
+	m := NewVirtualListModelFromSource(source, height, width, renderFunc)
+	m.columns = columns
+	return m
+}
+
+// Columns returns the column set of a column list, or nil.
+func (m *VirtualListModel[T]) Columns() *ColumnSet[T] {
+	return m.columns
+}
+
+// Items returns the items in display order, or nil for lists created from a
+// Source.
+func (m *VirtualListModel[T]) Items() []T {
+	return m.items
+}
+
+// PickerOpen reports whether the column picker is shown. While it is, the
+// owner should forward all keys to the list.
+func (m *VirtualListModel[T]) PickerOpen() bool {
+	return m.picker != nil
+}
+
+// Header renders the column header of a column list, or "" for other lists.
+func (m *VirtualListModel[T]) Header() string {
+	if m.columns == nil {
+		return ""
+	}
+	return m.columns.Header()
+}
+
+// handleColumnKey handles the sort and column picker keys of column lists.
+// It reports whether the key was handled.
+func (m *VirtualListModel[T]) handleColumnKey(msg tea.KeyMsg) (tea.Cmd, bool) {
+	if m.columns == nil {
+		return nil, false
+	}
+	if m.picker != nil {
+		return m.handlePickerKey(msg), true
+	}
+	switch msg.String() {
+	case keySort:
+		if !m.columns.CycleSort() {
+			return nil, true
+		}
+		m.sortItems()
+		return m.layoutChanged(), true
+	case keyPicker:
+		m.picker = &columnPicker{}
+		return nil, true
+	}
+	return nil, false
+}
+
+// handlePickerKey handles a key while the column picker is shown.
+func (m *VirtualListModel[T]) handlePickerKey(msg tea.KeyMsg) tea.Cmd {
+	p := m.picker
+	last := len(m.columns.All()) - 1
+	switch msg.String() {
+	case keyUp, keyK:
+		p.cursor = max(p.cursor-1, 0)
+	case keyDown, keyJ:
+		p.cursor = min(p.cursor+1, last)
+	case keySpace, keyX:
+		if m.columns.Toggle(p.cursor) {
+			p.changed = true
+		}
+	case keyMoveUp, keyShiftUp:
+		if moved := m.columns.Move(p.cursor, -1); moved != p.cursor {
+			p.cursor, p.changed = moved, true
+		}
+	case keyMoveDown, keyShiftDown:
+		if moved := m.columns.Move(p.cursor, 1); moved != p.cursor {
+			p.cursor, p.changed = moved, true
+		}
+	case keyEnter, keyEsc, keyPicker:
+		m.picker = nil
+		if p.changed {
+			return m.layoutChanged()
+		}
+	}
+	return nil
+}
+
+// layoutChanged returns a command reporting the current layout.
+func (m *VirtualListModel[T]) layoutChanged() tea.Cmd {
+	layout := m.columns.Layout()
+	return func() tea.Msg { return LayoutChangedMsg{Layout: layout} }
+}
+
+// sortItems sorts the items by the sort column, keeping the selected item selected.
+func (m *VirtualListModel[T]) sortItems() {
+	if len(m.items) == 0 {
+		return
+	}
+	order := make([]int, len(m.items))
+	for i := range order {
+		order[i] = i
+	}
+	if less := m.columns.less(); less != nil {
+		sort.SliceStable(order, func(i, j int) bool { return less(m.items[order[i]], m.items[order[j]]) })
+	}
+
+	selected := m.selected
+	sorted := make([]T, len(m.items))
+	for i, idx := range order {
+		sorted[i] = m.items[idx]
+		if idx == selected {
+			m.selected = i
+		}
+	}
+	m.items = sorted
+	m.updateVisibleRange()
+}
+
+// viewPicker renders the column picker overlay.
+func (m *VirtualListModel[T]) viewPicker() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Columns  (%s)", pickerHelp)
+	for i, c := range m.columns.All() {
+		cursor := "  "
+		if i == m.picker.cursor {
+			cursor = "> "
+		}
+		check := pickerChecked
+		if m.columns.IsHidden(c.Key) {
+			check = pickerCleared
+		}
+		title := c.Title
+		if c.Key == m.columns.SortBy() {
+			title += " " + sortIndicator
+		}
+		fmt.Fprintf(&b, "\n%s%s %s", cursor, check, title)
+	}
+	return b.String()
+}
@@ -0,0 +1,370 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/proto"
+)
+
+// Keys used by the recommendations multi-select and bulk action menu.
+const (
+	keySpace = " "
+	keyA     = "a"
+	keyB     = "b"
+	keyUp    = "up"
+	keyDown  = "down"
+	keyK     = "k"
+	keyJ     = "j"
+)
+
+const (
+	// reasonOther is excluded from bulk dismissal because it requires a note.
+	reasonOther = "other"
+
+	// hoursPerDay converts snooze durations in days to time.Duration.
+	hoursPerDay = 24
+)
+
+// RecommendationActions performs lifecycle actions on recommendations selected
+// in the interactive TUI. Implementations report how many recommendations were
+// processed; a non-nil error may accompany a partial count.
+type RecommendationActions interface {
+	// Dismiss permanently dismisses the recommendations with the given reason
+	// (a CLI reason value such as "not-applicable").
+	Dismiss(ctx context.Context, recs []engine.Recommendation, reason string) (int, error)
+
+	// Snooze dismisses the recommendations until the given time.
+	Snooze(ctx context.Context, recs []engine.Recommendation, until time.Time) (int, error)
+
+	// Export writes the recommendations to a file and returns its path.
+	Export(ctx context.Context, recs []engine.Recommendation) (string, error)
+}
+
+// bulkAction identifies an action in the bulk action menu.
+type bulkAction string
+
+const (
+	bulkActionDismiss bulkAction = "Dismiss"
+	bulkActionSnooze  bulkAction = "Snooze"
+	bulkActionExport  bulkAction = "Export selection"
+)
+
+// bulkStage is the step of the bulk action menu currently shown.
+type bulkStage int
+
+const (
+	bulkStageAction bulkStage = iota
+	bulkStageReason
+	bulkStageDuration
+)
+
+// snoozeDurationDays returns the snooze durations, in days, offered by the picker.
+func snoozeDurationDays() []int {
+	return []int{7, 30, 90, 180, 365}
+}
+
+// bulkDismissReasons returns the reasons offered for bulk dismissal. "other"
+// is excluded because it requires a free-text note.
+func bulkDismissReasons() []string {
+	var reasons []string
+	for _, reason := range proto.ValidDismissalReasons() {
+		if reason != reasonOther {
+			reasons = append(reasons, reason)
+		}
+	}
+	return reasons
+}
+
+// bulkMenu holds the state of the bulk action menu.
+type bulkMenu struct {
+	stage  bulkStage
+	cursor int
+}
+
+// options returns the labels for the current menu stage.
+func (b *bulkMenu) options() []string {
+	switch b.stage {
+	case bulkStageReason:
+		return bulkDismissReasons()
+	case bulkStageDuration:
+		durations := snoozeDurationDays()
+		labels := make([]string, len(durations))
+		for i, days := range durations {
+			labels[i] = fmt.Sprintf("%d days", days)
+		}
+		return labels
+	case bulkStageAction:
+	}
+	return []string{string(bulkActionDismiss), string(bulkActionSnooze), string(bulkActionExport)}
+}
+
+// bulkActionResultMsg reports the outcome of a bulk action.
+type bulkActionResultMsg struct {
+	action bulkAction
+	count  int
+	path   string
+	err    error
+	// removed lists the keys of recommendations that should leave the list.
+	removed []string
+}
+
+// WithActions enables multi-select and the bulk action menu, using ctx for
+// the actions it runs.
+func (m *RecommendationsViewModel) WithActions(
+	ctx context.Context,
+	actions RecommendationActions,
+) *RecommendationsViewModel {
+	m.actionsCtx = ctx
+	m.actions = actions
+	return m
+}
+
+// recommendationKey identifies a recommendation for selection tracking.
+func recommendationKey(rec engine.Recommendation) string {
+	if rec.ID != "" {
+		return rec.ID
+	}
+	return rec.ResourceID + "|" + rec.Type + "|" + rec.Description
+}
+
+// isMarked reports whether a recommendation is part of the multi-selection.
+func (m *RecommendationsViewModel) isMarked(rec engine.Recommendation) bool {
+	return m.marked[recommendationKey(rec)]
+}
+
+// toggleMark toggles the multi-selection state of the item under the cursor.
+// It is a no-op when bulk actions are disabled.
+func (m *RecommendationsViewModel) toggleMark() {
+	if m.actions == nil || m.virtualList == nil {
+		return
+	}
+	idx := m.virtualList.Selected()
+	if idx < 0 || idx >= len(m.rows) {
+		return
+	}
+	key := m.rows[idx].key
+	if m.marked[key] {
+		delete(m.marked, key)
+	} else {
+		m.marked[key] = true
+	}
+}
+
+// toggleMarkAll marks every visible recommendation, or clears the selection
+// when all are already marked. It is a no-op when bulk actions are disabled.
+func (m *RecommendationsViewModel) toggleMarkAll() {
+	if m.actions == nil {
+		return
+	}
+	allMarked := len(m.rows) > 0
+	for _, row := range m.rows {
+		if !m.marked[row.key] {
+			allMarked = false
+			break
+		}
+	}
+	if allMarked {
+		m.marked = make(map[string]bool)
+		return
+	}
+	for _, row := range m.rows {
+		m.marked[row.key] = true
+	}
+}
+
+// markedRecommendations returns the marked recommendations in the order
+// they were loaded.
+func (m *RecommendationsViewModel) markedRecommendations() ([]engine.Recommendation, error) {
+	var out []engine.Recommendation
+	err := m.eachRecommendation(func(_ int, rec engine.Recommendation) {
+		if m.isMarked(rec) {
+			out = append(out, rec)
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("reading recommendations: %w", err)
+	}
+	return out, nil
+}
+
+// openBulkMenu opens the bulk action menu when actions are enabled and at
+// least one recommendation is marked.
+func (m *RecommendationsViewModel) openBulkMenu() {
+	switch {
+	case m.actions == nil:
+		m.statusMsg = "Bulk actions are not available"
+	case len(m.marked) == 0:
+		m.statusMsg = "Select recommendations with [space] first"
+	default:
+		m.statusMsg = ""
+		m.bulk = &bulkMenu{stage: bulkStageAction}
+	}
+}
+
+// handleBulkMenuUpdate handles key presses while the bulk action menu is open.
+func (m *RecommendationsViewModel) handleBulkMenuUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	keyMsg, ok := msg.(tea.KeyMsg)
+	if !ok {
+		return m, nil
+	}
+
+	options := m.bulk.options()
+	switch keyMsg.String() {
+	case keyCtrlC:
+		m.state = ViewStateQuitting
+		return m, tea.Quit
+	case keyEsc:
+		m.bulk = nil
+	case keyUp, keyK:
+		if m.bulk.cursor > 0 {
+			m.bulk.cursor--
+		}
+	case keyDown, keyJ:
+		if m.bulk.cursor < len(options)-1 {
+			m.bulk.cursor++
+		}
+	case keyEnter:
+		return m, m.selectBulkOption()
+	}
+	return m, nil
+}
+
+// selectBulkOption advances the menu or starts the chosen action.
+func (m *RecommendationsViewModel) selectBulkOption() tea.Cmd {
+	choice := m.bulk.options()[m.bulk.cursor]
+	recs, err := m.markedRecommendations()
+	if err != nil {
+		m.bulk = nil
+		m.statusMsg = err.Error()
+		return nil
+	}
+
+	switch m.bulk.stage {
+	case bulkStageAction:
+		switch bulkAction(choice) {
+		case bulkActionDismiss:
+			m.bulk = &bulkMenu{stage: bulkStageReason}
+			return nil
+		case bulkActionSnooze:
+			m.bulk = &bulkMenu{stage: bulkStageDuration}
+			return nil
+		case bulkActionExport:
+			m.bulk = nil
+			return m.runBulkExport(recs)
+		}
+	case bulkStageReason:
+		m.bulk = nil
+		return m.runBulkDismiss(recs, choice)
+	case bulkStageDuration:
+		days := snoozeDurationDays()[m.bulk.cursor]
+		m.bulk = nil
+		until := time.Now().Add(time.Duration(days) * hoursPerDay * time.Hour)
+		return m.runBulkSnooze(recs, until)
+	}
+	return nil
+}
+
+// runBulkDismiss returns a command that dismisses recs.
+func (m *RecommendationsViewModel) runBulkDismiss(recs []engine.Recommendation, reason string) tea.Cmd {
+	ctx, actions := m.actionsCtx, m.actions
+	m.statusMsg = fmt.Sprintf("Dismissing %d recommendation(s)...", len(recs))
+	return func() tea.Msg {
+		count, err := actions.Dismiss(ctx, recs, reason)
+		return bulkActionResultMsg{action: bulkActionDismiss, count: count, err: err, removed: keysOf(recs)}
+	}
+}
+
+// runBulkSnooze returns a command that snoozes recs until the given time.
+func (m *RecommendationsViewModel) runBulkSnooze(recs []engine.Recommendation, until time.Time) tea.Cmd {
+	ctx, actions := m.actionsCtx, m.actions
+	m.statusMsg = fmt.Sprintf("Snoozing %d recommendation(s)...", len(recs))
+	return func() tea.Msg {
+		count, err := actions.Snooze(ctx, recs, until)
+		return bulkActionResultMsg{action: bulkActionSnooze, count: count, err: err, removed: keysOf(recs)}
+	}
+}
+
+// runBulkExport returns a command that exports recs.
+func (m *RecommendationsViewModel) runBulkExport(recs []engine.Recommendation) tea.Cmd {
+	ctx, actions := m.actionsCtx, m.actions
+	return func() tea.Msg {
+		path, err := actions.Export(ctx, recs)
+		return bulkActionResultMsg{action: bulkActionExport, count: len(recs), path: path, err: err}
+	}
+}
+
+// handleBulkActionResult applies a finished bulk action to the model.
+// Dismissed and snoozed recommendations leave the list once the action
+// succeeds for all of them; on partial failure the list is left intact.
+func (m *RecommendationsViewModel) handleBulkActionResult(msg bulkActionResultMsg) (tea.Model, tea.Cmd) {
+	switch {
+	case msg.err != nil:
+		m.statusMsg = fmt.Sprintf("%s: %d succeeded, error: %v", msg.action, msg.count, msg.err)
+		return m, nil
+	case msg.action == bulkActionExport:
+		m.statusMsg = fmt.Sprintf("Exported %d recommendation(s) to %s", msg.count, msg.path)
+		return m, nil
+	case msg.action == bulkActionDismiss:
+		m.statusMsg = fmt.Sprintf("Dismissed %d recommendation(s)", msg.count)
+	default:
+		m.statusMsg = fmt.Sprintf("Snoozed %d recommendation(s)", msg.count)
+	}
+
+	removed := make(map[string]bool, len(msg.removed))
+	for _, key := range msg.removed {
+		removed[key] = true
+		delete(m.marked, key)
+	}
+	err := m.eachRecommendation(func(index int, rec engine.Recommendation) {
+		if removed[recommendationKey(rec)] {
+			m.removed[index] = true
+		}
+	})
+	m.applyFilter()
+	if err != nil {
+		m.statusMsg += ", but the list could not be updated: " + err.Error()
+	}
+	return m, nil
+}
+
+// renderBulkMenu renders the bulk action menu.
+func (m *RecommendationsViewModel) renderBulkMenu() string {
+	titles := map[bulkStage]string{
+		bulkStageAction:   fmt.Sprintf("Bulk action (%d selected)", len(m.marked)),
+		bulkStageReason:   "Dismiss reason",
+		bulkStageDuration: "Snooze for",
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString(lipgloss.NewStyle().Bold(true).Render(titles[m.bulk.stage]) + "\n")
+	for i, option := range m.bulk.options() {
+		cursor := "  "
+		if i == m.bulk.cursor {
+			cursor = "> "
+		}
+		_, _ = sb.WriteString(cursor + option + "\n")
+	}
+	_, _ = sb.WriteString("[↑↓] Choose  [Enter] Select  [Esc] Cancel")
+
+	return lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(ColorAccent).
+		Padding(0, 1).
+		Render(sb.String())
+}
+
+// keysOf returns the selection keys of recs.
+func keysOf(recs []engine.Recommendation) []string {
+	keys := make([]string, len(recs))
+	for i, rec := range recs {
+		keys[i] = recommendationKey(rec)
+	}
+	return keys
+}
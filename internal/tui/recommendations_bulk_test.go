@@ -0,0 +1,179 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// fakeRecommendationActions records bulk action calls.
+type fakeRecommendationActions struct {
+	dismissed []string
+	reason    string
+	snoozed   []string
+	until     time.Time
+	exported  int
+	err       error
+}
+
+func (f *fakeRecommendationActions) Dismiss(
+	_ context.Context, recs []engine.Recommendation, reason string,
+) (int, error) {
+	f.reason = reason
+	for _, r := range recs {
+		f.dismissed = append(f.dismissed, r.ID)
+	}
+	return len(recs), f.err
+}
+
+func (f *fakeRecommendationActions) Snooze(
+	_ context.Context, recs []engine.Recommendation, until time.Time,
+) (int, error) {
+	f.until = until
+	for _, r := range recs {
+		f.snoozed = append(f.snoozed, r.ID)
+	}
+	return len(recs), f.err
+}
+
+func (f *fakeRecommendationActions) Export(_ context.Context, recs []engine.Recommendation) (string, error) {
+	f.exported = len(recs)
+	return "selection.csv", f.err
+}
+
+func bulkTestModel(actions RecommendationActions) *RecommendationsViewModel {
+	recs := []engine.Recommendation{
+		{ID: "rec-1", ResourceID: "r1", Type: "RIGHTSIZE", EstimatedSavings: 100, Currency: "USD"},
+		{ID: "rec-2", ResourceID: "r2", Type: "TERMINATE", EstimatedSavings: 50, Currency: "USD"},
+		{ID: "rec-3", ResourceID: "r3", Type: "MIGRATE", EstimatedSavings: 10, Currency: "USD"},
+	}
+	model := NewRecommendationsViewModel(recs)
+	model.WithActions(context.Background(), actions)
+	model.rebuildList()
+	return model
+}
+
+func pressKey(t *testing.T, m *RecommendationsViewModel, msg tea.KeyMsg) (*RecommendationsViewModel, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	model, ok := updated.(*RecommendationsViewModel)
+	require.True(t, ok)
+	return model, cmd
+}
+
+func runes(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+// runCmd executes a command and feeds its message back into the model.
+func runCmd(t *testing.T, m *RecommendationsViewModel, cmd tea.Cmd) *RecommendationsViewModel {
+	t.Helper()
+	require.NotNil(t, cmd)
+	updated, _ := m.Update(cmd())
+	model, ok := updated.(*RecommendationsViewModel)
+	require.True(t, ok)
+	return model
+}
+
+func TestRecommendationsViewModel_MultiSelect(t *testing.T) {
+	m := bulkTestModel(&fakeRecommendationActions{})
+
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeySpace})
+	assert.Len(t, m.marked, 1)
+	assert.Contains(t, m.View(), "[x] ")
+
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeySpace})
+	assert.Empty(t, m.marked, "space toggles")
+
+	m, _ = pressKey(t, m, runes("a"))
+	assert.Len(t, m.marked, 3)
+	m, _ = pressKey(t, m, runes("a"))
+	assert.Empty(t, m.marked, "a clears when all marked")
+}
+
+func TestRecommendationsViewModel_BulkMenuRequiresSelection(t *testing.T) {
+	m := bulkTestModel(&fakeRecommendationActions{})
+	m, _ = pressKey(t, m, runes("b"))
+	assert.Nil(t, m.bulk)
+	assert.Contains(t, m.View(), "Select recommendations with [space] first")
+
+	noActions := NewRecommendationsViewModel([]engine.Recommendation{{ID: "x"}})
+	noActions, _ = pressKey(t, noActions, tea.KeyMsg{Type: tea.KeySpace})
+	assert.Empty(t, noActions.marked, "multi-select disabled without actions")
+}
+
+func TestRecommendationsViewModel_BulkDismiss(t *testing.T) {
+	actions := &fakeRecommendationActions{}
+	m := bulkTestModel(actions)
+
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeySpace})
+	m, _ = pressKey(t, m, runes("b"))
+	require.NotNil(t, m.bulk)
+	assert.Contains(t, m.View(), "Bulk action (1 selected)")
+
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // Dismiss
+	require.NotNil(t, m.bulk)
+	assert.Equal(t, bulkStageReason, m.bulk.stage)
+
+	m, cmd := pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // first reason
+	assert.Nil(t, m.bulk)
+	m = runCmd(t, m, cmd)
+
+	assert.Equal(t, []string{"rec-1"}, actions.dismissed)
+	assert.Equal(t, bulkDismissReasons()[0], actions.reason)
+	assert.Len(t, shownRecommendations(t, m), 2, "dismissed recommendation leaves the list")
+	assert.Equal(t, 2, m.summary.TotalCount)
+	assert.Contains(t, m.View(), "Dismissed 1 recommendation(s)")
+}
+
+func TestRecommendationsViewModel_BulkSnoozeDurationPicker(t *testing.T) {
+	actions := &fakeRecommendationActions{}
+	m := bulkTestModel(actions)
+
+	m, _ = pressKey(t, m, runes("a"))
+	m, _ = pressKey(t, m, runes("b"))
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})  // Snooze
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter}) // open duration picker
+	require.Equal(t, bulkStageDuration, m.bulk.stage)
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown}) // 30 days
+	m, cmd := pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+
+	assert.Len(t, actions.snoozed, 3)
+	assert.WithinDuration(t, time.Now().Add(30*24*time.Hour), actions.until, time.Minute)
+	assert.Empty(t, shownRecommendations(t, m))
+}
+
+func TestRecommendationsViewModel_BulkExportAndErrors(t *testing.T) {
+	actions := &fakeRecommendationActions{}
+	m := bulkTestModel(actions)
+
+	m, _ = pressKey(t, m, runes("a"))
+	m, _ = pressKey(t, m, runes("b"))
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m, cmd := pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+	assert.Equal(t, 3, actions.exported)
+	assert.Len(t, shownRecommendations(t, m), 3, "export keeps the list")
+	assert.Contains(t, m.View(), "Exported 3 recommendation(s) to selection.csv")
+
+	actions.err = errors.New("plugin unavailable")
+	m, _ = pressKey(t, m, runes("b"))
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m, cmd = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+	assert.Len(t, shownRecommendations(t, m), 3, "failed dismissal keeps the list")
+	assert.Contains(t, m.statusMsg, "plugin unavailable")
+
+	m, _ = pressKey(t, m, runes("b"))
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Nil(t, m.bulk, "esc closes the menu")
+}
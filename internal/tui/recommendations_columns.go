@@ -0,0 +1,114 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rshade/finfocus/internal/engine"
+	listview "github.com/rshade/finfocus/internal/tui/list"
+)
+
+// RecommendationsLayoutView names the recommendations list in saved layouts.
+const RecommendationsLayoutView = "recommendations"
+
+// Column keys of the recommendations list.
+const (
+	recColResource    = "resource"
+	recColAction      = "action"
+	recColSavings     = "savings"
+	recColDescription = "description"
+)
+
+// LayoutSaver persists the column layout of a list view.
+type LayoutSaver func(layout listview.Layout) error
+
+// layoutSavedMsg reports the outcome of saving a column layout.
+type layoutSavedMsg struct {
+	err error
+}
+
+// recommendationColumns returns the columns of the recommendations list in
+// their default order.
+func recommendationColumns() []listview.Column[engine.Recommendation] {
+	return []listview.Column[engine.Recommendation]{
+		{
+			Key: recColResource, Title: "Resource", Width: recColWidthResource,
+			Value: func(r engine.Recommendation) string { return r.ResourceID },
+			Less:  func(a, b engine.Recommendation) bool { return a.ResourceID < b.ResourceID },
+		},
+		{
+			Key: recColAction, Title: "Action", Width: recColWidthAction,
+			Value: func(r engine.Recommendation) string { return r.Type },
+			Less:  func(a, b engine.Recommendation) bool { return a.Type < b.Type },
+		},
+		{
+			Key: recColSavings, Title: "Savings", Width: recColWidthSavings, AlignRight: true,
+			Value: formatRecommendationSavings,
+			Less:  func(a, b engine.Recommendation) bool { return a.EstimatedSavings > b.EstimatedSavings },
+		},
+		{
+			Key: recColDescription, Title: "Description", Width: recColWidthDescription,
+			Value: func(r engine.Recommendation) string { return r.Description },
+		},
+	}
+}
+
+// newRecommendationColumns applies a saved layout to the recommendations
+// columns, sorting by savings unless the layout names a sort column.
+func newRecommendationColumns(layout listview.Layout) *listview.ColumnSet[engine.Recommendation] {
+	columns := listview.NewColumnSet(recommendationColumns(), layout)
+	if columns.SortBy() == "" {
+		columns.SetSortBy(recColSavings)
+	}
+	return columns
+}
+
+// formatRecommendationSavings formats the savings with the currency symbol.
+func formatRecommendationSavings(rec engine.Recommendation) string {
+	currency := rec.Currency
+	if currency == "" {
+		currency = defaultCurrency
+	}
+	return fmt.Sprintf("%s%.2f", getCurrencySymbol(currency), rec.EstimatedSavings)
+}
+
+// recommendationSortField returns the sort field of a sortable column key.
+func recommendationSortField(key string) RecommendationSortField {
+	switch key {
+	case recColResource:
+		return SortByResourceID
+	case recColAction:
+		return SortByActionType
+	default:
+		return SortBySavings
+	}
+}
+
+// WithLayout applies a saved column layout to the list and enables saving
+// the layout with save whenever the user sorts or picks columns.
+func (m *RecommendationsViewModel) WithLayout(layout listview.Layout, save LayoutSaver) *RecommendationsViewModel {
+	m.columns = newRecommendationColumns(layout)
+	m.saveLayout = save
+	m.sortBy = recommendationSortField(m.columns.SortBy())
+	m.applySort()
+	m.rebuildList()
+	return m
+}
+
+// saveLayoutCmd saves layout in the background, if saving is enabled.
+func (m *RecommendationsViewModel) saveLayoutCmd(layout listview.Layout) tea.Cmd {
+	if m.saveLayout == nil {
+		return nil
+	}
+	save := m.saveLayout
+	return func() tea.Msg {
+		return layoutSavedMsg{err: save(layout)}
+	}
+}
+
+// pickerOpen reports whether the column picker is shown, in which case the
+// list handles all keys.
+func (m *RecommendationsViewModel) pickerOpen() bool {
+	return m.virtualList != nil && m.virtualList.PickerOpen()
+}
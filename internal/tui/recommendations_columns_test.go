@@ -0,0 +1,109 @@
+package tui
+
+import (
+	"errors"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+	listview "github.com/rshade/finfocus/internal/tui/list"
+)
+
+func layoutTestRecommendations() []engine.Recommendation {
+	return []engine.Recommendation{
+		{ResourceID: "r1", Type: "TERMINATE", Description: "Unused", EstimatedSavings: 10, Currency: "USD"},
+		{ResourceID: "r2", Type: "RIGHTSIZE", Description: "Oversized", EstimatedSavings: 50, Currency: "USD"},
+	}
+}
+
+func TestRecommendationsViewModel_WithLayout(t *testing.T) {
+	model := NewRecommendationsViewModel(layoutTestRecommendations()).WithLayout(
+		listview.Layout{Columns: []string{recColAction, recColResource}, SortBy: recColAction}, nil)
+
+	assert.Equal(t, SortByActionType, model.sortBy)
+	assert.Equal(t, "RIGHTSIZE", shownRecommendations(t, model)[0].Type)
+
+	view := model.View()
+	assert.Contains(t, view, "Action ▼")
+	assert.NotContains(t, view, "Description")
+	assert.Contains(t, view, "RIGHTSIZE        r2  ", "hidden columns are not rendered")
+}
+
+func TestRecommendationsViewModel_SortSavesLayout(t *testing.T) {
+	var saved []listview.Layout
+	model := NewRecommendationsViewModel(layoutTestRecommendations()).WithLayout(listview.Layout{},
+		func(layout listview.Layout) error {
+			saved = append(saved, layout)
+			return nil
+		})
+
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'s'}})
+	require.NotNil(t, cmd)
+	_, _ = model.Update(cmd())
+
+	require.Len(t, saved, 1)
+	assert.Equal(t, recColResource, saved[0].SortBy)
+	assert.Equal(t, SortByResourceID, model.sortBy)
+}
+
+func TestRecommendationsViewModel_ColumnPicker(t *testing.T) {
+	var saved []listview.Layout
+	model := NewRecommendationsViewModel(layoutTestRecommendations()).WithLayout(listview.Layout{},
+		func(layout listview.Layout) error {
+			saved = append(saved, layout)
+			return errors.New("read-only config")
+		})
+
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'c'}})
+	require.True(t, model.pickerOpen())
+
+	// Keys go to the picker: q does not quit and space hides the column.
+	_, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'q'}})
+	assert.Nil(t, cmd)
+	assert.Equal(t, ViewStateList, model.state)
+	_, _ = model.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+
+	_, cmd = model.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.False(t, model.pickerOpen())
+	assert.Equal(t, ViewStateList, model.state, "enter closes the picker without opening details")
+
+	_, cmd = model.Update(cmd())
+	require.NotNil(t, cmd)
+	_, _ = model.Update(cmd())
+
+	require.Len(t, saved, 1)
+	assert.Equal(t, []string{recColAction, recColSavings, recColDescription}, saved[0].Columns)
+	assert.Contains(t, model.View(), "Could not save column layout: read-only config")
+}
+
+func TestRecommendationsViewModel_FuzzyFilterByTag(t *testing.T) {
+	model := NewRecommendationsViewModel(layoutTestRecommendations()).WithResourceTags(map[string]map[string]string{
+		"r1": {"team": "payments"},
+		"r2": {"team": "search"},
+	})
+
+	model.textInput.SetValue("pymnts")
+	model.applyFilter()
+
+	require.Len(t, shownRecommendations(t, model), 1)
+	assert.Equal(t, "r1", shownRecommendations(t, model)[0].ResourceID)
+	assert.Contains(t, model.matches[recommendationKey(shownRecommendations(t, model)[0])], "tag:team")
+}
+
+func TestRecommendationsViewModel_FuzzyFilterHighlightsColumns(t *testing.T) {
+	model := NewRecommendationsViewModel(layoutTestRecommendations())
+
+	model.textInput.SetValue("rsz")
+	model.applyFilter()
+
+	require.Len(t, shownRecommendations(t, model), 1)
+	rec := shownRecommendations(t, model)[0]
+	positions := model.matches[recommendationKey(rec)]
+	assert.Equal(t, map[string][]int{recColAction: {0, 5, 7}}, positions)
+	assert.Equal(t, []int{recColWidthResource + 2, recColWidthResource + 7, recColWidthResource + 9},
+		model.columns.RowPositions(rec, positions))
+}
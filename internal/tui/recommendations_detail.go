@@ -0,0 +1,213 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/tui/detail"
+)
+
+const (
+	// keyR retries a failed resource load in the detail pane.
+	keyR = "r"
+
+	// splitPaneMinWidth is the terminal width below which the detail view
+	// replaces the list instead of sitting beside it.
+	splitPaneMinWidth = 120
+
+	// splitPaneListPercent is the share of the width given to the list pane.
+	splitPaneListPercent = 40
+
+	// detailMaxPropertyLen truncates long resource property values.
+	detailMaxPropertyLen = 60
+
+	// percentDivisor converts a percentage to a ratio.
+	percentDivisor = 100
+
+	// detailHelpText is the key help line shown under the detail pane.
+	detailHelpText = "[Esc] Back to list  [↑/↓] Previous/next  [q] Quit"
+)
+
+// ResourceFetcher loads the resource descriptor a recommendation refers to.
+type ResourceFetcher = detail.FetchFunc[*engine.ResourceDescriptor]
+
+// WithResourceLookup enables the raw resource section of the detail pane,
+// loading descriptors lazily with fetch when a recommendation is opened.
+func (m *RecommendationsViewModel) WithResourceLookup(
+	ctx context.Context,
+	fetch ResourceFetcher,
+) *RecommendationsViewModel {
+	m.resourceLoader = detail.NewLoader(ctx, fetch)
+	return m
+}
+
+// selectedRecommendation returns the recommendation under the cursor.
+func (m *RecommendationsViewModel) selectedRecommendation() (engine.Recommendation, bool) {
+	if m.virtualList == nil {
+		return engine.Recommendation{}, false
+	}
+	selected := m.virtualList.Selected()
+	if selected < 0 || selected >= len(m.rows) {
+		return engine.Recommendation{}, false
+	}
+	rec, err := m.store.At(m.rows[selected].index)
+	if err != nil {
+		return engine.Recommendation{}, false
+	}
+	return rec, true
+}
+
+// loadSelectedResource starts loading the resource for the selected recommendation.
+func (m *RecommendationsViewModel) loadSelectedResource() tea.Cmd {
+	if m.resourceLoader == nil {
+		return nil
+	}
+	rec, ok := m.selectedRecommendation()
+	if !ok || rec.ResourceID == "" {
+		return nil
+	}
+	return m.resourceLoader.Load(rec.ResourceID)
+}
+
+// renderDetailView renders the detail pane, beside the list on wide terminals.
+func (m *RecommendationsViewModel) renderDetailView() string {
+	rec, ok := m.selectedRecommendation()
+	if !ok {
+		return msgSelectedOutOfBounds
+	}
+
+	if m.width < splitPaneMinWidth || m.virtualList == nil {
+		return renderRecommendationDetailBody(rec, m.width) + m.renderResourceSection(rec) +
+			"\n" + detailHelpText
+	}
+
+	listWidth := m.width * splitPaneListPercent / percentDivisor
+	detailWidth := m.width - listWidth - 1
+
+	listPane := lipgloss.NewStyle().
+		Width(listWidth).
+		MaxWidth(listWidth).
+		Render(m.virtualList.View())
+	detailPane := lipgloss.NewStyle().
+		Width(detailWidth).
+		BorderStyle(lipgloss.NormalBorder()).
+		BorderLeft(true).
+		BorderForeground(ColorMuted).
+		PaddingLeft(1).
+		Render(renderRecommendationDetailBody(rec, detailWidth) + m.renderResourceSection(rec))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top, listPane, detailPane) + "\n\n" + detailHelpText
+}
+
+// renderResourceSection renders the lazily loaded resource descriptor.
+func (m *RecommendationsViewModel) renderResourceSection(rec engine.Recommendation) string {
+	if m.resourceLoader == nil || rec.ResourceID == "" {
+		return ""
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString("\nRESOURCE\n--------\n")
+
+	switch m.resourceLoader.State() {
+	case detail.StateLoading, detail.StateIdle:
+		_, _ = sb.WriteString("Loading resource details...\n")
+	case detail.StateError:
+		_, _ = sb.WriteString(fmt.Sprintf("Failed to load resource: %v\n[r] Retry\n", m.resourceLoader.Err()))
+	case detail.StateLoaded:
+		_, _ = sb.WriteString(renderResourceDescriptor(m.resourceLoader.Value()) + "\n")
+	}
+
+	return sb.String()
+}
+
+// renderResourceDescriptor renders a resource's identity and properties.
+func renderResourceDescriptor(res *engine.ResourceDescriptor) string {
+	if res == nil {
+		return "Resource not found in plan."
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString(fmt.Sprintf("Type:     %s\n", res.Type))
+	_, _ = sb.WriteString(fmt.Sprintf("ID:       %s\n", res.ID))
+	_, _ = sb.WriteString(fmt.Sprintf("Provider: %s", res.Provider))
+
+	if len(res.Properties) == 0 {
+		return sb.String()
+	}
+
+	keys := make([]string, 0, len(res.Properties))
+	for k := range res.Properties {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	_, _ = sb.WriteString("\nProperties:")
+	for _, k := range keys {
+		value := fmt.Sprintf("%v", res.Properties[k])
+		if len(value) > detailMaxPropertyLen {
+			value = value[:detailMaxPropertyLen-3] + "..."
+		}
+		_, _ = sb.WriteString(fmt.Sprintf("\n  %s: %s", k, value))
+	}
+	return sb.String()
+}
+
+// renderImpactSection renders the current vs projected cost breakdown.
+func renderImpactSection(rec engine.Recommendation, symbol string) string {
+	if rec.CurrentCost == 0 && rec.ProjectedCost == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString("\nIMPACT\n------\n")
+	_, _ = sb.WriteString(fmt.Sprintf("Current Cost:   %s%.2f\n", symbol, rec.CurrentCost))
+	_, _ = sb.WriteString(fmt.Sprintf("Projected Cost: %s%.2f\n", symbol, rec.ProjectedCost))
+
+	percent := rec.SavingsPercentage
+	if percent == 0 && rec.CurrentCost > 0 {
+		percent = (rec.CurrentCost - rec.ProjectedCost) / rec.CurrentCost * percentDivisor
+	}
+	_, _ = sb.WriteString(fmt.Sprintf("Savings:        %s%.2f (%.1f%%)\n",
+		symbol, rec.CurrentCost-rec.ProjectedCost, percent))
+	return sb.String()
+}
+
+// renderReasoningSection renders plugin reasoning strings as a bullet list.
+func renderReasoningSection(rec engine.Recommendation) string {
+	if len(rec.Reasoning) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	_, _ = sb.WriteString("\nREASONING\n---------\n")
+	for _, reason := range rec.Reasoning {
+		_, _ = sb.WriteString("  - " + reason + "\n")
+	}
+	return sb.String()
+}
+
+// renderMetadataSection renders plugin metadata sorted by key.
+func renderMetadataSection(rec engine.Recommendation) string {
+	if len(rec.Metadata) == 0 {
+		return ""
+	}
+
+	keys := make([]string, 0, len(rec.Metadata))
+	for k := range rec.Metadata {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	_, _ = sb.WriteString("\nMETADATA\n--------\n")
+	for _, k := range keys {
+		_, _ = sb.WriteString(fmt.Sprintf("  %s: %s\n", k, rec.Metadata[k]))
+	}
+	return sb.String()
+}
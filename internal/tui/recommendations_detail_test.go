@@ -0,0 +1,125 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+func detailTestModel(fetch ResourceFetcher) *RecommendationsViewModel {
+	recs := []engine.Recommendation{
+		{
+			ID: "rec-1", ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 40, Currency: "USD",
+			CurrentCost: 100, ProjectedCost: 60,
+			Reasoning: []string{"CPU below 10% for 14 days"},
+			Metadata:  map[string]string{"region": "us-east-1", "instance": "m5.large"},
+		},
+		{ID: "rec-2", ResourceID: "db", Type: "TERMINATE", EstimatedSavings: 20, Currency: "USD"},
+	}
+	model := NewRecommendationsViewModel(recs)
+	if fetch != nil {
+		model.WithResourceLookup(context.Background(), fetch)
+	}
+	return model
+}
+
+func TestRenderRecommendationDetail_Sections(t *testing.T) {
+	rec := engine.Recommendation{
+		ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 40, Currency: "USD",
+		CurrentCost: 100, ProjectedCost: 60,
+		Reasoning: []string{"CPU below 10% for 14 days"},
+		Metadata:  map[string]string{"region": "us-east-1", "instance": "m5.large"},
+	}
+
+	out := RenderRecommendationDetail(rec, 80)
+	assert.Contains(t, out, "Current Cost:   $100.00")
+	assert.Contains(t, out, "Projected Cost: $60.00")
+	assert.Contains(t, out, "$40.00 (40.0%)")
+	assert.Contains(t, out, "  - CPU below 10% for 14 days")
+	assert.Less(t, strings.Index(out, "instance: m5.large"), strings.Index(out, "region: us-east-1"),
+		"metadata sorted by key")
+
+	bare := RenderRecommendationDetail(engine.Recommendation{ResourceID: "x"}, 80)
+	assert.NotContains(t, bare, "IMPACT")
+	assert.NotContains(t, bare, "REASONING")
+	assert.NotContains(t, bare, "METADATA")
+}
+
+func TestRecommendationsViewModel_DetailLazyLoadsResource(t *testing.T) {
+	var fetched []string
+	m := detailTestModel(func(_ context.Context, id string) (*engine.ResourceDescriptor, error) {
+		fetched = append(fetched, id)
+		return &engine.ResourceDescriptor{
+			ID: id, Type: "aws:ec2/instance:Instance", Provider: "aws",
+			Properties: map[string]interface{}{"instanceType": "m5.large"},
+		}, nil
+	})
+
+	m, cmd := pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	assert.Equal(t, ViewStateDetail, m.state)
+	assert.Contains(t, m.View(), "Loading resource details...")
+
+	m = runCmd(t, m, cmd)
+	view := m.View()
+	assert.Contains(t, view, "aws:ec2/instance:Instance")
+	assert.Contains(t, view, "instanceType: m5.large")
+	assert.Equal(t, []string{"web"}, fetched)
+
+	m, cmd = pressKey(t, m, tea.KeyMsg{Type: tea.KeyDown})
+	m = runCmd(t, m, cmd)
+	assert.Contains(t, m.View(), "Resource:    db")
+	assert.Equal(t, []string{"web", "db"}, fetched)
+
+	m, cmd = pressKey(t, m, tea.KeyMsg{Type: tea.KeyUp})
+	assert.Nil(t, cmd, "cached resource needs no fetch")
+	assert.Contains(t, m.View(), "Resource:    web")
+}
+
+func TestRecommendationsViewModel_DetailRetry(t *testing.T) {
+	fail := true
+	m := detailTestModel(func(_ context.Context, id string) (*engine.ResourceDescriptor, error) {
+		if fail {
+			return nil, errors.New("state backend unreachable")
+		}
+		return &engine.ResourceDescriptor{ID: id, Type: "aws:ec2/instance:Instance"}, nil
+	})
+
+	m, cmd := pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	m = runCmd(t, m, cmd)
+	assert.Contains(t, m.View(), "Failed to load resource: state backend unreachable")
+	assert.Contains(t, m.View(), "[r] Retry")
+
+	fail = false
+	m, cmd = pressKey(t, m, runes("r"))
+	m = runCmd(t, m, cmd)
+	assert.Contains(t, m.View(), "aws:ec2/instance:Instance")
+	assert.NotContains(t, m.View(), "[r] Retry")
+}
+
+func TestRecommendationsViewModel_DetailSplitPane(t *testing.T) {
+	m := detailTestModel(nil)
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: 160, Height: 30})
+	m, ok := updated.(*RecommendationsViewModel)
+	require.True(t, ok)
+
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEnter})
+	view := m.View()
+	assert.Contains(t, view, "RECOMMENDATION DETAIL")
+	assert.Contains(t, view, "TERMINATE", "list pane stays visible beside the detail")
+	assert.NotContains(t, view, "RESOURCE\n", "no resource section without a lookup")
+
+	updated, _ = m.Update(tea.WindowSizeMsg{Width: 80, Height: 30})
+	m, ok = updated.(*RecommendationsViewModel)
+	require.True(t, ok)
+	assert.NotContains(t, m.View(), "TERMINATE", "narrow terminals show the detail only")
+
+	m, _ = pressKey(t, m, tea.KeyMsg{Type: tea.KeyEscape})
+	assert.Equal(t, ViewStateList, m.state)
+}
@@ -0,0 +1,71 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// defaultRecommendationsExportPath prefills the export prompt.
+const defaultRecommendationsExportPath = "recommendations.csv"
+
+// WithExporter enables exporting the filtered and sorted recommendations
+// with [e], using ctx for the exports it runs.
+func (m *RecommendationsViewModel) WithExporter(
+	ctx context.Context,
+	export ViewExporter[engine.Recommendation],
+) *RecommendationsViewModel {
+	m.exportCtx = ctx
+	m.exporter = export
+	return m
+}
+
+// openExport opens the export prompt when exporting is enabled.
+func (m *RecommendationsViewModel) openExport() tea.Cmd {
+	if m.exporter == nil {
+		m.statusMsg = "Export is not available"
+		return nil
+	}
+	m.statusMsg = ""
+	m.export = newExportPrompt(defaultRecommendationsExportPath)
+	return textinput.Blink
+}
+
+// visibleRecommendations returns the recommendations in display order.
+func (m *RecommendationsViewModel) visibleRecommendations() ([]engine.Recommendation, error) {
+	source := recommendationSource{store: m.store, rows: m.rows}
+	return source.Slice(0, source.Len())
+}
+
+// handleExportUpdate handles a message while the export prompt is open.
+func (m *RecommendationsViewModel) handleExportUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
+	path, closed, cmd := m.export.update(msg)
+	if !closed {
+		return m, cmd
+	}
+	m.export = nil
+	if path == "" {
+		return m, nil
+	}
+	recs, err := m.visibleRecommendations()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Exporting to %s...", path)
+	return m, exportRowsCmd(m.exportCtx, m.exporter, path, recs)
+}
+
+// handleViewExported reports a finished export in the status line.
+func (m *RecommendationsViewModel) handleViewExported(msg viewExportedMsg) (tea.Model, tea.Cmd) {
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Export failed: %v", msg.err)
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Exported %d recommendation(s) to %s", msg.count, msg.path)
+	return m, nil
+}
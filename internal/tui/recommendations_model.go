@@ -3,6 +3,7 @@ package tui
 import (
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 
@@ -11,6 +12,8 @@ import (
 	"github.com/charmbracelet/lipgloss"
 
 	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/engine/spill"
+	"github.com/rshade/finfocus/internal/tui/detail"
 	listview "github.com/rshade/finfocus/internal/tui/list"
 )
 
@@ -101,89 +104,68 @@ type RecommendationsSummary struct {
 // NewRecommendationsSummary creates a summary from a list of recommendations.
 // It calculates aggregate statistics and extracts the top 5 by savings.
 func NewRecommendationsSummary(recs []engine.Recommendation) *RecommendationsSummary {
-	summary := &RecommendationsSummary{
-		TotalCount:      len(recs),
-		CountByAction:   make(map[string]int),
-		SavingsByAction: make(map[string]float64),
-	}
-
-	if len(recs) == 0 {
-		return summary
-	}
-
-	// Calculate aggregates.
+	summary := newRecommendationsSummary()
 	for _, rec := range recs {
-		summary.TotalSavings += rec.EstimatedSavings
-		summary.CountByAction[rec.Type]++
-		summary.SavingsByAction[rec.Type] += rec.EstimatedSavings
-
-		// Set currency from first recommendation with a currency.
-		if summary.Currency == "" && rec.Currency != "" {
-			summary.Currency = rec.Currency
-		}
+		summary.add(rec)
 	}
-
-	// Sort by savings descending and take top 5.
-	sorted := make([]engine.Recommendation, len(recs))
-	copy(sorted, recs)
-	sort.Slice(sorted, func(i, j int) bool {
-		return sorted[i].EstimatedSavings > sorted[j].EstimatedSavings
-	})
-
-	if len(sorted) > topRecommendationsLimit {
-		summary.TopRecommendations = sorted[:topRecommendationsLimit]
-	} else {
-		summary.TopRecommendations = sorted
-	}
-
 	return summary
 }
 
-// renderRecommendation formats a single recommendation for list display.
-// The selected parameter indicates whether this item is currently selected.
-func renderRecommendation(rec engine.Recommendation, selected bool) string {
-	// Format resource ID (truncate if too long)
-	resourceID := rec.ResourceID
-	if len(resourceID) > recColWidthResource {
-		resourceID = resourceID[:recColWidthResource-3] + "..."
+// newRecommendationsSummary creates an empty summary for add to fill.
+func newRecommendationsSummary() *RecommendationsSummary {
+	return &RecommendationsSummary{
+		CountByAction:   make(map[string]int),
+		SavingsByAction: make(map[string]float64),
 	}
+}
 
-	// Format action type
-	actionType := rec.Type
-	if len(actionType) > recColWidthAction {
-		actionType = actionType[:recColWidthAction-3] + "..."
-	}
+// add counts rec in the summary, keeping the top 5 recommendations by
+// savings in the order they were added when their savings tie.
+func (s *RecommendationsSummary) add(rec engine.Recommendation) {
+	s.TotalCount++
+	s.TotalSavings += rec.EstimatedSavings
+	s.CountByAction[rec.Type]++
+	s.SavingsByAction[rec.Type] += rec.EstimatedSavings
 
-	// Format savings with correct currency symbol
-	currency := rec.Currency
-	if currency == "" {
-		currency = defaultCurrency
+	// Set currency from first recommendation with a currency.
+	if s.Currency == "" && rec.Currency != "" {
+		s.Currency = rec.Currency
 	}
-	savings := fmt.Sprintf("%s%.2f", getCurrencySymbol(currency), rec.EstimatedSavings)
 
-	// Format description (truncate if too long)
-	description := rec.Description
-	if len(description) > recDescTruncateLen {
-		description = description[:recDescTruncateLen] + "..."
+	i := sort.Search(len(s.TopRecommendations), func(i int) bool {
+		return s.TopRecommendations[i].EstimatedSavings < rec.EstimatedSavings
+	})
+	if i < topRecommendationsLimit {
+		s.TopRecommendations = slices.Insert(s.TopRecommendations, i, rec)
+		s.TopRecommendations = s.TopRecommendations[:min(len(s.TopRecommendations), topRecommendationsLimit)]
 	}
+}
 
-	// Build the row
-	row := fmt.Sprintf("%-*s  %-*s  %*s  %-*s",
-		recColWidthResource, resourceID,
-		recColWidthAction, actionType,
-		recColWidthSavings, savings,
-		recColWidthDescription, description,
-	)
+// renderRecommendation formats a single recommendation as a row of the
+// visible columns, highlighting the filter matches at positions (keyed by
+// column). The selected parameter indicates whether this item is currently
+// selected.
+func renderRecommendation(
+	columns *listview.ColumnSet[engine.Recommendation],
+	rec engine.Recommendation,
+	selected bool,
+	positions map[string][]int,
+) string {
+	row := columns.Row(rec)
 
 	// Apply selection styling
+	base := lipgloss.NewStyle()
 	if selected {
-		return lipgloss.NewStyle().
-			Foreground(lipgloss.Color("229")).
-			Background(lipgloss.Color("57")).
-			Render(row)
+		base = accentSelectedStyle()
+	}
+	if len(positions) == 0 {
+		if selected {
+			return base.Render(row)
+		}
+		return row
 	}
 
-	return row
+	return HighlightMatches(row, columns.RowPositions(rec, positions), base, FuzzyMatchStyle)
 }
 
 // Messages for RecommendationsViewModel.
@@ -192,17 +174,76 @@ type recommendationsLoadingMsg struct {
 	err             error
 }
 
+// recommendationRef is a row of the recommendations list: the store index
+// of a recommendation and the fields the list sorts and marks it by.
+type recommendationRef struct {
+	index      int
+	key        string
+	resourceID string
+	action     string
+	savings    float64
+	score      int // Filter match score
+}
+
+// newRecommendationRef returns the row of rec, stored at index.
+func newRecommendationRef(index int, rec engine.Recommendation) recommendationRef {
+	return recommendationRef{
+		index:      index,
+		key:        recommendationKey(rec),
+		resourceID: rec.ResourceID,
+		action:     rec.Type,
+		savings:    rec.EstimatedSavings,
+	}
+}
+
+// recommendationSource is the list source of rows, reading each
+// recommendation from store as it is shown.
+type recommendationSource struct {
+	store *spill.Store[engine.Recommendation]
+	rows  []recommendationRef
+}
+
+// Len returns the number of rows.
+func (s recommendationSource) Len() int {
+	return len(s.rows)
+}
+
+// Slice returns the recommendations of the rows from up to, but not
+// including, to.
+func (s recommendationSource) Slice(from, to int) ([]engine.Recommendation, error) {
+	recs := make([]engine.Recommendation, 0, to-from)
+	for _, row := range s.rows[from:to] {
+		rec, err := s.store.At(row.index)
+		if err != nil {
+			return nil, fmt.Errorf("reading recommendation: %w", err)
+		}
+		recs = append(recs, rec)
+	}
+	return recs, nil
+}
+
 // RecommendationsViewModel is the Bubble Tea model for interactive recommendations display.
 type RecommendationsViewModel struct {
 	// View state
-	state              ViewState
-	allRecommendations []engine.Recommendation // Source of truth
-	recommendations    []engine.Recommendation // Filtered/sorted for display
+	state ViewState
+	rows  []recommendationRef // Filtered/sorted for display
 
 	// Interactive components
 	virtualList *listview.VirtualListModel[engine.Recommendation]
+	columns     *listview.ColumnSet[engine.Recommendation]
+	saveLayout  LayoutSaver
 	textInput   textinput.Model
 
+	// Every recommendation, kept on disk past spillThreshold and read
+	// back as shown; removed holds the store indices bulk actions removed
+	store          *spill.Store[engine.Recommendation]
+	spillThreshold int
+	removed        map[int]bool
+
+	// Filter matches, by recommendation key and then column key
+	matches      map[string]map[string][]int
+	resourceTags map[string]map[string]string
+
 	// Display configuration
 	width      int
 	height     int
@@ -217,6 +258,21 @@ type RecommendationsViewModel struct {
 	// Aggregated data
 	summary *RecommendationsSummary
 
+	// Multi-select and bulk actions
+	marked     map[string]bool
+	bulk       *bulkMenu
+	actions    RecommendationActions
+	actionsCtx context.Context
+	statusMsg  string
+
+	// View export
+	export    *exportPrompt
+	exporter  ViewExporter[engine.Recommendation]
+	exportCtx context.Context
+
+	// Detail pane resource loading
+	resourceLoader *detail.Loader[*engine.ResourceDescriptor]
+
 	// Error handling
 	err error
 }
@@ -224,19 +280,72 @@ type RecommendationsViewModel struct {
 // NewRecommendationsViewModel creates a new model with the given recommendations.
 func NewRecommendationsViewModel(recs []engine.Recommendation) *RecommendationsViewModel {
 	m := &RecommendationsViewModel{
-		state:              ViewStateList,
-		allRecommendations: recs,
-		recommendations:    recs,
-		textInput:          newRecTextInput(),
-		summary:            NewRecommendationsSummary(recs),
-		width:              defaultWidth,
-		height:             defaultHeight,
+		state:     ViewStateList,
+		columns:   newRecommendationColumns(listview.Layout{}),
+		textInput: newRecTextInput(),
+		marked:    make(map[string]bool),
+		width:     defaultWidth,
+		height:    defaultHeight,
+	}
+	if err := m.setRecommendations(recs); err != nil {
+		m.err = err
+		m.state = ViewStateError
 	}
-	m.applySort()
-	m.rebuildList()
 	return m
 }
 
+// setRecommendations stores recs as the recommendations of the model, for
+// the rest of its life, and shows them.
+func (m *RecommendationsViewModel) setRecommendations(recs []engine.Recommendation) error {
+	m.Close()
+	m.store = spill.New[engine.Recommendation](m.spillThreshold, "")
+	m.removed = make(map[int]bool)
+	if err := m.store.Append(recs...); err != nil {
+		return fmt.Errorf("storing recommendations: %w", err)
+	}
+	m.applyFilter()
+	return nil
+}
+
+// WithSpillThreshold keeps the recommendations on disk once there are more
+// than threshold of them, reading back only the rows shown. Zero or less
+// uses spill.DefaultThreshold. Call it before the program starts.
+func (m *RecommendationsViewModel) WithSpillThreshold(threshold int) *RecommendationsViewModel {
+	if threshold == m.spillThreshold {
+		return m
+	}
+	m.spillThreshold = threshold
+	if m.store == nil || m.store.Len() == 0 {
+		return m
+	}
+	recs, err := m.store.Slice(0, m.store.Len())
+	if err == nil {
+		err = m.setRecommendations(recs)
+	}
+	if err != nil {
+		m.err = err
+		m.state = ViewStateError
+	}
+	return m
+}
+
+// eachRecommendation calls fn with the store index of every recommendation
+// that no bulk action removed, and the recommendation, in the order they
+// were loaded.
+func (m *RecommendationsViewModel) eachRecommendation(fn func(index int, rec engine.Recommendation)) error {
+	if m.store == nil {
+		return nil
+	}
+	index := 0
+	return m.store.Each(func(rec engine.Recommendation) error {
+		if !m.removed[index] {
+			fn(index, rec)
+		}
+		index++
+		return nil
+	})
+}
+
 // RecommendationFetcher is a context-aware function that fetches recommendations.
 // The fetcher should check ctx.Done() to support cancellation.
 type RecommendationFetcher func(ctx context.Context) ([]engine.Recommendation, error)
@@ -250,8 +359,10 @@ func NewRecommendationsViewModelWithLoading(
 	m := &RecommendationsViewModel{
 		state:     ViewStateLoading,
 		loading:   NewLoadingState(),
+		columns:   newRecommendationColumns(listview.Layout{}),
 		textInput: newRecTextInput(),
 		summary:   &RecommendationsSummary{Currency: defaultCurrency}, // Initialize with empty summary
+		marked:    make(map[string]bool),
 		width:     defaultWidth,
 		height:    defaultHeight,
 		fetchCmd: func() tea.Msg {
@@ -265,7 +376,7 @@ func NewRecommendationsViewModelWithLoading(
 // newRecTextInput creates a new text input for filtering recommendations.
 func newRecTextInput() textinput.Model {
 	ti := textinput.New()
-	ti.Placeholder = "Filter recommendations..."
+	ti.Placeholder = "Fuzzy search recommendations"
 	ti.CharLimit = filterInputCharLimit
 	ti.Width = filterInputWidth
 	return ti
@@ -301,6 +412,42 @@ func (m *RecommendationsViewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		return m.handleLoadingComplete(loadMsg)
 	}
 
+	// Handle lazily loaded resource details
+	if m.resourceLoader != nil && m.resourceLoader.Update(msg) {
+		return m, nil
+	}
+
+	// Handle completed bulk actions
+	if resultMsg, ok := msg.(bulkActionResultMsg); ok {
+		return m.handleBulkActionResult(resultMsg)
+	}
+
+	// Handle finished view exports
+	if exportedMsg, ok := msg.(viewExportedMsg); ok {
+		return m.handleViewExported(exportedMsg)
+	}
+
+	// Handle column layout changes
+	if layoutMsg, ok := msg.(listview.LayoutChangedMsg); ok {
+		return m, m.saveLayoutCmd(layoutMsg.Layout)
+	}
+	if savedMsg, ok := msg.(layoutSavedMsg); ok {
+		if savedMsg.err != nil {
+			m.statusMsg = "Could not save column layout: " + savedMsg.err.Error()
+		}
+		return m, nil
+	}
+
+	// Handle bulk action menu
+	if m.bulk != nil {
+		return m.handleBulkMenuUpdate(msg)
+	}
+
+	// Handle export prompt
+	if m.export != nil {
+		return m.handleExportUpdate(msg)
+	}
+
 	// Handle filter input
 	if m.showFilter {
 		return m.handleFilterInput(msg)
@@ -329,12 +476,12 @@ func (m *RecommendationsViewModel) handleLoadingComplete(
 		m.state = ViewStateError
 		return m, tea.Quit
 	}
-	m.allRecommendations = msg.recommendations
-	m.recommendations = msg.recommendations
-	m.summary = NewRecommendationsSummary(msg.recommendations)
+	if err := m.setRecommendations(msg.recommendations); err != nil {
+		m.err = err
+		m.state = ViewStateError
+		return m, tea.Quit
+	}
 	m.state = ViewStateList
-	m.applySort()
-	m.rebuildList()
 	return m, nil
 }
 
@@ -358,14 +505,15 @@ func (m *RecommendationsViewModel) handleLoadingUpdate(msg tea.Msg) (tea.Model,
 }
 
 func (m *RecommendationsViewModel) handleListUpdate(msg tea.Msg) (tea.Model, tea.Cmd) {
-	if keyMsg, ok := msg.(tea.KeyMsg); ok {
+	if keyMsg, ok := msg.(tea.KeyMsg); ok && !m.pickerOpen() {
 		switch keyMsg.String() {
 		case keyQuit, keyCtrlC:
 			m.state = ViewStateQuitting
 			return m, tea.Quit
 		case keyEnter:
-			if len(m.recommendations) > 0 {
+			if len(m.rows) > 0 {
 				m.state = ViewStateDetail
+				return m, m.loadSelectedResource()
 			}
 			return m, nil
 		case keySlash:
@@ -373,8 +521,18 @@ func (m *RecommendationsViewModel) handleListUpdate(msg tea.Msg) (tea.Model, tea
 			m.textInput.Focus()
 			return m, nil
 		case keyS:
-			m.cycleSort()
+			return m, m.cycleSort()
+		case keySpace:
+			m.toggleMark()
 			return m, nil
+		case keyA:
+			m.toggleMarkAll()
+			return m, nil
+		case keyB:
+			m.openBulkMenu()
+			return m, nil
+		case keyE:
+			return m, m.openExport()
 		case keyEsc:
 			if m.textInput.Value() != "" {
 				m.textInput.SetValue("")
@@ -405,6 +563,20 @@ func (m *RecommendationsViewModel) handleDetailUpdate(msg tea.Msg) (tea.Model, t
 		case keyEsc:
 			m.state = ViewStateList
 			return m, nil
+		case keyR:
+			if m.resourceLoader != nil {
+				return m, m.resourceLoader.Retry()
+			}
+			return m, nil
+		case keyUp, keyDown, keyK, keyJ:
+			// Move the list cursor so the split pane follows the selection.
+			if m.virtualList != nil {
+				updatedModel, _ := m.virtualList.Update(msg)
+				if vl, ok := updatedModel.(*listview.VirtualListModel[engine.Recommendation]); ok {
+					m.virtualList = vl
+				}
+			}
+			return m, m.loadSelectedResource()
 		}
 	}
 	return m, nil
@@ -421,46 +593,92 @@ func (m *RecommendationsViewModel) handleQuitUpdate(msg tea.Msg) (tea.Model, tea
 	return m, nil
 }
 
-// applyFilter filters recommendations based on the text input value.
+// applyFilter fuzzy-matches the text input value against the resource ID,
+// action type, description, and resource tags of each recommendation, and
+// records the matched characters for highlighting. The list keeps its sort
+// order; the match score only orders recommendations the sort column ties.
 func (m *RecommendationsViewModel) applyFilter() {
-	val := m.textInput.Value()
-	if val == "" {
-		m.recommendations = m.allRecommendations
-	} else {
-		var filtered []engine.Recommendation
-		query := strings.ToLower(val)
-		for _, r := range m.allRecommendations {
-			if strings.Contains(strings.ToLower(r.ResourceID), query) ||
-				strings.Contains(strings.ToLower(r.Type), query) ||
-				strings.Contains(strings.ToLower(r.Description), query) {
-				filtered = append(filtered, r)
+	terms := strings.Fields(m.textInput.Value())
+	m.matches = nil
+	if len(terms) > 0 {
+		m.matches = make(map[string]map[string][]int)
+	}
+	m.rows = nil
+	summary := newRecommendationsSummary()
+	err := m.eachRecommendation(func(index int, rec engine.Recommendation) {
+		ref := newRecommendationRef(index, rec)
+		if len(terms) > 0 {
+			result, ok := fuzzyMatchItem(rec, terms, m.searchFields(rec))
+			if !ok {
+				return
 			}
+			ref.score = result.Score
+			m.matches[ref.key] = result.Positions
 		}
-		m.recommendations = filtered
+		m.rows = append(m.rows, ref)
+		summary.add(rec)
+	})
+	if err != nil {
+		m.statusMsg = "Could not read recommendations: " + err.Error()
+	}
+	if len(terms) > 0 {
+		sort.SliceStable(m.rows, func(i, j int) bool { return m.rows[i].score > m.rows[j].score })
 	}
-	m.summary = NewRecommendationsSummary(m.recommendations)
+	m.summary = summary
 	m.applySort()
 	m.rebuildList()
 }
 
-// cycleSort cycles through the available sort fields.
-func (m *RecommendationsViewModel) cycleSort() {
-	m.sortBy = (m.sortBy + 1) % numRecommendationSortFields
+// searchFields returns the fields the filter searches. Fields shown as list
+// columns are named after their column so matches can be highlighted.
+func (m *RecommendationsViewModel) searchFields(rec engine.Recommendation) []FuzzyField {
+	fields := []FuzzyField{
+		{Name: recColResource, Text: rec.ResourceID},
+		{Name: recColAction, Text: rec.Type},
+		{Name: recColDescription, Text: rec.Description},
+	}
+	tags := m.resourceTags[rec.ResourceID]
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		fields = append(fields, FuzzyField{Name: "tag:" + k, Text: k + "=" + tags[k]})
+	}
+	return fields
+}
+
+// WithResourceTags enables searching recommendations by the tags of their
+// resources, keyed by resource ID.
+func (m *RecommendationsViewModel) WithResourceTags(tags map[string]map[string]string) *RecommendationsViewModel {
+	m.resourceTags = tags
+	return m
+}
+
+// cycleSort moves the sort to the next visible sortable column and saves
+// the layout.
+func (m *RecommendationsViewModel) cycleSort() tea.Cmd {
+	if !m.columns.CycleSort() {
+		return nil
+	}
+	m.sortBy = recommendationSortField(m.columns.SortBy())
 	m.applySort()
 	m.rebuildList()
+	return m.saveLayoutCmd(m.columns.Layout())
 }
 
 // applySort sorts recommendations based on the current sort field.
 func (m *RecommendationsViewModel) applySort() {
-	sort.Slice(m.recommendations, func(i, j int) bool {
-		a, b := m.recommendations[i], m.recommendations[j]
+	sort.SliceStable(m.rows, func(i, j int) bool {
+		a, b := m.rows[i], m.rows[j]
 		switch m.sortBy {
 		case SortBySavings:
-			return a.EstimatedSavings > b.EstimatedSavings
+			return a.savings > b.savings
 		case SortByResourceID:
-			return a.ResourceID < b.ResourceID
+			return a.resourceID < b.resourceID
 		case SortByActionType:
-			return a.Type < b.Type
+			return a.action < b.action
 		default:
 			return false
 		}
@@ -473,14 +691,36 @@ func (m *RecommendationsViewModel) rebuildList() {
 	if availableHeight < minHeight {
 		availableHeight = minHeight
 	}
-	m.virtualList = listview.NewVirtualListModel(
-		m.recommendations,
+	render := func(rec engine.Recommendation, selected bool) string {
+		return renderRecommendation(m.columns, rec, selected, m.matches[recommendationKey(rec)])
+	}
+	if m.actions != nil {
+		render = func(rec engine.Recommendation, selected bool) string {
+			mark := "[ ] "
+			if m.isMarked(rec) {
+				mark = "[x] "
+			}
+			return mark + renderRecommendation(m.columns, rec, selected, m.matches[recommendationKey(rec)])
+		}
+	}
+	m.virtualList = listview.NewColumnListModelFromSource[engine.Recommendation](
+		recommendationSource{store: m.store, rows: m.rows},
 		availableHeight,
 		m.width,
-		renderRecommendation,
+		m.columns,
+		render,
 	)
 }
 
+// Close removes the file of recommendations too many to hold in memory.
+// Call it once the program showing the model has ended.
+func (m *RecommendationsViewModel) Close() {
+	if m.store != nil {
+		_ = m.store.Close()
+		m.store = nil
+	}
+}
+
 // View renders the current view.
 func (m *RecommendationsViewModel) View() string {
 	switch m.state {
@@ -491,13 +731,7 @@ func (m *RecommendationsViewModel) View() string {
 	case ViewStateLoading:
 		return RenderLoading(m.loading)
 	case ViewStateDetail:
-		if m.virtualList != nil {
-			selected := m.virtualList.Selected()
-			if selected >= 0 && selected < len(m.recommendations) {
-				return RenderRecommendationDetail(m.recommendations[selected], m.width)
-			}
-		}
-		return msgSelectedOutOfBounds
+		return m.renderDetailView()
 	case ViewStateList:
 		return m.renderListView()
 	default:
@@ -505,6 +739,14 @@ func (m *RecommendationsViewModel) View() string {
 	}
 }
 
+// markColumnHeader returns the header padding for the selection column.
+func (m *RecommendationsViewModel) markColumnHeader() string {
+	if m.actions == nil {
+		return ""
+	}
+	return "    "
+}
+
 func (m *RecommendationsViewModel) renderListView() string {
 	summary := RenderRecommendationsSummaryTUI(m.summary, m.width)
 
@@ -512,21 +754,31 @@ func (m *RecommendationsViewModel) renderListView() string {
 	var listView string
 	if m.virtualList != nil {
 		// Add table header before virtual list
-		header := fmt.Sprintf("%-*s  %-*s  %*s  %-*s",
-			recColWidthResource, "Resource",
-			recColWidthAction, "Action",
-			recColWidthSavings, "Savings",
-			recColWidthDescription, "Description",
-		)
+		header := m.markColumnHeader() + m.virtualList.Header()
 		headerStyle := lipgloss.NewStyle().
 			BorderStyle(lipgloss.NormalBorder()).
-			BorderForeground(lipgloss.Color("240")).
+			BorderForeground(ColorMuted).
 			BorderBottom(true).
 			Bold(true)
 		listView = headerStyle.Render(header) + "\n" + m.virtualList.View()
 	}
 
-	helpText := "\n[/] Filter  [s] Sort  [↑↓/jk] Navigate  [Enter] Details  [q] Quit"
+	helpText := "\n[/] Filter  [s] Sort  [c] Columns  [e] Export  [↑↓/jk] Navigate  [Enter] Details  [q] Quit"
+	if m.actions != nil {
+		helpText = "\n[/] Filter  [s] Sort  [c] Columns  [e] Export  [↑↓/jk] Navigate  [space] Select  [a] All  " +
+			"[b] Bulk  [Enter] Details  [q] Quit"
+	}
+	if m.statusMsg != "" {
+		helpText = "\n" + m.statusMsg + helpText
+	}
+
+	if m.bulk != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, summary, listView, m.renderBulkMenu())
+	}
+
+	if m.export != nil {
+		return lipgloss.JoinVertical(lipgloss.Left, summary, listView, m.export.View())
+	}
 
 	if m.showFilter {
 		return lipgloss.JoinVertical(
@@ -605,6 +857,12 @@ func RenderRecommendationsSummaryTUI(summary *RecommendationsSummary, _ int) str
 
 // RenderRecommendationDetail renders a detailed view of a single recommendation.
 func RenderRecommendationDetail(rec engine.Recommendation, width int) string {
+	return renderRecommendationDetailBody(rec, width) + "\n" + detailHelpText
+}
+
+// renderRecommendationDetailBody renders the recommendation fields, impact
+// breakdown, reasoning, and metadata without the key help line.
+func renderRecommendationDetailBody(rec engine.Recommendation, width int) string {
 	_ = width // Reserved for future width-aware rendering
 
 	currency := rec.Currency
@@ -620,7 +878,9 @@ func RenderRecommendationDetail(rec engine.Recommendation, width int) string {
 	_, _ = sb.WriteString(fmt.Sprintf("Savings:     %s%.2f %s\n",
 		getCurrencySymbol(currency), rec.EstimatedSavings, currency))
 	_, _ = sb.WriteString(fmt.Sprintf("Description: %s\n", rec.Description))
-	_, _ = sb.WriteString("\n[Esc] Back to list  [q] Quit")
+	_, _ = sb.WriteString(renderImpactSection(rec, getCurrencySymbol(currency)))
+	_, _ = sb.WriteString(renderReasoningSection(rec))
+	_, _ = sb.WriteString(renderMetadataSection(rec))
 
 	return sb.String()
 }
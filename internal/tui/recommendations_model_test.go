@@ -202,7 +202,7 @@ func TestRecommendationsViewModel_LoadingState(t *testing.T) {
 		m := updatedModel.(*RecommendationsViewModel)
 
 		assert.Equal(t, ViewStateList, m.state)
-		assert.Len(t, m.recommendations, 2)
+		assert.Len(t, shownRecommendations(t, m), 2)
 		require.NotNil(t, m.summary)
 		assert.Equal(t, 2, m.summary.TotalCount)
 		assert.Equal(t, 150.0, m.summary.TotalSavings)
@@ -236,7 +236,7 @@ func TestRecommendationsViewModel_LoadingState(t *testing.T) {
 		m := updatedModel.(*RecommendationsViewModel)
 
 		assert.Equal(t, ViewStateList, m.state)
-		assert.Empty(t, m.recommendations)
+		assert.Empty(t, shownRecommendations(t, m))
 		require.NotNil(t, m.summary)
 		assert.Equal(t, 0, m.summary.TotalCount)
 	})
@@ -322,7 +322,7 @@ func TestRecommendationsViewModel_FilterLogic(t *testing.T) {
 		model.textInput.SetValue("aws")
 		model.applyFilter()
 
-		assert.Len(t, model.recommendations, 2) // aws-ec2-1 and aws-rds-3
+		assert.Len(t, shownRecommendations(t, model), 2) // aws-ec2-1 and aws-rds-3
 	})
 
 	t.Run("filter by action type", func(t *testing.T) {
@@ -330,8 +330,8 @@ func TestRecommendationsViewModel_FilterLogic(t *testing.T) {
 		model.textInput.SetValue("TERMINATE")
 		model.applyFilter()
 
-		assert.Len(t, model.recommendations, 1)
-		assert.Equal(t, "gcp-vm-2", model.recommendations[0].ResourceID)
+		assert.Len(t, shownRecommendations(t, model), 1)
+		assert.Equal(t, "gcp-vm-2", shownRecommendations(t, model)[0].ResourceID)
 	})
 
 	t.Run("filter by description", func(t *testing.T) {
@@ -339,19 +339,19 @@ func TestRecommendationsViewModel_FilterLogic(t *testing.T) {
 		model.textInput.SetValue("database")
 		model.applyFilter()
 
-		assert.Len(t, model.recommendations, 1)
-		assert.Equal(t, "aws-rds-3", model.recommendations[0].ResourceID)
+		assert.Len(t, shownRecommendations(t, model), 1)
+		assert.Equal(t, "aws-rds-3", shownRecommendations(t, model)[0].ResourceID)
 	})
 
 	t.Run("clear filter restores all", func(t *testing.T) {
 		model := NewRecommendationsViewModel(recs)
 		model.textInput.SetValue("aws")
 		model.applyFilter()
-		assert.Len(t, model.recommendations, 2)
+		assert.Len(t, shownRecommendations(t, model), 2)
 
 		model.textInput.SetValue("")
 		model.applyFilter()
-		assert.Len(t, model.recommendations, 3)
+		assert.Len(t, shownRecommendations(t, model), 3)
 	})
 
 	t.Run("filter updates summary", func(t *testing.T) {
@@ -619,9 +619,9 @@ func TestRecommendationsViewModel_SortingVariants(t *testing.T) {
 		model.sortBy = SortByResourceID
 		model.applySort()
 
-		assert.Equal(t, "a-resource", model.recommendations[0].ResourceID)
-		assert.Equal(t, "b-resource", model.recommendations[1].ResourceID)
-		assert.Equal(t, "c-resource", model.recommendations[2].ResourceID)
+		assert.Equal(t, "a-resource", shownRecommendations(t, model)[0].ResourceID)
+		assert.Equal(t, "b-resource", shownRecommendations(t, model)[1].ResourceID)
+		assert.Equal(t, "c-resource", shownRecommendations(t, model)[2].ResourceID)
 	})
 
 	t.Run("sorts by action type", func(t *testing.T) {
@@ -630,9 +630,9 @@ func TestRecommendationsViewModel_SortingVariants(t *testing.T) {
 		model.applySort()
 
 		// DELETE_UNUSED < RIGHTSIZE < TERMINATE alphabetically
-		assert.Equal(t, "DELETE_UNUSED", model.recommendations[0].Type)
-		assert.Equal(t, "RIGHTSIZE", model.recommendations[1].Type)
-		assert.Equal(t, "TERMINATE", model.recommendations[2].Type)
+		assert.Equal(t, "DELETE_UNUSED", shownRecommendations(t, model)[0].Type)
+		assert.Equal(t, "RIGHTSIZE", shownRecommendations(t, model)[1].Type)
+		assert.Equal(t, "TERMINATE", shownRecommendations(t, model)[2].Type)
 	})
 
 	t.Run("sorts by savings (default)", func(t *testing.T) {
@@ -641,9 +641,9 @@ func TestRecommendationsViewModel_SortingVariants(t *testing.T) {
 		model.applySort()
 
 		// Highest savings first
-		assert.Equal(t, 100.0, model.recommendations[0].EstimatedSavings)
-		assert.Equal(t, 50.0, model.recommendations[1].EstimatedSavings)
-		assert.Equal(t, 25.0, model.recommendations[2].EstimatedSavings)
+		assert.Equal(t, 100.0, shownRecommendations(t, model)[0].EstimatedSavings)
+		assert.Equal(t, 50.0, shownRecommendations(t, model)[1].EstimatedSavings)
+		assert.Equal(t, 25.0, shownRecommendations(t, model)[2].EstimatedSavings)
 	})
 }
 
@@ -681,3 +681,42 @@ func TestRecommendationsViewModel_SetVerbose(t *testing.T) {
 	model.SetVerbose(false)
 	assert.False(t, model.verbose)
 }
+
+// shownRecommendations returns the recommendations m shows, in order.
+func shownRecommendations(t *testing.T, m *RecommendationsViewModel) []engine.Recommendation {
+	t.Helper()
+	recs, err := m.visibleRecommendations()
+	require.NoError(t, err)
+	return recs
+}
+
+func TestRecommendationsViewModel_SpillsLargeLists(t *testing.T) {
+	recs := []engine.Recommendation{
+		{ID: "a", ResourceID: "web", Type: "RIGHTSIZE", EstimatedSavings: 10, Currency: "USD"},
+		{ID: "b", ResourceID: "db", Type: "TERMINATE", EstimatedSavings: 30, Currency: "USD"},
+		{ID: "c", ResourceID: "cache", Type: "RIGHTSIZE", EstimatedSavings: 20, Currency: "USD"},
+	}
+	m := NewRecommendationsViewModel(recs)
+	require.False(t, m.store.Spilled())
+	m.WithSpillThreshold(2)
+	defer m.Close()
+
+	store := m.store
+	require.True(t, store.Spilled())
+	assert.Equal(t, 3, m.virtualList.ItemCount())
+	assert.Contains(t, m.virtualList.View(), "cache")
+	m.virtualList.SetSelected(1)
+	selected := m.virtualList.GetSelectedItem()
+	require.NotNil(t, selected)
+	assert.Equal(t, "c", selected.ID, "rows keep the savings order")
+
+	m.textInput.SetValue("rightsize")
+	m.applyFilter()
+	m.cycleSort()
+	assert.Same(t, store, m.store, "filtering and sorting keep the store")
+	assert.Len(t, shownRecommendations(t, m), 2)
+	assert.Equal(t, 2, m.summary.TotalCount)
+
+	m.Close()
+	assert.Nil(t, m.store)
+}
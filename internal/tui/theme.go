@@ -0,0 +1,211 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// Theme is the color palette of the TUI. ApplyTheme makes a theme current
+// for all views and styled output.
+type Theme struct {
+	Name string
+
+	OK       lipgloss.Color
+	Warning  lipgloss.Color
+	Critical lipgloss.Color
+	Info     lipgloss.Color
+
+	Header     lipgloss.Color
+	Label      lipgloss.Color
+	Value      lipgloss.Color
+	Border     lipgloss.Color
+	Highlight  lipgloss.Color
+	Muted      lipgloss.Color
+	SelectedBg lipgloss.Color
+	Spinner    lipgloss.Color
+	Subtle     lipgloss.Color
+	Accent     lipgloss.Color
+
+	PriorityMedium lipgloss.Color
+
+	// Mono marks selections with reverse video instead of colors. It is set
+	// for NO_COLOR, where every color is empty.
+	Mono bool
+}
+
+// activeTheme is the theme last applied with ApplyTheme.
+//
+//nolint:gochecknoglobals // The current theme is process-wide, like the styles built from it.
+var activeTheme = DarkTheme()
+
+// DarkTheme returns the default palette, for dark terminal backgrounds.
+func DarkTheme() Theme {
+	return Theme{
+		Name:           config.TUIThemeDark,
+		OK:             "82",  // #5fd700 - Green
+		Warning:        "208", // #ff8700 - Orange
+		Critical:       "196", // #ff0000 - Red
+		Info:           "33",  // #0087ff - Blue
+		Header:         "99",  // #875fff - Purple
+		Label:          "245", // #8a8a8a - Gray
+		Value:          "255", // #eeeeee - White
+		Border:         "238", // #444444 - Dark gray
+		Highlight:      "229", // #ffffaf - Yellow
+		Muted:          "240", // #585858 - Dim gray
+		SelectedBg:     "237", // #3a3a3a - Dark gray
+		Spinner:        "205", // #ff5faf - Pink
+		Subtle:         "243", // #767676 - Gray
+		Accent:         "57",  // #5f00ff - Violet
+		PriorityMedium: "226", // #ffff00 - Yellow
+	}
+}
+
+// LightTheme returns a palette for light terminal backgrounds.
+func LightTheme() Theme {
+	return Theme{
+		Name:           config.TUIThemeLight,
+		OK:             "28",  // #008700 - Dark green
+		Warning:        "166", // #d75f00 - Dark orange
+		Critical:       "160", // #d70000 - Dark red
+		Info:           "25",  // #005faf - Dark blue
+		Header:         "55",  // #5f00af - Dark purple
+		Label:          "242", // #6c6c6c - Gray
+		Value:          "235", // #262626 - Near black
+		Border:         "250", // #bcbcbc - Light gray
+		Highlight:      "17",  // #00005f - Navy
+		Muted:          "246", // #949494 - Gray
+		SelectedBg:     "253", // #dadada - Light gray
+		Spinner:        "162", // #d70087 - Magenta
+		Subtle:         "244", // #808080 - Gray
+		Accent:         "189", // #d7d7ff - Lavender
+		PriorityMedium: "136", // #af8700 - Dark yellow
+	}
+}
+
+// HighContrastTheme returns a palette of the basic bright ANSI colors, for
+// low-vision use and terminals with limited palettes.
+func HighContrastTheme() Theme {
+	return Theme{
+		Name:           config.TUIThemeHighContrast,
+		OK:             "10", // Bright green
+		Warning:        "11", // Bright yellow
+		Critical:       "9",  // Bright red
+		Info:           "14", // Bright cyan
+		Header:         "15", // Bright white
+		Label:          "15",
+		Value:          "15",
+		Border:         "15",
+		Highlight:      "0",  // Black on the white selection
+		Muted:          "7",  // White
+		SelectedBg:     "15", // Bright white
+		Spinner:        "13", // Bright magenta
+		Subtle:         "7",
+		Accent:         "15",
+		PriorityMedium: "11",
+	}
+}
+
+// NoColorTheme returns the palette used when NO_COLOR is set: no colors, and
+// reverse video for selections.
+func NoColorTheme() Theme {
+	return Theme{Name: "no-color", Mono: true}
+}
+
+// ResolveTheme returns the theme named by tui.theme with the tui.colors
+// overrides applied. The auto theme, also used for an empty name, is dark or
+// light to match the terminal background. When colors are disabled, by
+// NO_COLOR, TERM=dumb, or the output controls, the result is NoColorTheme
+// whatever the configuration.
+//
+// Usage:
+//
+//	theme, err := ResolveTheme(cfg.TUI.ThemeName(), cfg.TUI.ThemeColors())
+//	if err != nil {
+//		return err
+//	}
+//	ApplyTheme(theme)
+func ResolveTheme(name string, colors map[string]string) (Theme, error) {
+	if !ColorEnabled() {
+		return NoColorTheme(), nil
+	}
+
+	var theme Theme
+	switch strings.ToLower(name) {
+	case "", config.TUIThemeAuto:
+		theme = DarkTheme()
+		if !lipgloss.HasDarkBackground() {
+			theme = LightTheme()
+		}
+	case config.TUIThemeDark:
+		theme = DarkTheme()
+	case config.TUIThemeLight:
+		theme = LightTheme()
+	case config.TUIThemeHighContrast:
+		theme = HighContrastTheme()
+	default:
+		return Theme{}, fmt.Errorf("unknown theme %q (supported: %s)",
+			name, strings.Join(config.TUIThemes(), ", "))
+	}
+	return theme.WithColors(colors)
+}
+
+// WithColors returns the theme with palette roles overridden by colors, keyed
+// by the roles of config.TUIColorRoles.
+func (t Theme) WithColors(colors map[string]string) (Theme, error) {
+	roles := t.roles()
+	for role, color := range colors {
+		field, ok := roles[role]
+		if !ok {
+			return Theme{}, fmt.Errorf("unknown color role %q (supported: %s)",
+				role, strings.Join(config.TUIColorRoles(), ", "))
+		}
+		*field = lipgloss.Color(color)
+	}
+	return t, nil
+}
+
+// roles maps the palette roles of config.TUIColorRoles to the fields of t.
+func (t *Theme) roles() map[string]*lipgloss.Color {
+	return map[string]*lipgloss.Color{
+		"ok":              &t.OK,
+		"warning":         &t.Warning,
+		"critical":        &t.Critical,
+		"info":            &t.Info,
+		"header":          &t.Header,
+		"label":           &t.Label,
+		"value":           &t.Value,
+		"border":          &t.Border,
+		"highlight":       &t.Highlight,
+		"muted":           &t.Muted,
+		"selected_bg":     &t.SelectedBg,
+		"spinner":         &t.Spinner,
+		"subtle":          &t.Subtle,
+		"accent":          &t.Accent,
+		"priority_medium": &t.PriorityMedium,
+	}
+}
+
+// ApplyTheme makes t the palette of all TUI views and styled output, and
+// rebuilds the global styles from it. Call it before starting a program;
+// views that are already running keep the styles they captured.
+func ApplyTheme(t Theme) {
+	activeTheme = t
+
+	ColorOK, ColorWarning, ColorCritical, ColorInfo = t.OK, t.Warning, t.Critical, t.Info
+	ColorHeader, ColorLabel, ColorValue, ColorBorder = t.Header, t.Label, t.Value, t.Border
+	ColorHighlight, ColorMuted, ColorSelectedBg = t.Highlight, t.Muted, t.SelectedBg
+	ColorSpinner, ColorSubtle, ColorAccent = t.Spinner, t.Subtle, t.Accent
+	ColorPriorityCritical, ColorPriorityHigh = t.Critical, t.Warning
+	ColorPriorityMedium, ColorPriorityLow = t.PriorityMedium, t.OK
+
+	rebuildStyles()
+}
+
+// CurrentTheme returns the theme last applied with ApplyTheme.
+func CurrentTheme() Theme {
+	return activeTheme
+}
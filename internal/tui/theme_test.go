@@ -0,0 +1,103 @@
+package tui
+
+import (
+	"sort"
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/config"
+)
+
+// restoreDarkTheme applies the default theme again when the test ends.
+func restoreDarkTheme(t *testing.T) {
+	t.Helper()
+	t.Cleanup(func() { ApplyTheme(DarkTheme()) })
+}
+
+func TestResolveTheme(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	tests := []struct {
+		name     string
+		theme    string
+		colors   map[string]string
+		wantName string
+		wantHdr  lipgloss.Color
+		wantErr  string
+	}{
+		{name: "dark", theme: "dark", wantName: config.TUIThemeDark, wantHdr: "99"},
+		{name: "light", theme: "LIGHT", wantName: config.TUIThemeLight, wantHdr: "55"},
+		{name: "high contrast", theme: "high-contrast", wantName: config.TUIThemeHighContrast, wantHdr: "15"},
+		{
+			name: "custom colors override the palette", theme: "dark",
+			colors:   map[string]string{"header": "#ff00ff"},
+			wantName: config.TUIThemeDark, wantHdr: "#ff00ff",
+		},
+		{name: "unknown theme", theme: "neon", wantErr: `unknown theme "neon"`},
+		{name: "unknown role", theme: "dark", colors: map[string]string{"title": "1"}, wantErr: `unknown color role`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theme, err := ResolveTheme(tt.theme, tt.colors)
+			if tt.wantErr != "" {
+				require.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantName, theme.Name)
+			assert.Equal(t, tt.wantHdr, theme.Header)
+		})
+	}
+}
+
+func TestResolveTheme_NoColor(t *testing.T) {
+	t.Setenv("NO_COLOR", "1")
+
+	theme, err := ResolveTheme(config.TUIThemeLight, map[string]string{"header": "#ff00ff"})
+	require.NoError(t, err)
+	assert.True(t, theme.Mono)
+	assert.Empty(t, theme.Header, "NO_COLOR wins over the configured palette")
+}
+
+func TestResolveTheme_AutoPicksDarkOrLight(t *testing.T) {
+	t.Setenv("NO_COLOR", "")
+
+	theme, err := ResolveTheme("", nil)
+	require.NoError(t, err)
+	want := config.TUIThemeLight
+	if lipgloss.HasDarkBackground() {
+		want = config.TUIThemeDark
+	}
+	assert.Equal(t, want, theme.Name)
+}
+
+func TestApplyTheme_RebuildsStyles(t *testing.T) {
+	restoreDarkTheme(t)
+
+	ApplyTheme(LightTheme())
+	assert.Equal(t, lipgloss.Color("55"), ColorHeader)
+	assert.Equal(t, lipgloss.Color("55"), HeaderStyle.GetForeground())
+	assert.Equal(t, lipgloss.Color("253"), TableSelectedStyle.GetBackground())
+	assert.Equal(t, lipgloss.Color("160"), ColorPriorityCritical)
+	assert.Equal(t, config.TUIThemeLight, CurrentTheme().Name)
+
+	ApplyTheme(NoColorTheme())
+	assert.True(t, TableSelectedStyle.GetReverse(), "selections use reverse video without colors")
+	assert.True(t, dashboardActiveTabStyle.GetReverse())
+	assert.True(t, accentSelectedStyle().GetReverse())
+}
+
+func TestTheme_RolesMatchConfig(t *testing.T) {
+	theme := DarkTheme()
+	roles := make([]string, 0)
+	for role := range theme.roles() {
+		roles = append(roles, role)
+	}
+	want := config.TUIColorRoles()
+	sort.Strings(roles)
+	sort.Strings(want)
+	assert.Equal(t, want, roles, "every configurable role maps to a palette color")
+}
@@ -0,0 +1,13 @@
+// Package tree provides a collapsible tree view for Bubble Tea TUI applications.
+//
+// The tree keeps the visible nodes in a flat slice in display order and splices
+// children in and out as nodes expand and collapse, so toggling a node costs
+// O(its visible descendants) rather than a walk of the whole tree. Key features:
+//   - Expand and collapse with enter/space, right/l and left/h
+//   - Keyboard navigation (up/down, j/k, pgup/pgdn, home/end)
+//   - Virtual scrolling: only the rows inside the viewport are rendered, so
+//     trees with 10,000+ nodes stay responsive
+//
+// Rendering of node values is left to the owner, which receives the depth of
+// each row to line up columns under the indentation.
+package tree
@@ -0,0 +1,299 @@
+package tree
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Tree rendering constants.
+const (
+	// indentUnit indents each level of the tree.
+	indentUnit = "  "
+	// markerExpanded, markerCollapsed, and markerLeaf prefix the node values.
+	markerExpanded  = "▾ "
+	markerCollapsed = "▸ "
+	markerLeaf      = "  "
+)
+
+// Keys of the tree view.
+const (
+	keyUp     = "up"
+	keyDown   = "down"
+	keyK      = "k"
+	keyJ      = "j"
+	keyLeft   = "left"
+	keyRight  = "right"
+	keyH      = "h"
+	keyL      = "l"
+	keyEnter  = "enter"
+	keySpace  = " "
+	keyPgUp   = "pgup"
+	keyPgDown = "pgdown"
+	keyHome   = "home"
+	keyEnd    = "end"
+)
+
+// Node is a node of a tree. Its children are shown while it is expanded.
+type Node[T any] struct {
+	Value    T
+	Children []*Node[T]
+	Expanded bool
+}
+
+// IsLeaf reports whether the node has no children.
+func (n *Node[T]) IsLeaf() bool {
+	return len(n.Children) == 0
+}
+
+// Walk calls fn for n and each of its descendants, depth first, whether
+// they are visible or not.
+func (n *Node[T]) Walk(fn func(node *Node[T], depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *Node[T]) walk(fn func(node *Node[T], depth int), depth int) {
+	fn(n, depth)
+	for _, c := range n.Children {
+		c.walk(fn, depth+1)
+	}
+}
+
+// RenderFunc renders the value of a node at depth. The tree prefixes the
+// result with the indentation and the expand marker, which take
+// Indent(depth) characters. The selected parameter indicates whether this
+// row is currently selected.
+type RenderFunc[T any] func(node *Node[T], depth int, selected bool) string
+
+// Indent returns the width of the prefix the tree renders before a node
+// value at depth.
+func Indent(depth int) int {
+	return len(indentUnit)*depth + len([]rune(markerLeaf))
+}
+
+// row is a visible node and its depth.
+type row[T any] struct {
+	node  *Node[T]
+	depth int
+}
+
+// Model is a collapsible tree with virtual scrolling.
+type Model[T any] struct {
+	roots  []*Node[T]
+	render RenderFunc[T]
+
+	// rows are the visible nodes in display order.
+	rows []row[T]
+
+	// selected is the index of the selected row.
+	selected int
+
+	// offset is the index of the first row in the viewport.
+	offset int
+
+	// height is the viewport height in rows.
+	height int
+}
+
+// New creates a tree of roots with a viewport of height rows. Nodes keep
+// their Expanded state.
+func New[T any](roots []*Node[T], height int, render RenderFunc[T]) *Model[T] {
+	m := &Model[T]{roots: roots, render: render, height: max(height, 1)}
+	for _, r := range roots {
+		m.rows = m.appendVisible(m.rows, r, 0)
+	}
+	return m
+}
+
+// appendVisible appends node and its visible descendants to rows.
+func (m *Model[T]) appendVisible(rows []row[T], node *Node[T], depth int) []row[T] {
+	rows = append(rows, row[T]{node: node, depth: depth})
+	if node.Expanded {
+		for _, c := range node.Children {
+			rows = m.appendVisible(rows, c, depth+1)
+		}
+	}
+	return rows
+}
+
+// Init initializes the model (required for tea.Model interface).
+func (m *Model[T]) Init() tea.Cmd {
+	return nil
+}
+
+// Update handles keyboard and resize messages.
+func (m *Model[T]) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		m.handleKey(msg.String())
+	case tea.WindowSizeMsg:
+		m.SetHeight(msg.Height)
+	}
+	return m, nil
+}
+
+// handleKey moves the selection or expands and collapses the selected node.
+func (m *Model[T]) handleKey(key string) {
+	if len(m.rows) == 0 {
+		return
+	}
+	switch key {
+	case keyUp, keyK:
+		m.selectRow(m.selected - 1)
+	case keyDown, keyJ:
+		m.selectRow(m.selected + 1)
+	case keyPgUp:
+		m.selectRow(m.selected - m.height)
+	case keyPgDown:
+		m.selectRow(m.selected + m.height)
+	case keyHome:
+		m.selectRow(0)
+	case keyEnd:
+		m.selectRow(len(m.rows) - 1)
+	case keyEnter, keySpace:
+		m.Toggle()
+	case keyRight, keyL:
+		if n := m.rows[m.selected].node; !n.IsLeaf() {
+			if n.Expanded {
+				m.selectRow(m.selected + 1)
+			} else {
+				m.expand(m.selected)
+			}
+		}
+	case keyLeft, keyH:
+		if n := m.rows[m.selected].node; n.Expanded {
+			m.collapse(m.selected)
+		} else {
+			m.selectRow(m.parentRow(m.selected))
+		}
+	}
+}
+
+// Toggle expands or collapses the selected node.
+func (m *Model[T]) Toggle() {
+	if len(m.rows) == 0 {
+		return
+	}
+	if m.rows[m.selected].node.Expanded {
+		m.collapse(m.selected)
+	} else {
+		m.expand(m.selected)
+	}
+}
+
+// expand shows the children of the node at row i.
+func (m *Model[T]) expand(i int) {
+	r := m.rows[i]
+	if r.node.IsLeaf() || r.node.Expanded {
+		return
+	}
+	r.node.Expanded = true
+	var children []row[T]
+	for _, c := range r.node.Children {
+		children = m.appendVisible(children, c, r.depth+1)
+	}
+	tail := append(children, m.rows[i+1:]...)
+	m.rows = append(m.rows[:i+1], tail...)
+	m.scrollToSelected()
+}
+
+// collapse hides the descendants of the node at row i.
+func (m *Model[T]) collapse(i int) {
+	r := m.rows[i]
+	if !r.node.Expanded {
+		return
+	}
+	r.node.Expanded = false
+	end := i + 1
+	for end < len(m.rows) && m.rows[end].depth > r.depth {
+		end++
+	}
+	m.rows = append(m.rows[:i+1], m.rows[end:]...)
+	if m.selected > i {
+		m.selected = i
+	}
+	m.scrollToSelected()
+}
+
+// parentRow returns the row of the parent of row i, or i for roots.
+func (m *Model[T]) parentRow(i int) int {
+	for p := i - 1; p >= 0; p-- {
+		if m.rows[p].depth < m.rows[i].depth {
+			return p
+		}
+	}
+	return i
+}
+
+// selectRow selects row i, clamped to the visible rows.
+func (m *Model[T]) selectRow(i int) {
+	m.selected = min(max(i, 0), len(m.rows)-1)
+	m.scrollToSelected()
+}
+
+// scrollToSelected moves the viewport the least needed to show the selected row.
+func (m *Model[T]) scrollToSelected() {
+	switch {
+	case m.selected < m.offset:
+		m.offset = m.selected
+	case m.selected >= m.offset+m.height:
+		m.offset = m.selected - m.height + 1
+	}
+	m.offset = max(min(m.offset, len(m.rows)-m.height), 0)
+}
+
+// Select selects the first visible node for which match returns true and
+// reports whether one was found.
+func (m *Model[T]) Select(match func(node *Node[T]) bool) bool {
+	for i, r := range m.rows {
+		if match(r.node) {
+			m.selectRow(i)
+			return true
+		}
+	}
+	return false
+}
+
+// SetHeight sets the viewport height in rows.
+func (m *Model[T]) SetHeight(height int) {
+	m.height = max(height, 1)
+	m.scrollToSelected()
+}
+
+// Roots returns the root nodes.
+func (m *Model[T]) Roots() []*Node[T] {
+	return m.roots
+}
+
+// SelectedNode returns the selected node, or nil if the tree is empty.
+func (m *Model[T]) SelectedNode() *Node[T] {
+	if len(m.rows) == 0 {
+		return nil
+	}
+	return m.rows[m.selected].node
+}
+
+// RowCount returns the number of visible rows.
+func (m *Model[T]) RowCount() int {
+	return len(m.rows)
+}
+
+// View renders the rows inside the viewport.
+func (m *Model[T]) View() string {
+	end := min(m.offset+m.height, len(m.rows))
+	lines := make([]string, 0, end-m.offset)
+	for i := m.offset; i < end; i++ {
+		r := m.rows[i]
+		marker := markerLeaf
+		switch {
+		case r.node.IsLeaf():
+		case r.node.Expanded:
+			marker = markerExpanded
+		default:
+			marker = markerCollapsed
+		}
+		prefix := strings.Repeat(indentUnit, r.depth) + marker
+		lines = append(lines, prefix+m.render(r.node, r.depth, i == m.selected))
+	}
+	return strings.Join(lines, "\n")
+}
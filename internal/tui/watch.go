@@ -0,0 +1,322 @@
+package tui
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// watchChromeHeight is the number of lines around a watched frame: title,
+// two rules, and the footer.
+const watchChromeHeight = 4
+
+// Markers of MarkChangedLines.
+const (
+	watchChangedLineMarker   = "* "
+	watchUnchangedLineMarker = "  "
+)
+
+// watchTokenPattern splits a line into runs of spaces and of other characters.
+var watchTokenPattern = regexp.MustCompile(`\s+|\S+`)
+
+// ChangedStyle highlights values that changed since the previous refresh of
+// a watched command.
+//
+//nolint:gochecknoglobals // Global styles are the standard pattern for lipgloss.
+var ChangedStyle = changedStyle()
+
+func changedStyle() lipgloss.Style {
+	return lipgloss.NewStyle().Bold(true).Reverse(true)
+}
+
+// lineChanges compares the lines of next with the lines of prev. Lines are
+// matched by their first field, so rows that move keep their comparison;
+// within a matched line each field is compared with the field at the same
+// position. It returns the tokens of each line of next with whether each one
+// changed. With an empty prev nothing has changed.
+func lineChanges(prev, next string) ([][]string, [][]bool) {
+	previous := make(map[string][]string)
+	for _, line := range strings.Split(ansi.Strip(prev), "\n") {
+		fields := strings.Fields(line)
+		if len(fields) > 0 {
+			if _, dup := previous[fields[0]]; !dup {
+				previous[fields[0]] = fields
+			}
+		}
+	}
+
+	lines := strings.Split(ansi.Strip(next), "\n")
+	tokens := make([][]string, len(lines))
+	changed := make([][]bool, len(lines))
+	for i, line := range lines {
+		tokens[i] = watchTokenPattern.FindAllString(line, -1)
+		changed[i] = make([]bool, len(tokens[i]))
+		fields := strings.Fields(line)
+		if prev == "" || len(fields) == 0 {
+			continue
+		}
+		old, matched := previous[fields[0]]
+		field := 0
+		for j, token := range tokens[i] {
+			if strings.TrimSpace(token) == "" {
+				continue
+			}
+			changed[i][j] = !matched || field >= len(old) || old[field] != token
+			field++
+		}
+	}
+	return tokens, changed
+}
+
+// HighlightChanges returns next with the values that differ from prev styled
+// with ChangedStyle, and the number of lines with changes. Rows missing from
+// prev are highlighted whole. ANSI styling of both frames is dropped so the
+// highlights stand out.
+//
+// Usage:
+//
+//	view, changed := HighlightChanges(previousFrame, frame)
+func HighlightChanges(prev, next string) (string, int) {
+	tokens, changed := lineChanges(prev, next)
+	lines := make([]string, len(tokens))
+	count := 0
+	for i := range tokens {
+		var b strings.Builder
+		lineChanged := false
+		for j, token := range tokens[i] {
+			if changed[i][j] {
+				b.WriteString(ChangedStyle.Render(token))
+				lineChanged = true
+				continue
+			}
+			b.WriteString(token)
+		}
+		if lineChanged {
+			count++
+		}
+		lines[i] = b.String()
+	}
+	return strings.Join(lines, "\n"), count
+}
+
+// MarkChangedLines returns next with "* " before the lines whose values
+// differ from prev and two spaces before the other non-empty lines, for
+// output without styling. With an empty prev next is returned unchanged.
+func MarkChangedLines(prev, next string) string {
+	if prev == "" {
+		return next
+	}
+	_, changed := lineChanges(prev, next)
+	lines := strings.Split(next, "\n")
+	for i, line := range lines {
+		if line == "" {
+			continue
+		}
+		marker := watchUnchangedLineMarker
+		for _, c := range changed[i] {
+			if c {
+				marker = watchChangedLineMarker
+				break
+			}
+		}
+		lines[i] = marker + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// WatchRenderer runs the query of a watched command and returns its output.
+type WatchRenderer func(ctx context.Context) (string, error)
+
+// watchFrameMsg carries the output of one refresh.
+type watchFrameMsg struct {
+	frame string
+	err   error
+}
+
+// watchTickMsg triggers a scheduled refresh.
+type watchTickMsg struct{}
+
+// WatchModel is the Bubble Tea model of the --watch mode of cost commands.
+// It re-runs the command every interval and renders its output in place,
+// highlighting the values that changed since the previous refresh. When a
+// refresh fails the last output stays on screen with the error below it.
+type WatchModel struct {
+	ctx      context.Context
+	title    string
+	render   WatchRenderer
+	interval time.Duration
+
+	frame      string
+	view       []string
+	changes    int
+	updatedAt  time.Time
+	offset     int
+	refreshing bool
+	// scheduled is set while a refresh tick is pending, so refreshes run
+	// with r do not start a second schedule.
+	scheduled bool
+	loading   *LoadingState
+	err       error
+	quitting  bool
+
+	width  int
+	height int
+}
+
+// NewWatchModel creates a watch view titled title that renders the command
+// output with render every interval.
+func NewWatchModel(ctx context.Context, title string, render WatchRenderer, interval time.Duration) *WatchModel {
+	return &WatchModel{
+		ctx:      ctx,
+		title:    title,
+		render:   render,
+		interval: interval,
+		loading:  NewLoadingState(),
+		width:    defaultWidth,
+		height:   defaultHeight,
+	}
+}
+
+// Init runs the first refresh.
+func (m *WatchModel) Init() tea.Cmd {
+	m.refreshing = true
+	return tea.Batch(m.loading.Init(), m.renderCmd())
+}
+
+// Update handles messages and updates the model state.
+func (m *WatchModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+	case tea.KeyMsg:
+		return m.handleKey(msg)
+	case watchFrameMsg:
+		m.refreshing = false
+		if msg.err != nil {
+			m.err = msg.err
+		} else {
+			view, changes := HighlightChanges(m.frame, msg.frame)
+			m.frame, m.view, m.changes = msg.frame, strings.Split(view, "\n"), changes
+			m.err, m.updatedAt = nil, time.Now()
+			m.offset = min(m.offset, max(len(m.view)-1, 0))
+		}
+		return m, m.scheduleRefresh()
+	case watchTickMsg:
+		m.scheduled = false
+		return m, m.startRefresh()
+	default:
+		return m, m.loading.Update(msg)
+	}
+}
+
+// handleKey handles scrolling, refresh, and quitting.
+func (m *WatchModel) handleKey(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case keyQuit, keyEsc, keyCtrlC:
+		m.quitting = true
+		return m, tea.Quit
+	case keyDown, keyJ:
+		if m.offset < len(m.view)-1 {
+			m.offset++
+		}
+	case keyUp, keyK:
+		if m.offset > 0 {
+			m.offset--
+		}
+	case keyRefresh:
+		return m, m.startRefresh()
+	}
+	return m, nil
+}
+
+// startRefresh re-runs the command unless a refresh is already running.
+func (m *WatchModel) startRefresh() tea.Cmd {
+	if m.refreshing {
+		return nil
+	}
+	m.refreshing = true
+	return m.renderCmd()
+}
+
+// scheduleRefresh schedules the next refresh unless one is pending.
+func (m *WatchModel) scheduleRefresh() tea.Cmd {
+	if m.scheduled {
+		return nil
+	}
+	m.scheduled = true
+	return tea.Tick(m.interval, func(time.Time) tea.Msg { return watchTickMsg{} })
+}
+
+// renderCmd runs the renderer in the background.
+func (m *WatchModel) renderCmd() tea.Cmd {
+	ctx, render := m.ctx, m.render
+	return func() tea.Msg {
+		frame, err := render(ctx)
+		return watchFrameMsg{frame: frame, err: err}
+	}
+}
+
+// Err returns the error of the last refresh, or nil if it succeeded.
+func (m *WatchModel) Err() error {
+	return m.err
+}
+
+// View renders the highlighted output between the title and the footer.
+func (m *WatchModel) View() string {
+	if m.quitting {
+		return ""
+	}
+
+	var b strings.Builder
+	title := HeaderStyle.Render(m.title)
+	if !m.updatedAt.IsZero() {
+		updated := LabelStyle.Render("Updated " + m.updatedAt.Format(dashboardTimeLayout))
+		title += strings.Repeat(" ", max(m.width-lipgloss.Width(title)-lipgloss.Width(updated), 1)) + updated
+	}
+	b.WriteString(title)
+	b.WriteString("\n")
+	rule := LabelStyle.Render(strings.Repeat("─", max(m.width, 1)))
+	b.WriteString(rule)
+	b.WriteString("\n")
+
+	height := max(m.height-watchChromeHeight, minHeight)
+	var lines []string
+	switch {
+	case m.view != nil:
+		lines = visibleRows(m.view, m.offset, height)
+	case m.err != nil:
+		lines = []string{CriticalStyle.Render("Error: " + m.err.Error())}
+	default:
+		lines = strings.Split(RenderLoading(m.loading), "\n")
+	}
+	for len(lines) < height {
+		lines = append(lines, "")
+	}
+	b.WriteString(strings.Join(lines[:height], "\n"))
+	b.WriteString("\n")
+	b.WriteString(rule)
+	b.WriteString("\n")
+	b.WriteString(m.renderFooter())
+	return b.String()
+}
+
+// renderFooter renders the key help and the refresh status.
+func (m *WatchModel) renderFooter() string {
+	help := LabelStyle.Render("↑↓ scroll · r refresh · q quit")
+	switch {
+	case m.refreshing:
+		return help + "  " + m.loading.spinner.View() + " Refreshing..."
+	case m.err != nil:
+		return help + "  " + CriticalStyle.Render("Refresh failed: "+m.err.Error())
+	default:
+		return help + "  " + LabelStyle.Render(fmt.Sprintf("Every %s · %d line(s) changed", m.interval, m.changes))
+	}
+}
@@ -0,0 +1,94 @@
+package tui
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const watchPrevFrame = `RESOURCE  ADAPTER  TOTAL
+web       aws      $10.00
+db        aws      $25.00`
+
+func TestHighlightChanges(t *testing.T) {
+	view, changed := HighlightChanges("", watchPrevFrame)
+	assert.Equal(t, watchPrevFrame, view, "the first frame has nothing to compare with")
+	assert.Zero(t, changed)
+
+	view, changed = HighlightChanges(watchPrevFrame, watchPrevFrame)
+	assert.Equal(t, watchPrevFrame, view)
+	assert.Zero(t, changed)
+
+	next := `RESOURCE  ADAPTER  TOTAL
+db        aws      $25.00
+web       aws      $12.50
+cache     aws      $3.00`
+	view, changed = HighlightChanges(watchPrevFrame, next)
+	assert.Equal(t, next, ansi.Strip(view), "highlighting keeps the layout")
+	assert.Equal(t, 2, changed, "rows are matched by their first field, so the moved db row is unchanged")
+
+	lines, marks := lineChanges(watchPrevFrame, next)
+	assert.Equal(t, []bool{false, false, false, false, true}, marks[2], "only the web total changed")
+	assert.Equal(t, "$12.50", lines[2][4])
+	assert.Equal(t, []bool{true, false, true, false, true}, marks[3], "new rows are highlighted whole")
+}
+
+func TestMarkChangedLines(t *testing.T) {
+	next := "RESOURCE  ADAPTER  TOTAL\nweb       aws      $11.00\ndb        aws      $25.00\n"
+
+	assert.Equal(t, next, MarkChangedLines("", next))
+	assert.Equal(t,
+		"  RESOURCE  ADAPTER  TOTAL\n* web       aws      $11.00\n  db        aws      $25.00\n",
+		MarkChangedLines(watchPrevFrame, next))
+}
+
+func updateWatch(t *testing.T, m *WatchModel, msg tea.Msg) (*WatchModel, tea.Cmd) {
+	t.Helper()
+	updated, cmd := m.Update(msg)
+	wm, ok := updated.(*WatchModel)
+	require.True(t, ok)
+	return wm, cmd
+}
+
+func TestWatchModel_HighlightsAndKeepsLastFrame(t *testing.T) {
+	m := NewWatchModel(context.Background(), "Actual Costs", nil, time.Minute)
+	m.Init()
+
+	m, cmd := updateWatch(t, m, watchFrameMsg{frame: watchPrevFrame})
+	require.NotNil(t, cmd, "the next refresh is scheduled")
+	assert.Contains(t, ansi.Strip(m.View()), "0 line(s) changed")
+
+	m, cmd = updateWatch(t, m, tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{'r'}})
+	require.NotNil(t, cmd)
+	m, cmd = updateWatch(t, m, watchFrameMsg{frame: watchPrevFrame + "\ncache     aws      $3.00"})
+	assert.Nil(t, cmd, "a refresh with r does not start a second schedule")
+	assert.Contains(t, ansi.Strip(m.View()), "1 line(s) changed")
+
+	m, _ = updateWatch(t, m, watchFrameMsg{err: errors.New("plugin timeout")})
+	require.Error(t, m.Err())
+	view := ansi.Strip(m.View())
+	assert.Contains(t, view, "cache")
+	assert.Contains(t, view, "Refresh failed: plugin timeout")
+}
+
+func TestWatchModel_RendersWithRenderer(t *testing.T) {
+	calls := 0
+	render := func(context.Context) (string, error) {
+		calls++
+		return "frame", nil
+	}
+	m := NewWatchModel(context.Background(), "Budgets", render, time.Minute)
+	m.Init()
+	m.refreshing = false
+
+	_, cmd := updateWatch(t, m, watchTickMsg{})
+	require.NotNil(t, cmd)
+	assert.Equal(t, watchFrameMsg{frame: "frame"}, cmd())
+	assert.Equal(t, 1, calls)
+}
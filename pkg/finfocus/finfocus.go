@@ -0,0 +1,390 @@
+// Package finfocus embeds the FinFocus cost engine in Go programs.
+//
+// It is intended for Pulumi Automation API drivers that want projected costs
+// in-process after stack.Preview(), without shelling out to the finfocus CLI
+// and parsing its output. Plugins, pricing specs, and routing are resolved the
+// same way as the CLI, from the finfocus config directory ($FINFOCUS_HOME,
+// $PULUMI_HOME/finfocus, or ~/.finfocus).
+//
+//	est, err := finfocus.New(ctx)
+//	if err != nil {
+//		return err
+//	}
+//	defer est.Close()
+//
+//	projection, err := est.ProjectedCostFromPreview(ctx, previewJSON)
+//	if err != nil {
+//		return err
+//	}
+//	for _, r := range projection.Results {
+//		fmt.Printf("%s %s $%.2f/mo\n", r.ResourceType, r.ResourceID, r.Monthly)
+//	}
+//
+// Besides projected costs, an Estimator fetches actual costs, recommendations,
+// and the status of the budgets configured under cost.budgets. Results use
+// the types of this package only, so callers never handle plugin protocol
+// types.
+package finfocus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+	"github.com/rshade/finfocus/internal/ingest"
+	"github.com/rshade/finfocus/internal/pluginhost"
+	"github.com/rshade/finfocus/internal/registry"
+	"github.com/rshade/finfocus/internal/router"
+	"github.com/rshade/finfocus/internal/spec"
+)
+
+// Types shared with the engine so results can be passed around without copying.
+type (
+	// CostResult is the cost of a single resource.
+	CostResult = engine.CostResult
+	// ErrorDetail describes a resource whose cost could not be calculated.
+	ErrorDetail = engine.ErrorDetail
+	// ResourceDescriptor is a provider-agnostic resource to be priced.
+	ResourceDescriptor = engine.ResourceDescriptor
+	// PreviewStep is one resource step of a Pulumi preview. Automation API
+	// callers can build it from the StepEventMetadata of a ResourcePreEvent.
+	PreviewStep = ingest.PulumiStep
+	// PreviewState is the old or new state of a PreviewStep.
+	PreviewState = ingest.PulumiState
+	// Recommendation is a cost optimization suggestion for a resource.
+	Recommendation = engine.Recommendation
+	// RecommendationError describes a plugin whose recommendations could not
+	// be fetched.
+	RecommendationError = engine.RecommendationError
+)
+
+// Projection holds projected costs for a set of resources.
+type Projection struct {
+	// Results has one entry per priced resource, in input order.
+	Results []CostResult `json:"results"`
+	// Errors lists resources that could not be priced.
+	Errors []ErrorDetail `json:"errors,omitempty"`
+}
+
+// TotalMonthly returns the sum of the monthly cost of every result. Results
+// are not converted between currencies.
+func (p *Projection) TotalMonthly() float64 {
+	var total float64
+	for _, r := range p.Results {
+		total += r.Monthly
+	}
+	return total
+}
+
+// Actuals holds actual costs for a set of resources over a period.
+type Actuals struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+	// Results has one entry per resource with billing data, in input order.
+	// TotalCost is the cost of the resource over the period.
+	Results []CostResult `json:"results"`
+	// Errors lists resources whose actual cost could not be fetched.
+	Errors []ErrorDetail `json:"errors,omitempty"`
+}
+
+// Total returns the sum of the cost of every result over the period. Results
+// are not converted between currencies.
+func (a *Actuals) Total() float64 {
+	var total float64
+	for _, r := range a.Results {
+		total += r.TotalCost
+	}
+	return total
+}
+
+// Recommendations holds the recommendations of the plugins for a set of
+// resources.
+type Recommendations struct {
+	Recommendations []Recommendation `json:"recommendations"`
+	// Errors lists plugins whose recommendations could not be fetched.
+	Errors []RecommendationError `json:"errors,omitempty"`
+	// TotalSavings is the sum of the estimated monthly savings, in Currency.
+	TotalSavings float64 `json:"totalSavings"`
+	Currency     string  `json:"currency"`
+}
+
+// Budget health levels of BudgetStatus.Health.
+const (
+	BudgetHealthOK       = "ok"
+	BudgetHealthWarning  = "warning"
+	BudgetHealthCritical = "critical"
+	BudgetHealthExceeded = "exceeded"
+	BudgetHealthUnknown  = "unknown"
+)
+
+// BudgetStatus is the projected monthly spend of one budget scope against its
+// budget.
+type BudgetStatus struct {
+	// Scope identifies the budget: "global", "provider:aws", "tag:team=web",
+	// "type:aws:ec2/instance:Instance", or "stack:prod".
+	Scope string `json:"scope"`
+	// Resources counts the resources whose cost counts toward the scope.
+	Resources int     `json:"resources"`
+	Spend     float64 `json:"spend"`
+	Budget    float64 `json:"budget"`
+	Currency  string  `json:"currency,omitempty"`
+	// Percentage is Spend / Budget * 100.
+	Percentage float64 `json:"percentage"`
+	// Health is one of the BudgetHealth constants.
+	Health string `json:"health"`
+}
+
+// Option configures an Estimator.
+type Option func(*options)
+
+type options struct {
+	adapter   string
+	specDir   string
+	noPlugins bool
+}
+
+// WithAdapter limits the Estimator to the named plugin, like the CLI --adapter flag.
+func WithAdapter(name string) Option {
+	return func(o *options) {
+		o.adapter = name
+	}
+}
+
+// WithSpecDir overrides the directory of local pricing specs used when no
+// plugin can price a resource.
+func WithSpecDir(dir string) Option {
+	return func(o *options) {
+		o.specDir = dir
+	}
+}
+
+// WithoutPlugins prices resources from local pricing specs only, without
+// starting any plugin processes.
+func WithoutPlugins() Option {
+	return func(o *options) {
+		o.noPlugins = true
+	}
+}
+
+// Estimator computes projected costs in-process. It holds open plugin
+// connections; call Close when done. An Estimator is safe for sequential use.
+type Estimator struct {
+	engine  *engine.Engine
+	budgets *config.BudgetsConfig
+	adapter string
+	cleanup func()
+}
+
+// New opens the installed plugins and returns an Estimator. Plugin discovery
+// honours the same config directory and routing rules as the CLI.
+func New(ctx context.Context, opts ...Option) (*Estimator, error) {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	cfg := config.New()
+	specDir := o.specDir
+	if specDir == "" {
+		specDir = cfg.SpecDir
+	}
+
+	var clients []*pluginhost.Client
+	cleanup := func() {}
+	if !o.noPlugins {
+		opened, closeFn, err := registry.NewDefault().Open(ctx, o.adapter)
+		if err != nil {
+			return nil, fmt.Errorf("opening plugins: %w", err)
+		}
+		clients = opened
+		if closeFn != nil {
+			cleanup = closeFn
+		}
+	}
+
+	eng := engine.New(clients, spec.NewLoader(specDir))
+	if cfg.Routing != nil {
+		r, err := router.NewRouter(router.WithClients(clients), router.WithConfig(cfg.Routing))
+		if err != nil {
+			cleanup()
+			return nil, fmt.Errorf("creating plugin router: %w", err)
+		}
+		eng = eng.WithRouter(router.NewEngineAdapter(r))
+	}
+
+	return &Estimator{engine: eng, budgets: cfg.Cost.Budgets, adapter: o.adapter, cleanup: cleanup}, nil
+}
+
+// Close stops the plugins opened by New. It is safe to call more than once.
+func (e *Estimator) Close() {
+	if e.cleanup != nil {
+		e.cleanup()
+		e.cleanup = nil
+	}
+}
+
+// ProjectedCost prices resources. A resource that cannot be priced is reported
+// in Projection.Errors rather than failing the call.
+func (e *Estimator) ProjectedCost(ctx context.Context, resources []ResourceDescriptor) (*Projection, error) {
+	if err := e.ready(); err != nil {
+		return nil, err
+	}
+	result, err := e.engine.GetProjectedCostWithErrors(ctx, resources)
+	if err != nil {
+		return nil, fmt.Errorf("calculating projected costs: %w", err)
+	}
+	return &Projection{Results: result.Results, Errors: result.Errors}, nil
+}
+
+// ProjectedCostFromPreview prices the resources created, updated, or kept by
+// a `pulumi preview --json` document.
+func (e *Estimator) ProjectedCostFromPreview(ctx context.Context, previewJSON []byte) (*Projection, error) {
+	plan, err := ingest.ParsePulumiPlanWithContext(ctx, previewJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing preview: %w", err)
+	}
+	return e.projectPlan(ctx, plan)
+}
+
+// ProjectedCostFromSteps prices the resources created, updated, or kept by
+// the given preview steps, as collected from an Automation API event stream.
+func (e *Estimator) ProjectedCostFromSteps(ctx context.Context, steps []PreviewStep) (*Projection, error) {
+	return e.projectPlan(ctx, &ingest.PulumiPlan{Steps: steps})
+}
+
+// projectPlan maps the plan's resources and prices them.
+func (e *Estimator) projectPlan(ctx context.Context, plan *ingest.PulumiPlan) (*Projection, error) {
+	resources, err := mapPlanResources(ctx, plan)
+	if err != nil {
+		return nil, err
+	}
+	return e.ProjectedCost(ctx, resources)
+}
+
+// ResourcesFromPreview maps the resources created, updated, or kept by a
+// `pulumi preview --json` document to descriptors, for ActualCost,
+// Recommendations, and BudgetStatus.
+func ResourcesFromPreview(ctx context.Context, previewJSON []byte) ([]ResourceDescriptor, error) {
+	plan, err := ingest.ParsePulumiPlanWithContext(ctx, previewJSON)
+	if err != nil {
+		return nil, fmt.Errorf("parsing preview: %w", err)
+	}
+	return mapPlanResources(ctx, plan)
+}
+
+// mapPlanResources maps the plan's resources to descriptors.
+func mapPlanResources(ctx context.Context, plan *ingest.PulumiPlan) ([]ResourceDescriptor, error) {
+	resources, err := ingest.MapResources(plan.GetResourcesWithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("mapping preview resources: %w", err)
+	}
+	return resources, nil
+}
+
+// ActualCost fetches the actual cost of resources between from and to from
+// the plugins. A resource whose cost cannot be fetched is reported in
+// Actuals.Errors rather than failing the call.
+func (e *Estimator) ActualCost(
+	ctx context.Context,
+	resources []ResourceDescriptor,
+	from, to time.Time,
+) (*Actuals, error) {
+	if err := e.ready(); err != nil {
+		return nil, err
+	}
+	if !to.After(from) {
+		return nil, fmt.Errorf("invalid period: %s is not after %s", to.Format(time.RFC3339), from.Format(time.RFC3339))
+	}
+	result, err := e.engine.GetActualCostWithOptionsAndErrors(ctx, engine.ActualCostRequest{
+		Resources: resources,
+		From:      from,
+		To:        to,
+		Adapter:   e.adapter,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("fetching actual costs: %w", err)
+	}
+	return &Actuals{From: from, To: to, Results: result.Results, Errors: result.Errors}, nil
+}
+
+// Recommendations fetches the cost optimization recommendations of the
+// plugins for resources. A plugin that fails is reported in
+// Recommendations.Errors rather than failing the call.
+func (e *Estimator) Recommendations(ctx context.Context, resources []ResourceDescriptor) (*Recommendations, error) {
+	if err := e.ready(); err != nil {
+		return nil, err
+	}
+	result, err := e.engine.GetRecommendationsForResources(ctx, resources)
+	if err != nil {
+		return nil, fmt.Errorf("fetching recommendations: %w", err)
+	}
+	return &Recommendations{
+		Recommendations: result.Recommendations,
+		Errors:          result.Errors,
+		TotalSavings:    result.TotalSavings,
+		Currency:        result.Currency,
+	}, nil
+}
+
+// BudgetStatus prices resources and returns the projected monthly spend of
+// each configured budget scope, highest share of budget first. It returns no
+// statuses when no budgets are configured.
+func (e *Estimator) BudgetStatus(ctx context.Context, resources []ResourceDescriptor) ([]BudgetStatus, error) {
+	projection, err := e.ProjectedCost(ctx, resources)
+	if err != nil {
+		return nil, err
+	}
+	if e.budgets == nil || !e.budgets.IsEnabled() {
+		return nil, nil
+	}
+
+	tags := make(map[string]map[string]string, len(resources))
+	for _, r := range resources {
+		tags[r.ID] = engine.ResourceTags(r.Properties)
+	}
+	impacts := engine.NewScopedBudgetEvaluator(e.budgets).ProjectBudgetImpact(ctx, projection.Results, tags)
+	statuses := make([]BudgetStatus, 0, len(impacts))
+	for _, impact := range impacts {
+		statuses = append(statuses, BudgetStatus{
+			Scope:      impact.Scope,
+			Resources:  impact.Resources,
+			Spend:      impact.Projected,
+			Budget:     impact.Budget,
+			Currency:   impact.Currency,
+			Percentage: impact.Percentage,
+			Health:     budgetHealth(impact.Health),
+		})
+	}
+	return statuses, nil
+}
+
+// ready returns an error when the Estimator was not created by New.
+func (e *Estimator) ready() error {
+	if e.engine == nil {
+		return errors.New("estimator is not initialized; use finfocus.New")
+	}
+	return nil
+}
+
+// budgetHealth maps a budget health status to a BudgetHealth constant.
+func budgetHealth(health pbc.BudgetHealthStatus) string {
+	switch health {
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_OK:
+		return BudgetHealthOK
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_WARNING:
+		return BudgetHealthWarning
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL:
+		return BudgetHealthCritical
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED:
+		return BudgetHealthExceeded
+	case pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_UNSPECIFIED:
+		return BudgetHealthUnknown
+	default:
+		return BudgetHealthUnknown
+	}
+}
@@ -0,0 +1,162 @@
+package finfocus_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/pkg/finfocus"
+)
+
+const t3MicroSpec = `provider: aws
+service: ec2
+sku: t3.micro
+currency: USD
+pricing:
+  onDemandHourly: 0.0104
+  monthlyEstimate: 7.59
+`
+
+// newTestEstimator returns a spec-only Estimator isolated from the user's config.
+func newTestEstimator(t *testing.T) *finfocus.Estimator {
+	t.Helper()
+	t.Setenv("FINFOCUS_HOME", t.TempDir())
+
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"), []byte(t3MicroSpec), 0o600))
+
+	est, err := finfocus.New(context.Background(), finfocus.WithoutPlugins(), finfocus.WithSpecDir(specDir))
+	require.NoError(t, err)
+	t.Cleanup(est.Close)
+	return est
+}
+
+func TestEstimator_ProjectedCostFromPreview(t *testing.T) {
+	est := newTestEstimator(t)
+
+	data, err := os.ReadFile(filepath.Join("..", "..", "examples", "plans", "aws-simple-plan.json"))
+	require.NoError(t, err)
+
+	projection, err := est.ProjectedCostFromPreview(context.Background(), data)
+	require.NoError(t, err)
+	require.Len(t, projection.Results, 3)
+
+	web := projection.Results[0]
+	assert.Equal(t, "aws:ec2/instance:Instance", web.ResourceType)
+	assert.InDelta(t, 7.59, web.Monthly, 0.001)
+	assert.InDelta(t, 7.59, projection.TotalMonthly(), 0.001, "unpriced resources contribute nothing")
+
+	_, err = est.ProjectedCostFromPreview(context.Background(), []byte("not json"))
+	require.Error(t, err)
+
+	resources, err := finfocus.ResourcesFromPreview(context.Background(), data)
+	require.NoError(t, err)
+	assert.Len(t, resources, 3)
+}
+
+func TestEstimator_ProjectedCostFromSteps(t *testing.T) {
+	est := newTestEstimator(t)
+
+	steps := []finfocus.PreviewStep{
+		{
+			Op:  "create",
+			URN: "urn:pulumi:dev::app::aws:ec2/instance:Instance::web",
+			NewState: &finfocus.PreviewState{
+				Type:   "aws:ec2/instance:Instance",
+				Inputs: map[string]interface{}{"instanceType": "t3.micro"},
+			},
+		},
+		{Op: "delete", URN: "urn:pulumi:dev::app::aws:ec2/instance:Instance::old"},
+	}
+
+	projection, err := est.ProjectedCostFromSteps(context.Background(), steps)
+	require.NoError(t, err)
+	require.Len(t, projection.Results, 1, "deleted resources are not priced")
+	assert.Equal(t, "urn:pulumi:dev::app::aws:ec2/instance:Instance::web", projection.Results[0].ResourceID)
+	assert.InDelta(t, 7.59, projection.Results[0].Monthly, 0.001)
+}
+
+func TestEstimator_ProjectedCost(t *testing.T) {
+	est := newTestEstimator(t)
+
+	projection, err := est.ProjectedCost(context.Background(), []finfocus.ResourceDescriptor{{
+		Type:       "aws:ec2/instance:Instance",
+		ID:         "web",
+		Provider:   "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}})
+	require.NoError(t, err)
+	require.Len(t, projection.Results, 1)
+	assert.InDelta(t, 7.59, projection.Results[0].Monthly, 0.001)
+
+	var zero finfocus.Estimator
+	_, err = zero.ProjectedCost(context.Background(), nil)
+	require.Error(t, err)
+}
+
+func TestEstimator_ActualCost(t *testing.T) {
+	est := newTestEstimator(t)
+	resources := []finfocus.ResourceDescriptor{{Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws"}}
+	to := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, -1, 0)
+
+	actuals, err := est.ActualCost(context.Background(), resources, from, to)
+	require.NoError(t, err)
+	assert.Equal(t, from, actuals.From)
+	assert.Zero(t, actuals.Total(), "no plugins report billing data")
+
+	_, err = est.ActualCost(context.Background(), resources, to, from)
+	require.ErrorContains(t, err, "invalid period")
+}
+
+func TestEstimator_Recommendations(t *testing.T) {
+	est := newTestEstimator(t)
+
+	recommendations, err := est.Recommendations(context.Background(), []finfocus.ResourceDescriptor{
+		{Type: "aws:ec2/instance:Instance", ID: "web", Provider: "aws"},
+	})
+	require.NoError(t, err)
+	assert.Empty(t, recommendations.Recommendations)
+	assert.Zero(t, recommendations.TotalSavings)
+}
+
+func TestEstimator_BudgetStatus(t *testing.T) {
+	resources := []finfocus.ResourceDescriptor{{
+		Type:       "aws:ec2/instance:Instance",
+		ID:         "web",
+		Provider:   "aws",
+		Properties: map[string]interface{}{"instanceType": "t3.micro"},
+	}}
+
+	est := newTestEstimator(t)
+	statuses, err := est.BudgetStatus(context.Background(), resources)
+	require.NoError(t, err)
+	assert.Empty(t, statuses, "no budgets configured")
+
+	home := t.TempDir()
+	t.Setenv("FINFOCUS_HOME", home)
+	require.NoError(t, os.WriteFile(filepath.Join(home, "config.yaml"), []byte(`cost:
+  budgets:
+    global:
+      amount: 10
+      currency: USD
+`), 0o600))
+	specDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(specDir, "aws-ec2-t3.micro.yaml"), []byte(t3MicroSpec), 0o600))
+	est, err = finfocus.New(context.Background(), finfocus.WithoutPlugins(), finfocus.WithSpecDir(specDir))
+	require.NoError(t, err)
+	t.Cleanup(est.Close)
+
+	statuses, err = est.BudgetStatus(context.Background(), resources)
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	assert.Equal(t, "global", statuses[0].Scope)
+	assert.InDelta(t, 7.59, statuses[0].Spend, 0.001)
+	assert.InDelta(t, 75.9, statuses[0].Percentage, 0.01)
+	assert.Equal(t, finfocus.BudgetHealthOK, statuses[0].Health)
+}
@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 
@@ -90,6 +92,14 @@ func (m *mockCostSourceClient) DismissRecommendation(
 	return &proto.DismissRecommendationResponse{Success: true}, nil
 }
 
+func (m *mockCostSourceClient) EstimateCost(
+	ctx context.Context,
+	in *proto.EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.EstimateCostResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "EstimateCost not implemented")
+}
+
 func TestBudgetHealth_EndToEnd(t *testing.T) {
 	ctx := context.Background()
 
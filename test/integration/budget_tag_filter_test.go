@@ -7,6 +7,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 
 	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
 
@@ -89,6 +91,14 @@ func (m *mockTagFilterClient) DismissRecommendation(
 	return &proto.DismissRecommendationResponse{Success: true}, nil
 }
 
+func (m *mockTagFilterClient) EstimateCost(
+	ctx context.Context,
+	in *proto.EstimateCostRequest,
+	opts ...grpc.CallOption,
+) (*proto.EstimateCostResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "EstimateCost not implemented")
+}
+
 // TestBudgetTagFilter_EndToEnd tests tag-based budget filtering (Issue #222).
 func TestBudgetTagFilter_EndToEnd(t *testing.T) {
 	ctx := context.Background()
@@ -227,6 +227,42 @@ func TestNDJSONStreaming_JQProcessing(t *testing.T) {
 	})
 }
 
+// TestNDJSONStreaming_QueryFlag tests that --query applies a JMESPath
+// expression to each NDJSON line without piping the output through jq.
+func TestNDJSONStreaming_QueryFlag(t *testing.T) {
+	planJSON := `{
+		"version": 3,
+		"steps": []
+	}`
+
+	tmpDir := t.TempDir()
+	planPath := filepath.Join(tmpDir, "plan.json")
+	err := os.WriteFile(planPath, []byte(planJSON), 0o600)
+	require.NoError(t, err)
+
+	binPath := filepath.Join("..", "..", "bin", "finfocus")
+
+	// Run: finfocus cost recommendations --pulumi-json plan.json --output ndjson --query 'type || resource_id'
+	cmd := exec.Command(binPath, "cost", "recommendations", "--pulumi-json", planPath,
+		"--output", "ndjson", "--query", "type || resource_id")
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	err = cmd.Start()
+	if err != nil {
+		t.Skip("finfocus binary not available, skipping integration test")
+		return
+	}
+	_ = cmd.Wait()
+
+	output := strings.TrimSpace(out.String())
+	if output != "" {
+		lines := strings.Split(output, "\n")
+		// First line should be "summary" (from summary.type field), JSON-encoded
+		assert.Equal(t, `"summary"`, lines[0], "first --query result should be \"summary\"")
+	}
+}
+
 // TestNDJSONStreaming_NoBuffering tests that NDJSON output appears immediately
 // without buffering delays when processed line-by-line.
 func TestNDJSONStreaming_NoBuffering(t *testing.T) {
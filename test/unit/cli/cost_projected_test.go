@@ -202,9 +202,11 @@ func TestCostProjectedCmd_NDJSONOutput(t *testing.T) {
 	err := cmd.Execute()
 	require.NoError(t, err)
 
-	// Without plugins/specs, NDJSON output is empty (no lines to output)
-	output := out.String()
-	assert.Empty(t, output) // No results = no NDJSON lines
+	// Without plugins/specs, NDJSON output is only the summary record
+	var summary engine.NDJSONSummary
+	require.NoError(t, json.Unmarshal(out.Bytes(), &summary))
+	assert.Equal(t, engine.NDJSONSummaryType, summary.Type)
+	assert.Zero(t, summary.Resources)
 }
 
 // TestCostProjectedCmd_FilterByType tests resource filtering.
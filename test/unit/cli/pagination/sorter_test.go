@@ -221,6 +221,31 @@ func TestRecommendationSorter_StableSort(t *testing.T) {
 	assert.Equal(t, "resource-3", sorted[2].ResourceID)
 }
 
+// TestCostResultSorter verifies sorting cost results by cost, name, and type.
+func TestCostResultSorter(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "urn:b", ResourceType: "aws:s3/bucket:Bucket", Monthly: 5},
+		{ResourceID: "urn:c", ResourceType: "aws:ec2/instance:Instance", Monthly: 50},
+		{ResourceID: "urn:a", ResourceType: "aws:rds/instance:Instance", TotalCost: 20},
+	}
+
+	sorter := pagination.NewCostResultSorter()
+	assert.Equal(t, []string{"cost", "name", "type"}, sorter.GetValidFields())
+
+	ids := func(sorted []engine.CostResult) []string {
+		out := make([]string, 0, len(sorted))
+		for _, r := range sorted {
+			out = append(out, r.ResourceID)
+		}
+		return out
+	}
+	assert.Equal(t, []string{"urn:c", "urn:a", "urn:b"}, ids(sorter.Sort(results, "cost", "desc")),
+		"actual costs sort by total cost")
+	assert.Equal(t, []string{"urn:a", "urn:b", "urn:c"}, ids(sorter.Sort(results, "name", "asc")))
+	assert.Equal(t, []string{"urn:c", "urn:a", "urn:b"}, ids(sorter.Sort(results, "type", "asc")))
+	assert.Equal(t, "urn:b", results[0].ResourceID, "the input is not modified")
+}
+
 // TestParseSortExpression verifies sort expression parsing.
 func TestParseSortExpression(t *testing.T) {
 	tests := []struct {
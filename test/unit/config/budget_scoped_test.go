@@ -561,6 +561,44 @@ func TestBudgetsConfig_Validation(t *testing.T) {
 			wantErr:     true,
 			errContains: "currency",
 		},
+		{
+			name: "stack budgets require global",
+			config: &config.BudgetsConfig{
+				Stacks: map[string]*config.ScopedBudget{
+					"prod": {Amount: 3000.0, Currency: "USD"},
+				},
+			},
+			wantErr:     true,
+			errContains: "global budget is required",
+		},
+		{
+			name: "stack budget currency must match global",
+			config: &config.BudgetsConfig{
+				Global: &config.ScopedBudget{
+					Amount:   5000.0,
+					Currency: "USD",
+				},
+				Stacks: map[string]*config.ScopedBudget{
+					"prod": {Amount: 3000.0, Currency: "EUR"},
+				},
+			},
+			wantErr:     true,
+			errContains: "stack \"prod\" budget",
+		},
+		{
+			name: "empty stack name is rejected",
+			config: &config.BudgetsConfig{
+				Global: &config.ScopedBudget{
+					Amount:   5000.0,
+					Currency: "USD",
+				},
+				Stacks: map[string]*config.ScopedBudget{
+					"": {Amount: 3000.0},
+				},
+			},
+			wantErr:     true,
+			errContains: "stack budget name",
+		},
 		{
 			name: "full valid configuration",
 			config: &config.BudgetsConfig{
@@ -579,6 +617,10 @@ func TestBudgetsConfig_Validation(t *testing.T) {
 				Types: map[string]*config.ScopedBudget{
 					"aws:ec2/instance": {Amount: 1000.0},
 				},
+				Stacks: map[string]*config.ScopedBudget{
+					"dev":  {Amount: 500.0},
+					"prod": {Amount: 8000.0},
+				},
 				ExitOnThreshold: true,
 				ExitCode:        ptr(1),
 			},
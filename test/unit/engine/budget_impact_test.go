@@ -0,0 +1,59 @@
+package engine_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	pbc "github.com/rshade/finfocus-spec/sdk/go/proto/finfocus/v1"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// TestProjectBudgetImpact tests the share of each budget scope that projected
+// costs would use.
+func TestProjectBudgetImpact(t *testing.T) {
+	cfg := &config.BudgetsConfig{
+		Global: &config.ScopedBudget{Amount: 1000, Currency: "USD"},
+		Providers: map[string]*config.ScopedBudget{
+			"aws": {Amount: 500, Currency: "USD"},
+			"gcp": {Amount: 200, Currency: "USD"},
+		},
+		Tags: []config.TagBudget{
+			{Selector: "team:platform", Priority: 10, ScopedBudget: config.ScopedBudget{Amount: 100, Currency: "USD"}},
+		},
+		Types: map[string]*config.ScopedBudget{
+			"aws:rds/instance:Instance": {Amount: 400, Currency: "USD"},
+		},
+	}
+	costs := []engine.CostResult{
+		{ResourceID: "web", ResourceType: "aws:ec2/instance:Instance", Monthly: 60},
+		{ResourceID: "db", ResourceType: "aws:rds/instance:Instance", Monthly: 400},
+		{ResourceID: "broken", ResourceType: "aws:ec2/instance:Instance", Monthly: 99,
+			Error: &engine.StructuredError{Code: engine.ErrCodePluginError}},
+	}
+	tags := map[string]map[string]string{"web": {"team": "platform"}}
+
+	impacts := engine.NewScopedBudgetEvaluator(cfg).ProjectBudgetImpact(context.Background(), costs, tags)
+
+	require.Len(t, impacts, 4, "scopes without projected costs are left out")
+	assert.Equal(t, "type:aws:rds/instance:Instance", impacts[0].Scope, "highest share first")
+	assert.InDelta(t, 100, impacts[0].Percentage, 1e-9)
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_EXCEEDED, impacts[0].Health)
+
+	aws := impacts[1]
+	assert.Equal(t, "provider:aws", aws.Scope)
+	assert.Equal(t, 2, aws.Resources, "errored results are skipped")
+	assert.InDelta(t, 460, aws.Projected, 1e-9)
+	assert.InDelta(t, 92, aws.Percentage, 1e-9)
+	assert.Equal(t, "USD", aws.Currency)
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL, aws.Health)
+
+	assert.Equal(t, "tag:team:platform", impacts[2].Scope)
+	assert.InDelta(t, 60, impacts[2].Percentage, 1e-9)
+	assert.Equal(t, "global", impacts[3].Scope)
+	assert.InDelta(t, 46, impacts[3].Percentage, 1e-9)
+}
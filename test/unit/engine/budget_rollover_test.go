@@ -0,0 +1,173 @@
+package engine_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/rshade/finfocus/internal/config"
+	"github.com/rshade/finfocus/internal/engine"
+)
+
+// fakeSpendHistory is an in-memory SpendHistory keyed by scope then period.
+type fakeSpendHistory map[string]map[string]config.BudgetPeriodRecord
+
+func (f fakeSpendHistory) GetPeriod(scope, period string) (*config.BudgetPeriodRecord, bool) {
+	record, ok := f[scope][period]
+	if !ok {
+		return nil, false
+	}
+	return &record, true
+}
+
+// TestBudgetPeriodBounds tests monthly period boundary math.
+func TestBudgetPeriodBounds(t *testing.T) {
+	tests := []struct {
+		name          string
+		now           time.Time
+		expectedStart time.Time
+		expectedEnd   time.Time
+		expectedKey   string
+		expectedPrev  string
+	}{
+		{
+			name:          "mid month",
+			now:           time.Date(2026, 3, 15, 10, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectedKey:   "2026-03",
+			expectedPrev:  "2026-02",
+		},
+		{
+			name:          "year boundary",
+			now:           time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedStart: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC),
+			expectedKey:   "2026-01",
+			expectedPrev:  "2025-12",
+		},
+		{
+			name:          "end of long month",
+			now:           time.Date(2026, 3, 31, 23, 59, 0, 0, time.UTC),
+			expectedStart: time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC),
+			expectedKey:   "2026-03",
+			expectedPrev:  "2026-02",
+		},
+		{
+			name:          "non-UTC input normalized to UTC",
+			now:           time.Date(2026, 4, 30, 22, 0, 0, 0, time.FixedZone("UTC-5", -5*60*60)),
+			expectedStart: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC),
+			expectedEnd:   time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC),
+			expectedKey:   "2026-05",
+			expectedPrev:  "2026-04",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end := engine.BudgetPeriodBounds(tt.now)
+			assert.Equal(t, tt.expectedStart, start)
+			assert.Equal(t, tt.expectedEnd, end)
+			assert.Equal(t, tt.expectedKey, engine.BudgetPeriodKey(tt.now))
+			assert.Equal(t, tt.expectedPrev, engine.PreviousBudgetPeriodKey(tt.now))
+		})
+	}
+}
+
+// TestCalculateRolloverAmount tests the carried-forward amount calculation.
+func TestCalculateRolloverAmount(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	history := fakeSpendHistory{
+		"global":       {"2026-02": {Amount: 1000, Spend: 700}},
+		"provider:aws": {"2026-02": {Amount: 500, Spend: 650}},
+		"stack:prod":   {"2026-01": {Amount: 300, Spend: 100}},
+	}
+
+	tests := []struct {
+		name     string
+		budget   *config.ScopedBudget
+		history  engine.SpendHistory
+		scope    string
+		expected float64
+	}{
+		{
+			name:     "unspent amount carried",
+			budget:   &config.ScopedBudget{Amount: 1000, Rollover: true},
+			history:  history,
+			scope:    "global",
+			expected: 300,
+		},
+		{
+			name:     "overspend floors at zero",
+			budget:   &config.ScopedBudget{Amount: 500, Rollover: true},
+			history:  history,
+			scope:    "provider:aws",
+			expected: 0,
+		},
+		{
+			name:     "only previous period is considered",
+			budget:   &config.ScopedBudget{Amount: 300, Rollover: true},
+			history:  history,
+			scope:    "stack:prod",
+			expected: 0,
+		},
+		{
+			name:     "rollover disabled",
+			budget:   &config.ScopedBudget{Amount: 1000},
+			history:  history,
+			scope:    "global",
+			expected: 0,
+		},
+		{
+			name:     "disabled budget",
+			budget:   &config.ScopedBudget{Amount: 0, Rollover: true},
+			history:  history,
+			scope:    "global",
+			expected: 0,
+		},
+		{
+			name:     "nil history",
+			budget:   &config.ScopedBudget{Amount: 1000, Rollover: true},
+			history:  nil,
+			scope:    "global",
+			expected: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := engine.CalculateRolloverAmount(tt.budget, tt.history, tt.scope, now)
+			assert.InDelta(t, tt.expected, got, 0.001)
+		})
+	}
+}
+
+// TestScopedBudgetEvaluator_EffectiveBudget tests effective budget resolution with history.
+func TestScopedBudgetEvaluator_EffectiveBudget(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	budget := &config.ScopedBudget{Amount: 1000, Currency: "USD", Rollover: true}
+	cfg := &config.BudgetsConfig{Global: budget}
+
+	t.Run("without history", func(t *testing.T) {
+		eval := engine.NewScopedBudgetEvaluator(cfg)
+		effective, carried := eval.EffectiveBudget("global", budget)
+		assert.Same(t, budget, effective)
+		assert.Zero(t, carried)
+	})
+
+	t.Run("with history", func(t *testing.T) {
+		history := fakeSpendHistory{"global": {"2026-02": {Amount: 1200, Spend: 900}}}
+		eval := engine.NewScopedBudgetEvaluator(cfg).
+			WithSpendHistory(history, func() time.Time { return now })
+
+		effective, carried := eval.EffectiveBudget("global", budget)
+		assert.InDelta(t, 300.0, carried, 0.001)
+		assert.InDelta(t, 1300.0, effective.Amount, 0.001)
+		assert.InDelta(t, 1000.0, budget.Amount, 0.001, "configured budget must not be mutated")
+
+		status := engine.CalculateProviderBudgetStatus("", effective, 650)
+		assert.InDelta(t, 50.0, status.Percentage, 0.001)
+	})
+}
@@ -20,6 +20,7 @@ func TestScopeType(t *testing.T) {
 		assert.Equal(t, "provider", engine.ScopeTypeProvider.String())
 		assert.Equal(t, "tag", engine.ScopeTypeTag.String())
 		assert.Equal(t, "type", engine.ScopeTypeType.String())
+		assert.Equal(t, "stack", engine.ScopeTypeStack.String())
 	})
 
 	t.Run("IsValid", func(t *testing.T) {
@@ -27,6 +28,7 @@ func TestScopeType(t *testing.T) {
 		assert.True(t, engine.ScopeTypeProvider.IsValid())
 		assert.True(t, engine.ScopeTypeTag.IsValid())
 		assert.True(t, engine.ScopeTypeType.IsValid())
+		assert.True(t, engine.ScopeTypeStack.IsValid())
 		assert.False(t, engine.ScopeType("invalid").IsValid())
 		assert.False(t, engine.ScopeType("").IsValid())
 	})
@@ -899,6 +901,92 @@ func TestCalculateTypeBudgetStatus(t *testing.T) {
 	})
 }
 
+// TestExtractStackFromURN tests stack name extraction from Pulumi URNs.
+func TestExtractStackFromURN(t *testing.T) {
+	tests := []struct {
+		urn      string
+		expected string
+	}{
+		{"urn:pulumi:prod::webapp::aws:ec2/instance:Instance::web", "prod"},
+		{"urn:pulumi:dev-us::proj::gcp:compute/instance:Instance::vm", "dev-us"},
+		{"urn:pulumi:prod", ""},
+		{"i-0abc123", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.urn, func(t *testing.T) {
+			assert.Equal(t, tt.expected, engine.ExtractStackFromURN(tt.urn))
+		})
+	}
+}
+
+// TestResolveActiveStack tests active stack resolution from results and overrides.
+func TestResolveActiveStack(t *testing.T) {
+	results := []engine.CostResult{
+		{ResourceID: "i-0abc123"},
+		{ResourceID: "urn:pulumi:staging::app::aws:s3/bucket:Bucket::logs"},
+	}
+
+	assert.Equal(t, "staging", engine.ResolveActiveStack(results, ""))
+	assert.Equal(t, "prod", engine.ResolveActiveStack(results, "prod"))
+	assert.Empty(t, engine.ResolveActiveStack(nil, ""))
+}
+
+// TestAllocateCostToStack tests allocation of costs to Pulumi stack budgets.
+func TestAllocateCostToStack(t *testing.T) {
+	ctx := context.Background()
+	cfg := &config.BudgetsConfig{
+		Global: &config.ScopedBudget{Amount: 5000, Currency: "USD"},
+		Stacks: map[string]*config.ScopedBudget{
+			"prod":     {Amount: 3000},
+			"disabled": {Amount: 0},
+		},
+	}
+	eval := engine.NewScopedBudgetEvaluator(cfg)
+
+	t.Run("stack from URN", func(t *testing.T) {
+		alloc := eval.AllocateCostToStack(ctx,
+			"urn:pulumi:prod::app::aws:ec2/instance:Instance::web", "aws:ec2/instance", "", 100)
+		assert.Equal(t, []string{"stack:prod"}, alloc.AllocatedScopes)
+	})
+
+	t.Run("falls back to active stack", func(t *testing.T) {
+		alloc := eval.AllocateCostToStack(ctx, "i-0abc123", "aws:ec2/instance", "prod", 100)
+		assert.Equal(t, []string{"stack:prod"}, alloc.AllocatedScopes)
+	})
+
+	t.Run("no budget for stack", func(t *testing.T) {
+		alloc := eval.AllocateCostToStack(ctx,
+			"urn:pulumi:dev::app::aws:ec2/instance:Instance::web", "aws:ec2/instance", "prod", 100)
+		assert.Empty(t, alloc.AllocatedScopes)
+	})
+
+	t.Run("disabled stack budget is skipped", func(t *testing.T) {
+		assert.Nil(t, eval.GetStackBudget("disabled"))
+	})
+}
+
+// TestCalculateStackBudgetStatus tests status calculation for stack scopes.
+func TestCalculateStackBudgetStatus(t *testing.T) {
+	budget := &config.ScopedBudget{Amount: 1000, Currency: "USD"}
+
+	status := engine.CalculateStackBudgetStatus("prod", budget, 950.0)
+
+	require.NotNil(t, status)
+	assert.Equal(t, engine.ScopeTypeStack, status.ScopeType)
+	assert.Equal(t, "prod", status.ScopeKey)
+	assert.Equal(t, "stack:prod", status.ScopeIdentifier())
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL, status.Health)
+
+	result := &engine.ScopedBudgetResult{
+		ByStack: map[string]*engine.ScopedBudgetStatus{"prod": status},
+	}
+	assert.Equal(t, pbc.BudgetHealthStatus_BUDGET_HEALTH_STATUS_CRITICAL, engine.CalculateOverallHealth(result))
+	assert.Equal(t, []string{"stack:prod"}, engine.IdentifyCriticalScopes(result))
+	assert.Len(t, result.AllScopes(), 1)
+}
+
 // TestBudgetAllocation tests the BudgetAllocation struct.
 func TestBudgetAllocation(t *testing.T) {
 	allocation := engine.BudgetAllocation{
@@ -0,0 +1,193 @@
+package listview_test
+
+import (
+	"strconv"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	listview "github.com/rshade/finfocus/internal/tui/list"
+)
+
+type testRow struct {
+	name string
+	cost int
+}
+
+func testColumns() []listview.Column[testRow] {
+	return []listview.Column[testRow]{
+		{
+			Key: "name", Title: "Name", Width: 8,
+			Value: func(r testRow) string { return r.name },
+			Less:  func(a, b testRow) bool { return a.name < b.name },
+		},
+		{
+			Key: "cost", Title: "Cost", Width: 6, AlignRight: true,
+			Value: func(r testRow) string { return strconv.Itoa(r.cost) },
+			Less:  func(a, b testRow) bool { return a.cost > b.cost },
+		},
+		{
+			Key: "note", Title: "Note", Width: 4,
+			Value: func(testRow) string { return "-" },
+		},
+	}
+}
+
+func keys(columns []listview.Column[testRow]) []string {
+	out := make([]string, 0, len(columns))
+	for _, c := range columns {
+		out = append(out, c.Key)
+	}
+	return out
+}
+
+func runeKey(r rune) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}}
+}
+
+func TestNewColumnSet_AppliesLayout(t *testing.T) {
+	tests := []struct {
+		name        string
+		layout      listview.Layout
+		wantVisible []string
+		wantAll     []string
+		wantSort    string
+	}{
+		{
+			name:        "empty layout shows all columns",
+			wantVisible: []string{"name", "cost", "note"},
+			wantAll:     []string{"name", "cost", "note"},
+		},
+		{
+			name:        "saved order and hidden columns",
+			layout:      listview.Layout{Columns: []string{"cost", "name"}, SortBy: "cost"},
+			wantVisible: []string{"cost", "name"},
+			wantAll:     []string{"cost", "name", "note"},
+			wantSort:    "cost",
+		},
+		{
+			name:        "unknown keys and unsortable sort column are ignored",
+			layout:      listview.Layout{Columns: []string{"gone", "note"}, SortBy: "note"},
+			wantVisible: []string{"note"},
+			wantAll:     []string{"note", "name", "cost"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := listview.NewColumnSet(testColumns(), tt.layout)
+			assert.Equal(t, tt.wantVisible, keys(s.Visible()))
+			assert.Equal(t, tt.wantAll, keys(s.All()))
+			assert.Equal(t, tt.wantSort, s.SortBy())
+			assert.Equal(t, listview.Layout{Columns: tt.wantVisible, SortBy: tt.wantSort}, s.Layout())
+		})
+	}
+}
+
+func TestColumnSet_CycleSortSkipsHiddenAndUnsortable(t *testing.T) {
+	s := listview.NewColumnSet(testColumns(), listview.Layout{})
+
+	require.True(t, s.CycleSort())
+	assert.Equal(t, "name", s.SortBy())
+	require.True(t, s.CycleSort())
+	assert.Equal(t, "cost", s.SortBy())
+	require.True(t, s.CycleSort())
+	assert.Equal(t, "name", s.SortBy(), "the unsortable note column is skipped")
+
+	require.True(t, s.Toggle(1))
+	assert.False(t, s.CycleSort(), "only one visible sortable column is left")
+}
+
+func TestColumnSet_ToggleAndMove(t *testing.T) {
+	s := listview.NewColumnSet(testColumns(), listview.Layout{})
+
+	assert.True(t, s.Toggle(0))
+	assert.True(t, s.Toggle(1))
+	assert.False(t, s.Toggle(2), "the last visible column stays visible")
+	assert.Equal(t, []string{"note"}, keys(s.Visible()))
+	assert.True(t, s.Toggle(0))
+
+	assert.Equal(t, 0, s.Move(2, -5), "moves clamp to the ends")
+	assert.Equal(t, []string{"note", "name", "cost"}, keys(s.All()))
+	assert.Equal(t, 2, s.Move(0, 2))
+	assert.Equal(t, []string{"name", "cost", "note"}, keys(s.All()))
+}
+
+func TestColumnSet_HeaderAndRow(t *testing.T) {
+	s := listview.NewColumnSet(testColumns(), listview.Layout{SortBy: "cost"})
+
+	assert.Equal(t, "Name      Cost ▼  Note", s.Header())
+	assert.Equal(t, "a-ver...      42  -   ", s.Row(testRow{name: "a-very-long-name", cost: 42}))
+}
+
+func TestColumnListModel_SortKeepsSelection(t *testing.T) {
+	items := []testRow{{"b", 1}, {"a", 3}, {"c", 2}}
+	s := listview.NewColumnSet(testColumns(), listview.Layout{SortBy: "cost"})
+	m := listview.NewColumnListModel(items, 10, 80, s, func(r testRow, _ bool) string { return s.Row(r) })
+
+	assert.Equal(t, []testRow{{"a", 3}, {"c", 2}, {"b", 1}}, m.Items())
+	assert.Equal(t, []testRow{{"b", 1}, {"a", 3}, {"c", 2}}, items, "the caller's items are not reordered")
+
+	m.SetSelected(1)
+	_, cmd := m.Update(runeKey('s'))
+	require.NotNil(t, cmd)
+	assert.Equal(t, listview.LayoutChangedMsg{
+		Layout: listview.Layout{Columns: []string{"name", "cost", "note"}, SortBy: "name"},
+	}, cmd())
+	assert.Equal(t, []testRow{{"a", 3}, {"b", 1}, {"c", 2}}, m.Items())
+	assert.Equal(t, testRow{"c", 2}, *m.GetSelectedItem(), "the selected item stays selected")
+}
+
+func TestColumnListModel_Picker(t *testing.T) {
+	s := listview.NewColumnSet(testColumns(), listview.Layout{})
+	m := listview.NewColumnListModel([]testRow{{"a", 1}}, 10, 80, s, func(r testRow, _ bool) string { return s.Row(r) })
+
+	_, cmd := m.Update(runeKey('c'))
+	assert.Nil(t, cmd)
+	require.True(t, m.PickerOpen())
+	assert.Contains(t, m.View(), "> [x] Name")
+
+	// Hide "cost", then move "note" above "name".
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(tea.KeyMsg{Type: tea.KeySpace, Runes: []rune{' '}})
+	assert.Contains(t, m.View(), "> [ ] Cost")
+	m.Update(tea.KeyMsg{Type: tea.KeyDown})
+	m.Update(runeKey('K'))
+	m.Update(runeKey('K'))
+	assert.Contains(t, m.View(), "> [x] Note")
+
+	_, sorted := m.Update(runeKey('s'))
+	assert.Nil(t, sorted, "sorting is disabled while the picker is open")
+
+	_, cmd = m.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	require.NotNil(t, cmd)
+	assert.False(t, m.PickerOpen())
+	assert.Equal(t, listview.LayoutChangedMsg{
+		Layout: listview.Layout{Columns: []string{"note", "name"}},
+	}, cmd())
+	assert.Equal(t, "Note  Name    ", m.Header())
+}
+
+func TestColumnListModel_PickerWithoutChangesReportsNothing(t *testing.T) {
+	s := listview.NewColumnSet(testColumns(), listview.Layout{})
+	m := listview.NewColumnListModel([]testRow{{"a", 1}}, 10, 80, s, func(r testRow, _ bool) string { return s.Row(r) })
+
+	m.Update(runeKey('c'))
+	_, cmd := m.Update(tea.KeyMsg{Type: tea.KeyEsc})
+	assert.Nil(t, cmd)
+	assert.False(t, m.PickerOpen())
+}
+
+func TestColumnSet_RowPositions(t *testing.T) {
+	s := listview.NewColumnSet(testColumns(), listview.Layout{})
+	row := testRow{name: "a-very-long-name", cost: 42}
+
+	// "a-ver..." shows five name characters; cost is right-aligned in 6.
+	positions := s.RowPositions(row, map[string][]int{
+		"name":  {0, 4, 5, 9},
+		"cost":  {1},
+		"other": {0},
+	})
+	assert.Equal(t, []int{0, 4, 15}, positions)
+}
@@ -1,12 +1,15 @@
 package listview_test
 
 import (
+	"strconv"
+	"strings"
 	"testing"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/rshade/finfocus/internal/engine/spill"
 	listview "github.com/rshade/finfocus/internal/tui/list"
 )
 
@@ -367,3 +370,31 @@ func TestVirtualListModel_SetSelected(t *testing.T) {
 		})
 	}
 }
+
+// TestVirtualListModel_FromSpilledStore tests a list reading its items from a
+// result store spilled to disk.
+func TestVirtualListModel_FromSpilledStore(t *testing.T) {
+	store := spill.New[string](10, t.TempDir())
+	defer store.Close()
+	for i := range 100 {
+		require.NoError(t, store.Append("item"+strconv.Itoa(i)))
+	}
+	require.True(t, store.Spilled())
+
+	model := listview.NewVirtualListModelFromSource[string](store, 10, 80, func(item string, selected bool) string {
+		if selected {
+			return "> " + item
+		}
+		return item
+	})
+	assert.Equal(t, 100, model.ItemCount())
+	assert.Nil(t, model.Items())
+
+	model.Update(tea.KeyMsg{Type: tea.KeyEnd})
+	require.NotNil(t, model.GetSelectedItem())
+	assert.Equal(t, "item99", *model.GetSelectedItem())
+
+	lines := strings.Split(model.View(), "\n")
+	assert.Len(t, lines, 15, "the viewport and the buffer above it")
+	assert.Equal(t, "> item99", lines[len(lines)-1])
+}
@@ -0,0 +1,120 @@
+package tree_test
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/rshade/finfocus/internal/tui/tree"
+)
+
+func key(k string) tea.KeyMsg {
+	switch k {
+	case "enter":
+		return tea.KeyMsg{Type: tea.KeyEnter}
+	case "left":
+		return tea.KeyMsg{Type: tea.KeyLeft}
+	case "right":
+		return tea.KeyMsg{Type: tea.KeyRight}
+	case "down":
+		return tea.KeyMsg{Type: tea.KeyDown}
+	case "end":
+		return tea.KeyMsg{Type: tea.KeyEnd}
+	}
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+}
+
+func node(label string, children ...*tree.Node[string]) *tree.Node[string] {
+	return &tree.Node[string]{Value: label, Children: children}
+}
+
+func renderLabel(n *tree.Node[string], _ int, selected bool) string {
+	if selected {
+		return "[" + n.Value + "]"
+	}
+	return n.Value
+}
+
+func sampleTree() []*tree.Node[string] {
+	return []*tree.Node[string]{
+		node("aws", node("ec2", node("web"), node("api")), node("s3", node("logs"))),
+		node("gcp", node("gce", node("vm"))),
+	}
+}
+
+func TestModel_ExpandAndCollapse(t *testing.T) {
+	m := tree.New(sampleTree(), 10, renderLabel)
+	assert.Equal(t, "▸ [aws]\n▸ gcp", m.View())
+
+	m.Update(key("enter"))
+	assert.Equal(t, "▾ [aws]\n  ▸ ec2\n  ▸ s3\n▸ gcp", m.View())
+
+	m.Update(key("down"))
+	m.Update(key(" "))
+	assert.Equal(t, "▾ aws\n  ▾ [ec2]\n      web\n      api\n  ▸ s3\n▸ gcp", m.View())
+	assert.Equal(t, 6, m.RowCount())
+
+	m.Update(key("j"))
+	m.Update(key("h"))
+	assert.Equal(t, "ec2", m.SelectedNode().Value, "left on a leaf moves to its parent")
+
+	// Collapsing aws hides its expanded descendants; ec2 stays expanded.
+	m.Update(key("k"))
+	m.Update(key("left"))
+	assert.Equal(t, 2, m.RowCount())
+	m.Update(key("right"))
+	m.Update(key("right"))
+	assert.Equal(t, "ec2", m.SelectedNode().Value, "right on an expanded node moves to its first child")
+	assert.Equal(t, 6, m.RowCount())
+
+	m.Update(key("h"))
+	assert.Equal(t, 4, m.RowCount(), "left on an expanded node collapses it")
+}
+
+func TestModel_SelectAndWalk(t *testing.T) {
+	roots := sampleTree()
+	roots[0].Expanded = true
+	roots[0].Children[1].Expanded = true
+	m := tree.New(roots, 10, renderLabel)
+
+	require.True(t, m.Select(func(n *tree.Node[string]) bool { return n.Value == "logs" }))
+	assert.Equal(t, "logs", m.SelectedNode().Value)
+	assert.False(t, m.Select(func(n *tree.Node[string]) bool { return n.Value == "web" }),
+		"hidden nodes are not selectable")
+
+	var walked []string
+	roots[0].Walk(func(n *tree.Node[string], depth int) {
+		walked = append(walked, strconv.Itoa(depth)+n.Value)
+	})
+	assert.Equal(t, []string{"0aws", "1ec2", "2web", "2api", "1s3", "2logs"}, walked)
+	assert.Equal(t, 4, tree.Indent(1))
+}
+
+func TestModel_VirtualizesLargeTrees(t *testing.T) {
+	const leaves = 20000
+	root := node("root")
+	root.Expanded = true
+	for i := range leaves {
+		root.Children = append(root.Children, node("leaf-"+strconv.Itoa(i)))
+	}
+	rendered := 0
+	m := tree.New([]*tree.Node[string]{root}, 5, func(n *tree.Node[string], d int, s bool) string {
+		rendered++
+		return renderLabel(n, d, s)
+	})
+
+	m.Update(key("end"))
+	view := m.View()
+	assert.Equal(t, 5, rendered, "only the rows in the viewport are rendered")
+	assert.True(t, strings.HasSuffix(view, "[leaf-19999]"))
+	assert.Equal(t, leaves+1, m.RowCount())
+
+	m.Update(key("g"))
+	m.Update(tea.KeyMsg{Type: tea.KeyHome})
+	m.Update(key("enter"))
+	assert.Equal(t, 1, m.RowCount())
+}